@@ -15,12 +15,17 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/openshift/generic-admission-server/pkg/cmd"
 	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 	"github.com/pingcap/tidb-operator/pkg/version"
+	"github.com/pingcap/tidb-operator/pkg/webhook/backuppolicy"
+	"github.com/pingcap/tidb-operator/pkg/webhook/diagnostic"
 	"github.com/pingcap/tidb-operator/pkg/webhook/statefulset"
 	"github.com/pingcap/tidb-operator/pkg/webhook/strategy"
 	"k8s.io/component-base/logs"
@@ -31,6 +36,7 @@ var (
 	printVersion         bool
 	extraServiceAccounts string
 	minResyncDuration    time.Duration
+	fipsMode             bool
 )
 
 func init() {
@@ -42,6 +48,7 @@ func init() {
 	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
 	flag.StringVar(&extraServiceAccounts, "extraServiceAccounts", "", "comma-separated, extra Service Accounts the Webhook should control. The full pattern for each common service account is system:serviceaccount:<namespace>:<serviceaccount-name>")
 	flag.DurationVar(&minResyncDuration, "min-resync-duration", 12*time.Hour, "The resync period in reflectors will be random between MinResyncPeriod and 2*MinResyncPeriod.")
+	flag.BoolVar(&fipsMode, "fips-mode", false, "Whether to restrict the TLS versions and cipher suites of the webhook server to the FIPS approved ones")
 	features.DefaultFeatureGate.AddFlag(flag.CommandLine)
 }
 
@@ -69,8 +76,36 @@ func main() {
 		klog.Fatal("ENV NAMESPACE should be set.")
 	}
 
+	if fipsMode {
+		applyFIPSServingFlags()
+	}
+
 	statefulSetAdmissionHook := statefulset.NewStatefulSetAdmissionControl()
 	strategyAdmissionHook := strategy.NewStrategyAdmissionHook(&strategy.Registry)
+	backupPolicyAdmissionHook := backuppolicy.NewBackupPolicyAdmissionControl()
+	diagnosticAdmissionHook := diagnostic.NewDiagnosticAdmissionControl()
+
+	cmd.RunAdmissionServer(statefulSetAdmissionHook, strategyAdmissionHook, backupPolicyAdmissionHook, diagnosticAdmissionHook)
+}
 
-	cmd.RunAdmissionServer(statefulSetAdmissionHook, strategyAdmissionHook)
+// applyFIPSServingFlags restricts the TLS serving options of the admission server in the FIPS mode,
+// the options are parsed from the command line by the admission server, so they are appended to
+// os.Args unless they are specified explicitly
+func applyFIPSServingFlags() {
+	defaults := map[string]string{
+		"tls-min-version":   "VersionTLS12",
+		"tls-cipher-suites": strings.Join(crypto.FIPSCipherSuiteNames(), ","),
+	}
+	for name, value := range defaults {
+		specified := false
+		for _, arg := range os.Args[1:] {
+			if arg == "--"+name || strings.HasPrefix(arg, "--"+name+"=") {
+				specified = true
+				break
+			}
+		}
+		if !specified {
+			os.Args = append(os.Args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
 }
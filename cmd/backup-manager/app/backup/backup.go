@@ -41,6 +41,8 @@ import (
 // Options contains the input arguments to the backup command
 type Options struct {
 	backupUtil.GenericOptions
+	// Verify the complete backup instead of backing up, it's used by the verification job.
+	Verify bool
 }
 
 // backupData generates br args and runs br binary to do the real backup work
@@ -111,6 +113,12 @@ func (bo *Options) backupData(
 		defer cancel()
 		go bo.updateProgressFromFile(progressCtx.Done(), backup, progressFile, progressStep, statusUpdater)
 	}
+	if logCallback == nil {
+		progressThrottler := backupUtil.NewProgressThrottler(backupUtil.ProgressUpdateInterval)
+		logCallback = func(line string) {
+			bo.updateProgressAccordingToBrLog(line, backup, progressThrottler, statusUpdater)
+		}
+	}
 
 	fullArgs, err := bo.backupCommandTemplate(backup, specificArgs)
 	if err != nil {
@@ -141,6 +149,10 @@ func constructOptions(backup *v1alpha1.Backup) ([]string, error) {
 	if config.CheckRequirements != nil {
 		args = append(args, fmt.Sprintf("--check-requirements=%t", *config.CheckRequirements))
 	}
+	if config.Stats != nil {
+		args = append(args, fmt.Sprintf("--ignore-stats=%t", !*config.Stats))
+	}
+	args = append(args, backupUtil.ConstructBREncryptionOptions(backup.Spec.Encryption)...)
 	args = append(args, config.Options...)
 	return args, nil
 }
@@ -204,13 +216,7 @@ func (bo *Options) backupCommandTemplate(backup *v1alpha1.Backup, specificArgs [
 	if backup.Spec.BR.ClusterNamespace == "" {
 		clusterNamespace = backup.Namespace
 	}
-	args := make([]string, 0)
-	args = append(args, fmt.Sprintf("--pd=%s-pd.%s:2379", backup.Spec.BR.Cluster, clusterNamespace))
-	if bo.TLSCluster {
-		args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey)))
-		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
-		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey)))
-	}
+	args := bo.clusterArgs(backup.Spec.BR.Cluster, clusterNamespace)
 	// `options` in spec are put to the last because we want them to have higher priority than generated arguments
 	dataArgs, err := constructOptions(backup)
 	if err != nil {
@@ -222,6 +228,18 @@ func (bo *Options) backupCommandTemplate(backup *v1alpha1.Backup, specificArgs [
 	return fullArgs, nil
 }
 
+// clusterArgs generates br args to connect to the PD of the cluster.
+func (bo *Options) clusterArgs(cluster, namespace string) []string {
+	args := make([]string, 0)
+	args = append(args, fmt.Sprintf("--pd=%s-pd.%s:2379", cluster, namespace))
+	if bo.TLSCluster {
+		args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey)))
+		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
+		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey)))
+	}
+	return args
+}
+
 // brCommandRun run br binary to do backup work.
 func (bo *Options) brCommandRun(ctx context.Context, fullArgs []string) error {
 	return bo.brCommandRunWithLogCallback(ctx, fullArgs, nil)
@@ -232,7 +250,7 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 	if len(fullArgs) == 0 {
 		return fmt.Errorf("command is invalid, fullArgs: %v", fullArgs)
 	}
-	klog.Infof("Running br command with args: %v", fullArgs)
+	klog.Infof("Running br command with args: %v", pkgutil.RedactStorageArgs(fullArgs))
 	bin := filepath.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
 
@@ -246,7 +264,7 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 	}
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", bo, fullArgs, err)
+		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", bo, pkgutil.RedactStorageArgs(fullArgs), err)
 	}
 	var errMsg string
 	reader := bufio.NewReader(stdOut)
@@ -276,10 +294,38 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 
 	e2eTestSimulate(bo)
 
-	klog.Infof("Run br commond %v for cluster %s successfully", fullArgs, bo)
+	klog.Infof("Run br commond %v for cluster %s successfully", pkgutil.RedactStorageArgs(fullArgs), bo)
 	return nil
 }
 
+// updateProgressAccordingToBrLog updates the backup progress according to the progress log of br.
+func (bo *Options) updateProgressAccordingToBrLog(line string, backup *v1alpha1.Backup, throttler *backupUtil.ProgressThrottler,
+	statusUpdater controller.BackupConditionUpdaterInterface) {
+	step, progressStr := backupUtil.ParseRestoreProgress(line)
+	if step == "" {
+		return
+	}
+	progress, err := strconv.ParseFloat(progressStr, 64)
+	if err != nil {
+		klog.Errorf("parse backup %s progress string value %s to float error %v", bo, progressStr, err)
+		return
+	}
+	now := time.Now()
+	if !throttler.Allow(step, progress, now) {
+		return
+	}
+	speed := backupUtil.ParseBRProgressSpeed(line)
+	klog.Infof("update backup %s step %s progress %f speed %q", bo, step, progress, speed)
+	if err := statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		ProgressStep:       &step,
+		Progress:           &progress,
+		ProgressSpeed:      &speed,
+		ProgressUpdateTime: &metav1.Time{Time: now},
+	}); err != nil {
+		klog.Errorf("update backup %s progress error %v", bo, err)
+	}
+}
+
 func (bo *Options) updateProgressFromFile(
 	stopCh <-chan struct{},
 	backup *v1alpha1.Backup,
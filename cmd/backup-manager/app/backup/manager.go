@@ -95,6 +95,10 @@ func (bm *Manager) ProcessBackup() error {
 		return errorutils.NewAggregate(errs)
 	}
 
+	if bm.Verify {
+		return bm.verifyBackup(ctx, backup.DeepCopy())
+	}
+
 	// we treat snapshot backup as restarted if its status is not scheduled when backup pod just start to run
 	// we will clean backup data before run br command
 	if backup.Spec.Mode == v1alpha1.BackupModeSnapshot && (backup.Status.Phase != v1alpha1.BackupScheduled || v1alpha1.IsBackupRestart(backup)) {
@@ -200,6 +204,29 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 		return err
 	}
 
+	// resolve the table filter against the schema of the cluster, so that a typo in the filter fails
+	// the backup instead of making an empty backup silently
+	if db != nil && len(backup.Spec.TableFilter) > 0 {
+		reason := "ResolveTableFilterFailed"
+		result, err := bm.resolveTableFilter(ctx, backup, db)
+		if err == nil && result != nil && result.MatchedTables == 0 {
+			reason = "TableFilterMatchedNoTable"
+			err = fmt.Errorf("table filter %v matches no table of the cluster", backup.Spec.TableFilter)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			klog.Errorf("resolve table filter of backup %s failed, err: %s", bm, err)
+			uerr := bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Type:    v1alpha1.BackupFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  reason,
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+	}
+
 	var (
 		oldTikvGCTime, tikvGCLifeTime             string
 		oldTikvGCTimeDuration, tikvGCTimeDuration time.Duration
@@ -364,6 +391,18 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 			BackupSizeReadable: &backupSizeReadable,
 		}
 	default:
+		// BR does not back up the statistics unless it is asked to
+		statsIncluded := backup.Spec.BR != nil && backup.Spec.BR.Stats != nil && *backup.Spec.BR.Stats
+		if backup.Spec.Encryption != nil {
+			// the backup meta is encrypted by BR, so the size and commitTs can not be read from it
+			klog.Infof("backup files in %s of cluster %s are encrypted, skip reading the backup metadata", backupFullPath, bm)
+			updateStatus = &controller.BackupUpdateStatus{
+				TimeStarted:   &metav1.Time{Time: started},
+				TimeCompleted: &metav1.Time{Time: time.Now()},
+				StatsIncluded: &statsIncluded,
+			}
+			break
+		}
 		backupMeta, err := util.GetBRMetaData(ctx, backup.Spec.StorageProvider)
 		if err != nil {
 			errs = append(errs, err)
@@ -390,6 +429,7 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 			BackupSize:         &backupSize,
 			BackupSizeReadable: &backupSizeReadable,
 			CommitTs:           &ts,
+			StatsIncluded:      &statsIncluded,
 		}
 	}
 	return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
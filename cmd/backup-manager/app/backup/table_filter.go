@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// resolveTableFilter resolves spec.tableFilter against the schema of the cluster before running BR, and records
+// the matched tables in the status. It returns nil if the filter can not be resolved, e.g. the rules are imported
+// from files, which is left to be validated by BR.
+func (bm *Manager) resolveTableFilter(ctx context.Context, backup *v1alpha1.Backup, db *sql.DB) (*v1alpha1.BackupTableFilterResult, error) {
+	filter, err := util.ParseTableFilter(backup.Spec.TableFilter)
+	if err != nil {
+		klog.Warningf("skip resolving table filter of backup %s, err: %v", bm, err)
+		return nil, nil
+	}
+	tables, err := listUserTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, unmatched := filter.Resolve(tables)
+	dbs := map[string]struct{}{}
+	for _, t := range matched {
+		dbs[strings.ToLower(t.Schema)] = struct{}{}
+	}
+	result := &v1alpha1.BackupTableFilterResult{
+		MatchedDatabases: int32(len(dbs)),
+		MatchedTables:    int32(len(matched)),
+		UnmatchedRules:   unmatched,
+		ResolvedTime:     metav1.Time{Time: time.Now()},
+	}
+	klog.Infof("table filter of backup %s matches %d tables in %d databases", bm, result.MatchedTables, result.MatchedDatabases)
+	if len(unmatched) > 0 {
+		klog.Warningf("table filter rules %v of backup %s match no table", unmatched, bm)
+	}
+	if err := bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{TableFilter: result}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// listUserTables lists the tables and views of the cluster except the system ones
+func listUserTables(ctx context.Context, db *sql.DB) ([]util.Table, error) {
+	query := "SELECT table_schema, table_name FROM information_schema.tables"
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list tables failed, sql: %s, err: %v", query, err)
+	}
+	defer rows.Close()
+
+	var tables []util.Table
+	for rows.Next() {
+		var t util.Table
+		if err := rows.Scan(&t.Schema, &t.Name); err != nil {
+			return nil, fmt.Errorf("list tables failed, sql: %s, err: %v", query, err)
+		}
+		switch strings.ToLower(t.Schema) {
+		case "mysql", "sys", "information_schema", "performance_schema", "metrics_schema":
+			continue
+		}
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list tables failed, sql: %s, err: %v", query, err)
+	}
+	return tables, nil
+}
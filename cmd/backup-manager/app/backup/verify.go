@@ -0,0 +1,108 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	pkgutil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	corev1 "k8s.io/api/core/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// verifyBackup verifies the complete backup by BR and records the result in the Verified condition.
+func (bm *Manager) verifyBackup(ctx context.Context, backup *v1alpha1.Backup) error {
+	if !v1alpha1.IsBackupComplete(backup) || backup.Spec.Verify == nil {
+		return fmt.Errorf("backup %s is not complete or has no verify config", bm)
+	}
+	mode := backup.Spec.Verify.Mode
+	if mode == "" {
+		mode = v1alpha1.BackupVerifyChecksum
+	}
+
+	klog.Infof("start to verify backup %s in %s mode", bm, mode)
+	fullArgs, err := bm.verifyCommandArgs(backup, mode)
+	if err == nil {
+		err = bm.brCommandRun(ctx, fullArgs)
+	}
+	if err != nil {
+		klog.Errorf("verify backup %s failed, err: %v", bm, err)
+		errs := []error{err}
+		uerr := bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+			Type:    v1alpha1.BackupVerified,
+			Status:  corev1.ConditionFalse,
+			Reason:  "VerificationFailed",
+			Message: err.Error(),
+		}, nil)
+		errs = append(errs, uerr)
+		return errorutils.NewAggregate(errs)
+	}
+
+	klog.Infof("backup %s is verified in %s mode", bm, mode)
+	return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:    v1alpha1.BackupVerified,
+		Status:  corev1.ConditionTrue,
+		Reason:  "VerificationPassed",
+		Message: fmt.Sprintf("backup is verified in %s mode", mode),
+	}, nil)
+}
+
+// verifyCommandArgs generates br args to verify the backup. In the Checksum mode, the checksums of the backup
+// files are validated against the backup meta, and in the ScratchRestore mode, the backup is restored to the
+// scratch cluster with the checksums of the restored tables validated.
+func (bo *Options) verifyCommandArgs(backup *v1alpha1.Backup, mode v1alpha1.BackupVerifyMode) ([]string, error) {
+	storageArgs, err := pkgutil.GenStorageArgsForFlag(backup.Spec.StorageProvider, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	switch mode {
+	case v1alpha1.BackupVerifyChecksum:
+		clusterNamespace := backup.Spec.BR.ClusterNamespace
+		if clusterNamespace == "" {
+			clusterNamespace = backup.Namespace
+		}
+		args = append(args, "debug", "checksum")
+		args = append(args, bo.clusterArgs(backup.Spec.BR.Cluster, clusterNamespace)...)
+		args = append(args, storageArgs...)
+	case v1alpha1.BackupVerifyScratchRestore:
+		scratch := backup.Spec.Verify.ScratchCluster
+		if scratch == nil {
+			return nil, fmt.Errorf("no scratch cluster to verify backup %s", bo)
+		}
+		scratchNamespace := scratch.Namespace
+		if scratchNamespace == "" {
+			scratchNamespace = backup.Namespace
+		}
+		filters := backup.Spec.Verify.TableFilter
+		if len(filters) == 0 {
+			filters = backup.Spec.TableFilter
+		}
+		args = append(args, "restore", "full", "--checksum=true")
+		args = append(args, bo.clusterArgs(scratch.Name, scratchNamespace)...)
+		args = append(args, storageArgs...)
+		for _, filter := range filters {
+			args = append(args, "--filter", filter)
+		}
+	default:
+		return nil, fmt.Errorf("invalid verify mode %s of backup %s", mode, bo)
+	}
+	args = append(args, backupUtil.ConstructBREncryptionOptions(backup.Spec.Encryption)...)
+	return args, nil
+}
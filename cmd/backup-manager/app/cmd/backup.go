@@ -51,6 +51,7 @@ func NewBackupCommand() *cobra.Command {
 	cmd.Flags().StringVar(&bo.SubCommand, "subcommand", string(v1alpha1.LogStartCommand), "the log backup subcommand")
 	cmd.Flags().StringVar(&bo.CommitTS, "commit-ts", "0", "the log backup start ts")
 	cmd.Flags().StringVar(&bo.TruncateUntil, "truncate-until", "0", "the log backup truncate until")
+	cmd.Flags().BoolVar(&bo.Verify, "verify", false, "Whether to verify the complete backup instead of backing up")
 	return cmd
 }
 
@@ -0,0 +1,52 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/diagnose"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewDiagnoseCommand implements the diagnose command
+func NewDiagnoseCommand() *cobra.Command {
+	opts := diagnose.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Collect the diagnostic bundle of specific tidb cluster.",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.ValidCmdFlags(cmd.CommandPath(), cmd.LocalFlags())
+			cmdutil.CheckErr(runDiagnose(opts, kubecfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", "", "Diagnostic CR's namespace")
+	cmd.Flags().StringVar(&opts.DiagnosticName, "diagnosticName", "", "Diagnostic CRD object name")
+	cmd.Flags().BoolVar(&opts.TLSCluster, "cluster-tls", false, "Whether cluster tls is enabled")
+	return cmd
+}
+
+func runDiagnose(opts diagnose.Options, kubecfg string) error {
+	kubeCli, cli, err := util.NewKubeAndCRCli(kubecfg)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("start to collect diagnostic %s", opts.String())
+	dm := diagnose.NewManager(kubeCli, cli, opts)
+	return dm.ProcessDiagnostic()
+}
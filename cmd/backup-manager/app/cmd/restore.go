@@ -52,6 +52,8 @@ func NewRestoreCommand() *cobra.Command {
 	cmd.Flags().StringVar(&ro.Mode, "mode", string(v1alpha1.RestoreModeSnapshot), "restore mode, which is pitr or snapshot(default)")
 	cmd.Flags().StringVar(&ro.PitrRestoredTs, "pitrRestoredTs", "0", "The pitr restored ts")
 	cmd.Flags().BoolVar(&ro.Prepare, "prepare", false, "Whether to prepare for restore")
+	cmd.Flags().BoolVar(&ro.Resume, "resume", false, "Whether to resume the restore from checkpoint of the previous failed attempt")
+	cmd.Flags().BoolVar(&ro.Verify, "verify", false, "Whether to verify the restored data instead of restoring")
 	return cmd
 }
 
@@ -0,0 +1,430 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	bkutil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	pdClientPort   = 2379
+	tikvStatusPort = 20180
+
+	// unredactVerb is the RBAC verb on diagnostics which allows to collect the bundle without redaction
+	unredactVerb = "unredact"
+	// requestTimeout is the timeout of the requests except the CPU profile
+	requestTimeout = 2 * time.Minute
+)
+
+// Options contains the input arguments to the diagnose command
+type Options struct {
+	Namespace      string
+	DiagnosticName string
+	TLSCluster     bool
+}
+
+func (o *Options) String() string {
+	return fmt.Sprintf("%s/%s", o.Namespace, o.DiagnosticName)
+}
+
+// Manager collects the diagnostic bundle of a Diagnostic
+type Manager struct {
+	kubeCli kubernetes.Interface
+	cli     versioned.Interface
+	Options
+}
+
+// NewManager returns a Manager
+func NewManager(kubeCli kubernetes.Interface, cli versioned.Interface, opts Options) *Manager {
+	return &Manager{
+		kubeCli: kubeCli,
+		cli:     cli,
+		Options: opts,
+	}
+}
+
+// ProcessDiagnostic collects the bundle and uploads it to the storage, the status of the Diagnostic is
+// updated to Completed with the data failed to be collected, or Failed if the bundle can't be stored.
+func (m *Manager) ProcessDiagnostic() error {
+	ctx, cancel := util.GetContextForTerminationSignals(fmt.Sprintf("diagnose %s", m))
+	defer cancel()
+
+	d, err := m.cli.PingcapV1alpha1().Diagnostics(m.Namespace).Get(ctx, m.DiagnosticName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("can't find diagnostic %s, err: %v", m, err)
+	}
+	bundlePath, redacted, errs, err := m.collect(ctx, d)
+	if err != nil {
+		klog.Errorf("diagnostic %s failed, err: %v", m, err)
+		if updateErr := m.updateStatus(ctx, func(status *v1alpha1.DiagnosticStatus) {
+			status.Phase = v1alpha1.DiagnosticPhaseFailed
+			status.Message = err.Error()
+		}); updateErr != nil {
+			klog.Errorf("update status of diagnostic %s failed, err: %v", m, updateErr)
+		}
+		return err
+	}
+
+	klog.Infof("diagnostic %s is completed, the bundle is stored at %s", m, bundlePath)
+	return m.updateStatus(ctx, func(status *v1alpha1.DiagnosticStatus) {
+		status.Phase = v1alpha1.DiagnosticPhaseCompleted
+		status.Path = bundlePath
+		status.Redacted = redacted
+		status.Errors = errs
+	})
+}
+
+func (m *Manager) collect(ctx context.Context, d *v1alpha1.Diagnostic) (string, bool, []string, error) {
+	tc, err := m.cli.PingcapV1alpha1().TidbClusters(m.Namespace).Get(ctx, d.Spec.Cluster, metav1.GetOptions{})
+	if err != nil {
+		return "", false, nil, fmt.Errorf("get tidbcluster %s/%s failed, err: %v", m.Namespace, d.Spec.Cluster, err)
+	}
+	httpClient, err := m.newHTTPClient()
+	if err != nil {
+		return "", false, nil, err
+	}
+
+	c := &collector{
+		Manager:    m,
+		diagnostic: d,
+		tc:         tc,
+		httpClient: httpClient,
+		scheme:     "http",
+	}
+	if m.TLSCluster {
+		c.scheme = "https"
+	}
+	if d.Spec.Redaction != nil {
+		c.redactor, err = newRedactor(d.Spec.Redaction.Patterns)
+	} else {
+		c.redactor, err = newRedactor(nil)
+	}
+	if err != nil {
+		return "", false, nil, err
+	}
+	redacted := true
+	if d.RedactionDisabled() {
+		allowed, err := m.canUnredact(ctx, d)
+		if err != nil {
+			c.addError("check the permission to disable redaction failed, the bundle is redacted, err: %v", err)
+		} else if !allowed {
+			c.addError("the creator %q is not allowed to %s diagnostics, the bundle is redacted",
+				d.Annotations[label.AnnDiagnosticCreatorKey], unredactVerb)
+		} else {
+			redacted = false
+			c.redactor = nil
+		}
+	}
+
+	file, err := os.CreateTemp("", "diagnostic-*.tar.gz")
+	if err != nil {
+		return "", false, nil, fmt.Errorf("create bundle file failed, err: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	gw := gzip.NewWriter(file)
+	c.tw = tar.NewWriter(gw)
+
+	c.collectAll(ctx)
+
+	if err := c.tw.Close(); err != nil {
+		return "", false, nil, fmt.Errorf("write bundle failed, err: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		return "", false, nil, fmt.Errorf("write bundle failed, err: %v", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", false, nil, fmt.Errorf("read bundle failed, err: %v", err)
+	}
+
+	key := fmt.Sprintf("%s-%s.tar.gz", d.Name, time.Now().UTC().Format("20060102150405"))
+	if err := m.upload(ctx, d, key, file); err != nil {
+		return "", false, nil, err
+	}
+	storagePath, err := bkutil.GetStoragePath(d.Spec.StorageProvider)
+	if err != nil {
+		return "", false, nil, err
+	}
+	return strings.TrimSuffix(storagePath, "/") + "/" + key, redacted, c.errs, nil
+}
+
+func (m *Manager) upload(ctx context.Context, d *v1alpha1.Diagnostic, key string, r io.Reader) error {
+	backend, err := bkutil.NewStorageBackend(d.Spec.StorageProvider, &bkutil.StorageCredential{})
+	if err != nil {
+		return fmt.Errorf("create storage backend failed, err: %v", err)
+	}
+	defer backend.Close()
+
+	w, err := backend.NewWriter(ctx, key, nil)
+	if err != nil {
+		return fmt.Errorf("upload bundle %s failed, err: %v", key, err)
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return fmt.Errorf("upload bundle %s failed, err: %v", key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("upload bundle %s failed, err: %v", key, err)
+	}
+	return nil
+}
+
+// canUnredact checks whether the user who creates the Diagnostic is allowed to collect the bundle without
+// redaction. The service account of the job is chosen by the creator, so the creator recorded by the
+// admission webhook is checked instead.
+func (m *Manager) canUnredact(ctx context.Context, d *v1alpha1.Diagnostic) (bool, error) {
+	creator := d.Annotations[label.AnnDiagnosticCreatorKey]
+	if creator == "" {
+		return false, fmt.Errorf("the creator of diagnostic %s is not recorded by the admission webhook", m)
+	}
+	var groups []string
+	if v := d.Annotations[label.AnnDiagnosticCreatorGroupsKey]; v != "" {
+		groups = strings.Split(v, ",")
+	}
+	review := &authv1.LocalSubjectAccessReview{
+		ObjectMeta: metav1.ObjectMeta{Namespace: m.Namespace},
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   creator,
+			Groups: groups,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: m.Namespace,
+				Verb:      unredactVerb,
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  v1alpha1.DiagnosticName,
+				Name:      m.DiagnosticName,
+			},
+		},
+	}
+	result, err := m.kubeCli.AuthorizationV1().LocalSubjectAccessReviews(m.Namespace).Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
+
+func (m *Manager) newHTTPClient() (*http.Client, error) {
+	if !m.TLSCluster {
+		return &http.Client{}, nil
+	}
+	cert, err := tls.LoadX509KeyPair(
+		path.Join(pkgutil.ClusterClientTLSPath, corev1.TLSCertKey),
+		path.Join(pkgutil.ClusterClientTLSPath, corev1.TLSPrivateKeyKey))
+	if err != nil {
+		return nil, fmt.Errorf("load cluster client certificate failed, err: %v", err)
+	}
+	ca, err := os.ReadFile(path.Join(pkgutil.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey))
+	if err != nil {
+		return nil, fmt.Errorf("load cluster client CA failed, err: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{cert},
+				RootCAs:      pool,
+			},
+		},
+	}, nil
+}
+
+func (m *Manager) updateStatus(ctx context.Context, update func(status *v1alpha1.DiagnosticStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		d, err := m.cli.PingcapV1alpha1().Diagnostics(m.Namespace).Get(ctx, m.DiagnosticName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		update(&d.Status)
+		d.Status.TimeCompleted = metav1.Now()
+		_, err = m.cli.PingcapV1alpha1().Diagnostics(m.Namespace).UpdateStatus(ctx, d, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// collector writes the collected data into the bundle, the data failed to be collected is recorded in errs
+type collector struct {
+	*Manager
+	diagnostic *v1alpha1.Diagnostic
+	tc         *v1alpha1.TidbCluster
+	httpClient *http.Client
+	scheme     string
+	redactor   *redactor
+
+	lock sync.Mutex
+	tw   *tar.Writer
+	errs []string
+}
+
+func (c *collector) collectAll(ctx context.Context) {
+	d := c.diagnostic
+	var wg sync.WaitGroup
+	for _, component := range d.GetComponents() {
+		pods, err := c.kubeCli.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: label.New().Instance(c.tc.Name).Component(component.String()).String(),
+		})
+		if err != nil {
+			c.addError("list %s pods failed, err: %v", component, err)
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			component := component
+			// the CPU profiles last for a while, so the pods are collected concurrently
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.collectPod(ctx, component, pod)
+			}()
+		}
+	}
+
+	pdURL := fmt.Sprintf("%s://%s.%s:%d", c.scheme, controller.PDMemberName(c.tc.Name), c.Namespace, pdClientPort)
+	if d.ShouldCollect(v1alpha1.DiagnosticItemStores) {
+		c.collectHTTP(ctx, pdURL+"/pd/api/v1/stores", "pd/stores.json", requestTimeout, true)
+	}
+	if d.ShouldCollect(v1alpha1.DiagnosticItemRegions) {
+		c.collectHTTP(ctx, pdURL+"/pd/api/v1/regions", "pd/regions.json", requestTimeout, true)
+	}
+	wg.Wait()
+}
+
+func (c *collector) collectPod(ctx context.Context, component v1alpha1.MemberType, pod *corev1.Pod) {
+	d := c.diagnostic
+	var base string
+	switch component {
+	case v1alpha1.PDMemberType:
+		base = fmt.Sprintf("%s://%s:%d", c.scheme, c.podHost(controller.PDPeerMemberName(c.tc.Name), pod.Name), pdClientPort)
+	case v1alpha1.TiKVMemberType:
+		base = fmt.Sprintf("%s://%s:%d", c.scheme, c.podHost(controller.TiKVPeerMemberName(c.tc.Name), pod.Name), tikvStatusPort)
+	default:
+		c.addError("collect from %s is not supported", component)
+		return
+	}
+	dir := path.Join(component.String(), pod.Name)
+
+	if d.ShouldCollect(v1alpha1.DiagnosticItemLogs) {
+		tailLines := d.GetLogTailLines()
+		data, err := c.kubeCli.CoreV1().Pods(c.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: component.String(),
+			TailLines: &tailLines,
+		}).DoRaw(ctx)
+		if err != nil {
+			c.addError("collect logs of pod %s failed, err: %v", pod.Name, err)
+		} else {
+			c.addFile(path.Join(dir, "log"), data, true)
+		}
+	}
+	if d.ShouldCollect(v1alpha1.DiagnosticItemMetrics) {
+		c.collectHTTP(ctx, base+"/metrics", path.Join(dir, "metrics"), requestTimeout, false)
+	}
+	if d.ShouldCollect(v1alpha1.DiagnosticItemProfiles) {
+		for _, profile := range d.GetProfiles() {
+			url := fmt.Sprintf("%s/debug/pprof/%s", base, profile)
+			timeout := requestTimeout
+			if profile == "profile" {
+				url = fmt.Sprintf("%s?seconds=%d", url, int(d.GetProfileDuration().Seconds()))
+				timeout += d.GetProfileDuration()
+			}
+			c.collectHTTP(ctx, url, path.Join(dir, "pprof", profile+".prof"), timeout, false)
+		}
+	}
+}
+
+func (c *collector) podHost(peerService, podName string) string {
+	host := fmt.Sprintf("%s.%s.%s", podName, peerService, c.Namespace)
+	if c.tc.Spec.ClusterDomain != "" {
+		host = fmt.Sprintf("%s.svc.%s", host, c.tc.Spec.ClusterDomain)
+	}
+	return host
+}
+
+func (c *collector) collectHTTP(ctx context.Context, url, name string, timeout time.Duration, redact bool) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		c.addError("collect %s failed, err: %v", name, err)
+		return
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.addError("collect %s failed, err: %v", name, err)
+		return
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.addError("collect %s failed, err: %v", name, err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.addError("collect %s failed, status %d: %s", name, resp.StatusCode, string(data))
+		return
+	}
+	c.addFile(name, data, redact)
+}
+
+func (c *collector) addFile(name string, data []byte, redact bool) {
+	if redact && c.redactor != nil {
+		data = c.redactor.Redact(data)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("write %s failed, err: %v", name, err))
+		return
+	}
+	if _, err := c.tw.Write(data); err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("write %s failed, err: %v", name, err))
+	}
+}
+
+func (c *collector) addError(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	klog.Warningf("diagnostic %s: %s", c.Manager, msg)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.errs = append(c.errs, msg)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const redactedValue = "?"
+
+// keyPattern matches the user keys in the logs and the region dumps, e.g. `start_key=7480...`,
+// `[key=7480...]` and `"end_key":"7480..."`, the first group is the name of the field which is kept.
+var keyPattern = regexp.MustCompile(`(?i)(\b[a-z_]*key"?\s*[=:]\s*"?)([^\s",\]}]+)`)
+
+// redactor removes the sensitive data from the logs and the dumps
+type redactor struct {
+	patterns []*regexp.Regexp
+}
+
+func newRedactor(patterns []string) (*redactor, error) {
+	r := &redactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %v", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact replaces the keys and the matches of the additional patterns with `?`
+func (r *redactor) Redact(data []byte) []byte {
+	data = keyPattern.ReplaceAll(data, []byte("${1}"+redactedValue))
+	for _, re := range r.patterns {
+		data = re.ReplaceAll(data, []byte(redactedValue))
+	}
+	return data
+}
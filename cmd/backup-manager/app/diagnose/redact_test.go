@@ -0,0 +1,63 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRedact(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	r, err := newRedactor([]string{`\d+\.\d+\.\d+\.\d+`})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cases := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "log fields",
+			input:  `[2023/01/01 00:00:00.000 +00:00] [INFO] [split] [region_id=2] [start_key=7480000000000000FF3F5F] [end_key=]`,
+			expect: `[2023/01/01 00:00:00.000 +00:00] [INFO] [split] [region_id=2] [start_key=?] [end_key=]`,
+		},
+		{
+			name:   "region dump",
+			input:  `{"id":2,"start_key":"7480000000000000FF3F5F","end_key":"7480000000000000FF4100"}`,
+			expect: `{"id":2,"start_key":"?","end_key":"?"}`,
+		},
+		{
+			name:   "key with space",
+			input:  `lock conflict, key: 7480000000000000FF3F5F, ts 1`,
+			expect: `lock conflict, key: ?, ts 1`,
+		},
+		{
+			name:   "additional patterns",
+			input:  `connection from 10.0.0.1 is closed`,
+			expect: `connection from ? is closed`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(string(r.Redact([]byte(c.input)))).To(Equal(c.expect))
+		})
+	}
+
+	_, err = newRedactor([]string{"("})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -56,6 +56,10 @@ func (bo *Options) getBackupRelativePath() string {
 }
 
 func (bo *Options) getDestBucketURI(remotePath string) string {
+	if bo.StorageType == string(v1alpha1.BackupStorageTypeLocal) {
+		// the bucket of the local storage is the absolute mount path of the volume
+		remotePath = "/" + remotePath
+	}
 	return fmt.Sprintf("%s://%s", bo.StorageType, remotePath)
 }
 
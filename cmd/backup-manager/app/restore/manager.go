@@ -23,7 +23,9 @@ import (
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	bkconstants "github.com/pingcap/tidb-operator/pkg/backup/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
@@ -100,7 +102,7 @@ func (rm *Manager) ProcessRestore() error {
 		return rm.performRestore(ctx, restore.DeepCopy(), nil)
 	}
 
-	rm.setOptions(restore)
+	rm.setOptions(backuputil.RemapRestoreSource(restore))
 
 	var db *sql.DB
 	var dsn string
@@ -136,6 +138,9 @@ func (rm *Manager) ProcessRestore() error {
 	}
 
 	defer db.Close()
+	if rm.Verify {
+		return rm.verifyRestore(ctx, restore.DeepCopy(), db)
+	}
 	return rm.performRestore(ctx, restore.DeepCopy(), db)
 }
 
@@ -278,6 +283,23 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 	}
 	klog.Infof("restore cluster %s from %s succeed", rm, restore.Spec.Type)
 
+	if restore.Spec.ScatterRegions != nil && rm.Mode != string(v1alpha1.RestoreModeVolumeSnapshot) {
+		if db == nil {
+			klog.Warningf("spec.to of restore %s is not set, skip scattering the regions of the restored tables", rm)
+		} else if err := rm.scatterRestoredRegions(ctx, restore, db); err != nil {
+			errs = append(errs, err)
+			klog.Errorf("scatter regions of cluster %s failed, err: %s", rm, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "ScatterRegionsFailed",
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+	}
+
 	var (
 		commitTS    *string
 		restoreType v1alpha1.RestoreConditionType
@@ -293,7 +315,34 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 			restoreType = v1alpha1.RestoreDataComplete
 			allFinished = true
 		}
+	case string(v1alpha1.RestoreModePiTR):
+		// In pitr mode, the storage is the log backup which has no backup meta,
+		// the cluster is restored to the restored ts after the log backup is replayed.
+		ts, err := config.ParseTSString(rm.PitrRestoredTs)
+		if err != nil {
+			errs = append(errs, err)
+			klog.Errorf("parse cluster %s pitr restored ts failed, err: %s", rm, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "ParsePitrRestoredTsFailed",
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+		restoreType = v1alpha1.RestoreComplete
+		tsStr := strconv.FormatUint(ts, 10)
+		commitTS = &tsStr
+		allFinished = true
 	default:
+		if restore.Spec.Encryption != nil {
+			// the backup meta is encrypted by BR, so the commitTs can not be read from it
+			klog.Infof("backup files of cluster %s are encrypted, skip reading the commitTs from the backup metadata", rm)
+			restoreType = v1alpha1.RestoreComplete
+			allFinished = true
+			break
+		}
 		ts, err := util.GetCommitTsFromBRMetaData(ctx, restore.Spec.StorageProvider)
 		if err != nil {
 			errs = append(errs, err)
@@ -313,6 +362,13 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 		allFinished = true
 	}
 
+	if restoreType == v1alpha1.RestoreComplete && restore.NeedVerification() {
+		// the restore is completed by the verification job
+		klog.Infof("restored data of cluster %s will be verified by %s", rm, restore.Spec.Verification)
+		restoreType = v1alpha1.RestoreVerifying
+		allFinished = false
+	}
+
 	updateStatus := &controller.RestoreUpdateStatus{
 		TimeStarted: &metav1.Time{Time: started},
 		CommitTs:    commitTS,
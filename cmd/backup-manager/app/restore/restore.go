@@ -42,6 +42,10 @@ type Options struct {
 	backupUtil.GenericOptions
 	// Prepare to restore data. It's used in volume-snapshot mode.
 	Prepare bool
+	// Resume the restore from checkpoint of the previous failed attempt.
+	Resume bool
+	// Verify the restored data instead of restoring, it's used by the verification job.
+	Verify bool
 }
 
 func (ro *Options) restoreData(
@@ -109,13 +113,20 @@ func (ro *Options) restoreData(
 		}
 		useProgressFile = true
 	}
+	if ro.Resume && ro.Mode != string(v1alpha1.RestoreModeVolumeSnapshot) {
+		if ro.isBRCanResumeByCheckpoint() {
+			args = append(args, "--use-checkpoint=true")
+		} else {
+			klog.Warningf("restore %s can not resume from checkpoint with tikv version %s, restore from scratch", ro, ro.TiKVVersion)
+		}
+	}
 
 	fullArgs := []string{
 		"restore",
 		restoreType,
 	}
 	fullArgs = append(fullArgs, args...)
-	klog.Infof("Running br command with args: %v", fullArgs)
+	klog.Infof("Running br command with args: %v", pkgutil.RedactStorageArgs(fullArgs))
 	bin := path.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
 
@@ -129,7 +140,7 @@ func (ro *Options) restoreData(
 	}
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", ro, fullArgs, err)
+		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", ro, pkgutil.RedactStorageArgs(fullArgs), err)
 	}
 
 	var (
@@ -149,6 +160,8 @@ func (ro *Options) restoreData(
 	}
 
 	var errMsg string
+	snapshotComplete := v1alpha1.IsRestoreSnapshotComplete(restore)
+	progressThrottler := backupUtil.NewProgressThrottler(backupUtil.ProgressUpdateInterval)
 	reader := bufio.NewReader(stdOut)
 	for {
 		line, err := reader.ReadString('\n')
@@ -156,7 +169,10 @@ func (ro *Options) restoreData(
 			errMsg += line
 		} else {
 			if !useProgressFile {
-				ro.updateProgressAccordingToBrLog(line, restore, statusUpdater)
+				ro.updateProgressAccordingToBrLog(line, restore, progressThrottler, statusUpdater)
+			}
+			if ro.Mode == string(v1alpha1.RestoreModePiTR) && !snapshotComplete {
+				snapshotComplete = ro.updateSnapshotCompleteForPiTR(line, restore, statusUpdater)
 			}
 			ro.updateResolvedTSForCSB(line, restore, progressStep, statusUpdater)
 		}
@@ -251,12 +267,17 @@ func constructBROptions(restore *v1alpha1.Restore) ([]string, error) {
 	if config.OnLine != nil {
 		args = append(args, fmt.Sprintf("--online=%t", *config.OnLine))
 	}
+	if config.Stats != nil {
+		args = append(args, fmt.Sprintf("--load-stats=%t", *config.Stats))
+	}
+	args = append(args, backupUtil.ConstructBREncryptionOptions(restore.Spec.Encryption)...)
 	args = append(args, config.Options...)
 	return args, nil
 }
 
 // updateProgressAccordingToBrLog update restore progress according to the br log.
-func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1.Restore, statusUpdater controller.RestoreConditionUpdaterInterface) {
+func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1.Restore, throttler *backupUtil.ProgressThrottler,
+	statusUpdater controller.RestoreConditionUpdaterInterface) {
 	step, progress := backupUtil.ParseRestoreProgress(line)
 	if step != "" {
 		fvalue, progressUpdateErr := strconv.ParseFloat(progress, 64)
@@ -264,11 +285,17 @@ func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1
 			klog.Errorf("parse restore %s progress string value %s to float error %v", ro, progress, progressUpdateErr)
 			fvalue = 0
 		}
-		klog.Infof("update restore %s step %s progress %s float value %f", ro, step, progress, fvalue)
+		now := time.Now()
+		if !throttler.Allow(step, fvalue, now) {
+			return
+		}
+		speed := backupUtil.ParseBRProgressSpeed(line)
+		klog.Infof("update restore %s step %s progress %s float value %f speed %q", ro, step, progress, fvalue, speed)
 		progressUpdateErr = statusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{
 			ProgressStep:       &step,
 			Progress:           &fvalue,
-			ProgressUpdateTime: &metav1.Time{Time: time.Now()},
+			ProgressSpeed:      &speed,
+			ProgressUpdateTime: &metav1.Time{Time: now},
 		})
 		if progressUpdateErr != nil {
 			klog.Errorf("update restore %s progress error %v", ro, progressUpdateErr)
@@ -276,6 +303,26 @@ func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1
 	}
 }
 
+// updateSnapshotCompleteForPiTR marks the full snapshot backup of the pitr restore as restored
+// once br starts to replay the log backup, it returns true if the condition is updated.
+func (ro *Options) updateSnapshotCompleteForPiTR(line string, restore *v1alpha1.Restore, statusUpdater controller.RestoreConditionUpdaterInterface) bool {
+	// br restores the full snapshot backup in step "Full Restore" at first,
+	// then replays the log backup in steps like "Restore Meta Files" and "Restore KV Files".
+	step, _ := backupUtil.ParseRestoreProgress(line)
+	if !strings.HasPrefix(step, "Restore ") {
+		return false
+	}
+	klog.Infof("restore %s has restored the full snapshot backup, start to replay the log backup", ro)
+	if err := statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+		Type:   v1alpha1.RestoreSnapshotComplete,
+		Status: corev1.ConditionTrue,
+	}, nil); err != nil {
+		klog.Errorf("update restore %s snapshot complete condition error %v", ro, err)
+		return false
+	}
+	return true
+}
+
 func (ro *Options) updateResolvedTSForCSB(
 	line string,
 	restore *v1alpha1.Restore,
@@ -344,3 +391,8 @@ func (ro *Options) updateProgressFromFile(
 		}
 	}
 }
+
+// isBRCanResumeByCheckpoint returns whether br supports restoring from checkpoint, which is supported since v7.1.0.
+func (ro *Options) isBRCanResumeByCheckpoint() bool {
+	return pkgutil.CanResumeRestoreByCheckpoint(ro.TiKVVersion)
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// scatterRegionsStep is the progress step of scattering the regions of the restored tables
+	scatterRegionsStep = "Scatter Region"
+	// scatterRegionOperatorDesc is the description of the operators created by PD to scatter regions
+	scatterRegionOperatorDesc = "scatter-region"
+
+	defaultScatterRetryLimit   = 5
+	defaultScatterTimeout      = 30 * time.Minute
+	scatterRegionsPollInterval = 10 * time.Second
+
+	// signMask flips the sign bit of the table ID to make the encoded keys sorted by the ID
+	signMask uint64 = 0x8000000000000000
+	// encGroupSize is the group size of the memcomparable encoding of the region keys
+	encGroupSize = 8
+)
+
+// newPDClient returns the client of the PD of the restored cluster
+var newPDClient = func(ro *Options, restore *v1alpha1.Restore) (pdapi.PDClient, error) {
+	clusterNamespace := restore.Spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = restore.Namespace
+	}
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if ro.TLSCluster {
+		cert, err := tls.LoadX509KeyPair(
+			path.Join(pkgutil.ClusterClientTLSPath, corev1.TLSCertKey),
+			path.Join(pkgutil.ClusterClientTLSPath, corev1.TLSPrivateKeyKey))
+		if err != nil {
+			return nil, fmt.Errorf("load cluster client certificate failed, err: %v", err)
+		}
+		ca, err := os.ReadFile(path.Join(pkgutil.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey))
+		if err != nil {
+			return nil, fmt.Errorf("load cluster client CA failed, err: %v", err)
+		}
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(ca)
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+		}
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s-pd.%s:2379", scheme, restore.Spec.BR.Cluster, clusterNamespace)
+	return pdapi.NewPDClient(url, pdapi.DefaultTimeout, tlsConfig), nil
+}
+
+// scatterRestoredRegions scatters the regions of the restored tables by PD, and waits until all the
+// scatter operators finish. The progress of the scattering is reported in the `Scatter Region` step.
+func (rm *Manager) scatterRestoredRegions(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) error {
+	retryLimit := defaultScatterRetryLimit
+	timeout := defaultScatterTimeout
+	if cfg := restore.Spec.ScatterRegions; cfg != nil {
+		if cfg.RetryLimit != nil {
+			retryLimit = int(*cfg.RetryLimit)
+		}
+		if cfg.Timeout != nil {
+			timeout = cfg.Timeout.Duration
+		}
+	}
+
+	tables, err := rm.listScatterTables(ctx, restore, db)
+	if err != nil {
+		return err
+	}
+	var ids []int64
+	for _, t := range tables {
+		tableIDs, err := getTableIDs(ctx, db, t)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, tableIDs...)
+	}
+	ranges := mergeTableIDs(ids)
+	klog.Infof("scatter regions of %d restored tables in %d key ranges of cluster %s", len(tables), len(ranges), rm)
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	pdClient, err := newPDClient(&rm.Options, restore)
+	if err != nil {
+		return err
+	}
+	for _, r := range ranges {
+		result, err := pdClient.ScatterRegionsByRange(encodeTablePrefix(r[0]), encodeTablePrefix(r[1]), retryLimit)
+		if err != nil {
+			return fmt.Errorf("scatter regions of tables [%d, %d) failed, err: %v", r[0], r[1], err)
+		}
+		if len(result.FailedRegions) > 0 {
+			// the failed regions are left to the balance of PD
+			klog.Warningf("failed to scatter %d regions of tables [%d, %d) of cluster %s: %v", len(result.FailedRegions), r[0], r[1], rm, result.FailedRegions)
+		}
+	}
+	return rm.waitScatterRegions(ctx, restore, pdClient, timeout)
+}
+
+// waitScatterRegions waits until there are no scatter operators running in PD
+func (rm *Manager) waitScatterRegions(ctx context.Context, restore *v1alpha1.Restore, pdClient pdapi.PDClient, timeout time.Duration) error {
+	step := scatterRegionsStep
+	total := 0
+	err := wait.PollImmediate(scatterRegionsPollInterval, timeout, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		operators, err := pdClient.GetOperators()
+		if err != nil {
+			klog.Warningf("get operators of cluster %s failed, err: %v", rm, err)
+			return false, nil
+		}
+		running := 0
+		for _, op := range operators {
+			if strings.HasPrefix(op, scatterRegionOperatorDesc) {
+				running++
+			}
+		}
+		if running > total {
+			total = running
+		}
+		progress := 100.0
+		if total > 0 {
+			progress = float64(total-running) * 100 / float64(total)
+		}
+		klog.Infof("%d of %d scatter operators of cluster %s are running", running, total, rm)
+		if err := rm.StatusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{
+			ProgressStep:       &step,
+			Progress:           &progress,
+			ProgressUpdateTime: &metav1.Time{Time: time.Now()},
+		}); err != nil {
+			klog.Errorf("update restore %s progress error %v", rm, err)
+		}
+		return running == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("scattering regions is not completed in %s", timeout)
+	}
+	return err
+}
+
+// listScatterTables lists the restored tables to scatter from the backup meta, or from the
+// information schema if the schemas are not in the backup meta.
+func (rm *Manager) listScatterTables(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) ([]verifyTable, error) {
+	// the backup meta is encrypted by BR, and there is no backup meta in the log backup
+	if restore.Spec.Encryption == nil && rm.Mode != string(v1alpha1.RestoreModePiTR) {
+		meta, err := util.GetBRMetaData(ctx, restore.Spec.StorageProvider)
+		if err != nil {
+			return nil, fmt.Errorf("read backup meta failed, err: %v", err)
+		}
+		if len(meta.Schemas) > 0 {
+			return tablesFromBackupMeta(meta, restore.Spec.BR)
+		}
+	}
+	tables, _, err := rm.listRestoredTables(ctx, restore, db)
+	return tables, err
+}
+
+// getTableIDs returns the IDs of the table and its partitions, the data of a partitioned table is stored
+// under the IDs of the partitions. It returns nil if the table is not restored.
+func getTableIDs(ctx context.Context, db *sql.DB, t verifyTable) ([]int64, error) {
+	var id int64
+	query := "SELECT TIDB_TABLE_ID FROM information_schema.tables WHERE table_schema = ? AND table_name = ?"
+	err := db.QueryRowContext(ctx, query, t.db, t.table).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query id of table %s failed, sql: %s, err: %v", t, query, err)
+	}
+	ids := []int64{id}
+
+	query = "SELECT TIDB_PARTITION_ID FROM information_schema.partitions WHERE table_schema = ? AND table_name = ? AND TIDB_PARTITION_ID IS NOT NULL"
+	rows, err := db.QueryContext(ctx, query, t.db, t.table)
+	if err != nil {
+		return nil, fmt.Errorf("query partition ids of table %s failed, sql: %s, err: %v", t, query, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("query partition ids of table %s failed, sql: %s, err: %v", t, query, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query partition ids of table %s failed, sql: %s, err: %v", t, query, err)
+	}
+	return ids, nil
+}
+
+// mergeTableIDs merges the table IDs into the ranges [start, end) of the consecutive IDs,
+// the tables restored by BR are allocated the consecutive IDs mostly.
+func mergeTableIDs(ids []int64) [][2]int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var ranges [][2]int64
+	for _, id := range ids {
+		if n := len(ranges); n > 0 && id <= ranges[n-1][1] {
+			if id == ranges[n-1][1] {
+				ranges[n-1][1]++
+			}
+			continue
+		}
+		ranges = append(ranges, [2]int64{id, id + 1})
+	}
+	return ranges
+}
+
+// encodeTablePrefix returns the region key of the prefix `t{tableID}` of the table data
+func encodeTablePrefix(tableID int64) []byte {
+	key := make([]byte, 1, 1+8)
+	key[0] = 't'
+	key = binary.BigEndian.AppendUint64(key, uint64(tableID)^signMask)
+	return encodeBytes(key)
+}
+
+// encodeBytes encodes the key in the memcomparable format of the region keys, the key is split
+// into groups of 8 bytes padded with zeros, and each group is followed by the marker `0xFF - padding`.
+func encodeBytes(data []byte) []byte {
+	result := make([]byte, 0, (len(data)/encGroupSize+1)*(encGroupSize+1))
+	for idx := 0; idx <= len(data); idx += encGroupSize {
+		remain := len(data) - idx
+		padCount := 0
+		if remain >= encGroupSize {
+			result = append(result, data[idx:idx+encGroupSize]...)
+		} else {
+			padCount = encGroupSize - remain
+			result = append(result, data[idx:]...)
+			result = append(result, make([]byte, padCount)...)
+		}
+		result = append(result, byte(0xFF-padCount))
+	}
+	return result
+}
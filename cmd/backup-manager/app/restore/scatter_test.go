@@ -0,0 +1,37 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"encoding/hex"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEncodeTablePrefix(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(hex.EncodeToString(encodeTablePrefix(1))).To(Equal("7480000000000000ff0100000000000000f8"))
+	g.Expect(hex.EncodeToString(encodeTablePrefix(100))).To(Equal("7480000000000000ff6400000000000000f8"))
+	// the encoded keys are sorted by the table IDs
+	g.Expect(string(encodeTablePrefix(255)) < string(encodeTablePrefix(256))).To(BeTrue())
+}
+
+func TestMergeTableIDs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(mergeTableIDs(nil)).To(BeEmpty())
+	g.Expect(mergeTableIDs([]int64{105, 101, 102, 100, 102, 110})).To(Equal([][2]int64{{100, 103}, {105, 106}, {110, 111}}))
+}
@@ -0,0 +1,329 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maxVerificationFailures is the max number of the failed tables recorded in the status,
+	// to keep the size of the Restore object bounded.
+	maxVerificationFailures = 100
+)
+
+// verifyTable is a restored table to verify, with the checksum recorded in the backup meta.
+type verifyTable struct {
+	db         string
+	table      string
+	crc64Xor   uint64
+	totalKvs   uint64
+	totalBytes uint64
+}
+
+func (t verifyTable) String() string {
+	return fmt.Sprintf("`%s`.`%s`", t.db, t.table)
+}
+
+// schemaName is the name of a database or a table encoded in the backup meta by TiDB.
+type schemaName struct {
+	O string `json:"O"`
+}
+
+// tablesFromBackupMeta returns the tables to verify from the schemas of the backup meta, the system tables,
+// views and sequences are skipped, and so are the tables out of the DB and table of the restore.
+func tablesFromBackupMeta(meta *kvbackup.BackupMeta, br *v1alpha1.BRConfig) ([]verifyTable, error) {
+	var tables []verifyTable
+	for _, schema := range meta.Schemas {
+		if len(schema.Table) == 0 {
+			// the database has no tables
+			continue
+		}
+		var dbInfo struct {
+			Name schemaName `json:"db_name"`
+		}
+		if err := json.Unmarshal(schema.Db, &dbInfo); err != nil {
+			return nil, fmt.Errorf("failed to parse database info in backup meta, err: %v", err)
+		}
+		var tableInfo struct {
+			Name     schemaName      `json:"name"`
+			View     json.RawMessage `json:"view"`
+			Sequence json.RawMessage `json:"sequence"`
+		}
+		if err := json.Unmarshal(schema.Table, &tableInfo); err != nil {
+			return nil, fmt.Errorf("failed to parse table info of database %s in backup meta, err: %v", dbInfo.Name.O, err)
+		}
+		if isSystemDB(dbInfo.Name.O) || isNonNull(tableInfo.View) || isNonNull(tableInfo.Sequence) {
+			continue
+		}
+		if br != nil && br.DB != "" && !strings.EqualFold(br.DB, dbInfo.Name.O) {
+			continue
+		}
+		if br != nil && br.Table != "" && !strings.EqualFold(br.Table, tableInfo.Name.O) {
+			continue
+		}
+		tables = append(tables, verifyTable{
+			db:         dbInfo.Name.O,
+			table:      tableInfo.Name.O,
+			crc64Xor:   schema.Crc64Xor,
+			totalKvs:   schema.TotalKvs,
+			totalBytes: schema.TotalBytes,
+		})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].String() < tables[j].String() })
+	return tables, nil
+}
+
+func isSystemDB(db string) bool {
+	switch strings.ToLower(db) {
+	case "mysql", "sys", "information_schema", "performance_schema", "metrics_schema":
+		return true
+	}
+	// the temporary database used by BR to restore the system tables
+	return strings.HasPrefix(strings.ToLower(db), "__tidb_br_temporary_")
+}
+
+func isNonNull(raw json.RawMessage) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
+
+// compareChecksum compares the result of `ADMIN CHECKSUM TABLE` with the checksum in the backup meta,
+// and returns the message of the mismatch, or an empty string if they match.
+func compareChecksum(t verifyTable, crc64Xor, totalKvs, totalBytes uint64) string {
+	var mismatches []string
+	if crc64Xor != t.crc64Xor {
+		mismatches = append(mismatches, fmt.Sprintf("crc64xor %d != %d", crc64Xor, t.crc64Xor))
+	}
+	if totalKvs != t.totalKvs {
+		mismatches = append(mismatches, fmt.Sprintf("total kvs %d != %d", totalKvs, t.totalKvs))
+	}
+	if totalBytes != t.totalBytes {
+		mismatches = append(mismatches, fmt.Sprintf("total bytes %d != %d", totalBytes, t.totalBytes))
+	}
+	if len(mismatches) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("checksum mismatch with backup (restored != backup): %s", strings.Join(mismatches, ", "))
+}
+
+// listVerifyTables lists the restored tables to verify. The tables in the backup meta which are not restored are
+// skipped if the restore has table filters, which can not be evaluated here, otherwise they fail the verification.
+func (rm *Manager) listVerifyTables(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) ([]verifyTable, []v1alpha1.RestoreVerificationFailure, error) {
+	meta, err := util.GetBRMetaData(ctx, restore.Spec.StorageProvider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read backup meta failed, err: %v", err)
+	}
+	tables, err := tablesFromBackupMeta(meta, restore.Spec.BR)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(meta.Schemas) == 0 {
+		// the schemas are not in the backup meta, e.g. the backup meta v2 of BR
+		if restore.Spec.Verification == v1alpha1.RestoreVerificationChecksum {
+			return nil, nil, fmt.Errorf("no table checksums in the backup meta")
+		}
+		return rm.listRestoredTables(ctx, restore, db)
+	}
+
+	br := restore.Spec.BR
+	hasFilter := len(restore.Spec.TableFilter) > 0 || (br != nil && (br.DB != "" || br.Table != ""))
+	var (
+		restored []verifyTable
+		failures []v1alpha1.RestoreVerificationFailure
+	)
+	for _, t := range tables {
+		var count int
+		query := "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = ?"
+		if err := db.QueryRowContext(ctx, query, t.db, t.table).Scan(&count); err != nil {
+			return nil, nil, fmt.Errorf("query table %s failed, sql: %s, err: %v", t, query, err)
+		}
+		switch {
+		case count > 0:
+			restored = append(restored, t)
+		case !hasFilter:
+			failures = append(failures, v1alpha1.RestoreVerificationFailure{Table: t.String(), Message: "table is not restored"})
+		}
+	}
+	return restored, failures, nil
+}
+
+// listRestoredTables lists the restored base tables from the information schema.
+func (rm *Manager) listRestoredTables(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) ([]verifyTable, []v1alpha1.RestoreVerificationFailure, error) {
+	query := "SELECT table_schema, table_name FROM information_schema.tables WHERE table_type = 'BASE TABLE'"
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list restored tables failed, sql: %s, err: %v", query, err)
+	}
+	defer rows.Close()
+
+	var tables []verifyTable
+	for rows.Next() {
+		var t verifyTable
+		if err := rows.Scan(&t.db, &t.table); err != nil {
+			return nil, nil, fmt.Errorf("list restored tables failed, sql: %s, err: %v", query, err)
+		}
+		if isSystemDB(t.db) {
+			continue
+		}
+		if br := restore.Spec.BR; br != nil && ((br.DB != "" && !strings.EqualFold(br.DB, t.db)) || (br.Table != "" && !strings.EqualFold(br.Table, t.table))) {
+			continue
+		}
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("list restored tables failed, sql: %s, err: %v", query, err)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].String() < tables[j].String() })
+	return tables, nil, nil
+}
+
+// checkTable verifies a restored table, and returns the message of the failure, or an empty string if it passes.
+func (rm *Manager) checkTable(ctx context.Context, verification v1alpha1.RestoreVerificationType, db *sql.DB, t verifyTable) (string, error) {
+	switch verification {
+	case v1alpha1.RestoreVerificationChecksum:
+		var (
+			dbName, tableName              string
+			crc64Xor, totalKvs, totalBytes uint64
+		)
+		query := fmt.Sprintf("ADMIN CHECKSUM TABLE %s", quoteTable(t))
+		err := db.QueryRowContext(ctx, query).Scan(&dbName, &tableName, &crc64Xor, &totalKvs, &totalBytes)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return fmt.Sprintf("admin checksum table failed: %v", err), nil
+		}
+		return compareChecksum(t, crc64Xor, totalKvs, totalBytes), nil
+	case v1alpha1.RestoreVerificationAdminCheck:
+		query := fmt.Sprintf("ADMIN CHECK TABLE %s", quoteTable(t))
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return fmt.Sprintf("admin check table failed: %v", err), nil
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown verification %s", verification)
+	}
+}
+
+func quoteTable(t verifyTable) string {
+	quote := func(name string) string {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return quote(t.db) + "." + quote(t.table)
+}
+
+// verifyRestore verifies the restored data of the restore by the way in `spec.verification`, which is run by the
+// verification job after the data is restored. The restore is completed if all the restored tables pass the
+// verification, otherwise it fails with the tables failing the verification recorded in the status.
+func (rm *Manager) verifyRestore(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) error {
+	started := time.Now()
+	status := &v1alpha1.RestoreVerificationStatus{
+		Type:        restore.Spec.Verification,
+		TimeStarted: metav1.Time{Time: started},
+	}
+	if restore.Status.Verification != nil && !restore.Status.Verification.TimeStarted.IsZero() {
+		status.TimeStarted = restore.Status.Verification.TimeStarted
+	}
+
+	var errs []error
+	tables, failures, err := rm.listVerifyTables(ctx, restore, db)
+	if err != nil {
+		errs = append(errs, err)
+		klog.Errorf("list tables of cluster %s to verify failed, err: %s", rm, err)
+		uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:    v1alpha1.RestoreFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "ListVerificationTablesFailed",
+			Message: err.Error(),
+		}, nil)
+		errs = append(errs, uerr)
+		return errorutils.NewAggregate(errs)
+	}
+	status.TotalTables = int32(len(tables) + len(failures))
+	status.FailedTables = failures
+	klog.Infof("start to verify %d restored tables of cluster %s by %s", len(tables), rm, restore.Spec.Verification)
+
+	for _, t := range tables {
+		msg, err := rm.checkTable(ctx, restore.Spec.Verification, db, t)
+		if err != nil {
+			errs = append(errs, err)
+			klog.Errorf("verify table %s of cluster %s failed, err: %s", t, rm, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "VerifyRestoredDataFailed",
+				Message: err.Error(),
+			}, &controller.RestoreUpdateStatus{Verification: status})
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+		status.VerifiedTables++
+		if msg != "" {
+			klog.Warningf("table %s of cluster %s fails the verification: %s", t, rm, msg)
+			status.FailedTables = append(status.FailedTables, v1alpha1.RestoreVerificationFailure{Table: t.String(), Message: msg})
+		}
+	}
+
+	finished := time.Now()
+	status.TimeCompleted = metav1.Time{Time: finished}
+	status.Duration = finished.Sub(status.TimeStarted.Time).Round(time.Second).String()
+	if len(status.FailedTables) > 0 {
+		failed := len(status.FailedTables)
+		names := make([]string, 0, failed)
+		for _, f := range status.FailedTables {
+			names = append(names, f.Table)
+		}
+		if len(names) > 5 {
+			names = append(names[:5], "...")
+		}
+		if failed > maxVerificationFailures {
+			status.FailedTables = status.FailedTables[:maxVerificationFailures]
+		}
+		msg := fmt.Sprintf("%d of %d restored tables fail the %s verification: %s", failed, status.TotalTables, restore.Spec.Verification, strings.Join(names, ", "))
+		klog.Errorf("verify restored data of cluster %s failed, %s", rm, msg)
+		return rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:    v1alpha1.RestoreFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "VerificationFailed",
+			Message: msg,
+		}, &controller.RestoreUpdateStatus{Verification: status})
+	}
+
+	klog.Infof("verify %d restored tables of cluster %s succeed in %s", status.VerifiedTables, rm, status.Duration)
+	return rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+		Type:   v1alpha1.RestoreComplete,
+		Status: corev1.ConditionTrue,
+	}, &controller.RestoreUpdateStatus{
+		TimeCompleted: &metav1.Time{Time: finished},
+		Verification:  status,
+	})
+}
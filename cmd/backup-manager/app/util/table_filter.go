@@ -0,0 +1,204 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Table is a table of a schema
+type Table struct {
+	Schema string
+	Name   string
+}
+
+func (t Table) String() string {
+	return fmt.Sprintf("`%s`.`%s`", t.Schema, t.Name)
+}
+
+// TableFilter matches the tables by the rules of the table filter of BR and Dumpling, see
+// https://docs.pingcap.com/tidb/stable/table-filter. A table is decided by the last rule matching it,
+// and it is excluded if the rule starts with `!`. The names are matched case-insensitively.
+type TableFilter struct {
+	rules []tableRule
+}
+
+type tableRule struct {
+	raw     string
+	exclude bool
+	schema  *regexp.Regexp
+	table   *regexp.Regexp
+}
+
+func (r *tableRule) match(t Table) bool {
+	return r.schema.MatchString(t.Schema) && r.table.MatchString(t.Name)
+}
+
+// ParseTableFilter parses the rules of the table filter, the empty rules and the comments starting with `#` are
+// skipped. The rules importing the rules from files by `@` are not supported.
+func ParseTableFilter(rules []string) (*TableFilter, error) {
+	f := &TableFilter{}
+	for _, raw := range rules {
+		s := strings.TrimSpace(raw)
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		r := tableRule{raw: s}
+		if strings.HasPrefix(s, "!") {
+			r.exclude = true
+			s = strings.TrimSpace(s[1:])
+		}
+		if strings.HasPrefix(s, "@") {
+			return nil, fmt.Errorf("importing table filter rules from file %s is not supported", s[1:])
+		}
+		var err error
+		r.schema, s, err = parseTablePattern(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid table filter rule %q, err: %v", raw, err)
+		}
+		if !strings.HasPrefix(s, ".") {
+			return nil, fmt.Errorf("invalid table filter rule %q, it should be in the form of schema.table", raw)
+		}
+		r.table, s, err = parseTablePattern(s[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid table filter rule %q, err: %v", raw, err)
+		}
+		if s != "" {
+			return nil, fmt.Errorf("invalid table filter rule %q, unexpected %q", raw, s)
+		}
+		f.rules = append(f.rules, r)
+	}
+	return f, nil
+}
+
+// parseTablePattern parses the schema or table pattern at the beginning of s, and returns the regexp of the
+// pattern and the rest of s. The pattern is a wildcard pattern, a quoted name or a regexp enclosed in `/`.
+func parseTablePattern(s string) (*regexp.Regexp, string, error) {
+	if strings.HasPrefix(s, "/") {
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '/':
+				re, err := regexp.Compile("(?i)" + s[1:i])
+				return re, s[i+1:], err
+			}
+		}
+		return nil, "", fmt.Errorf("regexp %s is not closed by /", s)
+	}
+
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	i := 0
+loop:
+	for i < len(s) {
+		switch c := s[i]; c {
+		case '.':
+			break loop
+		case '*':
+			b.WriteString(".*")
+			i++
+		case '?':
+			b.WriteString(".")
+			i++
+		case '\\':
+			if i+1 >= len(s) {
+				return nil, "", fmt.Errorf("pattern %s ends with an escape", s)
+			}
+			b.WriteString(regexp.QuoteMeta(s[i+1 : i+2]))
+			i += 2
+		case '[':
+			end := strings.IndexByte(s[i+1:], ']')
+			if end < 0 {
+				return nil, "", fmt.Errorf("character class of %s is not closed by ]", s)
+			}
+			class := s[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 2
+		case '"', '`':
+			// the quote in the quoted name is escaped by doubling it
+			var name strings.Builder
+			j := i + 1
+			for {
+				if j >= len(s) {
+					return nil, "", fmt.Errorf("quoted name of %s is not closed", s)
+				}
+				if s[j] == c {
+					if j+1 < len(s) && s[j+1] == c {
+						name.WriteByte(c)
+						j += 2
+						continue
+					}
+					break
+				}
+				name.WriteByte(s[j])
+				j++
+			}
+			b.WriteString(regexp.QuoteMeta(name.String()))
+			i = j + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(s[i : i+1]))
+			i++
+		}
+	}
+	if i == 0 {
+		return nil, "", fmt.Errorf("pattern is empty")
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	return re, s[i:], err
+}
+
+// MatchTable returns whether the table is matched by the filter
+func (f *TableFilter) MatchTable(t Table) bool {
+	for i := len(f.rules) - 1; i >= 0; i-- {
+		if f.rules[i].match(t) {
+			return !f.rules[i].exclude
+		}
+	}
+	return false
+}
+
+// Resolve returns the tables matched by the filter, and the include rules matching none of the tables
+func (f *TableFilter) Resolve(tables []Table) ([]Table, []string) {
+	var matched []Table
+	for _, t := range tables {
+		if f.MatchTable(t) {
+			matched = append(matched, t)
+		}
+	}
+	var unmatched []string
+	for i := range f.rules {
+		r := &f.rules[i]
+		if r.exclude {
+			continue
+		}
+		found := false
+		for _, t := range tables {
+			if r.match(t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, r.raw)
+		}
+	}
+	return matched, unmatched
+}
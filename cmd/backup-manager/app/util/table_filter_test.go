@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTableFilter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tables := []Table{
+		{"db1", "t1"},
+		{"db1", "t2"},
+		{"DB2", "orders"},
+		{"db.3", "t1"},
+		{"logs_2023", "access"},
+	}
+
+	tests := []struct {
+		rules     []string
+		matched   []Table
+		unmatched []string
+	}{
+		{
+			rules:   []string{"db1.*"},
+			matched: []Table{{"db1", "t1"}, {"db1", "t2"}},
+		},
+		{
+			rules:   []string{"*.*", "!db1.t?"},
+			matched: []Table{{"DB2", "orders"}, {"db.3", "t1"}, {"logs_2023", "access"}},
+		},
+		{
+			// the last matching rule decides
+			rules:   []string{"!db1.t2", "db1.*"},
+			matched: []Table{{"db1", "t1"}, {"db1", "t2"}},
+		},
+		{
+			rules:   []string{"db2.orders", "`db.3`.t1", "/^logs_\\d+$/.*"},
+			matched: []Table{{"DB2", "orders"}, {"db.3", "t1"}, {"logs_2023", "access"}},
+		},
+		{
+			rules:     []string{"# comment", "", "db[!1].*", "dbl.*"},
+			matched:   []Table{{"DB2", "orders"}},
+			unmatched: []string{"dbl.*"},
+		},
+	}
+	for _, tt := range tests {
+		f, err := ParseTableFilter(tt.rules)
+		g.Expect(err).NotTo(HaveOccurred())
+		matched, unmatched := f.Resolve(tables)
+		g.Expect(matched).To(Equal(tt.matched), "%v", tt.rules)
+		g.Expect(unmatched).To(Equal(tt.unmatched), "%v", tt.rules)
+	}
+
+	for _, rules := range [][]string{{"db1"}, {"db1.t1.x"}, {"`db1.t1"}, {"db[1.t1"}, {"@filter.txt"}} {
+		_, err := ParseTableFilter(rules)
+		g.Expect(err).To(HaveOccurred(), "%v", rules)
+	}
+}
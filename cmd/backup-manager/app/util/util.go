@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/util"
+	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -273,6 +274,27 @@ func constructBRGlobalOptions(config *v1alpha1.BRConfig) []string {
 	return args
 }
 
+// ConstructBREncryptionOptions constructs BR options to encrypt or decrypt the backup data.
+func ConstructBREncryptionOptions(encryption *v1alpha1.BackupEncryption) []string {
+	if encryption == nil {
+		return nil
+	}
+	method := encryption.Method
+	if method == "" {
+		method = v1alpha1.BackupEncryptionMethodAES256CTR
+	}
+	var args []string
+	if encryption.KeySecretRef != nil {
+		args = append(args, fmt.Sprintf("--crypter.method=%s", method))
+		args = append(args, fmt.Sprintf("--crypter.key-file=%s", path.Join(pkgutil.BackupEncryptionKeyPath, pkgutil.BackupEncryptionKeyFile)))
+	}
+	if encryption.MasterKey != "" {
+		args = append(args, fmt.Sprintf("--master-key-crypter-method=%s", method))
+		args = append(args, fmt.Sprintf("--master-key=%s", encryption.MasterKey))
+	}
+	return args
+}
+
 // Suffix parses the major and minor version from the string and return the suffix
 func Suffix(version string) string {
 	numS := strings.Split(DefaultVersion, ".")
@@ -494,7 +516,7 @@ func RetryOnError(ctx context.Context, attempts int, sleep time.Duration,
 	return err
 }
 
-// ParseRestoreProgress parse restore progress and return restore step and progress
+// ParseRestoreProgress parse the progress log of br and return the step and progress, it works for both backup and restore
 func ParseRestoreProgress(line string) (step, progress string) {
 	matchStr := "\\[progress\\] \\[step=\"(.*?)\"\\] \\[progress=(.*?)\\%\\]"
 	complieRegex := regexp.MustCompile(matchStr)
@@ -506,6 +528,44 @@ func ParseRestoreProgress(line string) (step, progress string) {
 	return
 }
 
+// ParseBRProgressSpeed parses the speed of the step from the progress log of br, e.g. `[speed="7.5 p/s"]`
+func ParseBRProgressSpeed(line string) string {
+	matchs := brProgressSpeedRegex.FindStringSubmatch(line)
+	if len(matchs) < 2 {
+		return ""
+	}
+	return matchs[1]
+}
+
+var brProgressSpeedRegex = regexp.MustCompile(`\[progress\] \[step=".*?"\].*?\[speed="(.*?)"\]`)
+
+// ProgressUpdateInterval is the min interval to update the progress of the same step into the status
+const ProgressUpdateInterval = 10 * time.Second
+
+// ProgressThrottler limits how often the progress parsed from the log of br is updated into the
+// status of Backup and Restore, because br may print the progress every second for large clusters.
+type ProgressThrottler struct {
+	interval   time.Duration
+	lastStep   string
+	lastUpdate time.Time
+}
+
+// NewProgressThrottler returns a throttler which allows to update the progress once per interval
+func NewProgressThrottler(interval time.Duration) *ProgressThrottler {
+	return &ProgressThrottler{interval: interval}
+}
+
+// Allow returns whether the progress can be updated now, the progress of a new step and
+// the completed progress are always allowed so that no step is missed in the status.
+func (t *ProgressThrottler) Allow(step string, progress float64, now time.Time) bool {
+	if step == t.lastStep && progress < 100 && now.Sub(t.lastUpdate) < t.interval {
+		return false
+	}
+	t.lastStep = step
+	t.lastUpdate = now
+	return true
+}
+
 const (
 	e2eBackupEnv                string = "E2E_TEST_ENV"
 	e2eExtendBackupTime         string = "Extend_BACKUP_TIME"
@@ -208,6 +208,46 @@ func TestConstructBRGlobalOptionsForBackup(t *testing.T) {
 	}
 }
 
+func TestConstructBREncryptionOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name       string
+		encryption *v1alpha1.BackupEncryption
+		expect     []string
+	}{
+		{
+			name:       "no encryption",
+			encryption: nil,
+			expect:     nil,
+		},
+		{
+			name: "data key in secret",
+			encryption: &v1alpha1.BackupEncryption{
+				KeySecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "backup-key"},
+					Key:                  "key",
+				},
+			},
+			expect: []string{"--crypter.method=aes256-ctr", "--crypter.key-file=/var/lib/backup-encryption/key"},
+		},
+		{
+			name: "kms master key",
+			encryption: &v1alpha1.BackupEncryption{
+				Method:    v1alpha1.BackupEncryptionMethodAES128CTR,
+				MasterKey: "aws-kms:///key-id?REGION=us-west-2",
+			},
+			expect: []string{"--master-key-crypter-method=aes128-ctr", "--master-key=aws-kms:///key-id?REGION=us-west-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Expect(ConstructBREncryptionOptions(tt.encryption)).To(Equal(tt.expect))
+		})
+	}
+}
+
 func TestGetRemotePath(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -629,6 +669,34 @@ func TestParseRestoreProgress(t *testing.T) {
 	}
 }
 
+func TestParseBRProgressSpeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	line := `[2023/06/01 10:00:00.000 +00:00] [INFO] [progress.go:150] [progress] [step="Full Backup"] [progress=3.07%] [count="17 / 553"] [speed="7.5 p/s"] [elapsed=2s] [remaining=1m10s]`
+	g.Expect(ParseBRProgressSpeed(line)).To(Equal("7.5 p/s"))
+	step, progress := ParseRestoreProgress(line)
+	g.Expect(step).To(Equal("Full Backup"))
+	g.Expect(progress).To(Equal("3.07"))
+
+	g.Expect(ParseBRProgressSpeed(`[progress] [step="Full Backup"] [progress=3.07%]`)).To(BeEmpty())
+	g.Expect(ParseBRProgressSpeed(`[INFO] [speed="7.5 p/s"]`)).To(BeEmpty())
+}
+
+func TestProgressThrottler(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	throttler := NewProgressThrottler(10 * time.Second)
+	g.Expect(throttler.Allow("Full Backup", 1, now)).To(BeTrue())
+	g.Expect(throttler.Allow("Full Backup", 2, now.Add(5*time.Second))).To(BeFalse())
+	g.Expect(throttler.Allow("Full Backup", 3, now.Add(10*time.Second))).To(BeTrue())
+	// the completed progress is always allowed
+	g.Expect(throttler.Allow("Full Backup", 100, now.Add(11*time.Second))).To(BeTrue())
+	// the progress of a new step is always allowed
+	g.Expect(throttler.Allow("Checksum", 1, now.Add(12*time.Second))).To(BeTrue())
+	g.Expect(throttler.Allow("Checksum", 2, now.Add(13*time.Second))).To(BeFalse())
+}
+
 func newBackup() *v1alpha1.Backup {
 	return &v1alpha1.Backup{
 		TypeMeta: metav1.TypeMeta{
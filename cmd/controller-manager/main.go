@@ -30,9 +30,16 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller/autoscaler"
 	"github.com/pingcap/tidb-operator/pkg/controller/backup"
 	"github.com/pingcap/tidb-operator/pkg/controller/backupschedule"
+	"github.com/pingcap/tidb-operator/pkg/controller/diagnostic"
 	"github.com/pingcap/tidb-operator/pkg/controller/dmcluster"
+	"github.com/pingcap/tidb-operator/pkg/controller/dmtask"
+	"github.com/pingcap/tidb-operator/pkg/controller/notification"
+	"github.com/pingcap/tidb-operator/pkg/controller/orphansweeper"
+	"github.com/pingcap/tidb-operator/pkg/controller/pdrecovery"
 	"github.com/pingcap/tidb-operator/pkg/controller/restore"
+	"github.com/pingcap/tidb-operator/pkg/controller/ticdcchangefeed"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbcluster"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbclusterrollout"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbdashboard"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbinitializer"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbmonitor"
@@ -41,6 +48,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
 	"github.com/pingcap/tidb-operator/pkg/upgrader"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 	"github.com/pingcap/tidb-operator/pkg/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,6 +90,28 @@ func main() {
 		klog.Fatalf("failed to get hostname: %v", err)
 	}
 
+	if cliCfg.OrphanSweepPolicy != controller.OrphanSweepPolicyReport && cliCfg.OrphanSweepPolicy != controller.OrphanSweepPolicyDelete {
+		klog.Fatalf("invalid orphan sweep policy %q, must be %s or %s", cliCfg.OrphanSweepPolicy, controller.OrphanSweepPolicyReport, controller.OrphanSweepPolicyDelete)
+	}
+
+	if cliCfg.ClusterScoped && cliCfg.WatchNamespaces != "" {
+		klog.Fatal("watch-namespaces can only be set if cluster-scoped is false")
+	}
+
+	if cliCfg.NodeDrainLeaderEviction && !cliCfg.HasNodePermission() {
+		klog.Fatal("node-drain-leader-eviction requires the permission for nodes, set cluster-scoped or cluster-permission-node")
+	}
+
+	if cliCfg.AirGapped {
+		for _, image := range []string{cliCfg.TiDBDiscoveryImage, cliCfg.TiDBBackupManagerImage} {
+			if !util.IsImagePinned(image) {
+				klog.Fatalf("image %q is not pinned by a tag or digest in the air-gapped mode", image)
+			}
+		}
+	}
+
+	crypto.SetFIPSMode(cliCfg.FIPSMode)
+
 	ns := os.Getenv("NAMESPACE")
 	if ns == "" {
 		klog.Fatal("NAMESPACE environment variable not set")
@@ -125,12 +156,13 @@ func main() {
 	}
 
 	// note that kubeCli here must not be the hijacked one
-	var operatorUpgrader upgrader.Interface
-	if cliCfg.ClusterScoped {
-		operatorUpgrader = upgrader.NewUpgrader(kubeCli, cli, asCli, metav1.NamespaceAll)
-	} else {
-		operatorUpgrader = upgrader.NewUpgrader(kubeCli, cli, asCli, ns)
+	namespaces := cliCfg.WatchedNamespaces(ns)
+	klog.Infof("tidb-operator watches namespaces %v", namespaces)
+	var operatorUpgraders []upgrader.Interface
+	for _, watched := range namespaces {
+		operatorUpgraders = append(operatorUpgraders, upgrader.NewUpgrader(kubeCli, cli, asCli, watched))
 	}
+	preflight := upgrader.NewPreflight(kubeCli, cli, cliCfg, namespaces...)
 
 	if features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
 		// If AdvancedStatefulSet is enabled, we hijack the Kubernetes client to use
@@ -138,7 +170,7 @@ func main() {
 		kubeCli = helper.NewHijackClient(kubeCli, asCli)
 	}
 
-	deps, err := controller.NewDependencies(ns, cliCfg, cli, kubeCli, genericCli)
+	deps, err := controller.NewDependencies(namespaces, cliCfg, cli, kubeCli, genericCli)
 	if err != nil {
 		klog.Fatalf("failed to create Dependencies: %s", err)
 	}
@@ -146,8 +178,15 @@ func main() {
 	onStarted := func(ctx context.Context) {
 		// Upgrade before running any controller logic. If it fails, we wait
 		// for process supervisor to restart it again.
-		if err := operatorUpgrader.Upgrade(); err != nil {
-			klog.Fatalf("failed to upgrade: %v", err)
+		for _, operatorUpgrader := range operatorUpgraders {
+			if err := operatorUpgrader.Upgrade(); err != nil {
+				klog.Fatalf("failed to upgrade: %v", err)
+			}
+		}
+		// Report the clusters affected by the changes of this version and hold them if required,
+		// the reconciliation of the held clusters is skipped by the controllers.
+		if _, err := preflight.Run(); err != nil {
+			klog.Fatalf("failed to run preflight: %v", err)
 		}
 
 		// Define some nested types to simplify the codebase
@@ -176,10 +215,32 @@ func main() {
 			tidbmonitor.NewController(deps),
 			tidbngmonitoring.NewController(deps),
 			tidbdashboard.NewController(deps),
+			notification.NewController(deps),
 		}
 		if features.DefaultFeatureGate.Enabled(features.AutoScaling) {
 			controllers = append(controllers, autoscaler.NewController(deps))
 		}
+		if features.DefaultFeatureGate.Enabled(features.TidbClusterRollout) {
+			controllers = append(controllers, tidbclusterrollout.NewController(deps))
+		}
+		if features.DefaultFeatureGate.Enabled(features.Diagnostic) {
+			controllers = append(controllers, diagnostic.NewController(deps))
+		}
+		if features.DefaultFeatureGate.Enabled(features.TiCDCChangefeed) {
+			controllers = append(controllers, ticdcchangefeed.NewController(deps))
+		}
+		if features.DefaultFeatureGate.Enabled(features.PDRecovery) {
+			controllers = append(controllers, pdrecovery.NewController(deps))
+		}
+		if features.DefaultFeatureGate.Enabled(features.DMTask) {
+			controllers = append(controllers, dmtask.NewController(deps))
+		}
+		if cliCfg.OrphanSweepPeriod > 0 {
+			controllers = append(controllers, orphansweeper.NewController(deps))
+		}
+		if cliCfg.NodeDrainLeaderEviction {
+			controllers = append(controllers, tidbcluster.NewNodeController(deps))
+		}
 
 		// Start informer factories after all controllers are initialized.
 		informerFactories := []InformerFactory{
@@ -187,6 +248,9 @@ func main() {
 			deps.KubeInformerFactory,
 			deps.LabelFilterKubeInformerFactory,
 		}
+		if deps.PriceSheetInformerFactory != nil {
+			informerFactories = append(informerFactories, deps.PriceSheetInformerFactory)
+		}
 		for _, f := range informerFactories {
 			f.Start(ctx.Done())
 			for v, synced := range f.WaitForCacheSync(wait.NeverStop) {
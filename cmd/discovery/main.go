@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/version"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -43,6 +44,7 @@ var (
 	printVersion bool
 	port         int
 	proxyPort    int
+	metricsPort  int
 )
 
 func init() {
@@ -50,6 +52,7 @@ func init() {
 	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
 	flag.IntVar(&port, "port", 10261, "The port that the tidb discovery's http service runs on (default 10261)")
 	flag.IntVar(&proxyPort, "proxy-port", 10262, "The port that the tidb discovery's proxy service runs on (default 10262)")
+	flag.IntVar(&metricsPort, "metrics-port", 10263, "The port that the tidb discovery's metrics service runs on (default 10263)")
 	flag.Parse()
 }
 
@@ -114,10 +117,31 @@ func main() {
 	go wait.Forever(func() {
 		addr := fmt.Sprintf("0.0.0.0:%d", proxyPort)
 		klog.Infof("starting TiDB Proxy server, listening on %s", addr)
-		proxyServer := server.NewProxyServer(tcName, tcTls)
+		var routes []server.ProxyRoute
+		if os.Getenv("PROXY_TICDC_OWNER") == strconv.FormatBool(true) {
+			route, err := server.NewTiCDCOwnerRoute(tcName, tcTls)
+			if err != nil {
+				klog.Errorf("failed to create the proxy route of ticdc owner: %v", err)
+				return
+			}
+			routes = append(routes, route)
+		}
+		if os.Getenv("PROXY_DM_MASTER") == strconv.FormatBool(true) {
+			dmTls := os.Getenv("DM_TLS_ENABLED") == strconv.FormatBool(true)
+			routes = append(routes, server.NewDMMasterRoute(os.Getenv("MY_POD_NAMESPACE"), tcName, dmTls, kubeInformerFactory.Core().V1().Secrets().Lister()))
+		}
+		proxyServer := server.NewProxyServer(tcName, tcTls, routes...)
 		proxyServer.ListenAndServe(addr)
 	}, 5*time.Second)
 
+	go wait.Forever(func() {
+		addr := fmt.Sprintf("0.0.0.0:%d", metricsPort)
+		klog.Infof("starting TiDB Discovery metrics server, listening on %s", addr)
+		serverMux := http.NewServeMux()
+		serverMux.Handle("/metrics", promhttp.Handler())
+		klog.Error(http.ListenAndServe(addr, serverMux))
+	}, 5*time.Second)
+
 	srv := http.Server{Addr: ":6060"}
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc,
@@ -62,6 +62,18 @@ const (
 	// BackupProtectionFinalizer is the name of finalizer on backups
 	BackupProtectionFinalizer string = "tidb.pingcap.com/backup-protection"
 
+	// ChangefeedProtectionFinalizer is the name of finalizer on ticdc changefeeds, the changefeed
+	// is removed from TiCDC before the finalizer is removed
+	ChangefeedProtectionFinalizer string = "tidb.pingcap.com/changefeed-protection"
+
+	// PDRecoveryProtectionFinalizer is the name of finalizer on pd recoveries, the lock of the
+	// tidb cluster is released before the finalizer is removed
+	PDRecoveryProtectionFinalizer string = "tidb.pingcap.com/pd-recovery-protection"
+
+	// DMTaskProtectionFinalizer is the name of finalizer on dm tasks, the task and the sources which
+	// are not used by other tasks are deleted from dm-master before the finalizer is removed
+	DMTaskProtectionFinalizer string = "tidb.pingcap.com/dm-task-protection"
+
 	// AutoScalingGroupLabelKey describes the autoscaling group of the TiDB
 	AutoScalingGroupLabelKey = "tidb.pingcap.com/autoscaling-group"
 	// AutoInstanceLabelKey is label key used in autoscaling, it represents the autoscaler name
@@ -70,6 +82,13 @@ const (
 	AutoComponentLabelKey string = "tidb.pingcap.com/auto-component"
 	// BaseTCLabelKey is label key used for heterogeneous clusters to refer to its base TidbCluster
 	BaseTCLabelKey string = "tidb.pingcap.com/base-tc"
+	// TiKVGroupLabelKey is label key used for the heterogeneous clusters of TiKV groups, it represents the group name
+	TiKVGroupLabelKey string = "tidb.pingcap.com/tikv-group"
+	// ZoneLabelKey is label key of the TiDB pods and the per-zone TiDB services, it represents the zone of the node
+	ZoneLabelKey string = "tidb.pingcap.com/zone"
+	// SecretReplicaOfLabelKey is label key of the Secrets replicated for heterogeneous clusters, it represents
+	// the namespace of the source Secret
+	SecretReplicaOfLabelKey string = "tidb.pingcap.com/secret-replica-of"
 
 	// AnnHATopologyKey defines the High availability topology key
 	AnnHATopologyKey = "pingcap.com/ha-topology-key"
@@ -87,6 +106,17 @@ const (
 	AnnTiDBPartition string = "tidb.pingcap.com/tidb-partition"
 	// AnnTiKVPartition is pod annotation which TiKV pod should upgrade to
 	AnnTiKVPartition string = "tidb.pingcap.com/tikv-partition"
+	// AnnTiDBCanaryPromoted is tc annotation key to promote the canary of the TiDB rolling update,
+	// the value should be the update revision of the TiDB StatefulSet
+	AnnTiDBCanaryPromoted = "tidb.pingcap.com/tidb-canary-promoted"
+	// AnnTiKVCanaryPromoted is tc annotation key to promote the canary of the TiKV rolling update,
+	// the value should be the update revision of the TiKV StatefulSet
+	AnnTiKVCanaryPromoted = "tidb.pingcap.com/tikv-canary-promoted"
+	// AnnTiKVIOTuningApplied is pod annotation key to record the IO tuning applied to the TiKV pod online
+	AnnTiKVIOTuningApplied = "tidb.pingcap.com/tikv-io-tuning-applied"
+	// AnnTiKVForceScaleInKey is TiKV pod annotation key set to "true" by users, it confirms the pod is scaled in
+	// even if its store is not drained in spec.tikv.drainTimeout
+	AnnTiKVForceScaleInKey = "tidb.pingcap.com/tikv-force-scale-in"
 	// AnnForceUpgradeKey is tc annotation key to indicate whether force upgrade should be done
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
@@ -107,6 +137,8 @@ const (
 	AnnForceUpgradeVal = "true"
 	// AnnSysctlInitVal is pod annotation value to indicate whether configuring sysctls with init container
 	AnnSysctlInitVal = "true"
+	// AnnBootstrapWipeStaleVolumesVal is tc annotation value to delete the PVCs containing the data of another cluster
+	AnnBootstrapWipeStaleVolumesVal = "true"
 
 	// AnnPDDeleteSlots is annotation key of pd delete slots.
 	AnnPDDeleteSlots = "pd.tidb.pingcap.com/delete-slots"
@@ -137,14 +169,67 @@ const (
 	// when TiDB cluster is restored from volume snapshot based backup.
 	AnnTiKVVolumesReadyKey = "tidb.pingcap.com/tikv-volumes-ready"
 
+	// AnnBootstrapWipeStaleVolumesKey is tc annotation key to delete the PVCs containing the data of
+	// another cluster, which block the bootstrap of PD.
+	AnnBootstrapWipeStaleVolumesKey = "tidb.pingcap.com/bootstrap-wipe-stale-volumes"
+	// AnnBootstrapAdoptClusterIDKey is tc annotation key whose value is the cluster ID of the existing volumes,
+	// the bootstrap of PD proceeds with the volumes of the adopted cluster.
+	AnnBootstrapAdoptClusterIDKey = "tidb.pingcap.com/bootstrap-adopt-cluster-id"
+	// AnnPDRecoverFromKey is tc annotation key whose value is the name of the surviving PD pod, PD is rebuilt
+	// from the data of the survivor when the majority of PD members are lost.
+	AnnPDRecoverFromKey = "tidb.pingcap.com/pd-recover-from"
+	// AnnPDRecoverFailedStoresKey is tc annotation key whose value is the comma separated IDs of the lost TiKV
+	// stores, which are removed by the unsafe recovery after PD is rebuilt.
+	AnnPDRecoverFailedStoresKey = "tidb.pingcap.com/pd-recover-failed-stores"
+	// AnnPDRecoverConfirmKey is tc annotation key whose value must be the name of the surviving PD pod, it
+	// confirms the other PD members are wiped after the majority loss is found.
+	AnnPDRecoverConfirmKey = "tidb.pingcap.com/pd-recover-confirm"
+	// AnnPDRecoveryLockKey is tc annotation key whose value is the name of the PDRecovery which rebuilds PD
+	// from the surviving TiKV stores, the sync of the tidb cluster is blocked until the annotation is removed.
+	AnnPDRecoveryLockKey = "tidb.pingcap.com/pd-recovery-lock"
+	// AnnServiceTrafficPolicyKey is service annotation key whose value is the JSON of the traffic fields of the
+	// service spec which are not known by the client of the operator, they are applied by a merge patch.
+	AnnServiceTrafficPolicyKey = "tidb.pingcap.com/service-traffic-policy"
+	// AnnPreflightHoldKey is tc annotation key whose value is the operator version, it is set by the preflight on
+	// startup to hold the reconciliation of the cluster affected by the changes of the version.
+	AnnPreflightHoldKey = "tidb.pingcap.com/preflight-hold"
+	// AnnPreflightAckKey is tc annotation key whose value is the operator version whose changes are acknowledged
+	// by the user, the reconciliation held by the preflight of the version is resumed.
+	AnnPreflightAckKey = "tidb.pingcap.com/preflight-ack"
+	// AnnTLSCertHashKey is pod annotation key whose value is the hash of the renewed cluster TLS cert,
+	// it is changed to roll the pods to apply the renewed cert.
+	AnnTLSCertHashKey = "tidb.pingcap.com/tls-cert-hash"
+	// AnnNativeSidecarContainersKey is pod template annotation key whose value is the comma separated names of
+	// the containers which run as native sidecars, they are moved to the init containers with restartPolicy
+	// Always by a patch, as the field is not known by the client of the operator.
+	AnnNativeSidecarContainersKey = "tidb.pingcap.com/native-sidecar-containers"
+	// AnnSecretReplicaHashKey is secret annotation key whose value is the hash of the data of the source Secret,
+	// the replica is updated when the hash of the source changes.
+	AnnSecretReplicaHashKey = "tidb.pingcap.com/secret-replica-hash"
+	// AnnSecretReplicationAllowedNamespacesKey is tc annotation key whose value is the comma separated namespaces,
+	// the heterogeneous clusters in them are allowed to replicate the TLS secrets of the TidbCluster.
+	AnnSecretReplicationAllowedNamespacesKey = "tidb.pingcap.com/secret-replication-allowed-namespaces"
+	// AnnDiagnosticCreatorKey is diagnostic annotation key whose value is the name of the user who creates the
+	// Diagnostic, it's recorded by the admission webhook and any value set by the user is overwritten.
+	AnnDiagnosticCreatorKey = "tidb.pingcap.com/diagnostic-creator"
+	// AnnDiagnosticCreatorGroupsKey is diagnostic annotation key whose value is the comma separated groups of
+	// the user who creates the Diagnostic, it's recorded along with AnnDiagnosticCreatorKey.
+	AnnDiagnosticCreatorGroupsKey = "tidb.pingcap.com/diagnostic-creator-groups"
+
 	// PDLabelVal is PD label value
 	PDLabelVal string = "pd"
+	// TSOLabelVal is the label value of the TSO microservice of PD
+	TSOLabelVal string = "tso"
+	// SchedulingLabelVal is the label value of the scheduling microservice of PD
+	SchedulingLabelVal string = "scheduling"
 	// TiDBLabelVal is TiDB label value
 	TiDBLabelVal string = "tidb"
 	// TiKVLabelVal is TiKV label value
 	TiKVLabelVal string = "tikv"
 	// TiFlashLabelVal is TiFlash label value
 	TiFlashLabelVal string = "tiflash"
+	// TiFlashComputeLabelVal is the label value of the TiFlash compute nodes in the disaggregated mode
+	TiFlashComputeLabelVal string = "tiflash-compute"
 	// TiCDCLabelVal is TiCDC label value
 	TiCDCLabelVal string = "ticdc"
 	// TiProxyLabelVal is TiProxy label value
@@ -166,6 +251,10 @@ const (
 	BackupScheduleJobLabelVal string = "backup-schedule"
 	// InitJobLabelVal is TiDB initializer job label value
 	InitJobLabelVal string = "initializer"
+	// DiagnosticJobLabelVal is diagnostic collector job label value
+	DiagnosticJobLabelVal string = "diagnostic"
+	// PDRecoverJobLabelVal is pd-recover job label value
+	PDRecoverJobLabelVal string = "pd-recover"
 	// TiDBOperator is ManagedByLabelKey label value
 	TiDBOperator string = "tidb-operator"
 
@@ -247,6 +336,24 @@ func NewBackupSchedule() Label {
 	}
 }
 
+// NewDiagnostic initialize a new Label for Jobs of diagnostic
+func NewDiagnostic() Label {
+	return Label{
+		NameLabelKey:      "tidb-cluster",
+		ComponentLabelKey: DiagnosticJobLabelVal,
+		ManagedByLabelKey: TiDBOperator,
+	}
+}
+
+// NewPDRecovery initialize a new Label for Jobs of pd recovery
+func NewPDRecovery() Label {
+	return Label{
+		NameLabelKey:      "tidb-cluster",
+		ComponentLabelKey: PDRecoverJobLabelVal,
+		ManagedByLabelKey: TiDBOperator,
+	}
+}
+
 func NewMonitor() Label {
 	return Label{
 		// NameLabelKey is used to be compatible with helm monitor
@@ -372,6 +479,11 @@ func (l Label) IsPD() bool {
 	return l[ComponentLabelKey] == PDLabelVal
 }
 
+// PDMS assigns the name of the PD microservice to component key in label
+func (l Label) PDMS(name string) Label {
+	return l.Component(name)
+}
+
 // TiProxy assigns tiproxy to component key in label
 func (l Label) TiProxy() Label {
 	return l.Component(TiProxyLabelVal)
@@ -482,6 +594,11 @@ func (l Label) IsTiFlash() bool {
 	return l[ComponentLabelKey] == TiFlashLabelVal
 }
 
+// TiFlashCompute assigns tiflash-compute to component key in label
+func (l Label) TiFlashCompute() Label {
+	return l.Component(TiFlashComputeLabelVal)
+}
+
 // TiCDC assigns ticdc to component key in label
 func (l Label) TiCDC() Label {
 	return l.Component(TiCDCLabelVal)
@@ -50,6 +50,16 @@ func (bk *Backup) GetBackupJobName() string {
 	return fmt.Sprintf("backup-%s", bk.GetName())
 }
 
+// GetVerifyJobName return the name of the job verifying the backup
+func (bk *Backup) GetVerifyJobName() string {
+	return fmt.Sprintf("backup-%s-verify", bk.GetName())
+}
+
+// NeedVerification returns whether the backup needs to be verified by the verification job after it completes
+func (bk *Backup) NeedVerification() bool {
+	return bk.Spec.Verify != nil && bk.Spec.BR != nil && bk.Spec.Mode != BackupModeLog && bk.Spec.Mode != BackupModeVolumeSnapshot
+}
+
 // GetAllLogBackupJobName return the all log backup job name
 func (bk *Backup) GetAllLogBackupJobName() []string {
 	return []string{
@@ -118,7 +128,20 @@ func GetBackupCondition(status *BackupStatus, conditionType BackupConditionType)
 // UpdateBackupCondition updates existing Backup condition or creates a new
 // one. Sets LastTransitionTime to now if the status has changed.
 // Returns true if Backup condition has changed or has been added.
+// The standard Ready, Progressing and Degraded conditions are refreshed from the phase afterwards.
+// The Verified condition is written without changing the phase.
 func UpdateBackupCondition(status *BackupStatus, condition *BackupCondition) bool {
+	if condition != nil && condition.Type == BackupVerified {
+		return updateBackupVerifiedCondition(status, condition)
+	}
+	changed := updateBackupCondition(status, condition)
+	if changed {
+		updateBackupStandardConditions(status)
+	}
+	return changed
+}
+
+func updateBackupCondition(status *BackupStatus, condition *BackupCondition) bool {
 	if condition == nil {
 		return false
 	}
@@ -160,6 +183,63 @@ func UpdateBackupCondition(status *BackupStatus, condition *BackupCondition) boo
 	return !isUpdate
 }
 
+func updateBackupVerifiedCondition(status *BackupStatus, condition *BackupCondition) bool {
+	condition.LastTransitionTime = metav1.Now()
+	index, old := GetBackupCondition(status, BackupVerified)
+	if old == nil {
+		status.Conditions = append(status.Conditions, *condition)
+		return true
+	}
+	if old.Status == condition.Status {
+		condition.LastTransitionTime = old.LastTransitionTime
+		if old.Reason == condition.Reason && old.Message == condition.Message {
+			return false
+		}
+	}
+	status.Conditions[index] = *condition
+	return true
+}
+
+// updateBackupStandardConditions derives the standard Ready, Progressing and Degraded
+// conditions from the phase, so that generic tools like kstatus can tell the state of a Backup.
+// These conditions are written directly and never change the phase.
+func updateBackupStandardConditions(status *BackupStatus) {
+	var ready, progressing, degraded bool
+	switch status.Phase {
+	case BackupComplete, BackupStopped:
+		ready = true
+	case BackupScheduled, BackupPrepare, BackupRunning, BackupRetryTheFailed:
+		progressing = true
+	case BackupFailed, BackupInvalid:
+		degraded = true
+	}
+	reason := string(status.Phase)
+	setBackupStandardCondition(status, BackupReady, ready, reason)
+	setBackupStandardCondition(status, BackupProgressing, progressing, reason)
+	setBackupStandardCondition(status, BackupDegraded, degraded, reason)
+}
+
+func setBackupStandardCondition(status *BackupStatus, conditionType BackupConditionType, value bool, reason string) {
+	condition := BackupCondition{
+		Type:               conditionType,
+		Status:             corev1.ConditionFalse,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	}
+	if value {
+		condition.Status = corev1.ConditionTrue
+	}
+	index, old := GetBackupCondition(status, conditionType)
+	if old == nil {
+		status.Conditions = append(status.Conditions, condition)
+		return
+	}
+	if old.Status == condition.Status {
+		condition.LastTransitionTime = old.LastTransitionTime
+	}
+	status.Conditions[index] = condition
+}
+
 // IsBackupComplete returns true if a Backup has successfully completed
 func IsBackupComplete(backup *Backup) bool {
 	if backup.Spec.Mode == BackupModeLog {
@@ -169,6 +249,12 @@ func IsBackupComplete(backup *Backup) bool {
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
 
+// IsBackupVerificationDone returns true if the verification of a Backup has passed or failed
+func IsBackupVerificationDone(backup *Backup) bool {
+	_, condition := GetBackupCondition(&backup.Status, BackupVerified)
+	return condition != nil && condition.Status != corev1.ConditionUnknown
+}
+
 // IsBackupInvalid returns true if a Backup has invalid condition set
 func IsBackupInvalid(backup *Backup) bool {
 	if backup.Spec.Mode == BackupModeLog {
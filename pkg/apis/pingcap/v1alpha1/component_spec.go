@@ -69,6 +69,7 @@ type ComponentAccessor interface {
 	Env() []corev1.EnvVar
 	EnvFrom() []corev1.EnvFromSource
 	AdditionalContainers() []corev1.Container
+	NativeSidecarContainers() []string
 	InitContainers() []corev1.Container
 	AdditionalVolumes() []corev1.Volume
 	AdditionalVolumeMounts() []corev1.VolumeMount
@@ -85,6 +86,9 @@ func (tc *TidbCluster) AllComponentSpec() []ComponentAccessor {
 	if tc.Spec.PD != nil {
 		components = append(components, tc.BasePDSpec())
 	}
+	for _, spec := range tc.Spec.PDMS {
+		components = append(components, tc.BasePDMSSpec(spec))
+	}
 	if tc.Spec.TiDB != nil {
 		components = append(components, tc.BaseTiDBSpec())
 	}
@@ -152,6 +156,21 @@ func (ngm *TidbNGMonitoring) ComponentSpec(typ MemberType) ComponentAccessor {
 	return nil
 }
 
+// defaultTerminationGracePeriodSeconds is the default termination grace period of the components by their roles,
+// the storage components need more time to flush data and transfer leaders while the stateless ones exit quickly.
+var defaultTerminationGracePeriodSeconds = map[MemberType]int64{
+	PDMemberType:             60,
+	PDMSTSOMemberType:        30,
+	PDMSSchedulingMemberType: 30,
+	TiKVMemberType:           300,
+	TiFlashMemberType:        300,
+	TiDBMemberType:           60,
+	TiCDCMemberType:          60,
+	TiProxyMemberType:        60,
+	PumpMemberType:           120,
+	DiscoveryMemberType:      5,
+}
+
 type componentAccessorImpl struct {
 	component MemberType
 	name      string
@@ -365,6 +384,8 @@ func (a *componentAccessorImpl) BuildPodSpec() corev1.PodSpec {
 	}
 	if a.TerminationGracePeriodSeconds() != nil {
 		spec.TerminationGracePeriodSeconds = a.TerminationGracePeriodSeconds()
+	} else if seconds, ok := defaultTerminationGracePeriodSeconds[a.component]; ok {
+		spec.TerminationGracePeriodSeconds = &seconds
 	}
 	return spec
 }
@@ -397,6 +418,13 @@ func (a *componentAccessorImpl) AdditionalContainers() []corev1.Container {
 	return a.ComponentSpec.AdditionalContainers
 }
 
+func (a *componentAccessorImpl) NativeSidecarContainers() []string {
+	if a.ComponentSpec == nil {
+		return nil
+	}
+	return a.ComponentSpec.NativeSidecarContainers
+}
+
 func (a *componentAccessorImpl) AdditionalVolumes() []corev1.Volume {
 	if a.ComponentSpec == nil {
 		return nil
@@ -674,6 +702,11 @@ func (tc *TidbCluster) BasePDSpec() ComponentAccessor {
 	return buildTidbClusterComponentAccessor(PDMemberType, tc, spec)
 }
 
+// BasePDMSSpec returns the base spec of a PD microservice
+func (tc *TidbCluster) BasePDMSSpec(spec *PDMSSpec) ComponentAccessor {
+	return buildTidbClusterComponentAccessor(MemberType(spec.Name), tc, &spec.ComponentSpec)
+}
+
 // BasePumpSpec returns the base spec of Pump:
 func (tc *TidbCluster) BasePumpSpec() ComponentAccessor {
 	var spec *ComponentSpec
@@ -61,6 +61,30 @@ const (
 	TiDBDashboardKind    = "TidbDashboard"
 	TiDBDashboardKindKey = "tidbdashboard"
 
+	TidbClusterRolloutName    = "tidbclusterrollouts"
+	TidbClusterRolloutKind    = "TidbClusterRollout"
+	TidbClusterRolloutKindKey = "tidbclusterrollout"
+
+	DiagnosticName    = "diagnostics"
+	DiagnosticKind    = "Diagnostic"
+	DiagnosticKindKey = "diagnostic"
+
+	TiCDCChangefeedName    = "ticdcchangefeeds"
+	TiCDCChangefeedKind    = "TiCDCChangefeed"
+	TiCDCChangefeedKindKey = "ticdcchangefeed"
+
+	PDRecoveryName    = "pdrecoveries"
+	PDRecoveryKind    = "PDRecovery"
+	PDRecoveryKindKey = "pdrecovery"
+
+	DMTaskName    = "dmtasks"
+	DMTaskKind    = "DMTask"
+	DMTaskKindKey = "dmtask"
+
+	TidbClusterBackupPolicyName    = "tidbclusterbackuppolicies"
+	TidbClusterBackupPolicyKind    = "TidbClusterBackupPolicy"
+	TidbClusterBackupPolicyKindKey = "tidbclusterbackuppolicy"
+
 	SpecPath = "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1."
 )
 
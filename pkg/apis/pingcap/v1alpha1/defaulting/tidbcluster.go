@@ -38,6 +38,9 @@ func SetTidbClusterDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.PD != nil {
 		setPdSpecDefault(tc)
 	}
+	for _, spec := range tc.Spec.PDMS {
+		setPDMSSpecDefault(tc, spec)
+	}
 	if tc.Spec.TiKV != nil {
 		setTikvSpecDefault(tc)
 	}
@@ -125,6 +128,14 @@ func setPdSpecDefault(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+func setPDMSSpecDefault(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) {
+	if len(tc.Spec.Version) > 0 || spec.Version != nil {
+		if spec.BaseImage == "" {
+			spec.BaseImage = defaultPDImage
+		}
+	}
+}
+
 func setPumpSpecDefault(tc *v1alpha1.TidbCluster) {
 	if len(tc.Spec.Version) > 0 || tc.Spec.Pump.Version != nil {
 		if tc.Spec.Pump.BaseImage == "" {
@@ -0,0 +1,101 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultDiagnosticProfileDuration = 30 * time.Second
+	defaultDiagnosticLogTailLines    = 10000
+)
+
+var (
+	defaultDiagnosticComponents = []MemberType{PDMemberType, TiKVMemberType}
+	defaultDiagnosticItems      = []DiagnosticItem{
+		DiagnosticItemProfiles,
+		DiagnosticItemMetrics,
+		DiagnosticItemLogs,
+		DiagnosticItemStores,
+		DiagnosticItemRegions,
+	}
+	defaultDiagnosticProfiles = []string{"profile", "heap", "goroutine"}
+)
+
+// GetDiagnosticJobName returns the name of the collector job of the Diagnostic
+func (d *Diagnostic) GetDiagnosticJobName() string {
+	return fmt.Sprintf("diagnostic-%s", d.GetName())
+}
+
+// GetComponents returns the components to collect from
+func (d *Diagnostic) GetComponents() []MemberType {
+	if len(d.Spec.Components) == 0 {
+		return defaultDiagnosticComponents
+	}
+	return d.Spec.Components
+}
+
+// GetItems returns the kinds of data to collect
+func (d *Diagnostic) GetItems() []DiagnosticItem {
+	if len(d.Spec.Items) == 0 {
+		return defaultDiagnosticItems
+	}
+	return d.Spec.Items
+}
+
+// ShouldCollect returns whether the item should be collected
+func (d *Diagnostic) ShouldCollect(item DiagnosticItem) bool {
+	for _, i := range d.GetItems() {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProfiles returns the names of the pprof profiles to collect
+func (d *Diagnostic) GetProfiles() []string {
+	if len(d.Spec.Profiles) == 0 {
+		return defaultDiagnosticProfiles
+	}
+	return d.Spec.Profiles
+}
+
+// GetProfileDuration returns the duration of the CPU profile
+func (d *Diagnostic) GetProfileDuration() time.Duration {
+	if d.Spec.ProfileDuration == nil {
+		return defaultDiagnosticProfileDuration
+	}
+	return d.Spec.ProfileDuration.Duration
+}
+
+// GetLogTailLines returns the number of the recent log lines collected from each pod
+func (d *Diagnostic) GetLogTailLines() int64 {
+	if d.Spec.LogTailLines == nil {
+		return defaultDiagnosticLogTailLines
+	}
+	return *d.Spec.LogTailLines
+}
+
+// RedactionDisabled returns whether the redaction is requested to be disabled
+func (d *Diagnostic) RedactionDisabled() bool {
+	return d.Spec.Redaction != nil && d.Spec.Redaction.Disabled
+}
+
+// IsDiagnosticFinished returns whether the Diagnostic is completed or failed
+func IsDiagnosticFinished(d *Diagnostic) bool {
+	return d.Status.Phase == DiagnosticPhaseCompleted || d.Status.Phase == DiagnosticPhaseFailed
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiagnosticPhase is the phase of a Diagnostic
+type DiagnosticPhase string
+
+const (
+	// DiagnosticPhaseRunning means the collector job is created and collecting the bundle
+	DiagnosticPhaseRunning DiagnosticPhase = "Running"
+	// DiagnosticPhaseCompleted means the bundle is collected and uploaded
+	DiagnosticPhaseCompleted DiagnosticPhase = "Completed"
+	// DiagnosticPhaseFailed means the Diagnostic is invalid or the collector job failed
+	DiagnosticPhaseFailed DiagnosticPhase = "Failed"
+)
+
+// DiagnosticItem is a kind of data collected into the diagnostic bundle
+type DiagnosticItem string
+
+const (
+	// DiagnosticItemProfiles collects the pprof profiles of each pod
+	DiagnosticItemProfiles DiagnosticItem = "profiles"
+	// DiagnosticItemMetrics collects a snapshot of the metrics of each pod
+	DiagnosticItemMetrics DiagnosticItem = "metrics"
+	// DiagnosticItemLogs collects the recent logs of each pod
+	DiagnosticItemLogs DiagnosticItem = "logs"
+	// DiagnosticItemStores dumps the stores from PD
+	DiagnosticItemStores DiagnosticItem = "stores"
+	// DiagnosticItemRegions dumps the regions from PD
+	DiagnosticItemRegions DiagnosticItem = "regions"
+)
+
+// Diagnostic collects a diagnostic bundle of a TidbCluster for support engagements, including
+// pprof profiles, metrics snapshots, recent logs and store/region dumps of PD and TiKV.
+// The bundle is collected by a Job and uploaded to the object storage or written to a volume.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="diag"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The cluster to collect from"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The current phase of the diagnostic"
+// +kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.status.path`,description="The location of the bundle",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type Diagnostic struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the diagnostic.
+	Spec DiagnosticSpec `json:"spec"`
+
+	// Status is most recently observed status of the diagnostic.
+	//
+	// +k8s:openapi-gen=false
+	Status DiagnosticStatus `json:"status,omitempty"`
+}
+
+// DiagnosticList is a Diagnostic list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DiagnosticList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Diagnostic `json:"items"`
+}
+
+// DiagnosticSpec is spec of Diagnostic.
+//
+// +k8s:openapi-gen=true
+type DiagnosticSpec struct {
+	// Cluster is the name of the TidbCluster to collect from, it must be in the same namespace as the Diagnostic.
+	Cluster string `json:"cluster"`
+
+	// Components are the components to collect from, only pd and tikv are supported.
+	// Optional: Defaults to [pd, tikv]
+	// +optional
+	Components []MemberType `json:"components,omitempty"`
+
+	// Items are the kinds of data to collect.
+	// Optional: Defaults to all of profiles, metrics, logs, stores and regions
+	// +optional
+	Items []DiagnosticItem `json:"items,omitempty"`
+
+	// Profiles are the names of the pprof profiles to collect, e.g. profile, heap, goroutine, mutex.
+	// `profile` is the CPU profile which lasts for ProfileDuration.
+	// Optional: Defaults to [profile, heap, goroutine]
+	// +optional
+	Profiles []string `json:"profiles,omitempty"`
+
+	// ProfileDuration is the duration of the CPU profile.
+	// Optional: Defaults to 30s
+	// +optional
+	ProfileDuration *metav1.Duration `json:"profileDuration,omitempty"`
+
+	// LogTailLines is the number of the recent log lines collected from each pod.
+	// Optional: Defaults to 10000
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+
+	// Redaction configures how the sensitive data in the bundle is redacted.
+	// The keys in the logs and the region dumps are always redacted unless redaction is disabled.
+	// +optional
+	Redaction *DiagnosticRedaction `json:"redaction,omitempty"`
+
+	// StorageProvider configures where the bundle is stored, use `local` with a PVC volume to
+	// write the bundle to a PVC.
+	StorageProvider `json:",inline"`
+
+	// Specify service account of the collector job.
+	// The service account should be allowed to get and update diagnostics, get tidbclusters, list pods,
+	// get pods/log and create localsubjectaccessreviews in the namespace.
+	// Optional: Defaults to tidb-backup-manager
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// Resources of the collector job
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// List of environment variables to set in the collector container, e.g. the credentials of the storage.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Tolerations of the collector job
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the collector job
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling the collector image.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PodSecurityContext of the collector job
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// PriorityClassName of the collector job
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// DiagnosticRedaction configures how the sensitive data in the diagnostic bundle is redacted.
+//
+// +k8s:openapi-gen=true
+type DiagnosticRedaction struct {
+	// Disabled collects the raw data without redaction.
+	// It takes effect only if the user who creates the Diagnostic is allowed to `unredact`
+	// diagnostics in the namespace by RBAC, otherwise the data is still redacted. The creator
+	// is recorded by the admission webhook of tidb-operator, so the data is always redacted
+	// if the webhook is not deployed.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Patterns are the additional regular expressions to redact from the logs and dumps,
+	// the matches are replaced by `?`.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// DiagnosticStatus is status of Diagnostic.
+type DiagnosticStatus struct {
+	// Phase is the phase of the diagnostic
+	Phase DiagnosticPhase `json:"phase,omitempty"`
+
+	// TimeStarted is the time at which the collector job was created.
+	// +nullable
+	TimeStarted metav1.Time `json:"timeStarted,omitempty"`
+
+	// TimeCompleted is the time at which the diagnostic was completed or failed.
+	// +nullable
+	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+
+	// Path is the location of the bundle.
+	Path string `json:"path,omitempty"`
+
+	// Redacted indicates whether the sensitive data in the bundle is redacted.
+	Redacted bool `json:"redacted,omitempty"`
+
+	// Errors are the errors of the data failed to be collected, the bundle still contains the other data.
+	Errors []string `json:"errors,omitempty"`
+
+	// Message is the reason of the failure
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// GetTaskName returns the name of the task in dm-master
+func (t *DMTask) GetTaskName() string {
+	if t.Spec.TaskName != "" {
+		return t.Spec.TaskName
+	}
+	return t.GetName()
+}
+
+// GetTaskMode returns the mode of the task
+func (t *DMTask) GetTaskMode() DMTaskMode {
+	if t.Spec.TaskMode != "" {
+		return t.Spec.TaskMode
+	}
+	return DMTaskModeAll
+}
+
+// GetSourceNames returns the names of the sources of the task
+func (t *DMTask) GetSourceNames() []string {
+	names := make([]string, 0, len(t.Spec.Sources))
+	for _, s := range t.Spec.Sources {
+		names = append(names, s.Name)
+	}
+	return names
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DMTaskMode is the mode of a DM task
+type DMTaskMode string
+
+const (
+	// DMTaskModeAll migrates the full data and then the incremental data
+	DMTaskModeAll DMTaskMode = "all"
+	// DMTaskModeFull only migrates the full data
+	DMTaskModeFull DMTaskMode = "full"
+	// DMTaskModeIncremental only migrates the incremental data from the binlog position
+	DMTaskModeIncremental DMTaskMode = "incremental"
+)
+
+// DMShardMode is the mode to coordinate the DDLs of the sharded tables merged by a DM task
+type DMShardMode string
+
+const (
+	// DMShardModePessimistic blocks the DMLs until the DDL is received from all the shards
+	DMShardModePessimistic DMShardMode = "pessimistic"
+	// DMShardModeOptimistic executes the compatible DDLs without blocking the other shards
+	DMShardModeOptimistic DMShardMode = "optimistic"
+)
+
+// DMTaskStage is the stage of a DM subtask reported by dm-master
+type DMTaskStage string
+
+const (
+	// DMTaskStageRunning means the subtask is migrating
+	DMTaskStageRunning DMTaskStage = "Running"
+	// DMTaskStagePaused means the subtask is paused by an error
+	DMTaskStagePaused DMTaskStage = "Paused"
+	// DMTaskStageStopped means the subtask is stopped by the user
+	DMTaskStageStopped DMTaskStage = "Stopped"
+	// DMTaskStageFinished means the full data of the subtask is migrated in the full mode
+	DMTaskStageFinished DMTaskStage = "Finished"
+)
+
+// DMTask declares a data migration task of a DMCluster, the upstream sources and the task are created,
+// updated, stopped, started and deleted by the OpenAPI of dm-master accordingly, which must be enabled by
+// `openapi = true` in the config of dm-master.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="dmt"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The DM cluster which runs the task"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.taskMode`,description="The mode of the task"
+// +kubebuilder:printcolumn:name="Stage",type=string,JSONPath=`.status.stage`,description="The stage of the task"
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,description="The last error",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type DMTask struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the task.
+	Spec DMTaskSpec `json:"spec"`
+
+	// Status is most recently observed status of the task.
+	//
+	// +k8s:openapi-gen=false
+	Status DMTaskStatus `json:"status,omitempty"`
+}
+
+// DMTaskList is a DMTask list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DMTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DMTask `json:"items"`
+}
+
+// DMTaskSpec is spec of DMTask.
+//
+// +k8s:openapi-gen=true
+type DMTaskSpec struct {
+	// Cluster is the name of the DMCluster which runs the task, it must be in the same namespace as the DMTask.
+	Cluster string `json:"cluster"`
+
+	// TaskName is the name of the task in dm-master, it can not be changed.
+	// Optional: Defaults to the name of the DMTask
+	// +optional
+	TaskName string `json:"taskName,omitempty"`
+
+	// TaskMode is the mode of the task, `all`, `full` or `incremental`.
+	// Optional: Defaults to `all`
+	// +optional
+	// +kubebuilder:validation:Enum=all;full;incremental
+	TaskMode DMTaskMode `json:"taskMode,omitempty"`
+
+	// ShardMode is the mode to coordinate the DDLs of the sharded tables merged into the same table,
+	// `pessimistic` or `optimistic`.
+	// Optional: Defaults to no sharding
+	// +optional
+	ShardMode DMShardMode `json:"shardMode,omitempty"`
+
+	// Sources are the upstream MySQL sources of the task, the sources which do not exist are created.
+	Sources []DMTaskSource `json:"sources"`
+
+	// Target is the downstream TiDB of the task.
+	Target TiDBAccessConfig `json:"target"`
+
+	// Config is the rest of the task config in the format of the OpenAPI of dm-master, e.g. `table_migrate_rule`,
+	// `binlog_filter_rule` and `source_config.full_migrate_conf`. The name, the modes, the target config and the
+	// source names are overwritten by the other fields of the spec.
+	// Optional: Defaults to migrate all the tables of the sources
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+
+	// Paused stops the task, it is started again after Paused is unset.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// DMTaskSource is an upstream MySQL source of DMTask.
+//
+// +k8s:openapi-gen=true
+type DMTaskSource struct {
+	// Name is the name of the source in dm-master.
+	Name string `json:"name"`
+
+	// Host is the address of the MySQL.
+	Host string `json:"host"`
+
+	// Port is the port of the MySQL.
+	// Optional: Defaults to 3306
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// User is the user to connect the MySQL.
+	User string `json:"user"`
+
+	// SecretName is the name of the Secret which stores the password of the user in the key `password`.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// EnableGTID replicates the binlog by GTID instead of the binlog position.
+	// +optional
+	EnableGTID bool `json:"enableGTID,omitempty"`
+
+	// BinlogName is the binlog file where the incremental migration starts.
+	// +optional
+	BinlogName string `json:"binlogName,omitempty"`
+
+	// BinlogPos is the binlog position where the incremental migration starts.
+	// +optional
+	BinlogPos *int64 `json:"binlogPos,omitempty"`
+
+	// BinlogGTID is the GTID set where the incremental migration starts.
+	// +optional
+	BinlogGTID string `json:"binlogGTID,omitempty"`
+
+	// Config is the rest of the source config in the format of the OpenAPI of dm-master, e.g. `relay_config`
+	// and `purge`.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+}
+
+// DMTaskStatus is status of DMTask.
+type DMTaskStatus struct {
+	// ObservedGeneration is the generation of the spec which is applied to the task.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TaskName is the name of the task in dm-master.
+	TaskName string `json:"taskName,omitempty"`
+
+	// Stage is the stage of the task, it is the stage of the subtask which is not running if there is one.
+	Stage DMTaskStage `json:"stage,omitempty"`
+
+	// Sources are the status of the subtasks of the sources.
+	Sources []DMTaskSourceStatus `json:"sources,omitempty"`
+
+	// Message is the last error of the task reported by dm-master or met by the controller.
+	Message string `json:"message,omitempty"`
+}
+
+// DMTaskSourceStatus is the status of the subtask of a source of DMTask.
+type DMTaskSourceStatus struct {
+	// Name is the name of the source.
+	Name string `json:"name"`
+
+	// Worker is the name of the dm-worker which runs the subtask.
+	Worker string `json:"worker,omitempty"`
+
+	// Stage is the stage of the subtask.
+	Stage DMTaskStage `json:"stage,omitempty"`
+
+	// Unit is the running unit of the subtask, e.g. `Dump`, `Load` and `Sync`.
+	Unit string `json:"unit,omitempty"`
+
+	// SecondsBehindMaster is the replication lag of the incremental migration.
+	SecondsBehindMaster int64 `json:"secondsBehindMaster,omitempty"`
+
+	// Synced is whether the incremental migration catches up with the upstream.
+	Synced bool `json:"synced,omitempty"`
+
+	// MasterBinlog is the latest binlog position of the upstream.
+	MasterBinlog string `json:"masterBinlog,omitempty"`
+
+	// SyncerBinlog is the binlog position which is migrated.
+	SyncerBinlog string `json:"syncerBinlog,omitempty"`
+
+	// UnresolvedDDLLockID is the ID of the shard DDL lock which blocks the subtask.
+	UnresolvedDDLLockID string `json:"unresolvedDDLLockID,omitempty"`
+
+	// Message is the error of the subtask.
+	Message string `json:"message,omitempty"`
+}
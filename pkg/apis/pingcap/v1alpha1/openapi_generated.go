@@ -33,16 +33,20 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider":         schema_pkg_apis_pingcap_v1alpha1_AzblobStorageProvider(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig":                      schema_pkg_apis_pingcap_v1alpha1_BRConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Backup":                        schema_pkg_apis_pingcap_v1alpha1_Backup(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEncryption":              schema_pkg_apis_pingcap_v1alpha1_BackupEncryption(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEstimateSpec":            schema_pkg_apis_pingcap_v1alpha1_BackupEstimateSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupList":                    schema_pkg_apis_pingcap_v1alpha1_BackupList(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupSchedule":                schema_pkg_apis_pingcap_v1alpha1_BackupSchedule(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupScheduleList":            schema_pkg_apis_pingcap_v1alpha1_BackupScheduleList(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupScheduleSpec":            schema_pkg_apis_pingcap_v1alpha1_BackupScheduleSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupSpec":                    schema_pkg_apis_pingcap_v1alpha1_BackupSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupVerifySpec":              schema_pkg_apis_pingcap_v1alpha1_BackupVerifySpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAuth":                     schema_pkg_apis_pingcap_v1alpha1_BasicAuth(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAutoScalerSpec":           schema_pkg_apis_pingcap_v1alpha1_BasicAutoScalerSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAutoScalerStatus":         schema_pkg_apis_pingcap_v1alpha1_BasicAutoScalerStatus(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BatchDeleteOption":             schema_pkg_apis_pingcap_v1alpha1_BatchDeleteOption(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Binlog":                        schema_pkg_apis_pingcap_v1alpha1_Binlog(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CapacityReservation":           schema_pkg_apis_pingcap_v1alpha1_CapacityReservation(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CleanOption":                   schema_pkg_apis_pingcap_v1alpha1_CleanOption(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ClusterRef":                    schema_pkg_apis_pingcap_v1alpha1_ClusterRef(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CommonConfig":                  schema_pkg_apis_pingcap_v1alpha1_CommonConfig(ref),
@@ -53,13 +57,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMClusterSpec":                 schema_pkg_apis_pingcap_v1alpha1_DMClusterSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMDiscoverySpec":               schema_pkg_apis_pingcap_v1alpha1_DMDiscoverySpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMExperimental":                schema_pkg_apis_pingcap_v1alpha1_DMExperimental(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTask":                        schema_pkg_apis_pingcap_v1alpha1_DMTask(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskList":                    schema_pkg_apis_pingcap_v1alpha1_DMTaskList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSource":                  schema_pkg_apis_pingcap_v1alpha1_DMTaskSource(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSpec":                    schema_pkg_apis_pingcap_v1alpha1_DMTaskSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DashboardConfig":               schema_pkg_apis_pingcap_v1alpha1_DashboardConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Diagnostic":                    schema_pkg_apis_pingcap_v1alpha1_Diagnostic(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticList":                schema_pkg_apis_pingcap_v1alpha1_DiagnosticList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticRedaction":           schema_pkg_apis_pingcap_v1alpha1_DiagnosticRedaction(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticSpec":                schema_pkg_apis_pingcap_v1alpha1_DiagnosticSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiscoverySpec":                 schema_pkg_apis_pingcap_v1alpha1_DiscoverySpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DumplingConfig":                schema_pkg_apis_pingcap_v1alpha1_DumplingConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Experimental":                  schema_pkg_apis_pingcap_v1alpha1_Experimental(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalConfig":                schema_pkg_apis_pingcap_v1alpha1_ExternalConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalDNS":                   schema_pkg_apis_pingcap_v1alpha1_ExternalDNS(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalEndpoint":              schema_pkg_apis_pingcap_v1alpha1_ExternalEndpoint(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover":                      schema_pkg_apis_pingcap_v1alpha1_Failover(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FailoverDetection":             schema_pkg_apis_pingcap_v1alpha1_FailoverDetection(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig":                 schema_pkg_apis_pingcap_v1alpha1_FileLogConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Flash":                         schema_pkg_apis_pingcap_v1alpha1_Flash(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashCluster":                  schema_pkg_apis_pingcap_v1alpha1_FlashCluster(ref),
@@ -67,10 +81,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashProxy":                    schema_pkg_apis_pingcap_v1alpha1_FlashProxy(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashSecurity":                 schema_pkg_apis_pingcap_v1alpha1_FlashSecurity(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashServerConfig":             schema_pkg_apis_pingcap_v1alpha1_FlashServerConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningPolicy":                schema_pkg_apis_pingcap_v1alpha1_GCTuningPolicy(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningSettings":              schema_pkg_apis_pingcap_v1alpha1_GCTuningSettings(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningWindow":                schema_pkg_apis_pingcap_v1alpha1_GCTuningWindow(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider":            schema_pkg_apis_pingcap_v1alpha1_GcsStorageProvider(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.HelperSpec":                    schema_pkg_apis_pingcap_v1alpha1_HelperSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IngressSpec":                   schema_pkg_apis_pingcap_v1alpha1_IngressSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitContainerSpec":             schema_pkg_apis_pingcap_v1alpha1_InitContainerSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSource":                 schema_pkg_apis_pingcap_v1alpha1_InitSqlSource(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSourceStatus":           schema_pkg_apis_pingcap_v1alpha1_InitSqlSourceStatus(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IsolationRead":                 schema_pkg_apis_pingcap_v1alpha1_IsolationRead(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Log":                           schema_pkg_apis_pingcap_v1alpha1_Log(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LogTailerSpec":                 schema_pkg_apis_pingcap_v1alpha1_LogTailerSpec(ref),
@@ -80,25 +99,37 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterSpec":                    schema_pkg_apis_pingcap_v1alpha1_MasterSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MetadataConfig":                schema_pkg_apis_pingcap_v1alpha1_MetadataConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MonitorContainer":              schema_pkg_apis_pingcap_v1alpha1_MonitorContainer(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofOffload":    schema_pkg_apis_pingcap_v1alpha1_NGMonitoringConprofOffload(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofSpec":       schema_pkg_apis_pingcap_v1alpha1_NGMonitoringConprofSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringSpec":              schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSink":              schema_pkg_apis_pingcap_v1alpha1_NotificationSink(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSpec":              schema_pkg_apis_pingcap_v1alpha1_NotificationSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing":                   schema_pkg_apis_pingcap_v1alpha1_OpenTracing(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter":           schema_pkg_apis_pingcap_v1alpha1_OpenTracingReporter(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler":            schema_pkg_apis_pingcap_v1alpha1_OpenTracingSampler(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OperationTimeoutPolicy":        schema_pkg_apis_pingcap_v1alpha1_OperationTimeoutPolicy(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfig":                      schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLeaderLocality":              schema_pkg_apis_pingcap_v1alpha1_PDLeaderLocality(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig":                   schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig":                schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig":             schema_pkg_apis_pingcap_v1alpha1_PDNamespaceConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecovery":                    schema_pkg_apis_pingcap_v1alpha1_PDRecovery(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecoveryList":                schema_pkg_apis_pingcap_v1alpha1_PDRecoveryList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecoverySpec":                schema_pkg_apis_pingcap_v1alpha1_PDRecoverySpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig":           schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig":              schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig":             schema_pkg_apis_pingcap_v1alpha1_PDSchedulerConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig":              schema_pkg_apis_pingcap_v1alpha1_PDSecurityConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig":                schema_pkg_apis_pingcap_v1alpha1_PDServerConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMSSpec":                      schema_pkg_apis_pingcap_v1alpha1_PDMSSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSpec":                        schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel":                  schema_pkg_apis_pingcap_v1alpha1_PDStoreLabel(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PeerRegistry":                  schema_pkg_apis_pingcap_v1alpha1_PeerRegistry(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance":                   schema_pkg_apis_pingcap_v1alpha1_Performance(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn":                schema_pkg_apis_pingcap_v1alpha1_PessimisticTxn(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PlanCache":                     schema_pkg_apis_pingcap_v1alpha1_PlanCache(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Plugin":                        schema_pkg_apis_pingcap_v1alpha1_Plugin(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec":       schema_pkg_apis_pingcap_v1alpha1_PodDisruptionBudgetSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PreparedPlanCache":             schema_pkg_apis_pingcap_v1alpha1_PreparedPlanCache(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe":                         schema_pkg_apis_pingcap_v1alpha1_Probe(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PrometheusConfiguration":       schema_pkg_apis_pingcap_v1alpha1_PrometheusConfiguration(ref),
@@ -106,31 +137,60 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyProtocol":                 schema_pkg_apis_pingcap_v1alpha1_ProxyProtocol(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PumpSpec":                      schema_pkg_apis_pingcap_v1alpha1_PumpSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.QueueConfig":                   schema_pkg_apis_pingcap_v1alpha1_QueueConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RaftLogVolumeClaim":            schema_pkg_apis_pingcap_v1alpha1_RaftLogVolumeClaim(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RelabelConfig":                 schema_pkg_apis_pingcap_v1alpha1_RelabelConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RemoteWriteSpec":               schema_pkg_apis_pingcap_v1alpha1_RemoteWriteSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ResourceOverride":              schema_pkg_apis_pingcap_v1alpha1_ResourceOverride(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Restore":                       schema_pkg_apis_pingcap_v1alpha1_Restore(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreList":                   schema_pkg_apis_pingcap_v1alpha1_RestoreList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreResumePolicy":           schema_pkg_apis_pingcap_v1alpha1_RestoreResumePolicy(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreScatterRegions":         schema_pkg_apis_pingcap_v1alpha1_RestoreScatterRegions(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSource":                 schema_pkg_apis_pingcap_v1alpha1_RestoreSource(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSpec":                   schema_pkg_apis_pingcap_v1alpha1_RestoreSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RollingUpdateStrategy":         schema_pkg_apis_pingcap_v1alpha1_RollingUpdateStrategy(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider":             schema_pkg_apis_pingcap_v1alpha1_S3StorageProvider(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SafeTLSConfig":                 schema_pkg_apis_pingcap_v1alpha1_SafeTLSConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHTTPHook":               schema_pkg_apis_pingcap_v1alpha1_ScaleInHTTPHook(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHook":                   schema_pkg_apis_pingcap_v1alpha1_ScaleInHook(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInJobHook":                schema_pkg_apis_pingcap_v1alpha1_ScaleInJobHook(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleOutBalanceGate":           schema_pkg_apis_pingcap_v1alpha1_ScaleOutBalanceGate(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretRef":                     schema_pkg_apis_pingcap_v1alpha1_SecretRef(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretReplication":             schema_pkg_apis_pingcap_v1alpha1_SecretReplication(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Security":                      schema_pkg_apis_pingcap_v1alpha1_Security(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec":                   schema_pkg_apis_pingcap_v1alpha1_ServiceSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Status":                        schema_pkg_apis_pingcap_v1alpha1_Status(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StatusCompaction":              schema_pkg_apis_pingcap_v1alpha1_StatusCompaction(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StmtSummary":                   schema_pkg_apis_pingcap_v1alpha1_StmtSummary(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageClaim":                  schema_pkg_apis_pingcap_v1alpha1_StorageClaim(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageProvider":               schema_pkg_apis_pingcap_v1alpha1_StorageProvider(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction":                 schema_pkg_apis_pingcap_v1alpha1_SuspendAction(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCertIssuerRef":              schema_pkg_apis_pingcap_v1alpha1_TLSCertIssuerRef(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCertManager":                schema_pkg_apis_pingcap_v1alpha1_TLSCertManager(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSConfig":                     schema_pkg_apis_pingcap_v1alpha1_TLSConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeed":               schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeed(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedFilter":         schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedFilter(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedList":           schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedSpec":           schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCConfig":                   schema_pkg_apis_pingcap_v1alpha1_TiCDCConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCSpec":                     schema_pkg_apis_pingcap_v1alpha1_TiCDCSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig":              schema_pkg_apis_pingcap_v1alpha1_TiDBAccessConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConfig":                    schema_pkg_apis_pingcap_v1alpha1_TiDBConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionPool":            schema_pkg_apis_pingcap_v1alpha1_TiDBConnectionPool(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionSecret":          schema_pkg_apis_pingcap_v1alpha1_TiDBConnectionSecret(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBPluginArtifact":            schema_pkg_apis_pingcap_v1alpha1_TiDBPluginArtifact(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBProxyProtocol":             schema_pkg_apis_pingcap_v1alpha1_TiDBProxyProtocol(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBRestartPolicy":             schema_pkg_apis_pingcap_v1alpha1_TiDBRestartPolicy(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSQLReadinessGate":          schema_pkg_apis_pingcap_v1alpha1_TiDBSQLReadinessGate(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBServiceSpec":               schema_pkg_apis_pingcap_v1alpha1_TiDBServiceSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSlowLogTailerSpec":         schema_pkg_apis_pingcap_v1alpha1_TiDBSlowLogTailerSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSpec":                      schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTLSClient":                 schema_pkg_apis_pingcap_v1alpha1_TiDBTLSClient(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTokenBasedAuth":            schema_pkg_apis_pingcap_v1alpha1_TiDBTokenBasedAuth(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBZoneLocalRouting":          schema_pkg_apis_pingcap_v1alpha1_TiDBZoneLocalRouting(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashComputeNodeSpec":        schema_pkg_apis_pingcap_v1alpha1_TiFlashComputeNodeSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashConfig":                 schema_pkg_apis_pingcap_v1alpha1_TiFlashConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashDisaggregatedSpec":      schema_pkg_apis_pingcap_v1alpha1_TiFlashDisaggregatedSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashS3Storage":              schema_pkg_apis_pingcap_v1alpha1_TiFlashS3Storage(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashSpec":                   schema_pkg_apis_pingcap_v1alpha1_TiFlashSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVBackupConfig":              schema_pkg_apis_pingcap_v1alpha1_TiKVBackupConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVBlockCacheConfig":          schema_pkg_apis_pingcap_v1alpha1_TiKVBlockCacheConfig(ref),
@@ -142,6 +202,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVDbConfig":                  schema_pkg_apis_pingcap_v1alpha1_TiKVDbConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVEncryptionConfig":          schema_pkg_apis_pingcap_v1alpha1_TiKVEncryptionConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGCConfig":                  schema_pkg_apis_pingcap_v1alpha1_TiKVGCConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGroup":                     schema_pkg_apis_pingcap_v1alpha1_TiKVGroup(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVIOTuning":                  schema_pkg_apis_pingcap_v1alpha1_TiKVIOTuning(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVImportConfig":              schema_pkg_apis_pingcap_v1alpha1_TiKVImportConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVMasterKeyConfig":           schema_pkg_apis_pingcap_v1alpha1_TiKVMasterKeyConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVPDConfig":                  schema_pkg_apis_pingcap_v1alpha1_TiKVPDConfig(ref),
@@ -151,9 +213,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVReadPoolConfig":            schema_pkg_apis_pingcap_v1alpha1_TiKVReadPoolConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSecurityConfig":            schema_pkg_apis_pingcap_v1alpha1_TiKVSecurityConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVServerConfig":              schema_pkg_apis_pingcap_v1alpha1_TiKVServerConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSlowStorePolicy":           schema_pkg_apis_pingcap_v1alpha1_TiKVSlowStorePolicy(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec":                      schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageConfig":             schema_pkg_apis_pingcap_v1alpha1_TiKVStorageConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageReadPoolConfig":     schema_pkg_apis_pingcap_v1alpha1_TiKVStorageReadPoolConfig(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStoreGC":                   schema_pkg_apis_pingcap_v1alpha1_TiKVStoreGC(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVTitanCfConfig":             schema_pkg_apis_pingcap_v1alpha1_TiKVTitanCfConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVTitanDBConfig":             schema_pkg_apis_pingcap_v1alpha1_TiKVTitanDBConfig(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVUnifiedReadPoolConfig":     schema_pkg_apis_pingcap_v1alpha1_TiKVUnifiedReadPoolConfig(ref),
@@ -166,8 +230,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterAutoScalerRef":      schema_pkg_apis_pingcap_v1alpha1_TidbClusterAutoScalerRef(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterAutoScalerSpec":     schema_pkg_apis_pingcap_v1alpha1_TidbClusterAutoScalerSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterAutoScalerStatus":   schema_pkg_apis_pingcap_v1alpha1_TidbClusterAutoScalerStatus(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicy":       schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicy(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicyList":   schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicyList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicySpec":   schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicySpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterList":               schema_pkg_apis_pingcap_v1alpha1_TidbClusterList(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef":                schema_pkg_apis_pingcap_v1alpha1_TidbClusterRef(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRollout":            schema_pkg_apis_pingcap_v1alpha1_TidbClusterRollout(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRolloutList":        schema_pkg_apis_pingcap_v1alpha1_TidbClusterRolloutList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRolloutSpec":        schema_pkg_apis_pingcap_v1alpha1_TidbClusterRolloutSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterSpec":               schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbDashboard":                 schema_pkg_apis_pingcap_v1alpha1_TidbDashboard(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbDashboardList":             schema_pkg_apis_pingcap_v1alpha1_TidbDashboardList(ref),
@@ -177,6 +247,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbInitializerSpec":           schema_pkg_apis_pingcap_v1alpha1_TidbInitializerSpec(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbInitializerStatus":         schema_pkg_apis_pingcap_v1alpha1_TidbInitializerStatus(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitor":                   schema_pkg_apis_pingcap_v1alpha1_TidbMonitor(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorAlertingSpec":       schema_pkg_apis_pingcap_v1alpha1_TidbMonitorAlertingSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorClusterSelector":    schema_pkg_apis_pingcap_v1alpha1_TidbMonitorClusterSelector(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorHealthCheck":        schema_pkg_apis_pingcap_v1alpha1_TidbMonitorHealthCheck(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorList":               schema_pkg_apis_pingcap_v1alpha1_TidbMonitorList(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorRef":                schema_pkg_apis_pingcap_v1alpha1_TidbMonitorRef(ref),
 		"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorSpec":               schema_pkg_apis_pingcap_v1alpha1_TidbMonitorSpec(ref),
@@ -564,7 +637,14 @@ func schema_pkg_apis_pingcap_v1alpha1_AzblobStorageProvider(ref common.Reference
 					},
 					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretName is the name of secret which stores the azblob service account credentials.",
+							Description: "SecretName is the name of secret which stores the azblob service account credentials, or the sas token of the storage account in the `AZURE_STORAGE_SAS_TOKEN` key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageAccount is the storage account of the azure blob storage. If this field is set, then use this to set backup-manager env, otherwise retrieve the storage account from secret. If secretName is not set, the managed identity of the pod is used to access the storage account.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -681,6 +761,13 @@ func schema_pkg_apis_pingcap_v1alpha1_BRConfig(ref common.ReferenceCallback) com
 							Format:      "",
 						},
 					},
+					"stats": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stats specifies whether to back up the statistics of the tables along with the data during backup, and whether to load the statistics from the backup during restore, so that the optimizer of the restored cluster does not need to wait for the statistics to be collected again. It is passed to BR as `--ignore-stats` for backup and `--load-stats` for restore.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"options": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Options means options for backup data to remote storage with BR. These options has highest priority.",
@@ -739,6 +826,76 @@ func schema_pkg_apis_pingcap_v1alpha1_Backup(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_BackupEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupEncryption contains the settings to encrypt the backup data at rest. Exactly one of KeySecretRef and MasterKey should be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method is the method to encrypt the backup data. Defaults to aes256-ctr.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keySecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeySecretRef refers to the key of a Secret which holds the hex encoded data key, the length of the key must match the method, e.g. 32 bytes for aes256-ctr.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"masterKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MasterKey is the URI of the KMS master key for envelope encryption, e.g. `aws-kms:///<key-id>?REGION=<region>` or `gcp-kms:///projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>`. BR generates the data key and encrypts it by the master key, the credentials to access the KMS are read from the environment of the job, e.g. the service account.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_BackupEstimateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupEstimateSpec contains the settings to estimate a backup before it starts. The size of the backup is estimated by the data size of the cluster and the ratio of the backup size to the data size of the recent complete backups of the same cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"storageQuota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageQuota is the quota of the destination of the backup, i.e. the same bucket or container, the storage used by the complete backups in the same namespace and destination plus the estimated size of the backup should not exceed it.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"rejectOnQuotaExceeded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RejectOnQuotaExceeded denotes whether to fail the backup before it starts if the storage quota would be exceeded, otherwise only a warning event is recorded. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pricePerGiBMonth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PricePerGiBMonth is the price of storing 1 GiB in the destination for a month, e.g. \"0.023\", it is used to estimate the monthly cost of the backup.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_BackupList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -916,6 +1073,13 @@ func schema_pkg_apis_pingcap_v1alpha1_BackupScheduleSpec(ref common.ReferenceCal
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupSpec"),
 						},
 					},
+					"logBackupRetention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogBackupRetention is to specify how long the cluster could be restored to any time by the log backup. The log backup is truncated to the last snapshot backup before the window, and the snapshot backups needed by the window are kept even if they are expired by MaxBackups or MaxReservedTime.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "The storageClassName of the persistent volume for Backup data storage if not storage class name set in BackupSpec. Defaults to Kubernetes default storage class.",
@@ -1047,6 +1211,12 @@ func schema_pkg_apis_pingcap_v1alpha1_BackupSpec(ref common.ReferenceCallback) c
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig"),
 						},
 					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption is the settings to encrypt the backup data at rest with BR, it is only valid for snapshot backup.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEncryption"),
+						},
+					},
 					"commitTs": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CommitTs is the commit ts of the backup, snapshot ts for full backup or start ts for log backup. Format supports TSO or datetime, e.g. '400036290571534337', '2018-05-11 01:42:23'. Default is current timestamp.",
@@ -1177,11 +1347,66 @@ func schema_pkg_apis_pingcap_v1alpha1_BackupSpec(ref common.ReferenceCallback) c
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackoffRetryPolicy"),
 						},
 					},
+					"estimate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Estimate enables estimating the size, duration and cost of the backup before the backup job is created, it is only valid for snapshot backup with BR.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEstimateSpec"),
+						},
+					},
+					"verify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verify enables verifying the backup by a verification job after the backup completes, it is only valid for snapshot backup with BR. The result is recorded in the Verified condition.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupVerifySpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackoffRetryPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEncryption", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEstimateSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupVerifySpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CleanOption", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DumplingConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_BackupVerifySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupVerifySpec contains the settings to verify a backup after it completes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the way to verify the backup, Checksum or ScratchRestore. Defaults to Checksum.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scratchCluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScratchCluster is the cluster to restore the backup to in the ScratchRestore mode, it must not be the cluster being backed up, and the restored tables must not exist in it.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef"),
+						},
+					},
+					"tableFilter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TableFilter selects the tables to restore in the ScratchRestore mode, the table filter of the backup is used if it is not set.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackoffRetryPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CleanOption", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DumplingConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef"},
 	}
 }
 
@@ -1292,6 +1517,13 @@ func schema_pkg_apis_pingcap_v1alpha1_BasicAutoScalerStatus(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"scaleInDeferredReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleInDeferredReason describes why the last scale-in was deferred, e.g. it would violate the PodDisruptionBudget or the regions are still being migrated. It is cleared after the next auto-scaling.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -1382,6 +1614,58 @@ func schema_pkg_apis_pingcap_v1alpha1_Binlog(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_CapacityReservation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CapacityReservation configures the low-priority placeholder pods which reserve the compute resources for the replacement pods of a component.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the number of the placeholder pods, each of them reserves the resources of a member Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of the placeholder pods. It must have a lower priority than the pods of the component, so the placeholder pods can be preempted by them.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the placeholder pods, which runs nothing but the pause process Optional: Defaults to registry.k8s.io/pause:3.9",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of the placeholder pods to keep them on the standby nodes Optional: Defaults to the node selector of the component",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"priorityClassName"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_CleanOption(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1719,6 +2003,21 @@ func schema_pkg_apis_pingcap_v1alpha1_ComponentSpec(ref common.ReferenceCallback
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -1762,7 +2061,7 @@ func schema_pkg_apis_pingcap_v1alpha1_ComponentSpec(ref common.ReferenceCallback
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -2392,6 +2691,21 @@ func schema_pkg_apis_pingcap_v1alpha1_DMDiscoverySpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -2435,7 +2749,7 @@ func schema_pkg_apis_pingcap_v1alpha1_DMDiscoverySpec(ref common.ReferenceCallba
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -2518,6 +2832,20 @@ func schema_pkg_apis_pingcap_v1alpha1_DMDiscoverySpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The desired replicas of discovery, more than one replica makes discovery run in stateless mode and use a RollingUpdate strategy. Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"proxyDMMaster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyDMMaster exposes the API of the DM-master leader under the path `/dm-master` of the proxy service on port 10262, so the clients don't need to track which DM-master is the leader. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -2546,1142 +2874,1075 @@ func schema_pkg_apis_pingcap_v1alpha1_DMExperimental(ref common.ReferenceCallbac
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_DashboardConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DMTask(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DashboardConfig is the configuration for tidb-dashboard.",
+				Description: "DMTask declares a data migration task of a DMCluster, the upstream sources and the task are created, updated, stopped, started and deleted by the OpenAPI of dm-master accordingly, which must be enabled by `openapi = true` in the config of dm-master.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tidb-cacert-path": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"tidb-cert-path": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"tidb-key-path": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"public-path-prefix": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"internal-proxy": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"enable-telemetry": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "When enabled, usage data will be sent to PingCAP for improving user experience. Optional: Defaults to true",
-							Type:        []string{"boolean"},
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enable-experimental": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "When enabled, experimental TiDB Dashboard features will be available. These features are incomplete or not well tested. Suggest not to enable in production. Optional: Defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Spec contains all spec about the task.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSpec"),
 						},
 					},
 				},
+				Required: []string{"spec"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSpec"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_DiscoverySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DMTaskList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DiscoverySpec contains details of Discovery members",
+				Description: "DMTaskList is a DMTask list.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"version": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullPolicy": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullSecrets": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
-							Type:        []string{"array"},
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTask"),
 									},
 								},
 							},
 						},
 					},
-					"hostNetwork": {
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTask"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_DMTaskSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DMTaskSource is an upstream MySQL source of DMTask.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"boolean"},
+							Description: "Name is the name of the source in dm-master.",
+							Default:     "",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"affinity": {
+					"host": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "Host is the address of the MySQL.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"priorityClassName": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "Port is the port of the MySQL. Optional: Defaults to 3306",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"user": {
+						SchemaProps: spec.SchemaProps{
+							Description: "User is the user to connect the MySQL.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "SecretName is the name of the Secret which stores the password of the user in the key `password`.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
+					"enableGTID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "EnableGTID replicates the binlog by GTID instead of the binlog position.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"annotations": {
+					"binlogName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "BinlogName is the binlog file where the incremental migration starts.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"labels": {
+					"binlogPos": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "BinlogPos is the binlog position where the incremental migration starts.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"tolerations": {
+					"binlogGTID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
+							Description: "BinlogGTID is the GTID set where the incremental migration starts.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"podSecurityContext": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+							Description: "Config is the rest of the source config in the format of the OpenAPI of dm-master, e.g. `relay_config` and `purge`.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
 						},
 					},
-					"configUpdateStrategy": {
+				},
+				Required: []string{"name", "host", "user"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_DMTaskSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DMTaskSpec is spec of DMTask.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
+							Description: "Cluster is the name of the DMCluster which runs the task, it must be in the same namespace as the DMTask.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"env": {
+					"taskName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
-									},
-								},
-							},
+							Description: "TaskName is the name of the task in dm-master, it can not be changed. Optional: Defaults to the name of the DMTask",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"envFrom": {
+					"taskMode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
-									},
-								},
-							},
+							Description: "TaskMode is the mode of the task, `all`, `full` or `incremental`. Optional: Defaults to `all`",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"initContainers": {
+					"shardMode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "ShardMode is the mode to coordinate the DDLs of the sharded tables merged into the same table, `pessimistic` or `optimistic`. Optional: Defaults to no sharding",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"additionalContainers": {
+					"sources": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Description: "Sources are the upstream MySQL sources of the task, the sources which do not exist are created.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSource"),
 									},
 								},
 							},
 						},
 					},
-					"additionalVolumes": {
+					"target": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volumes of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Volume"),
-									},
-								},
-							},
+							Description: "Target is the downstream TiDB of the task.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig"),
 						},
 					},
-					"additionalVolumeMounts": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volume mounts of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
-									},
-								},
-							},
+							Description: "Config is the rest of the task config in the format of the OpenAPI of dm-master, e.g. `table_migrate_rule`, `binlog_filter_rule` and `source_config.full_migrate_conf`. The name, the modes, the target config and the source names are overwritten by the other fields of the spec. Optional: Defaults to migrate all the tables of the sources",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
 						},
 					},
-					"dnsConfig": {
+					"paused": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Description: "Paused stops the task, it is started again after Paused is unset.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"dnsPolicy": {
+				},
+				Required: []string{"cluster", "sources", "target"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMTaskSource", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_DashboardConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DashboardConfig is the configuration for tidb-dashboard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tidb-cacert-path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"tidb-cert-path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"statefulSetUpdateStrategy": {
+					"tidb-key-path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"podManagementPolicy": {
+					"public-path-prefix": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+					"internal-proxy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
-									},
-								},
-							},
-						},
-					},
-					"suspendAction": {
-						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
-						},
-					},
-					"readinessProbe": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"limits": {
+					"enable-telemetry": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "When enabled, usage data will be sent to PingCAP for improving user experience. Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"requests": {
+					"enable-experimental": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "When enabled, experimental TiDB Dashboard features will be available. These features are incomplete or not well tested. Suggest not to enable in production. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_DumplingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_Diagnostic(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DumplingConfig contains config for dumpling",
+				Description: "Diagnostic collects a diagnostic bundle of a TidbCluster for support engagements, including pprof profiles, metrics snapshots, recent logs and store/region dumps of PD and TiKV. The bundle is collected by a Job and uploaded to the object storage or written to a volume.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"options": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Options means options for backup data to remote storage with dumpling.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"tableFilter": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated. Please use `Spec.TableFilter` instead. TableFilter means Table filter expression for 'db.table' matching",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec contains all spec about the diagnostic.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticSpec"),
 						},
 					},
 				},
+				Required: []string{"spec"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticSpec"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_Experimental(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DiagnosticList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Experimental controls the features that are still experimental: their semantics, interfaces are subject to change. Using these features in the production environment is not recommended.",
+				Description: "DiagnosticList is a Diagnostic list.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"allow-auto-random": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether enable the syntax like `auto_random(3)` on the primary key column. Imported from TiDB v3.1.0. Deprecated in TiDB v4.0.3, please check detail in https://docs.pingcap.com/tidb/dev/release-4.0.3#improvements.",
-							Type:        []string{"boolean"},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"allow-expression-index": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether enable creating expression index.",
-							Type:        []string{"boolean"},
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Diagnostic"),
+									},
+								},
+							},
+						},
+					},
 				},
+				Required: []string{"items"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Diagnostic"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_ExternalConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DiagnosticRedaction(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExternalConfig represents the external config.",
+				Description: "DiagnosticRedaction configures how the sensitive data in the diagnostic bundle is redacted.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"endpoint": {
+					"disabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExternalEndpoint makes the auto-scaler controller able to query the external service to fetch the recommended replicas for TiKV/TiDB",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalEndpoint"),
+							Description: "Disabled collects the raw data without redaction. It takes effect only if the user who creates the Diagnostic is allowed to `unredact` diagnostics in the namespace by RBAC, otherwise the data is still redacted. The creator is recorded by the admission webhook of tidb-operator, so the data is always redacted if the webhook is not deployed.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"maxReplicas": {
+					"patterns": {
 						SchemaProps: spec.SchemaProps{
-							Description: "maxReplicas is the upper limit for the number of replicas to which the autoscaler can scale out.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Patterns are the additional regular expressions to redact from the logs and dumps, the matches are replaced by `?`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"maxReplicas"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalEndpoint"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_ExternalEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DiagnosticSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExternalEndpoint describes the external service endpoint which provides the ability to get the tikv/tidb auto-scaling recommended replicas",
+				Description: "DiagnosticSpec is spec of Diagnostic.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"host": {
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Host indicates the external service's host",
+							Description: "Cluster is the name of the TidbCluster to collect from, it must be in the same namespace as the Diagnostic.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"port": {
+					"components": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Port indicates the external service's port",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Components are the components to collect from, only pd and tikv are supported. Optional: Defaults to [pd, tikv]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"path": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path indicates the external service's path",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Items are the kinds of data to collect. Optional: Defaults to all of profiles, metrics, logs, stores and regions",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"tlsSecret": {
+					"profiles": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSSecret indicates the Secret which stores the TLS configuration. If set, the operator will use https to communicate to the external service",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretRef"),
+							Description: "Profiles are the names of the pprof profiles to collect, e.g. profile, heap, goroutine, mutex. `profile` is the CPU profile which lasts for ProfileDuration. Optional: Defaults to [profile, heap, goroutine]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-				},
-				Required: []string{"host", "port", "path"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretRef"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Failover(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Failover contains the failover specification.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"recoverByUID": {
+					"profileDuration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RecoverByUID indicates that TiDB Operator will recover the failover by this UID, it takes effect only when set `spec.recoverFailover=false`",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ProfileDuration is the duration of the CPU profile. Optional: Defaults to 30s",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_FileLogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"filename": {
+					"logTailLines": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log filename, leave empty to disable file log.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "LogTailLines is the number of the recent log lines collected from each pod. Optional: Defaults to 10000",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"log-rotate": {
+					"redaction": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated in v4.0.0 Is log rotate enabled.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Redaction configures how the sensitive data in the bundle is redacted. The keys in the logs and the region dumps are always redacted unless redaction is disabled.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticRedaction"),
 						},
 					},
-					"max-size": {
+					"s3": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Max size for a single file, in MB.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
 						},
 					},
-					"max-days": {
+					"gcs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Max log keep days, default is never deleting.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
 						},
 					},
-					"max-backups": {
+					"azblob": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Maximum number of old log files to retain.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Flash(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Flash is the configuration of [flash] section.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"overlap_threshold": {
+					"local": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0.6",
-							Type:        []string{"number"},
-							Format:      "double",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider"),
 						},
 					},
-					"compact_log_min_period": {
+					"serviceAccount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 200",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Specify service account of the collector job. The service account should be allowed to get and update diagnostics, get tidbclusters, list pods, get pods/log and create localsubjectaccessreviews in the namespace. Optional: Defaults to tidb-backup-manager",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"flash_cluster": {
+					"resources": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashCluster"),
+							Description: "Resources of the collector job",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
 						},
 					},
-					"proxy": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashProxy"),
+							Description: "List of environment variables to set in the collector container, e.g. the credentials of the storage.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashCluster", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashProxy"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_FlashCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "FlashCluster is the configuration of [flash.flash_cluster] section.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"log": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/logs/flash_cluster_manager.log",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Tolerations of the collector job",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"refresh_interval": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 20",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Affinity of the collector job",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"update_rule_interval": {
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling the collector image.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
 						},
 					},
-					"master_ttl": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 60",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "PodSecurityContext of the collector job",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of the collector job",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"cluster"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiagnosticRedaction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_FlashLogger(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DiscoverySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "FlashLogger is the configuration of [logger] section.",
+				Description: "DiscoverySpec contains details of Discovery members",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"errorlog": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/logs/error.log",
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"size": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 100M",
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"log": {
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/logs/server.log",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
 						},
 					},
-					"level": {
+					"hostNetwork": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to information",
-							Type:        []string{"string"},
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"count": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_FlashProxy(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "FlashProxy is the configuration of [flash.proxy] section.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"addr": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0.0.0.0:20170",
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"advertise-addr": {
+					"schedulerName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:20170",
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"data-dir": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/proxy",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"config": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/proxy.toml",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"log-file": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to /data0/logs/proxy.log",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_FlashSecurity(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"cert_allowed_cn": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CertAllowedCN is the Common Name that allowed",
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
 									},
 								},
 							},
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_FlashServerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "FlashServerConfig is the configuration of Proxy server.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"engine-addr": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Default to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:3930",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
 						},
 					},
-					"status-addr": {
+					"configUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Default to 0.0.0.0:20292",
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"advertise-status-addr": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Default to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:20292",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-					"status-thread-pool-size": {
+					"envFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
 						},
 					},
-					"max-grpc-send-msg-len": {
+					"initContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10485760",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"grpc-compression-type": {
+					"additionalContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to none",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"grpc-concurrency": {
+					"nativeSidecarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 4",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"grpc-concurrent-stream": {
+					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1024",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
 						},
 					},
-					"grpc-memory-pool-quota": {
+					"additionalVolumeMounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 32G",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
 						},
 					},
-					"grpc-raft-conn-num": {
+					"dnsConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
 						},
 					},
-					"grpc-stream-initial-window-size": {
+					"dnsPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 2MB",
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"grpc-keepalive-time": {
+					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10s",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"grpc-keepalive-timeout": {
+					"statefulSetUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 3s",
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"concurrent-send-snap-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 32",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"concurrent-recv-snap-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 32",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"end-point-recursion-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1000",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"end-point-stream-channel-size": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"end-point-batch-row-limit": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"end-point-stream-batch-row-limit": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"end-point-enable-batch-if-possible": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"end-point-request-max-handle-duration": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"snap-max-write-bytes-per-sec": {
+					"podManagementPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 100MB",
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"snap-max-total-size": {
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
 						},
 					},
-					"stats-concurrency": {
+					"suspendAction": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
 						},
 					},
-					"heavy-load-threshold": {
+					"readinessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
 						},
 					},
-					"heavy-load-wait-duration": {
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 60s",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"labels": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"enable-request-batch": {
+					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"request-batch-enable-cross-command": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "The desired replicas of discovery, more than one replica makes discovery run in stateless mode and use a RollingUpdate strategy. Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"request-batch-wait-duration": {
+					"proxyTiCDCOwner": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "ProxyTiCDCOwner exposes the open API of the TiCDC owner under the path `/ticdc` of the proxy service on port 10262, so the clients don't need to track which capture is the owner. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_GcsStorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_DumplingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "GcsStorageProvider represents the google cloud storage for storing backups.",
+				Description: "DumplingConfig contains config for dumpling",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"projectId": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ProjectId represents the project that organizes all your Google Cloud Platform resources",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"location": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Location in which the gcs bucket is located.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Path is the full path where the backup is saved. The format of the path must be: \"<bucket-name>/<path-to-backup-file>\"",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"bucket": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Bucket in which to store the backup data.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"storageClass": {
-						SchemaProps: spec.SchemaProps{
-							Description: "StorageClass represents the storage class",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"objectAcl": {
+					"options": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ObjectAcl represents the access control list for new objects",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Options means options for backup data to remote storage with dumpling.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"bucketAcl": {
+					"tableFilter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "BucketAcl represents the access control list for new buckets",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Deprecated. Please use `Spec.TableFilter` instead. TableFilter means Table filter expression for 'db.table' matching",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"secretName": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Experimental(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Experimental controls the features that are still experimental: their semantics, interfaces are subject to change. Using these features in the production environment is not recommended.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"allow-auto-random": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretName is the name of secret which stores the gcs service account credentials JSON.",
-							Type:        []string{"string"},
+							Description: "Whether enable the syntax like `auto_random(3)` on the primary key column. Imported from TiDB v3.1.0. Deprecated in TiDB v4.0.3, please check detail in https://docs.pingcap.com/tidb/dev/release-4.0.3#improvements.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"prefix": {
+					"allow-expression-index": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Prefix of the data path.",
-							Type:        []string{"string"},
+							Description: "Whether enable creating expression index.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"projectId"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_HelperSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_ExternalConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "HelperSpec contains details of helper component",
+				Description: "ExternalConfig represents the external config.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"image": {
+					"endpoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Image used to tail slow log and set kernel parameters if necessary, must have `tail` and `sysctl` installed Optional: Defaults to busybox:1.26.2. Recommended to set to 1.34.1 for new installations.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ExternalEndpoint makes the auto-scaler controller able to query the external service to fetch the recommended replicas for TiKV/TiDB",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalEndpoint"),
 						},
 					},
-					"imagePullPolicy": {
+					"maxReplicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to the cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "maxReplicas is the upper limit for the number of replicas to which the autoscaler can scale out.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"maxReplicas"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalEndpoint"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_IngressSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_ExternalDNS(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "IngressSpec describe the ingress desired state for the target component",
+				Description: "ExternalDNS configures the DNS records managed by external-dns for a service, it is translated into the external-dns annotations of the service.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"hosts": {
+					"hostnames": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Hosts describe the hosts for the ingress",
+							Description: "Hostnames of the DNS records which point to the service",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -3694,806 +3955,662 @@ func schema_pkg_apis_pingcap_v1alpha1_IngressSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
-					"annotations": {
+					"ttl": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations describe the desired annotations for the ingress",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "TTL of the DNS records in seconds Optional: Defaults to the TTL of the DNS provider",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"tls": {
+					"verifyPropagation": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLS configuration. Currently the Ingress only supports a single TLS port, 443. If multiple members of this list specify different hosts, they will be multiplexed on the same port according to the hostname specified through the SNI TLS extension, if the ingress controller fulfilling the ingress supports SNI.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/networking/v1.IngressTLS"),
-									},
-								},
-							},
+							Description: "VerifyPropagation indicates whether to verify that the DNS records are resolved to the load balancer of the service before the service is marked as ready. It only takes effect for the service of LoadBalancer type. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"hosts"},
+				Required: []string{"hostnames"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/api/networking/v1.IngressTLS"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_InitContainerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_ExternalEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "InitContainerSpec contains basic spec about a init container",
+				Description: "ExternalEndpoint describes the external service endpoint which provides the ability to get the tikv/tidb auto-scaling recommended replicas",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"limits": {
+					"host": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Host indicates the external service's host",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"requests": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Port indicates the external service's port",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path indicates the external service's path",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tlsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSSecret indicates the Secret which stores the TLS configuration. If set, the operator will use https to communicate to the external service",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretRef"),
 						},
 					},
 				},
+				Required: []string{"host", "port", "path"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretRef"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_IsolationRead(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_Failover(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "IsolationRead is the config for isolation read.",
+				Description: "Failover contains the failover specification.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"engines": {
+					"recoverByUID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Engines filters tidb-server access paths by engine type. imported from v3.1.0",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "RecoverByUID indicates that TiDB Operator will recover the failover by this UID, it takes effect only when set `spec.recoverFailover=false`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"detection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Detection is the configurations of detecting the failure of a member",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FailoverDetection"),
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FailoverDetection"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_Log(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_FailoverDetection(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Log is the log section of config.",
+				Description: "FailoverDetection contains the configurations of detecting the failure of a member.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"level": {
+					"period": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log level. Optional: Defaults to info",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Period is how long a member must be unhealthy before it's marked as failed. Optional: Defaults to the failover period of the component set in tidb-controller-manager",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"format": {
+					"probeFailureThreshold": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log format. one of json, text, or console. Optional: Defaults to text",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ProbeFailureThreshold is how many consecutive times a member must be probed unhealthy before it's marked as failed, in addition to Period. Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"disable-timestamp": {
+					"podPhaseAsEvidence": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated in v3.0.5. Use EnableTimestamp instead Disable automatic timestamps in output.",
+							Description: "PodPhaseAsEvidence indicates that a member is marked as failed only if its pod is not running or not ready as well, so that the members reported unhealthy while their pods are running well, e.g. under heavy load, are not failed over.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"enable-timestamp": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_FileLogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"filename": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableTimestamp enables automatic timestamps in log output.",
-							Type:        []string{"boolean"},
+							Description: "Log filename, leave empty to disable file log.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enable-error-stack": {
+					"log-rotate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableErrorStack enables annotating logs with the full stack error message.",
+							Description: "Deprecated in v4.0.0 Is log rotate enabled.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"file": {
-						SchemaProps: spec.SchemaProps{
-							Description: "File log config.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"),
-						},
-					},
-					"enable-slow-log": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"slow-query-file": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"slow-threshold": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 300",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"expensive-threshold": {
+					"max-size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 10000",
+							Description: "Max size for a single file, in MB.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
-					"query-log-max-len": {
+					"max-days": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 2048",
+							Description: "Max log keep days, default is never deleting.",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"record-plan-in-slow-log": {
+					"max-backups": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1",
+							Description: "Maximum number of old log files to retain.",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_LogTailerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_Flash(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogTailerSpec represents an optional log tailer sidecar container",
+				Description: "Flash is the configuration of [flash] section.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"limits": {
+					"overlap_threshold": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 0.6",
+							Type:        []string{"number"},
+							Format:      "double",
 						},
 					},
-					"requests": {
+					"compact_log_min_period": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 200",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flash_cluster": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashCluster"),
+						},
+					},
+					"proxy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashProxy"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashCluster", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashProxy"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MasterConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_FlashCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MasterConfig is the configuration of dm-master-server",
+				Description: "FlashCluster is the configuration of [flash.flash_cluster] section.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"log-level": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Log level. Optional: Defaults to info",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"log-file": {
-						SchemaProps: spec.SchemaProps{
-							Description: "File log config.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"log-format": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Log format. one of json or text.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"rpc-timeout": {
+					"log": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RPC timeout when dm-master request to dm-worker Optional: Defaults to 30s",
+							Description: "Optional: Defaults to /data0/logs/flash_cluster_manager.log",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"rpc-rate-limit": {
+					"refresh_interval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RPC agent rate limit when dm-master request to dm-worker Optional: Defaults to 10",
-							Type:        []string{"number"},
-							Format:      "double",
+							Description: "Optional: Defaults to 20",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"rpc-rate-burst": {
+					"update_rule_interval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RPC agent rate burst when dm-master request to dm-worker Optional: Defaults to 40",
+							Description: "Optional: Defaults to 10",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
-					"experimental": {
+					"master_ttl": {
 						SchemaProps: spec.SchemaProps{
-							Description: "dm-master's experimental config",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMExperimental"),
+							Description: "Optional: Defaults to 60",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMExperimental"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MasterKeyFileConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_FlashLogger(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "FlashLogger is the configuration of [logger] section.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"method": {
+					"errorlog": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Encrypyion method, use master key encryption data key Possible values: plaintext, aes128-ctr, aes192-ctr, aes256-ctr Optional: Default to plaintext optional",
+							Description: "Optional: Defaults to /data0/logs/error.log",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"path": {
+					"size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Text file containing the key in hex form, end with '\n'",
+							Description: "Optional: Defaults to 100M",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"log": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to /data0/logs/server.log",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"level": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to information",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 10",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
-				Required: []string{"path"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MasterKeyKMSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_FlashProxy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "FlashProxy is the configuration of [flash.proxy] section.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"key-id": {
+					"addr": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AWS CMK key-id it can be find in AWS Console or use aws cli This field is required",
+							Description: "Optional: Defaults to 0.0.0.0:20170",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"access-key": {
+					"advertise-addr": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AccessKey of AWS user, leave empty if using other authrization method optional",
+							Description: "Optional: Defaults to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:20170",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"secret-access-key": {
+					"data-dir": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretKey of AWS user, leave empty if using other authrization method optional",
+							Description: "Optional: Defaults to /data0/proxy",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"region": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Region of this KMS key Optional: Default to us-east-1 optional",
+							Description: "Optional: Defaults to /data0/proxy.toml",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"endpoint": {
+					"log-file": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Used for KMS compatible KMS, such as Ceph, minio, If use AWS, leave empty optional",
+							Description: "Optional: Defaults to /data0/logs/proxy.log",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"key-id"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MasterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_FlashSecurity(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MasterSpec contains details of dm-master members",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"version": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"imagePullPolicy": {
+					"cert_allowed_cn": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"imagePullSecrets": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Description: "CertAllowedCN is the Common Name that allowed",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"hostNetwork": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_FlashServerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FlashServerConfig is the configuration of Proxy server.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"engine-addr": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"boolean"},
+							Description: "Default to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:3930",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"affinity": {
+					"status-addr": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "Default to 0.0.0.0:20292",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"priorityClassName": {
+					"advertise-status-addr": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "Default to {clusterName}-tiflash-POD_NUM.{clusterName}-tiflash-peer.{namespace}:20292",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"status-thread-pool-size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "Optional: Defaults to 1",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
+					"max-grpc-send-msg-len": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Optional: Defaults to 10485760",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"annotations": {
+					"grpc-compression-type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Optional: Defaults to none",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"labels": {
+					"grpc-concurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Optional: Defaults to 4",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"tolerations": {
+					"grpc-concurrent-stream": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 1024",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"podSecurityContext": {
+					"grpc-memory-pool-quota": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+							Description: "Optional: Defaults to 32G",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"configUpdateStrategy": {
+					"grpc-raft-conn-num": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
+							Description: "Optional: Defaults to 10",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"grpc-stream-initial-window-size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 2MB",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"env": {
+					"grpc-keepalive-time": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 10s",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"envFrom": {
+					"grpc-keepalive-timeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 3s",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"initContainers": {
+					"concurrent-send-snap-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 32",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"additionalContainers": {
+					"concurrent-recv-snap-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 32",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"additionalVolumes": {
+					"end-point-recursion-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volumes of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Volume"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 1000",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"additionalVolumeMounts": {
+					"end-point-stream-channel-size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volume mounts of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
-									},
-								},
-							},
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"dnsConfig": {
+					"end-point-batch-row-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"dnsPolicy": {
+					"end-point-stream-batch-row-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"end-point-enable-batch-if-possible": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"statefulSetUpdateStrategy": {
+					"end-point-request-max-handle-duration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"podManagementPolicy": {
+					"snap-max-write-bytes-per-sec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Description: "Optional: Defaults to 100MB",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+					"snap-max-total-size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"suspendAction": {
+					"stats-concurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"readinessProbe": {
+					"heavy-load-threshold": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"limits": {
+					"heavy-load-wait-duration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Optional: Defaults to 60s",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"requests": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
+							Type: []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The desired ready replicas",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"baseImage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"service": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Service defines a Kubernetes service of Master cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterServiceSpec"),
-						},
-					},
-					"maxFailoverCount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"storageClassName": {
+					"enable-request-batch": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for dm-master data storage. Defaults to Kubernetes default storage class.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"storageSize": {
+					"request-batch-enable-cross-command": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageSize is the request storage size for dm-master. Defaults to \"10Gi\".",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"dataSubDir": {
+					"request-batch-wait-duration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Subdirectory within the volume to store dm-master Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/dm-master. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/dm-master/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"config": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_GCTuningPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GCTuningPolicy describes the windows in which the GC settings of TiKV are adjusted. The settings are applied by the global system variables of TiDB when a window starts, and the settings before the window are restored after the window.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"windows": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Config is the Configuration of dm-master-servers",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterConfigWraper"),
+							Description: "Windows are the scheduled windows of the GC settings. The first active one takes effect if the windows overlap.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningWindow"),
+									},
+								},
+							},
 						},
 					},
-					"startUpScriptVersion": {
+					"passwordSecret": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Start up script version",
+							Description: "PasswordSecret is the name of the Secret which contains the password of the root user of TiDB in the `root` key. The password is empty if it is not set.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"replicas"},
+				Required: []string{"windows"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningWindow"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MetadataConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_GCTuningSettings(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Configures the sending of series metadata to remote storage.",
+				Description: "GCTuningSettings are the GC settings of TiKV, the unset ones are not changed.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"send": {
+					"gcLifeTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether metric metadata is sent to remote storage or not.",
-							Type:        []string{"boolean"},
+							Description: "GCLifeTime is the value of `tidb_gc_life_time`, which is the retention of the old versions of data, e.g. `10m`. It must be no less than 10m.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"sendInterval": {
+					"gcConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "How frequently metric metadata is sent to remote storage.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "GCConcurrency is the value of `tidb_gc_concurrency`, which is the number of the concurrent GC threads in the resolve locks step. -1 means it is decided by the number of TiKV stores.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
@@ -4502,153 +4619,175 @@ func schema_pkg_apis_pingcap_v1alpha1_MetadataConfig(ref common.ReferenceCallbac
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_MonitorContainer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_GCTuningWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MonitorContainer is the common attributes of the container of monitoring",
+				Description: "GCTuningWindow is a scheduled window of the GC settings",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"limits": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Name of the window, it is shown in the status when the window is active",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"requests": {
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Schedule is the cron expression of the start of the window, e.g. `0 1 * * *`. It is in the time zone of the cluster.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"baseImage": {
+					"duration": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Duration of the window, e.g. `4h`",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"version": {
+					"gcLifeTime": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "GCLifeTime is the value of `tidb_gc_life_time`, which is the retention of the old versions of data, e.g. `10m`. It must be no less than 10m.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"imagePullPolicy": {
+					"gcConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "GCConcurrency is the value of `tidb_gc_concurrency`, which is the number of the concurrent GC threads in the resolve locks step. -1 means it is decided by the number of TiKV stores.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"name", "schedule", "duration"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_GcsStorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "NGMonitoringSpec is spec of ng monitoring",
+				Description: "GcsStorageProvider represents the google cloud storage for storing backups.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"version": {
+					"projectId": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Description: "ProjectId represents the project that organizes all your Google Cloud Platform resources",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullPolicy": {
+					"location": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Description: "Location in which the gcs bucket is located.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullSecrets": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
-									},
-								},
-							},
+							Description: "Path is the full path where the backup is saved. The format of the path must be: \"<bucket-name>/<path-to-backup-file>\"",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"hostNetwork": {
+					"bucket": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"boolean"},
+							Description: "Bucket in which to store the backup data.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"affinity": {
+					"storageClass": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "StorageClass represents the storage class",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"priorityClassName": {
+					"objectAcl": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "ObjectAcl represents the access control list for new objects",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"bucketAcl": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "BucketAcl represents the access control list for new buckets",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "SecretName is the name of secret which stores the gcs service account credentials JSON.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"annotations": {
+					"prefix": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Prefix of the data path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"projectId"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_HelperSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HelperSpec contains details of helper component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image used to tail slow log and set kernel parameters if necessary, must have `tail` and `sysctl` installed Optional: Defaults to busybox:1.26.2. Recommended to set to 1.34.1 for new installations.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to the cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_IngressSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IngressSpec describe the ingress desired state for the target component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hosts describe the hosts for the ingress",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: "",
@@ -4659,9 +4798,9 @@ func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref common.ReferenceCallb
 							},
 						},
 					},
-					"labels": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Description: "Annotations describe the desired annotations for the ingress",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -4675,185 +4814,303 @@ func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref common.ReferenceCallb
 							},
 						},
 					},
-					"tolerations": {
+					"tls": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Description: "TLS configuration. Currently the Ingress only supports a single TLS port, 443. If multiple members of this list specify different hosts, they will be multiplexed on the same port according to the hostname specified through the SNI TLS extension, if the ingress controller fulfilling the ingress supports SNI.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+										Ref:     ref("k8s.io/api/networking/v1.IngressTLS"),
 									},
 								},
 							},
 						},
 					},
-					"podSecurityContext": {
-						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
-						},
-					},
-					"configUpdateStrategy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"env": {
+				},
+				Required: []string{"hosts"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/networking/v1.IngressTLS"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_InitContainerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitContainerSpec contains basic spec about a init container",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"envFrom": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"initContainers": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_InitSqlSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitSqlSource is a source of SQL statements stored in a ConfigMap or a Secret. Only one of configMapKeyRef and secretKeyRef should be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "Name of the source, it is used to identify the source in the status",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"additionalContainers": {
+					"configMapKeyRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "ConfigMapKeyRef selects a key of a ConfigMap which stores the SQL statements",
+							Ref:         ref("k8s.io/api/core/v1.ConfigMapKeySelector"),
 						},
 					},
-					"additionalVolumes": {
+					"secretKeyRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volumes of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Volume"),
-									},
-								},
-							},
+							Description: "SecretKeyRef selects a key of a Secret which stores the SQL statements",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
 						},
 					},
-					"additionalVolumeMounts": {
+					"retries": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volume mounts of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
-									},
-								},
-							},
+							Description: "Retries is the number of times a failed statement is retried Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"dnsConfig": {
+					"onFailure": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Description: "OnFailure is the action taken when a statement still fails after retries Optional: Defaults to Abort",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"dnsPolicy": {
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ConfigMapKeySelector", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_InitSqlSourceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitSqlSourceStatus is the execution progress of a SQL source",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
+							Description: "Name of the source",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the source",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"executedStatements": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "ExecutedStatements is the number of statements executed successfully",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"statefulSetUpdateStrategy": {
+					"failedStatements": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "FailedStatements is the number of statements still failed after retries",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"podManagementPolicy": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Description: "Message is the last error of the source",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_IsolationRead(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IsolationRead is the config for isolation read.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"engines": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Description: "Engines filters tidb-server access paths by engine type. imported from v3.1.0",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"suspendAction": {
-						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Log(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Log is the log section of config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"level": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level. Optional: Defaults to info",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"readinessProbe": {
+					"format": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Description: "Log format. one of json, text, or console. Optional: Defaults to text",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"disable-timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deprecated in v3.0.5. Use EnableTimestamp instead Disable automatic timestamps in output.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableTimestamp enables automatic timestamps in log output.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-error-stack": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableErrorStack enables annotating logs with the full stack error message.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"file": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File log config.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"),
+						},
+					},
+					"enable-slow-log": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"slow-query-file": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
+					"slow-threshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 300",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"expensive-threshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 10000",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"query-log-max-len": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 2048",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"record-plan-in-slow-log": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_LogTailerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogTailerSpec represents an optional log tailer sidecar container",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
 					"limits": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
@@ -4884,448 +5141,576 @@ func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref common.ReferenceCallb
 							},
 						},
 					},
-					"baseImage": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_MasterConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MasterConfig is the configuration of dm-master-server",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"log-level": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
+							Description: "Log level. Optional: Defaults to info",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"storageClassName": {
+					"log-file": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageClassName is the persistent volume for ng monitoring. Defaults to Kubernetes default storage class.",
+							Description: "File log config.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"storageVolumes": {
+					"log-format": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageVolumes configures additional storage for NG Monitoring pods.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
-									},
-								},
-							},
+							Description: "Log format. one of json or text.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"config": {
+					"rpc-timeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Config is the configuration of ng monitoring",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
+							Description: "RPC timeout when dm-master request to dm-worker Optional: Defaults to 30s",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rpc-rate-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RPC agent rate limit when dm-master request to dm-worker Optional: Defaults to 10",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"rpc-rate-burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RPC agent rate burst when dm-master request to dm-worker Optional: Defaults to 40",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"experimental": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dm-master's experimental config",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMExperimental"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMExperimental"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_OpenTracing(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_MasterKeyFileConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OpenTracing is the opentracing section of the config.",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enable": {
+					"method": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
+							Description: "Encrypyion method, use master key encryption data key Possible values: plaintext, aes128-ctr, aes192-ctr, aes256-ctr Optional: Default to plaintext optional",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"sampler": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler"),
-						},
-					},
-					"reporter": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter"),
-						},
-					},
-					"rpc-metrics": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Text file containing the key in hex form, end with '\n'",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"path"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_OpenTracingReporter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_MasterKeyKMSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OpenTracingReporter is the config for opentracing reporter. See https://godoc.org/github.com/uber/jaeger-client-go/config#ReporterConfig",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"queue-size": {
+					"key-id": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "AWS CMK key-id it can be find in AWS Console or use aws cli This field is required",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"buffer-flush-interval": {
+					"access-key": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Description: "AccessKey of AWS user, leave empty if using other authrization method optional",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"log-spans": {
+					"secret-access-key": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "SecretKey of AWS user, leave empty if using other authrization method optional",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"local-agent-host-port": {
+					"region": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Region of this KMS key Optional: Default to us-east-1 optional",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Used for KMS compatible KMS, such as Ceph, minio, If use AWS, leave empty optional",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"key-id"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_OpenTracingSampler(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_MasterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OpenTracingSampler is the config for opentracing sampler. See https://godoc.org/github.com/uber/jaeger-client-go/config#SamplerConfig",
+				Description: "MasterSpec contains details of dm-master members",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"type": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"param": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"number"},
-							Format: "double",
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"sampling-server-url": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"max-operations": {
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
 						},
 					},
-					"sampling-refresh-interval": {
+					"hostNetwork": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDConfig is the configuration of pd-server",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"force-new-cluster": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"enable-grpc-gateway": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"initial-cluster-token": {
+					"schedulerName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "set different tokens to prevent communication between PDs in different clusters.",
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"lease": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LeaderLease time, if leader doesn't update its TTL in etcd after lease time, etcd will expire the leader key and other servers can campaign the leader again. Etcd only supports seconds TTL, so here is second too. Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"log": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log related config.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig"),
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"log-file": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Backward compatibility.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"log-level": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"tso-save-interval": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TsoSaveInterval is the interval to save timestamp. Optional: Defaults to 3s",
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"configUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metric": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig"),
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-					"schedule": {
+					"envFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig"),
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
 						},
 					},
-					"replication": {
+					"initContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig"),
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"namespace": {
+					"additionalContainers": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig"),
+										Ref:     ref("k8s.io/api/core/v1.Container"),
 									},
 								},
 							},
 						},
 					},
-					"pd-server": {
+					"nativeSidecarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig"),
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"cluster-version": {
+					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
 						},
 					},
-					"quota-backend-bytes": {
+					"additionalVolumeMounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "QuotaBackendBytes Raise alarms when backend size exceeds the given quota. 0 means use the default quota. the default size is 2GB, the maximum is 8GB.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
 						},
 					},
-					"auto-compaction-mode": {
+					"dnsConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AutoCompactionMode is either 'periodic' or 'revision'. The default value is 'periodic'.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
 						},
 					},
-					"auto-compaction-retention-v2": {
+					"dnsPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AutoCompactionRetention is either duration string with time unit (e.g. '5m' for 5-minute), or revision unit (e.g. '5000'). If no time unit is provided and compaction mode is 'periodic', the unit defaults to hour. For example, '5' translates into 5-hour. The default retention is 1 hour. Before etcd v3.3.x, the type of retention is int. We add 'v2' suffix to make it backward compatible.",
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"tikv-interval": {
+					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TickInterval is the interval for etcd Raft tick.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"statefulSetUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"election-interval": {
+					"podManagementPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ElectionInterval is the interval for etcd Raft election.",
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enable-prevote": {
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Prevote is true to enable Raft Pre-Vote. If enabled, Raft runs an additional election phase to check whether it would get enough votes to win an election, thus minimizing disruptions. Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
 						},
 					},
-					"security": {
+					"suspendAction": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig"),
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
 						},
 					},
-					"label-property": {
+					"readinessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type: []string{"array"},
-										Items: &spec.SchemaOrArray{
-											Schema: &spec.Schema{
-												SchemaProps: spec.SchemaProps{
-													Default: map[string]interface{}{},
-													Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel"),
-												},
-											},
-										},
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"namespace-classifier": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated in v4.0.0 NamespaceClassifier is for classifying stores/regions into different namespaces. Optional: Defaults to true",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"dashboard": {
+					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DashboardConfig"),
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DashboardConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDLogConfig serializes log related config in toml/json.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"level": {
+					"baseImage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log level. Optional: Defaults to info",
+							Description: "Base image of the component, image tag is now allowed during validation",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"format": {
+					"service": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Log format. one of json, text, or console.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Service defines a Kubernetes service of Master cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterServiceSpec"),
 						},
 					},
-					"disable-timestamp": {
+					"maxFailoverCount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Disable automatic timestamps in output.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"file": {
+					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "File log config.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"),
+							Description: "The storageClassName of the persistent volume for dm-master data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"development": {
+					"storageSize": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Development puts the logger in development mode, which changes the behavior of DPanicLevel and takes stacktraces more liberally.",
-							Type:        []string{"boolean"},
+							Description: "StorageSize is the request storage size for dm-master. Defaults to \"10Gi\".",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"disable-caller": {
+					"dataSubDir": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableCaller stops annotating logs with the calling function's file name and line number. By default, all logs are annotated.",
-							Type:        []string{"boolean"},
+							Description: "Subdirectory within the volume to store dm-master Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/dm-master. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/dm-master/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"disable-stacktrace": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableStacktrace completely disables automatic stacktrace capturing. By default, stacktraces are captured for WarnLevel and above logs in development and ErrorLevel and above in production.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Config is the Configuration of dm-master-servers",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterConfigWraper"),
 						},
 					},
-					"disable-error-verbose": {
+					"startUpScriptVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableErrorVerbose stops annotating logs with the full verbose error message.",
-							Type:        []string{"boolean"},
+							Description: "Start up script version",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MasterServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_MetadataConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "Configures the sending of series metadata to remote storage.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"job": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"address": {
+					"send": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Whether metric metadata is sent to remote storage or not.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"interval": {
+					"sendInterval": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "How frequently metric metadata is sent to remote storage.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
@@ -5334,280 +5719,218 @@ func schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref common.ReferenceCallbac
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDNamespaceConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_MonitorContainer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PDNamespaceConfig is to overwrite the global setting for specific namespace",
+				Description: "MonitorContainer is the common attributes of the container of monitoring",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"leader-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "LeaderScheduleLimit is the max coexist leader schedules.",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"region-schedule-limit": {
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RegionScheduleLimit is the max coexist region schedules.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"replica-schedule-limit": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReplicaScheduleLimit is the max coexist replica schedules.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"merge-schedule-limit": {
+					"baseImage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MergeScheduleLimit is the max coexist merge schedules.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"hot-region-schedule-limit": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "HotRegionScheduleLimit is the max coexist hot region schedules.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"max-replicas": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MaxReplicas is the number of replicas for each region.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringConprofOffload(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PDReplicationConfig is the replication configuration.",
+				Description: "NGMonitoringConprofOffload is the object storage to archive the continuous profiling data, only one of S3 and GCS can be set",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"max-replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MaxReplicas is the number of replicas for each region. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"strictly-match-label": {
+					"s3": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StrictlyMatchLabel strictly checks if the label of TiKV is matched with LocaltionLabels. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
 						},
 					},
-					"enable-placement-rules": {
+					"gcs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "When PlacementRules feature is enabled. MaxReplicas and LocationLabels are not used anymore.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringConprofSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ScheduleConfig is the schedule configuration.",
+				Description: "NGMonitoringConprofSpec is spec of the continuous profiling data of ng monitoring",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"max-snapshot-count": {
-						SchemaProps: spec.SchemaProps{
-							Description: "If the snapshot count of one store is greater than this value, it will never be used as a source or target store. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"max-pending-peer-count": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 16",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"max-merge-region-size": {
-						SchemaProps: spec.SchemaProps{
-							Description: "If both the size of region is smaller than MaxMergeRegionSize and the number of rows in region is smaller than MaxMergeRegionKeys, it will try to merge with adjacent regions. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 20",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"max-merge-region-keys": {
+					"retention": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 200000",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Retention is how long the profiling data is kept, e.g. `72h`. Defaults to the retention of ng monitoring, which is 3 days.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"split-merge-interval": {
+					"offload": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SplitMergeInterval is the minimum interval time to permit merge after split. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 1h",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Offload archives the profiling data to the object storage, so that only the profiling data within the retention is kept in the data volume",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofOffload"),
 						},
 					},
-					"patrol-region-interval": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofOffload", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_NGMonitoringSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NGMonitoringSpec is spec of ng monitoring",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PatrolRegionInterval is the interval for scanning region during patrol. Immutable, change should be made through pd-ctl after cluster creation",
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"max-store-down-time": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MaxStoreDownTime is the max duration after which a store will be considered to be down if it hasn't reported heartbeats. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 30m",
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"leader-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "LeaderScheduleLimit is the max coexist leader schedules. Immutable, change should be made through pd-ctl after cluster creation. Optional: Defaults to 4. Imported from v3.1.0",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"region-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "RegionScheduleLimit is the max coexist region schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 2048",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"replica-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ReplicaScheduleLimit is the max coexist replica schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 64",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"merge-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MergeScheduleLimit is the max coexist merge schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 8",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"hot-region-schedule-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "HotRegionScheduleLimit is the max coexist hot region schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 4",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"hot-region-cache-hits-threshold": {
-						SchemaProps: spec.SchemaProps{
-							Description: "HotRegionCacheHitThreshold is the cache hits threshold of the hot region. If the number of times a region hits the hot cache is greater than this threshold, it is considered a hot region. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"tolerant-size-ratio": {
-						SchemaProps: spec.SchemaProps{
-							Description: "TolerantSizeRatio is the ratio of buffer size for balance scheduler. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
-							Type:        []string{"number"},
-							Format:      "double",
-						},
-					},
-					"low-space-ratio": {
-						SchemaProps: spec.SchemaProps{
-							Description: "\n     high space stage         transition stage           low space stage\n  |--------------------|-----------------------------|-------------------------|\n  ^                    ^                             ^                         ^\n  0       HighSpaceRatio * capacity       LowSpaceRatio * capacity          capacity\n\nLowSpaceRatio is the lowest usage ratio of store which regraded as low space. When in low space, store region score increases to very large and varies inversely with available size. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"number"},
-							Format:      "double",
-						},
-					},
-					"high-space-ratio": {
-						SchemaProps: spec.SchemaProps{
-							Description: "HighSpaceRatio is the highest usage ratio of store which regraded as high space. High space means there is a lot of spare capacity, and store region score varies directly with used size. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"number"},
-							Format:      "double",
-						},
-					},
-					"disable-raft-learner": {
-						SchemaProps: spec.SchemaProps{
-							Description: "DisableLearner is the option to disable using AddLearnerNode instead of AddNode Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"disable-remove-down-replica": {
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableRemoveDownReplica is the option to prevent replica checker from removing down replicas. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
 						},
 					},
-					"disable-replace-offline-replica": {
+					"hostNetwork": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableReplaceOfflineReplica is the option to prevent replica checker from repalcing offline replicas. Immutable, change should be made through pd-ctl after cluster creation",
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"disable-make-up-replica": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableMakeUpReplica is the option to prevent replica checker from making up replicas when replica count is less than expected. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"disable-remove-extra-replica": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableRemoveExtraReplica is the option to prevent replica checker from removing extra replicas. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"disable-location-replacement": {
+					"schedulerName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableLocationReplacement is the option to prevent replica checker from moving replica to a better location. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"disable-namespace-relocation": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableNamespaceRelocation is the option to prevent namespace checker from moving replica to the target namespace. Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"schedulers-v2": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Schedulers support for loding customized schedulers Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"schedulers-payload": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Only used to display",
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -5621,299 +5944,78 @@ func schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref common.ReferenceCallb
 							},
 						},
 					},
-					"enable-one-way-merge": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableOneWayMerge is the option to enable one way merge. This means a Region can only be merged into the next region of it. Imported from v3.1.0",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"enable-cross-table-merge": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableCrossTableMerge is the option to enable cross table merge. This means two Regions can be merged with different table IDs. This option only works when key type is \"table\". Imported from v3.1.0",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDSchedulerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDSchedulerConfig is customized scheduler configuration",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"type": {
+					"configUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"args": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
 									},
 								},
 							},
 						},
 					},
-					"disable": {
+					"envFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Immutable, change should be made through pd-ctl after cluster creation",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDSecurityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDSecurityConfig is the configuration for supporting tls.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"cacert-path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "CAPath is the path of file that contains list of trusted SSL CAs.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"cert-path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "CertPath is the path of file that contains X509 certificate in PEM format.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"key-path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "KeyPath is the path of file that contains X509 key in PEM format.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDServerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDServerConfig is the configuration for pd server.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"use-region-storage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "UseRegionStorage enables the independent region storage.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"metric-storage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MetricStorage is the cluster metric storage. Currently we use prometheus as metric storage, we may use PD/TiKV as metric storage later. Imported from v3.1.0",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PDSpec contains details of PD members",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"version": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"imagePullPolicy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"imagePullSecrets": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
-									},
-								},
-							},
-						},
-					},
-					"hostNetwork": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"affinity": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
-						},
-					},
-					"priorityClassName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"schedulerName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"nodeSelector": {
-						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"annotations": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"labels": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"tolerations": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
-						},
-					},
-					"podSecurityContext": {
-						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
-						},
-					},
-					"configUpdateStrategy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"env": {
-						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Description: "Extend the use scenarios for env",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
 									},
 								},
 							},
 						},
 					},
-					"envFrom": {
+					"initContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
+							Description: "Init containers of the components",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+										Ref:     ref("k8s.io/api/core/v1.Container"),
 									},
 								},
 							},
 						},
 					},
-					"initContainers": {
+					"additionalContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -5925,15 +6027,16 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) commo
 							},
 						},
 					},
-					"additionalContainers": {
+					"nativeSidecarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
@@ -5982,7 +6085,7 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) commo
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -6065,52 +6168,23 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) commo
 							},
 						},
 					},
-					"serviceAccount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specify a Service Account for pd",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The desired ready replicas",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
 					"baseImage": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Base image of the component, image tag is now allowed during validation",
-							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"service": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Service defines a Kubernetes service of PD cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec"),
-						},
-					},
-					"maxFailoverCount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
 					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for PD data storage. Defaults to Kubernetes default storage class.",
+							Description: "StorageClassName is the persistent volume for ng monitoring. Defaults to Kubernetes default storage class.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"storageVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageVolumes configure additional storage for PD pods.",
+							Description: "StorageVolumes configures additional storage for NG Monitoring pods.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -6122,1017 +6196,4712 @@ func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) commo
 							},
 						},
 					},
-					"dataSubDir": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Subdirectory within the volume to store PD Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/pd. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/pd/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
+							Description: "Config is the configuration of ng monitoring",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_NotificationSink(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NotificationSink describes an external sink of the events, e.g. Slack, PagerDuty or a generic webhook",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the sink, it must be unique among the sinks.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"config": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Config is the Configuration of pd-servers",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper"),
+							Description: "Type of the sink.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"tlsClientSecretName": {
+					"url": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate which used by Dashboard.",
+							Description: "URL is the Slack incoming webhook URL or the URL of the generic webhook. The PagerDuty Events API v2 endpoint is used for PagerDuty if it is not set. For the sinks of a TidbCluster, the host of the URL must be the Slack or PagerDuty endpoint, or be listed in the key `allowedHosts` of the notification ConfigMap of tidb-operator.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enableDashboardInternalProxy": {
+					"secretRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "(Deprecated) EnableDashboardInternalProxy would directly set `internal-proxy` in the `PdConfig`. Note that this is deprecated, we should just set `dashboard.internal-proxy` in `pd.config`.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "SecretRef refers to the key of a Secret which contains the URL of Slack and generic webhooks or the routing key of PagerDuty, it takes precedence over URL for Slack and generic webhooks. The Secret is in the namespace of the TidbCluster, or the namespace of the ConfigMap for the global sinks of tidb-operator.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
 						},
 					},
-					"mountClusterClientSecret": {
+					"reasons": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MountClusterClientSecret indicates whether to mount `cluster-client-secret` to the Pod",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Reasons are the reasons of the events to forward, e.g. `PDMemberDeleted` or `UpgradeFinished`. All the Warning events are forwarded if it is empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"startUpScriptVersion": {
+					"template": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Start up script version",
+							Description: "Template is the Go template of the notification message, the fields `.Namespace`, `.Cluster`, `.Kind`, `.Name`, `.Type`, `.Reason` and `.Message` of the event can be referred. Optional: Defaults to `[{{.Type}}] {{.Kind}} {{.Namespace}}/{{.Name}} {{.Reason}}: {{.Message}}`",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"minInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinInterval is the min interval between two notifications of the same reason of a cluster, the events in the interval are dropped. Optional: Defaults to 5m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
-				Required: []string{"replicas"},
+				Required: []string{"name", "type"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"k8s.io/api/core/v1.SecretKeySelector", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PDStoreLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_NotificationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PDStoreLabel is the config item of LabelPropertyConfig.",
+				Description: "NotificationSpec describes the external sinks which the events of a cluster are forwarded to",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"key": {
+					"sinks": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"value": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Sinks are the external sinks which the events are forwarded to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSink"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSink"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_Performance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_OpenTracing(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Performance is the performance section of the config.",
+				Description: "OpenTracing is the opentracing section of the config.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"max-procs": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"max-memory": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"stats-lease": {
+					"enable": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 3s",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"stmt-count-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 5000",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"feedback-probability": {
+					"sampler": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0.05",
-							Type:        []string{"number"},
-							Format:      "double",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler"),
 						},
 					},
-					"query-feedback-limit": {
+					"reporter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 512",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter"),
 						},
 					},
-					"pseudo-estimate-ratio": {
+					"rpc-metrics": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0.8",
-							Type:        []string{"number"},
-							Format:      "double",
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"force-priority": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingReporter", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracingSampler"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_OpenTracingReporter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OpenTracingReporter is the config for opentracing reporter. See https://godoc.org/github.com/uber/jaeger-client-go/config#ReporterConfig",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"queue-size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to NO_PRIORITY",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"bind-info-lease": {
+					"buffer-flush-interval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 3s",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"integer"},
+							Format: "int64",
 						},
 					},
-					"txn-total-size-limit": {
+					"log-spans": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 104857600",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"tcp-keep-alive": {
+					"local-agent-host-port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"cross-join": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_OpenTracingSampler(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OpenTracingSampler is the config for opentracing sampler. See https://godoc.org/github.com/uber/jaeger-client-go/config#SamplerConfig",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"run-auto-analyze": {
+					"param": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Type:   []string{"number"},
+							Format: "double",
 						},
 					},
-					"agg-push-down-join": {
+					"sampling-server-url": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
+							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"committer-concurrency": {
+					"max-operations": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"integer"},
 							Format: "int32",
 						},
 					},
-					"max-txn-ttl": {
+					"sampling-refresh-interval": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"integer"},
 							Format: "int64",
 						},
 					},
-					"txn-entry-count-limit": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated in v4.0.0 Optional: Defaults to 300000",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
 				},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PessimisticTxn(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_OperationTimeoutPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PessimisticTxn is the config for pessimistic transaction.",
+				Description: "OperationTimeoutPolicy is the policy of the timeouts of the long-running operations of the components. The timer of an operation starts when the component enters the Upgrade or Scale phase, and restarts when the spec of the cluster is changed.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enable": {
+					"upgrade": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable must be true for 'begin lock' or session variable to start a pessimistic transaction. Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Upgrade is the max duration of the rolling upgrade of a component. The upgrade never times out if it is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"max-retry-count": {
+					"scaleIn": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The max count of retry for a single statement in a pessimistic transaction. Optional: Defaults to 256",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ScaleIn is the max duration of the scaling in of a component. The scaling in never times out if it is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"scaleOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleOut is the max duration of the scaling out of a component. The scaling out never times out if it is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the action taken when an operation times out. Alert: the timeout is reported by the condition and a warning event only. Abort: the operation is also halted, i.e. no more pods are upgraded and no more replicas are scaled, and the stores being deleted by the scaling in are restored to Up. The operation is resumed after the spec of the cluster is changed. Optional: Defaults to Alert",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PlanCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_PDConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PlanCache is the PlanCache section of the config.",
+				Description: "PDConfig is the configuration of pd-server",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"force-new-cluster": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"boolean"},
 							Format: "",
 						},
 					},
-					"capacity": {
+					"enable-grpc-gateway": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"shards": {
+					"initial-cluster-token": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Plugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Plugin is the config for plugin",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"dir": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "set different tokens to prevent communication between PDs in different clusters.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"load": {
+					"lease": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "LeaderLease time, if leader doesn't update its TTL in etcd after lease time, etcd will expire the leader key and other servers can campaign the leader again. Etcd only supports seconds TTL, so here is second too. Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PreparedPlanCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PreparedPlanCache is the PreparedPlanCache section of the config.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enabled": {
+					"log": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Log related config.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig"),
 						},
 					},
-					"capacity": {
+					"log-file": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 100",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Backward compatibility.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"memory-guard-ratio": {
+					"log-level": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 0.1",
-							Type:        []string{"number"},
-							Format:      "double",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Probe(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Probe contains details of probing tidb. default probe by TCPPort on tidb 4000 / tikv 20160 / pd 2349.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"type": {
+					"tso-save-interval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "\"tcp\" will use TCP socket to connect component port.\n\n\"command\" will probe the status api of tidb. This will use curl command to request tidb, before v4.0.9 there is no curl in the image, So do not use this before v4.0.9.",
+							Description: "TsoSaveInterval is the interval to save timestamp. Optional: Defaults to 3s",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"initialDelaySeconds": {
+					"metric": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of seconds after the container has started before liveness probes are initiated. Default to 10 seconds.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig"),
 						},
 					},
-					"periodSeconds": {
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Description: "How often (in seconds) to perform the probe. Default to Kubernetes default (10 seconds). Minimum value is 1.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_PrometheusConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Config  is the the desired state of Prometheus Configuration",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"configMapRef": {
+					"replication": {
 						SchemaProps: spec.SchemaProps{
-							Description: "User can mount prometheus config with external configMap. The external configMap must contain `prometheus-config` key in data.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"),
+							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig"),
 						},
 					},
-					"commandOptions": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "user can  use it specify prometheus command options",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig"),
 									},
 								},
 							},
 						},
 					},
-					"ruleConfigRef": {
-						SchemaProps: spec.SchemaProps{
-							Description: "User can mount prometheus rule config with external configMap. The external configMap must use the key with suffix `.rules.yml`.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"),
-						},
-					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_ProxyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "ProxyConfig is the configuration of TiFlash proxy process. All the configurations are same with those of TiKV except adding `engine-addr` in the TiKVServerConfig",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"log-level": {
+					"pd-server": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to info",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig"),
 						},
 					},
-					"log-file": {
+					"cluster-version": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"log-rotation-timespan": {
+					"quota-backend-bytes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 24h",
+							Description: "QuotaBackendBytes Raise alarms when backend size exceeds the given quota. 0 means use the default quota. the default size is 2GB, the maximum is 8GB.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"panic-when-unexpected-key-or-data": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"server": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashServerConfig"),
-						},
-					},
-					"storage": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageConfig"),
-						},
-					},
-					"raftstore": {
+					"auto-compaction-mode": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftstoreConfig"),
+							Description: "AutoCompactionMode is either 'periodic' or 'revision'. The default value is 'periodic'.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"rocksdb": {
+					"auto-compaction-retention-v2": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVDbConfig"),
+							Description: "AutoCompactionRetention is either duration string with time unit (e.g. '5m' for 5-minute), or revision unit (e.g. '5000'). If no time unit is provided and compaction mode is 'periodic', the unit defaults to hour. For example, '5' translates into 5-hour. The default retention is 1 hour. Before etcd v3.3.x, the type of retention is int. We add 'v2' suffix to make it backward compatible.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"coprocessor": {
+					"tikv-interval": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVCoprocessorConfig"),
+							Description: "TickInterval is the interval for etcd Raft tick.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"readpool": {
+					"election-interval": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVReadPoolConfig"),
+							Description: "ElectionInterval is the interval for etcd Raft election.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"raftdb": {
+					"enable-prevote": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftDBConfig"),
+							Description: "Prevote is true to enable Raft Pre-Vote. If enabled, Raft runs an additional election phase to check whether it would get enough votes to win an election, thus minimizing disruptions. Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"import": {
+					"security": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVImportConfig"),
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig"),
 						},
 					},
-					"gc": {
+					"label-property": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGCConfig"),
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Default: map[string]interface{}{},
+													Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel"),
+												},
+											},
+										},
+									},
+								},
+							},
 						},
 					},
-					"pd": {
+					"namespace-classifier": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVPDConfig"),
+							Description: "Deprecated in v4.0.0 NamespaceClassifier is for classifying stores/regions into different namespaces. Optional: Defaults to true",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"security": {
+					"dashboard": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSecurityConfig"),
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DashboardConfig"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashServerConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVCoprocessorConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVDbConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGCConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVImportConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVPDConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftDBConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftstoreConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVReadPoolConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSecurityConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageConfig"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DashboardConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLogConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMetricConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDNamespaceConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDReplicationConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDScheduleConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSecurityConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDServerConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDStoreLabel"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_ProxyProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_PDLeaderLocality(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ProxyProtocol is the PROXY protocol section of the config.",
+				Description: "PDLeaderLocality keeps the PD leader in the primary data plane of a cluster deployed across multiple Kubernetes clusters, since the latency increases if the leader is in a remote region.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"networks": {
+					"primaryClusterDomain": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PROXY protocol acceptable client networks. Empty *string means disable PROXY protocol, * means all networks.",
+							Description: "PrimaryClusterDomain is the cluster domain of the primary data plane. If the PD leader moves to other data planes, it is transferred back to a healthy PD member of the TidbCluster whose `spec.clusterDomain` is the same. All the TidbClusters of the cluster should set the same value.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"header-timeout": {
-						SchemaProps: spec.SchemaProps{
-							Description: "PROXY protocol header read timeout, Unit is second.",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
 				},
+				Required: []string{"primaryClusterDomain"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_PumpSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_PDLogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PumpSpec contains details of Pump members",
+				Description: "PDLogConfig serializes log related config in toml/json.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"version": {
+					"level": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Description: "Log level. Optional: Defaults to info",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullPolicy": {
+					"format": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Description: "Log format. one of json, text, or console.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullSecrets": {
+					"disable-timestamp": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
-									},
-								},
-							},
+							Description: "Disable automatic timestamps in output.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"hostNetwork": {
+					"file": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Description: "File log config.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"),
+						},
+					},
+					"development": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Development puts the logger in development mode, which changes the behavior of DPanicLevel and takes stacktraces more liberally.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"affinity": {
+					"disable-caller": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "DisableCaller stops annotating logs with the calling function's file name and line number. By default, all logs are annotated.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"priorityClassName": {
+					"disable-stacktrace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
+							Description: "DisableStacktrace completely disables automatic stacktrace capturing. By default, stacktraces are captured for WarnLevel and above logs in development and ErrorLevel and above in production.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"disable-error-verbose": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
+							Description: "DisableErrorVerbose stops annotating logs with the full verbose error message.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FileLogConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDMetricConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"job": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"annotations": {
+					"address": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"labels": {
+					"interval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"tolerations": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDNamespaceConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDNamespaceConfig is to overwrite the global setting for specific namespace",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"leader-schedule-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
+							Description: "LeaderScheduleLimit is the max coexist leader schedules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"podSecurityContext": {
+					"region-schedule-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+							Description: "RegionScheduleLimit is the max coexist region schedules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"configUpdateStrategy": {
+					"replica-schedule-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ReplicaScheduleLimit is the max coexist replica schedules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"env": {
+					"merge-schedule-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
-									},
-								},
-							},
+							Description: "MergeScheduleLimit is the max coexist merge schedules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"envFrom": {
+					"hot-region-schedule-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
-									},
-								},
-							},
+							Description: "HotRegionScheduleLimit is the max coexist hot region schedules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"initContainers": {
+					"max-replicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "MaxReplicas is the number of replicas for each region.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"additionalContainers": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRecovery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRecovery rebuilds PD of a TidbCluster from the surviving TiKV stores after the data of all the PD members is lost. It replaces the manual pd-recover runbook: the cluster ID and the alloc ID are resolved, a new PD cluster is bootstrapped and recovered by pd-recover, then the stores rejoin it. The steps which destroy data or make the stores serve again wait for the confirmation in spec.confirmedSteps.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"additionalVolumes": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volumes of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Volume"),
-									},
-								},
-							},
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"additionalVolumeMounts": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volume mounts of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
-									},
-								},
-							},
+							Description: "Spec contains all spec about the recovery.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecoverySpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecoverySpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRecoveryList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRecoveryList is a PDRecovery list.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecovery"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDRecovery"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDRecoverySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDRecoverySpec is spec of PDRecovery.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the name of the TidbCluster whose PD is rebuilt, it must be in the same namespace as the PDRecovery.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterID is the ID of the cluster which the surviving TiKV stores belong to. It must be set if the cluster ID in the status of the TidbCluster is overwritten by a new PD cluster. Optional: Defaults to the cluster ID in the status of the TidbCluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"allocID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllocID is the ID from which the recovered PD allocates the IDs, it must be greater than all the IDs allocated by the lost PD, including the IDs of the regions. Optional: Defaults to the largest store ID in the status of the TidbCluster plus 100000000",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"confirmedSteps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfirmedSteps are the steps which are confirmed to run, the steps RebuildPD and RejoinStores wait until they are listed here. Check the cluster ID and the alloc ID in the status before confirming RebuildPD.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources of the pd-recover job.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDReplicationConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDReplicationConfig is the replication configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"max-replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxReplicas is the number of replicas for each region. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"strictly-match-label": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StrictlyMatchLabel strictly checks if the label of TiKV is matched with LocaltionLabels. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-placement-rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "When PlacementRules feature is enabled. MaxReplicas and LocationLabels are not used anymore.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDScheduleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScheduleConfig is the schedule configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"max-snapshot-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If the snapshot count of one store is greater than this value, it will never be used as a source or target store. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"max-pending-peer-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 16",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"max-merge-region-size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If both the size of region is smaller than MaxMergeRegionSize and the number of rows in region is smaller than MaxMergeRegionKeys, it will try to merge with adjacent regions. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 20",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"max-merge-region-keys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 200000",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"split-merge-interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SplitMergeInterval is the minimum interval time to permit merge after split. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 1h",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"patrol-region-interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PatrolRegionInterval is the interval for scanning region during patrol. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"max-store-down-time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxStoreDownTime is the max duration after which a store will be considered to be down if it hasn't reported heartbeats. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 30m",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"leader-schedule-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaderScheduleLimit is the max coexist leader schedules. Immutable, change should be made through pd-ctl after cluster creation. Optional: Defaults to 4. Imported from v3.1.0",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"region-schedule-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegionScheduleLimit is the max coexist region schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 2048",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"replica-schedule-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicaScheduleLimit is the max coexist replica schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 64",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"merge-schedule-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MergeScheduleLimit is the max coexist merge schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 8",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"hot-region-schedule-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HotRegionScheduleLimit is the max coexist hot region schedules. Immutable, change should be made through pd-ctl after cluster creation Optional: Defaults to 4",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"hot-region-cache-hits-threshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HotRegionCacheHitThreshold is the cache hits threshold of the hot region. If the number of times a region hits the hot cache is greater than this threshold, it is considered a hot region. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tolerant-size-ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TolerantSizeRatio is the ratio of buffer size for balance scheduler. Immutable, change should be made through pd-ctl after cluster creation. Imported from v3.1.0",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"low-space-ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "\n     high space stage         transition stage           low space stage\n  |--------------------|-----------------------------|-------------------------|\n  ^                    ^                             ^                         ^\n  0       HighSpaceRatio * capacity       LowSpaceRatio * capacity          capacity\n\nLowSpaceRatio is the lowest usage ratio of store which regraded as low space. When in low space, store region score increases to very large and varies inversely with available size. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"high-space-ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HighSpaceRatio is the highest usage ratio of store which regraded as high space. High space means there is a lot of spare capacity, and store region score varies directly with used size. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"disable-raft-learner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableLearner is the option to disable using AddLearnerNode instead of AddNode Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-remove-down-replica": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableRemoveDownReplica is the option to prevent replica checker from removing down replicas. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-replace-offline-replica": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableReplaceOfflineReplica is the option to prevent replica checker from repalcing offline replicas. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-make-up-replica": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableMakeUpReplica is the option to prevent replica checker from making up replicas when replica count is less than expected. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-remove-extra-replica": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableRemoveExtraReplica is the option to prevent replica checker from removing extra replicas. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-location-replacement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableLocationReplacement is the option to prevent replica checker from moving replica to a better location. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"disable-namespace-relocation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableNamespaceRelocation is the option to prevent namespace checker from moving replica to the target namespace. Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"schedulers-v2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedulers support for loding customized schedulers Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig"),
+									},
+								},
+							},
+						},
+					},
+					"schedulers-payload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Only used to display",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"enable-one-way-merge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableOneWayMerge is the option to enable one way merge. This means a Region can only be merged into the next region of it. Imported from v3.1.0",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-cross-table-merge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableCrossTableMerge is the option to enable cross table merge. This means two Regions can be merged with different table IDs. This option only works when key type is \"table\". Imported from v3.1.0",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSchedulerConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDSchedulerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDSchedulerConfig is customized scheduler configuration",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"disable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Immutable, change should be made through pd-ctl after cluster creation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDSecurityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDSecurityConfig is the configuration for supporting tls.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cacert-path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CAPath is the path of file that contains list of trusted SSL CAs.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cert-path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertPath is the path of file that contains X509 certificate in PEM format.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key-path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyPath is the path of file that contains X509 key in PEM format.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDServerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDServerConfig is the configuration for pd server.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"use-region-storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UseRegionStorage enables the independent region storage.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"metric-storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetricStorage is the cluster metric storage. Currently we use prometheus as metric storage, we may use PD/TiKV as metric storage later. Imported from v3.1.0",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDMSSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDMSSpec contains details of a PD microservice",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"hostNetwork": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"configUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"initContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"additionalContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumeMounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
+						},
+					},
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"statefulSetUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podManagementPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"suspendAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the microservice, each microservice is deployed by its own StatefulSet",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify a Service Account for the microservice",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"baseImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the Configuration of the microservice",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper"),
+						},
+					},
+				},
+				Required: []string{"name", "replicas"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDSpec contains details of PD members",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"hostNetwork": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"configUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"initContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"additionalContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumeMounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
+						},
+					},
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"statefulSetUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podManagementPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"suspendAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"serviceAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify a Service Account for pd",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"baseImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service defines a Kubernetes service of PD cluster. Optional: Defaults to `.spec.services` in favor of backward compatibility",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec"),
+						},
+					},
+					"maxFailoverCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover. Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failover": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Failover is the configurations of failover",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover"),
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The storageClassName of the persistent volume for PD data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageVolumes configure additional storage for PD pods.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+									},
+								},
+							},
+						},
+					},
+					"dataSubDir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subdirectory within the volume to store PD Data. By default, the data is stored in the root directory of volume which is mounted at /var/lib/pd. Specifying this will change the data directory to a subdirectory, e.g. /var/lib/pd/data if you set the value to \"data\". It's dangerous to change this value for a running cluster as it will upgrade your cluster to use a new storage directory. Defaults to \"\" (volume's root).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the Configuration of pd-servers",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper"),
+						},
+					},
+					"tlsClientSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate which used by Dashboard.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enableDashboardInternalProxy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "(Deprecated) EnableDashboardInternalProxy would directly set `internal-proxy` in the `PdConfig`. Note that this is deprecated, we should just set `dashboard.internal-proxy` in `pd.config`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"mountClusterClientSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountClusterClientSecret indicates whether to mount `cluster-client-secret` to the Pod",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"startUpScriptVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start up script version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podDisruptionBudget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodDisruptionBudget configures the PodDisruptionBudget of PD pods. No PodDisruptionBudget is created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec"),
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the mode of PD cluster, in \"ms\" mode PD only serves as the API service and the TSO and scheduling services are deployed by `spec.pdms`. Optional: Defaults to \"\", which means PD serves all the services",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"leaderLocality": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaderLocality keeps the PD leader in the primary data plane if the cluster is deployed across multiple Kubernetes clusters.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLeaderLocality"),
+						},
+					},
+					"scaleInHook": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleInHook is the check run before a PD member is removed during scale-in, the scale-in proceeds only if the check passes.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHook"),
+						},
+					},
+				},
+				Required: []string{"replicas"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDLeaderLocality", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHook", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PDStoreLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PDStoreLabel is the config item of LabelPropertyConfig.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PeerRegistry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PeerRegistry is the registry served by the discovery service of one of the TidbClusters deployed across Kubernetes clusters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the discovery service which serves the registry, it must be reachable from all the Kubernetes clusters, e.g. http://basic-discovery.pingcap.svc.cluster1.local:10261",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the name which the TidbClusters of the same deployment register with Optional: Defaults to the name of the TidbCluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Performance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Performance is the performance section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"max-procs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"max-memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"stats-lease": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 3s",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stmt-count-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 5000",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"feedback-probability": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 0.05",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"query-feedback-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 512",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"pseudo-estimate-ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 0.8",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"force-priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to NO_PRIORITY",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bind-info-lease": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 3s",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"txn-total-size-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 104857600",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tcp-keep-alive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cross-join": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"run-auto-analyze": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"agg-push-down-join": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"committer-concurrency": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"max-txn-ttl": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"txn-entry-count-limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deprecated in v4.0.0 Optional: Defaults to 300000",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PessimisticTxn(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PessimisticTxn is the config for pessimistic transaction.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable must be true for 'begin lock' or session variable to start a pessimistic transaction. Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"max-retry-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The max count of retry for a single statement in a pessimistic transaction. Optional: Defaults to 256",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PlanCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PlanCache is the PlanCache section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Plugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Plugin is the config for plugin",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"dir": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"load": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PodDisruptionBudgetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodDisruptionBudgetSpec describes the PodDisruptionBudget created for the pods of a component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxUnavailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUnavailable is the max number of pods that can be unavailable during a voluntary disruption. Only one of MaxUnavailable and MinAvailable can be set. Optional: Defaults to the value computed from the quorum of the component if MinAvailable is not set",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"minAvailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinAvailable is the min number of pods that must be available during a voluntary disruption. Only one of MaxUnavailable and MinAvailable can be set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PreparedPlanCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PreparedPlanCache is the PreparedPlanCache section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 100",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"memory-guard-ratio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 0.1",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Probe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Probe contains details of probing tidb. default probe by TCPPort on tidb 4000 / tikv 20160 / pd 2349.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "\"tcp\" will use TCP socket to connect component port.\n\n\"command\" will probe the status api of tidb. This will use curl command to request tidb, before v4.0.9 there is no curl in the image, So do not use this before v4.0.9.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"initialDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of seconds after the container has started before liveness probes are initiated. Default to 10 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"periodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How often (in seconds) to perform the probe. Default to Kubernetes default (10 seconds). Minimum value is 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PrometheusConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Config  is the the desired state of Prometheus Configuration",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"configMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "User can mount prometheus config with external configMap. The external configMap must contain `prometheus-config` key in data.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"),
+						},
+					},
+					"commandOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "user can  use it specify prometheus command options",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"ruleConfigRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "User can mount prometheus rule config with external configMap. The external configMap must use the key with suffix `.rules.yml`.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ConfigMapRef"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ProxyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProxyConfig is the configuration of TiFlash proxy process. All the configurations are same with those of TiKV except adding `engine-addr` in the TiKVServerConfig",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"log-level": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to info",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"log-file": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"log-rotation-timespan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 24h",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"panic-when-unexpected-key-or-data": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"server": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashServerConfig"),
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageConfig"),
+						},
+					},
+					"raftstore": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftstoreConfig"),
+						},
+					},
+					"rocksdb": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVDbConfig"),
+						},
+					},
+					"coprocessor": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVCoprocessorConfig"),
+						},
+					},
+					"readpool": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVReadPoolConfig"),
+						},
+					},
+					"raftdb": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftDBConfig"),
+						},
+					},
+					"import": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVImportConfig"),
+						},
+					},
+					"gc": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGCConfig"),
+						},
+					},
+					"pd": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVPDConfig"),
+						},
+					},
+					"security": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSecurityConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.FlashServerConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVCoprocessorConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVDbConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGCConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVImportConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVPDConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftDBConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVRaftstoreConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVReadPoolConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSecurityConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStorageConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ProxyProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProxyProtocol is the PROXY protocol section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"networks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PROXY protocol acceptable client networks. Empty *string means disable PROXY protocol, * means all networks.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"header-timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PROXY protocol header read timeout, Unit is second.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_PumpSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PumpSpec contains details of Pump members",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"hostNetwork": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"configUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"initContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"additionalContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"additionalVolumeMounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
 						},
 					},
 					"dnsConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"statefulSetUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podManagementPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"suspendAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"serviceAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify a Service Account for pump",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"baseImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The storageClassName of the persistent volume for Pump data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The configuration of Pump cluster.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
+						},
+					},
+				},
+				Required: []string{"replicas"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_QueueConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QueueConfig allows the tuning of remote_write queue_config parameters. This object is referenced in the RemoteWriteSpec object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of samples to buffer per shard before we start dropping them.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"minShards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinShards is the minimum number of shards, i.e. amount of concurrency. Only valid in Prometheus versions 2.6.0 and newer.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxShards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of shards, i.e. amount of concurrency.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxSamplesPerSend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of samples per send.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"batchSendDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum time sample will wait in buffer.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of times to retry a batch on recoverable errors.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"minBackoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "On recoverable errors, backoff exponentially.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxBackoff": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RaftLogVolumeClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RaftLogVolumeClaim is the dedicated volume for the raft log of TiKV",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassName is the storage class of the volume. Defaults to spec.tikv.storageClassName",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageSize is the request size of the volume",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"storageSize"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RelabelConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RelabelConfig allows dynamic rewriting of the label set, being applied to samples before ingestion. It defines `<metric_relabel_configs>`-section of Prometheus configuration. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sourceLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A list of labels from which values are taken and concatenated with the configured separator in order.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"separator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Separator is the string between concatenated values from the source labels.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"regex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regular expression against which the extracted value is matched. Default is '(.*)'",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"modulus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Modulus to take of the hash of concatenated values from the source labels.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"targetLabel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetLabel is the label to which the resulting string is written in a replacement. Regexp interpolation is allowed for the replace action.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replacement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replacement is the regex replacement pattern to be used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the action to be performed for the relabeling.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RemoteWriteSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemoteWriteSpec defines the remote_write configuration for prometheus.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The URL of the endpoint to send samples to.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the remote write queue, must be unique if specified. The name is used in metrics and logging in order to differentiate queues. Only valid in Prometheus versions 2.15.0 and newer.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"remoteTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"writeRelabelConfigs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The list of remote write relabel configurations.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RelabelConfig"),
+									},
+								},
+							},
+						},
+					},
+					"basicAuth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BasicAuth for the URL.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAuth"),
+						},
+					},
+					"bearerToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File to read bearer token for remote write.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bearerTokenFile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File to read bearer token for remote write.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tlsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS Config to use for remote write.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSConfig"),
+						},
+					},
+					"proxyUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Proxy url",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"queueConfig": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.QueueConfig"),
+						},
+					},
+					"metadataConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetadataConfig configures the sending of series metadata to remote storage. Only valid in Prometheus versions 2.23.0 and newer.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MetadataConfig"),
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Custom HTTP headers to be sent along with each remote write request. Be aware that headers that are set by Prometheus itself can't be overwritten. Only valid in Prometheus versions 2.25.0 and newer.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAuth", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MetadataConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.QueueConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RelabelConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ResourceOverride(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceOverride is a patch applied to a resource generated by tidb-operator",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the generated resource, only the StatefulSets and Services of the components are supported",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the generated resource, e.g. basic-tikv",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the patch. StrategicMerge: a strategic merge patch, the same as `kubectl patch --type strategic`. JSON: a JSON patch of RFC 6902, the same as `kubectl patch --type json`. Optional: Defaults to StrategicMerge",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"patch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patch in YAML or JSON",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"kind", "name", "patch"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Restore(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Restore represents the restoration of backup of a tidb cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RestoreList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreList contains a list of Restore.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Restore"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Restore"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RestoreResumePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreResumePolicy is the policy to resume the failed restore from checkpoint.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxRetryTimes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetryTimes is the max times to relaunch the failed restore job",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RestoreScatterRegions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreScatterRegions is the config of scattering the regions of the restored tables",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"retryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryLimit is the times PD retries to scatter each region. Optional: Defaults to 5",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the max duration to wait for the scattering to complete, the restore fails if it's not completed in time. Optional: Defaults to 30m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RestoreSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreSource is the cluster which the backup to restore is taken from.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the name of the source cluster",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterNamespace is the namespace of the source cluster Optional: Defaults to the namespace of the restore",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RestoreSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreSpec contains the specification for a restore of a tidb cluster backup.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following builtin env vars will be overwritten by values set here - S3_PROVIDER - S3_ENDPOINT - AWS_REGION - AWS_ACL - AWS_STORAGE_CLASS - AWS_DEFAULT_REGION - AWS_ACCESS_KEY_ID - AWS_SECRET_ACCESS_KEY - GCS_PROJECT_ID - GCS_OBJECT_ACL - GCS_BUCKET_ACL - GCS_LOCATION - GCS_STORAGE_CLASS - GCS_SERVICE_ACCOUNT_JSON_KEY - BR_LOG_TO_TERM",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "To is the tidb cluster that needs to restore.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig"),
+						},
+					},
+					"backupType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the backup type for tidb cluster and only used when Mode = snapshot, such as full, db, table.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"restoreMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the restore mode. such as snapshot or pitr.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pitrRestoredTs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PitrRestoredTs is the pitr restored ts.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"logRestoreStartTs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogRestoreStartTs is the start timestamp which log restore from and it will be used in the future.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tikvGCLifeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TikvGCLifeTime is to specify the safe gc life time for restore. The time limit during which data is retained for each GC, in the format of Go Duration. When a GC happens, the current time minus this value is the safe point.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
+						},
+					},
+					"gcs": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
+						},
+					},
+					"azblob": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider"),
+						},
+					},
+					"local": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider"),
+						},
+					},
+					"pitrFullBackupStorageProvider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PitrFullBackupStorageProvider configures where and how pitr dependent full backup should be stored.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageProvider"),
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The storageClassName of the persistent volume for Restore data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageSize is the request storage size for backup job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"br": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BR is the configs for BR.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption is the settings to decrypt the encrypted backup data, it must be the same as the backup.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEncryption"),
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base tolerations of restore Pods, components may add more tolerations upon this respectively",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity of restore Pods",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"useKMS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Use KMS to decrypt the secrets",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"serviceAccount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specify service account of restore",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"toolImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ToolImage specifies the tool image used in `Restore`, which supports BR and TiDB Lightning images. For examples `spec.toolImage: pingcap/br:v4.0.8` or `spec.toolImage: pingcap/tidb-lightning:v4.0.8` For BR image, if it does not contain tag, Pod will use image 'ToolImage:${TiKV_Version}'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"tableFilter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TableFilter means Table filter expression for 'db.table' matching. BR supports this from v4.0.3.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of Restore Job Pods",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resumePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResumePolicy is the policy to relaunch the restore job after it failed, the relaunched job resumes the restore from the checkpoint of BR, which requires BR v7.1.0 or later. It's only valid for snapshot and pitr restore with BR. The restore is marked as failed directly if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreResumePolicy"),
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source is the cluster which the backup is taken from. If it's different from the target cluster in `spec.br`, the references to the source cluster in the restore, i.e. the TiDB host in `spec.to`, the TLS client secret name and the instance label, are remapped to the target cluster. So the restore can be copied from the backup of the source cluster to clone it into a cluster with a different name or namespace, e.g. a staging cluster. It's only valid for restore with BR.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSource"),
+						},
+					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification is the way to verify the restored data after the data is restored, a verification job is launched to verify the restored tables and the restore fails if any of them fails the verification. It's only valid for snapshot restore with BR, and `spec.to` is required to access the restored tables. Optional: Defaults to None",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scatterRegions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScatterRegions scatters the regions of the restored tables by PD after the data is restored, and the restore is not completed until the scattering completes, to avoid the hot spot stores after large restores. It's only valid for snapshot and pitr restore with BR, and `spec.to` is required to find the restored tables.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreScatterRegions"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BackupEncryption", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreResumePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreScatterRegions", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSource", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_RollingUpdateStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RollingUpdateStrategy describes the partitioned rolling update of a component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Canary is the number of pods upgraded before the rolling update pauses, the pods are upgraded from the highest ordinal. The rolling update continues after the annotation `tidb.pingcap.com/<component>-canary-promoted` of the TidbCluster is set to the update revision of the component StatefulSet, i.e. `status.<component>.statefulSet.updateRevision`. The component stays in the Upgrade phase while the rolling update pauses. Optional: Defaults to nil, which means the rolling update does not pause",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_S3StorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "S3StorageProvider represents a S3 compliant storage for storing backups.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"provider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provider represents the specific storage provider that implements the S3 interface",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Region in which the S3 compatible bucket is located.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the full path where the backup is saved. The format of the path must be: \"<bucket-name>/<path-to-backup-file>\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bucket in which to store the backup data.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint of S3 compatible storage service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClass represents the storage class",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"acl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Acl represents access control permissions for this bucket",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretName is the name of secret which stores S3 compliant storage access key and secret key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prefix of the data path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SSE Sever-Side Encryption.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"options": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Options Rclone options for backup and restore with dumpling and lightning.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"provider"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_SafeTLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SafeTLSConfig specifies safe TLS configuration parameters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ca": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Struct containing the CA cert to use for the targets.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+						},
+					},
+					"cert": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Struct containing the client cert file for the targets.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+						},
+					},
+					"keySecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing the client key file for the targets.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"serverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Used to verify the hostname for the targets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"insecureSkipVerify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Disable target certificate validation.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ScaleInHTTPHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScaleInHTTPHook is the HTTP callback of the scale-in hook",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the HTTP endpoint",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds of the request Optional: Defaults to 10",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ScaleInHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScaleInHook is a user defined check run by the operator before a pod is scaled in, it can be used to check the capacity or the replication safety. Exactly one of HTTP and Job should be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP posts the scale-in request in JSON to an HTTP endpoint, the check passes if the response status is 2xx.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHTTPHook"),
+						},
+					},
+					"job": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Job runs a Job for each pod to be scaled in, the check passes if the Job succeeds. The Job is not retried once it fails, delete the Job to run the check again.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInJobHook"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHTTPHook", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInJobHook"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ScaleInJobHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScaleInJobHook is the Job of the scale-in hook. The information of the pod to be scaled in is passed to the containers by the environment variables SCALE_IN_NAMESPACE, SCALE_IN_CLUSTER, SCALE_IN_COMPONENT, SCALE_IN_POD and SCALE_IN_STORE_ID.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template of the pods of the Job, the restart policy is Never if it is not set",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.PodTemplateSpec"),
+						},
+					},
+					"backoffLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackoffLimit of the Job Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"template"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.PodTemplateSpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ScaleOutBalanceGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScaleOutBalanceGate is the gate to wait for the regions and leaders to be balanced after scaling out",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxSkewPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSkewPercent is the max skew of the region and leader counts among the stores in percentage, which is the difference between the max and the min counts divided by the max count.\n\nDefaults to 20",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the max duration to wait for the balance since the scaling out begins, the scaling is marked as complete once the timeout is hit even if the stores are not balanced.\n\nDefaults to 1h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_SecretRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretRef indicates to secret ref",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_SecretReplication(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretReplication is the Secrets replicated from the namespace of the TidbCluster referenced by `spec.cluster`. The replicas are labeled with the source namespace and annotated with the hash of the source data, they are updated when the source changes, and deleted when they are not replicated any more. The referenced TidbCluster must allow the namespace by the annotation `tidb.pingcap.com/secret-replication-allowed-namespaces`, and only its own Secrets, which are labeled with its instance and managed by tidb-operator, are replicated.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretNames": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretNames are the names of the Secrets in the namespace of the referenced TidbCluster, the replicas have the same names. The Secrets which already exist and are not replicas are not overwritten.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"secretNames"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Security(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Security is the security section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"skip-grant-table": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"ssl-ca": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ssl-cert": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ssl-key": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"cluster-ssl-ca": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"cluster-ssl-cert": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"cluster-ssl-key": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_ServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceSpec specifies the service object in k8s",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the real kubernetes service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional annotations for the service",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional labels for the service",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"loadBalancerIP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadBalancerIP is the loadBalancerIP of service Optional: Defaults to omitted",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterIP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterIP is the clusterIP of service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"portName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PortName is the name of service port",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The port that will be exposed by this service.\n\nNOTE: only used for TiDB",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"loadBalancerSourceRanges": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadBalancerSourceRanges is the loadBalancerSourceRanges of service If specified and supported by the platform, this will restrict traffic through the cloud-provider load-balancer will be restricted to the specified client IPs. This field will be ignored if the cloud-provider does not support the feature.\" More info: https://kubernetes.io/docs/concepts/services-networking/service/#aws-nlb-support Optional: Defaults to omitted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"internalTrafficPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InternalTrafficPolicy describes how nodes distribute the service traffic they receive on the ClusterIP, `Local` only routes the traffic to the endpoints on the same node. It requires Kubernetes v1.22+ and is not applied on the older versions. Optional: Defaults to omitted",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"trafficDistribution": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrafficDistribution expresses the preference of how the traffic is distributed to the endpoints, `PreferClose` prefers the endpoints in the same zone as the client. It requires Kubernetes v1.31+ and is not applied on the older versions. Optional: Defaults to omitted",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologyAwareRouting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyAwareRouting keeps the traffic in the zone where it originates by the topology aware hints, it sets the `service.kubernetes.io/topology-mode` annotation (Kubernetes v1.27+) and the `service.kubernetes.io/topology-aware-hints` annotation (Kubernetes v1.23 ~ v1.26) of the service. It can not be used together with TrafficDistribution. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"sessionAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SessionAffinity of the service, `ClientIP` routes the connections from the same client to the same endpoint. Optional: Defaults to None",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sessionAffinityConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SessionAffinityConfig contains the configurations of the `ClientIP` session affinity.",
+							Ref:         ref("k8s.io/api/core/v1.SessionAffinityConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SessionAffinityConfig"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_Status(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Status is the status section of the config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metrics-addr": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"metrics-interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 15",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"report-status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"record-db-qps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_StatusCompaction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StatusCompaction describes how to compact the status of very large clusters",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"memberThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemberThreshold is the min number of stores or members of TiKV, TiFlash and TiDB to compact the status. The stores and members are summarized in the summary of the component, only the unhealthy ones are kept in the status and the healthy ones are moved to the companion ConfigMap named `<cluster>-full-status`.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_StmtSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StmtSummary is the config for statement summary.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable statement summary or not.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"enable-internal-query": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable summary internal query.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"max-stmt-count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The maximum number of statements kept in memory. Optional: Defaults to 100",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"max-sql-length": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The maximum length of displayed normalized SQL and sample SQL. Optional: Defaults to 4096",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"refresh-interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The refresh interval of statement summary.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"history-size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The maximum history size of statement summary.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_StorageClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StorageClaim contains details of TiFlash storages",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources represents the minimum resources the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the StorageClass required by the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_StorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StorageProvider defines the configuration for storing a backup in backend storage.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
+						},
+					},
+					"gcs": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
+						},
+					},
+					"azblob": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider"),
+						},
+					},
+					"local": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_SuspendAction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SuspendAction defines the suspend actions for a component.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"suspendStatefulSet": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TLSCertIssuerRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TLSCertIssuerRef references a cert-manager issuer",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the issuer",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the issuer, Issuer or ClusterIssuer. Optional: Defaults to Issuer",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group of the issuer. Optional: Defaults to cert-manager.io",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TLSCertManager(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TLSCertManager configures the cert-manager Certificates created for the cluster TLS secrets",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"issuerRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IssuerRef references the cert-manager Issuer or ClusterIssuer signing the certs",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCertIssuerRef"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the validity of the certs. Optional: Defaults to the default of cert-manager",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"renewBefore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewBefore is how long before the expiry the certs are renewed. Optional: Defaults to the default of cert-manager",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"issuerRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCertIssuerRef", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TLSConfig extends the safe TLS configuration with file parameters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ca": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Struct containing the CA cert to use for the targets.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+						},
+					},
+					"cert": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Struct containing the client cert file for the targets.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+						},
+					},
+					"keySecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret containing the client key file for the targets.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"serverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Used to verify the hostname for the targets.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"dnsPolicy": {
+					"insecureSkipVerify": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
-							Type:        []string{"string"},
+							Description: "Disable target certificate validation.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"caFile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Path to the CA cert in the Prometheus container to use for the targets.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"statefulSetUpdateStrategy": {
+					"certFile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Description: "Path to the client cert file in the Prometheus container for the targets.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"podManagementPolicy": {
+					"keyFile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Description: "Path to the client key file in the Prometheus container for the targets.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeed(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiCDCChangefeed declares a changefeed of the TiCDC of a TidbCluster, the changefeed is created, updated, paused, resumed and removed by the TiCDC open API accordingly.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
-									},
-								},
-							},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"suspendAction": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"readinessProbe": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Description: "Spec contains all spec about the changefeed.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedSpec"),
 						},
 					},
-					"limits": {
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedSpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedFilter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiCDCChangefeedFilter configures the tables and the transactions replicated by the changefeed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rules": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Rules are the table filter rules, e.g. `test.*` and `!test.tmp_*`. Optional: Defaults to all the tables",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"requests": {
+					"ignoreTxnStartTs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "IgnoreTxnStartTs are the start ts of the transactions which are not replicated.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
 									},
 								},
 							},
 						},
 					},
-					"serviceAccount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specify a Service Account for pump",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The desired ready replicas",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"baseImage": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiCDCChangefeedList is a TiCDCChangefeed list.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
-							Default:     "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"storageClassName": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for Pump data storage. Defaults to Kubernetes default storage class.",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"config": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The configuration of Pump cluster.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeed"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"replicas"},
+				Required: []string{"items"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeed"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_QueueConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCChangefeedSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "QueueConfig allows the tuning of remote_write queue_config parameters. This object is referenced in the RemoteWriteSpec object.",
+				Description: "TiCDCChangefeedSpec is spec of TiCDCChangefeed.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"capacity": {
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of samples to buffer per shard before we start dropping them.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Cluster is the name of the TidbCluster whose TiCDC replicates the changefeed, it must be in the same namespace as the TiCDCChangefeed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"minShards": {
+					"changefeedID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MinShards is the minimum number of shards, i.e. amount of concurrency. Only valid in Prometheus versions 2.6.0 and newer.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "ChangefeedID is the ID of the changefeed in TiCDC, it can not be changed. Optional: Defaults to the name of the TiCDCChangefeed",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"maxShards": {
+					"sinkURI": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Max number of shards, i.e. amount of concurrency.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "SinkURI is the URI of the downstream, e.g. `mysql://root@downstream:4000/` or `kafka://broker:9092/topic`. Use SinkURISecretRef instead if the URI contains credentials.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"maxSamplesPerSend": {
+					"sinkURISecretRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Maximum number of samples per send.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "SinkURISecretRef selects the key of a Secret which contains the URI of the downstream.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
 						},
 					},
-					"batchSendDeadline": {
+					"startTs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Maximum time sample will wait in buffer.",
+							Description: "StartTs is the TSO where the changefeed starts to replicate, it is only used when the changefeed is created. Optional: Defaults to the current TSO",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
-					"maxRetries": {
+					"targetTs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Max number of times to retry a batch on recoverable errors.",
+							Description: "TargetTs is the TSO where the changefeed stops replicating and becomes finished. Optional: Defaults to 0, which means the changefeed never stops",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
-					"minBackoff": {
+					"filter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "On recoverable errors, backoff exponentially.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Filter configures the tables and the transactions to replicate.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedFilter"),
 						},
 					},
-					"maxBackoff": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Description: "Config is the replica config of the changefeed in TOML, in the same format as the config file of `cdc cli changefeed create --config`.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig"),
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paused pauses the changefeed, it is resumed after Paused is unset.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"cluster"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCChangefeedFilter", "github.com/pingcap/tidb-operator/pkg/apis/util/config.GenericConfig", "k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_RelabelConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RelabelConfig allows dynamic rewriting of the label set, being applied to samples before ingestion. It defines `<metric_relabel_configs>`-section of Prometheus configuration. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs",
+				Description: "TiCDCConfig is the configuration of tidbcdc ref https://github.com/pingcap/ticdc/blob/a28d9e43532edc4a0380f0ef87314631bf18d866/pkg/config/config.go#L176",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"sourceLabels": {
-						SchemaProps: spec.SchemaProps{
-							Description: "A list of labels from which values are taken and concatenated with the configured separator in order.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"separator": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Separator is the string between concatenated values from the source labels.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"regex": {
+					"timezone": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Regular expression against which the extracted value is matched. Default is '(.*)'",
+							Description: "Time zone of TiCDC Optional: Defaults to UTC",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"modulus": {
+					"gcTTL": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Modulus to take of the hash of concatenated values from the source labels.",
+							Description: "CDC GC safepoint TTL duration, specified in seconds Optional: Defaults to 86400",
 							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"targetLabel": {
-						SchemaProps: spec.SchemaProps{
-							Description: "TargetLabel is the label to which the resulting string is written in a replacement. Regexp interpolation is allowed for the replace action.",
-							Type:        []string{"string"},
-							Format:      "",
+							Format:      "int32",
 						},
 					},
-					"replacement": {
+					"logLevel": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Replacement is the regex replacement pattern to be used.",
+							Description: "LogLevel is the log level Optional: Defaults to info",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"action": {
+					"logFile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Action is the action to be performed for the relabeling.",
+							Description: "LogFile is the log file Optional: Defaults to /dev/stderr",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -7143,95 +10912,103 @@ func schema_pkg_apis_pingcap_v1alpha1_RelabelConfig(ref common.ReferenceCallback
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_RemoteWriteSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiCDCSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RemoteWriteSpec defines the remote_write configuration for prometheus.",
+				Description: "TiCDCSpec contains details of TiCDC members",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"url": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The URL of the endpoint to send samples to.",
-							Default:     "",
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"name": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The name of the remote write queue, must be unique if specified. The name is used in metrics and logging in order to differentiate queues. Only valid in Prometheus versions 2.15.0 and newer.",
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"remoteTimeout": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
-						},
-					},
-					"writeRelabelConfigs": {
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The list of remote write relabel configurations.",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RelabelConfig"),
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
 									},
 								},
 							},
 						},
 					},
-					"basicAuth": {
+					"hostNetwork": {
 						SchemaProps: spec.SchemaProps{
-							Description: "BasicAuth for the URL.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAuth"),
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"bearerToken": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "File to read bearer token for remote write.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"bearerTokenFile": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "File to read bearer token for remote write.",
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"tlsConfig": {
-						SchemaProps: spec.SchemaProps{
-							Description: "TLS Config to use for remote write.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSConfig"),
-						},
-					},
-					"proxyUrl": {
+					"schedulerName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Proxy url",
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"queueConfig": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.QueueConfig"),
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"metadataConfig": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MetadataConfig configures the sending of series metadata to remote storage. Only valid in Prometheus versions 2.23.0 and newer.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MetadataConfig"),
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"headers": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Custom HTTP headers to be sent along with each remote write request. Be aware that headers that are set by Prometheus itself can't be overwritten. Only valid in Prometheus versions 2.25.0 and newer.",
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -7245,610 +11022,584 @@ func schema_pkg_apis_pingcap_v1alpha1_RemoteWriteSpec(ref common.ReferenceCallba
 							},
 						},
 					},
-				},
-				Required: []string{"url"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BasicAuth", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.MetadataConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.QueueConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RelabelConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSConfig"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Restore(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Restore represents the restoration of backup of a tidb cluster.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"apiVersion": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"configUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"spec": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSpec"),
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-				},
-				Required: []string{"spec"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RestoreSpec"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_RestoreList(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "RestoreList contains a list of Restore.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"envFrom": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
 						},
 					},
-					"apiVersion": {
+					"initContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"items": {
+					"additionalContainers": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Restore"),
+										Ref:     ref("k8s.io/api/core/v1.Container"),
 									},
 								},
 							},
 						},
 					},
-				},
-				Required: []string{"items"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Restore"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_RestoreSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "RestoreSpec contains the specification for a restore of a tidb cluster backup.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"resources": {
+					"nativeSidecarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/api/core/v1.ResourceRequirements"),
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"env": {
+					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following builtin env vars will be overwritten by values set here - S3_PROVIDER - S3_ENDPOINT - AWS_REGION - AWS_ACL - AWS_STORAGE_CLASS - AWS_DEFAULT_REGION - AWS_ACCESS_KEY_ID - AWS_SECRET_ACCESS_KEY - GCS_PROJECT_ID - GCS_OBJECT_ACL - GCS_BUCKET_ACL - GCS_LOCATION - GCS_STORAGE_CLASS - GCS_SERVICE_ACCOUNT_JSON_KEY - BR_LOG_TO_TERM",
+							Description: "Additional volumes of component pod.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
 									},
 								},
 							},
 						},
 					},
-					"to": {
+					"additionalVolumeMounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "To is the tidb cluster that needs to restore.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig"),
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
 						},
 					},
-					"backupType": {
+					"dnsConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type is the backup type for tidb cluster and only used when Mode = snapshot, such as full, db, table.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
 						},
 					},
-					"restoreMode": {
+					"dnsPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Mode is the restore mode. such as snapshot or pitr.",
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"pitrRestoredTs": {
+					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PitrRestoredTs is the pitr restored ts.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"logRestoreStartTs": {
+					"statefulSetUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LogRestoreStartTs is the start timestamp which log restore from and it will be used in the future.",
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"tikvGCLifeTime": {
+					"podManagementPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TikvGCLifeTime is to specify the safe gc life time for restore. The time limit during which data is retained for each GC, in the format of Go Duration. When a GC happens, the current time minus this value is the safe point.",
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"s3": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
 						},
-					},
-					"gcs": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
 						},
 					},
-					"azblob": {
+					"suspendAction": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider"),
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
 						},
 					},
-					"local": {
+					"readinessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider"),
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
 						},
 					},
-					"pitrFullBackupStorageProvider": {
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PitrFullBackupStorageProvider configures where and how pitr dependent full backup should be stored.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageProvider"),
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"storageClassName": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for Restore data storage. Defaults to Kubernetes default storage class.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"storageSize": {
+					"serviceAccount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageSize is the request storage size for backup job",
+							Description: "Specify a Service Account for TiCDC",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"br": {
+					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "BR is the configs for BR.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig"),
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"tolerations": {
+					"tlsClientSecretNames": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Base tolerations of restore Pods, components may add more tolerations upon this respectively",
+							Description: "TLSClientSecretNames are the names of secrets that store the client certificates for the downstream.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"affinity": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of restore Pods",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
-						},
-					},
-					"useKMS": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Use KMS to decrypt the secrets",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"serviceAccount": {
+					"baseImage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specify service account of restore",
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"toolImage": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ToolImage specifies the tool image used in `Restore`, which supports BR and TiDB Lightning images. For examples `spec.toolImage: pingcap/br:v4.0.8` or `spec.toolImage: pingcap/tidb-lightning:v4.0.8` For BR image, if it does not contain tag, Pod will use image 'ToolImage:${TiKV_Version}'.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Config is the Configuration of tidbcdc servers",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CDCConfigWraper"),
 						},
 					},
-					"imagePullSecrets": {
+					"storageVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Description: "StorageVolumes configure additional storage for TiCDC pods.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
 									},
 								},
 							},
 						},
 					},
-					"tableFilter": {
+					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TableFilter means Table filter expression for 'db.table' matching. BR supports this from v4.0.3.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "The storageClassName of the persistent volume for TiCDC data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"podSecurityContext": {
+					"gracefulShutdownTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+							Description: "GracefulShutdownTimeout is the timeout of gracefully shutdown a TiCDC pod. Encoded in the format of Go Duration. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"priorityClassName": {
+					"podDisruptionBudget": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of Restore Job Pods",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "PodDisruptionBudget configures the PodDisruptionBudget of TiCDC pods. No PodDisruptionBudget is created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec"),
 						},
 					},
 				},
+				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.BRConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBAccessConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CDCConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_S3StorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBAccessConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "S3StorageProvider represents a S3 compliant storage for storing backups.",
+				Description: "TiDBAccessConfig defines the configuration for access tidb cluster",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"provider": {
+					"host": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Provider represents the specific storage provider that implements the S3 interface",
+							Description: "Host is the tidb cluster access address",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"region": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Region in which the S3 compatible bucket is located.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Port is the port number to use for connecting tidb cluster",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"path": {
+					"user": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path is the full path where the backup is saved. The format of the path must be: \"<bucket-name>/<path-to-backup-file>\"",
+							Description: "User is the user for login tidb cluster",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"bucket": {
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Bucket in which to store the backup data.",
+							Description: "SecretName is the name of secret which stores tidb cluster's password.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"endpoint": {
+					"tlsClientSecretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Endpoint of S3 compatible storage service",
+							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate Optional: Defaults to nil",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"storageClass": {
+				},
+				Required: []string{"host", "secretName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBConfig is the configuration of tidb-server For more detail, refer to https://pingcap.com/docs/stable/reference/configuration/tidb-server/configuration/",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cors": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageClass represents the storage class",
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"socket": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"lease": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to 45s",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"acl": {
+					"run-ddl": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Acl represents access control permissions for this bucket",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"secretName": {
+					"split-table": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretName is the name of secret which stores S3 compliant storage access key and secret key.",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"prefix": {
+					"token-limit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Prefix of the data path.",
+							Description: "Optional: Defaults to 1000",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"oom-use-tmp-storage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"tmp-storage-path": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"oom-action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optional: Defaults to log",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"sse": {
+					"max-index-length": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SSE Sever-Side Encryption.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Optional: Defaults to 3072",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"options": {
+					"mem-quota-query": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Options Rclone options for backup and restore with dumpling and lightning.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Optional: Defaults to 34359738368",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-				},
-				Required: []string{"provider"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_SafeTLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "SafeTLSConfig specifies safe TLS configuration parameters.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"ca": {
+					"tmp-storage-quota": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Struct containing the CA cert to use for the targets.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+							Description: "TempStorageQuota describe the temporary storage Quota during query exector when OOMUseTmpStorage is enabled If the quota exceed the capacity of the TempStoragePath, the tidb-server would exit with fatal error",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"cert": {
+					"enable-streaming": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Struct containing the client cert file for the targets.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"keySecret": {
+					"enable-batch-dml": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Secret containing the client key file for the targets.",
-							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"serverName": {
+					"txn-local-latches": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Used to verify the hostname for the targets.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Deprecated in v4.0.0",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TxnLocalLatches"),
 						},
 					},
-					"insecureSkipVerify": {
+					"lower-case-table-names": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Disable target certificate validation.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap", "k8s.io/api/core/v1.SecretKeySelector"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_SecretRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "SecretRef indicates to secret ref",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"name": {
+					"log": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Log"),
 						},
 					},
-					"namespace": {
+					"security": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Security"),
 						},
 					},
-				},
-				Required: []string{"name", "namespace"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Security(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Security is the security section of the config.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"skip-grant-table": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Status"),
 						},
 					},
-					"ssl-ca": {
+					"performance": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance"),
 						},
 					},
-					"ssl-cert": {
+					"prepared-plan-cache": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PreparedPlanCache"),
 						},
 					},
-					"ssl-key": {
+					"opentracing": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing"),
 						},
 					},
-					"cluster-ssl-ca": {
+					"proxy-protocol": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyProtocol"),
 						},
 					},
-					"cluster-ssl-cert": {
+					"tikv-client": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVClient"),
 						},
 					},
-					"cluster-ssl-key": {
+					"binlog": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Binlog"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_ServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "ServiceSpec specifies the service object in k8s",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"type": {
+					"compatible-kill-query": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type of the real kubernetes service",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"boolean"},
+							Format: "",
 						},
 					},
-					"annotations": {
+					"plugin": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional annotations for the service",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Plugin"),
 						},
 					},
-					"labels": {
+					"pessimistic-txn": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional labels for the service",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn"),
 						},
 					},
-					"loadBalancerIP": {
+					"check-mb4-value-in-utf8": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LoadBalancerIP is the loadBalancerIP of service Optional: Defaults to omitted",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"clusterIP": {
+					"alter-primary-key": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ClusterIP is the clusterIP of service",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"portName": {
+					"treat-old-version-utf8-as-utf8mb4": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PortName is the name of service port",
-							Type:        []string{"string"},
+							Description: "Optional: Defaults to true",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"port": {
+					"split-region-max-num": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The port that will be exposed by this service.\n\nNOTE: only used for TiDB",
+							Description: "Optional: Defaults to 1000",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
-					"loadBalancerSourceRanges": {
+					"stmt-summary": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LoadBalancerSourceRanges is the loadBalancerSourceRanges of service If specified and supported by the platform, this will restrict traffic through the cloud-provider load-balancer will be restricted to the specified client IPs. This field will be ignored if the cloud-provider does not support the feature.\" More info: https://kubernetes.io/docs/concepts/services-networking/service/#aws-nlb-support Optional: Defaults to omitted",
-							Type:        []string{"array"},
+							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StmtSummary"),
+						},
+					},
+					"repair-mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RepairMode indicates that the TiDB is in the repair mode for table meta.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"repair-table-list": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -7860,182 +11611,192 @@ func schema_pkg_apis_pingcap_v1alpha1_ServiceSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_Status(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Status is the status section of the config.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"metrics-addr": {
+					"isolation-read": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "IsolationRead indicates that the TiDB reads data from which isolation level(engine and label).",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IsolationRead"),
 						},
 					},
-					"metrics-interval": {
+					"max-server-connections": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 15",
+							Description: "MaxServerConnections is the maximum permitted number of simultaneous client connections.",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
-					"report-status": {
+					"new_collations_enabled_on_first_bootstrap": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
+							Description: "NewCollationsEnabledOnFirstBootstrap indicates if the new collations are enabled, it effects only when a TiDB cluster bootstrapped on the first time.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"record-db-qps": {
+					"experimental": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Experimental contains parameters for experimental features.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Experimental"),
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_StmtSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "StmtSummary is the config for statement summary.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enable": {
+					"enable-dynamic-config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable statement summary or not.",
+							Description: "EnableDynamicConfig enables the TiDB to fetch configs from PD and update itself during runtime. see https://github.com/pingcap/tidb/pull/13660 for more details.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"enable-internal-query": {
+					"enable-table-lock": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable summary internal query.",
+							Description: "imported from v3.1.0",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"max-stmt-count": {
+					"delay-clean-table-lock": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The maximum number of statements kept in memory. Optional: Defaults to 100",
+							Description: "imported from v3.1.0",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
-					"max-sql-length": {
+					"skip-register-to-dashboard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The maximum length of displayed normalized SQL and sample SQL. Optional: Defaults to 4096",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "imported from v4.0.5 SkipRegisterToDashboard tells TiDB don't register itself to the dashboard.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"refresh-interval": {
+					"enable-telemetry": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The refresh interval of statement summary.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "When enabled, usage data (for example, instance versions) will be reported to PingCAP periodically for user experience analytics. If this config is set to `false` on all TiDB servers, telemetry will be always disabled regardless of the value of the global variable `tidb_enable_telemetry`. See PingCAP privacy policy for details: https://pingcap.com/en/privacy-policy/. Imported from v4.0.2. Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"history-size": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The maximum history size of statement summary.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Labels are labels for TiDB server",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Binlog", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Experimental", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IsolationRead", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Log", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Plugin", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PreparedPlanCache", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyProtocol", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Security", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Status", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StmtSummary", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVClient", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TxnLocalLatches"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_StorageClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBConnectionPool(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "StorageClaim contains details of TiFlash storages",
+				Description: "TiDBConnectionPool is the connection pool sidecar of TiDB pods. The image is a lightweight MySQL proxy configured by the environment variables:\n\n  - POOL_LISTEN_ADDR: the address to listen on for the client connections\n  - POOL_SERVER_ADDR: the address of the TiDB server in the same pod\n  - POOL_MAX_CONNECTIONS: the max number of the client connections, the excess connections are rejected\n  - POOL_MAX_SERVER_CONNECTIONS: the max number of the connections to the TiDB server\n  - POOL_DRAIN_TIMEOUT_SECONDS: set if `spec.tidb.gracefulShutdownSeconds` is set, the pool drains the client\n    connections and closes the server connections in the timeout after it receives SIGTERM, so the preStop\n    hook of TiDB observes the connections drop in rolling upgrades and scale-in.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"resources": {
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Resources represents the minimum resources the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"storageClassName": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the StorageClass required by the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1",
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the connection pool",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/api/core/v1.ResourceRequirements"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_StorageProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "StorageProvider defines the configuration for storing a backup in backend storage.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"s3": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"),
+							Description: "ImagePullPolicy of the connection pool Optional: Defaults to the imagePullPolicy of TiDB",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"gcs": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider"),
+							Description: "Port to listen on for the client connections, the TiDB Service forwards the client connections to it. Optional: Defaults to 6000",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"azblob": {
+					"maxConnections": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider"),
+							Description: "MaxConnections is the max number of the client connections admitted by the pool of each pod. Optional: Defaults to 1000",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"local": {
+					"maxServerConnections": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider"),
+							Description: "MaxServerConnections is the max number of the connections from the pool to the TiDB server of each pod. Optional: Defaults to 100",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"image"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.AzblobStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GcsStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LocalStorageProvider", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.S3StorageProvider"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_SuspendAction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBConnectionSecret(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SuspendAction defines the suspend actions for a component.",
+				Description: "TiDBConnectionSecret describes the Secret which contains the ready-to-use connection info of TiDB, including host, port, CA certificate if TLS is enabled, and the user credentials if configured.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"suspendStatefulSet": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Name of the Secret. Optional: Defaults to `<clusterName>-tidb-connection`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"user": {
+						SchemaProps: spec.SchemaProps{
+							Description: "User to put in the Secret. Its password is read from the password secret of the TidbInitializer of this cluster. No user credentials are put in the Secret if it is not set.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
@@ -8044,456 +11805,405 @@ func schema_pkg_apis_pingcap_v1alpha1_SuspendAction(ref common.ReferenceCallback
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBPluginArtifact(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TLSConfig extends the safe TLS configuration with file parameters.",
+				Description: "TiDBPluginArtifact is a plugin of TiDB together with the source of its binary, exactly one of configMap, image and persistentVolumeClaim should be set.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"ca": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Struct containing the CA cert to use for the targets.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
-						},
-					},
-					"cert": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Struct containing the client cert file for the targets.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap"),
+							Description: "Name is the name in the manifest of the plugin, e.g. audit",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"keySecret": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Secret containing the client key file for the targets.",
-							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+							Description: "Version is the version in the manifest of the plugin, the plugin is loaded as <name>-<version>",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"serverName": {
+					"tidbVersions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Used to verify the hostname for the targets.",
+							Description: "TiDBVersions is a constraint of the TiDB versions the plugin is built for, e.g. \">= 7.1.0, < 7.2.0\", the cluster is rejected if the version of TiDB doesn't satisfy it.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"insecureSkipVerify": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Disable target certificate validation.",
-							Type:        []string{"boolean"},
+							Description: "Path is the path of the plugin binary in the source, i.e. the key of the ConfigMap, the path in the image or the sub path in the volume. Defaults to <name>-<version>.so",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"caFile": {
+					"configMap": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path to the CA cert in the Prometheus container to use for the targets.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ConfigMap is the ConfigMap containing the plugin binary",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
 						},
 					},
-					"certFile": {
+					"image": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path to the client cert file in the Prometheus container for the targets.",
+							Description: "Image is the image containing the plugin binary, e.g. an OCI artifact, the binary is copied into the plugin directory by an init container, so the image should have `sh` and `cp`.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"keyFile": {
+					"persistentVolumeClaim": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path to the client key file in the Prometheus container for the targets.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "PersistentVolumeClaim is the volume containing the plugin binary",
+							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"),
 						},
 					},
 				},
+				Required: []string{"name", "version"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretOrConfigMap", "k8s.io/api/core/v1.SecretKeySelector"},
+			"k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiCDCConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBRestartPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiCDCConfig is the configuration of tidbcdc ref https://github.com/pingcap/ticdc/blob/a28d9e43532edc4a0380f0ef87314631bf18d866/pkg/config/config.go#L176",
+				Description: "TiDBRestartPolicy describes the scheduled rolling restarts of TiDB",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"timezone": {
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Time zone of TiCDC Optional: Defaults to UTC",
+							Description: "Schedule is the cron expression of the start of the restart windows, e.g. `0 3 * * 0`. It is in the time zone of the cluster.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"gcTTL": {
-						SchemaProps: spec.SchemaProps{
-							Description: "CDC GC safepoint TTL duration, specified in seconds Optional: Defaults to 86400",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"logLevel": {
+					"window": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LogLevel is the log level Optional: Defaults to info",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Window is the duration of a restart window, no pod is restarted after the window ends and the remaining pods are restarted in the next windows. Optional: Defaults to 1h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"logFile": {
+					"maxPodsPerRun": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LogFile is the log file Optional: Defaults to /dev/stderr",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "MaxPodsPerRun is the max number of pods restarted in a window. Optional: Defaults to the replicas of TiDB, i.e. all pods are restarted in a window",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"schedule"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiCDCSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBProxyProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiCDCSpec contains details of TiCDC members",
+				Description: "TiDBProxyProtocol configures the PROXY protocol between the load balancer and TiDB.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"version": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"imagePullPolicy": {
+					"provider": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Description: "Provider of the load balancer, the annotations which enable the PROXY protocol on the load balancer are added to the service. The service must be of LoadBalancer type if it is set. Optional: Defaults to omitted, the load balancer is configured by the annotations in the service spec",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"imagePullSecrets": {
+					"networks": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
+							Description: "Networks are the IPs or CIDRs of the load balancer which send the PROXY protocol header, `*` means all networks. The clients connecting from these networks without the header are rejected by TiDB.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"hostNetwork": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"affinity": {
+					"headerTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "HeaderTimeout is the timeout in seconds to read the PROXY protocol header Optional: Defaults to the default of TiDB",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"priorityClassName": {
+				},
+				Required: []string{"networks"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBSQLReadinessGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBSQLReadinessGate configures the SQL health check feeding the readiness gate of TiDB pods. The check runs `SELECT 1` and queries `information_schema.cluster_info` on each TiDB pod.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"user": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "User to connect to TiDB. Optional: Defaults to root",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Description: "SecretName is the name of the Secret storing the password of the user with the key `password`. Optional: Defaults to the root password created by spec.tidb.initializer, or an empty password",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
-						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"annotations": {
+					"timeoutSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "TimeoutSeconds is the timeout of the SQL health check of each pod, the pods are checked concurrently every 10 seconds and the timeout is capped at 30 seconds. Optional: Defaults to 5",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"labels": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"externalTrafficPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "ExternalTrafficPolicy of the service Optional: Defaults to omitted",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"tolerations": {
+					"exposeStatus": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
+							Description: "Whether expose the status port Optional: Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"podSecurityContext": {
+					"mysqlNodePort": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodSecurityContext of the component",
-							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+							Description: "Expose the tidb cluster mysql port to MySQLNodePort Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"configUpdateStrategy": {
+					"statusNodePort": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Expose the tidb status node port to StatusNodePort Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"env": {
+					"additionalPorts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Description: "Expose additional ports for TiDB Optional: Defaults to omitted",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+										Ref:     ref("k8s.io/api/core/v1.ServicePort"),
 									},
 								},
 							},
 						},
 					},
-					"envFrom": {
+					"externalDNS": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Extend the use scenarios for env",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
-									},
-								},
-							},
+							Description: "ExternalDNS configures the DNS records of the service managed by external-dns Optional: Defaults to omitted",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalDNS"),
 						},
 					},
-					"initContainers": {
+					"zoneLocal": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Init containers of the components",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "ZoneLocal routes the applications to the TiDB pods in the same zone to cut the cross-zone traffic, the TiDB pods are labeled with their zones by the `tidb.pingcap.com/zone` label. Optional: Defaults to omitted",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBZoneLocalRouting"),
 						},
 					},
-					"additionalContainers": {
+					"proxyProtocol": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Container"),
-									},
-								},
-							},
+							Description: "ProxyProtocol enables the PROXY protocol between the load balancer of the service and TiDB, so that the client IPs appear correctly in the logs and privilege checks of TiDB. It configures the `proxy-protocol` section of the TiDB config and the annotations of the load balancer together. Optional: Defaults to omitted",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBProxyProtocol"),
 						},
 					},
-					"additionalVolumes": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ExternalDNS", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBProxyProtocol", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBZoneLocalRouting", "k8s.io/api/core/v1.ServicePort"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBSlowLogTailerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBSlowLogTailerSpec represents an optional log tailer sidecar with TiDB",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volumes of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.Volume"),
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"additionalVolumeMounts": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional volume mounts of component pod.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 									},
 								},
 							},
 						},
 					},
-					"dnsConfig": {
-						SchemaProps: spec.SchemaProps{
-							Description: "DNSConfig Specifies the DNS parameters of a pod.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
-						},
-					},
-					"dnsPolicy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"terminationGracePeriodSeconds": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"statefulSetUpdateStrategy": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBSpec contains details of TiDB members",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Description: "Version of the component. Override the cluster-level version if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"podManagementPolicy": {
+					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"topologySpreadConstraints": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"topologyKey",
-								},
-								"x-kubernetes-list-type": "map",
-							},
+							Description: "ImagePullPolicy of the component. Override the cluster-level imagePullPolicy if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
 						},
+					},
+					"imagePullSecrets": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
 									},
 								},
 							},
 						},
 					},
-					"suspendAction": {
+					"hostNetwork": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SuspendAction defines the suspend actions for all component.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
+							Description: "Whether Hostnetwork of the component is enabled. Override the cluster-level setting if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"readinessProbe": {
+					"affinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
+							Description: "Affinity of the component. Override the cluster-level setting if present. Optional: Defaults to cluster-level setting",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"limits": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Description: "PriorityClassName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName of the component. Override the cluster-level one if present Optional: Defaults to cluster-level setting",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of the component. Merged into the cluster-level nodeSelector if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"requests": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Description: "Annotations for the component. Merge into the cluster-level annotations if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"serviceAccount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specify a Service Account for TiCDC",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The desired ready replicas",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"tlsClientSecretNames": {
+					"labels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSClientSecretNames are the names of secrets that store the client certificates for the downstream.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "Labels for the component. Merge into the cluster-level labels if non-empty Optional: Defaults to cluster-level setting",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: "",
@@ -8504,324 +12214,322 @@ func schema_pkg_apis_pingcap_v1alpha1_TiCDCSpec(ref common.ReferenceCallback) co
 							},
 						},
 					},
-					"baseImage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"config": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Config is the Configuration of tidbcdc servers",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CDCConfigWraper"),
-						},
-					},
-					"storageVolumes": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageVolumes configure additional storage for TiCDC pods.",
+							Description: "Tolerations of the component. Override the cluster-level tolerations if non-empty Optional: Defaults to cluster-level setting",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
 									},
 								},
 							},
 						},
 					},
-					"storageClassName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for TiCDC data storage. Defaults to Kubernetes default storage class.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"gracefulShutdownTimeout": {
-						SchemaProps: spec.SchemaProps{
-							Description: "GracefulShutdownTimeout is the timeout of gracefully shutdown a TiCDC pod. Encoded in the format of Go Duration. Defaults to 10m",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
-						},
-					},
-				},
-				Required: []string{"replicas"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CDCConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_TiDBAccessConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "TiDBAccessConfig defines the configuration for access tidb cluster",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"host": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Host is the tidb cluster access address",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"port": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Port is the port number to use for connecting tidb cluster",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"user": {
-						SchemaProps: spec.SchemaProps{
-							Description: "User is the user for login tidb cluster",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"secretName": {
+					"podSecurityContext": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretName is the name of secret which stores tidb cluster's password.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "PodSecurityContext of the component",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
 						},
 					},
-					"tlsClientSecretName": {
+					"configUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSClientSecretName is the name of secret which stores tidb server client certificate Optional: Defaults to nil",
+							Description: "ConfigUpdateStrategy of the component. Override the cluster-level updateStrategy if present Optional: Defaults to cluster-level setting",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"host", "secretName"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_pingcap_v1alpha1_TiDBConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "TiDBConfig is the configuration of tidb-server For more detail, refer to https://pingcap.com/docs/stable/reference/configuration/tidb-server/configuration/",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"cors": {
+					"env": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "List of environment variables to set in the container, like v1.Container.Env. Note that the following env names cannot be used and will be overridden by TiDB Operator builtin envs - NAMESPACE - TZ - SERVICE_NAME - PEER_SERVICE_NAME - HEADLESS_SERVICE_NAME - SET_NAME - HOSTNAME - CLUSTER_NAME - POD_NAME - BINLOG_ENABLED - SLOW_LOG_FILE",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
 						},
 					},
-					"socket": {
+					"envFrom": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Extend the use scenarios for env",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
 						},
 					},
-					"lease": {
+					"initContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 45s",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Init containers of the components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"run-ddl": {
+					"additionalContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Additional containers of the component. If the container names in this field match with the ones generated by TiDB Operator, the container configurations will be merged into the containers generated by TiDB Operator via strategic merge patch. If the container names in this field do not match with the ones generated by TiDB Operator, the container configurations will be appended to the Pod container spec directly.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
 						},
 					},
-					"split-table": {
+					"nativeSidecarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"token-limit": {
+					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1000",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Additional volumes of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
 						},
 					},
-					"oom-use-tmp-storage": {
+					"additionalVolumeMounts": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Additional volume mounts of component pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
 						},
 					},
-					"tmp-storage-path": {
+					"dnsConfig": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "DNSConfig Specifies the DNS parameters of a pod.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
 						},
 					},
-					"oom-action": {
+					"dnsPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to log",
+							Description: "DNSPolicy Specifies the DNSPolicy parameters of a pod.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"max-index-length": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 3072",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"mem-quota-query": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 34359738368",
-							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"tmp-storage-quota": {
+					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TempStorageQuota describe the temporary storage Quota during query exector when OOMUseTmpStorage is enabled If the quota exceed the capacity of the TempStoragePath, the tidb-server would exit with fatal error",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
-					"enable-streaming": {
+					"statefulSetUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
+							Description: "StatefulSetUpdateStrategy indicates the StatefulSetUpdateStrategy that will be employed to update Pods in the StatefulSet when a revision is made to Template.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enable-batch-dml": {
+					"podManagementPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
+							Description: "PodManagementPolicy of TiDB cluster StatefulSets",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"txn-local-latches": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated in v4.0.0",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TxnLocalLatches"),
-						},
-					},
-					"lower-case-table-names": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+					"topologySpreadConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"topologyKey",
+								},
+								"x-kubernetes-list-type": "map",
+							},
 						},
-					},
-					"log": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Log"),
+							Description: "TopologySpreadConstraints describes how a group of pods ought to spread across topology domains. Scheduler will schedule pods in a way which abides by the constraints. This field is is only honored by clusters that enables the EvenPodsSpread feature. All topologySpreadConstraints are ANDed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint"),
+									},
+								},
+							},
 						},
 					},
-					"security": {
+					"suspendAction": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Security"),
+							Description: "SuspendAction defines the suspend actions for all component.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction"),
 						},
 					},
-					"status": {
+					"readinessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Status"),
+							Description: "ReadinessProbe describes actions that probe the pd's readiness. the default behavior is like setting type as \"tcp\"",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe"),
 						},
 					},
-					"performance": {
+					"limits": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance"),
+							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"prepared-plan-cache": {
+					"requests": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PreparedPlanCache"),
+							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"opentracing": {
+					"serviceAccount": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing"),
+							Description: "Specify a Service Account for tidb",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"proxy-protocol": {
+					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyProtocol"),
+							Description: "The desired ready replicas",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"tikv-client": {
+					"baseImage": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVClient"),
+							Description: "Base image of the component, image tag is now allowed during validation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"binlog": {
+					"service": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Binlog"),
+							Description: "Service defines a Kubernetes service of TiDB cluster. Optional: No kubernetes service will be created by default.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBServiceSpec"),
 						},
 					},
-					"compatible-kill-query": {
+					"binlogEnabled": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Whether enable TiDB Binlog, it is encouraged to not set this field and rely on the default behavior Optional: Defaults to true if PumpSpec is non-nil, otherwise false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"plugin": {
+					"maxFailoverCount": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Plugin"),
+							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover Optional: Defaults to 3",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"pessimistic-txn": {
+					"failover": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn"),
+							Description: "Failover is the configurations of failover",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover"),
 						},
 					},
-					"check-mb4-value-in-utf8": {
+					"separateSlowLog": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
+							Description: "Whether output the slow log in an separate sidecar container Optional: Defaults to true",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"alter-primary-key": {
+					"slowLogVolumeName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to false",
-							Type:        []string{"boolean"},
+							Description: "Optional volume name configuration for slow query log.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"treat-old-version-utf8-as-utf8mb4": {
+					"slowLogTailer": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "The specification of the slow log tailer sidecar",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSlowLogTailerSpec"),
 						},
 					},
-					"split-region-max-num": {
+					"tlsClient": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional: Defaults to 1000",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Whether enable the TLS connection between the SQL client and TiDB server Optional: Defaults to nil",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTLSClient"),
 						},
 					},
-					"stmt-summary": {
+					"tokenBasedAuthEnabled": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StmtSummary"),
+							Description: "Whether enable `tidb_auth_token` authentication method. The tidb_auth_token authentication method is used only for the internal operation of TiDB Cloud. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"repair-mode": {
+					"tokenBasedAuth": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RepairMode indicates that the TiDB is in the repair mode for table meta.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "TokenBasedAuth makes the operator generate and rotate the signing keys of the `tidb_auth_token` authentication method in the JWKS Secret `<clusterName>-tidb-auth-token-jwks-secret`. Applications sign their JWTs with the private key published in that Secret. Setting it implies tokenBasedAuthEnabled.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTokenBasedAuth"),
 						},
 					},
-					"repair-table-list": {
+					"plugins": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Plugins is a list of plugins that are loaded by TiDB server, empty means plugin disabled",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -8833,198 +12541,206 @@ func schema_pkg_apis_pingcap_v1alpha1_TiDBConfig(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"isolation-read": {
+					"pluginArtifacts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "IsolationRead indicates that the TiDB reads data from which isolation level(engine and label).",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IsolationRead"),
+							Description: "PluginArtifacts are the plugins whose binaries are mounted into the plugin directory of TiDB by the operator, they are loaded together with the plugins in `plugins`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBPluginArtifact"),
+									},
+								},
+							},
 						},
 					},
-					"max-server-connections": {
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MaxServerConnections is the maximum permitted number of simultaneous client connections.",
+							Description: "Config is the Configuration of tidb-servers",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConfigWraper"),
+						},
+					},
+					"lifecycle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lifecycle describes actions that the management system should take in response to container lifecycle events. For the PostStart and PreStop lifecycle handlers, management of the container blocks until the action is complete, unless the container process fails, in which case the handler is aborted.",
+							Ref:         ref("k8s.io/api/core/v1.Lifecycle"),
+						},
+					},
+					"gracefulShutdownSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GracefulShutdownSeconds enables draining the client connections before a TiDB pod is terminated, e.g. in rolling upgrades and scale-in. A preStop hook fails the readiness probe of the pod to remove it from the Service endpoints, then waits at most GracefulShutdownSeconds for the active connections reported by the status API to drop to zero before TiDB is stopped. The readiness probe is switched to the command type, and terminationGracePeriodSeconds defaults to GracefulShutdownSeconds + 30 if it is not set. It can't be used together with lifecycle.preStop.",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"new_collations_enabled_on_first_bootstrap": {
+					"connectionPool": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NewCollationsEnabledOnFirstBootstrap indicates if the new collations are enabled, it effects only when a TiDB cluster bootstrapped on the first time.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "ConnectionPool adds a connection pool sidecar to TiDB pods, the client connections of the TiDB Service are admitted by the pool and multiplexed on the connections to the TiDB server in the same pod.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionPool"),
 						},
 					},
-					"experimental": {
+					"sqlReadinessGate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Experimental contains parameters for experimental features.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Experimental"),
+							Description: "SQLReadinessGate adds a readiness gate to TiDB pods, the condition of the gate is set by the operator after checking the SQL health of TiDB, so the pods which accept TCP connections but fail SQL auth or schema loading are not considered ready.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSQLReadinessGate"),
+						},
+					},
+					"storageVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageVolumes configure additional storage for TiDB pods.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+									},
+								},
+							},
 						},
 					},
-					"enable-dynamic-config": {
+					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableDynamicConfig enables the TiDB to fetch configs from PD and update itself during runtime. see https://github.com/pingcap/tidb/pull/13660 for more details.",
-							Type:        []string{"boolean"},
+							Description: "The storageClassName of the persistent volume for TiDB data storage. Defaults to Kubernetes default storage class.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"enable-table-lock": {
+					"initializer": {
 						SchemaProps: spec.SchemaProps{
-							Description: "imported from v3.1.0",
-							Type:        []string{"boolean"},
+							Description: "Initializer is the init configurations of TiDB",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBInitializer"),
+						},
+					},
+					"bootstrapSQLConfigMapName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BootstrapSQLConfigMapName is the name of the ConfigMap which contains the bootstrap SQL file with the key `bootstrap-sql`, which will only be executed when a TiDB cluster bootstrap on the first time. The field should be set ONLY when create a TC, since it only take effect on the first time bootstrap. Only v6.6.0+ supports this feature.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"delay-clean-table-lock": {
+					"podDisruptionBudget": {
 						SchemaProps: spec.SchemaProps{
-							Description: "imported from v3.1.0",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "PodDisruptionBudget configures the PodDisruptionBudget of TiDB pods. No PodDisruptionBudget is created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec"),
 						},
 					},
-					"skip-register-to-dashboard": {
+					"connectionSecret": {
 						SchemaProps: spec.SchemaProps{
-							Description: "imported from v4.0.5 SkipRegisterToDashboard tells TiDB don't register itself to the dashboard.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "ConnectionSecret configures the Secret published for applications to connect to TiDB. No Secret is published if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionSecret"),
 						},
 					},
-					"enable-telemetry": {
+					"rollingUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "When enabled, usage data (for example, instance versions) will be reported to PingCAP periodically for user experience analytics. If this config is set to `false` on all TiDB servers, telemetry will be always disabled regardless of the value of the global variable `tidb_enable_telemetry`. See PingCAP privacy policy for details: https://pingcap.com/en/privacy-policy/. Imported from v4.0.2. Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "RollingUpdateStrategy configures the partitioned rolling update of TiDB.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RollingUpdateStrategy"),
 						},
 					},
-					"labels": {
+					"restartPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Labels are labels for TiDB server",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "RestartPolicy configures the scheduled rolling restarts of TiDB, e.g. a weekly restart to curb the memory growth caused by fragmentation.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBRestartPolicy"),
 						},
 					},
 				},
+				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Binlog", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Experimental", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.IsolationRead", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Log", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OpenTracing", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Performance", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PessimisticTxn", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Plugin", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PreparedPlanCache", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyProtocol", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Security", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Status", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StmtSummary", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVClient", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TxnLocalLatches"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RollingUpdateStrategy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionPool", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConnectionSecret", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBInitializer", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBPluginArtifact", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBRestartPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSQLReadinessGate", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSlowLogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTLSClient", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTokenBasedAuth", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiDBServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBTLSClient(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.",
+				Description: "TiDBTLSClient can enable TLS connection between TiDB server and MySQL client",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"externalTrafficPolicy": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExternalTrafficPolicy of the service Optional: Defaults to omitted",
-							Type:        []string{"string"},
+							Description: "When enabled, TiDB will accept TLS encrypted connections from MySQL client The steps to enable this feature:\n  1. Generate a TiDB server-side certificate and a client-side certifiacete for the TiDB cluster.\n     There are multiple ways to generate certificates:\n       - user-provided certificates: https://pingcap.com/docs/stable/how-to/secure/enable-tls-clients/\n       - use the K8s built-in certificate signing system signed certificates: https://kubernetes.io/docs/tasks/tls/managing-tls-in-a-cluster/\n       - or use cert-manager signed certificates: https://cert-manager.io/\n  2. Create a K8s Secret object which contains the TiDB server-side certificate created above.\n     The name of this Secret must be: <clusterName>-tidb-server-secret.\n       kubectl create secret generic <clusterName>-tidb-server-secret --namespace=<namespace> --from-file=tls.crt=<path/to/tls.crt> --from-file=tls.key=<path/to/tls.key> --from-file=ca.crt=<path/to/ca.crt>\n  3. Create a K8s Secret object which contains the TiDB client-side certificate created above which will be used by TiDB Operator.\n     The name of this Secret must be: <clusterName>-tidb-client-secret.\n       kubectl create secret generic <clusterName>-tidb-client-secret --namespace=<namespace> --from-file=tls.crt=<path/to/tls.crt> --from-file=tls.key=<path/to/tls.key> --from-file=ca.crt=<path/to/ca.crt>\n  4. Set Enabled to `true`.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"exposeStatus": {
+					"disableClientAuthn": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether expose the status port Optional: Defaults to true",
+							Description: "DisableClientAuthn will skip client's certificate validation from the TiDB server. Optional: defaults to false",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"mysqlNodePort": {
+					"skipInternalClientCA": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Expose the tidb cluster mysql port to MySQLNodePort Optional: Defaults to 0",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "SkipInternalClientCA will skip TiDB server's certificate validation for internal components like Initializer, Dashboard, etc. Optional: defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"statusNodePort": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiDBTokenBasedAuth(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiDBTokenBasedAuth describes how the operator provisions the JWKS of the `tidb_auth_token` authentication method.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rotationInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Expose the tidb status node port to StatusNodePort Optional: Defaults to 0",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "RotationInterval is the interval to rotate the signing key. The previous public key is kept in the JWKS after a rotation, so tokens signed before the rotation are still valid. Optional: Defaults to 720h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"additionalPorts": {
+					"refreshInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Expose additional ports for TiDB Optional: Defaults to omitted",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.ServicePort"),
-									},
-								},
-							},
+							Description: "RefreshInterval is the interval for TiDB to reload the JWKS, it is rendered to `security.auth-token-refresh-interval` of the TiDB config. Optional: Defaults to the default value of TiDB",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ServicePort"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiDBSlowLogTailerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiDBZoneLocalRouting(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiDBSlowLogTailerSpec represents an optional log tailer sidecar with TiDB",
+				Description: "TiDBZoneLocalRouting configures how the applications are routed to the TiDB pods in the same zone.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"limits": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
-						},
-					},
-					"requests": {
+					"mode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
-							},
+							Description: "Mode is the way to route the applications to the TiDB pods in the same zone",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"mode"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiFlashComputeNodeSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiDBSpec contains details of TiDB members",
+				Description: "TiFlashComputeNodeSpec contains details of the TiFlash compute nodes, the fields not specified here, e.g. the base image and the config, are the same as the write nodes.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"version": {
@@ -9213,6 +12929,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) com
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -9256,7 +12987,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) com
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -9339,13 +13070,6 @@ func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) com
 							},
 						},
 					},
-					"serviceAccount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specify a Service Account for tidb",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 					"replicas": {
 						SchemaProps: spec.SchemaProps{
 							Description: "The desired ready replicas",
@@ -9354,195 +13078,127 @@ func schema_pkg_apis_pingcap_v1alpha1_TiDBSpec(ref common.ReferenceCallback) com
 							Format:      "int32",
 						},
 					},
-					"baseImage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Base image of the component, image tag is now allowed during validation",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"service": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Service defines a Kubernetes service of TiDB cluster. Optional: No kubernetes service will be created by default.",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBServiceSpec"),
-						},
-					},
-					"binlogEnabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether enable TiDB Binlog, it is encouraged to not set this field and rely on the default behavior Optional: Defaults to true if PumpSpec is non-nil, otherwise false",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"maxFailoverCount": {
-						SchemaProps: spec.SchemaProps{
-							Description: "MaxFailoverCount limit the max replicas could be added in failover, 0 means no failover Optional: Defaults to 3",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"separateSlowLog": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether output the slow log in an separate sidecar container Optional: Defaults to true",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"slowLogVolumeName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Optional volume name configuration for slow query log.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"slowLogTailer": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The specification of the slow log tailer sidecar",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSlowLogTailerSpec"),
-						},
-					},
-					"tlsClient": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether enable the TLS connection between the SQL client and TiDB server Optional: Defaults to nil",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTLSClient"),
-						},
-					},
-					"tokenBasedAuthEnabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Whether enable `tidb_auth_token` authentication method. The tidb_auth_token authentication method is used only for the internal operation of TiDB Cloud. Optional: Defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"plugins": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Plugins is a list of plugins that are loaded by TiDB server, empty means plugin disabled",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"config": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Config is the Configuration of tidb-servers",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConfigWraper"),
-						},
-					},
-					"lifecycle": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Lifecycle describes actions that the management system should take in response to container lifecycle events. For the PostStart and PreStop lifecycle handlers, management of the container blocks until the action is complete, unless the container process fails, in which case the handler is aborted.",
-							Ref:         ref("k8s.io/api/core/v1.Lifecycle"),
-						},
-					},
-					"storageVolumes": {
+					"storageClaims": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageVolumes configure additional storage for TiDB pods.",
+							Description: "The persistent volume claims of the compute nodes, the first one stores the cache of the data read from the S3 storage.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume"),
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageClaim"),
 									},
 								},
 							},
 						},
 					},
-					"storageClassName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The storageClassName of the persistent volume for TiDB data storage. Defaults to Kubernetes default storage class.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"initializer": {
+				},
+				Required: []string{"replicas", "storageClaims"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageClaim", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiFlashConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiFlashConfig is the configuration of TiFlash.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"config": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Initializer is the init configurations of TiDB",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBInitializer"),
+							Description: "commonConfig is the Configuration of TiFlash process",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CommonConfig"),
 						},
 					},
-					"bootstrapSQLConfigMapName": {
+					"proxy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "BootstrapSQLConfigMapName is the name of the ConfigMap which contains the bootstrap SQL file with the key `bootstrap-sql`, which will only be executed when a TiDB cluster bootstrap on the first time. The field should be set ONLY when create a TC, since it only take effect on the first time bootstrap. Only v6.6.0+ supports this feature.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "proxyConfig is the Configuration of proxy process",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyConfig"),
 						},
 					},
 				},
-				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBInitializer", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBServiceSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSlowLogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBTLSClient", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CommonConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyConfig"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiDBTLSClient(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiFlashDisaggregatedSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiDBTLSClient can enable TLS connection between TiDB server and MySQL client",
+				Description: "TiFlashDisaggregatedSpec contains details of TiFlash in the disaggregated storage and compute architecture",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "When enabled, TiDB will accept TLS encrypted connections from MySQL client The steps to enable this feature:\n  1. Generate a TiDB server-side certificate and a client-side certifiacete for the TiDB cluster.\n     There are multiple ways to generate certificates:\n       - user-provided certificates: https://pingcap.com/docs/stable/how-to/secure/enable-tls-clients/\n       - use the K8s built-in certificate signing system signed certificates: https://kubernetes.io/docs/tasks/tls/managing-tls-in-a-cluster/\n       - or use cert-manager signed certificates: https://cert-manager.io/\n  2. Create a K8s Secret object which contains the TiDB server-side certificate created above.\n     The name of this Secret must be: <clusterName>-tidb-server-secret.\n       kubectl create secret generic <clusterName>-tidb-server-secret --namespace=<namespace> --from-file=tls.crt=<path/to/tls.crt> --from-file=tls.key=<path/to/tls.key> --from-file=ca.crt=<path/to/ca.crt>\n  3. Create a K8s Secret object which contains the TiDB client-side certificate created above which will be used by TiDB Operator.\n     The name of this Secret must be: <clusterName>-tidb-client-secret.\n       kubectl create secret generic <clusterName>-tidb-client-secret --namespace=<namespace> --from-file=tls.crt=<path/to/tls.crt> --from-file=tls.key=<path/to/tls.key> --from-file=ca.crt=<path/to/ca.crt>\n  4. Set Enabled to `true`.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"disableClientAuthn": {
+					"s3": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DisableClientAuthn will skip client's certificate validation from the TiDB server. Optional: defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "S3 is the storage shared by the write nodes and the compute nodes",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashS3Storage"),
 						},
 					},
-					"skipInternalClientCA": {
+					"computeNode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SkipInternalClientCA will skip TiDB server's certificate validation for internal components like Initializer, Dashboard, etc. Optional: defaults to false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "ComputeNode is the spec of the compute nodes",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashComputeNodeSpec"),
 						},
 					},
 				},
+				Required: []string{"s3", "computeNode"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashComputeNodeSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashS3Storage"},
 	}
 }
 
-func schema_pkg_apis_pingcap_v1alpha1_TiFlashConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_pingcap_v1alpha1_TiFlashS3Storage(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "TiFlashConfig is the configuration of TiFlash.",
+				Description: "TiFlashS3Storage is the S3 compatible storage of TiFlash in the disaggregated storage and compute architecture",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"config": {
+					"endpoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "commonConfig is the Configuration of TiFlash process",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CommonConfig"),
+							Description: "Endpoint of the S3 compatible storage service",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"proxy": {
+					"bucket": {
 						SchemaProps: spec.SchemaProps{
-							Description: "proxyConfig is the Configuration of proxy process",
-							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyConfig"),
+							Description: "Bucket in which to store the data",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"root": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Root is the path in the bucket where the data is stored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretName is the name of the secret which stores the access key and the secret key of the storage in `access_key` and `secret_key`. The credentials of the pods are used if it is not set, e.g. the IAM role of the service account.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"endpoint", "bucket"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CommonConfig", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ProxyConfig"},
 	}
 }
 
@@ -9739,6 +13395,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TiFlashSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -9782,7 +13453,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TiFlashSpec(ref common.ReferenceCallback)
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -9954,12 +13625,24 @@ func schema_pkg_apis_pingcap_v1alpha1_TiFlashSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy"),
 						},
 					},
+					"podDisruptionBudget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodDisruptionBudget configures the PodDisruptionBudget of TiFlash pods. No PodDisruptionBudget is created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec"),
+						},
+					},
+					"disaggregated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Disaggregated deploys TiFlash in the disaggregated storage and compute architecture, the TiFlash servers of this spec are the write nodes, which write the data to the S3 storage, and the compute nodes are deployed in a separate StatefulSet to run the queries. Requires TiFlash v7.0.0 or later, and it can't be changed once the TiFlash servers are deployed.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashDisaggregatedSpec"),
+						},
+					},
 				},
 				Required: []string{"replicas", "storageClaims"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitContainerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageClaim", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitContainerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageClaim", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashDisaggregatedSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -10891,6 +14574,92 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVGCConfig(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_TiKVGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiKVGroup is a group of TiKV in the TidbCluster",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the group, it must be unique in the TidbCluster",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec of the TiKV in the group, the cluster-level settings such as version and TLS are inherited from the TidbCluster",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec"),
+						},
+					},
+				},
+				Required: []string{"name", "spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TiKVIOTuning(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiKVIOTuning is the frequently tuned IO rate limit and background jobs of TiKV, which can be changed online. They override the same items in `spec.tikv.config` once TiKV is running.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rateBytesPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateBytesPerSec is `rocksdb.rate-bytes-per-sec`, the rate limit of the RocksDB compaction and flush, e.g. \"100MB\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ioRateLimitMaxBytesPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IORateLimitMaxBytesPerSec is `storage.io-rate-limit.max-bytes-per-sec`, the rate limit of the disk IO including the backup and import, e.g. \"500MB\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxBackgroundJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxBackgroundJobs is `rocksdb.max-background-jobs`, the number of the background compaction and flush threads",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxSubCompactions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSubCompactions is `rocksdb.max-sub-compactions`, the number of the sub-compactions of a compaction",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"backupNumThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupNumThreads is `backup.num-threads`, the number of the threads used by backup",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"importNumThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImportNumThreads is `import.num-threads`, the number of the threads used by import and restore",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_TiKVImportConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -11922,6 +15691,41 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVServerConfig(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_TiKVSlowStorePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiKVSlowStorePolicy configures the detection and the remediation of the slow TiKV stores.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scoreThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScoreThreshold is the slow score from which a store is slow, PD reports the slow score from 1 to 100 and a higher score means the store is slower. Optional: Defaults to 80",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is how long the slow score must stay above the threshold before the store is slow Optional: Defaults to 5m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"evictLeader": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictLeader evicts the leaders from the slow store until its slow score recovers, the leaders are evicted from at most one store at a time and not during the upgrade of TiKV.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -12115,6 +15919,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -12158,7 +15977,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -12306,6 +16125,12 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 							Format:      "",
 						},
 					},
+					"raftLogVolumeClaim": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RaftLogVolumeClaim provisions a dedicated volume for the raft engine and raftdb of TiKV, the volume is mounted to /var/lib/raft-log and the corresponding TiKV config is rendered unless it is set explicitly. It can not be added to or removed from an existing cluster because the raft log is not migrated.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RaftLogVolumeClaim"),
+						},
+					},
 					"logTailer": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LogTailer is the configurations of the log tailers for TiKV",
@@ -12359,12 +16184,31 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 							Format:      "",
 						},
 					},
+					"evictLeaderTimeoutPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictLeaderTimeoutPolicy indicates what to do when the leaders are not evicted within the EvictLeaderTimeout during the rolling upgrade. Defaults to ForceUpgradeAfterTimeout",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"waitLeaderTransferBackTimeout": {
 						SchemaProps: spec.SchemaProps{
 							Description: "WaitLeaderTransferBackTimeout indicates the timeout to wait for leader transfer back before the next tikv upgrade.\n\nDefaults to 400s",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"drainTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DrainTimeout indicates the timeout to wait for the store to migrate all its regions when scaling in. Once the timeout is hit, a DrainTimeout event is recorded and the scale-in stops until the store becomes tombstone or the pod is annotated with `tidb.pingcap.com/tikv-force-scale-in: \"true\"`.\n\nDefaults to wait until the store becomes tombstone",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"scaleOutBalance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleOutBalance configures the gate to wait for the regions and leaders to be balanced among the stores after scaling out, the scaling is not complete and the cluster is not ready until the stores are balanced or the timeout is hit.\n\nDefaults to nil, which means not to wait for the balance",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleOutBalanceGate"),
+						},
+					},
 					"storageVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "StorageVolumes configure additional storage for TiKV pods.",
@@ -12408,12 +16252,54 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVSpec(ref common.ReferenceCallback) com
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy"),
 						},
 					},
+					"podDisruptionBudget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodDisruptionBudget configures the PodDisruptionBudget of TiKV pods. No PodDisruptionBudget is created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec"),
+						},
+					},
+					"rollingUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RollingUpdateStrategy configures the partitioned rolling update of TiKV.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RollingUpdateStrategy"),
+						},
+					},
+					"ioTuning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOTuning configures the IO rate limit and the background jobs of TiKV, they are applied to the running TiKV pods by the online config API without rolling update.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVIOTuning"),
+						},
+					},
+					"capacityReservation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityReservation keeps placeholder pods sized like a TiKV member on the standby nodes, the placeholder pods are preempted by the replacement TiKV pods during failover, so the replacement TiKV pods are scheduled without waiting for new nodes. No placeholder pods are created if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CapacityReservation"),
+						},
+					},
+					"scaleInHook": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleInHook is the check run before the store of a TiKV pod is deleted during scale-in, the scale-in proceeds only if the check passes.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHook"),
+						},
+					},
+					"storeGC": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StoreGC is the policy to remove the tombstone and the orphan stores from PD, the stores are kept in PD if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStoreGC"),
+						},
+					},
+					"slowStore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SlowStore is the policy to detect the slow stores by the slow scores reported by PD, the SlowStore condition is raised for them and their leaders can be evicted. The slow stores are not detected if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSlowStorePolicy"),
+						},
+					},
 				},
 				Required: []string{"replicas"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.CapacityReservation", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Failover", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.LogTailerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PodDisruptionBudgetSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RaftLogVolumeClaim", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.RollingUpdateStrategy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleInHook", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScaleOutBalanceGate", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ScalePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StorageVolume", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVConfigWraper", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVIOTuning", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSlowStorePolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVStoreGC", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -12546,6 +16432,33 @@ func schema_pkg_apis_pingcap_v1alpha1_TiKVStorageReadPoolConfig(ref common.Refer
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_TiKVStoreGC(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TiKVStoreGC configures the removal of the stores which are left in PD after failed scale-ins or node losses.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tombstoneGracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TombstoneGracePeriod is how long the tombstone stores are kept before they are removed. PD removes all the tombstone stores at once, so they are removed only after the latest of them has been tombstone for the period. Optional: Defaults to 24h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"orphanGracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OrphanGracePeriod is how long an orphan store is kept before it is deleted. A store is orphan if it is down and no TiKV pod serves it anymore, e.g. the pod is scaled in or it is recreated with a new store after the loss of its node. Optional: Defaults to 24h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_TiKVTitanCfConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -12901,6 +16814,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TiProxySpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -12944,7 +16872,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TiProxySpec(ref common.ReferenceCallback)
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -13171,6 +17099,13 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbAutoScalerStatus(ref common.ReferenceC
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"scaleInDeferredReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleInDeferredReason describes why the last scale-in was deferred, e.g. it would violate the PodDisruptionBudget or the regions are still being migrated. It is cleared after the next auto-scaling.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -13411,6 +17346,130 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterAutoScalerStatus(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterBackupPolicy requires a recent successful backup of the TidbClusters in its namespace before the destructive operations on them are admitted. It is enforced by the validating admission webhook, which checks the completed snapshot and volume-snapshot Backups of the cluster in the namespace of the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec contains all spec about the policy.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicySpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicySpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterBackupPolicyList is a TidbClusterBackupPolicy list.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicy"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterBackupPolicy"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterBackupPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterBackupPolicySpec is spec of TidbClusterBackupPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the name of the TidbCluster in the same namespace which the policy applies to. Optional: Defaults to all the TidbClusters in the namespace",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"operations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operations are the destructive operations which are refused without a recent successful backup. Optional: Defaults to all the operations",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"maxBackupAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxBackupAge is the max age of the latest successful backup, the operations are refused unless a backup of the cluster is completed within the duration, e.g. 24h.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"maxBackupAge"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_TidbClusterList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13463,30 +17522,182 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterRef(ref common.ReferenceCallbac
 				Properties: map[string]spec.Schema{
 					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Namespace is the namespace that TidbCluster object locates, default to the same namespace as TidbMonitor/TidbCluster/TidbNGMonitoring/TidbDashboard",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Namespace is the namespace that TidbCluster object locates, default to the same namespace as TidbMonitor/TidbCluster/TidbNGMonitoring/TidbDashboard",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of TidbCluster object",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterDomain is the domain of TidbCluster object",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterRollout(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterRollout applies a spec patch, e.g. a version bump, to the TidbClusters selected by labels in waves. A wave is started only after all clusters of the previous wave are healthy or failed, and the rollout is halted when the failed clusters exceed maxFailures.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec contains all spec about the rollout.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRolloutSpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRolloutSpec"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterRolloutList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterRolloutList is a TidbClusterRollout list.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRollout"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRollout"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbClusterRolloutSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterRolloutSpec is spec of TidbClusterRollout.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector selects the TidbClusters to roll out from the namespace of the rollout. The clusters are selected when the rollout starts, clusters created later are not rolled out.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"patch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patch is the JSON merge patch applied to the spec of the selected TidbClusters, e.g. {\"version\": \"v7.5.0\"}",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"waves": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Waves is the number of clusters patched in each wave, ordered by name. The last wave size is used for the rest clusters, e.g. [1, 5] patches 1 cluster as a canary, then 5 clusters in every following wave. Optional: Defaults to [1]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReadySeconds is the time a patched cluster should wait before checking its health, so that the operator has enough time to start upgrading the cluster. Optional: Defaults to 60",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"healthCheckTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheckTimeout is the max duration waiting for a patched cluster to be healthy, the cluster is considered as failed after that. A cluster is healthy when it's ready and none of its components is upgrading. Optional: Defaults to 30m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"name": {
+					"maxFailures": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name is the name of TidbCluster object",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "MaxFailures is the max number of failed clusters tolerated, the rollout is halted when the failed clusters exceed it. Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"clusterDomain": {
+					"paused": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ClusterDomain is the domain of TidbCluster object",
-							Type:        []string{"string"},
+							Description: "Paused stops patching more clusters, the patched clusters are still checked.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"name"},
+				Required: []string{"selector", "patch"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -13517,6 +17728,19 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref common.ReferenceCallba
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSpec"),
 						},
 					},
+					"pdms": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PDMS is the spec of the PD microservices, it only takes effect when `spec.pd.mode` is \"ms\"",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMSSpec"),
+									},
+								},
+							},
+						},
+					},
 					"tidb": {
 						SchemaProps: spec.SchemaProps{
 							Description: "TiDB cluster spec",
@@ -13529,6 +17753,20 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref common.ReferenceCallba
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec"),
 						},
 					},
+					"tikvGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TiKVGroups are the groups of TiKV with different resources, storage classes, labels or configs, each group is deployed as its own StatefulSet by a heterogeneous TidbCluster `<cluster>-<group>` owned by this TidbCluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGroup"),
+									},
+								},
+							},
+						},
+					},
 					"tiflash": {
 						SchemaProps: spec.SchemaProps{
 							Description: "TiFlash cluster spec",
@@ -13616,9 +17854,32 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"registryMirrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegistryMirrors maps the registries or repository prefixes to their mirrors, e.g. `docker.io: mirror.example.com`, it is applied to the images of all the components and overrides the global registry mirrors of tidb-operator.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"imageDigestPinning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImageDigestPinning pins the pods of PD, TiKV, TiDB and TiFlash to the digests of their images, so that the mutation of a tag during a rolling update can not produce mixed binaries across a StatefulSet. The digest of an image is resolved by a probe pod pulling the image before the StatefulSet is upgraded to it, and the StatefulSet is upgraded to the pinned image at once. Optional: Defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"configUpdateStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigUpdateStrategy determines how the configuration change is applied to the cluster. UpdateStrategyInPlace will update the ConfigMap of configuration in-place and an extra rolling-update of the cluster component is needed to reload the configuration change. UpdateStrategyRollingUpdate will create a new ConfigMap with the new configuration and rolling-update the related components to use the new ConfigMap, that is, the new configuration will be applied automatically.",
+							Description: "ConfigUpdateStrategy determines how the configuration change is applied to the cluster. UpdateStrategyInPlace will update the ConfigMap of configuration in-place and an extra rolling-update of the cluster component is needed to reload the configuration change. UpdateStrategyRollingUpdate will create a new ConfigMap with the new configuration and rolling-update the related components to use the new ConfigMap, that is, the new configuration will be applied automatically. UpdateStrategyHotReload will apply the changes by the online config API of PD and TiKV without restarting them if only the hot-reloadable items are changed, otherwise it works like UpdateStrategyRollingUpdate.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -13759,12 +18020,24 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"peerRegistry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PeerRegistry is the registry which the TidbClusters deployed across Kubernetes clusters register with to exchange their PD addresses and cluster domains, so PD joins the PD of the registered TidbClusters without configuring cluster or pdAddresses. It is only used if acrossK8s is set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PeerRegistry"),
+						},
+					},
 					"cluster": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Cluster is the external cluster, if configured, the components in this TidbCluster will join to this configured cluster.",
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef"),
 						},
 					},
+					"secretReplication": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretReplication replicates the Secrets of the TidbCluster referenced by cluster into the namespace of this TidbCluster, e.g. the TLS client Secrets, so they are not copied manually. It is only used if the referenced TidbCluster is in another namespace of the same Kubernetes cluster.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretReplication"),
+						},
+					},
 					"pdAddresses": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PDAddresses are the external PD addresses, if configured, the PDs in this TidbCluster will join to the configured PD cluster.",
@@ -13842,11 +18115,49 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbClusterSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"statusCompaction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatusCompaction configures the compaction of the status of very large clusters. The status is not compacted if it is not set.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StatusCompaction"),
+						},
+					},
+					"notification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Notification configures the external sinks which the significant events of the cluster are forwarded to, in addition to the global sinks of tidb-operator.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSpec"),
+						},
+					},
+					"gcTuning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GCTuning configures the windows in which the GC settings of TiKV are adjusted, e.g. a more aggressive GC in the off-peak hours. It takes effect only if the GCTuning feature of tidb-operator is enabled.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningPolicy"),
+						},
+					},
+					"operationTimeouts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperationTimeouts configures the timeouts of the upgrade and scaling of the components, the operations not completed in time are reported by the ComponentOperationTimeout condition and a warning event.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OperationTimeoutPolicy"),
+						},
+					},
+					"overrides": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides are the patches applied to the resources generated by tidb-operator, they cover the gaps in the API without forking tidb-operator. The overrides are applied in order after the resources are generated, the overrides which change the fields managed by tidb-operator are not applied and are reported by the status and a warning event.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ResourceOverride"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiscoverySpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.HelperSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PumpSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCluster", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiProxySpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DiscoverySpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GCTuningPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.HelperSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NotificationSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.OperationTimeoutPolicy", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDMSSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PDSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PeerRegistry", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PumpSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ResourceOverride", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SecretReplication", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.StatusCompaction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TLSCluster", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiCDCSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiDBSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiFlashSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVGroup", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiKVSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TiProxySpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -14123,6 +18434,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbDashboardSpec(ref common.ReferenceCall
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -14166,7 +18492,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbDashboardSpec(ref common.ReferenceCall
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -14481,6 +18807,20 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbInitializerSpec(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"sqlSources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SqlSources are the SQL sources executed in the declared order after initSql or initSqlConfigMap. Every line of a source is executed as a statement, the same as initSql.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSource"),
+									},
+								},
+							},
+						},
+					},
 					"passwordSecret": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -14511,7 +18851,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbInitializerSpec(ref common.ReferenceCa
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSource", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
@@ -14581,11 +18921,25 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbInitializerStatus(ref common.Reference
 							Format:      "",
 						},
 					},
+					"sqlSources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SqlSources is the execution progress of the SQL sources, it is recorded when the initializer job finishes",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSourceStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/batch/v1.JobCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitSqlSourceStatus", "k8s.io/api/batch/v1.JobCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -14626,6 +18980,97 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbMonitor(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorAlertingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbMonitorAlertingSpec configures the alerting of TidbMonitor when a cluster is monitored by several TidbMonitors",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority of the TidbMonitor to claim the alerting of the monitored TidbClusters. When a TidbCluster is monitored by several TidbMonitors with alert rules, only the one with the highest priority sends the alerts of the TidbCluster, ties are broken by the namespace and name of the TidbMonitors. A TidbMonitor that claims none of the monitored clusters does not render the alert rules. Optional: Defaults to 0",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dedupLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DedupLabels are the external labels dropped from the alerts before they are sent to Alertmanager, so the same alerts sent by the replicas of the TidbMonitor are deduplicated by Alertmanager. Optional: Defaults to the replica external label",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorClusterSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbMonitorClusterSelector selects the clusters to monitor by labels",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LabelSelector selects the TidbClusters and DMClusters by their labels, an empty selector selects all clusters. DMClusters are selected only if spec.dm is set.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces to discover the clusters in, an empty selector selects all namespaces. Optional: Defaults to the namespace of the TidbMonitor",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorHealthCheck(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbMonitorHealthCheck is the health check configuration of TidbMonitor",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"autoRestart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoRestart indicates whether to restart the TidbMonitor pod whose Prometheus or Grafana keeps unhealthy longer than RestartThreshold.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"restartThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartThreshold is the duration a pod must be unhealthy before it is restarted. Optional: Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14726,6 +19171,12 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorSpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"clusterSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterSelector selects the TidbClusters and DMClusters to monitor in addition to the clusters listed explicitly. The selected clusters are discovered dynamically, and the Prometheus config is updated when they appear or disappear. Set clusterScoped to true if the clusters in other namespaces are selected.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorClusterSelector"),
+						},
+					},
 					"prometheus": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Prometheus spec",
@@ -14917,6 +19368,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorSpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"clusterScoped": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ClusterScoped indicates whether this monitor should manage Kubernetes cluster-wide TiDB clusters",
@@ -14988,6 +19454,12 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"alerting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Alerting configures how the alerts of the clusters monitored by several TidbMonitors are deduplicated.",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorAlertingSpec"),
+						},
+					},
 					"timezone": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Time zone of TidbMonitor Optional: Defaults to UTC",
@@ -14995,12 +19467,18 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbMonitorSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheck configures the health checks of the Prometheus and Grafana of TidbMonitor, the results are reported in the conditions of the TidbMonitor status. Optional: Defaults to nil, which means the health checks are disabled",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorHealthCheck"),
+						},
+					},
 				},
 				Required: []string{"prometheus", "reloader", "initializer"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMMonitorSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GrafanaSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitializerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PrometheusReloaderSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PrometheusSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ReloaderSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ThanosSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.DMMonitorSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.GrafanaSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.InitializerSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PrometheusReloaderSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.PrometheusSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ReloaderSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.ThanosSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorAlertingSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorClusterSelector", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbMonitorHealthCheck", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
 	}
 }
 
@@ -15277,6 +19755,21 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbNGMonitoringSpec(ref common.ReferenceC
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -15320,7 +19813,7 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbNGMonitoringSpec(ref common.ReferenceC
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -15415,12 +19908,18 @@ func schema_pkg_apis_pingcap_v1alpha1_TidbNGMonitoringSpec(ref common.ReferenceC
 							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringSpec"),
 						},
 					},
+					"conprof": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conprof configures the continuous profiling data of ng monitoring",
+							Ref:         ref("github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofSpec"),
+						},
+					},
 				},
 				Required: []string{"clusters", "ngMonitoring"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount"},
+			"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringConprofSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.NGMonitoringSpec", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.Probe", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.SuspendAction", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TidbClusterRef", "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1.TopologySpreadConstraint", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
@@ -15502,6 +20001,13 @@ func schema_pkg_apis_pingcap_v1alpha1_TikvAutoScalerStatus(ref common.ReferenceC
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"scaleInDeferredReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleInDeferredReason describes why the last scale-in was deferred, e.g. it would violate the PodDisruptionBudget or the regions are still being migrated. It is cleared after the next auto-scaling.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -15805,6 +20311,21 @@ func schema_pkg_apis_pingcap_v1alpha1_WorkerSpec(ref common.ReferenceCallback) c
 							},
 						},
 					},
+					"nativeSidecarContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeSidecarContainers are the names of the additional containers which run as native sidecar containers, they start before and terminate after the main container of the component. They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on older Kubernetes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"additionalVolumes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional volumes of component pod.",
@@ -15848,7 +20369,7 @@ func schema_pkg_apis_pingcap_v1alpha1_WorkerSpec(ref common.ReferenceCallback) c
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -23375,7 +27896,7 @@ func schema_k8sio_api_core_v1_PodSpec(ref common.ReferenceCallback) common.OpenA
 					},
 					"terminationGracePeriodSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to 30 seconds.",
+							Description: "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process. Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash, 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds. The existing StatefulSets keep their current grace period unless it is set explicitly.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -0,0 +1,67 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "fmt"
+
+// GetPDRecoverJobName returns the name of the job which runs pd-recover
+func (r *PDRecovery) GetPDRecoverJobName() string {
+	return fmt.Sprintf("%s-pd-recover", r.GetName())
+}
+
+// IsPDRecoveryFinished returns whether the recovery is complete or failed
+func IsPDRecoveryFinished(r *PDRecovery) bool {
+	return r.Status.Phase == PDRecoveryWorkflowPhaseComplete || r.Status.Phase == PDRecoveryWorkflowPhaseFailed
+}
+
+// IsStepConfirmed returns whether the step can be started, the steps which don't need the
+// confirmation are always confirmed
+func (r *PDRecovery) IsStepConfirmed(step PDRecoveryStep) bool {
+	needed := false
+	for _, s := range PDRecoveryConfirmationSteps {
+		if s == step {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return true
+	}
+	for _, s := range r.Spec.ConfirmedSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStepStatus returns the status of the step, or nil if the step is not started
+func (r *PDRecovery) GetStepStatus(step PDRecoveryStep) *PDRecoveryStepStatus {
+	for i := range r.Status.Steps {
+		if r.Status.Steps[i].Step == step {
+			return &r.Status.Steps[i]
+		}
+	}
+	return nil
+}
+
+// NextPDRecoveryStep returns the step after the given one, it returns false if the given step is the last one
+func NextPDRecoveryStep(step PDRecoveryStep) (PDRecoveryStep, bool) {
+	for i, s := range PDRecoverySteps {
+		if s == step && i+1 < len(PDRecoverySteps) {
+			return PDRecoverySteps[i+1], true
+		}
+	}
+	return "", false
+}
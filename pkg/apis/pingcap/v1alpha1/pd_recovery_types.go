@@ -0,0 +1,179 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PDRecoveryStep is a step of rebuilding PD from the surviving TiKV stores
+type PDRecoveryStep string
+
+const (
+	// PDRecoveryStepCheck checks all the PD data is lost and resolves the cluster ID and the alloc ID
+	PDRecoveryStepCheck PDRecoveryStep = "Check"
+	// PDRecoveryStepRebuildPD wipes the volumes of the PD members and bootstraps a new PD cluster
+	PDRecoveryStepRebuildPD PDRecoveryStep = "RebuildPD"
+	// PDRecoveryStepRecoverPD runs pd-recover to restore the cluster ID and the alloc ID of the new
+	// PD cluster, and restarts the PD members
+	PDRecoveryStepRecoverPD PDRecoveryStep = "RecoverPD"
+	// PDRecoveryStepRejoinStores restarts the TiKV and TiFlash stores to register to the recovered PD cluster
+	PDRecoveryStepRejoinStores PDRecoveryStep = "RejoinStores"
+	// PDRecoveryStepRestartTiDB restarts the TiDB members to reload the schema from the recovered cluster
+	PDRecoveryStepRestartTiDB PDRecoveryStep = "RestartTiDB"
+)
+
+// PDRecoverySteps are the steps of PDRecovery in order
+var PDRecoverySteps = []PDRecoveryStep{
+	PDRecoveryStepCheck,
+	PDRecoveryStepRebuildPD,
+	PDRecoveryStepRecoverPD,
+	PDRecoveryStepRejoinStores,
+	PDRecoveryStepRestartTiDB,
+}
+
+// PDRecoveryConfirmationSteps are the steps which are not started until they are listed in
+// spec.confirmedSteps, as they destroy data or make the stores serve again
+var PDRecoveryConfirmationSteps = []PDRecoveryStep{
+	PDRecoveryStepRebuildPD,
+	PDRecoveryStepRejoinStores,
+}
+
+// PDRecoveryWorkflowPhase is the phase of PDRecovery
+type PDRecoveryWorkflowPhase string
+
+const (
+	// PDRecoveryWorkflowPhaseRunning means a step is running
+	PDRecoveryWorkflowPhaseRunning PDRecoveryWorkflowPhase = "Running"
+	// PDRecoveryWorkflowPhaseWaitingForConfirmation means the next step is not confirmed by spec.confirmedSteps
+	PDRecoveryWorkflowPhaseWaitingForConfirmation PDRecoveryWorkflowPhase = "WaitingForConfirmation"
+	// PDRecoveryWorkflowPhaseComplete means PD is rebuilt and the stores rejoin it
+	PDRecoveryWorkflowPhaseComplete PDRecoveryWorkflowPhase = "Complete"
+	// PDRecoveryWorkflowPhaseFailed means the recovery is refused or failed, it must be checked manually
+	PDRecoveryWorkflowPhaseFailed PDRecoveryWorkflowPhase = "Failed"
+)
+
+// PDRecovery rebuilds PD of a TidbCluster from the surviving TiKV stores after the data of all the
+// PD members is lost. It replaces the manual pd-recover runbook: the cluster ID and the alloc ID are
+// resolved, a new PD cluster is bootstrapped and recovered by pd-recover, then the stores rejoin it.
+// The steps which destroy data or make the stores serve again wait for the confirmation in spec.confirmedSteps.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="pdr"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The cluster whose PD is rebuilt"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The phase of the recovery"
+// +kubebuilder:printcolumn:name="Step",type=string,JSONPath=`.status.step`,description="The current step of the recovery"
+// +kubebuilder:printcolumn:name="ClusterID",type=string,JSONPath=`.status.clusterID`,description="The cluster ID to recover"
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,description="The message of the current step",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type PDRecovery struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the recovery.
+	Spec PDRecoverySpec `json:"spec"`
+
+	// Status is most recently observed status of the recovery.
+	//
+	// +k8s:openapi-gen=false
+	Status PDRecoveryWorkflowStatus `json:"status,omitempty"`
+}
+
+// PDRecoveryList is a PDRecovery list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PDRecoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PDRecovery `json:"items"`
+}
+
+// PDRecoverySpec is spec of PDRecovery.
+//
+// +k8s:openapi-gen=true
+type PDRecoverySpec struct {
+	// Cluster is the name of the TidbCluster whose PD is rebuilt, it must be in the same
+	// namespace as the PDRecovery.
+	Cluster string `json:"cluster"`
+
+	// ClusterID is the ID of the cluster which the surviving TiKV stores belong to. It must be set
+	// if the cluster ID in the status of the TidbCluster is overwritten by a new PD cluster.
+	// Optional: Defaults to the cluster ID in the status of the TidbCluster
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// AllocID is the ID from which the recovered PD allocates the IDs, it must be greater than all
+	// the IDs allocated by the lost PD, including the IDs of the regions.
+	// Optional: Defaults to the largest store ID in the status of the TidbCluster plus 100000000
+	// +optional
+	AllocID uint64 `json:"allocID,omitempty"`
+
+	// ConfirmedSteps are the steps which are confirmed to run, the steps RebuildPD and RejoinStores
+	// wait until they are listed here. Check the cluster ID and the alloc ID in the status before
+	// confirming RebuildPD.
+	// +optional
+	ConfirmedSteps []PDRecoveryStep `json:"confirmedSteps,omitempty"`
+
+	// Resources of the pd-recover job.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PDRecoveryWorkflowStatus is status of PDRecovery.
+type PDRecoveryWorkflowStatus struct {
+	// Phase is the phase of the recovery.
+	Phase PDRecoveryWorkflowPhase `json:"phase,omitempty"`
+
+	// Step is the current step of the recovery.
+	Step PDRecoveryStep `json:"step,omitempty"`
+
+	// ClusterID is the resolved ID of the cluster to recover.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// AllocID is the resolved alloc ID passed to pd-recover.
+	AllocID uint64 `json:"allocID,omitempty"`
+
+	// Steps are the status of the started steps.
+	Steps []PDRecoveryStepStatus `json:"steps,omitempty"`
+
+	// Message describes the current step or the reason of the failure.
+	Message string `json:"message,omitempty"`
+}
+
+// PDRecoveryStepStatus is the status of a step of PDRecovery
+type PDRecoveryStepStatus struct {
+	// Step is the name of the step.
+	Step PDRecoveryStep `json:"step"`
+
+	// StartTime is the time when the step starts.
+	// +nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the step is completed.
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message describes the progress of the step.
+	Message string `json:"message,omitempty"`
+}
@@ -72,6 +72,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TidbNGMonitoringList{},
 		&TidbDashboard{},
 		&TidbDashboardList{},
+		&TidbClusterRollout{},
+		&TidbClusterRolloutList{},
+		&Diagnostic{},
+		&DiagnosticList{},
+		&TiCDCChangefeed{},
+		&TiCDCChangefeedList{},
+		&PDRecovery{},
+		&PDRecoveryList{},
+		&DMTask{},
+		&DMTaskList{},
+		&TidbClusterBackupPolicy{},
+		&TidbClusterBackupPolicyList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -23,12 +23,20 @@ import (
 
 // GetRestoreJobName return the restore job name
 func (rs *Restore) GetRestoreJobName() string {
+	if IsRestoreVerifying(rs) {
+		return fmt.Sprintf("restore-verify-%s", rs.GetName())
+	}
 	if IsRestoreVolumeComplete(rs) && !IsRestoreDataComplete(rs) {
 		return fmt.Sprintf("restore-data-%s", rs.GetName())
 	}
 	return fmt.Sprintf("restore-%s", rs.GetName())
 }
 
+// NeedVerification returns whether the restored data needs to be verified by the verification job
+func (rs *Restore) NeedVerification() bool {
+	return rs.Spec.Verification == RestoreVerificationChecksum || rs.Spec.Verification == RestoreVerificationAdminCheck
+}
+
 // GetInstanceName return the restore instance name
 func (rs *Restore) GetInstanceName() string {
 	if rs.Labels != nil {
@@ -66,6 +74,14 @@ func GetRestoreCondition(status *RestoreStatus, conditionType RestoreConditionTy
 // one. Sets LastTransitionTime to now if the status has changed.
 // Returns true if Restore condition has changed or has been added.
 func UpdateRestoreCondition(status *RestoreStatus, condition *RestoreCondition) bool {
+	changed := updateRestoreCondition(status, condition)
+	if changed {
+		updateRestoreStandardConditions(status)
+	}
+	return changed
+}
+
+func updateRestoreCondition(status *RestoreStatus, condition *RestoreCondition) bool {
 	if condition == nil {
 		return false
 	}
@@ -74,9 +90,20 @@ func UpdateRestoreCondition(status *RestoreStatus, condition *RestoreCondition)
 	conditionIndex, oldCondition := GetRestoreCondition(status, condition.Type)
 
 	switch condition.Type {
-	case RestoreVolumeComplete, RestoreDataComplete:
-		// VolumeComplete and DataComplete are intermediately conditions,
+	case RestoreVolumeComplete, RestoreDataComplete, RestoreSnapshotComplete:
+		// VolumeComplete, DataComplete and SnapshotComplete are intermediately conditions,
 		// they can not represent the current phase of restore.
+	case RestoreResuming:
+		// the failed condition set by the failed restore job is reverted when the restore is resuming,
+		// otherwise the resumed restore is skipped. Resuming is cleared once the job is relaunched.
+		if condition.Status == corev1.ConditionTrue {
+			status.Phase = condition.Type
+			if _, failed := GetRestoreCondition(status, RestoreFailed); failed != nil && failed.Status == corev1.ConditionTrue {
+				failed.Status = corev1.ConditionFalse
+				failed.Reason = string(RestoreResuming)
+				failed.LastTransitionTime = condition.LastTransitionTime
+			}
+		}
 	default:
 		status.Phase = condition.Type
 	}
@@ -101,6 +128,51 @@ func UpdateRestoreCondition(status *RestoreStatus, condition *RestoreCondition)
 	return !isUpdate
 }
 
+// updateRestoreStandardConditions derives the standard Ready, Progressing and Degraded
+// conditions from the phase. These conditions are written directly and never change the phase.
+func updateRestoreStandardConditions(status *RestoreStatus) {
+	var ready, progressing, degraded bool
+	switch status.Phase {
+	case RestoreComplete:
+		ready = true
+	case RestoreScheduled, RestoreRunning, RestoreVerifying, RestoreRetryFailed, RestoreResuming:
+		progressing = true
+	case RestoreFailed, RestoreInvalid:
+		degraded = true
+	}
+	reason := string(status.Phase)
+	setRestoreStandardCondition(status, RestoreReady, ready, reason)
+	setRestoreStandardCondition(status, RestoreProgressing, progressing, reason)
+	setRestoreStandardCondition(status, RestoreDegraded, degraded, reason)
+}
+
+func setRestoreStandardCondition(status *RestoreStatus, conditionType RestoreConditionType, value bool, reason string) {
+	condition := RestoreCondition{
+		Type:               conditionType,
+		Status:             corev1.ConditionFalse,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	}
+	if value {
+		condition.Status = corev1.ConditionTrue
+	}
+	index, old := GetRestoreCondition(status, conditionType)
+	if old == nil {
+		status.Conditions = append(status.Conditions, condition)
+		return
+	}
+	if old.Status == condition.Status {
+		condition.LastTransitionTime = old.LastTransitionTime
+	}
+	status.Conditions[index] = condition
+}
+
+// IsRestoreResuming returns true if the failed restore job is waiting to be relaunched to resume from checkpoint
+func IsRestoreResuming(restore *Restore) bool {
+	attempts := restore.Status.ResumeAttempts
+	return len(attempts) > 0 && attempts[len(attempts)-1].ResumedAt == nil
+}
+
 // IsRestoreInvalid returns true if a Restore has invalid condition set
 func IsRestoreInvalid(restore *Restore) bool {
 	_, condition := GetRestoreCondition(&restore.Status, RestoreInvalid)
@@ -113,6 +185,12 @@ func IsRestoreComplete(restore *Restore) bool {
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
 
+// IsRestoreVerifying returns true if the restored data of a Restore is being verified
+func IsRestoreVerifying(restore *Restore) bool {
+	_, condition := GetRestoreCondition(&restore.Status, RestoreVerifying)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
 // IsRestoreScheduled returns true if a Restore has successfully scheduled
 func IsRestoreScheduled(restore *Restore) bool {
 	_, condition := GetRestoreCondition(&restore.Status, RestoreScheduled)
@@ -137,6 +215,12 @@ func IsRestoreVolumeComplete(restore *Restore) bool {
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
 
+// IsRestoreSnapshotComplete returns true if the full snapshot backup of a pitr Restore has been restored
+func IsRestoreSnapshotComplete(restore *Restore) bool {
+	_, condition := GetRestoreCondition(&restore.Status, RestoreSnapshotComplete)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
 // IsRestoreDataComplete returns true if a Restore for data consistency has successfully completed
 func IsRestoreDataComplete(restore *Restore) bool {
 	_, condition := GetRestoreCondition(&restore.Status, RestoreDataComplete)
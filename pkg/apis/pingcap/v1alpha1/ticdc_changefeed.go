@@ -0,0 +1,27 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// GetChangefeedID returns the ID of the changefeed in TiCDC
+func (cf *TiCDCChangefeed) GetChangefeedID() string {
+	if cf.Spec.ChangefeedID != "" {
+		return cf.Spec.ChangefeedID
+	}
+	return cf.GetName()
+}
+
+// IsChangefeedPaused returns whether TiCDC reports the changefeed is paused
+func (cf *TiCDCChangefeed) IsChangefeedPaused() bool {
+	return cf.Status.State == TiCDCChangefeedStateStopped
+}
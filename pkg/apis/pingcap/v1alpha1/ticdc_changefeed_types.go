@@ -0,0 +1,163 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TiCDCChangefeedState is the state of a changefeed reported by TiCDC
+type TiCDCChangefeedState string
+
+const (
+	// TiCDCChangefeedStateNormal means the changefeed is replicating
+	TiCDCChangefeedStateNormal TiCDCChangefeedState = "normal"
+	// TiCDCChangefeedStateStopped means the changefeed is paused
+	TiCDCChangefeedStateStopped TiCDCChangefeedState = "stopped"
+	// TiCDCChangefeedStateWarning means the changefeed meets a retryable error
+	TiCDCChangefeedStateWarning TiCDCChangefeedState = "warning"
+	// TiCDCChangefeedStateError means the changefeed meets an error and is retrying
+	TiCDCChangefeedStateError TiCDCChangefeedState = "error"
+	// TiCDCChangefeedStateFailed means the changefeed meets an unrecoverable error
+	TiCDCChangefeedStateFailed TiCDCChangefeedState = "failed"
+	// TiCDCChangefeedStateFinished means the changefeed reaches the target ts
+	TiCDCChangefeedStateFinished TiCDCChangefeedState = "finished"
+)
+
+// TiCDCChangefeed declares a changefeed of the TiCDC of a TidbCluster, the changefeed is created,
+// updated, paused, resumed and removed by the TiCDC open API accordingly.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="cf"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The cluster which replicates the changefeed"
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The state of the changefeed"
+// +kubebuilder:printcolumn:name="Checkpoint",type=string,JSONPath=`.status.checkpointTime`,description="The time of the checkpoint"
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,description="The last error",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TiCDCChangefeed struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the changefeed.
+	Spec TiCDCChangefeedSpec `json:"spec"`
+
+	// Status is most recently observed status of the changefeed.
+	//
+	// +k8s:openapi-gen=false
+	Status TiCDCChangefeedStatus `json:"status,omitempty"`
+}
+
+// TiCDCChangefeedList is a TiCDCChangefeed list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TiCDCChangefeedList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TiCDCChangefeed `json:"items"`
+}
+
+// TiCDCChangefeedSpec is spec of TiCDCChangefeed.
+//
+// +k8s:openapi-gen=true
+type TiCDCChangefeedSpec struct {
+	// Cluster is the name of the TidbCluster whose TiCDC replicates the changefeed,
+	// it must be in the same namespace as the TiCDCChangefeed.
+	Cluster string `json:"cluster"`
+
+	// ChangefeedID is the ID of the changefeed in TiCDC, it can not be changed.
+	// Optional: Defaults to the name of the TiCDCChangefeed
+	// +optional
+	ChangefeedID string `json:"changefeedID,omitempty"`
+
+	// SinkURI is the URI of the downstream, e.g. `mysql://root@downstream:4000/` or `kafka://broker:9092/topic`.
+	// Use SinkURISecretRef instead if the URI contains credentials.
+	// +optional
+	SinkURI string `json:"sinkURI,omitempty"`
+
+	// SinkURISecretRef selects the key of a Secret which contains the URI of the downstream.
+	// +optional
+	SinkURISecretRef *corev1.SecretKeySelector `json:"sinkURISecretRef,omitempty"`
+
+	// StartTs is the TSO where the changefeed starts to replicate, it is only used when the
+	// changefeed is created.
+	// Optional: Defaults to the current TSO
+	// +optional
+	StartTs uint64 `json:"startTs,omitempty"`
+
+	// TargetTs is the TSO where the changefeed stops replicating and becomes finished.
+	// Optional: Defaults to 0, which means the changefeed never stops
+	// +optional
+	TargetTs uint64 `json:"targetTs,omitempty"`
+
+	// Filter configures the tables and the transactions to replicate.
+	// +optional
+	Filter *TiCDCChangefeedFilter `json:"filter,omitempty"`
+
+	// Config is the replica config of the changefeed in TOML, in the same format as the
+	// config file of `cdc cli changefeed create --config`.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+
+	// Paused pauses the changefeed, it is resumed after Paused is unset.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TiCDCChangefeedFilter configures the tables and the transactions replicated by the changefeed.
+//
+// +k8s:openapi-gen=true
+type TiCDCChangefeedFilter struct {
+	// Rules are the table filter rules, e.g. `test.*` and `!test.tmp_*`.
+	// Optional: Defaults to all the tables
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+
+	// IgnoreTxnStartTs are the start ts of the transactions which are not replicated.
+	// +optional
+	IgnoreTxnStartTs []uint64 `json:"ignoreTxnStartTs,omitempty"`
+}
+
+// TiCDCChangefeedStatus is status of TiCDCChangefeed.
+type TiCDCChangefeedStatus struct {
+	// ObservedGeneration is the generation of the spec which is applied to the changefeed.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ChangefeedID is the ID of the changefeed in TiCDC.
+	ChangefeedID string `json:"changefeedID,omitempty"`
+
+	// State is the state of the changefeed reported by TiCDC.
+	State TiCDCChangefeedState `json:"state,omitempty"`
+
+	// CheckpointTs is the TSO which all the changes before are replicated to the downstream.
+	CheckpointTs uint64 `json:"checkpointTs,omitempty"`
+
+	// CheckpointTime is the physical time of CheckpointTs.
+	CheckpointTime string `json:"checkpointTime,omitempty"`
+
+	// Message is the last error of the changefeed reported by TiCDC or met by the controller.
+	Message string `json:"message,omitempty"`
+}
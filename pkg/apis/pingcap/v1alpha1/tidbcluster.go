@@ -40,14 +40,38 @@ const (
 	// defaultEvictLeaderTimeout is the timeout limit of evict leader
 	defaultEvictLeaderTimeout            = 1500 * time.Minute
 	defaultWaitLeaderTransferBackTimeout = 400 * time.Second
+	// defaultScaleOutBalanceTimeout is the timeout to wait for the balance after scaling out
+	defaultScaleOutBalanceTimeout = time.Hour
+	// defaultScaleOutBalanceMaxSkewPercent is the max skew of the stores after scaling out
+	defaultScaleOutBalanceMaxSkewPercent int32 = 20
 	// defaultTiCDCGracefulShutdownTimeout is the timeout limit of graceful
 	// shutdown a TiCDC pod.
 	defaultTiCDCGracefulShutdownTimeout = 10 * time.Minute
+	// defaultStatusCompactionMemberThreshold is the default min number of
+	// stores or members of a component to compact its status.
+	defaultStatusCompactionMemberThreshold = 50
+	// defaultTiDBAuthTokenRotationInterval is the default interval to rotate the signing key of `tidb_auth_token`
+	defaultTiDBAuthTokenRotationInterval = 720 * time.Hour
+	// defaultTiDBRestartWindow is the default duration of the scheduled restart windows of TiDB
+	defaultTiDBRestartWindow = time.Hour
+	// defaultTiDBConnectionPoolPort is the default port of the connection pool sidecar of TiDB
+	defaultTiDBConnectionPoolPort int32 = 6000
+	// defaultTiDBConnectionPoolMaxConnections is the default max number of the client connections of the pool
+	defaultTiDBConnectionPoolMaxConnections int32 = 1000
+	// defaultTiDBConnectionPoolMaxServerConnections is the default max number of the connections to TiDB of the pool
+	defaultTiDBConnectionPoolMaxServerConnections int32 = 100
 
 	// the latest version
 	versionLatest = "latest"
 )
 
+const (
+	// TiKVRaftLogVolumeName is the name of the storage volume provisioned by spec.tikv.raftLogVolumeClaim
+	TiKVRaftLogVolumeName = "raft-log"
+	// TiKVRaftLogVolumeMountPath is the mount path of the volume provisioned by spec.tikv.raftLogVolumeClaim
+	TiKVRaftLogVolumeMountPath = "/var/lib/raft-log"
+)
+
 var (
 	defaultSlowLogTailerSpec = TiDBSlowLogTailerSpec{
 		ResourceRequirements: corev1.ResourceRequirements{},
@@ -94,6 +118,40 @@ func (tc *TidbCluster) PDVersion() string {
 	return getImageVersion(tc.PDImage())
 }
 
+// PDMSImage return the image used by the PD microservice.
+func (tc *TidbCluster) PDMSImage(spec *PDMSSpec) string {
+	image := spec.Image
+	baseImage := spec.BaseImage
+	// base image takes higher priority
+	if baseImage != "" {
+		version := spec.Version
+		if version == nil {
+			version = &tc.Spec.Version
+		}
+		if *version == "" {
+			image = baseImage
+		} else {
+			image = fmt.Sprintf("%s:%s", baseImage, *version)
+		}
+	}
+	return image
+}
+
+// PDMSEnabled returns whether PD is deployed in the microservice mode.
+func (tc *TidbCluster) PDMSEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.Mode == PDModeMicroservice
+}
+
+// PDMSStsDesiredReplicas returns the desired replicas of the PD microservice
+func (tc *TidbCluster) PDMSStsDesiredReplicas(name string) int32 {
+	for _, spec := range tc.Spec.PDMS {
+		if spec.Name == name {
+			return spec.Replicas
+		}
+	}
+	return 0
+}
+
 // TiKVImage return the image used by TiKV.
 //
 // If TiKV isn't specified, return empty string.
@@ -148,6 +206,32 @@ func (tc *TidbCluster) TiKVEvictLeaderTimeout() time.Duration {
 	return defaultEvictLeaderTimeout
 }
 
+func (tc *TidbCluster) TiKVEvictLeaderTimeoutPolicy() EvictLeaderTimeoutPolicy {
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.EvictLeaderTimeoutPolicy != nil {
+		return *tc.Spec.TiKV.EvictLeaderTimeoutPolicy
+	}
+	return EvictLeaderTimeoutPolicyForceUpgradeAfterTimeout
+}
+
+// TiKVScaleOutBalanceGateEnabled returns whether to wait for the balance of the stores after scaling out
+func (tc *TidbCluster) TiKVScaleOutBalanceGateEnabled() bool {
+	return tc.Spec.TiKV != nil && tc.Spec.TiKV.ScaleOutBalance != nil
+}
+
+func (tc *TidbCluster) TiKVScaleOutBalanceMaxSkewPercent() int32 {
+	if tc.TiKVScaleOutBalanceGateEnabled() && tc.Spec.TiKV.ScaleOutBalance.MaxSkewPercent != nil {
+		return *tc.Spec.TiKV.ScaleOutBalance.MaxSkewPercent
+	}
+	return defaultScaleOutBalanceMaxSkewPercent
+}
+
+func (tc *TidbCluster) TiKVScaleOutBalanceTimeout() time.Duration {
+	if tc.TiKVScaleOutBalanceGateEnabled() && tc.Spec.TiKV.ScaleOutBalance.Timeout != nil {
+		return tc.Spec.TiKV.ScaleOutBalance.Timeout.Duration
+	}
+	return defaultScaleOutBalanceTimeout
+}
+
 func (tc *TidbCluster) TiKVWaitLeaderTransferBackTimeout() time.Duration {
 	if tc.Spec.TiKV != nil && tc.Spec.TiKV.WaitLeaderTransferBackTimeout != nil {
 		return tc.Spec.TiKV.WaitLeaderTransferBackTimeout.Duration
@@ -155,6 +239,101 @@ func (tc *TidbCluster) TiKVWaitLeaderTransferBackTimeout() time.Duration {
 	return defaultWaitLeaderTransferBackTimeout
 }
 
+// TiKVDrainTimeout returns the timeout to wait for the store to drain when scaling in TiKV,
+// 0 means no timeout.
+func (tc *TidbCluster) TiKVDrainTimeout() time.Duration {
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.DrainTimeout != nil {
+		return tc.Spec.TiKV.DrainTimeout.Duration
+	}
+	return 0
+}
+
+// StatusCompactionEnabled returns whether the status of the tidb cluster should be compacted.
+func (tc *TidbCluster) StatusCompactionEnabled() bool {
+	return tc.Spec.StatusCompaction != nil
+}
+
+// StatusCompactionMemberThreshold returns the min number of stores or members of a component to compact its status.
+func (tc *TidbCluster) StatusCompactionMemberThreshold() int {
+	if tc.Spec.StatusCompaction != nil && tc.Spec.StatusCompaction.MemberThreshold != nil {
+		return int(*tc.Spec.StatusCompaction.MemberThreshold)
+	}
+	return defaultStatusCompactionMemberThreshold
+}
+
+const (
+	// keys of the companion ConfigMap which stores the stores and members moved out of the status by the status compaction
+	FullStatusTiKVStoresKey    = "tikv-stores.json"
+	FullStatusTiFlashStoresKey = "tiflash-stores.json"
+	FullStatusTiDBMembersKey   = "tidb-members.json"
+)
+
+// FullStatusConfigMapName returns the name of the companion ConfigMap which stores the stores and members
+// moved out of the status by the status compaction.
+func (tc *TidbCluster) FullStatusConfigMapName() string {
+	return fmt.Sprintf("%s-full-status", tc.Name)
+}
+
+// StatusCompacted returns whether the stores or members of any component are moved out of the status.
+func (tc *TidbCluster) StatusCompacted() bool {
+	return tc.Status.TiKV.Summary != nil || tc.Status.TiFlash.Summary != nil || tc.Status.TiDB.Summary != nil
+}
+
+// RestoreCompactedStatus adds the stores and members in the companion ConfigMap back to the status of the
+// compacted components and clears the summaries, so the status is listed in full as if it is not compacted.
+// The stores and members left in the status take precedence over the ones in the ConfigMap.
+func (tc *TidbCluster) RestoreCompactedStatus(cm *corev1.ConfigMap) error {
+	if tc.Status.TiKV.Summary != nil {
+		stores, err := restoreStores(tc.Status.TiKV.Stores, cm.Data[FullStatusTiKVStoresKey])
+		if err != nil {
+			return fmt.Errorf("failed to restore tikv stores from configmap %s/%s: %v", cm.Namespace, cm.Name, err)
+		}
+		tc.Status.TiKV.Stores = stores
+		tc.Status.TiKV.Summary = nil
+	}
+	if tc.Status.TiFlash.Summary != nil {
+		stores, err := restoreStores(tc.Status.TiFlash.Stores, cm.Data[FullStatusTiFlashStoresKey])
+		if err != nil {
+			return fmt.Errorf("failed to restore tiflash stores from configmap %s/%s: %v", cm.Namespace, cm.Name, err)
+		}
+		tc.Status.TiFlash.Stores = stores
+		tc.Status.TiFlash.Summary = nil
+	}
+	if tc.Status.TiDB.Summary != nil {
+		members := map[string]TiDBMember{}
+		if data := cm.Data[FullStatusTiDBMembersKey]; data != "" {
+			if err := json.Unmarshal([]byte(data), &members); err != nil {
+				return fmt.Errorf("failed to restore tidb members from configmap %s/%s: %v", cm.Namespace, cm.Name, err)
+			}
+		}
+		for name, member := range tc.Status.TiDB.Members {
+			members[name] = member
+		}
+		tc.Status.TiDB.Members = members
+		tc.Status.TiDB.Summary = nil
+	}
+	return nil
+}
+
+func restoreStores(stores map[string]TiKVStore, data string) (map[string]TiKVStore, error) {
+	restored := map[string]TiKVStore{}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &restored); err != nil {
+			return nil, err
+		}
+	}
+	for id, store := range stores {
+		restored[id] = store
+	}
+	return restored, nil
+}
+
+// summaryReady returns whether all the stores or members in the summary are healthy and the number of them
+// is the desired one. It is used if the status is compacted, as the healthy ones are not listed then.
+func summaryReady(summary *MemberSummary, desired int) bool {
+	return int(summary.Total) == desired && summary.Healthy == summary.Total
+}
+
 // TiFlashImage return the image used by TiFlash.
 //
 // If TiFlash isn't specified, return empty string.
@@ -191,6 +370,11 @@ func (tc *TidbCluster) TiFlashVersion() string {
 	return getImageVersion(tc.TiFlashImage())
 }
 
+// TiFlashDisaggregated returns whether TiFlash is deployed in the disaggregated storage and compute architecture
+func (tc *TidbCluster) TiFlashDisaggregated() bool {
+	return tc.Spec.TiFlash != nil && tc.Spec.TiFlash.Disaggregated != nil
+}
+
 func (tc *TidbCluster) TiFlashContainerPrivilege() *bool {
 	if tc.Spec.TiFlash == nil || tc.Spec.TiFlash.Privileged == nil {
 		pri := false
@@ -275,6 +459,22 @@ func (tc *TidbCluster) TiCDCGracefulShutdownTimeout() time.Duration {
 	return defaultTiCDCGracefulShutdownTimeout
 }
 
+// TiDBRestartWindow returns the duration of the scheduled restart windows of TiDB.
+func (tc *TidbCluster) TiDBRestartWindow() time.Duration {
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.RestartPolicy != nil && tc.Spec.TiDB.RestartPolicy.Window != nil {
+		return tc.Spec.TiDB.RestartPolicy.Window.Duration
+	}
+	return defaultTiDBRestartWindow
+}
+
+// TiDBRestartMaxPodsPerRun returns the max number of TiDB pods restarted in a scheduled restart window.
+func (tc *TidbCluster) TiDBRestartMaxPodsPerRun() int32 {
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.RestartPolicy != nil && tc.Spec.TiDB.RestartPolicy.MaxPodsPerRun != nil {
+		return *tc.Spec.TiDB.RestartPolicy.MaxPodsPerRun
+	}
+	return tc.TiDBStsDesiredReplicas()
+}
+
 // TiDBImage return the image used by TiDB.
 //
 // If TiDB isn't specified, return empty string.
@@ -597,6 +797,10 @@ func (tc *TidbCluster) TiKVAllStoresReady() bool {
 		return false
 	}
 
+	if tc.Status.TiKV.Summary != nil {
+		return summaryReady(tc.Status.TiKV.Summary, int(tc.TiKVStsDesiredReplicas()))
+	}
+
 	if int(tc.TiKVStsDesiredReplicas()) != len(tc.Status.TiKV.Stores) {
 		return false
 	}
@@ -654,6 +858,10 @@ func (tc *TidbCluster) TiFlashAllStoresReady() bool {
 		return false
 	}
 
+	if tc.Status.TiFlash.Summary != nil {
+		return summaryReady(tc.Status.TiFlash.Summary, int(tc.TiFlashStsDesiredReplicas()))
+	}
+
 	if int(tc.TiFlashStsDesiredReplicas()) != len(tc.Status.TiFlash.Stores) {
 		return false
 	}
@@ -774,6 +982,10 @@ func (tc *TidbCluster) TiDBAllMembersReady() bool {
 		return false
 	}
 
+	if tc.Status.TiDB.Summary != nil {
+		return summaryReady(tc.Status.TiDB.Summary, int(tc.TiDBStsDesiredReplicas()))
+	}
+
 	if int(tc.TiDBStsDesiredReplicas()) != len(tc.Status.TiDB.Members) {
 		return false
 	}
@@ -858,11 +1070,16 @@ func (tc *TidbCluster) PDIsAvailable() bool {
 
 func (tc *TidbCluster) TiKVIsAvailable() bool {
 	var lowerLimit int32 = 1
-	if int32(len(tc.Status.TiKV.Stores)+len(tc.Status.TiKV.PeerStores)) < lowerLimit {
+	var availableNum, compactedNum int32
+	if tc.Status.TiKV.Summary != nil {
+		// the stores which are up are not listed if the status is compacted
+		compactedNum = tc.Status.TiKV.Summary.Healthy
+		availableNum = compactedNum
+	}
+	if int32(len(tc.Status.TiKV.Stores)+len(tc.Status.TiKV.PeerStores))+compactedNum < lowerLimit {
 		return false
 	}
 
-	var availableNum int32
 	for _, store := range tc.Status.TiKV.Stores {
 		if store.State == TiKVStateUp {
 			availableNum++
@@ -894,6 +1111,10 @@ func (tc *TidbCluster) TiKVIsAvailable() bool {
 }
 
 func (tc *TidbCluster) AllTiKVsAreAvailable() bool {
+	if tc.Status.TiKV.Summary != nil {
+		return summaryReady(tc.Status.TiKV.Summary, int(tc.Spec.TiKV.Replicas))
+	}
+
 	if len(tc.Status.TiKV.Stores) != int(tc.Spec.TiKV.Replicas) {
 		return false
 	}
@@ -991,6 +1212,19 @@ func (tidb *TiDBSpec) IsTLSClientEnabled() bool {
 	return tidb.TLSClient != nil && tidb.TLSClient.Enabled
 }
 
+// IsTokenBasedAuthEnabled returns whether the `tidb_auth_token` authentication method is enabled
+func (tidb *TiDBSpec) IsTokenBasedAuthEnabled() bool {
+	return tidb.TokenBasedAuth != nil || (tidb.TokenBasedAuthEnabled != nil && *tidb.TokenBasedAuthEnabled)
+}
+
+// GetTokenBasedAuthRotationInterval returns the interval to rotate the signing key of `tidb_auth_token`
+func (tidb *TiDBSpec) GetTokenBasedAuthRotationInterval() time.Duration {
+	if tidb.TokenBasedAuth == nil || tidb.TokenBasedAuth.RotationInterval == nil {
+		return defaultTiDBAuthTokenRotationInterval
+	}
+	return tidb.TokenBasedAuth.RotationInterval.Duration
+}
+
 func (tidb *TiDBSpec) ShouldSeparateSlowLog() bool {
 	separateSlowLog := tidb.SeparateSlowLog
 	if separateSlowLog == nil {
@@ -1006,6 +1240,66 @@ func (tidb *TiDBSpec) GetSlowLogTailerSpec() TiDBSlowLogTailerSpec {
 	return *tidb.SlowLogTailer
 }
 
+// GetPort returns the port of the connection pool for the client connections
+func (p *TiDBConnectionPool) GetPort() int32 {
+	if p.Port == nil {
+		return defaultTiDBConnectionPoolPort
+	}
+	return *p.Port
+}
+
+// GetMaxConnections returns the max number of the client connections of the connection pool
+func (p *TiDBConnectionPool) GetMaxConnections() int32 {
+	if p.MaxConnections == nil {
+		return defaultTiDBConnectionPoolMaxConnections
+	}
+	return *p.MaxConnections
+}
+
+// GetMaxServerConnections returns the max number of the connections to TiDB of the connection pool
+func (p *TiDBConnectionPool) GetMaxServerConnections() int32 {
+	if p.MaxServerConnections == nil {
+		return defaultTiDBConnectionPoolMaxServerConnections
+	}
+	return *p.MaxServerConnections
+}
+
+// ID returns the ID of the plugin, which is used to load the plugin
+func (p *TiDBPluginArtifact) ID() string {
+	return fmt.Sprintf("%s-%s", p.Name, p.Version)
+}
+
+// GetPath returns the path of the plugin binary in the source
+func (p *TiDBPluginArtifact) GetPath() string {
+	if p.Path != "" {
+		return p.Path
+	}
+	return p.ID() + ".so"
+}
+
+// GetPluginLoadList returns the IDs of the plugins to load, i.e. the plugins in `plugins` followed by
+// the plugin artifacts not listed in `plugins`
+func (tidb *TiDBSpec) GetPluginLoadList() []string {
+	if len(tidb.PluginArtifacts) == 0 {
+		return tidb.Plugins
+	}
+	plugins := append([]string{}, tidb.Plugins...)
+	for i := range tidb.PluginArtifacts {
+		id := tidb.PluginArtifacts[i].ID()
+		found := false
+		for _, p := range tidb.Plugins {
+			if p == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			plugins = append(plugins, id)
+		}
+	}
+	return plugins
+}
+
 // GetServicePort returns the service port for tidb
 func (tidb *TiDBSpec) GetServicePort() int32 {
 	port := DefaultTiDBServicePort
@@ -1031,6 +1325,21 @@ func (tikv *TiKVSpec) ShouldSeparateRaftLog() bool {
 	return *separateRaftLog
 }
 
+// AllStorageVolumes returns the storage volumes of TiKV, including the dedicated raft log volume
+func (tikv *TiKVSpec) AllStorageVolumes() []StorageVolume {
+	if tikv.RaftLogVolumeClaim == nil {
+		return tikv.StorageVolumes
+	}
+	volumes := make([]StorageVolume, 0, len(tikv.StorageVolumes)+1)
+	volumes = append(volumes, tikv.StorageVolumes...)
+	return append(volumes, StorageVolume{
+		Name:             TiKVRaftLogVolumeName,
+		StorageClassName: tikv.RaftLogVolumeClaim.StorageClassName,
+		StorageSize:      tikv.RaftLogVolumeClaim.StorageSize,
+		MountPath:        TiKVRaftLogVolumeMountPath,
+	})
+}
+
 func (tikv *TiKVSpec) GetLogTailerSpec() LogTailerSpec {
 	if tikv.LogTailer == nil {
 		return defaultLogTailerSpec
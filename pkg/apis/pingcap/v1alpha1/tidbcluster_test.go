@@ -813,6 +813,19 @@ func TestTiCDCGracefulShutdownTimeout(t *testing.T) {
 	g.Expect(tc.TiCDCGracefulShutdownTimeout()).To(Equal(time.Minute))
 }
 
+func TestTerminationGracePeriodSeconds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.TiKV = &TiKVSpec{}
+	g.Expect(tc.BasePDSpec().BuildPodSpec().TerminationGracePeriodSeconds).To(Equal(pointer.Int64Ptr(60)))
+	g.Expect(tc.BaseTiKVSpec().BuildPodSpec().TerminationGracePeriodSeconds).To(Equal(pointer.Int64Ptr(300)))
+	g.Expect(tc.BaseDiscoverySpec().BuildPodSpec().TerminationGracePeriodSeconds).To(Equal(pointer.Int64Ptr(5)))
+
+	tc.Spec.TiKV.TerminationGracePeriodSeconds = pointer.Int64Ptr(600)
+	g.Expect(tc.BaseTiKVSpec().BuildPodSpec().TerminationGracePeriodSeconds).To(Equal(pointer.Int64Ptr(600)))
+}
+
 func TestComponentFunc(t *testing.T) {
 	t.Run("ComponentIsNormal", func(t *testing.T) {
 		g := NewGomegaWithT(t)
@@ -189,6 +189,10 @@ type BasicAutoScalerStatus struct {
 	// LastAutoScalingTimestamp describes the last auto-scaling timestamp for the component(tidb/tikv)
 	// +optional
 	LastAutoScalingTimestamp *metav1.Time `json:"lastAutoScalingTimestamp,omitempty"`
+	// ScaleInDeferredReason describes why the last scale-in was deferred, e.g. it would violate
+	// the PodDisruptionBudget or the regions are still being migrated. It is cleared after the next auto-scaling.
+	// +optional
+	ScaleInDeferredReason string `json:"scaleInDeferredReason,omitempty"`
 }
 
 // +k8s:openapi-gen=true
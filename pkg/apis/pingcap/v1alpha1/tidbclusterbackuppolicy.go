@@ -0,0 +1,30 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// AppliesTo returns whether the policy guards the operation on the cluster
+func (p *TidbClusterBackupPolicy) AppliesTo(tc *TidbCluster, op TidbClusterBackupPolicyOperation) bool {
+	if p.Namespace != tc.Namespace || (p.Spec.Cluster != "" && p.Spec.Cluster != tc.Name) {
+		return false
+	}
+	if len(p.Spec.Operations) == 0 {
+		return true
+	}
+	for _, o := range p.Spec.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
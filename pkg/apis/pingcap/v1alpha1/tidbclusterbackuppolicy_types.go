@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterBackupPolicyOperation is a destructive operation on a TidbCluster guarded by a backup policy
+type TidbClusterBackupPolicyOperation string
+
+const (
+	// TidbClusterBackupPolicyOperationDowngrade is the update which lowers the version of any component
+	TidbClusterBackupPolicyOperationDowngrade TidbClusterBackupPolicyOperation = "Downgrade"
+	// TidbClusterBackupPolicyOperationScaleTiKVToZero is the update which scales the TiKV replicas to zero
+	TidbClusterBackupPolicyOperationScaleTiKVToZero TidbClusterBackupPolicyOperation = "ScaleTiKVToZero"
+	// TidbClusterBackupPolicyOperationDelete is the deletion of the cluster
+	TidbClusterBackupPolicyOperationDelete TidbClusterBackupPolicyOperation = "Delete"
+)
+
+// TidbClusterBackupPolicyOperations are all the operations which can be guarded by a backup policy
+var TidbClusterBackupPolicyOperations = []TidbClusterBackupPolicyOperation{
+	TidbClusterBackupPolicyOperationDowngrade,
+	TidbClusterBackupPolicyOperationScaleTiKVToZero,
+	TidbClusterBackupPolicyOperationDelete,
+}
+
+// TidbClusterBackupPolicy requires a recent successful backup of the TidbClusters in its namespace before the
+// destructive operations on them are admitted. It is enforced by the validating admission webhook, which checks
+// the completed snapshot and volume-snapshot Backups of the cluster in the namespace of the cluster.
+//
+// +genclient
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcbp"
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The cluster which the policy applies to, empty for all the clusters in the namespace"
+// +kubebuilder:printcolumn:name="MaxBackupAge",type=string,JSONPath=`.spec.maxBackupAge`,description="The max age of the latest successful backup"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterBackupPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the policy.
+	Spec TidbClusterBackupPolicySpec `json:"spec"`
+}
+
+// TidbClusterBackupPolicyList is a TidbClusterBackupPolicy list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterBackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterBackupPolicy `json:"items"`
+}
+
+// TidbClusterBackupPolicySpec is spec of TidbClusterBackupPolicy.
+//
+// +k8s:openapi-gen=true
+type TidbClusterBackupPolicySpec struct {
+	// Cluster is the name of the TidbCluster in the same namespace which the policy applies to.
+	// Optional: Defaults to all the TidbClusters in the namespace
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Operations are the destructive operations which are refused without a recent successful backup.
+	// Optional: Defaults to all the operations
+	// +optional
+	Operations []TidbClusterBackupPolicyOperation `json:"operations,omitempty"`
+
+	// MaxBackupAge is the max age of the latest successful backup, the operations are refused
+	// unless a backup of the cluster is completed within the duration, e.g. 24h.
+	MaxBackupAge metav1.Duration `json:"maxBackupAge"`
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "time"
+
+const (
+	defaultRolloutMinReadySeconds    = 60
+	defaultRolloutHealthCheckTimeout = 30 * time.Minute
+)
+
+// GetWaveSize returns the number of clusters patched in the wave
+func (r *TidbClusterRollout) GetWaveSize(wave int) int {
+	waves := r.Spec.Waves
+	if len(waves) == 0 {
+		return 1
+	}
+	if wave >= len(waves) {
+		wave = len(waves) - 1
+	}
+	if waves[wave] < 1 {
+		return 1
+	}
+	return int(waves[wave])
+}
+
+// GetMinReadySeconds returns the time a patched cluster waits before checking its health
+func (r *TidbClusterRollout) GetMinReadySeconds() time.Duration {
+	if r.Spec.MinReadySeconds == nil {
+		return defaultRolloutMinReadySeconds * time.Second
+	}
+	return time.Duration(*r.Spec.MinReadySeconds) * time.Second
+}
+
+// GetHealthCheckTimeout returns the max duration waiting for a patched cluster to be healthy
+func (r *TidbClusterRollout) GetHealthCheckTimeout() time.Duration {
+	if r.Spec.HealthCheckTimeout == nil {
+		return defaultRolloutHealthCheckTimeout
+	}
+	return r.Spec.HealthCheckTimeout.Duration
+}
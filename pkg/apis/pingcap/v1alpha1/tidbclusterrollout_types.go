@@ -0,0 +1,165 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterRolloutPhase is the phase of a TidbClusterRollout
+type TidbClusterRolloutPhase string
+
+const (
+	// TidbClusterRolloutPhaseProgressing means the patch is being rolled out
+	TidbClusterRolloutPhaseProgressing TidbClusterRolloutPhase = "Progressing"
+	// TidbClusterRolloutPhasePaused means the rollout is paused by users, no more clusters are patched
+	TidbClusterRolloutPhasePaused TidbClusterRolloutPhase = "Paused"
+	// TidbClusterRolloutPhaseHalted means the failed clusters exceed maxFailures and the rollout is halted
+	TidbClusterRolloutPhaseHalted TidbClusterRolloutPhase = "Halted"
+	// TidbClusterRolloutPhaseCompleted means all the selected clusters are rolled out
+	TidbClusterRolloutPhaseCompleted TidbClusterRolloutPhase = "Completed"
+	// TidbClusterRolloutPhaseFailed means the selector or the patch is invalid, no cluster is patched
+	TidbClusterRolloutPhaseFailed TidbClusterRolloutPhase = "Failed"
+)
+
+// TidbClusterRolloutClusterPhase is the rollout phase of a TidbCluster
+type TidbClusterRolloutClusterPhase string
+
+const (
+	// TidbClusterRolloutClusterPending means the wave of the cluster is not started yet
+	TidbClusterRolloutClusterPending TidbClusterRolloutClusterPhase = "Pending"
+	// TidbClusterRolloutClusterUpdating means the cluster is patched and waiting to be healthy
+	TidbClusterRolloutClusterUpdating TidbClusterRolloutClusterPhase = "Updating"
+	// TidbClusterRolloutClusterSucceeded means the cluster is healthy after patched
+	TidbClusterRolloutClusterSucceeded TidbClusterRolloutClusterPhase = "Succeeded"
+	// TidbClusterRolloutClusterFailed means the cluster failed to be patched or is not healthy in time
+	TidbClusterRolloutClusterFailed TidbClusterRolloutClusterPhase = "Failed"
+)
+
+// TidbClusterRollout applies a spec patch, e.g. a version bump, to the TidbClusters selected by labels
+// in waves. A wave is started only after all clusters of the previous wave are healthy or failed, and
+// the rollout is halted when the failed clusters exceed maxFailures.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcr"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The current phase of the rollout"
+// +kubebuilder:printcolumn:name="Wave",type=integer,JSONPath=`.status.currentWave`,description="The current wave of the rollout"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterRollout struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the rollout.
+	Spec TidbClusterRolloutSpec `json:"spec"`
+
+	// Status is most recently observed status of the rollout.
+	//
+	// +k8s:openapi-gen=false
+	Status TidbClusterRolloutStatus `json:"status,omitempty"`
+}
+
+// TidbClusterRolloutList is a TidbClusterRollout list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterRollout `json:"items"`
+}
+
+// TidbClusterRolloutSpec is spec of TidbClusterRollout.
+//
+// +k8s:openapi-gen=true
+type TidbClusterRolloutSpec struct {
+	// Selector selects the TidbClusters to roll out from the namespace of the rollout.
+	// The clusters are selected when the rollout starts, clusters created later are not rolled out.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Patch is the JSON merge patch applied to the spec of the selected TidbClusters,
+	// e.g. {"version": "v7.5.0"}
+	Patch string `json:"patch"`
+
+	// Waves is the number of clusters patched in each wave, ordered by name.
+	// The last wave size is used for the rest clusters, e.g. [1, 5] patches 1 cluster as a canary,
+	// then 5 clusters in every following wave.
+	// Optional: Defaults to [1]
+	// +optional
+	Waves []int32 `json:"waves,omitempty"`
+
+	// MinReadySeconds is the time a patched cluster should wait before checking its health,
+	// so that the operator has enough time to start upgrading the cluster.
+	// Optional: Defaults to 60
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// HealthCheckTimeout is the max duration waiting for a patched cluster to be healthy,
+	// the cluster is considered as failed after that.
+	// A cluster is healthy when it's ready and none of its components is upgrading.
+	// Optional: Defaults to 30m
+	// +optional
+	HealthCheckTimeout *metav1.Duration `json:"healthCheckTimeout,omitempty"`
+
+	// MaxFailures is the max number of failed clusters tolerated, the rollout is halted
+	// when the failed clusters exceed it.
+	// Optional: Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures int32 `json:"maxFailures,omitempty"`
+
+	// Paused stops patching more clusters, the patched clusters are still checked.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TidbClusterRolloutStatus is status of TidbClusterRollout.
+type TidbClusterRolloutStatus struct {
+	// Phase is the phase of the rollout
+	Phase TidbClusterRolloutPhase `json:"phase,omitempty"`
+
+	// Message is the reason of the failure of the rollout
+	Message string `json:"message,omitempty"`
+
+	// CurrentWave is the index of the wave in progress
+	CurrentWave int32 `json:"currentWave,omitempty"`
+
+	// Clusters is the rollout status of the selected clusters
+	Clusters []TidbClusterRolloutClusterStatus `json:"clusters,omitempty"`
+}
+
+// TidbClusterRolloutClusterStatus is the rollout status of a TidbCluster.
+type TidbClusterRolloutClusterStatus struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Wave is the index of the wave the cluster belongs to
+	Wave int32 `json:"wave"`
+
+	Phase TidbClusterRolloutClusterPhase `json:"phase"`
+
+	// LastTransitionTime is the last time the phase transitioned
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message is the reason of the failure
+	Message string `json:"message,omitempty"`
+}
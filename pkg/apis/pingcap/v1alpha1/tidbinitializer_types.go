@@ -85,6 +85,11 @@ type TidbInitializerSpec struct {
 	// +optional
 	InitSqlConfigMap *string `json:"initSqlConfigMap,omitempty"`
 
+	// SqlSources are the SQL sources executed in the declared order after initSql or initSqlConfigMap.
+	// Every line of a source is executed as a statement, the same as initSql.
+	// +optional
+	SqlSources []InitSqlSource `json:"sqlSources,omitempty"`
+
 	// +optional
 	PasswordSecret *string `json:"passwordSecret,omitempty"`
 
@@ -107,6 +112,83 @@ type TidbInitializerStatus struct {
 
 	// Phase is a user readable state inferred from the underlying Job status and TidbCluster status
 	Phase InitializePhase `json:"phase,omitempty"`
+
+	// SqlSources is the execution progress of the SQL sources, it is recorded when the initializer job finishes
+	// +optional
+	SqlSources []InitSqlSourceStatus `json:"sqlSources,omitempty"`
+}
+
+// InitSqlFailurePolicy is the action taken when a statement of a SQL source still fails after retries
+type InitSqlFailurePolicy string
+
+const (
+	// InitSqlFailurePolicyAbort aborts the initialization, the rest SQL sources are not executed
+	InitSqlFailurePolicyAbort InitSqlFailurePolicy = "Abort"
+	// InitSqlFailurePolicyContinue skips the failed statement and continues the initialization
+	InitSqlFailurePolicyContinue InitSqlFailurePolicy = "Continue"
+)
+
+// InitSqlSource is a source of SQL statements stored in a ConfigMap or a Secret.
+// Only one of configMapKeyRef and secretKeyRef should be set.
+// +k8s:openapi-gen=true
+type InitSqlSource struct {
+	// Name of the source, it is used to identify the source in the status
+	Name string `json:"name"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap which stores the SQL statements
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret which stores the SQL statements
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// Retries is the number of times a failed statement is retried
+	// Optional: Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// OnFailure is the action taken when a statement still fails after retries
+	// Optional: Defaults to Abort
+	// +kubebuilder:validation:Enum=Abort;Continue
+	// +optional
+	OnFailure InitSqlFailurePolicy `json:"onFailure,omitempty"`
+}
+
+// InitSqlSourcePhase is the execution phase of a SQL source
+type InitSqlSourcePhase string
+
+const (
+	// InitSqlSourcePhaseRunning indicates the source is being executed when the job finishes
+	InitSqlSourcePhaseRunning InitSqlSourcePhase = "Running"
+	// InitSqlSourcePhaseCompleted indicates all statements of the source are executed successfully
+	InitSqlSourcePhaseCompleted InitSqlSourcePhase = "Completed"
+	// InitSqlSourcePhaseCompletedWithErrors indicates some statements of the source failed and are skipped
+	InitSqlSourcePhaseCompletedWithErrors InitSqlSourcePhase = "CompletedWithErrors"
+	// InitSqlSourcePhaseFailed indicates a statement of the source failed and the initialization is aborted
+	InitSqlSourcePhaseFailed InitSqlSourcePhase = "Failed"
+)
+
+// InitSqlSourceStatus is the execution progress of a SQL source
+// +k8s:openapi-gen=true
+type InitSqlSourceStatus struct {
+	// Name of the source
+	Name string `json:"name"`
+
+	// Phase of the source
+	Phase InitSqlSourcePhase `json:"phase,omitempty"`
+
+	// ExecutedStatements is the number of statements executed successfully
+	ExecutedStatements int32 `json:"executedStatements,omitempty"`
+
+	// FailedStatements is the number of statements still failed after retries
+	// +optional
+	FailedStatements int32 `json:"failedStatements,omitempty"`
+
+	// Message is the last error of the source
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,6 +55,13 @@ type TidbMonitorSpec struct {
 	// monitored TiDB cluster info
 	Clusters []TidbClusterRef `json:"clusters,omitempty"`
 
+	// ClusterSelector selects the TidbClusters and DMClusters to monitor in addition to
+	// the clusters listed explicitly. The selected clusters are discovered dynamically,
+	// and the Prometheus config is updated when they appear or disappear.
+	// Set clusterScoped to true if the clusters in other namespaces are selected.
+	// +optional
+	ClusterSelector *TidbMonitorClusterSelector `json:"clusterSelector,omitempty"`
+
 	// Prometheus spec
 	Prometheus PrometheusSpec `json:"prometheus"`
 
@@ -181,10 +188,34 @@ type TidbMonitorSpec struct {
 	// +optional
 	EnableAlertRules bool `json:"enableAlertRules,omitempty"`
 
+	// Alerting configures how the alerts of the clusters monitored by several TidbMonitors are deduplicated.
+	// +optional
+	Alerting *TidbMonitorAlertingSpec `json:"alerting,omitempty"`
+
 	// Time zone of TidbMonitor
 	// Optional: Defaults to UTC
 	// +optional
 	Timezone string `json:"timezone,omitempty"`
+
+	// HealthCheck configures the health checks of the Prometheus and Grafana of TidbMonitor,
+	// the results are reported in the conditions of the TidbMonitor status.
+	// Optional: Defaults to nil, which means the health checks are disabled
+	// +optional
+	HealthCheck *TidbMonitorHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// TidbMonitorHealthCheck is the health check configuration of TidbMonitor
+type TidbMonitorHealthCheck struct {
+	// AutoRestart indicates whether to restart the TidbMonitor pod whose Prometheus or Grafana
+	// keeps unhealthy longer than RestartThreshold.
+	// +optional
+	AutoRestart bool `json:"autoRestart,omitempty"`
+
+	// RestartThreshold is the duration a pod must be unhealthy before it is restarted.
+	// Optional: Defaults to 10m
+	// +optional
+	RestartThreshold *metav1.Duration `json:"restartThreshold,omitempty"`
 }
 
 // PrometheusReloaderSpec is the desired state of prometheus configuration reloader
@@ -372,13 +403,67 @@ type TidbClusterRef struct {
 // ClusterRef reference to a TidbCluster
 type ClusterRef TidbClusterRef
 
+// +k8s:openapi-gen=true
+// TidbMonitorClusterSelector selects the clusters to monitor by labels
+type TidbMonitorClusterSelector struct {
+	// LabelSelector selects the TidbClusters and DMClusters by their labels, an empty selector selects all clusters.
+	// DMClusters are selected only if spec.dm is set.
+	// +optional
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// NamespaceSelector selects the namespaces to discover the clusters in, an empty selector selects all namespaces.
+	// Optional: Defaults to the namespace of the TidbMonitor
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// TidbMonitorAlertingSpec configures the alerting of TidbMonitor when a cluster is monitored by several TidbMonitors
+type TidbMonitorAlertingSpec struct {
+	// Priority of the TidbMonitor to claim the alerting of the monitored TidbClusters. When a TidbCluster is
+	// monitored by several TidbMonitors with alert rules, only the one with the highest priority sends the alerts
+	// of the TidbCluster, ties are broken by the namespace and name of the TidbMonitors. A TidbMonitor that claims
+	// none of the monitored clusters does not render the alert rules.
+	// Optional: Defaults to 0
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// DedupLabels are the external labels dropped from the alerts before they are sent to Alertmanager,
+	// so the same alerts sent by the replicas of the TidbMonitor are deduplicated by Alertmanager.
+	// Optional: Defaults to the replica external label
+	// +optional
+	DedupLabels []string `json:"dedupLabels,omitempty"`
+}
+
 type TidbMonitorStatus struct {
 	// Storage status for deployment
 	DeploymentStorageStatus *DeploymentStorageStatus `json:"deploymentStorageStatus,omitempty"`
 
 	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+
+	// Conditions represent the latest observations of TidbMonitor, the Ready, Progressing and Degraded
+	// conditions are always updated, the health of the Prometheus and Grafana are only updated
+	// when the health checks are enabled.
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// TidbMonitorPrometheusReady indicates whether the TSDB of the Prometheus in all pods are ready
+	TidbMonitorPrometheusReady = "PrometheusReady"
+	// TidbMonitorGrafanaReady indicates whether the Grafana in all pods are healthy and
+	// their datasources are valid
+	TidbMonitorGrafanaReady = "GrafanaReady"
+	// TidbMonitorReady indicates whether all pods of TidbMonitor are up to date and ready,
+	// and none of the health checks fails
+	TidbMonitorReady = "Ready"
+	// TidbMonitorProgressing indicates whether the statefulset of TidbMonitor is being rolled out
+	TidbMonitorProgressing = "Progressing"
+	// TidbMonitorDegraded indicates whether any of the health checks of TidbMonitor fails
+	TidbMonitorDegraded = "Degraded"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // +k8s:openapi-gen=true
@@ -561,3 +646,16 @@ func (tm *TidbMonitor) Timezone() string {
 	}
 	return tz
 }
+
+// AlertingPriority returns the priority of the TidbMonitor to claim the alerting of the monitored clusters
+func (tm *TidbMonitor) AlertingPriority() int32 {
+	if tm.Spec.Alerting == nil || tm.Spec.Alerting.Priority == nil {
+		return 0
+	}
+	return *tm.Spec.Alerting.Priority
+}
+
+// RendersAlertRules returns whether the built-in alert rules are rendered in the Prometheus config
+func (tm *TidbMonitor) RendersAlertRules() bool {
+	return tm.Spec.EnableAlertRules || (tm.Spec.AlertmanagerURL != nil && len(*tm.Spec.AlertmanagerURL) > 0)
+}
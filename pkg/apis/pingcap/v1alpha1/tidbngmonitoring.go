@@ -37,3 +37,12 @@ func (tngm *TidbNGMonitoring) NGMonitoringImage() string {
 	}
 	return image
 }
+
+// ConprofOffload returns the object storage to archive the continuous profiling data,
+// nil is returned if the offload is not enabled.
+func (tngm *TidbNGMonitoring) ConprofOffload() *NGMonitoringConprofOffload {
+	if tngm.Spec.Conprof == nil {
+		return nil
+	}
+	return tngm.Spec.Conprof.Offload
+}
@@ -82,6 +82,11 @@ type TidbNGMonitoringSpec struct {
 
 	// NGMonitoring is spec of ng monitoring
 	NGMonitoring NGMonitoringSpec `json:"ngMonitoring"`
+
+	// Conprof configures the continuous profiling data of ng monitoring
+	//
+	// +optional
+	Conprof *NGMonitoringConprofSpec `json:"conprof,omitempty"`
 }
 
 // TidbNGMonitoringStatus is status of tidb ng monitoring
@@ -116,6 +121,32 @@ type NGMonitoringSpec struct {
 	Config *config.GenericConfig `json:"config,omitempty"`
 }
 
+// NGMonitoringConprofSpec is spec of the continuous profiling data of ng monitoring
+//
+// +k8s:openapi-gen=true
+type NGMonitoringConprofSpec struct {
+	// Retention is how long the profiling data is kept, e.g. `72h`.
+	// Defaults to the retention of ng monitoring, which is 3 days.
+	//
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+
+	// Offload archives the profiling data to the object storage, so that only the profiling
+	// data within the retention is kept in the data volume
+	//
+	// +optional
+	Offload *NGMonitoringConprofOffload `json:"offload,omitempty"`
+}
+
+// NGMonitoringConprofOffload is the object storage to archive the continuous profiling data,
+// only one of S3 and GCS can be set
+//
+// +k8s:openapi-gen=true
+type NGMonitoringConprofOffload struct {
+	S3  *S3StorageProvider  `json:"s3,omitempty"`
+	Gcs *GcsStorageProvider `json:"gcs,omitempty"`
+}
+
 // NGMonitoringStatus is latest status of ng monitoring
 type NGMonitoringStatus struct {
 	Synced bool        `json:"synced,omitempty"`
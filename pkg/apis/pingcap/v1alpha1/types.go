@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 )
@@ -57,6 +58,7 @@ const (
 	ContainerSlowLogTailer    ContainerName = "slowlog"
 	ContainerRocksDBLogTailer ContainerName = "rocksdblog"
 	ContainerRaftLogTailer    ContainerName = "raftlog"
+	ContainerConnectionPool   ContainerName = "connection-pool"
 )
 
 // MemberType represents member type
@@ -67,6 +69,10 @@ const (
 	DiscoveryMemberType MemberType = "discovery"
 	// PDMemberType is pd member type
 	PDMemberType MemberType = "pd"
+	// PDMSTSOMemberType is the member type of the TSO microservice of PD
+	PDMSTSOMemberType MemberType = "tso"
+	// PDMSSchedulingMemberType is the member type of the scheduling microservice of PD
+	PDMSSchedulingMemberType MemberType = "scheduling"
 	// TiDBMemberType is tidb member type
 	TiDBMemberType MemberType = "tidb"
 	// TiKVMemberType is tikv member type
@@ -123,6 +129,10 @@ const (
 	// ConfigUpdateStrategyRollingUpdate generate different configmap on configuration update and
 	// try to rolling-update the pod controller (e.g. statefulset) to apply updates.
 	ConfigUpdateStrategyRollingUpdate ConfigUpdateStrategy = "RollingUpdate"
+	// ConfigUpdateStrategyHotReload applies the changes online by the config API of the component and
+	// update the configmap in-place if only the hot-reloadable items are changed, otherwise it works like
+	// ConfigUpdateStrategyRollingUpdate.
+	ConfigUpdateStrategyHotReload ConfigUpdateStrategy = "HotReload"
 )
 
 type StartScriptVersion string
@@ -192,6 +202,10 @@ type TidbClusterSpec struct {
 	// +optional
 	PD *PDSpec `json:"pd,omitempty"`
 
+	// PDMS is the spec of the PD microservices, it only takes effect when `spec.pd.mode` is "ms"
+	// +optional
+	PDMS []*PDMSSpec `json:"pdms,omitempty"`
+
 	// TiDB cluster spec
 	// +optional
 	TiDB *TiDBSpec `json:"tidb,omitempty"`
@@ -200,6 +214,12 @@ type TidbClusterSpec struct {
 	// +optional
 	TiKV *TiKVSpec `json:"tikv,omitempty"`
 
+	// TiKVGroups are the groups of TiKV with different resources, storage classes, labels or configs,
+	// each group is deployed as its own StatefulSet by a heterogeneous TidbCluster `<cluster>-<group>`
+	// owned by this TidbCluster.
+	// +optional
+	TiKVGroups []TiKVGroup `json:"tikvGroups,omitempty"`
+
 	// TiFlash cluster spec
 	// +optional
 	TiFlash *TiFlashSpec `json:"tiflash,omitempty"`
@@ -250,11 +270,27 @@ type TidbClusterSpec struct {
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 
+	// RegistryMirrors maps the registries or repository prefixes to their mirrors, e.g.
+	// `docker.io: mirror.example.com`, it is applied to the images of all the components
+	// and overrides the global registry mirrors of tidb-operator.
+	// +optional
+	RegistryMirrors map[string]string `json:"registryMirrors,omitempty"`
+
+	// ImageDigestPinning pins the pods of PD, TiKV, TiDB and TiFlash to the digests of their images, so that the
+	// mutation of a tag during a rolling update can not produce mixed binaries across a StatefulSet. The digest of
+	// an image is resolved by a probe pod pulling the image before the StatefulSet is upgraded to it, and the
+	// StatefulSet is upgraded to the pinned image at once.
+	// Optional: Defaults to false
+	// +optional
+	ImageDigestPinning bool `json:"imageDigestPinning,omitempty"`
+
 	// ConfigUpdateStrategy determines how the configuration change is applied to the cluster.
 	// UpdateStrategyInPlace will update the ConfigMap of configuration in-place and an extra rolling-update of the
 	// cluster component is needed to reload the configuration change.
 	// UpdateStrategyRollingUpdate will create a new ConfigMap with the new configuration and rolling-update the
 	// related components to use the new ConfigMap, that is, the new configuration will be applied automatically.
+	// UpdateStrategyHotReload will apply the changes by the online config API of PD and TiKV without restarting
+	// them if only the hot-reloadable items are changed, otherwise it works like UpdateStrategyRollingUpdate.
 	ConfigUpdateStrategy ConfigUpdateStrategy `json:"configUpdateStrategy,omitempty"`
 
 	// Whether enable PVC reclaim for orphan PVC left by statefulset scale-in
@@ -332,10 +368,22 @@ type TidbClusterSpec struct {
 	// +optional
 	AcrossK8s bool `json:"acrossK8s,omitempty"`
 
+	// PeerRegistry is the registry which the TidbClusters deployed across Kubernetes clusters register
+	// with to exchange their PD addresses and cluster domains, so PD joins the PD of the registered
+	// TidbClusters without configuring cluster or pdAddresses. It is only used if acrossK8s is set.
+	// +optional
+	PeerRegistry *PeerRegistry `json:"peerRegistry,omitempty"`
+
 	// Cluster is the external cluster, if configured, the components in this TidbCluster will join to this configured cluster.
 	// +optional
 	Cluster *TidbClusterRef `json:"cluster,omitempty"`
 
+	// SecretReplication replicates the Secrets of the TidbCluster referenced by cluster into the namespace of
+	// this TidbCluster, e.g. the TLS client Secrets, so they are not copied manually. It is only used if the
+	// referenced TidbCluster is in another namespace of the same Kubernetes cluster.
+	// +optional
+	SecretReplication *SecretReplication `json:"secretReplication,omitempty"`
+
 	// PDAddresses are the external PD addresses, if configured, the PDs in this TidbCluster will join to the configured PD cluster.
 	// +optional
 	PDAddresses []string `json:"pdAddresses,omitempty"`
@@ -374,8 +422,134 @@ type TidbClusterSpec struct {
 
 	// PreferIPv6 indicates whether to prefer IPv6 addresses for all components.
 	PreferIPv6 bool `json:"preferIPv6,omitempty"`
+
+	// StatusCompaction configures the compaction of the status of very large clusters.
+	// The status is not compacted if it is not set.
+	// +optional
+	StatusCompaction *StatusCompaction `json:"statusCompaction,omitempty"`
+
+	// Notification configures the external sinks which the significant events of the cluster
+	// are forwarded to, in addition to the global sinks of tidb-operator.
+	// +optional
+	Notification *NotificationSpec `json:"notification,omitempty"`
+
+	// GCTuning configures the windows in which the GC settings of TiKV are adjusted,
+	// e.g. a more aggressive GC in the off-peak hours.
+	// It takes effect only if the GCTuning feature of tidb-operator is enabled.
+	// +optional
+	GCTuning *GCTuningPolicy `json:"gcTuning,omitempty"`
+
+	// OperationTimeouts configures the timeouts of the upgrade and scaling of the components, the operations
+	// not completed in time are reported by the ComponentOperationTimeout condition and a warning event.
+	// +optional
+	OperationTimeouts *OperationTimeoutPolicy `json:"operationTimeouts,omitempty"`
+
+	// Overrides are the patches applied to the resources generated by tidb-operator, they cover the gaps
+	// in the API without forking tidb-operator. The overrides are applied in order after the resources are
+	// generated, the overrides which change the fields managed by tidb-operator are not applied and are
+	// reported by the status and a warning event.
+	// +optional
+	Overrides []ResourceOverride `json:"overrides,omitempty"`
 }
 
+// ResourceOverride is a patch applied to a resource generated by tidb-operator
+// +k8s:openapi-gen=true
+type ResourceOverride struct {
+	// Kind of the generated resource, only the StatefulSets and Services of the components are supported
+	// +kubebuilder:validation:Enum=StatefulSet;Service
+	Kind ResourceOverrideKind `json:"kind"`
+	// Name of the generated resource, e.g. basic-tikv
+	Name string `json:"name"`
+	// Type of the patch.
+	// StrategicMerge: a strategic merge patch, the same as `kubectl patch --type strategic`.
+	// JSON: a JSON patch of RFC 6902, the same as `kubectl patch --type json`.
+	// Optional: Defaults to StrategicMerge
+	// +kubebuilder:validation:Enum=StrategicMerge;JSON
+	// +optional
+	Type ResourceOverridePatchType `json:"type,omitempty"`
+	// Patch in YAML or JSON
+	Patch string `json:"patch"`
+}
+
+// ResourceOverrideKind is the kind of the resource patched by an override
+type ResourceOverrideKind string
+
+const (
+	ResourceOverrideKindStatefulSet ResourceOverrideKind = "StatefulSet"
+	ResourceOverrideKindService     ResourceOverrideKind = "Service"
+)
+
+// ResourceOverridePatchType is the type of the patch of an override
+type ResourceOverridePatchType string
+
+const (
+	ResourceOverridePatchTypeStrategicMerge ResourceOverridePatchType = "StrategicMerge"
+	ResourceOverridePatchTypeJSON           ResourceOverridePatchType = "JSON"
+)
+
+// ResourceOverridePhase is the phase of an override
+type ResourceOverridePhase string
+
+const (
+	// ResourceOverridePhasePending means the resource of the override is not generated yet
+	ResourceOverridePhasePending ResourceOverridePhase = "Pending"
+	// ResourceOverridePhaseApplied means the override is applied to the resource
+	ResourceOverridePhaseApplied ResourceOverridePhase = "Applied"
+	// ResourceOverridePhaseConflict means the override changes the fields managed by tidb-operator
+	// and is not applied
+	ResourceOverridePhaseConflict ResourceOverridePhase = "Conflict"
+	// ResourceOverridePhaseFailed means the patch of the override can't be applied to the resource
+	ResourceOverridePhaseFailed ResourceOverridePhase = "Failed"
+)
+
+// ResourceOverrideStatus is the status of an override, it's in the same order as `spec.overrides`
+type ResourceOverrideStatus struct {
+	Kind  ResourceOverrideKind  `json:"kind"`
+	Name  string                `json:"name"`
+	Phase ResourceOverridePhase `json:"phase"`
+	// Message is the reason of the conflict or failure
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OperationTimeoutPolicy is the policy of the timeouts of the long-running operations of the components.
+// The timer of an operation starts when the component enters the Upgrade or Scale phase, and restarts
+// when the spec of the cluster is changed.
+// +k8s:openapi-gen=true
+type OperationTimeoutPolicy struct {
+	// Upgrade is the max duration of the rolling upgrade of a component.
+	// The upgrade never times out if it is not set.
+	// +optional
+	Upgrade *metav1.Duration `json:"upgrade,omitempty"`
+	// ScaleIn is the max duration of the scaling in of a component.
+	// The scaling in never times out if it is not set.
+	// +optional
+	ScaleIn *metav1.Duration `json:"scaleIn,omitempty"`
+	// ScaleOut is the max duration of the scaling out of a component.
+	// The scaling out never times out if it is not set.
+	// +optional
+	ScaleOut *metav1.Duration `json:"scaleOut,omitempty"`
+	// Action is the action taken when an operation times out.
+	// Alert: the timeout is reported by the condition and a warning event only.
+	// Abort: the operation is also halted, i.e. no more pods are upgraded and no more replicas are scaled,
+	// and the stores being deleted by the scaling in are restored to Up. The operation is resumed after the
+	// spec of the cluster is changed.
+	// Optional: Defaults to Alert
+	// +kubebuilder:validation:Enum=Alert;Abort
+	// +optional
+	Action OperationTimeoutAction `json:"action,omitempty"`
+}
+
+// OperationTimeoutAction is the action taken when an operation of a component times out
+type OperationTimeoutAction string
+
+const (
+	// OperationTimeoutActionAlert reports the timeout only
+	OperationTimeoutActionAlert OperationTimeoutAction = "Alert"
+	// OperationTimeoutActionAbort halts the operation after reporting the timeout
+	OperationTimeoutActionAbort OperationTimeoutAction = "Abort"
+)
+
 // TidbClusterStatus represents the current status of a tidb cluster.
 type TidbClusterStatus struct {
 	ClusterID  string                    `json:"clusterID,omitempty"`
@@ -387,10 +561,94 @@ type TidbClusterStatus struct {
 	TiProxy    TiProxyStatus             `json:"tiproxy,omitempty"`
 	TiCDC      TiCDCStatus               `json:"ticdc,omitempty"`
 	AutoScaler *TidbClusterAutoScalerRef `json:"auto-scaler,omitempty"`
+	// TiKVGroups is the status of the TiKV groups
+	// +optional
+	TiKVGroups []TiKVGroupStatus `json:"tikvGroups,omitempty"`
 	// Represents the latest available observations of a tidb cluster's state.
 	// +optional
 	// +nullable
 	Conditions []TidbClusterCondition `json:"conditions,omitempty"`
+	// CostEstimates is the estimated monthly cost of each component.
+	// It is only set when tidb-operator is configured with a price sheet.
+	// +optional
+	CostEstimates map[MemberType]ComponentCostEstimate `json:"costEstimates,omitempty"`
+	// PDMS is the status of the PD microservices, the key is the name of the microservice
+	// +optional
+	PDMS map[string]*PDMSStatus `json:"pdms,omitempty"`
+	// ObservedGeneration is the most recent generation of the TidbCluster observed by tidb-operator,
+	// it is used together with the conditions by the GitOps tools to compute the health.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// GCTuning is the status of the GC tuning policy
+	// +optional
+	GCTuning *GCTuningStatus `json:"gcTuning,omitempty"`
+	// TLSRotations is the status of the rotation of the cluster TLS certs of each component
+	// +optional
+	TLSRotations map[MemberType]TLSRotationStatus `json:"tlsRotations,omitempty"`
+	// RegisteredPeers are the TidbClusters registered with the peer registry, including this one
+	// +optional
+	RegisteredPeers []RegisteredPeer `json:"registeredPeers,omitempty"`
+	// Overrides is the status of the overrides of `spec.overrides`
+	// +optional
+	Overrides []ResourceOverrideStatus `json:"overrides,omitempty"`
+}
+
+// PeerRegistry is the registry served by the discovery service of one of the TidbClusters deployed
+// across Kubernetes clusters.
+//
+// +k8s:openapi-gen=true
+type PeerRegistry struct {
+	// URL of the discovery service which serves the registry, it must be reachable from all the
+	// Kubernetes clusters, e.g. http://basic-discovery.pingcap.svc.cluster1.local:10261
+	URL string `json:"url"`
+
+	// Group is the name which the TidbClusters of the same deployment register with
+	// Optional: Defaults to the name of the TidbCluster
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// RegisteredPeer is a TidbCluster registered with the peer registry
+type RegisteredPeer struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// PDAddresses are the client URLs of the healthy PD members of the TidbCluster
+	// +optional
+	PDAddresses []string `json:"pdAddresses,omitempty"`
+	// RegisteredAt is the time when the TidbCluster is registered first, the TidbCluster registered
+	// first bootstraps PD and the others join it.
+	// +nullable
+	RegisteredAt metav1.Time `json:"registeredAt,omitempty"`
+}
+
+// SecretReplication is the Secrets replicated from the namespace of the TidbCluster referenced by
+// `spec.cluster`. The replicas are labeled with the source namespace and annotated with the hash of the
+// source data, they are updated when the source changes, and deleted when they are not replicated any more.
+// The referenced TidbCluster must allow the namespace by the annotation
+// `tidb.pingcap.com/secret-replication-allowed-namespaces`, and only its own Secrets, which are labeled
+// with its instance and managed by tidb-operator, are replicated.
+//
+// +k8s:openapi-gen=true
+type SecretReplication struct {
+	// SecretNames are the names of the Secrets in the namespace of the referenced TidbCluster, the replicas
+	// have the same names. The Secrets which already exist and are not replicas are not overwritten.
+	SecretNames []string `json:"secretNames"`
+}
+
+// ComponentCostEstimate is the estimated monthly cost of a component, computed by
+// multiplying the requested resources and storage by the unit prices of the price sheet.
+type ComponentCostEstimate struct {
+	// Currency of the estimated cost, it is copied from the price sheet
+	// +optional
+	Currency string `json:"currency,omitempty"`
+	// Compute is the estimated monthly cost of the requested CPU and memory
+	Compute string `json:"compute"`
+	// Storage is the estimated monthly cost of the requested storage
+	Storage string `json:"storage"`
+	// Total is the sum of Compute and Storage
+	Total string `json:"total"`
 }
 
 // TidbClusterCondition describes the state of a tidb cluster at a certain point.
@@ -426,12 +684,72 @@ const (
 	// - All TiKV stores are up.
 	// - All TiFlash stores are up.
 	TidbClusterReady TidbClusterConditionType = "Ready"
+	// TidbClusterBootstrapBlocked indicates that the bootstrap of PD is blocked because the
+	// volumes of the tidb cluster contain the data of another cluster.
+	TidbClusterBootstrapBlocked TidbClusterConditionType = "BootstrapBlocked"
+	// TidbClusterProgressing indicates that the tidb cluster is being upgraded or scaled, i.e. any
+	// statefulset is not up to date or any component is in the Upgrade or Scale phase.
+	TidbClusterProgressing TidbClusterConditionType = "Progressing"
+	// TidbClusterDegraded indicates that the tidb cluster runs with failed members or stores
+	// which are handled by the failover, or the bootstrap of PD is blocked.
+	TidbClusterDegraded TidbClusterConditionType = "Degraded"
+	// TidbClusterTLSCompliant indicates whether the certificates of the TLS secrets of the tidb
+	// cluster meet the crypto policy, it is only maintained in the FIPS mode.
+	TidbClusterTLSCompliant TidbClusterConditionType = "TLSCompliant"
+	// TidbClusterDiscoveryReady indicates whether the resources of the discovery service are
+	// reconciled and the discovery is available for the bootstrap of PD.
+	TidbClusterDiscoveryReady TidbClusterConditionType = "DiscoveryReady"
+	// TidbClusterSlowStore indicates whether any TiKV store is slow according to `spec.tikv.slowStore`,
+	// it is only maintained if the policy is set.
+	TidbClusterSlowStore TidbClusterConditionType = "SlowStore"
 )
 
 // The `Type` of the component condition
 const (
 	// ComponentVolumeResizing indicates that any volume of this component is resizing.
 	ComponentVolumeResizing string = "ComponentVolumeResizing"
+	// ComponentEvictLeaderTimeout indicates that the leaders of a store are not evicted within the timeout
+	// during the rolling upgrade, the reason is the EvictLeaderTimeoutPolicy applied.
+	ComponentEvictLeaderTimeout string = "ComponentEvictLeaderTimeout"
+	// ComponentRegionBalancing indicates that the regions and leaders are being balanced among the stores after scaling out.
+	ComponentRegionBalancing string = "ComponentRegionBalancing"
+	// ComponentOperationProgressing indicates that the component is being upgraded or scaled, the reason is the operation
+	// and the last transition time is when it started. It's only maintained if spec.operationTimeouts is set.
+	ComponentOperationProgressing string = "ComponentOperationProgressing"
+	// ComponentOperationTimeout indicates that the upgrade or scaling of the component is not completed within the timeout
+	// of spec.operationTimeouts, the reason is OperationAborted if the operation is aborted by the policy.
+	ComponentOperationTimeout string = "ComponentOperationTimeout"
+)
+
+// ScaleOutBalanceGate is the gate to wait for the regions and leaders to be balanced after scaling out
+// +k8s:openapi-gen=true
+type ScaleOutBalanceGate struct {
+	// MaxSkewPercent is the max skew of the region and leader counts among the stores in percentage,
+	// which is the difference between the max and the min counts divided by the max count.
+	//
+	// Defaults to 20
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxSkewPercent *int32 `json:"maxSkewPercent,omitempty"`
+
+	// Timeout is the max duration to wait for the balance since the scaling out begins,
+	// the scaling is marked as complete once the timeout is hit even if the stores are not balanced.
+	//
+	// Defaults to 1h
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// EvictLeaderTimeoutPolicy is the policy applied when the leaders are not evicted within the timeout.
+type EvictLeaderTimeoutPolicy string
+
+const (
+	// EvictLeaderTimeoutPolicyForceUpgradeAfterTimeout upgrades the store even if the leaders are not evicted
+	EvictLeaderTimeoutPolicyForceUpgradeAfterTimeout EvictLeaderTimeoutPolicy = "ForceUpgradeAfterTimeout"
+	// EvictLeaderTimeoutPolicyPauseAndAlert pauses the rolling upgrade and records a warning event until the
+	// leaders are evicted, or the timeout or the policy is changed
+	EvictLeaderTimeoutPolicyPauseAndAlert EvictLeaderTimeoutPolicy = "PauseAndAlert"
 )
 
 // +k8s:openapi-gen=true
@@ -439,6 +757,19 @@ const (
 type DiscoverySpec struct {
 	*ComponentSpec              `json:",inline"`
 	corev1.ResourceRequirements `json:",inline"`
+
+	// The desired replicas of discovery, more than one replica makes discovery run
+	// in stateless mode and use a RollingUpdate strategy.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ProxyTiCDCOwner exposes the open API of the TiCDC owner under the path `/ticdc` of the proxy
+	// service on port 10262, so the clients don't need to track which capture is the owner.
+	// Optional: Defaults to false
+	// +optional
+	ProxyTiCDCOwner bool `json:"proxyTiCDCOwner,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -470,6 +801,10 @@ type PDSpec struct {
 	// +optional
 	MaxFailoverCount *int32 `json:"maxFailoverCount,omitempty"`
 
+	// Failover is the configurations of failover
+	// +optional
+	Failover *Failover `json:"failover,omitempty"`
+
 	// The storageClassName of the persistent volume for PD data storage.
 	// Defaults to Kubernetes default storage class.
 	// +optional
@@ -514,6 +849,89 @@ type PDSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:="";"v1"
 	StartUpScriptVersion string `json:"startUpScriptVersion,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of PD pods.
+	// No PodDisruptionBudget is created if it is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Mode is the mode of PD cluster, in "ms" mode PD only serves as the API service and
+	// the TSO and scheduling services are deployed by `spec.pdms`.
+	// Optional: Defaults to "", which means PD serves all the services
+	// +optional
+	// +kubebuilder:validation:Enum:="";"ms"
+	Mode PDMode `json:"mode,omitempty"`
+
+	// LeaderLocality keeps the PD leader in the primary data plane if the cluster is deployed
+	// across multiple Kubernetes clusters.
+	// +optional
+	LeaderLocality *PDLeaderLocality `json:"leaderLocality,omitempty"`
+
+	// ScaleInHook is the check run before a PD member is removed during scale-in,
+	// the scale-in proceeds only if the check passes.
+	// +optional
+	ScaleInHook *ScaleInHook `json:"scaleInHook,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// PDLeaderLocality keeps the PD leader in the primary data plane of a cluster deployed across
+// multiple Kubernetes clusters, since the latency increases if the leader is in a remote region.
+type PDLeaderLocality struct {
+	// PrimaryClusterDomain is the cluster domain of the primary data plane. If the PD leader moves to
+	// other data planes, it is transferred back to a healthy PD member of the TidbCluster whose
+	// `spec.clusterDomain` is the same. All the TidbClusters of the cluster should set the same value.
+	PrimaryClusterDomain string `json:"primaryClusterDomain"`
+}
+
+// PDMode is the mode of PD cluster
+type PDMode string
+
+const (
+	// PDModeNormal is the mode that PD serves all the services
+	PDModeNormal PDMode = ""
+	// PDModeMicroservice is the mode that PD serves as the API service, and the TSO and
+	// scheduling services are served by the PD microservices
+	PDModeMicroservice PDMode = "ms"
+)
+
+// +k8s:openapi-gen=true
+// PDMSSpec contains details of a PD microservice
+type PDMSSpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Name of the microservice, each microservice is deployed by its own StatefulSet
+	// +kubebuilder:validation:Enum:="tso";"scheduling"
+	Name string `json:"name"`
+
+	// Specify a Service Account for the microservice
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// The desired ready replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Base image of the component, image tag is now allowed during validation
+	// +kubebuilder:default=pingcap/pd
+	// +optional
+	BaseImage string `json:"baseImage"`
+
+	// Config is the Configuration of the microservice
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *PDConfigWraper `json:"config,omitempty"`
+}
+
+// TiKVGroup is a group of TiKV in the TidbCluster
+// +k8s:openapi-gen=true
+type TiKVGroup struct {
+	// Name of the group, it must be unique in the TidbCluster
+	Name string `json:"name"`
+
+	// Spec of the TiKV in the group, the cluster-level settings such as version and TLS
+	// are inherited from the TidbCluster
+	Spec TiKVSpec `json:"spec"`
 }
 
 // TiKVSpec contains details of TiKV members
@@ -564,6 +982,13 @@ type TiKVSpec struct {
 	// +optional
 	RaftLogVolumeName string `json:"raftLogVolumeName,omitempty"`
 
+	// RaftLogVolumeClaim provisions a dedicated volume for the raft engine and raftdb of TiKV,
+	// the volume is mounted to /var/lib/raft-log and the corresponding TiKV config is rendered
+	// unless it is set explicitly.
+	// It can not be added to or removed from an existing cluster because the raft log is not migrated.
+	// +optional
+	RaftLogVolumeClaim *RaftLogVolumeClaim `json:"raftLogVolumeClaim,omitempty"`
+
 	// LogTailer is the configurations of the log tailers for TiKV
 	// +optional
 	LogTailer *LogTailerSpec `json:"logTailer,omitempty"`
@@ -607,6 +1032,13 @@ type TiKVSpec struct {
 	// +optional
 	EvictLeaderTimeout *string `json:"evictLeaderTimeout,omitempty"`
 
+	// EvictLeaderTimeoutPolicy indicates what to do when the leaders are not evicted within
+	// the EvictLeaderTimeout during the rolling upgrade.
+	// Defaults to ForceUpgradeAfterTimeout
+	// +optional
+	// +kubebuilder:validation:Enum=ForceUpgradeAfterTimeout;PauseAndAlert
+	EvictLeaderTimeoutPolicy *EvictLeaderTimeoutPolicy `json:"evictLeaderTimeoutPolicy,omitempty"`
+
 	// WaitLeaderTransferBackTimeout indicates the timeout to wait for leader transfer back before
 	// the next tikv upgrade.
 	//
@@ -614,6 +1046,22 @@ type TiKVSpec struct {
 	// +optional
 	WaitLeaderTransferBackTimeout *metav1.Duration `json:"waitLeaderTransferBackTimeout,omitempty"`
 
+	// DrainTimeout indicates the timeout to wait for the store to migrate all its regions when scaling in.
+	// Once the timeout is hit, a DrainTimeout event is recorded and the scale-in stops until the store becomes
+	// tombstone or the pod is annotated with `tidb.pingcap.com/tikv-force-scale-in: "true"`.
+	//
+	// Defaults to wait until the store becomes tombstone
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// ScaleOutBalance configures the gate to wait for the regions and leaders to be balanced among
+	// the stores after scaling out, the scaling is not complete and the cluster is not ready until
+	// the stores are balanced or the timeout is hit.
+	//
+	// Defaults to nil, which means not to wait for the balance
+	// +optional
+	ScaleOutBalance *ScaleOutBalanceGate `json:"scaleOutBalance,omitempty"`
+
 	// StorageVolumes configure additional storage for TiKV pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -629,6 +1077,155 @@ type TiKVSpec struct {
 	// ScalePolicy is the scale configuration for TiKV
 	// +optional
 	ScalePolicy ScalePolicy `json:"scalePolicy,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of TiKV pods.
+	// No PodDisruptionBudget is created if it is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// RollingUpdateStrategy configures the partitioned rolling update of TiKV.
+	// +optional
+	RollingUpdateStrategy *RollingUpdateStrategy `json:"rollingUpdateStrategy,omitempty"`
+	// IOTuning configures the IO rate limit and the background jobs of TiKV, they are applied to
+	// the running TiKV pods by the online config API without rolling update.
+	// +optional
+	IOTuning *TiKVIOTuning `json:"ioTuning,omitempty"`
+
+	// CapacityReservation keeps placeholder pods sized like a TiKV member on the standby nodes,
+	// the placeholder pods are preempted by the replacement TiKV pods during failover, so the
+	// replacement TiKV pods are scheduled without waiting for new nodes.
+	// No placeholder pods are created if it is not set.
+	// +optional
+	CapacityReservation *CapacityReservation `json:"capacityReservation,omitempty"`
+
+	// ScaleInHook is the check run before the store of a TiKV pod is deleted during scale-in,
+	// the scale-in proceeds only if the check passes.
+	// +optional
+	ScaleInHook *ScaleInHook `json:"scaleInHook,omitempty"`
+
+	// StoreGC is the policy to remove the tombstone and the orphan stores from PD, the stores are
+	// kept in PD if it is not set.
+	// +optional
+	StoreGC *TiKVStoreGC `json:"storeGC,omitempty"`
+
+	// SlowStore is the policy to detect the slow stores by the slow scores reported by PD, the SlowStore
+	// condition is raised for them and their leaders can be evicted. The slow stores are not detected
+	// if it is not set.
+	// +optional
+	SlowStore *TiKVSlowStorePolicy `json:"slowStore,omitempty"`
+}
+
+// ScaleInHook is a user defined check run by the operator before a pod is scaled in, it can be used
+// to check the capacity or the replication safety. Exactly one of HTTP and Job should be set.
+//
+// +k8s:openapi-gen=true
+type ScaleInHook struct {
+	// HTTP posts the scale-in request in JSON to an HTTP endpoint,
+	// the check passes if the response status is 2xx.
+	// +optional
+	HTTP *ScaleInHTTPHook `json:"http,omitempty"`
+
+	// Job runs a Job for each pod to be scaled in, the check passes if the Job succeeds.
+	// The Job is not retried once it fails, delete the Job to run the check again.
+	// +optional
+	Job *ScaleInJobHook `json:"job,omitempty"`
+}
+
+// ScaleInHTTPHook is the HTTP callback of the scale-in hook
+//
+// +k8s:openapi-gen=true
+type ScaleInHTTPHook struct {
+	// URL of the HTTP endpoint
+	URL string `json:"url"`
+
+	// TimeoutSeconds of the request
+	// Optional: Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ScaleInJobHook is the Job of the scale-in hook. The information of the pod to be scaled in is
+// passed to the containers by the environment variables SCALE_IN_NAMESPACE, SCALE_IN_CLUSTER,
+// SCALE_IN_COMPONENT, SCALE_IN_POD and SCALE_IN_STORE_ID.
+//
+// +k8s:openapi-gen=true
+type ScaleInJobHook struct {
+	// Template of the pods of the Job, the restart policy is Never if it is not set
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// BackoffLimit of the Job
+	// Optional: Defaults to 0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+}
+
+// CapacityReservation configures the low-priority placeholder pods which reserve the compute
+// resources for the replacement pods of a component.
+//
+// +k8s:openapi-gen=true
+type CapacityReservation struct {
+	// Replicas is the number of the placeholder pods, each of them reserves the resources of a member
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PriorityClassName of the placeholder pods. It must have a lower priority than the pods of the
+	// component, so the placeholder pods can be preempted by them.
+	PriorityClassName string `json:"priorityClassName"`
+
+	// Image of the placeholder pods, which runs nothing but the pause process
+	// Optional: Defaults to registry.k8s.io/pause:3.9
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// NodeSelector of the placeholder pods to keep them on the standby nodes
+	// Optional: Defaults to the node selector of the component
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// TiKVStoreGC configures the removal of the stores which are left in PD after failed scale-ins
+// or node losses.
+//
+// +k8s:openapi-gen=true
+type TiKVStoreGC struct {
+	// TombstoneGracePeriod is how long the tombstone stores are kept before they are removed.
+	// PD removes all the tombstone stores at once, so they are removed only after the latest
+	// of them has been tombstone for the period.
+	// Optional: Defaults to 24h
+	// +optional
+	TombstoneGracePeriod *metav1.Duration `json:"tombstoneGracePeriod,omitempty"`
+
+	// OrphanGracePeriod is how long an orphan store is kept before it is deleted. A store is
+	// orphan if it is down and no TiKV pod serves it anymore, e.g. the pod is scaled in or it is
+	// recreated with a new store after the loss of its node.
+	// Optional: Defaults to 24h
+	// +optional
+	OrphanGracePeriod *metav1.Duration `json:"orphanGracePeriod,omitempty"`
+}
+
+// TiKVSlowStorePolicy configures the detection and the remediation of the slow TiKV stores.
+//
+// +k8s:openapi-gen=true
+type TiKVSlowStorePolicy struct {
+	// ScoreThreshold is the slow score from which a store is slow, PD reports the slow score
+	// from 1 to 100 and a higher score means the store is slower.
+	// Optional: Defaults to 80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ScoreThreshold *int32 `json:"scoreThreshold,omitempty"`
+
+	// Duration is how long the slow score must stay above the threshold before the store is slow
+	// Optional: Defaults to 5m
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// EvictLeader evicts the leaders from the slow store until its slow score recovers, the leaders
+	// are evicted from at most one store at a time and not during the upgrade of TiKV.
+	// +optional
+	EvictLeader bool `json:"evictLeader,omitempty"`
 }
 
 // TiFlashSpec contains details of TiFlash members
@@ -689,6 +1286,64 @@ type TiFlashSpec struct {
 	// ScalePolicy is the scale configuration for TiFlash
 	// +optional
 	ScalePolicy ScalePolicy `json:"scalePolicy,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of TiFlash pods.
+	// No PodDisruptionBudget is created if it is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Disaggregated deploys TiFlash in the disaggregated storage and compute architecture, the TiFlash
+	// servers of this spec are the write nodes, which write the data to the S3 storage, and the compute
+	// nodes are deployed in a separate StatefulSet to run the queries.
+	// Requires TiFlash v7.0.0 or later, and it can't be changed once the TiFlash servers are deployed.
+	// +optional
+	Disaggregated *TiFlashDisaggregatedSpec `json:"disaggregated,omitempty"`
+}
+
+// TiFlashDisaggregatedSpec contains details of TiFlash in the disaggregated storage and compute architecture
+// +k8s:openapi-gen=true
+type TiFlashDisaggregatedSpec struct {
+	// S3 is the storage shared by the write nodes and the compute nodes
+	S3 TiFlashS3Storage `json:"s3"`
+
+	// ComputeNode is the spec of the compute nodes
+	ComputeNode TiFlashComputeNodeSpec `json:"computeNode"`
+}
+
+// TiFlashS3Storage is the S3 compatible storage of TiFlash in the disaggregated storage and compute architecture
+// +k8s:openapi-gen=true
+type TiFlashS3Storage struct {
+	// Endpoint of the S3 compatible storage service
+	Endpoint string `json:"endpoint"`
+
+	// Bucket in which to store the data
+	Bucket string `json:"bucket"`
+
+	// Root is the path in the bucket where the data is stored
+	// +optional
+	Root string `json:"root,omitempty"`
+
+	// SecretName is the name of the secret which stores the access key and the secret key of the storage in
+	// `access_key` and `secret_key`. The credentials of the pods are used if it is not set, e.g. the IAM role
+	// of the service account.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// TiFlashComputeNodeSpec contains details of the TiFlash compute nodes, the fields not specified here, e.g. the
+// base image and the config, are the same as the write nodes.
+// +k8s:openapi-gen=true
+type TiFlashComputeNodeSpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// The desired ready replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// The persistent volume claims of the compute nodes, the first one stores the cache of the data read
+	// from the S3 storage.
+	StorageClaims []StorageClaim `json:"storageClaims"`
 }
 
 // TiCDCSpec contains details of TiCDC members
@@ -734,6 +1389,11 @@ type TiCDCSpec struct {
 	// Defaults to 10m
 	// +optional
 	GracefulShutdownTimeout *metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of TiCDC pods.
+	// No PodDisruptionBudget is created if it is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 }
 
 // TiCDCConfig is the configuration of tidbcdc
@@ -826,6 +1486,35 @@ type StorageClaim struct {
 	StorageClassName *string `json:"storageClassName,omitempty"`
 }
 
+// TiDBPluginArtifact is a plugin of TiDB together with the source of its binary,
+// exactly one of configMap, image and persistentVolumeClaim should be set.
+// +k8s:openapi-gen=true
+type TiDBPluginArtifact struct {
+	// Name is the name in the manifest of the plugin, e.g. audit
+	Name string `json:"name"`
+	// Version is the version in the manifest of the plugin, the plugin is loaded as <name>-<version>
+	Version string `json:"version"`
+	// TiDBVersions is a constraint of the TiDB versions the plugin is built for, e.g. ">= 7.1.0, < 7.2.0",
+	// the cluster is rejected if the version of TiDB doesn't satisfy it.
+	// +optional
+	TiDBVersions string `json:"tidbVersions,omitempty"`
+	// Path is the path of the plugin binary in the source, i.e. the key of the ConfigMap, the path in the image
+	// or the sub path in the volume.
+	// Defaults to <name>-<version>.so
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ConfigMap is the ConfigMap containing the plugin binary
+	// +optional
+	ConfigMap *corev1.LocalObjectReference `json:"configMap,omitempty"`
+	// Image is the image containing the plugin binary, e.g. an OCI artifact, the binary is copied into the plugin
+	// directory by an init container, so the image should have `sh` and `cp`.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// PersistentVolumeClaim is the volume containing the plugin binary
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
 // TiDBSpec contains details of TiDB members
 // +k8s:openapi-gen=true
 type TiDBSpec struct {
@@ -860,6 +1549,10 @@ type TiDBSpec struct {
 	// +optional
 	MaxFailoverCount *int32 `json:"maxFailoverCount,omitempty"`
 
+	// Failover is the configurations of failover
+	// +optional
+	Failover *Failover `json:"failover,omitempty"`
+
 	// Whether output the slow log in an separate sidecar container
 	// Optional: Defaults to true
 	// +optional
@@ -883,11 +1576,21 @@ type TiDBSpec struct {
 	// +optional
 	TokenBasedAuthEnabled *bool `json:"tokenBasedAuthEnabled,omitempty"`
 
+	// TokenBasedAuth makes the operator generate and rotate the signing keys of the `tidb_auth_token`
+	// authentication method in the JWKS Secret `<clusterName>-tidb-auth-token-jwks-secret`.
+	// Applications sign their JWTs with the private key published in that Secret.
+	// Setting it implies tokenBasedAuthEnabled.
+	// +optional
+	TokenBasedAuth *TiDBTokenBasedAuth `json:"tokenBasedAuth,omitempty"`
+
 	// Plugins is a list of plugins that are loaded by TiDB server, empty means plugin disabled
 	// +optional
 	Plugins []string `json:"plugins,omitempty"`
-	// TODO: additional volumes should be used to hold .so plugin binaries.
-	// Because this is not a complete implementation, maybe we can change this without backward compatibility.
+
+	// PluginArtifacts are the plugins whose binaries are mounted into the plugin directory of TiDB by the operator,
+	// they are loaded together with the plugins in `plugins`.
+	// +optional
+	PluginArtifacts []TiDBPluginArtifact `json:"pluginArtifacts,omitempty"`
 
 	// Config is the Configuration of tidb-servers
 	// +optional
@@ -901,6 +1604,27 @@ type TiDBSpec struct {
 	// +optional
 	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
 
+	// GracefulShutdownSeconds enables draining the client connections before a TiDB pod is terminated,
+	// e.g. in rolling upgrades and scale-in. A preStop hook fails the readiness probe of the pod to remove it
+	// from the Service endpoints, then waits at most GracefulShutdownSeconds for the active connections
+	// reported by the status API to drop to zero before TiDB is stopped.
+	// The readiness probe is switched to the command type, and terminationGracePeriodSeconds defaults to
+	// GracefulShutdownSeconds + 30 if it is not set. It can't be used together with lifecycle.preStop.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GracefulShutdownSeconds *int32 `json:"gracefulShutdownSeconds,omitempty"`
+
+	// ConnectionPool adds a connection pool sidecar to TiDB pods, the client connections of the TiDB Service are
+	// admitted by the pool and multiplexed on the connections to the TiDB server in the same pod.
+	// +optional
+	ConnectionPool *TiDBConnectionPool `json:"connectionPool,omitempty"`
+
+	// SQLReadinessGate adds a readiness gate to TiDB pods, the condition of the gate is set by the operator
+	// after checking the SQL health of TiDB, so the pods which accept TCP connections but fail SQL auth or
+	// schema loading are not considered ready.
+	// +optional
+	SQLReadinessGate *TiDBSQLReadinessGate `json:"sqlReadinessGate,omitempty"`
+
 	// StorageVolumes configure additional storage for TiDB pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -921,6 +1645,139 @@ type TiDBSpec struct {
 	// Only v6.6.0+ supports this feature.
 	// +optional
 	BootstrapSQLConfigMapName *string `json:"bootstrapSQLConfigMapName,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of TiDB pods.
+	// No PodDisruptionBudget is created if it is not set.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// ConnectionSecret configures the Secret published for applications to connect to TiDB.
+	// No Secret is published if it is not set.
+	// +optional
+	ConnectionSecret *TiDBConnectionSecret `json:"connectionSecret,omitempty"`
+	// RollingUpdateStrategy configures the partitioned rolling update of TiDB.
+	// +optional
+	RollingUpdateStrategy *RollingUpdateStrategy `json:"rollingUpdateStrategy,omitempty"`
+
+	// RestartPolicy configures the scheduled rolling restarts of TiDB, e.g. a weekly restart
+	// to curb the memory growth caused by fragmentation.
+	// +optional
+	RestartPolicy *TiDBRestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// TiDBRestartPolicy describes the scheduled rolling restarts of TiDB
+// +k8s:openapi-gen=true
+type TiDBRestartPolicy struct {
+	// Schedule is the cron expression of the start of the restart windows, e.g. `0 3 * * 0`.
+	// It is in the time zone of the cluster.
+	Schedule string `json:"schedule"`
+
+	// Window is the duration of a restart window, no pod is restarted after the window ends
+	// and the remaining pods are restarted in the next windows.
+	// Optional: Defaults to 1h
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// MaxPodsPerRun is the max number of pods restarted in a window.
+	// Optional: Defaults to the replicas of TiDB, i.e. all pods are restarted in a window
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPodsPerRun *int32 `json:"maxPodsPerRun,omitempty"`
+}
+
+// TiDBConnectionSecret describes the Secret which contains the ready-to-use connection info of TiDB,
+// including host, port, CA certificate if TLS is enabled, and the user credentials if configured.
+// +k8s:openapi-gen=true
+type TiDBConnectionSecret struct {
+	// Name of the Secret.
+	// Optional: Defaults to `<clusterName>-tidb-connection`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// User to put in the Secret. Its password is read from the password secret
+	// of the TidbInitializer of this cluster.
+	// No user credentials are put in the Secret if it is not set.
+	// +optional
+	User string `json:"user,omitempty"`
+}
+
+// TiDBTokenBasedAuth describes how the operator provisions the JWKS of the `tidb_auth_token` authentication method.
+// +k8s:openapi-gen=true
+type TiDBTokenBasedAuth struct {
+	// RotationInterval is the interval to rotate the signing key. The previous public key
+	// is kept in the JWKS after a rotation, so tokens signed before the rotation are still valid.
+	// Optional: Defaults to 720h
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// RefreshInterval is the interval for TiDB to reload the JWKS, it is rendered to
+	// `security.auth-token-refresh-interval` of the TiDB config.
+	// Optional: Defaults to the default value of TiDB
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// TiDBSQLReadinessGate configures the SQL health check feeding the readiness gate of TiDB pods.
+// The check runs `SELECT 1` and queries `information_schema.cluster_info` on each TiDB pod.
+// +k8s:openapi-gen=true
+type TiDBSQLReadinessGate struct {
+	// User to connect to TiDB.
+	// Optional: Defaults to root
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// SecretName is the name of the Secret storing the password of the user with the key `password`.
+	// Optional: Defaults to the root password created by spec.tidb.initializer, or an empty password
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// TimeoutSeconds is the timeout of the SQL health check of each pod, the pods are checked concurrently
+	// every 10 seconds and the timeout is capped at 30 seconds.
+	// Optional: Defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// TiDBConnectionPool is the connection pool sidecar of TiDB pods. The image is a lightweight MySQL proxy
+// configured by the environment variables:
+//
+//   - POOL_LISTEN_ADDR: the address to listen on for the client connections
+//   - POOL_SERVER_ADDR: the address of the TiDB server in the same pod
+//   - POOL_MAX_CONNECTIONS: the max number of the client connections, the excess connections are rejected
+//   - POOL_MAX_SERVER_CONNECTIONS: the max number of the connections to the TiDB server
+//   - POOL_DRAIN_TIMEOUT_SECONDS: set if `spec.tidb.gracefulShutdownSeconds` is set, the pool drains the client
+//     connections and closes the server connections in the timeout after it receives SIGTERM, so the preStop
+//     hook of TiDB observes the connections drop in rolling upgrades and scale-in.
+//
+// +k8s:openapi-gen=true
+type TiDBConnectionPool struct {
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Image of the connection pool
+	Image string `json:"image"`
+
+	// ImagePullPolicy of the connection pool
+	// Optional: Defaults to the imagePullPolicy of TiDB
+	// +optional
+	ImagePullPolicy *corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Port to listen on for the client connections, the TiDB Service forwards the client connections to it.
+	// Optional: Defaults to 6000
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// MaxConnections is the max number of the client connections admitted by the pool of each pod.
+	// Optional: Defaults to 1000
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// MaxServerConnections is the max number of the connections from the pool to the TiDB server of each pod.
+	// Optional: Defaults to 100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxServerConnections *int32 `json:"maxServerConnections,omitempty"`
 }
 
 type TiDBInitializer struct {
@@ -1127,6 +1984,14 @@ type ComponentSpec struct {
 	// +optional
 	AdditionalContainers []corev1.Container `json:"additionalContainers,omitempty"`
 
+	// NativeSidecarContainers are the names of the additional containers which run as native sidecar
+	// containers, they start before and terminate after the main container of the component.
+	// They are converted to init containers with restartPolicy Always on Kubernetes v1.28+ (the
+	// SidecarContainers feature gate must be enabled on v1.28), and run as regular containers on
+	// older Kubernetes.
+	// +optional
+	NativeSidecarContainers []string `json:"nativeSidecarContainers,omitempty"`
+
 	// Additional volumes of component pod.
 	// +optional
 	AdditionalVolumes []corev1.Volume `json:"additionalVolumes,omitempty"`
@@ -1148,7 +2013,9 @@ type ComponentSpec struct {
 	// The grace period is the duration in seconds after the processes running in the pod are sent
 	// a termination signal and the time when the processes are forcibly halted with a kill signal.
 	// Set this value longer than the expected cleanup time for your process.
-	// Defaults to 30 seconds.
+	// Defaults to the value by the role of the component in TidbCluster, e.g. 300 seconds for TiKV and TiFlash,
+	// 60 seconds for PD and TiDB, and 30 seconds for the components of other kinds.
+	// The existing StatefulSets keep their current grace period unless it is set explicitly.
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 
@@ -1225,8 +2092,55 @@ type ServiceSpec struct {
 	// Optional: Defaults to omitted
 	// +optional
 	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// InternalTrafficPolicy describes how nodes distribute the service traffic they receive on the ClusterIP,
+	// `Local` only routes the traffic to the endpoints on the same node.
+	// It requires Kubernetes v1.22+ and is not applied on the older versions.
+	// Optional: Defaults to omitted
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	InternalTrafficPolicy *ServiceInternalTrafficPolicyType `json:"internalTrafficPolicy,omitempty"`
+
+	// TrafficDistribution expresses the preference of how the traffic is distributed to the endpoints,
+	// `PreferClose` prefers the endpoints in the same zone as the client.
+	// It requires Kubernetes v1.31+ and is not applied on the older versions.
+	// Optional: Defaults to omitted
+	// +kubebuilder:validation:Enum=PreferClose
+	// +optional
+	TrafficDistribution *string `json:"trafficDistribution,omitempty"`
+
+	// TopologyAwareRouting keeps the traffic in the zone where it originates by the topology aware hints,
+	// it sets the `service.kubernetes.io/topology-mode` annotation (Kubernetes v1.27+) and the
+	// `service.kubernetes.io/topology-aware-hints` annotation (Kubernetes v1.23 ~ v1.26) of the service.
+	// It can not be used together with TrafficDistribution.
+	// Optional: Defaults to false
+	// +optional
+	TopologyAwareRouting *bool `json:"topologyAwareRouting,omitempty"`
+
+	// SessionAffinity of the service, `ClientIP` routes the connections from the same client to the same endpoint.
+	// Optional: Defaults to None
+	// +kubebuilder:validation:Enum="";None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityConfig contains the configurations of the `ClientIP` session affinity.
+	// +optional
+	SessionAffinityConfig *corev1.SessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
 }
 
+// ServiceInternalTrafficPolicyType describes how nodes distribute the service traffic they receive on the ClusterIP.
+type ServiceInternalTrafficPolicyType string
+
+const (
+	// ServiceInternalTrafficPolicyCluster routes the traffic to all the endpoints
+	ServiceInternalTrafficPolicyCluster ServiceInternalTrafficPolicyType = "Cluster"
+	// ServiceInternalTrafficPolicyLocal only routes the traffic to the endpoints on the same node
+	ServiceInternalTrafficPolicyLocal ServiceInternalTrafficPolicyType = "Local"
+
+	// ServiceTrafficDistributionPreferClose prefers the endpoints in the same zone as the client
+	ServiceTrafficDistributionPreferClose = "PreferClose"
+)
+
 // TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.
 // +k8s:openapi-gen=true
 type TiDBServiceSpec struct {
@@ -1257,6 +2171,98 @@ type TiDBServiceSpec struct {
 	// Optional: Defaults to omitted
 	// +optional
 	AdditionalPorts []corev1.ServicePort `json:"additionalPorts,omitempty"`
+
+	// ExternalDNS configures the DNS records of the service managed by external-dns
+	// Optional: Defaults to omitted
+	// +optional
+	ExternalDNS *ExternalDNS `json:"externalDNS,omitempty"`
+
+	// ZoneLocal routes the applications to the TiDB pods in the same zone to cut the cross-zone traffic,
+	// the TiDB pods are labeled with their zones by the `tidb.pingcap.com/zone` label.
+	// Optional: Defaults to omitted
+	// +optional
+	ZoneLocal *TiDBZoneLocalRouting `json:"zoneLocal,omitempty"`
+
+	// ProxyProtocol enables the PROXY protocol between the load balancer of the service and TiDB,
+	// so that the client IPs appear correctly in the logs and privilege checks of TiDB.
+	// It configures the `proxy-protocol` section of the TiDB config and the annotations of the load balancer together.
+	// Optional: Defaults to omitted
+	// +optional
+	ProxyProtocol *TiDBProxyProtocol `json:"proxyProtocol,omitempty"`
+}
+
+// TiDBZoneLocalMode is the way to route the applications to the TiDB pods in the same zone
+type TiDBZoneLocalMode string
+
+const (
+	// TiDBZoneLocalModePerZoneService creates a ClusterIP Service `<cluster>-tidb-<zone>` for each zone
+	// of the TiDB pods, which only selects the TiDB pods in the zone. The services are listed in the ConfigMap
+	// `<cluster>-tidb-zones`, whose keys are the zones and values are the `<host>:<port>` addresses of the
+	// services, so the applications pin the connections to their zone by the key of the zone of their node.
+	TiDBZoneLocalModePerZoneService TiDBZoneLocalMode = "PerZoneService"
+	// TiDBZoneLocalModeTopologyAwareHints enables the topology aware routing of Kubernetes on the TiDB service
+	TiDBZoneLocalModeTopologyAwareHints TiDBZoneLocalMode = "TopologyAwareHints"
+)
+
+// TiDBZoneLocalRouting configures how the applications are routed to the TiDB pods in the same zone.
+//
+// +k8s:openapi-gen=true
+type TiDBZoneLocalRouting struct {
+	// Mode is the way to route the applications to the TiDB pods in the same zone
+	// +kubebuilder:validation:Enum=PerZoneService;TopologyAwareHints
+	Mode TiDBZoneLocalMode `json:"mode"`
+}
+
+// TiDBProxyProtocolProvider is the cloud provider of the load balancer which sends the PROXY protocol header
+type TiDBProxyProtocolProvider string
+
+const (
+	// TiDBProxyProtocolProviderAWS enables the PROXY protocol on the AWS load balancer
+	TiDBProxyProtocolProviderAWS TiDBProxyProtocolProvider = "aws"
+	// TiDBProxyProtocolProviderAlibabaCloud enables the PROXY protocol on the Alibaba Cloud load balancer
+	TiDBProxyProtocolProviderAlibabaCloud TiDBProxyProtocolProvider = "alibabacloud"
+)
+
+// TiDBProxyProtocol configures the PROXY protocol between the load balancer and TiDB.
+//
+// +k8s:openapi-gen=true
+type TiDBProxyProtocol struct {
+	// Provider of the load balancer, the annotations which enable the PROXY protocol on the load balancer
+	// are added to the service. The service must be of LoadBalancer type if it is set.
+	// Optional: Defaults to omitted, the load balancer is configured by the annotations in the service spec
+	// +kubebuilder:validation:Enum=aws;alibabacloud
+	// +optional
+	Provider TiDBProxyProtocolProvider `json:"provider,omitempty"`
+
+	// Networks are the IPs or CIDRs of the load balancer which send the PROXY protocol header, `*` means all networks.
+	// The clients connecting from these networks without the header are rejected by TiDB.
+	Networks []string `json:"networks"`
+
+	// HeaderTimeout is the timeout in seconds to read the PROXY protocol header
+	// Optional: Defaults to the default of TiDB
+	// +optional
+	HeaderTimeout *int32 `json:"headerTimeout,omitempty"`
+}
+
+// ExternalDNS configures the DNS records managed by external-dns for a service,
+// it is translated into the external-dns annotations of the service.
+//
+// +k8s:openapi-gen=true
+type ExternalDNS struct {
+	// Hostnames of the DNS records which point to the service
+	Hostnames []string `json:"hostnames"`
+
+	// TTL of the DNS records in seconds
+	// Optional: Defaults to the TTL of the DNS provider
+	// +optional
+	TTL *int32 `json:"ttl,omitempty"`
+
+	// VerifyPropagation indicates whether to verify that the DNS records are resolved to the
+	// load balancer of the service before the service is marked as ready.
+	// It only takes effect for the service of LoadBalancer type.
+	// Optional: Defaults to false
+	// +optional
+	VerifyPropagation bool `json:"verifyPropagation,omitempty"`
 }
 
 // (Deprecated) Service represent service type used in TidbCluster
@@ -1293,6 +2299,74 @@ type PDStatus struct {
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Recovery is the progress of the recovery from the majority loss of PD members,
+	// which is triggered by the tidb.pingcap.com/pd-recover-from annotation.
+	// +optional
+	Recovery *PDRecoveryStatus `json:"recovery,omitempty"`
+	// LeaderClusterDomain is the cluster domain of the data plane which hosts the PD leader,
+	// it's empty if the leader is in a data plane without cluster domain.
+	// +optional
+	LeaderClusterDomain string `json:"leaderClusterDomain,omitempty"`
+	// ImageDigest is the digest which the image of the component is resolved to
+	// +optional
+	ImageDigest *ImageDigest `json:"imageDigest,omitempty"`
+}
+
+// ImageDigest is the digest of the image of a component, resolved by a probe pod pulling the image if the digest
+// pinning is enabled, and from the image IDs of the pods running the image otherwise
+type ImageDigest struct {
+	// Image is the image of the component in the spec, e.g. `pingcap/pd:v7.1.0`
+	Image string `json:"image"`
+	// Digest is the digest of the image, e.g. `sha256:...`, it's the digest of the manifest list for the
+	// multi-arch images, so the image pinned to it still works on the nodes of different architectures
+	Digest string `json:"digest"`
+}
+
+// PDRecoveryPhase is the step of the recovery from the majority loss of PD members
+type PDRecoveryPhase string
+
+const (
+	// PDRecoveryPhaseChecking means the majority loss and the survivor are being checked
+	PDRecoveryPhaseChecking PDRecoveryPhase = "Checking"
+	// PDRecoveryPhaseForceNewCluster means the survivor is restarted with --force-new-cluster
+	// to rebuild a one-member PD cluster from its data
+	PDRecoveryPhaseForceNewCluster PDRecoveryPhase = "ForceNewCluster"
+	// PDRecoveryPhaseRebuildMembers means the other members are rejoining the rebuilt PD cluster
+	// with empty volumes
+	PDRecoveryPhaseRebuildMembers PDRecoveryPhase = "RebuildMembers"
+	// PDRecoveryPhaseUnsafeRecover means the regions which lost the majority of replicas on the
+	// failed TiKV stores are being recovered by the unsafe recovery of PD
+	PDRecoveryPhaseUnsafeRecover PDRecoveryPhase = "UnsafeRecover"
+	// PDRecoveryPhaseCompleted means the recovery is completed
+	PDRecoveryPhaseCompleted PDRecoveryPhase = "Completed"
+	// PDRecoveryPhaseFailed means the recovery is refused or failed, it must be checked manually
+	PDRecoveryPhaseFailed PDRecoveryPhase = "Failed"
+)
+
+// PDRecoveryStatus is the status of the recovery from the majority loss of PD members
+type PDRecoveryStatus struct {
+	// Survivor is the name of the PD pod whose data is used to rebuild PD.
+	Survivor string `json:"survivor"`
+	// SurvivorUID is the UID of the survivor pod before it is restarted with --force-new-cluster.
+	// +optional
+	SurvivorUID types.UID `json:"survivorUID,omitempty"`
+	// RebuiltMembers are the PD pods whose volumes are wiped to rejoin the rebuilt PD cluster.
+	// +optional
+	RebuiltMembers []string `json:"rebuiltMembers,omitempty"`
+	// FailedStores are the IDs of the TiKV stores removed by the unsafe recovery.
+	// +optional
+	FailedStores []uint64 `json:"failedStores,omitempty"`
+	// Phase is the current step of the recovery.
+	Phase PDRecoveryPhase `json:"phase"`
+	// StartTime is the time when the recovery starts.
+	// +nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// LastTransitionTime is the time when the recovery transits to the current phase.
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message describes the current step or the reason of the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // PDMember is PD member
@@ -1307,6 +2381,9 @@ type PDMember struct {
 	// TODO: remove nullable, https://github.com/kubernetes/kubernetes/issues/86811
 	// +nullable
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// ProbeFailures is the number of consecutive times the member is probed unhealthy.
+	// +optional
+	ProbeFailures int32 `json:"probeFailures,omitempty"`
 }
 
 // EmptyStruct is defined to delight controller-gen tools
@@ -1343,12 +2420,34 @@ type TiDBStatus struct {
 	ResignDDLOwnerRetryCount int32                        `json:"resignDDLOwnerRetryCount,omitempty"`
 	Image                    string                       `json:"image,omitempty"`
 	PasswordInitialized      *bool                        `json:"passwordInitialized,omitempty"`
+	// Summary of the members if the status is compacted, only the unhealthy members are listed in Members then.
+	// +optional
+	Summary *MemberSummary `json:"summary,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Restart is the status of the scheduled rolling restarts
+	// +optional
+	Restart *TiDBRestartStatus `json:"restart,omitempty"`
+	// ImageDigest is the digest which the image of the component is resolved to
+	// +optional
+	ImageDigest *ImageDigest `json:"imageDigest,omitempty"`
+}
+
+// TiDBRestartStatus is the status of the scheduled rolling restarts of TiDB
+type TiDBRestartStatus struct {
+	// ScheduleTime is the start time of the latest restart window
+	// +nullable
+	ScheduleTime metav1.Time `json:"scheduleTime,omitempty"`
+	// RestartedPods are the pods restarted in the latest restart window
+	// +optional
+	RestartedPods []string `json:"restartedPods,omitempty"`
+	// Message describes the progress of the latest restart window, e.g. why the restart is skipped
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // TiDBMember is TiDB member
@@ -1358,6 +2457,9 @@ type TiDBMember struct {
 	// Last time the health transitioned from one to another.
 	// +nullable
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// ProbeFailures is the number of consecutive times the member is probed unhealthy.
+	// +optional
+	ProbeFailures int32 `json:"probeFailures,omitempty"`
 	// Node hosting pod of this TiDB member.
 	NodeName string `json:"node,omitempty"`
 }
@@ -1370,7 +2472,9 @@ type TiDBFailureMember struct {
 }
 
 var (
-	EvictLeaderAnnKeys = []string{EvictLeaderAnnKey, EvictLeaderAnnKeyForResize}
+	EvictLeaderAnnKeys = []string{EvictLeaderAnnKey, EvictLeaderAnnKeyForResize, EvictLeaderAnnKeyForNodeDrain}
+	// PDLeaderTransferAnnKeys are the annotation keys to transfer PD leader.
+	PDLeaderTransferAnnKeys = []string{PDLeaderTransferAnnKey, PDLeaderTransferAnnKeyForNodeDrain}
 )
 
 const (
@@ -1378,8 +2482,12 @@ const (
 	EvictLeaderAnnKey = "tidb.pingcap.com/evict-leader"
 	// EvictLeaderAnnKeyForResize is the annotation key to evict leader user by pvc resizer.
 	EvictLeaderAnnKeyForResize = "tidb.pingcap.com/evict-leader-for-resize"
+	// EvictLeaderAnnKeyForNodeDrain is the annotation key to evict leader used by node drain controller.
+	EvictLeaderAnnKeyForNodeDrain = "tidb.pingcap.com/evict-leader-for-node-drain"
 	// PDLeaderTransferAnnKey is the annotation key to transfer PD leader used by user.
 	PDLeaderTransferAnnKey = "tidb.pingcap.com/pd-transfer-leader"
+	// PDLeaderTransferAnnKeyForNodeDrain is the annotation key to transfer PD leader used by node drain controller.
+	PDLeaderTransferAnnKeyForNodeDrain = "tidb.pingcap.com/pd-transfer-leader-for-node-drain"
 	// TiDBGracefulShutdownAnnKey is the annotation key to graceful shutdown tidb pod by user.
 	TiDBGracefulShutdownAnnKey = "tidb.pingcap.com/tidb-graceful-shutdown"
 )
@@ -1423,8 +2531,37 @@ const (
 	// Normally we only allow one pod evicts leader.
 	// TODO: set this condition before all leader eviction behavior
 	ConditionTypeLeaderEvicting = "LeaderEvicting"
+
+	// It means whether the service of the component is ready for external access,
+	// it is only set when the DNS records of the service are managed by external-dns.
+	ConditionTypeServiceReady = "ServiceReady"
+
+	// It means whether the PD leader is in the primary data plane,
+	// it is only set when `spec.pd.leaderLocality` is set.
+	ConditionTypeLeaderLocalized = "LeaderLocalized"
+
+	// It means whether the PD microservices serving the TSO and scheduling services have ready members,
+	// it is only set when PD is in the "ms" mode.
+	ConditionTypeServiceModeHealthy = "ServiceModeHealthy"
 )
 
+// TiKVGroupStatus is the status of a TiKV group
+type TiKVGroupStatus struct {
+	// Name of the group
+	Name string `json:"name"`
+	// Cluster is the name of the TidbCluster which deploys the group
+	Cluster string `json:"cluster"`
+	// Phase of the TiKV in the group
+	// +optional
+	Phase MemberPhase `json:"phase,omitempty"`
+	// Replicas is the number of TiKV Pods of the group
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of ready TiKV Pods of the group
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
 // TiKVStatus is TiKV status
 type TiKVStatus struct {
 	Synced          bool                          `json:"synced,omitempty"`
@@ -1438,12 +2575,18 @@ type TiKVStatus struct {
 	FailoverUID     types.UID                     `json:"failoverUID,omitempty"`
 	Image           string                        `json:"image,omitempty"`
 	EvictLeader     map[string]*EvictLeaderStatus `json:"evictLeader,omitempty"`
+	// Summary of the stores if the status is compacted, only the stores which are not up are listed in Stores then.
+	// +optional
+	Summary *MemberSummary `json:"summary,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ImageDigest is the digest which the image of the component is resolved to
+	// +optional
+	ImageDigest *ImageDigest `json:"imageDigest,omitempty"`
 }
 
 // TiFlashStatus is TiFlash status
@@ -1457,12 +2600,29 @@ type TiFlashStatus struct {
 	FailureStores   map[string]TiKVFailureStore `json:"failureStores,omitempty"`
 	FailoverUID     types.UID                   `json:"failoverUID,omitempty"`
 	Image           string                      `json:"image,omitempty"`
+	// Summary of the stores if the status is compacted, only the stores which are not up are listed in Stores then.
+	// +optional
+	Summary *MemberSummary `json:"summary,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ImageDigest is the digest which the image of the component is resolved to
+	// +optional
+	ImageDigest *ImageDigest `json:"imageDigest,omitempty"`
+	// ComputeNode is the status of the compute nodes in the disaggregated storage and compute architecture
+	// +optional
+	ComputeNode *TiFlashComputeNodeStatus `json:"computeNode,omitempty"`
+}
+
+// TiFlashComputeNodeStatus is the status of the TiFlash compute nodes
+type TiFlashComputeNodeStatus struct {
+	Phase       MemberPhase             `json:"phase,omitempty"`
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+	// Stores of the compute nodes, the keys are the store IDs
+	Stores map[string]TiKVStore `json:"stores,omitempty"`
 }
 
 // TiProxyMember is TiProxy member
@@ -1478,6 +2638,18 @@ type TiProxyMember struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
+// PDMSStatus is the status of a PD microservice
+type PDMSStatus struct {
+	Name        string                  `json:"name,omitempty"`
+	Synced      bool                    `json:"synced,omitempty"`
+	Phase       MemberPhase             `json:"phase,omitempty"`
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+	// Members are the advertised URLs of the ready members
+	// +optional
+	Members []string `json:"members,omitempty"`
+	Image   string   `json:"image,omitempty"`
+}
+
 // TiProxyStatus is TiProxy status
 type TiProxyStatus struct {
 	Synced      bool                                       `json:"synced,omitempty"`
@@ -1522,15 +2694,61 @@ type TiKVStore struct {
 	IP          string `json:"ip"`
 	LeaderCount int32  `json:"leaderCount"`
 	State       string `json:"state"`
+	// RegionCount is the region count of the store reported by PD
+	// +optional
+	RegionCount int32 `json:"regionCount,omitempty"`
+	// Capacity is the capacity of the store reported by PD
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Available is the available size of the store reported by PD
+	// +optional
+	Available *resource.Quantity `json:"available,omitempty"`
+	// StartTime is the time when the store is started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// LastHeartbeatTime is the time of the last heartbeat of the store received by PD
+	// +optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
 	// Last time the health transitioned from one to another.
 	// TODO: remove nullable, https://github.com/kubernetes/kubernetes/issues/86811
 	// +nullable
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// ProbeFailures is the number of consecutive times the store is probed down.
+	// +optional
+	ProbeFailures int32 `json:"probeFailures,omitempty"`
 	// LeaderCountBeforeUpgrade records the leader count before upgrade.
 	//
 	// It is set when evicting leader and used to wait for most leaders to transfer back after upgrade.
 	// It is unset after leader transfer is completed.
 	LeaderCountBeforeUpgrade *int32 `json:"leaderCountBeforeUpgrade,omitempty"`
+	// DrainProgress is the progress of migrating regions out of the store when it is offline.
+	// +optional
+	DrainProgress *TiKVStoreDrainProgress `json:"drainProgress,omitempty"`
+	// SlowScore is the slow score of the store reported by PD
+	// +optional
+	SlowScore uint64 `json:"slowScore,omitempty"`
+	// SlowSince is the time since which the slow score is above the threshold of `spec.tikv.slowStore`
+	// +optional
+	SlowSince *metav1.Time `json:"slowSince,omitempty"`
+	// LeaderEvicted indicates that the leaders are evicted from the store as it is slow.
+	// +optional
+	LeaderEvicted bool `json:"leaderEvicted,omitempty"`
+}
+
+// TiKVStoreDrainProgress is the progress of migrating regions out of an offline store
+type TiKVStoreDrainProgress struct {
+	// StartTime is the time when the store began to drain.
+	// +nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// InitialRegionCount is the region count of the store when it began to drain.
+	InitialRegionCount int32 `json:"initialRegionCount"`
+	// RegionCount is the remaining region count of the store.
+	RegionCount int32 `json:"regionCount"`
+	// LeaderCount is the remaining leader count of the store.
+	LeaderCount int32 `json:"leaderCount"`
+	// EstimatedCompletionTime is estimated by the average drain speed since the store began to drain.
+	// +nullable
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
 }
 
 // TiKVFailureStore is the tikv failure store information
@@ -1622,6 +2840,96 @@ type TLSCluster struct {
 	//        Same for other components.
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+
+	// CertManager makes the operator create the cert-manager Certificates of the cluster TLS secrets
+	// instead of requiring the secrets to be created by the users. The certs are renewed by cert-manager
+	// in place, and the renewed certs are reloaded by the components from the mounted secrets.
+	// +optional
+	CertManager *TLSCertManager `json:"certManager,omitempty"`
+
+	// RotationStrategy is how the renewed certs of the cluster TLS secrets are applied to the components.
+	// Auto reloads the certs online for PD, TiKV, TiDB and TiCDC, which reload the certs from the mounted secrets,
+	// and rolls the pods of the other components. Restart rolls the pods of all the components, and None leaves the
+	// renewed certs to be applied by manual restarts. The components are rolled one by one in the upgrade order.
+	// Optional: Defaults to Auto
+	// +optional
+	RotationStrategy TLSRotationStrategy `json:"rotationStrategy,omitempty"`
+}
+
+// TLSRotationStrategy is the strategy to apply the renewed certs of the cluster TLS secrets
+type TLSRotationStrategy string
+
+const (
+	// TLSRotationStrategyAuto reloads the renewed certs online if the component supports it, and restarts the pods otherwise
+	TLSRotationStrategyAuto TLSRotationStrategy = "Auto"
+	// TLSRotationStrategyRestart restarts the pods of all the components to apply the renewed certs
+	TLSRotationStrategyRestart TLSRotationStrategy = "Restart"
+	// TLSRotationStrategyNone does not apply the renewed certs
+	TLSRotationStrategyNone TLSRotationStrategy = "None"
+)
+
+// TLSRotationMethod is how the renewed cert is applied to a component
+type TLSRotationMethod string
+
+const (
+	// TLSRotationMethodReload means the renewed cert is reloaded by the component online
+	TLSRotationMethodReload TLSRotationMethod = "Reload"
+	// TLSRotationMethodRestart means the pods of the component are rolled to apply the renewed cert
+	TLSRotationMethodRestart TLSRotationMethod = "Restart"
+)
+
+// TLSRotationStatus is the status of the rotation of the cluster TLS cert of a component
+type TLSRotationStatus struct {
+	// CertHash is the hash of the cert in the cluster TLS secret of the component which is applied
+	CertHash string `json:"certHash,omitempty"`
+
+	// Method is how the last renewed cert is applied
+	// +optional
+	Method TLSRotationMethod `json:"method,omitempty"`
+
+	// RestartHash is the hash of the cert which the pods are rolled for, it is set to the pod template
+	// annotation tidb.pingcap.com/tls-cert-hash
+	// +optional
+	RestartHash string `json:"restartHash,omitempty"`
+
+	// LastRotationTime is the last time a renewed cert is applied
+	// +optional
+	// +nullable
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// TLSCertManager configures the cert-manager Certificates created for the cluster TLS secrets
+// +k8s:openapi-gen=true
+type TLSCertManager struct {
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer signing the certs
+	IssuerRef TLSCertIssuerRef `json:"issuerRef"`
+
+	// Duration is the validity of the certs.
+	// Optional: Defaults to the default of cert-manager
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before the expiry the certs are renewed.
+	// Optional: Defaults to the default of cert-manager
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// TLSCertIssuerRef references a cert-manager issuer
+// +k8s:openapi-gen=true
+type TLSCertIssuerRef struct {
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Kind of the issuer, Issuer or ClusterIssuer.
+	// Optional: Defaults to Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer.
+	// Optional: Defaults to cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // +genclient
@@ -1772,8 +3080,15 @@ type AzblobStorageProvider struct {
 	// Access tier of the uploaded objects.
 	AccessTier string `json:"accessTier,omitempty"`
 	// SecretName is the name of secret which stores the
-	// azblob service account credentials.
+	// azblob service account credentials, or the sas token
+	// of the storage account in the `AZURE_STORAGE_SAS_TOKEN` key.
 	SecretName string `json:"secretName,omitempty"`
+	// StorageAccount is the storage account of the azure blob storage.
+	// If this field is set, then use this to set backup-manager env,
+	// otherwise retrieve the storage account from secret.
+	// If secretName is not set, the managed identity of the pod is
+	// used to access the storage account.
+	StorageAccount string `json:"storageAccount,omitempty"`
 	// Prefix of the data path.
 	Prefix string `json:"prefix,omitempty"`
 }
@@ -1875,6 +3190,9 @@ type Progress struct {
 	Step string `json:"step,omitempty"`
 	// Progress is the backup progress value
 	Progress float64 `json:"progress,omitempty"`
+	// Speed is the speed of the step reported by br, e.g. `7.5 p/s`
+	// +optional
+	Speed string `json:"speed,omitempty"`
 	// LastTransitionTime is the update time
 	// +nullable
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
@@ -1924,6 +3242,10 @@ type BackupSpec struct {
 	StorageSize string `json:"storageSize,omitempty"`
 	// BRConfig is the configs for BR
 	BR *BRConfig `json:"br,omitempty"`
+	// Encryption is the settings to encrypt the backup data at rest with BR,
+	// it is only valid for snapshot backup.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
 	// CommitTs is the commit ts of the backup, snapshot ts for full backup or start ts for log backup.
 	// Format supports TSO or datetime, e.g. '400036290571534337', '2018-05-11 01:42:23'.
 	// Default is current timestamp.
@@ -1972,6 +3294,116 @@ type BackupSpec struct {
 
 	// BackoffRetryPolicy the backoff retry policy, currently only valid for snapshot backup
 	BackoffRetryPolicy BackoffRetryPolicy `json:"backoffRetryPolicy,omitempty"`
+
+	// Estimate enables estimating the size, duration and cost of the backup before the backup job is created,
+	// it is only valid for snapshot backup with BR.
+	// +optional
+	Estimate *BackupEstimateSpec `json:"estimate,omitempty"`
+
+	// Verify enables verifying the backup by a verification job after the backup completes,
+	// it is only valid for snapshot backup with BR. The result is recorded in the Verified condition.
+	// +optional
+	Verify *BackupVerifySpec `json:"verify,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// BackupEstimateSpec contains the settings to estimate a backup before it starts.
+// The size of the backup is estimated by the data size of the cluster and the ratio of the
+// backup size to the data size of the recent complete backups of the same cluster.
+type BackupEstimateSpec struct {
+	// StorageQuota is the quota of the destination of the backup, i.e. the same bucket or container,
+	// the storage used by the complete backups in the same namespace and destination plus the estimated
+	// size of the backup should not exceed it.
+	// +optional
+	StorageQuota *resource.Quantity `json:"storageQuota,omitempty"`
+	// RejectOnQuotaExceeded denotes whether to fail the backup before it starts if the storage quota would be exceeded,
+	// otherwise only a warning event is recorded.
+	// Defaults to true.
+	// +optional
+	RejectOnQuotaExceeded *bool `json:"rejectOnQuotaExceeded,omitempty"`
+	// PricePerGiBMonth is the price of storing 1 GiB in the destination for a month, e.g. "0.023",
+	// it is used to estimate the monthly cost of the backup.
+	// +optional
+	PricePerGiBMonth string `json:"pricePerGiBMonth,omitempty"`
+}
+
+// BackupEstimate is the estimate of a backup made before the backup job is created.
+type BackupEstimate struct {
+	// ClusterDataSize is the data size of the cluster, i.e. the used size of all the TiKV stores divided by the max replicas.
+	ClusterDataSize int64 `json:"clusterDataSize,omitempty"`
+	// SizeRatio is the average ratio of the backup size to the cluster data size of the recent complete backups,
+	// it reflects the compression and deduplication of the backup data.
+	SizeRatio string `json:"sizeRatio,omitempty"`
+	// Size is the estimated size of the backup.
+	Size int64 `json:"size,omitempty"`
+	// SizeReadable is the estimated size of the backup in human readable format.
+	SizeReadable string `json:"sizeReadable,omitempty"`
+	// Duration is the estimated duration of the backup, in the format of Go Duration.
+	// It is empty if there is no complete backup of the cluster to learn the speed from.
+	Duration string `json:"duration,omitempty"`
+	// MonthlyCost is the estimated monthly cost to store the backup.
+	MonthlyCost string `json:"monthlyCost,omitempty"`
+	// UsedStorage is the size of the complete backups in the same namespace and destination.
+	UsedStorage int64 `json:"usedStorage,omitempty"`
+	// EstimatedTime is the time at which the estimate was made.
+	EstimatedTime metav1.Time `json:"estimatedTime,omitempty"`
+}
+
+// BackupVerifyMode is the way to verify a backup
+type BackupVerifyMode string
+
+const (
+	// BackupVerifyChecksum means the checksums of the backup files are validated against the backup meta by BR
+	BackupVerifyChecksum BackupVerifyMode = "Checksum"
+	// BackupVerifyScratchRestore means the backup, or the selected tables of it, is restored to a scratch cluster
+	BackupVerifyScratchRestore BackupVerifyMode = "ScratchRestore"
+)
+
+// +k8s:openapi-gen=true
+// BackupVerifySpec contains the settings to verify a backup after it completes.
+type BackupVerifySpec struct {
+	// Mode is the way to verify the backup, Checksum or ScratchRestore.
+	// Defaults to Checksum.
+	// +optional
+	Mode BackupVerifyMode `json:"mode,omitempty"`
+	// ScratchCluster is the cluster to restore the backup to in the ScratchRestore mode, it must not be
+	// the cluster being backed up, and the restored tables must not exist in it.
+	// +optional
+	ScratchCluster *TidbClusterRef `json:"scratchCluster,omitempty"`
+	// TableFilter selects the tables to restore in the ScratchRestore mode,
+	// the table filter of the backup is used if it is not set.
+	// +optional
+	TableFilter []string `json:"tableFilter,omitempty"`
+}
+
+// BackupEncryptionMethod is the method to encrypt the backup data
+type BackupEncryptionMethod string
+
+const (
+	BackupEncryptionMethodAES128CTR BackupEncryptionMethod = "aes128-ctr"
+	BackupEncryptionMethodAES192CTR BackupEncryptionMethod = "aes192-ctr"
+	BackupEncryptionMethodAES256CTR BackupEncryptionMethod = "aes256-ctr"
+)
+
+// +k8s:openapi-gen=true
+// BackupEncryption contains the settings to encrypt the backup data at rest.
+// Exactly one of KeySecretRef and MasterKey should be set.
+type BackupEncryption struct {
+	// Method is the method to encrypt the backup data.
+	// Defaults to aes256-ctr.
+	// +kubebuilder:validation:Enum:="aes128-ctr";"aes192-ctr";"aes256-ctr"
+	// +optional
+	Method BackupEncryptionMethod `json:"method,omitempty"`
+	// KeySecretRef refers to the key of a Secret which holds the hex encoded data key,
+	// the length of the key must match the method, e.g. 32 bytes for aes256-ctr.
+	// +optional
+	KeySecretRef *corev1.SecretKeySelector `json:"keySecretRef,omitempty"`
+	// MasterKey is the URI of the KMS master key for envelope encryption, e.g.
+	// `aws-kms:///<key-id>?REGION=<region>` or `gcp-kms:///projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>`.
+	// BR generates the data key and encrypts it by the master key, the credentials to access the KMS
+	// are read from the environment of the job, e.g. the service account.
+	// +optional
+	MasterKey string `json:"masterKey,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -2012,6 +3444,11 @@ type BRConfig struct {
 	SendCredToTikv *bool `json:"sendCredToTikv,omitempty"`
 	// OnLine specifies whether online during restore
 	OnLine *bool `json:"onLine,omitempty"`
+	// Stats specifies whether to back up the statistics of the tables along with the data during backup,
+	// and whether to load the statistics from the backup during restore, so that the optimizer of the
+	// restored cluster does not need to wait for the statistics to be collected again.
+	// It is passed to BR as `--ignore-stats` for backup and `--load-stats` for restore.
+	Stats *bool `json:"stats,omitempty"`
 	// Options means options for backup data to remote storage with BR. These options has highest priority.
 	Options []string `json:"options,omitempty"`
 }
@@ -2080,6 +3517,16 @@ const (
 	BackupStopped BackupConditionType = "Stopped"
 	// BackupRestart means the backup was restarted, now just support snapshot backup
 	BackupRestart BackupConditionType = "Restart"
+	// BackupReady is a standard condition which is true when the backup has completed.
+	// It is derived from the phase and never set as the phase.
+	BackupReady BackupConditionType = "Ready"
+	// BackupProgressing is a standard condition which is true while the backup is being scheduled or executed.
+	BackupProgressing BackupConditionType = "Progressing"
+	// BackupDegraded is a standard condition which is true when the backup has failed or is invalid.
+	BackupDegraded BackupConditionType = "Degraded"
+	// BackupVerified is the result of the verification of a complete backup, it is unknown while the
+	// verification job is running. It never changes the phase.
+	BackupVerified BackupConditionType = "Verified"
 )
 
 // BackupCondition describes the observed state of a Backup at a certain point.
@@ -2159,6 +3606,29 @@ type BackupStatus struct {
 	Progresses []Progress `json:"progresses,omitempty"`
 	// BackoffRetryStatus is status of the backoff retry, it will be used when backup pod or job exited unexpectedly
 	BackoffRetryStatus []BackoffRetryRecord `json:"backoffRetryStatus,omitempty"`
+	// StatsIncluded indicates whether the statistics of the tables are included in the backup.
+	StatsIncluded bool `json:"statsIncluded,omitempty"`
+	// Estimate is the estimate of the backup made before the backup job is created.
+	// +optional
+	Estimate *BackupEstimate `json:"estimate,omitempty"`
+	// TableFilter is the result of resolving spec.tableFilter against the schema of the cluster before running BR,
+	// it is only resolved for the snapshot backup with spec.from.
+	// +optional
+	TableFilter *BackupTableFilterResult `json:"tableFilter,omitempty"`
+}
+
+// BackupTableFilterResult is the result of resolving the table filter of a backup against the schema of the cluster.
+// The backup fails before running BR if the filter matches no table.
+type BackupTableFilterResult struct {
+	// MatchedDatabases is the number of the databases with any table matched by the filter.
+	MatchedDatabases int32 `json:"matchedDatabases"`
+	// MatchedTables is the number of the tables matched by the filter, the system tables are not counted.
+	MatchedTables int32 `json:"matchedTables"`
+	// UnmatchedRules are the include rules of the filter which match no table, which are probably typos.
+	// +optional
+	UnmatchedRules []string `json:"unmatchedRules,omitempty"`
+	// ResolvedTime is the time at which the filter was resolved.
+	ResolvedTime metav1.Time `json:"resolvedTime,omitempty"`
 }
 
 // +genclient
@@ -2213,6 +3683,11 @@ type BackupScheduleSpec struct {
 	BackupTemplate BackupSpec `json:"backupTemplate"`
 	// LogBackupTemplate is the specification of the log backup structure to get scheduled.
 	LogBackupTemplate *BackupSpec `json:"logBackupTemplate"`
+	// LogBackupRetention is to specify how long the cluster could be restored to any time by the log backup.
+	// The log backup is truncated to the last snapshot backup before the window, and the snapshot backups
+	// needed by the window are kept even if they are expired by MaxBackups or MaxReservedTime.
+	// +optional
+	LogBackupRetention *string `json:"logBackupRetention,omitempty"`
 	// The storageClassName of the persistent volume for Backup data storage if not storage class name set in BackupSpec.
 	// Defaults to Kubernetes default storage class.
 	// +optional
@@ -2234,6 +3709,42 @@ type BackupScheduleStatus struct {
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
 	// AllBackupCleanTime represents the time when all backup entries are cleaned up
 	AllBackupCleanTime *metav1.Time `json:"allBackupCleanTime,omitempty"`
+	// RestorablePoints lists all the points the cluster could be restored to by the backups of this schedule,
+	// including the completed snapshot backups and the PITR window of the log backup.
+	// +nullable
+	RestorablePoints []RestorablePoint `json:"restorablePoints,omitempty"`
+}
+
+// RestorablePointType represents the type of a restorable point.
+type RestorablePointType string
+
+const (
+	// RestorablePointTypeSnapshot means the cluster could be restored to the snapshot of a full backup.
+	RestorablePointTypeSnapshot RestorablePointType = "snapshot"
+	// RestorablePointTypePITR means the cluster could be restored to any time within a window
+	// by a full backup and the log backup.
+	RestorablePointTypePITR RestorablePointType = "pitr"
+)
+
+// RestorablePoint is a point or a window of time the cluster could be restored to.
+type RestorablePoint struct {
+	// Type is the type of the restorable point.
+	Type RestorablePointType `json:"type"`
+	// BackupName is the name of the Backup CR, it is the log backup for PITR window.
+	BackupName string `json:"backupName"`
+	// Location is the location of the backup.
+	Location string `json:"location,omitempty"`
+	// BackupSize is the data size of the backup.
+	BackupSize int64 `json:"backupSize,omitempty"`
+	// BackupSizeReadable is the human readable data size of the backup.
+	BackupSizeReadable string `json:"backupSizeReadable,omitempty"`
+	// CommitTs is the snapshot ts of a full backup, or the start ts of the PITR window.
+	CommitTs string `json:"commitTs,omitempty"`
+	// EndTs is the end ts of the PITR window, it is the checkpoint ts of the log backup.
+	EndTs string `json:"endTs,omitempty"`
+	// TimeCompleted is the time at which the full backup was completed.
+	// +nullable
+	TimeCompleted *metav1.Time `json:"timeCompleted,omitempty"`
 }
 
 // +genclient
@@ -2297,6 +3808,12 @@ const (
 	// RestoreDataComplete means the Restore has successfully executed part-2 and the
 	// data in restore volumes has been deal with consistency based on min_resolved_ts
 	RestoreDataComplete RestoreConditionType = "DataComplete"
+	// RestoreSnapshotComplete means the Restore in pitr mode has successfully restored the
+	// full snapshot backup and is replaying the log backup up to the restored ts
+	RestoreSnapshotComplete RestoreConditionType = "SnapshotComplete"
+	// RestoreVerifying means the backup data has been loaded into tidb cluster and
+	// is being verified by the verification job
+	RestoreVerifying RestoreConditionType = "Verifying"
 	// RestoreComplete means the Restore has successfully executed and the
 	// backup data has been loaded into tidb cluster.
 	RestoreComplete RestoreConditionType = "Complete"
@@ -2304,8 +3821,18 @@ const (
 	RestoreFailed RestoreConditionType = "Failed"
 	// RestoreRetryFailed means this failure can be retried
 	RestoreRetryFailed RestoreConditionType = "RetryFailed"
+	// RestoreResuming means the failed restore job is being relaunched to resume from checkpoint.
+	// It is the phase only when it is true.
+	RestoreResuming RestoreConditionType = "Resuming"
 	// RestoreInvalid means invalid restore CR.
 	RestoreInvalid RestoreConditionType = "Invalid"
+	// RestoreReady is a standard condition which is true when the restore has completed.
+	// It is derived from the phase and never set as the phase.
+	RestoreReady RestoreConditionType = "Ready"
+	// RestoreProgressing is a standard condition which is true while the restore is being scheduled or executed.
+	RestoreProgressing RestoreConditionType = "Progressing"
+	// RestoreDegraded is a standard condition which is true when the restore has failed or is invalid.
+	RestoreDegraded RestoreConditionType = "Degraded"
 )
 
 // RestoreCondition describes the observed state of a Restore at a certain point.
@@ -2369,6 +3896,9 @@ type RestoreSpec struct {
 	StorageSize string `json:"storageSize,omitempty"`
 	// BR is the configs for BR.
 	BR *BRConfig `json:"br,omitempty"`
+	// Encryption is the settings to decrypt the encrypted backup data, it must be the same as the backup.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
 	// Base tolerations of restore Pods, components may add more tolerations upon this respectively
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
@@ -2396,6 +3926,96 @@ type RestoreSpec struct {
 
 	// PriorityClassName of Restore Job Pods
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ResumePolicy is the policy to relaunch the restore job after it failed, the relaunched job
+	// resumes the restore from the checkpoint of BR, which requires BR v7.1.0 or later.
+	// It's only valid for snapshot and pitr restore with BR.
+	// The restore is marked as failed directly if it is not set.
+	// +optional
+	ResumePolicy *RestoreResumePolicy `json:"resumePolicy,omitempty"`
+
+	// Source is the cluster which the backup is taken from. If it's different from the target cluster
+	// in `spec.br`, the references to the source cluster in the restore, i.e. the TiDB host in `spec.to`,
+	// the TLS client secret name and the instance label, are remapped to the target cluster. So the restore
+	// can be copied from the backup of the source cluster to clone it into a cluster with a different name
+	// or namespace, e.g. a staging cluster.
+	// It's only valid for restore with BR.
+	// +optional
+	Source *RestoreSource `json:"source,omitempty"`
+
+	// Verification is the way to verify the restored data after the data is restored, a verification job
+	// is launched to verify the restored tables and the restore fails if any of them fails the verification.
+	// It's only valid for snapshot restore with BR, and `spec.to` is required to access the restored tables.
+	// Optional: Defaults to None
+	// +kubebuilder:validation:Enum=None;Checksum;AdminCheck
+	// +optional
+	Verification RestoreVerificationType `json:"verification,omitempty"`
+
+	// ScatterRegions scatters the regions of the restored tables by PD after the data is restored, and the
+	// restore is not completed until the scattering completes, to avoid the hot spot stores after large restores.
+	// It's only valid for snapshot and pitr restore with BR, and `spec.to` is required to find the restored tables.
+	// +optional
+	ScatterRegions *RestoreScatterRegions `json:"scatterRegions,omitempty"`
+}
+
+// RestoreScatterRegions is the config of scattering the regions of the restored tables
+// +k8s:openapi-gen=true
+type RestoreScatterRegions struct {
+	// RetryLimit is the times PD retries to scatter each region.
+	// Optional: Defaults to 5
+	// +optional
+	RetryLimit *int32 `json:"retryLimit,omitempty"`
+	// Timeout is the max duration to wait for the scattering to complete, the restore fails if it's not completed in time.
+	// Optional: Defaults to 30m
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RestoreVerificationType is the way to verify the restored data
+type RestoreVerificationType string
+
+const (
+	// RestoreVerificationNone means the restored data is not verified
+	RestoreVerificationNone RestoreVerificationType = "None"
+	// RestoreVerificationChecksum means the checksums of the restored tables are calculated by
+	// `ADMIN CHECKSUM TABLE` and compared with the checksums recorded in the backup meta
+	RestoreVerificationChecksum RestoreVerificationType = "Checksum"
+	// RestoreVerificationAdminCheck means the consistency between the data and the indices of the
+	// restored tables is checked by `ADMIN CHECK TABLE`
+	RestoreVerificationAdminCheck RestoreVerificationType = "AdminCheck"
+)
+
+// +k8s:openapi-gen=true
+// RestoreSource is the cluster which the backup to restore is taken from.
+type RestoreSource struct {
+	// Cluster is the name of the source cluster
+	Cluster string `json:"cluster"`
+	// ClusterNamespace is the namespace of the source cluster
+	// Optional: Defaults to the namespace of the restore
+	// +optional
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// RestoreResumePolicy is the policy to resume the failed restore from checkpoint.
+type RestoreResumePolicy struct {
+	// MaxRetryTimes is the max times to relaunch the failed restore job
+	// +kubebuilder:default=3
+	MaxRetryTimes int `json:"maxRetryTimes,omitempty"`
+}
+
+// RestoreResumeAttempt is the record of a failed restore job and its relaunch.
+type RestoreResumeAttempt struct {
+	// AttemptNum is the number of the attempt, starts from 1
+	AttemptNum int `json:"attemptNum"`
+	// FailedPod is the name of the failed restore pod
+	FailedPod string `json:"failedPod,omitempty"`
+	// Reason is the reason of the restore job failure
+	Reason string `json:"reason,omitempty"`
+	// DetectFailedAt is the time when the failure is detected
+	DetectFailedAt *metav1.Time `json:"detectFailedAt,omitempty"`
+	// ResumedAt is the time when the restore job is relaunched to resume from checkpoint
+	ResumedAt *metav1.Time `json:"resumedAt,omitempty"`
 }
 
 // RestoreStatus represents the current status of a tidb cluster restore.
@@ -2415,6 +4035,41 @@ type RestoreStatus struct {
 	// Progresses is the progress of restore.
 	// +nullable
 	Progresses []Progress `json:"progresses,omitempty"`
+	// ResumeAttempts records the history of the failed restore jobs which are relaunched to resume from checkpoint.
+	// +nullable
+	ResumeAttempts []RestoreResumeAttempt `json:"resumeAttempts,omitempty"`
+	// Verification is the status of the verification of the restored data.
+	// +optional
+	Verification *RestoreVerificationStatus `json:"verification,omitempty"`
+}
+
+// RestoreVerificationStatus is the status of the verification of the restored data.
+type RestoreVerificationStatus struct {
+	// Type is the way to verify the restored data
+	Type RestoreVerificationType `json:"type"`
+	// TimeStarted is the time at which the verification was started.
+	// +nullable
+	TimeStarted metav1.Time `json:"timeStarted,omitempty"`
+	// TimeCompleted is the time at which the verification was completed.
+	// +nullable
+	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+	// Duration is the time taken by the verification, e.g. `1m30s`
+	Duration string `json:"duration,omitempty"`
+	// TotalTables is the number of the restored tables to verify
+	TotalTables int32 `json:"totalTables,omitempty"`
+	// VerifiedTables is the number of the tables which have been verified
+	VerifiedTables int32 `json:"verifiedTables,omitempty"`
+	// FailedTables are the tables failing the verification
+	// +optional
+	FailedTables []RestoreVerificationFailure `json:"failedTables,omitempty"`
+}
+
+// RestoreVerificationFailure is a restored table failing the verification.
+type RestoreVerificationFailure struct {
+	// Table is the full name of the table, e.g. `db`.`table`
+	Table string `json:"table"`
+	// Message is why the table fails the verification
+	Message string `json:"message"`
 }
 
 // +k8s:openapi-gen=true
@@ -2484,6 +4139,19 @@ type DMDiscoverySpec struct {
 	*ComponentSpec              `json:",inline"`
 	corev1.ResourceRequirements `json:",inline"`
 
+	// The desired replicas of discovery, more than one replica makes discovery run
+	// in stateless mode and use a RollingUpdate strategy.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ProxyDMMaster exposes the API of the DM-master leader under the path `/dm-master` of the proxy
+	// service on port 10262, so the clients don't need to track which DM-master is the leader.
+	// Optional: Defaults to false
+	// +optional
+	ProxyDMMaster bool `json:"proxyDMMaster,omitempty"`
+
 	// (Deprecated) Address indicates the existed TiDB discovery address
 	// +k8s:openapi-gen=false
 	Address string `json:"address,omitempty"`
@@ -2630,6 +4298,10 @@ type DMClusterStatus struct {
 	// +optional
 	// +nullable
 	Conditions []DMClusterCondition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation of the DMCluster observed by tidb-operator,
+	// it is used together with the conditions by the GitOps tools to compute the health.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -2792,6 +4464,15 @@ const (
 	// - All Master members are healthy.
 	// - All Worker pods are up.
 	DMClusterReady DMClusterConditionType = "Ready"
+	// DMClusterProgressing indicates that the dm cluster is being upgraded or scaled, i.e. any
+	// statefulset is not up to date or any component is in the Upgrade or Scale phase.
+	DMClusterProgressing DMClusterConditionType = "Progressing"
+	// DMClusterDegraded indicates that the dm cluster runs with failed members which are
+	// handled by the failover.
+	DMClusterDegraded DMClusterConditionType = "Degraded"
+	// DMClusterDiscoveryReady indicates whether the resources of the discovery service are
+	// reconciled and the discovery is available for the bootstrap of dm-master.
+	DMClusterDiscoveryReady DMClusterConditionType = "DiscoveryReady"
 )
 
 // MasterStatus is dm-master status
@@ -2881,6 +4562,17 @@ type StorageVolume struct {
 	MountPath        string  `json:"mountPath,omitempty"`
 }
 
+// RaftLogVolumeClaim is the dedicated volume for the raft log of TiKV
+// +k8s:openapi-gen=true
+type RaftLogVolumeClaim struct {
+	// StorageClassName is the storage class of the volume.
+	// Defaults to spec.tikv.storageClassName
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// StorageSize is the request size of the volume
+	StorageSize string `json:"storageSize"`
+}
+
 type ObservedStorageVolumeStatus struct {
 	// BoundCount is the count of bound volumes.
 	// +optional
@@ -2946,6 +4638,29 @@ type Failover struct {
 	// it takes effect only when set `spec.recoverFailover=false`
 	// +optional
 	RecoverByUID types.UID `json:"recoverByUID,omitempty"`
+	// Detection is the configurations of detecting the failure of a member
+	// +optional
+	Detection *FailoverDetection `json:"detection,omitempty"`
+}
+
+// FailoverDetection contains the configurations of detecting the failure of a member.
+// +k8s:openapi-gen=true
+type FailoverDetection struct {
+	// Period is how long a member must be unhealthy before it's marked as failed.
+	// Optional: Defaults to the failover period of the component set in tidb-controller-manager
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// ProbeFailureThreshold is how many consecutive times a member must be probed unhealthy
+	// before it's marked as failed, in addition to Period.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProbeFailureThreshold *int32 `json:"probeFailureThreshold,omitempty"`
+	// PodPhaseAsEvidence indicates that a member is marked as failed only if its pod is not running
+	// or not ready as well, so that the members reported unhealthy while their pods are running well,
+	// e.g. under heavy load, are not failed over.
+	// +optional
+	PodPhaseAsEvidence bool `json:"podPhaseAsEvidence,omitempty"`
 }
 
 type ScalePolicy struct {
@@ -2959,3 +4674,216 @@ type ScalePolicy struct {
 	// +optional
 	ScaleOutParallelism *int32 `json:"scaleOutParallelism,omitempty"`
 }
+
+// PodDisruptionBudgetSpec describes the PodDisruptionBudget created for the pods of a component
+// +k8s:openapi-gen=true
+type PodDisruptionBudgetSpec struct {
+	// MaxUnavailable is the max number of pods that can be unavailable during a voluntary disruption.
+	// Only one of MaxUnavailable and MinAvailable can be set.
+	// Optional: Defaults to the value computed from the quorum of the component if MinAvailable is not set
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MinAvailable is the min number of pods that must be available during a voluntary disruption.
+	// Only one of MaxUnavailable and MinAvailable can be set.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// TiKVIOTuning is the frequently tuned IO rate limit and background jobs of TiKV, which can be
+// changed online. They override the same items in `spec.tikv.config` once TiKV is running.
+// +k8s:openapi-gen=true
+type TiKVIOTuning struct {
+	// RateBytesPerSec is `rocksdb.rate-bytes-per-sec`, the rate limit of the RocksDB compaction and flush, e.g. "100MB"
+	// +optional
+	RateBytesPerSec *string `json:"rateBytesPerSec,omitempty"`
+
+	// IORateLimitMaxBytesPerSec is `storage.io-rate-limit.max-bytes-per-sec`, the rate limit of the disk IO
+	// including the backup and import, e.g. "500MB"
+	// +optional
+	IORateLimitMaxBytesPerSec *string `json:"ioRateLimitMaxBytesPerSec,omitempty"`
+
+	// MaxBackgroundJobs is `rocksdb.max-background-jobs`, the number of the background compaction and flush threads
+	// +optional
+	MaxBackgroundJobs *int64 `json:"maxBackgroundJobs,omitempty"`
+
+	// MaxSubCompactions is `rocksdb.max-sub-compactions`, the number of the sub-compactions of a compaction
+	// +optional
+	MaxSubCompactions *int64 `json:"maxSubCompactions,omitempty"`
+
+	// BackupNumThreads is `backup.num-threads`, the number of the threads used by backup
+	// +optional
+	BackupNumThreads *int64 `json:"backupNumThreads,omitempty"`
+
+	// ImportNumThreads is `import.num-threads`, the number of the threads used by import and restore
+	// +optional
+	ImportNumThreads *int64 `json:"importNumThreads,omitempty"`
+}
+
+// RollingUpdateStrategy describes the partitioned rolling update of a component
+// +k8s:openapi-gen=true
+type RollingUpdateStrategy struct {
+	// Canary is the number of pods upgraded before the rolling update pauses, the pods are upgraded
+	// from the highest ordinal. The rolling update continues after the annotation
+	// `tidb.pingcap.com/<component>-canary-promoted` of the TidbCluster is set to the update revision
+	// of the component StatefulSet, i.e. `status.<component>.statefulSet.updateRevision`.
+	// The component stays in the Upgrade phase while the rolling update pauses.
+	// Optional: Defaults to nil, which means the rolling update does not pause
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Canary *int32 `json:"canary,omitempty"`
+}
+
+// StatusCompaction describes how to compact the status of very large clusters
+// +k8s:openapi-gen=true
+type StatusCompaction struct {
+	// MemberThreshold is the min number of stores or members of TiKV, TiFlash and TiDB to compact the status.
+	// The stores and members are summarized in the summary of the component, only the unhealthy ones are
+	// kept in the status and the healthy ones are moved to the companion ConfigMap named `<cluster>-full-status`.
+	// +kubebuilder:default=50
+	// +optional
+	MemberThreshold *int32 `json:"memberThreshold,omitempty"`
+}
+
+// NotificationSinkType is the type of a notification sink
+type NotificationSinkType string
+
+const (
+	// NotificationSinkTypeSlack posts the notifications to a Slack incoming webhook
+	NotificationSinkTypeSlack NotificationSinkType = "Slack"
+	// NotificationSinkTypePagerDuty triggers PagerDuty incidents by the Events API v2
+	NotificationSinkTypePagerDuty NotificationSinkType = "PagerDuty"
+	// NotificationSinkTypeWebhook posts the events in JSON to a generic webhook
+	NotificationSinkTypeWebhook NotificationSinkType = "Webhook"
+)
+
+// NotificationSpec describes the external sinks which the events of a cluster are forwarded to
+// +k8s:openapi-gen=true
+type NotificationSpec struct {
+	// Sinks are the external sinks which the events are forwarded to.
+	// +optional
+	Sinks []NotificationSink `json:"sinks,omitempty"`
+}
+
+// NotificationSink describes an external sink of the events, e.g. Slack, PagerDuty or a generic webhook
+// +k8s:openapi-gen=true
+type NotificationSink struct {
+	// Name of the sink, it must be unique among the sinks.
+	Name string `json:"name"`
+
+	// Type of the sink.
+	// +kubebuilder:validation:Enum:="Slack";"PagerDuty";"Webhook"
+	Type NotificationSinkType `json:"type"`
+
+	// URL is the Slack incoming webhook URL or the URL of the generic webhook.
+	// The PagerDuty Events API v2 endpoint is used for PagerDuty if it is not set.
+	// For the sinks of a TidbCluster, the host of the URL must be the Slack or PagerDuty endpoint,
+	// or be listed in the key `allowedHosts` of the notification ConfigMap of tidb-operator.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SecretRef refers to the key of a Secret which contains the URL of Slack and generic webhooks
+	// or the routing key of PagerDuty, it takes precedence over URL for Slack and generic webhooks.
+	// The Secret is in the namespace of the TidbCluster, or the namespace of the ConfigMap for the
+	// global sinks of tidb-operator.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// Reasons are the reasons of the events to forward, e.g. `PDMemberDeleted` or `UpgradeFinished`.
+	// All the Warning events are forwarded if it is empty.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Template is the Go template of the notification message, the fields `.Namespace`, `.Cluster`,
+	// `.Kind`, `.Name`, `.Type`, `.Reason` and `.Message` of the event can be referred.
+	// Optional: Defaults to `[{{.Type}}] {{.Kind}} {{.Namespace}}/{{.Name}} {{.Reason}}: {{.Message}}`
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// MinInterval is the min interval between two notifications of the same reason of a cluster,
+	// the events in the interval are dropped.
+	// Optional: Defaults to 5m
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+}
+
+// MemberSummary is the summary of the stores or members of a component whose status is compacted
+type MemberSummary struct {
+	// Total is the number of all stores or members.
+	Total int32 `json:"total"`
+	// Healthy is the number of healthy stores or members.
+	Healthy int32 `json:"healthy"`
+	// Unhealthy is the sorted IDs of the stores or names of the members which are not healthy.
+	// +optional
+	Unhealthy []string `json:"unhealthy,omitempty"`
+}
+
+// GCTuningPolicy describes the windows in which the GC settings of TiKV are adjusted.
+// The settings are applied by the global system variables of TiDB when a window starts,
+// and the settings before the window are restored after the window.
+// +k8s:openapi-gen=true
+type GCTuningPolicy struct {
+	// Windows are the scheduled windows of the GC settings.
+	// The first active one takes effect if the windows overlap.
+	Windows []GCTuningWindow `json:"windows"`
+
+	// PasswordSecret is the name of the Secret which contains the password of the root user
+	// of TiDB in the `root` key. The password is empty if it is not set.
+	// +optional
+	PasswordSecret *string `json:"passwordSecret,omitempty"`
+}
+
+// GCTuningWindow is a scheduled window of the GC settings
+// +k8s:openapi-gen=true
+type GCTuningWindow struct {
+	// Name of the window, it is shown in the status when the window is active
+	Name string `json:"name"`
+
+	// Schedule is the cron expression of the start of the window, e.g. `0 1 * * *`.
+	// It is in the time zone of the cluster.
+	Schedule string `json:"schedule"`
+
+	// Duration of the window, e.g. `4h`
+	Duration metav1.Duration `json:"duration"`
+
+	// Settings are applied in the window
+	GCTuningSettings `json:",inline"`
+}
+
+// GCTuningSettings are the GC settings of TiKV, the unset ones are not changed.
+// +k8s:openapi-gen=true
+type GCTuningSettings struct {
+	// GCLifeTime is the value of `tidb_gc_life_time`, which is the retention of the
+	// old versions of data, e.g. `10m`. It must be no less than 10m.
+	// +optional
+	GCLifeTime *string `json:"gcLifeTime,omitempty"`
+
+	// GCConcurrency is the value of `tidb_gc_concurrency`, which is the number of the
+	// concurrent GC threads in the resolve locks step. -1 means it is decided by the
+	// number of TiKV stores.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Maximum=256
+	// +optional
+	GCConcurrency *int32 `json:"gcConcurrency,omitempty"`
+}
+
+// GCTuningStatus is the status of the GC tuning policy
+type GCTuningStatus struct {
+	// ActiveWindow is the name of the window whose settings are applied
+	// +optional
+	ActiveWindow string `json:"activeWindow,omitempty"`
+
+	// WindowEnd is the end time of the active window
+	// +optional
+	// +nullable
+	WindowEnd *metav1.Time `json:"windowEnd,omitempty"`
+
+	// OriginalSettings are the settings before the window, which are restored after the window
+	// +optional
+	OriginalSettings *GCTuningSettings `json:"originalSettings,omitempty"`
+
+	// LastTransitionTime is the last time a window is entered or left
+	// +optional
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
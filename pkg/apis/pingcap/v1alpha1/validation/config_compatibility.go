@@ -0,0 +1,168 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+)
+
+// configKeyChange is a change of a config key in a version of the component
+type configKeyChange struct {
+	key string
+	// version is the first version with the change
+	version string
+	// removed means the key is not supported any more, otherwise the key is deprecated and still accepted
+	removed bool
+	// replacement is the config key or the system variable replacing the key
+	replacement string
+}
+
+var pdConfigKeyChanges = []configKeyChange{
+	{key: "namespace", version: "v4.0.0", removed: true},
+	{key: "namespace-classifier", version: "v4.0.0", removed: true},
+	{key: "schedule.disable-remove-down-replica", version: "v4.0.0", replacement: "schedule.enable-remove-down-replica"},
+	{key: "schedule.disable-replace-offline-replica", version: "v4.0.0", replacement: "schedule.enable-replace-offline-replica"},
+	{key: "schedule.disable-make-up-replica", version: "v4.0.0", replacement: "schedule.enable-make-up-replica"},
+	{key: "schedule.disable-remove-extra-replica", version: "v4.0.0", replacement: "schedule.enable-remove-extra-replica"},
+	{key: "schedule.disable-location-replacement", version: "v4.0.0", replacement: "schedule.enable-location-replacement"},
+}
+
+var tikvConfigKeyChanges = []configKeyChange{
+	{key: "server.end-point-concurrency", version: "v3.0.0", removed: true, replacement: "readpool.coprocessor"},
+	{key: "raftstore.sync-log", version: "v5.0.0"},
+	{key: "log-level", version: "v5.4.0", replacement: "log.level"},
+	{key: "log-format", version: "v5.4.0", replacement: "log.format"},
+	{key: "log-file", version: "v5.4.0", replacement: "log.file.filename"},
+	{key: "log-rotation-size", version: "v5.4.0", replacement: "log.file.max-size"},
+	{key: "log-rotation-timespan", version: "v5.4.0", replacement: "log.file.max-days"},
+	{key: "storage.block-cache.shared", version: "v6.6.0"},
+}
+
+var tidbConfigKeyChanges = []configKeyChange{
+	{key: "log.file.log-rotate", version: "v4.0.0", removed: true},
+	{key: "alter-primary-key", version: "v5.0.0", replacement: "the clustered index"},
+	{key: "mem-quota-query", version: "v6.1.0", replacement: "system variable tidb_mem_quota_query"},
+	{key: "oom-action", version: "v6.1.0", replacement: "system variable tidb_mem_oom_action"},
+	{key: "prepared-plan-cache.enabled", version: "v6.1.0", replacement: "system variable tidb_enable_prepared_plan_cache"},
+	{key: "check-mb4-value-in-utf8", version: "v6.1.0", replacement: "instance.tidb_check_mb4_value_in_utf8"},
+	{key: "enable-collect-execution-info", version: "v6.1.0", replacement: "instance.tidb_enable_collect_execution_info"},
+	{key: "log.enable-slow-log", version: "v6.1.0", replacement: "instance.tidb_enable_slow_log"},
+	{key: "log.slow-threshold", version: "v6.1.0", replacement: "instance.tidb_slow_log_threshold"},
+	{key: "log.record-plan-in-slow-log", version: "v6.1.0", replacement: "instance.tidb_record_plan_in_slow_log"},
+	{key: "log.expensive-threshold", version: "v6.1.0", replacement: "instance.tidb_expensive_query_time_threshold"},
+	{key: "performance.force-priority", version: "v6.1.0", replacement: "instance.tidb_force_priority"},
+	{key: "plugin.load", version: "v6.1.0", replacement: "instance.plugin_load"},
+	{key: "plugin.dir", version: "v6.1.0", replacement: "instance.plugin_dir"},
+}
+
+// the known top level keys of the config, the other keys are probably typos
+var (
+	pdConfigTopLevelKeys = sets.NewString(
+		"name", "data-dir", "client-urls", "peer-urls", "advertise-client-urls", "advertise-peer-urls",
+		"initial-cluster", "initial-cluster-state", "initial-cluster-token", "join", "force-new-cluster",
+		"lease", "log", "log-file", "log-level", "tso-save-interval", "tso-update-physical-interval", "enable-local-tso",
+		"enable-grpc-gateway", "enable-dynamic-config", "enable-prevote", "quota-backend-bytes", "auto-compaction-mode",
+		"auto-compaction-retention", "auto-compaction-retention-v2", "tick-interval", "election-interval", "max-request-bytes",
+		"disable-strict-reconfig-check", "cluster-version", "labels", "metric", "schedule", "replication", "pd-server",
+		"security", "label-property", "dashboard", "replication-mode", "keyspace", "controller", "micro-service",
+		"namespace", "namespace-classifier",
+	)
+	tikvConfigTopLevelKeys = sets.NewString(
+		"log-level", "log-file", "log-format", "log-rotation-timespan", "log-rotation-size", "slow-log-file",
+		"slow-log-threshold", "panic-when-unexpected-key-or-data", "abort-on-panic", "memory-usage-limit",
+		"memory-usage-high-water", "enable-io-snoop", "log", "memory", "quota", "readpool", "server", "storage", "pd",
+		"metric", "raftstore", "coprocessor", "coprocessor-v2", "rocksdb", "raftdb", "raft-engine", "security", "import",
+		"backup", "log-backup", "backup-stream", "pessimistic-txn", "gc", "split", "cdc", "resolved-ts",
+		"resource-metering", "causal-ts", "resource-control", "in-memory-engine",
+	)
+	tidbConfigTopLevelKeys = sets.NewString(
+		"host", "advertise-address", "port", "cors", "store", "path", "socket", "lease", "split-table", "token-limit",
+		"temp-dir", "tmp-storage-path", "tmp-storage-quota", "server-memory-quota", "oom-use-tmp-storage", "oom-action",
+		"mem-quota-query", "enable-batch-dml", "lower-case-table-names", "compatible-kill-query", "check-mb4-value-in-utf8",
+		"treat-old-version-utf8-as-utf8mb4", "max-index-length", "index-limit", "table-column-count-limit",
+		"graceful-wait-before-shutdown", "enable-table-lock", "delay-clean-table-lock", "split-region-max-num",
+		"max-server-connections", "new_collations_enabled_on_first_bootstrap", "enable-telemetry",
+		"deprecate-integer-display-length", "enable-enum-length-limit", "stores-refresh-interval", "enable-tcp4-only",
+		"enable-forwarding", "enable-global-kill", "enable-32bits-connection-id", "initialize-sql-file",
+		"enable-dynamic-config", "alter-primary-key", "repair-mode", "repair-table-list", "skip-register-to-dashboard",
+		"enable-collect-execution-info", "run-ddl", "version-comment", "server-version", "keyspace-name",
+		"disaggregated-tiflash", "is-tiflashcompute-fixed-pool", "autoscaler-type", "autoscaler-addr",
+		"autoscaler-cluster-id", "use-autoscaler", "tidb-max-reuse-chunk", "tidb-max-reuse-column",
+		"tidb-enable-exit-check", "in-mem-slow-query-topn-num", "in-mem-slow-query-recent-num", "labels",
+		"log", "instance", "security", "status", "performance", "prepared-plan-cache", "opentracing", "proxy-protocol",
+		"tikv-client", "binlog", "plugin", "pessimistic-txn", "stmt-summary", "isolation-read", "experimental",
+		"top-sql", "txn-local-latches",
+	)
+)
+
+// validateConfigCompatibility validates the config keys against the version of the component. The keys removed
+// in the version are rejected because the component can not run with them as expected, and the deprecated and
+// unknown keys are reported by the warnings. The version based checks are skipped if the version is not a
+// semantic version, e.g. latest or nightly.
+func validateConfigCompatibility(cfg *config.GenericConfig, version string, changes []configKeyChange, topLevelKeys sets.String,
+	fldPath *field.Path) ([]metav1.StatusCause, []metav1.StatusCause) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var causes, warnings []metav1.StatusCause
+
+	keys := make([]string, 0, len(cfg.Inner()))
+	for key := range cfg.Inner() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !topLevelKeys.Has(key) {
+			warnings = append(warnings, metav1.StatusCause{
+				Type:    ReasonConfigKeyUnknown,
+				Field:   fldPath.Child(key).String(),
+				Message: fmt.Sprintf("unknown config key %q, which may be a typo", key),
+			})
+		}
+	}
+
+	ver, err := semver.NewVersion(version)
+	if err != nil {
+		return causes, warnings
+	}
+	for _, c := range changes {
+		if cfg.Get(c.key) == nil || ver.LessThan(semver.MustParse(c.version)) {
+			continue
+		}
+		msg := fmt.Sprintf("config key %q is deprecated since %s", c.key, c.version)
+		if c.removed {
+			msg = fmt.Sprintf("config key %q is removed since %s", c.key, c.version)
+		}
+		if c.replacement != "" {
+			msg += fmt.Sprintf(", use %s instead", c.replacement)
+		}
+		cause := metav1.StatusCause{Field: fldPath.Child(c.key).String(), Message: msg}
+		if c.removed {
+			cause.Type = ReasonConfigKeyRemoved
+			causes = append(causes, cause)
+		} else {
+			cause.Type = ReasonConfigKeyDeprecated
+			warnings = append(warnings, cause)
+		}
+	}
+	return causes, warnings
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// The machine-readable reasons of the dangerous transitions of TidbCluster, they are
+// set as the type of the causes of the rejected requests and the prefix of the warnings.
+const (
+	// ReasonPDReplicasBelowQuorum means the PD replicas are shrunk below the quorum of the current members
+	ReasonPDReplicasBelowQuorum metav1.CauseType = "PDReplicasBelowQuorum"
+	// ReasonStorageSizeShrunk means the storage request is shrunk, which is not supported by PVCs
+	ReasonStorageSizeShrunk metav1.CauseType = "StorageSizeShrunk"
+	// ReasonStorageSizeExpanded means the storage request is expanded, which requires the volume expansion of the storage class
+	ReasonStorageSizeExpanded metav1.CauseType = "StorageSizeExpanded"
+	// ReasonConfigRequiresRestart means the config is changed with the InPlace config update strategy,
+	// which does not take effect until the pods are restarted
+	ReasonConfigRequiresRestart metav1.CauseType = "ConfigRequiresRestart"
+	// ReasonRaftLogVolumeChanged means the dedicated raft log volume of TiKV is added or removed,
+	// which requires the raft log to be migrated
+	ReasonRaftLogVolumeChanged metav1.CauseType = "RaftLogVolumeChanged"
+	// ReasonConfigKeyRemoved means the config contains a key removed in the version of the component
+	ReasonConfigKeyRemoved metav1.CauseType = "ConfigKeyRemoved"
+	// ReasonConfigKeyDeprecated means the config contains a key deprecated in the version of the component
+	ReasonConfigKeyDeprecated metav1.CauseType = "ConfigKeyDeprecated"
+	// ReasonConfigKeyUnknown means the config contains a top level key unknown to the component, which may be a typo
+	ReasonConfigKeyUnknown metav1.CauseType = "ConfigKeyUnknown"
+)
+
+// ValidateTidbClusterTransition validates the transition from the old TidbCluster to the new one, it returns
+// the causes to reject the update and the warnings of the transitions which are allowed but may not work as expected.
+func ValidateTidbClusterTransition(old, tc *v1alpha1.TidbCluster) ([]metav1.StatusCause, []string) {
+	var causes, warnings []metav1.StatusCause
+	spec := field.NewPath("spec")
+
+	if old.Spec.PD != nil && tc.Spec.PD != nil {
+		// PD may lose the quorum if more than half of the members are removed
+		quorum := old.Spec.PD.Replicas/2 + 1
+		if tc.Spec.PD.Replicas > 0 && tc.Spec.PD.Replicas < quorum {
+			causes = append(causes, metav1.StatusCause{
+				Type:  ReasonPDReplicasBelowQuorum,
+				Field: spec.Child("pd", "replicas").String(),
+				Message: fmt.Sprintf("shrinking PD replicas from %d to %d is below the quorum %d, scale in PD step by step instead",
+					old.Spec.PD.Replicas, tc.Spec.PD.Replicas, quorum),
+			})
+		}
+		c, w := validateStorageTransition(old.Spec.PD.Requests, tc.Spec.PD.Requests, spec.Child("pd", "requests", "storage"))
+		causes, warnings = append(causes, c...), append(warnings, w...)
+	}
+	if old.Spec.TiKV != nil && tc.Spec.TiKV != nil {
+		c, w := validateStorageTransition(old.Spec.TiKV.Requests, tc.Spec.TiKV.Requests, spec.Child("tikv", "requests", "storage"))
+		causes, warnings = append(causes, c...), append(warnings, w...)
+		c, w = validateRaftLogVolumeTransition(old.Spec.TiKV.RaftLogVolumeClaim, tc.Spec.TiKV.RaftLogVolumeClaim, spec.Child("tikv", "raftLogVolumeClaim"))
+		causes, warnings = append(causes, c...), append(warnings, w...)
+	}
+
+	// the components do not reload the config file, so the config updated in place takes effect after restart
+	type configTransition struct {
+		name     string
+		accessor v1alpha1.ComponentAccessor
+		changed  bool
+	}
+	transitions := []configTransition{
+		{"pd", tc.BasePDSpec(), old.Spec.PD != nil && tc.Spec.PD != nil && !apiequality.Semantic.DeepEqual(old.Spec.PD.Config, tc.Spec.PD.Config)},
+		{"tikv", tc.BaseTiKVSpec(), old.Spec.TiKV != nil && tc.Spec.TiKV != nil && !apiequality.Semantic.DeepEqual(old.Spec.TiKV.Config, tc.Spec.TiKV.Config)},
+		{"tidb", tc.BaseTiDBSpec(), old.Spec.TiDB != nil && tc.Spec.TiDB != nil && !apiequality.Semantic.DeepEqual(old.Spec.TiDB.Config, tc.Spec.TiDB.Config)},
+		{"tiflash", tc.BaseTiFlashSpec(), old.Spec.TiFlash != nil && tc.Spec.TiFlash != nil && !apiequality.Semantic.DeepEqual(old.Spec.TiFlash.Config, tc.Spec.TiFlash.Config)},
+		{"ticdc", tc.BaseTiCDCSpec(), old.Spec.TiCDC != nil && tc.Spec.TiCDC != nil && !apiequality.Semantic.DeepEqual(old.Spec.TiCDC.Config, tc.Spec.TiCDC.Config)},
+		{"pump", tc.BasePumpSpec(), old.Spec.Pump != nil && tc.Spec.Pump != nil && !apiequality.Semantic.DeepEqual(old.Spec.Pump.Config, tc.Spec.Pump.Config)},
+	}
+	for _, t := range transitions {
+		if !t.changed || t.accessor.ConfigUpdateStrategy() != v1alpha1.ConfigUpdateStrategyInPlace {
+			continue
+		}
+		warnings = append(warnings, metav1.StatusCause{
+			Type:    ReasonConfigRequiresRestart,
+			Field:   spec.Child(t.name, "config").String(),
+			Message: "the config is updated in place and takes effect only after the pods are restarted, set configUpdateStrategy to RollingUpdate to restart them automatically",
+		})
+	}
+
+	// the config is validated against the version when either of them is changed, so that a typo or a key
+	// removed in the new version is found before the pods are rolled and crash
+	type configCompatibility struct {
+		name         string
+		changed      bool
+		config       *config.GenericConfig
+		version      string
+		changes      []configKeyChange
+		topLevelKeys sets.String
+	}
+	compatibilities := []configCompatibility{}
+	if old.Spec.PD != nil && tc.Spec.PD != nil && tc.Spec.PD.Config != nil {
+		compatibilities = append(compatibilities, configCompatibility{"pd",
+			old.PDVersion() != tc.PDVersion() || !apiequality.Semantic.DeepEqual(old.Spec.PD.Config, tc.Spec.PD.Config),
+			tc.Spec.PD.Config.GenericConfig, tc.PDVersion(), pdConfigKeyChanges, pdConfigTopLevelKeys})
+	}
+	if old.Spec.TiKV != nil && tc.Spec.TiKV != nil && tc.Spec.TiKV.Config != nil {
+		compatibilities = append(compatibilities, configCompatibility{"tikv",
+			old.TiKVVersion() != tc.TiKVVersion() || !apiequality.Semantic.DeepEqual(old.Spec.TiKV.Config, tc.Spec.TiKV.Config),
+			tc.Spec.TiKV.Config.GenericConfig, tc.TiKVVersion(), tikvConfigKeyChanges, tikvConfigTopLevelKeys})
+	}
+	if old.Spec.TiDB != nil && tc.Spec.TiDB != nil && tc.Spec.TiDB.Config != nil {
+		compatibilities = append(compatibilities, configCompatibility{"tidb",
+			old.TiDBVersion() != tc.TiDBVersion() || !apiequality.Semantic.DeepEqual(old.Spec.TiDB.Config, tc.Spec.TiDB.Config),
+			tc.Spec.TiDB.Config.GenericConfig, tc.TiDBVersion(), tidbConfigKeyChanges, tidbConfigTopLevelKeys})
+	}
+	for _, c := range compatibilities {
+		if !c.changed {
+			continue
+		}
+		cs, ws := validateConfigCompatibility(c.config, c.version, c.changes, c.topLevelKeys, spec.Child(c.name, "config"))
+		causes, warnings = append(causes, cs...), append(warnings, ws...)
+	}
+
+	msgs := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		msgs = append(msgs, fmt.Sprintf("%s: %s: %s", w.Type, w.Field, w.Message))
+	}
+	return causes, msgs
+}
+
+// validateStorageTransition returns the causes to reject and the warnings of the change of the storage request
+func validateStorageTransition(oldRequests, requests corev1.ResourceList, fldPath *field.Path) ([]metav1.StatusCause, []metav1.StatusCause) {
+	oldSize, oldOk := oldRequests[corev1.ResourceStorage]
+	newSize, newOk := requests[corev1.ResourceStorage]
+	if !oldOk || !newOk {
+		return nil, nil
+	}
+	switch newSize.Cmp(oldSize) {
+	case -1:
+		return []metav1.StatusCause{{
+			Type:    ReasonStorageSizeShrunk,
+			Field:   fldPath.String(),
+			Message: fmt.Sprintf("storage can not be shrunk from %s to %s", oldSize.String(), newSize.String()),
+		}}, nil
+	case 1:
+		return nil, []metav1.StatusCause{{
+			Type:    ReasonStorageSizeExpanded,
+			Field:   fldPath.String(),
+			Message: fmt.Sprintf("storage is expanded from %s to %s, which requires the storage class to allow volume expansion", oldSize.String(), newSize.String()),
+		}}
+	}
+	return nil, nil
+}
+
+// validateRaftLogVolumeTransition returns the causes to reject and the warnings of the change of the raft log volume
+func validateRaftLogVolumeTransition(old, claim *v1alpha1.RaftLogVolumeClaim, fldPath *field.Path) ([]metav1.StatusCause, []metav1.StatusCause) {
+	if old == nil && claim == nil {
+		return nil, nil
+	}
+	if old == nil || claim == nil {
+		return []metav1.StatusCause{{
+			Type:    ReasonRaftLogVolumeChanged,
+			Field:   fldPath.String(),
+			Message: "raftLogVolumeClaim can not be added to or removed from an existing cluster because the raft log is not migrated",
+		}}, nil
+	}
+	oldSize, err := resource.ParseQuantity(old.StorageSize)
+	if err != nil {
+		return nil, nil
+	}
+	size, err := resource.ParseQuantity(claim.StorageSize)
+	if err != nil {
+		return nil, nil
+	}
+	return validateStorageTransition(
+		corev1.ResourceList{corev1.ResourceStorage: oldSize},
+		corev1.ResourceList{corev1.ResourceStorage: size},
+		fldPath.Child("storageSize"))
+}
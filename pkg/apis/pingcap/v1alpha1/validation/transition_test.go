@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateTidbClusterTransition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTc := func() *v1alpha1.TidbCluster {
+		tc := newTidbCluster()
+		tc.Spec.PD.Replicas = 5
+		tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+		tc.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
+		tc.Spec.TiKV.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}
+		return tc
+	}
+
+	tests := []struct {
+		name           string
+		update         func(tc *v1alpha1.TidbCluster)
+		expectCauses   []metav1.CauseType
+		expectWarnings []metav1.CauseType
+	}{
+		{
+			name:   "nothing changed",
+			update: func(tc *v1alpha1.TidbCluster) {},
+		},
+		{
+			name:   "scale in PD above the quorum",
+			update: func(tc *v1alpha1.TidbCluster) { tc.Spec.PD.Replicas = 3 },
+		},
+		{
+			name:         "scale in PD below the quorum",
+			update:       func(tc *v1alpha1.TidbCluster) { tc.Spec.PD.Replicas = 2 },
+			expectCauses: []metav1.CauseType{ReasonPDReplicasBelowQuorum},
+		},
+		{
+			name: "shrink TiKV storage",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Requests[corev1.ResourceStorage] = resource.MustParse("50Gi")
+			},
+			expectCauses: []metav1.CauseType{ReasonStorageSizeShrunk},
+		},
+		{
+			name: "expand TiKV storage",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Requests[corev1.ResourceStorage] = resource.MustParse("200Gi")
+			},
+			expectWarnings: []metav1.CauseType{ReasonStorageSizeExpanded},
+		},
+		{
+			name: "add TiKV raft log volume",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.RaftLogVolumeClaim = &v1alpha1.RaftLogVolumeClaim{StorageSize: "50Gi"}
+			},
+			expectCauses: []metav1.CauseType{ReasonRaftLogVolumeChanged},
+		},
+		{
+			name: "change TiKV config in place",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.Config.Set("log-level", "info")
+			},
+			expectWarnings: []metav1.CauseType{ReasonConfigRequiresRestart},
+		},
+		{
+			name: "change TiKV config with rolling update",
+			update: func(tc *v1alpha1.TidbCluster) {
+				strategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+				tc.Spec.TiKV.ConfigUpdateStrategy = &strategy
+				tc.Spec.TiKV.Config.Set("log-level", "info")
+			},
+		},
+		{
+			name: "typo in TiDB config",
+			update: func(tc *v1alpha1.TidbCluster) {
+				strategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+				tc.Spec.TiDB.ConfigUpdateStrategy = &strategy
+				tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+				tc.Spec.TiDB.Config.Set("performence.max-procs", 8)
+			},
+			expectWarnings: []metav1.CauseType{ReasonConfigKeyUnknown},
+		},
+		{
+			name: "upgrade TiKV with deprecated config",
+			update: func(tc *v1alpha1.TidbCluster) {
+				strategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+				tc.Spec.TiKV.ConfigUpdateStrategy = &strategy
+				tc.Spec.TiKV.BaseImage = "pingcap/tikv"
+				tc.Spec.Version = "v5.4.0"
+				tc.Spec.TiKV.Config.Set("log-level", "info")
+			},
+			expectWarnings: []metav1.CauseType{ReasonConfigKeyDeprecated},
+		},
+		{
+			name: "upgrade PD with removed config",
+			update: func(tc *v1alpha1.TidbCluster) {
+				strategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+				tc.Spec.PD.ConfigUpdateStrategy = &strategy
+				tc.Spec.PD.BaseImage = "pingcap/pd"
+				tc.Spec.Version = "v4.0.0"
+				tc.Spec.PD.Config.Set("namespace-classifier", "table")
+			},
+			expectCauses: []metav1.CauseType{ReasonConfigKeyRemoved},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+		old := newTc()
+		tc := newTc()
+		tt.update(tc)
+
+		causes, warnings := ValidateTidbClusterTransition(old, tc)
+		g.Expect(causes).To(HaveLen(len(tt.expectCauses)))
+		for i, reason := range tt.expectCauses {
+			g.Expect(causes[i].Type).To(Equal(reason))
+		}
+		g.Expect(warnings).To(HaveLen(len(tt.expectWarnings)))
+		for i, reason := range tt.expectWarnings {
+			g.Expect(strings.HasPrefix(warnings[i], string(reason)+": ")).To(BeTrue(), warnings[i])
+		}
+	}
+}
@@ -16,12 +16,16 @@ package validation
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -31,11 +35,18 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilnet "k8s.io/utils/net"
+	"sigs.k8s.io/yaml"
 )
 
+// maxClientIPServiceAffinitySeconds is the max timeout of the ClientIP session affinity of a Service
+const maxClientIPServiceAffinitySeconds int32 = 86400
+
 // ValidateTidbCluster validates a TidbCluster, it performs basic validation for all TidbClusters despite it is legacy
 // or not
 func ValidateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
@@ -46,6 +57,12 @@ func ValidateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
 	allErrs = append(allErrs, validateAnnotations(tc.ObjectMeta.Annotations, fldPath.Child("annotations"))...)
 	// validate spec
 	allErrs = append(allErrs, validateTiDBClusterSpec(&tc.Spec, field.NewPath("spec"))...)
+	if tc.Spec.TiDB != nil && len(tc.Spec.TiDB.PluginArtifacts) > 0 {
+		allErrs = append(allErrs, validateTiDBPluginCompatibility(tc, field.NewPath("spec", "tidb", "pluginArtifacts"))...)
+	}
+	if tc.Spec.SecretReplication != nil {
+		allErrs = append(allErrs, validateSecretReplication(tc, field.NewPath("spec", "secretReplication"))...)
+	}
 	return allErrs
 }
 
@@ -101,6 +118,192 @@ func ValidateTidbMonitor(monitor *v1alpha1.TidbMonitor) field.ErrorList {
 	if monitor.Spec.Persistent {
 		allErrs = append(allErrs, validateStorageInfo(monitor.Spec.Storage, field.NewPath("spec"))...)
 	}
+	if cs := monitor.Spec.ClusterSelector; cs != nil {
+		fldPath := field.NewPath("spec", "clusterSelector")
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&cs.LabelSelector, fldPath.Child("labelSelector"))...)
+		if cs.NamespaceSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(cs.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+		}
+	}
+	return allErrs
+}
+
+// ValidateDiagnostic validates a Diagnostic
+func ValidateDiagnostic(d *v1alpha1.Diagnostic) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &d.Spec
+
+	if len(spec.Cluster) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), ""))
+	}
+	for i, c := range spec.Components {
+		if c != v1alpha1.PDMemberType && c != v1alpha1.TiKVMemberType {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("components").Index(i), c,
+				[]string{v1alpha1.PDMemberType.String(), v1alpha1.TiKVMemberType.String()}))
+		}
+	}
+	supportedItems := sets.NewString(
+		string(v1alpha1.DiagnosticItemProfiles), string(v1alpha1.DiagnosticItemMetrics), string(v1alpha1.DiagnosticItemLogs),
+		string(v1alpha1.DiagnosticItemStores), string(v1alpha1.DiagnosticItemRegions),
+	)
+	for i, item := range spec.Items {
+		if !supportedItems.Has(string(item)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("items").Index(i), item, supportedItems.List()))
+		}
+	}
+	supportedProfiles := sets.NewString("profile", "heap", "goroutine", "mutex", "block", "allocs", "threadcreate")
+	for i, profile := range spec.Profiles {
+		if !supportedProfiles.Has(profile) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("profiles").Index(i), profile, supportedProfiles.List()))
+		}
+	}
+	if spec.ProfileDuration != nil && spec.ProfileDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("profileDuration"), spec.ProfileDuration.Duration.String(), "must be greater than 0"))
+	}
+	if spec.LogTailLines != nil && *spec.LogTailLines <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logTailLines"), *spec.LogTailLines, "must be greater than 0"))
+	}
+	if spec.Redaction != nil {
+		for i, pattern := range spec.Redaction.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("redaction", "patterns").Index(i), pattern, err.Error()))
+			}
+		}
+	}
+
+	providers := 0
+	for _, set := range []bool{spec.S3 != nil, spec.Gcs != nil, spec.Azblob != nil, spec.Local != nil} {
+		if set {
+			providers++
+		}
+	}
+	if providers != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, providers, "exactly one of s3, gcs, azblob and local must be set"))
+	}
+	return allErrs
+}
+
+// changefeedIDPattern is the format of the changefeed ID accepted by TiCDC
+var changefeedIDPattern = regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`)
+
+// ValidateTiCDCChangefeed validates a TiCDCChangefeed
+func ValidateTiCDCChangefeed(cf *v1alpha1.TiCDCChangefeed) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &cf.Spec
+
+	if len(spec.Cluster) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), ""))
+	}
+	if id := cf.GetChangefeedID(); len(id) > 128 || !changefeedIDPattern.MatchString(id) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("changefeedID"), id,
+			"must consist of alphanumeric characters separated by '-' and be at most 128 characters"))
+	}
+	if (spec.SinkURI == "") == (spec.SinkURISecretRef == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.SinkURI, "exactly one of sinkURI and sinkURISecretRef must be set"))
+	}
+	if spec.TargetTs != 0 && spec.TargetTs <= spec.StartTs {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetTs"), spec.TargetTs, "must be greater than startTs"))
+	}
+	return allErrs
+}
+
+// ValidateDMTask validates a DMTask
+func ValidateDMTask(t *v1alpha1.DMTask) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &t.Spec
+
+	if len(spec.Cluster) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), ""))
+	}
+	switch spec.TaskMode {
+	case "", v1alpha1.DMTaskModeAll, v1alpha1.DMTaskModeFull, v1alpha1.DMTaskModeIncremental:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("taskMode"), spec.TaskMode,
+			[]string{string(v1alpha1.DMTaskModeAll), string(v1alpha1.DMTaskModeFull), string(v1alpha1.DMTaskModeIncremental)}))
+	}
+	switch spec.ShardMode {
+	case "", v1alpha1.DMShardModePessimistic, v1alpha1.DMShardModeOptimistic:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("shardMode"), spec.ShardMode,
+			[]string{string(v1alpha1.DMShardModePessimistic), string(v1alpha1.DMShardModeOptimistic)}))
+	}
+
+	if len(spec.Sources) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sources"), "at least one source is required"))
+	}
+	names := sets.NewString()
+	for i, source := range spec.Sources {
+		idxPath := fldPath.Child("sources").Index(i)
+		if len(source.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if names.Has(source.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), source.Name))
+		}
+		names.Insert(source.Name)
+		if len(source.Host) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("host"), ""))
+		}
+		if len(source.User) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("user"), ""))
+		}
+		if source.BinlogPos != nil && len(source.BinlogName) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("binlogName"), "binlogName is required if binlogPos is set"))
+		}
+	}
+
+	if len(spec.Target.Host) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("target", "host"), ""))
+	}
+	return allErrs
+}
+
+// ValidateTidbClusterBackupPolicy validates a TidbClusterBackupPolicy
+func ValidateTidbClusterBackupPolicy(p *v1alpha1.TidbClusterBackupPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &p.Spec
+
+	if spec.MaxBackupAge.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackupAge"), spec.MaxBackupAge.Duration.String(), "must be greater than 0"))
+	}
+	supported := sets.NewString()
+	for _, op := range v1alpha1.TidbClusterBackupPolicyOperations {
+		supported.Insert(string(op))
+	}
+	for i, op := range spec.Operations {
+		if !supported.Has(string(op)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("operations").Index(i), op, supported.List()))
+		}
+	}
+	return allErrs
+}
+
+// ValidatePDRecovery validates a PDRecovery
+func ValidatePDRecovery(r *v1alpha1.PDRecovery) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &r.Spec
+
+	if len(spec.Cluster) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster"), ""))
+	}
+	if spec.ClusterID != "" {
+		if id, err := strconv.ParseUint(spec.ClusterID, 10, 64); err != nil || id == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterID"), spec.ClusterID, "must be a positive integer"))
+		}
+	}
+	confirmable := sets.NewString()
+	for _, step := range v1alpha1.PDRecoveryConfirmationSteps {
+		confirmable.Insert(string(step))
+	}
+	for i, step := range spec.ConfirmedSteps {
+		if !confirmable.Has(string(step)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("confirmedSteps").Index(i), step, confirmable.List()))
+		}
+	}
 	return allErrs
 }
 
@@ -130,9 +333,15 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.PD != nil {
 		allErrs = append(allErrs, validatePDSpec(spec.PD, fldPath.Child("pd"))...)
 	}
+	if len(spec.PDMS) > 0 || (spec.PD != nil && spec.PD.Mode == v1alpha1.PDModeMicroservice) {
+		allErrs = append(allErrs, validatePDMS(spec, fldPath.Child("pdms"))...)
+	}
 	if spec.TiKV != nil {
 		allErrs = append(allErrs, validateTiKVSpec(spec.TiKV, fldPath.Child("tikv"))...)
 	}
+	if len(spec.TiKVGroups) > 0 {
+		allErrs = append(allErrs, validateTiKVGroups(spec, fldPath.Child("tikvGroups"))...)
+	}
 	if spec.TiDB != nil {
 		allErrs = append(allErrs, validateTiDBSpec(spec.TiDB, fldPath.Child("tidb"))...)
 	}
@@ -145,9 +354,299 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.TiCDC != nil {
 		allErrs = append(allErrs, validateTiCDCSpec(spec.TiCDC, fldPath.Child("ticdc"))...)
 	}
+	if spec.TiProxy != nil {
+		allErrs = append(allErrs, validateTiProxySpec(spec.TiProxy, fldPath.Child("tiproxy"))...)
+	}
 	if spec.PDAddresses != nil {
 		allErrs = append(allErrs, validatePDAddresses(spec.PDAddresses, fldPath.Child("pdAddresses"))...)
 	}
+	if spec.StatusCompaction != nil {
+		allErrs = append(allErrs, validateStatusCompaction(spec.StatusCompaction, fldPath.Child("statusCompaction"))...)
+	}
+	if spec.Notification != nil {
+		allErrs = append(allErrs, ValidateNotificationSinks(spec.Notification.Sinks, fldPath.Child("notification", "sinks"))...)
+	}
+	if spec.GCTuning != nil {
+		allErrs = append(allErrs, validateGCTuning(spec.GCTuning, fldPath.Child("gcTuning"))...)
+	}
+	if spec.TLSCluster != nil && spec.TLSCluster.CertManager != nil {
+		allErrs = append(allErrs, validateTLSCertManager(spec.TLSCluster.CertManager, fldPath.Child("tlsCluster", "certManager"))...)
+	}
+	if spec.TLSCluster != nil {
+		switch s := spec.TLSCluster.RotationStrategy; s {
+		case "", v1alpha1.TLSRotationStrategyAuto, v1alpha1.TLSRotationStrategyRestart, v1alpha1.TLSRotationStrategyNone:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("tlsCluster", "rotationStrategy"), s,
+				[]string{string(v1alpha1.TLSRotationStrategyAuto), string(v1alpha1.TLSRotationStrategyRestart), string(v1alpha1.TLSRotationStrategyNone)}))
+		}
+	}
+	if spec.OperationTimeouts != nil {
+		allErrs = append(allErrs, validateOperationTimeouts(spec.OperationTimeouts, fldPath.Child("operationTimeouts"))...)
+	}
+	if spec.PeerRegistry != nil {
+		allErrs = append(allErrs, validatePeerRegistry(spec, fldPath.Child("peerRegistry"))...)
+	}
+	for i := range spec.Overrides {
+		allErrs = append(allErrs, validateResourceOverride(&spec.Overrides[i], fldPath.Child("overrides").Index(i))...)
+	}
+	return allErrs
+}
+
+// validatePeerRegistry validates the registry of the cluster deployed across Kubernetes clusters, which is not
+// used together with the manually configured PD of another cluster.
+func validatePeerRegistry(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !spec.AcrossK8s {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "peer registry can only be set if acrossK8s is set"))
+	}
+	if spec.Cluster != nil && len(spec.Cluster.Name) > 0 || len(spec.PDAddresses) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "peer registry can not be set together with cluster or pdAddresses"))
+	}
+	if u, err := url.Parse(spec.PeerRegistry.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), spec.PeerRegistry.URL, "must be an http or https URL"))
+	}
+	return allErrs
+}
+
+// validateSecretReplication validates the Secrets replicated for the heterogeneous cluster, which are replicated
+// from another namespace of the same Kubernetes cluster.
+func validateSecretReplication(tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ref := tc.Spec.Cluster
+	if ref == nil || len(ref.Name) == 0 || len(ref.Namespace) == 0 || ref.Namespace == tc.Namespace {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "secrets can only be replicated if cluster is set to a TidbCluster in another namespace"))
+	} else if len(ref.ClusterDomain) > 0 && ref.ClusterDomain != tc.Spec.ClusterDomain {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "secrets can not be replicated from another Kubernetes cluster"))
+	}
+	names := map[string]struct{}{}
+	for i, name := range tc.Spec.SecretReplication.SecretNames {
+		idxPath := fldPath.Child("secretNames").Index(i)
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(idxPath, name, msg))
+		}
+		if _, ok := names[name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath, name))
+		}
+		names[name] = struct{}{}
+	}
+	return allErrs
+}
+
+func validateTiKVGroups(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Cluster != nil && len(spec.Cluster.Name) > 0 {
+		// the groups are deployed by heterogeneous clusters, which can not have groups any more
+		allErrs = append(allErrs, field.Forbidden(fldPath, "tikv groups can not be set in a heterogeneous cluster"))
+		return allErrs
+	}
+	names := map[string]struct{}{}
+	for i := range spec.TiKVGroups {
+		group := &spec.TiKVGroups[i]
+		idxPath := fldPath.Index(i)
+		for _, msg := range validation.IsDNS1123Label(group.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), group.Name, msg))
+		}
+		if _, ok := names[group.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), group.Name))
+		}
+		names[group.Name] = struct{}{}
+		allErrs = append(allErrs, validateTiKVSpec(&group.Spec, idxPath.Child("spec"))...)
+	}
+	return allErrs
+}
+
+func validatePDMS(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.PD == nil || spec.PD.Mode != v1alpha1.PDModeMicroservice {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "pd microservices can only be set when spec.pd.mode is \"ms\""))
+		return allErrs
+	}
+	names := map[string]struct{}{}
+	for i, ms := range spec.PDMS {
+		idxPath := fldPath.Index(i)
+		switch v1alpha1.MemberType(ms.Name) {
+		case v1alpha1.PDMSTSOMemberType, v1alpha1.PDMSSchedulingMemberType:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("name"), ms.Name,
+				[]string{v1alpha1.PDMSTSOMemberType.String(), v1alpha1.PDMSSchedulingMemberType.String()}))
+		}
+		if _, ok := names[ms.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), ms.Name))
+		}
+		names[ms.Name] = struct{}{}
+		allErrs = append(allErrs, validateComponentSpec(&ms.ComponentSpec, idxPath)...)
+	}
+	if _, ok := names[v1alpha1.PDMSTSOMemberType.String()]; !ok {
+		allErrs = append(allErrs, field.Required(fldPath, "the tso microservice is required when spec.pd.mode is \"ms\""))
+	}
+	return allErrs
+}
+
+func validateStatusCompaction(spec *v1alpha1.StatusCompaction, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.MemberThreshold != nil && *spec.MemberThreshold <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memberThreshold"), *spec.MemberThreshold, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateOperationTimeouts(policy *v1alpha1.OperationTimeoutPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	timeouts := []struct {
+		name    string
+		timeout *metav1.Duration
+	}{
+		{"upgrade", policy.Upgrade},
+		{"scaleIn", policy.ScaleIn},
+		{"scaleOut", policy.ScaleOut},
+	}
+	for _, t := range timeouts {
+		if t.timeout != nil && t.timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(t.name), t.timeout.Duration.String(), "must be positive"))
+		}
+	}
+	switch policy.Action {
+	case "", v1alpha1.OperationTimeoutActionAlert, v1alpha1.OperationTimeoutActionAbort:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("action"), policy.Action,
+			[]string{string(v1alpha1.OperationTimeoutActionAlert), string(v1alpha1.OperationTimeoutActionAbort)}))
+	}
+	return allErrs
+}
+
+func validateResourceOverride(override *v1alpha1.ResourceOverride, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch override.Kind {
+	case v1alpha1.ResourceOverrideKindStatefulSet, v1alpha1.ResourceOverrideKindService:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), override.Kind,
+			[]string{string(v1alpha1.ResourceOverrideKindStatefulSet), string(v1alpha1.ResourceOverrideKindService)}))
+	}
+	if len(override.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "the name of the generated resource must be specified"))
+	}
+	if len(override.Patch) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("patch"), "the patch must be specified"))
+		return allErrs
+	}
+
+	var patch interface{}
+	data, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err == nil {
+		err = json.Unmarshal(data, &patch)
+	}
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("patch"), override.Patch, fmt.Sprintf("must be valid YAML or JSON: %v", err)))
+	}
+	switch override.Type {
+	case "", v1alpha1.ResourceOverridePatchTypeStrategicMerge:
+		if _, ok := patch.(map[string]interface{}); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), override.Patch, "a strategic merge patch must be an object"))
+		}
+	case v1alpha1.ResourceOverridePatchTypeJSON:
+		if _, ok := patch.([]interface{}); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), override.Patch, "a JSON patch must be an array of operations"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), override.Type,
+			[]string{string(v1alpha1.ResourceOverridePatchTypeStrategicMerge), string(v1alpha1.ResourceOverridePatchTypeJSON)}))
+	}
+	return allErrs
+}
+
+func validateTLSCertManager(spec *v1alpha1.TLSCertManager, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.IssuerRef.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("issuerRef", "name"), "the issuer of the certs must be specified"))
+	}
+	if kind := spec.IssuerRef.Kind; kind != "" && kind != "Issuer" && kind != "ClusterIssuer" && (spec.IssuerRef.Group == "" || spec.IssuerRef.Group == "cert-manager.io") {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("issuerRef", "kind"), kind, []string{"Issuer", "ClusterIssuer"}))
+	}
+	if spec.Duration != nil && spec.RenewBefore != nil && spec.RenewBefore.Duration >= spec.Duration.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), spec.RenewBefore.Duration.String(), "must be less than duration"))
+	}
+	return allErrs
+}
+
+// validateGCTuning validates the GC tuning policy, the schedules of the windows are parsed
+// by the GC tuning manager because the cron parser is not a dependency of the API.
+func validateGCTuning(spec *v1alpha1.GCTuningPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]struct{}{}
+	for i := range spec.Windows {
+		window := &spec.Windows[i]
+		idxPath := fldPath.Child("windows").Index(i)
+		if len(window.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if _, ok := names[window.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), window.Name))
+		}
+		names[window.Name] = struct{}{}
+		if len(window.Schedule) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("schedule"), ""))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("duration"), window.Duration.Duration.String(), "must be greater than 0"))
+		}
+		if window.GCLifeTime != nil {
+			if d, err := time.ParseDuration(*window.GCLifeTime); err != nil || d < 10*time.Minute {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("gcLifeTime"), *window.GCLifeTime, "must be a duration no less than 10m"))
+			}
+		}
+		if window.GCConcurrency != nil {
+			if c := *window.GCConcurrency; c != -1 && (c < 1 || c > 256) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("gcConcurrency"), c, "must be -1 or in the range of [1, 256]"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateNotificationSinks validates the sinks of the notifications, it is also used to validate
+// the global sinks of tidb-operator.
+func ValidateNotificationSinks(sinks []v1alpha1.NotificationSink, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]struct{}{}
+	for i := range sinks {
+		sink := &sinks[i]
+		idxPath := fldPath.Index(i)
+		if len(sink.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if _, ok := names[sink.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), sink.Name))
+		}
+		names[sink.Name] = struct{}{}
+
+		switch sink.Type {
+		case v1alpha1.NotificationSinkTypeSlack, v1alpha1.NotificationSinkTypeWebhook:
+			if len(sink.URL) == 0 && sink.SecretRef == nil {
+				allErrs = append(allErrs, field.Required(idxPath.Child("url"), "either url or secretRef must be set"))
+			}
+		case v1alpha1.NotificationSinkTypePagerDuty:
+			if sink.SecretRef == nil {
+				allErrs = append(allErrs, field.Required(idxPath.Child("secretRef"), "the routing key of PagerDuty must be set"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), sink.Type, []string{
+				string(v1alpha1.NotificationSinkTypeSlack), string(v1alpha1.NotificationSinkTypePagerDuty), string(v1alpha1.NotificationSinkTypeWebhook)}))
+		}
+		if len(sink.URL) > 0 {
+			if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("url"), sink.URL, "must be a http or https URL"))
+			}
+		}
+		if sink.SecretRef != nil {
+			allErrs = append(allErrs, validateSecretKeySelector(sink.SecretRef, idxPath.Child("secretRef"))...)
+		}
+		if len(sink.Template) > 0 {
+			if _, err := template.New(sink.Name).Parse(sink.Template); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("template"), sink.Template, err.Error()))
+			}
+		}
+		if sink.MinInterval != nil && sink.MinInterval.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("minInterval"), sink.MinInterval.Duration.String(), "must not be negative"))
+		}
+	}
 	return allErrs
 }
 
@@ -169,6 +668,11 @@ func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(spec.Service, fldPath)...)
 	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(spec.PodDisruptionBudget, fldPath.Child("podDisruptionBudget"))...)
+	if spec.LeaderLocality != nil && spec.LeaderLocality.PrimaryClusterDomain == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("leaderLocality", "primaryClusterDomain"), "the cluster domain of the primary data plane must be specified"))
+	}
+	allErrs = append(allErrs, validateScaleInHook(spec.ScaleInHook, fldPath.Child("scaleInHook"))...)
 	return allErrs
 }
 
@@ -198,6 +702,9 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
 	}
+	if spec.RaftLogVolumeClaim != nil {
+		allErrs = append(allErrs, validateRaftLogVolumeClaim(spec, fldPath)...)
+	}
 	if spec.ShouldSeparateRaftLog() && spec.RaftLogVolumeName != "" {
 		allErrs = append(allErrs, validateVolumeName(spec.RaftLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
@@ -205,6 +712,93 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 		allErrs = append(allErrs, validateVolumeName(spec.RocksDBLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
 	allErrs = append(allErrs, validateTimeDurationStr(spec.EvictLeaderTimeout, fldPath.Child("evictLeaderTimeout"))...)
+	if spec.DrainTimeout != nil && spec.DrainTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("drainTimeout"), spec.DrainTimeout.Duration.String(), "drainTimeout should not be negative"))
+	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(spec.PodDisruptionBudget, fldPath.Child("podDisruptionBudget"))...)
+	allErrs = append(allErrs, validateRollingUpdateStrategy(spec.RollingUpdateStrategy, fldPath.Child("rollingUpdateStrategy"))...)
+	allErrs = append(allErrs, validateCapacityReservation(spec.CapacityReservation, spec.PriorityClassName, fldPath.Child("capacityReservation"))...)
+	allErrs = append(allErrs, validateScaleInHook(spec.ScaleInHook, fldPath.Child("scaleInHook"))...)
+	allErrs = append(allErrs, validateTiKVStoreGC(spec.StoreGC, fldPath.Child("storeGC"))...)
+	allErrs = append(allErrs, validateTiKVSlowStorePolicy(spec.SlowStore, fldPath.Child("slowStore"))...)
+	return allErrs
+}
+
+// validateTiKVSlowStorePolicy validates that the threshold is in the range of the slow score reported by PD.
+func validateTiKVSlowStorePolicy(policy *v1alpha1.TiKVSlowStorePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.ScoreThreshold != nil && (*policy.ScoreThreshold < 1 || *policy.ScoreThreshold > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scoreThreshold"), *policy.ScoreThreshold, "must be between 1 and 100"))
+	}
+	if policy.Duration != nil && policy.Duration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), policy.Duration.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+// validateTiKVStoreGC validates that the grace periods are positive, the stores would be removed as soon as
+// they are left otherwise.
+func validateTiKVStoreGC(gc *v1alpha1.TiKVStoreGC, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if gc == nil {
+		return allErrs
+	}
+	if gc.TombstoneGracePeriod != nil && gc.TombstoneGracePeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tombstoneGracePeriod"), gc.TombstoneGracePeriod.Duration.String(), "must be greater than 0"))
+	}
+	if gc.OrphanGracePeriod != nil && gc.OrphanGracePeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("orphanGracePeriod"), gc.OrphanGracePeriod.Duration.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+// validateCapacityReservation validates the placeholder pods, which must be preemptible by the pods of the component.
+// The priorities are not compared as the PriorityClasses may not be readable by the validation.
+func validateCapacityReservation(spec *v1alpha1.CapacityReservation, priorityClassName *string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		return allErrs
+	}
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *spec.Replicas, "must be greater than or equal to 0"))
+	}
+	if spec.PriorityClassName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("priorityClassName"), "a priority class lower than the component is required"))
+	} else if priorityClassName != nil && *priorityClassName == spec.PriorityClassName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityClassName"), spec.PriorityClassName, "must be different from the priority class of the component"))
+	}
+	return allErrs
+}
+
+// validateScaleInHook validates that exactly one of the HTTP callback and the Job is set.
+func validateScaleInHook(hook *v1alpha1.ScaleInHook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hook == nil {
+		return allErrs
+	}
+	if (hook.HTTP == nil) == (hook.Job == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "exactly one of http and job must be set"))
+		return allErrs
+	}
+	if hook.HTTP != nil {
+		if u, err := url.Parse(hook.HTTP.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http", "url"), hook.HTTP.URL, "must be an http or https URL"))
+		}
+		if hook.HTTP.TimeoutSeconds != nil && *hook.HTTP.TimeoutSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http", "timeoutSeconds"), *hook.HTTP.TimeoutSeconds, "must be greater than 0"))
+		}
+	}
+	if hook.Job != nil {
+		if len(hook.Job.Template.Spec.Containers) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("job", "template", "spec", "containers"), "at least one container is required"))
+		}
+		if hook.Job.BackoffLimit != nil && *hook.Job.BackoffLimit < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("job", "backoffLimit"), *hook.Job.BackoffLimit, "must be greater than or equal to 0"))
+		}
+	}
 	return allErrs
 }
 
@@ -217,10 +811,42 @@ func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.
 			spec.StorageClaims, "storageClaims should be configured at least one item."))
 	}
 	allErrs = append(allErrs, validateScalePolicy(&spec.ScalePolicy, fldPath.Child("scalePolicy"))...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(spec.PodDisruptionBudget, fldPath.Child("podDisruptionBudget"))...)
+	allErrs = append(allErrs, validateTiFlashDisaggregated(spec.Disaggregated, fldPath.Child("disaggregated"))...)
+	return allErrs
+}
+
+func validateTiFlashDisaggregated(spec *v1alpha1.TiFlashDisaggregatedSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		return allErrs
+	}
+	if spec.S3.Endpoint == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("s3", "endpoint"), "endpoint of the S3 storage must be set"))
+	}
+	if spec.S3.Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucket"), "bucket of the S3 storage must be set"))
+	}
+	computePath := fldPath.Child("computeNode")
+	allErrs = append(allErrs, validateComponentSpec(&spec.ComputeNode.ComponentSpec, computePath)...)
+	if len(spec.ComputeNode.StorageClaims) < 1 {
+		allErrs = append(allErrs, field.Invalid(computePath.Child("storageClaims"),
+			spec.ComputeNode.StorageClaims, "storageClaims should be configured at least one item."))
+	}
 	return allErrs
 }
 
 func validateTiCDCSpec(spec *v1alpha1.TiCDCSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if len(spec.StorageVolumes) > 0 {
+		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
+	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(spec.PodDisruptionBudget, fldPath.Child("podDisruptionBudget"))...)
+	return allErrs
+}
+
+func validateTiProxySpec(spec *v1alpha1.TiProxySpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
 	if len(spec.StorageVolumes) > 0 {
@@ -285,6 +911,15 @@ func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorL
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(&spec.Service.ServiceSpec, fldPath)...)
+		if spec.Service.ExternalDNS != nil {
+			allErrs = append(allErrs, validateExternalDNS(spec.Service.ExternalDNS, fldPath.Child("service", "externalDNS"))...)
+		}
+		if spec.Service.ZoneLocal != nil {
+			allErrs = append(allErrs, validateTiDBZoneLocalRouting(spec.Service, fldPath.Child("service"))...)
+		}
+		if spec.Service.ProxyProtocol != nil {
+			allErrs = append(allErrs, validateTiDBProxyProtocol(spec, fldPath.Child("service", "proxyProtocol"))...)
+		}
 	}
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
@@ -292,6 +927,141 @@ func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorL
 	if spec.ShouldSeparateSlowLog() && spec.SlowLogVolumeName != "" {
 		allErrs = append(allErrs, validateVolumeName(spec.SlowLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(spec.PodDisruptionBudget, fldPath.Child("podDisruptionBudget"))...)
+	allErrs = append(allErrs, validateRollingUpdateStrategy(spec.RollingUpdateStrategy, fldPath.Child("rollingUpdateStrategy"))...)
+	if seconds := spec.GracefulShutdownSeconds; seconds != nil {
+		if *seconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gracefulShutdownSeconds"), *seconds, "must be greater than 0"))
+		}
+		if spec.Lifecycle != nil && spec.Lifecycle.PreStop != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("lifecycle", "preStop"), "can't be set together with gracefulShutdownSeconds"))
+		}
+		if grace := spec.TerminationGracePeriodSeconds; grace != nil && *grace <= int64(*seconds) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("terminationGracePeriodSeconds"), *grace, "must be greater than gracefulShutdownSeconds"))
+		}
+	}
+	if spec.RestartPolicy != nil {
+		allErrs = append(allErrs, validateTiDBRestartPolicy(spec.RestartPolicy, fldPath.Child("restartPolicy"))...)
+	}
+	if len(spec.PluginArtifacts) > 0 {
+		allErrs = append(allErrs, validateTiDBPluginArtifacts(spec.PluginArtifacts, fldPath.Child("pluginArtifacts"))...)
+	}
+	if spec.ConnectionPool != nil {
+		allErrs = append(allErrs, validateTiDBConnectionPool(spec.ConnectionPool, fldPath.Child("connectionPool"))...)
+	}
+	return allErrs
+}
+
+// validateTiDBConnectionPool validates the connection pool sidecar of TiDB, whose port can't conflict with
+// the ports of TiDB in the same pod.
+func validateTiDBConnectionPool(pool *v1alpha1.TiDBConnectionPool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pool.Image == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), "image of the connection pool must be set"))
+	}
+	port := pool.GetPort()
+	for _, msg := range validation.IsValidPortNum(int(port)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port, msg))
+	}
+	if port == 4000 || port == 10080 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port, "must not conflict with the ports of TiDB"))
+	}
+	if pool.GetMaxConnections() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConnections"), pool.GetMaxConnections(), "must be greater than 0"))
+	}
+	if pool.GetMaxServerConnections() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxServerConnections"), pool.GetMaxServerConnections(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+var tidbPluginNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// validateTiDBPluginArtifacts validates the plugin artifacts of TiDB, the name of a plugin can't contain
+// '-' and the version must be an uint16 because they are parsed from the plugin ID by TiDB.
+func validateTiDBPluginArtifacts(plugins []v1alpha1.TiDBPluginArtifact, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ids := sets.NewString()
+	for i := range plugins {
+		p := &plugins[i]
+		idxPath := fldPath.Index(i)
+		if !tidbPluginNameRegexp.MatchString(p.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), p.Name, "must consist of alphanumeric characters or '_'"))
+		}
+		if _, err := strconv.ParseUint(p.Version, 10, 16); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("version"), p.Version, "must be an integer between 0 and 65535"))
+		}
+		if ids.Has(p.ID()) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, p.ID()))
+		}
+		ids.Insert(p.ID())
+		if p.TiDBVersions != "" {
+			if _, err := semver.NewConstraint(p.TiDBVersions); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("tidbVersions"), p.TiDBVersions, err.Error()))
+			}
+		}
+		sources := 0
+		if p.ConfigMap != nil {
+			sources++
+			if p.ConfigMap.Name == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("configMap", "name"), ""))
+			}
+		}
+		if p.Image != "" {
+			sources++
+		}
+		if p.PersistentVolumeClaim != nil {
+			sources++
+			if p.PersistentVolumeClaim.ClaimName == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("persistentVolumeClaim", "claimName"), ""))
+			}
+		}
+		if sources != 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath, p.ID(), "exactly one of configMap, image and persistentVolumeClaim should be set"))
+		}
+	}
+	return allErrs
+}
+
+// validateTiDBPluginCompatibility validates that the version of TiDB satisfies the constraints of the plugins,
+// the check is skipped if the version of TiDB is not a semantic version, e.g. latest or nightly.
+func validateTiDBPluginCompatibility(tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	version, err := semver.NewVersion(tc.TiDBVersion())
+	if err != nil {
+		return allErrs
+	}
+	for i := range tc.Spec.TiDB.PluginArtifacts {
+		p := &tc.Spec.TiDB.PluginArtifacts[i]
+		if p.TiDBVersions == "" {
+			continue
+		}
+		constraint, err := semver.NewConstraint(p.TiDBVersions)
+		if err != nil {
+			// reported by validateTiDBPluginArtifacts
+			continue
+		}
+		if !constraint.Check(version) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("tidbVersions"), p.TiDBVersions,
+				fmt.Sprintf("plugin %s is not compatible with TiDB %s", p.ID(), tc.TiDBVersion())))
+		}
+	}
+	return allErrs
+}
+
+// validateTiDBRestartPolicy validates the scheduled restarts of TiDB, the schedule is parsed
+// by the TiDB member manager because the cron parser is not a dependency of the API.
+func validateTiDBRestartPolicy(spec *v1alpha1.TiDBRestartPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.Schedule) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("schedule"), ""))
+	}
+	if spec.Window != nil && spec.Window.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("window"), spec.Window.Duration.String(), "must be greater than 0"))
+	}
+	if spec.MaxPodsPerRun != nil && *spec.MaxPodsPerRun <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPodsPerRun"), *spec.MaxPodsPerRun, "must be greater than 0"))
+	}
 	return allErrs
 }
 
@@ -354,6 +1124,32 @@ func validateTidbNGMonitoringSpec(spec *v1alpha1.TidbNGMonitoringSpec, fldPath *
 	}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
 	allErrs = append(allErrs, validateNGMonitoringSpec(&spec.NGMonitoring, fldPath.Child("ngMonitoring"))...)
+	if spec.Conprof != nil {
+		allErrs = append(allErrs, validateNGMonitoringConprofSpec(spec.Conprof, fldPath.Child("conprof"))...)
+	}
+
+	return allErrs
+}
+
+func validateNGMonitoringConprofSpec(spec *v1alpha1.NGMonitoringConprofSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Retention != nil && spec.Retention.Duration < time.Hour {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retention"), spec.Retention.Duration.String(), "must be at least 1h"))
+	}
+	if offload := spec.Offload; offload != nil {
+		offloadPath := fldPath.Child("offload")
+		switch {
+		case offload.S3 == nil && offload.Gcs == nil:
+			allErrs = append(allErrs, field.Required(offloadPath, "one of s3 and gcs must be set"))
+		case offload.S3 != nil && offload.Gcs != nil:
+			allErrs = append(allErrs, field.Invalid(offloadPath, "s3,gcs", "only one of s3 and gcs can be set"))
+		case offload.S3 != nil && offload.S3.Bucket == "":
+			allErrs = append(allErrs, field.Required(offloadPath.Child("s3", "bucket"), "bucket must be set"))
+		case offload.Gcs != nil && offload.Gcs.Bucket == "":
+			allErrs = append(allErrs, field.Required(offloadPath.Child("gcs", "bucket"), "bucket must be set"))
+		}
+	}
 
 	return allErrs
 }
@@ -374,6 +1170,7 @@ func validateComponentSpec(spec *v1alpha1.ComponentSpec, fldPath *field.Path) fi
 	// TODO validate other fields
 	allErrs = append(allErrs, validateEnv(spec.Env, fldPath.Child("env"))...)
 	allErrs = append(allErrs, validateAdditionalContainers(spec.AdditionalContainers, fldPath.Child("additionalContainers"))...)
+	allErrs = append(allErrs, validateNativeSidecarContainers(spec.NativeSidecarContainers, spec.AdditionalContainers, fldPath.Child("nativeSidecarContainers"))...)
 	return allErrs
 }
 
@@ -405,6 +1202,21 @@ func validateStorageVolumes(storageVolumes []v1alpha1.StorageVolume, fldPath *fi
 	return allErrs
 }
 
+// validateRaftLogVolumeClaim validates the dedicated raft log volume of TiKV
+func validateRaftLogVolumeClaim(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := resource.ParseQuantity(spec.RaftLogVolumeClaim.StorageSize); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("raftLogVolumeClaim", "storageSize"), spec.RaftLogVolumeClaim.StorageSize, "value of \"storageSize\" format not supported"))
+	}
+	for i, volume := range spec.StorageVolumes {
+		if volume.Name == v1alpha1.TiKVRaftLogVolumeName {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("storageVolumes").Index(i).Child("name"), volume.Name,
+				fmt.Sprintf("storage volume name %q is reserved for raftLogVolumeClaim", v1alpha1.TiKVRaftLogVolumeName)))
+		}
+	}
+	return allErrs
+}
+
 func validateVolumeName(volumeName string, storageVolumes []v1alpha1.StorageVolume, additionalVolumes []corev1.Volume, additionalVolumeMounts []corev1.VolumeMount, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for _, volume := range storageVolumes {
@@ -538,6 +1350,7 @@ func ValidateUpdateTidbCluster(old, tc *v1alpha1.TidbCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateUpdatePDConfig(old.Spec.PD, tc.Spec.PD, field.NewPath("spec.pd.config"))...)
 	allErrs = append(allErrs, disallowMutateBootstrapSQLConfigMapName(old.Spec.TiDB, tc.Spec.TiDB, field.NewPath("spec.tidb.bootstrapSQLConfigMapName"))...)
+	allErrs = append(allErrs, disallowMutateTiFlashDisaggregated(old.Spec.TiFlash, tc.Spec.TiFlash, field.NewPath("spec.tiflash.disaggregated"))...)
 	allErrs = append(allErrs, disallowUsingLegacyAPIInNewCluster(old, tc)...)
 
 	return allErrs
@@ -662,6 +1475,19 @@ func disallowMutateBootstrapSQLConfigMapName(old, new *v1alpha1.TiDBSpec, p *fie
 	return allErrs
 }
 
+// disallowMutateTiFlashDisaggregated rejects enabling or disabling the disaggregated mode of the deployed TiFlash,
+// as the data of the write nodes can't be migrated between the local disks and the S3 storage.
+func disallowMutateTiFlashDisaggregated(old, new *v1alpha1.TiFlashSpec, p *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old == nil || new == nil {
+		return allErrs
+	}
+	if (old.Disaggregated == nil) != (new.Disaggregated == nil) {
+		return append(allErrs, field.Invalid(p, new.Disaggregated, "disaggregated can't be enabled or disabled for the deployed TiFlash"))
+	}
+	return allErrs
+}
+
 func validateDeleteSlots(annotations map[string]string, key string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if annotations != nil {
@@ -687,6 +1513,124 @@ func validateService(spec *v1alpha1.ServiceSpec, fldPath *field.Path) field.Erro
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("spec.LoadBalancerSourceRanges"), spec.LoadBalancerSourceRanges, "service.Spec.LoadBalancerSourceRanges is not valid. Expecting a list of IP ranges. For example, 10.0.0.0/24."))
 		}
 	}
+	allErrs = append(allErrs, validateServiceTraffic(spec, fldPath.Child("service"))...)
+	return allErrs
+}
+
+// validateServiceTraffic validates the traffic options of the service, the options which are not supported by
+// the version of Kubernetes are skipped with a warning event when the service is synced.
+func validateServiceTraffic(spec *v1alpha1.ServiceSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.InternalTrafficPolicy != nil {
+		supported := sets.NewString(string(v1alpha1.ServiceInternalTrafficPolicyCluster), string(v1alpha1.ServiceInternalTrafficPolicyLocal))
+		if !supported.Has(string(*spec.InternalTrafficPolicy)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("internalTrafficPolicy"), *spec.InternalTrafficPolicy, supported.List()))
+		}
+	}
+	if spec.TrafficDistribution != nil {
+		if *spec.TrafficDistribution != v1alpha1.ServiceTrafficDistributionPreferClose {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("trafficDistribution"), *spec.TrafficDistribution, []string{v1alpha1.ServiceTrafficDistributionPreferClose}))
+		}
+		if spec.TopologyAwareRouting != nil && *spec.TopologyAwareRouting {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("trafficDistribution"), "can not be used together with topologyAwareRouting"))
+		}
+	}
+
+	switch spec.SessionAffinity {
+	case "", corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sessionAffinity"), spec.SessionAffinity,
+			[]string{string(corev1.ServiceAffinityNone), string(corev1.ServiceAffinityClientIP)}))
+	}
+	if spec.SessionAffinityConfig != nil {
+		if spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sessionAffinityConfig"), "can only be set when sessionAffinity is ClientIP"))
+		}
+		if clientIP := spec.SessionAffinityConfig.ClientIP; clientIP != nil && clientIP.TimeoutSeconds != nil {
+			timeout := *clientIP.TimeoutSeconds
+			if timeout <= 0 || timeout > maxClientIPServiceAffinitySeconds {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("sessionAffinityConfig", "clientIP", "timeoutSeconds"), timeout,
+					fmt.Sprintf("must be greater than 0 and less than or equal to %d", maxClientIPServiceAffinitySeconds)))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateExternalDNS(spec *v1alpha1.ExternalDNS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.Hostnames) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("hostnames"), "at least one hostname is required"))
+	}
+	for i, hostname := range spec.Hostnames {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimPrefix(hostname, "*.")) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostnames").Index(i), hostname, msg))
+		}
+	}
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), *spec.TTL, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateTiDBZoneLocalRouting(spec *v1alpha1.TiDBServiceSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch spec.ZoneLocal.Mode {
+	case v1alpha1.TiDBZoneLocalModePerZoneService:
+	case v1alpha1.TiDBZoneLocalModeTopologyAwareHints:
+		if spec.TrafficDistribution != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoneLocal", "mode"), "topology aware hints can not be used together with trafficDistribution"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("zoneLocal", "mode"), spec.ZoneLocal.Mode,
+			[]string{string(v1alpha1.TiDBZoneLocalModePerZoneService), string(v1alpha1.TiDBZoneLocalModeTopologyAwareHints)}))
+	}
+	return allErrs
+}
+
+func validateTiDBProxyProtocol(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	svc := spec.Service
+	proxy := svc.ProxyProtocol
+	switch proxy.Provider {
+	case "":
+	case v1alpha1.TiDBProxyProtocolProviderAWS, v1alpha1.TiDBProxyProtocolProviderAlibabaCloud:
+		if svc.Type != corev1.ServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("provider"), proxy.Provider,
+				fmt.Sprintf("the service must be of %s type to enable the PROXY protocol on the load balancer", corev1.ServiceTypeLoadBalancer)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provider"), proxy.Provider,
+			[]string{string(v1alpha1.TiDBProxyProtocolProviderAWS), string(v1alpha1.TiDBProxyProtocolProviderAlibabaCloud)}))
+	}
+	if len(proxy.Networks) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("networks"), "the networks of the load balancer are required"))
+	}
+	for i, network := range proxy.Networks {
+		if network == "*" {
+			// the applications in the cluster connect to the per-zone services without the PROXY protocol header
+			if svc.ZoneLocal != nil && svc.ZoneLocal.Mode == v1alpha1.TiDBZoneLocalModePerZoneService {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("networks").Index(i), network,
+					"all networks can not be used together with the per-zone services, the connections from the applications in the cluster would be rejected"))
+			}
+			continue
+		}
+		if net.ParseIP(network) == nil {
+			if _, _, err := net.ParseCIDR(network); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("networks").Index(i), network, "must be an IP, a CIDR or *"))
+			}
+		}
+	}
+	if proxy.HeaderTimeout != nil && *proxy.HeaderTimeout <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("headerTimeout"), *proxy.HeaderTimeout, "must be greater than 0"))
+	}
+	if spec.Config != nil {
+		for _, key := range []string{"proxy-protocol.networks", "proxy-protocol.header-timeout"} {
+			if spec.Config.Get(key) != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("%s of the config is managed by proxyProtocol and can not be set together", key)))
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -767,6 +1711,29 @@ func validateAdditionalContainers(containers []corev1.Container, fldPath *field.
 	return allErrs
 }
 
+// validateNativeSidecarContainers validates the native sidecar containers are additional containers
+func validateNativeSidecarContainers(names []string, containers []corev1.Container, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	additional := sets.NewString()
+	for _, container := range containers {
+		additional.Insert(container.Name)
+	}
+	seen := sets.NewString()
+	for i, name := range names {
+		idxPath := fldPath.Index(i)
+		if !additional.Has(name) {
+			allErrs = append(allErrs, field.Invalid(idxPath, name, "must be the name of an additional container"))
+		}
+		if seen.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, name))
+		}
+		seen.Insert(name)
+	}
+
+	return allErrs
+}
+
 func validateStorageInfo(storage string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(storage) == 0 {
@@ -796,3 +1763,25 @@ func validateScalePolicy(scalePolicy *v1alpha1.ScalePolicy, fldPath *field.Path)
 	}
 	return allErrs
 }
+
+func validatePodDisruptionBudget(pdb *v1alpha1.PodDisruptionBudgetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pdb == nil {
+		return allErrs
+	}
+	if pdb.MaxUnavailable != nil && pdb.MinAvailable != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, pdb, "maxUnavailable and minAvailable can not be set at the same time"))
+	}
+	return allErrs
+}
+
+func validateRollingUpdateStrategy(strategy *v1alpha1.RollingUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy == nil {
+		return allErrs
+	}
+	if strategy.Canary != nil && *strategy.Canary < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("canary"), *strategy.Canary, "canary should not be negative"))
+	}
+	return allErrs
+}
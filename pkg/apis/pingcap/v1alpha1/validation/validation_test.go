@@ -16,6 +16,7 @@ package validation
 import (
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
@@ -23,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -381,6 +383,37 @@ func TestValidatePumpSpec(t *testing.T) {
 	}
 }
 
+func TestValidateTiProxySpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		storageVolumes []v1alpha1.StorageVolume
+		expectedErrors int
+	}{
+		{
+			name:           "no storage volumes",
+			expectedErrors: 0,
+		},
+		{
+			name:           "valid storage volumes",
+			storageVolumes: []v1alpha1.StorageVolume{{Name: "log", StorageSize: "1Gi"}},
+			expectedErrors: 0,
+		},
+		{
+			name:           "invalid storage volumes",
+			storageVolumes: []v1alpha1.StorageVolume{{StorageSize: "1Gi"}, {Name: "log", StorageSize: "invalid"}},
+			expectedErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TiProxySpec{Replicas: 1, StorageVolumes: tt.storageVolumes}
+			err := validateTiProxySpec(spec, field.NewPath("spec", "tiproxy"))
+			g.Expect(len(err)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
 func TestValidateRequestsStorage(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -456,6 +489,306 @@ func TestValidateService(t *testing.T) {
 	}
 }
 
+func TestValidateServiceTraffic(t *testing.T) {
+	local := v1alpha1.ServiceInternalTrafficPolicyLocal
+	unknown := v1alpha1.ServiceInternalTrafficPolicyType("Node")
+	clientIP := &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: pointer.Int32Ptr(600)}}
+
+	successCases := []*v1alpha1.ServiceSpec{
+		{},
+		{InternalTrafficPolicy: &local, TrafficDistribution: pointer.StringPtr(v1alpha1.ServiceTrafficDistributionPreferClose)},
+		{TopologyAwareRouting: pointer.BoolPtr(true), SessionAffinity: corev1.ServiceAffinityClientIP, SessionAffinityConfig: clientIP},
+	}
+	for _, c := range successCases {
+		if errs := validateServiceTraffic(c, field.NewPath("service")); len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.ServiceSpec{
+		{InternalTrafficPolicy: &unknown},
+		{TrafficDistribution: pointer.StringPtr("PreferSameNode")},
+		{TrafficDistribution: pointer.StringPtr(v1alpha1.ServiceTrafficDistributionPreferClose), TopologyAwareRouting: pointer.BoolPtr(true)},
+		{SessionAffinity: "Cookie"},
+		{SessionAffinityConfig: clientIP},
+		{SessionAffinity: corev1.ServiceAffinityClientIP, SessionAffinityConfig: &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: pointer.Int32Ptr(86401)},
+		}},
+	}
+	for _, c := range errorCases {
+		if errs := validateServiceTraffic(c, field.NewPath("service")); len(errs) != 1 {
+			t.Errorf("expected exactly one failure for %+v: %v", c, errs)
+		}
+	}
+}
+
+func TestValidateGCTuning(t *testing.T) {
+	newWindow := func(name string, lifeTime *string, concurrency *int32) v1alpha1.GCTuningWindow {
+		return v1alpha1.GCTuningWindow{
+			Name:     name,
+			Schedule: "0 1 * * *",
+			Duration: metav1.Duration{Duration: 4 * time.Hour},
+			GCTuningSettings: v1alpha1.GCTuningSettings{
+				GCLifeTime:    lifeTime,
+				GCConcurrency: concurrency,
+			},
+		}
+	}
+
+	successCases := []*v1alpha1.GCTuningPolicy{
+		{},
+		{Windows: []v1alpha1.GCTuningWindow{
+			newWindow("night", pointer.StringPtr("10m"), pointer.Int32Ptr(-1)),
+			newWindow("weekend", pointer.StringPtr("1h30m"), pointer.Int32Ptr(256)),
+		}},
+	}
+	for _, c := range successCases {
+		if errs := validateGCTuning(c, field.NewPath("gcTuning")); len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	noSchedule := newWindow("night", nil, nil)
+	noSchedule.Schedule = ""
+	noDuration := newWindow("night", nil, nil)
+	noDuration.Duration = metav1.Duration{}
+	errorCases := []*v1alpha1.GCTuningPolicy{
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("", nil, nil)}},
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("night", nil, nil), newWindow("night", nil, nil)}},
+		{Windows: []v1alpha1.GCTuningWindow{noSchedule}},
+		{Windows: []v1alpha1.GCTuningWindow{noDuration}},
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("night", pointer.StringPtr("5m"), nil)}},
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("night", pointer.StringPtr("1d"), nil)}},
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("night", nil, pointer.Int32Ptr(0))}},
+		{Windows: []v1alpha1.GCTuningWindow{newWindow("night", nil, pointer.Int32Ptr(257))}},
+	}
+	for _, c := range errorCases {
+		if errs := validateGCTuning(c, field.NewPath("gcTuning")); len(errs) != 1 {
+			t.Errorf("expected exactly one failure for %+v: %v", c, errs)
+		}
+	}
+}
+
+func TestValidateTiDBRestartPolicy(t *testing.T) {
+	successCases := []*v1alpha1.TiDBRestartPolicy{
+		{Schedule: "0 3 * * 0"},
+		{Schedule: "0 3 * * 0", Window: &metav1.Duration{Duration: 2 * time.Hour}, MaxPodsPerRun: pointer.Int32Ptr(1)},
+	}
+	for _, c := range successCases {
+		if errs := validateTiDBRestartPolicy(c, field.NewPath("restartPolicy")); len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.TiDBRestartPolicy{
+		{},
+		{Schedule: "0 3 * * 0", Window: &metav1.Duration{}},
+		{Schedule: "0 3 * * 0", MaxPodsPerRun: pointer.Int32Ptr(0)},
+	}
+	for _, c := range errorCases {
+		if errs := validateTiDBRestartPolicy(c, field.NewPath("restartPolicy")); len(errs) != 1 {
+			t.Errorf("expected exactly one failure for %+v: %v", c, errs)
+		}
+	}
+}
+
+func TestValidateTiDBPluginArtifacts(t *testing.T) {
+	cm := &corev1.LocalObjectReference{Name: "audit-plugin"}
+	successCases := [][]v1alpha1.TiDBPluginArtifact{
+		{{Name: "audit", Version: "1", ConfigMap: cm}},
+		{
+			{Name: "audit", Version: "1", TiDBVersions: ">= 7.1.0, < 7.2.0", Image: "my-registry/audit-plugin:v7.1"},
+			{Name: "white_list", Version: "1", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugins"}},
+		},
+	}
+	for _, c := range successCases {
+		if errs := validateTiDBPluginArtifacts(c, field.NewPath("pluginArtifacts")); len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := [][]v1alpha1.TiDBPluginArtifact{
+		{{Name: "audit-log", Version: "1", ConfigMap: cm}},
+		{{Name: "audit", Version: "v1", ConfigMap: cm}},
+		{{Name: "audit", Version: "1", TiDBVersions: "newer than 7", ConfigMap: cm}},
+		{{Name: "audit", Version: "1"}},
+		{{Name: "audit", Version: "1", ConfigMap: cm, Image: "audit-plugin"}},
+		{{Name: "audit", Version: "1", ConfigMap: cm}, {Name: "audit", Version: "1", Image: "audit-plugin"}},
+	}
+	for _, c := range errorCases {
+		if errs := validateTiDBPluginArtifacts(c, field.NewPath("pluginArtifacts")); len(errs) != 1 {
+			t.Errorf("expected exactly one failure for %+v: %v", c, errs)
+		}
+	}
+
+	// the version of TiDB should satisfy the constraints of the plugins
+	tc := newTidbCluster()
+	tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+		PluginArtifacts: []v1alpha1.TiDBPluginArtifact{{Name: "audit", Version: "1", TiDBVersions: ">= 7.1.0, < 7.2.0", ConfigMap: cm}},
+	}
+	tc.Spec.TiDB.Image = "pingcap/tidb:v7.1.2"
+	if errs := validateTiDBPluginCompatibility(tc, field.NewPath("pluginArtifacts")); len(errs) > 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	tc.Spec.TiDB.Image = "pingcap/tidb:v7.5.0"
+	if errs := validateTiDBPluginCompatibility(tc, field.NewPath("pluginArtifacts")); len(errs) != 1 {
+		t.Errorf("expected exactly one failure: %v", errs)
+	}
+	tc.Spec.TiDB.Image = "pingcap/tidb:nightly"
+	if errs := validateTiDBPluginCompatibility(tc, field.NewPath("pluginArtifacts")); len(errs) > 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+}
+
+func TestValidateExternalDNS(t *testing.T) {
+	successCases := []*v1alpha1.ExternalDNS{
+		{Hostnames: []string{"tidb.example.com"}},
+		{Hostnames: []string{"tidb.example.com", "*.tidb.example.com"}, TTL: pointer.Int32Ptr(60), VerifyPropagation: true},
+	}
+
+	for _, c := range successCases {
+		errs := validateExternalDNS(c, field.NewPath("externalDNS"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.ExternalDNS{
+		{},
+		{Hostnames: []string{"TiDB_example.com"}},
+		{Hostnames: []string{"tidb.example.com"}, TTL: pointer.Int32Ptr(0)},
+	}
+
+	for _, c := range errorCases {
+		errs := validateExternalDNS(c, field.NewPath("externalDNS"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateTiDBProxyProtocol(t *testing.T) {
+	newSpec := func(svcType corev1.ServiceType, proxy *v1alpha1.TiDBProxyProtocol) *v1alpha1.TiDBSpec {
+		return &v1alpha1.TiDBSpec{
+			Service: &v1alpha1.TiDBServiceSpec{
+				ServiceSpec:   v1alpha1.ServiceSpec{Type: svcType},
+				ProxyProtocol: proxy,
+			},
+		}
+	}
+
+	successCases := []*v1alpha1.TiDBSpec{
+		newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Provider: v1alpha1.TiDBProxyProtocolProviderAWS, Networks: []string{"*"}}),
+		newSpec(corev1.ServiceTypeNodePort, &v1alpha1.TiDBProxyProtocol{Networks: []string{"10.0.0.1", "192.168.0.0/16"}, HeaderTimeout: pointer.Int32Ptr(5)}),
+	}
+
+	for _, c := range successCases {
+		errs := validateTiDBProxyProtocol(c, field.NewPath("proxyProtocol"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	withConfig := newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Networks: []string{"*"}})
+	withConfig.Config = v1alpha1.NewTiDBConfig()
+	withConfig.Config.Set("proxy-protocol.networks", "*")
+	withZoneLocal := newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Networks: []string{"*"}})
+	withZoneLocal.Service.ZoneLocal = &v1alpha1.TiDBZoneLocalRouting{Mode: v1alpha1.TiDBZoneLocalModePerZoneService}
+	errorCases := []*v1alpha1.TiDBSpec{
+		newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{}),
+		newSpec(corev1.ServiceTypeClusterIP, &v1alpha1.TiDBProxyProtocol{Provider: v1alpha1.TiDBProxyProtocolProviderAWS, Networks: []string{"*"}}),
+		newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Provider: "gcp", Networks: []string{"*"}}),
+		newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Networks: []string{"10.0.0.0/33"}}),
+		newSpec(corev1.ServiceTypeLoadBalancer, &v1alpha1.TiDBProxyProtocol{Networks: []string{"*"}, HeaderTimeout: pointer.Int32Ptr(0)}),
+		withConfig,
+		withZoneLocal,
+	}
+
+	for _, c := range errorCases {
+		errs := validateTiDBProxyProtocol(c, field.NewPath("proxyProtocol"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c.Service.ProxyProtocol)
+		}
+	}
+}
+
+func TestValidateTiKVGroups(t *testing.T) {
+	newGroup := func(name string) v1alpha1.TiKVGroup {
+		group := v1alpha1.TiKVGroup{Name: name}
+		group.Spec.ResourceRequirements.Requests = corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse("100Gi"),
+		}
+		return group
+	}
+
+	successCases := []*v1alpha1.TidbClusterSpec{
+		{TiKVGroups: []v1alpha1.TiKVGroup{newGroup("hot"), newGroup("cold")}},
+	}
+
+	for _, c := range successCases {
+		errs := validateTiKVGroups(c, field.NewPath("tikvGroups"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.TidbClusterSpec{
+		{TiKVGroups: []v1alpha1.TiKVGroup{newGroup("")}},
+		{TiKVGroups: []v1alpha1.TiKVGroup{newGroup("Hot_Group")}},
+		{TiKVGroups: []v1alpha1.TiKVGroup{newGroup("hot"), newGroup("hot")}},
+		// storage request is required
+		{TiKVGroups: []v1alpha1.TiKVGroup{{Name: "hot"}}},
+		{
+			Cluster:    &v1alpha1.TidbClusterRef{Name: "basic"},
+			TiKVGroups: []v1alpha1.TiKVGroup{newGroup("hot")},
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validateTiKVGroups(c, field.NewPath("tikvGroups"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidatePDMS(t *testing.T) {
+	msPD := &v1alpha1.PDSpec{Mode: v1alpha1.PDModeMicroservice}
+	newMS := func(name string) *v1alpha1.PDMSSpec {
+		return &v1alpha1.PDMSSpec{Name: name, Replicas: 1}
+	}
+
+	successCases := []*v1alpha1.TidbClusterSpec{
+		{PD: msPD, PDMS: []*v1alpha1.PDMSSpec{newMS("tso")}},
+		{PD: msPD, PDMS: []*v1alpha1.PDMSSpec{newMS("tso"), newMS("scheduling")}},
+	}
+
+	for _, c := range successCases {
+		errs := validatePDMS(c, field.NewPath("pdms"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.TidbClusterSpec{
+		// pd is not in the microservice mode
+		{PD: &v1alpha1.PDSpec{}, PDMS: []*v1alpha1.PDMSSpec{newMS("tso")}},
+		{PDMS: []*v1alpha1.PDMSSpec{newMS("tso")}},
+		// tso is required
+		{PD: msPD},
+		{PD: msPD, PDMS: []*v1alpha1.PDMSSpec{newMS("scheduling")}},
+		{PD: msPD, PDMS: []*v1alpha1.PDMSSpec{newMS("tso"), newMS("router")}},
+		{PD: msPD, PDMS: []*v1alpha1.PDMSSpec{newMS("tso"), newMS("tso")}},
+	}
+
+	for _, c := range errorCases {
+		errs := validatePDMS(c, field.NewPath("pdms"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
 func TestValidateTidbMonitor(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -639,6 +972,35 @@ func TestValidateEvictLeaderTimeout(t *testing.T) {
 	}
 }
 
+func TestValidatePodDisruptionBudget(t *testing.T) {
+	one := intstr.FromInt(1)
+	half := intstr.FromString("50%")
+	successCases := []*v1alpha1.PodDisruptionBudgetSpec{
+		nil,
+		{},
+		{MaxUnavailable: &one},
+		{MinAvailable: &half},
+	}
+
+	for _, c := range successCases {
+		errs := validatePodDisruptionBudget(c, field.NewPath("podDisruptionBudget"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []*v1alpha1.PodDisruptionBudgetSpec{
+		{MaxUnavailable: &one, MinAvailable: &half},
+	}
+
+	for _, c := range errorCases {
+		errs := validatePodDisruptionBudget(c, field.NewPath("podDisruptionBudget"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
 func TestValidatePromDurationStr(t *testing.T) {
 	successCases := []*string{
 		nil,
@@ -709,6 +1071,64 @@ func TestValidatePDAddresses(t *testing.T) {
 	}
 }
 
+func TestValidateResourceOverride(t *testing.T) {
+	successCases := []v1alpha1.ResourceOverride{
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "basic-tikv",
+			Patch: "spec:\n  minReadySeconds: 10\n",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindService,
+			Name:  "basic-tidb",
+			Type:  v1alpha1.ResourceOverridePatchTypeJSON,
+			Patch: `[{"op": "add", "path": "/spec/externalTrafficPolicy", "value": "Local"}]`,
+		},
+	}
+
+	for _, c := range successCases {
+		errs := validateResourceOverride(&c, field.NewPath("overrides").Index(0))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.ResourceOverride{
+		{
+			Kind:  "Deployment",
+			Name:  "basic-discovery",
+			Patch: "spec: {}",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Patch: "spec: {}",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "basic-tikv",
+			Patch: `[{"op": "remove", "path": "/spec/minReadySeconds"}]`,
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "basic-tikv",
+			Type:  v1alpha1.ResourceOverridePatchTypeJSON,
+			Patch: "spec: {}",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "basic-tikv",
+			Patch: "spec: [",
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validateResourceOverride(&c, field.NewPath("overrides").Index(0))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
 func TestValidatePDSpec(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -756,6 +1176,54 @@ func TestValidatePDSpec(t *testing.T) {
 	}
 }
 
+func Test_disallowMutateTiFlashDisaggregated(t *testing.T) {
+	g := NewGomegaWithT(t)
+	disaggregated := &v1alpha1.TiFlashDisaggregatedSpec{
+		S3: v1alpha1.TiFlashS3Storage{Endpoint: "http://minio:9000", Bucket: "tiflash"},
+	}
+	tests := []struct {
+		name      string
+		old       *v1alpha1.TiFlashSpec
+		new       *v1alpha1.TiFlashSpec
+		wantError bool
+	}{
+		{
+			name:      "tiflash is added",
+			old:       nil,
+			new:       &v1alpha1.TiFlashSpec{Disaggregated: disaggregated},
+			wantError: false,
+		},
+		{
+			name:      "the compute nodes are changed",
+			old:       &v1alpha1.TiFlashSpec{Disaggregated: disaggregated},
+			new:       &v1alpha1.TiFlashSpec{Disaggregated: &v1alpha1.TiFlashDisaggregatedSpec{ComputeNode: v1alpha1.TiFlashComputeNodeSpec{Replicas: 2}}},
+			wantError: false,
+		},
+		{
+			name:      "disaggregated is enabled",
+			old:       &v1alpha1.TiFlashSpec{},
+			new:       &v1alpha1.TiFlashSpec{Disaggregated: disaggregated},
+			wantError: true,
+		},
+		{
+			name:      "disaggregated is disabled",
+			old:       &v1alpha1.TiFlashSpec{Disaggregated: disaggregated},
+			new:       &v1alpha1.TiFlashSpec{},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := disallowMutateTiFlashDisaggregated(tt.old, tt.new, field.NewPath("spec.tiflash.disaggregated"))
+			if tt.wantError {
+				g.Expect(len(errs)).NotTo(Equal(0))
+			} else {
+				g.Expect(len(errs)).To(Equal(0))
+			}
+		})
+	}
+}
+
 func Test_disallowMutateBootstrapSQLConfigMapName(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -130,6 +131,11 @@ func (in *BRConfig) DeepCopyInto(out *BRConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Options != nil {
 		in, out := &in.Options, &out.Options
 		*out = make([]string, len(*in))
@@ -237,6 +243,70 @@ func (in *BackupCondition) DeepCopy() *BackupCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
+	*out = *in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimate) DeepCopyInto(out *BackupEstimate) {
+	*out = *in
+	in.EstimatedTime.DeepCopyInto(&out.EstimatedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimate.
+func (in *BackupEstimate) DeepCopy() *BackupEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimateSpec) DeepCopyInto(out *BackupEstimateSpec) {
+	*out = *in
+	if in.StorageQuota != nil {
+		in, out := &in.StorageQuota, &out.StorageQuota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RejectOnQuotaExceeded != nil {
+		in, out := &in.RejectOnQuotaExceeded, &out.RejectOnQuotaExceeded
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimateSpec.
+func (in *BackupEstimateSpec) DeepCopy() *BackupEstimateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -350,6 +420,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 		*out = new(BackupSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LogBackupRetention != nil {
+		in, out := &in.LogBackupRetention, &out.LogBackupRetention
+		*out = new(string)
+		**out = **in
+	}
 	if in.StorageClassName != nil {
 		in, out := &in.StorageClassName, &out.StorageClassName
 		*out = new(string)
@@ -389,6 +464,13 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 		in, out := &in.AllBackupCleanTime, &out.AllBackupCleanTime
 		*out = (*in).DeepCopy()
 	}
+	if in.RestorablePoints != nil {
+		in, out := &in.RestorablePoints, &out.RestorablePoints
+		*out = make([]RestorablePoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -434,6 +516,11 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = new(BRConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Dumpling != nil {
 		in, out := &in.Dumpling, &out.Dumpling
 		*out = new(DumplingConfig)
@@ -472,6 +559,16 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.BackoffRetryPolicy = in.BackoffRetryPolicy
+	if in.Estimate != nil {
+		in, out := &in.Estimate, &out.Estimate
+		*out = new(BackupEstimateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(BackupVerifySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -518,6 +615,16 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Estimate != nil {
+		in, out := &in.Estimate, &out.Estimate
+		*out = new(BackupEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableFilter != nil {
+		in, out := &in.TableFilter, &out.TableFilter
+		*out = new(BackupTableFilterResult)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -531,6 +638,54 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTableFilterResult) DeepCopyInto(out *BackupTableFilterResult) {
+	*out = *in
+	if in.UnmatchedRules != nil {
+		in, out := &in.UnmatchedRules, &out.UnmatchedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedTime.DeepCopyInto(&out.ResolvedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTableFilterResult.
+func (in *BackupTableFilterResult) DeepCopy() *BackupTableFilterResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTableFilterResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerifySpec) DeepCopyInto(out *BackupVerifySpec) {
+	*out = *in
+	if in.ScratchCluster != nil {
+		in, out := &in.ScratchCluster, &out.ScratchCluster
+		*out = new(TidbClusterRef)
+		**out = **in
+	}
+	if in.TableFilter != nil {
+		in, out := &in.TableFilter, &out.TableFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerifySpec.
+func (in *BackupVerifySpec) DeepCopy() *BackupVerifySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerifySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -691,6 +846,34 @@ func (in *CDCConfigWraper) DeepCopy() *CDCConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservation) DeepCopyInto(out *CapacityReservation) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservation.
+func (in *CapacityReservation) DeepCopy() *CapacityReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanOption) DeepCopyInto(out *CleanOption) {
 	*out = *in
@@ -850,6 +1033,22 @@ func (in *CommonConfig) DeepCopy() *CommonConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentCostEstimate) DeepCopyInto(out *ComponentCostEstimate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentCostEstimate.
+func (in *ComponentCostEstimate) DeepCopy() *ComponentCostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentCostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 	*out = *in
@@ -954,6 +1153,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NativeSidecarContainers != nil {
+		in, out := &in.NativeSidecarContainers, &out.NativeSidecarContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AdditionalVolumes != nil {
 		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
 		*out = make([]v1.Volume, len(*in))
@@ -1221,7 +1425,7 @@ func (in *DMClusterSpec) DeepCopyInto(out *DMClusterSpec) {
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLSClientSecretNames != nil {
 		in, out := &in.TLSClientSecretNames, &out.TLSClientSecretNames
@@ -1338,6 +1542,11 @@ func (in *DMDiscoverySpec) DeepCopyInto(out *DMDiscoverySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1426,86 +1635,27 @@ func (in *DMSecurityConfig) DeepCopy() *DMSecurityConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
-	*out = *in
-	if in.TiDBCAPath != nil {
-		in, out := &in.TiDBCAPath, &out.TiDBCAPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.TiDBCertPath != nil {
-		in, out := &in.TiDBCertPath, &out.TiDBCertPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.TiDBKeyPath != nil {
-		in, out := &in.TiDBKeyPath, &out.TiDBKeyPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.PublicPathPrefix != nil {
-		in, out := &in.PublicPathPrefix, &out.PublicPathPrefix
-		*out = new(string)
-		**out = **in
-	}
-	if in.InternalProxy != nil {
-		in, out := &in.InternalProxy, &out.InternalProxy
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisableTelemetry != nil {
-		in, out := &in.DisableTelemetry, &out.DisableTelemetry
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EnableTelemetry != nil {
-		in, out := &in.EnableTelemetry, &out.EnableTelemetry
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EnableExperimental != nil {
-		in, out := &in.EnableExperimental, &out.EnableExperimental
-		*out = new(bool)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
-func (in *DashboardConfig) DeepCopy() *DashboardConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(DashboardConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataResource) DeepCopyInto(out *DataResource) {
+func (in *DMTask) DeepCopyInto(out *DMTask) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResource.
-func (in *DataResource) DeepCopy() *DataResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTask.
+func (in *DMTask) DeepCopy() *DMTask {
 	if in == nil {
 		return nil
 	}
-	out := new(DataResource)
+	out := new(DMTask)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataResource) DeepCopyObject() runtime.Object {
+func (in *DMTask) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1513,13 +1663,13 @@ func (in *DataResource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataResourceList) DeepCopyInto(out *DataResourceList) {
+func (in *DMTaskList) DeepCopyInto(out *DMTaskList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DataResource, len(*in))
+		*out = make([]DMTask, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1527,18 +1677,18 @@ func (in *DataResourceList) DeepCopyInto(out *DataResourceList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResourceList.
-func (in *DataResourceList) DeepCopy() *DataResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskList.
+func (in *DMTaskList) DeepCopy() *DMTaskList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataResourceList)
+	out := new(DMTaskList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataResourceList) DeepCopyObject() runtime.Object {
+func (in *DMTaskList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1546,55 +1696,452 @@ func (in *DataResourceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentStorageStatus) DeepCopyInto(out *DeploymentStorageStatus) {
+func (in *DMTaskSource) DeepCopyInto(out *DMTaskSource) {
 	*out = *in
+	if in.BinlogPos != nil {
+		in, out := &in.BinlogPos, &out.BinlogPos
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStorageStatus.
-func (in *DeploymentStorageStatus) DeepCopy() *DeploymentStorageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskSource.
+func (in *DMTaskSource) DeepCopy() *DMTaskSource {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStorageStatus)
+	out := new(DMTaskSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
+func (in *DMTaskSourceStatus) DeepCopyInto(out *DMTaskSourceStatus) {
 	*out = *in
-	if in.ComponentSpec != nil {
-		in, out := &in.ComponentSpec, &out.ComponentSpec
-		*out = new(ComponentSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoverySpec.
-func (in *DiscoverySpec) DeepCopy() *DiscoverySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskSourceStatus.
+func (in *DMTaskSourceStatus) DeepCopy() *DMTaskSourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DiscoverySpec)
+	out := new(DMTaskSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DumplingConfig) DeepCopyInto(out *DumplingConfig) {
+func (in *DMTaskSpec) DeepCopyInto(out *DMTaskSpec) {
 	*out = *in
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.TableFilter != nil {
-		in, out := &in.TableFilter, &out.TableFilter
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]DMTaskSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskSpec.
+func (in *DMTaskSpec) DeepCopy() *DMTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskStatus) DeepCopyInto(out *DMTaskStatus) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]DMTaskSourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskStatus.
+func (in *DMTaskStatus) DeepCopy() *DMTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
+	*out = *in
+	if in.TiDBCAPath != nil {
+		in, out := &in.TiDBCAPath, &out.TiDBCAPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TiDBCertPath != nil {
+		in, out := &in.TiDBCertPath, &out.TiDBCertPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TiDBKeyPath != nil {
+		in, out := &in.TiDBKeyPath, &out.TiDBKeyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicPathPrefix != nil {
+		in, out := &in.PublicPathPrefix, &out.PublicPathPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.InternalProxy != nil {
+		in, out := &in.InternalProxy, &out.InternalProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableTelemetry != nil {
+		in, out := &in.DisableTelemetry, &out.DisableTelemetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableTelemetry != nil {
+		in, out := &in.EnableTelemetry, &out.EnableTelemetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableExperimental != nil {
+		in, out := &in.EnableExperimental, &out.EnableExperimental
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
+func (in *DashboardConfig) DeepCopy() *DashboardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataResource) DeepCopyInto(out *DataResource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResource.
+func (in *DataResource) DeepCopy() *DataResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataResourceList) DeepCopyInto(out *DataResourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResourceList.
+func (in *DataResourceList) DeepCopy() *DataResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStorageStatus) DeepCopyInto(out *DeploymentStorageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStorageStatus.
+func (in *DeploymentStorageStatus) DeepCopy() *DeploymentStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Diagnostic) DeepCopyInto(out *Diagnostic) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Diagnostic.
+func (in *Diagnostic) DeepCopy() *Diagnostic {
+	if in == nil {
+		return nil
+	}
+	out := new(Diagnostic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Diagnostic) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticList) DeepCopyInto(out *DiagnosticList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Diagnostic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticList.
+func (in *DiagnosticList) DeepCopy() *DiagnosticList {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiagnosticList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticRedaction) DeepCopyInto(out *DiagnosticRedaction) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticRedaction.
+func (in *DiagnosticRedaction) DeepCopy() *DiagnosticRedaction {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticRedaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticSpec) DeepCopyInto(out *DiagnosticSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]MemberType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DiagnosticItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProfileDuration != nil {
+		in, out := &in.ProfileDuration, &out.ProfileDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LogTailLines != nil {
+		in, out := &in.LogTailLines, &out.LogTailLines
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Redaction != nil {
+		in, out := &in.Redaction, &out.Redaction
+		*out = new(DiagnosticRedaction)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StorageProvider.DeepCopyInto(&out.StorageProvider)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticSpec.
+func (in *DiagnosticSpec) DeepCopy() *DiagnosticSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticStatus) DeepCopyInto(out *DiagnosticStatus) {
+	*out = *in
+	in.TimeStarted.DeepCopyInto(&out.TimeStarted)
+	in.TimeCompleted.DeepCopyInto(&out.TimeCompleted)
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticStatus.
+func (in *DiagnosticStatus) DeepCopy() *DiagnosticStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
+	*out = *in
+	if in.ComponentSpec != nil {
+		in, out := &in.ComponentSpec, &out.ComponentSpec
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoverySpec.
+func (in *DiscoverySpec) DeepCopy() *DiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DumplingConfig) DeepCopyInto(out *DumplingConfig) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TableFilter != nil {
+		in, out := &in.TableFilter, &out.TableFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
@@ -1686,6 +2233,32 @@ func (in *ExternalConfig) DeepCopy() *ExternalConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNS) DeepCopyInto(out *ExternalDNS) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNS.
+func (in *ExternalDNS) DeepCopy() *ExternalDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalEndpoint) DeepCopyInto(out *ExternalEndpoint) {
 	*out = *in
@@ -1710,6 +2283,11 @@ func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Failover) DeepCopyInto(out *Failover) {
 	*out = *in
+	if in.Detection != nil {
+		in, out := &in.Detection, &out.Detection
+		*out = new(FailoverDetection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1723,6 +2301,32 @@ func (in *Failover) DeepCopy() *Failover {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverDetection) DeepCopyInto(out *FailoverDetection) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ProbeFailureThreshold != nil {
+		in, out := &in.ProbeFailureThreshold, &out.ProbeFailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverDetection.
+func (in *FailoverDetection) DeepCopy() *FailoverDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileLogConfig) DeepCopyInto(out *FileLogConfig) {
 	*out = *in
@@ -2045,104 +2649,202 @@ func (in *FlashSecurity) DeepCopyInto(out *FlashSecurity) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.KeyPath != nil {
-		in, out := &in.KeyPath, &out.KeyPath
+	if in.KeyPath != nil {
+		in, out := &in.KeyPath, &out.KeyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertAllowedCN != nil {
+		in, out := &in.CertAllowedCN, &out.CertAllowedCN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashSecurity.
+func (in *FlashSecurity) DeepCopy() *FlashSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashServerConfig) DeepCopyInto(out *FlashServerConfig) {
+	*out = *in
+	if in.EngineAddr != nil {
+		in, out := &in.EngineAddr, &out.EngineAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusAddr != nil {
+		in, out := &in.StatusAddr, &out.StatusAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdvertiseStatusAddr != nil {
+		in, out := &in.AdvertiseStatusAddr, &out.AdvertiseStatusAddr
+		*out = new(string)
+		**out = **in
+	}
+	in.TiKVServerConfig.DeepCopyInto(&out.TiKVServerConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashServerConfig.
+func (in *FlashServerConfig) DeepCopy() *FlashServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashStatus) DeepCopyInto(out *FlashStatus) {
+	*out = *in
+	if in.MetricsPort != nil {
+		in, out := &in.MetricsPort, &out.MetricsPort
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashStatus.
+func (in *FlashStatus) DeepCopy() *FlashStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashUser) DeepCopyInto(out *FlashUser) {
+	*out = *in
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(User)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(User)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashUser.
+func (in *FlashUser) DeepCopy() *FlashUser {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCTuningPolicy) DeepCopyInto(out *GCTuningPolicy) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]GCTuningWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
 		*out = new(string)
 		**out = **in
 	}
-	if in.CertAllowedCN != nil {
-		in, out := &in.CertAllowedCN, &out.CertAllowedCN
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashSecurity.
-func (in *FlashSecurity) DeepCopy() *FlashSecurity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCTuningPolicy.
+func (in *GCTuningPolicy) DeepCopy() *GCTuningPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(FlashSecurity)
+	out := new(GCTuningPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FlashServerConfig) DeepCopyInto(out *FlashServerConfig) {
+func (in *GCTuningSettings) DeepCopyInto(out *GCTuningSettings) {
 	*out = *in
-	if in.EngineAddr != nil {
-		in, out := &in.EngineAddr, &out.EngineAddr
+	if in.GCLifeTime != nil {
+		in, out := &in.GCLifeTime, &out.GCLifeTime
 		*out = new(string)
 		**out = **in
 	}
-	if in.StatusAddr != nil {
-		in, out := &in.StatusAddr, &out.StatusAddr
-		*out = new(string)
-		**out = **in
-	}
-	if in.AdvertiseStatusAddr != nil {
-		in, out := &in.AdvertiseStatusAddr, &out.AdvertiseStatusAddr
-		*out = new(string)
+	if in.GCConcurrency != nil {
+		in, out := &in.GCConcurrency, &out.GCConcurrency
+		*out = new(int32)
 		**out = **in
 	}
-	in.TiKVServerConfig.DeepCopyInto(&out.TiKVServerConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashServerConfig.
-func (in *FlashServerConfig) DeepCopy() *FlashServerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCTuningSettings.
+func (in *GCTuningSettings) DeepCopy() *GCTuningSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(FlashServerConfig)
+	out := new(GCTuningSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FlashStatus) DeepCopyInto(out *FlashStatus) {
+func (in *GCTuningStatus) DeepCopyInto(out *GCTuningStatus) {
 	*out = *in
-	if in.MetricsPort != nil {
-		in, out := &in.MetricsPort, &out.MetricsPort
-		*out = new(int32)
-		**out = **in
+	if in.WindowEnd != nil {
+		in, out := &in.WindowEnd, &out.WindowEnd
+		*out = (*in).DeepCopy()
+	}
+	if in.OriginalSettings != nil {
+		in, out := &in.OriginalSettings, &out.OriginalSettings
+		*out = new(GCTuningSettings)
+		(*in).DeepCopyInto(*out)
 	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashStatus.
-func (in *FlashStatus) DeepCopy() *FlashStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCTuningStatus.
+func (in *GCTuningStatus) DeepCopy() *GCTuningStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FlashStatus)
+	out := new(GCTuningStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FlashUser) DeepCopyInto(out *FlashUser) {
+func (in *GCTuningWindow) DeepCopyInto(out *GCTuningWindow) {
 	*out = *in
-	if in.Readonly != nil {
-		in, out := &in.Readonly, &out.Readonly
-		*out = new(User)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(User)
-		(*in).DeepCopyInto(*out)
-	}
+	out.Duration = in.Duration
+	in.GCTuningSettings.DeepCopyInto(&out.GCTuningSettings)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashUser.
-func (in *FlashUser) DeepCopy() *FlashUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCTuningWindow.
+func (in *GCTuningWindow) DeepCopy() *GCTuningWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(FlashUser)
+	out := new(GCTuningWindow)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2236,6 +2938,22 @@ func (in *HelperSpec) DeepCopy() *HelperSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageDigest) DeepCopyInto(out *ImageDigest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDigest.
+func (in *ImageDigest) DeepCopy() *ImageDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
 	*out = *in
@@ -2288,6 +3006,48 @@ func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitSqlSource) DeepCopyInto(out *InitSqlSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitSqlSource.
+func (in *InitSqlSource) DeepCopy() *InitSqlSource {
+	if in == nil {
+		return nil
+	}
+	out := new(InitSqlSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitSqlSourceStatus) DeepCopyInto(out *InitSqlSourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitSqlSourceStatus.
+func (in *InitSqlSourceStatus) DeepCopy() *InitSqlSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InitSqlSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InitializerSpec) DeepCopyInto(out *InitializerSpec) {
 	*out = *in
@@ -2818,6 +3578,27 @@ func (in *MasterStatus) DeepCopy() *MasterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberSummary) DeepCopyInto(out *MemberSummary) {
+	*out = *in
+	if in.Unhealthy != nil {
+		in, out := &in.Unhealthy, &out.Unhealthy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberSummary.
+func (in *MemberSummary) DeepCopy() *MemberSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataConfig) DeepCopyInto(out *MetadataConfig) {
 	*out = *in
@@ -2856,6 +3637,58 @@ func (in *MonitorContainer) DeepCopy() *MonitorContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NGMonitoringConprofOffload) DeepCopyInto(out *NGMonitoringConprofOffload) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3StorageProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(GcsStorageProvider)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NGMonitoringConprofOffload.
+func (in *NGMonitoringConprofOffload) DeepCopy() *NGMonitoringConprofOffload {
+	if in == nil {
+		return nil
+	}
+	out := new(NGMonitoringConprofOffload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NGMonitoringConprofSpec) DeepCopyInto(out *NGMonitoringConprofSpec) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Offload != nil {
+		in, out := &in.Offload, &out.Offload
+		*out = new(NGMonitoringConprofOffload)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NGMonitoringConprofSpec.
+func (in *NGMonitoringConprofSpec) DeepCopy() *NGMonitoringConprofSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NGMonitoringConprofSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NGMonitoringSpec) DeepCopyInto(out *NGMonitoringSpec) {
 	*out = *in
@@ -2932,6 +3765,60 @@ func (in *Networks) DeepCopy() *Networks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSpec) DeepCopyInto(out *NotificationSpec) {
+	*out = *in
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]NotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSpec.
+func (in *NotificationSpec) DeepCopy() *NotificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservedStorageVolumeStatus) DeepCopyInto(out *ObservedStorageVolumeStatus) {
 	*out = *in
@@ -3064,6 +3951,37 @@ func (in *OpenTracingSampler) DeepCopy() *OpenTracingSampler {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationTimeoutPolicy) DeepCopyInto(out *OperationTimeoutPolicy) {
+	*out = *in
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleIn != nil {
+		in, out := &in.ScaleIn, &out.ScaleIn
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleOut != nil {
+		in, out := &in.ScaleOut, &out.ScaleOut
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationTimeoutPolicy.
+func (in *OperationTimeoutPolicy) DeepCopy() *OperationTimeoutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationTimeoutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDConfig) DeepCopyInto(out *PDConfig) {
 	*out = *in
@@ -3290,6 +4208,22 @@ func (in PDLabelPropertyConfig) DeepCopy() PDLabelPropertyConfig {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDLeaderLocality) DeepCopyInto(out *PDLeaderLocality) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLeaderLocality.
+func (in *PDLeaderLocality) DeepCopy() *PDLeaderLocality {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLeaderLocality)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDLogConfig) DeepCopyInto(out *PDLogConfig) {
 	*out = *in
@@ -3323,25 +4257,74 @@ func (in *PDLogConfig) DeepCopyInto(out *PDLogConfig) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.DisableStacktrace != nil {
-		in, out := &in.DisableStacktrace, &out.DisableStacktrace
-		*out = new(bool)
-		**out = **in
+	if in.DisableStacktrace != nil {
+		in, out := &in.DisableStacktrace, &out.DisableStacktrace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableErrorVerbose != nil {
+		in, out := &in.DisableErrorVerbose, &out.DisableErrorVerbose
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLogConfig.
+func (in *PDLogConfig) DeepCopy() *PDLogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDMSSpec) DeepCopyInto(out *PDMSSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(PDConfigWraper)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDMSSpec.
+func (in *PDMSSpec) DeepCopy() *PDMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDMSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDMSStatus) DeepCopyInto(out *PDMSStatus) {
+	*out = *in
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(appsv1.StatefulSetStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.DisableErrorVerbose != nil {
-		in, out := &in.DisableErrorVerbose, &out.DisableErrorVerbose
-		*out = new(bool)
-		**out = **in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLogConfig.
-func (in *PDLogConfig) DeepCopy() *PDLogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDMSStatus.
+func (in *PDMSStatus) DeepCopy() *PDMSStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PDLogConfig)
+	out := new(PDMSStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3440,6 +4423,161 @@ func (in *PDNamespaceConfig) DeepCopy() *PDNamespaceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecovery) DeepCopyInto(out *PDRecovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecovery.
+func (in *PDRecovery) DeepCopy() *PDRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PDRecovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecoveryList) DeepCopyInto(out *PDRecoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PDRecovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecoveryList.
+func (in *PDRecoveryList) DeepCopy() *PDRecoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PDRecoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecoverySpec) DeepCopyInto(out *PDRecoverySpec) {
+	*out = *in
+	if in.ConfirmedSteps != nil {
+		in, out := &in.ConfirmedSteps, &out.ConfirmedSteps
+		*out = make([]PDRecoveryStep, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecoverySpec.
+func (in *PDRecoverySpec) DeepCopy() *PDRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecoveryStatus) DeepCopyInto(out *PDRecoveryStatus) {
+	*out = *in
+	if in.RebuiltMembers != nil {
+		in, out := &in.RebuiltMembers, &out.RebuiltMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedStores != nil {
+		in, out := &in.FailedStores, &out.FailedStores
+		*out = make([]uint64, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecoveryStatus.
+func (in *PDRecoveryStatus) DeepCopy() *PDRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecoveryStepStatus) DeepCopyInto(out *PDRecoveryStepStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecoveryStepStatus.
+func (in *PDRecoveryStepStatus) DeepCopy() *PDRecoveryStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecoveryStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDRecoveryWorkflowStatus) DeepCopyInto(out *PDRecoveryWorkflowStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]PDRecoveryStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDRecoveryWorkflowStatus.
+func (in *PDRecoveryWorkflowStatus) DeepCopy() *PDRecoveryWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDRecoveryWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDReplicationConfig) DeepCopyInto(out *PDReplicationConfig) {
 	*out = *in
@@ -3761,6 +4899,11 @@ func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageClassName != nil {
 		in, out := &in.StorageClassName, &out.StorageClassName
 		*out = new(string)
@@ -3793,6 +4936,21 @@ func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderLocality != nil {
+		in, out := &in.LeaderLocality, &out.LeaderLocality
+		*out = new(PDLeaderLocality)
+		**out = **in
+	}
+	if in.ScaleInHook != nil {
+		in, out := &in.ScaleInHook, &out.ScaleInHook
+		*out = new(ScaleInHook)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3865,6 +5023,16 @@ func (in *PDStatus) DeepCopyInto(out *PDStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Recovery != nil {
+		in, out := &in.Recovery, &out.Recovery
+		*out = new(PDRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageDigest != nil {
+		in, out := &in.ImageDigest, &out.ImageDigest
+		*out = new(ImageDigest)
+		**out = **in
+	}
 	return
 }
 
@@ -3926,6 +5094,22 @@ func (in PDStoreLabels) DeepCopy() PDStoreLabels {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerRegistry) DeepCopyInto(out *PeerRegistry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerRegistry.
+func (in *PeerRegistry) DeepCopy() *PeerRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Performance) DeepCopyInto(out *Performance) {
 	*out = *in
@@ -4110,6 +5294,32 @@ func (in *Plugin) DeepCopy() *Plugin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreparedPlanCache) DeepCopyInto(out *PreparedPlanCache) {
 	*out = *in
@@ -4595,6 +5805,49 @@ func (in *Quota) DeepCopy() *Quota {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftLogVolumeClaim) DeepCopyInto(out *RaftLogVolumeClaim) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftLogVolumeClaim.
+func (in *RaftLogVolumeClaim) DeepCopy() *RaftLogVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftLogVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegisteredPeer) DeepCopyInto(out *RegisteredPeer) {
+	*out = *in
+	if in.PDAddresses != nil {
+		in, out := &in.PDAddresses, &out.PDAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.RegisteredAt.DeepCopyInto(&out.RegisteredAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisteredPeer.
+func (in *RegisteredPeer) DeepCopy() *RegisteredPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisteredPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RelabelConfig) DeepCopyInto(out *RelabelConfig) {
 	*out = *in
@@ -4694,6 +5947,58 @@ func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOverride) DeepCopyInto(out *ResourceOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOverride.
+func (in *ResourceOverride) DeepCopy() *ResourceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOverrideStatus) DeepCopyInto(out *ResourceOverrideStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOverrideStatus.
+func (in *ResourceOverrideStatus) DeepCopy() *ResourceOverrideStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOverrideStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorablePoint) DeepCopyInto(out *RestorablePoint) {
+	*out = *in
+	if in.TimeCompleted != nil {
+		in, out := &in.TimeCompleted, &out.TimeCompleted
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorablePoint.
+func (in *RestorablePoint) DeepCopy() *RestorablePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorablePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
@@ -4772,6 +6077,88 @@ func (in *RestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResumeAttempt) DeepCopyInto(out *RestoreResumeAttempt) {
+	*out = *in
+	if in.DetectFailedAt != nil {
+		in, out := &in.DetectFailedAt, &out.DetectFailedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ResumedAt != nil {
+		in, out := &in.ResumedAt, &out.ResumedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResumeAttempt.
+func (in *RestoreResumeAttempt) DeepCopy() *RestoreResumeAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResumeAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResumePolicy) DeepCopyInto(out *RestoreResumePolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResumePolicy.
+func (in *RestoreResumePolicy) DeepCopy() *RestoreResumePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResumePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreScatterRegions) DeepCopyInto(out *RestoreScatterRegions) {
+	*out = *in
+	if in.RetryLimit != nil {
+		in, out := &in.RetryLimit, &out.RetryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreScatterRegions.
+func (in *RestoreScatterRegions) DeepCopy() *RestoreScatterRegions {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreScatterRegions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
+func (in *RestoreSource) DeepCopy() *RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 	*out = *in
@@ -4805,6 +6192,11 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(BRConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -4832,6 +6224,21 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResumePolicy != nil {
+		in, out := &in.ResumePolicy, &out.ResumePolicy
+		*out = new(RestoreResumePolicy)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(RestoreSource)
+		**out = **in
+	}
+	if in.ScatterRegions != nil {
+		in, out := &in.ScatterRegions, &out.ScatterRegions
+		*out = new(RestoreScatterRegions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4864,6 +6271,18 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResumeAttempts != nil {
+		in, out := &in.ResumeAttempts, &out.ResumeAttempts
+		*out = make([]RestoreResumeAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4877,6 +6296,66 @@ func (in *RestoreStatus) DeepCopy() *RestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationFailure) DeepCopyInto(out *RestoreVerificationFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationFailure.
+func (in *RestoreVerificationFailure) DeepCopy() *RestoreVerificationFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationStatus) DeepCopyInto(out *RestoreVerificationStatus) {
+	*out = *in
+	in.TimeStarted.DeepCopyInto(&out.TimeStarted)
+	in.TimeCompleted.DeepCopyInto(&out.TimeCompleted)
+	if in.FailedTables != nil {
+		in, out := &in.FailedTables, &out.FailedTables
+		*out = make([]RestoreVerificationFailure, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationStatus.
+func (in *RestoreVerificationStatus) DeepCopy() *RestoreVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateStrategy) DeepCopyInto(out *RollingUpdateStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStrategy.
+func (in *RollingUpdateStrategy) DeepCopy() *RollingUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3StorageProvider) DeepCopyInto(out *S3StorageProvider) {
 	*out = *in
@@ -4921,6 +6400,101 @@ func (in *SafeTLSConfig) DeepCopy() *SafeTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleInHTTPHook) DeepCopyInto(out *ScaleInHTTPHook) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInHTTPHook.
+func (in *ScaleInHTTPHook) DeepCopy() *ScaleInHTTPHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleInHTTPHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleInHook) DeepCopyInto(out *ScaleInHook) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ScaleInHTTPHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(ScaleInJobHook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInHook.
+func (in *ScaleInHook) DeepCopy() *ScaleInHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleInHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleInJobHook) DeepCopyInto(out *ScaleInJobHook) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInJobHook.
+func (in *ScaleInJobHook) DeepCopy() *ScaleInJobHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleInJobHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutBalanceGate) DeepCopyInto(out *ScaleOutBalanceGate) {
+	*out = *in
+	if in.MaxSkewPercent != nil {
+		in, out := &in.MaxSkewPercent, &out.MaxSkewPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutBalanceGate.
+func (in *ScaleOutBalanceGate) DeepCopy() *ScaleOutBalanceGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutBalanceGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalePolicy) DeepCopyInto(out *ScalePolicy) {
 	*out = *in
@@ -4989,6 +6563,27 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReplication) DeepCopyInto(out *SecretReplication) {
+	*out = *in
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReplication.
+func (in *SecretReplication) DeepCopy() *SecretReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Security) DeepCopyInto(out *Security) {
 	*out = *in
@@ -5103,6 +6698,26 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(ServiceInternalTrafficPolicyType)
+		**out = **in
+	}
+	if in.TrafficDistribution != nil {
+		in, out := &in.TrafficDistribution, &out.TrafficDistribution
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologyAwareRouting != nil {
+		in, out := &in.TopologyAwareRouting, &out.TopologyAwareRouting
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SessionAffinityConfig != nil {
+		in, out := &in.SessionAffinityConfig, &out.SessionAffinityConfig
+		*out = new(v1.SessionAffinityConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5152,6 +6767,27 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCompaction) DeepCopyInto(out *StatusCompaction) {
+	*out = *in
+	if in.MemberThreshold != nil {
+		in, out := &in.MemberThreshold, &out.MemberThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCompaction.
+func (in *StatusCompaction) DeepCopy() *StatusCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StmtSummary) DeepCopyInto(out *StmtSummary) {
 	*out = *in
@@ -5305,7 +6941,50 @@ func (in *SuspendAction) DeepCopy() *SuspendAction {
 	if in == nil {
 		return nil
 	}
-	out := new(SuspendAction)
+	out := new(SuspendAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCertIssuerRef) DeepCopyInto(out *TLSCertIssuerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCertIssuerRef.
+func (in *TLSCertIssuerRef) DeepCopy() *TLSCertIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCertIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCertManager) DeepCopyInto(out *TLSCertManager) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCertManager.
+func (in *TLSCertManager) DeepCopy() *TLSCertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCertManager)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5313,6 +6992,11 @@ func (in *SuspendAction) DeepCopy() *SuspendAction {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSCluster) DeepCopyInto(out *TLSCluster) {
 	*out = *in
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(TLSCertManager)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5343,6 +7027,26 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSRotationStatus) DeepCopyInto(out *TLSRotationStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSRotationStatus.
+func (in *TLSRotationStatus) DeepCopy() *TLSRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThanosSpec) DeepCopyInto(out *ThanosSpec) {
 	*out = *in
@@ -5408,6 +7112,139 @@ func (in *TiCDCCapture) DeepCopy() *TiCDCCapture {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeed) DeepCopyInto(out *TiCDCChangefeed) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeed.
+func (in *TiCDCChangefeed) DeepCopy() *TiCDCChangefeed {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TiCDCChangefeed) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeedFilter) DeepCopyInto(out *TiCDCChangefeedFilter) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreTxnStartTs != nil {
+		in, out := &in.IgnoreTxnStartTs, &out.IgnoreTxnStartTs
+		*out = make([]uint64, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeedFilter.
+func (in *TiCDCChangefeedFilter) DeepCopy() *TiCDCChangefeedFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeedFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeedList) DeepCopyInto(out *TiCDCChangefeedList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TiCDCChangefeed, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeedList.
+func (in *TiCDCChangefeedList) DeepCopy() *TiCDCChangefeedList {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeedList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TiCDCChangefeedList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeedSpec) DeepCopyInto(out *TiCDCChangefeedSpec) {
+	*out = *in
+	if in.SinkURISecretRef != nil {
+		in, out := &in.SinkURISecretRef, &out.SinkURISecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(TiCDCChangefeedFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeedSpec.
+func (in *TiCDCChangefeedSpec) DeepCopy() *TiCDCChangefeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeedStatus) DeepCopyInto(out *TiCDCChangefeedStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeedStatus.
+func (in *TiCDCChangefeedStatus) DeepCopy() *TiCDCChangefeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiCDCConfig) DeepCopyInto(out *TiCDCConfig) {
 	*out = *in
@@ -5476,6 +7313,11 @@ func (in *TiCDCSpec) DeepCopyInto(out *TiCDCSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5823,6 +7665,59 @@ func (in *TiDBConfigWraper) DeepCopy() *TiDBConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBConnectionPool) DeepCopyInto(out *TiDBConnectionPool) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(v1.PullPolicy)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxServerConnections != nil {
+		in, out := &in.MaxServerConnections, &out.MaxServerConnections
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBConnectionPool.
+func (in *TiDBConnectionPool) DeepCopy() *TiDBConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBConnectionSecret) DeepCopyInto(out *TiDBConnectionSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBConnectionSecret.
+func (in *TiDBConnectionSecret) DeepCopy() *TiDBConnectionSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBConnectionSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiDBFailureMember) DeepCopyInto(out *TiDBFailureMember) {
 	*out = *in
@@ -5830,45 +7725,166 @@ func (in *TiDBFailureMember) DeepCopyInto(out *TiDBFailureMember) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBFailureMember.
-func (in *TiDBFailureMember) DeepCopy() *TiDBFailureMember {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBFailureMember.
+func (in *TiDBFailureMember) DeepCopy() *TiDBFailureMember {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBFailureMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBInitializer) DeepCopyInto(out *TiDBInitializer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBInitializer.
+func (in *TiDBInitializer) DeepCopy() *TiDBInitializer {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBInitializer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBMember) DeepCopyInto(out *TiDBMember) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBMember.
+func (in *TiDBMember) DeepCopy() *TiDBMember {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBPluginArtifact) DeepCopyInto(out *TiDBPluginArtifact) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBPluginArtifact.
+func (in *TiDBPluginArtifact) DeepCopy() *TiDBPluginArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBPluginArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBProxyProtocol) DeepCopyInto(out *TiDBProxyProtocol) {
+	*out = *in
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeaderTimeout != nil {
+		in, out := &in.HeaderTimeout, &out.HeaderTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBProxyProtocol.
+func (in *TiDBProxyProtocol) DeepCopy() *TiDBProxyProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBProxyProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBRestartPolicy) DeepCopyInto(out *TiDBRestartPolicy) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxPodsPerRun != nil {
+		in, out := &in.MaxPodsPerRun, &out.MaxPodsPerRun
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBRestartPolicy.
+func (in *TiDBRestartPolicy) DeepCopy() *TiDBRestartPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(TiDBFailureMember)
+	out := new(TiDBRestartPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TiDBInitializer) DeepCopyInto(out *TiDBInitializer) {
+func (in *TiDBRestartStatus) DeepCopyInto(out *TiDBRestartStatus) {
 	*out = *in
+	in.ScheduleTime.DeepCopyInto(&out.ScheduleTime)
+	if in.RestartedPods != nil {
+		in, out := &in.RestartedPods, &out.RestartedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBInitializer.
-func (in *TiDBInitializer) DeepCopy() *TiDBInitializer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBRestartStatus.
+func (in *TiDBRestartStatus) DeepCopy() *TiDBRestartStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TiDBInitializer)
+	out := new(TiDBRestartStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TiDBMember) DeepCopyInto(out *TiDBMember) {
+func (in *TiDBSQLReadinessGate) DeepCopyInto(out *TiDBSQLReadinessGate) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBMember.
-func (in *TiDBMember) DeepCopy() *TiDBMember {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBSQLReadinessGate.
+func (in *TiDBSQLReadinessGate) DeepCopy() *TiDBSQLReadinessGate {
 	if in == nil {
 		return nil
 	}
-	out := new(TiDBMember)
+	out := new(TiDBSQLReadinessGate)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5904,6 +7920,21 @@ func (in *TiDBServiceSpec) DeepCopyInto(out *TiDBServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneLocal != nil {
+		in, out := &in.ZoneLocal, &out.ZoneLocal
+		*out = new(TiDBZoneLocalRouting)
+		**out = **in
+	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(TiDBProxyProtocol)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5964,6 +7995,11 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SeparateSlowLog != nil {
 		in, out := &in.SeparateSlowLog, &out.SeparateSlowLog
 		*out = new(bool)
@@ -5984,11 +8020,23 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TokenBasedAuth != nil {
+		in, out := &in.TokenBasedAuth, &out.TokenBasedAuth
+		*out = new(TiDBTokenBasedAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PluginArtifacts != nil {
+		in, out := &in.PluginArtifacts, &out.PluginArtifacts
+		*out = make([]TiDBPluginArtifact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(TiDBConfigWraper)
@@ -5999,6 +8047,21 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(v1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GracefulShutdownSeconds != nil {
+		in, out := &in.GracefulShutdownSeconds, &out.GracefulShutdownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(TiDBConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQLReadinessGate != nil {
+		in, out := &in.SQLReadinessGate, &out.SQLReadinessGate
+		*out = new(TiDBSQLReadinessGate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -6021,6 +8084,26 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(TiDBConnectionSecret)
+		**out = **in
+	}
+	if in.RollingUpdateStrategy != nil {
+		in, out := &in.RollingUpdateStrategy, &out.RollingUpdateStrategy
+		*out = new(RollingUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(TiDBRestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6061,6 +8144,11 @@ func (in *TiDBStatus) DeepCopyInto(out *TiDBStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(MemberSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make(map[StorageVolumeName]*StorageVolumeStatus, len(*in))
@@ -6083,6 +8171,16 @@ func (in *TiDBStatus) DeepCopyInto(out *TiDBStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Restart != nil {
+		in, out := &in.Restart, &out.Restart
+		*out = new(TiDBRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageDigest != nil {
+		in, out := &in.ImageDigest, &out.ImageDigest
+		*out = new(ImageDigest)
+		**out = **in
+	}
 	return
 }
 
@@ -6112,6 +8210,48 @@ func (in *TiDBTLSClient) DeepCopy() *TiDBTLSClient {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBTokenBasedAuth) DeepCopyInto(out *TiDBTokenBasedAuth) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBTokenBasedAuth.
+func (in *TiDBTokenBasedAuth) DeepCopy() *TiDBTokenBasedAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBTokenBasedAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBZoneLocalRouting) DeepCopyInto(out *TiDBZoneLocalRouting) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBZoneLocalRouting.
+func (in *TiDBZoneLocalRouting) DeepCopy() *TiDBZoneLocalRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBZoneLocalRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashCommonConfigWraper) DeepCopyInto(out *TiFlashCommonConfigWraper) {
 	*out = *in
@@ -6132,6 +8272,59 @@ func (in *TiFlashCommonConfigWraper) DeepCopy() *TiFlashCommonConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashComputeNodeSpec) DeepCopyInto(out *TiFlashComputeNodeSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.StorageClaims != nil {
+		in, out := &in.StorageClaims, &out.StorageClaims
+		*out = make([]StorageClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashComputeNodeSpec.
+func (in *TiFlashComputeNodeSpec) DeepCopy() *TiFlashComputeNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashComputeNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashComputeNodeStatus) DeepCopyInto(out *TiFlashComputeNodeStatus) {
+	*out = *in
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(appsv1.StatefulSetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make(map[string]TiKVStore, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashComputeNodeStatus.
+func (in *TiFlashComputeNodeStatus) DeepCopy() *TiFlashComputeNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashComputeNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashConfig) DeepCopyInto(out *TiFlashConfig) {
 	*out = *in
@@ -6184,6 +8377,24 @@ func (in *TiFlashConfigWraper) DeepCopy() *TiFlashConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashDisaggregatedSpec) DeepCopyInto(out *TiFlashDisaggregatedSpec) {
+	*out = *in
+	out.S3 = in.S3
+	in.ComputeNode.DeepCopyInto(&out.ComputeNode)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashDisaggregatedSpec.
+func (in *TiFlashDisaggregatedSpec) DeepCopy() *TiFlashDisaggregatedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashDisaggregatedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashProxyConfigWraper) DeepCopyInto(out *TiFlashProxyConfigWraper) {
 	*out = *in
@@ -6204,6 +8415,22 @@ func (in *TiFlashProxyConfigWraper) DeepCopy() *TiFlashProxyConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiFlashS3Storage) DeepCopyInto(out *TiFlashS3Storage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiFlashS3Storage.
+func (in *TiFlashS3Storage) DeepCopy() *TiFlashS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(TiFlashS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 	*out = *in
@@ -6244,9 +8471,19 @@ func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
 		*out = new(Failover)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disaggregated != nil {
+		in, out := &in.Disaggregated, &out.Disaggregated
+		*out = new(TiFlashDisaggregatedSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6296,6 +8533,11 @@ func (in *TiFlashStatus) DeepCopyInto(out *TiFlashStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(MemberSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make(map[StorageVolumeName]*StorageVolumeStatus, len(*in))
@@ -6318,6 +8560,16 @@ func (in *TiFlashStatus) DeepCopyInto(out *TiFlashStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImageDigest != nil {
+		in, out := &in.ImageDigest, &out.ImageDigest
+		*out = new(ImageDigest)
+		**out = **in
+	}
+	if in.ComputeNode != nil {
+		in, out := &in.ComputeNode, &out.ComputeNode
+		*out = new(TiFlashComputeNodeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -7170,6 +9422,85 @@ func (in *TiKVGCConfig) DeepCopy() *TiKVGCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroup) DeepCopyInto(out *TiKVGroup) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroup.
+func (in *TiKVGroup) DeepCopy() *TiKVGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroupStatus) DeepCopyInto(out *TiKVGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroupStatus.
+func (in *TiKVGroupStatus) DeepCopy() *TiKVGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVIOTuning) DeepCopyInto(out *TiKVIOTuning) {
+	*out = *in
+	if in.RateBytesPerSec != nil {
+		in, out := &in.RateBytesPerSec, &out.RateBytesPerSec
+		*out = new(string)
+		**out = **in
+	}
+	if in.IORateLimitMaxBytesPerSec != nil {
+		in, out := &in.IORateLimitMaxBytesPerSec, &out.IORateLimitMaxBytesPerSec
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackgroundJobs != nil {
+		in, out := &in.MaxBackgroundJobs, &out.MaxBackgroundJobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxSubCompactions != nil {
+		in, out := &in.MaxSubCompactions, &out.MaxSubCompactions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BackupNumThreads != nil {
+		in, out := &in.BackupNumThreads, &out.BackupNumThreads
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ImportNumThreads != nil {
+		in, out := &in.ImportNumThreads, &out.ImportNumThreads
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVIOTuning.
+func (in *TiKVIOTuning) DeepCopy() *TiKVIOTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVIOTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVImportConfig) DeepCopyInto(out *TiKVImportConfig) {
 	*out = *in
@@ -8047,20 +10378,46 @@ func (in *TiKVServerConfig) DeepCopyInto(out *TiKVServerConfig) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.RequestBatchWaitDuration != nil {
-		in, out := &in.RequestBatchWaitDuration, &out.RequestBatchWaitDuration
-		*out = new(string)
+	if in.RequestBatchWaitDuration != nil {
+		in, out := &in.RequestBatchWaitDuration, &out.RequestBatchWaitDuration
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVServerConfig.
+func (in *TiKVServerConfig) DeepCopy() *TiKVServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVSlowStorePolicy) DeepCopyInto(out *TiKVSlowStorePolicy) {
+	*out = *in
+	if in.ScoreThreshold != nil {
+		in, out := &in.ScoreThreshold, &out.ScoreThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVServerConfig.
-func (in *TiKVServerConfig) DeepCopy() *TiKVServerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVSlowStorePolicy.
+func (in *TiKVSlowStorePolicy) DeepCopy() *TiKVSlowStorePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(TiKVServerConfig)
+	out := new(TiKVSlowStorePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -8090,6 +10447,11 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RaftLogVolumeClaim != nil {
+		in, out := &in.RaftLogVolumeClaim, &out.RaftLogVolumeClaim
+		*out = new(RaftLogVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LogTailer != nil {
 		in, out := &in.LogTailer, &out.LogTailer
 		*out = new(LogTailerSpec)
@@ -8108,7 +10470,7 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
 		*out = new(Failover)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.MountClusterClientSecret != nil {
 		in, out := &in.MountClusterClientSecret, &out.MountClusterClientSecret
@@ -8120,11 +10482,26 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EvictLeaderTimeoutPolicy != nil {
+		in, out := &in.EvictLeaderTimeoutPolicy, &out.EvictLeaderTimeoutPolicy
+		*out = new(EvictLeaderTimeoutPolicy)
+		**out = **in
+	}
 	if in.WaitLeaderTransferBackTimeout != nil {
 		in, out := &in.WaitLeaderTransferBackTimeout, &out.WaitLeaderTransferBackTimeout
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleOutBalance != nil {
+		in, out := &in.ScaleOutBalance, &out.ScaleOutBalance
+		*out = new(ScaleOutBalanceGate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -8138,6 +10515,41 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		copy(*out, *in)
 	}
 	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingUpdateStrategy != nil {
+		in, out := &in.RollingUpdateStrategy, &out.RollingUpdateStrategy
+		*out = new(RollingUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IOTuning != nil {
+		in, out := &in.IOTuning, &out.IOTuning
+		*out = new(TiKVIOTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityReservation != nil {
+		in, out := &in.CapacityReservation, &out.CapacityReservation
+		*out = new(CapacityReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleInHook != nil {
+		in, out := &in.ScaleInHook, &out.ScaleInHook
+		*out = new(ScaleInHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoreGC != nil {
+		in, out := &in.StoreGC, &out.StoreGC
+		*out = new(TiKVStoreGC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowStore != nil {
+		in, out := &in.SlowStore, &out.SlowStore
+		*out = new(TiKVSlowStorePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -8202,6 +10614,11 @@ func (in *TiKVStatus) DeepCopyInto(out *TiKVStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(MemberSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make(map[StorageVolumeName]*StorageVolumeStatus, len(*in))
@@ -8224,6 +10641,11 @@ func (in *TiKVStatus) DeepCopyInto(out *TiKVStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImageDigest != nil {
+		in, out := &in.ImageDigest, &out.ImageDigest
+		*out = new(ImageDigest)
+		**out = **in
+	}
 	return
 }
 
@@ -8347,12 +10769,39 @@ func (in *TiKVStorageReadPoolConfig) DeepCopy() *TiKVStorageReadPoolConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVStore) DeepCopyInto(out *TiKVStore) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	if in.LeaderCountBeforeUpgrade != nil {
 		in, out := &in.LeaderCountBeforeUpgrade, &out.LeaderCountBeforeUpgrade
 		*out = new(int32)
 		**out = **in
 	}
+	if in.DrainProgress != nil {
+		in, out := &in.DrainProgress, &out.DrainProgress
+		*out = new(TiKVStoreDrainProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowSince != nil {
+		in, out := &in.SlowSince, &out.SlowSince
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -8366,6 +10815,53 @@ func (in *TiKVStore) DeepCopy() *TiKVStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVStoreDrainProgress) DeepCopyInto(out *TiKVStoreDrainProgress) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVStoreDrainProgress.
+func (in *TiKVStoreDrainProgress) DeepCopy() *TiKVStoreDrainProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVStoreDrainProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVStoreGC) DeepCopyInto(out *TiKVStoreGC) {
+	*out = *in
+	if in.TombstoneGracePeriod != nil {
+		in, out := &in.TombstoneGracePeriod, &out.TombstoneGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.OrphanGracePeriod != nil {
+		in, out := &in.OrphanGracePeriod, &out.OrphanGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVStoreGC.
+func (in *TiKVStoreGC) DeepCopy() *TiKVStoreGC {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVStoreGC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVTitanCfConfig) DeepCopyInto(out *TiKVTitanCfConfig) {
 	*out = *in
@@ -8724,7 +11220,191 @@ func (in *TidbClusterAutoScaler) DeepCopy() *TidbClusterAutoScaler {
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbClusterAutoScaler) DeepCopyObject() runtime.Object {
+func (in *TidbClusterAutoScaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterAutoScalerList) DeepCopyInto(out *TidbClusterAutoScalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterAutoScaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerList.
+func (in *TidbClusterAutoScalerList) DeepCopy() *TidbClusterAutoScalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterAutoScalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterAutoScalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterAutoScalerRef) DeepCopyInto(out *TidbClusterAutoScalerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerRef.
+func (in *TidbClusterAutoScalerRef) DeepCopy() *TidbClusterAutoScalerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterAutoScalerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterAutoScalerSpec) DeepCopyInto(out *TidbClusterAutoScalerSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.TiKV != nil {
+		in, out := &in.TiKV, &out.TiKV
+		*out = new(TikvAutoScalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TiDB != nil {
+		in, out := &in.TiDB, &out.TiDB
+		*out = new(TidbAutoScalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerSpec.
+func (in *TidbClusterAutoScalerSpec) DeepCopy() *TidbClusterAutoScalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterAutoScalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterAutoScalerStatus) DeepCopyInto(out *TidbClusterAutoScalerStatus) {
+	*out = *in
+	if in.TiKV != nil {
+		in, out := &in.TiKV, &out.TiKV
+		*out = make(map[string]TikvAutoScalerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.TiDB != nil {
+		in, out := &in.TiDB, &out.TiDB
+		*out = make(map[string]TidbAutoScalerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerStatus.
+func (in *TidbClusterAutoScalerStatus) DeepCopy() *TidbClusterAutoScalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterAutoScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterCondition) DeepCopyInto(out *TidbClusterCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterCondition.
+func (in *TidbClusterCondition) DeepCopy() *TidbClusterCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterBackupPolicy) DeepCopyInto(out *TidbClusterBackupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterBackupPolicy.
+func (in *TidbClusterBackupPolicy) DeepCopy() *TidbClusterBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterBackupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterBackupPolicyList) DeepCopyInto(out *TidbClusterBackupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterBackupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterBackupPolicyList.
+func (in *TidbClusterBackupPolicyList) DeepCopy() *TidbClusterBackupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterBackupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterBackupPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8732,13 +11412,35 @@ func (in *TidbClusterAutoScaler) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterAutoScalerList) DeepCopyInto(out *TidbClusterAutoScalerList) {
+func (in *TidbClusterBackupPolicySpec) DeepCopyInto(out *TidbClusterBackupPolicySpec) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]TidbClusterBackupPolicyOperation, len(*in))
+		copy(*out, *in)
+	}
+	out.MaxBackupAge = in.MaxBackupAge
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterBackupPolicySpec.
+func (in *TidbClusterBackupPolicySpec) DeepCopy() *TidbClusterBackupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterBackupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TidbClusterAutoScaler, len(*in))
+		*out = make([]TidbCluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8746,18 +11448,18 @@ func (in *TidbClusterAutoScalerList) DeepCopyInto(out *TidbClusterAutoScalerList
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerList.
-func (in *TidbClusterAutoScalerList) DeepCopy() *TidbClusterAutoScalerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
+func (in *TidbClusterList) DeepCopy() *TidbClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterAutoScalerList)
+	out := new(TidbClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbClusterAutoScalerList) DeepCopyObject() runtime.Object {
+func (in *TidbClusterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8765,104 +11467,74 @@ func (in *TidbClusterAutoScalerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterAutoScalerRef) DeepCopyInto(out *TidbClusterAutoScalerRef) {
+func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerRef.
-func (in *TidbClusterAutoScalerRef) DeepCopy() *TidbClusterAutoScalerRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
+func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterAutoScalerRef)
+	out := new(TidbClusterRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterAutoScalerSpec) DeepCopyInto(out *TidbClusterAutoScalerSpec) {
+func (in *TidbClusterRollout) DeepCopyInto(out *TidbClusterRollout) {
 	*out = *in
-	out.Cluster = in.Cluster
-	if in.TiKV != nil {
-		in, out := &in.TiKV, &out.TiKV
-		*out = new(TikvAutoScalerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TiDB != nil {
-		in, out := &in.TiDB, &out.TiDB
-		*out = new(TidbAutoScalerSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerSpec.
-func (in *TidbClusterAutoScalerSpec) DeepCopy() *TidbClusterAutoScalerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRollout.
+func (in *TidbClusterRollout) DeepCopy() *TidbClusterRollout {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterAutoScalerSpec)
+	out := new(TidbClusterRollout)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterAutoScalerStatus) DeepCopyInto(out *TidbClusterAutoScalerStatus) {
-	*out = *in
-	if in.TiKV != nil {
-		in, out := &in.TiKV, &out.TiKV
-		*out = make(map[string]TikvAutoScalerStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.TiDB != nil {
-		in, out := &in.TiDB, &out.TiDB
-		*out = make(map[string]TidbAutoScalerStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterAutoScalerStatus.
-func (in *TidbClusterAutoScalerStatus) DeepCopy() *TidbClusterAutoScalerStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(TidbClusterAutoScalerStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterCondition) DeepCopyInto(out *TidbClusterCondition) {
+func (in *TidbClusterRolloutClusterStatus) DeepCopyInto(out *TidbClusterRolloutClusterStatus) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterCondition.
-func (in *TidbClusterCondition) DeepCopy() *TidbClusterCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRolloutClusterStatus.
+func (in *TidbClusterRolloutClusterStatus) DeepCopy() *TidbClusterRolloutClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterCondition)
+	out := new(TidbClusterRolloutClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
+func (in *TidbClusterRolloutList) DeepCopyInto(out *TidbClusterRolloutList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TidbCluster, len(*in))
+		*out = make([]TidbClusterRollout, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8870,18 +11542,18 @@ func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
-func (in *TidbClusterList) DeepCopy() *TidbClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRolloutList.
+func (in *TidbClusterRolloutList) DeepCopy() *TidbClusterRolloutList {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterList)
+	out := new(TidbClusterRolloutList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbClusterList) DeepCopyObject() runtime.Object {
+func (in *TidbClusterRolloutList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8889,17 +11561,56 @@ func (in *TidbClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
+func (in *TidbClusterRolloutSpec) DeepCopyInto(out *TidbClusterRolloutSpec) {
 	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthCheckTimeout != nil {
+		in, out := &in.HealthCheckTimeout, &out.HealthCheckTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
-func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRolloutSpec.
+func (in *TidbClusterRolloutSpec) DeepCopy() *TidbClusterRolloutSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterRef)
+	out := new(TidbClusterRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterRolloutStatus) DeepCopyInto(out *TidbClusterRolloutStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]TidbClusterRolloutClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRolloutStatus.
+func (in *TidbClusterRolloutStatus) DeepCopy() *TidbClusterRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -8913,6 +11624,17 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(PDSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PDMS != nil {
+		in, out := &in.PDMS, &out.PDMS
+		*out = make([]*PDMSSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PDMSSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.TiDB != nil {
 		in, out := &in.TiDB, &out.TiDB
 		*out = new(TiDBSpec)
@@ -8923,6 +11645,13 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(TiKVSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TiKVGroups != nil {
+		in, out := &in.TiKVGroups, &out.TiKVGroups
+		*out = make([]TiKVGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TiFlash != nil {
 		in, out := &in.TiFlash, &out.TiFlash
 		*out = new(TiFlashSpec)
@@ -8958,6 +11687,13 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.RegistryMirrors != nil {
+		in, out := &in.RegistryMirrors, &out.RegistryMirrors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.EnablePVReclaim != nil {
 		in, out := &in.EnablePVReclaim, &out.EnablePVReclaim
 		*out = new(bool)
@@ -8966,7 +11702,7 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
@@ -9026,11 +11762,21 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PeerRegistry != nil {
+		in, out := &in.PeerRegistry, &out.PeerRegistry
+		*out = new(PeerRegistry)
+		**out = **in
+	}
 	if in.Cluster != nil {
 		in, out := &in.Cluster, &out.Cluster
 		*out = new(TidbClusterRef)
 		**out = **in
 	}
+	if in.SecretReplication != nil {
+		in, out := &in.SecretReplication, &out.SecretReplication
+		*out = new(SecretReplication)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PDAddresses != nil {
 		in, out := &in.PDAddresses, &out.PDAddresses
 		*out = make([]string, len(*in))
@@ -9051,6 +11797,31 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(SuspendAction)
 		**out = **in
 	}
+	if in.StatusCompaction != nil {
+		in, out := &in.StatusCompaction, &out.StatusCompaction
+		*out = new(StatusCompaction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(NotificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCTuning != nil {
+		in, out := &in.GCTuning, &out.GCTuning
+		*out = new(GCTuningPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperationTimeouts != nil {
+		in, out := &in.OperationTimeouts, &out.OperationTimeouts
+		*out = new(OperationTimeoutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]ResourceOverride, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -9079,6 +11850,11 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 		*out = new(TidbClusterAutoScalerRef)
 		**out = **in
 	}
+	if in.TiKVGroups != nil {
+		in, out := &in.TiKVGroups, &out.TiKVGroups
+		*out = make([]TiKVGroupStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]TidbClusterCondition, len(*in))
@@ -9086,6 +11862,52 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CostEstimates != nil {
+		in, out := &in.CostEstimates, &out.CostEstimates
+		*out = make(map[MemberType]ComponentCostEstimate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PDMS != nil {
+		in, out := &in.PDMS, &out.PDMS
+		*out = make(map[string]*PDMSStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PDMSStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PDMSStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.GCTuning != nil {
+		in, out := &in.GCTuning, &out.GCTuning
+		*out = new(GCTuningStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSRotations != nil {
+		in, out := &in.TLSRotations, &out.TLSRotations
+		*out = make(map[MemberType]TLSRotationStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RegisteredPeers != nil {
+		in, out := &in.RegisteredPeers, &out.RegisteredPeers
+		*out = make([]RegisteredPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]ResourceOverrideStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -9332,6 +12154,13 @@ func (in *TidbInitializerSpec) DeepCopyInto(out *TidbInitializerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SqlSources != nil {
+		in, out := &in.SqlSources, &out.SqlSources
+		*out = make([]InitSqlSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
 		*out = new(string)
@@ -9364,6 +12193,11 @@ func (in *TidbInitializerSpec) DeepCopy() *TidbInitializerSpec {
 func (in *TidbInitializerStatus) DeepCopyInto(out *TidbInitializerStatus) {
 	*out = *in
 	in.JobStatus.DeepCopyInto(&out.JobStatus)
+	if in.SqlSources != nil {
+		in, out := &in.SqlSources, &out.SqlSources
+		*out = make([]InitSqlSourceStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -9405,6 +12239,75 @@ func (in *TidbMonitor) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbMonitorAlertingSpec) DeepCopyInto(out *TidbMonitorAlertingSpec) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DedupLabels != nil {
+		in, out := &in.DedupLabels, &out.DedupLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbMonitorAlertingSpec.
+func (in *TidbMonitorAlertingSpec) DeepCopy() *TidbMonitorAlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbMonitorAlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbMonitorClusterSelector) DeepCopyInto(out *TidbMonitorClusterSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbMonitorClusterSelector.
+func (in *TidbMonitorClusterSelector) DeepCopy() *TidbMonitorClusterSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbMonitorClusterSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbMonitorHealthCheck) DeepCopyInto(out *TidbMonitorHealthCheck) {
+	*out = *in
+	if in.RestartThreshold != nil {
+		in, out := &in.RestartThreshold, &out.RestartThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbMonitorHealthCheck.
+func (in *TidbMonitorHealthCheck) DeepCopy() *TidbMonitorHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbMonitorHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbMonitorList) DeepCopyInto(out *TidbMonitorList) {
 	*out = *in
@@ -9462,6 +12365,11 @@ func (in *TidbMonitorSpec) DeepCopyInto(out *TidbMonitorSpec) {
 		*out = make([]TidbClusterRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(TidbMonitorClusterSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	if in.Grafana != nil {
 		in, out := &in.Grafana, &out.Grafana
@@ -9584,6 +12492,16 @@ func (in *TidbMonitorSpec) DeepCopyInto(out *TidbMonitorSpec) {
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(TidbMonitorAlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(TidbMonitorHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -9610,6 +12528,13 @@ func (in *TidbMonitorStatus) DeepCopyInto(out *TidbMonitorStatus) {
 		*out = new(appsv1.StatefulSetStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -9699,6 +12624,11 @@ func (in *TidbNGMonitoringSpec) DeepCopyInto(out *TidbNGMonitoringSpec) {
 		**out = **in
 	}
 	in.NGMonitoring.DeepCopyInto(&out.NGMonitoring)
+	if in.Conprof != nil {
+		in, out := &in.Conprof, &out.Conprof
+		*out = new(NGMonitoringConprofSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -9974,7 +12904,7 @@ func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
 		*out = new(Failover)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
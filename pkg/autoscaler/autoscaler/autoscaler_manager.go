@@ -204,6 +204,7 @@ func updateLastAutoScalingTimestamp(tac *v1alpha1.TidbClusterAutoScaler, memberT
 		}
 		status := tac.Status.TiKV[group]
 		status.LastAutoScalingTimestamp = &metav1.Time{Time: time.Now()}
+		status.ScaleInDeferredReason = ""
 		tac.Status.TiKV[group] = status
 	case v1alpha1.TiDBMemberType.String():
 		if tac.Status.TiDB == nil {
@@ -211,6 +212,7 @@ func updateLastAutoScalingTimestamp(tac *v1alpha1.TidbClusterAutoScaler, memberT
 		}
 		status := tac.Status.TiDB[group]
 		status.LastAutoScalingTimestamp = &metav1.Time{Time: time.Now()}
+		status.ScaleInDeferredReason = ""
 		tac.Status.TiDB[group] = status
 	}
 }
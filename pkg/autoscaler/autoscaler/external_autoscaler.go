@@ -47,6 +47,9 @@ func (am *autoScalerManager) syncExternalResult(tc *v1alpha1.TidbCluster, tac *v
 	}
 
 	if targetReplicas <= 0 {
+		if externalTc.Spec.TiKV != nil && am.deferScaleIn(tac, tc, externalTc, v1alpha1.TiKVMemberType, externalStatusKey, externalTc.Spec.TiKV.Replicas, 0) {
+			return nil
+		}
 		err := am.gracefullyDeleteTidbCluster(externalTc)
 		if err != nil {
 			klog.Errorf("tac[%s/%s] failed to delete external tc[%s/%s], err: %v", tac.Namespace, tac.Name, tc.Namespace, externalTcName, err)
@@ -63,7 +66,7 @@ func (am *autoScalerManager) syncExternalResult(tc *v1alpha1.TidbCluster, tac *v
 		return nil
 	}
 
-	return am.updateExternalAutoCluster(externalTc, tac, component, targetReplicas)
+	return am.updateExternalAutoCluster(tc, externalTc, tac, component, targetReplicas)
 }
 
 func (am *autoScalerManager) createExternalAutoCluster(tc *v1alpha1.TidbCluster, externalTcName string, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
@@ -87,7 +90,7 @@ func (am *autoScalerManager) createExternalAutoCluster(tc *v1alpha1.TidbCluster,
 	return nil
 }
 
-func (am *autoScalerManager) updateExternalAutoCluster(externalTc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
+func (am *autoScalerManager) updateExternalAutoCluster(tc, externalTc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
 	updated := externalTc.DeepCopy()
 	switch component {
 	case v1alpha1.TiDBMemberType:
@@ -98,6 +101,9 @@ func (am *autoScalerManager) updateExternalAutoCluster(externalTc *v1alpha1.Tidb
 		if !checkAutoScaling(tac, component, externalStatusKey, updated.Spec.TiDB.Replicas, targetReplicas) {
 			return nil
 		}
+		if am.deferScaleIn(tac, tc, externalTc, component, externalStatusKey, updated.Spec.TiDB.Replicas, targetReplicas) {
+			return nil
+		}
 		updated.Spec.TiDB.Replicas = targetReplicas
 	case v1alpha1.TiKVMemberType:
 		if updated.Spec.TiKV.Replicas == targetReplicas {
@@ -107,6 +113,9 @@ func (am *autoScalerManager) updateExternalAutoCluster(externalTc *v1alpha1.Tidb
 		if !checkAutoScaling(tac, component, externalStatusKey, updated.Spec.TiKV.Replicas, targetReplicas) {
 			return nil
 		}
+		if am.deferScaleIn(tac, tc, externalTc, component, externalStatusKey, updated.Spec.TiKV.Replicas, targetReplicas) {
+			return nil
+		}
 		updated.Spec.TiKV.Replicas = targetReplicas
 	}
 
@@ -91,7 +91,7 @@ func (am *autoScalerManager) syncPlans(tc *v1alpha1.TidbCluster, tac *v1alpha1.T
 	}
 
 	toUpdate := planGroups.Intersection(existedGroups)
-	err = am.updateAutoscalingClusters(tac, toUpdate.UnsortedList(), groupTcMap, groupPlanMap)
+	err = am.updateAutoscalingClusters(tac, tc, toUpdate.UnsortedList(), groupTcMap, groupPlanMap)
 	if err != nil {
 		return err
 	}
@@ -109,6 +109,9 @@ func (am *autoScalerManager) deleteAutoscalingClusters(tac *v1alpha1.TidbCluster
 	var errs []error
 	for _, group := range groupsToDelete {
 		deleteTc := groupTcMap[group]
+		if deleteTc.Spec.TiKV != nil && am.deferScaleIn(tac, tc, deleteTc, v1alpha1.TiKVMemberType, group, deleteTc.Spec.TiKV.Replicas, 0) {
+			continue
+		}
 
 		err := am.gracefullyDeleteTidbCluster(deleteTc)
 		if err != nil {
@@ -125,7 +128,7 @@ func (am *autoScalerManager) deleteAutoscalingClusters(tac *v1alpha1.TidbCluster
 	return errorutils.NewAggregate(errs)
 }
 
-func (am *autoScalerManager) updateAutoscalingClusters(tac *v1alpha1.TidbClusterAutoScaler, groupsToUpdate []string, groupTcMap map[string]*v1alpha1.TidbCluster, groupPlanMap map[string]pdapi.Plan) error {
+func (am *autoScalerManager) updateAutoscalingClusters(tac *v1alpha1.TidbClusterAutoScaler, tc *v1alpha1.TidbCluster, groupsToUpdate []string, groupTcMap map[string]*v1alpha1.TidbCluster, groupPlanMap map[string]pdapi.Plan) error {
 	var errs []error
 	for _, group := range groupsToUpdate {
 		actual, oldTc, plan := groupTcMap[group].DeepCopy(), groupTcMap[group], groupPlanMap[group]
@@ -138,6 +141,9 @@ func (am *autoScalerManager) updateAutoscalingClusters(tac *v1alpha1.TidbCluster
 			if !checkAutoScaling(tac, v1alpha1.TiKVMemberType, group, actual.Spec.TiKV.Replicas, int32(plan.Count)) {
 				continue
 			}
+			if am.deferScaleIn(tac, tc, actual, v1alpha1.TiKVMemberType, group, actual.Spec.TiKV.Replicas, int32(plan.Count)) {
+				continue
+			}
 			actual.Spec.TiKV.Replicas = int32(plan.Count)
 		case v1alpha1.TiDBMemberType.String():
 			if tac.Spec.TiDB == nil || actual.Spec.TiDB.Replicas == int32(plan.Count) {
@@ -146,6 +152,9 @@ func (am *autoScalerManager) updateAutoscalingClusters(tac *v1alpha1.TidbCluster
 			if !checkAutoScaling(tac, v1alpha1.TiDBMemberType, group, actual.Spec.TiDB.Replicas, int32(plan.Count)) {
 				continue
 			}
+			if am.deferScaleIn(tac, tc, actual, v1alpha1.TiDBMemberType, group, actual.Spec.TiDB.Replicas, int32(plan.Count)) {
+				continue
+			}
 			actual.Spec.TiDB.Replicas = int32(plan.Count)
 		default:
 			errs = append(errs, fmt.Errorf("unexpected component %s for group %s in autoscaling plan", plan.Component, group))
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// scaleInDeferredReason is the event reason when a scale-in is deferred
+	scaleInDeferredReason = "ScaleInDeferred"
+	// defaultMaxReplicas is the default max-replicas of regions in PD
+	defaultMaxReplicas = 3
+)
+
+// deferScaleIn simulates the scale-in of the component of the autoscaled tc and returns true if it
+// should be deferred. The reason is recorded in the status of tac and as an event.
+// baseTc is the TidbCluster referred by tac, whose PD serves all the autoscaled clusters.
+func (am *autoScalerManager) deferScaleIn(tac *v1alpha1.TidbClusterAutoScaler, baseTc, tc *v1alpha1.TidbCluster,
+	memberType v1alpha1.MemberType, group string, beforeReplicas, afterReplicas int32) bool {
+	if beforeReplicas <= afterReplicas {
+		return false
+	}
+	reason, err := am.checkScaleInSafety(baseTc, tc, memberType, beforeReplicas, afterReplicas)
+	if err != nil {
+		reason = fmt.Sprintf("failed to check the safety of scaling in: %v", err)
+	}
+	if reason == "" {
+		return false
+	}
+
+	msg := fmt.Sprintf("scaling in %s of tc[%s/%s] from %d to %d is deferred: %s", memberType, tc.Namespace, tc.Name, beforeReplicas, afterReplicas, reason)
+	klog.Infof("tac[%s/%s] %s", tac.Namespace, tac.Name, msg)
+	am.deps.Recorder.Event(tac, corev1.EventTypeWarning, scaleInDeferredReason, msg)
+	setScaleInDeferredReason(tac, memberType.String(), group, reason)
+	return true
+}
+
+// checkScaleInSafety returns the reason why scaling in the component of tc from beforeReplicas
+// to afterReplicas is unsafe, an empty reason means it is safe. The scale-in is unsafe if
+//   - it removes more pods than the PodDisruptionBudget of the component allows
+//   - the remaining TiKV stores are fewer than the max-replicas of regions
+//   - there are TiKV stores being removed, whose regions are still being migrated
+func (am *autoScalerManager) checkScaleInSafety(baseTc, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType,
+	beforeReplicas, afterReplicas int32) (string, error) {
+	removed := beforeReplicas - afterReplicas
+
+	pdbName := controller.MemberName(tc.Name, memberType)
+	pdb, err := am.deps.PDBControl.GetPDB(tc.Namespace, pdbName)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil && pdb.Status.DisruptionsAllowed < removed {
+		return fmt.Sprintf("PodDisruptionBudget %s allows %d disruptions, but %d pods would be removed", pdbName, pdb.Status.DisruptionsAllowed, removed), nil
+	}
+
+	if memberType != v1alpha1.TiKVMemberType {
+		return "", nil
+	}
+
+	pdClient := controller.GetPDClient(am.deps.PDControl, baseTc)
+	storesInfo, err := pdClient.GetStores()
+	if err != nil {
+		return "", err
+	}
+	upStores := 0
+	for _, store := range storesInfo.Stores {
+		if store.Store == nil || !util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
+			continue
+		}
+		switch store.Store.StateName {
+		case v1alpha1.TiKVStateUp:
+			upStores++
+		case v1alpha1.TiKVStateOffline:
+			return fmt.Sprintf("the regions of the offline store %d are still being migrated", store.Store.Id), nil
+		}
+	}
+
+	maxReplicas := uint64(defaultMaxReplicas)
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	if config.Replication != nil && config.Replication.MaxReplicas != nil {
+		maxReplicas = *config.Replication.MaxReplicas
+	}
+	if remaining := upStores - int(removed); remaining < int(maxReplicas) {
+		return fmt.Sprintf("%d TiKV stores would be left, fewer than the max-replicas %d of regions", remaining, maxReplicas), nil
+	}
+	return "", nil
+}
+
+func setScaleInDeferredReason(tac *v1alpha1.TidbClusterAutoScaler, memberType string, group string, reason string) {
+	switch memberType {
+	case v1alpha1.TiKVMemberType.String():
+		if tac.Status.TiKV == nil {
+			tac.Status.TiKV = map[string]v1alpha1.TikvAutoScalerStatus{}
+		}
+		status := tac.Status.TiKV[group]
+		status.ScaleInDeferredReason = reason
+		tac.Status.TiKV[group] = status
+	case v1alpha1.TiDBMemberType.String():
+		if tac.Status.TiDB == nil {
+			tac.Status.TiDB = map[string]v1alpha1.TidbAutoScalerStatus{}
+		}
+		status := tac.Status.TiDB[group]
+		status.ScaleInDeferredReason = reason
+		tac.Status.TiDB[group] = status
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestCheckScaleInSafety(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newStore := func(id uint64, state string) *pdapi.StoreInfo {
+		return &pdapi.StoreInfo{Store: &pdapi.MetaStore{Store: &metapb.Store{Id: id}, StateName: state}}
+	}
+
+	tests := []struct {
+		name               string
+		memberType         v1alpha1.MemberType
+		before             int32
+		after              int32
+		disruptionsAllowed *int32
+		stores             []*pdapi.StoreInfo
+		expectDeferred     bool
+	}{
+		{
+			name:       "tidb without pdb",
+			memberType: v1alpha1.TiDBMemberType,
+			before:     3,
+			after:      1,
+		},
+		{
+			name:               "tidb removes more pods than pdb allows",
+			memberType:         v1alpha1.TiDBMemberType,
+			before:             3,
+			after:              1,
+			disruptionsAllowed: pointer.Int32Ptr(1),
+			expectDeferred:     true,
+		},
+		{
+			name:       "tikv with enough stores",
+			memberType: v1alpha1.TiKVMemberType,
+			before:     2,
+			after:      1,
+			stores:     []*pdapi.StoreInfo{newStore(1, v1alpha1.TiKVStateUp), newStore(2, v1alpha1.TiKVStateUp), newStore(3, v1alpha1.TiKVStateUp), newStore(4, v1alpha1.TiKVStateUp)},
+		},
+		{
+			name:           "tikv leaves fewer stores than max-replicas",
+			memberType:     v1alpha1.TiKVMemberType,
+			before:         2,
+			after:          0,
+			stores:         []*pdapi.StoreInfo{newStore(1, v1alpha1.TiKVStateUp), newStore(2, v1alpha1.TiKVStateUp), newStore(3, v1alpha1.TiKVStateUp), newStore(4, v1alpha1.TiKVStateUp)},
+			expectDeferred: true,
+		},
+		{
+			name:           "tikv regions are being migrated",
+			memberType:     v1alpha1.TiKVMemberType,
+			before:         2,
+			after:          1,
+			stores:         []*pdapi.StoreInfo{newStore(1, v1alpha1.TiKVStateUp), newStore(2, v1alpha1.TiKVStateUp), newStore(3, v1alpha1.TiKVStateUp), newStore(4, v1alpha1.TiKVStateUp), newStore(5, v1alpha1.TiKVStateOffline)},
+			expectDeferred: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := controller.NewFakeDependencies()
+			am := NewAutoScalerManager(deps)
+			tac := newTidbClusterAutoScaler()
+			baseTc := newTidbCluster()
+			tc := newTidbCluster()
+			tc.Name = "tc-auto"
+
+			if tt.disruptionsAllowed != nil {
+				pdb := &policyv1beta1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: controller.MemberName(tc.Name, tt.memberType)},
+					Status:     policyv1beta1.PodDisruptionBudgetStatus{DisruptionsAllowed: *tt.disruptionsAllowed},
+				}
+				g.Expect(deps.GenericControl.(*controller.FakeGenericControl).FakeCli.Create(context.TODO(), pdb)).To(Succeed())
+			}
+			pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), baseTc)
+			pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.StoresInfo{Count: len(tt.stores), Stores: tt.stores}, nil
+			})
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.PDConfigFromAPI{}, nil
+			})
+
+			deferred := am.deferScaleIn(tac, baseTc, tc, tt.memberType, "group", tt.before, tt.after)
+			g.Expect(deferred).To(Equal(tt.expectDeferred))
+			var reason string
+			if tt.memberType == v1alpha1.TiKVMemberType {
+				reason = tac.Status.TiKV["group"].ScaleInDeferredReason
+			} else {
+				reason = tac.Status.TiDB["group"].ScaleInDeferredReason
+			}
+			g.Expect(reason != "").To(Equal(tt.expectDeferred))
+		})
+	}
+}
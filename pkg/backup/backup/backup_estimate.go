@@ -0,0 +1,227 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// estimateHistorySize is the number of the recent complete backups to learn the size ratio and speed from
+	estimateHistorySize = 5
+	// defaultMaxReplicas is used when max-replicas is not returned by PD
+	defaultMaxReplicas = 3
+	// storageQuotaExceededReason is the reason of the event and condition when the storage quota would be exceeded
+	storageQuotaExceededReason = "StorageQuotaExceeded"
+)
+
+// estimateBackup estimates the size, duration and cost of a snapshot backup before the backup job is created,
+// writes the estimate into the status and fails the backup if the storage quota of the destination would be exceeded.
+// A backup is only estimated once, the estimate is kept when the job is retried.
+func (bm *backupManager) estimateBackup(backup *v1alpha1.Backup) error {
+	if backup.Spec.Estimate == nil || backup.Spec.BR == nil || backup.Status.Estimate != nil {
+		return nil
+	}
+	if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		return nil
+	}
+
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	clusterNamespace := backupClusterNamespace(backup)
+	tc, err := bm.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(backup.Spec.BR.Cluster)
+	if err != nil {
+		return fmt.Errorf("backup %s/%s get tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, backup.Spec.BR.Cluster, err)
+	}
+	dataSize, err := bm.getClusterDataSize(tc)
+	if err != nil {
+		return fmt.Errorf("backup %s/%s estimate data size of tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, tc.Name, err)
+	}
+	backups, err := bm.deps.BackupLister.Backups(ns).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("backup %s/%s list backups failed, err: %v", ns, name, err)
+	}
+
+	estimate, err := makeBackupEstimate(backup, backups, dataSize)
+	if err != nil {
+		return fmt.Errorf("backup %s/%s estimate failed, err: %v", ns, name, err)
+	}
+	estimate.EstimatedTime = metav1.Now()
+	klog.Infof("backup %s/%s is estimated, cluster data size %d, size %s, duration %q, used storage %d",
+		ns, name, estimate.ClusterDataSize, estimate.SizeReadable, estimate.Duration, estimate.UsedStorage)
+
+	quota := backup.Spec.Estimate.StorageQuota
+	if quota == nil || estimate.UsedStorage+estimate.Size <= quota.Value() {
+		return bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Estimate: estimate})
+	}
+
+	msg := fmt.Sprintf("the estimated size %s of the backup plus the used storage %s of the destination exceeds the storage quota %s",
+		estimate.SizeReadable, humanize.IBytes(uint64(estimate.UsedStorage)), quota.String())
+	reject := backup.Spec.Estimate.RejectOnQuotaExceeded
+	if reject != nil && !*reject {
+		bm.deps.Recorder.Event(backup, corev1.EventTypeWarning, storageQuotaExceededReason, msg)
+		return bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Estimate: estimate})
+	}
+	if err := bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:    v1alpha1.BackupFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  storageQuotaExceededReason,
+		Message: msg,
+	}, &controller.BackupUpdateStatus{Estimate: estimate}); err != nil {
+		return err
+	}
+	return controller.IgnoreErrorf("backup %s/%s is rejected, %s", ns, name, msg)
+}
+
+// getClusterDataSize returns the logical data size of the cluster, i.e. the used size of all the TiKV stores divided by the max replicas
+func (bm *backupManager) getClusterDataSize(tc *v1alpha1.TidbCluster) (int64, error) {
+	pdClient := controller.GetPDClient(bm.deps.PDControl, tc)
+	storesInfo, err := pdClient.GetStores()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stores info, err: %v", err)
+	}
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get config, err: %v", err)
+	}
+	maxReplicas := int64(defaultMaxReplicas)
+	if config.Replication != nil && config.Replication.MaxReplicas != nil && *config.Replication.MaxReplicas > 0 {
+		maxReplicas = int64(*config.Replication.MaxReplicas)
+	}
+
+	var used int64
+	for _, store := range storesInfo.Stores {
+		// filter out TiFlash and the tombstone stores
+		if store.Store == nil || store.Status == nil || store.Store.StateName == v1alpha1.TiKVStateTombstone ||
+			!util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
+			continue
+		}
+		used += int64(store.Status.UsedSize)
+	}
+	return used / maxReplicas, nil
+}
+
+// makeBackupEstimate estimates the backup by the data size of the cluster and the recent complete backups in the same namespace
+func makeBackupEstimate(backup *v1alpha1.Backup, backups []*v1alpha1.Backup, dataSize int64) (*v1alpha1.BackupEstimate, error) {
+	estimate := &v1alpha1.BackupEstimate{ClusterDataSize: dataSize}
+
+	var history []*v1alpha1.Backup
+	destination := backupDestination(backup)
+	for _, b := range backups {
+		if b.UID == backup.UID || !v1alpha1.IsBackupComplete(b) || b.Status.BackupSize <= 0 {
+			continue
+		}
+		if destination != "" && backupDestination(b) == destination {
+			estimate.UsedStorage += b.Status.BackupSize
+		}
+		if isSameBackupSource(backup, b) {
+			history = append(history, b)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Status.TimeCompleted.After(history[j].Status.TimeCompleted.Time)
+	})
+	if len(history) > estimateHistorySize {
+		history = history[:estimateHistorySize]
+	}
+
+	// the ratio reflects the compression and deduplication of the backup data, it is 1 without history
+	ratio := 1.0
+	var ratioSum float64
+	var ratioCount int
+	var totalSize int64
+	var totalDuration time.Duration
+	for _, b := range history {
+		if b.Status.Estimate != nil && b.Status.Estimate.ClusterDataSize > 0 {
+			ratioSum += float64(b.Status.BackupSize) / float64(b.Status.Estimate.ClusterDataSize)
+			ratioCount++
+		}
+		if d := b.Status.TimeCompleted.Sub(b.Status.TimeStarted.Time); !b.Status.TimeStarted.IsZero() && d > 0 {
+			totalSize += b.Status.BackupSize
+			totalDuration += d
+		}
+	}
+	if ratioCount > 0 {
+		ratio = ratioSum / float64(ratioCount)
+	}
+	estimate.SizeRatio = strconv.FormatFloat(ratio, 'f', 2, 64)
+	estimate.Size = int64(float64(dataSize) * ratio)
+	estimate.SizeReadable = humanize.IBytes(uint64(estimate.Size))
+	if totalSize > 0 {
+		speed := float64(totalSize) / totalDuration.Seconds()
+		estimate.Duration = time.Duration(float64(estimate.Size) / speed * float64(time.Second)).Round(time.Second).String()
+	}
+
+	if price := backup.Spec.Estimate.PricePerGiBMonth; price != "" {
+		pricePerGiB, err := strconv.ParseFloat(price, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pricePerGiBMonth %q: %v", price, err)
+		}
+		estimate.MonthlyCost = strconv.FormatFloat(float64(estimate.Size)/float64(1<<30)*pricePerGiB, 'f', 2, 64)
+	}
+	return estimate, nil
+}
+
+// isSameBackupSource returns whether the backups are the snapshot backups of the same data in the same cluster
+func isSameBackupSource(backup, other *v1alpha1.Backup) bool {
+	if other.Spec.BR == nil || other.Spec.Mode == v1alpha1.BackupModeLog || other.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		return false
+	}
+	if backupClusterNamespace(backup) != backupClusterNamespace(other) || backup.Spec.BR.Cluster != other.Spec.BR.Cluster {
+		return false
+	}
+	backupType, otherType := backup.Spec.Type, other.Spec.Type
+	if backupType == "" {
+		backupType = v1alpha1.BackupTypeFull
+	}
+	if otherType == "" {
+		otherType = v1alpha1.BackupTypeFull
+	}
+	return backupType == otherType && backup.Spec.BR.DB == other.Spec.BR.DB && backup.Spec.BR.Table == other.Spec.BR.Table
+}
+
+func backupClusterNamespace(backup *v1alpha1.Backup) string {
+	if backup.Spec.BR.ClusterNamespace != "" {
+		return backup.Spec.BR.ClusterNamespace
+	}
+	return backup.GetNamespace()
+}
+
+// backupDestination returns the bucket or container the backup is stored in, it returns empty if unknown
+func backupDestination(backup *v1alpha1.Backup) string {
+	switch {
+	case backup.Spec.S3 != nil && backup.Spec.S3.Bucket != "":
+		return "s3://" + backup.Spec.S3.Bucket
+	case backup.Spec.Gcs != nil && backup.Spec.Gcs.Bucket != "":
+		return "gcs://" + backup.Spec.Gcs.Bucket
+	case backup.Spec.Azblob != nil && backup.Spec.Azblob.Container != "":
+		return "azure://" + backup.Spec.Azblob.Container
+	case backup.Spec.Local != nil:
+		return "local://" + backup.Spec.Local.Volume.Name
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/tikv/pd/pkg/typeutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+const gib = int64(1 << 30)
+
+func newEstimateBackup(name, bucket string) *v1alpha1.Backup {
+	return &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)},
+		Spec: v1alpha1.BackupSpec{
+			StorageProvider: v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Bucket: bucket}},
+			BR:              &v1alpha1.BRConfig{Cluster: "tc"},
+			Estimate:        &v1alpha1.BackupEstimateSpec{},
+		},
+	}
+}
+
+func completeBackup(b *v1alpha1.Backup, size, clusterDataSize int64, duration time.Duration) *v1alpha1.Backup {
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.Status.BackupSize = size
+	b.Status.TimeStarted = metav1.NewTime(started)
+	b.Status.TimeCompleted = metav1.NewTime(started.Add(duration))
+	b.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}}
+	if clusterDataSize > 0 {
+		b.Status.Estimate = &v1alpha1.BackupEstimate{ClusterDataSize: clusterDataSize}
+	}
+	return b
+}
+
+func TestMakeBackupEstimate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := newEstimateBackup("backup", "bucket")
+	backup.Spec.Estimate.PricePerGiBMonth = "0.02"
+
+	// without history, the backup size is estimated as the data size
+	estimate, err := makeBackupEstimate(backup, nil, 100*gib)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(estimate.SizeRatio).To(Equal("1.00"))
+	g.Expect(estimate.Size).To(Equal(100 * gib))
+	g.Expect(estimate.Duration).To(BeEmpty())
+	g.Expect(estimate.MonthlyCost).To(Equal("2.00"))
+
+	other := newEstimateBackup("other-cluster", "bucket")
+	other.Spec.BR.Cluster = "other"
+	backups := []*v1alpha1.Backup{
+		completeBackup(newEstimateBackup("b1", "bucket"), 40*gib, 80*gib, time.Hour),
+		completeBackup(newEstimateBackup("b2", "bucket"), 60*gib, 100*gib, time.Hour),
+		// the backup without estimate is only used to learn the speed
+		completeBackup(newEstimateBackup("b3", "bucket"), 20*gib, 0, time.Hour),
+		// the backups of other clusters are only counted in the used storage
+		completeBackup(other, 10*gib, 10*gib, time.Minute),
+		// the backups in other buckets are not counted in the used storage
+		completeBackup(newEstimateBackup("b4", "other"), 10*gib, 0, 0),
+		// the running backups are ignored
+		newEstimateBackup("running", "bucket"),
+	}
+	estimate, err = makeBackupEstimate(backup, backups, 100*gib)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(estimate.SizeRatio).To(Equal("0.55"))
+	g.Expect(estimate.Size).To(Equal(55 * gib))
+	// 120GiB in 3h
+	g.Expect(estimate.Duration).To(Equal("1h22m30s"))
+	g.Expect(estimate.UsedStorage).To(Equal(130 * gib))
+	g.Expect(estimate.MonthlyCost).To(Equal("1.10"))
+}
+
+func TestEstimateBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+	bm := NewBackupManager(deps).(*backupManager)
+
+	helper.CreateTC("ns", "tc")
+	tc, err := deps.TiDBClusterLister.TidbClusters("ns").Get("tc")
+	g.Expect(err).NotTo(HaveOccurred())
+	// the fake pd client is only used if the cluster TLS is disabled
+	tc = tc.DeepCopy()
+	tc.Spec.TLSCluster = nil
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters("ns").Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Eventually(func() bool {
+		tc, err := deps.TiDBClusterLister.TidbClusters("ns").Get("tc")
+		return err == nil && !tc.IsTLSClusterEnabled()
+	}, time.Second*10).Should(BeTrue())
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		store := func(used int64, labels ...*metapb.StoreLabel) *pdapi.StoreInfo {
+			return &pdapi.StoreInfo{
+				Store:  &pdapi.MetaStore{StateName: v1alpha1.TiKVStateUp, Store: &metapb.Store{Labels: labels}},
+				Status: &pdapi.StoreStatus{UsedSize: typeutil.ByteSize(used)},
+			}
+		}
+		return &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{
+			store(10 * gib), store(10 * gib), store(10 * gib),
+			store(100*gib, &metapb.StoreLabel{Key: "engine", Value: "tiflash"}),
+		}}, nil
+	})
+	maxReplicas := uint64(3)
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas}}, nil
+	})
+
+	create := func(backup *v1alpha1.Backup) {
+		_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Eventually(func() error {
+			_, err := deps.BackupLister.Backups(backup.Namespace).Get(backup.Name)
+			return err
+		}, time.Second*10).Should(BeNil())
+	}
+	create(completeBackup(newEstimateBackup("done", "bucket"), 15*gib, 0, time.Hour))
+
+	// the backup is rejected if the storage quota would be exceeded
+	quota := resource.MustParse("20Gi")
+	backup := newEstimateBackup("rejected", "bucket")
+	backup.Spec.Estimate.StorageQuota = &quota
+	create(backup)
+	err = bm.estimateBackup(backup)
+	g.Expect(controller.IsIgnoreError(err)).To(BeTrue(), fmt.Sprintf("%v", err))
+	g.Expect(backup.Status.Estimate).NotTo(BeNil())
+	g.Expect(backup.Status.Estimate.ClusterDataSize).To(Equal(10 * gib))
+	g.Expect(backup.Status.Estimate.UsedStorage).To(Equal(15 * gib))
+	helper.hasCondition("ns", "rejected", v1alpha1.BackupFailed, storageQuotaExceededReason)
+
+	// only a warning is recorded if the backup is not rejected
+	backup = newEstimateBackup("warned", "bucket")
+	backup.Spec.Estimate.StorageQuota = &quota
+	backup.Spec.Estimate.RejectOnQuotaExceeded = pointer.BoolPtr(false)
+	create(backup)
+	g.Expect(bm.estimateBackup(backup)).To(Succeed())
+	g.Expect(backup.Status.Estimate).NotTo(BeNil())
+	g.Expect(backup.Status.Estimate.Size).To(Equal(10 * gib))
+	g.Expect(v1alpha1.IsBackupFailed(backup)).To(BeFalse())
+	events := deps.Recorder.(*record.FakeRecorder).Events
+	g.Eventually(events).Should(Receive(ContainSubstring(storageQuotaExceededReason)))
+}
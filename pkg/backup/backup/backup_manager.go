@@ -68,6 +68,10 @@ func (bm *backupManager) Sync(backup *v1alpha1.Backup) error {
 		return nil
 	}
 
+	if v1alpha1.IsBackupComplete(backup) && backup.NeedVerification() {
+		return bm.syncVerifyJob(backup)
+	}
+
 	return bm.syncBackupJob(backup)
 }
 
@@ -106,6 +110,12 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 		return err
 	}
 
+	// estimate backup before the job is created
+	if err = bm.estimateBackup(backup); err != nil {
+		klog.Errorf("backup %s/%s estimate error %v.", ns, name, err)
+		return err
+	}
+
 	// make backup job
 	var job *batchv1.Job
 	var reason string
@@ -349,6 +359,12 @@ func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 		})
 	}
 
+	// mount volumes if specified
+	if backup.Spec.Local != nil {
+		volumes = append(volumes, backup.Spec.Local.Volume)
+		volumeMounts = append(volumeMounts, backup.Spec.Local.VolumeMount)
+	}
+
 	if backup.Spec.ToolImage != "" {
 		dumplingVolumeMount := corev1.VolumeMount{
 			Name:      "dumpling-bin",
@@ -564,6 +580,25 @@ func (bm *backupManager) makeBRBackupJob(backup *v1alpha1.Backup) (*batchv1.Job,
 		})
 	}
 
+	if backup.Spec.Encryption != nil && backup.Spec.Encryption.KeySecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      util.BackupEncryptionKeyVolName,
+			ReadOnly:  true,
+			MountPath: util.BackupEncryptionKeyPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: util.BackupEncryptionKeyVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: backup.Spec.Encryption.KeySecretRef.Name,
+					Items: []corev1.KeyToPath{
+						{Key: backup.Spec.Encryption.KeySecretRef.Key, Path: util.BackupEncryptionKeyFile},
+					},
+				},
+			},
+		})
+	}
+
 	brVolumeMount := corev1.VolumeMount{
 		Name:      "br-bin",
 		ReadOnly:  false,
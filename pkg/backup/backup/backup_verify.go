@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// syncVerifyJob creates the job verifying a complete backup, the result of the verification is written
+// into the Verified condition by the job. The condition is set to false if the job fails without a result.
+func (bm *backupManager) syncVerifyJob(backup *v1alpha1.Backup) error {
+	if v1alpha1.IsBackupVerificationDone(backup) {
+		return nil
+	}
+
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	verifyJobName := backup.GetVerifyJobName()
+
+	job, err := bm.deps.JobLister.Jobs(ns).Get(verifyJobName)
+	if err == nil {
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+				return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+					Type:    v1alpha1.BackupVerified,
+					Status:  corev1.ConditionFalse,
+					Reason:  "VerifyJobFailed",
+					Message: fmt.Sprintf("job %s failed: %s", verifyJobName, c.Message),
+				}, nil)
+			}
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s get verify job %s failed, err: %v", ns, name, verifyJobName, err)
+	}
+
+	job, reason, err := bm.makeVerifyJob(backup)
+	if err != nil {
+		bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+			Type:    v1alpha1.BackupVerified,
+			Status:  corev1.ConditionUnknown,
+			Reason:  reason,
+			Message: err.Error(),
+		}, nil)
+		return fmt.Errorf("backup %s/%s make verify job %s failed, reason is %s, err: %v", ns, name, verifyJobName, reason, err)
+	}
+	if err := bm.deps.JobControl.CreateJob(backup, job); err != nil {
+		return fmt.Errorf("create backup %s/%s verify job %s failed, err: %v", ns, name, verifyJobName, err)
+	}
+	klog.Infof("backup %s/%s verify job %s is created", ns, name, verifyJobName)
+
+	return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupVerified,
+		Status: corev1.ConditionUnknown,
+		Reason: "Verifying",
+	}, nil)
+}
+
+// makeVerifyJob makes the job verifying the backup, it's the same as the backup job except that the
+// backup manager runs in the verify mode. In the ScratchRestore mode, the job connects to the scratch
+// cluster, so the client certificates of the scratch cluster are mounted instead.
+func (bm *backupManager) makeVerifyJob(backup *v1alpha1.Backup) (*batchv1.Job, string, error) {
+	job, reason, err := bm.makeBRBackupJob(backup)
+	if err != nil {
+		return nil, reason, err
+	}
+	job.Name = backup.GetVerifyJobName()
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Args = append(container.Args, "--verify=true")
+
+	verify := backup.Spec.Verify
+	if verify.Mode != v1alpha1.BackupVerifyScratchRestore {
+		return job, "", nil
+	}
+
+	scratchNamespace := verify.ScratchCluster.Namespace
+	if scratchNamespace == "" {
+		scratchNamespace = backup.GetNamespace()
+	}
+	scratch, err := bm.deps.TiDBClusterLister.TidbClusters(scratchNamespace).Get(verify.ScratchCluster.Name)
+	if err != nil {
+		return nil, fmt.Sprintf("failed to fetch scratch tidbcluster %s/%s", scratchNamespace, verify.ScratchCluster.Name), err
+	}
+	tc, err := bm.deps.TiDBClusterLister.TidbClusters(backupClusterNamespace(backup)).Get(backup.Spec.BR.Cluster)
+	if err != nil {
+		return nil, fmt.Sprintf("failed to fetch tidbcluster %s/%s", backupClusterNamespace(backup), backup.Spec.BR.Cluster), err
+	}
+	if scratch.IsTLSClusterEnabled() != tc.IsTLSClusterEnabled() {
+		return nil, "ScratchClusterTLSMismatch", fmt.Errorf("cluster TLS of scratch tidbcluster %s/%s should be the same as tidbcluster %s/%s",
+			scratchNamespace, scratch.Name, tc.Namespace, tc.Name)
+	}
+	for i := range job.Spec.Template.Spec.Volumes {
+		vol := &job.Spec.Template.Spec.Volumes[i]
+		if vol.Name == util.ClusterClientVolName && vol.Secret != nil {
+			vol.Secret.SecretName = util.ClusterClientTLSSecretName(scratch.Name)
+		}
+	}
+	return job, "", nil
+}
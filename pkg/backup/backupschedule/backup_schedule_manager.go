@@ -50,6 +50,8 @@ func NewBackupScheduleManager(deps *controller.Dependencies) backup.BackupSchedu
 }
 
 func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
+	// refresh the restorable points after the backups are gc'ed
+	defer bm.syncRestorablePoints(bs)
 	defer bm.backupGC(bs)
 
 	if bs.Spec.Pause {
@@ -326,6 +328,10 @@ func (bm *backupScheduleManager) backupGC(bs *v1alpha1.BackupSchedule) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
+	if bs.Spec.LogBackupRetention != nil {
+		bm.backupGCByLogBackupRetention(bs)
+	}
+
 	// if MaxBackups and MaxReservedTime are set at the same time, MaxReservedTime is preferred.
 	if bs.Spec.MaxReservedTime != nil {
 		bm.backupGCByMaxReservedTime(bs)
@@ -380,6 +386,12 @@ func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSc
 		}
 	}
 
+	// the log backup is truncated by the log backup retention instead
+	if bs.Spec.LogBackupRetention != nil {
+		truncateTSO = 0
+	}
+	expiredBackups = bm.excludeRetainedBackups(bs, backupsList, expiredBackups)
+
 	for _, backup := range expiredBackups {
 		// delete the expired backup
 		if err = bm.deps.BackupControl.DeleteBackup(backup); err != nil {
@@ -626,12 +638,13 @@ func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedul
 	}
 
 	sort.Sort(byCreateTimeDesc(backupsList))
+	var expiredBackups []*v1alpha1.Backup
+	if len(backupsList) > int(*bs.Spec.MaxBackups) {
+		expiredBackups = bm.excludeRetainedBackups(bs, backupsList, backupsList[*bs.Spec.MaxBackups:])
+	}
 
 	var deleteCount int
-	for i, backup := range backupsList {
-		if i < int(*bs.Spec.MaxBackups) {
-			continue
-		}
+	for _, backup := range expiredBackups {
 		// delete the backup
 		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
 			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
@@ -647,6 +660,251 @@ func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedul
 	}
 }
 
+// backupGCByLogBackupRetention truncates the log backup to the snapshot backup the PITR window of the
+// log backup retention starts from, the snapshot backups after it are kept by excludeRetainedBackups.
+func (bm *backupScheduleManager) backupGCByLogBackupRetention(bs *v1alpha1.BackupSchedule) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backupGCByLogBackupRetention, err: %s", err)
+		return
+	}
+	retainTSO, truncatable, logBackup, err := calLogBackupRetention(bs, backupsList)
+	if err != nil {
+		klog.Errorf("backup schedule %s/%s, calculate log backup retention failed, err: %s", ns, bsName, err)
+		return
+	}
+	if !truncatable {
+		return
+	}
+
+	// skip if the log backup is already truncated to the ts
+	for _, truncated := range []string{logBackup.Spec.LogTruncateUntil, logBackup.Status.LogSuccessTruncateUntil} {
+		if truncated == "" {
+			continue
+		}
+		truncatedTSO, err := config.ParseTSString(truncated)
+		if err != nil {
+			klog.Errorf("backup schedule %s/%s, parse truncate ts %s of log backup %s failed, err: %s", ns, bsName, truncated, logBackup.GetName(), err)
+			return
+		}
+		if truncatedTSO >= retainTSO {
+			return
+		}
+	}
+
+	if err = bm.deps.BackupControl.TruncateLogBackup(logBackup, retainTSO); err != nil {
+		klog.Errorf("backup schedule %s/%s truncate log backup %s failed, truncateTSO %d, err %v", ns, bsName, logBackup.GetName(), retainTSO, err)
+		return
+	}
+	klog.Infof("backup schedule %s/%s truncate log backup %s success, truncateTSO %d", ns, bsName, logBackup.GetName(), retainTSO)
+}
+
+// excludeRetainedBackups excludes the snapshot backups needed by the PITR window of the log backup retention
+// from the expired backups.
+func (bm *backupScheduleManager) excludeRetainedBackups(bs *v1alpha1.BackupSchedule, backupsList, expiredBackups []*v1alpha1.Backup) []*v1alpha1.Backup {
+	if bs.Spec.LogBackupRetention == nil {
+		return expiredBackups
+	}
+	retainTSO, _, _, err := calLogBackupRetention(bs, backupsList)
+	if err != nil {
+		// keep all the backups if we don't know which are needed
+		klog.Errorf("backup schedule %s/%s, calculate log backup retention failed, skip gc, err: %s", bs.GetNamespace(), bs.GetName(), err)
+		return nil
+	}
+
+	var backups []*v1alpha1.Backup
+	for _, backup := range expiredBackups {
+		if isRetainedByLogBackupRetention(backup, retainTSO) {
+			klog.Infof("backup schedule %s/%s, backup %s is retained by the log backup retention", bs.GetNamespace(), bs.GetName(), backup.GetName())
+			continue
+		}
+		backups = append(backups, backup)
+	}
+	return backups
+}
+
+// isRetainedByLogBackupRetention returns whether the backup is needed by the PITR window which starts from retainTSO
+func isRetainedByLogBackupRetention(backup *v1alpha1.Backup, retainTSO uint64) bool {
+	if backup.Spec.Mode == v1alpha1.BackupModeLog {
+		return true
+	}
+	if retainTSO == 0 || !v1alpha1.IsBackupComplete(backup) || backup.Status.CommitTs == "" {
+		return false
+	}
+	commitTSO, err := config.ParseTSString(backup.Status.CommitTs)
+	return err == nil && commitTSO >= retainTSO
+}
+
+// calLogBackupRetention calculates the commit ts of the snapshot backup the PITR window of the log backup
+// retention starts from, and whether the log backup could be truncated to it.
+//
+// snapshot1----snapshot2-------------------snapshot3-----snapshot...----snapshot n-------> snapshot backups
+// ---logStartTS------------------------------------------------------------checkpointTS-> log backup
+// ---t1-----------t2-------windowStartTS-----t3------------t...-----------tn-------------> time
+//
+// supposed that windowStartTS = checkpointTS - retention, the cluster could be restored to any time
+// within the window by snapshot2 and the log backup after t2, so t2 is returned and the log backup
+// could be truncated to it. If there is no snapshot before windowStartTS, the first snapshot is returned
+// and the log backup should not be truncated.
+func calLogBackupRetention(bs *v1alpha1.BackupSchedule, backupsList []*v1alpha1.Backup) (uint64, bool, *v1alpha1.Backup, error) {
+	retention, err := time.ParseDuration(*bs.Spec.LogBackupRetention)
+	if err != nil {
+		return 0, false, nil, perrors.Annotatef(err, "parse log backup retention %s", *bs.Spec.LogBackupRetention)
+	}
+
+	var (
+		snapshotTSOs []uint64
+		logBackup    *v1alpha1.Backup
+	)
+	for _, backup := range backupsList {
+		if backup.Spec.Mode == v1alpha1.BackupModeLog {
+			logBackup = backup
+			continue
+		}
+		if !v1alpha1.IsBackupComplete(backup) || backup.Status.CommitTs == "" {
+			continue
+		}
+		tso, err := config.ParseTSString(backup.Status.CommitTs)
+		if err != nil {
+			return 0, false, nil, perrors.Annotatef(err, "parse backup ts of backup %s/%s", backup.Namespace, backup.Name)
+		}
+		snapshotTSOs = append(snapshotTSOs, tso)
+	}
+	// the log backup is not running yet, no snapshot is needed by the window
+	if logBackup == nil || logBackup.Status.CommitTs == "" || logBackup.Status.LogCheckpointTs == "" || len(snapshotTSOs) == 0 {
+		return 0, false, logBackup, nil
+	}
+	sort.Slice(snapshotTSOs, func(i, j int) bool { return snapshotTSOs[i] < snapshotTSOs[j] })
+
+	checkpointTSO, err := config.ParseTSString(logBackup.Status.LogCheckpointTs)
+	if err != nil {
+		return 0, false, nil, perrors.Annotatef(err, "parse checkpoint ts of log backup %s/%s", logBackup.Namespace, logBackup.Name)
+	}
+	windowStartTSO := calculateExpiredTSO(checkpointTSO, retention)
+
+	i := sort.Search(len(snapshotTSOs), func(i int) bool { return snapshotTSOs[i] > windowStartTSO })
+	if i == 0 {
+		return snapshotTSOs[0], false, logBackup, nil
+	}
+	retainTSO := snapshotTSOs[i-1]
+	truncatable, err := checkTruncateTSOWithinLogBackupRange(logBackup, retainTSO)
+	if err != nil {
+		return 0, false, nil, err
+	}
+	return retainTSO, truncatable, logBackup, nil
+}
+
+// syncRestorablePoints aggregates the restorable points of all the backups created by the backup schedule,
+// so that users could find what they can restore without enumerating the Backup CRs.
+func (bm *backupScheduleManager) syncRestorablePoints(bs *v1alpha1.BackupSchedule) {
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("syncRestorablePoints failed, err: %s", err)
+		return
+	}
+	bs.Status.RestorablePoints = buildRestorablePoints(backupsList)
+}
+
+// buildRestorablePoints returns the snapshots of the completed full backups ordered by commit ts asc,
+// and the PITR window of the log backup if there is any.
+//
+// ----------------snapshot1----------snapshot2--------snapshot3-------> full backups
+// ------logStartTS---------------------------------------checkpointTS-> log backup
+//
+// the PITR window starts from the first snapshot after the start ts of the log backup,
+// in the above case, it's from snapshot1 to checkpointTS.
+func buildRestorablePoints(backupsList []*v1alpha1.Backup) []v1alpha1.RestorablePoint {
+	type snapshot struct {
+		tso   uint64
+		point v1alpha1.RestorablePoint
+	}
+
+	var (
+		snapshots []snapshot
+		logBackup *v1alpha1.Backup
+	)
+	for _, backup := range backupsList {
+		if backup.Spec.Mode == v1alpha1.BackupModeLog {
+			logBackup = backup
+			continue
+		}
+		if !v1alpha1.IsBackupComplete(backup) || backup.Status.CommitTs == "" {
+			continue
+		}
+		tso, err := config.ParseTSString(backup.Status.CommitTs)
+		if err != nil {
+			klog.Warningf("parse commit ts of backup %s/%s failed, skip it, err: %v", backup.Namespace, backup.Name, err)
+			continue
+		}
+		point := v1alpha1.RestorablePoint{
+			Type:               v1alpha1.RestorablePointTypeSnapshot,
+			BackupName:         backup.Name,
+			Location:           backup.Status.BackupPath,
+			BackupSize:         backup.Status.BackupSize,
+			BackupSizeReadable: backup.Status.BackupSizeReadable,
+			CommitTs:           backup.Status.CommitTs,
+		}
+		if !backup.Status.TimeCompleted.IsZero() {
+			point.TimeCompleted = backup.Status.TimeCompleted.DeepCopy()
+		}
+		snapshots = append(snapshots, snapshot{tso: tso, point: point})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].tso < snapshots[j].tso
+	})
+
+	points := make([]v1alpha1.RestorablePoint, 0, len(snapshots)+1)
+	for _, s := range snapshots {
+		points = append(points, s.point)
+	}
+
+	if logBackup == nil || logBackup.Status.CommitTs == "" || logBackup.Status.LogCheckpointTs == "" {
+		return points
+	}
+	startTSO, err := config.ParseTSString(logBackup.Status.CommitTs)
+	if err != nil {
+		klog.Warningf("parse commit ts of log backup %s/%s failed, err: %v", logBackup.Namespace, logBackup.Name, err)
+		return points
+	}
+	if logBackup.Status.LogSuccessTruncateUntil != "" {
+		truncateTSO, err := config.ParseTSString(logBackup.Status.LogSuccessTruncateUntil)
+		if err != nil {
+			klog.Warningf("parse truncate ts of log backup %s/%s failed, err: %v", logBackup.Namespace, logBackup.Name, err)
+			return points
+		}
+		if truncateTSO > startTSO {
+			startTSO = truncateTSO
+		}
+	}
+	checkpointTSO, err := config.ParseTSString(logBackup.Status.LogCheckpointTs)
+	if err != nil {
+		klog.Warningf("parse checkpoint ts of log backup %s/%s failed, err: %v", logBackup.Namespace, logBackup.Name, err)
+		return points
+	}
+
+	for _, s := range snapshots {
+		if s.tso < startTSO {
+			continue
+		}
+		if s.tso < checkpointTSO {
+			points = append(points, v1alpha1.RestorablePoint{
+				Type:               v1alpha1.RestorablePointTypePITR,
+				BackupName:         logBackup.Name,
+				Location:           logBackup.Status.BackupPath,
+				BackupSize:         logBackup.Status.BackupSize,
+				BackupSizeReadable: logBackup.Status.BackupSizeReadable,
+				CommitTs:           s.point.CommitTs,
+				EndTs:              logBackup.Status.LogCheckpointTs,
+			})
+		}
+		break
+	}
+	return points
+}
+
 func (bm *backupScheduleManager) resetLastBackup(bs *v1alpha1.BackupSchedule) {
 	bs.Status.LastBackupTime = nil
 	bs.Status.LastBackup = ""
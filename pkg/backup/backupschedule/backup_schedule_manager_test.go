@@ -342,6 +342,127 @@ type helper struct {
 	stop chan struct{}
 }
 
+func TestBuildRestorablePoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	completed := func(name string, ts int64) *v1alpha1.Backup {
+		backup := fakeBackup(&ts)
+		backup.Name = name
+		backup.Status.BackupPath = "s3://bucket/" + name
+		backup.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: v1.ConditionTrue}}
+		return backup
+	}
+	var (
+		ts1 int64 = 100
+		ts2 int64 = 200
+		ts3 int64 = 300
+		ts4 int64 = 400
+	)
+	running := fakeBackup(&ts4)
+	running.Name = "running"
+	backups := []*v1alpha1.Backup{completed("bk3", ts3), completed("bk1", ts1), completed("bk2", ts2), running}
+
+	// no log backup
+	points := buildRestorablePoints(backups)
+	g.Expect(points).To(HaveLen(3))
+	for i, name := range []string{"bk1", "bk2", "bk3"} {
+		g.Expect(points[i].Type).To(Equal(v1alpha1.RestorablePointTypeSnapshot))
+		g.Expect(points[i].BackupName).To(Equal(name))
+		g.Expect(points[i].Location).To(Equal("s3://bucket/" + name))
+	}
+
+	// the PITR window starts from the first snapshot after the log backup start ts
+	var (
+		logStart      int64 = 150
+		logCheckpoint int64 = 350
+	)
+	logBackup := fakeLogBackup(&logStart, &logCheckpoint)
+	logBackup.Name = "log"
+	logBackup.Spec.Mode = v1alpha1.BackupModeLog
+	points = buildRestorablePoints(append(backups, logBackup))
+	g.Expect(points).To(HaveLen(4))
+	g.Expect(points[3]).To(Equal(v1alpha1.RestorablePoint{
+		Type:       v1alpha1.RestorablePointTypePITR,
+		BackupName: "log",
+		CommitTs:   getTSOStr(ts2),
+		EndTs:      getTSOStr(logCheckpoint),
+	}))
+
+	// the log backup is truncated after all the snapshots
+	logBackup.Status.LogSuccessTruncateUntil = getTSOStr(ts3 + 1)
+	points = buildRestorablePoints(append(backups, logBackup))
+	g.Expect(points).To(HaveLen(3))
+}
+
+func TestCalLogBackupRetention(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	completed := func(ts int64) *v1alpha1.Backup {
+		backup := fakeBackup(&ts)
+		backup.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: v1.ConditionTrue}}
+		return backup
+	}
+	logBackup := func(startTS, checkPointTS *int64) *v1alpha1.Backup {
+		backup := fakeLogBackup(startTS, checkPointTS)
+		backup.Spec.Mode = v1alpha1.BackupModeLog
+		return backup
+	}
+	var (
+		now       = time.Now()
+		last10Min = now.Add(-time.Minute * 10).Unix()
+		last1Day  = now.Add(-time.Hour * 24 * 1).Unix()
+		last2Day  = now.Add(-time.Hour * 24 * 2).Unix()
+		last3Day  = now.Add(-time.Hour * 24 * 3).Unix()
+		last4Day  = now.Add(-time.Hour * 24 * 4).Unix()
+	)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Spec.LogBackupRetention = pointer.StringPtr("36h")
+
+	tests := []struct {
+		name              string
+		backups           []*v1alpha1.Backup
+		expectRetainTSO   uint64
+		expectTruncatable bool
+	}{
+		{
+			name:    "log backup is not running",
+			backups: []*v1alpha1.Backup{completed(last2Day), logBackup(&last3Day, nil)},
+		},
+		{
+			name:            "no snapshot before the window",
+			backups:         []*v1alpha1.Backup{completed(last1Day), completed(last10Min), logBackup(&last3Day, &last10Min)},
+			expectRetainTSO: getTSO(last1Day),
+		},
+		{
+			name: "truncate to the last snapshot before the window",
+			backups: []*v1alpha1.Backup{
+				completed(last3Day), completed(last2Day), completed(last1Day), fakeBackup(&last10Min), logBackup(&last4Day, &last10Min),
+			},
+			expectRetainTSO:   getTSO(last2Day),
+			expectTruncatable: true,
+		},
+		{
+			name:            "snapshot before the start of the log backup",
+			backups:         []*v1alpha1.Backup{completed(last3Day), completed(last1Day), logBackup(&last2Day, &last10Min)},
+			expectRetainTSO: getTSO(last3Day),
+		},
+	}
+	for _, tt := range tests {
+		t.Log(tt.name)
+		retainTSO, truncatable, _, err := calLogBackupRetention(bs, tt.backups)
+		g.Expect(err).Should(BeNil())
+		g.Expect(retainTSO).Should(Equal(tt.expectRetainTSO))
+		g.Expect(truncatable).Should(Equal(tt.expectTruncatable))
+	}
+
+	// the log backup and the snapshots needed by the window are retained
+	g.Expect(isRetainedByLogBackupRetention(logBackup(&last4Day, &last10Min), getTSO(last2Day))).Should(BeTrue())
+	g.Expect(isRetainedByLogBackupRetention(completed(last2Day), getTSO(last2Day))).Should(BeTrue())
+	g.Expect(isRetainedByLogBackupRetention(completed(last3Day), getTSO(last2Day))).Should(BeFalse())
+	g.Expect(isRetainedByLogBackupRetention(fakeBackup(&last1Day), getTSO(last2Day))).Should(BeFalse())
+}
+
 func newHelper(t *testing.T) *helper {
 	deps := controller.NewSimpleClientDependencies()
 	stop := make(chan struct{})
@@ -53,6 +53,9 @@ const (
 	// AzblobTenantID represents the Azure Directory (tenant) ID for the application using AAD credtentials in related secret
 	AzblobTenantID = "AZURE_TENANT_ID"
 
+	// AzblobSasToken represents the Azure shared access signature token of the Storage Account in related secret
+	AzblobSasToken = "AZURE_STORAGE_SAS_TOKEN"
+
 	// BackupManagerEnvVarPrefix represents the environment variable used for tidb-backup-manager must include this prefix
 	BackupManagerEnvVarPrefix = "BACKUP_MANAGER"
 
@@ -63,6 +63,11 @@ func (rm *restoreManager) UpdateCondition(restore *v1alpha1.Restore, condition *
 	return rm.statusUpdater.Update(restore, condition, nil)
 }
 
+// UpdateStatus updates the status for a Restore, include condition and status info.
+func (rm *restoreManager) UpdateStatus(restore *v1alpha1.Restore, condition *v1alpha1.RestoreCondition, newStatus *controller.RestoreUpdateStatus) error {
+	return rm.statusUpdater.Update(restore, condition, newStatus)
+}
+
 func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 	ns := restore.GetNamespace()
 	name := restore.GetName()
@@ -140,10 +145,19 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 	}
 
 	restoreJobName := restore.GetRestoreJobName()
-	_, err = rm.deps.JobLister.Jobs(ns).Get(restoreJobName)
+	oldJob, err := rm.deps.JobLister.Jobs(ns).Get(restoreJobName)
 	if err == nil {
-		klog.Infof("restore job %s/%s has been created, skip", ns, restoreJobName)
-		return nil
+		if !v1alpha1.IsRestoreResuming(restore) {
+			klog.Infof("restore job %s/%s has been created, skip", ns, restoreJobName)
+			return nil
+		}
+		// the failed job must be removed before relaunching a new one to resume from checkpoint
+		if oldJob.DeletionTimestamp == nil {
+			if err := rm.deps.JobControl.DeleteJob(restore, oldJob); err != nil {
+				return fmt.Errorf("restore %s/%s delete failed job %s for resuming failed, err: %v", ns, name, restoreJobName, err)
+			}
+		}
+		return controller.RequeueErrorf("restore %s/%s: waiting for failed job %s to be deleted before resuming", ns, name, restoreJobName)
 	} else if !errors.IsNotFound(err) {
 		return fmt.Errorf("restore %s/%s get job %s failed, err: %v", ns, name, restoreJobName, err)
 	}
@@ -198,6 +212,37 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 		return errMsg
 	}
 
+	if v1alpha1.IsRestoreVerifying(restore) {
+		klog.Infof("restore %s/%s verification job %s is created", ns, name, restoreJobName)
+		return rm.statusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{
+			Verification: &v1alpha1.RestoreVerificationStatus{
+				Type:        restore.Spec.Verification,
+				TimeStarted: metav1.Time{Time: time.Now()},
+			},
+		})
+	}
+
+	if v1alpha1.IsRestoreResuming(restore) {
+		attempt := restore.Status.ResumeAttempts[len(restore.Status.ResumeAttempts)-1]
+		attempt.ResumedAt = &metav1.Time{Time: time.Now()}
+		klog.Infof("restore %s/%s resumed from checkpoint, attempt %d", ns, name, attempt.AttemptNum)
+		if err := rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:   v1alpha1.RestoreResuming,
+			Status: corev1.ConditionFalse,
+			Reason: "ResumedFromCheckpoint",
+		}, nil); err != nil {
+			return err
+		}
+		// the phase of the resuming restore is reverted to running
+		return rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:   v1alpha1.RestoreRunning,
+			Status: corev1.ConditionTrue,
+			Reason: "ResumedFromCheckpoint",
+		}, &controller.RestoreUpdateStatus{
+			ResumeAttempt: &attempt,
+		})
+	}
+
 	// Currently, the restore phase reuses the condition type and is updated when the condition is changed.
 	// However, conditions are only used to describe the detailed status of the restore job. It is not suitable
 	// for describing a state machine.
@@ -492,6 +537,12 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 		})
 	}
 
+	// mount volumes if specified
+	if restore.Spec.Local != nil {
+		volumes = append(volumes, restore.Spec.Local.Volume)
+		volumeMounts = append(volumeMounts, restore.Spec.Local.VolumeMount)
+	}
+
 	if restore.Spec.ToolImage != "" {
 		lightningVolumeMount := corev1.VolumeMount{
 			Name:      "lightning-bin",
@@ -586,6 +637,8 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 }
 
 func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Job, string, error) {
+	// the references to the source cluster are remapped to the target cluster before building the job
+	restore = backuputil.RemapRestoreSource(restore)
 	ns := restore.GetNamespace()
 	name := restore.GetName()
 	restoreNamespace := ns
@@ -644,6 +697,17 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 	default:
 		args = append(args, fmt.Sprintf("--mode=%s", v1alpha1.RestoreModeSnapshot))
 	}
+	if v1alpha1.IsRestoreVerifying(restore) {
+		args = append(args, "--verify")
+	} else if len(restore.Status.ResumeAttempts) > 0 {
+		if backuputil.CanResumeRestoreByCheckpoint(tikvVersion) {
+			args = append(args, "--resume")
+		} else {
+			klog.Warningf("restore %s/%s can not resume from checkpoint with tikv version %s, restore from scratch", ns, name, tikvVersion)
+			rm.deps.Recorder.Eventf(restore, corev1.EventTypeWarning, "ResumeUnsupported",
+				"checkpoint is not supported by tikv version %s, the restore is relaunched from scratch", tikvVersion)
+		}
+	}
 
 	jobLabels := util.CombineStringMap(label.NewRestore().Instance(restore.GetInstanceName()).RestoreJob().Restore(name), restore.Labels)
 	podLabels := jobLabels
@@ -690,6 +754,25 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		})
 	}
 
+	if restore.Spec.Encryption != nil && restore.Spec.Encryption.KeySecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      util.BackupEncryptionKeyVolName,
+			ReadOnly:  true,
+			MountPath: util.BackupEncryptionKeyPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: util.BackupEncryptionKeyVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: restore.Spec.Encryption.KeySecretRef.Name,
+					Items: []corev1.KeyToPath{
+						{Key: restore.Spec.Encryption.KeySecretRef.Key, Path: util.BackupEncryptionKeyFile},
+					},
+				},
+			},
+		})
+	}
+
 	brVolumeMount := corev1.VolumeMount{
 		Name:      "br-bin",
 		ReadOnly:  false,
@@ -852,4 +935,8 @@ func (frm *FakeRestoreManager) UpdateCondition(_ *v1alpha1.Restore, _ *v1alpha1.
 	return nil
 }
 
+func (frm *FakeRestoreManager) UpdateStatus(_ *v1alpha1.Restore, _ *v1alpha1.RestoreCondition, _ *controller.RestoreUpdateStatus) error {
+	return nil
+}
+
 var _ backup.RestoreManager = &FakeRestoreManager{}
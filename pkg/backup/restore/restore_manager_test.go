@@ -234,6 +234,39 @@ func TestBRRestore(t *testing.T) {
 	}
 }
 
+func TestBRRestoreResume(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	restore := genValidBRRestores()[0]
+	restore.Status.ResumeAttempts = []v1alpha1.RestoreResumeAttempt{{AttemptNum: 1, FailedPod: "pod-1"}}
+	helper.createRestore(restore)
+	helper.CreateSecret(restore)
+	helper.CreateTC(restore.Spec.BR.ClusterNamespace, restore.Spec.BR.Cluster)
+	m := NewRestoreManager(deps).(*restoreManager)
+
+	// checkpoint is not supported by the tikv version, the restore is relaunched from scratch
+	job, _, err := m.makeRestoreJob(restore)
+	g.Expect(err).Should(BeNil())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement("--resume"))
+
+	tc, err := deps.Clientset.PingcapV1alpha1().TidbClusters(restore.Spec.BR.ClusterNamespace).Get(context.TODO(), restore.Spec.BR.Cluster, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	tc.Spec.Version = "v7.5.0"
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() string {
+		tc, _ := deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name)
+		return tc.Spec.Version
+	}, time.Second*10).Should(Equal("v7.5.0"))
+
+	job, _, err = m.makeRestoreJob(restore)
+	g.Expect(err).Should(BeNil())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--resume"))
+}
+
 func TestBRRestoreByEBS(t *testing.T) {
 	g := NewGomegaWithT(t)
 	helper := newHelper(t)
@@ -125,6 +125,9 @@ func (s *BaseSnapshotter) PrepareCSBK8SMeta(csb *CloudSnapBackup, tc *v1alpha1.T
 	if err != nil {
 		return nil, fmt.Sprintf("unexpected error generating pv label selector: %v", err), err
 	}
+	if s.deps.PVLister == nil {
+		return nil, "NoPermissionForPVs", fmt.Errorf("persistent volumes lister is unavailable, the permission for persistent volumes is required by the volume snapshot backup")
+	}
 	pvs, err := s.deps.PVLister.List(pvSels)
 	if err != nil {
 		return nil, fmt.Sprintf("failed to fetch pvs %s:%s", label.ComponentLabelKey, label.TiKVLabelVal), err
@@ -205,6 +208,9 @@ func commitPVsAndPVCsToK8S(
 	if err != nil {
 		return "BuildTiKVSelectorFailed", err
 	}
+	if deps.PVLister == nil {
+		return "NoPermissionForPVs", fmt.Errorf("persistent volumes lister is unavailable, the permission for persistent volumes is required by the volume snapshot restore")
+	}
 	existingPVs, err := deps.PVLister.List(sel)
 	if err != nil {
 		return "ListPVsFailed", err
@@ -52,6 +52,7 @@ import (
 const (
 	maxRetries         = 3 // number of retries to make of operations
 	defaultStorageFlag = "storage"
+	azblobSasTokenFlag = "azblob.sas-token"
 )
 
 type StorageCredential struct {
@@ -83,10 +84,13 @@ type gcsConfig struct {
 }
 
 type azblobConfig struct {
-	container  string
-	accessTier string
-	secretName string
-	prefix     string
+	container      string
+	accessTier     string
+	secretName     string
+	storageAccount string
+	prefix         string
+	// sasToken is the shared access signature token of the storage account read from the env
+	sasToken string
 }
 
 type localConfig struct {
@@ -390,6 +394,19 @@ func GenStorageArgsForFlag(provider v1alpha1.StorageProvider, flag string) ([]st
 	}
 }
 
+// RedactStorageArgs returns a copy of the storage args of br with the credentials in them redacted,
+// so that the args can be logged
+func RedactStorageArgs(args []string) []string {
+	redacted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--"+azblobSasTokenFlag+"=") {
+			arg = fmt.Sprintf("--%s=******", azblobSasTokenFlag)
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted
+}
+
 // newLocalStorageOption constructs `--flag local://$PATH` arg for br
 func newLocalStorageOptionForFlag(conf *localConfig, flag string) ([]string, error) {
 	if flag != "" && flag != defaultStorageFlag {
@@ -512,7 +529,10 @@ type azblobSharedKeyCred struct {
 
 // newAzblobStorage initialize a new azblob storage
 func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
-	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
+	account := conf.storageAccount
+	if len(account) == 0 {
+		account = os.Getenv("AZURE_STORAGE_ACCOUNT")
+	}
 	if len(account) == 0 {
 		return nil, errors.New("No AZURE_STORAGE_ACCOUNT")
 	}
@@ -525,31 +545,29 @@ func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
 	// Azure shared key with access to the storage account
 	accountKey := os.Getenv("AZURE_STORAGE_KEY")
 
-	// check condition for using AAD credentials first
-	var usingAAD bool
-	if len(clientID) != 0 && len(clientSecret) != 0 && len(tenantID) != 0 {
-		usingAAD = true
-	} else if len(accountKey) != 0 {
-		usingAAD = false
-	} else {
-		return nil, errors.New("Missing necessary key(s) for credentials")
-	}
+	// Azure shared access signature token of the storage account
+	sasToken := conf.sasToken
 
-	// initialize a new azblob storage using AAD or shared key credentials
+	// initialize a new azblob storage using sas token, AAD, shared key or managed identity credentials in order
 	var bucket *blob.Bucket
 	var err error
-	if usingAAD {
+	if len(sasToken) != 0 {
+		bucket, err = newAzblobStorageUsingSasToken(conf, account, sasToken)
+	} else if len(clientID) != 0 && len(clientSecret) != 0 && len(tenantID) != 0 {
 		bucket, err = newAzblobStorageUsingAAD(conf, &azblobAADCred{
 			account:      account,
 			clientID:     clientID,
 			clientSecret: clientSecret,
 			tenantID:     tenantID,
 		})
-	} else {
+	} else if len(accountKey) != 0 {
 		bucket, err = newAzblobStorageUsingSharedKey(conf, &azblobSharedKeyCred{
 			account:   account,
 			sharedKey: accountKey,
 		})
+	} else {
+		// AZURE_CLIENT_ID may be set alone to choose the user-assigned managed identity
+		bucket, err = newAzblobStorageUsingManagedIdentity(conf, account, clientID)
 	}
 	if err != nil {
 		return nil, err
@@ -610,6 +628,44 @@ func newAzblobStorageUsingSharedKey(conf *azblobConfig, cred *azblobSharedKeyCre
 	return azureblob.OpenBucket(ctx, pipeline, accountName, conf.container, &azureblob.Options{Credential: credential})
 }
 
+// newAzblobStorageUsingSasToken initialize a new azblob storage using sas token
+func newAzblobStorageUsingSasToken(conf *azblobConfig, account, sasToken string) (*blob.Bucket, error) {
+	ctx := context.Background()
+
+	// The sas token is appended to the url of each request, so no credential is needed by the pipeline.
+	pipeline := azureblob.NewPipeline(azblob.NewAnonymousCredential(), azblob.PipelineOptions{})
+
+	return azureblob.OpenBucket(ctx, pipeline, azureblob.AccountName(account), conf.container, &azureblob.Options{
+		SASToken: azureblob.SASToken(strings.TrimPrefix(sasToken, "?")),
+	})
+}
+
+// newAzblobStorageUsingManagedIdentity initialize a new azblob storage using the managed identity of the pod
+func newAzblobStorageUsingManagedIdentity(conf *azblobConfig, account, clientID string) (*blob.Bucket, error) {
+	msi := auth.NewMSIConfig()
+	msi.Resource = "https://storage.azure.com/"
+	msi.ClientID = clientID
+	token, err := msi.ServicePrincipalToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh OAuth2 token.
+	if err := token.RefreshWithContext(context.Background()); err != nil {
+		return nil, err
+	}
+
+	// Create the credential using the OAuth2 token.
+	credential := azblob.NewTokenCredential(token.OAuthToken(), nil)
+
+	// Create a Pipeline, using whatever PipelineOptions you need.
+	pipeline := azureblob.NewPipeline(credential, azblob.PipelineOptions{})
+
+	// Create a *blob.Bucket.
+	ctx := context.Background()
+	return azureblob.OpenBucket(ctx, pipeline, azureblob.AccountName(account), conf.container, new(azureblob.Options))
+}
+
 // newGcsStorageOption constructs the arg for --flag option and the remote path for br
 func newGcsStorageOptionForFlag(conf *gcsConfig, flag string) []string {
 	var gcsoptions []string
@@ -644,6 +700,10 @@ func newAzblobStorageOptionForFlag(conf *azblobConfig, flag string) []string {
 	if conf.accessTier != "" {
 		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.access-tier=%s", conf.accessTier))
 	}
+	// br does not read the sas token from the env
+	if conf.sasToken != "" {
+		azblobOptions = append(azblobOptions, fmt.Sprintf("--%s=%s", azblobSasTokenFlag, conf.sasToken))
+	}
 	return azblobOptions
 }
 
@@ -705,7 +765,9 @@ func makeAzblobConfig(azblob *v1alpha1.AzblobStorageProvider) *azblobConfig {
 	conf.container = fields[0]
 	conf.accessTier = azblob.AccessTier
 	conf.secretName = azblob.SecretName
+	conf.storageAccount = azblob.StorageAccount
 	conf.prefix = fields[1]
+	conf.sasToken = os.Getenv(constants.AzblobSasToken)
 
 	return &conf
 }
@@ -33,6 +33,7 @@ import (
 	"gocloud.dev/blob/driver"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 )
 
 type mockS3Client struct {
@@ -620,6 +621,32 @@ func TestBatchDeleteObjectsConcurrently(t *testing.T) {
 	}
 }
 
+func TestGenStorageArgsForFlagAzblob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	provider := v1alpha1.StorageProvider{
+		Azblob: &v1alpha1.AzblobStorageProvider{
+			Container:  "container",
+			Prefix:     "prefix",
+			AccessTier: "Cool",
+		},
+	}
+
+	args, err := GenStorageArgsForFlag(provider, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(args).To(gomega.Equal([]string{"--storage=azure://container/prefix/", "--azblob.access-tier=Cool"}))
+
+	// the sas token in the env is passed to br by the flag
+	t.Setenv(constants.AzblobSasToken, "sv=2021&sig=abc")
+	args, err = GenStorageArgsForFlag(provider, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(args).To(gomega.Equal([]string{"--storage=azure://container/prefix/", "--azblob.access-tier=Cool", "--azblob.sas-token=sv=2021&sig=abc"}))
+	g.Expect(RedactStorageArgs(args)).To(gomega.Equal([]string{"--storage=azure://container/prefix/", "--azblob.access-tier=Cool", "--azblob.sas-token=******"}))
+
+	args, err = GenStorageArgsForFlag(provider, "full-backup-storage")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(args).To(gomega.Equal([]string{"--full-backup-storage=azure://container/prefix/"}))
+}
+
 func objects(size int) []*blob.ListObject {
 	objs := make([]*blob.ListObject, 0, size)
 	for i := 0; i < size; i++ {
@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+// RemapRestoreSource returns a copy of the restore whose references to the source cluster in `spec.source`
+// are remapped to the target cluster in `spec.br`, the restore is returned directly if nothing needs remapping.
+//
+// The remapped references are:
+//   - the TiDB service of the source cluster in `spec.to.host`, e.g. `basic-tidb.prod` to `staging-tidb.test`
+//   - the TLS client secret name prefixed by the source cluster name in `spec.to.tlsClientSecretName`
+//   - the instance label of the restore, which is also set to the restore job
+func RemapRestoreSource(restore *v1alpha1.Restore) *v1alpha1.Restore {
+	source := restore.Spec.Source
+	if source == nil || restore.Spec.BR == nil {
+		return restore
+	}
+	srcName, srcNs := source.Cluster, source.ClusterNamespace
+	if srcNs == "" {
+		srcNs = restore.Namespace
+	}
+	dstName, dstNs := restore.Spec.BR.Cluster, restore.Spec.BR.ClusterNamespace
+	if dstNs == "" {
+		dstNs = restore.Namespace
+	}
+	if srcName == dstName && srcNs == dstNs {
+		return restore
+	}
+
+	remapped := restore.DeepCopy()
+	if to := remapped.Spec.To; to != nil {
+		to.Host = remapTiDBHost(to.Host, srcName, srcNs, dstName, dstNs)
+		if to.TLSClientSecretName != nil && strings.HasPrefix(*to.TLSClientSecretName, srcName+"-") {
+			secretName := dstName + strings.TrimPrefix(*to.TLSClientSecretName, srcName)
+			to.TLSClientSecretName = &secretName
+		}
+	}
+	if remapped.Labels[label.InstanceLabelKey] == srcName {
+		remapped.Labels[label.InstanceLabelKey] = dstName
+	}
+	return remapped
+}
+
+// remapTiDBHost remaps the address of the TiDB service of the source cluster to the target cluster,
+// the address may be the short name, or the name qualified by namespace and the cluster domain.
+func remapTiDBHost(host, srcName, srcNs, dstName, dstNs string) string {
+	srcSvc, dstSvc := controller.TiDBMemberName(srcName), controller.TiDBMemberName(dstName)
+	if host == srcSvc {
+		if srcNs != dstNs {
+			return dstSvc + "." + dstNs
+		}
+		return dstSvc
+	}
+	srcFQDN := srcSvc + "." + srcNs
+	if host == srcFQDN || strings.HasPrefix(host, srcFQDN+".") {
+		return dstSvc + "." + dstNs + strings.TrimPrefix(host, srcFQDN)
+	}
+	return host
+}
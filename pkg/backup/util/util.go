@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -40,6 +41,8 @@ var (
 	tikvLessThanV408, _ = semver.NewConstraint("<v4.0.8-0")
 	// the first version which supports log backup
 	tikvLessThanV610, _ = semver.NewConstraint("<v6.1.0-0")
+	// the first version which supports restoring from checkpoint
+	tikvLessThanV710, _ = semver.NewConstraint("<v7.1.0-0")
 )
 
 // CheckAllKeysExistInSecret check if all keys are included in the specific secret
@@ -183,7 +186,7 @@ func generateGcsCertEnvVar(gcs *v1alpha1.GcsStorageProvider) ([]corev1.EnvVar, s
 }
 
 // generateAzblobCertEnvVar generate the env info in order to access azure blob storage
-func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, useAAD bool) ([]corev1.EnvVar, string, error) {
+func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, useAAD, useSasToken bool) ([]corev1.EnvVar, string, error) {
 	if len(azblob.AccessTier) == 0 {
 		azblob.AccessTier = "Cool"
 	}
@@ -193,18 +196,34 @@ func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, useAAD boo
 			Value: azblob.AccessTier,
 		},
 	}
-	if azblob.SecretName != "" {
-		envVars = append(envVars, []corev1.EnvVar{
-			{
-				Name: "AZURE_STORAGE_ACCOUNT",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-						Key:                  constants.AzblobAccountName,
-					},
+	if azblob.StorageAccount != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "AZURE_STORAGE_ACCOUNT",
+			Value: azblob.StorageAccount,
+		})
+	} else if azblob.SecretName != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "AZURE_STORAGE_ACCOUNT",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
+					Key:                  constants.AzblobAccountName,
 				},
 			},
-		}...)
+		})
+	}
+	// the managed identity of the pod is used if no credentials is provided by secret.
+	if azblob.SecretName != "" && useSasToken {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: constants.AzblobSasToken,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
+					Key:                  constants.AzblobSasToken,
+				},
+			},
+		})
+	} else if azblob.SecretName != "" {
 		if useAAD {
 			envVars = append(envVars, []corev1.EnvVar{
 				{
@@ -302,7 +321,7 @@ func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StoragePro
 			return certEnv, reason, err
 		}
 	case v1alpha1.BackupStorageTypeAzblob:
-		useAAD := true
+		useAAD, useSasToken := true, false
 		azblobSecretName := provider.Azblob.SecretName
 		if azblobSecretName != "" {
 			secret, err := secretLister.Secrets(ns).Get(azblobSecretName)
@@ -311,18 +330,31 @@ func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StoragePro
 				return certEnv, "GetAzblobSecretFailed", err
 			}
 
-			keyStrAAD, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName, constants.AzblobClientID, constants.AzblobClientScrt, constants.AzblobTenantID)
-			if !exist {
-				keyStrShared, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName, constants.AzblobAccountKey)
+			var accountKeys []string
+			if provider.Azblob.StorageAccount == "" {
+				accountKeys = append(accountKeys, constants.AzblobAccountName)
+			}
+			if _, exist := CheckAllKeysExistInSecret(secret, constants.AzblobSasToken); exist {
+				useSasToken = true
+				keyStr, exist := CheckAllKeysExistInSecret(secret, accountKeys...)
 				if !exist {
-					err := fmt.Errorf("the azblob secret %s/%s missing some keys for AAD %s or shared %s", ns, azblobSecretName, keyStrAAD, keyStrShared)
+					err := fmt.Errorf("the azblob secret %s/%s missing some keys %s", ns, azblobSecretName, keyStr)
 					return certEnv, "azblobKeyNotExist", err
 				}
-				useAAD = false
+			} else {
+				keyStrAAD, exist := CheckAllKeysExistInSecret(secret, append(accountKeys, constants.AzblobClientID, constants.AzblobClientScrt, constants.AzblobTenantID)...)
+				if !exist {
+					keyStrShared, exist := CheckAllKeysExistInSecret(secret, append(accountKeys, constants.AzblobAccountKey)...)
+					if !exist {
+						err := fmt.Errorf("the azblob secret %s/%s missing some keys for AAD %s or shared %s", ns, azblobSecretName, keyStrAAD, keyStrShared)
+						return certEnv, "azblobKeyNotExist", err
+					}
+					useAAD = false
+				}
 			}
 		}
 
-		certEnv, reason, err = generateAzblobCertEnvVar(provider.Azblob, useAAD)
+		certEnv, reason, err = generateAzblobCertEnvVar(provider.Azblob, useAAD, useSasToken)
 
 		if err != nil {
 			return certEnv, reason, err
@@ -388,6 +420,9 @@ func GetBackupBucketName(backup *v1alpha1.Backup) (string, string, error) {
 		bucketName = backup.Spec.S3.Bucket
 	case v1alpha1.BackupStorageTypeGcs:
 		bucketName = backup.Spec.Gcs.Bucket
+	case v1alpha1.BackupStorageTypeLocal:
+		// the backup data is stored in the mounted volume
+		bucketName = backup.Spec.Local.VolumeMount.MountPath
 	default:
 		return bucketName, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -406,6 +441,8 @@ func GetBackupPrefixName(backup *v1alpha1.Backup) (string, string, error) {
 		prefix = backup.Spec.S3.Prefix
 	case v1alpha1.BackupStorageTypeGcs:
 		prefix = backup.Spec.Gcs.Prefix
+	case v1alpha1.BackupStorageTypeLocal:
+		prefix = backup.Spec.Local.Prefix
 	default:
 		return prefix, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -440,6 +477,9 @@ func GetBackupDataPath(provider v1alpha1.StorageProvider) (string, string, error
 		backupPath = provider.S3.Path
 	case v1alpha1.BackupStorageTypeGcs:
 		backupPath = provider.Gcs.Path
+	case v1alpha1.BackupStorageTypeLocal:
+		// the prefix of the local storage is the path of the backup data in the mounted volume
+		backupPath = path.Join(provider.Local.VolumeMount.MountPath, provider.Local.Prefix)
 	default:
 		return backupPath, "UnsupportedStorageType", fmt.Errorf("unsupported storage type %s", storageType)
 	}
@@ -471,6 +511,42 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 	ns := backup.Namespace
 	name := backup.Name
 
+	if backup.Spec.Encryption != nil {
+		if backup.Spec.BR == nil {
+			return fmt.Errorf("encryption is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+			return fmt.Errorf("encryption is not supported by %s backup in spec of %s/%s", backup.Spec.Mode, ns, name)
+		}
+		if err := validateEncryption(ns, name, backup.Spec.Encryption); err != nil {
+			return err
+		}
+	}
+
+	if backup.Spec.Estimate != nil {
+		if backup.Spec.BR == nil {
+			return fmt.Errorf("estimate is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+			return fmt.Errorf("estimate is not supported by %s backup in spec of %s/%s", backup.Spec.Mode, ns, name)
+		}
+		if err := validateEstimate(ns, name, backup.Spec.Estimate); err != nil {
+			return err
+		}
+	}
+
+	if backup.Spec.Verify != nil {
+		if backup.Spec.BR == nil {
+			return fmt.Errorf("verify is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+			return fmt.Errorf("verify is not supported by %s backup in spec of %s/%s", backup.Spec.Mode, ns, name)
+		}
+		if err := validateVerify(backup, backup.Spec.Verify); err != nil {
+			return err
+		}
+	}
+
 	if backup.Spec.BR == nil {
 		if reason := validateAccessConfig(backup.Spec.From); reason != "" {
 			return fmt.Errorf(reason, ns, name)
@@ -512,6 +588,10 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 			if err := validateGcs(ns, name, backup.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if backup.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, backup.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if backup.Spec.Local != nil {
 			if err := validateLocal(ns, name, backup.Spec.Local); err != nil {
 				return err
@@ -559,7 +639,60 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 	ns := restore.Namespace
 	name := restore.Name
 
+	if restore.Spec.Encryption != nil {
+		if restore.Spec.BR == nil {
+			return fmt.Errorf("encryption is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if restore.Spec.Mode == v1alpha1.RestoreModePiTR || restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return fmt.Errorf("encryption is not supported by %s restore in spec of %s/%s", restore.Spec.Mode, ns, name)
+		}
+		if err := validateEncryption(ns, name, restore.Spec.Encryption); err != nil {
+			return err
+		}
+	}
+
+	switch restore.Spec.Verification {
+	case "", v1alpha1.RestoreVerificationNone:
+	case v1alpha1.RestoreVerificationChecksum, v1alpha1.RestoreVerificationAdminCheck:
+		if restore.Spec.BR == nil {
+			return fmt.Errorf("verification is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if restore.Spec.Mode == v1alpha1.RestoreModePiTR || restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return fmt.Errorf("verification is not supported by %s restore in spec of %s/%s", restore.Spec.Mode, ns, name)
+		}
+		if restore.Spec.To == nil {
+			return fmt.Errorf("to should be configured for verification in spec of %s/%s", ns, name)
+		}
+		if restore.Spec.Verification == v1alpha1.RestoreVerificationChecksum && restore.Spec.Encryption != nil {
+			// the checksums are read from the backup meta, which is encrypted by BR
+			return fmt.Errorf("checksum verification is not supported by encrypted backup in spec of %s/%s", ns, name)
+		}
+	default:
+		return fmt.Errorf("invalid verification %s in spec of %s/%s", restore.Spec.Verification, ns, name)
+	}
+
+	if scatter := restore.Spec.ScatterRegions; scatter != nil {
+		if restore.Spec.BR == nil {
+			return fmt.Errorf("scatterRegions is only supported by BR in spec of %s/%s", ns, name)
+		}
+		if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return fmt.Errorf("scatterRegions is not supported by %s restore in spec of %s/%s", restore.Spec.Mode, ns, name)
+		}
+		if restore.Spec.To == nil {
+			return fmt.Errorf("to should be configured for scatterRegions in spec of %s/%s", ns, name)
+		}
+		if scatter.RetryLimit != nil && *scatter.RetryLimit < 0 {
+			return fmt.Errorf("retryLimit of scatterRegions should not be negative in spec of %s/%s", ns, name)
+		}
+		if scatter.Timeout != nil && scatter.Timeout.Duration <= 0 {
+			return fmt.Errorf("timeout of scatterRegions should be positive in spec of %s/%s", ns, name)
+		}
+	}
+
 	if restore.Spec.BR == nil {
+		if restore.Spec.Mode == v1alpha1.RestoreModePiTR {
+			return fmt.Errorf("BR should be configured for pitr restore in spec of %s/%s", ns, name)
+		}
 		if reason := validateAccessConfig(restore.Spec.To); reason != "" {
 			return fmt.Errorf(reason, ns, name)
 		}
@@ -576,6 +709,10 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			return fmt.Errorf("cluster should be configured for BR in spec of %s/%s", ns, name)
 		}
 
+		if restore.Spec.Source != nil && restore.Spec.Source.Cluster == "" {
+			return fmt.Errorf("cluster should be configured for source in spec of %s/%s", ns, name)
+		}
+
 		if restore.Spec.Type != "" &&
 			restore.Spec.Type != v1alpha1.BackupTypeFull &&
 			restore.Spec.Type != v1alpha1.BackupTypeDB &&
@@ -600,11 +737,84 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			if err := validateGcs(ns, name, restore.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if restore.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, restore.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if restore.Spec.Local != nil {
 			if err := validateLocal(ns, name, restore.Spec.Local); err != nil {
 				return err
 			}
 		}
+
+		// validate pitr restore
+		if restore.Spec.Mode == v1alpha1.RestoreModePiTR {
+			if restore.Spec.PitrRestoredTs == "" {
+				return fmt.Errorf("pitrRestoredTs should be configured for pitr restore in spec of %s/%s", ns, name)
+			}
+			if _, err := config.ParseTSString(restore.Spec.PitrRestoredTs); err != nil {
+				return fmt.Errorf("invalid pitrRestoredTs %s in spec of %s/%s, err: %v", restore.Spec.PitrRestoredTs, ns, name, err)
+			}
+			if GetStorageType(restore.Spec.PitrFullBackupStorageProvider) == v1alpha1.BackupStorageTypeUnknown {
+				return fmt.Errorf("pitrFullBackupStorageProvider should be configured for pitr restore in spec of %s/%s", ns, name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateEncryption(ns, name string, encryption *v1alpha1.BackupEncryption) error {
+	switch encryption.Method {
+	case "", v1alpha1.BackupEncryptionMethodAES128CTR, v1alpha1.BackupEncryptionMethodAES192CTR, v1alpha1.BackupEncryptionMethodAES256CTR:
+	default:
+		return fmt.Errorf("invalid encryption method %s in spec of %s/%s", encryption.Method, ns, name)
+	}
+	if (encryption.KeySecretRef == nil) == (encryption.MasterKey == "") {
+		return fmt.Errorf("exactly one of keySecretRef and masterKey should be configured for encryption in spec of %s/%s", ns, name)
+	}
+	if encryption.KeySecretRef != nil && (encryption.KeySecretRef.Name == "" || encryption.KeySecretRef.Key == "") {
+		return fmt.Errorf("name and key of keySecretRef should be configured for encryption in spec of %s/%s", ns, name)
+	}
+	return nil
+}
+
+func validateEstimate(ns, name string, estimate *v1alpha1.BackupEstimateSpec) error {
+	if estimate.StorageQuota != nil && estimate.StorageQuota.Sign() < 0 {
+		return fmt.Errorf("storageQuota of estimate should not be negative in spec of %s/%s", ns, name)
+	}
+	if estimate.PricePerGiBMonth != "" {
+		price, err := strconv.ParseFloat(estimate.PricePerGiBMonth, 64)
+		if err != nil || price < 0 {
+			return fmt.Errorf("invalid pricePerGiBMonth %q of estimate in spec of %s/%s", estimate.PricePerGiBMonth, ns, name)
+		}
+	}
+	return nil
+}
+
+func validateVerify(backup *v1alpha1.Backup, verify *v1alpha1.BackupVerifySpec) error {
+	ns, name := backup.GetNamespace(), backup.GetName()
+	switch verify.Mode {
+	case "", v1alpha1.BackupVerifyChecksum:
+		if verify.ScratchCluster != nil || len(verify.TableFilter) > 0 {
+			return fmt.Errorf("scratchCluster and tableFilter of verify are only valid in %s mode in spec of %s/%s", v1alpha1.BackupVerifyScratchRestore, ns, name)
+		}
+	case v1alpha1.BackupVerifyScratchRestore:
+		if verify.ScratchCluster == nil || verify.ScratchCluster.Name == "" {
+			return fmt.Errorf("scratchCluster of verify should be configured in %s mode in spec of %s/%s", v1alpha1.BackupVerifyScratchRestore, ns, name)
+		}
+		clusterNamespace := backup.Spec.BR.ClusterNamespace
+		if clusterNamespace == "" {
+			clusterNamespace = ns
+		}
+		scratchNamespace := verify.ScratchCluster.Namespace
+		if scratchNamespace == "" {
+			scratchNamespace = ns
+		}
+		if verify.ScratchCluster.Name == backup.Spec.BR.Cluster && scratchNamespace == clusterNamespace {
+			return fmt.Errorf("scratchCluster of verify should not be the cluster being backed up in spec of %s/%s", ns, name)
+		}
+	default:
+		return fmt.Errorf("invalid mode %q of verify in spec of %s/%s", verify.Mode, ns, name)
 	}
 	return nil
 }
@@ -641,6 +851,17 @@ func validateGcs(ns, name string, gcs *v1alpha1.GcsStorageProvider) error {
 	return nil
 }
 
+func validateAzblob(ns, name string, azblob *v1alpha1.AzblobStorageProvider) error {
+	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
+	if azblob.Container == "" {
+		return fmt.Errorf("container should be %s", configuredForBR)
+	}
+	if azblob.SecretName == "" && azblob.StorageAccount == "" {
+		return fmt.Errorf("storageAccount or secretName should be %s", configuredForBR)
+	}
+	return nil
+}
+
 func validateLocal(ns, name string, local *v1alpha1.LocalStorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if local.VolumeMount.Name != local.Volume.Name {
@@ -709,6 +930,17 @@ func isLogBackSupport(tikvImage string) bool {
 	return true
 }
 
+// CanResumeRestoreByCheckpoint returns whether br of the tikv version supports restoring from the
+// checkpoint of the previous failed attempt
+func CanResumeRestoreByCheckpoint(tikvVersion string) bool {
+	v, err := semver.NewVersion(tikvVersion)
+	if err != nil {
+		klog.Errorf("Parse version %s failure, error: %v", tikvVersion, err)
+		return false
+	}
+	return !tikvLessThanV710.Check(v)
+}
+
 // GetStorageRestorePath generate the path of a specific storage from Restore
 func GetStoragePath(privoder v1alpha1.StorageProvider) (string, error) {
 	var url, bucket, prefix string
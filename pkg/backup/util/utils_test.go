@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
@@ -168,15 +169,35 @@ func TestGenerateAzblobCertEnvVar(t *testing.T) {
 	azblob = &v1alpha1.AzblobStorageProvider{
 		AccessTier: "",
 	}
-	envs, _, err := generateAzblobCertEnvVar(azblob, true)
+	envs, _, err := generateAzblobCertEnvVar(azblob, true, false)
 	g.Expect(err).Should(BeNil())
 	contains(envs, "AZURE_ACCESS_TIER", "Cool")
 
 	// test &v1alpha1.AzblobStorageProvider AccessTier set value
 	azblob.AccessTier = "Hot"
-	envs, _, err = generateAzblobCertEnvVar(azblob, true)
+	envs, _, err = generateAzblobCertEnvVar(azblob, true, false)
 	g.Expect(err).Should(BeNil())
 	contains(envs, "AZURE_ACCESS_TIER", "Hot")
+
+	// test storage account from spec with managed identity
+	azblob.StorageAccount = "account"
+	envs, _, err = generateAzblobCertEnvVar(azblob, true, false)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(HaveLen(2))
+	contains(envs, "AZURE_STORAGE_ACCOUNT", "account")
+
+	// test the sas token is read from secret instead of the other credentials
+	azblob.SecretName = "secret"
+	envs, _, err = generateAzblobCertEnvVar(azblob, false, false)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(HaveLen(3))
+	envs, _, err = generateAzblobCertEnvVar(azblob, false, true)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(HaveLen(3))
+	contains(envs, "AZURE_STORAGE_ACCOUNT", "account")
+	g.Expect(envs[2].Name).Should(Equal(constants.AzblobSasToken))
+	g.Expect(envs[2].Value).Should(BeEmpty())
+	g.Expect(envs[2].ValueFrom.SecretKeyRef.Key).Should(Equal(constants.AzblobSasToken))
 }
 
 func TestGenerateStorageCertEnv(t *testing.T) {
@@ -382,6 +403,19 @@ func TestGetBackupBucketAdnPrefixName(t *testing.T) {
 			},
 			name: "gcs",
 		},
+		{
+			backup: &v1alpha1.Backup{
+				Spec: v1alpha1.BackupSpec{
+					StorageProvider: v1alpha1.StorageProvider{
+						Local: &v1alpha1.LocalStorageProvider{
+							VolumeMount: corev1.VolumeMount{MountPath: "local"},
+							Prefix:      "local",
+						},
+					},
+				},
+			},
+			name: "local",
+		},
 		{
 			backup: &v1alpha1.Backup{},
 			name:   "",
@@ -446,6 +480,15 @@ func TestGetBackupDataPath(t *testing.T) {
 			},
 			name: "gcs://host",
 		},
+		{
+			provider: v1alpha1.StorageProvider{
+				Local: &v1alpha1.LocalStorageProvider{
+					VolumeMount: corev1.VolumeMount{MountPath: "/mnt/backup"},
+					Prefix:      "backup-2023-01-01T00:00:00Z.tgz",
+				},
+			},
+			name: "local:///mnt/backup/backup-2023-01-01T00:00:00Z.tgz",
+		},
 		{
 			provider: v1alpha1.StorageProvider{},
 			name:     "",
@@ -521,6 +564,82 @@ func TestValidateBackup(t *testing.T) {
 
 	backup.Spec.S3.Endpoint = "s3://localhost:80"
 	match("")
+
+	backup.Spec.S3 = nil
+	backup.Spec.Azblob = &v1alpha1.AzblobStorageProvider{}
+	match("container should be configured for BR in spec of")
+
+	backup.Spec.Azblob.Container = "container"
+	match("storageAccount or secretName should be configured for BR in spec of")
+
+	backup.Spec.Azblob.StorageAccount = "account"
+	match("")
+
+	// encryption case
+	backup.Spec.Encryption = &v1alpha1.BackupEncryption{Method: "aes-invalid"}
+	match("invalid encryption method")
+
+	backup.Spec.Encryption.Method = v1alpha1.BackupEncryptionMethodAES256CTR
+	match("exactly one of keySecretRef and masterKey should be configured")
+
+	backup.Spec.Encryption.KeySecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "key"}}
+	match("name and key of keySecretRef should be configured")
+
+	backup.Spec.Encryption.KeySecretRef.Key = "data-key"
+	match("")
+
+	backup.Spec.Encryption.MasterKey = "aws-kms:///key-id?REGION=us-west-2"
+	match("exactly one of keySecretRef and masterKey should be configured")
+
+	backup.Spec.Encryption.KeySecretRef = nil
+	match("")
+
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	match("encryption is not supported by log backup")
+
+	backup.Spec.Mode = v1alpha1.BackupModeSnapshot
+	backup.Spec.BR = nil
+	match("encryption is only supported by BR")
+
+	// estimate case
+	backup.Spec.Encryption = nil
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb", DB: "dbName", Table: "tableName"}
+	quota := resource.MustParse("-1Ti")
+	backup.Spec.Estimate = &v1alpha1.BackupEstimateSpec{StorageQuota: &quota}
+	match("storageQuota of estimate should not be negative")
+
+	quota = resource.MustParse("1Ti")
+	backup.Spec.Estimate.PricePerGiBMonth = "free"
+	match("invalid pricePerGiBMonth")
+
+	backup.Spec.Estimate.PricePerGiBMonth = "0.023"
+	match("")
+
+	backup.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+	match("estimate is not supported by volume-snapshot backup")
+
+	// verify case
+	backup.Spec.Estimate = nil
+	backup.Spec.Verify = &v1alpha1.BackupVerifySpec{}
+	match("verify is not supported by volume-snapshot backup")
+
+	backup.Spec.Mode = v1alpha1.BackupModeSnapshot
+	match("")
+
+	backup.Spec.Verify.TableFilter = []string{"db.*"}
+	match("only valid in ScratchRestore mode")
+
+	backup.Spec.Verify.Mode = v1alpha1.BackupVerifyScratchRestore
+	match("scratchCluster of verify should be configured")
+
+	backup.Spec.Verify.ScratchCluster = &v1alpha1.TidbClusterRef{Name: "tidb"}
+	match("should not be the cluster being backed up")
+
+	backup.Spec.Verify.ScratchCluster.Name = "scratch"
+	match("")
+
+	backup.Spec.Verify.Mode = "Restore"
+	match("invalid mode")
 }
 
 func TestValidateRestore(t *testing.T) {
@@ -581,6 +700,53 @@ func TestValidateRestore(t *testing.T) {
 
 	restore.Spec.S3.Endpoint = "s3://localhost:80"
 	match("")
+
+	// pitr restore case
+	restore.Spec.Mode = v1alpha1.RestoreModePiTR
+	match("pitrRestoredTs should be configured for pitr restore")
+
+	restore.Spec.PitrRestoredTs = "invalid"
+	match("invalid pitrRestoredTs")
+
+	restore.Spec.PitrRestoredTs = "2023-03-01 12:00:00"
+	match("pitrFullBackupStorageProvider should be configured for pitr restore")
+
+	restore.Spec.PitrFullBackupStorageProvider.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket", Prefix: "full"}
+	match("")
+
+	restore.Spec.BR = nil
+	match("BR should be configured for pitr restore")
+
+	// encryption case
+	restore.Spec.Encryption = &v1alpha1.BackupEncryption{MasterKey: "gcp-kms:///projects/p/locations/global/keyRings/r/cryptoKeys/k"}
+	match("encryption is only supported by BR")
+
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb", DB: "dbName", Table: "tableName"}
+	match("encryption is not supported by pitr restore")
+
+	restore.Spec.Mode = v1alpha1.RestoreModeSnapshot
+	match("")
+
+	// verification case
+	restore.Spec.Verification = v1alpha1.RestoreVerificationType("invalid")
+	match("invalid verification")
+
+	restore.Spec.Verification = v1alpha1.RestoreVerificationChecksum
+	match("checksum verification is not supported by encrypted backup")
+
+	restore.Spec.Verification = v1alpha1.RestoreVerificationAdminCheck
+	match("")
+
+	restore.Spec.Encryption = nil
+	restore.Spec.Verification = v1alpha1.RestoreVerificationChecksum
+	match("")
+
+	restore.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+	match("verification is not supported by volume-snapshot restore")
+
+	restore.Spec.Mode = v1alpha1.RestoreModeSnapshot
+	restore.Spec.To = nil
+	match("to should be configured for verification")
 }
 
 func TestGetImageTag(t *testing.T) {
@@ -640,3 +806,45 @@ func TestGetImageTag(t *testing.T) {
 		})
 	}
 }
+
+func TestRemapRestoreSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tlsSecret := "prod-tidb-client-secret"
+	restore := &v1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "clone",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/instance": "prod"},
+		},
+		Spec: v1alpha1.RestoreSpec{
+			To: &v1alpha1.TiDBAccessConfig{
+				Host:                "prod-tidb.prod.svc.cluster.local",
+				SecretName:          "restore-secret",
+				TLSClientSecretName: &tlsSecret,
+			},
+			BR: &v1alpha1.BRConfig{Cluster: "staging"},
+		},
+	}
+
+	// nothing is remapped without the source
+	g.Expect(RemapRestoreSource(restore)).To(BeIdenticalTo(restore))
+
+	restore.Spec.Source = &v1alpha1.RestoreSource{Cluster: "prod", ClusterNamespace: "prod"}
+	remapped := RemapRestoreSource(restore)
+	g.Expect(remapped.Spec.To.Host).To(Equal("staging-tidb.test.svc.cluster.local"))
+	g.Expect(*remapped.Spec.To.TLSClientSecretName).To(Equal("staging-tidb-client-secret"))
+	g.Expect(remapped.Spec.To.SecretName).To(Equal("restore-secret"))
+	g.Expect(remapped.Labels["app.kubernetes.io/instance"]).To(Equal("staging"))
+	// the restore itself is not changed
+	g.Expect(restore.Spec.To.Host).To(Equal("prod-tidb.prod.svc.cluster.local"))
+	g.Expect(restore.Labels["app.kubernetes.io/instance"]).To(Equal("prod"))
+
+	// the short name of the service is qualified by namespace if the namespace is different
+	restore.Spec.To.Host = "prod-tidb"
+	g.Expect(RemapRestoreSource(restore).Spec.To.Host).To(Equal("staging-tidb.test"))
+
+	// the hosts of other services are not remapped
+	restore.Spec.To.Host = "tidb.example.com"
+	g.Expect(RemapRestoreSource(restore).Spec.To.Host).To(Equal("tidb.example.com"))
+}
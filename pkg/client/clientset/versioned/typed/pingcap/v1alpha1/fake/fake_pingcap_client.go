@@ -37,14 +37,30 @@ func (c *FakePingcapV1alpha1) DMClusters(namespace string) v1alpha1.DMClusterInt
 	return &FakeDMClusters{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) DMTasks(namespace string) v1alpha1.DMTaskInterface {
+	return &FakeDMTasks{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) DataResources(namespace string) v1alpha1.DataResourceInterface {
 	return &FakeDataResources{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) Diagnostics(namespace string) v1alpha1.DiagnosticInterface {
+	return &FakeDiagnostics{c, namespace}
+}
+
+func (c *FakePingcapV1alpha1) PDRecoveries(namespace string) v1alpha1.PDRecoveryInterface {
+	return &FakePDRecoveries{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) Restores(namespace string) v1alpha1.RestoreInterface {
 	return &FakeRestores{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) TiCDCChangefeeds(namespace string) v1alpha1.TiCDCChangefeedInterface {
+	return &FakeTiCDCChangefeeds{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) TidbClusters(namespace string) v1alpha1.TidbClusterInterface {
 	return &FakeTidbClusters{c, namespace}
 }
@@ -53,6 +69,14 @@ func (c *FakePingcapV1alpha1) TidbClusterAutoScalers(namespace string) v1alpha1.
 	return &FakeTidbClusterAutoScalers{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) TidbClusterBackupPolicies(namespace string) v1alpha1.TidbClusterBackupPolicyInterface {
+	return &FakeTidbClusterBackupPolicies{c, namespace}
+}
+
+func (c *FakePingcapV1alpha1) TidbClusterRollouts(namespace string) v1alpha1.TidbClusterRolloutInterface {
+	return &FakeTidbClusterRollouts{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) TidbDashboards(namespace string) v1alpha1.TidbDashboardInterface {
 	return &FakeTidbDashboards{c, namespace}
 }
@@ -21,14 +21,26 @@ type BackupScheduleExpansion interface{}
 
 type DMClusterExpansion interface{}
 
+type DMTaskExpansion interface{}
+
 type DataResourceExpansion interface{}
 
+type DiagnosticExpansion interface{}
+
+type PDRecoveryExpansion interface{}
+
 type RestoreExpansion interface{}
 
+type TiCDCChangefeedExpansion interface{}
+
 type TidbClusterExpansion interface{}
 
 type TidbClusterAutoScalerExpansion interface{}
 
+type TidbClusterBackupPolicyExpansion interface{}
+
+type TidbClusterRolloutExpansion interface{}
+
 type TidbDashboardExpansion interface{}
 
 type TidbInitializerExpansion interface{}
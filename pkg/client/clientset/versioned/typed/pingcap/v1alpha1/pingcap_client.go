@@ -26,10 +26,16 @@ type PingcapV1alpha1Interface interface {
 	BackupsGetter
 	BackupSchedulesGetter
 	DMClustersGetter
+	DMTasksGetter
 	DataResourcesGetter
+	DiagnosticsGetter
+	PDRecoveriesGetter
 	RestoresGetter
+	TiCDCChangefeedsGetter
 	TidbClustersGetter
 	TidbClusterAutoScalersGetter
+	TidbClusterBackupPoliciesGetter
+	TidbClusterRolloutsGetter
 	TidbDashboardsGetter
 	TidbInitializersGetter
 	TidbMonitorsGetter
@@ -53,14 +59,30 @@ func (c *PingcapV1alpha1Client) DMClusters(namespace string) DMClusterInterface
 	return newDMClusters(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) DMTasks(namespace string) DMTaskInterface {
+	return newDMTasks(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) DataResources(namespace string) DataResourceInterface {
 	return newDataResources(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) Diagnostics(namespace string) DiagnosticInterface {
+	return newDiagnostics(c, namespace)
+}
+
+func (c *PingcapV1alpha1Client) PDRecoveries(namespace string) PDRecoveryInterface {
+	return newPDRecoveries(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) Restores(namespace string) RestoreInterface {
 	return newRestores(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TiCDCChangefeeds(namespace string) TiCDCChangefeedInterface {
+	return newTiCDCChangefeeds(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbClusters(namespace string) TidbClusterInterface {
 	return newTidbClusters(c, namespace)
 }
@@ -69,6 +91,14 @@ func (c *PingcapV1alpha1Client) TidbClusterAutoScalers(namespace string) TidbClu
 	return newTidbClusterAutoScalers(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TidbClusterBackupPolicies(namespace string) TidbClusterBackupPolicyInterface {
+	return newTidbClusterBackupPolicies(c, namespace)
+}
+
+func (c *PingcapV1alpha1Client) TidbClusterRollouts(namespace string) TidbClusterRolloutInterface {
+	return newTidbClusterRollouts(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbDashboards(namespace string) TidbDashboardInterface {
 	return newTidbDashboards(c, namespace)
 }
@@ -56,14 +56,26 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().BackupSchedules().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dmclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().DMClusters().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dmtasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().DMTasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dataresources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().DataResources().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("diagnostics"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().Diagnostics().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("pdrecoveries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().PDRecoveries().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("restores"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().Restores().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("ticdcchangefeeds"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TiCDCChangefeeds().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tidbclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TidbClusters().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tidbclusterautoscalers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TidbClusterAutoScalers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tidbclusterbackuppolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TidbClusterBackupPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tidbclusterrollouts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TidbClusterRollouts().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tidbdashboards"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Pingcap().V1alpha1().TidbDashboards().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tidbinitializers"):
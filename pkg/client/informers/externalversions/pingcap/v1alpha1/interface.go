@@ -27,14 +27,26 @@ type Interface interface {
 	BackupSchedules() BackupScheduleInformer
 	// DMClusters returns a DMClusterInformer.
 	DMClusters() DMClusterInformer
+	// DMTasks returns a DMTaskInformer.
+	DMTasks() DMTaskInformer
 	// DataResources returns a DataResourceInformer.
 	DataResources() DataResourceInformer
+	// Diagnostics returns a DiagnosticInformer.
+	Diagnostics() DiagnosticInformer
+	// PDRecoveries returns a PDRecoveryInformer.
+	PDRecoveries() PDRecoveryInformer
 	// Restores returns a RestoreInformer.
 	Restores() RestoreInformer
+	// TiCDCChangefeeds returns a TiCDCChangefeedInformer.
+	TiCDCChangefeeds() TiCDCChangefeedInformer
 	// TidbClusters returns a TidbClusterInformer.
 	TidbClusters() TidbClusterInformer
 	// TidbClusterAutoScalers returns a TidbClusterAutoScalerInformer.
 	TidbClusterAutoScalers() TidbClusterAutoScalerInformer
+	// TidbClusterBackupPolicies returns a TidbClusterBackupPolicyInformer.
+	TidbClusterBackupPolicies() TidbClusterBackupPolicyInformer
+	// TidbClusterRollouts returns a TidbClusterRolloutInformer.
+	TidbClusterRollouts() TidbClusterRolloutInformer
 	// TidbDashboards returns a TidbDashboardInformer.
 	TidbDashboards() TidbDashboardInformer
 	// TidbInitializers returns a TidbInitializerInformer.
@@ -71,16 +83,36 @@ func (v *version) DMClusters() DMClusterInformer {
 	return &dMClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DMTasks returns a DMTaskInformer.
+func (v *version) DMTasks() DMTaskInformer {
+	return &dMTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DataResources returns a DataResourceInformer.
 func (v *version) DataResources() DataResourceInformer {
 	return &dataResourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Diagnostics returns a DiagnosticInformer.
+func (v *version) Diagnostics() DiagnosticInformer {
+	return &diagnosticInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PDRecoveries returns a PDRecoveryInformer.
+func (v *version) PDRecoveries() PDRecoveryInformer {
+	return &pDRecoveryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Restores returns a RestoreInformer.
 func (v *version) Restores() RestoreInformer {
 	return &restoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TiCDCChangefeeds returns a TiCDCChangefeedInformer.
+func (v *version) TiCDCChangefeeds() TiCDCChangefeedInformer {
+	return &tiCDCChangefeedInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbClusters returns a TidbClusterInformer.
 func (v *version) TidbClusters() TidbClusterInformer {
 	return &tidbClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -91,6 +123,16 @@ func (v *version) TidbClusterAutoScalers() TidbClusterAutoScalerInformer {
 	return &tidbClusterAutoScalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TidbClusterBackupPolicies returns a TidbClusterBackupPolicyInformer.
+func (v *version) TidbClusterBackupPolicies() TidbClusterBackupPolicyInformer {
+	return &tidbClusterBackupPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TidbClusterRollouts returns a TidbClusterRolloutInformer.
+func (v *version) TidbClusterRollouts() TidbClusterRolloutInformer {
+	return &tidbClusterRolloutInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbDashboards returns a TidbDashboardInformer.
 func (v *version) TidbDashboards() TidbDashboardInformer {
 	return &tidbDashboardInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
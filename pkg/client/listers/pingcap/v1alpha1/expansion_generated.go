@@ -39,6 +39,14 @@ type DMClusterListerExpansion interface{}
 // DMClusterNamespaceLister.
 type DMClusterNamespaceListerExpansion interface{}
 
+// DMTaskListerExpansion allows custom methods to be added to
+// DMTaskLister.
+type DMTaskListerExpansion interface{}
+
+// DMTaskNamespaceListerExpansion allows custom methods to be added to
+// DMTaskNamespaceLister.
+type DMTaskNamespaceListerExpansion interface{}
+
 // DataResourceListerExpansion allows custom methods to be added to
 // DataResourceLister.
 type DataResourceListerExpansion interface{}
@@ -47,6 +55,22 @@ type DataResourceListerExpansion interface{}
 // DataResourceNamespaceLister.
 type DataResourceNamespaceListerExpansion interface{}
 
+// DiagnosticListerExpansion allows custom methods to be added to
+// DiagnosticLister.
+type DiagnosticListerExpansion interface{}
+
+// DiagnosticNamespaceListerExpansion allows custom methods to be added to
+// DiagnosticNamespaceLister.
+type DiagnosticNamespaceListerExpansion interface{}
+
+// PDRecoveryListerExpansion allows custom methods to be added to
+// PDRecoveryLister.
+type PDRecoveryListerExpansion interface{}
+
+// PDRecoveryNamespaceListerExpansion allows custom methods to be added to
+// PDRecoveryNamespaceLister.
+type PDRecoveryNamespaceListerExpansion interface{}
+
 // RestoreListerExpansion allows custom methods to be added to
 // RestoreLister.
 type RestoreListerExpansion interface{}
@@ -55,6 +79,14 @@ type RestoreListerExpansion interface{}
 // RestoreNamespaceLister.
 type RestoreNamespaceListerExpansion interface{}
 
+// TiCDCChangefeedListerExpansion allows custom methods to be added to
+// TiCDCChangefeedLister.
+type TiCDCChangefeedListerExpansion interface{}
+
+// TiCDCChangefeedNamespaceListerExpansion allows custom methods to be added to
+// TiCDCChangefeedNamespaceLister.
+type TiCDCChangefeedNamespaceListerExpansion interface{}
+
 // TidbClusterListerExpansion allows custom methods to be added to
 // TidbClusterLister.
 type TidbClusterListerExpansion interface{}
@@ -71,6 +103,22 @@ type TidbClusterAutoScalerListerExpansion interface{}
 // TidbClusterAutoScalerNamespaceLister.
 type TidbClusterAutoScalerNamespaceListerExpansion interface{}
 
+// TidbClusterBackupPolicyListerExpansion allows custom methods to be added to
+// TidbClusterBackupPolicyLister.
+type TidbClusterBackupPolicyListerExpansion interface{}
+
+// TidbClusterBackupPolicyNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterBackupPolicyNamespaceLister.
+type TidbClusterBackupPolicyNamespaceListerExpansion interface{}
+
+// TidbClusterRolloutListerExpansion allows custom methods to be added to
+// TidbClusterRolloutLister.
+type TidbClusterRolloutListerExpansion interface{}
+
+// TidbClusterRolloutNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterRolloutNamespaceLister.
+type TidbClusterRolloutNamespaceListerExpansion interface{}
+
 // TidbDashboardListerExpansion allows custom methods to be added to
 // TidbDashboardLister.
 type TidbDashboardListerExpansion interface{}
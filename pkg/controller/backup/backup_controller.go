@@ -171,6 +171,11 @@ func (c *Controller) updateBackup(cur interface{}) {
 	}
 
 	if v1alpha1.IsBackupComplete(newBackup) {
+		if newBackup.NeedVerification() && !v1alpha1.IsBackupVerificationDone(newBackup) {
+			klog.V(4).Infof("backup %s/%s is Complete and waits for verification, enqueue.", ns, name)
+			c.enqueueBackup(newBackup)
+			return
+		}
 		klog.V(4).Infof("backup %s/%s is Complete, skipping.", ns, name)
 		return
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -47,6 +48,8 @@ type BackupUpdateStatus struct {
 	BackupSize *int64
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs *string
+	// StatsIncluded indicates whether the statistics of the tables are included in the backup.
+	StatsIncluded *bool
 	// LogCheckpointTs is the ts of log backup process.
 	LogCheckpointTs *string
 	// LogSuccessTruncateUntil is log backup already successfully truncate until timestamp.
@@ -57,8 +60,14 @@ type BackupUpdateStatus struct {
 	ProgressStep *string
 	// Progress is the step's progress value.
 	Progress *float64
+	// ProgressSpeed is the step's speed reported by br.
+	ProgressSpeed *string
 	// ProgressUpdateTime is the progress update time.
 	ProgressUpdateTime *metav1.Time
+	// Estimate is the estimate of the backup made before the backup job is created.
+	Estimate *v1alpha1.BackupEstimate
+	// TableFilter is the result of resolving the table filter against the schema of the cluster.
+	TableFilter *v1alpha1.BackupTableFilterResult
 
 	// RetryNum is the number of retry
 	RetryNum *int
@@ -162,6 +171,10 @@ func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateSt
 		status.CommitTs = *newStatus.CommitTs
 		isUpdate = true
 	}
+	if newStatus.StatsIncluded != nil && status.StatsIncluded != *newStatus.StatsIncluded {
+		status.StatsIncluded = *newStatus.StatsIncluded
+		isUpdate = true
+	}
 	if newStatus.LogCheckpointTs != nil && status.LogCheckpointTs != *newStatus.LogCheckpointTs {
 		status.LogCheckpointTs = *newStatus.LogCheckpointTs
 		isUpdate = true
@@ -170,8 +183,16 @@ func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateSt
 		status.LogSuccessTruncateUntil = *newStatus.LogSuccessTruncateUntil
 		isUpdate = true
 	}
+	if newStatus.Estimate != nil && !apiequality.Semantic.DeepEqual(status.Estimate, newStatus.Estimate) {
+		status.Estimate = newStatus.Estimate.DeepCopy()
+		isUpdate = true
+	}
+	if newStatus.TableFilter != nil && !apiequality.Semantic.DeepEqual(status.TableFilter, newStatus.TableFilter) {
+		status.TableFilter = newStatus.TableFilter.DeepCopy()
+		isUpdate = true
+	}
 	if newStatus.ProgressStep != nil {
-		progresses, updated := updateBRProgress(status.Progresses, newStatus.ProgressStep, newStatus.Progress, newStatus.ProgressUpdateTime)
+		progresses, updated := updateBRProgress(status.Progresses, newStatus.ProgressStep, newStatus.Progress, newStatus.ProgressSpeed, newStatus.ProgressUpdateTime)
 		if updated {
 			status.Progresses = progresses
 			isUpdate = true
@@ -411,7 +432,7 @@ func updateLogSubCommandConditionOnly(status *v1alpha1.LogSubCommandStatus, cond
 }
 
 // updateBRProgress updates progress for backup/restore.
-func updateBRProgress(progresses []v1alpha1.Progress, step *string, progress *float64, updateTime *metav1.Time) ([]v1alpha1.Progress, bool) {
+func updateBRProgress(progresses []v1alpha1.Progress, step *string, progress *float64, speed *string, updateTime *metav1.Time) ([]v1alpha1.Progress, bool) {
 	var oldProgress *v1alpha1.Progress
 	for i, p := range progresses {
 		if p.Step == *step {
@@ -426,17 +447,22 @@ func updateBRProgress(progresses []v1alpha1.Progress, step *string, progress *fl
 			return
 		}
 		progresses[size-1].Progress = 100
+		progresses[size-1].Speed = ""
 		progresses[size-1].LastTransitionTime = metav1.Time{Time: time.Now()}
 	}
 
 	// no such progress, will new
 	if oldProgress == nil {
 		makeSureLastProgressOver()
-		progresses = append(progresses, v1alpha1.Progress{
+		newProgress := v1alpha1.Progress{
 			Step:               *step,
 			Progress:           *progress,
 			LastTransitionTime: *updateTime,
-		})
+		}
+		if speed != nil {
+			newProgress.Speed = *speed
+		}
+		progresses = append(progresses, newProgress)
 		return progresses, true
 	}
 
@@ -446,6 +472,16 @@ func updateBRProgress(progresses []v1alpha1.Progress, step *string, progress *fl
 		isUpdate = true
 	}
 
+	// the speed is meaningless once the step is completed
+	if speed != nil && oldProgress.Speed != *speed {
+		oldProgress.Speed = *speed
+		isUpdate = true
+	}
+	if oldProgress.Progress >= 100 && oldProgress.Speed != "" {
+		oldProgress.Speed = ""
+		isUpdate = true
+	}
+
 	if oldProgress.LastTransitionTime != *updateTime {
 		oldProgress.LastTransitionTime = *updateTime
 		isUpdate = true
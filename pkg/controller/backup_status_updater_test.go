@@ -19,6 +19,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -64,8 +65,10 @@ func newUpdateBackupStatus() *BackupUpdateStatus {
 	path := "abcd"
 	sizeReadable := "5M"
 	size := int64(5024)
+	statsIncluded := true
 	return &BackupUpdateStatus{
 		CommitTs:           &ts,
+		StatsIncluded:      &statsIncluded,
 		TimeCompleted:      &metav1.Time{Time: end},
 		TimeStarted:        &metav1.Time{Time: start},
 		BackupPath:         &path,
@@ -119,5 +122,53 @@ func newExpectBackupStatus() *v1alpha1.BackupStatus {
 	s.BackupPath = path
 	s.BackupSizeReadable = sizeReadable
 	s.BackupSize = size
+	s.StatsIncluded = true
 	return s
 }
+
+func TestUpdateBRProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	step := "Full Backup"
+	progress := 10.0
+	speed := "7.5 p/s"
+	now := metav1.Now()
+	progresses, updated := updateBRProgress(nil, &step, &progress, &speed, &now)
+	g.Expect(updated).To(BeTrue())
+	g.Expect(progresses).To(Equal([]v1alpha1.Progress{{Step: step, Progress: progress, Speed: speed, LastTransitionTime: now}}))
+
+	// the speed is updated with the progress
+	progress, speed = 20, "8 p/s"
+	progresses, updated = updateBRProgress(progresses, &step, &progress, &speed, &now)
+	g.Expect(updated).To(BeTrue())
+	g.Expect(progresses[0].Progress).To(Equal(20.0))
+	g.Expect(progresses[0].Speed).To(Equal("8 p/s"))
+
+	// the last step is completed and its speed is cleared when a new step starts
+	newStep := "Checksum"
+	progresses, updated = updateBRProgress(progresses, &newStep, &progress, nil, &now)
+	g.Expect(updated).To(BeTrue())
+	g.Expect(progresses).To(HaveLen(2))
+	g.Expect(progresses[0].Progress).To(Equal(100.0))
+	g.Expect(progresses[0].Speed).To(BeEmpty())
+	g.Expect(progresses[1].Speed).To(BeEmpty())
+}
+
+func TestUpdateSnapshotBackupVerifiedCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := &v1alpha1.Backup{}
+	g.Expect(updateSnapshotBackupStatus(backup, &v1alpha1.BackupCondition{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}, nil)).To(BeTrue())
+
+	// the Verified condition never changes the phase
+	verified := &v1alpha1.BackupCondition{Type: v1alpha1.BackupVerified, Status: corev1.ConditionUnknown, Reason: "Verifying"}
+	g.Expect(updateSnapshotBackupStatus(backup, verified, nil)).To(BeTrue())
+	g.Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupComplete))
+	g.Expect(v1alpha1.IsBackupVerificationDone(backup)).To(BeFalse())
+	g.Expect(updateSnapshotBackupStatus(backup, verified.DeepCopy(), nil)).To(BeFalse())
+
+	g.Expect(updateSnapshotBackupStatus(backup, &v1alpha1.BackupCondition{Type: v1alpha1.BackupVerified, Status: corev1.ConditionTrue, Reason: "VerificationPassed"}, nil)).To(BeTrue())
+	g.Expect(backup.Status.Phase).To(Equal(v1alpha1.BackupComplete))
+	g.Expect(v1alpha1.IsBackupComplete(backup)).To(BeTrue())
+	g.Expect(v1alpha1.IsBackupVerificationDone(backup)).To(BeTrue())
+}
@@ -50,6 +50,12 @@ var (
 	// RestoreControllerKind contains the schema.GroupVersionKind for restore controller type.
 	RestoreControllerKind = v1alpha1.SchemeGroupVersion.WithKind("Restore")
 
+	// DiagnosticControllerKind contains the schema.GroupVersionKind for diagnostic controller type.
+	DiagnosticControllerKind = v1alpha1.SchemeGroupVersion.WithKind("Diagnostic")
+
+	// PDRecoveryControllerKind contains the schema.GroupVersionKind for pd recovery controller type.
+	PDRecoveryControllerKind = v1alpha1.SchemeGroupVersion.WithKind("PDRecovery")
+
 	// backupScheduleControllerKind contains the schema.GroupVersionKind for backupschedule controller type.
 	backupScheduleControllerKind = v1alpha1.SchemeGroupVersion.WithKind("BackupSchedule")
 
@@ -162,6 +168,34 @@ func GetRestoreOwnerRef(restore *v1alpha1.Restore) metav1.OwnerReference {
 	}
 }
 
+// GetDiagnosticOwnerRef returns Diagnostic's OwnerReference
+func GetDiagnosticOwnerRef(d *v1alpha1.Diagnostic) metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+	return metav1.OwnerReference{
+		APIVersion:         DiagnosticControllerKind.GroupVersion().String(),
+		Kind:               DiagnosticControllerKind.Kind,
+		Name:               d.GetName(),
+		UID:                d.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
+// GetPDRecoveryOwnerRef returns PDRecovery's OwnerReference
+func GetPDRecoveryOwnerRef(r *v1alpha1.PDRecovery) metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+	return metav1.OwnerReference{
+		APIVersion:         PDRecoveryControllerKind.GroupVersion().String(),
+		Kind:               PDRecoveryControllerKind.Kind,
+		Name:               r.GetName(),
+		UID:                r.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
 // GetBackupScheduleOwnerRef returns BackupSchedule's OwnerReference
 func GetBackupScheduleOwnerRef(bs *v1alpha1.BackupSchedule) metav1.OwnerReference {
 	controller := true
@@ -286,6 +320,16 @@ func PDPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-pd-peer", clusterName)
 }
 
+// PDMSMemberName returns the member name of the PD microservice
+func PDMSMemberName(clusterName, name string) string {
+	return fmt.Sprintf("%s-%s", clusterName, name)
+}
+
+// PDMSPeerMemberName returns the peer service name of the PD microservice
+func PDMSPeerMemberName(clusterName, name string) string {
+	return fmt.Sprintf("%s-%s-peer", clusterName, name)
+}
+
 // TiKVMemberName returns tikv member name
 func TiKVMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tikv", clusterName)
@@ -306,6 +350,16 @@ func TiFlashPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tiflash-peer", clusterName)
 }
 
+// TiFlashComputeMemberName returns the member name of the tiflash compute nodes
+func TiFlashComputeMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-tiflash-compute", clusterName)
+}
+
+// TiFlashComputePeerMemberName returns the peer service name of the tiflash compute nodes
+func TiFlashComputePeerMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-tiflash-compute-peer", clusterName)
+}
+
 // TiProxyMemberName returns tiproxy member name
 func TiProxyMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tiproxy", clusterName)
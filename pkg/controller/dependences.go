@@ -17,12 +17,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
@@ -30,10 +32,13 @@ import (
 	appslisters "k8s.io/client-go/listers/apps/v1"
 	batchlisters "k8s.io/client-go/listers/batch/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1beta1"
 	extensionslister "k8s.io/client-go/listers/extensions/v1beta1"
 	networklister "k8s.io/client-go/listers/networking/v1"
 	storagelister "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -61,6 +66,9 @@ type CLIConfig struct {
 	// Controls whether operator should manage kubernetes cluster
 	// wide TiDB clusters
 	ClusterScoped bool
+	// WatchNamespaces is a comma separated list of the namespaces watched by the operator
+	// if it's not cluster scoped, only the namespace of the operator is watched if it's empty
+	WatchNamespaces string
 
 	ClusterPermissionNode bool
 	ClusterPermissionPV   bool
@@ -95,8 +103,68 @@ type CLIConfig struct {
 	// KubeClientQPS indicates the maximum QPS to the kubenetes API server from client.
 	KubeClientQPS   float64
 	KubeClientBurst int
+
+	// PriceSheetConfigMap is the ConfigMap in the format of `namespace/name` which contains
+	// the unit prices used to estimate the cost of TidbClusters, cost estimation is disabled if empty
+	PriceSheetConfigMap string
+
+	// OrphanSweepPeriod is the period to sweep the resources whose owner CR no longer exists,
+	// the sweeper is disabled if it is zero
+	OrphanSweepPeriod time.Duration
+	// OrphanSweepPolicy is the policy to handle the orphaned resources, Report or Delete
+	OrphanSweepPolicy string
+
+	// StoreLabelNodeLabels maps the store labels to the node labels which are not
+	// well-known, e.g. `rack=example.com/rack`, it is used when setting the store labels
+	// from the labels of the node where the pod is scheduled
+	StoreLabelNodeLabels cliflag.ConfigurationMap
+
+	// RegistryMirrors maps the registries or repository prefixes to their mirrors,
+	// e.g. `docker.io=mirror.example.com`, it is applied to the images of all the
+	// components and can be overridden by the registryMirrors of the TidbCluster
+	RegistryMirrors cliflag.ConfigurationMap
+
+	// AirGapped disables the telemetry of all the components, requires all the images
+	// to be pinned by a tag or digest and validates the versions by the VersionCatalogConfigMap
+	AirGapped bool
+	// VersionCatalogConfigMap is the ConfigMap in the format of `namespace/name` which contains
+	// the supported versions of the components, it is only used in the air-gapped mode
+	VersionCatalogConfigMap string
+
+	// NodeDrainLeaderEviction transfers the PD leader and evicts the TiKV region leaders off
+	// the pods on the nodes which are cordoned or tainted by NodeDrainTaintKey
+	NodeDrainLeaderEviction bool
+	// NodeDrainTaintKey is the key of the taint which marks a node as being drained
+	NodeDrainTaintKey string
+
+	// NotificationConfigMap is the ConfigMap in the format of `namespace/name` which contains
+	// the global notification sinks of all the clusters, the significant events are forwarded
+	// to them besides the sinks of each TidbCluster
+	NotificationConfigMap string
+
+	// FIPSMode restricts the TLS versions and cipher suites used by tidb-operator and the
+	// components, and reports the TLS secrets whose certificates are not compliant
+	FIPSMode bool
+
+	// PreflightReportConfigMap is the ConfigMap in the format of `namespace/name` which the report of the
+	// clusters affected by the changes of the operator version is written to by the preflight on startup
+	PreflightReportConfigMap string
+	// PreflightHoldReconcile holds the reconciliation of the clusters affected by the changes of the operator
+	// version until the changes are acknowledged by the preflight-ack annotation
+	PreflightHoldReconcile bool
+
+	// EndpointSliceDiscovery resolves the members of the services from the EndpointSlices instead of
+	// the Endpoints and pods, it falls back to the Endpoints if the EndpointSlices are not supported
+	EndpointSliceDiscovery bool
 }
 
+const (
+	// OrphanSweepPolicyReport reports the orphaned resources via metrics and events
+	OrphanSweepPolicyReport = "Report"
+	// OrphanSweepPolicyDelete deletes the orphaned resources after reporting them
+	OrphanSweepPolicyDelete = "Delete"
+)
+
 // DefaultCLIConfig returns the default command line configuration
 func DefaultCLIConfig() *CLIConfig {
 	return &CLIConfig{
@@ -119,6 +187,11 @@ func DefaultCLIConfig() *CLIConfig {
 		TiDBBackupManagerImage: "pingcap/tidb-backup-manager:latest",
 		TiDBDiscoveryImage:     "pingcap/tidb-operator:latest",
 		Selector:               "",
+		OrphanSweepPeriod:      time.Hour,
+		OrphanSweepPolicy:      OrphanSweepPolicyReport,
+		StoreLabelNodeLabels:   cliflag.ConfigurationMap{},
+		RegistryMirrors:        cliflag.ConfigurationMap{},
+		EndpointSliceDiscovery: true,
 	}
 }
 
@@ -128,6 +201,7 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.BoolVar(&c.PrintVersion, "version", false, "Show version and quit")
 	flag.IntVar(&c.Workers, "workers", c.Workers, "The number of workers that are allowed to sync concurrently. Larger number = more responsive management, but more CPU (and network) load")
 	flag.BoolVar(&c.ClusterScoped, "cluster-scoped", c.ClusterScoped, "Whether tidb-operator should manage kubernetes cluster wide TiDB Clusters")
+	flag.StringVar(&c.WatchNamespaces, "watch-namespaces", c.WatchNamespaces, "A comma separated list of the namespaces watched by tidb-operator if cluster-scoped is false, only Roles in these namespaces are required, default to the namespace of tidb-operator")
 	flag.BoolVar(&c.ClusterPermissionNode, "cluster-permission-node", c.ClusterPermissionNode, "Whether tidb-operator should have node permissions even if cluster-scoped is false")
 	flag.BoolVar(&c.ClusterPermissionPV, "cluster-permission-pv", c.ClusterPermissionPV, "Whether tidb-operator should have persistent volume permissions even if cluster-scoped is false")
 	flag.BoolVar(&c.ClusterPermissionSC, "cluster-permission-sc", c.ClusterPermissionSC, "Whether tidb-operator should have storage class permissions even if cluster-scoped is false")
@@ -153,6 +227,42 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.DurationVar(&c.RetryPeriod, "leader-retry-period", c.RetryPeriod, "leader-retry-period is the duration the LeaderElector clients should wait between tries of actions")
 	flag.Float64Var(&c.KubeClientQPS, "kube-client-qps", c.KubeClientQPS, "The maximum QPS to the kubenetes API server from client")
 	flag.IntVar(&c.KubeClientBurst, "kube-client-burst", c.KubeClientBurst, "The maximum burst for throttle to the kubenetes API server from client")
+	flag.StringVar(&c.PriceSheetConfigMap, "price-sheet-configmap", c.PriceSheetConfigMap, "The ConfigMap in the format of namespace/name which contains the unit prices used to estimate the cost of TidbClusters")
+	flag.DurationVar(&c.OrphanSweepPeriod, "orphan-sweep-period", c.OrphanSweepPeriod, "The period to sweep the resources whose owner CR no longer exists, 0 to disable the sweeper")
+	flag.StringVar(&c.OrphanSweepPolicy, "orphan-sweep-policy", c.OrphanSweepPolicy, "The policy to handle the orphaned resources, Report or Delete")
+	flag.Var(&c.StoreLabelNodeLabels, "store-label-node-labels", "A set of storeLabel=nodeLabel pairs which map the store labels to the node labels, e.g. rack=example.com/rack")
+	flag.Var(&c.RegistryMirrors, "registry-mirrors", "A set of registry=mirror pairs which rewrite the images of all the components, e.g. docker.io=mirror.example.com")
+	flag.BoolVar(&c.AirGapped, "air-gapped", c.AirGapped, "Whether tidb-operator runs in an air-gapped environment, which disables the telemetry and requires all the images to be pinned")
+	flag.StringVar(&c.VersionCatalogConfigMap, "version-catalog-configmap", c.VersionCatalogConfigMap, "The ConfigMap in the format of namespace/name which contains the supported versions of the components in the air-gapped mode")
+	flag.BoolVar(&c.NodeDrainLeaderEviction, "node-drain-leader-eviction", c.NodeDrainLeaderEviction, "Whether to transfer the PD leader and evict the TiKV region leaders off the pods on the nodes being drained")
+	flag.StringVar(&c.NodeDrainTaintKey, "node-drain-taint-key", c.NodeDrainTaintKey, "The key of the taint which marks a node as being drained, besides the node being cordoned")
+	flag.StringVar(&c.NotificationConfigMap, "notification-configmap", c.NotificationConfigMap, "The ConfigMap in the format of namespace/name which contains the global sinks the significant events of all the clusters are forwarded to")
+	flag.BoolVar(&c.FIPSMode, "fips-mode", c.FIPSMode, "Whether to restrict the TLS versions and cipher suites to the FIPS approved ones and validate the certificates of the TLS secrets")
+	flag.StringVar(&c.PreflightReportConfigMap, "preflight-report-configmap", c.PreflightReportConfigMap, "The ConfigMap in the format of namespace/name which the report of the clusters affected by the changes of the operator version is written to on startup")
+	flag.BoolVar(&c.PreflightHoldReconcile, "preflight-hold-reconcile", c.PreflightHoldReconcile, "Whether to hold the reconciliation of the clusters affected by the changes of the operator version until they are acknowledged by the tidb.pingcap.com/preflight-ack annotation")
+	flag.BoolVar(&c.EndpointSliceDiscovery, "endpoint-slice-discovery", c.EndpointSliceDiscovery, "Whether to resolve the members of the services from the EndpointSlices instead of the Endpoints and pods, it falls back to the Endpoints if the EndpointSlices are not supported by the kubernetes cluster")
+}
+
+// WatchedNamespaces returns the namespaces watched by the operator running in namespace ns,
+// it returns metav1.NamespaceAll if the operator is cluster scoped.
+func (c *CLIConfig) WatchedNamespaces(ns string) []string {
+	if c.ClusterScoped {
+		return []string{metav1.NamespaceAll}
+	}
+	var namespaces []string
+	seen := map[string]bool{}
+	for _, watched := range strings.Split(c.WatchNamespaces, ",") {
+		watched = strings.TrimSpace(watched)
+		if watched == "" || seen[watched] {
+			continue
+		}
+		seen[watched] = true
+		namespaces = append(namespaces, watched)
+	}
+	if len(namespaces) == 0 {
+		return []string{ns}
+	}
+	return namespaces
 }
 
 // HasNodePermission returns whether the user has permission for node operations.
@@ -193,6 +303,7 @@ type Controls struct {
 	BackupControl      BackupControlInterface
 	RestoreControl     RestoreControlInterface
 	SecretControl      SecretControlInterface
+	PDBControl         PDBControlInterface
 }
 
 // Dependencies is used to store all shared dependent resources to avoid
@@ -208,17 +319,23 @@ type Dependencies struct {
 	InformerFactory                informers.SharedInformerFactory
 	KubeInformerFactory            kubeinformers.SharedInformerFactory
 	LabelFilterKubeInformerFactory kubeinformers.SharedInformerFactory
-	Recorder                       record.EventRecorder
+	// PriceSheetInformerFactory only watches the price sheet ConfigMap, it is nil if the cost estimation is disabled
+	PriceSheetInformerFactory kubeinformers.SharedInformerFactory
+	Recorder                  record.EventRecorder
+	// EventBroadcaster is the broadcaster of Recorder, it is nil in tests
+	EventBroadcaster record.EventBroadcaster
 
 	// Listers
 	ServiceLister               corelisterv1.ServiceLister
 	EndpointLister              corelisterv1.EndpointsLister
+	EndpointSliceLister         discoverylisters.EndpointSliceLister // nil if the EndpointSlice discovery is disabled or not supported
 	PVCLister                   corelisterv1.PersistentVolumeClaimLister
 	PVLister                    corelisterv1.PersistentVolumeLister
 	PodLister                   corelisterv1.PodLister
 	NodeLister                  corelisterv1.NodeLister
 	SecretLister                corelisterv1.SecretLister
 	ConfigMapLister             corelisterv1.ConfigMapLister
+	PriceSheetLister            corelisterv1.ConfigMapLister // nil if the cost estimation is disabled
 	StatefulSetLister           appslisters.StatefulSetLister
 	DeploymentLister            appslisters.DeploymentLister
 	JobLister                   batchlisters.JobLister
@@ -294,6 +411,7 @@ func newRealControls(
 		BackupControl:      NewRealBackupControl(clientset, recorder),
 		RestoreControl:     NewRealRestoreControl(clientset, restoreLister, recorder),
 		SecretControl:      NewRealSecretControl(kubeClientset, secretLister, recorder),
+		PDBControl:         NewRealPDBControl(kubeClientset.Discovery(), genericCli, NewTypedControl(genericCtrl)),
 	}
 }
 
@@ -313,6 +431,7 @@ func newDependencies(
 		scLister         storagelister.StorageClassLister
 		ingLister        networklister.IngressLister
 		ingv1beta1Lister extensionslister.IngressLister
+		epSliceLister    discoverylisters.EndpointSliceLister
 	)
 	if cliCfg.HasNodePermission() {
 		nodeLister = kubeInformerFactory.Core().V1().Nodes().Lister()
@@ -340,6 +459,18 @@ func newDependencies(
 		ingv1beta1Lister = kubeInformerFactory.Extensions().V1beta1().Ingresses().Lister()
 	}
 
+	if cliCfg.EndpointSliceDiscovery {
+		supported, err := utildiscovery.IsAPIGroupVersionResourceSupported(kubeClientset.Discovery(), "discovery.k8s.io/v1beta1", "endpointslices")
+		if err != nil {
+			return nil, fmt.Errorf("failed to check resource discovery.k8s.io/v1beta1/endpointslices: %s", err)
+		}
+		if supported {
+			epSliceLister = kubeInformerFactory.Discovery().V1beta1().EndpointSlices().Lister()
+		} else {
+			klog.Info("endpoint slices are not supported, fall back to endpoints for the discovery of members")
+		}
+	}
+
 	cfg, err := config.LoadDefaultConfig(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("can't load aws config: %w", err)
@@ -358,6 +489,7 @@ func newDependencies(
 		// Listers
 		ServiceLister:               kubeInformerFactory.Core().V1().Services().Lister(),
 		EndpointLister:              kubeInformerFactory.Core().V1().Endpoints().Lister(),
+		EndpointSliceLister:         epSliceLister,
 		PVCLister:                   kubeInformerFactory.Core().V1().PersistentVolumeClaims().Lister(),
 		PVLister:                    pvLister,
 		PodLister:                   kubeInformerFactory.Core().V1().Pods().Lister(),
@@ -385,25 +517,26 @@ func newDependencies(
 	}, nil
 }
 
-// NewDependencies is used to construct the dependencies
-func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, genericCli client.Client) (*Dependencies, error) {
+// NewDependencies is used to construct the dependencies, the informers list and watch only the given namespaces
+// unless the operator is cluster scoped
+func NewDependencies(namespaces []string, cliCfg *CLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface, genericCli client.Client) (*Dependencies, error) {
 	var (
 		options     []informers.SharedInformerOption
 		kubeoptions []kubeinformers.SharedInformerOption
 	)
-	if !cliCfg.ClusterScoped {
-		options = append(options, informers.WithNamespace(ns))
-		kubeoptions = append(kubeoptions, kubeinformers.WithNamespace(ns))
+	if !cliCfg.ClusterScoped && len(namespaces) == 1 {
+		options = append(options, informers.WithNamespace(namespaces[0]))
+		kubeoptions = append(kubeoptions, kubeinformers.WithNamespace(namespaces[0]))
 	}
-	tweakListOptionsFunc := func(options *metav1.ListOptions) {
+	labelTweakListOptionsFunc := func(options *metav1.ListOptions) {
 		if len(options.LabelSelector) > 0 {
 			options.LabelSelector += ",app.kubernetes.io/managed-by=tidb-operator"
 		} else {
 			options.LabelSelector = "app.kubernetes.io/managed-by=tidb-operator"
 		}
 	}
-	labelKubeOptions := append(kubeoptions, kubeinformers.WithTweakListOptions(tweakListOptionsFunc))
-	tweakListOptionsFunc = func(options *metav1.ListOptions) {
+	labelKubeOptions := append(kubeoptions, kubeinformers.WithTweakListOptions(labelTweakListOptionsFunc))
+	tweakListOptionsFunc := func(options *metav1.ListOptions) {
 		if len(cliCfg.Selector) > 0 {
 			options.LabelSelector = cliCfg.Selector
 		}
@@ -411,9 +544,22 @@ func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface
 	options = append(options, informers.WithTweakListOptions(tweakListOptionsFunc))
 
 	// Initialize the informer factories
-	informerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, cliCfg.ResyncDuration, options...)
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, kubeoptions...)
-	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, labelKubeOptions...)
+	var (
+		informerFactory                informers.SharedInformerFactory
+		kubeInformerFactory            kubeinformers.SharedInformerFactory
+		labelFilterKubeInformerFactory kubeinformers.SharedInformerFactory
+	)
+	if !cliCfg.ClusterScoped && len(namespaces) > 1 {
+		// the informers of the namespaced resources merge the lists and watches of the namespaces,
+		// so only Roles in these namespaces are required
+		informerFactory = newMultiNamespaceInformerFactory(clientset, namespaces, cliCfg.ResyncDuration, tweakListOptionsFunc)
+		kubeInformerFactory = newMultiNamespaceKubeInformerFactory(kubeClientset, namespaces, cliCfg.ResyncDuration, nil)
+		labelFilterKubeInformerFactory = newMultiNamespaceKubeInformerFactory(kubeClientset, namespaces, cliCfg.ResyncDuration, labelTweakListOptionsFunc)
+	} else {
+		informerFactory = informers.NewSharedInformerFactoryWithOptions(clientset, cliCfg.ResyncDuration, options...)
+		kubeInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, kubeoptions...)
+		labelFilterKubeInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration, labelKubeOptions...)
+	}
 
 	// Initialize the event recorder
 	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{QPS: 1})
@@ -425,6 +571,23 @@ func NewDependencies(ns string, cliCfg *CLIConfig, clientset versioned.Interface
 	if err != nil {
 		return nil, err
 	}
+	deps.EventBroadcaster = eventBroadcaster
+	if cliCfg.PriceSheetConfigMap != "" {
+		// the price sheet is not created by tidb-operator, so it is not in the label filtered informers
+		ns, name, err := cache.SplitMetaNamespaceKey(cliCfg.PriceSheetConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price sheet ConfigMap %q: %v", cliCfg.PriceSheetConfigMap, err)
+		}
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+		}
+		deps.PriceSheetInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, cliCfg.ResyncDuration,
+			kubeinformers.WithNamespace(ns),
+			kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+			}))
+		deps.PriceSheetLister = deps.PriceSheetInformerFactory.Core().V1().ConfigMaps().Lister()
+	}
 	deps.Controls = newRealControls(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, recorder)
 	return deps, nil
 }
@@ -452,6 +615,7 @@ func newFakeControl(kubeClientset kubernetes.Interface, informerFactory informer
 		TiDBControl:        NewFakeTiDBControl(kubeInformerFactory.Core().V1().Secrets().Lister()),
 		BackupControl:      NewFakeBackupControl(informerFactory.Pingcap().V1alpha1().Backups()),
 		SecretControl:      NewFakeSecretControl(kubeInformerFactory.Core().V1().Secrets()),
+		PDBControl:         NewFakePDBControl(genericCtrl),
 	}
 }
 
@@ -471,6 +635,8 @@ func NewFakeDependencies() *Dependencies {
 	kubeCli := kubefake.NewSimpleClientset()
 	genCli := controllerfake.NewFakeClientWithScheme(scheme.Scheme)
 	cliCfg := DefaultCLIConfig()
+	// the members are resolved from the Endpoints in tests unless the EndpointSliceLister is set explicitly
+	cliCfg.EndpointSliceDiscovery = false
 	informerFactory := informers.NewSharedInformerFactory(cli, 0)
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
 	labelFilterKubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
@@ -489,6 +655,7 @@ func NewFakeDependencies() *Dependencies {
 	if err != nil {
 		klog.Fatalf("failed to create Dependencies: %s", err)
 	}
+	deps.PriceSheetLister = kubeInformerFactory.Core().V1().ConfigMaps().Lister()
 	deps.Controls = newFakeControl(kubeCli, informerFactory, kubeInformerFactory)
 	return deps
 }
@@ -50,3 +50,17 @@ func TestFakeTidbCluster(t *testing.T) {
 		}, time.Second*10).Should(BeNil())
 	}
 }
+
+func TestWatchedNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cfg := DefaultCLIConfig()
+	cfg.ClusterScoped = false
+	g.Expect(cfg.WatchedNamespaces("tidb-admin")).To(Equal([]string{"tidb-admin"}))
+
+	cfg.WatchNamespaces = "ns1, ns2,,ns1"
+	g.Expect(cfg.WatchedNamespaces("tidb-admin")).To(Equal([]string{"ns1", "ns2"}))
+
+	cfg.ClusterScoped = true
+	g.Expect(cfg.WatchedNamespaces("tidb-admin")).To(Equal([]string{v1.NamespaceAll}))
+}
@@ -0,0 +1,304 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// diagnosticInvalid is the event reason when the Diagnostic is invalid
+	diagnosticInvalid = "Invalid"
+	// diagnosticStarted is the event reason when the collector job is created
+	diagnosticStarted = "Started"
+	// diagnosticFailed is the event reason when the collector job failed
+	diagnosticFailed = "Failed"
+)
+
+// ControlInterface abstracts the business logic for Diagnostic reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.Diagnostic) error
+}
+
+// NewDiagnosticControl returns a ControlInterface which runs the collector job of Diagnostic
+func NewDiagnosticControl(deps *controller.Dependencies, lister listers.DiagnosticLister) ControlInterface {
+	return &defaultDiagnosticControl{
+		deps:   deps,
+		lister: lister,
+		now:    time.Now,
+	}
+}
+
+type defaultDiagnosticControl struct {
+	deps   *controller.Dependencies
+	lister listers.DiagnosticLister
+	now    func() time.Time
+}
+
+func (c *defaultDiagnosticControl) Reconcile(d *v1alpha1.Diagnostic) error {
+	if d.DeletionTimestamp != nil || v1alpha1.IsDiagnosticFinished(d) {
+		return nil
+	}
+
+	oldStatus := d.Status.DeepCopy()
+	err := c.reconcile(d)
+
+	if !apiequality.Semantic.DeepEqual(&d.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(d.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.Status.Phase == v1alpha1.DiagnosticPhaseRunning {
+		return controller.RequeueErrorf("Diagnostic: [%s/%s] is %s", d.Namespace, d.Name, d.Status.Phase)
+	}
+	return nil
+}
+
+func (c *defaultDiagnosticControl) reconcile(d *v1alpha1.Diagnostic) error {
+	ns := d.GetNamespace()
+	name := d.GetName()
+
+	if errs := validation.ValidateDiagnostic(d); len(errs) > 0 {
+		c.fail(d, diagnosticInvalid, errs.ToAggregate().Error())
+		return nil
+	}
+
+	jobName := d.GetDiagnosticJobName()
+	job, err := c.deps.JobLister.Jobs(ns).Get(jobName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("Diagnostic: [%s/%s], get job %s failed, err: %v", ns, name, jobName, err)
+	}
+	if errors.IsNotFound(err) {
+		if d.Status.Phase == v1alpha1.DiagnosticPhaseRunning {
+			c.fail(d, diagnosticFailed, fmt.Sprintf("the collector job %s is deleted", jobName))
+			return nil
+		}
+		tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(d.Spec.Cluster)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				c.fail(d, diagnosticInvalid, fmt.Sprintf("tidbcluster %s/%s is not found", ns, d.Spec.Cluster))
+				return nil
+			}
+			return fmt.Errorf("Diagnostic: [%s/%s], get tidbcluster %s failed, err: %v", ns, name, d.Spec.Cluster, err)
+		}
+		job, reason, err := c.makeDiagnosticJob(d, tc)
+		if err != nil {
+			c.fail(d, reason, err.Error())
+			return nil
+		}
+		if err := c.deps.JobControl.CreateJob(d, job); err != nil {
+			return fmt.Errorf("Diagnostic: [%s/%s], create job %s failed, err: %v", ns, name, jobName, err)
+		}
+		d.Status.Phase = v1alpha1.DiagnosticPhaseRunning
+		d.Status.TimeStarted = metav1.NewTime(c.now())
+		klog.Infof("Diagnostic: [%s/%s], collector job %s is created", ns, name, jobName)
+		c.deps.Recorder.Eventf(d, corev1.EventTypeNormal, diagnosticStarted, "collector job %s is created", jobName)
+		return nil
+	}
+
+	if d.Status.Phase == "" {
+		d.Status.Phase = v1alpha1.DiagnosticPhaseRunning
+		d.Status.TimeStarted = job.CreationTimestamp
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			c.fail(d, diagnosticFailed, fmt.Sprintf("the collector job %s failed: %s", jobName, cond.Message))
+			return nil
+		}
+	}
+	// the collector updates the status to Completed itself, it is only a fallback if the update is lost
+	if job.Status.Succeeded > 0 {
+		d.Status.Phase = v1alpha1.DiagnosticPhaseCompleted
+		d.Status.TimeCompleted = metav1.NewTime(c.now())
+	}
+	return nil
+}
+
+func (c *defaultDiagnosticControl) fail(d *v1alpha1.Diagnostic, reason, msg string) {
+	klog.Errorf("Diagnostic: [%s/%s], %s: %s", d.Namespace, d.Name, reason, msg)
+	c.deps.Recorder.Event(d, corev1.EventTypeWarning, reason, msg)
+	d.Status.Phase = v1alpha1.DiagnosticPhaseFailed
+	d.Status.TimeCompleted = metav1.NewTime(c.now())
+	d.Status.Message = msg
+}
+
+func (c *defaultDiagnosticControl) makeDiagnosticJob(d *v1alpha1.Diagnostic, tc *v1alpha1.TidbCluster) (*batchv1.Job, string, error) {
+	ns := d.GetNamespace()
+	name := d.GetName()
+
+	envVars, reason, err := backuputil.GenerateStorageCertEnv(ns, false, d.Spec.StorageProvider, c.deps.SecretLister)
+	if err != nil {
+		return nil, reason, fmt.Errorf("diagnostic %s/%s, %v", ns, name, err)
+	}
+	envVars = util.AppendOverwriteEnv(envVars, d.Spec.Env)
+
+	args := []string{
+		"diagnose",
+		fmt.Sprintf("--namespace=%s", ns),
+		fmt.Sprintf("--diagnosticName=%s", name),
+	}
+
+	volumeMounts := []corev1.VolumeMount{}
+	volumes := []corev1.Volume{}
+	if tc.IsTLSClusterEnabled() {
+		args = append(args, "--cluster-tls=true")
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      util.ClusterClientVolName,
+			ReadOnly:  true,
+			MountPath: util.ClusterClientTLSPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: util.ClusterClientVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterClientTLSSecretName(tc.Name),
+				},
+			},
+		})
+	}
+	if d.Spec.Local != nil {
+		volumes = append(volumes, d.Spec.Local.Volume)
+		volumeMounts = append(volumeMounts, d.Spec.Local.VolumeMount)
+	}
+
+	serviceAccount := constants.DefaultServiceAccountName
+	if d.Spec.ServiceAccount != "" {
+		serviceAccount = d.Spec.ServiceAccount
+	}
+
+	jobLabels := util.CombineStringMap(label.NewDiagnostic().Instance(tc.Name).Labels(), d.Labels)
+	podSpec := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      jobLabels,
+			Annotations: d.Annotations,
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext:    d.Spec.PodSecurityContext,
+			ServiceAccountName: serviceAccount,
+			Containers: []corev1.Container{
+				{
+					Name:            label.DiagnosticJobLabelVal,
+					Image:           c.deps.CLIConfig.TiDBBackupManagerImage,
+					Args:            args,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					VolumeMounts:    volumeMounts,
+					Env:             util.AppendEnvIfPresent(envVars, "TZ"),
+					Resources:       d.Spec.Resources,
+				},
+			},
+			RestartPolicy:     corev1.RestartPolicyNever,
+			Tolerations:       d.Spec.Tolerations,
+			ImagePullSecrets:  d.Spec.ImagePullSecrets,
+			Affinity:          d.Spec.Affinity,
+			Volumes:           volumes,
+			PriorityClassName: d.Spec.PriorityClassName,
+		},
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        d.GetDiagnosticJobName(),
+			Namespace:   ns,
+			Labels:      jobLabels,
+			Annotations: d.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				controller.GetDiagnosticOwnerRef(d),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(0),
+			Template:     podSpec,
+		},
+	}
+	return job, "", nil
+}
+
+func (c *defaultDiagnosticControl) updateStatus(d *v1alpha1.Diagnostic) (*v1alpha1.Diagnostic, error) {
+	var (
+		ns     = d.GetNamespace()
+		name   = d.GetName()
+		status = d.Status.DeepCopy()
+		update *v1alpha1.Diagnostic
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().Diagnostics(ns).UpdateStatus(context.TODO(), d, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.Infof("Diagnostic: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("Diagnostic: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		if updated, err := c.lister.Diagnostics(ns).Get(name); err == nil {
+			// the collector may have finished the diagnostic, its status is not overwritten
+			if v1alpha1.IsDiagnosticFinished(updated) {
+				update = updated
+				return nil
+			}
+			d = updated.DeepCopy()
+			d.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated Diagnostic %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("Diagnostic: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+type FakeDiagnosticControl struct {
+	reconcile func(*v1alpha1.Diagnostic) error
+}
+
+func (c *FakeDiagnosticControl) MockReconcile(reconcile func(*v1alpha1.Diagnostic) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeDiagnosticControl) Reconcile(d *v1alpha1.Diagnostic) error {
+	if c.reconcile != nil {
+		return c.reconcile(d)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDiagnostic(name string) *v1alpha1.Diagnostic {
+	return &v1alpha1.Diagnostic{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.DiagnosticSpec{
+			Cluster: "tc",
+			StorageProvider: v1alpha1.StorageProvider{
+				Local: &v1alpha1.LocalStorageProvider{
+					Volume:      corev1.Volume{Name: "bundle"},
+					VolumeMount: corev1.VolumeMount{Name: "bundle", MountPath: "/bundle"},
+				},
+			},
+		},
+	}
+}
+
+func TestDiagnosticControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	informer := deps.InformerFactory.Pingcap().V1alpha1().Diagnostics()
+	control := NewDiagnosticControl(deps, informer.Lister()).(*defaultDiagnosticControl)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.TidbClusterSpec{TLSCluster: &v1alpha1.TLSCluster{Enabled: true}},
+	}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+
+	create := func(d *v1alpha1.Diagnostic) {
+		_, err := deps.Clientset.PingcapV1alpha1().Diagnostics(d.Namespace).Create(context.TODO(), d, metav1.CreateOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(informer.Informer().GetIndexer().Add(d)).To(Succeed())
+	}
+
+	// the invalid diagnostic fails without creating the job
+	invalid := newDiagnostic("invalid")
+	invalid.Spec.Components = []v1alpha1.MemberType{v1alpha1.TiDBMemberType}
+	create(invalid)
+	g.Expect(control.Reconcile(invalid)).To(Succeed())
+	g.Expect(invalid.Status.Phase).To(Equal(v1alpha1.DiagnosticPhaseFailed))
+	g.Expect(invalid.Status.Message).To(ContainSubstring("spec.components[0]"))
+	_, err := deps.JobLister.Jobs(corev1.NamespaceDefault).Get(invalid.GetDiagnosticJobName())
+	g.Expect(err).To(HaveOccurred())
+
+	// the collector job is created and the diagnostic is running
+	d := newDiagnostic("diag")
+	create(d)
+	err = control.Reconcile(d)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(d.Status.Phase).To(Equal(v1alpha1.DiagnosticPhaseRunning))
+	g.Expect(d.Status.TimeStarted.IsZero()).To(BeFalse())
+	job, err := deps.JobLister.Jobs(corev1.NamespaceDefault).Get(d.GetDiagnosticJobName())
+	g.Expect(err).To(Succeed())
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Args).To(ContainElements("diagnose", "--diagnosticName=diag", "--cluster-tls=true"))
+	g.Expect(container.VolumeMounts).To(HaveLen(2))
+	g.Expect(container.VolumeMounts[0].MountPath).To(Equal(util.ClusterClientTLSPath))
+	g.Expect(job.OwnerReferences[0].Kind).To(Equal(v1alpha1.DiagnosticKind))
+
+	// the diagnostic fails with the job
+	job = job.DeepCopy()
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	g.Expect(deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer().Update(job)).To(Succeed())
+	g.Expect(control.Reconcile(d)).To(Succeed())
+	g.Expect(d.Status.Phase).To(Equal(v1alpha1.DiagnosticPhaseFailed))
+	g.Expect(d.Status.Message).To(ContainSubstring("BackoffLimitExceeded"))
+
+	// the finished diagnostic is not reconciled again
+	g.Expect(control.Reconcile(d)).To(Succeed())
+	updated, err := deps.Clientset.PingcapV1alpha1().Diagnostics(d.Namespace).Get(context.TODO(), d.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(updated.Status.Phase).To(Equal(v1alpha1.DiagnosticPhaseFailed))
+}
@@ -14,6 +14,9 @@
 package dmcluster
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	utildmcluster "github.com/pingcap/tidb-operator/pkg/util/dmcluster"
 	appsv1 "k8s.io/api/apps/v1"
@@ -33,6 +36,9 @@ var _ DMClusterConditionUpdater = &dmClusterConditionUpdater{}
 
 func (u *dmClusterConditionUpdater) Update(dc *v1alpha1.DMCluster) error {
 	u.updateReadyCondition(dc)
+	u.updateProgressingCondition(dc)
+	u.updateDegradedCondition(dc)
+	dc.Status.ObservedGeneration = dc.Generation
 	// in the future, we may return error when we need to Kubernetes API, etc.
 	return nil
 }
@@ -71,3 +77,54 @@ func (u *dmClusterConditionUpdater) updateReadyCondition(dc *v1alpha1.DMCluster)
 	cond := utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterReady, status, reason, message)
 	utildmcluster.SetDMClusterCondition(&dc.Status, *cond)
 }
+
+func (u *dmClusterConditionUpdater) updateProgressingCondition(dc *v1alpha1.DMCluster) {
+	status := v1.ConditionTrue
+	reason := ""
+	message := ""
+
+	var components []string
+	for _, component := range dc.AllComponentStatus() {
+		if phase := component.GetPhase(); phase == v1alpha1.UpgradePhase || phase == v1alpha1.ScalePhase {
+			components = append(components, fmt.Sprintf("%s is in %s phase", component.MemberType(), phase))
+		}
+	}
+	switch {
+	case !allStatefulSetsAreUpToDate(dc):
+		reason = utildmcluster.StatfulSetNotUpToDate
+		message = "Statefulset(s) are in progress"
+	case len(components) > 0:
+		reason = utildmcluster.ComponentsProgressing
+		message = strings.Join(components, ", ")
+	default:
+		status = v1.ConditionFalse
+		reason = utildmcluster.RolloutComplete
+		message = "All components are rolled out"
+	}
+	cond := utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterProgressing, status, reason, message)
+	utildmcluster.SetDMClusterCondition(&dc.Status, *cond)
+}
+
+func (u *dmClusterConditionUpdater) updateDegradedCondition(dc *v1alpha1.DMCluster) {
+	status := v1.ConditionTrue
+	reason := ""
+	message := ""
+
+	var components []string
+	if n := len(dc.Status.Master.FailureMembers); n > 0 {
+		components = append(components, fmt.Sprintf("%d dm-master failure member(s)", n))
+	}
+	if n := len(dc.Status.Worker.FailureMembers); n > 0 {
+		components = append(components, fmt.Sprintf("%d dm-worker failure member(s)", n))
+	}
+	if len(components) > 0 {
+		reason = utildmcluster.FailureMembersFound
+		message = strings.Join(components, ", ")
+	} else {
+		status = v1.ConditionFalse
+		reason = utildmcluster.AsExpected
+		message = "No failure member is found"
+	}
+	cond := utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterDegraded, status, reason, message)
+	utildmcluster.SetDMClusterCondition(&dc.Status, *cond)
+}
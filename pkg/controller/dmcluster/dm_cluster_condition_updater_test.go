@@ -149,3 +149,41 @@ func TestDMClusterConditionUpdater_Ready(t *testing.T) {
 		})
 	}
 }
+
+func TestDMClusterConditionUpdater_ProgressingAndDegraded(t *testing.T) {
+	dc := &v1alpha1.DMCluster{
+		Status: v1alpha1.DMClusterStatus{
+			Master: v1alpha1.MasterStatus{
+				Phase: v1alpha1.UpgradePhase,
+				FailureMembers: map[string]v1alpha1.MasterFailureMember{
+					"dm-master-0": {PodName: "dm-master-0"},
+				},
+				StatefulSet: &appsv1.StatefulSetStatus{
+					CurrentRevision: "2",
+					UpdateRevision:  "2",
+				},
+			},
+		},
+	}
+	dc.Generation = 2
+	conditionUpdater := &dmClusterConditionUpdater{}
+	conditionUpdater.Update(dc)
+
+	cond := utildmcluster.GetDMClusterCondition(dc.Status, v1alpha1.DMClusterProgressing)
+	if diff := cmp.Diff(v1.ConditionTrue, cond.Status); diff != "" {
+		t.Errorf("unexpected progressing status (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(utildmcluster.ComponentsProgressing, cond.Reason); diff != "" {
+		t.Errorf("unexpected progressing reason (-want, +got): %s", diff)
+	}
+	cond = utildmcluster.GetDMClusterCondition(dc.Status, v1alpha1.DMClusterDegraded)
+	if diff := cmp.Diff(v1.ConditionTrue, cond.Status); diff != "" {
+		t.Errorf("unexpected degraded status (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(utildmcluster.FailureMembersFound, cond.Reason); diff != "" {
+		t.Errorf("unexpected degraded reason (-want, +got): %s", diff)
+	}
+	if dc.Status.ObservedGeneration != 2 {
+		t.Errorf("unexpected observed generation %d", dc.Status.ObservedGeneration)
+	}
+}
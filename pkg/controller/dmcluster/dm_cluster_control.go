@@ -14,12 +14,16 @@
 package dmcluster
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager"
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	utildmcluster "github.com/pingcap/tidb-operator/pkg/util/dmcluster"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
@@ -42,6 +46,7 @@ func NewDefaultDMClusterControl(
 	masterMemberManager manager.DMManager,
 	workerMemberManager manager.DMManager,
 	reclaimPolicyManager manager.DMManager,
+	certManager manager.DMManager,
 	orphanPodsCleaner member.OrphanPodsCleaner,
 	pvcCleaner member.PVCCleanerInterface,
 	pvcResizer member.PVCResizerInterface,
@@ -53,6 +58,7 @@ func NewDefaultDMClusterControl(
 		masterMemberManager,
 		workerMemberManager,
 		reclaimPolicyManager,
+		certManager,
 		//metaManager,
 		orphanPodsCleaner,
 		pvcCleaner,
@@ -68,6 +74,7 @@ type defaultDMClusterControl struct {
 	masterMemberManager  manager.DMManager
 	workerMemberManager  manager.DMManager
 	reclaimPolicyManager manager.DMManager
+	certManager          manager.DMManager
 	//metaManager       manager.DMManager
 	orphanPodsCleaner member.OrphanPodsCleaner
 	pvcCleaner        member.PVCCleanerInterface
@@ -109,6 +116,26 @@ func (c *defaultDMClusterControl) defaulting(dc *v1alpha1.DMCluster) {
 	defaulting.SetDMClusterDefault(dc)
 }
 
+// syncDiscoveryReadyCondition sets the DiscoveryReady condition by the result of reconciling the discovery
+func syncDiscoveryReadyCondition(dc *v1alpha1.DMCluster, result *manager.Result, err error) {
+	if result == nil {
+		return
+	}
+	var cond *v1alpha1.DMClusterCondition
+	switch {
+	case result.Failed() != nil:
+		cond = utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterDiscoveryReady, v1.ConditionFalse,
+			utildmcluster.DiscoveryReconcileFailed, fmt.Sprintf("failed to reconcile %s/%s: %v", result.Failed().Kind, result.Failed().Name, err))
+	case !result.Ready():
+		cond = utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterDiscoveryReady, v1.ConditionFalse,
+			utildmcluster.DiscoveryNotAvailable, fmt.Sprintf("waiting on %s", strings.Join(result.WaitingOn, "; ")))
+	default:
+		cond = utildmcluster.NewDMClusterCondition(v1alpha1.DMClusterDiscoveryReady, v1.ConditionTrue,
+			utildmcluster.DiscoveryAvailable, "discovery is available")
+	}
+	utildmcluster.SetDMClusterCondition(&dc.Status, *cond)
+}
+
 func (c *defaultDMClusterControl) validate(dc *v1alpha1.DMCluster) bool {
 	errs := v1alpha1validation.ValidateDMCluster(dc)
 	if len(errs) > 0 {
@@ -137,8 +164,15 @@ func (c *defaultDMClusterControl) updateDMCluster(dc *v1alpha1.DMCluster) error
 		}
 	}
 
-	// reconcile DM Discovery service
-	if err = c.discoveryManager.Reconcile(dc); err != nil {
+	// reconcile DM Discovery service, and report the stage of it by the DiscoveryReady condition
+	result, err := c.discoveryManager.Reconcile(dc)
+	syncDiscoveryReadyCondition(dc, result, err)
+	if err != nil {
+		return err
+	}
+
+	// issue the certs used between dm-master and dm-worker if TLS is enabled and the secrets are missing
+	if err := c.certManager.SyncDM(dc); err != nil {
 		return err
 	}
 
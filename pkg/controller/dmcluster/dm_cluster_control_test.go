@@ -253,6 +253,7 @@ func newFakeDMClusterControl() (
 	masterMemberManager := mm.NewFakeMasterMemberManager()
 	workerMemberManager := mm.NewFakeWorkerMemberManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
+	certManager := mm.NewFakeDMClusterCertManager()
 	orphanPodCleaner := mm.NewFakeOrphanPodsCleaner()
 	pvcCleaner := mm.NewFakePVCCleaner()
 	pvcResizer := mm.NewFakePVCResizer()
@@ -262,6 +263,7 @@ func newFakeDMClusterControl() (
 		masterMemberManager,
 		workerMemberManager,
 		reclaimPolicyManager,
+		certManager,
 		orphanPodCleaner,
 		pvcCleaner,
 		pvcResizer,
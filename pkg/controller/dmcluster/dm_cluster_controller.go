@@ -55,6 +55,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 			mm.NewMasterMemberManager(deps, mm.NewMasterScaler(deps), mm.NewMasterUpgrader(deps), mm.NewMasterFailover(deps), suspender),
 			mm.NewWorkerMemberManager(deps, mm.NewWorkerScaler(deps), mm.NewWorkerFailover(deps), suspender),
 			meta.NewReclaimPolicyManager(deps),
+			mm.NewDMClusterCertManager(deps),
 			mm.NewOrphanPodsCleaner(deps),
 			mm.NewRealPVCCleaner(deps),
 			mm.NewPVCResizer(deps),
@@ -0,0 +1,554 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmtask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+const (
+	// taskInvalid is the event reason when the DMTask is invalid
+	taskInvalid = "Invalid"
+	// taskCreated is the event reason when the task is created in dm-master
+	taskCreated = "Created"
+	// taskUpdated is the event reason when the task is updated in dm-master
+	taskUpdated = "Updated"
+	// taskDeleted is the event reason when the task is deleted from dm-master
+	taskDeleted = "Deleted"
+	// sourceDeleteFailed is the event reason when the source which is not used by the DMTasks can not be deleted
+	sourceDeleteFailed = "SourceDeleteFailed"
+
+	// passwordKey is the key of the password in the Secrets of the sources and the target
+	passwordKey = "password"
+
+	defaultSourcePort = 3306
+	defaultTargetPort = 4000
+	defaultTargetUser = "root"
+)
+
+// ControlInterface abstracts the business logic for DMTask reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.DMTask) error
+}
+
+// NewTaskControl returns a ControlInterface which reconciles the task against the OpenAPI of dm-master
+func NewTaskControl(deps *controller.Dependencies, lister listers.DMTaskLister) ControlInterface {
+	return &defaultTaskControl{
+		deps:   deps,
+		lister: lister,
+	}
+}
+
+type defaultTaskControl struct {
+	deps   *controller.Dependencies
+	lister listers.DMTaskLister
+}
+
+func (c *defaultTaskControl) Reconcile(t *v1alpha1.DMTask) error {
+	if t.DeletionTimestamp != nil {
+		return c.deleteTask(t)
+	}
+	if err := c.addProtectionFinalizer(t); err != nil {
+		return err
+	}
+
+	oldStatus := t.Status.DeepCopy()
+	err := c.reconcile(t)
+	if err != nil {
+		t.Status.Message = err.Error()
+	}
+
+	if !apiequality.Semantic.DeepEqual(&t.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(t.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+	return err
+}
+
+func (c *defaultTaskControl) reconcile(t *v1alpha1.DMTask) error {
+	ns := t.GetNamespace()
+	name := t.GetName()
+
+	if errs := validation.ValidateDMTask(t); len(errs) > 0 {
+		c.invalid(t, errs.ToAggregate().Error())
+		return nil
+	}
+	taskName := t.GetTaskName()
+	if t.Status.TaskName != "" && t.Status.TaskName != taskName {
+		c.invalid(t, fmt.Sprintf("taskName can not be changed from %s to %s", t.Status.TaskName, taskName))
+		return nil
+	}
+
+	client, err := c.getMasterClient(t)
+	if err != nil {
+		return err
+	}
+	sources, err := c.buildSourceConfigs(t)
+	if err != nil {
+		return err
+	}
+	task, err := c.buildTaskConfig(t)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := client.ListTasks()
+	if err != nil {
+		return fmt.Errorf("DMTask: [%s/%s], list tasks failed, err: %v", ns, name, err)
+	}
+	if !sets.NewString(tasks...).Has(taskName) {
+		if err := c.syncSources(t, client, sources, false); err != nil {
+			return err
+		}
+		if err := client.CreateTask(task); err != nil {
+			return fmt.Errorf("DMTask: [%s/%s], create task %s failed, err: %v", ns, name, taskName, err)
+		}
+		if !t.Spec.Paused {
+			if err := client.StartTask(taskName); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], start task %s failed, err: %v", ns, name, taskName, err)
+			}
+		}
+		klog.Infof("DMTask: [%s/%s], task %s is created", ns, name, taskName)
+		c.deps.Recorder.Eventf(t, corev1.EventTypeNormal, taskCreated, "task %s is created", taskName)
+		t.Status.TaskName = taskName
+		t.Status.ObservedGeneration = t.Generation
+		t.Status.Message = ""
+		return controller.RequeueErrorf("DMTask: [%s/%s], task %s is created", ns, name, taskName)
+	}
+
+	t.Status.TaskName = taskName
+	subtasks, err := client.GetTaskStatus(taskName)
+	if err != nil {
+		return fmt.Errorf("DMTask: [%s/%s], get status of task %s failed, err: %v", ns, name, taskName, err)
+	}
+	stopped := len(subtasks) > 0
+	for _, subtask := range subtasks {
+		if v1alpha1.DMTaskStage(subtask.Stage) != v1alpha1.DMTaskStageStopped {
+			stopped = false
+		}
+	}
+
+	switch {
+	case t.Status.ObservedGeneration != t.Generation:
+		// the task and its sources can be updated only when the task is stopped
+		if !stopped {
+			if err := client.StopTask(taskName); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], stop task %s failed, err: %v", ns, name, taskName, err)
+			}
+		}
+		if err := c.syncSources(t, client, sources, true); err != nil {
+			return err
+		}
+		if err := client.UpdateTask(taskName, task); err != nil {
+			return fmt.Errorf("DMTask: [%s/%s], update task %s failed, err: %v", ns, name, taskName, err)
+		}
+		removed := sets.NewString()
+		for _, s := range t.Status.Sources {
+			removed.Insert(s.Name)
+		}
+		removed.Delete(t.GetSourceNames()...)
+		c.deleteUnusedSources(t, client, removed.List())
+		if !t.Spec.Paused {
+			if err := client.StartTask(taskName); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], start task %s failed, err: %v", ns, name, taskName, err)
+			}
+		}
+		klog.Infof("DMTask: [%s/%s], task %s is updated", ns, name, taskName)
+		c.deps.Recorder.Eventf(t, corev1.EventTypeNormal, taskUpdated, "task %s is updated", taskName)
+		t.Status.ObservedGeneration = t.Generation
+		t.Status.Message = ""
+		return controller.RequeueErrorf("DMTask: [%s/%s], task %s is updated", ns, name, taskName)
+	case t.Spec.Paused && !stopped:
+		if err := client.StopTask(taskName); err != nil {
+			return fmt.Errorf("DMTask: [%s/%s], stop task %s failed, err: %v", ns, name, taskName, err)
+		}
+		return controller.RequeueErrorf("DMTask: [%s/%s], task %s is stopping", ns, name, taskName)
+	case !t.Spec.Paused && stopped:
+		if err := client.StartTask(taskName); err != nil {
+			return fmt.Errorf("DMTask: [%s/%s], start task %s failed, err: %v", ns, name, taskName, err)
+		}
+		return controller.RequeueErrorf("DMTask: [%s/%s], task %s is starting", ns, name, taskName)
+	}
+
+	setTaskStatus(&t.Status, subtasks)
+	return nil
+}
+
+// setTaskStatus reflects the status of the subtasks, the stage of the task is the stage of the
+// subtask which is not running if there is one.
+func setTaskStatus(status *v1alpha1.DMTaskStatus, subtasks []*dmapi.SubTaskStatus) {
+	status.Stage = ""
+	status.Sources = nil
+	var messages []string
+	for _, subtask := range subtasks {
+		source := v1alpha1.DMTaskSourceStatus{
+			Name:                subtask.SourceName,
+			Worker:              subtask.WorkerName,
+			Stage:               v1alpha1.DMTaskStage(subtask.Stage),
+			Unit:                subtask.Unit,
+			UnresolvedDDLLockID: subtask.UnresolvedDDLLockID,
+			Message:             subtask.ErrorMsg,
+		}
+		if sync := subtask.SyncStatus; sync != nil {
+			source.SecondsBehindMaster = sync.SecondsBehindMaster
+			source.Synced = sync.Synced
+			source.MasterBinlog = sync.MasterBinlog
+			source.SyncerBinlog = sync.SyncerBinlog
+		}
+		status.Sources = append(status.Sources, source)
+
+		if status.Stage == "" || status.Stage == v1alpha1.DMTaskStageRunning {
+			status.Stage = source.Stage
+		}
+		if subtask.ErrorMsg != "" {
+			messages = append(messages, fmt.Sprintf("[%s] %s", subtask.SourceName, subtask.ErrorMsg))
+		}
+	}
+	status.Message = strings.Join(messages, "; ")
+}
+
+func (c *defaultTaskControl) invalid(t *v1alpha1.DMTask, msg string) {
+	if t.Status.Message == msg {
+		return
+	}
+	klog.Errorf("DMTask: [%s/%s] is invalid: %s", t.Namespace, t.Name, msg)
+	c.deps.Recorder.Event(t, corev1.EventTypeWarning, taskInvalid, msg)
+	t.Status.Message = msg
+}
+
+func (c *defaultTaskControl) getMasterClient(t *v1alpha1.DMTask) (dmapi.MasterClient, error) {
+	ns := t.GetNamespace()
+	dc, err := c.deps.DMClusterLister.DMClusters(ns).Get(t.Spec.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("DMTask: [%s/%s], get dmcluster %s failed, err: %v", ns, t.Name, t.Spec.Cluster, err)
+	}
+	if !dc.MasterIsAvailable() {
+		return nil, controller.RequeueErrorf("DMTask: [%s/%s], dm-master of dmcluster %s is not available", ns, t.Name, t.Spec.Cluster)
+	}
+	return c.deps.DMMasterControl.GetMasterClient(ns, dc.Name, dc.IsTLSClusterEnabled()), nil
+}
+
+// syncSources creates the sources which do not exist in dm-master, the existing sources are updated if update is true
+func (c *defaultTaskControl) syncSources(t *v1alpha1.DMTask, client dmapi.MasterClient, sources []dmapi.SourceConfig, update bool) error {
+	existing, err := client.ListSources()
+	if err != nil {
+		return fmt.Errorf("DMTask: [%s/%s], list sources failed, err: %v", t.Namespace, t.Name, err)
+	}
+	names := sets.NewString(existing...)
+	for i, source := range sources {
+		sourceName := t.Spec.Sources[i].Name
+		if !names.Has(sourceName) {
+			if err := client.CreateSource(source); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], create source %s failed, err: %v", t.Namespace, t.Name, sourceName, err)
+			}
+			klog.Infof("DMTask: [%s/%s], source %s is created", t.Namespace, t.Name, sourceName)
+			continue
+		}
+		if update {
+			if err := client.UpdateSource(sourceName, source); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], update source %s failed, err: %v", t.Namespace, t.Name, sourceName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteUnusedSources deletes the sources which are not used by the other DMTasks of the DMCluster, the sources
+// used by the tasks created outside are not deleted by dm-master, which are only reported by the events.
+func (c *defaultTaskControl) deleteUnusedSources(t *v1alpha1.DMTask, client dmapi.MasterClient, sources []string) {
+	if len(sources) == 0 {
+		return
+	}
+	tasks, err := c.lister.DMTasks(t.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("DMTask: [%s/%s], list dmtasks failed, err: %v", t.Namespace, t.Name, err)
+		return
+	}
+	used := sets.NewString()
+	for _, other := range tasks {
+		if other.Name != t.Name && other.Spec.Cluster == t.Spec.Cluster {
+			used.Insert(other.GetSourceNames()...)
+		}
+	}
+	for _, source := range sources {
+		if used.Has(source) {
+			continue
+		}
+		if err := client.DeleteSource(source); err != nil {
+			msg := fmt.Sprintf("delete source %s failed, err: %v", source, err)
+			klog.Errorf("DMTask: [%s/%s], %s", t.Namespace, t.Name, msg)
+			c.deps.Recorder.Event(t, corev1.EventTypeWarning, sourceDeleteFailed, msg)
+			continue
+		}
+		klog.Infof("DMTask: [%s/%s], source %s is deleted", t.Namespace, t.Name, source)
+	}
+}
+
+// buildSourceConfigs builds the configs of the sources in the order of spec.sources
+func (c *defaultTaskControl) buildSourceConfigs(t *v1alpha1.DMTask) ([]dmapi.SourceConfig, error) {
+	sources := make([]dmapi.SourceConfig, 0, len(t.Spec.Sources))
+	for _, s := range t.Spec.Sources {
+		source := dmapi.SourceConfig{}
+		if s.Config != nil {
+			for key, value := range s.Config.Inner() {
+				source[key] = value
+			}
+		}
+		port := s.Port
+		if port == 0 {
+			port = defaultSourcePort
+		}
+		source["source_name"] = s.Name
+		source["host"] = s.Host
+		source["port"] = port
+		source["user"] = s.User
+		source["enable_gtid"] = s.EnableGTID
+		source["enable"] = true
+		if s.SecretName != "" {
+			password, err := c.getPassword(t, s.SecretName)
+			if err != nil {
+				return nil, err
+			}
+			source["password"] = password
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// buildTaskConfig builds the config of the task, the fields in spec.config are overwritten by the other fields of the spec.
+// All the tables of the sources are migrated if table_migrate_rule is not set.
+func (c *defaultTaskControl) buildTaskConfig(t *v1alpha1.DMTask) (dmapi.TaskConfig, error) {
+	task := dmapi.TaskConfig{
+		"on_duplicate":                 "overwrite",
+		"enhance_online_schema_change": true,
+	}
+	if t.Spec.Config != nil {
+		for key, value := range t.Spec.Config.Inner() {
+			task[key] = value
+		}
+	}
+	task["name"] = t.GetTaskName()
+	task["task_mode"] = string(t.GetTaskMode())
+	if t.Spec.ShardMode != "" {
+		task["shard_mode"] = string(t.Spec.ShardMode)
+	}
+
+	target, err := c.buildTargetConfig(t)
+	if err != nil {
+		return nil, err
+	}
+	task["target_config"] = target
+
+	sourceConfig := map[string]interface{}{}
+	if config, ok := task["source_config"].(map[string]interface{}); ok {
+		for key, value := range config {
+			sourceConfig[key] = value
+		}
+	}
+	sourceConf := make([]interface{}, 0, len(t.Spec.Sources))
+	rules := make([]interface{}, 0, len(t.Spec.Sources))
+	for _, s := range t.Spec.Sources {
+		conf := map[string]interface{}{"source_name": s.Name}
+		if s.BinlogName != "" {
+			conf["binlog_name"] = s.BinlogName
+		}
+		if s.BinlogPos != nil {
+			conf["binlog_pos"] = *s.BinlogPos
+		}
+		if s.BinlogGTID != "" {
+			conf["binlog_gtid"] = s.BinlogGTID
+		}
+		sourceConf = append(sourceConf, conf)
+		rules = append(rules, map[string]interface{}{
+			"source": map[string]interface{}{"source_name": s.Name, "schema": "*", "table": "*"},
+		})
+	}
+	sourceConfig["source_conf"] = sourceConf
+	task["source_config"] = sourceConfig
+	if _, ok := task["table_migrate_rule"]; !ok {
+		task["table_migrate_rule"] = rules
+	}
+	return task, nil
+}
+
+func (c *defaultTaskControl) buildTargetConfig(t *v1alpha1.DMTask) (map[string]interface{}, error) {
+	spec := &t.Spec.Target
+	target := map[string]interface{}{
+		"host": spec.Host,
+		"port": spec.Port,
+		"user": spec.User,
+	}
+	if spec.Port == 0 {
+		target["port"] = defaultTargetPort
+	}
+	if spec.User == "" {
+		target["user"] = defaultTargetUser
+	}
+	if spec.SecretName != "" {
+		password, err := c.getPassword(t, spec.SecretName)
+		if err != nil {
+			return nil, err
+		}
+		target["password"] = password
+	}
+	if spec.TLSClientSecretName != nil {
+		secret, err := c.deps.SecretLister.Secrets(t.Namespace).Get(*spec.TLSClientSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("DMTask: [%s/%s], get target tls secret %s failed, err: %v", t.Namespace, t.Name, *spec.TLSClientSecretName, err)
+		}
+		target["security"] = map[string]interface{}{
+			"ssl_ca_content":   string(secret.Data[corev1.ServiceAccountRootCAKey]),
+			"ssl_cert_content": string(secret.Data[corev1.TLSCertKey]),
+			"ssl_key_content":  string(secret.Data[corev1.TLSPrivateKeyKey]),
+		}
+	}
+	return target, nil
+}
+
+func (c *defaultTaskControl) getPassword(t *v1alpha1.DMTask, secretName string) (string, error) {
+	secret, err := c.deps.SecretLister.Secrets(t.Namespace).Get(secretName)
+	if err != nil {
+		return "", fmt.Errorf("DMTask: [%s/%s], get secret %s failed, err: %v", t.Namespace, t.Name, secretName, err)
+	}
+	password, ok := secret.Data[passwordKey]
+	if !ok {
+		return "", fmt.Errorf("DMTask: [%s/%s], key %s is not found in secret %s", t.Namespace, t.Name, passwordKey, secretName)
+	}
+	return string(password), nil
+}
+
+// addProtectionFinalizer adds the finalizer so that the task is deleted from dm-master before the CR is deleted
+func (c *defaultTaskControl) addProtectionFinalizer(t *v1alpha1.DMTask) error {
+	if slice.ContainsString(t.Finalizers, label.DMTaskProtectionFinalizer, nil) {
+		return nil
+	}
+	t.Finalizers = append(t.Finalizers, label.DMTaskProtectionFinalizer)
+	updated, err := c.deps.Clientset.PingcapV1alpha1().DMTasks(t.Namespace).Update(context.TODO(), t, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("add dm task %s/%s protection finalizers failed, err: %v", t.Namespace, t.Name, err)
+	}
+	updated.DeepCopyInto(t)
+	return nil
+}
+
+// deleteTask deletes the task and the sources which are not used by the other DMTasks from dm-master, and then
+// removes the finalizer. The finalizer is removed directly if the dmcluster is gone.
+func (c *defaultTaskControl) deleteTask(t *v1alpha1.DMTask) error {
+	ns := t.GetNamespace()
+	name := t.GetName()
+
+	if !slice.ContainsString(t.Finalizers, label.DMTaskProtectionFinalizer, nil) {
+		return nil
+	}
+
+	dc, err := c.deps.DMClusterLister.DMClusters(ns).Get(t.Spec.Cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("DMTask: [%s/%s], get dmcluster %s failed, err: %v", ns, name, t.Spec.Cluster, err)
+	}
+	if err == nil && dc.DeletionTimestamp == nil && t.Status.TaskName != "" {
+		client, err := c.getMasterClient(t)
+		if err != nil {
+			return err
+		}
+		tasks, err := client.ListTasks()
+		if err != nil {
+			return fmt.Errorf("DMTask: [%s/%s], list tasks failed, err: %v", ns, name, err)
+		}
+		if sets.NewString(tasks...).Has(t.Status.TaskName) {
+			if err := client.DeleteTask(t.Status.TaskName); err != nil {
+				return fmt.Errorf("DMTask: [%s/%s], delete task %s failed, err: %v", ns, name, t.Status.TaskName, err)
+			}
+			klog.Infof("DMTask: [%s/%s], task %s is deleted", ns, name, t.Status.TaskName)
+			c.deps.Recorder.Eventf(t, corev1.EventTypeNormal, taskDeleted, "task %s is deleted", t.Status.TaskName)
+		}
+		c.deleteUnusedSources(t, client, t.GetSourceNames())
+	}
+
+	t.Finalizers = slice.RemoveString(t.Finalizers, label.DMTaskProtectionFinalizer, nil)
+	if _, err := c.deps.Clientset.PingcapV1alpha1().DMTasks(ns).Update(context.TODO(), t, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("remove dm task %s/%s protection finalizers failed, err: %v", ns, name, err)
+	}
+	return nil
+}
+
+func (c *defaultTaskControl) updateStatus(t *v1alpha1.DMTask) (*v1alpha1.DMTask, error) {
+	var (
+		ns     = t.GetNamespace()
+		name   = t.GetName()
+		status = t.Status.DeepCopy()
+		update *v1alpha1.DMTask
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().DMTasks(ns).UpdateStatus(context.TODO(), t, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.V(4).Infof("DMTask: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("DMTask: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		if updated, err := c.lister.DMTasks(ns).Get(name); err == nil {
+			t = updated.DeepCopy()
+			t.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated DMTask %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("DMTask: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+type FakeTaskControl struct {
+	reconcile func(*v1alpha1.DMTask) error
+}
+
+func (c *FakeTaskControl) MockReconcile(reconcile func(*v1alpha1.DMTask) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTaskControl) Reconcile(t *v1alpha1.DMTask) error {
+	if c.reconcile != nil {
+		return c.reconcile(t)
+	}
+	return nil
+}
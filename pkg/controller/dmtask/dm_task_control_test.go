@@ -0,0 +1,217 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmtask
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestBuildTaskConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	control := NewTaskControl(deps, deps.InformerFactory.Pingcap().V1alpha1().DMTasks().Lister()).(*defaultTaskControl)
+	pos := int64(4)
+	task := &v1alpha1.DMTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.DMTaskSpec{
+			Cluster:   "dc",
+			TaskMode:  v1alpha1.DMTaskModeIncremental,
+			ShardMode: v1alpha1.DMShardModeOptimistic,
+			Sources: []v1alpha1.DMTaskSource{
+				{Name: "mysql-01", Host: "mysql-01", User: "root", BinlogName: "mysql-bin.000001", BinlogPos: &pos},
+			},
+			Target: v1alpha1.TiDBAccessConfig{Host: "tidb"},
+			Config: config.New(map[string]interface{}{
+				"name":          "overwritten",
+				"on_duplicate":  "error",
+				"source_config": map[string]interface{}{"full_migrate_conf": map[string]interface{}{"export_threads": int64(4)}},
+			}),
+		},
+	}
+
+	cfg, err := control.buildTaskConfig(task)
+	g.Expect(err).To(Succeed())
+	g.Expect(cfg).To(HaveKeyWithValue("name", "task"))
+	g.Expect(cfg).To(HaveKeyWithValue("task_mode", "incremental"))
+	g.Expect(cfg).To(HaveKeyWithValue("shard_mode", "optimistic"))
+	g.Expect(cfg).To(HaveKeyWithValue("on_duplicate", "error"))
+	g.Expect(cfg["target_config"]).To(Equal(map[string]interface{}{"host": "tidb", "port": defaultTargetPort, "user": defaultTargetUser}))
+	g.Expect(cfg["source_config"]).To(Equal(map[string]interface{}{
+		"full_migrate_conf": map[string]interface{}{"export_threads": int64(4)},
+		"source_conf": []interface{}{
+			map[string]interface{}{"source_name": "mysql-01", "binlog_name": "mysql-bin.000001", "binlog_pos": int64(4)},
+		},
+	}))
+	g.Expect(cfg["table_migrate_rule"]).To(HaveLen(1))
+	// the config of the spec is not changed
+	g.Expect(task.Spec.Config.Inner()["source_config"]).NotTo(HaveKey("source_conf"))
+}
+
+func TestTaskControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	informer := deps.InformerFactory.Pingcap().V1alpha1().DMTasks()
+	control := NewTaskControl(deps, informer.Lister()).(*defaultTaskControl)
+
+	dc := &v1alpha1.DMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.DMClusterSpec{Master: v1alpha1.MasterSpec{Replicas: 1}},
+		Status: v1alpha1.DMClusterStatus{Master: v1alpha1.MasterStatus{
+			Members:     map[string]v1alpha1.MasterMember{"dc-dm-master-0": {Name: "dc-dm-master-0", Health: true}},
+			StatefulSet: &apps.StatefulSetStatus{ReadyReplicas: 1},
+		}},
+	}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().GetIndexer().Add(dc)).To(Succeed())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: corev1.NamespaceDefault},
+		Data:       map[string][]byte{passwordKey: []byte("secret")},
+	}
+	g.Expect(deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(secret)).To(Succeed())
+
+	masterClient := dmapi.NewFakeMasterClient()
+	deps.DMMasterControl.(*dmapi.FakeMasterControl).SetMasterClient(dc.Namespace, dc.Name, masterClient)
+
+	task := &v1alpha1.DMTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: corev1.NamespaceDefault, Generation: 1},
+		Spec: v1alpha1.DMTaskSpec{
+			Cluster: "dc",
+			Sources: []v1alpha1.DMTaskSource{{Name: "mysql-01", Host: "mysql-01", User: "root", SecretName: "mysql"}},
+			Target:  v1alpha1.TiDBAccessConfig{Host: "tidb"},
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().DMTasks(task.Namespace).Create(context.TODO(), task, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(informer.Informer().GetIndexer().Add(task)).To(Succeed())
+
+	sources := sets.NewString()
+	var tasks []string
+	var subtasks []*dmapi.SubTaskStatus
+	var calls []string
+	masterClient.AddReaction(dmapi.ListSourcesActionType, func(action *dmapi.Action) (interface{}, error) {
+		return sources.List(), nil
+	})
+	masterClient.AddReaction(dmapi.CreateSourceActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "create-source")
+		g.Expect(action.Config).To(HaveKeyWithValue("password", "secret"))
+		sources.Insert(action.Config["source_name"].(string))
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.UpdateSourceActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "update-source")
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.DeleteSourceActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "delete-source")
+		sources.Delete(action.Name)
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.ListTasksActionType, func(action *dmapi.Action) (interface{}, error) {
+		return tasks, nil
+	})
+	masterClient.AddReaction(dmapi.CreateTaskActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "create-task")
+		tasks = []string{action.Config["name"].(string)}
+		subtasks = []*dmapi.SubTaskStatus{{Name: "task", SourceName: "mysql-01", Stage: "Stopped"}}
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.UpdateTaskActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "update-task")
+		g.Expect(action.Config).To(HaveKeyWithValue("task_mode", "full"))
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.DeleteTaskActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "delete-task")
+		tasks = nil
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.StartTaskActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "start-task")
+		subtasks[0].Stage = "Running"
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.StopTaskActionType, func(action *dmapi.Action) (interface{}, error) {
+		calls = append(calls, "stop-task")
+		subtasks[0].Stage = "Stopped"
+		return nil, nil
+	})
+	masterClient.AddReaction(dmapi.GetTaskStatusActionType, func(action *dmapi.Action) (interface{}, error) {
+		return subtasks, nil
+	})
+
+	// the source and the task are created and started
+	err = control.Reconcile(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"create-source", "create-task", "start-task"}))
+	g.Expect(task.Finalizers).To(ContainElement(label.DMTaskProtectionFinalizer))
+	g.Expect(task.Status.TaskName).To(Equal("task"))
+	g.Expect(task.Status.ObservedGeneration).To(Equal(int64(1)))
+
+	// the status reflects the subtasks reported by dm-master
+	subtasks[0].Unit = "Sync"
+	subtasks[0].SyncStatus = &dmapi.SyncStatus{SecondsBehindMaster: 5}
+	g.Expect(control.Reconcile(task)).To(Succeed())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStageRunning))
+	g.Expect(task.Status.Sources).To(HaveLen(1))
+	g.Expect(task.Status.Sources[0].SecondsBehindMaster).To(Equal(int64(5)))
+	subtasks[0].Stage = "Paused"
+	subtasks[0].ErrorMsg = "connection refused"
+	g.Expect(control.Reconcile(task)).To(Succeed())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStagePaused))
+	g.Expect(task.Status.Message).To(ContainSubstring("connection refused"))
+	updated, err := deps.Clientset.PingcapV1alpha1().DMTasks(task.Namespace).Get(context.TODO(), task.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(updated.Status.Stage).To(Equal(v1alpha1.DMTaskStagePaused))
+
+	// the changed spec is applied by stopping, updating and starting the task
+	calls = nil
+	subtasks[0].ErrorMsg = ""
+	task.Spec.TaskMode = v1alpha1.DMTaskModeFull
+	task.Generation = 2
+	err = control.Reconcile(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"stop-task", "update-source", "update-task", "start-task"}))
+	g.Expect(task.Status.ObservedGeneration).To(Equal(int64(2)))
+
+	// the task is stopped
+	calls = nil
+	task.Spec.Paused = true
+	err = control.Reconcile(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"stop-task"}))
+	g.Expect(control.Reconcile(task)).To(Succeed())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStageStopped))
+
+	// the task and the unused source are deleted before the finalizer is removed
+	calls = nil
+	now := metav1.Now()
+	task.DeletionTimestamp = &now
+	g.Expect(control.Reconcile(task)).To(Succeed())
+	g.Expect(calls).To(Equal([]string{"delete-task", "delete-source"}))
+	g.Expect(task.Finalizers).NotTo(ContainElement(label.DMTaskProtectionFinalizer))
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EndpointAddress is an address of a service, which is resolved from either the EndpointSlices
+// or the Endpoints of the service.
+type EndpointAddress struct {
+	IP string
+	// PodName is the name of the pod the address targets, empty if the target is not a pod
+	PodName string
+	// NodeName is the name of the node where the target is running, empty if unknown
+	NodeName string
+	Ready    bool
+}
+
+// GetServiceEndpointAddresses returns the addresses of the service sorted by the pod names. The addresses
+// are resolved from the EndpointSlices of the service if the EndpointSliceLister is set, otherwise from the
+// Endpoints of the service, in which case an error is returned if the Endpoints do not exist.
+func GetServiceEndpointAddresses(deps *Dependencies, ns, svcName string) ([]EndpointAddress, error) {
+	var addrs []EndpointAddress
+	if deps.EndpointSliceLister != nil {
+		selector := labels.SelectorFromSet(labels.Set{discoveryv1beta1.LabelServiceName: svcName})
+		slices, err := deps.EndpointSliceLister.EndpointSlices(ns).List(selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list endpoint slices of service %s/%s: %v", ns, svcName, err)
+		}
+		addrs = endpointAddressesFromSlices(slices)
+	} else {
+		eps, err := deps.EndpointLister.Endpoints(ns).Get(svcName)
+		if err != nil {
+			return nil, err
+		}
+		addrs = endpointAddressesFromEndpoints(eps)
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		if addrs[i].PodName != addrs[j].PodName {
+			return addrs[i].PodName < addrs[j].PodName
+		}
+		return addrs[i].IP < addrs[j].IP
+	})
+	return addrs, nil
+}
+
+// HasReadyEndpointAddress returns whether any of the addresses is ready.
+func HasReadyEndpointAddress(addrs []EndpointAddress) bool {
+	for _, addr := range addrs {
+		if addr.Ready {
+			return true
+		}
+	}
+	return false
+}
+
+func endpointAddressesFromSlices(slices []*discoveryv1beta1.EndpointSlice) []EndpointAddress {
+	// the same address may be in more than one slices during the update of the slices
+	seen := map[string]bool{}
+	var addrs []EndpointAddress
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			// the unknown state of the condition is interpreted as ready
+			ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			podName := ""
+			if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
+				podName = ep.TargetRef.Name
+			}
+			nodeName := ep.Topology[corev1.LabelHostname]
+			if ep.NodeName != nil {
+				nodeName = *ep.NodeName
+			}
+			for _, ip := range ep.Addresses {
+				if seen[ip] {
+					continue
+				}
+				seen[ip] = true
+				addrs = append(addrs, EndpointAddress{IP: ip, PodName: podName, NodeName: nodeName, Ready: ready})
+			}
+		}
+	}
+	return addrs
+}
+
+func endpointAddressesFromEndpoints(eps *corev1.Endpoints) []EndpointAddress {
+	var addrs []EndpointAddress
+	add := func(addr corev1.EndpointAddress, ready bool) {
+		podName := ""
+		if addr.TargetRef != nil && addr.TargetRef.Kind == "Pod" {
+			podName = addr.TargetRef.Name
+		}
+		nodeName := ""
+		if addr.NodeName != nil {
+			nodeName = *addr.NodeName
+		}
+		addrs = append(addrs, EndpointAddress{IP: addr.IP, PodName: podName, NodeName: nodeName, Ready: ready})
+	}
+	for _, subset := range eps.Subsets {
+		for _, addr := range subset.Addresses {
+			add(addr, true)
+		}
+		for _, addr := range subset.NotReadyAddresses {
+			add(addr, false)
+		}
+	}
+	return addrs
+}
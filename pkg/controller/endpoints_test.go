@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetServiceEndpointAddresses(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := NewFakeDependencies()
+
+	// the Endpoints are required in the fallback mode
+	_, err := GetServiceEndpointAddresses(deps, corev1.NamespaceDefault, "test-tidb")
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(deps.KubeInformerFactory.Core().V1().Endpoints().Informer().GetIndexer().Add(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "test-tidb"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{
+				{IP: "10.0.0.2", NodeName: pointer.StringPtr("node-2"), TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "test-tidb-1"}},
+			},
+			NotReadyAddresses: []corev1.EndpointAddress{
+				{IP: "10.0.0.1", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "test-tidb-0"}},
+			},
+		}},
+	})).To(Succeed())
+	addrs, err := GetServiceEndpointAddresses(deps, corev1.NamespaceDefault, "test-tidb")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(Equal([]EndpointAddress{
+		{IP: "10.0.0.1", PodName: "test-tidb-0"},
+		{IP: "10.0.0.2", PodName: "test-tidb-1", NodeName: "node-2", Ready: true},
+	}))
+	g.Expect(HasReadyEndpointAddress(addrs)).To(BeTrue())
+
+	// the EndpointSlices are used if the lister is set
+	informer := deps.KubeInformerFactory.Discovery().V1beta1().EndpointSlices()
+	deps.EndpointSliceLister = informer.Lister()
+	addrs, err = GetServiceEndpointAddresses(deps, corev1.NamespaceDefault, "test-tidb")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(BeEmpty())
+
+	for _, slice := range []*discoveryv1beta1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "test-tidb-abcde",
+				Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "test-tidb"},
+			},
+			Endpoints: []discoveryv1beta1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					Conditions: discoveryv1beta1.EndpointConditions{Ready: pointer.BoolPtr(false)},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "test-tidb-0"},
+					Topology:   map[string]string{corev1.LabelHostname: "node-1"},
+				},
+				{
+					Addresses: []string{"10.0.0.2"},
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "test-tidb-1"},
+				},
+			},
+		},
+		{
+			// the slice of another service is ignored
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "test-tidb-peer-abcde",
+				Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "test-tidb-peer"},
+			},
+			Endpoints: []discoveryv1beta1.Endpoint{{Addresses: []string{"10.0.0.3"}}},
+		},
+	} {
+		g.Expect(informer.Informer().GetIndexer().Add(slice)).To(Succeed())
+	}
+	addrs, err = GetServiceEndpointAddresses(deps, corev1.NamespaceDefault, "test-tidb")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addrs).To(Equal([]EndpointAddress{
+		{IP: "10.0.0.1", PodName: "test-tidb-0", NodeName: "node-1"},
+		{IP: "10.0.0.2", PodName: "test-tidb-1", Ready: true},
+	}))
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
@@ -73,6 +74,10 @@ func SetServiceLastAppliedConfigAnnotation(svc *corev1.Service) error {
 
 // ServiceEqual compares the new Service's spec with old Service's last applied config
 func ServiceEqual(newSvc, oldSvc *corev1.Service) (bool, error) {
+	// the traffic fields which are not in the vendored API are recorded in the annotation
+	if newSvc.Annotations[label.AnnServiceTrafficPolicyKey] != oldSvc.Annotations[label.AnnServiceTrafficPolicyKey] {
+		return false, nil
+	}
 	oldSpec := corev1.ServiceSpec{}
 	if lastAppliedConfig, ok := oldSvc.Annotations[LastAppliedConfigAnnotation]; ok {
 		err := json.Unmarshal([]byte(lastAppliedConfig), &oldSpec)
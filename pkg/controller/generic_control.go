@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +63,8 @@ type TypedControlInterface interface {
 	CreateOrUpdateIngress(controller client.Object, ingress *networkingv1.Ingress) (*networkingv1.Ingress, error)
 	// CreateOrUpdateIngressV1beta1 create the desired v1beta1 ingress or update the current one to desired state if already existed
 	CreateOrUpdateIngressV1beta1(controller client.Object, ingress *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error)
+	// CreateOrUpdatePodDisruptionBudget create the desired pdb or update the current one to desired state if already existed
+	CreateOrUpdatePodDisruptionBudget(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error)
 	// UpdateStatus update the /status subresource of the object
 	UpdateStatus(newStatus client.Object) error
 	// Delete delete the given object from the cluster
@@ -132,6 +135,9 @@ func (w *typedWrapper) CreateOrUpdateSecret(controller client.Object, secret *co
 
 		existingSecret.Data = desiredSecret.Data
 		existingSecret.Labels = desiredSecret.Labels
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = map[string]string{}
+		}
 		for k, v := range desiredSecret.Annotations {
 			existingSecret.Annotations[k] = v
 		}
@@ -237,6 +243,19 @@ func (w *typedWrapper) CreateOrUpdateServiceAccount(controller client.Object, sa
 		desiredSA := desired.(*corev1.ServiceAccount)
 
 		existingSA.Labels = desiredSA.Labels
+		// keep the image pull secrets added by others, e.g. the admission controllers
+		for _, secret := range desiredSA.ImagePullSecrets {
+			found := false
+			for _, existingSecret := range existingSA.ImagePullSecrets {
+				if existingSecret.Name == secret.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				existingSA.ImagePullSecrets = append(existingSA.ImagePullSecrets, secret)
+			}
+		}
 		return nil
 	}, true)
 	if err != nil {
@@ -317,6 +336,23 @@ func (w *typedWrapper) CreateOrUpdateService(controller client.Object, svc *core
 	return result.(*corev1.Service), nil
 }
 
+func (w *typedWrapper) CreateOrUpdatePodDisruptionBudget(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	result, err := w.GenericControlInterface.CreateOrUpdate(controller, pdb, func(existing, desired client.Object) error {
+		existingPDB := existing.(*policyv1beta1.PodDisruptionBudget)
+		desiredPDB := desired.(*policyv1beta1.PodDisruptionBudget)
+
+		existingPDB.Labels = desiredPDB.Labels
+		existingPDB.Spec.Selector = desiredPDB.Spec.Selector
+		existingPDB.Spec.MaxUnavailable = desiredPDB.Spec.MaxUnavailable
+		existingPDB.Spec.MinAvailable = desiredPDB.Spec.MinAvailable
+		return nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*policyv1beta1.PodDisruptionBudget), nil
+}
+
 func (w *typedWrapper) CreateOrUpdateIngressV1beta1(controller client.Object, ingress *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error) {
 	result, err := w.GenericControlInterface.CreateOrUpdate(controller, ingress, func(existing, desired client.Object) error {
 		existingIngress := existing.(*extensionsv1beta1.Ingress)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 	v1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
@@ -56,10 +57,10 @@ func (c *httpClient) getHTTPClient(tc *v1alpha1.TidbCluster) (*http.Client, erro
 
 	rootCAs := x509.NewCertPool()
 	rootCAs.AppendCertsFromPEM(secret.Data[v1.ServiceAccountRootCAKey])
-	config := &tls.Config{
+	config := crypto.ApplyFIPSPolicy(&tls.Config{
 		RootCAs:      rootCAs,
 		Certificates: []tls.Certificate{tlsCert},
-	}
+	})
 	httpClient.Transport = &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}
 
 	return httpClient, nil
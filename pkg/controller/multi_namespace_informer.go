@@ -0,0 +1,398 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
+	internalinterfaces "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/internalinterfaces"
+	"github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/informers/apps"
+	"k8s.io/client-go/informers/batch"
+	"k8s.io/client-go/informers/core"
+	"k8s.io/client-go/informers/discovery"
+	"k8s.io/client-go/informers/extensions"
+	kubeinternalinterfaces "k8s.io/client-go/informers/internalinterfaces"
+	"k8s.io/client-go/informers/networking"
+	"k8s.io/client-go/informers/storage"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// multiNamespaceKubeInformerFactory is a kube informer factory whose informers of the namespaced resources
+// list and watch only the given namespaces, so that Roles in these namespaces are enough for the operator.
+// The informers of the cluster scoped resources are created by the wrapped factory.
+type multiNamespaceKubeInformerFactory struct {
+	kubeinformers.SharedInformerFactory
+
+	client           kubernetes.Interface
+	namespaces       []string
+	tweakListOptions kubeinternalinterfaces.TweakListOptionsFunc
+}
+
+func newMultiNamespaceKubeInformerFactory(client kubernetes.Interface, namespaces []string, resync time.Duration, tweakListOptions kubeinternalinterfaces.TweakListOptionsFunc) kubeinformers.SharedInformerFactory {
+	var options []kubeinformers.SharedInformerOption
+	if tweakListOptions != nil {
+		options = append(options, kubeinformers.WithTweakListOptions(tweakListOptions))
+	}
+	return &multiNamespaceKubeInformerFactory{
+		SharedInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(client, resync, options...),
+		client:                client,
+		namespaces:            namespaces,
+		tweakListOptions:      tweakListOptions,
+	}
+}
+
+func (f *multiNamespaceKubeInformerFactory) InformerFor(obj runtime.Object, newFunc kubeinternalinterfaces.NewInformerFunc) cache.SharedIndexInformer {
+	newListWatch := kubeListWatchFunc(f.client, obj)
+	if newListWatch == nil {
+		return f.SharedInformerFactory.InformerFor(obj, newFunc)
+	}
+	return f.SharedInformerFactory.InformerFor(obj, func(_ kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		lw := newMultiNamespaceListWatch(f.namespaces, f.tweakListOptions, newListWatch)
+		return cache.NewSharedIndexInformer(lw, obj, resync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *multiNamespaceKubeInformerFactory) Apps() apps.Interface {
+	return apps.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Batch() batch.Interface {
+	return batch.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Core() core.Interface {
+	return core.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Discovery() discovery.Interface {
+	return discovery.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Extensions() extensions.Interface {
+	return extensions.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Networking() networking.Interface {
+	return networking.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+func (f *multiNamespaceKubeInformerFactory) Storage() storage.Interface {
+	return storage.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+// kubeListWatchFunc returns the function to create the ListerWatcher of the namespaced resource in a namespace,
+// nil is returned for the cluster scoped resources
+func kubeListWatchFunc(client kubernetes.Interface, obj runtime.Object) func(namespace string) cache.ListerWatcher {
+	fromClient := func(c cache.Getter, resource string) func(namespace string) cache.ListerWatcher {
+		return func(namespace string) cache.ListerWatcher {
+			return cache.NewListWatchFromClient(c, resource, namespace, fields.Everything())
+		}
+	}
+	switch obj.(type) {
+	case *corev1.Pod:
+		return fromClient(client.CoreV1().RESTClient(), "pods")
+	case *corev1.Service:
+		return fromClient(client.CoreV1().RESTClient(), "services")
+	case *corev1.Endpoints:
+		return fromClient(client.CoreV1().RESTClient(), "endpoints")
+	case *corev1.PersistentVolumeClaim:
+		return fromClient(client.CoreV1().RESTClient(), "persistentvolumeclaims")
+	case *corev1.ConfigMap:
+		return fromClient(client.CoreV1().RESTClient(), "configmaps")
+	case *corev1.Secret:
+		return fromClient(client.CoreV1().RESTClient(), "secrets")
+	case *appsv1.Deployment:
+		return fromClient(client.AppsV1().RESTClient(), "deployments")
+	case *appsv1.StatefulSet:
+		// the typed client is used as the StatefulSets are served by AdvancedStatefulSet if the client is hijacked
+		return func(namespace string) cache.ListerWatcher {
+			return &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					return client.AppsV1().StatefulSets(namespace).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return client.AppsV1().StatefulSets(namespace).Watch(context.TODO(), options)
+				},
+			}
+		}
+	case *batchv1.Job:
+		return fromClient(client.BatchV1().RESTClient(), "jobs")
+	case *discoveryv1beta1.EndpointSlice:
+		return fromClient(client.DiscoveryV1beta1().RESTClient(), "endpointslices")
+	case *extensionsv1beta1.Ingress:
+		return fromClient(client.ExtensionsV1beta1().RESTClient(), "ingresses")
+	case *networkingv1.Ingress:
+		return fromClient(client.NetworkingV1().RESTClient(), "ingresses")
+	}
+	return nil
+}
+
+// multiNamespaceInformerFactory is the informer factory of the pingcap resources which lists and watches
+// only the given namespaces
+type multiNamespaceInformerFactory struct {
+	informers.SharedInformerFactory
+
+	client           versioned.Interface
+	namespaces       []string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+func newMultiNamespaceInformerFactory(client versioned.Interface, namespaces []string, resync time.Duration, tweakListOptions internalinterfaces.TweakListOptionsFunc) informers.SharedInformerFactory {
+	var options []informers.SharedInformerOption
+	if tweakListOptions != nil {
+		options = append(options, informers.WithTweakListOptions(tweakListOptions))
+	}
+	return &multiNamespaceInformerFactory{
+		SharedInformerFactory: informers.NewSharedInformerFactoryWithOptions(client, resync, options...),
+		client:                client,
+		namespaces:            namespaces,
+		tweakListOptions:      tweakListOptions,
+	}
+}
+
+func (f *multiNamespaceInformerFactory) InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer {
+	resource, ok := pingcapResources[fmt.Sprintf("%T", obj)]
+	if !ok {
+		return f.SharedInformerFactory.InformerFor(obj, newFunc)
+	}
+	return f.SharedInformerFactory.InformerFor(obj, func(_ versioned.Interface, resync time.Duration) cache.SharedIndexInformer {
+		lw := newMultiNamespaceListWatch(f.namespaces, f.tweakListOptions, func(namespace string) cache.ListerWatcher {
+			return cache.NewListWatchFromClient(f.client.PingcapV1alpha1().RESTClient(), resource, namespace, fields.Everything())
+		})
+		return cache.NewSharedIndexInformer(lw, obj, resync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *multiNamespaceInformerFactory) Pingcap() pingcap.Interface {
+	return pingcap.New(f, metav1.NamespaceAll, f.tweakListOptions)
+}
+
+// pingcapResources maps the types of the pingcap resources to their plural names
+var pingcapResources = map[string]string{
+	fmt.Sprintf("%T", &v1alpha1.TidbCluster{}):             v1alpha1.TiDBClusterName,
+	fmt.Sprintf("%T", &v1alpha1.DMCluster{}):               v1alpha1.DMClusterName,
+	fmt.Sprintf("%T", &v1alpha1.Backup{}):                  v1alpha1.BackupName,
+	fmt.Sprintf("%T", &v1alpha1.Restore{}):                 v1alpha1.RestoreName,
+	fmt.Sprintf("%T", &v1alpha1.BackupSchedule{}):          v1alpha1.BackupScheduleName,
+	fmt.Sprintf("%T", &v1alpha1.TidbMonitor{}):             v1alpha1.TiDBMonitorName,
+	fmt.Sprintf("%T", &v1alpha1.TidbInitializer{}):         v1alpha1.TiDBInitializerName,
+	fmt.Sprintf("%T", &v1alpha1.TidbClusterAutoScaler{}):   v1alpha1.TidbClusterAutoScalerName,
+	fmt.Sprintf("%T", &v1alpha1.TidbNGMonitoring{}):        v1alpha1.TiDBNGMonitoringName,
+	fmt.Sprintf("%T", &v1alpha1.TidbDashboard{}):           v1alpha1.TiDBDashboardName,
+	fmt.Sprintf("%T", &v1alpha1.TidbClusterRollout{}):      v1alpha1.TidbClusterRolloutName,
+	fmt.Sprintf("%T", &v1alpha1.Diagnostic{}):              v1alpha1.DiagnosticName,
+	fmt.Sprintf("%T", &v1alpha1.TiCDCChangefeed{}):         v1alpha1.TiCDCChangefeedName,
+	fmt.Sprintf("%T", &v1alpha1.PDRecovery{}):              v1alpha1.PDRecoveryName,
+	fmt.Sprintf("%T", &v1alpha1.DMTask{}):                  v1alpha1.DMTaskName,
+	fmt.Sprintf("%T", &v1alpha1.TidbClusterBackupPolicy{}): v1alpha1.TidbClusterBackupPolicyName,
+	fmt.Sprintf("%T", &v1alpha1.DataResource{}):            "dataresources",
+}
+
+// multiNamespaceListWatch lists and watches a resource in several namespaces as if they were one.
+// The resource version of the merged list is composed of the resource versions of the namespaces,
+// a watch can only be resumed from the last resource version returned by this ListWatch,
+// otherwise the reflector has to relist.
+type multiNamespaceListWatch struct {
+	namespaces       []string
+	tweakListOptions func(*metav1.ListOptions)
+	newListWatch     func(namespace string) cache.ListerWatcher
+
+	mu                  sync.Mutex
+	resourceVersions    map[string]string
+	lastResourceVersion string
+}
+
+var _ cache.ListerWatcher = &multiNamespaceListWatch{}
+
+func newMultiNamespaceListWatch(namespaces []string, tweakListOptions func(*metav1.ListOptions), newListWatch func(namespace string) cache.ListerWatcher) *multiNamespaceListWatch {
+	return &multiNamespaceListWatch{
+		namespaces:       namespaces,
+		tweakListOptions: tweakListOptions,
+		newListWatch:     newListWatch,
+		resourceVersions: map[string]string{},
+	}
+}
+
+func (lw *multiNamespaceListWatch) List(options metav1.ListOptions) (runtime.Object, error) {
+	if lw.tweakListOptions != nil {
+		lw.tweakListOptions(&options)
+	}
+	// the composed resource version and the continue token can't be passed to a single namespace
+	options.Limit, options.Continue = 0, ""
+	if options.ResourceVersion != "0" {
+		options.ResourceVersion = ""
+	}
+	options.ResourceVersionMatch = ""
+
+	var list runtime.Object
+	var items []runtime.Object
+	resourceVersions := map[string]string{}
+	for _, namespace := range lw.namespaces {
+		obj, err := lw.newListWatch(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		listMeta, err := meta.ListAccessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, objs...)
+		resourceVersions[namespace] = listMeta.GetResourceVersion()
+		list = obj
+	}
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(lw.namespaces))
+	for _, namespace := range lw.namespaces {
+		versions = append(versions, fmt.Sprintf("%s/%s", namespace, resourceVersions[namespace]))
+	}
+	resourceVersion := strings.Join(versions, ",")
+	listMeta.SetResourceVersion(resourceVersion)
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.resourceVersions = resourceVersions
+	lw.lastResourceVersion = resourceVersion
+	return list, nil
+}
+
+func (lw *multiNamespaceListWatch) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	if lw.tweakListOptions != nil {
+		lw.tweakListOptions(&options)
+	}
+	lw.mu.Lock()
+	if options.ResourceVersion != lw.lastResourceVersion {
+		lw.mu.Unlock()
+		return nil, apierrors.NewResourceExpired(fmt.Sprintf("resource version %q can't be resumed in namespaces %v", options.ResourceVersion, lw.namespaces))
+	}
+	resourceVersions := make(map[string]string, len(lw.resourceVersions))
+	for namespace, rv := range lw.resourceVersions {
+		resourceVersions[namespace] = rv
+	}
+	lw.mu.Unlock()
+
+	w := &multiNamespaceWatcher{
+		result: make(chan watch.Event),
+		stopCh: make(chan struct{}),
+	}
+	for _, namespace := range lw.namespaces {
+		opts := options
+		opts.ResourceVersion = resourceVersions[namespace]
+		watcher, err := lw.newListWatch(namespace).Watch(opts)
+		if err != nil {
+			w.Stop()
+			return nil, err
+		}
+		w.watchers = append(w.watchers, watcher)
+	}
+
+	var wg sync.WaitGroup
+	for i, namespace := range lw.namespaces {
+		wg.Add(1)
+		go func(namespace string, watcher watch.Interface) {
+			defer wg.Done()
+			// the watch is ended once the watch of any namespace is ended
+			defer w.Stop()
+			for {
+				select {
+				case <-w.stopCh:
+					return
+				case event, ok := <-watcher.ResultChan():
+					if !ok {
+						return
+					}
+					select {
+					case w.result <- event:
+					case <-w.stopCh:
+						return
+					}
+					lw.record(namespace, event)
+				}
+			}
+		}(namespace, w.watchers[i])
+	}
+	go func() {
+		wg.Wait()
+		close(w.result)
+	}()
+	return w, nil
+}
+
+// record saves the resource version of the event delivered, the watch of each namespace
+// is resumed from its own resource version
+func (lw *multiNamespaceListWatch) record(namespace string, event watch.Event) {
+	if event.Type == watch.Error {
+		return
+	}
+	accessor, err := meta.Accessor(event.Object)
+	if err != nil {
+		return
+	}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.resourceVersions[namespace] = accessor.GetResourceVersion()
+	lw.lastResourceVersion = accessor.GetResourceVersion()
+}
+
+// multiNamespaceWatcher merges the events of the watches of several namespaces
+type multiNamespaceWatcher struct {
+	watchers []watch.Interface
+	result   chan watch.Event
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *multiNamespaceWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		for _, watcher := range w.watchers {
+			watcher.Stop()
+		}
+	})
+}
+
+func (w *multiNamespaceWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestMultiNamespaceListWatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newPod := func(namespace, name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	kubeCli := kubefake.NewSimpleClientset(newPod("ns1", "pod1"), newPod("ns2", "pod2"), newPod("ns3", "pod3"))
+	lw := newMultiNamespaceListWatch([]string{"ns1", "ns2"}, nil, func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kubeCli.CoreV1().Pods(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kubeCli.CoreV1().Pods(namespace).Watch(context.TODO(), options)
+			},
+		}
+	})
+
+	// only the pods in the watched namespaces are listed
+	list, err := lw.List(metav1.ListOptions{})
+	g.Expect(err).To(Succeed())
+	items, err := meta.ExtractList(list)
+	g.Expect(err).To(Succeed())
+	g.Expect(items).To(HaveLen(2))
+	listMeta, err := meta.ListAccessor(list)
+	g.Expect(err).To(Succeed())
+
+	// the watch can't be resumed from an unknown resource version
+	_, err = lw.Watch(metav1.ListOptions{ResourceVersion: "unknown"})
+	g.Expect(apierrors.IsResourceExpired(err)).To(BeTrue())
+
+	w, err := lw.Watch(metav1.ListOptions{ResourceVersion: listMeta.GetResourceVersion()})
+	g.Expect(err).To(Succeed())
+	defer w.Stop()
+
+	_, err = kubeCli.CoreV1().Pods("ns3").Create(context.TODO(), newPod("ns3", "pod4"), metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	_, err = kubeCli.CoreV1().Pods("ns2").Create(context.TODO(), newPod("ns2", "pod5"), metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+
+	var event watch.Event
+	g.Eventually(w.ResultChan(), time.Second*10).Should(Receive(&event))
+	g.Expect(event.Type).To(Equal(watch.Added))
+	g.Expect(event.Object.(*corev1.Pod).Name).To(Equal("pod5"))
+
+	// the result channel is closed after the watch is stopped
+	w.Stop()
+	g.Eventually(w.ResultChan(), time.Second*10).Should(BeClosed())
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// nativeSidecarMinorVersion is the minor version of Kubernetes v1 which supports the init containers with
+// restartPolicy Always, the SidecarContainers feature gate is enabled by default since v1.29.
+const nativeSidecarMinorVersion = 28
+
+// nativeSidecarContainers returns the names of the containers of the StatefulSet which run as native sidecars,
+// they are recorded in the annotation of the pod template by the member managers.
+func nativeSidecarContainers(set *apps.StatefulSet) []string {
+	value := set.Spec.Template.Annotations[label.AnnNativeSidecarContainersKey]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// nativeSidecarSupported returns whether the native sidecar containers of the StatefulSet can be created,
+// the sidecars run as regular containers with a warning event if the version of Kubernetes is too old or unknown.
+func (c *realStatefulSetControl) nativeSidecarSupported(controller runtime.Object, set *apps.StatefulSet, sidecars []string) bool {
+	minor, known := c.version.minorVersion(c.kubeCli)
+	if known && minor >= nativeSidecarMinorVersion {
+		return true
+	}
+	version := "unknown"
+	if known {
+		version = fmt.Sprintf("v1.%d", minor)
+	}
+	msg := fmt.Sprintf("native sidecar containers %s of StatefulSet %s are not supported by Kubernetes %s, run them as regular containers",
+		strings.Join(sidecars, ","), set.Name, version)
+	c.recorder.Event(controller, corev1.EventTypeWarning, "UnsupportedNativeSidecar", msg)
+	return false
+}
+
+// patchNativeSidecarContainers replaces the spec, labels and annotations of the StatefulSet by a JSON patch,
+// the native sidecar containers are converted to init containers with restartPolicy Always which is not
+// in the vendored API.
+func (c *realStatefulSetControl) patchNativeSidecarContainers(set *apps.StatefulSet, sidecars []string) (*apps.StatefulSet, error) {
+	patch, err := nativeSidecarStatefulSetPatch(set, sidecars)
+	if err != nil {
+		return nil, err
+	}
+	return c.kubeCli.AppsV1().StatefulSets(set.Namespace).Patch(context.TODO(), set.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+}
+
+// nativeSidecarStatefulSetPatch returns the JSON patch which replaces the spec, labels and annotations of the
+// StatefulSet. The sidecar containers are moved to the end of the init containers, so they start after the other
+// init containers and before the main container, and restartPolicy Always is set to them.
+func nativeSidecarStatefulSetPatch(set *apps.StatefulSet, sidecars []string) ([]byte, error) {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&set.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert spec of StatefulSet %s/%s, error: %v", set.Namespace, set.Name, err)
+	}
+	containers, _, err := unstructured.NestedSlice(spec, "template", "spec", "containers")
+	if err != nil {
+		return nil, err
+	}
+	initContainers, _, err := unstructured.NestedSlice(spec, "template", "spec", "initContainers")
+	if err != nil {
+		return nil, err
+	}
+
+	names := sets.NewString(sidecars...)
+	isSidecar := func(c interface{}) bool {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		name, _ := container["name"].(string)
+		return names.Has(name)
+	}
+	var mainContainers, sidecarContainers []interface{}
+	for _, c := range containers {
+		if isSidecar(c) {
+			sidecarContainers = append(sidecarContainers, c)
+		} else {
+			mainContainers = append(mainContainers, c)
+		}
+	}
+	// the containers may be init containers already if the StatefulSet is got from the API server
+	newInitContainers := make([]interface{}, 0, len(initContainers)+len(sidecarContainers))
+	for _, c := range append(initContainers, sidecarContainers...) {
+		if isSidecar(c) {
+			c.(map[string]interface{})["restartPolicy"] = string(corev1.RestartPolicyAlways)
+		}
+		newInitContainers = append(newInitContainers, c)
+	}
+
+	if err := unstructured.SetNestedSlice(spec, mainContainers, "template", "spec", "containers"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedSlice(spec, newInitContainers, "template", "spec", "initContainers"); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec", "value": spec},
+		{"op": "add", "path": "/metadata/labels", "value": set.Labels},
+		{"op": "add", "path": "/metadata/annotations", "value": set.Annotations},
+	})
+}
@@ -0,0 +1,384 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+const (
+	// globalSinksKey is the key of the global sinks in the notification ConfigMap
+	globalSinksKey = "sinks"
+	// allowedHostsKey is the key of the hosts the sinks of the clusters can send notifications to
+	// in the notification ConfigMap
+	allowedHostsKey = "allowedHosts"
+	// globalScope is the scope of the global sinks
+	globalScope = "global"
+	// defaultMinInterval is the default min interval between two notifications of the same reason of a cluster
+	defaultMinInterval = 5 * time.Minute
+	// eventQueueSize is the max number of the events waiting to be forwarded, the others are dropped
+	eventQueueSize = 1000
+	// sendTimeout is the timeout to send a notification to a sink
+	sendTimeout = 10 * time.Second
+	// lastSentTTL is how long the last sent time of a notification is kept
+	lastSentTTL = 24 * time.Hour
+)
+
+// Event is an event to forward to the sinks, it is also the data of the message template.
+type Event struct {
+	Namespace string    `json:"namespace"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// defaultAllowedHosts are the hosts of the Slack incoming webhooks and the PagerDuty Events API,
+// the sinks of the clusters can always send notifications to them
+var defaultAllowedHosts = []string{"hooks.slack.com", "events.pagerduty.com"}
+
+// scopedSink is a sink with the namespace where its Secret is in
+type scopedSink struct {
+	v1alpha1.NotificationSink
+	// scope is `global` for the global sinks and `namespace/name` of the TidbCluster for the sinks of a cluster
+	scope           string
+	secretNamespace string
+	// allowedHosts are the hosts the sink can send notifications to, the global sinks configured by
+	// the administrator are not restricted, while the sinks of the clusters can only send to the
+	// default allowed hosts and the ones in the notification ConfigMap
+	allowedHosts []string
+}
+
+// globalConfig is the content of the notification ConfigMap
+type globalConfig struct {
+	sinks        []scopedSink
+	allowedHosts []string
+}
+
+// Controller forwards the significant events recorded by tidb-operator to the external sinks,
+// e.g. Slack, PagerDuty or generic webhooks. The sinks are configured by `spec.notification` of
+// each TidbCluster and the global notification ConfigMap of tidb-operator.
+//
+// The events are watched from the event broadcaster of tidb-operator, so only the events recorded
+// by tidb-operator itself are forwarded. The notifications of the same reason of a cluster are rate
+// limited by the min interval of each sink.
+type Controller struct {
+	deps       *controller.Dependencies
+	httpClient *http.Client
+	events     chan *Event
+
+	// globalInformerFactory caches the notification ConfigMap and the Secrets of the global sinks,
+	// they are not created by tidb-operator and may be out of the watched namespaces
+	globalInformerFactory kubeinformers.SharedInformerFactory
+	globalConfigMapLister corelisterv1.ConfigMapLister
+	globalSecretLister    corelisterv1.SecretLister
+
+	lock sync.Mutex
+	// lastSent is the last time a notification is sent, keyed by the sink, cluster and reason
+	lastSent map[string]time.Time
+	now      func() time.Time
+}
+
+// NewController creates a notification controller which watches the events recorded by tidb-operator.
+func NewController(deps *controller.Dependencies) *Controller {
+	c := &Controller{
+		deps: deps,
+		httpClient: &http.Client{
+			Timeout: sendTimeout,
+			// the redirects are not followed, otherwise the allowed hosts can be bypassed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		events:   make(chan *Event, eventQueueSize),
+		lastSent: map[string]time.Time{},
+		now:      time.Now,
+	}
+	if deps.CLIConfig.NotificationConfigMap != "" {
+		if ns, _, err := splitConfigMapKey(deps.CLIConfig.NotificationConfigMap); err != nil {
+			klog.Errorf("notification: invalid notification configmap %s, error: %v", deps.CLIConfig.NotificationConfigMap, err)
+		} else {
+			c.globalInformerFactory = kubeinformers.NewSharedInformerFactoryWithOptions(deps.KubeClientset, deps.CLIConfig.ResyncDuration, kubeinformers.WithNamespace(ns))
+			c.globalConfigMapLister = c.globalInformerFactory.Core().V1().ConfigMaps().Lister()
+			c.globalSecretLister = c.globalInformerFactory.Core().V1().Secrets().Lister()
+		}
+	}
+	if deps.EventBroadcaster != nil {
+		deps.EventBroadcaster.StartEventWatcher(c.onEvent)
+	}
+	return c
+}
+
+// Name returns the name of the notification controller.
+func (c *Controller) Name() string {
+	return "notification"
+}
+
+// Run forwards the events to the sinks by the workers until stopCh is closed.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+
+	klog.Info("Starting notification controller")
+	defer klog.Info("Shutting down notification controller")
+
+	if c.globalInformerFactory != nil {
+		c.globalInformerFactory.Start(stopCh)
+		for v, synced := range c.globalInformerFactory.WaitForCacheSync(stopCh) {
+			if !synced {
+				klog.Errorf("notification: failed to sync the informer for %v", v)
+				return
+			}
+		}
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() { c.doWork(stopCh) }, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+// doWork forwards the events one by one, so that the number of the concurrent notifications
+// is bounded by the number of the workers
+func (c *Controller) doWork(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case e := <-c.events:
+			c.notify(e)
+		}
+	}
+}
+
+// onEvent is called by the event broadcaster, it must not block
+func (c *Controller) onEvent(event *corev1.Event) {
+	e := c.newEvent(event)
+	select {
+	case c.events <- e:
+	default:
+		klog.Warningf("notification: the queue is full, drop event %s %s/%s %s", e.Kind, e.Namespace, e.Name, e.Reason)
+		metrics.Notifications.WithLabelValues("", "", "dropped").Inc()
+	}
+}
+
+// newEvent converts the event and resolves the TidbCluster the involved object belongs to
+func (c *Controller) newEvent(event *corev1.Event) *Event {
+	obj := event.InvolvedObject
+	e := &Event{
+		Namespace: obj.Namespace,
+		Kind:      obj.Kind,
+		Name:      obj.Name,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Timestamp: event.LastTimestamp.Time,
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = event.EventTime.Time
+	}
+
+	var br *v1alpha1.BRConfig
+	switch obj.Kind {
+	case v1alpha1.TiDBClusterKind:
+		e.Cluster = obj.Name
+		return e
+	case v1alpha1.BackupKind:
+		if backup, err := c.deps.BackupLister.Backups(obj.Namespace).Get(obj.Name); err == nil {
+			br = backup.Spec.BR
+		}
+	case v1alpha1.RestoreKind:
+		if restore, err := c.deps.RestoreLister.Restores(obj.Namespace).Get(obj.Name); err == nil {
+			br = restore.Spec.BR
+		}
+	}
+	// only the sinks of the cluster in the same namespace are taken into account,
+	// the sinks of a cluster should not receive the events of other namespaces
+	if br != nil && (br.ClusterNamespace == "" || br.ClusterNamespace == obj.Namespace) {
+		e.Cluster = br.Cluster
+	}
+	return e
+}
+
+func (c *Controller) notify(e *Event) {
+	for _, sink := range c.getSinks(e) {
+		if !matchEvent(&sink.NotificationSink, e) {
+			continue
+		}
+		if !c.allow(&sink, e) {
+			klog.V(4).Infof("notification: sink %s of %s is throttled for event %s %s/%s %s", sink.Name, sink.scope, e.Kind, e.Namespace, e.Name, e.Reason)
+			metrics.Notifications.WithLabelValues(sink.Name, string(sink.Type), "throttled").Inc()
+			continue
+		}
+		if err := c.send(&sink, e); err != nil {
+			klog.Errorf("notification: failed to send event %s %s/%s %s to sink %s of %s, error: %v", e.Kind, e.Namespace, e.Name, e.Reason, sink.Name, sink.scope, err)
+			metrics.Notifications.WithLabelValues(sink.Name, string(sink.Type), "failed").Inc()
+			continue
+		}
+		metrics.Notifications.WithLabelValues(sink.Name, string(sink.Type), "sent").Inc()
+	}
+}
+
+// getSinks returns the global sinks and the sinks of the cluster of the event
+func (c *Controller) getSinks(e *Event) []scopedSink {
+	var sinks []scopedSink
+	allowedHosts := defaultAllowedHosts
+	cfg, err := c.getGlobalConfig()
+	if err != nil {
+		// the sinks of the cluster still work even if the global sinks are broken
+		klog.Errorf("notification: failed to get the global sinks, error: %v", err)
+	} else if cfg != nil {
+		sinks = cfg.sinks
+		allowedHosts = append(append([]string{}, defaultAllowedHosts...), cfg.allowedHosts...)
+	}
+	if e.Cluster == "" {
+		return sinks
+	}
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(e.Namespace).Get(e.Cluster)
+	if err != nil || tc.Spec.Notification == nil {
+		return sinks
+	}
+	if errs := validation.ValidateNotificationSinks(tc.Spec.Notification.Sinks, field.NewPath("spec", "notification", "sinks")); len(errs) > 0 {
+		klog.Errorf("notification: the sinks of tc %s/%s are invalid, error: %v", tc.Namespace, tc.Name, errs.ToAggregate())
+		return sinks
+	}
+	for _, sink := range tc.Spec.Notification.Sinks {
+		sinks = append(sinks, scopedSink{NotificationSink: sink, scope: tc.Namespace + "/" + tc.Name, secretNamespace: tc.Namespace, allowedHosts: allowedHosts})
+	}
+	return sinks
+}
+
+// getGlobalConfig returns the global sinks and the allowed hosts in the notification ConfigMap
+func (c *Controller) getGlobalConfig() (*globalConfig, error) {
+	if c.globalConfigMapLister == nil {
+		return nil, nil
+	}
+	ns, name, err := splitConfigMapKey(c.deps.CLIConfig.NotificationConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := c.globalConfigMapLister.ConfigMaps(ns).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var globalSinks []v1alpha1.NotificationSink
+	if err := yaml.Unmarshal([]byte(cm.Data[globalSinksKey]), &globalSinks); err != nil {
+		return nil, fmt.Errorf("failed to parse key %s of configmap %s/%s: %v", globalSinksKey, ns, name, err)
+	}
+	if errs := validation.ValidateNotificationSinks(globalSinks, field.NewPath(globalSinksKey)); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid sinks in configmap %s/%s: %v", ns, name, errs.ToAggregate())
+	}
+	cfg := &globalConfig{sinks: make([]scopedSink, 0, len(globalSinks))}
+	if err := yaml.Unmarshal([]byte(cm.Data[allowedHostsKey]), &cfg.allowedHosts); err != nil {
+		return nil, fmt.Errorf("failed to parse key %s of configmap %s/%s: %v", allowedHostsKey, ns, name, err)
+	}
+	for _, sink := range globalSinks {
+		cfg.sinks = append(cfg.sinks, scopedSink{NotificationSink: sink, scope: globalScope, secretNamespace: ns})
+	}
+	return cfg, nil
+}
+
+// splitConfigMapKey returns the namespace and name of the notification ConfigMap
+func splitConfigMapKey(key string) (string, string, error) {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	return ns, name, nil
+}
+
+// isAllowedHost returns whether the host matches any of the allowed hosts,
+// an allowed host in the format of `*.example.com` matches all the subdomains of `example.com`
+func isAllowedHost(host string, allowedHosts []string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEvent returns whether the event should be forwarded to the sink
+func matchEvent(sink *v1alpha1.NotificationSink, e *Event) bool {
+	if len(sink.Reasons) == 0 {
+		return e.Type == corev1.EventTypeWarning
+	}
+	for _, reason := range sink.Reasons {
+		if reason == e.Reason {
+			return true
+		}
+	}
+	return false
+}
+
+// allow returns whether the notification can be sent to the sink, and records the time if it is allowed
+func (c *Controller) allow(sink *scopedSink, e *Event) bool {
+	interval := defaultMinInterval
+	if sink.MinInterval != nil {
+		interval = sink.MinInterval.Duration
+	}
+	key := fmt.Sprintf("%s/%s/%s/%s/%s", sink.scope, sink.Name, e.Namespace, e.Cluster, e.Reason)
+	if e.Cluster == "" {
+		// the events out of any cluster are rate limited by the involved object
+		key = fmt.Sprintf("%s/%s/%s/%s/%s/%s", sink.scope, sink.Name, e.Namespace, e.Kind, e.Name, e.Reason)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	if last, ok := c.lastSent[key]; ok && now.Sub(last) < interval {
+		return false
+	}
+	c.lastSent[key] = now
+	// forget the expired records so that the map does not grow forever
+	for k, last := range c.lastSent {
+		if now.Sub(last) > lastSentTTL && now.Sub(last) > interval {
+			delete(c.lastSent, k)
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+type fakeSink struct {
+	lock     sync.Mutex
+	messages map[string][]map[string]interface{}
+}
+
+func newFakeSink() (*fakeSink, *httptest.Server) {
+	s := &fakeSink{messages: map[string][]map[string]interface{}{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.messages[r.URL.Path] = append(s.messages[r.URL.Path], msg)
+	}))
+	return s, server
+}
+
+func (s *fakeSink) get(path string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.messages[path]
+}
+
+func newTCEvent(eventType, reason, message string) *corev1.Event {
+	return &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: v1alpha1.TiDBClusterKind, Namespace: "ns", Name: "tc"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+	}
+}
+
+func TestNotify(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sink, server := newFakeSink()
+	defer server.Close()
+
+	deps := controller.NewFakeDependencies()
+	deps.CLIConfig.NotificationConfigMap = "admin/notification"
+	c := NewController(deps)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	g.Expect(deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "slack"},
+		Data:       map[string][]byte{"url": []byte(server.URL + "/slack\n")},
+	})).To(Succeed())
+	_, err := deps.KubeClientset.CoreV1().Secrets("admin").Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "admin", Name: "pagerduty"},
+		Data:       map[string][]byte{"key": []byte("routing-key")},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = deps.KubeClientset.CoreV1().ConfigMaps("admin").Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "admin", Name: "notification"},
+		Data: map[string]string{globalSinksKey: `
+- name: oncall
+  type: PagerDuty
+  url: ` + server.URL + `/pagerduty
+  secretRef:
+    name: pagerduty
+    key: key
+  reasons: [PDMemberDeleted]
+`, allowedHostsKey: `[127.0.0.1]`},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	// the notification ConfigMap and the Secrets of the global sinks are cached by the controller
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.globalInformerFactory.Start(stopCh)
+	c.globalInformerFactory.WaitForCacheSync(stopCh)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tc"},
+		Spec: v1alpha1.TidbClusterSpec{
+			Notification: &v1alpha1.NotificationSpec{
+				Sinks: []v1alpha1.NotificationSink{
+					{
+						Name:      "slack",
+						Type:      v1alpha1.NotificationSinkTypeSlack,
+						SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+						Template:  "{{.Cluster}}: {{.Reason}}",
+					},
+					{
+						Name:        "webhook",
+						Type:        v1alpha1.NotificationSinkTypeWebhook,
+						URL:         server.URL + "/webhook",
+						Reasons:     []string{"UpgradeFinished"},
+						MinInterval: &metav1.Duration{Duration: 0},
+					},
+					{
+						Name:        "not-allowed",
+						Type:        v1alpha1.NotificationSinkTypeWebhook,
+						URL:         strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/not-allowed",
+						Reasons:     []string{"UpgradeFinished"},
+						MinInterval: &metav1.Duration{Duration: 0},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+
+	// Warning events are forwarded to the sinks without reasons
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeWarning, "PDMemberUnhealthy", "pd-0 is unhealthy")))
+	g.Expect(sink.get("/slack")).To(HaveLen(1))
+	g.Expect(sink.get("/slack")[0]["text"]).To(Equal("tc: PDMemberUnhealthy"))
+	g.Expect(sink.get("/webhook")).To(BeEmpty())
+	g.Expect(sink.get("/pagerduty")).To(BeEmpty())
+
+	// the same reason is throttled in the min interval
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeWarning, "PDMemberUnhealthy", "pd-1 is unhealthy")))
+	g.Expect(sink.get("/slack")).To(HaveLen(1))
+	now = now.Add(defaultMinInterval)
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeWarning, "PDMemberUnhealthy", "pd-1 is unhealthy")))
+	g.Expect(sink.get("/slack")).To(HaveLen(2))
+
+	// the events are forwarded to the sinks matching the reason
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeNormal, "UpgradeFinished", "tikv is upgraded successfully")))
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeNormal, "UpgradeFinished", "tidb is upgraded successfully")))
+	g.Expect(sink.get("/slack")).To(HaveLen(2))
+	g.Expect(sink.get("/webhook")).To(HaveLen(2))
+	event := sink.get("/webhook")[1]["event"].(map[string]interface{})
+	g.Expect(event["cluster"]).To(Equal("tc"))
+	g.Expect(event["message"]).To(Equal("tidb is upgraded successfully"))
+	// the sinks of the clusters can't send to the hosts which are not allowed
+	g.Expect(sink.get("/not-allowed")).To(BeEmpty())
+
+	// the events are forwarded to the global sinks
+	c.notify(c.newEvent(newTCEvent(corev1.EventTypeWarning, "PDMemberDeleted", "pd-0 is deleted")))
+	g.Expect(sink.get("/slack")).To(HaveLen(3))
+	g.Expect(sink.get("/pagerduty")).To(HaveLen(1))
+	g.Expect(sink.get("/pagerduty")[0]["routing_key"]).To(Equal("routing-key"))
+	g.Expect(sink.get("/pagerduty")[0]["event_action"]).To(Equal("trigger"))
+}
+
+func TestNewEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	c := NewController(deps)
+	backups := deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer().GetIndexer()
+	g.Expect(backups.Add(&v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "br"},
+		Spec:       v1alpha1.BackupSpec{BR: &v1alpha1.BRConfig{Cluster: "tc"}},
+	})).To(Succeed())
+	g.Expect(backups.Add(&v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other-ns"},
+		Spec:       v1alpha1.BackupSpec{BR: &v1alpha1.BRConfig{Cluster: "tc", ClusterNamespace: "other"}},
+	})).To(Succeed())
+
+	e := c.newEvent(&corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: v1alpha1.BackupKind, Namespace: "ns", Name: "br"}})
+	g.Expect(e.Cluster).To(Equal("tc"))
+	// the sinks of the clusters in other namespaces are not used
+	e = c.newEvent(&corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: v1alpha1.BackupKind, Namespace: "ns", Name: "other-ns"}})
+	g.Expect(e.Cluster).To(BeEmpty())
+	e = c.newEvent(&corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "tc-pd-0"}})
+	g.Expect(e.Cluster).To(BeEmpty())
+}
+
+func TestRenderMessage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	e := &Event{Namespace: "ns", Cluster: "tc", Kind: "TidbCluster", Name: "tc", Type: corev1.EventTypeWarning, Reason: "Unhealthy", Message: "pd-0 is unhealthy"}
+	msg, err := renderMessage(&v1alpha1.NotificationSink{Name: "default"}, e)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg).To(Equal("[Warning] TidbCluster ns/tc Unhealthy: pd-0 is unhealthy"))
+
+	_, err = renderMessage(&v1alpha1.NotificationSink{Name: "invalid", Template: "{{.Unknown}}"}, e)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestIsAllowedHost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	allowedHosts := append([]string{"*.example.com", "alert.internal"}, defaultAllowedHosts...)
+	g.Expect(isAllowedHost("hooks.slack.com", allowedHosts)).To(BeTrue())
+	g.Expect(isAllowedHost("Alert.Internal", allowedHosts)).To(BeTrue())
+	g.Expect(isAllowedHost("hooks.example.com", allowedHosts)).To(BeTrue())
+	g.Expect(isAllowedHost("example.com", allowedHosts)).To(BeFalse())
+	g.Expect(isAllowedHost("169.254.169.254", allowedHosts)).To(BeFalse())
+	g.Expect(isAllowedHost("kubernetes.default.svc", allowedHosts)).To(BeFalse())
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+const (
+	// defaultTemplate is the default template of the notification message
+	defaultTemplate = "[{{.Type}}] {{.Kind}} {{.Namespace}}/{{.Name}} {{.Reason}}: {{.Message}}"
+	// pagerDutyEventsURL is the endpoint of the PagerDuty Events API v2
+	pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// slackMessage is the payload of Slack incoming webhooks
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// webhookMessage is the payload of generic webhooks
+type webhookMessage struct {
+	Text  string `json:"text"`
+	Event *Event `json:"event"`
+}
+
+// pagerDutyEvent is the payload of the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key,omitempty"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary   string `json:"summary"`
+	Source    string `json:"source"`
+	Severity  string `json:"severity"`
+	Component string `json:"component,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Class     string `json:"class,omitempty"`
+}
+
+// send renders the message of the event and sends it to the sink
+func (c *Controller) send(sink *scopedSink, e *Event) error {
+	text, err := renderMessage(&sink.NotificationSink, e)
+	if err != nil {
+		return err
+	}
+
+	var secret string
+	if sink.SecretRef != nil {
+		secret, err = c.getSecretValue(sink)
+		if err != nil {
+			return err
+		}
+	}
+
+	url := sink.URL
+	var payload interface{}
+	switch sink.Type {
+	case v1alpha1.NotificationSinkTypeSlack:
+		if secret != "" {
+			url = secret
+		}
+		payload = &slackMessage{Text: text}
+	case v1alpha1.NotificationSinkTypeWebhook:
+		if secret != "" {
+			url = secret
+		}
+		payload = &webhookMessage{Text: text, Event: e}
+	case v1alpha1.NotificationSinkTypePagerDuty:
+		if url == "" {
+			url = pagerDutyEventsURL
+		}
+		payload = newPagerDutyEvent(secret, text, e)
+	default:
+		return fmt.Errorf("unsupported sink type %s", sink.Type)
+	}
+	if sink.scope != globalScope {
+		// the sinks of the clusters are configured by the users of the clusters, they must not make
+		// tidb-operator send requests to the internal services. The URL is not logged as it may
+		// contain the token of the webhook.
+		u, err := neturl.Parse(url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("the url of the sink must be a http or https URL")
+		}
+		if !isAllowedHost(u.Hostname(), sink.allowedHosts) {
+			return fmt.Errorf("host %s of the sink is not allowed, it must be added to key %s of the notification configmap", u.Hostname(), allowedHostsKey)
+		}
+	}
+	return c.post(url, payload)
+}
+
+func newPagerDutyEvent(routingKey, text string, e *Event) *pagerDutyEvent {
+	severity := "info"
+	if e.Type == corev1.EventTypeWarning {
+		severity = "warning"
+	}
+	group := e.Namespace
+	if e.Cluster != "" {
+		group = e.Namespace + "/" + e.Cluster
+	}
+	return &pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		// the events of the same reason of the same object are grouped into one incident
+		DedupKey: fmt.Sprintf("%s/%s/%s/%s", e.Namespace, e.Kind, e.Name, e.Reason),
+		Payload: pagerDutyPayload{
+			Summary:   text,
+			Source:    fmt.Sprintf("%s %s/%s", e.Kind, e.Namespace, e.Name),
+			Severity:  severity,
+			Component: e.Kind,
+			Group:     group,
+			Class:     e.Reason,
+		},
+	}
+}
+
+// renderMessage renders the notification message of the event by the template of the sink
+func renderMessage(sink *v1alpha1.NotificationSink, e *Event) (string, error) {
+	text := sink.Template
+	if text == "" {
+		text = defaultTemplate
+	}
+	tmpl, err := template.New(sink.Name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, e); err != nil {
+		return "", fmt.Errorf("failed to render template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// getSecretValue returns the value of the Secret of the sink, the Secrets of the global sinks are
+// cached by the informer of the namespace of the notification ConfigMap
+func (c *Controller) getSecretValue(sink *scopedSink) (string, error) {
+	ns, ref := sink.secretNamespace, sink.SecretRef
+	lister := c.deps.SecretLister
+	if sink.scope == globalScope {
+		lister = c.globalSecretLister
+	}
+	secret, err := lister.Secrets(ns).Get(ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %v", ns, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s is not found in secret %s/%s", ref.Key, ns, ref.Name)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+func (c *Controller) post(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// the URL is not logged as it may contain the token of the webhook
+		if urlErr, ok := err.(*neturl.Error); ok {
+			return fmt.Errorf("failed to post the notification: %v", urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, string(msg))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orphansweeper
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// Controller sweeps the resources managed by tidb-operator periodically to find the orphaned ones
+// whose owner CR no longer exists, e.g. the owner reference points to a CR in another namespace or
+// the cascading deletion of the CR is failed. The orphaned resources are reported via metrics and
+// events, and deleted if the sweep policy is Delete.
+//
+// Only the resources controlled by a CR of tidb-operator are taken into account, the resources without
+// controller reference may be created by users or waiting to be adopted, so they are never touched.
+type Controller struct {
+	deps *controller.Dependencies
+}
+
+// NewController creates an orphan sweeper.
+func NewController(deps *controller.Dependencies) *Controller {
+	return &Controller{
+		deps: deps,
+	}
+}
+
+// Name returns the name of the orphan sweeper.
+func (c *Controller) Name() string {
+	return "orphan-sweeper"
+}
+
+// Run sweeps the orphaned resources periodically until stopCh is closed.
+func (c *Controller) Run(_ int, stopCh <-chan struct{}) {
+	klog.Info("Starting orphan sweeper")
+	defer klog.Info("Shutting down orphan sweeper")
+
+	wait.Until(c.sweep, c.deps.CLIConfig.OrphanSweepPeriod, stopCh)
+}
+
+// orphan is a resource whose owner CR no longer exists
+type orphan struct {
+	kind  string
+	obj   client.Object
+	owner metav1.OwnerReference
+}
+
+func (c *Controller) sweep() {
+	orphans, err := c.findOrphans()
+	if err != nil {
+		klog.Errorf("orphan sweeper: failed to find orphaned resources, error: %v", err)
+		return
+	}
+
+	deleteOrphans := c.deps.CLIConfig.OrphanSweepPolicy == controller.OrphanSweepPolicyDelete
+	metrics.OrphanResources.Reset()
+	for _, o := range orphans {
+		ns, name := o.obj.GetNamespace(), o.obj.GetName()
+		metrics.OrphanResources.WithLabelValues(ns, o.kind).Inc()
+		klog.Warningf("orphan sweeper: %s %s/%s is orphaned, its owner %s %s(%s) no longer exists", o.kind, ns, name, o.owner.Kind, o.owner.Name, o.owner.UID)
+		c.deps.Recorder.Eventf(o.obj, corev1.EventTypeWarning, "OrphanedResource", "owner %s %s(%s) no longer exists", o.owner.Kind, o.owner.Name, o.owner.UID)
+
+		if !deleteOrphans {
+			continue
+		}
+		uid := o.obj.GetUID()
+		err := c.deps.GenericClient.Delete(context.TODO(), o.obj, client.Preconditions{UID: &uid})
+		if err != nil && !errors.IsNotFound(err) {
+			klog.Errorf("orphan sweeper: failed to delete %s %s/%s, error: %v", o.kind, ns, name, err)
+			continue
+		}
+		metrics.OrphanResourcesDeleted.WithLabelValues(ns, o.kind).Inc()
+		klog.Infof("orphan sweeper: %s %s/%s is deleted", o.kind, ns, name)
+	}
+}
+
+// findOrphans lists the resources managed by tidb-operator and returns the ones whose owner CR no longer exists
+func (c *Controller) findOrphans() ([]orphan, error) {
+	selector := labels.SelectorFromSet(labels.Set{label.ManagedByLabelKey: label.TiDBOperator})
+
+	objs := map[string][]client.Object{}
+	svcs, err := c.deps.ServiceLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %v", err)
+	}
+	for _, svc := range svcs {
+		objs["Service"] = append(objs["Service"], svc)
+	}
+	cms, err := c.deps.ConfigMapLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %v", err)
+	}
+	for _, cm := range cms {
+		objs["ConfigMap"] = append(objs["ConfigMap"], cm)
+	}
+	secrets, err := c.deps.SecretLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %v", err)
+	}
+	for _, secret := range secrets {
+		objs["Secret"] = append(objs["Secret"], secret)
+	}
+	sts, err := c.deps.StatefulSetLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %v", err)
+	}
+	for _, s := range sts {
+		objs["StatefulSet"] = append(objs["StatefulSet"], s)
+	}
+	deploys, err := c.deps.DeploymentLister.List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
+	}
+	for _, deploy := range deploys {
+		objs["Deployment"] = append(objs["Deployment"], deploy)
+	}
+
+	// the existence of the owners is cached in one sweep
+	ownerExists := map[types.UID]bool{}
+	orphans := []orphan{}
+	for kind, list := range objs {
+		for _, obj := range list {
+			ref := metav1.GetControllerOf(obj)
+			if ref == nil {
+				continue
+			}
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil || gv.Group != v1alpha1.GroupName {
+				continue
+			}
+			exist, ok := ownerExists[ref.UID]
+			if !ok {
+				exist, err = c.ownerExists(obj.GetNamespace(), ref)
+				if err != nil {
+					klog.Errorf("orphan sweeper: failed to get owner %s %s/%s of %s %s, error: %v", ref.Kind, obj.GetNamespace(), ref.Name, kind, obj.GetName(), err)
+					continue
+				}
+				ownerExists[ref.UID] = exist
+			}
+			if !exist {
+				orphans = append(orphans, orphan{kind: kind, obj: obj.DeepCopyObject().(client.Object), owner: *ref})
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// ownerExists checks the owner from the API server directly instead of the listers, because
+// the CRs may be filtered out by the selector of the controller manager.
+// The owner is considered existing if it is an unknown kind.
+func (c *Controller) ownerExists(ns string, ref *metav1.OwnerReference) (bool, error) {
+	var (
+		owner metav1.Object
+		err   error
+	)
+	ctx := context.TODO()
+	cli := c.deps.Clientset.PingcapV1alpha1()
+	switch ref.Kind {
+	case v1alpha1.TiDBClusterKind:
+		owner, err = cli.TidbClusters(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.DMClusterKind:
+		owner, err = cli.DMClusters(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.TiDBMonitorKind:
+		owner, err = cli.TidbMonitors(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.TiDBNGMonitoringKind:
+		owner, err = cli.TidbNGMonitorings(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.TiDBDashboardKind:
+		owner, err = cli.TidbDashboards(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.TiDBInitializerKind:
+		owner, err = cli.TidbInitializers(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.BackupKind:
+		owner, err = cli.Backups(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	case v1alpha1.RestoreKind:
+		owner, err = cli.Restores(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return true, nil
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// the CR with the same name is recreated
+	return owner.GetUID() == ref.UID, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orphansweeper
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func newObjectMeta(name string, owner *v1alpha1.TidbCluster) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: metav1.NamespaceDefault,
+		UID:       types.UID(name),
+		Labels:    map[string]string{label.ManagedByLabelKey: label.TiDBOperator},
+	}
+	if owner != nil {
+		meta.OwnerReferences = []metav1.OwnerReference{controller.GetOwnerRef(owner)}
+	}
+	return meta
+}
+
+func newTidbCluster(name string, uid types.UID) *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			UID:       uid,
+		},
+	}
+}
+
+func newFakeController(g *GomegaWithT, policy string) *Controller {
+	deps := controller.NewFakeDependencies()
+	deps.CLIConfig.OrphanSweepPolicy = policy
+
+	existing := newTidbCluster("existing", "existing-uid")
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusters(existing.Namespace).Create(context.TODO(), existing, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	recreated := newTidbCluster("recreated", "recreated-uid")
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(recreated.Namespace).Create(context.TODO(), recreated, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+
+	objs := []client.Object{
+		// owned by an existing TidbCluster
+		&corev1.Service{ObjectMeta: newObjectMeta("svc", existing)},
+		// owned by a deleted TidbCluster
+		&corev1.ConfigMap{ObjectMeta: newObjectMeta("cm", newTidbCluster("deleted", "deleted-uid"))},
+		// not controlled by any CR
+		&corev1.Secret{ObjectMeta: newObjectMeta("secret", nil)},
+		// owned by a TidbCluster which is deleted and recreated with the same name
+		&appsv1.StatefulSet{ObjectMeta: newObjectMeta("sts", newTidbCluster("recreated", "old-uid"))},
+	}
+	kubeInformerFactory := deps.KubeInformerFactory
+	indexers := map[string]func(obj interface{}) error{
+		"svc":    kubeInformerFactory.Core().V1().Services().Informer().GetIndexer().Add,
+		"cm":     deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer().Add,
+		"secret": kubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add,
+		"sts":    kubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add,
+	}
+	for _, obj := range objs {
+		g.Expect(indexers[obj.GetName()](obj)).To(Succeed())
+		g.Expect(deps.GenericClient.Create(context.TODO(), obj.DeepCopyObject().(client.Object))).To(Succeed())
+	}
+	return NewController(deps)
+}
+
+func TestFindOrphans(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	c := newFakeController(g, controller.OrphanSweepPolicyReport)
+	orphans, err := c.findOrphans()
+	g.Expect(err).To(Succeed())
+
+	names := []string{}
+	for _, o := range orphans {
+		names = append(names, o.kind+"/"+o.obj.GetName())
+	}
+	sort.Strings(names)
+	g.Expect(names).To(Equal([]string{"ConfigMap/cm", "StatefulSet/sts"}))
+}
+
+func TestSweep(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		deleted bool
+	}{
+		{
+			name:    "report only",
+			policy:  controller.OrphanSweepPolicyReport,
+			deleted: false,
+		},
+		{
+			name:    "delete",
+			policy:  controller.OrphanSweepPolicyDelete,
+			deleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			c := newFakeController(g, tt.policy)
+			c.sweep()
+
+			key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "cm"}
+			err := c.deps.GenericClient.Get(context.TODO(), key, &corev1.ConfigMap{})
+			if tt.deleted {
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			// the resources with an existing owner are never deleted
+			key.Name = "svc"
+			g.Expect(c.deps.GenericClient.Get(context.TODO(), key, &corev1.Service{})).To(Succeed())
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	utildiscovery "github.com/pingcap/tidb-operator/pkg/util/discovery"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pdbV1GVK is the PodDisruptionBudget of policy/v1, it's served since Kubernetes 1.21
+// and policy/v1beta1 is not served since Kubernetes 1.25
+var pdbV1GVK = schema.GroupVersionKind{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget"}
+
+// PDBControlInterface manages the PodDisruptionBudgets by policy/v1 if it is served, and by policy/v1beta1
+// otherwise. The PodDisruptionBudgets are passed as policy/v1beta1 objects as the fields used by the operator
+// are the same in both versions.
+type PDBControlInterface interface {
+	// GetPDB returns the PodDisruptionBudget, a NotFound error is returned if it does not exist or
+	// the PodDisruptionBudget API is not served
+	GetPDB(namespace, name string) (*policyv1beta1.PodDisruptionBudget, error)
+	CreateOrUpdatePDB(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) error
+	DeletePDB(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) error
+}
+
+type realPDBControl struct {
+	discoveryCli discovery.DiscoveryInterface
+	cli          client.Client
+	typed        TypedControlInterface
+
+	mutex sync.Mutex
+	// v1 is whether policy/v1 is served, it is checked by discovery at the first use
+	v1 *bool
+}
+
+// NewRealPDBControl creates a new PDBControlInterface
+func NewRealPDBControl(discoveryCli discovery.DiscoveryInterface, cli client.Client, typed TypedControlInterface) PDBControlInterface {
+	return &realPDBControl{
+		discoveryCli: discoveryCli,
+		cli:          cli,
+		typed:        typed,
+	}
+}
+
+func (c *realPDBControl) useV1() (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.v1 == nil {
+		supported, err := utildiscovery.IsAPIGroupVersionResourceSupported(c.discoveryCli, pdbV1GVK.GroupVersion().String(), "poddisruptionbudgets")
+		if err != nil {
+			return false, fmt.Errorf("failed to check resource policy/v1/poddisruptionbudgets: %v", err)
+		}
+		c.v1 = &supported
+	}
+	return *c.v1, nil
+}
+
+func (c *realPDBControl) GetPDB(namespace, name string) (*policyv1beta1.PodDisruptionBudget, error) {
+	v1, err := c.useV1()
+	if err != nil {
+		return nil, err
+	}
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	if !v1 {
+		err = c.cli.Get(context.TODO(), key, pdb)
+	} else {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(pdbV1GVK)
+		if err = c.cli.Get(context.TODO(), key, u); err == nil {
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pdb)
+		}
+	}
+	if meta.IsNoMatchError(err) {
+		return nil, errors.NewNotFound(policyv1beta1.Resource("poddisruptionbudgets"), name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pdb, nil
+}
+
+func (c *realPDBControl) CreateOrUpdatePDB(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) error {
+	v1, err := c.useV1()
+	if err != nil {
+		return err
+	}
+	if !v1 {
+		_, err := c.typed.CreateOrUpdatePodDisruptionBudget(controller, pdb)
+		return err
+	}
+
+	desired, err := toPDBV1(pdb)
+	if err != nil {
+		return err
+	}
+	if err := setControllerReference(controller, desired); err != nil {
+		return err
+	}
+	err = c.cli.Create(context.TODO(), desired)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(pdbV1GVK)
+	if err := c.cli.Get(context.TODO(), client.ObjectKeyFromObject(desired), existing); err != nil {
+		return err
+	}
+	if err := setControllerReference(controller, existing); err != nil {
+		return err
+	}
+	// only the fields controlled by the operator are merged, the same as policy/v1beta1
+	existing.SetLabels(desired.GetLabels())
+	for _, field := range []string{"selector", "maxUnavailable", "minAvailable"} {
+		value, found, err := unstructured.NestedFieldNoCopy(desired.Object, "spec", field)
+		if err != nil {
+			return err
+		}
+		if found {
+			err = unstructured.SetNestedField(existing.Object, value, "spec", field)
+		} else {
+			unstructured.RemoveNestedField(existing.Object, "spec", field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return c.cli.Update(context.TODO(), existing)
+}
+
+func (c *realPDBControl) DeletePDB(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) error {
+	v1, err := c.useV1()
+	if err != nil {
+		return err
+	}
+	if !v1 {
+		return c.typed.Delete(controller, pdb)
+	}
+	u, err := toPDBV1(pdb)
+	if err != nil {
+		return err
+	}
+	return c.cli.Delete(context.TODO(), u)
+}
+
+func toPDBV1(pdb *policyv1beta1.PodDisruptionBudget) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pdb.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(pdbV1GVK)
+	unstructured.RemoveNestedField(u.Object, "status")
+	return u, nil
+}
+
+var _ PDBControlInterface = &realPDBControl{}
+
+// NewFakePDBControl returns a PDBControlInterface which manages the PodDisruptionBudgets of policy/v1beta1
+// by the fake client of the generic control
+func NewFakePDBControl(genericCtrl *FakeGenericControl) PDBControlInterface {
+	v1 := false
+	return &realPDBControl{
+		cli:   genericCtrl.FakeCli,
+		typed: NewTypedControl(genericCtrl),
+		v1:    &v1,
+	}
+}
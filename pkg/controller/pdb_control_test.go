@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPDBControlPolicyV1beta1(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbCluster()
+	genericCtrl := NewFakeGenericControl()
+	control := NewFakePDBControl(genericCtrl)
+
+	_, err := control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	g.Expect(control.CreateOrUpdatePDB(tc, newPDBForTest(tc, 1))).To(Succeed())
+	g.Expect(control.CreateOrUpdatePDB(tc, newPDBForTest(tc, 2))).To(Succeed())
+	pdb, err := control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(2))
+	g.Expect(metav1.IsControlledBy(pdb, tc)).To(BeTrue())
+
+	g.Expect(control.DeletePDB(tc, pdb)).To(Succeed())
+	_, err = control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestPDBControlPolicyV1(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbCluster()
+	genericCtrl := NewFakeGenericControl()
+	v1 := true
+	control := &realPDBControl{cli: genericCtrl.FakeCli, typed: NewTypedControl(genericCtrl), v1: &v1}
+
+	_, err := control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	g.Expect(control.CreateOrUpdatePDB(tc, newPDBForTest(tc, 1))).To(Succeed())
+	g.Expect(control.CreateOrUpdatePDB(tc, newPDBForTest(tc, 2))).To(Succeed())
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(pdbV1GVK)
+	g.Expect(genericCtrl.FakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: "demo-tikv"}, u)).To(Succeed())
+	g.Expect(u.GetAPIVersion()).To(Equal("policy/v1"))
+	maxUnavailable, _, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "maxUnavailable")
+	g.Expect(maxUnavailable).To(BeEquivalentTo(2))
+
+	pdb, err := control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(2))
+	g.Expect(metav1.IsControlledBy(pdb, tc)).To(BeTrue())
+
+	g.Expect(control.DeletePDB(tc, pdb)).To(Succeed())
+	_, err = control.GetPDB(tc.Namespace, "demo-tikv")
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func newPDBForTest(tc metav1.Object, maxUnavailable int) *policyv1beta1.PodDisruptionBudget {
+	value := intstr.FromInt(maxUnavailable)
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tc.GetName() + "-tikv",
+			Namespace: tc.GetNamespace(),
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &value,
+		},
+	}
+}
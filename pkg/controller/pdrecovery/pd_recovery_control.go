@@ -0,0 +1,644 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdrecovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/kubernetes/pkg/util/slice"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// pdRecoveryInvalid is the event reason when the PDRecovery is invalid or refused
+	pdRecoveryInvalid = "Invalid"
+	// pdRecoveryStepStarted is the event reason when a step is started
+	pdRecoveryStepStarted = "StepStarted"
+	// pdRecoveryCompleted is the event reason when PD is rebuilt
+	pdRecoveryCompleted = "Completed"
+	// pdRecoveryFailed is the event reason when a step failed
+	pdRecoveryFailed = "Failed"
+
+	// defaultAllocIDMargin is added to the largest known ID to get the default alloc ID, as the
+	// IDs of the regions allocated by the lost PD are unknown
+	defaultAllocIDMargin = 100000000
+)
+
+// ControlInterface abstracts the business logic for PDRecovery reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.PDRecovery) error
+}
+
+// NewPDRecoveryControl returns a ControlInterface which rebuilds PD from the surviving TiKV stores step by step
+func NewPDRecoveryControl(deps *controller.Dependencies, lister listers.PDRecoveryLister) ControlInterface {
+	return &defaultPDRecoveryControl{
+		deps:   deps,
+		lister: lister,
+		now:    time.Now,
+	}
+}
+
+type defaultPDRecoveryControl struct {
+	deps   *controller.Dependencies
+	lister listers.PDRecoveryLister
+	now    func() time.Time
+}
+
+func (c *defaultPDRecoveryControl) Reconcile(r *v1alpha1.PDRecovery) error {
+	// the lock of the failed recovery is kept until the PDRecovery is deleted, as the cluster
+	// must be checked manually before it is synced again
+	if r.DeletionTimestamp != nil || r.Status.Phase == v1alpha1.PDRecoveryWorkflowPhaseComplete {
+		return c.release(r)
+	}
+	if r.Status.Phase == v1alpha1.PDRecoveryWorkflowPhaseFailed {
+		return nil
+	}
+	if err := c.addProtectionFinalizer(r); err != nil {
+		return err
+	}
+
+	oldStatus := r.Status.DeepCopy()
+	err := c.reconcile(r)
+
+	if !apiequality.Semantic.DeepEqual(&r.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(r.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	switch r.Status.Phase {
+	case v1alpha1.PDRecoveryWorkflowPhaseComplete:
+		return c.release(r)
+	case v1alpha1.PDRecoveryWorkflowPhaseRunning:
+		return controller.RequeueErrorf("PDRecovery: [%s/%s] is running step %s", r.Namespace, r.Name, r.Status.Step)
+	}
+	// the recovery waiting for the confirmation continues after the spec is updated
+	return nil
+}
+
+func (c *defaultPDRecoveryControl) reconcile(r *v1alpha1.PDRecovery) error {
+	ns := r.GetNamespace()
+	name := r.GetName()
+
+	if errs := validation.ValidatePDRecovery(r); len(errs) > 0 {
+		c.fail(r, pdRecoveryInvalid, errs.ToAggregate().Error())
+		return nil
+	}
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(r.Spec.Cluster)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			c.fail(r, pdRecoveryInvalid, fmt.Sprintf("tidbcluster %s/%s is not found", ns, r.Spec.Cluster))
+			return nil
+		}
+		return fmt.Errorf("PDRecovery: [%s/%s], get tidbcluster %s failed, err: %v", ns, name, r.Spec.Cluster, err)
+	}
+
+	if r.Status.Step == "" {
+		r.Status.Step = v1alpha1.PDRecoveryStepCheck
+	}
+	step := r.Status.Step
+	st := r.GetStepStatus(step)
+	if st == nil {
+		if !r.IsStepConfirmed(step) {
+			r.Status.Phase = v1alpha1.PDRecoveryWorkflowPhaseWaitingForConfirmation
+			r.Status.Message = fmt.Sprintf("step %s waits for the confirmation in spec.confirmedSteps, cluster ID: %s, alloc ID: %d",
+				step, r.Status.ClusterID, r.Status.AllocID)
+			return nil
+		}
+		r.Status.Steps = append(r.Status.Steps, v1alpha1.PDRecoveryStepStatus{Step: step, StartTime: metav1.NewTime(c.now())})
+		st = &r.Status.Steps[len(r.Status.Steps)-1]
+		klog.Infof("PDRecovery: [%s/%s], step %s is started", ns, name, step)
+		c.deps.Recorder.Eventf(r, corev1.EventTypeNormal, pdRecoveryStepStarted, "step %s is started", step)
+	}
+	r.Status.Phase = v1alpha1.PDRecoveryWorkflowPhaseRunning
+
+	var done bool
+	switch step {
+	case v1alpha1.PDRecoveryStepCheck:
+		done, err = c.check(r, tc, st)
+	case v1alpha1.PDRecoveryStepRebuildPD:
+		done, err = c.rebuildPD(r, tc, st)
+	case v1alpha1.PDRecoveryStepRecoverPD:
+		done, err = c.recoverPD(r, tc, st)
+	case v1alpha1.PDRecoveryStepRejoinStores:
+		done, err = c.rejoinStores(r, tc, st)
+	case v1alpha1.PDRecoveryStepRestartTiDB:
+		done, err = c.restartTiDB(r, tc, st)
+	default:
+		c.fail(r, pdRecoveryInvalid, fmt.Sprintf("unknown step %s", step))
+		return nil
+	}
+	r.Status.Message = st.Message
+	if err != nil || !done || r.Status.Phase == v1alpha1.PDRecoveryWorkflowPhaseFailed {
+		return err
+	}
+
+	now := metav1.NewTime(c.now())
+	st.CompletionTime = &now
+	klog.Infof("PDRecovery: [%s/%s], step %s is completed: %s", ns, name, step, st.Message)
+	next, ok := v1alpha1.NextPDRecoveryStep(step)
+	if !ok {
+		r.Status.Phase = v1alpha1.PDRecoveryWorkflowPhaseComplete
+		r.Status.Message = fmt.Sprintf("pd of tidbcluster %s is rebuilt with cluster ID %s", tc.Name, r.Status.ClusterID)
+		klog.Infof("PDRecovery: [%s/%s], %s", ns, name, r.Status.Message)
+		c.deps.Recorder.Event(r, corev1.EventTypeNormal, pdRecoveryCompleted, r.Status.Message)
+		return nil
+	}
+	r.Status.Step = next
+	return nil
+}
+
+// check confirms the data of PD is lost while the TiKV stores survive, resolves the cluster ID and
+// the alloc ID, and locks the tidb cluster so that it is not synced during the recovery
+func (c *defaultPDRecoveryControl) check(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster, st *v1alpha1.PDRecoveryStepStatus) (bool, error) {
+	if tc.Spec.PD == nil || tc.Spec.TiKV == nil || tc.AcrossK8s() || tc.Heterogeneous() || tc.Spec.ClusterDomain != "" || len(tc.Status.PD.PeerMembers) > 0 {
+		c.fail(r, pdRecoveryInvalid, "pd recovery is only supported for the pd and tikv members in a single tidb cluster")
+		return false, nil
+	}
+	if lock := tc.Annotations[label.AnnPDRecoveryLockKey]; lock != "" && lock != r.Name {
+		c.fail(r, pdRecoveryInvalid, fmt.Sprintf("pd of tidbcluster %s is being rebuilt by PDRecovery %s", tc.Name, lock))
+		return false, nil
+	}
+	if survivor := tc.Annotations[label.AnnPDRecoverFromKey]; survivor != "" {
+		c.fail(r, pdRecoveryInvalid, fmt.Sprintf("pd of tidbcluster %s is being recovered from %s", tc.Name, survivor))
+		return false, nil
+	}
+
+	clusterID := r.Spec.ClusterID
+	if clusterID == "" {
+		clusterID = tc.Status.ClusterID
+	}
+	if clusterID == "" {
+		c.fail(r, pdRecoveryInvalid, fmt.Sprintf("the cluster ID of tidbcluster %s is unknown, set it by spec.clusterID", tc.Name))
+		return false, nil
+	}
+	allocID := r.Spec.AllocID
+	if allocID == 0 {
+		known := tc
+		if tc.StatusCompacted() {
+			// the stores moved out of the status by the status compaction are counted too
+			cm, err := c.deps.ConfigMapLister.ConfigMaps(tc.Namespace).Get(tc.FullStatusConfigMapName())
+			if errors.IsNotFound(err) {
+				c.fail(r, pdRecoveryInvalid, fmt.Sprintf("the stores of the compacted status of tidbcluster %s are unknown, set the alloc ID by spec.allocID", tc.Name))
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			known = tc.DeepCopy()
+			if err := known.RestoreCompactedStatus(cm); err != nil {
+				return false, err
+			}
+		}
+		allocID = maxKnownID(known) + defaultAllocIDMargin
+	}
+
+	// PD serving the cluster ID has the data of the cluster, the PD of a new cluster is bootstrapped
+	// when the volumes of all the members are lost
+	if cluster, err := controller.GetPDClient(c.deps.PDControl, tc).GetCluster(); err == nil && strconv.FormatUint(cluster.Id, 10) == clusterID {
+		c.fail(r, pdRecoveryInvalid, fmt.Sprintf("pd of tidbcluster %s is serving cluster %s, the data of pd is not lost", tc.Name, clusterID))
+		return false, nil
+	}
+
+	if err := c.lock(tc, r.Name); err != nil {
+		return false, err
+	}
+	r.Status.ClusterID = clusterID
+	r.Status.AllocID = allocID
+	st.Message = fmt.Sprintf("tidbcluster %s is locked, cluster ID: %s, alloc ID: %d", tc.Name, clusterID, allocID)
+	return true, nil
+}
+
+// rebuildPD wipes the volumes of all the PD members and waits for a new PD cluster to be bootstrapped
+func (c *defaultPDRecoveryControl) rebuildPD(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster, st *v1alpha1.PDRecoveryStepStatus) (bool, error) {
+	ns := tc.GetNamespace()
+
+	// the discovery bootstraps the PD cluster only if no member is known in the stateless mode
+	if len(tc.Status.PD.Members) > 0 {
+		newStatus := tc.Status.DeepCopy()
+		newStatus.PD.Members = nil
+		if _, err := c.deps.TiDBClusterControl.UpdateTidbCluster(tc.DeepCopy(), newStatus, &tc.Status); err != nil {
+			return false, err
+		}
+	}
+
+	selector, err := label.New().Instance(tc.Name).PD().Selector()
+	if err != nil {
+		return false, err
+	}
+	pvcs, err := c.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("PDRecovery: [%s/%s], list pd pvcs failed, err: %v", r.Namespace, r.Name, err)
+	}
+	// the PVCs recreated after the step starts are empty
+	for _, pvc := range pvcs {
+		if pvc.DeletionTimestamp != nil || !pvc.CreationTimestamp.Before(&st.StartTime) {
+			continue
+		}
+		if err := c.deps.PVCControl.DeletePVC(tc, pvc); err != nil {
+			return false, err
+		}
+	}
+	ready, err := c.restartPods(tc, label.New().Instance(tc.Name).PD(), tc.PDStsDesiredReplicas(), st.StartTime.Time)
+	if err != nil {
+		return false, err
+	}
+	if !ready {
+		st.Message = "waiting for the pd members to be recreated with empty volumes"
+		return false, nil
+	}
+
+	id, healthy := c.getPDCluster(tc)
+	if !healthy || id == r.Status.ClusterID {
+		st.Message = "waiting for a new pd cluster to be bootstrapped"
+		return false, nil
+	}
+	st.Message = fmt.Sprintf("a new pd cluster %s is bootstrapped", id)
+	return true, nil
+}
+
+// recoverPD runs pd-recover to restore the cluster ID and the alloc ID, then restarts the PD members to apply them
+func (c *defaultPDRecoveryControl) recoverPD(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster, st *v1alpha1.PDRecoveryStepStatus) (bool, error) {
+	ns := r.GetNamespace()
+	jobName := r.GetPDRecoverJobName()
+
+	job, err := c.deps.JobLister.Jobs(ns).Get(jobName)
+	if errors.IsNotFound(err) {
+		if err := c.deps.JobControl.CreateJob(r, c.makePDRecoverJob(r, tc)); err != nil {
+			return false, fmt.Errorf("PDRecovery: [%s/%s], create job %s failed, err: %v", ns, r.Name, jobName, err)
+		}
+		st.Message = fmt.Sprintf("pd-recover job %s is created", jobName)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("PDRecovery: [%s/%s], get job %s failed, err: %v", ns, r.Name, jobName, err)
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			c.fail(r, pdRecoveryFailed, fmt.Sprintf("pd-recover job %s failed: %s", jobName, cond.Message))
+			return false, nil
+		}
+	}
+	if job.Status.Succeeded == 0 || job.Status.CompletionTime == nil {
+		st.Message = fmt.Sprintf("waiting for pd-recover job %s to complete", jobName)
+		return false, nil
+	}
+
+	ready, err := c.restartPods(tc, label.New().Instance(tc.Name).PD(), tc.PDStsDesiredReplicas(), job.Status.CompletionTime.Time)
+	if err != nil {
+		return false, err
+	}
+	id, healthy := c.getPDCluster(tc)
+	if !ready || !healthy || id != r.Status.ClusterID {
+		st.Message = "waiting for the pd members to be restarted with the recovered cluster ID"
+		return false, nil
+	}
+	st.Message = fmt.Sprintf("pd is recovered with cluster ID %s and alloc ID %d", id, r.Status.AllocID)
+	return true, nil
+}
+
+// rejoinStores restarts the TiKV and TiFlash stores and waits for them to be up in the recovered PD
+func (c *defaultPDRecoveryControl) rejoinStores(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster, st *v1alpha1.PDRecoveryStepStatus) (bool, error) {
+	ready, err := c.restartPods(tc, label.New().Instance(tc.Name).TiKV(), tc.TiKVStsDesiredReplicas(), st.StartTime.Time)
+	if err != nil {
+		return false, err
+	}
+	replicas := tc.TiKVStsDesiredReplicas()
+	if tc.Spec.TiFlash != nil {
+		tiflashReady, err := c.restartPods(tc, label.New().Instance(tc.Name).TiFlash(), tc.TiFlashStsDesiredReplicas(), st.StartTime.Time)
+		if err != nil {
+			return false, err
+		}
+		ready = ready && tiflashReady
+		replicas += tc.TiFlashStsDesiredReplicas()
+	}
+
+	up := 0
+	if stores, err := controller.GetPDClient(c.deps.PDControl, tc).GetStores(); err == nil {
+		for _, store := range stores.Stores {
+			if store.Store != nil && store.Store.StateName == v1alpha1.TiKVStateUp {
+				up++
+			}
+		}
+	}
+	st.Message = fmt.Sprintf("%d of %d stores are up", up, replicas)
+	return ready && up >= int(replicas), nil
+}
+
+// restartTiDB restarts the TiDB members to reload the schema from the recovered cluster
+func (c *defaultPDRecoveryControl) restartTiDB(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster, st *v1alpha1.PDRecoveryStepStatus) (bool, error) {
+	if tc.Spec.TiDB == nil {
+		st.Message = "tidb is not deployed"
+		return true, nil
+	}
+	ready, err := c.restartPods(tc, label.New().Instance(tc.Name).TiDB(), tc.TiDBStsDesiredReplicas(), st.StartTime.Time)
+	if err != nil {
+		return false, err
+	}
+	if !ready {
+		st.Message = "waiting for the tidb members to be restarted"
+		return false, nil
+	}
+	st.Message = "the tidb members are restarted"
+	return true, nil
+}
+
+// restartPods deletes the pods of the component created before the time, it returns whether the
+// desired number of pods are recreated and ready
+func (c *defaultPDRecoveryControl) restartPods(tc *v1alpha1.TidbCluster, l label.Label, replicas int32, before time.Time) (bool, error) {
+	selector, err := l.Selector()
+	if err != nil {
+		return false, err
+	}
+	pods, err := c.deps.PodLister.Pods(tc.Namespace).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("list pods of tidbcluster %s/%s failed, err: %v", tc.Namespace, tc.Name, err)
+	}
+	ready := int32(0)
+	for _, pod := range pods {
+		if pod.CreationTimestamp.Time.Before(before) {
+			if pod.DeletionTimestamp == nil {
+				if err := c.deps.PodControl.DeletePod(tc, pod); err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+		if pod.DeletionTimestamp == nil && podutil.IsPodReady(pod) {
+			ready++
+		}
+	}
+	return ready >= replicas, nil
+}
+
+// getPDCluster returns the cluster ID served by PD and whether all the PD members are healthy
+func (c *defaultPDRecoveryControl) getPDCluster(tc *v1alpha1.TidbCluster) (string, bool) {
+	pdClient := controller.GetPDClient(c.deps.PDControl, tc)
+	health, err := pdClient.GetHealth()
+	if err != nil || len(health.Healths) == 0 {
+		return "", false
+	}
+	for _, member := range health.Healths {
+		if !member.Health {
+			return "", false
+		}
+	}
+	cluster, err := pdClient.GetCluster()
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatUint(cluster.Id, 10), true
+}
+
+func (c *defaultPDRecoveryControl) makePDRecoverJob(r *v1alpha1.PDRecovery, tc *v1alpha1.TidbCluster) *batchv1.Job {
+	ns := r.GetNamespace()
+	args := []string{
+		"-endpoints", fmt.Sprintf("%s://%s.%s:2379", tc.Scheme(), controller.PDMemberName(tc.Name), ns),
+		"-cluster-id", r.Status.ClusterID,
+		"-alloc-id", strconv.FormatUint(r.Status.AllocID, 10),
+	}
+
+	volumeMounts := []corev1.VolumeMount{}
+	volumes := []corev1.Volume{}
+	if tc.IsTLSClusterEnabled() {
+		args = append(args,
+			"-cacert", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey),
+			"-cert", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey),
+			"-key", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey),
+		)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      util.ClusterClientVolName,
+			ReadOnly:  true,
+			MountPath: util.ClusterClientTLSPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: util.ClusterClientVolName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterClientTLSSecretName(tc.Name),
+				},
+			},
+		})
+	}
+
+	basePDSpec := tc.BasePDSpec()
+	jobLabels := util.CombineStringMap(label.NewPDRecovery().Instance(tc.Name).Labels(), r.Labels)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.GetPDRecoverJobName(),
+			Namespace: ns,
+			Labels:    jobLabels,
+			OwnerReferences: []metav1.OwnerReference{
+				controller.GetPDRecoveryOwnerRef(r),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            label.PDRecoverJobLabelVal,
+							Image:           tc.PDImage(),
+							Command:         []string{"/pd-recover"},
+							Args:            args,
+							ImagePullPolicy: basePDSpec.ImagePullPolicy(),
+							VolumeMounts:    volumeMounts,
+							Resources:       r.Spec.Resources,
+						},
+					},
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: basePDSpec.ImagePullSecrets(),
+					NodeSelector:     basePDSpec.NodeSelector(),
+					Tolerations:      basePDSpec.Tolerations(),
+					Volumes:          volumes,
+				},
+			},
+		},
+	}
+}
+
+// maxKnownID returns the largest ID of the stores and the PD members recorded in the status of the tidb cluster
+func maxKnownID(tc *v1alpha1.TidbCluster) uint64 {
+	var max uint64
+	update := func(id string) {
+		if v, err := strconv.ParseUint(id, 10, 64); err == nil && v > max {
+			max = v
+		}
+	}
+	for _, stores := range []map[string]v1alpha1.TiKVStore{
+		tc.Status.TiKV.Stores, tc.Status.TiKV.TombstoneStores,
+		tc.Status.TiFlash.Stores, tc.Status.TiFlash.TombstoneStores,
+	} {
+		for _, store := range stores {
+			update(store.ID)
+		}
+	}
+	for _, member := range tc.Status.PD.Members {
+		update(member.ID)
+	}
+	return max
+}
+
+// lock sets the lock annotation on the tidb cluster, which blocks the sync of the tidb cluster
+func (c *defaultPDRecoveryControl) lock(tc *v1alpha1.TidbCluster, name string) error {
+	if tc.Annotations[label.AnnPDRecoveryLockKey] == name {
+		return nil
+	}
+	return c.patchLock(tc, &name)
+}
+
+func (c *defaultPDRecoveryControl) patchLock(tc *v1alpha1.TidbCluster, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{label.AnnPDRecoveryLockKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Patch(context.TODO(), tc.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patch annotation %s of tidbcluster %s/%s failed, err: %v", label.AnnPDRecoveryLockKey, tc.Namespace, tc.Name, err)
+	}
+	return nil
+}
+
+func (c *defaultPDRecoveryControl) fail(r *v1alpha1.PDRecovery, reason, msg string) {
+	klog.Errorf("PDRecovery: [%s/%s], %s: %s", r.Namespace, r.Name, reason, msg)
+	c.deps.Recorder.Event(r, corev1.EventTypeWarning, reason, msg)
+	r.Status.Phase = v1alpha1.PDRecoveryWorkflowPhaseFailed
+	r.Status.Message = msg
+	if st := r.GetStepStatus(r.Status.Step); st != nil {
+		st.Message = msg
+	}
+}
+
+// addProtectionFinalizer adds the finalizer so that the lock of the tidb cluster is released before the CR is deleted
+func (c *defaultPDRecoveryControl) addProtectionFinalizer(r *v1alpha1.PDRecovery) error {
+	if slice.ContainsString(r.Finalizers, label.PDRecoveryProtectionFinalizer, nil) {
+		return nil
+	}
+	r.Finalizers = append(r.Finalizers, label.PDRecoveryProtectionFinalizer)
+	updated, err := c.deps.Clientset.PingcapV1alpha1().PDRecoveries(r.Namespace).Update(context.TODO(), r, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("add pd recovery %s/%s protection finalizers failed, err: %v", r.Namespace, r.Name, err)
+	}
+	updated.DeepCopyInto(r)
+	return nil
+}
+
+// release removes the lock of the tidb cluster held by the PDRecovery and then removes the finalizer
+func (c *defaultPDRecoveryControl) release(r *v1alpha1.PDRecovery) error {
+	ns := r.GetNamespace()
+	name := r.GetName()
+
+	if !slice.ContainsString(r.Finalizers, label.PDRecoveryProtectionFinalizer, nil) {
+		return nil
+	}
+
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(r.Spec.Cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("PDRecovery: [%s/%s], get tidbcluster %s failed, err: %v", ns, name, r.Spec.Cluster, err)
+	}
+	if err == nil && tc.Annotations[label.AnnPDRecoveryLockKey] == name {
+		if err := c.patchLock(tc, nil); err != nil {
+			return err
+		}
+		klog.Infof("PDRecovery: [%s/%s], the lock of tidbcluster %s is released", ns, name, tc.Name)
+	}
+
+	r.Finalizers = slice.RemoveString(r.Finalizers, label.PDRecoveryProtectionFinalizer, nil)
+	if _, err := c.deps.Clientset.PingcapV1alpha1().PDRecoveries(ns).Update(context.TODO(), r, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("remove pd recovery %s/%s protection finalizers failed, err: %v", ns, name, err)
+	}
+	return nil
+}
+
+func (c *defaultPDRecoveryControl) updateStatus(r *v1alpha1.PDRecovery) (*v1alpha1.PDRecovery, error) {
+	var (
+		ns     = r.GetNamespace()
+		name   = r.GetName()
+		status = r.Status.DeepCopy()
+		update *v1alpha1.PDRecovery
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().PDRecoveries(ns).UpdateStatus(context.TODO(), r, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.V(4).Infof("PDRecovery: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("PDRecovery: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		if updated, err := c.lister.PDRecoveries(ns).Get(name); err == nil {
+			r = updated.DeepCopy()
+			r.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated PDRecovery %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("PDRecovery: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+type FakePDRecoveryControl struct {
+	reconcile func(*v1alpha1.PDRecovery) error
+}
+
+func (c *FakePDRecoveryControl) MockReconcile(reconcile func(*v1alpha1.PDRecovery) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakePDRecoveryControl) Reconcile(r *v1alpha1.PDRecovery) error {
+	if c.reconcile != nil {
+		return c.reconcile(r)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdrecovery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPDRecoveryControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	informer := deps.InformerFactory.Pingcap().V1alpha1().PDRecoveries()
+	control := NewPDRecoveryControl(deps, informer.Lister()).(*defaultPDRecoveryControl)
+	now := time.Now()
+	control.now = func() time.Time { return now }
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{Replicas: 1},
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 1},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			ClusterID: "100",
+			PD:        v1alpha1.PDStatus{Members: map[string]v1alpha1.PDMember{"tc-pd-0": {Name: "tc-pd-0", ID: "2"}}},
+			TiKV:      v1alpha1.TiKVStatus{Stores: map[string]v1alpha1.TiKVStore{"1": {ID: "1"}, "7": {ID: "7"}}},
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	addPod := func(name string, l label.Label, created time.Time) {
+		g.Expect(podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         corev1.NamespaceDefault,
+				Labels:            l.Labels(),
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+		})).To(Succeed())
+	}
+	podExists := func(name string) bool {
+		_, err := deps.PodLister.Pods(corev1.NamespaceDefault).Get(name)
+		return err == nil
+	}
+	addPod("tc-pd-0", label.New().Instance("tc").PD(), now.Add(-time.Hour))
+	addPod("tc-tikv-0", label.New().Instance("tc").TiKV(), now.Add(-time.Hour))
+	g.Expect(deps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "pd-tc-pd-0",
+			Namespace:         corev1.NamespaceDefault,
+			Labels:            label.New().Instance("tc").PD().Labels(),
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+	})).To(Succeed())
+
+	var cluster *metapb.Cluster
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		if cluster == nil {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return cluster, nil
+	})
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{{Name: "tc-pd-0", Health: true}}}, nil
+	})
+	pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{
+			{Store: &pdapi.MetaStore{Store: &metapb.Store{Id: 1}, StateName: v1alpha1.TiKVStateUp}},
+		}}, nil
+	})
+
+	r := &v1alpha1.PDRecovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "rebuild", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.PDRecoverySpec{Cluster: "tc"},
+	}
+	_, err = deps.Clientset.PingcapV1alpha1().PDRecoveries(r.Namespace).Create(context.TODO(), r, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(informer.Informer().GetIndexer().Add(r)).To(Succeed())
+
+	// the cluster ID and the alloc ID are resolved and the tidb cluster is locked
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(r.Status.Step).To(Equal(v1alpha1.PDRecoveryStepRebuildPD))
+	g.Expect(r.Status.ClusterID).To(Equal("100"))
+	g.Expect(r.Status.AllocID).To(Equal(uint64(100000007)))
+	g.Expect(r.Finalizers).To(ContainElement(label.PDRecoveryProtectionFinalizer))
+	locked, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(context.TODO(), tc.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(locked.Annotations[label.AnnPDRecoveryLockKey]).To(Equal("rebuild"))
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Update(locked)).To(Succeed())
+
+	// the volumes of PD are not wiped until the step is confirmed
+	g.Expect(control.Reconcile(r)).To(Succeed())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.PDRecoveryWorkflowPhaseWaitingForConfirmation))
+	g.Expect(r.Status.Message).To(ContainSubstring("RebuildPD"))
+	g.Expect(podExists("tc-pd-0")).To(BeTrue())
+
+	r.Spec.ConfirmedSteps = []v1alpha1.PDRecoveryStep{v1alpha1.PDRecoveryStepRebuildPD, v1alpha1.PDRecoveryStepRejoinStores}
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.PDRecoveryWorkflowPhaseRunning))
+	g.Expect(podExists("tc-pd-0")).To(BeFalse())
+	_, err = deps.PVCLister.PersistentVolumeClaims(corev1.NamespaceDefault).Get("pd-tc-pd-0")
+	g.Expect(err).To(HaveOccurred())
+
+	// a new PD cluster is bootstrapped and recovered by pd-recover
+	addPod("tc-pd-0", label.New().Instance("tc").PD(), now.Add(time.Minute))
+	cluster = &metapb.Cluster{Id: 200}
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(r.Status.Step).To(Equal(v1alpha1.PDRecoveryStepRecoverPD))
+
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	job, err := deps.JobLister.Jobs(corev1.NamespaceDefault).Get(r.GetPDRecoverJobName())
+	g.Expect(err).To(Succeed())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
+		"-endpoints", "http://tc-pd.default:2379", "-cluster-id", "100", "-alloc-id", "100000007",
+	}))
+	g.Expect(job.OwnerReferences[0].Kind).To(Equal(v1alpha1.PDRecoveryKind))
+
+	job = job.DeepCopy()
+	job.Status.Succeeded = 1
+	completed := metav1.NewTime(now.Add(2 * time.Minute))
+	job.Status.CompletionTime = &completed
+	g.Expect(deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer().Update(job)).To(Succeed())
+	cluster = &metapb.Cluster{Id: 100}
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(podExists("tc-pd-0")).To(BeFalse())
+	g.Expect(r.Status.Step).To(Equal(v1alpha1.PDRecoveryStepRecoverPD))
+
+	addPod("tc-pd-0", label.New().Instance("tc").PD(), now.Add(3*time.Minute))
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(r.Status.Step).To(Equal(v1alpha1.PDRecoveryStepRejoinStores))
+
+	// the stores rejoin the recovered PD after they are restarted
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(podExists("tc-tikv-0")).To(BeFalse())
+	g.Expect(r.Status.Message).To(Equal("1 of 1 stores are up"))
+
+	addPod("tc-tikv-0", label.New().Instance("tc").TiKV(), now.Add(time.Minute))
+	err = control.Reconcile(r)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(r.Status.Step).To(Equal(v1alpha1.PDRecoveryStepRestartTiDB))
+
+	// the lock is released after the recovery is complete
+	g.Expect(control.Reconcile(r)).To(Succeed())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.PDRecoveryWorkflowPhaseComplete))
+	g.Expect(r.Status.Steps).To(HaveLen(len(v1alpha1.PDRecoverySteps)))
+	g.Expect(r.Finalizers).NotTo(ContainElement(label.PDRecoveryProtectionFinalizer))
+	unlocked, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(context.TODO(), tc.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(unlocked.Annotations).NotTo(HaveKey(label.AnnPDRecoveryLockKey))
+}
+
+func TestPDRecoveryControlRefusesServingPD(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	control := NewPDRecoveryControl(deps, deps.InformerFactory.Pingcap().V1alpha1().PDRecoveries().Lister())
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{Replicas: 1},
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 1},
+		},
+		Status: v1alpha1.TidbClusterStatus{ClusterID: "100"},
+	}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetClusterActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &metapb.Cluster{Id: 100}, nil
+	})
+
+	r := &v1alpha1.PDRecovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "rebuild", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.PDRecoverySpec{Cluster: "tc"},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().PDRecoveries(r.Namespace).Create(context.TODO(), r, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+
+	g.Expect(control.Reconcile(r)).To(Succeed())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.PDRecoveryWorkflowPhaseFailed))
+	g.Expect(r.Status.Message).To(ContainSubstring("the data of pd is not lost"))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -164,6 +165,18 @@ func (c *Controller) updateRestore(cur interface{}) {
 	}
 
 	if v1alpha1.IsRestoreFailed(newRestore) {
+		// the restore job may mark the restore as failed before the controller sees the failed pod,
+		// so the resume policy is checked before the failed restore is skipped
+		if newRestore.Spec.ResumePolicy != nil {
+			pod, err := c.getFailedRestorePod(newRestore)
+			if err != nil {
+				klog.Errorf("Fail to get the failed pod of restore %s/%s, %v", ns, name, err)
+				return
+			}
+			if pod != nil && c.resumeRestoreIfPossible(newRestore, pod) {
+				return
+			}
+		}
 		klog.V(4).Infof("restore %s/%s is Failed, skipping.", ns, name)
 		return
 	}
@@ -199,20 +212,26 @@ func (c *Controller) updateRestore(cur interface{}) {
 		return
 	}
 
+	if v1alpha1.IsRestoreVerifying(newRestore) && newRestore.Status.Verification == nil {
+		klog.V(4).Infof("restore %s/%s is restored and waiting for verification, enqueue", ns, name)
+		c.enqueueRestore(newRestore)
+		return
+	}
+
 	if v1alpha1.IsRestoreScheduled(newRestore) || v1alpha1.IsRestoreRunning(newRestore) {
-		selector, err := label.NewRestore().Instance(newRestore.GetInstanceName()).RestoreJob().Restore(name).Selector()
-		if err != nil {
-			klog.Errorf("Fail to generate selector for restore %s/%s, %v", ns, name, err)
+		if v1alpha1.IsRestoreResuming(newRestore) {
+			klog.V(4).Infof("restore %s/%s is resuming from checkpoint, enqueue", ns, name)
+			c.enqueueRestore(newRestore)
 			return
 		}
-		pods, err := c.deps.PodLister.Pods(ns).List(selector)
+		pod, err := c.getFailedRestorePod(newRestore)
 		if err != nil {
-			klog.Errorf("Fail to list pod for restore %s/%s with selector %s, %v", ns, name, selector, err)
+			klog.Errorf("Fail to get the failed pod of restore %s/%s, %v", ns, name, err)
 			return
 		}
-		for _, pod := range pods {
-			if pod.Status.Phase == corev1.PodFailed {
-				klog.Infof("restore %s/%s has failed pod %s.", ns, name, pod.Name)
+		if pod != nil {
+			klog.Infof("restore %s/%s has failed pod %s.", ns, name, pod.Name)
+			if !c.resumeRestoreIfPossible(newRestore, pod) {
 				err = c.control.UpdateCondition(newRestore, &v1alpha1.RestoreCondition{
 					Type:    v1alpha1.RestoreFailed,
 					Status:  corev1.ConditionTrue,
@@ -222,7 +241,6 @@ func (c *Controller) updateRestore(cur interface{}) {
 				if err != nil {
 					klog.Errorf("Fail to update the condition of restore %s/%s, %v", ns, name, err)
 				}
-				break
 			}
 		}
 		klog.V(4).Infof("restore %s/%s is already Scheduled, Running or Failed, skipping.", ns, name)
@@ -233,6 +251,75 @@ func (c *Controller) updateRestore(cur interface{}) {
 	c.enqueueRestore(newRestore)
 }
 
+// getFailedRestorePod returns the first failed pod of the restore jobs, or nil if no pod has failed.
+func (c *Controller) getFailedRestorePod(restore *v1alpha1.Restore) (*corev1.Pod, error) {
+	selector, err := label.NewRestore().Instance(restore.GetInstanceName()).RestoreJob().Restore(restore.GetName()).Selector()
+	if err != nil {
+		return nil, fmt.Errorf("generate selector failed, %v", err)
+	}
+	pods, err := c.deps.PodLister.Pods(restore.GetNamespace()).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("list pod with selector %s failed, %v", selector, err)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodFailed {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// resumeRestoreIfPossible records a new resume attempt for the failed pod if the resume policy allows,
+// the failed job will be relaunched by restore manager to resume from BR checkpoint.
+// It returns false if the restore should be marked as failed.
+func (c *Controller) resumeRestoreIfPossible(restore *v1alpha1.Restore, pod *corev1.Pod) bool {
+	ns := restore.GetNamespace()
+	name := restore.GetName()
+
+	policy := restore.Spec.ResumePolicy
+	if policy == nil || restore.Spec.BR == nil || restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		return false
+	}
+	if v1alpha1.IsRestoreVerifying(restore) {
+		// the restored data is not resumed by the verification job
+		return false
+	}
+
+	attempts := restore.Status.ResumeAttempts
+	for _, attempt := range attempts {
+		if attempt.FailedPod == pod.Name {
+			// the pod of a previous attempt is not cleaned up yet
+			return true
+		}
+	}
+	if len(attempts) >= policy.MaxRetryTimes {
+		klog.Infof("restore %s/%s has exceeded the max retry times %d of resume policy", ns, name, policy.MaxRetryTimes)
+		return false
+	}
+
+	now := metav1.Now()
+	attempt := &v1alpha1.RestoreResumeAttempt{
+		AttemptNum:     len(attempts) + 1,
+		FailedPod:      pod.Name,
+		Reason:         fmt.Sprintf("Pod %s has failed", pod.Name),
+		DetectFailedAt: &now,
+	}
+	// the failed condition set by the restore job is reverted by the resuming condition
+	condition := &v1alpha1.RestoreCondition{
+		Type:    v1alpha1.RestoreResuming,
+		Status:  corev1.ConditionTrue,
+		Reason:  "PodFailed",
+		Message: attempt.Reason,
+	}
+	if err := c.control.UpdateStatus(restore, condition, &controller.RestoreUpdateStatus{ResumeAttempt: attempt}); err != nil {
+		klog.Errorf("Fail to record resume attempt %d of restore %s/%s, %v", attempt.AttemptNum, ns, name, err)
+		return true
+	}
+	klog.Infof("restore %s/%s will resume from checkpoint, attempt %d", ns, name, attempt.AttemptNum)
+	c.enqueueRestore(restore)
+	return true
+}
+
 // enqueueRestore enqueues the given restore in the work queue.
 func (c *Controller) enqueueRestore(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -166,6 +166,131 @@ func TestRestoreControllerUpdateRestore(t *testing.T) {
 	}
 }
 
+func TestRestoreControllerResumeRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newResumableRestore := func() *v1alpha1.Restore {
+		restore := newRestore()
+		restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo1"}
+		restore.Spec.ResumePolicy = &v1alpha1.RestoreResumePolicy{MaxRetryTimes: 2}
+		restore.Status.Conditions = []v1alpha1.RestoreCondition{
+			{
+				Type:   v1alpha1.RestoreRunning,
+				Status: corev1.ConditionTrue,
+			},
+		}
+		return restore
+	}
+	failedPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+		}
+	}
+
+	// no resume policy
+	rtc, _, control := newFakeRestoreController()
+	restore := newResumableRestore()
+	restore.Spec.ResumePolicy = nil
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-1"))).To(BeFalse())
+	g.Expect(control.newStatus).To(BeNil())
+
+	// volume snapshot restore can not be resumed
+	restore = newResumableRestore()
+	restore.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-1"))).To(BeFalse())
+	g.Expect(control.newStatus).To(BeNil())
+
+	// record the first attempt
+	restore = newResumableRestore()
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-1"))).To(BeTrue())
+	g.Expect(control.condition.Type).To(Equal(v1alpha1.RestoreResuming))
+	g.Expect(control.condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(control.newStatus.ResumeAttempt.AttemptNum).To(Equal(1))
+	g.Expect(control.newStatus.ResumeAttempt.FailedPod).To(Equal("pod-1"))
+	g.Expect(control.newStatus.ResumeAttempt.ResumedAt).To(BeNil())
+	g.Expect(rtc.queue.Len()).To(Equal(1))
+
+	// the failed pod of a recorded attempt is ignored
+	control.newStatus = nil
+	restore.Status.ResumeAttempts = []v1alpha1.RestoreResumeAttempt{
+		{AttemptNum: 1, FailedPod: "pod-1", ResumedAt: &metav1.Time{}},
+	}
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-1"))).To(BeTrue())
+	g.Expect(control.newStatus).To(BeNil())
+
+	// record the second attempt
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-2"))).To(BeTrue())
+	g.Expect(control.newStatus.ResumeAttempt.AttemptNum).To(Equal(2))
+
+	// exceed the max retry times
+	control.newStatus = nil
+	restore.Status.ResumeAttempts = append(restore.Status.ResumeAttempts,
+		v1alpha1.RestoreResumeAttempt{AttemptNum: 2, FailedPod: "pod-2", ResumedAt: &metav1.Time{}})
+	g.Expect(rtc.resumeRestoreIfPossible(restore, failedPod("pod-3"))).To(BeFalse())
+	g.Expect(control.newStatus).To(BeNil())
+
+	// resuming restore is enqueued to relaunch the job
+	rtc, _, _ = newFakeRestoreController()
+	restore = newResumableRestore()
+	restore.Status.ResumeAttempts = []v1alpha1.RestoreResumeAttempt{{AttemptNum: 1, FailedPod: "pod-1"}}
+	g.Expect(v1alpha1.IsRestoreResuming(restore)).To(BeTrue())
+	rtc.updateRestore(restore)
+	g.Expect(rtc.queue.Len()).To(Equal(1))
+}
+
+func TestRestoreControllerResumeFailedRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	rtc, _, control := newFakeRestoreController()
+	restore := newRestore()
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo1"}
+	restore.Spec.ResumePolicy = &v1alpha1.RestoreResumePolicy{MaxRetryTimes: 1}
+	// the restore job marks the restore as failed before the controller sees the failed pod
+	restore.Status.Conditions = []v1alpha1.RestoreCondition{
+		{Type: v1alpha1.RestoreRunning, Status: corev1.ConditionTrue},
+		{Type: v1alpha1.RestoreFailed, Status: corev1.ConditionTrue},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-1",
+			Namespace: restore.Namespace,
+			Labels:    label.NewRestore().Instance(restore.GetInstanceName()).RestoreJob().Restore(restore.Name),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	g.Expect(rtc.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+
+	// the failed restore is resumed
+	rtc.updateRestore(restore)
+	g.Expect(control.newStatus).NotTo(BeNil())
+	g.Expect(control.newStatus.ResumeAttempt.AttemptNum).To(Equal(1))
+	g.Expect(control.newStatus.ResumeAttempt.FailedPod).To(Equal("pod-1"))
+	g.Expect(control.condition.Type).To(Equal(v1alpha1.RestoreResuming))
+	g.Expect(control.condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(rtc.queue.Len()).To(Equal(1))
+
+	// the restore which has exceeded the max retry times stays failed
+	rtc, _, control = newFakeRestoreController()
+	g.Expect(rtc.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+	restore.Status.ResumeAttempts = []v1alpha1.RestoreResumeAttempt{
+		{AttemptNum: 1, FailedPod: "pod-0", ResumedAt: &metav1.Time{}},
+	}
+	rtc.updateRestore(restore)
+	g.Expect(control.newStatus).To(BeNil())
+	g.Expect(rtc.queue.Len()).To(Equal(0))
+
+	// the failed restore without resume policy is skipped
+	rtc, _, control = newFakeRestoreController()
+	g.Expect(rtc.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+	restore.Spec.ResumePolicy = nil
+	restore.Status.ResumeAttempts = nil
+	rtc.updateRestore(restore)
+	g.Expect(control.newStatus).To(BeNil())
+	g.Expect(control.condition).To(BeNil())
+	g.Expect(rtc.queue.Len()).To(Equal(0))
+}
+
 func TestRestoreControllerSync(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tests := []struct {
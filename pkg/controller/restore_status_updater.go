@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -43,8 +44,14 @@ type RestoreUpdateStatus struct {
 	ProgressStep *string
 	// Progress is the step's progress value.
 	Progress *float64
+	// ProgressSpeed is the step's speed reported by br.
+	ProgressSpeed *string
 	// ProgressUpdateTime is the progress update time.
 	ProgressUpdateTime *metav1.Time
+	// ResumeAttempt is a new attempt to append, or the latest attempt to update if the attempt num is the same.
+	ResumeAttempt *v1alpha1.RestoreResumeAttempt
+	// Verification is the status of the verification of the restored data.
+	Verification *v1alpha1.RestoreVerificationStatus
 }
 
 // RestoreConditionUpdaterInterface enables updating Restore conditions.
@@ -121,12 +128,28 @@ func updateRestoreStatus(status *v1alpha1.RestoreStatus, newStatus *RestoreUpdat
 		isUpdate = true
 	}
 	if newStatus.ProgressStep != nil {
-		progresses, updated := updateBRProgress(status.Progresses, newStatus.ProgressStep, newStatus.Progress, newStatus.ProgressUpdateTime)
+		progresses, updated := updateBRProgress(status.Progresses, newStatus.ProgressStep, newStatus.Progress, newStatus.ProgressSpeed, newStatus.ProgressUpdateTime)
 		if updated {
 			status.Progresses = progresses
 			isUpdate = true
 		}
 	}
+	if newStatus.ResumeAttempt != nil {
+		attempts := status.ResumeAttempts
+		if len(attempts) > 0 && attempts[len(attempts)-1].AttemptNum == newStatus.ResumeAttempt.AttemptNum {
+			if !apiequality.Semantic.DeepEqual(attempts[len(attempts)-1], *newStatus.ResumeAttempt) {
+				attempts[len(attempts)-1] = *newStatus.ResumeAttempt
+				isUpdate = true
+			}
+		} else {
+			status.ResumeAttempts = append(attempts, *newStatus.ResumeAttempt)
+			isUpdate = true
+		}
+	}
+	if newStatus.Verification != nil && !apiequality.Semantic.DeepEqual(status.Verification, newStatus.Verification) {
+		status.Verification = newStatus.Verification.DeepCopy()
+		isUpdate = true
+	}
 
 	return isUpdate
 }
@@ -19,6 +19,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -57,6 +58,28 @@ func TestUpdateRestoreStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateRestoreConditionResuming(t *testing.T) {
+	g := NewGomegaWithT(t)
+	status := &v1alpha1.RestoreStatus{}
+	v1alpha1.UpdateRestoreCondition(status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreRunning, Status: corev1.ConditionTrue})
+	v1alpha1.UpdateRestoreCondition(status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreFailed, Status: corev1.ConditionTrue})
+	g.Expect(status.Phase).To(Equal(v1alpha1.RestoreFailed))
+
+	// the failed condition is reverted when the restore is resuming
+	v1alpha1.UpdateRestoreCondition(status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreResuming, Status: corev1.ConditionTrue})
+	g.Expect(status.Phase).To(Equal(v1alpha1.RestoreResuming))
+	restore := &v1alpha1.Restore{Status: *status}
+	g.Expect(v1alpha1.IsRestoreFailed(restore)).To(BeFalse())
+	_, progressing := v1alpha1.GetRestoreCondition(status, v1alpha1.RestoreProgressing)
+	g.Expect(progressing.Status).To(Equal(corev1.ConditionTrue))
+
+	// clearing the resuming condition does not change the phase
+	v1alpha1.UpdateRestoreCondition(status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreResuming, Status: corev1.ConditionFalse})
+	g.Expect(status.Phase).To(Equal(v1alpha1.RestoreResuming))
+	v1alpha1.UpdateRestoreCondition(status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreRunning, Status: corev1.ConditionTrue, Reason: "ResumedFromCheckpoint"})
+	g.Expect(status.Phase).To(Equal(v1alpha1.RestoreRunning))
+}
+
 func newUpdateRestoreStatus() *RestoreUpdateStatus {
 	ts := "421762809912885269"
 	start, _ := time.Parse(time.RFC3339, "2020-12-25T21:46:59Z")
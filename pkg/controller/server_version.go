@@ -0,0 +1,53 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// serverVersion caches the minor version of Kubernetes, it is used to skip the fields which are
+// not supported by the Kubernetes that the operator runs on.
+type serverVersion struct {
+	lock  sync.Mutex
+	minor *int
+}
+
+// minorVersion returns the minor version of Kubernetes, it is cached after it is got successfully.
+// The minor version may contain a suffix, e.g. `27+` of EKS and GKE.
+func (v *serverVersion) minorVersion(kubeCli kubernetes.Interface) (int, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.minor != nil {
+		return *v.minor, true
+	}
+
+	info, err := kubeCli.Discovery().ServerVersion()
+	if err != nil {
+		klog.Warningf("failed to get the version of Kubernetes: %v", err)
+		return 0, false
+	}
+	minor, err := strconv.Atoi(strings.TrimSuffix(info.Minor, "+"))
+	if err != nil || info.Major != "1" {
+		klog.Warningf("unknown version of Kubernetes: %s.%s", info.Major, info.Minor)
+		return 0, false
+	}
+	v.minor = &minor
+	return minor, true
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,14 +47,16 @@ type realServiceControl struct {
 	kubeCli   kubernetes.Interface
 	svcLister corelisters.ServiceLister
 	recorder  record.EventRecorder
+
+	version serverVersion
 }
 
 // NewRealServiceControl creates a new ServiceControlInterface
 func NewRealServiceControl(kubeCli kubernetes.Interface, svcLister corelisters.ServiceLister, recorder record.EventRecorder) ServiceControlInterface {
 	return &realServiceControl{
-		kubeCli,
-		svcLister,
-		recorder,
+		kubeCli:   kubeCli,
+		svcLister: svcLister,
+		recorder:  recorder,
 	}
 }
 
@@ -66,7 +70,10 @@ func (c *realServiceControl) CreateService(controller runtime.Object, svc *corev
 	namespace := controllerMo.GetNamespace()
 	_, err := c.kubeCli.CoreV1().Services(namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
 	c.recordServiceEvent("create", name, kind, controller, svc, err)
-	return err
+	if err != nil {
+		return err
+	}
+	return c.syncServiceTrafficPolicy(controller, svc, false)
 }
 
 func (c *realServiceControl) UpdateService(controller runtime.Object, svc *corev1.Service) (*corev1.Service, error) {
@@ -79,6 +86,14 @@ func (c *realServiceControl) UpdateService(controller runtime.Object, svc *corev
 	namespace := controllerMo.GetNamespace()
 	svcName := svc.GetName()
 	svcSpec := svc.Spec.DeepCopy()
+	desiredSvc := svc
+	// the traffic fields are cleared from the service if the policy is removed
+	hadPolicy := false
+	if c.svcLister != nil {
+		if oldSvc, err := c.svcLister.Services(namespace).Get(svcName); err == nil {
+			_, hadPolicy = oldSvc.Annotations[label.AnnServiceTrafficPolicyKey]
+		}
+	}
 
 	var updateSvc *corev1.Service
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
@@ -98,7 +113,11 @@ func (c *realServiceControl) UpdateService(controller runtime.Object, svc *corev
 
 		return updateErr
 	})
-	return updateSvc, err
+	if err != nil {
+		return updateSvc, err
+	}
+	// the update by the vendored API drops the traffic fields, so they are always patched after the update
+	return updateSvc, c.syncServiceTrafficPolicy(controller, desiredSvc, hadPolicy)
 }
 
 func (c *realServiceControl) DeleteService(controller runtime.Object, svc *corev1.Service) error {
@@ -0,0 +1,167 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// annotations of the service which enable the topology aware routing,
+	// see https://kubernetes.io/docs/concepts/services-networking/topology-aware-routing/
+	topologyModeAnnotation       = "service.kubernetes.io/topology-mode"
+	topologyAwareHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+
+	// the minor versions of Kubernetes v1 which support the traffic fields of the service
+	internalTrafficPolicyMinorVersion = 22
+	trafficDistributionMinorVersion   = 31
+)
+
+// serviceTrafficAnnotations are the annotations of the service which are managed by the traffic options
+var serviceTrafficAnnotations = []string{
+	label.AnnServiceTrafficPolicyKey,
+	topologyModeAnnotation,
+	topologyAwareHintsAnnotation,
+}
+
+// serviceTrafficPolicy contains the traffic fields of the service spec which are not in the vendored API,
+// nil fields are removed from the service by the merge patch.
+type serviceTrafficPolicy struct {
+	InternalTrafficPolicy *v1alpha1.ServiceInternalTrafficPolicyType `json:"internalTrafficPolicy"`
+	TrafficDistribution   *string                                    `json:"trafficDistribution"`
+}
+
+// SetServiceTrafficOptions sets the traffic options of the ServiceSpec to the service. The session affinity
+// is set to the spec directly, the topology aware routing is set by the annotations, and the fields unknown
+// by the vendored API are recorded in an annotation which is applied by the ServiceControl.
+func SetServiceTrafficOptions(svc *corev1.Service, spec *v1alpha1.ServiceSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.SessionAffinity != "" {
+		svc.Spec.SessionAffinity = spec.SessionAffinity
+		svc.Spec.SessionAffinityConfig = spec.SessionAffinityConfig.DeepCopy()
+	}
+
+	if spec.TopologyAwareRouting != nil && *spec.TopologyAwareRouting {
+		SetServiceTopologyAwareRouting(svc)
+	}
+	if spec.InternalTrafficPolicy != nil || spec.TrafficDistribution != nil {
+		policy := serviceTrafficPolicy{
+			InternalTrafficPolicy: spec.InternalTrafficPolicy,
+			TrafficDistribution:   spec.TrafficDistribution,
+		}
+		data, err := json.Marshal(policy)
+		if err != nil {
+			klog.Errorf("failed to marshal traffic policy of service %s/%s: %v", svc.Namespace, svc.Name, err)
+			return
+		}
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		svc.Annotations[label.AnnServiceTrafficPolicyKey] = string(data)
+	}
+}
+
+// SetServiceTopologyAwareRouting enables the topology aware routing of the service by the annotations,
+// the traffic is kept in the zone where it originates if the endpoints are balanced across the zones.
+func SetServiceTopologyAwareRouting(svc *corev1.Service) {
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[topologyModeAnnotation] = "Auto"
+	svc.Annotations[topologyAwareHintsAnnotation] = "auto"
+}
+
+// CopyServiceTrafficAnnotations copies the annotations set by SetServiceTrafficOptions from src to dst,
+// the annotations which are not in src are removed from dst.
+func CopyServiceTrafficAnnotations(dst, src *corev1.Service) {
+	for _, key := range serviceTrafficAnnotations {
+		value, ok := src.Annotations[key]
+		if !ok {
+			delete(dst.Annotations, key)
+			continue
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[key] = value
+	}
+}
+
+// ServiceTrafficAnnotationsEqual compares the annotations set by SetServiceTrafficOptions of the services.
+func ServiceTrafficAnnotationsEqual(newSvc, oldSvc *corev1.Service) bool {
+	for _, key := range serviceTrafficAnnotations {
+		newValue, newOK := newSvc.Annotations[key]
+		oldValue, oldOK := oldSvc.Annotations[key]
+		if newOK != oldOK || newValue != oldValue {
+			return false
+		}
+	}
+	return true
+}
+
+// syncServiceTrafficPolicy applies the traffic policy recorded in the annotation of the service by a merge
+// patch, the fields are also patched if the policy is removed to clear them from the service. The fields which
+// are not supported by the Kubernetes are skipped with a warning event.
+func (c *realServiceControl) syncServiceTrafficPolicy(controller runtime.Object, svc *corev1.Service, hadPolicy bool) error {
+	data, ok := svc.Annotations[label.AnnServiceTrafficPolicyKey]
+	if !ok && !hadPolicy {
+		return nil
+	}
+
+	policy := serviceTrafficPolicy{}
+	if ok {
+		if err := json.Unmarshal([]byte(data), &policy); err != nil {
+			return fmt.Errorf("failed to unmarshal traffic policy of service %s/%s: %v", svc.Namespace, svc.Name, err)
+		}
+	}
+
+	minor, known := c.version.minorVersion(c.kubeCli)
+	var unsupported []string
+	if known && minor < internalTrafficPolicyMinorVersion && policy.InternalTrafficPolicy != nil {
+		policy.InternalTrafficPolicy = nil
+		unsupported = append(unsupported, "internalTrafficPolicy")
+	}
+	if known && minor < trafficDistributionMinorVersion && policy.TrafficDistribution != nil {
+		policy.TrafficDistribution = nil
+		unsupported = append(unsupported, "trafficDistribution")
+	}
+	if len(unsupported) > 0 {
+		msg := fmt.Sprintf("%s of Service %s is not supported by Kubernetes v1.%d, skip it", strings.Join(unsupported, ", "), svc.Name, minor)
+		c.recorder.Event(controller, corev1.EventTypeWarning, "UnsupportedServiceField", msg)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"spec": policy})
+	if err != nil {
+		return err
+	}
+	_, err = c.kubeCli.CoreV1().Services(svc.Namespace).Patch(context.TODO(), svc.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch traffic policy of service %s/%s: %v", svc.Namespace, svc.Name, err)
+	}
+	klog.Infof("patch traffic policy of Service: [%s/%s] successfully, patch: %s", svc.Namespace, svc.Name, patch)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+)
+
+func TestSetServiceTrafficOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	svc := newService(tc, "pd")
+	SetServiceTrafficOptions(svc, &v1alpha1.ServiceSpec{})
+	g.Expect(svc.Annotations).To(BeNil())
+	g.Expect(svc.Spec.SessionAffinity).To(BeEmpty())
+
+	local := v1alpha1.ServiceInternalTrafficPolicyLocal
+	spec := &v1alpha1.ServiceSpec{
+		InternalTrafficPolicy: &local,
+		TopologyAwareRouting:  pointer.BoolPtr(true),
+		SessionAffinity:       corev1.ServiceAffinityClientIP,
+		SessionAffinityConfig: &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: pointer.Int32Ptr(600)},
+		},
+	}
+	SetServiceTrafficOptions(svc, spec)
+	g.Expect(svc.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+	g.Expect(*svc.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(Equal(int32(600)))
+	g.Expect(svc.Annotations).To(HaveKeyWithValue(topologyModeAnnotation, "Auto"))
+	g.Expect(svc.Annotations).To(HaveKeyWithValue(topologyAwareHintsAnnotation, "auto"))
+	g.Expect(svc.Annotations).To(HaveKeyWithValue(label.AnnServiceTrafficPolicyKey, `{"internalTrafficPolicy":"Local","trafficDistribution":null}`))
+
+	// the traffic annotations are removed if they are not desired
+	oldSvc := svc.DeepCopy()
+	newSvc := newService(tc, "pd")
+	g.Expect(ServiceTrafficAnnotationsEqual(newSvc, oldSvc)).To(BeFalse())
+	CopyServiceTrafficAnnotations(oldSvc, newSvc)
+	g.Expect(oldSvc.Annotations).To(BeEmpty())
+	g.Expect(ServiceTrafficAnnotationsEqual(newSvc, oldSvc)).To(BeTrue())
+}
+
+func TestServiceControlSyncTrafficPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	svc := newService(tc, "pd")
+	SetServiceTrafficOptions(svc, &v1alpha1.ServiceSpec{
+		TrafficDistribution: pointer.StringPtr(v1alpha1.ServiceTrafficDistributionPreferClose),
+	})
+
+	for _, tt := range []struct {
+		minor         string
+		expectedPatch string
+		expectedEvent string
+	}{
+		{minor: "31", expectedPatch: `{"spec":{"internalTrafficPolicy":null,"trafficDistribution":"PreferClose"}}`},
+		{minor: "27+", expectedPatch: `{"spec":{"internalTrafficPolicy":null,"trafficDistribution":null}}`, expectedEvent: "UnsupportedServiceField"},
+	} {
+		recorder := record.NewFakeRecorder(10)
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{Major: "1", Minor: tt.minor}
+		var patch string
+		fakeClient.PrependReactor("patch", "services", func(action core.Action) (bool, runtime.Object, error) {
+			patch = string(action.(core.PatchAction).GetPatch())
+			return true, nil, nil
+		})
+		control := NewRealServiceControl(fakeClient, nil, recorder)
+
+		g.Expect(control.CreateService(tc, svc.DeepCopy())).To(Succeed())
+		g.Expect(patch).To(Equal(tt.expectedPatch))
+		events := collectEvents(recorder.Events)
+		if tt.expectedEvent != "" {
+			g.Expect(events).To(ContainElement(ContainSubstring(tt.expectedEvent)))
+		} else {
+			g.Expect(events).To(HaveLen(1))
+		}
+	}
+}
@@ -44,11 +44,17 @@ type realStatefulSetControl struct {
 	kubeCli   kubernetes.Interface
 	setLister appslisters.StatefulSetLister
 	recorder  record.EventRecorder
+
+	version serverVersion
 }
 
 // NewRealStatefuSetControl returns a StatefulSetControlInterface
 func NewRealStatefuSetControl(kubeCli kubernetes.Interface, setLister appslisters.StatefulSetLister, recorder record.EventRecorder) StatefulSetControlInterface {
-	return &realStatefulSetControl{kubeCli, setLister, recorder}
+	return &realStatefulSetControl{
+		kubeCli:   kubeCli,
+		setLister: setLister,
+		recorder:  recorder,
+	}
 }
 
 // CreateStatefulSet create a StatefulSet for a controller
@@ -66,6 +72,10 @@ func (c *realStatefulSetControl) CreateStatefulSet(controller runtime.Object, se
 	if apierrors.IsAlreadyExists(err) {
 		return err
 	}
+	// the native sidecar containers are created as regular containers, and converted by a patch
+	if sidecars := nativeSidecarContainers(set); err == nil && len(sidecars) > 0 && c.nativeSidecarSupported(controller, set, sidecars) {
+		_, err = c.patchNativeSidecarContainers(set, sidecars)
+	}
 	c.recordStatefulSetEvent("create", kind, name, controller, set, err)
 	return err
 }
@@ -86,6 +96,18 @@ func (c *realStatefulSetControl) UpdateStatefulSet(controller runtime.Object, se
 	setAnnotations := set.Annotations
 	var updatedSS *apps.StatefulSet
 
+	// the StatefulSet with native sidecar containers is replaced by a patch, which is not rejected by
+	// the conflicts as the resource version is not set
+	if sidecars := nativeSidecarContainers(set); len(sidecars) > 0 && c.nativeSidecarSupported(controller, set, sidecars) {
+		patched, err := c.patchNativeSidecarContainers(set, sidecars)
+		if err != nil {
+			klog.Errorf("failed to update %s: [%s/%s]'s StatefulSet: [%s/%s], error: %v", kind, namespace, name, namespace, setName, err)
+			return nil, err
+		}
+		klog.Infof("%s: [%s/%s]'s StatefulSet: [%s/%s] updated successfully", kind, namespace, name, namespace, setName)
+		return patched, nil
+	}
+
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		// TODO: verify if StatefulSet identity(name, namespace, labels) matches TidbCluster
 		var updateErr error
@@ -14,19 +14,27 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	appslisters "k8s.io/client-go/listers/apps/v1"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 )
 
 func TestStatefulSetControlCreatesStatefulSets(t *testing.T) {
@@ -187,3 +195,105 @@ func TestStatefulSetControlDeleteStatefulSetFailed(t *testing.T) {
 	g.Expect(events).To(HaveLen(1))
 	g.Expect(events[0]).To(ContainSubstring(corev1.EventTypeWarning))
 }
+
+func newStatefulSetWithNativeSidecar(tc *v1alpha1.TidbCluster) *apps.StatefulSet {
+	set := newStatefulSet(tc, "pd")
+	set.Labels = map[string]string{"app": "pd"}
+	set.Spec.Template.Annotations = map[string]string{label.AnnNativeSidecarContainersKey: "log"}
+	set.Spec.Template.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox"}}
+	set.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "pd", Image: "pingcap/pd"},
+		{Name: "log", Image: "fluent-bit"},
+	}
+	return set
+}
+
+func TestNativeSidecarStatefulSetPatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	set := newStatefulSetWithNativeSidecar(newTidbCluster())
+	g.Expect(nativeSidecarContainers(set)).To(Equal([]string{"log"}))
+
+	data, err := nativeSidecarStatefulSetPatch(set, nativeSidecarContainers(set))
+	g.Expect(err).To(Succeed())
+	var patch []struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value struct {
+			Template struct {
+				Spec struct {
+					Containers     []map[string]interface{} `json:"containers"`
+					InitContainers []map[string]interface{} `json:"initContainers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"value"`
+	}
+	g.Expect(json.Unmarshal(data, &patch)).To(Succeed())
+	g.Expect(patch).To(HaveLen(3))
+	g.Expect(patch[0].Path).To(Equal("/spec"))
+	podSpec := patch[0].Value.Template.Spec
+	g.Expect(podSpec.Containers).To(HaveLen(1))
+	g.Expect(podSpec.Containers[0]["name"]).To(Equal("pd"))
+	g.Expect(podSpec.InitContainers).To(HaveLen(2))
+	g.Expect(podSpec.InitContainers[0]["name"]).To(Equal("init"))
+	g.Expect(podSpec.InitContainers[0]).NotTo(HaveKey("restartPolicy"))
+	g.Expect(podSpec.InitContainers[1]["name"]).To(Equal("log"))
+	g.Expect(podSpec.InitContainers[1]["restartPolicy"]).To(Equal("Always"))
+
+	// the sidecar got from the API server is an init container without restartPolicy
+	set.Spec.Template.Spec.Containers = set.Spec.Template.Spec.Containers[:1]
+	set.Spec.Template.Spec.InitContainers = append(set.Spec.Template.Spec.InitContainers, corev1.Container{Name: "log", Image: "fluent-bit"})
+	data, err = nativeSidecarStatefulSetPatch(set, nativeSidecarContainers(set))
+	g.Expect(err).To(Succeed())
+	g.Expect(json.Unmarshal(data, &patch)).To(Succeed())
+	podSpec = patch[0].Value.Template.Spec
+	g.Expect(podSpec.InitContainers).To(HaveLen(2))
+	g.Expect(podSpec.InitContainers[1]["restartPolicy"]).To(Equal("Always"))
+}
+
+func TestStatefulSetControlUpdateStatefulSetNativeSidecar(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		minor   string
+		patched bool
+	}{
+		{minor: "27", patched: false},
+		{minor: "28", patched: true},
+		{minor: "29+", patched: true},
+		{minor: "", patched: false},
+	}
+	for _, tt := range tests {
+		recorder := record.NewFakeRecorder(10)
+		tc := newTidbCluster()
+		set := newStatefulSetWithNativeSidecar(tc)
+		fakeClient := fake.NewSimpleClientset(set.DeepCopy())
+		fakeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{Major: "1", Minor: tt.minor}
+		control := NewRealStatefuSetControl(fakeClient, nil, recorder)
+
+		set.Spec.Replicas = pointer.Int32Ptr(3)
+		updated, err := control.UpdateStatefulSet(tc, set)
+		g.Expect(err).To(Succeed(), "minor %q", tt.minor)
+		g.Expect(*updated.Spec.Replicas).To(Equal(int32(3)), "minor %q", tt.minor)
+
+		var patches []core.PatchAction
+		for _, action := range fakeClient.Actions() {
+			if patch, ok := action.(core.PatchAction); ok {
+				patches = append(patches, patch)
+			}
+		}
+		events := collectEvents(recorder.Events)
+		if !tt.patched {
+			g.Expect(patches).To(BeEmpty(), "minor %q", tt.minor)
+			g.Expect(events).To(HaveLen(1), "minor %q", tt.minor)
+			g.Expect(events[0]).To(ContainSubstring("UnsupportedNativeSidecar"))
+			g.Expect(updated.Spec.Template.Spec.Containers).To(HaveLen(2), "minor %q", tt.minor)
+			continue
+		}
+		g.Expect(patches).To(HaveLen(1), "minor %q", tt.minor)
+		g.Expect(patches[0].GetPatchType()).To(Equal(types.JSONPatchType))
+		g.Expect(events).To(BeEmpty(), "minor %q", tt.minor)
+		g.Expect(updated.Spec.Template.Spec.Containers).To(HaveLen(1), "minor %q", tt.minor)
+		g.Expect(updated.Spec.Template.Spec.InitContainers).To(HaveLen(2), "minor %q", tt.minor)
+		g.Expect(updated.Spec.Template.Spec.InitContainers[1].Name).To(Equal("log"))
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -39,6 +40,41 @@ type captureInfo struct {
 	AdvertiseAddr string `json:"address"`
 }
 
+// ChangefeedConfig is the request to create or update a changefeed by the open API v2
+type ChangefeedConfig struct {
+	ID            string                 `json:"changefeed_id,omitempty"`
+	SinkURI       string                 `json:"sink_uri,omitempty"`
+	StartTs       uint64                 `json:"start_ts,omitempty"`
+	TargetTs      uint64                 `json:"target_ts,omitempty"`
+	ReplicaConfig map[string]interface{} `json:"replica_config,omitempty"`
+}
+
+// ChangefeedInfo is the detail of a changefeed returned by the open API v2
+type ChangefeedInfo struct {
+	ID             string           `json:"id"`
+	State          string           `json:"state"`
+	CheckpointTs   uint64           `json:"checkpoint_ts"`
+	CheckpointTime string           `json:"checkpoint_time"`
+	Error          *ChangefeedError `json:"error,omitempty"`
+}
+
+// ChangefeedError is the last error of a changefeed
+type ChangefeedError struct {
+	Time    string `json:"time"`
+	Addr    string `json:"addr"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// openAPIError is the error returned by the open API v2
+type openAPIError struct {
+	Message string `json:"error_msg"`
+	Code    string `json:"error_code"`
+}
+
+// errChangefeedNotExists is the error code when the changefeed does not exist
+const errChangefeedNotExists = "CDC:ErrChangeFeedNotExists"
+
 // drainCaptureRequest is request for manual `DrainCapture`
 type drainCaptureRequest struct {
 	CaptureID string `json:"capture_id"`
@@ -65,6 +101,18 @@ type TiCDCControlInterface interface {
 	// IsHealthy gets the healthy status of TiCDC cluster.
 	// Returns true if the TiCDC cluster is heathy.
 	IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+	// GetChangefeed returns the detail of the changefeed, it returns nil if the changefeed does not exist.
+	GetChangefeed(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error)
+	// CreateChangefeed creates a changefeed.
+	CreateChangefeed(tc *v1alpha1.TidbCluster, config *ChangefeedConfig) error
+	// UpdateChangefeed updates the changefeed, the changefeed must be paused.
+	UpdateChangefeed(tc *v1alpha1.TidbCluster, id string, config *ChangefeedConfig) error
+	// PauseChangefeed pauses the changefeed.
+	PauseChangefeed(tc *v1alpha1.TidbCluster, id string) error
+	// ResumeChangefeed resumes the changefeed.
+	ResumeChangefeed(tc *v1alpha1.TidbCluster, id string) error
+	// RemoveChangefeed removes the changefeed, it succeeds if the changefeed does not exist.
+	RemoveChangefeed(tc *v1alpha1.TidbCluster, id string) error
 }
 
 // defaultTiCDCControl is default implementation of TiCDCControlInterface.
@@ -263,6 +311,125 @@ func (c *defaultTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32)
 	return true, nil
 }
 
+func (c *defaultTiCDCControl) GetChangefeed(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error) {
+	info := &ChangefeedInfo{}
+	apiErr, err := c.changefeedRequest(tc, http.MethodGet, "/"+url.PathEscape(id), nil, info)
+	if err != nil {
+		return nil, err
+	}
+	if apiErr != nil {
+		if apiErr.Code == errChangefeedNotExists {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ticdc get changefeed %s failed: %s", id, apiErr.Message)
+	}
+	return info, nil
+}
+
+func (c *defaultTiCDCControl) CreateChangefeed(tc *v1alpha1.TidbCluster, config *ChangefeedConfig) error {
+	apiErr, err := c.changefeedRequest(tc, http.MethodPost, "", config, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil {
+		return fmt.Errorf("ticdc create changefeed %s failed: %s", config.ID, apiErr.Message)
+	}
+	return nil
+}
+
+func (c *defaultTiCDCControl) UpdateChangefeed(tc *v1alpha1.TidbCluster, id string, config *ChangefeedConfig) error {
+	apiErr, err := c.changefeedRequest(tc, http.MethodPut, "/"+url.PathEscape(id), config, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil {
+		return fmt.Errorf("ticdc update changefeed %s failed: %s", id, apiErr.Message)
+	}
+	return nil
+}
+
+func (c *defaultTiCDCControl) PauseChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	apiErr, err := c.changefeedRequest(tc, http.MethodPost, "/"+url.PathEscape(id)+"/pause", nil, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil {
+		return fmt.Errorf("ticdc pause changefeed %s failed: %s", id, apiErr.Message)
+	}
+	return nil
+}
+
+func (c *defaultTiCDCControl) ResumeChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	apiErr, err := c.changefeedRequest(tc, http.MethodPost, "/"+url.PathEscape(id)+"/resume", struct{}{}, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil {
+		return fmt.Errorf("ticdc resume changefeed %s failed: %s", id, apiErr.Message)
+	}
+	return nil
+}
+
+func (c *defaultTiCDCControl) RemoveChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	apiErr, err := c.changefeedRequest(tc, http.MethodDelete, "/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil && apiErr.Code != errChangefeedNotExists {
+		return fmt.Errorf("ticdc remove changefeed %s failed: %s", id, apiErr.Message)
+	}
+	return nil
+}
+
+// changefeedRequest sends the request to the changefeed open API v2 of the first capture, the
+// request is forwarded to the owner by TiCDC. The error returned by the open API is returned
+// as openAPIError, and the response is decoded into result if the request succeeds.
+func (c *defaultTiCDCControl) changefeedRequest(tc *v1alpha1.TidbCluster, method, path string, payload, result interface{}) (*openAPIError, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("ticdc changefeed request failed, marshal request error: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.getBaseURL(tc, 0)+"/api/v2/changefeeds"+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("ticdc changefeed request failed, new request error: %v", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ticdc changefeed request failed, request error: %v", err)
+	}
+	defer httputil.DeferClose(res.Body)
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ticdc changefeed request failed, read response error: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		apiErr := &openAPIError{}
+		if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Message == "" {
+			return nil, fmt.Errorf("ticdc changefeed request failed, status code: %d, response: %s", res.StatusCode, string(data))
+		}
+		return apiErr, nil
+	}
+	if result != nil {
+		if err := json.Unmarshal(data, result); err != nil {
+			return nil, fmt.Errorf("ticdc changefeed request failed, unmarshal response error: %v", err)
+		}
+	}
+	return nil, nil
+}
+
 func (c *defaultTiCDCControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32) string {
 	if c.testURL != "" {
 		return c.testURL
@@ -334,6 +501,13 @@ type FakeTiCDCControl struct {
 	DrainCaptureFn func(tc *v1alpha1.TidbCluster, ordinal int32) (tableCount int, retry bool, err error)
 	ResignOwnerFn  func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
 	IsHealthyFn    func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+
+	GetChangefeedFn    func(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error)
+	CreateChangefeedFn func(tc *v1alpha1.TidbCluster, config *ChangefeedConfig) error
+	UpdateChangefeedFn func(tc *v1alpha1.TidbCluster, id string, config *ChangefeedConfig) error
+	PauseChangefeedFn  func(tc *v1alpha1.TidbCluster, id string) error
+	ResumeChangefeedFn func(tc *v1alpha1.TidbCluster, id string) error
+	RemoveChangefeedFn func(tc *v1alpha1.TidbCluster, id string) error
 }
 
 // NewFakeTiCDCControl returns a FakeTiCDCControl instance
@@ -368,3 +542,45 @@ func (c *FakeTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (b
 	}
 	return c.IsHealthyFn(tc, ordinal)
 }
+
+func (c *FakeTiCDCControl) GetChangefeed(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error) {
+	if c.GetChangefeedFn == nil {
+		return nil, fmt.Errorf("undefined GetChangefeed")
+	}
+	return c.GetChangefeedFn(tc, id)
+}
+
+func (c *FakeTiCDCControl) CreateChangefeed(tc *v1alpha1.TidbCluster, config *ChangefeedConfig) error {
+	if c.CreateChangefeedFn == nil {
+		return fmt.Errorf("undefined CreateChangefeed")
+	}
+	return c.CreateChangefeedFn(tc, config)
+}
+
+func (c *FakeTiCDCControl) UpdateChangefeed(tc *v1alpha1.TidbCluster, id string, config *ChangefeedConfig) error {
+	if c.UpdateChangefeedFn == nil {
+		return fmt.Errorf("undefined UpdateChangefeed")
+	}
+	return c.UpdateChangefeedFn(tc, id, config)
+}
+
+func (c *FakeTiCDCControl) PauseChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	if c.PauseChangefeedFn == nil {
+		return fmt.Errorf("undefined PauseChangefeed")
+	}
+	return c.PauseChangefeedFn(tc, id)
+}
+
+func (c *FakeTiCDCControl) ResumeChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	if c.ResumeChangefeedFn == nil {
+		return fmt.Errorf("undefined ResumeChangefeed")
+	}
+	return c.ResumeChangefeedFn(tc, id)
+}
+
+func (c *FakeTiCDCControl) RemoveChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	if c.RemoveChangefeedFn == nil {
+		return fmt.Errorf("undefined RemoveChangefeed")
+	}
+	return c.RemoveChangefeedFn(tc, id)
+}
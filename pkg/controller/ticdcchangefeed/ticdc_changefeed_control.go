@@ -0,0 +1,373 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticdcchangefeed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+const (
+	// changefeedInvalid is the event reason when the TiCDCChangefeed is invalid
+	changefeedInvalid = "Invalid"
+	// changefeedCreated is the event reason when the changefeed is created in TiCDC
+	changefeedCreated = "Created"
+	// changefeedRecreated is the event reason when the changefeed removed outside is created again
+	changefeedRecreated = "Recreated"
+	// changefeedUpdated is the event reason when the changefeed is updated in TiCDC
+	changefeedUpdated = "Updated"
+	// changefeedRemoved is the event reason when the changefeed is removed from TiCDC
+	changefeedRemoved = "Removed"
+)
+
+// ControlInterface abstracts the business logic for TiCDCChangefeed reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.TiCDCChangefeed) error
+}
+
+// NewChangefeedControl returns a ControlInterface which reconciles the changefeed against the TiCDC open API
+func NewChangefeedControl(deps *controller.Dependencies, lister listers.TiCDCChangefeedLister) ControlInterface {
+	return &defaultChangefeedControl{
+		deps:   deps,
+		lister: lister,
+	}
+}
+
+type defaultChangefeedControl struct {
+	deps   *controller.Dependencies
+	lister listers.TiCDCChangefeedLister
+}
+
+func (c *defaultChangefeedControl) Reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	if cf.DeletionTimestamp != nil {
+		return c.removeChangefeed(cf)
+	}
+	if err := c.addProtectionFinalizer(cf); err != nil {
+		return err
+	}
+
+	oldStatus := cf.Status.DeepCopy()
+	err := c.reconcile(cf)
+	if err != nil {
+		cf.Status.Message = err.Error()
+	}
+
+	if !apiequality.Semantic.DeepEqual(&cf.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(cf.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+	return err
+}
+
+func (c *defaultChangefeedControl) reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	ns := cf.GetNamespace()
+	name := cf.GetName()
+
+	if errs := validation.ValidateTiCDCChangefeed(cf); len(errs) > 0 {
+		c.invalid(cf, errs.ToAggregate().Error())
+		return nil
+	}
+	id := cf.GetChangefeedID()
+	if cf.Status.ChangefeedID != "" && cf.Status.ChangefeedID != id {
+		c.invalid(cf, fmt.Sprintf("changefeedID can not be changed from %s to %s", cf.Status.ChangefeedID, id))
+		return nil
+	}
+
+	tc, err := c.getTidbCluster(cf)
+	if err != nil {
+		return err
+	}
+	config, err := c.buildChangefeedConfig(cf)
+	if err != nil {
+		return err
+	}
+
+	info, err := c.deps.CDCControl.GetChangefeed(tc, id)
+	if err != nil {
+		return fmt.Errorf("TiCDCChangefeed: [%s/%s], get changefeed %s failed, err: %v", ns, name, id, err)
+	}
+
+	if info == nil {
+		config.ID = id
+		config.StartTs = cf.Spec.StartTs
+		reason := changefeedCreated
+		if cf.Status.ChangefeedID != "" {
+			// the changefeed is removed outside, it continues from the last checkpoint
+			reason = changefeedRecreated
+			if cf.Status.CheckpointTs > config.StartTs {
+				config.StartTs = cf.Status.CheckpointTs
+			}
+		}
+		if err := c.deps.CDCControl.CreateChangefeed(tc, config); err != nil {
+			return fmt.Errorf("TiCDCChangefeed: [%s/%s], create changefeed %s failed, err: %v", ns, name, id, err)
+		}
+		klog.Infof("TiCDCChangefeed: [%s/%s], changefeed %s is created", ns, name, id)
+		c.deps.Recorder.Eventf(cf, corev1.EventTypeNormal, reason, "changefeed %s is created", id)
+		if cf.Spec.Paused {
+			if err := c.deps.CDCControl.PauseChangefeed(tc, id); err != nil {
+				return fmt.Errorf("TiCDCChangefeed: [%s/%s], pause changefeed %s failed, err: %v", ns, name, id, err)
+			}
+		}
+		cf.Status.ChangefeedID = id
+		cf.Status.ObservedGeneration = cf.Generation
+		cf.Status.Message = ""
+		return controller.RequeueErrorf("TiCDCChangefeed: [%s/%s], changefeed %s is created", ns, name, id)
+	}
+
+	cf.Status.ChangefeedID = id
+	state := v1alpha1.TiCDCChangefeedState(info.State)
+	switch {
+	case cf.Status.ObservedGeneration != cf.Generation:
+		// the changefeed can be updated only when it is paused
+		if state != v1alpha1.TiCDCChangefeedStateStopped {
+			if err := c.deps.CDCControl.PauseChangefeed(tc, id); err != nil {
+				return fmt.Errorf("TiCDCChangefeed: [%s/%s], pause changefeed %s failed, err: %v", ns, name, id, err)
+			}
+		}
+		if err := c.deps.CDCControl.UpdateChangefeed(tc, id, config); err != nil {
+			return fmt.Errorf("TiCDCChangefeed: [%s/%s], update changefeed %s failed, err: %v", ns, name, id, err)
+		}
+		if !cf.Spec.Paused {
+			if err := c.deps.CDCControl.ResumeChangefeed(tc, id); err != nil {
+				return fmt.Errorf("TiCDCChangefeed: [%s/%s], resume changefeed %s failed, err: %v", ns, name, id, err)
+			}
+		}
+		klog.Infof("TiCDCChangefeed: [%s/%s], changefeed %s is updated", ns, name, id)
+		c.deps.Recorder.Eventf(cf, corev1.EventTypeNormal, changefeedUpdated, "changefeed %s is updated", id)
+		cf.Status.ObservedGeneration = cf.Generation
+		cf.Status.Message = ""
+		return controller.RequeueErrorf("TiCDCChangefeed: [%s/%s], changefeed %s is updated", ns, name, id)
+	case cf.Spec.Paused && state != v1alpha1.TiCDCChangefeedStateStopped && state != v1alpha1.TiCDCChangefeedStateFinished:
+		if err := c.deps.CDCControl.PauseChangefeed(tc, id); err != nil {
+			return fmt.Errorf("TiCDCChangefeed: [%s/%s], pause changefeed %s failed, err: %v", ns, name, id, err)
+		}
+		return controller.RequeueErrorf("TiCDCChangefeed: [%s/%s], changefeed %s is pausing", ns, name, id)
+	case !cf.Spec.Paused && state == v1alpha1.TiCDCChangefeedStateStopped:
+		if err := c.deps.CDCControl.ResumeChangefeed(tc, id); err != nil {
+			return fmt.Errorf("TiCDCChangefeed: [%s/%s], resume changefeed %s failed, err: %v", ns, name, id, err)
+		}
+		return controller.RequeueErrorf("TiCDCChangefeed: [%s/%s], changefeed %s is resuming", ns, name, id)
+	}
+
+	cf.Status.State = state
+	cf.Status.CheckpointTs = info.CheckpointTs
+	cf.Status.CheckpointTime = info.CheckpointTime
+	cf.Status.Message = ""
+	if info.Error != nil {
+		cf.Status.Message = fmt.Sprintf("[%s] %s", info.Error.Code, info.Error.Message)
+	}
+	return nil
+}
+
+func (c *defaultChangefeedControl) invalid(cf *v1alpha1.TiCDCChangefeed, msg string) {
+	if cf.Status.Message == msg {
+		return
+	}
+	klog.Errorf("TiCDCChangefeed: [%s/%s] is invalid: %s", cf.Namespace, cf.Name, msg)
+	c.deps.Recorder.Event(cf, corev1.EventTypeWarning, changefeedInvalid, msg)
+	cf.Status.Message = msg
+}
+
+func (c *defaultChangefeedControl) getTidbCluster(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TidbCluster, error) {
+	ns := cf.GetNamespace()
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(cf.Spec.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("TiCDCChangefeed: [%s/%s], get tidbcluster %s failed, err: %v", ns, cf.Name, cf.Spec.Cluster, err)
+	}
+	if tc.Spec.TiCDC == nil || tc.Spec.TiCDC.Replicas <= 0 {
+		return nil, fmt.Errorf("TiCDCChangefeed: [%s/%s], ticdc of tidbcluster %s is not deployed", ns, cf.Name, cf.Spec.Cluster)
+	}
+	return tc, nil
+}
+
+// buildChangefeedConfig builds the changefeed config without the ID and the start ts, which are only set on creation
+func (c *defaultChangefeedControl) buildChangefeedConfig(cf *v1alpha1.TiCDCChangefeed) (*controller.ChangefeedConfig, error) {
+	config := &controller.ChangefeedConfig{
+		SinkURI:  cf.Spec.SinkURI,
+		TargetTs: cf.Spec.TargetTs,
+	}
+	if ref := cf.Spec.SinkURISecretRef; ref != nil {
+		secret, err := c.deps.SecretLister.Secrets(cf.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("TiCDCChangefeed: [%s/%s], get sink uri secret %s failed, err: %v", cf.Namespace, cf.Name, ref.Name, err)
+		}
+		uri, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("TiCDCChangefeed: [%s/%s], key %s is not found in sink uri secret %s", cf.Namespace, cf.Name, ref.Key, ref.Name)
+		}
+		config.SinkURI = strings.TrimSpace(string(uri))
+	}
+	config.ReplicaConfig = buildReplicaConfig(&cf.Spec)
+	return config, nil
+}
+
+// buildReplicaConfig converts the TOML config and the filter to the replica config of the open API,
+// whose keys are in snake case instead of the kebab case of the TOML config.
+func buildReplicaConfig(spec *v1alpha1.TiCDCChangefeedSpec) map[string]interface{} {
+	replicaConfig := map[string]interface{}{}
+	if spec.Config != nil {
+		replicaConfig = toOpenAPIKeys(spec.Config.Inner()).(map[string]interface{})
+	}
+	if f := spec.Filter; f != nil && (len(f.Rules) > 0 || len(f.IgnoreTxnStartTs) > 0) {
+		filter, ok := replicaConfig["filter"].(map[string]interface{})
+		if !ok {
+			filter = map[string]interface{}{}
+		}
+		if len(f.Rules) > 0 {
+			filter["rules"] = f.Rules
+		}
+		if len(f.IgnoreTxnStartTs) > 0 {
+			filter["ignore_txn_start_ts"] = f.IgnoreTxnStartTs
+		}
+		replicaConfig["filter"] = filter
+	}
+	if len(replicaConfig) == 0 {
+		return nil
+	}
+	return replicaConfig
+}
+
+func toOpenAPIKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[strings.ReplaceAll(key, "-", "_")] = toOpenAPIKeys(value)
+		}
+		return m
+	case []map[string]interface{}:
+		s := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			s = append(s, toOpenAPIKeys(value))
+		}
+		return s
+	case []interface{}:
+		s := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			s = append(s, toOpenAPIKeys(value))
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// addProtectionFinalizer adds the finalizer so that the changefeed is removed from TiCDC before the CR is deleted
+func (c *defaultChangefeedControl) addProtectionFinalizer(cf *v1alpha1.TiCDCChangefeed) error {
+	if slice.ContainsString(cf.Finalizers, label.ChangefeedProtectionFinalizer, nil) {
+		return nil
+	}
+	cf.Finalizers = append(cf.Finalizers, label.ChangefeedProtectionFinalizer)
+	updated, err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(cf.Namespace).Update(context.TODO(), cf, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("add ticdc changefeed %s/%s protection finalizers failed, err: %v", cf.Namespace, cf.Name, err)
+	}
+	updated.DeepCopyInto(cf)
+	return nil
+}
+
+// removeChangefeed removes the changefeed from TiCDC and then removes the finalizer, the finalizer
+// is removed directly if the tidbcluster or its TiCDC is gone.
+func (c *defaultChangefeedControl) removeChangefeed(cf *v1alpha1.TiCDCChangefeed) error {
+	ns := cf.GetNamespace()
+	name := cf.GetName()
+
+	if !slice.ContainsString(cf.Finalizers, label.ChangefeedProtectionFinalizer, nil) {
+		return nil
+	}
+
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(cf.Spec.Cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("TiCDCChangefeed: [%s/%s], get tidbcluster %s failed, err: %v", ns, name, cf.Spec.Cluster, err)
+	}
+	if err == nil && tc.DeletionTimestamp == nil && tc.Spec.TiCDC != nil && tc.Spec.TiCDC.Replicas > 0 && cf.Status.ChangefeedID != "" {
+		if err := c.deps.CDCControl.RemoveChangefeed(tc, cf.Status.ChangefeedID); err != nil {
+			return fmt.Errorf("TiCDCChangefeed: [%s/%s], remove changefeed %s failed, err: %v", ns, name, cf.Status.ChangefeedID, err)
+		}
+		klog.Infof("TiCDCChangefeed: [%s/%s], changefeed %s is removed", ns, name, cf.Status.ChangefeedID)
+		c.deps.Recorder.Eventf(cf, corev1.EventTypeNormal, changefeedRemoved, "changefeed %s is removed", cf.Status.ChangefeedID)
+	}
+
+	cf.Finalizers = slice.RemoveString(cf.Finalizers, label.ChangefeedProtectionFinalizer, nil)
+	if _, err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Update(context.TODO(), cf, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("remove ticdc changefeed %s/%s protection finalizers failed, err: %v", ns, name, err)
+	}
+	return nil
+}
+
+func (c *defaultChangefeedControl) updateStatus(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TiCDCChangefeed, error) {
+	var (
+		ns     = cf.GetNamespace()
+		name   = cf.GetName()
+		status = cf.Status.DeepCopy()
+		update *v1alpha1.TiCDCChangefeed
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).UpdateStatus(context.TODO(), cf, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.V(4).Infof("TiCDCChangefeed: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("TiCDCChangefeed: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		if updated, err := c.lister.TiCDCChangefeeds(ns).Get(name); err == nil {
+			cf = updated.DeepCopy()
+			cf.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TiCDCChangefeed %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("TiCDCChangefeed: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+type FakeChangefeedControl struct {
+	reconcile func(*v1alpha1.TiCDCChangefeed) error
+}
+
+func (c *FakeChangefeedControl) MockReconcile(reconcile func(*v1alpha1.TiCDCChangefeed) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeChangefeedControl) Reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	if c.reconcile != nil {
+		return c.reconcile(cf)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticdcchangefeed
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildReplicaConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	spec := &v1alpha1.TiCDCChangefeedSpec{}
+	g.Expect(buildReplicaConfig(spec)).To(BeNil())
+
+	spec.Config = config.New(map[string]interface{}{})
+	spec.Config.Set("case-sensitive", true)
+	spec.Config.Set("filter.ignore-txn-start-ts", []interface{}{int64(1)})
+	spec.Config.Set("sink.dispatchers", []interface{}{map[string]interface{}{"matcher": []interface{}{"test.*"}, "partition-by": "ts"}})
+	spec.Filter = &v1alpha1.TiCDCChangefeedFilter{Rules: []string{"test.*"}}
+
+	replicaConfig := buildReplicaConfig(spec)
+	g.Expect(replicaConfig).To(HaveKeyWithValue("case_sensitive", true))
+	g.Expect(replicaConfig["filter"]).To(Equal(map[string]interface{}{
+		"ignore_txn_start_ts": []interface{}{int64(1)},
+		"rules":               []string{"test.*"},
+	}))
+	dispatcher := replicaConfig["sink"].(map[string]interface{})["dispatchers"].([]interface{})[0]
+	g.Expect(dispatcher).To(HaveKeyWithValue("partition_by", "ts"))
+}
+
+func TestChangefeedControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	informer := deps.InformerFactory.Pingcap().V1alpha1().TiCDCChangefeeds()
+	control := NewChangefeedControl(deps, informer.Lister()).(*defaultChangefeedControl)
+	cdcControl := deps.CDCControl.(*controller.FakeTiCDCControl)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.TidbClusterSpec{TiCDC: &v1alpha1.TiCDCSpec{Replicas: 1}},
+	}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+
+	cf := &v1alpha1.TiCDCChangefeed{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf", Namespace: corev1.NamespaceDefault, Generation: 1},
+		Spec: v1alpha1.TiCDCChangefeedSpec{
+			Cluster: "tc",
+			SinkURI: "blackhole://",
+			StartTs: 100,
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(cf.Namespace).Create(context.TODO(), cf, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(informer.Informer().GetIndexer().Add(cf)).To(Succeed())
+
+	var changefeed *controller.ChangefeedInfo
+	var calls []string
+	cdcControl.GetChangefeedFn = func(tc *v1alpha1.TidbCluster, id string) (*controller.ChangefeedInfo, error) {
+		return changefeed, nil
+	}
+	cdcControl.CreateChangefeedFn = func(tc *v1alpha1.TidbCluster, config *controller.ChangefeedConfig) error {
+		calls = append(calls, "create")
+		g.Expect(config.ID).To(Equal("cf"))
+		g.Expect(config.SinkURI).To(Equal("blackhole://"))
+		g.Expect(config.StartTs).To(Equal(uint64(100)))
+		changefeed = &controller.ChangefeedInfo{ID: config.ID, State: "normal", CheckpointTs: config.StartTs}
+		return nil
+	}
+	cdcControl.PauseChangefeedFn = func(tc *v1alpha1.TidbCluster, id string) error {
+		calls = append(calls, "pause")
+		changefeed.State = "stopped"
+		return nil
+	}
+	cdcControl.UpdateChangefeedFn = func(tc *v1alpha1.TidbCluster, id string, config *controller.ChangefeedConfig) error {
+		calls = append(calls, "update")
+		g.Expect(config.StartTs).To(BeZero())
+		g.Expect(config.SinkURI).To(Equal("kafka://broker:9092/topic"))
+		return nil
+	}
+	cdcControl.ResumeChangefeedFn = func(tc *v1alpha1.TidbCluster, id string) error {
+		calls = append(calls, "resume")
+		changefeed.State = "normal"
+		return nil
+	}
+	cdcControl.RemoveChangefeedFn = func(tc *v1alpha1.TidbCluster, id string) error {
+		calls = append(calls, "remove")
+		changefeed = nil
+		return nil
+	}
+
+	// the changefeed is created
+	err = control.Reconcile(cf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"create"}))
+	g.Expect(cf.Finalizers).To(ContainElement(label.ChangefeedProtectionFinalizer))
+	g.Expect(cf.Status.ChangefeedID).To(Equal("cf"))
+	g.Expect(cf.Status.ObservedGeneration).To(Equal(int64(1)))
+
+	// the status reflects the checkpoint and the error reported by TiCDC
+	changefeed.CheckpointTs = 200
+	changefeed.State = "warning"
+	changefeed.Error = &controller.ChangefeedError{Code: "CDC:ErrSinkURIInvalid", Message: "sink is unavailable"}
+	g.Expect(control.Reconcile(cf)).To(Succeed())
+	g.Expect(cf.Status.State).To(Equal(v1alpha1.TiCDCChangefeedStateWarning))
+	g.Expect(cf.Status.CheckpointTs).To(Equal(uint64(200)))
+	g.Expect(cf.Status.Message).To(ContainSubstring("sink is unavailable"))
+	updated, err := deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(cf.Namespace).Get(context.TODO(), cf.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(updated.Status.CheckpointTs).To(Equal(uint64(200)))
+
+	// the changed spec is applied by pausing, updating and resuming the changefeed
+	calls = nil
+	changefeed.Error = nil
+	cf.Spec.SinkURI = "kafka://broker:9092/topic"
+	cf.Generation = 2
+	err = control.Reconcile(cf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"pause", "update", "resume"}))
+	g.Expect(cf.Status.ObservedGeneration).To(Equal(int64(2)))
+
+	// the changefeed is paused
+	calls = nil
+	cf.Spec.Paused = true
+	err = control.Reconcile(cf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(calls).To(Equal([]string{"pause"}))
+	g.Expect(control.Reconcile(cf)).To(Succeed())
+	g.Expect(cf.IsChangefeedPaused()).To(BeTrue())
+	g.Expect(cf.Status.Message).To(BeEmpty())
+
+	// the changefeed is removed before the finalizer is removed
+	calls = nil
+	now := metav1.Now()
+	cf.DeletionTimestamp = &now
+	g.Expect(control.Reconcile(cf)).To(Succeed())
+	g.Expect(calls).To(Equal([]string{"remove"}))
+	g.Expect(cf.Finalizers).NotTo(ContainElement(label.ChangefeedProtectionFinalizer))
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// NodeController watches the nodes and annotates the PD and TiKV pods on the nodes being drained,
+// so that the PodController transfers the PD leader and evicts the TiKV region leaders off them
+// before they are evicted by the drain.
+type NodeController struct {
+	deps  *controller.Dependencies
+	queue workqueue.RateLimitingInterface
+}
+
+// NewNodeController creates a NodeController.
+func NewNodeController(deps *controller.Dependencies) *NodeController {
+	c := &NodeController{
+		deps: deps,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidbcluster nodes",
+		),
+	}
+
+	nodesInformer := deps.KubeInformerFactory.Core().V1().Nodes()
+	nodesInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueNode,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueNode(cur)
+		},
+	})
+
+	return c
+}
+
+func (c *NodeController) enqueueNode(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Cound't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Name returns the name of the NodeController.
+func (c *NodeController) Name() string {
+	return "tidbcluster-node"
+}
+
+// Run the controller.
+func (c *NodeController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidbcluster node controller")
+	defer klog.Info("Shutting down tidbcluster node controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *NodeController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *NodeController) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	if err := c.sync(key.(string)); err != nil {
+		utilruntime.HandleError(fmt.Errorf("TidbCluster node: %v, sync failed %v, requeuing", key.(string), err))
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *NodeController) sync(key string) error {
+	node, err := c.deps.NodeLister.Get(key)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	draining := isNodeDraining(node, c.deps.CLIConfig.NodeDrainTaintKey)
+
+	startTime := time.Now()
+	defer func() {
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(time.Since(startTime).Seconds())
+	}()
+
+	selector := labels.SelectorFromSet(labels.Set{label.ManagedByLabelKey: label.TiDBOperator})
+	componentReq, err := labels.NewRequirement(label.ComponentLabelKey, selection.In, []string{label.PDLabelVal, label.TiKVLabelVal})
+	if err != nil {
+		return err
+	}
+	pods, err := c.deps.PodLister.List(selector.Add(*componentReq))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node.Name || pod.DeletionTimestamp != nil {
+			continue
+		}
+		annKey, annValue := v1alpha1.EvictLeaderAnnKeyForNodeDrain, v1alpha1.EvictLeaderValueNone
+		if pod.Labels[label.ComponentLabelKey] == label.PDLabelVal {
+			annKey, annValue = v1alpha1.PDLeaderTransferAnnKeyForNodeDrain, v1alpha1.TransferLeaderValueNone
+		}
+		if err := c.updateNodeDrainAnn(pod, annKey, annValue, draining); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// updateNodeDrainAnn adds the annotation to the pod if the node is being drained, and removes it otherwise
+func (c *NodeController) updateNodeDrainAnn(pod *corev1.Pod, key, value string, draining bool) error {
+	_, exist := pod.Annotations[key]
+	if draining == exist {
+		return nil
+	}
+
+	pod = pod.DeepCopy()
+	if draining {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[key] = value
+		klog.Infof("node %s is being drained, add annotation %s to pod %s/%s", pod.Spec.NodeName, key, pod.Namespace, pod.Name)
+	} else {
+		delete(pod.Annotations, key)
+		klog.Infof("node %s is not being drained, remove annotation %s from pod %s/%s", pod.Spec.NodeName, key, pod.Namespace, pod.Name)
+	}
+	_, err := c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update annotation %s of pod %s/%s failed: %v", key, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// isNodeDraining returns whether the node is cordoned or tainted by the given taint key
+func isNodeDraining(node *corev1.Node, taintKey string) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	if taintKey == "" {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == taintKey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbcluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeControllerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	deps := controller.NewFakeDependencies()
+	deps.CLIConfig.NodeDrainTaintKey = "example.com/draining"
+	c := NewNodeController(deps)
+
+	tc := newTidbCluster()
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	tikvPod := newTiKVPod(tc)
+	tikvPod.Spec.NodeName = node.Name
+	pdPod := newTiKVPod(tc)
+	pdPod.Name = controller.PDMemberName(tc.Name) + "-0"
+	pdPod.Labels[label.ComponentLabelKey] = label.PDLabelVal
+	pdPod.Spec.NodeName = node.Name
+	otherPod := newTiKVPod(tc)
+	otherPod.Name = controller.TiKVMemberName(tc.Name) + "-1"
+	otherPod.Spec.NodeName = "node-2"
+	for _, pod := range []*corev1.Pod{tikvPod, pdPod, otherPod} {
+		_, err := deps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+	}
+
+	syncNode := func() {
+		g.Expect(deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer().Update(node)).To(Succeed())
+		g.Expect(c.sync(node.Name)).To(Succeed())
+		// refresh the cache with the updated pods
+		for _, pod := range []*corev1.Pod{tikvPod, pdPod, otherPod} {
+			updated, err := deps.KubeClientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			g.Expect(err).To(Succeed())
+			g.Expect(deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Update(updated)).To(Succeed())
+		}
+	}
+	getAnnotations := func(pod *corev1.Pod) map[string]string {
+		updated, err := deps.KubeClientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		return updated.Annotations
+	}
+
+	// the node is not being drained
+	syncNode()
+	g.Expect(getAnnotations(tikvPod)).NotTo(HaveKey(v1alpha1.EvictLeaderAnnKeyForNodeDrain))
+	g.Expect(getAnnotations(pdPod)).NotTo(HaveKey(v1alpha1.PDLeaderTransferAnnKeyForNodeDrain))
+
+	// the node is cordoned
+	node.Spec.Unschedulable = true
+	syncNode()
+	g.Expect(getAnnotations(tikvPod)).To(HaveKeyWithValue(v1alpha1.EvictLeaderAnnKeyForNodeDrain, v1alpha1.EvictLeaderValueNone))
+	g.Expect(getAnnotations(pdPod)).To(HaveKeyWithValue(v1alpha1.PDLeaderTransferAnnKeyForNodeDrain, v1alpha1.TransferLeaderValueNone))
+	g.Expect(getAnnotations(otherPod)).NotTo(HaveKey(v1alpha1.EvictLeaderAnnKeyForNodeDrain))
+
+	// the node is uncordoned but tainted
+	node.Spec.Unschedulable = false
+	node.Spec.Taints = []corev1.Taint{{Key: "example.com/draining", Effect: corev1.TaintEffectNoSchedule}}
+	syncNode()
+	g.Expect(getAnnotations(tikvPod)).To(HaveKey(v1alpha1.EvictLeaderAnnKeyForNodeDrain))
+
+	// the node is back to normal
+	node.Spec.Taints = nil
+	syncNode()
+	g.Expect(getAnnotations(tikvPod)).NotTo(HaveKey(v1alpha1.EvictLeaderAnnKeyForNodeDrain))
+	g.Expect(getAnnotations(pdPod)).NotTo(HaveKey(v1alpha1.PDLeaderTransferAnnKeyForNodeDrain))
+}
@@ -233,7 +233,7 @@ func (c *PodController) getPDClient(tc *v1alpha1.TidbCluster) pdapi.PDClient {
 }
 
 func (c *PodController) syncPDPod(ctx context.Context, pod *corev1.Pod, tc *v1alpha1.TidbCluster) (reconcile.Result, error) {
-	value, ok := needPDLeaderTransfer(pod)
+	key, value, ok := needPDLeaderTransfer(pod)
 	if !ok {
 		// No need to transfer leader
 		return reconcile.Result{}, nil
@@ -243,7 +243,7 @@ func (c *PodController) syncPDPod(ctx context.Context, pod *corev1.Pod, tc *v1al
 	case v1alpha1.TransferLeaderValueNone:
 	case v1alpha1.TransferLeaderValueDeletePod:
 	default:
-		klog.Warningf("Ignore unknown value %q of annotation %q for Pod %s/%s", value, v1alpha1.PDLeaderTransferAnnKey, pod.Namespace, pod.Name)
+		klog.Warningf("Ignore unknown value %q of annotation %q for Pod %s/%s", value, key, pod.Namespace, pod.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -415,6 +415,22 @@ func (c *PodController) syncTiKVPod(ctx context.Context, pod *corev1.Pod, tc *v1
 }
 
 func (c *PodController) syncTiDBPod(ctx context.Context, pod *corev1.Pod, tc *v1alpha1.TidbCluster) (reconcile.Result, error) {
+	result, err := c.syncTiDBPodDeletion(ctx, pod, tc)
+	if err != nil || needDeleteTiDBPod(pod) != "" {
+		return result, err
+	}
+
+	recheckAfter, err := member.SyncTiDBSQLReadinessGate(ctx, c.deps, tc, pod)
+	if err != nil {
+		return reconcile.Result{}, perrors.Annotatef(err, "failed to sync the SQL readiness gate of pod %q", pod.Name)
+	}
+	if recheckAfter > 0 && (result.RequeueAfter == 0 || recheckAfter < result.RequeueAfter) {
+		result.RequeueAfter = recheckAfter
+	}
+	return result, nil
+}
+
+func (c *PodController) syncTiDBPodDeletion(ctx context.Context, pod *corev1.Pod, tc *v1alpha1.TidbCluster) (reconcile.Result, error) {
 	value := needDeleteTiDBPod(pod)
 	if value == "" {
 		// No need to delete tidb pod
@@ -470,12 +486,15 @@ func needEvictLeader(pod *corev1.Pod) (string, string, bool) {
 	return "", "", false
 }
 
-func needPDLeaderTransfer(pod *corev1.Pod) (string, bool) {
-	value, exist := pod.Annotations[v1alpha1.PDLeaderTransferAnnKey]
-	if !exist {
-		return "", false
+func needPDLeaderTransfer(pod *corev1.Pod) (string, string, bool) {
+	for _, key := range v1alpha1.PDLeaderTransferAnnKeys {
+		value, exist := pod.Annotations[key]
+		if exist {
+			return key, value, true
+		}
 	}
-	return value, true
+
+	return "", "", false
 }
 
 func safeToRestartPD(tc *v1alpha1.TidbCluster) bool {
@@ -43,6 +43,10 @@ func (c *kvClient) GetLeaderCount() (int, error) {
 	return int(count), nil
 }
 
+func (c *kvClient) ModifyConfig(config map[string]interface{}) error {
+	return nil
+}
+
 func TestTiKVPodSync(t *testing.T) {
 	interval := time.Millisecond * 100
 	timeout := time.Minute * 1
@@ -14,10 +14,14 @@
 package tidbcluster
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 )
 
 // TidbClusterConditionUpdater interface that translates cluster state into
@@ -33,6 +37,9 @@ var _ TidbClusterConditionUpdater = &tidbClusterConditionUpdater{}
 
 func (u *tidbClusterConditionUpdater) Update(tc *v1alpha1.TidbCluster) error {
 	u.updateReadyCondition(tc)
+	u.updateProgressingCondition(tc)
+	u.updateDegradedCondition(tc)
+	tc.Status.ObservedGeneration = tc.Generation
 	// in the future, we may return error when we need to Kubernetes API, etc.
 	return nil
 }
@@ -65,6 +72,9 @@ func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbClus
 	case tc.Spec.TiKV != nil && !tc.TiKVAllStoresReady():
 		reason = utiltidbcluster.TiKVStoreNotUp
 		message = "TiKV store(s) are not up"
+	case tc.Spec.TiKV != nil && meta.IsStatusConditionTrue(tc.Status.TiKV.Conditions, v1alpha1.ComponentRegionBalancing):
+		reason = utiltidbcluster.TiKVRegionNotBalanced
+		message = "TiKV regions are being balanced after scaling out"
 	case tc.Spec.TiDB != nil && !tc.TiDBAllMembersReady():
 		reason = utiltidbcluster.TiDBUnhealthy
 		message = "TiDB(s) are not healthy"
@@ -82,3 +92,77 @@ func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbClus
 	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterReady, status, reason, message)
 	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
 }
+
+// progressingComponents returns the components in the Upgrade or Scale phase
+func progressingComponents(tc *v1alpha1.TidbCluster) []string {
+	var components []string
+	for _, status := range tc.AllComponentStatus() {
+		if phase := status.GetPhase(); phase == v1alpha1.UpgradePhase || phase == v1alpha1.ScalePhase {
+			components = append(components, fmt.Sprintf("%s is in %s phase", status.MemberType(), phase))
+		}
+	}
+	return components
+}
+
+func (u *tidbClusterConditionUpdater) updateProgressingCondition(tc *v1alpha1.TidbCluster) {
+	status := v1.ConditionTrue
+	reason := ""
+	message := ""
+
+	components := progressingComponents(tc)
+	switch {
+	case !allStatefulSetsAreUpToDate(tc):
+		reason = utiltidbcluster.StatfulSetNotUpToDate
+		message = "Statefulset(s) are in progress"
+	case len(components) > 0:
+		reason = utiltidbcluster.ComponentsProgressing
+		message = strings.Join(components, ", ")
+	default:
+		status = v1.ConditionFalse
+		reason = utiltidbcluster.RolloutComplete
+		message = "All components are rolled out"
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterProgressing, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
+// failureComponents returns the components which have failure members or stores
+func failureComponents(tc *v1alpha1.TidbCluster) []string {
+	var components []string
+	if n := len(tc.Status.PD.FailureMembers); n > 0 {
+		components = append(components, fmt.Sprintf("%d PD failure member(s)", n))
+	}
+	if n := len(tc.Status.TiKV.FailureStores); n > 0 {
+		components = append(components, fmt.Sprintf("%d TiKV failure store(s)", n))
+	}
+	if n := len(tc.Status.TiDB.FailureMembers); n > 0 {
+		components = append(components, fmt.Sprintf("%d TiDB failure member(s)", n))
+	}
+	if n := len(tc.Status.TiFlash.FailureStores); n > 0 {
+		components = append(components, fmt.Sprintf("%d TiFlash failure store(s)", n))
+	}
+	return components
+}
+
+func (u *tidbClusterConditionUpdater) updateDegradedCondition(tc *v1alpha1.TidbCluster) {
+	status := v1.ConditionTrue
+	reason := ""
+	message := ""
+
+	blocked := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterBootstrapBlocked)
+	components := failureComponents(tc)
+	switch {
+	case blocked != nil && blocked.Status == v1.ConditionTrue:
+		reason = utiltidbcluster.BootstrapBlocked
+		message = blocked.Message
+	case len(components) > 0:
+		reason = utiltidbcluster.FailureMembersFound
+		message = strings.Join(components, ", ")
+	default:
+		status = v1.ConditionFalse
+		reason = utiltidbcluster.AsExpected
+		message = "No failure member is found"
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDegraded, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
@@ -21,6 +21,7 @@ import (
 	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
@@ -151,6 +152,59 @@ func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
 			wantReason:  utiltidbcluster.TiKVStoreNotUp,
 			wantMessage: "TiKV store(s) are not up",
 		},
+		{
+			name: "tikv regions not balanced",
+			tc: &v1alpha1.TidbCluster{
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 1,
+					},
+					TiKV: &v1alpha1.TiKVSpec{
+						Replicas: 1,
+					},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"pd-0": {
+								Health: true,
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+					TiDB: v1alpha1.TiDBStatus{
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+					TiKV: v1alpha1.TiKVStatus{
+						Stores: map[string]v1alpha1.TiKVStore{
+							"tikv-0": {
+								State: "Up",
+							},
+						},
+						Conditions: []metav1.Condition{
+							{
+								Type:   v1alpha1.ComponentRegionBalancing,
+								Status: metav1.ConditionTrue,
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+				},
+			},
+			wantStatus:  v1.ConditionFalse,
+			wantReason:  utiltidbcluster.TiKVRegionNotBalanced,
+			wantMessage: "TiKV regions are being balanced after scaling out",
+		},
 		{
 			name: "tidb(s) not healthy",
 			tc: &v1alpha1.TidbCluster{
@@ -428,3 +482,102 @@ func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
 		})
 	}
 }
+
+func TestTidbClusterConditionUpdater_ProgressingAndDegraded(t *testing.T) {
+	tests := []struct {
+		name                  string
+		tc                    *v1alpha1.TidbCluster
+		wantProgressing       v1.ConditionStatus
+		wantProgressingReason string
+		wantDegraded          v1.ConditionStatus
+		wantDegradedReason    string
+	}{
+		{
+			name: "statfulset(s) not up to date",
+			tc: &v1alpha1.TidbCluster{
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "1",
+							UpdateRevision:  "2",
+						},
+					},
+				},
+			},
+			wantProgressing:       v1.ConditionTrue,
+			wantProgressingReason: utiltidbcluster.StatfulSetNotUpToDate,
+			wantDegraded:          v1.ConditionFalse,
+			wantDegradedReason:    utiltidbcluster.AsExpected,
+		},
+		{
+			name: "tikv is scaling with failure stores",
+			tc: &v1alpha1.TidbCluster{
+				Spec: v1alpha1.TidbClusterSpec{
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					TiKV: v1alpha1.TiKVStatus{
+						Phase: v1alpha1.ScalePhase,
+						FailureStores: map[string]v1alpha1.TiKVFailureStore{
+							"1": {PodName: "tikv-1"},
+						},
+					},
+				},
+			},
+			wantProgressing:       v1.ConditionTrue,
+			wantProgressingReason: utiltidbcluster.ComponentsProgressing,
+			wantDegraded:          v1.ConditionTrue,
+			wantDegradedReason:    utiltidbcluster.FailureMembersFound,
+		},
+		{
+			name: "bootstrap is blocked",
+			tc: &v1alpha1.TidbCluster{
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					Conditions: []v1alpha1.TidbClusterCondition{
+						{
+							Type:   v1alpha1.TidbClusterBootstrapBlocked,
+							Status: v1.ConditionTrue,
+							Reason: utiltidbcluster.StaleVolumesFound,
+						},
+					},
+				},
+			},
+			wantProgressing:       v1.ConditionFalse,
+			wantProgressingReason: utiltidbcluster.RolloutComplete,
+			wantDegraded:          v1.ConditionTrue,
+			wantDegradedReason:    utiltidbcluster.BootstrapBlocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tc.Generation = 2
+			conditionUpdater := &tidbClusterConditionUpdater{}
+			conditionUpdater.Update(tt.tc)
+			cond := utiltidbcluster.GetTidbClusterCondition(tt.tc.Status, v1alpha1.TidbClusterProgressing)
+			if diff := cmp.Diff(tt.wantProgressing, cond.Status); diff != "" {
+				t.Errorf("unexpected progressing status (-want, +got): %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantProgressingReason, cond.Reason); diff != "" {
+				t.Errorf("unexpected progressing reason (-want, +got): %s", diff)
+			}
+			cond = utiltidbcluster.GetTidbClusterCondition(tt.tc.Status, v1alpha1.TidbClusterDegraded)
+			if diff := cmp.Diff(tt.wantDegraded, cond.Status); diff != "" {
+				t.Errorf("unexpected degraded status (-want, +got): %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDegradedReason, cond.Reason); diff != "" {
+				t.Errorf("unexpected degraded reason (-want, +got): %s", diff)
+			}
+			if tt.tc.Status.ObservedGeneration != 2 {
+				t.Errorf("unexpected observed generation %d", tt.tc.Status.ObservedGeneration)
+			}
+		})
+	}
+}
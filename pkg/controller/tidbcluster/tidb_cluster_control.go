@@ -14,12 +14,16 @@
 package tidbcluster
 
 import (
+	"fmt"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
@@ -29,6 +33,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/upgrader"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 )
 
 // ControlInterface implements the control logic for updating TidbClusters and their children StatefulSets.
@@ -44,61 +50,108 @@ type ControlInterface interface {
 func NewDefaultTidbClusterControl(
 	tcControl controller.TidbClusterControlInterface,
 	pdMemberManager manager.Manager,
+	pdMSMemberManager manager.Manager,
 	tikvMemberManager manager.Manager,
+	tikvGroupManager manager.Manager,
 	tidbMemberManager manager.Manager,
 	tiproxyMemberManager manager.Manager,
 	reclaimPolicyManager manager.Manager,
 	metaManager manager.Manager,
 	orphanPodsCleaner member.OrphanPodsCleaner,
 	pvcCleaner member.PVCCleanerInterface,
-	// pvcResizer member.PVCResizerInterface,
+	pvcResizer member.PVCResizerInterface,
 	pvcModifier volumes.PVCModifierInterface,
 	pumpMemberManager manager.Manager,
 	tiflashMemberManager manager.Manager,
 	ticdcMemberManager manager.Manager,
+	pdbManager manager.Manager,
+	tidbConnectionSecretManager manager.Manager,
 	discoveryManager member.TidbDiscoveryManager,
 	tidbClusterStatusManager manager.Manager,
+	costEstimateManager manager.Manager,
+	statusCompactionManager member.StatusCompactionManager,
+	airGapManager manager.Manager,
+	tlsPolicyManager manager.Manager,
+	tlsCertManager manager.Manager,
+	tlsRotationManager manager.Manager,
+	pdRecoveryManager manager.Manager,
+	gcTuningManager manager.Manager,
+	operationTimeoutManager manager.Manager,
+	peerRegistryManager manager.Manager,
+	secretReplicationManager manager.Manager,
 	conditionUpdater TidbClusterConditionUpdater,
 	recorder record.EventRecorder) ControlInterface {
 	return &defaultTidbClusterControl{
-		tcControl:                tcControl,
-		pdMemberManager:          pdMemberManager,
-		tikvMemberManager:        tikvMemberManager,
-		tidbMemberManager:        tidbMemberManager,
-		tiproxyMemberManager:     tiproxyMemberManager,
-		reclaimPolicyManager:     reclaimPolicyManager,
-		metaManager:              metaManager,
-		orphanPodsCleaner:        orphanPodsCleaner,
-		pvcCleaner:               pvcCleaner,
-		pvcModifier:              pvcModifier,
-		pumpMemberManager:        pumpMemberManager,
-		tiflashMemberManager:     tiflashMemberManager,
-		ticdcMemberManager:       ticdcMemberManager,
-		discoveryManager:         discoveryManager,
-		tidbClusterStatusManager: tidbClusterStatusManager,
-		conditionUpdater:         conditionUpdater,
-		recorder:                 recorder,
+		tcControl:                   tcControl,
+		pdMemberManager:             pdMemberManager,
+		pdMSMemberManager:           pdMSMemberManager,
+		tikvMemberManager:           tikvMemberManager,
+		tikvGroupManager:            tikvGroupManager,
+		tidbMemberManager:           tidbMemberManager,
+		tiproxyMemberManager:        tiproxyMemberManager,
+		reclaimPolicyManager:        reclaimPolicyManager,
+		metaManager:                 metaManager,
+		orphanPodsCleaner:           orphanPodsCleaner,
+		pvcCleaner:                  pvcCleaner,
+		pvcResizer:                  pvcResizer,
+		pvcModifier:                 pvcModifier,
+		pumpMemberManager:           pumpMemberManager,
+		tiflashMemberManager:        tiflashMemberManager,
+		ticdcMemberManager:          ticdcMemberManager,
+		pdbManager:                  pdbManager,
+		tidbConnectionSecretManager: tidbConnectionSecretManager,
+		discoveryManager:            discoveryManager,
+		tidbClusterStatusManager:    tidbClusterStatusManager,
+		costEstimateManager:         costEstimateManager,
+		statusCompactionManager:     statusCompactionManager,
+		airGapManager:               airGapManager,
+		tlsPolicyManager:            tlsPolicyManager,
+		tlsCertManager:              tlsCertManager,
+		tlsRotationManager:          tlsRotationManager,
+		pdRecoveryManager:           pdRecoveryManager,
+		gcTuningManager:             gcTuningManager,
+		operationTimeoutManager:     operationTimeoutManager,
+		peerRegistryManager:         peerRegistryManager,
+		secretReplicationManager:    secretReplicationManager,
+		conditionUpdater:            conditionUpdater,
+		recorder:                    recorder,
 	}
 }
 
 type defaultTidbClusterControl struct {
-	tcControl                controller.TidbClusterControlInterface
-	pdMemberManager          manager.Manager
-	tikvMemberManager        manager.Manager
-	tidbMemberManager        manager.Manager
-	tiproxyMemberManager     manager.Manager
-	reclaimPolicyManager     manager.Manager
-	metaManager              manager.Manager
-	orphanPodsCleaner        member.OrphanPodsCleaner
-	pvcCleaner               member.PVCCleanerInterface
-	pvcModifier              volumes.PVCModifierInterface
-	pumpMemberManager        manager.Manager
-	tiflashMemberManager     manager.Manager
-	ticdcMemberManager       manager.Manager
-	discoveryManager         member.TidbDiscoveryManager
-	tidbClusterStatusManager manager.Manager
-	conditionUpdater         TidbClusterConditionUpdater
-	recorder                 record.EventRecorder
+	tcControl                   controller.TidbClusterControlInterface
+	pdMemberManager             manager.Manager
+	pdMSMemberManager           manager.Manager
+	tikvMemberManager           manager.Manager
+	tikvGroupManager            manager.Manager
+	tidbMemberManager           manager.Manager
+	tiproxyMemberManager        manager.Manager
+	reclaimPolicyManager        manager.Manager
+	metaManager                 manager.Manager
+	orphanPodsCleaner           member.OrphanPodsCleaner
+	pvcCleaner                  member.PVCCleanerInterface
+	pvcResizer                  member.PVCResizerInterface
+	pvcModifier                 volumes.PVCModifierInterface
+	pumpMemberManager           manager.Manager
+	tiflashMemberManager        manager.Manager
+	ticdcMemberManager          manager.Manager
+	pdbManager                  manager.Manager
+	tidbConnectionSecretManager manager.Manager
+	discoveryManager            member.TidbDiscoveryManager
+	tidbClusterStatusManager    manager.Manager
+	costEstimateManager         manager.Manager
+	statusCompactionManager     member.StatusCompactionManager
+	airGapManager               manager.Manager
+	tlsPolicyManager            manager.Manager
+	tlsCertManager              manager.Manager
+	tlsRotationManager          manager.Manager
+	pdRecoveryManager           manager.Manager
+	gcTuningManager             manager.Manager
+	operationTimeoutManager     manager.Manager
+	peerRegistryManager         manager.Manager
+	secretReplicationManager    manager.Manager
+	conditionUpdater            TidbClusterConditionUpdater
+	recorder                    record.EventRecorder
 }
 
 // UpdateStatefulSet executes the core logic loop for a tidbcluster.
@@ -107,21 +160,66 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 	if !c.validate(tc) {
 		return nil // fatal error, no need to retry on invalid object
 	}
+	if c.isHeldByPreflight(tc) {
+		return nil // resumed after the hold annotation is acknowledged, which triggers a new sync
+	}
 
 	var errs []error
 	oldStatus := tc.Status.DeepCopy()
 
+	// restoring the stores and members moved out of the status by the status compaction of the last sync,
+	// they are compacted again after all the status and conditions are synced
+	if err := c.statusCompactionManager.Restore(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "status_compaction").Inc()
+		return err
+	}
+
 	if err := c.updateTidbCluster(tc); err != nil {
 		errs = append(errs, err)
 	}
 
+	// reporting the TLS secrets which are not compliant with the crypto policy in the FIPS mode,
+	// it does not block the sync because the secrets are managed by the users
+	if err := c.tlsPolicyManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "tls_policy").Inc()
+		errs = append(errs, err)
+	}
+
+	// replicating the secrets of the referenced cluster into the namespace of the heterogeneous cluster, it does
+	// not block the sync because the components wait for the replicas to be mounted
+	if err := c.secretReplicationManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "secret_replication").Inc()
+		errs = append(errs, err)
+	}
+
+	// registering the cluster deployed across Kubernetes clusters with the peer registry, it does not block
+	// the sync because the registry is only required to bootstrap PD
+	if err := c.peerRegistryManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "peer_registry").Inc()
+		errs = append(errs, err)
+	}
+
+	// tracking the upgrade and scaling of the components with the phases synced above, it does not block
+	// the sync because the member managers may keep failing when the operations are stuck
+	if err := c.operationTimeoutManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "operation_timeout").Inc()
+		errs = append(errs, err)
+	}
+
 	if err := c.conditionUpdater.Update(tc); err != nil {
 		errs = append(errs, err)
 	}
 
+	// compacting the status of very large clusters after all the status and conditions are synced
+	if err := c.statusCompactionManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "status_compaction").Inc()
+		errs = append(errs, err)
+	}
+
 	if apiequality.Semantic.DeepEqual(&tc.Status, oldStatus) {
 		return errorutils.NewAggregate(errs)
 	}
+	c.recordUpgradeFinished(tc, oldStatus)
 	if _, err := c.tcControl.UpdateTidbCluster(tc.DeepCopy(), &tc.Status, oldStatus); err != nil {
 		errs = append(errs, err)
 	}
@@ -129,6 +227,55 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 	return errorutils.NewAggregate(errs)
 }
 
+// recordUpgradeFinished records an event for each component whose upgrade is finished in this round,
+// so that the end of the upgrade can be forwarded to the notification sinks.
+func (c *defaultTidbClusterControl) recordUpgradeFinished(tc *v1alpha1.TidbCluster, oldStatus *v1alpha1.TidbClusterStatus) {
+	phases := []struct {
+		memberType v1alpha1.MemberType
+		old, new   v1alpha1.MemberPhase
+	}{
+		{v1alpha1.PDMemberType, oldStatus.PD.Phase, tc.Status.PD.Phase},
+		{v1alpha1.TiKVMemberType, oldStatus.TiKV.Phase, tc.Status.TiKV.Phase},
+		{v1alpha1.TiDBMemberType, oldStatus.TiDB.Phase, tc.Status.TiDB.Phase},
+		{v1alpha1.TiFlashMemberType, oldStatus.TiFlash.Phase, tc.Status.TiFlash.Phase},
+		{v1alpha1.TiCDCMemberType, oldStatus.TiCDC.Phase, tc.Status.TiCDC.Phase},
+		{v1alpha1.PumpMemberType, oldStatus.Pump.Phase, tc.Status.Pump.Phase},
+	}
+	for _, p := range phases {
+		if p.old == v1alpha1.UpgradePhase && p.new == v1alpha1.NormalPhase {
+			c.recorder.Eventf(tc, v1.EventTypeNormal, "UpgradeFinished", "%s is upgraded successfully", p.memberType)
+		}
+	}
+}
+
+// syncMember syncs the component by the member manager and records the reconcile metrics of it
+func syncMember(tc *v1alpha1.TidbCluster, component string, m manager.Manager) error {
+	return member.ObserveReconcilePhase(tc, component, member.ReconcilePhaseSync, func() error {
+		return m.Sync(tc)
+	})
+}
+
+// syncDiscoveryReadyCondition sets the DiscoveryReady condition by the result of reconciling the discovery,
+// the condition is left as is if the discovery is not required by the cluster
+func syncDiscoveryReadyCondition(tc *v1alpha1.TidbCluster, result *manager.Result, err error) {
+	if result == nil {
+		return
+	}
+	var cond *v1alpha1.TidbClusterCondition
+	switch {
+	case result.Failed() != nil:
+		cond = utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDiscoveryReady, v1.ConditionFalse,
+			utiltidbcluster.DiscoveryReconcileFailed, fmt.Sprintf("failed to reconcile %s/%s: %v", result.Failed().Kind, result.Failed().Name, err))
+	case !result.Ready():
+		cond = utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDiscoveryReady, v1.ConditionFalse,
+			utiltidbcluster.DiscoveryNotAvailable, fmt.Sprintf("waiting on %s", strings.Join(result.WaitingOn, "; ")))
+	default:
+		cond = utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDiscoveryReady, v1.ConditionTrue,
+			utiltidbcluster.DiscoveryAvailable, "discovery is available")
+	}
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
 func (c *defaultTidbClusterControl) validate(tc *v1alpha1.TidbCluster) bool {
 	errs := v1alpha1validation.ValidateTidbCluster(tc)
 	if len(errs) > 0 {
@@ -140,6 +287,19 @@ func (c *defaultTidbClusterControl) validate(tc *v1alpha1.TidbCluster) bool {
 	return true
 }
 
+// isHeldByPreflight returns whether the reconciliation of the cluster is held by the preflight on startup
+// until the changes of the operator version are acknowledged
+func (c *defaultTidbClusterControl) isHeldByPreflight(tc *v1alpha1.TidbCluster) bool {
+	holdVersion := upgrader.HeldByPreflight(tc)
+	if holdVersion == "" {
+		return false
+	}
+	klog.Warningf("tidb cluster %s/%s is held by the preflight of operator %s", tc.GetNamespace(), tc.GetName(), holdVersion)
+	c.recorder.Eventf(tc, v1.EventTypeWarning, "PreflightHeld", "reconciliation is held until the changes of operator %s are acknowledged by annotation %s=%s",
+		holdVersion, label.AnnPreflightAckKey, holdVersion)
+	return true
+}
+
 func (c *defaultTidbClusterControl) defaulting(tc *v1alpha1.TidbCluster) {
 	defaulting.SetTidbClusterDefault(tc)
 }
@@ -150,6 +310,15 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	// aligning the status of the overrides with spec.overrides, they are applied by the member managers
+	member.SyncResourceOverrideStatus(tc)
+
+	// validating the images and versions of all the components in the air-gapped mode
+	if err := c.airGapManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "air_gap").Inc()
+		return err
+	}
+
 	// syncing all PVs managed by operator's reclaim policy to Retain
 	if err := c.reclaimPolicyManager.Sync(tc); err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pv_reclaim_policy").Inc()
@@ -169,12 +338,38 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
-	// reconcile TiDB discovery service
-	if err := c.discoveryManager.Reconcile(tc); err != nil {
+	// creating the cert-manager Certificates of the cluster TLS secrets before the components mount them
+	if err := c.tlsCertManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tls_cert").Inc()
+		return err
+	}
+
+	// applying the renewed certs of the cluster TLS secrets before the components are synced, the pods
+	// of the components which can not reload the certs are rolled by the upgraders
+	if err := c.tlsRotationManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tls_rotation").Inc()
+		return err
+	}
+
+	// reconcile TiDB discovery service, and report the stage of it by the DiscoveryReady condition
+	var result *manager.Result
+	err = member.ObserveReconcilePhase(tc, "discovery", member.ReconcilePhaseSync, func() (err error) {
+		result, err = c.discoveryManager.Reconcile(tc)
+		return err
+	})
+	syncDiscoveryReadyCondition(tc, result, err)
+	if err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "discovery").Inc()
 		return err
 	}
 
+	// recovering PD from the survivor after the majority of PD members are lost, which
+	// is triggered by annotation and blocks the sync of the components until it is finished
+	if err := c.pdRecoveryManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pd_recovery").Inc()
+		return err
+	}
+
 	// works that should be done to make the pd cluster current state match the desired state:
 	//   - create or update the pd service
 	//   - create or update the pd headless service
@@ -186,78 +381,40 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 	//   - upgrade the pd cluster
 	//   - scale out/in the pd cluster
 	//   - failover the pd cluster
-	if err := c.pdMemberManager.Sync(tc); err != nil {
+	if err := syncMember(tc, "pd", c.pdMemberManager); err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pd").Inc()
 		return err
 	}
 
-	// works that should be done to make the tiproxy cluster current state match the desired state:
-	//   - create or update the tiproxy service
-	//   - create or update the tiproxy headless service
-	//   - create the tiproxy statefulset
-	//   - sync tiproxy cluster status from tiproxy to TidbCluster object
-	//   - upgrade the tiproxy cluster
-	//   - scale out/in the tiproxy cluster
-	//   - failover the tiproxy cluster
-	if err := c.tiproxyMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tiproxy").Inc()
-		return err
-	}
-
-	// works that should be done to make the tiflash cluster current state match the desired state:
-	//   - waiting for the tidb cluster available
-	//   - create or update tiflash headless service
-	//   - create the tiflash statefulset
-	//   - sync tiflash cluster status from pd to TidbCluster object
-	//   - set scheduler labels to tiflash stores
-	//   - upgrade the tiflash cluster
-	//   - scale out/in the tiflash cluster
-	//   - failover the tiflash cluster
-	if err := c.tiflashMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tiflash").Inc()
-		return err
-	}
-
-	// works that should be done to make the tikv cluster current state match the desired state:
-	//   - waiting for the pd cluster available(pd cluster is in quorum)
-	//   - create or update tikv headless service
-	//   - create the tikv statefulset
-	//   - sync tikv cluster status from pd to TidbCluster object
-	//   - set scheduler labels to tikv stores
-	//   - upgrade the tikv cluster
-	//   - scale out/in the tikv cluster
-	//   - failover the tikv cluster
-	if err := c.tikvMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tikv").Inc()
-		return err
+	// the components after pd are synced concurrently if enabled, and the upgraders keep the upgrade ordering
+	// of them by waiting for the upstream components
+	if features.DefaultFeatureGate.Enabled(features.ConcurrentReconcile) {
+		if err := member.SyncComponentsConcurrently(tc, c.componentSyncers()); err != nil {
+			return err
+		}
+	} else {
+		for _, syncer := range c.componentSyncers() {
+			if err := syncer.Sync(tc); err != nil {
+				return err
+			}
+		}
 	}
 
-	// syncing the pump cluster
-	if err := c.pumpMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pump").Inc()
+	// syncing the PodDisruptionBudgets of pd, tikv, tidb, tiflash and ticdc
+	if err := c.pdbManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pdb").Inc()
 		return err
 	}
 
-	// works that should be done to make the tidb cluster current state match the desired state:
-	//   - waiting for the tikv cluster available(at least one peer works)
-	//   - create or update tidb headless service
-	//   - create the tidb statefulset
-	//   - sync tidb cluster status from pd to TidbCluster object
-	//   - upgrade the tidb cluster
-	//   - scale out/in the tidb cluster
-	//   - failover the tidb cluster
-	if err := c.tidbMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tidb").Inc()
+	// publishing the Secret which contains the connection info of tidb for applications
+	if err := c.tidbConnectionSecretManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "tidb_connection_secret").Inc()
 		return err
 	}
 
-	// works that should be done to make the ticdc cluster current state match the desired state:
-	//   - waiting for the pd cluster available(pd cluster is in quorum)
-	//   - waiting for the tikv cluster available(at least one peer works)
-	//   - create or update ticdc deployment
-	//   - sync ticdc cluster status from pd to TidbCluster object
-	if err := c.ticdcMemberManager.Sync(tc); err != nil {
-		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "ticdc").Inc()
+	// adjusting the gc settings in the windows of the gc tuning policy, and restoring them after the windows
+	if err := c.gcTuningManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "gc_tuning").Inc()
 		return err
 	}
 
@@ -282,12 +439,23 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
-	// modify volumes if necessary
+	// modify volumes if necessary, or only expand the PVCs online if volume modifying is disabled
 	if features.DefaultFeatureGate.Enabled(features.VolumeModifying) {
 		if err := c.pvcModifier.Sync(tc); err != nil {
 			metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_modifier").Inc()
 			return err
 		}
+	} else {
+		if err := c.pvcResizer.Sync(tc); err != nil {
+			metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_resizer").Inc()
+			return err
+		}
+	}
+
+	// estimating the monthly cost of each component by the price sheet
+	if err := c.costEstimateManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "cost_estimate").Inc()
+		return err
 	}
 
 	// syncing the some tidbcluster status attributes
@@ -299,6 +467,98 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 	return err
 }
 
+// componentSyncers returns the syncers of the components synced after pd, in the order of syncing them sequentially
+func (c *defaultTidbClusterControl) componentSyncers() []member.ComponentSyncer {
+	return []member.ComponentSyncer{
+		// works that should be done to make the pd microservices current state match the desired state:
+		//   - waiting for the pd cluster available
+		//   - create or update the service and headless service of each microservice
+		//   - create or update the statefulset of each microservice
+		//   - sync the status of each microservice to TidbCluster object
+		memberSyncer("pdms", c.pdMSMemberManager),
+
+		// works that should be done to make the tiproxy cluster current state match the desired state:
+		//   - create or update the tiproxy service
+		//   - create or update the tiproxy headless service
+		//   - create the tiproxy statefulset
+		//   - sync tiproxy cluster status from tiproxy to TidbCluster object
+		//   - upgrade the tiproxy cluster
+		//   - scale out/in the tiproxy cluster
+		//   - failover the tiproxy cluster
+		memberSyncer("tiproxy", c.tiproxyMemberManager),
+
+		// works that should be done to make the tiflash cluster current state match the desired state:
+		//   - waiting for the tidb cluster available
+		//   - create or update tiflash headless service
+		//   - create the tiflash statefulset
+		//   - sync tiflash cluster status from pd to TidbCluster object
+		//   - set scheduler labels to tiflash stores
+		//   - upgrade the tiflash cluster
+		//   - scale out/in the tiflash cluster
+		//   - failover the tiflash cluster
+		memberSyncer("tiflash", c.tiflashMemberManager),
+
+		// works that should be done to make the tikv cluster current state match the desired state:
+		//   - waiting for the pd cluster available(pd cluster is in quorum)
+		//   - create or update tikv headless service
+		//   - create the tikv statefulset
+		//   - sync tikv cluster status from pd to TidbCluster object
+		//   - set scheduler labels to tikv stores
+		//   - upgrade the tikv cluster
+		//   - scale out/in the tikv cluster
+		//   - failover the tikv cluster
+		//   - deploy each TiKV group by a heterogeneous TidbCluster owned by this cluster,
+		//     and scale in the removed groups before deleting them
+		{
+			Component: "tikv",
+			Sync: func(tc *v1alpha1.TidbCluster) error {
+				if err := memberSyncer("tikv", c.tikvMemberManager).Sync(tc); err != nil {
+					return err
+				}
+				if err := c.tikvGroupManager.Sync(tc); err != nil {
+					metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), "tikv_group").Inc()
+					return err
+				}
+				return nil
+			},
+		},
+
+		// syncing the pump cluster
+		memberSyncer("pump", c.pumpMemberManager),
+
+		// works that should be done to make the tidb cluster current state match the desired state:
+		//   - waiting for the tikv cluster available(at least one peer works)
+		//   - create or update tidb headless service
+		//   - create the tidb statefulset
+		//   - sync tidb cluster status from pd to TidbCluster object
+		//   - upgrade the tidb cluster
+		//   - scale out/in the tidb cluster
+		//   - failover the tidb cluster
+		memberSyncer("tidb", c.tidbMemberManager),
+
+		// works that should be done to make the ticdc cluster current state match the desired state:
+		//   - waiting for the pd cluster available(pd cluster is in quorum)
+		//   - waiting for the tikv cluster available(at least one peer works)
+		//   - create or update ticdc deployment
+		//   - sync ticdc cluster status from pd to TidbCluster object
+		memberSyncer("ticdc", c.ticdcMemberManager),
+	}
+}
+
+// memberSyncer returns the syncer of the component synced by the member manager
+func memberSyncer(component string, m manager.Manager) member.ComponentSyncer {
+	return member.ComponentSyncer{
+		Component: component,
+		Sync: func(tc *v1alpha1.TidbCluster) error {
+			if err := syncMember(tc, component, m); err != nil {
+				metrics.ClusterUpdateErrors.WithLabelValues(tc.GetNamespace(), tc.GetName(), component).Inc()
+				return err
+			}
+			return nil
+		},
+	}
+}
+
 func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
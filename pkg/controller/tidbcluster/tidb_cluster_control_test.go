@@ -23,8 +23,10 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
 	mm "github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/manager/meta"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -310,7 +312,9 @@ func newFakeTidbClusterControl() (
 
 	tcUpdater := controller.NewFakeTidbClusterControl(tcInformer)
 	pdMemberManager := mm.NewFakePDMemberManager()
+	pdMSMemberManager := mm.NewFakePDMSMemberManager()
 	tikvMemberManager := mm.NewFakeTiKVMemberManager()
+	tikvGroupManager := mm.NewFakeTiKVGroupManager()
 	tidbMemberManager := mm.NewFakeTiDBMemberManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
 	metaManager := meta.NewFakeMetaManager()
@@ -320,13 +324,28 @@ func newFakeTidbClusterControl() (
 	tiflashMemberManager := mm.NewFakeTiFlashMemberManager()
 	tiproxyMemberManager := mm.NewFakeTiProxyMemberManager()
 	ticdcMemberManager := mm.NewFakeTiCDCMemberManager()
+	pdbManager := mm.NewFakePDBManager()
+	tidbConnectionSecretManager := mm.NewFakeTiDBConnectionSecretManager()
 	discoveryManager := mm.NewFakeDiscoveryManger()
 	statusManager := mm.NewFakeTidbClusterStatusManager()
+	costEstimateManager := mm.NewFakeCostEstimateManager()
+	statusCompactionManager := mm.NewFakeStatusCompactionManager()
+	airGapManager := mm.NewFakeAirGapManager()
+	tlsPolicyManager := mm.NewFakeTLSPolicyManager()
+	tlsCertManager := mm.NewFakeTLSCertManager()
+	tlsRotationManager := mm.NewFakeTLSRotationManager()
+	pdRecoveryManager := mm.NewFakePDRecoveryManager()
+	gcTuningManager := mm.NewFakeGCTuningManager()
+	operationTimeoutManager := mm.NewFakeOperationTimeoutManager()
+	peerRegistryManager := mm.NewFakePeerRegistryManager()
+	secretReplicationManager := mm.NewFakeSecretReplicationManager()
 	pvcResizer := mm.NewFakePVCResizer()
 	control := NewDefaultTidbClusterControl(
 		tcUpdater,
 		pdMemberManager,
+		pdMSMemberManager,
 		tikvMemberManager,
+		tikvGroupManager,
 		tidbMemberManager,
 		tiproxyMemberManager,
 		reclaimPolicyManager,
@@ -334,11 +353,25 @@ func newFakeTidbClusterControl() (
 		orphanPodCleaner,
 		pvcCleaner,
 		pvcResizer,
+		pvcResizer,
 		pumpMemberManager,
 		tiflashMemberManager,
 		ticdcMemberManager,
+		pdbManager,
+		tidbConnectionSecretManager,
 		discoveryManager,
 		statusManager,
+		costEstimateManager,
+		statusCompactionManager,
+		airGapManager,
+		tlsPolicyManager,
+		tlsCertManager,
+		tlsRotationManager,
+		pdRecoveryManager,
+		gcTuningManager,
+		operationTimeoutManager,
+		peerRegistryManager,
+		secretReplicationManager,
 		&tidbClusterConditionUpdater{},
 		recorder,
 	)
@@ -387,3 +420,34 @@ func newTidbClusterForTidbClusterControl() *v1alpha1.TidbCluster {
 		},
 	}
 }
+
+func TestSyncDiscoveryReadyCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	syncDiscoveryReadyCondition(tc, nil, nil)
+	g.Expect(utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDiscoveryReady)).To(BeNil())
+
+	result := &manager.Result{}
+	result.Add("Role", "test-discovery", manager.ResourceUnchanged)
+	result.Add("ServiceAccount", "test-discovery", manager.ResourceFailed)
+	syncDiscoveryReadyCondition(tc, result, fmt.Errorf("API server down"))
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDiscoveryReady)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.DiscoveryReconcileFailed))
+	g.Expect(cond.Message).To(ContainSubstring("ServiceAccount/test-discovery"))
+
+	result = &manager.Result{}
+	result.Add("Deployment", "test-discovery", manager.ResourceCreated)
+	result.Wait("Deployment/test-discovery to be available")
+	syncDiscoveryReadyCondition(tc, result, nil)
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDiscoveryReady)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.DiscoveryNotAvailable))
+
+	result.WaitingOn = nil
+	syncDiscoveryReadyCondition(tc, result, nil)
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDiscoveryReady)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.DiscoveryAvailable))
+}
@@ -19,6 +19,8 @@ import (
 
 	perrors "github.com/pingcap/errors"
 	apps "k8s.io/api/apps/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -55,20 +57,36 @@ func NewController(deps *controller.Dependencies) *Controller {
 		deps: deps,
 		control: NewDefaultTidbClusterControl(
 			deps.TiDBClusterControl,
-			mm.NewPDMemberManager(deps, mm.NewPDScaler(deps), mm.NewPDUpgrader(deps), mm.NewPDFailover(deps), suspender, podVolumeModifier),
-			mm.NewTiKVMemberManager(deps, mm.NewTiKVFailover(deps), mm.NewTiKVScaler(deps), mm.NewTiKVUpgrader(deps, podVolumeModifier), suspender, podVolumeModifier),
-			mm.NewTiDBMemberManager(deps, mm.NewTiDBScaler(deps), mm.NewTiDBUpgrader(deps), mm.NewTiDBFailover(deps), suspender, podVolumeModifier),
-			mm.NewTiProxyMemberManager(deps, mm.NewTiProxyScaler(deps), mm.NewTiProxyUpgrader(deps), suspender),
+			mm.NewPDMemberManager(deps, mm.NewAbortableScaler(mm.NewPDScaler(deps), v1alpha1.PDMemberType), mm.NewAbortableUpgrader(mm.NewPDUpgrader(deps), v1alpha1.PDMemberType), mm.NewPDFailover(deps), suspender, podVolumeModifier),
+			mm.NewPDMSMemberManager(deps),
+			mm.NewTiKVMemberManager(deps, mm.NewTiKVFailover(deps), mm.NewAbortableScaler(mm.NewTiKVScaler(deps), v1alpha1.TiKVMemberType), mm.NewAbortableTiKVUpgrader(mm.NewTiKVUpgrader(deps, podVolumeModifier)), suspender, podVolumeModifier),
+			mm.NewTiKVGroupManager(deps),
+			mm.NewTiDBMemberManager(deps, mm.NewAbortableScaler(mm.NewTiDBScaler(deps), v1alpha1.TiDBMemberType), mm.NewAbortableUpgrader(mm.NewTiDBUpgrader(deps), v1alpha1.TiDBMemberType), mm.NewTiDBFailover(deps), suspender, podVolumeModifier),
+			mm.NewTiProxyMemberManager(deps, mm.NewAbortableScaler(mm.NewTiProxyScaler(deps), v1alpha1.TiProxyMemberType), mm.NewAbortableUpgrader(mm.NewTiProxyUpgrader(deps), v1alpha1.TiProxyMemberType), suspender),
 			meta.NewReclaimPolicyManager(deps),
 			meta.NewMetaManager(deps),
 			mm.NewOrphanPodsCleaner(deps),
 			mm.NewRealPVCCleaner(deps),
+			mm.NewPVCResizer(deps),
 			volumes.NewPVCModifier(deps),
-			mm.NewPumpMemberManager(deps, mm.NewPumpScaler(deps), suspender, podVolumeModifier),
-			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewTiFlashScaler(deps), mm.NewTiFlashUpgrader(deps), suspender, podVolumeModifier),
-			mm.NewTiCDCMemberManager(deps, mm.NewTiCDCScaler(deps), mm.NewTiCDCUpgrader(deps), suspender, podVolumeModifier),
+			mm.NewPumpMemberManager(deps, mm.NewAbortableScaler(mm.NewPumpScaler(deps), v1alpha1.PumpMemberType), suspender, podVolumeModifier),
+			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewAbortableScaler(mm.NewTiFlashScaler(deps), v1alpha1.TiFlashMemberType), mm.NewAbortableUpgrader(mm.NewTiFlashUpgrader(deps), v1alpha1.TiFlashMemberType), suspender, podVolumeModifier),
+			mm.NewTiCDCMemberManager(deps, mm.NewAbortableScaler(mm.NewTiCDCScaler(deps), v1alpha1.TiCDCMemberType), mm.NewAbortableUpgrader(mm.NewTiCDCUpgrader(deps), v1alpha1.TiCDCMemberType), suspender, podVolumeModifier),
+			mm.NewPDBManager(deps),
+			mm.NewTiDBConnectionSecretManager(deps),
 			mm.NewTidbDiscoveryManager(deps),
 			mm.NewTidbClusterStatusManager(deps),
+			mm.NewCostEstimateManager(deps),
+			mm.NewStatusCompactionManager(deps),
+			mm.NewAirGapManager(deps),
+			mm.NewTLSPolicyManager(deps),
+			mm.NewTLSCertManager(deps),
+			mm.NewTLSRotationManager(deps),
+			mm.NewPDRecoveryManager(deps),
+			mm.NewGCTuningManager(deps),
+			mm.NewOperationTimeoutManager(deps),
+			mm.NewPeerRegistryManager(deps),
+			mm.NewSecretReplicationManager(deps),
 			&tidbClusterConditionUpdater{},
 			deps.Recorder,
 		),
@@ -94,6 +112,12 @@ func NewController(deps *controller.Dependencies) *Controller {
 		},
 		DeleteFunc: c.deleteStatefulSet,
 	})
+	if deps.EndpointSliceLister != nil {
+		// pick up the readiness transitions of the members without waiting for the resync
+		deps.KubeInformerFactory.Discovery().V1beta1().EndpointSlices().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: c.updateEndpointSlice,
+		})
+	}
 
 	return c
 }
@@ -164,6 +188,7 @@ func (c *Controller) sync(key string) error {
 	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("TidbCluster has been deleted %v", key)
+		mm.DeleteCostEstimateMetrics(ns, name)
 		return nil
 	}
 	if err != nil {
@@ -262,10 +287,36 @@ func (c *Controller) deleteStatefulSet(obj interface{}) {
 	c.enqueueTidbCluster(tc)
 }
 
-// resolveTidbClusterFromSet returns the TidbCluster by a StatefulSet,
-// or nil if the StatefulSet could not be resolved to a matching TidbCluster
+// updateEndpointSlice adds the tidbcluster for the endpoint slice to the sync queue if the
+// endpoints of the slice are changed.
+func (c *Controller) updateEndpointSlice(old, cur interface{}) {
+	curSlice := cur.(*discoveryv1beta1.EndpointSlice)
+	oldSlice := old.(*discoveryv1beta1.EndpointSlice)
+	if curSlice.ResourceVersion == oldSlice.ResourceVersion || apiequality.Semantic.DeepEqual(curSlice.Endpoints, oldSlice.Endpoints) {
+		return
+	}
+
+	ns := curSlice.GetNamespace()
+	svcName := curSlice.Labels[discoveryv1beta1.LabelServiceName]
+	if svcName == "" {
+		return
+	}
+	svc, err := c.deps.ServiceLister.Services(ns).Get(svcName)
+	if err != nil {
+		return
+	}
+	tc := c.resolveTidbClusterFromSet(ns, svc)
+	if tc == nil {
+		return
+	}
+	klog.V(4).Infof("EndpointSlice %s/%s of service %s updated, TidbCluster: %s/%s", ns, curSlice.GetName(), svcName, ns, tc.Name)
+	c.enqueueTidbCluster(tc)
+}
+
+// resolveTidbClusterFromSet returns the TidbCluster by a StatefulSet or a Service,
+// or nil if the object could not be resolved to a matching TidbCluster
 // of the correct Kind.
-func (c *Controller) resolveTidbClusterFromSet(namespace string, set *apps.StatefulSet) *v1alpha1.TidbCluster {
+func (c *Controller) resolveTidbClusterFromSet(namespace string, set metav1.Object) *v1alpha1.TidbCluster {
 	controllerRef := metav1.GetControllerOf(set)
 	if controllerRef == nil {
 		return nil
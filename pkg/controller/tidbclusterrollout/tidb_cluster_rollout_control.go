@@ -0,0 +1,352 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterrollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// rolloutClusterPatched is the event reason when a cluster is patched by the rollout
+	rolloutClusterPatched = "ClusterPatched"
+	// rolloutClusterFailed is the event reason when a cluster fails in the rollout
+	rolloutClusterFailed = "ClusterFailed"
+	// rolloutHalted is the event reason when the rollout is halted
+	rolloutHalted = "Halted"
+	// rolloutCompleted is the event reason when the rollout is completed
+	rolloutCompleted = "Completed"
+)
+
+// ControlInterface abstracts the business logic for TidbClusterRollout reconciliation.
+type ControlInterface interface {
+	Reconcile(*v1alpha1.TidbClusterRollout) error
+}
+
+// NewTidbClusterRolloutControl returns a ControlInterface which rolls out the patch of TidbClusterRollout
+func NewTidbClusterRolloutControl(deps *controller.Dependencies, rolloutLister listers.TidbClusterRolloutLister) ControlInterface {
+	return &defaultTidbClusterRolloutControl{
+		deps:          deps,
+		rolloutLister: rolloutLister,
+		now:           time.Now,
+	}
+}
+
+type defaultTidbClusterRolloutControl struct {
+	deps          *controller.Dependencies
+	rolloutLister listers.TidbClusterRolloutLister
+	now           func() time.Time
+}
+
+func (c *defaultTidbClusterRolloutControl) Reconcile(r *v1alpha1.TidbClusterRollout) error {
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	switch r.Status.Phase {
+	case v1alpha1.TidbClusterRolloutPhaseHalted, v1alpha1.TidbClusterRolloutPhaseCompleted, v1alpha1.TidbClusterRolloutPhaseFailed:
+		return nil
+	}
+
+	oldStatus := r.Status.DeepCopy()
+	err := c.reconcile(r)
+
+	if !apiequality.Semantic.DeepEqual(&r.Status, oldStatus) {
+		if _, updateErr := c.updateStatus(r.DeepCopy()); updateErr != nil {
+			return updateErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	switch r.Status.Phase {
+	case v1alpha1.TidbClusterRolloutPhaseProgressing, v1alpha1.TidbClusterRolloutPhasePaused:
+		return controller.RequeueErrorf("TidbClusterRollout: [%s/%s] is %s", r.Namespace, r.Name, r.Status.Phase)
+	}
+	return nil
+}
+
+func (c *defaultTidbClusterRolloutControl) reconcile(r *v1alpha1.TidbClusterRollout) error {
+	if r.Status.Phase == "" {
+		if err := c.selectClusters(r); err != nil {
+			return err
+		}
+		if r.Status.Phase != v1alpha1.TidbClusterRolloutPhaseProgressing {
+			return nil
+		}
+	}
+
+	c.checkPatchedClusters(r)
+	if c.halted(r) {
+		return nil
+	}
+
+	// move to the next wave when all clusters of the current wave are finished
+	for waveFinished(r, r.Status.CurrentWave) {
+		if lastWave(r) == r.Status.CurrentWave {
+			r.Status.Phase = v1alpha1.TidbClusterRolloutPhaseCompleted
+			klog.Infof("TidbClusterRollout: [%s/%s], rollout is completed", r.Namespace, r.Name)
+			c.deps.Recorder.Event(r, corev1.EventTypeNormal, rolloutCompleted, "all clusters are rolled out")
+			return nil
+		}
+		r.Status.CurrentWave++
+	}
+
+	if r.Spec.Paused {
+		r.Status.Phase = v1alpha1.TidbClusterRolloutPhasePaused
+		return nil
+	}
+	r.Status.Phase = v1alpha1.TidbClusterRolloutPhaseProgressing
+
+	for i := range r.Status.Clusters {
+		cluster := &r.Status.Clusters[i]
+		if cluster.Wave != r.Status.CurrentWave || cluster.Phase != v1alpha1.TidbClusterRolloutClusterPending {
+			continue
+		}
+		if err := c.patchCluster(r, cluster); err != nil {
+			return err
+		}
+	}
+	c.halted(r)
+	return nil
+}
+
+// selectClusters selects the clusters to roll out from the namespace of the rollout and assigns them to waves
+func (c *defaultTidbClusterRolloutControl) selectClusters(r *v1alpha1.TidbClusterRollout) error {
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(r.Spec.Patch), &patch); err != nil {
+		c.failRollout(r, fmt.Sprintf("patch is not a valid JSON object: %v", err))
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&r.Spec.Selector)
+	if err != nil {
+		c.failRollout(r, fmt.Sprintf("selector is invalid: %v", err))
+		return nil
+	}
+	// the clusters in other namespaces are never patched, the rollout is namespaced
+	tcs, err := c.deps.TiDBClusterLister.TidbClusters(r.Namespace).List(selector)
+	if err != nil {
+		return fmt.Errorf("TidbClusterRollout: [%s/%s], failed to list TidbClusters, error: %v", r.Namespace, r.Name, err)
+	}
+	sort.Slice(tcs, func(i, j int) bool {
+		return tcs[i].Name < tcs[j].Name
+	})
+
+	now := metav1.NewTime(c.now())
+	wave, count := 0, 0
+	r.Status.Clusters = make([]v1alpha1.TidbClusterRolloutClusterStatus, 0, len(tcs))
+	for _, tc := range tcs {
+		if count == r.GetWaveSize(wave) {
+			wave++
+			count = 0
+		}
+		r.Status.Clusters = append(r.Status.Clusters, v1alpha1.TidbClusterRolloutClusterStatus{
+			Namespace:          tc.Namespace,
+			Name:               tc.Name,
+			Wave:               int32(wave),
+			Phase:              v1alpha1.TidbClusterRolloutClusterPending,
+			LastTransitionTime: now,
+		})
+		count++
+	}
+	r.Status.CurrentWave = 0
+	r.Status.Phase = v1alpha1.TidbClusterRolloutPhaseProgressing
+	klog.Infof("TidbClusterRollout: [%s/%s], select %d clusters in %d waves", r.Namespace, r.Name, len(tcs), lastWave(r)+1)
+	return nil
+}
+
+// checkPatchedClusters checks the health of the patched clusters
+func (c *defaultTidbClusterRolloutControl) checkPatchedClusters(r *v1alpha1.TidbClusterRollout) {
+	now := c.now()
+	for i := range r.Status.Clusters {
+		cluster := &r.Status.Clusters[i]
+		if cluster.Phase != v1alpha1.TidbClusterRolloutClusterUpdating {
+			continue
+		}
+		tc, err := c.deps.TiDBClusterLister.TidbClusters(cluster.Namespace).Get(cluster.Name)
+		if errors.IsNotFound(err) {
+			c.failCluster(r, cluster, "the cluster is deleted")
+			continue
+		}
+		if err != nil {
+			klog.Warningf("TidbClusterRollout: [%s/%s], failed to get TidbCluster %s/%s, error: %v", r.Namespace, r.Name, cluster.Namespace, cluster.Name, err)
+			continue
+		}
+
+		elapsed := now.Sub(cluster.LastTransitionTime.Time)
+		if elapsed >= r.GetMinReadySeconds() && isClusterHealthy(tc) {
+			cluster.Phase = v1alpha1.TidbClusterRolloutClusterSucceeded
+			cluster.LastTransitionTime = metav1.NewTime(now)
+			klog.Infof("TidbClusterRollout: [%s/%s], TidbCluster %s/%s is healthy after patched", r.Namespace, r.Name, cluster.Namespace, cluster.Name)
+			continue
+		}
+		if elapsed > r.GetHealthCheckTimeout() {
+			c.failCluster(r, cluster, fmt.Sprintf("the cluster is not healthy in %s", r.GetHealthCheckTimeout()))
+		}
+	}
+}
+
+func (c *defaultTidbClusterRolloutControl) patchCluster(r *v1alpha1.TidbClusterRollout, cluster *v1alpha1.TidbClusterRolloutClusterStatus) error {
+	patch := fmt.Sprintf(`{"spec":%s}`, r.Spec.Patch)
+	_, err := c.deps.Clientset.PingcapV1alpha1().TidbClusters(cluster.Namespace).Patch(context.TODO(), cluster.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if errors.IsNotFound(err) {
+		c.failCluster(r, cluster, "the cluster is deleted")
+		return nil
+	}
+	if errors.IsInvalid(err) || errors.IsBadRequest(err) {
+		c.failCluster(r, cluster, fmt.Sprintf("failed to patch the cluster: %v", err))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("TidbClusterRollout: [%s/%s], failed to patch TidbCluster %s/%s, error: %v", r.Namespace, r.Name, cluster.Namespace, cluster.Name, err)
+	}
+
+	cluster.Phase = v1alpha1.TidbClusterRolloutClusterUpdating
+	cluster.LastTransitionTime = metav1.NewTime(c.now())
+	klog.Infof("TidbClusterRollout: [%s/%s], patch TidbCluster %s/%s in wave %d", r.Namespace, r.Name, cluster.Namespace, cluster.Name, cluster.Wave)
+	c.deps.Recorder.Eventf(r, corev1.EventTypeNormal, rolloutClusterPatched, "patch TidbCluster %s/%s in wave %d", cluster.Namespace, cluster.Name, cluster.Wave)
+	return nil
+}
+
+func (c *defaultTidbClusterRolloutControl) failCluster(r *v1alpha1.TidbClusterRollout, cluster *v1alpha1.TidbClusterRolloutClusterStatus, message string) {
+	cluster.Phase = v1alpha1.TidbClusterRolloutClusterFailed
+	cluster.LastTransitionTime = metav1.NewTime(c.now())
+	cluster.Message = message
+	klog.Warningf("TidbClusterRollout: [%s/%s], TidbCluster %s/%s failed: %s", r.Namespace, r.Name, cluster.Namespace, cluster.Name, message)
+	c.deps.Recorder.Eventf(r, corev1.EventTypeWarning, rolloutClusterFailed, "TidbCluster %s/%s failed: %s", cluster.Namespace, cluster.Name, message)
+}
+
+// failRollout fails the rollout whose spec is invalid
+func (c *defaultTidbClusterRolloutControl) failRollout(r *v1alpha1.TidbClusterRollout, message string) {
+	r.Status.Phase = v1alpha1.TidbClusterRolloutPhaseFailed
+	r.Status.Message = message
+	klog.Errorf("TidbClusterRollout: [%s/%s], %s", r.Namespace, r.Name, message)
+	c.deps.Recorder.Event(r, corev1.EventTypeWarning, "FailedValidation", message)
+}
+
+// halted halts the rollout if the failed clusters exceed maxFailures
+func (c *defaultTidbClusterRolloutControl) halted(r *v1alpha1.TidbClusterRollout) bool {
+	var failed int32
+	for _, cluster := range r.Status.Clusters {
+		if cluster.Phase == v1alpha1.TidbClusterRolloutClusterFailed {
+			failed++
+		}
+	}
+	if failed <= r.Spec.MaxFailures {
+		return false
+	}
+	r.Status.Phase = v1alpha1.TidbClusterRolloutPhaseHalted
+	klog.Warningf("TidbClusterRollout: [%s/%s], %d clusters failed, rollout is halted", r.Namespace, r.Name, failed)
+	c.deps.Recorder.Eventf(r, corev1.EventTypeWarning, rolloutHalted, "%d clusters failed, exceeds maxFailures %d", failed, r.Spec.MaxFailures)
+	return true
+}
+
+func (c *defaultTidbClusterRolloutControl) updateStatus(r *v1alpha1.TidbClusterRollout) (*v1alpha1.TidbClusterRollout, error) {
+	var (
+		ns     = r.GetNamespace()
+		name   = r.GetName()
+		status = r.Status.DeepCopy()
+		update *v1alpha1.TidbClusterRollout
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TidbClusterRollouts(ns).UpdateStatus(context.TODO(), r, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.Infof("TidbClusterRollout: [%s/%s], update status successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("TidbClusterRollout: [%s/%s], update status failed, error: %v", ns, name, updateErr)
+
+		if updated, err := c.rolloutLister.TidbClusterRollouts(ns).Get(name); err == nil {
+			r = updated.DeepCopy()
+			r.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TidbClusterRollout %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("TidbClusterRollout: [%s/%s], failed to updateStatus, error: %v", ns, name, err)
+	}
+
+	return update, err
+}
+
+// isClusterHealthy returns whether the cluster is ready and none of its components is upgrading
+func isClusterHealthy(tc *v1alpha1.TidbCluster) bool {
+	cond := utiltidbcluster.GetTidbClusterReadyCondition(tc.Status)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return false
+	}
+	return !tc.PDUpgrading() && !tc.TiKVUpgrading() && !tc.TiDBUpgrading() && !tc.TiFlashUpgrading() && !tc.TiProxyUpgrading()
+}
+
+func waveFinished(r *v1alpha1.TidbClusterRollout, wave int32) bool {
+	for _, cluster := range r.Status.Clusters {
+		if cluster.Wave != wave {
+			continue
+		}
+		switch cluster.Phase {
+		case v1alpha1.TidbClusterRolloutClusterSucceeded, v1alpha1.TidbClusterRolloutClusterFailed:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func lastWave(r *v1alpha1.TidbClusterRollout) int32 {
+	var wave int32
+	for _, cluster := range r.Status.Clusters {
+		if cluster.Wave > wave {
+			wave = cluster.Wave
+		}
+	}
+	return wave
+}
+
+type FakeTidbClusterRolloutControl struct {
+	reconcile func(*v1alpha1.TidbClusterRollout) error
+}
+
+func (c *FakeTidbClusterRolloutControl) MockReconcile(reconcile func(*v1alpha1.TidbClusterRollout) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTidbClusterRolloutControl) Reconcile(r *v1alpha1.TidbClusterRollout) error {
+	if c.reconcile != nil {
+		return c.reconcile(r)
+	}
+	return nil
+}
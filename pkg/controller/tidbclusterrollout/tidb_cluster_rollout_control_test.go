@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterrollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTidbClusterRolloutControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	rolloutInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterRollouts()
+	rolloutIndexer := rolloutInformer.Informer().GetIndexer()
+	control := NewTidbClusterRolloutControl(deps, rolloutInformer.Lister()).(*defaultTidbClusterRolloutControl)
+	now := time.Now()
+	control.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		tc := &v1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("tc-%d", i),
+				Namespace: corev1.NamespaceDefault,
+				Labels:    map[string]string{"env": "prod"},
+			},
+			Spec: v1alpha1.TidbClusterSpec{Version: "v7.1.0"},
+		}
+		_, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	}
+	// the clusters in other namespaces are not selected
+	g.Expect(tcIndexer.Add(&v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc-0", Namespace: "other", Labels: map[string]string{"env": "prod"}},
+	})).To(Succeed())
+	r := &v1alpha1.TidbClusterRollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterRolloutSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Patch:    `{"version": "v7.5.0"}`,
+			Waves:    []int32{1, 2},
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusterRollouts(r.Namespace).Create(context.TODO(), r, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(rolloutIndexer.Add(r)).To(Succeed())
+
+	reconcile := func() {
+		err := control.Reconcile(r)
+		if r.Status.Phase == v1alpha1.TidbClusterRolloutPhaseProgressing {
+			g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+		} else {
+			g.Expect(err).To(Succeed())
+		}
+	}
+	version := func(name string) string {
+		tc, err := deps.Clientset.PingcapV1alpha1().TidbClusters(corev1.NamespaceDefault).Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		return tc.Spec.Version
+	}
+	setHealthy := func(name string) {
+		obj, _, err := tcIndexer.GetByKey(corev1.NamespaceDefault + "/" + name)
+		g.Expect(err).To(Succeed())
+		tc := obj.(*v1alpha1.TidbCluster).DeepCopy()
+		utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterReady, corev1.ConditionTrue, utiltidbcluster.Ready, ""))
+		g.Expect(tcIndexer.Update(tc)).To(Succeed())
+	}
+
+	// the canary wave
+	reconcile()
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.TidbClusterRolloutPhaseProgressing))
+	g.Expect(r.Status.Clusters).To(HaveLen(4))
+	g.Expect(r.Status.Clusters[0].Phase).To(Equal(v1alpha1.TidbClusterRolloutClusterUpdating))
+	g.Expect(r.Status.Clusters[1].Phase).To(Equal(v1alpha1.TidbClusterRolloutClusterPending))
+	g.Expect([]int32{r.Status.Clusters[1].Wave, r.Status.Clusters[2].Wave, r.Status.Clusters[3].Wave}).To(Equal([]int32{1, 1, 2}))
+	g.Expect(version("tc-0")).To(Equal("v7.5.0"))
+	g.Expect(version("tc-1")).To(Equal("v7.1.0"))
+
+	// the next wave is not started before the canary is healthy
+	setHealthy("tc-0")
+	reconcile()
+	g.Expect(r.Status.CurrentWave).To(Equal(int32(0)))
+
+	now = now.Add(r.GetMinReadySeconds())
+	reconcile()
+	g.Expect(r.Status.Clusters[0].Phase).To(Equal(v1alpha1.TidbClusterRolloutClusterSucceeded))
+	g.Expect(r.Status.CurrentWave).To(Equal(int32(1)))
+	g.Expect(version("tc-1")).To(Equal("v7.5.0"))
+	g.Expect(version("tc-2")).To(Equal("v7.5.0"))
+	g.Expect(version("tc-3")).To(Equal("v7.1.0"))
+
+	// tc-2 is not healthy in time and the rollout is halted
+	setHealthy("tc-1")
+	now = now.Add(r.GetHealthCheckTimeout() + time.Second)
+	reconcile()
+	g.Expect(r.Status.Clusters[1].Phase).To(Equal(v1alpha1.TidbClusterRolloutClusterSucceeded))
+	g.Expect(r.Status.Clusters[2].Phase).To(Equal(v1alpha1.TidbClusterRolloutClusterFailed))
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.TidbClusterRolloutPhaseHalted))
+	g.Expect(version("tc-3")).To(Equal("v7.1.0"))
+
+	// the halted rollout is not resumed
+	reconcile()
+	g.Expect(version("tc-3")).To(Equal("v7.1.0"))
+}
+
+func TestTidbClusterRolloutControlReconcileInvalidPatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	rolloutInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterRollouts()
+	control := NewTidbClusterRolloutControl(deps, rolloutInformer.Lister())
+
+	r := &v1alpha1.TidbClusterRollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterRolloutSpec{
+			Patch: `version: v7.5.0`,
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusterRollouts(r.Namespace).Create(context.TODO(), r, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(rolloutInformer.Informer().GetIndexer().Add(r)).To(Succeed())
+
+	g.Expect(control.Reconcile(r)).To(Succeed())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.TidbClusterRolloutPhaseFailed))
+	g.Expect(r.Status.Message).To(ContainSubstring("patch is not a valid JSON object"))
+	g.Expect(r.Status.Clusters).To(BeEmpty())
+	updated, err := deps.Clientset.PingcapV1alpha1().TidbClusterRollouts(r.Namespace).Get(context.TODO(), r.Name, metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(updated.Status.Phase).To(Equal(v1alpha1.TidbClusterRolloutPhaseFailed))
+
+	// the failed rollout is not reconciled again
+	r.Spec.Patch = `{"version": "v7.5.0"}`
+	g.Expect(control.Reconcile(r)).To(Succeed())
+	g.Expect(r.Status.Phase).To(Equal(v1alpha1.TidbClusterRolloutPhaseFailed))
+}
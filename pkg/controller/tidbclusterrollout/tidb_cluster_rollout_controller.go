@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterrollout
+
+import (
+	"fmt"
+	"time"
+
+	perrors "github.com/pingcap/errors"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller composes informer, queue and worker to a single object.
+// It acts as a high-level manager of async event processing for TidbClusterRollout crd.
+type Controller struct {
+	deps          *controller.Dependencies
+	control       ControlInterface
+	rolloutLister listers.TidbClusterRolloutLister
+	queue         workqueue.RateLimitingInterface
+}
+
+// NewController returns the TidbClusterRollout controller. The informer of TidbClusterRollout
+// is only registered here, so that the CRD is required only when the controller is enabled.
+func NewController(deps *controller.Dependencies) *Controller {
+	rolloutInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterRollouts()
+	rolloutLister := rolloutInformer.Lister()
+
+	c := &Controller{
+		deps:          deps,
+		control:       NewTidbClusterRolloutControl(deps, rolloutLister),
+		rolloutLister: rolloutLister,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidbcluster-rollout",
+		),
+	}
+	controller.WatchForObject(rolloutInformer.Informer(), c.queue)
+
+	return c
+}
+
+// Name returns the name of the controller.
+func (c *Controller) Name() string {
+	return "tidbcluster-rollout"
+}
+
+func (c *Controller) Run(numOfWorkers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidbcluster-rollout controller")
+	defer klog.Info("Shutting down tidbcluster-rollout controller")
+
+	for i := 0; i < numOfWorkers; i++ {
+		go wait.Until(c.doWork, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) doWork() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	keyIface, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(keyIface)
+
+	key := keyIface.(string)
+	err := c.sync(key)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("TidbClusterRollout %v still need sync: %v, re-queuing", key, err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("TidbClusterRollout %v sync failed, err: %v", key, err))
+		}
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(keyIface)
+	}
+
+	return true
+}
+
+func (c *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(duration.Seconds())
+		klog.V(4).Infof("Finished syncing TidbClusterRollout %s (%v)", key, duration)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.rolloutLister.TidbClusterRollouts(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbClusterRollout %s has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.control.Reconcile(r.DeepCopy())
+}
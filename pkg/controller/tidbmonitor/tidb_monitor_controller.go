@@ -15,6 +15,7 @@ package tidbmonitor
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	perrors "github.com/pingcap/errors"
@@ -22,6 +23,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/monitor/monitor"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -56,9 +59,46 @@ func NewController(deps *controller.Dependencies) *Controller {
 		return c.deps.TiDBMonitorLister.TidbMonitors(ns).Get(name)
 	}, nil)
 
+	// resync the TidbMonitors with cluster selector when the clusters appear, disappear or are relabeled
+	clusterHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueSelectingTidbMonitors,
+		UpdateFunc: func(old, cur interface{}) {
+			oldObj, oldOk := old.(metav1.Object)
+			curObj, curOk := cur.(metav1.Object)
+			if oldOk && curOk && reflect.DeepEqual(oldObj.GetLabels(), curObj.GetLabels()) {
+				return
+			}
+			c.enqueueSelectingTidbMonitors(cur)
+		},
+		DeleteFunc: c.enqueueSelectingTidbMonitors,
+	}
+	deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().AddEventHandler(clusterHandler)
+	deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().AddEventHandler(clusterHandler)
+
 	return c
 }
 
+// enqueueSelectingTidbMonitors enqueues all the TidbMonitors with cluster selector,
+// which may select the changed cluster
+func (c *Controller) enqueueSelectingTidbMonitors(_ interface{}) {
+	tms, err := c.deps.TiDBMonitorLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list tidbmonitors, err: %v", err))
+		return
+	}
+	for _, tm := range tms {
+		if tm.Spec.ClusterSelector == nil {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(tm)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("cound't get key for object %+v: %v", tm, err))
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
 // Name returns the name of the controller
 func (c *Controller) Name() string {
 	return "tidbmonitor"
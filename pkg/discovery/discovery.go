@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
@@ -33,7 +35,9 @@ import (
 type TiDBDiscovery interface {
 	Discover(string) (string, error)
 	DiscoverDM(string) (string, error)
+	DiscoverPDMS(string) (string, error)
 	VerifyPDEndpoint(string) (string, error)
+	Recover(string) (string, error)
 }
 
 type tidbDiscovery struct {
@@ -43,6 +47,10 @@ type tidbDiscovery struct {
 	dmClusters    map[string]*clusterInfo
 	pdControl     pdapi.PDControlInterface
 	masterControl dmapi.MasterControlInterface
+	// stateless is set when multiple discovery replicas serve concurrently, the
+	// in-memory peers can not be shared between replicas in this case, so only the
+	// first member is allowed to bootstrap the cluster and the others join it.
+	stateless bool
 }
 
 type clusterInfo struct {
@@ -65,6 +73,7 @@ func NewTiDBDiscovery(pdControl pdapi.PDControlInterface, masterControl dmapi.Ma
 		masterControl: masterControl,
 		clusters:      map[string]*clusterInfo{},
 		dmClusters:    map[string]*clusterInfo{},
+		stateless:     os.Getenv("DISCOVERY_STATELESS") == strconv.FormatBool(true),
 	}
 }
 
@@ -107,13 +116,27 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	currentCluster.peers[podName] = struct{}{}
 
 	// Should take failover replicas into consideration
-	if len(currentCluster.peers) == int(tc.PDStsDesiredReplicas()) && tc.Spec.Cluster == nil {
+	bootstrap := len(currentCluster.peers) == int(tc.PDStsDesiredReplicas())
+	if d.stateless {
+		bootstrap = len(tc.Status.PD.Members) == 0 && podName == fmt.Sprintf("%s-0", controller.PDMemberName(tcName))
+	}
+	if bootstrap && tc.Spec.Cluster == nil {
 		delete(currentCluster.peers, podName)
 		pdAddresses := tc.Spec.PDAddresses
 		// Join an existing PD cluster if tc.Spec.PDAddresses is set
 		if len(pdAddresses) != 0 {
 			return fmt.Sprintf("--join=%s", strings.Join(pdAddresses, ",")), nil
 		}
+		// Join the PD of the other TidbClusters registered with the peer registry unless this one is registered first
+		if tc.AcrossK8s() && tc.Spec.PeerRegistry != nil {
+			args, first, err := JoinRegisteredPeers(tc)
+			if err != nil {
+				return "", err
+			}
+			if !first {
+				return args, nil
+			}
+		}
 		// Initialize the PD cluster with the FQDN format service record if deploy across k8s or tc.Spec.ClusterDomain is set
 		if tc.AcrossK8s() || tc.Spec.ClusterDomain != "" {
 			return fmt.Sprintf("--initial-cluster=%s=%s://%s", strArr[0], tc.Scheme(), advertisePeerUrl), nil
@@ -181,6 +204,37 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	return fmt.Sprintf("--join=%s", strings.Join(membersArr, ",")), nil
 }
 
+// Recover returns the extra start args of an existing PD member. When the majority of PD members are
+// lost, the survivor is started with --force-new-cluster to rebuild a one-member PD cluster from its data.
+func (d *tidbDiscovery) Recover(advertisePeerUrl string) (string, error) {
+	if advertisePeerUrl == "" {
+		return "", fmt.Errorf("advertisePeerUrl is empty")
+	}
+	strArr := strings.Split(advertisePeerUrl, ":")
+	hostArr := strings.Split(strArr[0], ".")
+	if len(hostArr) < 4 || hostArr[3] != "svc" {
+		return "", fmt.Errorf("advertisePeerUrl format is wrong: %s", advertisePeerUrl)
+	}
+
+	podName, peerServiceName, ns := hostArr[0], hostArr[1], hostArr[2]
+	tcName := strings.TrimSuffix(peerServiceName, "-pd-peer")
+	podNamespace := os.Getenv("MY_POD_NAMESPACE")
+	if ns != podNamespace {
+		return "", fmt.Errorf("the peer's namespace: %s is not equal to discovery namespace: %s", ns, podNamespace)
+	}
+	tc, err := d.cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tcName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	recovery := tc.Status.PD.Recovery
+	if recovery == nil || recovery.Phase != v1alpha1.PDRecoveryPhaseForceNewCluster || recovery.Survivor != podName {
+		return "", nil
+	}
+	klog.Infof("pd member %s of cluster %s/%s is the survivor to rebuild pd cluster", podName, ns, tcName)
+	return "--force-new-cluster", nil
+}
+
 func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -219,7 +273,11 @@ func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 	currentCluster = d.dmClusters[keyName]
 	currentCluster.peers[podName] = struct{}{}
 
-	if len(currentCluster.peers) == int(dc.MasterStsDesiredReplicas()) {
+	bootstrap := len(currentCluster.peers) == int(dc.MasterStsDesiredReplicas())
+	if d.stateless {
+		bootstrap = len(dc.Status.Master.Members) == 0 && podName == fmt.Sprintf("%s-0", controller.DMMasterMemberName(dcName))
+	}
+	if bootstrap {
 		delete(currentCluster.peers, podName)
 		return fmt.Sprintf("--initial-cluster=%s=%s://%s", podName, dc.Scheme(), advertisePeerUrl), nil
 	}
@@ -245,6 +303,65 @@ func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 	return fmt.Sprintf("--join=%s", strings.Join(mastersArr, ",")), nil
 }
 
+// DiscoverPDMS returns the backend endpoints of the PD microservice member, which are the
+// client URLs of the PD members running as the API service. TiKV and TiDB keep connecting to
+// the PD service and are redirected to the microservices by PD, so they need no change.
+func (d *tidbDiscovery) DiscoverPDMS(advertiseAddr string) (string, error) {
+	if advertiseAddr == "" {
+		return "", fmt.Errorf("pdms advertiseAddr is empty")
+	}
+	klog.Infof("pdms advertiseAddr is: %s", advertiseAddr)
+	strArr := strings.Split(advertiseAddr, ":")
+	hostArr := strings.Split(strArr[0], ".")
+
+	if len(hostArr) < 4 || hostArr[3] != "svc" {
+		return "", fmt.Errorf("pdms advertiseAddr format is wrong: %s", advertiseAddr)
+	}
+
+	peerServiceName, ns := hostArr[1], hostArr[2]
+	podNamespace := os.Getenv("MY_POD_NAMESPACE")
+	if ns != podNamespace {
+		return "", fmt.Errorf("the peer's namespace: %s is not equal to discovery namespace: %s", ns, podNamespace)
+	}
+
+	var tcName string
+	for _, mt := range []v1alpha1.MemberType{v1alpha1.PDMSTSOMemberType, v1alpha1.PDMSSchedulingMemberType} {
+		if suffix := fmt.Sprintf("-%s-peer", mt); strings.HasSuffix(peerServiceName, suffix) {
+			tcName = strings.TrimSuffix(peerServiceName, suffix)
+			break
+		}
+	}
+	if tcName == "" {
+		return "", fmt.Errorf("pdms advertiseAddr format is wrong: %s", advertiseAddr)
+	}
+
+	tc, err := d.cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tcName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !tc.PDMSEnabled() {
+		return "", fmt.Errorf("pd of tidbcluster %s/%s is not in the microservice mode", ns, tcName)
+	}
+
+	pdClient := d.pdControl.GetPDClient(pdapi.Namespace(ns), tcName, tc.IsTLSClusterEnabled())
+	membersInfo, err := pdClient.GetMembers()
+	if err != nil {
+		return "", err
+	}
+
+	endpoints := make([]string, 0, len(membersInfo.Members))
+	for _, member := range membersInfo.Members {
+		if len(member.ClientUrls) == 0 {
+			continue
+		}
+		endpoints = append(endpoints, member.ClientUrls[0])
+	}
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("no pd member of tidbcluster %s/%s is found", ns, tcName)
+	}
+	return fmt.Sprintf("--backend-endpoints=%s", strings.Join(endpoints, ",")), nil
+}
+
 func (d *tidbDiscovery) VerifyPDEndpoint(pdURL string) (string, error) {
 	pdEndpoint := parsePDURL(pdURL)
 	klog.Infof("Get PD endpoint URL: %s, scheme is %s, pdMemberName is %s, pdMemberPort is %s, tcName is %s", pdURL, pdEndpoint.scheme, pdEndpoint.pdMemberName, pdEndpoint.pdMemberPort, pdEndpoint.tcName)
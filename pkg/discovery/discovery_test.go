@@ -41,6 +41,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 		ns           string
 		url          string
 		clusters     map[string]*clusterInfo
+		stateless    bool
 		tc           *v1alpha1.TidbCluster
 		getMembersFn func() (*pdapi.MembersInfo, error)
 		expectFn     func(*GomegaWithT, *tidbDiscovery, string, error)
@@ -62,6 +63,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 
 		td := NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli)
 		td.(*tidbDiscovery).clusters = test.clusters
+		td.(*tidbDiscovery).stateless = test.stateless
 
 		os.Setenv("MY_POD_NAMESPACE", test.ns)
 		re, err := td.Discover(test.url)
@@ -131,6 +133,61 @@ func TestDiscoveryDiscovery(t *testing.T) {
 				g.Expect(td.clusters["default/demo"].peers["demo-pd-0"]).To(Equal(struct{}{}))
 			},
 		},
+		{
+			name:      "stateless mode, the first member bootstraps the cluster",
+			ns:        "default",
+			url:       "demo-pd-0.demo-pd-peer.default.svc:2380",
+			clusters:  map[string]*clusterInfo{},
+			stateless: true,
+			tc:        newTC(),
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--initial-cluster=demo-pd-0=http://demo-pd-0.demo-pd-peer.default.svc:2380"))
+			},
+		},
+		{
+			name:      "stateless mode, other members wait for the first member",
+			ns:        "default",
+			url:       "demo-pd-1.demo-pd-peer.default.svc:2380",
+			clusters:  map[string]*clusterInfo{},
+			stateless: true,
+			tc:        newTC(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return nil, fmt.Errorf("get members failed")
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.Contains(err.Error(), "get members failed")).To(BeTrue())
+			},
+		},
+		{
+			name:      "stateless mode, the first member joins the bootstrapped cluster",
+			ns:        "default",
+			url:       "demo-pd-0.demo-pd-peer.default.svc:2380",
+			clusters:  map[string]*clusterInfo{},
+			stateless: true,
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+					"demo-pd-1": {Name: "demo-pd-1", Health: true},
+				}
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Members: []*pdpb.Member{
+						{
+							Name:     "demo-pd-1",
+							PeerUrls: []string{"http://demo-pd-1.demo-pd-peer:2380"},
+						},
+					},
+				}, nil
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--join=http://demo-pd-1.demo-pd-peer:2379"))
+			},
+		},
 		{
 			name: "resourceVersion changed",
 			ns:   "default",
@@ -885,6 +942,107 @@ func TestDiscoveryDMDiscovery(t *testing.T) {
 	}
 }
 
+func TestDiscoveryPDMSDiscovery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name         string
+		url          string
+		tc           *v1alpha1.TidbCluster
+		getMembersFn func() (*pdapi.MembersInfo, error)
+		expectFn     func(*GomegaWithT, string, error)
+	}
+	newMSTC := func() *v1alpha1.TidbCluster {
+		tc := newTC()
+		tc.Spec.PD.Mode = v1alpha1.PDModeMicroservice
+		tc.Spec.PDMS = []*v1alpha1.PDMSSpec{{Name: "tso", Replicas: 2}}
+		return tc
+	}
+	testFn := func(test testcase, t *testing.T) {
+		cli := fake.NewSimpleClientset()
+		kubeCli := kubefake.NewSimpleClientset()
+		informer := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+		fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+		fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+		pdClient := pdapi.NewFakePDClient()
+		if test.tc != nil {
+			cli.PingcapV1alpha1().TidbClusters(test.tc.Namespace).Create(context.TODO(), test.tc, metav1.CreateOptions{})
+			fakePDControl.SetPDClient(pdapi.Namespace(test.tc.GetNamespace()), test.tc.GetName(), pdClient)
+		}
+		pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+			return test.getMembersFn()
+		})
+
+		td := NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli)
+		os.Setenv("MY_POD_NAMESPACE", "default")
+		re, err := td.DiscoverPDMS(test.url)
+		test.expectFn(g, re, err)
+	}
+	tests := []testcase{
+		{
+			name: "advertiseAddr is wrong",
+			url:  "demo-tso-0.demo-tso-peer.svc:2379",
+			tc:   newMSTC(),
+			expectFn: func(g *GomegaWithT, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("pdms advertiseAddr format is wrong"))
+			},
+		},
+		{
+			name: "unknown microservice",
+			url:  "demo-router-0.demo-router-peer.default.svc:2379",
+			tc:   newMSTC(),
+			expectFn: func(g *GomegaWithT, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("pdms advertiseAddr format is wrong"))
+			},
+		},
+		{
+			name: "pd is not in the microservice mode",
+			url:  "demo-tso-0.demo-tso-peer.default.svc:2379",
+			tc:   newTC(),
+			expectFn: func(g *GomegaWithT, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("not in the microservice mode"))
+			},
+		},
+		{
+			name: "failed to get members",
+			url:  "demo-tso-0.demo-tso-peer.default.svc:2379",
+			tc:   newMSTC(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return nil, fmt.Errorf("get members failed")
+			},
+			expectFn: func(g *GomegaWithT, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("get members failed"))
+			},
+		},
+		{
+			name: "return the client urls of pd",
+			url:  "demo-scheduling-0.demo-scheduling-peer.default.svc:2379",
+			tc:   newMSTC(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Members: []*pdpb.Member{
+						{ClientUrls: []string{"http://demo-pd-0.demo-pd-peer.default.svc:2379"}},
+						{ClientUrls: []string{"http://demo-pd-1.demo-pd-peer.default.svc:2379"}},
+					},
+				}, nil
+			},
+			expectFn: func(g *GomegaWithT, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--backend-endpoints=http://demo-pd-0.demo-pd-peer.default.svc:2379,http://demo-pd-1.demo-pd-peer.default.svc:2379"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFn(tt, t)
+		})
+	}
+}
+
 func TestDiscoveryVerifyPDEndpoint(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -1148,6 +1306,51 @@ func TestDiscoveryVerifyPDEndpoint(t *testing.T) {
 	}
 }
 
+func TestDiscoveryRecover(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cli := fake.NewSimpleClientset()
+	kubeCli := kubefake.NewSimpleClientset()
+	informer := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+	fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+	fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+	td := NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli)
+	os.Setenv("MY_POD_NAMESPACE", "default")
+
+	tc := newTC()
+	_, err := cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	survivorURL := "demo-pd-1.demo-pd-peer.default.svc:2380"
+	otherURL := "demo-pd-0.demo-pd-peer.default.svc:2380"
+
+	// no recovery
+	re, err := td.Recover(survivorURL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(BeEmpty())
+
+	_, err = td.Recover("demo-pd-1.demo-pd-peer:2380")
+	g.Expect(err).To(HaveOccurred())
+
+	tc.Status.PD.Recovery = &v1alpha1.PDRecoveryStatus{Survivor: "demo-pd-1", Phase: v1alpha1.PDRecoveryPhaseForceNewCluster}
+	_, err = cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	re, err = td.Recover(survivorURL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(Equal("--force-new-cluster"))
+	re, err = td.Recover(otherURL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(BeEmpty())
+
+	// the survivor is not forced again after the other members rejoin
+	tc.Status.PD.Recovery.Phase = v1alpha1.PDRecoveryPhaseRebuildMembers
+	_, err = cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	re, err = td.Recover(survivorURL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(re).To(BeEmpty())
+}
+
 func newTC() *v1alpha1.TidbCluster {
 	return &v1alpha1.TidbCluster{
 		TypeMeta: metav1.TypeMeta{Kind: "TidbCluster", APIVersion: "v1alpha1"},
@@ -0,0 +1,147 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultPeerTTL is how long a peer is kept in the registry without registering again
+const DefaultPeerTTL = 10 * time.Minute
+
+// PeerRegistry is the registry which the TidbClusters deployed across Kubernetes clusters register with,
+// so they learn the PD addresses and the cluster domains of each other.
+type PeerRegistry interface {
+	// Register registers or refreshes the peer in the group, and returns all the peers of the group
+	// in the order of their first registration.
+	Register(group string, peer v1alpha1.RegisteredPeer) []v1alpha1.RegisteredPeer
+}
+
+type registeredPeer struct {
+	peer     v1alpha1.RegisteredPeer
+	lastSeen time.Time
+}
+
+type peerRegistry struct {
+	lock   sync.Mutex
+	groups map[string]map[string]*registeredPeer
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewPeerRegistry returns an in-memory PeerRegistry. The peers are registered again in each sync of the
+// TidbClusters, so the registry is rebuilt shortly after the discovery service restarts.
+func NewPeerRegistry(ttl time.Duration) PeerRegistry {
+	return &peerRegistry{
+		groups: map[string]map[string]*registeredPeer{},
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// peerKey identifies a TidbCluster across Kubernetes clusters
+func peerKey(peer v1alpha1.RegisteredPeer) string {
+	return fmt.Sprintf("%s/%s/%s", peer.ClusterDomain, peer.Namespace, peer.Name)
+}
+
+func (r *peerRegistry) Register(group string, peer v1alpha1.RegisteredPeer) []v1alpha1.RegisteredPeer {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	peers := r.groups[group]
+	if peers == nil {
+		peers = map[string]*registeredPeer{}
+		r.groups[group] = peers
+	}
+	key := peerKey(peer)
+	if old, ok := peers[key]; ok {
+		peer.RegisteredAt = old.peer.RegisteredAt
+	} else {
+		peer.RegisteredAt = metav1.NewTime(now)
+	}
+	peers[key] = &registeredPeer{peer: peer, lastSeen: now}
+
+	result := make([]v1alpha1.RegisteredPeer, 0, len(peers))
+	for key, p := range peers {
+		if now.Sub(p.lastSeen) > r.ttl {
+			delete(peers, key)
+			continue
+		}
+		result = append(result, *p.peer.DeepCopy())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].RegisteredAt.Equal(&result[j].RegisteredAt) {
+			return result[i].RegisteredAt.Before(&result[j].RegisteredAt)
+		}
+		return peerKey(result[i]) < peerKey(result[j])
+	})
+	return result
+}
+
+// RegisterPeer registers the peer with the registry served by the discovery service at registryURL
+func RegisterPeer(httpClient *http.Client, registryURL, group string, peer v1alpha1.RegisteredPeer) ([]v1alpha1.RegisteredPeer, error) {
+	body, err := json.Marshal(peer)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := fmt.Sprintf("%s/registry/%s", strings.TrimSuffix(registryURL, "/"), url.PathEscape(group))
+	data, err := httputil.PostBodyOK(httpClient, apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	var peers []v1alpha1.RegisteredPeer
+	if err := json.Unmarshal(data, &peers); err != nil {
+		return nil, fmt.Errorf("failed to decode the peers returned by %s: %v", apiURL, err)
+	}
+	return peers, nil
+}
+
+// JoinRegisteredPeers returns the start args of the first PD member of a TidbCluster with a peer registry,
+// it joins the PD of the other registered TidbClusters, or bootstraps PD if it is registered first.
+func JoinRegisteredPeers(tc *v1alpha1.TidbCluster) (string, bool, error) {
+	self := v1alpha1.RegisteredPeer{Name: tc.GetName(), Namespace: tc.GetNamespace(), ClusterDomain: tc.Spec.ClusterDomain}
+	registered := false
+	var addresses []string
+	for _, peer := range tc.Status.RegisteredPeers {
+		if peerKey(peer) == peerKey(self) {
+			registered = true
+			continue
+		}
+		addresses = append(addresses, peer.PDAddresses...)
+	}
+	if !registered {
+		return "", false, fmt.Errorf("tidbcluster %s/%s is not registered with the peer registry yet", tc.GetNamespace(), tc.GetName())
+	}
+	if len(addresses) != 0 {
+		return fmt.Sprintf("--join=%s", strings.Join(addresses, ",")), false, nil
+	}
+	if peerKey(tc.Status.RegisteredPeers[0]) == peerKey(self) {
+		return "", true, nil
+	}
+	first := tc.Status.RegisteredPeers[0]
+	return "", false, fmt.Errorf("waiting for the pd of the registered tidbcluster %s/%s to start", first.Namespace, first.Name)
+}
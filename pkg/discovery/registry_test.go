@@ -0,0 +1,93 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPeerRegistryRegister(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	registry := NewPeerRegistry(time.Minute).(*peerRegistry)
+	registry.now = func() time.Time { return now }
+
+	cluster1 := v1alpha1.RegisteredPeer{Name: "basic", Namespace: "pingcap", ClusterDomain: "cluster1.com"}
+	cluster2 := v1alpha1.RegisteredPeer{Name: "basic", Namespace: "pingcap", ClusterDomain: "cluster2.com"}
+	peers := registry.Register("basic", cluster1)
+	g.Expect(peers).To(HaveLen(1))
+
+	// the peers are ordered by the first registration, which is kept on refresh
+	now = now.Add(time.Second)
+	registry.Register("basic", cluster2)
+	now = now.Add(time.Second)
+	cluster1.PDAddresses = []string{"http://basic-pd-0.basic-pd-peer.pingcap.svc.cluster1.com:2379"}
+	peers = registry.Register("basic", cluster1)
+	g.Expect(peers).To(HaveLen(2))
+	g.Expect(peers[0].ClusterDomain).To(Equal("cluster1.com"))
+	g.Expect(peers[0].PDAddresses).To(HaveLen(1))
+	g.Expect(peers[1].ClusterDomain).To(Equal("cluster2.com"))
+
+	// the groups are separated
+	g.Expect(registry.Register("other", cluster2)).To(HaveLen(1))
+
+	// the peers which do not register again are expired
+	now = now.Add(2 * time.Minute)
+	peers = registry.Register("basic", cluster2)
+	g.Expect(peers).To(HaveLen(1))
+	g.Expect(peers[0].ClusterDomain).To(Equal("cluster2.com"))
+}
+
+func TestJoinRegisteredPeers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "pingcap"},
+		Spec: v1alpha1.TidbClusterSpec{
+			AcrossK8s:     true,
+			ClusterDomain: "cluster2.com",
+			PeerRegistry:  &v1alpha1.PeerRegistry{URL: "http://basic-discovery.pingcap.svc.cluster1.com:10261"},
+		},
+	}
+	cluster1 := v1alpha1.RegisteredPeer{Name: "basic", Namespace: "pingcap", ClusterDomain: "cluster1.com"}
+	cluster2 := v1alpha1.RegisteredPeer{Name: "basic", Namespace: "pingcap", ClusterDomain: "cluster2.com"}
+
+	// not registered yet
+	_, _, err := JoinRegisteredPeers(tc)
+	g.Expect(err).To(HaveOccurred())
+
+	// waiting for the pd of the cluster registered first
+	tc.Status.RegisteredPeers = []v1alpha1.RegisteredPeer{cluster1, cluster2}
+	_, _, err = JoinRegisteredPeers(tc)
+	g.Expect(err).To(HaveOccurred())
+
+	// joining the pd of the other clusters
+	tc.Status.RegisteredPeers[0].PDAddresses = []string{"http://basic-pd-0.basic-pd-peer.pingcap.svc.cluster1.com:2379"}
+	args, first, err := JoinRegisteredPeers(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(first).To(BeFalse())
+	g.Expect(args).To(Equal("--join=http://basic-pd-0.basic-pd-peer.pingcap.svc.cluster1.com:2379"))
+
+	// bootstrapping pd if registered first
+	tc.Status.RegisteredPeers = []v1alpha1.RegisteredPeer{cluster2, cluster1}
+	_, first, err = JoinRegisteredPeers(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(first).To(BeTrue())
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	// RequestsTotal is a prometheus counter metrics which holds the total number of
+	// requests served by the discovery service. It has two labels, handler label refers
+	// to the handler serving the request i.e new, verify, recover, and result label refers to
+	// the result i.e success, error.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tidb_discovery",
+		Name:      "requests_total",
+		Help:      "Total number of requests served by the discovery service",
+	}, []string{"handler", "result"})
+
+	// JoinResponsesTotal is a prometheus counter metrics which holds the total number of
+	// the start arguments returned to the members. The type label refers to the type of
+	// the arguments i.e initial, join, and the register_type label refers to the kind of
+	// the member i.e pd, dm, pdms.
+	JoinResponsesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tidb_discovery",
+		Name:      "join_responses_total",
+		Help:      "Total number of the start arguments returned to the members, by initial or join",
+	}, []string{"register_type", "type"})
+
+	// NegotiatedVersionsTotal is a prometheus counter metrics which holds the total number of
+	// requests served by the discovery service, by the negotiated version of the discovery protocol.
+	// It helps to find out the clients still using the old protocol during the upgrade.
+	NegotiatedVersionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tidb_discovery",
+		Name:      "negotiated_api_versions_total",
+		Help:      "Total number of requests served by the discovery service, by the negotiated api version",
+	}, []string{"handler", "api_version"})
+
+	// ProxyErrorsTotal is a prometheus counter metrics which holds the total number of
+	// errors when proxying the requests to PD, TiCDC owner and DM-master leader.
+	ProxyErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "tidb_discovery",
+		Name:      "proxy_errors_total",
+		Help:      "Total number of errors when proxying the requests to PD, TiCDC owner and DM-master leader",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		RequestsTotal,
+		JoinResponsesTotal,
+		NegotiatedVersionsTotal,
+		ProxyErrorsTotal,
+	)
+}
+
+// joinResponseType returns the type of the start arguments returned by the discovery,
+// the backend endpoints of the PD microservices are always a join to the PD cluster.
+func joinResponseType(result string) string {
+	if strings.HasPrefix(result, "--join") || strings.HasPrefix(result, "--backend-endpoints") {
+		return "join"
+	}
+	return "initial"
+}
@@ -16,6 +16,7 @@ package server
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -23,10 +24,104 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	utilhttp "github.com/pingcap/tidb-operator/pkg/util/http"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// TiCDCOwnerPathPrefix is the path prefix of the proxy routed to the open API of the TiCDC owner
+	TiCDCOwnerPathPrefix = "/ticdc"
+	// DMMasterPathPrefix is the path prefix of the proxy routed to the API of the DM-master leader
+	DMMasterPathPrefix = "/dm-master"
+)
+
+// ProxyRoute routes the requests with the path prefix to the endpoint returned by Resolve,
+// the path prefix is trimmed before the request is proxied.
+type ProxyRoute struct {
+	PathPrefix string
+	TLSEnabled bool
+	// LoadTLSConfig loads the TLS config to connect to the endpoint if TLS is enabled,
+	// the certificates of the cluster mounted to discovery are used if it's nil
+	LoadTLSConfig func() (*tls.Config, error)
+	// Resolve returns the current endpoint, e.g. the leader or owner of the component
+	Resolve func() (*url.URL, error)
+}
+
+type ticdcCapture struct {
+	IsOwner bool   `json:"is_owner"`
+	Address string `json:"address"`
+}
+
+// NewTiCDCOwnerRoute returns the route to the open API of the TiCDC owner, the owner is resolved
+// by the captures API of TiCDC in each request, so the requests follow the owner after it changes.
+func NewTiCDCOwnerRoute(tcName string, tlsEnabled bool) (ProxyRoute, error) {
+	scheme := "http"
+	client := &http.Client{Timeout: dmapi.DefaultTimeout}
+	if tlsEnabled {
+		scheme = "https"
+		tlsConfig, err := loadTLSConfig()
+		if err != nil {
+			return ProxyRoute{}, err
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	capturesURL := fmt.Sprintf("%s://%s:8301/api/v1/captures", scheme, controller.TiCDCPeerMemberName(tcName))
+	return ProxyRoute{
+		PathPrefix: TiCDCOwnerPathPrefix,
+		TLSEnabled: tlsEnabled,
+		Resolve: func() (*url.URL, error) {
+			body, err := utilhttp.GetBodyOK(client, capturesURL)
+			if err != nil {
+				return nil, err
+			}
+			var captures []ticdcCapture
+			if err := json.Unmarshal(body, &captures); err != nil {
+				return nil, fmt.Errorf("unable to unmarshal captures of ticdc %s: %v", body, err)
+			}
+			for _, c := range captures {
+				if c.IsOwner {
+					return &url.URL{Scheme: scheme, Host: c.Address}, nil
+				}
+			}
+			return nil, fmt.Errorf("ticdc owner is not found in %d captures", len(captures))
+		},
+	}, nil
+}
+
+// NewDMMasterRoute returns the route to the API of the DM-master leader, the leader is resolved
+// by the members API of DM-master in each request, so the requests follow the leader after it changes.
+// The client certificate of the DM cluster is loaded from the secret if TLS is enabled.
+func NewDMMasterRoute(namespace, dcName string, tlsEnabled bool, secretLister corelisterv1.SecretLister) ProxyRoute {
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	masterControl := dmapi.NewDefaultMasterControl(secretLister)
+	return ProxyRoute{
+		PathPrefix: DMMasterPathPrefix,
+		TLSEnabled: tlsEnabled,
+		LoadTLSConfig: func() (*tls.Config, error) {
+			return pdapi.GetTLSConfig(secretLister, pdapi.Namespace(namespace), util.DMClientTLSSecretName(dcName))
+		},
+		Resolve: func() (*url.URL, error) {
+			leader, err := masterControl.GetMasterClient(namespace, dcName, tlsEnabled).GetLeader()
+			if err != nil {
+				return nil, err
+			}
+			if leader.Addr == "" {
+				return nil, fmt.Errorf("dm-master leader is not found: %s", leader.Msg)
+			}
+			return &url.URL{Scheme: scheme, Host: leader.Addr}, nil
+		},
+	}
+}
+
 func buildUrl(tcName string, tlsEnabled bool) *url.URL {
 	url := &url.URL{
 		Host:   fmt.Sprintf("%s-pd:2379", tcName),
@@ -39,32 +134,44 @@ func buildUrl(tcName string, tlsEnabled bool) *url.URL {
 	return url
 }
 
+func loadTLSConfig() (*tls.Config, error) {
+	// load crt and key
+	certPath := fmt.Sprintf("%s/tls.crt", member.PdTlsCertPath)
+	keyPath := fmt.Sprintf("%s/tls.key", member.PdTlsCertPath)
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	// load ca
+	rootCAs := x509.NewCertPool()
+	caPath := fmt.Sprintf("%s/ca.crt", member.PdTlsCertPath)
+	caByte, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	rootCAs.AppendCertsFromPEM(caByte)
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+	}, nil
+}
+
 func buildProxy(url *url.URL, tlsEnabled bool) (*httputil.ReverseProxy, error) {
 	proxy := httputil.NewSingleHostReverseProxy(url)
 	if tlsEnabled {
-		// load crt and key
-		certPath := fmt.Sprintf("%s/tls.crt", member.PdTlsCertPath)
-		keyPath := fmt.Sprintf("%s/tls.key", member.PdTlsCertPath)
-		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		tlsConfig, err := loadTLSConfig()
 		if err != nil {
-			klog.Error(err)
 			return nil, err
 		}
-		// load ca
-		rootCAs := x509.NewCertPool()
-		caPath := fmt.Sprintf("%s/ca.crt", member.PdTlsCertPath)
-		caByte, err := ioutil.ReadFile(caPath)
-		if err != nil {
-			klog.Error(err)
-			return nil, err
-		}
-		rootCAs.AppendCertsFromPEM(caByte)
-		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{cert},
-			RootCAs:      rootCAs,
-		}
 		proxy.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		klog.Errorf("failed to proxy request %s to %s: %v", req.URL.Path, url.Host, err)
+		ProxyErrorsTotal.Inc()
+		w.WriteHeader(http.StatusBadGateway)
+	}
 	director := proxy.Director
 	proxy.Director = func(req *http.Request) {
 		if strings.HasPrefix(req.RequestURI, "/dashboard") {
@@ -78,18 +185,29 @@ func buildProxy(url *url.URL, tlsEnabled bool) (*httputil.ReverseProxy, error) {
 type proxyServer struct {
 	proxyTo      *url.URL
 	tcTlsEnabled bool
+	// routes are the components proxied in addition to PD
+	routes []ProxyRoute
 }
 
-func NewProxyServer(tcName string, tcTlsEnabled bool) Server {
+func NewProxyServer(tcName string, tcTlsEnabled bool, routes ...ProxyRoute) Server {
 	return &proxyServer{
 		proxyTo:      buildUrl(tcName, tcTlsEnabled),
 		tcTlsEnabled: tcTlsEnabled,
+		routes:       routes,
 	}
 }
 
 func (p *proxyServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	for _, route := range p.routes {
+		if req.URL.Path == route.PathPrefix || strings.HasPrefix(req.URL.Path, route.PathPrefix+"/") {
+			p.serveRoute(w, req, route)
+			return
+		}
+	}
+
 	proxy, err := buildProxy(p.proxyTo, p.tcTlsEnabled)
 	if err != nil {
+		ProxyErrorsTotal.Inc()
 		msg := fmt.Sprintf("Error Happed, err:%v", err)
 		w.Write([]byte(msg))
 		return
@@ -97,6 +215,42 @@ func (p *proxyServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	proxy.ServeHTTP(w, req)
 }
 
+func (p *proxyServer) serveRoute(w http.ResponseWriter, req *http.Request, route ProxyRoute) {
+	target, err := route.Resolve()
+	if err != nil {
+		klog.Errorf("failed to resolve the endpoint of %s: %v", route.PathPrefix, err)
+		ProxyErrorsTotal.Inc()
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(fmt.Sprintf("failed to resolve the endpoint of %s, err: %v", route.PathPrefix, err)))
+		return
+	}
+	proxy, err := buildProxy(target, route.TLSEnabled && route.LoadTLSConfig == nil)
+	if err == nil && route.TLSEnabled && route.LoadTLSConfig != nil {
+		var tlsConfig *tls.Config
+		tlsConfig, err = route.LoadTLSConfig()
+		if err == nil {
+			proxy.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		}
+	}
+	if err != nil {
+		ProxyErrorsTotal.Inc()
+		msg := fmt.Sprintf("Error Happed, err:%v", err)
+		w.Write([]byte(msg))
+		return
+	}
+	proxy.Director = func(r *http.Request) {
+		r.URL.Scheme = target.Scheme
+		r.URL.Host = target.Host
+		r.URL.Path = strings.TrimPrefix(r.URL.Path, route.PathPrefix)
+		r.URL.RawPath = ""
+		if r.URL.Path == "" {
+			r.URL.Path = "/"
+		}
+		r.Host = target.Host
+	}
+	proxy.ServeHTTP(w, req)
+}
+
 func (p *proxyServer) ListenAndServe(addr string) {
 	klog.Fatal(http.ListenAndServe(addr, p))
 }
@@ -14,6 +14,7 @@
 package server
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -62,3 +63,56 @@ func TestProxyServerTLS(t *testing.T) {
 
 	// TODO Add tests cases for TLS
 }
+
+func TestProxyServerRoutes(t *testing.T) {
+	owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("owner " + r.URL.Path))
+	}))
+	defer owner.Close()
+	ownerURL, err := url.Parse(owner.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := 0
+	s := NewProxyServer("foo", false,
+		ProxyRoute{
+			PathPrefix: TiCDCOwnerPathPrefix,
+			Resolve: func() (*url.URL, error) {
+				resolved++
+				return ownerURL, nil
+			},
+		},
+		ProxyRoute{
+			PathPrefix: DMMasterPathPrefix,
+			Resolve: func() (*url.URL, error) {
+				return nil, fmt.Errorf("no leader")
+			},
+		},
+	)
+	httpServer := httptest.NewServer(s.(*proxyServer))
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + "/ticdc/api/v1/changefeeds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(data) != "owner /api/v1/changefeeds" {
+		t.Fatalf("expects the request to be routed to the owner with the prefix trimmed, got %v %q", resp.StatusCode, string(data))
+	}
+	if resolved != 1 {
+		t.Fatalf("expects the owner to be resolved once, got %d", resolved)
+	}
+
+	resp, err = http.Get(httpServer.URL + "/dm-master/apis/v1alpha1/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("status code expects %v if the leader is not resolved, got %v", http.StatusBadGateway, resp.StatusCode)
+	}
+}
@@ -15,14 +15,17 @@ package server
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 
 	restful "github.com/emicklei/go-restful"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/discovery"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
@@ -32,6 +35,7 @@ import (
 
 type server struct {
 	discovery discovery.TiDBDiscovery
+	registry  discovery.PeerRegistry
 	container *restful.Container
 }
 
@@ -39,6 +43,7 @@ type server struct {
 func NewServer(pdControl pdapi.PDControlInterface, masterControl dmapi.MasterControlInterface, cli versioned.Interface, kubeCli kubernetes.Interface) Server {
 	s := &server{
 		discovery: discovery.NewTiDBDiscovery(pdControl, masterControl, cli, kubeCli),
+		registry:  discovery.NewPeerRegistry(discovery.DefaultPeerTTL),
 		container: restful.NewContainer(),
 	}
 	s.registerHandlers()
@@ -50,6 +55,9 @@ func (s *server) registerHandlers() {
 	ws.Route(ws.GET("/new/{advertise-peer-url}").To(s.newHandler))
 	ws.Route(ws.GET("/new/{advertise-peer-url}/{register-type}").To(s.newHandler))
 	ws.Route(ws.GET("/verify/{pd-url}").To(s.newVerifyHandler))
+	ws.Route(ws.GET("/recover/{advertise-peer-url}").To(s.recoverHandler))
+	ws.Route(ws.GET("/version").To(s.versionHandler))
+	ws.Route(ws.POST("/registry/{group}").To(s.registryHandler))
 	s.container.Add(ws)
 }
 
@@ -57,7 +65,28 @@ func (s *server) ListenAndServe(addr string) {
 	klog.Fatal(http.ListenAndServe(addr, s.container.ServeMux))
 }
 
+// negotiateVersion negotiates the version of the discovery protocol for the request and
+// replies it in the response header, it returns false if the request has been rejected.
+func (s *server) negotiateVersion(handler string, req *restful.Request, resp *restful.Response) (int, bool) {
+	version, err := negotiateAPIVersion(req.HeaderParameter(APIVersionHeader))
+	if err != nil {
+		klog.Errorf("failed to negotiate discovery api version for %s: %v", req.Request.URL.Path, err)
+		RequestsTotal.WithLabelValues(handler, resultError).Inc()
+		if werr := resp.WriteError(http.StatusBadRequest, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return 0, false
+	}
+	resp.AddHeader(APIVersionHeader, strconv.Itoa(version))
+	NegotiatedVersionsTotal.WithLabelValues(handler, strconv.Itoa(version)).Inc()
+	return version, true
+}
+
 func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
+	version, ok := s.negotiateVersion("new", req, resp)
+	if !ok {
+		return
+	}
 	encodedAdvertisePeerURL := req.PathParameter("advertise-peer-url")
 	registerType := req.PathParameter("register-type")
 	if registerType == "" {
@@ -66,6 +95,7 @@ func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
 	data, err := base64.StdEncoding.DecodeString(encodedAdvertisePeerURL)
 	if err != nil {
 		klog.Errorf("failed to decode advertise-peer-url: %s, register-type is: %s", encodedAdvertisePeerURL, registerType)
+		RequestsTotal.WithLabelValues("new", resultError).Inc()
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
 			klog.Errorf("failed to writeError: %v", werr)
 		}
@@ -79,9 +109,12 @@ func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
 		result, err = s.discovery.Discover(advertisePeerURL)
 	case "dm":
 		result, err = s.discovery.DiscoverDM(advertisePeerURL)
+	case "pdms":
+		result, err = s.discovery.DiscoverPDMS(advertisePeerURL)
 	default:
 		err = fmt.Errorf("invalid register-type %s", registerType)
 		klog.Errorf("%v", err)
+		RequestsTotal.WithLabelValues("new", resultError).Inc()
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
 			klog.Errorf("failed to writeError: %v", werr)
 		}
@@ -89,13 +122,16 @@ func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
 	}
 	if err != nil {
 		klog.Errorf("failed to discover: %s, %v, register-type is: %s", advertisePeerURL, err, registerType)
+		RequestsTotal.WithLabelValues("new", resultError).Inc()
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
 			klog.Errorf("failed to writeError: %v", werr)
 		}
 		return
 	}
 
-	klog.Infof("generated args for %s: %s, register-type: %s", advertisePeerURL, result, registerType)
+	klog.Infof("generated args for %s: %s, register-type: %s, api-version: %d", advertisePeerURL, result, registerType, version)
+	RequestsTotal.WithLabelValues("new", resultSuccess).Inc()
+	JoinResponsesTotal.WithLabelValues(registerType, joinResponseType(result)).Inc()
 	if _, err := io.WriteString(resp, result); err != nil {
 		klog.Errorf("failed to writeString: %s, %v", result, err)
 	}
@@ -103,10 +139,15 @@ func (s *server) newHandler(req *restful.Request, resp *restful.Response) {
 }
 
 func (s *server) newVerifyHandler(req *restful.Request, resp *restful.Response) {
+	version, ok := s.negotiateVersion("verify", req, resp)
+	if !ok {
+		return
+	}
 	encodedPDPeerURL := req.PathParameter("pd-url")
 	data, err := base64.StdEncoding.DecodeString(encodedPDPeerURL)
 	if err != nil {
 		klog.Errorf("failed to decode pd-peer-url: %s", encodedPDPeerURL)
+		RequestsTotal.WithLabelValues("verify", resultError).Inc()
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
 			klog.Errorf("failed to writeError: %v", werr)
 		}
@@ -119,14 +160,87 @@ func (s *server) newVerifyHandler(req *restful.Request, resp *restful.Response)
 	result, err = s.discovery.VerifyPDEndpoint(pdPeerURL)
 	if err != nil {
 		klog.Errorf("failed to verify pd-url: %s, %v", pdPeerURL, err)
+		RequestsTotal.WithLabelValues("verify", resultError).Inc()
 		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
 			klog.Errorf("failed to writeError: %v", werr)
 		}
 		// Return default value if verification failed
 		result = pdPeerURL
+	} else {
+		RequestsTotal.WithLabelValues("verify", resultSuccess).Inc()
+	}
+
+	klog.Infof("return pd-url for %s: %s, api-version: %d", pdPeerURL, result, version)
+	if _, err := io.WriteString(resp, result); err != nil {
+		klog.Errorf("failed to writeString: %s, %v", result, err)
+	}
+}
+
+// recoverHandler returns the extra start args of an existing PD member, which is empty unless
+// the member is the survivor to rebuild PD after the majority of PD members are lost.
+func (s *server) recoverHandler(req *restful.Request, resp *restful.Response) {
+	encodedAdvertisePeerURL := req.PathParameter("advertise-peer-url")
+	data, err := base64.StdEncoding.DecodeString(encodedAdvertisePeerURL)
+	if err != nil {
+		klog.Errorf("failed to decode advertise-peer-url: %s", encodedAdvertisePeerURL)
+		RequestsTotal.WithLabelValues("recover", resultError).Inc()
+		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return
+	}
+	advertisePeerURL := strings.Trim(string(data), "\n")
+
+	result, err := s.discovery.Recover(advertisePeerURL)
+	if err != nil {
+		klog.Errorf("failed to get recover args: %s, %v", advertisePeerURL, err)
+		RequestsTotal.WithLabelValues("recover", resultError).Inc()
+		if werr := resp.WriteError(http.StatusInternalServerError, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return
+	}
+
+	if result != "" {
+		klog.Infof("generated recover args for %s: %s", advertisePeerURL, result)
 	}
+	RequestsTotal.WithLabelValues("recover", resultSuccess).Inc()
+	if _, err := io.WriteString(resp, result); err != nil {
+		klog.Errorf("failed to writeString: %s, %v", result, err)
+	}
+}
+
+// registryHandler registers the TidbCluster in the request body with the peer registry, and returns all
+// the TidbClusters registered in the same group.
+func (s *server) registryHandler(req *restful.Request, resp *restful.Response) {
+	group := req.PathParameter("group")
+	var peer v1alpha1.RegisteredPeer
+	if err := json.NewDecoder(req.Request.Body).Decode(&peer); err != nil || peer.Name == "" || peer.Namespace == "" {
+		if err == nil {
+			err = fmt.Errorf("name and namespace of the peer are required")
+		}
+		klog.Errorf("failed to decode the peer of group %s: %v", group, err)
+		RequestsTotal.WithLabelValues("registry", resultError).Inc()
+		if werr := resp.WriteError(http.StatusBadRequest, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return
+	}
+
+	peers := s.registry.Register(group, peer)
+	klog.V(4).Infof("registered peer %s/%s of cluster domain %q in group %s, %d peers", peer.Namespace, peer.Name, peer.ClusterDomain, group, len(peers))
+	RequestsTotal.WithLabelValues("registry", resultSuccess).Inc()
+	if err := resp.WriteAsJson(peers); err != nil {
+		klog.Errorf("failed to write peers: %v", err)
+	}
+}
 
-	klog.Infof("return pd-url for %s: %s", pdPeerURL, result)
+// versionHandler returns the range of the discovery protocol versions served by the discovery service.
+// The discovery service before the protocol is versioned replies 404 for it, so the clients
+// should fall back to MinAPIVersion in that case.
+func (s *server) versionHandler(req *restful.Request, resp *restful.Response) {
+	resp.AddHeader(APIVersionHeader, strconv.Itoa(MaxAPIVersion))
+	result := fmt.Sprintf("%d-%d", MinAPIVersion, MaxAPIVersion)
 	if _, err := io.WriteString(resp, result); err != nil {
 		klog.Errorf("failed to writeString: %s, %v", result, err)
 	}
@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -149,6 +150,12 @@ func TestServer(t *testing.T) {
 	if join != 2 {
 		t.Errorf("join expects 2, got %d", join)
 	}
+	if v := testutil.ToFloat64(JoinResponsesTotal.WithLabelValues("pd", "initial")); v != 1 {
+		t.Errorf("initial responses metric expects 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(JoinResponsesTotal.WithLabelValues("pd", "join")); v != 2 {
+		t.Errorf("join responses metric expects 2, got %v", v)
+	}
 }
 
 func TestDMServer(t *testing.T) {
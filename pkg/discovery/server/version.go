@@ -0,0 +1,57 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// APIVersionHeader is the header used by the clients to request a version of the discovery
+	// protocol, the discovery service replies the negotiated version in the same header.
+	APIVersionHeader = "X-Discovery-API-Version"
+
+	// MinAPIVersion is the oldest version of the discovery protocol served by the discovery service.
+	// Requests without the version header are sent by the clients before the protocol is versioned,
+	// so they are served as MinAPIVersion.
+	MinAPIVersion = 1
+	// MaxAPIVersion is the newest version of the discovery protocol served by the discovery service.
+	// Bump it when the requests or responses of the discovery protocol are changed incompatibly.
+	MaxAPIVersion = 1
+)
+
+// negotiateAPIVersion returns the version of the discovery protocol used to serve the request.
+//
+// A client newer than the discovery service is downgraded to MaxAPIVersion, and it should
+// check the version header in the response to know which protocol the response follows.
+// A client older than MinAPIVersion is rejected.
+func negotiateAPIVersion(requested string) (int, error) {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return MinAPIVersion, nil
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(requested, "v"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid discovery api version %q", requested)
+	}
+	if version < MinAPIVersion {
+		return 0, fmt.Errorf("discovery api version %d is not supported, the supported versions are [%d, %d]", version, MinAPIVersion, MaxAPIVersion)
+	}
+	if version > MaxAPIVersion {
+		return MaxAPIVersion, nil
+	}
+	return version, nil
+}
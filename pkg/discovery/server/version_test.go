@@ -0,0 +1,123 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		expect    int
+		expectErr bool
+	}{
+		{name: "legacy client", requested: "", expect: MinAPIVersion},
+		{name: "same version", requested: strconv.Itoa(MaxAPIVersion), expect: MaxAPIVersion},
+		{name: "prefixed version", requested: fmt.Sprintf("v%d", MaxAPIVersion), expect: MaxAPIVersion},
+		{name: "newer client", requested: strconv.Itoa(MaxAPIVersion + 1), expect: MaxAPIVersion},
+		{name: "too old client", requested: strconv.Itoa(MinAPIVersion - 1), expectErr: true},
+		{name: "invalid version", requested: "foo", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := negotiateAPIVersion(tt.requested)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expects error, got version %d", version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != tt.expect {
+				t.Fatalf("version expects %d, got %d", tt.expect, version)
+			}
+		})
+	}
+}
+
+func TestServerAPIVersion(t *testing.T) {
+	os.Setenv("MY_POD_NAMESPACE", "default")
+	cli := fake.NewSimpleClientset()
+	kubeCli := kubefake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(kubeCli, 0)
+	fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+	fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+	s := NewServer(fakePDControl, fakeMasterControl, cli, kubeCli)
+	httpServer := httptest.NewServer(s.(*server).container.ServeMux)
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + "/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := fmt.Sprintf("%d-%d", MinAPIVersion, MaxAPIVersion); string(data) != expect {
+		t.Fatalf("versions expects %q, got %q", expect, string(data))
+	}
+
+	verifyURL := httpServer.URL + fmt.Sprintf("/verify/%s", base64.StdEncoding.EncodeToString([]byte("demo-pd:2380")))
+	tests := []struct {
+		name           string
+		requested      string
+		expectRejected bool
+		expectVersion  string
+	}{
+		{name: "legacy client", requested: "", expectVersion: strconv.Itoa(MinAPIVersion)},
+		{name: "newer client", requested: strconv.Itoa(MaxAPIVersion + 1), expectVersion: strconv.Itoa(MaxAPIVersion)},
+		{name: "invalid version", requested: "foo", expectRejected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, verifyURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.requested != "" {
+				req.Header.Set(APIVersionHeader, tt.requested)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if (resp.StatusCode == http.StatusBadRequest) != tt.expectRejected {
+				t.Fatalf("rejected expects %v, got status code %v", tt.expectRejected, resp.StatusCode)
+			}
+			if got := resp.Header.Get(APIVersionHeader); got != tt.expectVersion {
+				t.Fatalf("version header expects %q, got %q", tt.expectVersion, got)
+			}
+		})
+	}
+}
@@ -36,6 +36,20 @@ type MasterClient interface {
 	EvictLeader() error
 	DeleteMaster(name string) error
 	DeleteWorker(name string) error
+	// ListSources returns the names of the sources by the OpenAPI
+	ListSources() ([]string, error)
+	CreateSource(source SourceConfig) error
+	UpdateSource(name string, source SourceConfig) error
+	DeleteSource(name string) error
+	// ListTasks returns the names of the tasks by the OpenAPI
+	ListTasks() ([]string, error)
+	CreateTask(task TaskConfig) error
+	UpdateTask(name string, task TaskConfig) error
+	DeleteTask(name string) error
+	StartTask(name string) error
+	StopTask(name string) error
+	// GetTaskStatus returns the status of the subtasks of the task by the OpenAPI
+	GetTaskStatus(name string) ([]*SubTaskStatus, error)
 }
 
 var (
@@ -20,12 +20,23 @@ import (
 type ActionType string
 
 const (
-	GetMastersActionType   ActionType = "GetMasters"
-	GetWorkersActionType   ActionType = "GetWorkers"
-	GetLeaderActionType    ActionType = "GetLeader"
-	EvictLeaderActionType  ActionType = "EvictLeader"
-	DeleteMasterActionType ActionType = "DeleteMaster"
-	DeleteWorkerActionType ActionType = "DeleteWorker"
+	GetMastersActionType    ActionType = "GetMasters"
+	GetWorkersActionType    ActionType = "GetWorkers"
+	GetLeaderActionType     ActionType = "GetLeader"
+	EvictLeaderActionType   ActionType = "EvictLeader"
+	DeleteMasterActionType  ActionType = "DeleteMaster"
+	DeleteWorkerActionType  ActionType = "DeleteWorker"
+	ListSourcesActionType   ActionType = "ListSources"
+	CreateSourceActionType  ActionType = "CreateSource"
+	UpdateSourceActionType  ActionType = "UpdateSource"
+	DeleteSourceActionType  ActionType = "DeleteSource"
+	ListTasksActionType     ActionType = "ListTasks"
+	CreateTaskActionType    ActionType = "CreateTask"
+	UpdateTaskActionType    ActionType = "UpdateTask"
+	DeleteTaskActionType    ActionType = "DeleteTask"
+	StartTaskActionType     ActionType = "StartTask"
+	StopTaskActionType      ActionType = "StopTask"
+	GetTaskStatusActionType ActionType = "GetTaskStatus"
 )
 
 type NotFoundReaction struct {
@@ -40,6 +51,7 @@ type Action struct {
 	ID     uint64
 	Name   string
 	Labels map[string]string
+	Config map[string]interface{}
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -113,3 +125,78 @@ func (c *FakeMasterClient) DeleteWorker(_ string) error {
 	_, err := c.fakeAPI(DeleteWorkerActionType, action)
 	return err
 }
+
+func (c *FakeMasterClient) ListSources() ([]string, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(ListSourcesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+func (c *FakeMasterClient) CreateSource(source SourceConfig) error {
+	action := &Action{Config: source}
+	_, err := c.fakeAPI(CreateSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) UpdateSource(name string, source SourceConfig) error {
+	action := &Action{Name: name, Config: source}
+	_, err := c.fakeAPI(UpdateSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) DeleteSource(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(DeleteSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) ListTasks() ([]string, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(ListTasksActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+func (c *FakeMasterClient) CreateTask(task TaskConfig) error {
+	action := &Action{Config: task}
+	_, err := c.fakeAPI(CreateTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) UpdateTask(name string, task TaskConfig) error {
+	action := &Action{Name: name, Config: task}
+	_, err := c.fakeAPI(UpdateTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) DeleteTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(DeleteTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) StartTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(StartTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) StopTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(StopTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) GetTaskStatus(name string) ([]*SubTaskStatus, error) {
+	action := &Action{Name: name}
+	result, err := c.fakeAPI(GetTaskStatusActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*SubTaskStatus), nil
+}
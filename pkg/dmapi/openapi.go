@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+// the prefixes of the OpenAPI of dm-master, which is enabled by `openapi = true` in the config of dm-master
+var (
+	sourcesPrefix = "api/v1/sources"
+	tasksPrefix   = "api/v1/tasks"
+)
+
+// SourceConfig is the config of a source in the OpenAPI of dm-master
+type SourceConfig map[string]interface{}
+
+// TaskConfig is the config of a task in the OpenAPI of dm-master
+type TaskConfig map[string]interface{}
+
+// SubTaskStatus is the status of a subtask of a task in the OpenAPI of dm-master
+type SubTaskStatus struct {
+	Name                string      `json:"name"`
+	SourceName          string      `json:"source_name"`
+	WorkerName          string      `json:"worker_name"`
+	Stage               string      `json:"stage"`
+	Unit                string      `json:"unit"`
+	UnresolvedDDLLockID string      `json:"unresolved_ddl_lock_id,omitempty"`
+	ErrorMsg            string      `json:"error_msg,omitempty"`
+	SyncStatus          *SyncStatus `json:"sync_status,omitempty"`
+}
+
+// SyncStatus is the status of the incremental migration of a subtask
+type SyncStatus struct {
+	MasterBinlog        string `json:"master_binlog"`
+	SyncerBinlog        string `json:"syncer_binlog"`
+	SecondsBehindMaster int64  `json:"seconds_behind_master"`
+	Synced              bool   `json:"synced"`
+}
+
+type sourceListResp struct {
+	Total int `json:"total"`
+	Data  []struct {
+		SourceName string `json:"source_name"`
+	} `json:"data"`
+}
+
+type taskListResp struct {
+	Total int `json:"total"`
+	Data  []struct {
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
+type taskStatusResp struct {
+	Total int              `json:"total"`
+	Data  []*SubTaskStatus `json:"data"`
+}
+
+func (c *masterClient) ListSources() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, sourcesPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	resp := &sourceListResp{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal list sources resp: %s, err: %s", body, err)
+	}
+	names := make([]string, 0, len(resp.Data))
+	for _, source := range resp.Data {
+		names = append(names, source.SourceName)
+	}
+	return names, nil
+}
+
+func (c *masterClient) CreateSource(source SourceConfig) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, sourcesPrefix)
+	return c.doJSON(apiURL, "POST", map[string]interface{}{"source": source})
+}
+
+func (c *masterClient) UpdateSource(name string, source SourceConfig) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, sourcesPrefix, url.PathEscape(name))
+	return c.doJSON(apiURL, "PUT", map[string]interface{}{"source": source})
+}
+
+func (c *masterClient) DeleteSource(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s?force=true", c.url, sourcesPrefix, url.PathEscape(name))
+	_, err := httputil.DeleteBodyOK(c.httpClient, apiURL)
+	return err
+}
+
+func (c *masterClient) ListTasks() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, tasksPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taskListResp{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal list tasks resp: %s, err: %s", body, err)
+	}
+	names := make([]string, 0, len(resp.Data))
+	for _, task := range resp.Data {
+		names = append(names, task.Name)
+	}
+	return names, nil
+}
+
+func (c *masterClient) CreateTask(task TaskConfig) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, tasksPrefix)
+	return c.doJSON(apiURL, "POST", map[string]interface{}{"task": task})
+}
+
+func (c *masterClient) UpdateTask(name string, task TaskConfig) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, tasksPrefix, url.PathEscape(name))
+	return c.doJSON(apiURL, "PUT", map[string]interface{}{"task": task})
+}
+
+func (c *masterClient) DeleteTask(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s?force=true", c.url, tasksPrefix, url.PathEscape(name))
+	_, err := httputil.DeleteBodyOK(c.httpClient, apiURL)
+	return err
+}
+
+func (c *masterClient) StartTask(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s/start", c.url, tasksPrefix, url.PathEscape(name))
+	return c.doJSON(apiURL, "POST", map[string]interface{}{})
+}
+
+func (c *masterClient) StopTask(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s/stop", c.url, tasksPrefix, url.PathEscape(name))
+	return c.doJSON(apiURL, "POST", map[string]interface{}{})
+}
+
+func (c *masterClient) GetTaskStatus(name string) ([]*SubTaskStatus, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/status", c.url, tasksPrefix, url.PathEscape(name))
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taskStatusResp{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal task status resp: %s, err: %s", body, err)
+	}
+	return resp.Data, nil
+}
+
+// doJSON sends the request with the JSON body, the errors of the OpenAPI are returned by the status code
+func (c *masterClient) doJSON(apiURL, method string, reqBody interface{}) error {
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, apiURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("error response %v URL %s, body response: %v", res.StatusCode, apiURL, httputil.ReadErrorBody(res.Body))
+	}
+	return nil
+}
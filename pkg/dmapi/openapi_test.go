@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmapi
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestListSourcesAndTasks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("GET"), "check method")
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch request.URL.Path {
+		case "/" + sourcesPrefix:
+			w.Write([]byte(`{"total":2,"data":[{"source_name":"mysql-01"},{"source_name":"mysql-02"}]}`))
+		case "/" + tasksPrefix:
+			w.Write([]byte(`{"total":1,"data":[{"name":"task-1"}]}`))
+		case fmt.Sprintf("/%s/task-1/status", tasksPrefix):
+			w.Write([]byte(`{"total":1,"data":[{"name":"task-1","source_name":"mysql-01","worker_name":"worker-0","stage":"Running","unit":"Sync",` +
+				`"sync_status":{"master_binlog":"(mysql-bin.000001, 2000)","syncer_binlog":"(mysql-bin.000001, 1000)","seconds_behind_master":3,"synced":false}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer svc.Close()
+
+	masterClient := NewMasterClient(svc.URL, DefaultTimeout, &tls.Config{}, false)
+	sources, err := masterClient.ListSources()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sources).To(Equal([]string{"mysql-01", "mysql-02"}))
+
+	tasks, err := masterClient.ListTasks()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tasks).To(Equal([]string{"task-1"}))
+
+	status, err := masterClient.GetTaskStatus("task-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(HaveLen(1))
+	g.Expect(status[0].SourceName).To(Equal("mysql-01"))
+	g.Expect(status[0].Stage).To(Equal("Running"))
+	g.Expect(status[0].SyncStatus.SecondsBehindMaster).To(Equal(int64(3)))
+}
+
+func TestCreateTask(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var body map[string]interface{}
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("POST"), "check method")
+		g.Expect(request.URL.Path).To(Equal("/"+tasksPrefix), "check url")
+		g.Expect(request.Header.Get("Content-Type")).To(Equal(ContentTypeJSON))
+		data, err := io.ReadAll(request.Body)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(json.Unmarshal(data, &body)).To(Succeed())
+		if body["task"].(map[string]interface{})["name"] == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_code":46003,"error_msg":"invalid task"}`))
+		}
+	})
+	defer svc.Close()
+
+	masterClient := NewMasterClient(svc.URL, DefaultTimeout, &tls.Config{}, false)
+	g.Expect(masterClient.CreateTask(TaskConfig{"name": "task-1", "task_mode": "all"})).To(Succeed())
+	g.Expect(body).To(Equal(map[string]interface{}{"task": map[string]interface{}{"name": "task-1", "task_mode": "all"}}))
+
+	err := masterClient.CreateTask(TaskConfig{"name": "invalid"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("invalid task"))
+}
@@ -32,6 +32,13 @@ var (
 		AdvancedStatefulSet: false,
 		AutoScaling:         false,
 		VolumeModifying:     false,
+		TidbClusterRollout:  false,
+		Diagnostic:          false,
+		TiCDCChangefeed:     false,
+		PDRecovery:          false,
+		GCTuning:            false,
+		DMTask:              false,
+		ConcurrentReconcile: false,
 	}
 	// DefaultFeatureGate is a shared global FeatureGate.
 	DefaultFeatureGate FeatureGate = NewDefaultFeatureGate()
@@ -49,6 +56,27 @@ const (
 
 	// VolumeModifying controls whether allow to modify volumes
 	VolumeModifying string = "VolumeModifying"
+
+	// TidbClusterRollout controls whether to use TidbClusterRollout to roll out spec patches to TidbClusters in waves
+	TidbClusterRollout string = "TidbClusterRollout"
+
+	// Diagnostic controls whether to use Diagnostic to collect diagnostic bundles of TidbClusters
+	Diagnostic string = "Diagnostic"
+
+	// TiCDCChangefeed controls whether to use TiCDCChangefeed to manage the changefeeds of TiCDC declaratively
+	TiCDCChangefeed string = "TiCDCChangefeed"
+
+	// PDRecovery controls whether to use PDRecovery to rebuild PD from the surviving TiKV stores
+	PDRecovery string = "PDRecovery"
+
+	// GCTuning controls whether to adjust the GC settings of TidbClusters in the windows of their GC tuning policies
+	GCTuning string = "GCTuning"
+
+	// DMTask controls whether to use DMTask to manage the data migration tasks of DMClusters declaratively
+	DMTask string = "DMTask"
+
+	// ConcurrentReconcile controls whether to sync the components of a TidbCluster concurrently after PD is synced
+	ConcurrentReconcile string = "ConcurrentReconcile"
 )
 
 type FeatureGate interface {
@@ -14,6 +14,9 @@
 package manager
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 )
 
@@ -35,3 +38,79 @@ type TiDBNGMonitoringManager interface {
 type TiDBDashboardManager interface {
 	Sync(*v1alpha1.TidbDashboard, *v1alpha1.TidbCluster) error
 }
+
+// ResourceOutcome is the outcome of reconciling a resource
+type ResourceOutcome string
+
+const (
+	ResourceCreated   ResourceOutcome = "Created"
+	ResourceUpdated   ResourceOutcome = "Updated"
+	ResourceUnchanged ResourceOutcome = "Unchanged"
+	ResourceFailed    ResourceOutcome = "Failed"
+)
+
+// ResourceResult is the result of reconciling a resource
+type ResourceResult struct {
+	Kind    string
+	Name    string
+	Outcome ResourceOutcome
+}
+
+func (r ResourceResult) String() string {
+	return fmt.Sprintf("%s/%s %s", r.Kind, r.Name, r.Outcome)
+}
+
+// Result is the structured result of a reconciliation. The resources are recorded in the order they are
+// reconciled, so the progress made before a failure is still reported, and WaitingOn reports what the
+// reconciled resources are waiting on before they are ready.
+type Result struct {
+	Resources []ResourceResult
+	WaitingOn []string
+}
+
+// Add records the outcome of reconciling a resource
+func (r *Result) Add(kind, name string, outcome ResourceOutcome) {
+	r.Resources = append(r.Resources, ResourceResult{Kind: kind, Name: name, Outcome: outcome})
+}
+
+// Wait records what the reconciled resources are waiting on
+func (r *Result) Wait(format string, args ...interface{}) {
+	r.WaitingOn = append(r.WaitingOn, fmt.Sprintf(format, args...))
+}
+
+// Failed returns the resource failed to reconcile, or nil if there is none
+func (r *Result) Failed() *ResourceResult {
+	for i := range r.Resources {
+		if r.Resources[i].Outcome == ResourceFailed {
+			return &r.Resources[i]
+		}
+	}
+	return nil
+}
+
+// Changed returns whether any resource is created or updated
+func (r *Result) Changed() bool {
+	for _, res := range r.Resources {
+		if res.Outcome == ResourceCreated || res.Outcome == ResourceUpdated {
+			return true
+		}
+	}
+	return false
+}
+
+// Ready returns whether all the resources are reconciled and not waiting on anything
+func (r *Result) Ready() bool {
+	return r.Failed() == nil && len(r.WaitingOn) == 0
+}
+
+func (r *Result) String() string {
+	items := make([]string, 0, len(r.Resources))
+	for _, res := range r.Resources {
+		items = append(items, res.String())
+	}
+	s := strings.Join(items, ", ")
+	if len(r.WaitingOn) > 0 {
+		s += fmt.Sprintf(", waiting on %s", strings.Join(r.WaitingOn, "; "))
+	}
+	return s
+}
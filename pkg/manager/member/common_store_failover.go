@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -32,6 +33,7 @@ import (
 // StoreAccess contains the common set of functions to access the properties of TiKV and TiFlash types
 type StoreAccess interface {
 	GetFailoverPeriod(cliConfig *controller.CLIConfig) time.Duration
+	GetFailover(tc *v1alpha1.TidbCluster) *v1alpha1.Failover
 	GetMemberType() v1alpha1.MemberType
 	GetMaxFailoverCount(tc *v1alpha1.TidbCluster) *int32
 	GetStores(tc *v1alpha1.TidbCluster) map[string]v1alpha1.TiKVStore
@@ -117,7 +119,8 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 			// (before it enters into Offline/Tombstone state)
 			continue
 		}
-		deadline := store.LastTransitionTime.Add(sf.storeAccess.GetFailoverPeriod(sf.deps.CLIConfig))
+		failover := sf.storeAccess.GetFailover(tc)
+		deadlineReached := failoverDeadlineReached(failover, sf.storeAccess.GetFailoverPeriod(sf.deps.CLIConfig), store.LastTransitionTime, store.ProbeFailures)
 		exist := false
 		for _, failureStore := range sf.storeAccess.GetFailureStores(tc) {
 			if failureStore.PodName == podName {
@@ -125,7 +128,7 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 				break
 			}
 		}
-		if store.State == v1alpha1.TiKVStateDown && time.Now().After(deadline) {
+		if store.State == v1alpha1.TiKVStateDown && deadlineReached {
 			maxFailoverCount := sf.storeAccess.GetMaxFailoverCount(tc)
 			if maxFailoverCount != nil && *maxFailoverCount > 0 {
 				sf.storeAccess.SetFailoverUIDIfAbsent(tc)
@@ -135,6 +138,16 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 						klog.Warningf("%s/%s %s failure stores count reached the limit: %d", ns, tcName, sf.storeAccess.GetMemberType(), maxFailoverCount)
 						return nil
 					}
+					if podFailureEvidenceRequired(failover) {
+						pod, err := sf.deps.PodLister.Pods(ns).Get(podName)
+						if err != nil && !errors.IsNotFound(err) {
+							return fmt.Errorf("%s failover [tryMarkAStoreAsFailure]: failed to get pod %s/%s, error: %s", sf.storeAccess.GetMemberType(), ns, podName, err)
+						}
+						if !podFailureEvidenceFound(failover, pod) {
+							klog.Infof("%s failover [tryMarkAStoreAsFailure]: pod %s/%s of down store %s is running and ready, skip marking it as failure", sf.storeAccess.GetMemberType(), ns, podName, store.ID)
+							continue
+						}
+					}
 					pvcs, err := sf.failureRecovery.getPodPvcs(tc, podName)
 					if err != nil {
 						return err
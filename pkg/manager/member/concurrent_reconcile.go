@@ -0,0 +1,236 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+// pdmsComponent is the component of the pd microservices, whose status is not a ComponentStatus
+const pdmsComponent v1alpha1.MemberType = "pdms"
+
+// upgradeDependencies are the components whose upgrade must be decided before the upgrade of the component,
+// the upgraders of them wait for the upstream components when the components are synced concurrently
+var upgradeDependencies = map[v1alpha1.MemberType][]v1alpha1.MemberType{
+	v1alpha1.TiKVMemberType:  {v1alpha1.TiFlashMemberType},
+	v1alpha1.PumpMemberType:  {v1alpha1.TiFlashMemberType, v1alpha1.TiKVMemberType},
+	v1alpha1.TiDBMemberType:  {v1alpha1.TiKVMemberType, v1alpha1.TiFlashMemberType, v1alpha1.PumpMemberType},
+	v1alpha1.TiCDCMemberType: {v1alpha1.TiKVMemberType, v1alpha1.TiFlashMemberType, v1alpha1.PumpMemberType, v1alpha1.TiDBMemberType},
+}
+
+// ComponentSyncer syncs a component of the tidb cluster, the component is one of the member types or "pdms"
+type ComponentSyncer struct {
+	Component string
+	Sync      func(tc *v1alpha1.TidbCluster) error
+}
+
+// componentSync is a component being synced on its own copy of the tidb cluster
+type componentSync struct {
+	tc   *v1alpha1.TidbCluster
+	done chan struct{}
+	err  error
+}
+
+// concurrentSync is the components synced concurrently in one sync of the tidb cluster
+type concurrentSync struct {
+	components map[v1alpha1.MemberType]*componentSync
+}
+
+// concurrentSyncs maps the copies of the tidb clusters being synced concurrently to their syncs
+var concurrentSyncs sync.Map
+
+// SyncComponentsConcurrently syncs the components concurrently, each on its own copy of tc, and merges the
+// status synced by them back to tc. The upgrade ordering of the components is kept by the upgraders, which
+// wait for the upstream components to be synced before checking their phases.
+func SyncComponentsConcurrently(tc *v1alpha1.TidbCluster, syncers []ComponentSyncer) error {
+	cs := &concurrentSync{components: map[v1alpha1.MemberType]*componentSync{}}
+	for _, syncer := range syncers {
+		copied := tc.DeepCopy()
+		cs.components[v1alpha1.MemberType(syncer.Component)] = &componentSync{tc: copied, done: make(chan struct{})}
+		concurrentSyncs.Store(copied, cs)
+	}
+	defer func() {
+		for _, s := range cs.components {
+			concurrentSyncs.Delete(s.tc)
+		}
+	}()
+
+	base := tc.DeepCopy()
+	for _, syncer := range syncers {
+		s := cs.components[v1alpha1.MemberType(syncer.Component)]
+		go func(syncer ComponentSyncer, s *componentSync) {
+			defer close(s.done)
+			// a panic of one component must not crash the operator or leave the others waiting for it
+			defer func() {
+				if r := recover(); r != nil {
+					klog.Errorf("tidbcluster %s/%s: panic when syncing %s: %v\n%s", tc.GetNamespace(), tc.GetName(), syncer.Component, r, debug.Stack())
+					s.err = fmt.Errorf("panic when syncing %s: %v", syncer.Component, r)
+				}
+			}()
+			s.err = syncer.Sync(s.tc)
+		}(syncer, s)
+	}
+
+	var errs []error
+	for _, syncer := range syncers {
+		component := v1alpha1.MemberType(syncer.Component)
+		s := cs.components[component]
+		<-s.done
+		mergeComponentStatus(tc, base, s.tc, component)
+		if s.err != nil {
+			errs = append(errs, s.err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// waitForUpgradeDependencies waits for the upstream components of the component to be synced if tc is
+// synced concurrently, and refreshes the phases of them in tc. It returns at once for the sequential sync.
+func waitForUpgradeDependencies(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) {
+	v, ok := concurrentSyncs.Load(tc)
+	if !ok {
+		return
+	}
+	cs := v.(*concurrentSync)
+	for _, upstream := range upgradeDependencies[component] {
+		s, ok := cs.components[upstream]
+		if !ok {
+			continue
+		}
+		klog.V(4).Infof("tidbcluster %s/%s: %s is waiting for %s to be synced", tc.GetNamespace(), tc.GetName(), component, upstream)
+		<-s.done
+		synced, status := s.tc.ComponentStatus(upstream), tc.ComponentStatus(upstream)
+		if synced != nil && status != nil {
+			status.SetPhase(synced.GetPhase())
+		}
+	}
+}
+
+// mergeComponentStatus merges the status synced by the component from synced to tc, the fields shared by
+// the components are merged only if they are changed by the component
+func mergeComponentStatus(tc, base, synced *v1alpha1.TidbCluster, component v1alpha1.MemberType) {
+	switch component {
+	case pdmsComponent:
+		tc.Status.PDMS = synced.Status.PDMS
+	case v1alpha1.TiProxyMemberType:
+		tc.Status.TiProxy = synced.Status.TiProxy
+	case v1alpha1.TiFlashMemberType:
+		tc.Status.TiFlash = synced.Status.TiFlash
+	case v1alpha1.TiKVMemberType:
+		tc.Status.TiKV = synced.Status.TiKV
+		tc.Status.TiKVGroups = synced.Status.TiKVGroups
+	case v1alpha1.PumpMemberType:
+		tc.Status.Pump = synced.Status.Pump
+	case v1alpha1.TiDBMemberType:
+		tc.Status.TiDB = synced.Status.TiDB
+	case v1alpha1.TiCDCMemberType:
+		tc.Status.TiCDC = synced.Status.TiCDC
+	}
+
+	if tc.Status.ClusterID == "" {
+		tc.Status.ClusterID = synced.Status.ClusterID
+	}
+	mergeConditions(tc, base, synced)
+	tc.Annotations = mergeStringMap(tc.Annotations, base.Annotations, synced.Annotations)
+	tc.Labels = mergeStringMap(tc.Labels, base.Labels, synced.Labels)
+	for memberType := range unionKeys(base.Status.TLSRotations, synced.Status.TLSRotations) {
+		rotation, ok := synced.Status.TLSRotations[memberType]
+		baseRotation, baseOK := base.Status.TLSRotations[memberType]
+		if ok == baseOK && apiequality.Semantic.DeepEqual(rotation, baseRotation) {
+			continue
+		}
+		if !ok {
+			delete(tc.Status.TLSRotations, memberType)
+			continue
+		}
+		if tc.Status.TLSRotations == nil {
+			tc.Status.TLSRotations = map[v1alpha1.MemberType]v1alpha1.TLSRotationStatus{}
+		}
+		tc.Status.TLSRotations[memberType] = rotation
+	}
+	for i := range synced.Status.Overrides {
+		if i >= len(tc.Status.Overrides) || i >= len(base.Status.Overrides) {
+			break
+		}
+		if !apiequality.Semantic.DeepEqual(synced.Status.Overrides[i], base.Status.Overrides[i]) {
+			tc.Status.Overrides[i] = synced.Status.Overrides[i]
+		}
+	}
+}
+
+// mergeConditions merges the conditions added, changed or removed by the component from synced to tc
+func mergeConditions(tc, base, synced *v1alpha1.TidbCluster) {
+	condTypes := map[v1alpha1.TidbClusterConditionType]struct{}{}
+	for _, cond := range base.Status.Conditions {
+		condTypes[cond.Type] = struct{}{}
+	}
+	for _, cond := range synced.Status.Conditions {
+		condTypes[cond.Type] = struct{}{}
+	}
+	for condType := range condTypes {
+		cond := utiltidbcluster.GetTidbClusterCondition(synced.Status, condType)
+		if apiequality.Semantic.DeepEqual(cond, utiltidbcluster.GetTidbClusterCondition(base.Status, condType)) {
+			continue
+		}
+		conditions := make([]v1alpha1.TidbClusterCondition, 0, len(tc.Status.Conditions))
+		for _, c := range tc.Status.Conditions {
+			if c.Type != condType {
+				conditions = append(conditions, c)
+			}
+		}
+		if cond != nil {
+			conditions = append(conditions, *cond)
+		}
+		tc.Status.Conditions = conditions
+	}
+}
+
+// mergeStringMap merges the keys added, changed or removed in synced from base to m and returns the result
+func mergeStringMap(m, base, synced map[string]string) map[string]string {
+	for k := range base {
+		if _, ok := synced[k]; !ok {
+			delete(m, k)
+		}
+	}
+	for k, v := range synced {
+		if old, ok := base[k]; ok && old == v {
+			continue
+		}
+		if m == nil {
+			m = map[string]string{}
+		}
+		m[k] = v
+	}
+	return m
+}
+
+func unionKeys(a, b map[v1alpha1.MemberType]v1alpha1.TLSRotationStatus) map[v1alpha1.MemberType]struct{} {
+	keys := map[v1alpha1.MemberType]struct{}{}
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestSyncComponentsConcurrently(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:      &v1alpha1.PDSpec{},
+			TiKV:    &v1alpha1.TiKVSpec{},
+			TiDB:    &v1alpha1.TiDBSpec{},
+			TiFlash: &v1alpha1.TiFlashSpec{},
+			Overrides: []v1alpha1.ResourceOverride{
+				{Kind: v1alpha1.ResourceOverrideKindStatefulSet, Name: "test-tikv"},
+				{Kind: v1alpha1.ResourceOverrideKindStatefulSet, Name: "test-tidb"},
+			},
+		},
+	}
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc.Status.TiDB.Phase = v1alpha1.NormalPhase
+	tc.Status.Conditions = []v1alpha1.TidbClusterCondition{{Type: v1alpha1.TidbClusterReady, Status: corev1.ConditionTrue}}
+	tc.Annotations = map[string]string{"removed-by-tidb": "true"}
+	SyncResourceOverrideStatus(tc)
+
+	tikvSyncing := make(chan struct{})
+	var tikvPhaseSeenByTiDB v1alpha1.MemberPhase
+	syncers := []ComponentSyncer{
+		{
+			Component: "tiflash",
+			Sync: func(tc *v1alpha1.TidbCluster) error {
+				return fmt.Errorf("tiflash is not ready")
+			},
+		},
+		{
+			Component: "tiproxy",
+			Sync: func(tc *v1alpha1.TidbCluster) error {
+				panic("tiproxy panics")
+			},
+		},
+		{
+			Component: "tikv",
+			Sync: func(tc *v1alpha1.TidbCluster) error {
+				<-tikvSyncing
+				tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+				tc.Status.ClusterID = "1"
+				tc.Status.Overrides[0].Phase = v1alpha1.ResourceOverridePhaseApplied
+				tc.Status.Conditions = append(tc.Status.Conditions, v1alpha1.TidbClusterCondition{Type: v1alpha1.TidbClusterSlowStore, Status: corev1.ConditionTrue})
+				tc.Annotations["added-by-tikv"] = "true"
+				return nil
+			},
+		},
+		{
+			Component: "tidb",
+			Sync: func(tc *v1alpha1.TidbCluster) error {
+				close(tikvSyncing)
+				// tidb sees the phase of tikv synced in this round before upgrading
+				waitForUpgradeDependencies(tc, v1alpha1.TiDBMemberType)
+				tikvPhaseSeenByTiDB = tc.Status.TiKV.Phase
+				tc.Status.Overrides[1].Phase = v1alpha1.ResourceOverridePhaseConflict
+				tc.Status.Conditions = nil
+				tc.Status.TLSRotations = map[v1alpha1.MemberType]v1alpha1.TLSRotationStatus{v1alpha1.TiDBMemberType: {CertHash: "hash"}}
+				delete(tc.Annotations, "removed-by-tidb")
+				return nil
+			},
+		},
+	}
+
+	err := SyncComponentsConcurrently(tc, syncers)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("tiflash is not ready"))
+	g.Expect(err.Error()).To(ContainSubstring("tiproxy panics"))
+	g.Expect(tikvPhaseSeenByTiDB).To(Equal(v1alpha1.UpgradePhase))
+	g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.UpgradePhase))
+	g.Expect(tc.Status.TiDB.Phase).To(Equal(v1alpha1.NormalPhase))
+	g.Expect(tc.Status.ClusterID).To(Equal("1"))
+	g.Expect(tc.Status.Overrides[0].Phase).To(Equal(v1alpha1.ResourceOverridePhaseApplied))
+	g.Expect(tc.Status.Overrides[1].Phase).To(Equal(v1alpha1.ResourceOverridePhaseConflict))
+	g.Expect(tc.Status.Conditions).To(Equal([]v1alpha1.TidbClusterCondition{{Type: v1alpha1.TidbClusterSlowStore, Status: corev1.ConditionTrue}}))
+	g.Expect(tc.Status.TLSRotations).To(HaveKeyWithValue(v1alpha1.TiDBMemberType, v1alpha1.TLSRotationStatus{CertHash: "hash"}))
+	g.Expect(tc.Annotations).To(Equal(map[string]string{"added-by-tikv": "true"}))
+
+	// the copies are unregistered after the sync, and the sequential sync never waits
+	concurrentSyncs.Range(func(key, value interface{}) bool {
+		t.Errorf("copy of %s is not unregistered", key.(*v1alpha1.TidbCluster).Name)
+		return true
+	})
+	waitForUpgradeDependencies(tc, v1alpha1.TiCDCMemberType)
+}
@@ -124,7 +124,7 @@ func (m *masterMemberManager) syncMasterServiceForDMCluster(dc *v1alpha1.DMClust
 	if err != nil {
 		return err
 	}
-	if !equal {
+	if !equal || !controller.ServiceTrafficAnnotationsEqual(newSvc, oldSvc) {
 		svc := *oldSvc
 		svc.Spec = newSvc.Spec
 		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
@@ -135,6 +135,7 @@ func (m *masterMemberManager) syncMasterServiceForDMCluster(dc *v1alpha1.DMClust
 		for k, v := range newSvc.Annotations {
 			svc.Annotations[k] = v
 		}
+		controller.CopyServiceTrafficAnnotations(&svc, newSvc)
 		_, err = m.deps.ServiceControl.UpdateService(dc, &svc)
 		return err
 	}
@@ -211,6 +212,7 @@ func (m *masterMemberManager) syncMasterStatefulSetForDMCluster(dc *v1alpha1.DMC
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newMasterSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors)
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newMasterSet)
 		if err != nil {
@@ -320,12 +322,12 @@ func (m *masterMemberManager) syncDMClusterStatus(dc *v1alpha1.DMCluster, set *a
 	if err != nil {
 		dc.Status.Master.Synced = false
 		// get endpoints info
-		eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.DMMasterMemberName(dcName))
+		addrs, epErr := controller.GetServiceEndpointAddresses(m.deps, ns, controller.DMMasterMemberName(dcName))
 		if epErr != nil {
 			return fmt.Errorf("syncDMClusterStatus: failed to get endpoints %s for cluster %s/%s, err: %s, epErr %s", controller.DMMasterMemberName(dcName), ns, dcName, err, epErr)
 		}
 		// dm-master service has no endpoints
-		if eps != nil && len(eps.Subsets) == 0 {
+		if len(addrs) == 0 {
 			return fmt.Errorf("%s, service %s/%s has no endpoints", err, ns, controller.DMMasterMemberName(dcName))
 		}
 		return err
@@ -461,6 +463,7 @@ func (m *masterMemberManager) getNewMasterServiceForDMCluster(dc *v1alpha1.DMClu
 		if svcSpec.PortName != nil {
 			masterSvc.Spec.Ports[0].Name = *svcSpec.PortName
 		}
+		controller.SetServiceTrafficOptions(masterSvc, &svcSpec.ServiceSpec)
 	}
 	return masterSvc
 }
@@ -0,0 +1,192 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	certutil "github.com/pingcap/tidb-operator/pkg/util/crypto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	dmClusterCAValidity   = 10 * 365 * 24 * time.Hour
+	dmClusterCertValidity = 365 * 24 * time.Hour
+
+	// dmCertIssued is the event reason when a cert is issued for the dm cluster
+	dmCertIssued = "CertIssued"
+)
+
+// DMClusterCASecretName returns the name of the secret storing the CA which issues the dm cluster certs
+func DMClusterCASecretName(dcName string) string {
+	return fmt.Sprintf("%s-dm-cluster-ca", dcName)
+}
+
+// dmClusterCertManager issues the certs used between dm-master and dm-worker when
+// the TLS of the dm cluster is enabled.
+//
+// The certs are signed by a CA generated for the dm cluster, and only the missing secrets
+// are issued, so the secrets provided by users or cert-manager are never touched. Delete the
+// secret of a component to issue the cert again.
+type dmClusterCertManager struct {
+	deps *controller.Dependencies
+}
+
+// NewDMClusterCertManager returns a DMManager which issues the cluster certs of the dm cluster
+func NewDMClusterCertManager(deps *controller.Dependencies) manager.DMManager {
+	return &dmClusterCertManager{deps: deps}
+}
+
+func (m *dmClusterCertManager) SyncDM(dc *v1alpha1.DMCluster) error {
+	if !dc.IsTLSClusterEnabled() {
+		return nil
+	}
+
+	ns := dc.GetNamespace()
+	dcName := dc.GetName()
+
+	masterHosts := dmClusterCertHosts(ns, controller.DMMasterMemberName(dcName), controller.DMMasterPeerMemberName(dcName))
+	workerHosts := dmClusterCertHosts(ns, controller.DMWorkerMemberName(dcName), controller.DMWorkerPeerMemberName(dcName))
+	certs := []struct {
+		secretName string
+		commonName string
+		hosts      []string
+	}{
+		{util.ClusterTLSSecretName(dcName, label.DMMasterLabelVal), controller.DMMasterMemberName(dcName), masterHosts},
+		{util.ClusterTLSSecretName(dcName, label.DMWorkerLabelVal), controller.DMWorkerMemberName(dcName), workerHosts},
+		{util.DMClientTLSSecretName(dcName), fmt.Sprintf("%s-dm-client", dcName), nil},
+	}
+
+	var missing []int
+	for i, cert := range certs {
+		_, err := m.deps.SecretLister.Secrets(ns).Get(cert.secretName)
+		if errors.IsNotFound(err) {
+			missing = append(missing, i)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("dmClusterCertManager.SyncDM: failed to get secret %s/%s for dm cluster %s, error: %v", ns, cert.secretName, dcName, err)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	caCert, caKey, err := m.loadOrCreateCA(dc)
+	if err != nil {
+		return err
+	}
+	for _, i := range missing {
+		cert := certs[i]
+		certPEM, keyPEM, err := certutil.NewSignedCert(caCert, caKey, cert.commonName, cert.hosts, dmClusterCertValidity)
+		if err != nil {
+			return fmt.Errorf("dmClusterCertManager.SyncDM: failed to issue cert %s/%s for dm cluster %s, error: %v", ns, cert.secretName, dcName, err)
+		}
+		secret := newDMClusterCertSecret(dc, cert.secretName, map[string][]byte{
+			corev1.ServiceAccountRootCAKey: caCert,
+			corev1.TLSCertKey:              certPEM,
+			corev1.TLSPrivateKeyKey:        keyPEM,
+		})
+		if err := m.deps.SecretControl.Create(ns, secret); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("dmClusterCertManager.SyncDM: failed to create secret %s/%s for dm cluster %s, error: %v", ns, cert.secretName, dcName, err)
+		}
+		klog.Infof("DMCluster: [%s/%s], issue cert in secret %s", ns, dcName, cert.secretName)
+		m.deps.Recorder.Eventf(dc, corev1.EventTypeNormal, dmCertIssued, "issue cert in secret %s", cert.secretName)
+	}
+	return nil
+}
+
+// loadOrCreateCA returns the cert and key of the CA of the dm cluster, the CA is created if it doesn't exist
+func (m *dmClusterCertManager) loadOrCreateCA(dc *v1alpha1.DMCluster) ([]byte, []byte, error) {
+	ns := dc.GetNamespace()
+	secretName := DMClusterCASecretName(dc.GetName())
+
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(secretName)
+	if err == nil {
+		caCert, caKey := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		if len(caCert) == 0 || len(caKey) == 0 {
+			return nil, nil, fmt.Errorf("dmClusterCertManager.SyncDM: cert or key does not exist in CA secret %s/%s", ns, secretName)
+		}
+		return caCert, caKey, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("dmClusterCertManager.SyncDM: failed to get CA secret %s/%s, error: %v", ns, secretName, err)
+	}
+
+	caCert, caKey, err := certutil.NewSelfSignedCA(fmt.Sprintf("%s-dm-cluster-ca", dc.GetName()), dmClusterCAValidity)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dmClusterCertManager.SyncDM: failed to generate CA for dm cluster %s/%s, error: %v", ns, dc.GetName(), err)
+	}
+	secret = newDMClusterCertSecret(dc, secretName, map[string][]byte{
+		corev1.TLSCertKey:       caCert,
+		corev1.TLSPrivateKeyKey: caKey,
+	})
+	if err := m.deps.SecretControl.Create(ns, secret); err != nil {
+		// the CA may be created by the last sync but not observed by the lister yet, retry
+		// the sync to avoid issuing certs by different CAs
+		return nil, nil, fmt.Errorf("dmClusterCertManager.SyncDM: failed to create CA secret %s/%s, error: %v", ns, secretName, err)
+	}
+	klog.Infof("DMCluster: [%s/%s], create CA in secret %s", ns, dc.GetName(), secretName)
+	return caCert, caKey, nil
+}
+
+func dmClusterCertHosts(ns, svcName, peerSvcName string) []string {
+	var hosts []string
+	for _, name := range []string{svcName, peerSvcName, "*." + peerSvcName} {
+		hosts = append(hosts,
+			name,
+			fmt.Sprintf("%s.%s", name, ns),
+			fmt.Sprintf("%s.%s.svc", name, ns),
+		)
+	}
+	return hosts
+}
+
+func newDMClusterCertSecret(dc *v1alpha1.DMCluster, name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       dc.GetNamespace(),
+			Labels:          label.NewDM().Instance(dc.GetInstanceName()).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetDMOwnerRef(dc)},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
+
+type FakeDMClusterCertManager struct {
+	err error
+}
+
+func NewFakeDMClusterCertManager() *FakeDMClusterCertManager {
+	return &FakeDMClusterCertManager{}
+}
+
+func (m *FakeDMClusterCertManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeDMClusterCertManager) SyncDM(_ *v1alpha1.DMCluster) error {
+	return m.err
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"crypto/x509"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	certutil "github.com/pingcap/tidb-operator/pkg/util/crypto"
+)
+
+func TestDMClusterCertManagerSyncDM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	m := NewDMClusterCertManager(deps)
+
+	dc := newDMClusterForMaster()
+	g.Expect(m.SyncDM(dc)).To(Succeed())
+	g.Expect(secretIndexer.List()).To(BeEmpty())
+
+	dc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.ClusterTLSSecretName(dc.Name, label.DMWorkerLabelVal),
+			Namespace: dc.Namespace,
+		},
+	}
+	g.Expect(secretIndexer.Add(userSecret)).To(Succeed())
+	g.Expect(m.SyncDM(dc)).To(Succeed())
+	// CA, dm-master and client secrets are created, the dm-worker secret provided by users is kept
+	g.Expect(secretIndexer.List()).To(HaveLen(4))
+
+	worker, err := deps.SecretLister.Secrets(dc.Namespace).Get(userSecret.Name)
+	g.Expect(err).To(Succeed())
+	g.Expect(worker.Data).To(BeEmpty())
+
+	ca, err := deps.SecretLister.Secrets(dc.Namespace).Get(DMClusterCASecretName(dc.Name))
+	g.Expect(err).To(Succeed())
+	master, err := deps.SecretLister.Secrets(dc.Namespace).Get(util.ClusterTLSSecretName(dc.Name, label.DMMasterLabelVal))
+	g.Expect(err).To(Succeed())
+	g.Expect(master.Data[corev1.ServiceAccountRootCAKey]).To(Equal(ca.Data[corev1.TLSCertKey]))
+
+	tlsConfig, err := certutil.LoadTlsConfigFromSecret(master)
+	g.Expect(err).To(Succeed())
+	cert, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	g.Expect(err).To(Succeed())
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:   "test-dm-master-0.test-dm-master-peer.default.svc",
+		Roots:     tlsConfig.RootCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	g.Expect(err).To(Succeed())
+
+	_, err = deps.SecretLister.Secrets(dc.Namespace).Get(util.DMClientTLSSecretName(dc.Name))
+	g.Expect(err).To(Succeed())
+
+	// the issued secrets are not changed by the next sync
+	g.Expect(m.SyncDM(dc)).To(Succeed())
+	g.Expect(secretIndexer.List()).To(HaveLen(4))
+	master2, err := deps.SecretLister.Secrets(dc.Namespace).Get(master.Name)
+	g.Expect(err).To(Succeed())
+	g.Expect(master2.Data).To(Equal(master.Data))
+}
@@ -207,6 +207,7 @@ func (m *workerMemberManager) syncWorkerStatefulSetForDMCluster(dc *v1alpha1.DMC
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSts.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors)
 
 	if stsNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
@@ -14,7 +14,12 @@
 package member
 
 import (
+	"time"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
 // TODO: move this to a centralized place
@@ -40,3 +45,44 @@ type DMFailover interface {
 	Recover(*v1alpha1.DMCluster)
 	RemoveUndesiredFailures(*v1alpha1.DMCluster)
 }
+
+// failoverDeadlineReached returns whether a member which is unhealthy since lastTransitionTime and probed
+// unhealthy probeFailures times in a row is detected as failed by the failover detection of the component,
+// defaultPeriod is the failover period of the component set in tidb-controller-manager.
+func failoverDeadlineReached(failover *v1alpha1.Failover, defaultPeriod time.Duration, lastTransitionTime metav1.Time, probeFailures int32) bool {
+	period := defaultPeriod
+	if failover != nil && failover.Detection != nil {
+		if failover.Detection.Period != nil {
+			period = failover.Detection.Period.Duration
+		}
+		// the member is probed unhealthy at least once since lastTransitionTime, so the
+		// probe failures are checked only if the threshold is set
+		if threshold := failover.Detection.ProbeFailureThreshold; threshold != nil && probeFailures < *threshold {
+			return false
+		}
+	}
+	return time.Now().After(lastTransitionTime.Add(period))
+}
+
+// podFailureEvidenceRequired returns whether the pod of a member must show the failure as well
+// before the member is marked as failed.
+func podFailureEvidenceRequired(failover *v1alpha1.Failover) bool {
+	return failover != nil && failover.Detection != nil && failover.Detection.PodPhaseAsEvidence
+}
+
+// podFailureEvidenceFound returns whether the pod of a member shows the failure of the member,
+// it's always true if the pod evidence is not required by the failover detection.
+func podFailureEvidenceFound(failover *v1alpha1.Failover, pod *corev1.Pod) bool {
+	if !podFailureEvidenceRequired(failover) || pod == nil {
+		return true
+	}
+	return pod.Status.Phase != corev1.PodRunning || !podutil.IsPodReady(pod)
+}
+
+// nextProbeFailures returns the consecutive times a member is probed unhealthy after it's probed again.
+func nextProbeFailures(healthy bool, previous int32) int32 {
+	if healthy {
+		return 0
+	}
+	return previous + 1
+}
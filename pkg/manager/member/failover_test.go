@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestFailoverDeadlineReached(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	unhealthySince := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	tests := []struct {
+		name          string
+		failover      *v1alpha1.Failover
+		probeFailures int32
+		expect        bool
+	}{
+		{
+			name:   "default period is reached",
+			expect: true,
+		},
+		{
+			name: "period is not reached",
+			failover: &v1alpha1.Failover{Detection: &v1alpha1.FailoverDetection{
+				Period: &metav1.Duration{Duration: time.Hour},
+			}},
+			probeFailures: 100,
+			expect:        false,
+		},
+		{
+			name: "probe failures are not reached",
+			failover: &v1alpha1.Failover{Detection: &v1alpha1.FailoverDetection{
+				ProbeFailureThreshold: pointer.Int32Ptr(3),
+			}},
+			probeFailures: 2,
+			expect:        false,
+		},
+		{
+			name: "period and probe failures are reached",
+			failover: &v1alpha1.Failover{Detection: &v1alpha1.FailoverDetection{
+				Period:                &metav1.Duration{Duration: time.Minute},
+				ProbeFailureThreshold: pointer.Int32Ptr(3),
+			}},
+			probeFailures: 3,
+			expect:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Log(tt.name)
+		g.Expect(failoverDeadlineReached(tt.failover, 5*time.Minute, unhealthySince, tt.probeFailures)).To(Equal(tt.expect))
+	}
+}
+
+func TestPodFailureEvidenceFound(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	readyPod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReadyPod := readyPod.DeepCopy()
+	notReadyPod.Status.Conditions[0].Status = corev1.ConditionFalse
+	unknownPod := readyPod.DeepCopy()
+	unknownPod.Status.Phase = corev1.PodUnknown
+
+	g.Expect(podFailureEvidenceFound(nil, readyPod)).To(BeTrue())
+
+	failover := &v1alpha1.Failover{Detection: &v1alpha1.FailoverDetection{PodPhaseAsEvidence: true}}
+	g.Expect(podFailureEvidenceFound(failover, readyPod)).To(BeFalse())
+	g.Expect(podFailureEvidenceFound(failover, notReadyPod)).To(BeTrue())
+	g.Expect(podFailureEvidenceFound(failover, unknownPod)).To(BeTrue())
+	g.Expect(podFailureEvidenceFound(failover, nil)).To(BeTrue())
+}
+
+func TestNextProbeFailures(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(nextProbeFailures(false, 0)).To(Equal(int32(1)))
+	g.Expect(nextProbeFailures(false, 2)).To(Equal(int32(3)))
+	g.Expect(nextProbeFailures(true, 2)).To(Equal(int32(0)))
+}
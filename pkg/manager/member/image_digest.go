@@ -0,0 +1,223 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+// imageDigestProbeComponent is the component label of the pods which resolve the digests of the images
+const imageDigestProbeComponent = "image-digest-probe"
+
+// syncImageDigest resolves the digest of the image of a component and pins the container of the new StatefulSet
+// to it if the digest pinning of the cluster is enabled.
+//
+// With the digest pinning, the digest is resolved by a probe pod which pulls the image from the registry before
+// the StatefulSet is upgraded to the image, and the pod template of the old StatefulSet is kept until then, so the
+// StatefulSet is upgraded only once and all the pods run the same digest. Without the digest pinning, the digest
+// is only recorded from the image IDs of the pods at the update revision of the StatefulSet.
+//
+// The digest is resolved once for an image, so the later mutation of the tag does not change the resolved digest.
+func syncImageDigest(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, image string,
+	old *v1alpha1.ImageDigest, oldSet, newSet *apps.StatefulSet) (*v1alpha1.ImageDigest, error) {
+	containerName := memberType.String()
+	digest := old
+	if digest == nil || digest.Image != image {
+		var err error
+		if !tc.Spec.ImageDigestPinning {
+			digest, err = resolveImageDigestFromPods(deps, tc, oldSet, containerName, image)
+		} else {
+			digest, err = resolveImageDigestByProbe(deps, tc, memberType, image)
+		}
+		if err != nil {
+			return old, err
+		}
+	}
+	if digest != nil || !tc.Spec.ImageDigestPinning {
+		if err := deleteImageDigestProbe(deps, tc, memberType); err != nil {
+			return digest, err
+		}
+	}
+	if !tc.Spec.ImageDigestPinning {
+		return digest, nil
+	}
+
+	if digest == nil {
+		if oldSet == nil {
+			return nil, controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s waits for the digest of image %s to be resolved",
+				tc.GetNamespace(), tc.GetName(), memberType, image)
+		}
+		klog.Infof("tidbcluster: [%s/%s]'s %s keeps the pod template until the digest of image %s is resolved",
+			tc.GetNamespace(), tc.GetName(), memberType, image)
+		newSet.Spec.Template = *oldSet.Spec.Template.DeepCopy()
+		return nil, nil
+	}
+	podSpec := &newSet.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == containerName {
+			podSpec.Containers[i].Image = util.PinImageDigest(podSpec.Containers[i].Image, digest.Digest)
+		}
+	}
+	return digest, nil
+}
+
+// resolveImageDigest resolves the digest of an image which refers to a digest, nil is returned for a tag
+func resolveImageDigest(image string) *v1alpha1.ImageDigest {
+	if i := strings.LastIndexByte(image, '@'); i >= 0 {
+		return &v1alpha1.ImageDigest{Image: image, Digest: image[i+1:]}
+	}
+	return nil
+}
+
+// resolveImageDigestFromPods resolves the digest of the image of a component from the image IDs reported by the
+// container runtime of the pods at the update revision of the StatefulSet, nil is returned if it's not resolved yet.
+func resolveImageDigestFromPods(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, set *apps.StatefulSet, containerName, image string) (*v1alpha1.ImageDigest, error) {
+	if digest := resolveImageDigest(image); digest != nil {
+		return digest, nil
+	}
+	if set == nil {
+		return nil, nil
+	}
+	mirrors := util.MergeRegistryMirrors(deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	if c := findContainerByName(set, containerName); c == nil || c.Image != util.RewriteImage(image, mirrors) {
+		// the statefulset is not upgraded to the image yet
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(set.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("resolveImageDigestFromPods: failed to convert the selector of sts %s/%s, error: %v", set.Namespace, set.Name, err)
+	}
+	pods, err := deps.PodLister.Pods(set.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("resolveImageDigestFromPods: failed to list pods of sts %s/%s, error: %v", set.Namespace, set.Name, err)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	for _, pod := range pods {
+		if pod.Labels[apps.ControllerRevisionHashLabelKey] != set.Status.UpdateRevision {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			if digest := util.ImageDigestOf(status.ImageID); digest != "" {
+				return &v1alpha1.ImageDigest{Image: image, Digest: digest}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// resolveImageDigestByProbe resolves the digest of the image of a component by a probe pod which pulls the image
+// from the registry and exits at once, nil is returned if it's not resolved yet.
+func resolveImageDigestByProbe(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, image string) (*v1alpha1.ImageDigest, error) {
+	if digest := resolveImageDigest(image); digest != nil {
+		return digest, nil
+	}
+	ns := tc.GetNamespace()
+	mirrors := util.MergeRegistryMirrors(deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	probeImage := util.RewriteImage(image, mirrors)
+
+	pod, err := deps.PodLister.Pods(ns).Get(imageDigestProbeName(tc, memberType))
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("resolveImageDigestByProbe: failed to get pod %s/%s, error: %v", ns, imageDigestProbeName(tc, memberType), err)
+	}
+	if errors.IsNotFound(err) {
+		pod = newImageDigestProbe(tc, memberType, probeImage)
+		if err := deps.TypedControl.Create(tc, pod); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("resolveImageDigestByProbe: failed to create pod %s/%s, error: %v", ns, pod.Name, err)
+		}
+		klog.Infof("tidbcluster: [%s/%s] creates pod %s to resolve the digest of image %s", ns, tc.GetName(), pod.Name, probeImage)
+		return nil, nil
+	}
+	if len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Image != probeImage {
+		// the probe of a previous image
+		return nil, deleteImageDigestProbe(deps, tc, memberType)
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.ImageID == "" {
+			continue
+		}
+		digest := util.ImageDigestOf(status.ImageID)
+		if digest == "" {
+			return nil, fmt.Errorf("resolveImageDigestByProbe: image %s has no repository digest, image ID: %s", probeImage, status.ImageID)
+		}
+		return &v1alpha1.ImageDigest{Image: image, Digest: digest}, nil
+	}
+	return nil, nil
+}
+
+func deleteImageDigestProbe(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) error {
+	pod, err := deps.PodLister.Pods(tc.GetNamespace()).Get(imageDigestProbeName(tc, memberType))
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deleteImageDigestProbe: failed to get pod %s/%s, error: %v", tc.GetNamespace(), imageDigestProbeName(tc, memberType), err)
+	}
+	if !metav1.IsControlledBy(pod, tc) {
+		return nil
+	}
+	if err := deps.TypedControl.Delete(tc, pod); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleteImageDigestProbe: failed to delete pod %s/%s, error: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+func imageDigestProbeName(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) string {
+	return fmt.Sprintf("%s-%s-digest-probe", tc.GetName(), memberType)
+}
+
+// newImageDigestProbe returns the pod which pulls the image on the nodes of the component, the pod does not
+// have the instance label so it's not regarded as a pod of the cluster
+func newImageDigestProbe(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, image string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      imageDigestProbeName(tc, memberType),
+			Namespace: tc.GetNamespace(),
+			Labels:    label.New().Component(imageDigestProbeComponent).Labels(),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:            memberType.String(),
+				Image:           image,
+				ImagePullPolicy: corev1.PullAlways,
+				Command:         []string{"/bin/sh", "-c", "true"},
+			}},
+		},
+	}
+	if spec := tc.ComponentSpec(memberType); spec != nil {
+		pod.Spec.ImagePullSecrets = spec.ImagePullSecrets()
+		pod.Spec.NodeSelector = spec.NodeSelector()
+		pod.Spec.Tolerations = spec.Tolerations()
+		pod.Spec.SchedulerName = spec.SchedulerName()
+	}
+	return pod
+}
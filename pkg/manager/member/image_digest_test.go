@@ -0,0 +1,147 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestResolveImageDigest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const (
+		digest    = "sha256:0d3a3b4f7cb5f2b5d8e4b5c7ad3e5a1b2c3d4e5f60718293a4b5c6d7e8f90a1b"
+		newDigest = "sha256:1e4b4c5a8dc6a3c6e9f5c6d8be4f6b2c3d4e5f6a708192a3b4c5d6e7f8a90b1c"
+	)
+	deps := controller.NewFakeDependencies()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.BaseImage = "pingcap/pd"
+	tc.Spec.Version = "v7.1.0"
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-pd"},
+		Spec: apps.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "pd"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "pd", Image: "pingcap/pd:v7.0.0"}}},
+			},
+		},
+		Status: apps.StatefulSetStatus{UpdateRevision: "rev-2"},
+	}
+	newPod := func(name, revision, imageID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{"app": "pd", apps.ControllerRevisionHashLabelKey: revision},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "pd", ImageID: imageID}},
+			},
+		}
+	}
+	g.Expect(podIndexer.Add(newPod("test-pd-0", "rev-1", "docker-pullable://pingcap/pd@sha256:old"))).To(Succeed())
+	g.Expect(podIndexer.Add(newPod("test-pd-1", "rev-2", ""))).To(Succeed())
+	g.Expect(podIndexer.Add(newPod("test-pd-2", "rev-2", "docker.io/pingcap/pd@"+digest))).To(Succeed())
+
+	// the statefulset is not upgraded to the image yet
+	resolved, err := resolveImageDigestFromPods(deps, tc, set, "pd", tc.PDImage())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolved).To(BeNil())
+
+	// the digest is resolved from the pods at the update revision
+	set.Spec.Template.Spec.Containers[0].Image = "pingcap/pd:v7.1.0"
+	resolved, err = resolveImageDigestFromPods(deps, tc, set, "pd", tc.PDImage())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolved).To(Equal(&v1alpha1.ImageDigest{Image: "pingcap/pd:v7.1.0", Digest: digest}))
+
+	// the resolved digest is kept even if the tag is mutated, and the pods are not pinned without the pinning
+	g.Expect(podIndexer.Update(newPod("test-pd-2", "rev-2", "docker.io/pingcap/pd@"+newDigest))).To(Succeed())
+	newSet := set.DeepCopy()
+	resolved, err = syncImageDigest(deps, tc, v1alpha1.PDMemberType, tc.PDImage(), resolved, set, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolved.Digest).To(Equal(digest))
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/pd:v7.1.0"))
+}
+
+func TestSyncImageDigestByProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const digest = "sha256:0d3a3b4f7cb5f2b5d8e4b5c7ad3e5a1b2c3d4e5f60718293a4b5c6d7e8f90a1b"
+	deps := controller.NewFakeDependencies()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.BaseImage = "pingcap/pd"
+	tc.Spec.Version = "v7.1.0"
+	tc.Spec.ImageDigestPinning = true
+	oldDigest := &v1alpha1.ImageDigest{Image: "pingcap/pd:v7.0.0", Digest: "sha256:old"}
+	oldSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-pd"},
+		Spec: apps.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "pd", Image: "pingcap/pd@sha256:old"}}},
+			},
+		},
+	}
+	newSet := func() *apps.StatefulSet {
+		set := oldSet.DeepCopy()
+		set.Spec.Template.Spec.Containers[0].Image = "pingcap/pd:v7.1.0"
+		set.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "NEW", Value: "true"}}
+		return set
+	}
+
+	// the statefulset to create waits for the digest
+	resolved, err := syncImageDigest(deps, tc, v1alpha1.PDMemberType, tc.PDImage(), nil, nil, newSet())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(resolved).To(BeNil())
+
+	// the pod template is kept until the probe pulls the image
+	set := newSet()
+	resolved, err = syncImageDigest(deps, tc, v1alpha1.PDMemberType, tc.PDImage(), oldDigest, oldSet, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolved).To(BeNil())
+	g.Expect(set.Spec.Template).To(Equal(oldSet.Spec.Template))
+	probe := &corev1.Pod{}
+	g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: tc.Namespace, Name: "test-pd-digest-probe"}, probe)).To(Succeed())
+	g.Expect(probe.Spec.Containers[0].Image).To(Equal("pingcap/pd:v7.1.0"))
+	g.Expect(probe.Labels).NotTo(HaveKey(label.InstanceLabelKey))
+	g.Expect(metav1.IsControlledBy(probe, tc)).To(BeTrue())
+
+	// the image and the pinned digest are applied in the same update after the probe pulls the image
+	probe.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "pd", ImageID: "docker.io/pingcap/pd@" + digest}}
+	g.Expect(podIndexer.Add(probe)).To(Succeed())
+	set = newSet()
+	resolved, err = syncImageDigest(deps, tc, v1alpha1.PDMemberType, tc.PDImage(), oldDigest, oldSet, set)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resolved).To(Equal(&v1alpha1.ImageDigest{Image: "pingcap/pd:v7.1.0", Digest: digest}))
+	g.Expect(set.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/pd@" + digest))
+	g.Expect(set.Spec.Template.Spec.Containers[0].Env).To(HaveLen(1))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: tc.Namespace, Name: "test-pd-digest-probe"}, &corev1.Pod{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
@@ -33,7 +33,10 @@ type NodeAvailabilityStatus struct {
 	ReadOnlyDiskFound bool
 }
 
-func getNodeLabels(nodeLister corelisterv1.NodeLister, nodeName string, storeLabels []string) (map[string]string, error) {
+// getNodeLabels returns the values of the store labels from the labels of the node. A store label is
+// looked up by its own name at first, then by the node label mapped in nodeLabelMapping, and at last
+// by the k8s well-known labels.
+func getNodeLabels(nodeLister corelisterv1.NodeLister, nodeName string, storeLabels []string, nodeLabelMapping map[string]string) (map[string]string, error) {
 	node, err := nodeLister.Get(nodeName)
 	if err != nil {
 		return nil, err
@@ -46,6 +49,13 @@ func getNodeLabels(nodeLister corelisterv1.NodeLister, nodeName string, storeLab
 			continue
 		}
 
+		if name, ok := nodeLabelMapping[storeLabel]; ok {
+			if value, found := ls[name]; found {
+				labels[storeLabel] = value
+				continue
+			}
+		}
+
 		if k8sLabels, ok := shortLabelNameToK8sLabel[storeLabel]; ok {
 			for _, name := range k8sLabels {
 				if value, ok := ls[name]; ok {
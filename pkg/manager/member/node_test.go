@@ -28,6 +28,7 @@ func TestGetNodeLabels(t *testing.T) {
 	type testcase struct {
 		nodeLabels  map[string]string
 		labels      []string
+		mapping     map[string]string
 		result      map[string]string
 		errExpectFn func(*GomegaWithT, error)
 	}
@@ -45,7 +46,7 @@ func TestGetNodeLabels(t *testing.T) {
 			},
 		})
 
-		res, err := getNodeLabels(fakeDeps.NodeLister, testNodeName, c.labels)
+		res, err := getNodeLabels(fakeDeps.NodeLister, testNodeName, c.labels, c.mapping)
 		if c.errExpectFn != nil {
 			c.errExpectFn(g, err)
 		} else {
@@ -152,6 +153,24 @@ func TestGetNodeLabels(t *testing.T) {
 				"host":   "test-host",
 			},
 		},
+		{
+			nodeLabels: map[string]string{
+				"kubernetes.io/os":            "Linux",
+				"topology.kubernetes.io/zone": "us-west-1a",
+				"example.com/rack":            "rack-1",
+				"example.com/zone":            "test-zone",
+			},
+			labels: []string{"zone", "rack", "host"},
+			mapping: map[string]string{
+				"rack": "example.com/rack",
+				"zone": "example.com/zone",
+				"host": "example.com/host",
+			},
+			result: map[string]string{
+				"zone": "test-zone",
+				"rack": "rack-1",
+			},
+		},
 	}
 
 	for _, test := range tests {
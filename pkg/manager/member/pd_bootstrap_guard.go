@@ -0,0 +1,140 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// checkPDBootstrap checks the PVCs of the tidb cluster before PD is bootstrapped.
+//
+// The PVCs are labeled with the cluster ID after the pods are running, so the retained PVCs
+// of a deleted cluster are reused when a tidb cluster with the same name is created again.
+// PD bootstraps a new cluster if the PD volumes are missing, and the other components refuse
+// to join it with the data of the old cluster, so the bootstrap is blocked if the volumes are
+// from several clusters or the PD volumes are missing, until the stale volumes are wiped by the
+// tc annotation. The retained volumes of a single cluster are reused only after they are adopted
+// explicitly by the tc annotation, then the cluster ID is verified by verifyAdoptedClusterID.
+func (m *pdMemberManager) checkPDBootstrap(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if tc.Status.ClusterID != "" {
+		return nil
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).Selector()
+	if err != nil {
+		return err
+	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("checkPDBootstrap: failed to list pvcs for cluster %s/%s, error: %s", ns, tcName, err)
+	}
+
+	var stalePVCs []*corev1.PersistentVolumeClaim
+	pdIDs, otherIDs := sets.NewString(), sets.NewString()
+	for _, pvc := range pvcs {
+		clusterID := pvc.Labels[label.ClusterIDLabelKey]
+		if clusterID == "" || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		stalePVCs = append(stalePVCs, pvc)
+		if pvc.Labels[label.ComponentLabelKey] == label.PDLabelVal {
+			pdIDs.Insert(clusterID)
+		} else {
+			otherIDs.Insert(clusterID)
+		}
+	}
+	allIDs := pdIDs.Union(otherIDs)
+
+	if allIDs.Len() == 0 {
+		if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterBootstrapBlocked); cond != nil {
+			utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+				v1alpha1.TidbClusterBootstrapBlocked, corev1.ConditionFalse, utiltidbcluster.NoStaleVolumes, "no volumes of another cluster are found"))
+		}
+		return nil
+	}
+
+	adoptID := tc.Annotations[label.AnnBootstrapAdoptClusterIDKey]
+	if adoptID != "" && allIDs.Len() == 1 && allIDs.Has(adoptID) {
+		msg := fmt.Sprintf("the volumes of cluster %s are adopted, recover PD with the cluster ID by pd-recover if the PD volumes are missing", adoptID)
+		utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+			v1alpha1.TidbClusterBootstrapBlocked, corev1.ConditionFalse, utiltidbcluster.StaleVolumesAdopted, msg))
+		klog.Infof("TidbCluster: [%s/%s], %s", ns, tcName, msg)
+		return nil
+	}
+
+	if tc.Annotations[label.AnnBootstrapWipeStaleVolumesKey] == label.AnnBootstrapWipeStaleVolumesVal {
+		for _, pvc := range stalePVCs {
+			if adoptID != "" && pvc.Labels[label.ClusterIDLabelKey] == adoptID {
+				continue
+			}
+			if err := m.deps.PVCControl.DeletePVC(tc, pvc); err != nil {
+				return err
+			}
+			klog.Infof("TidbCluster: [%s/%s], delete pvc %s of cluster %s before bootstrap", ns, tcName, pvc.Name, pvc.Labels[label.ClusterIDLabelKey])
+		}
+		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for the volumes of another cluster to be deleted", ns, tcName)
+	}
+
+	names := make([]string, 0, len(stalePVCs))
+	for _, pvc := range stalePVCs {
+		names = append(names, fmt.Sprintf("%s(%s)", pvc.Name, pvc.Labels[label.ClusterIDLabelKey]))
+	}
+	sort.Strings(names)
+	reason := utiltidbcluster.StaleVolumesFound
+	msg := fmt.Sprintf("the volumes contain the data of cluster %s which can not be joined by a new PD cluster: %s. "+
+		"Annotate the tidb cluster with %s=%s to delete them, or %s=<cluster-id> to adopt them",
+		strings.Join(allIDs.List(), ","), strings.Join(names, ","),
+		label.AnnBootstrapWipeStaleVolumesKey, label.AnnBootstrapWipeStaleVolumesVal, label.AnnBootstrapAdoptClusterIDKey)
+	if allIDs.Len() == 1 && pdIDs.Len() == 1 {
+		reason = utiltidbcluster.RetainedVolumesFound
+		msg = fmt.Sprintf("the volumes of cluster %s are retained from a deleted tidb cluster: %s. "+
+			"Annotate the tidb cluster with %s=%s to resume the cluster with them, or %s=%s to delete them",
+			pdIDs.List()[0], strings.Join(names, ","), label.AnnBootstrapAdoptClusterIDKey, pdIDs.List()[0],
+			label.AnnBootstrapWipeStaleVolumesKey, label.AnnBootstrapWipeStaleVolumesVal)
+	}
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+		v1alpha1.TidbClusterBootstrapBlocked, corev1.ConditionTrue, reason, msg))
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, reason, msg)
+	return controller.RequeueErrorf("TidbCluster: [%s/%s], bootstrap of PD is blocked, %s", ns, tcName, msg)
+}
+
+// verifyAdoptedClusterID verifies the cluster ID reported by PD is the adopted one after the
+// retained volumes are adopted, it blocks the sync of the other components if they mismatch,
+// which means PD is bootstrapped as a new cluster instead of resumed from the PD volumes.
+func (m *pdMemberManager) verifyAdoptedClusterID(tc *v1alpha1.TidbCluster) error {
+	adoptID := tc.Annotations[label.AnnBootstrapAdoptClusterIDKey]
+	if adoptID == "" || tc.Status.ClusterID == "" || tc.Status.ClusterID == adoptID {
+		return nil
+	}
+
+	msg := fmt.Sprintf("the cluster ID %s reported by PD mismatches the adopted cluster ID %s, recover PD with the adopted cluster ID by pd-recover",
+		tc.Status.ClusterID, adoptID)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+		v1alpha1.TidbClusterBootstrapBlocked, corev1.ConditionTrue, utiltidbcluster.AdoptedClusterIDMismatch, msg))
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, utiltidbcluster.AdoptedClusterIDMismatch, msg)
+	return fmt.Errorf("TidbCluster: [%s/%s], %s", tc.GetNamespace(), tc.GetName(), msg)
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+func TestPDMemberManagerCheckPDBootstrap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newPVC := func(tc *v1alpha1.TidbCluster, name, component, clusterID string) *corev1.PersistentVolumeClaim {
+		l := label.New().Instance(tc.GetInstanceName()).Component(component)
+		l[label.ClusterIDLabelKey] = clusterID
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: tc.Namespace,
+				Labels:    l.Labels(),
+			},
+		}
+	}
+	getCondition := func(tc *v1alpha1.TidbCluster) *v1alpha1.TidbClusterCondition {
+		return utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterBootstrapBlocked)
+	}
+
+	tests := []struct {
+		name        string
+		pvcs        func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim
+		annotations map[string]string
+		expectErr   bool
+		expectCond  *corev1.ConditionStatus
+		expectPVCs  int
+	}{
+		{
+			name: "no volumes",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim { return nil },
+		},
+		{
+			name: "volumes retained from a deleted cluster",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{
+					newPVC(tc, "pd-test-pd-0", label.PDLabelVal, "1"),
+					newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "1"),
+				}
+			},
+			expectErr:  true,
+			expectCond: conditionStatusPtr(corev1.ConditionTrue),
+			expectPVCs: 2,
+		},
+		{
+			name: "adopt the volumes retained from a deleted cluster",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{
+					newPVC(tc, "pd-test-pd-0", label.PDLabelVal, "1"),
+					newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "1"),
+				}
+			},
+			annotations: map[string]string{label.AnnBootstrapAdoptClusterIDKey: "1"},
+			expectCond:  conditionStatusPtr(corev1.ConditionFalse),
+			expectPVCs:  2,
+		},
+		{
+			name: "PD volumes are missing",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "1")}
+			},
+			expectErr:  true,
+			expectCond: conditionStatusPtr(corev1.ConditionTrue),
+			expectPVCs: 1,
+		},
+		{
+			name: "volumes of several clusters",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{
+					newPVC(tc, "pd-test-pd-0", label.PDLabelVal, "1"),
+					newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "2"),
+				}
+			},
+			expectErr:  true,
+			expectCond: conditionStatusPtr(corev1.ConditionTrue),
+			expectPVCs: 2,
+		},
+		{
+			name: "adopt the volumes",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "1")}
+			},
+			annotations: map[string]string{label.AnnBootstrapAdoptClusterIDKey: "1"},
+			expectCond:  conditionStatusPtr(corev1.ConditionFalse),
+			expectPVCs:  1,
+		},
+		{
+			name: "wipe the volumes except the adopted ones",
+			pvcs: func(tc *v1alpha1.TidbCluster) []*corev1.PersistentVolumeClaim {
+				return []*corev1.PersistentVolumeClaim{
+					newPVC(tc, "pd-test-pd-0", label.PDLabelVal, "1"),
+					newPVC(tc, "tikv-test-tikv-0", label.TiKVLabelVal, "2"),
+				}
+			},
+			annotations: map[string]string{
+				label.AnnBootstrapWipeStaleVolumesKey: label.AnnBootstrapWipeStaleVolumesVal,
+				label.AnnBootstrapAdoptClusterIDKey:   "1",
+			},
+			expectErr:  true,
+			expectPVCs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+		pmm, _, pvcIndexer := newFakePDMemberManager()
+		tc := newTidbClusterForPD()
+		tc.Annotations = tt.annotations
+		for _, pvc := range tt.pvcs(tc) {
+			g.Expect(pvcIndexer.Add(pvc)).To(Succeed())
+		}
+
+		err := pmm.checkPDBootstrap(tc)
+		if tt.expectErr {
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+		} else {
+			g.Expect(err).To(Succeed())
+		}
+		cond := getCondition(tc)
+		if tt.expectCond == nil {
+			g.Expect(cond).To(BeNil())
+		} else {
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(*tt.expectCond))
+		}
+		g.Expect(pvcIndexer.List()).To(HaveLen(tt.expectPVCs))
+	}
+}
+
+func TestPDMemberManagerVerifyAdoptedClusterID(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pmm, _, _ := newFakePDMemberManager()
+	tc := newTidbClusterForPD()
+	g.Expect(pmm.verifyAdoptedClusterID(tc)).To(Succeed())
+
+	tc.Annotations = map[string]string{label.AnnBootstrapAdoptClusterIDKey: "1"}
+	// PD is not running yet
+	g.Expect(pmm.verifyAdoptedClusterID(tc)).To(Succeed())
+
+	tc.Status.ClusterID = "1"
+	g.Expect(pmm.verifyAdoptedClusterID(tc)).To(Succeed())
+
+	tc.Status.ClusterID = "2"
+	g.Expect(pmm.verifyAdoptedClusterID(tc)).NotTo(Succeed())
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterBootstrapBlocked)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.AdoptedClusterIDMismatch))
+}
+
+func conditionStatusPtr(status corev1.ConditionStatus) *corev1.ConditionStatus {
+	return &status
+}
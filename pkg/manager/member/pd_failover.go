@@ -17,7 +17,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
@@ -115,10 +114,9 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 		if tc.Status.PD.FailureMembers == nil {
 			tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{}
 		}
-		failoverDeadline := pdMember.LastTransitionTime.Add(f.deps.CLIConfig.PDFailoverPeriod)
 		_, exist := tc.Status.PD.FailureMembers[pdName]
 
-		if pdMember.Health || time.Now().Before(failoverDeadline) || exist {
+		if pdMember.Health || exist || !failoverDeadlineReached(tc.Spec.PD.Failover, f.deps.CLIConfig.PDFailoverPeriod, pdMember.LastTransitionTime, pdMember.ProbeFailures) {
 			continue
 		}
 
@@ -126,6 +124,10 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 		if err != nil {
 			return fmt.Errorf("tryToMarkAPeerAsFailure: failed to get pod %s/%s, error: %s", ns, podName, err)
 		}
+		if !podFailureEvidenceFound(tc.Spec.PD.Failover, pod) {
+			klog.Infof("pd failover: pod %s/%s of unhealthy member %s is running and ready, skip marking it as failure", ns, podName, pdName)
+			continue
+		}
 
 		pvcs, err := util.ResolvePVCFromPod(pod, f.deps.PVCLister)
 		if err != nil {
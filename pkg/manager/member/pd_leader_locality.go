@@ -0,0 +1,137 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+const (
+	pdLeaderTransferredReason = "PDLeaderTransferred"
+)
+
+// clusterDomainOfURL returns the cluster domain in the client URL of a PD member,
+// e.g. `cluster1.com` for `http://basic-pd-0.basic-pd-peer.ns.svc.cluster1.com:2379`.
+func clusterDomainOfURL(clientURL string) string {
+	host := clientURL
+	if u, err := url.Parse(clientURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	idx := strings.Index(host, ".svc.")
+	if idx < 0 {
+		return ""
+	}
+	return host[idx+len(".svc."):]
+}
+
+// syncLeaderLocality sets the LeaderLocalized condition of PD, and transfers the PD leader back to
+// the primary data plane if it moves to other data planes.
+//
+// Every TidbCluster of the across-K8s cluster reports where the leader is, but only the TidbCluster of
+// the primary data plane transfers the leader, to one of its own healthy PD members. The leader is not
+// transferred while PD is upgrading or scaling, as the upgrader moves the leader by itself.
+func (m *pdMemberManager) syncLeaderLocality(tc *v1alpha1.TidbCluster) error {
+	policy := tc.Spec.PD.LeaderLocality
+	if policy == nil {
+		removeComponentCondition(&tc.Status.PD, v1alpha1.ConditionTypeLeaderLocalized)
+		return nil
+	}
+	// the leader is unknown
+	if !tc.Status.PD.Synced || tc.Status.PD.Leader.Name == "" {
+		return nil
+	}
+
+	leader := tc.Status.PD.Leader
+	if tc.Status.PD.LeaderClusterDomain == policy.PrimaryClusterDomain {
+		tc.Status.PD.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ConditionTypeLeaderLocalized,
+			Status:  metav1.ConditionTrue,
+			Reason:  "LeaderInPrimary",
+			Message: fmt.Sprintf("pd leader %s is in the primary data plane", leader.Name),
+		})
+		return nil
+	}
+	tc.Status.PD.SetCondition(metav1.Condition{
+		Type:   v1alpha1.ConditionTypeLeaderLocalized,
+		Status: metav1.ConditionFalse,
+		Reason: "LeaderNotInPrimary",
+		Message: fmt.Sprintf("pd leader %s is in data plane %q instead of the primary data plane %q",
+			leader.Name, tc.Status.PD.LeaderClusterDomain, policy.PrimaryClusterDomain),
+	})
+
+	if tc.Spec.ClusterDomain != policy.PrimaryClusterDomain {
+		return nil
+	}
+	if tc.Status.PD.Phase != v1alpha1.NormalPhase || !tc.PDIsAvailable() {
+		klog.V(4).Infof("tc %s/%s: pd is in %s phase or unavailable, skip transferring pd leader to the primary data plane",
+			tc.GetNamespace(), tc.GetName(), tc.Status.PD.Phase)
+		return nil
+	}
+	var candidates []string
+	for name, member := range tc.Status.PD.Members {
+		if member.Health {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	target := candidates[0]
+
+	if err := controller.GetPDClient(m.deps.PDControl, tc).TransferPDLeader(target); err != nil {
+		return fmt.Errorf("syncLeaderLocality: failed to transfer pd leader of tc %s/%s to %s, error: %v", tc.GetNamespace(), tc.GetName(), target, err)
+	}
+	klog.Infof("tc %s/%s: pd leader is transferred from %s to %s in the primary data plane", tc.GetNamespace(), tc.GetName(), leader.Name, target)
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, pdLeaderTransferredReason, "transfer pd leader from %s in data plane %q to %s in the primary data plane",
+		leader.Name, tc.Status.PD.LeaderClusterDomain, target)
+	return nil
+}
+
+// syncServiceModeCondition sets the ServiceModeHealthy condition of PD in the "ms" mode, in which PD only serves
+// as the API service, and the TSO and scheduling services are unavailable if the microservices have no ready members.
+func syncServiceModeCondition(tc *v1alpha1.TidbCluster) {
+	if tc.Spec.PD.Mode != v1alpha1.PDModeMicroservice {
+		removeComponentCondition(&tc.Status.PD, v1alpha1.ConditionTypeServiceModeHealthy)
+		return
+	}
+	var unready []string
+	for _, spec := range tc.Spec.PDMS {
+		if status := tc.Status.PDMS[spec.Name]; status == nil || len(status.Members) == 0 {
+			unready = append(unready, spec.Name)
+		}
+	}
+	cond := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeServiceModeHealthy,
+		Status:  metav1.ConditionTrue,
+		Reason:  "MicroservicesReady",
+		Message: "all the pd microservices have ready members",
+	}
+	if len(unready) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "MicroservicesNotReady"
+		cond.Message = fmt.Sprintf("pd microservices %s have no ready members", strings.Join(unready, ", "))
+	}
+	tc.Status.PD.SetCondition(cond)
+}
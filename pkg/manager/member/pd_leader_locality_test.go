@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestClusterDomainOfURL(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(clusterDomainOfURL("http://basic-pd-0.basic-pd-peer.ns.svc.cluster1.com:2379")).To(Equal("cluster1.com"))
+	g.Expect(clusterDomainOfURL("https://basic-pd-0.basic-pd-peer.ns.svc:2379")).To(Equal(""))
+	g.Expect(clusterDomainOfURL("")).To(Equal(""))
+}
+
+func TestPDSyncLeaderLocality(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pmm, _, _ := newFakePDMemberManager()
+	tc := newTidbClusterForPD()
+	tc.Spec.AcrossK8s = true
+	tc.Spec.ClusterDomain = "cluster1.com"
+	tc.Spec.PD.Replicas = 2
+	tc.Spec.PD.LeaderLocality = &v1alpha1.PDLeaderLocality{PrimaryClusterDomain: "cluster1.com"}
+	tc.Status.PD.Synced = true
+	tc.Status.PD.Phase = v1alpha1.NormalPhase
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 2}
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-1.test-pd-peer.default.svc.cluster1.com": {Name: "test-pd-1.test-pd-peer.default.svc.cluster1.com", Health: true},
+		"test-pd-0.test-pd-peer.default.svc.cluster1.com": {Name: "test-pd-0.test-pd-peer.default.svc.cluster1.com", Health: true},
+	}
+	remote := v1alpha1.PDMember{
+		Name:      "test-pd-0.test-pd-peer.default.svc.cluster2.com",
+		ClientURL: "http://test-pd-0.test-pd-peer.default.svc.cluster2.com:2379",
+		Health:    true,
+	}
+	tc.Status.PD.PeerMembers = map[string]v1alpha1.PDMember{remote.Name: remote}
+	tc.Status.PD.Leader = remote
+	tc.Status.PD.LeaderClusterDomain = "cluster2.com"
+
+	pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.HealthInfo{}, nil
+	})
+	var transferredTo string
+	pdClient.AddReaction(pdapi.TransferPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		transferredTo = action.Name
+		return nil, nil
+	})
+	leaderLocalized := func() *metav1.Condition {
+		return meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeLeaderLocalized)
+	}
+
+	// the leader is transferred back to the primary data plane
+	g.Expect(pmm.syncLeaderLocality(tc)).To(Succeed())
+	g.Expect(transferredTo).To(Equal("test-pd-0.test-pd-peer.default.svc.cluster1.com"))
+	g.Expect(leaderLocalized().Status).To(Equal(metav1.ConditionFalse))
+
+	// the leader is not transferred while PD is upgrading
+	transferredTo = ""
+	tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	g.Expect(pmm.syncLeaderLocality(tc)).To(Succeed())
+	g.Expect(transferredTo).To(BeEmpty())
+	tc.Status.PD.Phase = v1alpha1.NormalPhase
+
+	// the leader is only transferred by the TidbCluster of the primary data plane
+	tc.Spec.PD.LeaderLocality.PrimaryClusterDomain = "cluster3.com"
+	g.Expect(pmm.syncLeaderLocality(tc)).To(Succeed())
+	g.Expect(transferredTo).To(BeEmpty())
+	g.Expect(leaderLocalized().Message).To(ContainSubstring(`instead of the primary data plane "cluster3.com"`))
+
+	// the leader is in the primary data plane
+	tc.Spec.PD.LeaderLocality.PrimaryClusterDomain = "cluster2.com"
+	g.Expect(pmm.syncLeaderLocality(tc)).To(Succeed())
+	g.Expect(transferredTo).To(BeEmpty())
+	g.Expect(leaderLocalized().Status).To(Equal(metav1.ConditionTrue))
+
+	// the condition is removed if the policy is removed
+	tc.Spec.PD.LeaderLocality = nil
+	g.Expect(pmm.syncLeaderLocality(tc)).To(Succeed())
+	g.Expect(leaderLocalized()).To(BeNil())
+}
+
+func TestSyncServiceModeCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	syncServiceModeCondition(tc)
+	g.Expect(tc.Status.PD.Conditions).To(BeEmpty())
+
+	tc.Spec.PD.Mode = v1alpha1.PDModeMicroservice
+	tc.Spec.PDMS = []*v1alpha1.PDMSSpec{{Name: "tso"}, {Name: "scheduling"}}
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{
+		"tso": {Name: "tso", Members: []string{"http://test-tso-0.test-tso-peer.default.svc:2379"}},
+	}
+	syncServiceModeCondition(tc)
+	cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeServiceModeHealthy)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Message).To(Equal("pd microservices scheduling have no ready members"))
+
+	tc.Status.PDMS["scheduling"] = &v1alpha1.PDMSStatus{Name: "scheduling", Members: []string{"http://test-scheduling-0.test-scheduling-peer.default.svc:2379"}}
+	syncServiceModeCondition(tc)
+	cond = meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeServiceModeHealthy)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+}
@@ -115,6 +115,7 @@ func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster)
 	tcName := tc.GetName()
 
 	newSvc := m.getNewPDServiceForTidbCluster(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -133,9 +134,10 @@ func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster)
 	if err != nil {
 		return err
 	}
-	if !equal {
+	if !equal || !controller.ServiceTrafficAnnotationsEqual(newSvc, oldSvc) {
 		svc := *oldSvc
 		svc.Spec = newSvc.Spec
+		controller.CopyServiceTrafficAnnotations(&svc, newSvc)
 		// TODO add unit test
 		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
 		if err != nil {
@@ -159,6 +161,7 @@ func (m *pdMemberManager) syncPDHeadlessServiceForTidbCluster(tc *v1alpha1.TidbC
 	tcName := tc.GetName()
 
 	newSvc := getNewPDHeadlessServiceForTidbCluster(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvc, err := m.deps.ServiceLister.Services(ns).Get(controller.PDPeerMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -201,7 +204,9 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 
 	oldPDSet := oldPDSetTmp.DeepCopy()
 
-	if err := m.syncTidbClusterStatus(tc, oldPDSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.PDMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTidbClusterStatus(tc, oldPDSet)
+	}); err != nil {
 		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s status, error: %v", ns, tcName, err)
 	}
 
@@ -218,7 +223,18 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newPDSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	digest, err := syncImageDigest(m.deps, tc, v1alpha1.PDMemberType, tc.PDImage(), tc.Status.PD.ImageDigest, oldPDSet, newPDSet)
+	tc.Status.PD.ImageDigest = digest
+	if err != nil {
+		return err
+	}
+	keepTerminationGracePeriodSeconds(tc.BasePDSpec(), newPDSet, oldPDSet)
+	newPDSet = overrideStatefulSet(tc, m.deps.Recorder, newPDSet)
 	if setNotExist {
+		if err := m.checkPDBootstrap(tc); err != nil {
+			return err
+		}
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDSet)
 		if err != nil {
 			return err
@@ -229,6 +245,9 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{}
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
 	}
+	if err := m.verifyAdoptedClusterID(tc); err != nil {
+		return err
+	}
 
 	// Force update takes precedence over scaling because force upgrade won't take effect when cluster gets stuck at scaling
 	if !tc.Status.PD.Synced && !templateEqual(newPDSet, oldPDSet) {
@@ -249,7 +268,9 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldPDSet, newPDSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.PDMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldPDSet, newPDSet)
+	}); err != nil {
 		return err
 	}
 
@@ -257,18 +278,26 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		if m.shouldRecover(tc) {
 			m.failover.Recover(tc)
 		} else if tc.Spec.PD.MaxFailoverCount != nil && *tc.Spec.PD.MaxFailoverCount > 0 && (tc.PDAllPodsStarted() && !tc.PDAllMembersReady() || tc.PDAutoFailovering()) {
-			if err := m.failover.Failover(tc); err != nil {
+			if err := ObserveReconcilePhase(tc, string(v1alpha1.PDMemberType), ReconcilePhaseFailover, func() error {
+				return m.failover.Failover(tc)
+			}); err != nil {
 				return err
 			}
 		}
 	}
 
 	if !templateEqual(newPDSet, oldPDSet) || tc.Status.PD.Phase == v1alpha1.UpgradePhase {
-		if err := m.upgrader.Upgrade(tc, oldPDSet, newPDSet); err != nil {
+		if err := ObserveReconcilePhase(tc, string(v1alpha1.PDMemberType), ReconcilePhaseUpgrade, func() error {
+			return m.upgrader.Upgrade(tc, oldPDSet, newPDSet)
+		}); err != nil {
 			return err
 		}
 	}
 
+	if err := m.syncLeaderLocality(tc); err != nil {
+		return err
+	}
+
 	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDSTS", newPDSet, oldPDSet)
 }
 
@@ -332,6 +361,7 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	} else {
 		tc.Status.PD.Phase = v1alpha1.NormalPhase
 	}
+	syncServiceModeCondition(tc)
 
 	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
 
@@ -339,12 +369,12 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	if err != nil {
 		tc.Status.PD.Synced = false
 		// get endpoints info
-		eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.PDMemberName(tcName))
+		addrs, epErr := controller.GetServiceEndpointAddresses(m.deps, ns, controller.PDMemberName(tcName))
 		if epErr != nil {
 			return fmt.Errorf("syncTidbClusterStatus: failed to get endpoints %s for cluster %s/%s, err: %s, epErr %s", controller.PDMemberName(tcName), ns, tcName, err, epErr)
 		}
 		// pd service has no endpoints
-		if eps != nil && len(eps.Subsets) == 0 {
+		if len(addrs) == 0 {
 			return fmt.Errorf("%s, service %s/%s has no endpoints", err, ns, controller.PDMemberName(tcName))
 		}
 		return err
@@ -395,12 +425,14 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 			if exist && status.Health == oldPDMember.Health {
 				status.LastTransitionTime = oldPDMember.LastTransitionTime
 			}
+			status.ProbeFailures = nextProbeFailures(status.Health, oldPDMember.ProbeFailures)
 			pdStatus[name] = status
 		} else {
 			oldPDMember, exist := tc.Status.PD.PeerMembers[name]
 			if exist && status.Health == oldPDMember.Health {
 				status.LastTransitionTime = oldPDMember.LastTransitionTime
 			}
+			status.ProbeFailures = nextProbeFailures(status.Health, oldPDMember.ProbeFailures)
 			peerPDStatus[name] = status
 		}
 
@@ -412,11 +444,11 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	tc.Status.PD.Synced = true
 	tc.Status.PD.Members = pdStatus
 	tc.Status.PD.PeerMembers = peerPDStatus
+	tc.Status.PD.LeaderClusterDomain = clusterDomainOfURL(tc.Status.PD.Leader.ClientURL)
 	tc.Status.PD.Image = ""
 	if c := findContainerByName(set, "pd"); c != nil {
 		tc.Status.PD.Image = c.Image
 	}
-
 	if err := m.collectUnjoinedMembers(tc, set, pdStatus); err != nil {
 		return err
 	}
@@ -434,7 +466,7 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	if tc.Spec.PD.Config == nil {
 		return nil, nil
 	}
-	newCm, err := getPDConfigMap(tc)
+	newCm, err := getPDConfigMap(tc, m.deps.CLIConfig.AirGapped)
 	if err != nil {
 		return nil, err
 	}
@@ -446,7 +478,14 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 		})
 	}
 
-	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BasePDSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	strategy := tc.BasePDSpec().ConfigUpdateStrategy()
+	if strategy == v1alpha1.ConfigUpdateStrategyHotReload {
+		err = mngerutils.HotReloadConfigMapIfNeed(m.deps.ConfigMapLister, v1alpha1.PDMemberType, tc.PDVersion(), inUseName, newCm, func(config map[string]interface{}) error {
+			return controller.GetPDClient(m.deps.PDControl, tc).UpdateConfig(config)
+		})
+	} else {
+		err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, strategy, inUseName, newCm)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -499,6 +538,7 @@ func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster
 		if svcSpec.PortName != nil {
 			pdService.Spec.Ports[0].Name = *svcSpec.PortName
 		}
+		controller.SetServiceTrafficOptions(pdService, svcSpec)
 	}
 
 	if tc.Spec.PreferIPv6 {
@@ -720,7 +760,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	setName := controller.PDMemberName(tcName)
 	stsLabels := label.New().Instance(instanceName).PD()
 	podLabels := util.CombineStringMap(stsLabels, basePDSpec.Labels())
-	podAnnotations := util.CombineStringMap(basePDSpec.Annotations(), controller.AnnProm(2379, "/metrics"))
+	podAnnotations := util.CombineStringMap(basePDSpec.Annotations(), controller.AnnProm(2379, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.PDMemberType))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.PDLabelVal)
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
@@ -806,6 +846,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	pdContainer.Env = util.AppendEnv(env, basePDSpec.Env())
 	pdContainer.EnvFrom = basePDSpec.EnvFrom()
 	podSpec.Volumes = append(vols, basePDSpec.AdditionalVolumes()...)
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation([]corev1.Container{pdContainer}, basePDSpec.NativeSidecarContainers()))
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{pdContainer}, basePDSpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for PD of [%s/%s], error: %v", tc.Namespace, tc.Name, err)
@@ -859,7 +900,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	return pdSet, nil
 }
 
-func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+func getPDConfigMap(tc *v1alpha1.TidbCluster, airGapped bool) (*corev1.ConfigMap, error) {
 	// For backward compatibility, only sync tidb configmap when .tidb.config is non-nil
 	if tc.Spec.PD.Config == nil {
 		return nil, nil
@@ -890,6 +931,11 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		config.Set("dashboard.internal-proxy", *tc.Spec.PD.EnableDashboardInternalProxy)
 	}
 
+	// the telemetry of the dashboard can not reach the outside in the air-gapped mode
+	if airGapped {
+		config.Set("dashboard.enable-telemetry", false)
+	}
+
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -380,6 +380,7 @@ func TestPDMemberManagerSyncUpdate(t *testing.T) {
 				g.Expect(tc.Status.PD.Members["pd1"].Health).To(Equal(true))
 				g.Expect(tc.Status.PD.Members["pd2"].Health).To(Equal(true))
 				g.Expect(tc.Status.PD.Members["pd3"].Health).To(Equal(false))
+				g.Expect(tc.Status.PD.Members["pd3"].ProbeFailures).To(Equal(int32(1)))
 			},
 		},
 		{
@@ -2307,7 +2308,7 @@ func TestGetPDConfigMap(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			cm, err := getPDConfigMap(&tt.tc)
+			cm, err := getPDConfigMap(&tt.tc, false)
 			g.Expect(err).To(Succeed())
 			if tt.expected == nil {
 				g.Expect(cm).To(BeNil())
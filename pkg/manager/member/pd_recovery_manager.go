@@ -0,0 +1,433 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	pdRecoveryStartedReason   = "PDRecoveryStarted"
+	pdRecoveryProgressReason  = "PDRecoveryProgress"
+	pdRecoveryCompletedReason = "PDRecoveryCompleted"
+	pdRecoveryFailedReason    = "PDRecoveryFailed"
+)
+
+type pdRecoveryManager struct {
+	deps *controller.Dependencies
+}
+
+// NewPDRecoveryManager returns a manager which recovers PD after the majority of PD members are lost.
+//
+// The recovery is triggered by the tidb.pingcap.com/pd-recover-from annotation whose value is the
+// surviving PD pod, and it goes through the following phases, which are recorded in status.pd.recovery:
+//   - Checking: the majority loss is found, the survivor is verified, and the wiping of the other members is
+//     confirmed by the tidb.pingcap.com/pd-recover-confirm annotation whose value is the survivor
+//   - ForceNewCluster: the other PD members are wiped, and the survivor is restarted with --force-new-cluster,
+//     which is returned by the discovery service, to rebuild a one-member PD cluster from its data
+//   - RebuildMembers: the other PD members rejoin the rebuilt PD cluster with empty volumes
+//   - UnsafeRecover: the lost TiKV stores listed by the tidb.pingcap.com/pd-recover-failed-stores annotation
+//     are removed from the regions by the unsafe recovery of PD
+//
+// The sync of the tidb cluster is blocked until the recovery is completed or failed. It is also blocked
+// while the tidb.pingcap.com/pd-recovery-lock annotation is set by a PDRecovery, which rebuilds PD from the
+// surviving TiKV stores after the data of all the PD members is lost.
+func NewPDRecoveryManager(deps *controller.Dependencies) manager.Manager {
+	return &pdRecoveryManager{
+		deps: deps,
+	}
+}
+
+func (m *pdRecoveryManager) Sync(tc *v1alpha1.TidbCluster) error {
+	// the members are wiped and restarted by the PDRecovery, they are not synced in the meantime
+	if name := tc.Annotations[label.AnnPDRecoveryLockKey]; name != "" {
+		return controller.RequeueErrorf("tc %s/%s: pd is being rebuilt by PDRecovery %s", tc.Namespace, tc.Name, name)
+	}
+
+	survivor := tc.Annotations[label.AnnPDRecoverFromKey]
+	recovery := tc.Status.PD.Recovery
+
+	if survivor == "" {
+		if recovery != nil {
+			klog.Infof("pd recovery of tc %s/%s is cleared as the annotation %s is removed", tc.Namespace, tc.Name, label.AnnPDRecoverFromKey)
+			tc.Status.PD.Recovery = nil
+		}
+		return nil
+	}
+
+	if recovery == nil || (isPDRecoveryFinished(recovery) && recovery.Survivor != survivor) {
+		now := metav1.Now()
+		recovery = &v1alpha1.PDRecoveryStatus{
+			Survivor:           survivor,
+			Phase:              v1alpha1.PDRecoveryPhaseChecking,
+			StartTime:          now,
+			LastTransitionTime: now,
+		}
+		tc.Status.PD.Recovery = recovery
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, pdRecoveryStartedReason, "start to recover pd from %s", survivor)
+	}
+	if isPDRecoveryFinished(recovery) {
+		return nil
+	}
+
+	var err error
+	switch recovery.Phase {
+	case v1alpha1.PDRecoveryPhaseChecking:
+		err = m.check(tc, recovery)
+	case v1alpha1.PDRecoveryPhaseForceNewCluster:
+		err = m.forceNewCluster(tc, recovery)
+	case v1alpha1.PDRecoveryPhaseRebuildMembers:
+		err = m.rebuildMembers(tc, recovery)
+	case v1alpha1.PDRecoveryPhaseUnsafeRecover:
+		err = m.unsafeRecover(tc, recovery)
+	default:
+		err = fmt.Errorf("unknown pd recovery phase %s", recovery.Phase)
+	}
+	if err != nil {
+		recovery.Message = err.Error()
+		return err
+	}
+	if isPDRecoveryFinished(recovery) {
+		return nil
+	}
+	// the other components are not synced until PD is recovered
+	return controller.RequeueErrorf("tc %s/%s: pd is recovering from %s, phase: %s", tc.Namespace, tc.Name, recovery.Survivor, recovery.Phase)
+}
+
+// check confirms the majority of PD members are lost and the survivor has the data of the cluster
+func (m *pdRecoveryManager) check(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus) error {
+	ns := tc.GetNamespace()
+
+	if tc.Spec.PD == nil || tc.AcrossK8s() || tc.Spec.ClusterDomain != "" || len(tc.Status.PD.PeerMembers) > 0 {
+		m.fail(tc, recovery, "pd recovery is only supported for the pd members in a single tidb cluster")
+		return nil
+	}
+	// only the start script v2 asks the discovery service for --force-new-cluster, the survivor would
+	// never rebuild pd after the other members are wiped otherwise
+	if tc.StartScriptVersion() != v1alpha1.StartScriptV2 {
+		m.fail(tc, recovery, fmt.Sprintf("pd recovery requires the start script %s, but it is %s", v1alpha1.StartScriptV2, tc.StartScriptVersion()))
+		return nil
+	}
+	if _, ok := tc.Status.PD.Members[recovery.Survivor]; !ok {
+		m.fail(tc, recovery, fmt.Sprintf("%s is not a pd member of the cluster", recovery.Survivor))
+		return nil
+	}
+
+	var failedStores []uint64
+	if value := tc.Annotations[label.AnnPDRecoverFailedStoresKey]; value != "" {
+		for _, s := range strings.Split(value, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				m.fail(tc, recovery, fmt.Sprintf("invalid store id %q in annotation %s", s, label.AnnPDRecoverFailedStoresKey))
+				return nil
+			}
+			failedStores = append(failedStores, id)
+		}
+	}
+
+	// PD is not recovered if it still has the quorum, the lost members are recovered by failover then
+	quorum := len(tc.Status.PD.Members)/2 + 1
+	healthy, err := m.healthyMembers(tc)
+	if err != nil {
+		return err
+	}
+	if len(healthy) >= quorum {
+		m.fail(tc, recovery, fmt.Sprintf("pd has the quorum with healthy members %v of %d", healthy, len(tc.Status.PD.Members)))
+		return nil
+	}
+
+	pod, err := m.deps.PodLister.Pods(ns).Get(recovery.Survivor)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			m.fail(tc, recovery, fmt.Sprintf("the survivor pod %s is not found", recovery.Survivor))
+			return nil
+		}
+		return fmt.Errorf("failed to get pod %s/%s, error: %v", ns, recovery.Survivor, err)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("the survivor pod %s/%s is %s, it must be running to recover pd", ns, recovery.Survivor, pod.Status.Phase)
+	}
+
+	var others []string
+	for name := range tc.Status.PD.Members {
+		if name != recovery.Survivor {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+
+	// wiping the other members is not reversible, it must be confirmed after the majority loss is found
+	if tc.Annotations[label.AnnPDRecoverConfirmKey] != recovery.Survivor {
+		recovery.Message = fmt.Sprintf("the majority of pd members is lost, set annotation %s=%s to confirm wiping pd members %v",
+			label.AnnPDRecoverConfirmKey, recovery.Survivor, others)
+		return nil
+	}
+
+	recovery.SurvivorUID = pod.UID
+	recovery.RebuiltMembers = others
+	recovery.FailedStores = failedStores
+	m.transit(tc, recovery, v1alpha1.PDRecoveryPhaseForceNewCluster,
+		fmt.Sprintf("wiping pd members %v and rebuilding pd from %s", others, recovery.Survivor))
+	return nil
+}
+
+// healthyMembers returns the PD members which may still be healthy. The health reported by PD is used if PD
+// is available, otherwise a member is regarded as lost only if it is reported unhealthy by the last synced
+// status or its pod is not ready, so the recovery never starts without the evidence of the majority loss.
+func (m *pdRecoveryManager) healthyMembers(tc *v1alpha1.TidbCluster) ([]string, error) {
+	var healthy []string
+	if healthInfo, err := controller.GetPDClient(m.deps.PDControl, tc).GetHealth(); err == nil {
+		for _, h := range healthInfo.Healths {
+			if h.Health {
+				healthy = append(healthy, h.Name)
+			}
+		}
+		sort.Strings(healthy)
+		return healthy, nil
+	}
+
+	ns := tc.GetNamespace()
+	for name, member := range tc.Status.PD.Members {
+		if !member.Health {
+			continue
+		}
+		pod, err := m.deps.PodLister.Pods(ns).Get(name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s/%s, error: %v", ns, name, err)
+		}
+		if pod.DeletionTimestamp == nil && podutil.IsPodReady(pod) {
+			healthy = append(healthy, name)
+		}
+	}
+	sort.Strings(healthy)
+	return healthy, nil
+}
+
+// forceNewCluster wipes the other PD members and restarts the survivor to rebuild a one-member PD cluster
+func (m *pdRecoveryManager) forceNewCluster(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus) error {
+	ns := tc.GetNamespace()
+
+	// the other members must not come back with the data of the lost cluster, which may form another quorum
+	for _, name := range recovery.RebuiltMembers {
+		if err := m.wipeMember(tc, recovery, name); err != nil {
+			return err
+		}
+	}
+
+	pod, err := m.deps.PodLister.Pods(ns).Get(recovery.Survivor)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get pod %s/%s, error: %v", ns, recovery.Survivor, err)
+	}
+	if err == nil && pod.UID == recovery.SurvivorUID {
+		if pod.DeletionTimestamp == nil {
+			if err := m.deps.PodControl.DeletePod(tc, pod); err != nil {
+				return err
+			}
+			klog.Infof("tc %s/%s: the survivor pod %s is restarted to rebuild pd", ns, tc.Name, recovery.Survivor)
+		}
+		recovery.Message = fmt.Sprintf("restarting %s with --force-new-cluster", recovery.Survivor)
+		return nil
+	}
+	if err != nil || pod.Status.Phase != corev1.PodRunning {
+		recovery.Message = fmt.Sprintf("waiting for %s to be running with --force-new-cluster", recovery.Survivor)
+		return nil
+	}
+
+	membersInfo, err := controller.GetPDClient(m.deps.PDControl, tc).GetMembers()
+	if err != nil || membersInfo.Leader == nil {
+		recovery.Message = fmt.Sprintf("waiting for %s to rebuild pd", recovery.Survivor)
+		return nil
+	}
+	// the members of the lost cluster are removed by --force-new-cluster, only the rejoined members may exist
+	oldIDs := sets.NewString()
+	for name, member := range tc.Status.PD.Members {
+		if name != recovery.Survivor {
+			oldIDs.Insert(member.ID)
+		}
+	}
+	survivorFound := false
+	for _, member := range membersInfo.Members {
+		if member.Name == recovery.Survivor {
+			survivorFound = true
+		}
+		if oldIDs.Has(strconv.FormatUint(member.MemberId, 10)) {
+			recovery.Message = fmt.Sprintf("waiting for %s to rebuild pd, the lost member %s still exists", recovery.Survivor, member.Name)
+			return nil
+		}
+	}
+	if !survivorFound {
+		recovery.Message = fmt.Sprintf("waiting for %s to rebuild pd", recovery.Survivor)
+		return nil
+	}
+
+	m.transit(tc, recovery, v1alpha1.PDRecoveryPhaseRebuildMembers,
+		fmt.Sprintf("pd is rebuilt from %s, waiting for pd members %v to rejoin", recovery.Survivor, recovery.RebuiltMembers))
+	return nil
+}
+
+// rebuildMembers waits for the wiped members to rejoin the rebuilt PD cluster
+func (m *pdRecoveryManager) rebuildMembers(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus) error {
+	healthInfo, err := controller.GetPDClient(m.deps.PDControl, tc).GetHealth()
+	if err != nil {
+		recovery.Message = fmt.Sprintf("waiting for pd to be available, error: %v", err)
+		return nil
+	}
+	healthy := sets.NewString()
+	for _, h := range healthInfo.Healths {
+		if h.Health {
+			healthy.Insert(h.Name)
+		}
+	}
+	for _, name := range recovery.RebuiltMembers {
+		if !healthy.Has(name) {
+			recovery.Message = fmt.Sprintf("waiting for pd member %s to rejoin, the pod may be stuck if its node is lost", name)
+			return nil
+		}
+	}
+
+	if len(recovery.FailedStores) == 0 {
+		m.complete(tc, recovery)
+		return nil
+	}
+	if err := controller.GetPDClient(m.deps.PDControl, tc).UnsafeRemoveFailedStores(recovery.FailedStores); err != nil {
+		return fmt.Errorf("failed to remove failed stores %v, error: %v", recovery.FailedStores, err)
+	}
+	m.transit(tc, recovery, v1alpha1.PDRecoveryPhaseUnsafeRecover,
+		fmt.Sprintf("pd is recovered, removing failed tikv stores %v", recovery.FailedStores))
+	return nil
+}
+
+// unsafeRecover waits for the unsafe recovery of the failed TiKV stores
+func (m *pdRecoveryManager) unsafeRecover(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus) error {
+	stages, err := controller.GetPDClient(m.deps.PDControl, tc).GetUnsafeRecoveryStages()
+	if err != nil {
+		return fmt.Errorf("failed to get the stages of the unsafe recovery, error: %v", err)
+	}
+	if len(stages) == 0 {
+		recovery.Message = "waiting for the unsafe recovery to start"
+		return nil
+	}
+	last := strings.ToLower(stages[len(stages)-1].Info)
+	switch {
+	case strings.Contains(last, "finished"):
+		m.complete(tc, recovery)
+	case strings.Contains(last, "failed") || strings.Contains(last, "exit"):
+		m.fail(tc, recovery, fmt.Sprintf("unsafe recovery of tikv stores %v failed: %s", recovery.FailedStores, stages[len(stages)-1].Info))
+	default:
+		recovery.Message = fmt.Sprintf("unsafe recovery of tikv stores %v: %s", recovery.FailedStores, stages[len(stages)-1].Info)
+	}
+	return nil
+}
+
+// wipeMember deletes the PVCs and the pod of a PD member, so that it rejoins PD with empty volumes
+func (m *pdRecoveryManager) wipeMember(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus, podName string) error {
+	ns := tc.GetNamespace()
+	ordinal, err := util.GetOrdinalFromPodName(podName)
+	if err != nil {
+		return fmt.Errorf("failed to parse ordinal from pod name %s/%s, error: %v", ns, podName, err)
+	}
+	selector, err := GetPVCSelectorForPod(tc, v1alpha1.PDMemberType, ordinal)
+	if err != nil {
+		return err
+	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("failed to list pvcs of pod %s/%s, error: %v", ns, podName, err)
+	}
+	// the pod and PVCs recreated after the wiping starts are empty
+	wipedAt := recovery.LastTransitionTime.Time
+	for _, pvc := range pvcs {
+		if pvc.DeletionTimestamp != nil || pvc.CreationTimestamp.After(wipedAt) {
+			continue
+		}
+		if err := m.deps.PVCControl.DeletePVC(tc, pvc); err != nil {
+			return err
+		}
+	}
+
+	pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s, error: %v", ns, podName, err)
+	}
+	if pod.DeletionTimestamp != nil || pod.CreationTimestamp.After(wipedAt) {
+		return nil
+	}
+	return m.deps.PodControl.DeletePod(tc, pod)
+}
+
+func (m *pdRecoveryManager) transit(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus, phase v1alpha1.PDRecoveryPhase, msg string) {
+	recovery.Phase = phase
+	recovery.LastTransitionTime = metav1.Now()
+	recovery.Message = msg
+	klog.Infof("tc %s/%s: pd recovery transits to %s, %s", tc.Namespace, tc.Name, phase, msg)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, pdRecoveryProgressReason, msg)
+}
+
+func (m *pdRecoveryManager) complete(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus) {
+	recovery.Phase = v1alpha1.PDRecoveryPhaseCompleted
+	recovery.LastTransitionTime = metav1.Now()
+	recovery.Message = fmt.Sprintf("pd is recovered from %s", recovery.Survivor)
+	klog.Infof("tc %s/%s: %s", tc.Namespace, tc.Name, recovery.Message)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, pdRecoveryCompletedReason, recovery.Message)
+}
+
+func (m *pdRecoveryManager) fail(tc *v1alpha1.TidbCluster, recovery *v1alpha1.PDRecoveryStatus, msg string) {
+	recovery.Phase = v1alpha1.PDRecoveryPhaseFailed
+	recovery.LastTransitionTime = metav1.Now()
+	recovery.Message = msg
+	klog.Errorf("tc %s/%s: pd recovery failed, %s", tc.Namespace, tc.Name, msg)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, pdRecoveryFailedReason, msg)
+}
+
+func isPDRecoveryFinished(recovery *v1alpha1.PDRecoveryStatus) bool {
+	return recovery.Phase == v1alpha1.PDRecoveryPhaseCompleted || recovery.Phase == v1alpha1.PDRecoveryPhaseFailed
+}
+
+type FakePDRecoveryManager struct {
+	err error
+}
+
+func NewFakePDRecoveryManager() *FakePDRecoveryManager {
+	return &FakePDRecoveryManager{}
+}
+
+func (m *FakePDRecoveryManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePDRecoveryManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
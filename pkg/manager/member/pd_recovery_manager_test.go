@@ -0,0 +1,186 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestPDRecoveryManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	m := NewPDRecoveryManager(fakeDeps)
+	podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	pvcIndexer := fakeDeps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   corev1.NamespaceDefault,
+			Name:        "basic",
+			Annotations: map[string]string{label.AnnPDRecoverFromKey: "basic-pd-1", label.AnnPDRecoverFailedStoresKey: "4, 5"},
+		},
+		Spec: v1alpha1.TidbClusterSpec{PD: &v1alpha1.PDSpec{Replicas: 3}},
+		Status: v1alpha1.TidbClusterStatus{
+			PD: v1alpha1.PDStatus{
+				Members: map[string]v1alpha1.PDMember{
+					"basic-pd-0": {Name: "basic-pd-0", ID: "1"},
+					"basic-pd-1": {Name: "basic-pd-1", ID: "2", Health: true},
+					"basic-pd-2": {Name: "basic-pd-2", ID: "3"},
+				},
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("basic-pd-%d", i)
+		g.Expect(podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: name, UID: types.UID(name)},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})).To(Succeed())
+		l := label.New().Instance("basic").PD()
+		l[label.AnnPodNameKey] = name
+		g.Expect(pvcIndexer.Add(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "pd-" + name, Labels: l.Labels()},
+		})).To(Succeed())
+	}
+
+	pdClient := controller.NewFakePDClient(fakeDeps.PDControl.(*pdapi.FakePDControl), tc)
+	healthy := map[string]bool{"basic-pd-0": true, "basic-pd-1": true}
+	pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+		if healthy == nil {
+			return nil, fmt.Errorf("no leader")
+		}
+		info := &pdapi.HealthInfo{}
+		for name, h := range healthy {
+			info.Healths = append(info.Healths, pdapi.MemberHealth{Name: name, Health: h})
+		}
+		return info, nil
+	})
+
+	// PD is not recovered by the start script v1, which doesn't restart the survivor with --force-new-cluster
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseFailed))
+	g.Expect(tc.Status.PD.Recovery.Message).To(ContainSubstring("start script"))
+	delete(tc.Annotations, label.AnnPDRecoverFromKey)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	tc.Annotations[label.AnnPDRecoverFromKey] = "basic-pd-1"
+	tc.Spec.StartScriptVersion = v1alpha1.StartScriptV2
+
+	// PD is not recovered if it has the quorum
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseFailed))
+	g.Expect(tc.Status.PD.Recovery.Message).To(ContainSubstring("quorum"))
+
+	// the recovery is restarted after the annotation is removed and added again
+	delete(tc.Annotations, label.AnnPDRecoverFromKey)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Recovery).To(BeNil())
+	tc.Annotations[label.AnnPDRecoverFromKey] = "basic-pd-1"
+	healthy = nil
+
+	// PD is not recovered if the members reported healthy by the last status are still ready
+	for _, name := range []string{"basic-pd-0", "basic-pd-1"} {
+		readyPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: name, UID: types.UID(name)},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		g.Expect(podIndexer.Update(readyPod)).To(Succeed())
+	}
+	tc.Status.PD.Members["basic-pd-0"] = v1alpha1.PDMember{Name: "basic-pd-0", ID: "1", Health: true}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseFailed))
+	delete(tc.Annotations, label.AnnPDRecoverFromKey)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	tc.Annotations[label.AnnPDRecoverFromKey] = "basic-pd-1"
+	tc.Status.PD.Members["basic-pd-0"] = v1alpha1.PDMember{Name: "basic-pd-0", ID: "1"}
+
+	// the members are not wiped until it is confirmed
+	err := m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	recovery := tc.Status.PD.Recovery
+	g.Expect(recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseChecking))
+	g.Expect(recovery.Message).To(ContainSubstring(label.AnnPDRecoverConfirmKey))
+	tc.Annotations[label.AnnPDRecoverConfirmKey] = "basic-pd-1"
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseForceNewCluster))
+	g.Expect(recovery.RebuiltMembers).To(Equal([]string{"basic-pd-0", "basic-pd-2"}))
+	g.Expect(recovery.FailedStores).To(Equal([]uint64{4, 5}))
+
+	// the other members are wiped and the survivor is restarted
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(podIndexer.ListKeys()).To(BeEmpty())
+	g.Expect(pvcIndexer.ListKeys()).To(ConsistOf("default/pd-basic-pd-1"))
+
+	// the survivor rebuilds PD
+	g.Expect(podIndexer.Add(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "basic-pd-1", UID: "new", CreationTimestamp: metav1.Now()},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	})).To(Succeed())
+	survivor := &pdpb.Member{Name: "basic-pd-1", MemberId: 2}
+	pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.MembersInfo{Members: []*pdpb.Member{survivor}, Leader: survivor}, nil
+	})
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseRebuildMembers))
+
+	// the failed stores are removed after the members rejoin
+	healthy = map[string]bool{"basic-pd-1": true, "basic-pd-0": true}
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(recovery.Message).To(ContainSubstring("basic-pd-2"))
+	var removedStores []uint64
+	pdClient.AddReaction(pdapi.UnsafeRemoveFailedStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		removedStores = action.StoreIDs
+		return nil, nil
+	})
+	healthy["basic-pd-2"] = true
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseUnsafeRecover))
+	g.Expect(removedStores).To(Equal([]uint64{4, 5}))
+
+	pdClient.AddReaction(pdapi.GetUnsafeRecoveryStagesActionType, func(action *pdapi.Action) (interface{}, error) {
+		return []pdapi.UnsafeRecoveryStage{{Info: "Unsafe recovery enters collect report stage"}, {Info: "Unsafe recovery finished"}}, nil
+	})
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseCompleted))
+
+	// the completed recovery is not run again
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Recovery.Phase).To(Equal(v1alpha1.PDRecoveryPhaseCompleted))
+
+	// the sync is blocked while PD is rebuilt by a PDRecovery
+	tc.Annotations[label.AnnPDRecoveryLockKey] = "rebuild"
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("PDRecovery rebuild"))
+}
@@ -99,6 +99,16 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return nil
 	}
 
+	if tc.Spec.PD.ScaleInHook != nil {
+		pod, err := s.deps.PodLister.Pods(ns).Get(pdPodName)
+		if err != nil {
+			return fmt.Errorf("pdScaler.ScaleIn: failed to get pod %s/%s for pd in tc %s/%s, error: %s", ns, pdPodName, ns, tcName, err)
+		}
+		if err := runScaleInHook(s.deps, tc, tc.Spec.PD.ScaleInHook, v1alpha1.PDMemberType, pod, ""); err != nil {
+			return err
+		}
+	}
+
 	pdClient := controller.GetPDClient(s.deps.PDControl, tc)
 	leader, err := pdClient.GetPDLeader()
 	if err != nil {
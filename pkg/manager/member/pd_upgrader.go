@@ -91,6 +91,10 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s pd pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == tc.Status.PD.StatefulSet.UpdateRevision {
 			if !podutil.IsPodReady(pod) {
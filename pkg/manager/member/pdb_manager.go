@@ -0,0 +1,180 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+)
+
+// pdbComponent describes the PodDisruptionBudget related info of a component
+type pdbComponent struct {
+	memberType v1alpha1.MemberType
+	label      label.Label
+	replicas   int32
+	spec       *v1alpha1.PodDisruptionBudgetSpec
+}
+
+type pdbManager struct {
+	deps *controller.Dependencies
+}
+
+// NewPDBManager returns a manager which creates and reconciles PodDisruptionBudgets
+// for PD, TiKV, TiDB, TiFlash and TiCDC.
+func NewPDBManager(deps *controller.Dependencies) manager.Manager {
+	return &pdbManager{
+		deps: deps,
+	}
+}
+
+func (m *pdbManager) Sync(tc *v1alpha1.TidbCluster) error {
+	for _, comp := range getPDBComponents(tc) {
+		if err := m.syncPDB(tc, comp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *pdbManager) syncPDB(tc *v1alpha1.TidbCluster, comp pdbComponent) error {
+	ns := tc.GetNamespace()
+	name := controller.MemberName(tc.GetName(), comp.memberType)
+
+	if comp.spec == nil {
+		// PodDisruptionBudget is not required anymore, remove it if it is created by us before
+		pdb, err := m.deps.PDBControl.GetPDB(ns, name)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("syncPDB: failed to get pdb %s/%s, error: %v", ns, name, err)
+		}
+		if !metav1.IsControlledBy(pdb, tc) {
+			return nil
+		}
+		if err := m.deps.PDBControl.DeletePDB(tc, pdb); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncPDB: failed to delete pdb %s/%s, error: %v", ns, name, err)
+		}
+		return nil
+	}
+
+	pdb := getNewPDB(tc, comp)
+	if err := m.deps.PDBControl.CreateOrUpdatePDB(tc, pdb); err != nil {
+		return fmt.Errorf("syncPDB: failed to create or update pdb %s/%s, error: %v", ns, name, err)
+	}
+	return nil
+}
+
+func getPDBComponents(tc *v1alpha1.TidbCluster) []pdbComponent {
+	instanceName := tc.GetInstanceName()
+	var comps []pdbComponent
+	if tc.Spec.PD != nil {
+		comps = append(comps, pdbComponent{
+			memberType: v1alpha1.PDMemberType,
+			label:      label.New().Instance(instanceName).PD(),
+			replicas:   tc.Spec.PD.Replicas,
+			spec:       tc.Spec.PD.PodDisruptionBudget,
+		})
+	}
+	if tc.Spec.TiKV != nil {
+		comps = append(comps, pdbComponent{
+			memberType: v1alpha1.TiKVMemberType,
+			label:      label.New().Instance(instanceName).TiKV(),
+			replicas:   tc.Spec.TiKV.Replicas,
+			spec:       tc.Spec.TiKV.PodDisruptionBudget,
+		})
+	}
+	if tc.Spec.TiDB != nil {
+		comps = append(comps, pdbComponent{
+			memberType: v1alpha1.TiDBMemberType,
+			label:      label.New().Instance(instanceName).TiDB(),
+			replicas:   tc.Spec.TiDB.Replicas,
+			spec:       tc.Spec.TiDB.PodDisruptionBudget,
+		})
+	}
+	if tc.Spec.TiFlash != nil {
+		comps = append(comps, pdbComponent{
+			memberType: v1alpha1.TiFlashMemberType,
+			label:      label.New().Instance(instanceName).TiFlash(),
+			replicas:   tc.Spec.TiFlash.Replicas,
+			spec:       tc.Spec.TiFlash.PodDisruptionBudget,
+		})
+	}
+	if tc.Spec.TiCDC != nil {
+		comps = append(comps, pdbComponent{
+			memberType: v1alpha1.TiCDCMemberType,
+			label:      label.New().Instance(instanceName).TiCDC(),
+			replicas:   tc.Spec.TiCDC.Replicas,
+			spec:       tc.Spec.TiCDC.PodDisruptionBudget,
+		})
+	}
+	return comps
+}
+
+func getNewPDB(tc *v1alpha1.TidbCluster, comp pdbComponent) *policyv1beta1.PodDisruptionBudget {
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.MemberName(tc.GetName(), comp.memberType),
+			Namespace:       tc.GetNamespace(),
+			Labels:          comp.label.Copy(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector:       comp.label.LabelSelector(),
+			MaxUnavailable: comp.spec.MaxUnavailable,
+			MinAvailable:   comp.spec.MinAvailable,
+		},
+	}
+	if pdb.Spec.MaxUnavailable == nil && pdb.Spec.MinAvailable == nil {
+		maxUnavailable := intstr.FromInt(int(defaultPDBMaxUnavailable(comp.memberType, comp.replicas)))
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return pdb
+}
+
+// defaultPDBMaxUnavailable returns the default maxUnavailable of the PodDisruptionBudget.
+// PD could tolerate the failure of a minority of members without losing the quorum,
+// TiKV could only tolerate one failure with the default 3 replicas of regions,
+// and one pod at a time is allowed for the other components.
+func defaultPDBMaxUnavailable(memberType v1alpha1.MemberType, replicas int32) int32 {
+	if memberType == v1alpha1.PDMemberType && (replicas-1)/2 > 1 {
+		return (replicas - 1) / 2
+	}
+	return 1
+}
+
+type FakePDBManager struct {
+	err error
+}
+
+func NewFakePDBManager() *FakePDBManager {
+	return &FakePDBManager{}
+}
+
+func (m *FakePDBManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePDBManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestPDBManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name    string
+		prepare func(tc *v1alpha1.TidbCluster)
+		expect  func(pdbs []policyv1beta1.PodDisruptionBudget, err error)
+	}
+
+	testFn := func(tt *testcase) {
+		t.Log(tt.name)
+
+		fakeDeps := controller.NewFakeDependencies()
+		ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
+		m := NewPDBManager(fakeDeps)
+
+		tc := newTidbClusterForPD()
+		if tt.prepare != nil {
+			tt.prepare(tc)
+		}
+		err := m.Sync(tc)
+		pdbList := &policyv1beta1.PodDisruptionBudgetList{}
+		g.Expect(ctrl.FakeCli.List(context.TODO(), pdbList)).To(Succeed())
+		tt.expect(pdbList.Items, err)
+	}
+
+	half := intstr.FromString("50%")
+	cases := []*testcase{
+		{
+			name: "no pdb is configured",
+			expect: func(pdbs []policyv1beta1.PodDisruptionBudget, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(pdbs).To(BeEmpty())
+			},
+		},
+		{
+			name: "default maxUnavailable from quorum",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Replicas = 5
+				tc.Spec.PD.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{}
+				tc.Spec.TiKV.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{}
+			},
+			expect: func(pdbs []policyv1beta1.PodDisruptionBudget, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(pdbs).To(HaveLen(2))
+				for _, pdb := range pdbs {
+					switch pdb.Name {
+					case "test-pd":
+						g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(2))
+					case "test-tikv":
+						g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+					default:
+						t.Errorf("unexpected pdb %s", pdb.Name)
+					}
+					g.Expect(pdb.Spec.MinAvailable).To(BeNil())
+				}
+			},
+		},
+		{
+			name: "user specified minAvailable",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiDB.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MinAvailable: &half}
+			},
+			expect: func(pdbs []policyv1beta1.PodDisruptionBudget, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(pdbs).To(HaveLen(1))
+				g.Expect(pdbs[0].Name).To(Equal("test-tidb"))
+				g.Expect(pdbs[0].Spec.MaxUnavailable).To(BeNil())
+				g.Expect(*pdbs[0].Spec.MinAvailable).To(Equal(half))
+				g.Expect(pdbs[0].Spec.Selector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/component", "tidb"))
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		testFn(tt)
+	}
+}
+
+func TestDefaultPDBMaxUnavailable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.PDMemberType, 1)).To(Equal(int32(1)))
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.PDMemberType, 3)).To(Equal(int32(1)))
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.PDMemberType, 5)).To(Equal(int32(2)))
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.PDMemberType, 7)).To(Equal(int32(3)))
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.TiKVMemberType, 7)).To(Equal(int32(1)))
+	g.Expect(defaultPDBMaxUnavailable(v1alpha1.TiDBMemberType, 3)).To(Equal(int32(1)))
+}
@@ -0,0 +1,530 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/manager/member/startscript"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const pdmsClusterCertPath = "/var/lib/pdms-tls"
+
+// pdmsMemberTypes are the PD microservices which can be deployed in `spec.pdms`
+var pdmsMemberTypes = []v1alpha1.MemberType{v1alpha1.PDMSTSOMemberType, v1alpha1.PDMSSchedulingMemberType}
+
+func labelPDMS(tc *v1alpha1.TidbCluster, name string) label.Label {
+	instanceName := tc.GetInstanceName()
+	return label.New().Instance(instanceName).PDMS(name)
+}
+
+// pdMSMemberManager implements manager.Manager.
+// It deploys each PD microservice in `spec.pdms` by its own StatefulSet when PD is in the microservice mode.
+type pdMSMemberManager struct {
+	deps *controller.Dependencies
+}
+
+// NewPDMSMemberManager returns a *pdMSMemberManager
+func NewPDMSMemberManager(deps *controller.Dependencies) manager.Manager {
+	return &pdMSMemberManager{
+		deps: deps,
+	}
+}
+
+// Sync fulfills the manager.Manager interface
+func (m *pdMSMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if tc.Spec.Paused {
+		klog.V(4).Infof("TidbCluster %s/%s is paused, skip syncing pd microservices", ns, tcName)
+		return nil
+	}
+
+	if err := m.cleanRemovedPDMS(tc); err != nil {
+		return err
+	}
+	if !tc.PDMSEnabled() || len(tc.Spec.PDMS) == 0 {
+		return nil
+	}
+
+	// the microservices can only serve after the PD cluster is available
+	if !tc.PDIsAvailable() {
+		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
+	}
+
+	if tc.Status.PDMS == nil {
+		tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{}
+	}
+	for _, spec := range tc.Spec.PDMS {
+		if err := m.syncService(tc, spec, false); err != nil {
+			return err
+		}
+		if err := m.syncService(tc, spec, true); err != nil {
+			return err
+		}
+		if err := m.syncStatefulSet(tc, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanRemovedPDMS deletes the StatefulSets, Services and ConfigMaps of the PD microservices
+// which are removed from `spec.pdms` or not deployed because PD is not in the microservice mode
+func (m *pdMSMemberManager) cleanRemovedPDMS(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	desired := map[string]struct{}{}
+	if tc.PDMSEnabled() {
+		for _, spec := range tc.Spec.PDMS {
+			desired[spec.Name] = struct{}{}
+		}
+	}
+
+	for _, memberType := range pdmsMemberTypes {
+		name := memberType.String()
+		if _, ok := desired[name]; ok {
+			continue
+		}
+
+		selector, err := labelPDMS(tc, name).Selector()
+		if err != nil {
+			return err
+		}
+		var objs []client.Object
+		sets, err := m.deps.StatefulSetLister.StatefulSets(ns).List(selector)
+		if err != nil {
+			return fmt.Errorf("cleanRemovedPDMS: failed to list sts of %s for cluster %s/%s, error: %v", name, ns, tc.GetName(), err)
+		}
+		for _, set := range sets {
+			objs = append(objs, set)
+		}
+		svcs, err := m.deps.ServiceLister.Services(ns).List(selector)
+		if err != nil {
+			return fmt.Errorf("cleanRemovedPDMS: failed to list svc of %s for cluster %s/%s, error: %v", name, ns, tc.GetName(), err)
+		}
+		for _, svc := range svcs {
+			objs = append(objs, svc)
+		}
+		cms, err := m.deps.ConfigMapLister.ConfigMaps(ns).List(selector)
+		if err != nil {
+			return fmt.Errorf("cleanRemovedPDMS: failed to list cm of %s for cluster %s/%s, error: %v", name, ns, tc.GetName(), err)
+		}
+		for _, cm := range cms {
+			objs = append(objs, cm)
+		}
+
+		for _, obj := range objs {
+			if !metav1.IsControlledBy(obj, tc) {
+				continue
+			}
+			if err := m.deps.TypedControl.Delete(tc, obj.DeepCopyObject().(client.Object)); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("cleanRemovedPDMS: failed to delete %s of %s for cluster %s/%s, error: %v", obj.GetName(), name, ns, tc.GetName(), err)
+			}
+			klog.Infof("TidbCluster %s/%s: %s of the removed pd microservice %s is deleted", ns, tc.GetName(), obj.GetName(), name)
+		}
+		delete(tc.Status.PDMS, name)
+	}
+	return nil
+}
+
+func (m *pdMSMemberManager) syncService(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, peer bool) error {
+	svcLabel := labelPDMS(tc, spec.Name)
+	newSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       tc.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: svcLabel,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "client",
+					Port:       2379,
+					TargetPort: intstr.FromInt(2379),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	if peer {
+		newSvc.ObjectMeta.Name = controller.PDMSPeerMemberName(tc.GetName(), spec.Name)
+		newSvc.ObjectMeta.Labels = svcLabel.Copy().UsedByPeer()
+		newSvc.Spec.ClusterIP = "None"
+		newSvc.Spec.PublishNotReadyAddresses = true
+	} else {
+		newSvc.ObjectMeta.Name = controller.PDMSMemberName(tc.GetName(), spec.Name)
+		newSvc.ObjectMeta.Labels = svcLabel.Copy().UsedByEndUser()
+	}
+	if tc.Spec.PreferIPv6 {
+		SetServiceWhenPreferIPv6(newSvc)
+	}
+
+	oldSvcTmp, err := m.deps.ServiceLister.Services(tc.GetNamespace()).Get(newSvc.Name)
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncService: failed to get svc %s for cluster %s/%s, error: %s", newSvc.Name, tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	equal, err := controller.ServiceEqual(newSvc, oldSvc)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		svc := *oldSvc
+		svc.Spec = newSvc.Spec
+		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
+		if err != nil {
+			return err
+		}
+		_, err = m.deps.ServiceControl.UpdateService(tc, &svc)
+		return err
+	}
+	return nil
+}
+
+func (m *pdMSMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	stsName := controller.PDMSMemberName(tcName, spec.Name)
+
+	oldStsTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(stsName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncStatefulSet: failed to get sts %s for cluster %s/%s, error: %s", stsName, ns, tcName, err)
+	}
+
+	stsNotExist := errors.IsNotFound(err)
+	oldStatefulSet := oldStsTmp.DeepCopy()
+
+	// failed to sync the status will not affect subsequent logic, just print the errors.
+	if err := m.syncStatus(tc, spec, oldStatefulSet); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s %s status, error: %v", ns, tcName, spec.Name, err)
+	}
+
+	cm, err := m.syncConfigMap(tc, spec, oldStatefulSet)
+	if err != nil {
+		return err
+	}
+
+	newSts, err := m.getNewStatefulSet(tc, spec, cm)
+	if err != nil {
+		return err
+	}
+	RewritePodImages(&newSts.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	keepTerminationGracePeriodSeconds(tc.BasePDMSSpec(spec), newSts, oldStatefulSet)
+	newSts = overrideStatefulSet(tc, m.deps.Recorder, newSts)
+
+	if stsNotExist {
+		if err := mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts); err != nil {
+			return err
+		}
+		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSts)
+	}
+
+	// the microservices are stateless, the replicas and the pod template are
+	// applied directly and rolled out by the RollingUpdate strategy of the StatefulSet
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDMSSTS", newSts, oldStatefulSet)
+}
+
+func (m *pdMSMemberManager) syncStatus(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, sts *apps.StatefulSet) error {
+	if sts == nil {
+		// skip if not created yet
+		return nil
+	}
+
+	status := tc.Status.PDMS[spec.Name]
+	if status == nil {
+		status = &v1alpha1.PDMSStatus{Name: spec.Name}
+		tc.Status.PDMS[spec.Name] = status
+	}
+	status.StatefulSet = &sts.Status
+	status.Image = tc.PDMSImage(spec)
+
+	if spec.Replicas != *sts.Spec.Replicas {
+		status.Phase = v1alpha1.ScalePhase
+	} else if mngerutils.StatefulSetIsUpgrading(sts) {
+		status.Phase = v1alpha1.UpgradePhase
+	} else {
+		status.Phase = v1alpha1.NormalPhase
+	}
+
+	selector, err := labelPDMS(tc, spec.Name).Selector()
+	if err != nil {
+		status.Synced = false
+		return err
+	}
+	pods, err := m.deps.PodLister.Pods(tc.GetNamespace()).List(selector)
+	if err != nil {
+		status.Synced = false
+		return fmt.Errorf("syncStatus: failed to list pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetName(), selector, err)
+	}
+	members := []string{}
+	for _, pod := range pods {
+		if !podutil.IsPodReady(pod) {
+			continue
+		}
+		members = append(members, fmt.Sprintf("%s://%s.%s.%s.svc%s:2379", tc.Scheme(), pod.Name,
+			controller.PDMSPeerMemberName(tc.GetName(), spec.Name), tc.GetNamespace(), controller.FormatClusterDomain(tc.Spec.ClusterDomain)))
+	}
+	status.Members = members
+	status.Synced = true
+	return nil
+}
+
+func (m *pdMSMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	var config *v1alpha1.PDConfigWraper
+	if spec.Config != nil {
+		config = spec.Config.DeepCopy() // use copy to not update tc spec
+	} else {
+		config = v1alpha1.NewPDConfig()
+	}
+
+	if tc.IsTLSClusterEnabled() {
+		config.Set("security.cacert-path", path.Join(pdmsClusterCertPath, tlsSecretRootCAKey))
+		config.Set("security.cert-path", path.Join(pdmsClusterCertPath, corev1.TLSCertKey))
+		config.Set("security.key-path", path.Join(pdmsClusterCertPath, corev1.TLSPrivateKeyKey))
+	}
+
+	confText, err := config.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+
+	startScript, err := startscript.RenderPDMSStartScript(tc, spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("render start-script of %s for tc %s/%s failed: %v", spec.Name, tc.Namespace, tc.Name, err)
+	}
+
+	memberName := controller.PDMSMemberName(tc.Name, spec.Name)
+	newCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            memberName,
+			Namespace:       tc.Namespace,
+			Labels:          labelPDMS(tc, spec.Name).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: map[string]string{
+			"config-file":    string(confText),
+			"startup-script": startScript,
+		},
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, memberName)
+		})
+	}
+
+	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BasePDMSSpec(spec).ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func (m *pdMSMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	baseSpec := tc.BasePDMSSpec(spec)
+	stsLabels := labelPDMS(tc, spec.Name)
+	stsName := controller.PDMSMemberName(tcName, spec.Name)
+	podLabels := util.CombineStringMap(stsLabels, baseSpec.Labels())
+	podAnnotations := util.CombineStringMap(baseSpec.Annotations(), controller.AnnProm(2379, "/metrics"))
+	stsAnnotations := getStsAnnotations(tc.Annotations, spec.Name)
+	headlessSvcName := controller.PDMSPeerMemberName(tcName, spec.Name)
+
+	annMount, annVolume := annotationsMountVolume()
+	vols := []corev1.Volume{
+		annVolume,
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cm.Name,
+					},
+					Items: []corev1.KeyToPath{{Key: "config-file", Path: "pd.toml"}},
+				},
+			},
+		},
+		{
+			Name: "startup-script",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cm.Name,
+					},
+					Items: []corev1.KeyToPath{{Key: "startup-script", Path: "pdms_start_script.sh"}},
+				},
+			},
+		},
+	}
+	volMounts := []corev1.VolumeMount{
+		annMount,
+		{Name: "config", ReadOnly: true, MountPath: "/etc/pd"},
+		{Name: "startup-script", ReadOnly: true, MountPath: "/usr/local/bin"},
+	}
+	if tc.IsTLSClusterEnabled() {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: "pdms-tls", ReadOnly: true, MountPath: pdmsClusterCertPath,
+		})
+		vols = append(vols, corev1.Volume{
+			Name: "pdms-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterTLSSecretName(tcName, spec.Name),
+				},
+			},
+		})
+	}
+	volMounts = append(volMounts, spec.AdditionalVolumeMounts...)
+
+	envs := []corev1.EnvVar{
+		{
+			Name: "NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+		{
+			Name:  "PEER_SERVICE_NAME",
+			Value: headlessSvcName,
+		},
+		{
+			Name:  "SERVICE_NAME",
+			Value: stsName,
+		},
+		{
+			Name:  "TZ",
+			Value: tc.Spec.Timezone,
+		},
+	}
+
+	container := corev1.Container{
+		Name:            spec.Name,
+		Image:           tc.PDMSImage(spec),
+		ImagePullPolicy: baseSpec.ImagePullPolicy(),
+		Command:         []string{"/bin/sh", "/usr/local/bin/pdms_start_script.sh"},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "client",
+				ContainerPort: int32(2379),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: volMounts,
+		Resources:    controller.ContainerResource(spec.ResourceRequirements),
+		Env:          util.AppendEnv(envs, baseSpec.Env()),
+		EnvFrom:      baseSpec.EnvFrom(),
+	}
+
+	podSpec := baseSpec.BuildPodSpec()
+	var err error
+	podSpec.Containers, err = MergePatchContainers([]corev1.Container{container}, baseSpec.AdditionalContainers())
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge containers spec for %s of [%s/%s], error: %v", spec.Name, ns, tcName, err)
+	}
+	podSpec.Volumes = append(vols, baseSpec.AdditionalVolumes()...)
+	podSpec.InitContainers = append(podSpec.InitContainers, baseSpec.InitContainers()...)
+	podSpec.ServiceAccountName = spec.ServiceAccount
+	if podSpec.ServiceAccountName == "" {
+		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
+	}
+
+	updateStrategy := apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType}
+	if baseSpec.StatefulSetUpdateStrategy() == apps.OnDeleteStatefulSetStrategyType {
+		updateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+	}
+
+	return &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            stsName,
+			Namespace:       ns,
+			Labels:          stsLabels.Labels(),
+			Annotations:     stsAnnotations,
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(tc.PDMSStsDesiredReplicas(spec.Name)),
+			Selector: stsLabels.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: podSpec,
+			},
+			ServiceName:         headlessSvcName,
+			PodManagementPolicy: baseSpec.PodManagementPolicy(),
+			UpdateStrategy:      updateStrategy,
+		},
+	}, nil
+}
+
+type FakePDMSMemberManager struct {
+	err error
+}
+
+func NewFakePDMSMemberManager() *FakePDMSMemberManager {
+	return &FakePDMSMemberManager{}
+}
+
+func (m *FakePDMSMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePDMSMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestPDMSMemberManagerSyncCreate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name                     string
+		prepare                  func(tc *v1alpha1.TidbCluster)
+		errWhenCreateStatefulSet bool
+		errWhenCreateService     bool
+		errExpectFn              func(*GomegaWithT, error)
+		svcCreated               bool
+		setCreated               bool
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+		tc := newTidbClusterForPDMS()
+		ns := tc.Namespace
+		tcName := tc.Name
+		if test.prepare != nil {
+			test.prepare(tc)
+		}
+
+		m := newFakePDMSMemberManager()
+		fakeSetControl := m.deps.StatefulSetControl.(*controller.FakeStatefulSetControl)
+		fakeSvcControl := m.deps.ServiceControl.(*controller.FakeServiceControl)
+		if test.errWhenCreateStatefulSet {
+			fakeSetControl.SetCreateStatefulSetError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		}
+		if test.errWhenCreateService {
+			fakeSvcControl.SetCreateServiceError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		}
+
+		err := m.Sync(tc)
+		test.errExpectFn(g, err)
+
+		svc, err := m.deps.ServiceLister.Services(ns).Get(controller.PDMSMemberName(tcName, "tso"))
+		if test.svcCreated {
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(svc.Spec.Selector).To(HaveKeyWithValue("app.kubernetes.io/component", "tso"))
+		} else {
+			expectErrIsNotFound(g, err)
+		}
+
+		set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.PDMSMemberName(tcName, "tso"))
+		if test.setCreated {
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+			g.Expect(set.Spec.ServiceName).To(Equal(controller.PDMSPeerMemberName(tcName, "tso")))
+		} else {
+			expectErrIsNotFound(g, err)
+		}
+	}
+
+	tests := []testcase{
+		{
+			name: "normal",
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+			svcCreated: true,
+			setCreated: true,
+		},
+		{
+			name: "pd is not in the microservice mode",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Mode = v1alpha1.PDModeNormal
+			},
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+			svcCreated: false,
+			setCreated: false,
+		},
+		{
+			name: "pd is not available",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Members = nil
+			},
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			},
+			svcCreated: false,
+			setCreated: false,
+		},
+		{
+			name:                 "error when create service",
+			errWhenCreateService: true,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("API server failed"))
+			},
+			svcCreated: false,
+			setCreated: false,
+		},
+		{
+			name:                     "error when create statefulset",
+			errWhenCreateStatefulSet: true,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("API server failed"))
+			},
+			svcCreated: true,
+			setCreated: false,
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i], t)
+	}
+}
+
+func TestPDMSMemberManagerSyncStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	for _, clusterDomain := range []string{"", "cluster.local"} {
+		tc := newTidbClusterForPDMS()
+		tc.Spec.ClusterDomain = clusterDomain
+		m := newFakePDMSMemberManager()
+
+		g.Expect(m.Sync(tc)).To(Succeed())
+		for i := 0; i < 2; i++ {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-%d", controller.PDMSMemberName(tc.Name, "tso"), i),
+					Namespace: tc.Namespace,
+					Labels:    labelPDMS(tc, "tso").Labels(),
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			g.Expect(m.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+		}
+
+		g.Expect(m.Sync(tc)).To(Succeed())
+		status := tc.Status.PDMS["tso"]
+		g.Expect(status).NotTo(BeNil())
+		g.Expect(status.Synced).To(BeTrue())
+		g.Expect(status.Phase).To(Equal(v1alpha1.NormalPhase))
+		domain := controller.FormatClusterDomain(clusterDomain)
+		g.Expect(status.Members).To(ConsistOf(
+			fmt.Sprintf("http://test-tso-0.test-tso-peer.default.svc%s:2379", domain),
+			fmt.Sprintf("http://test-tso-1.test-tso-peer.default.svc%s:2379", domain),
+		))
+	}
+}
+
+func TestPDMSMemberManagerCleanRemovedPDMS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name    string
+		prepare func(tc *v1alpha1.TidbCluster)
+		deleted []string
+		kept    []string
+	}
+
+	tests := []testcase{
+		{
+			name: "scheduling is removed from spec.pdms",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PDMS = tc.Spec.PDMS[:1]
+			},
+			deleted: []string{"scheduling"},
+			kept:    []string{"tso"},
+		},
+		{
+			name: "pd is switched to the normal mode",
+			prepare: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Mode = v1alpha1.PDModeNormal
+			},
+			deleted: []string{"tso", "scheduling"},
+		},
+		{
+			name:    "nothing is removed",
+			prepare: func(tc *v1alpha1.TidbCluster) {},
+			kept:    []string{"tso", "scheduling"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		tc := newTidbClusterForPDMS()
+		tc.Spec.PDMS = append(tc.Spec.PDMS, &v1alpha1.PDMSSpec{Name: "scheduling", Replicas: 1})
+		m := newFakePDMSMemberManager()
+		fakeCli := m.deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+
+		for _, name := range []string{"tso", "scheduling"} {
+			objs := []client.Object{
+				&apps.StatefulSet{ObjectMeta: pdmsObjectMeta(tc, controller.PDMSMemberName(tc.Name, name), name)},
+				&corev1.Service{ObjectMeta: pdmsObjectMeta(tc, controller.PDMSMemberName(tc.Name, name), name)},
+				&corev1.Service{ObjectMeta: pdmsObjectMeta(tc, controller.PDMSPeerMemberName(tc.Name, name), name)},
+				&corev1.ConfigMap{ObjectMeta: pdmsObjectMeta(tc, controller.PDMSMemberName(tc.Name, name)+"-6239636", name)},
+			}
+			for _, obj := range objs {
+				g.Expect(fakeCli.Create(context.TODO(), obj)).To(Succeed())
+			}
+			g.Expect(m.deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(objs[0])).To(Succeed())
+			g.Expect(m.deps.KubeInformerFactory.Core().V1().Services().Informer().GetIndexer().Add(objs[1])).To(Succeed())
+			g.Expect(m.deps.KubeInformerFactory.Core().V1().Services().Informer().GetIndexer().Add(objs[2])).To(Succeed())
+			g.Expect(m.deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer().Add(objs[3])).To(Succeed())
+			tc.Status.PDMS[name] = &v1alpha1.PDMSStatus{Name: name}
+		}
+		test.prepare(tc)
+
+		g.Expect(m.cleanRemovedPDMS(tc)).To(Succeed())
+		for _, name := range test.deleted {
+			err := fakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMSMemberName(tc.Name, name)}, &apps.StatefulSet{})
+			expectErrIsNotFound(g, err)
+			err = fakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMSPeerMemberName(tc.Name, name)}, &corev1.Service{})
+			expectErrIsNotFound(g, err)
+			err = fakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMSMemberName(tc.Name, name) + "-6239636"}, &corev1.ConfigMap{})
+			expectErrIsNotFound(g, err)
+			g.Expect(tc.Status.PDMS).NotTo(HaveKey(name))
+		}
+		for _, name := range test.kept {
+			err := fakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMSMemberName(tc.Name, name)}, &apps.StatefulSet{})
+			g.Expect(err).NotTo(HaveOccurred())
+			err = fakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: controller.PDMSMemberName(tc.Name, name)}, &corev1.Service{})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tc.Status.PDMS).To(HaveKey(name))
+		}
+	}
+}
+
+func newFakePDMSMemberManager() *pdMSMemberManager {
+	return &pdMSMemberManager{
+		deps: controller.NewFakeDependencies(),
+	}
+}
+
+func newTidbClusterForPDMS() *v1alpha1.TidbCluster {
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Mode = v1alpha1.PDModeMicroservice
+	tc.Spec.PD.BaseImage = "pingcap/pd"
+	tc.Spec.PD.Version = pointer.StringPtr("v7.2.0")
+	tc.Spec.PDMS = []*v1alpha1.PDMSSpec{
+		{Name: "tso", Replicas: 2},
+	}
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"test-pd-0": {Name: "test-pd-0", Health: true},
+		"test-pd-1": {Name: "test-pd-1", Health: true},
+		"test-pd-2": {Name: "test-pd-2", Health: true},
+	}
+	tc.Status.PD.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 3}
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{}
+	return tc
+}
+
+func pdmsObjectMeta(tc *v1alpha1.TidbCluster, name, component string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       tc.Namespace,
+		Labels:          labelPDMS(tc, component).Labels(),
+		OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+	}
+}
@@ -103,7 +103,9 @@ func (m *pumpMemberManager) syncPumpStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	notFound := errors.IsNotFound(err)
 	oldSet := oldPumpSetTemp.DeepCopy()
 
-	if err := m.syncTiDBClusterStatus(tc, oldSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.PumpMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTiDBClusterStatus(tc, oldSet)
+	}); err != nil {
 		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s status, error: %v", tc.Namespace, tc.Name, err)
 		return err
 	}
@@ -122,6 +124,9 @@ func (m *pumpMemberManager) syncPumpStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	keepTerminationGracePeriodSeconds(tc.BasePumpSpec(), newSet, oldSet)
+	newSet = overrideStatefulSet(tc, m.deps.Recorder, newSet)
 	if notFound {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
@@ -130,12 +135,17 @@ func (m *pumpMemberManager) syncPumpStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSet)
 	}
 
-	if err := m.scaler.Scale(tc, oldSet, newSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.PumpMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldSet, newSet)
+	}); err != nil {
 		return err
 	}
 
 	// Wait for PD & TiKV upgrading done
 	// NO check for v1alpha1.ScalePhase now, as it shouldn't block when some other components scaling to 0 and deleting Pump
+	if !templateEqual(newSet, oldSet) {
+		waitForUpgradeDependencies(tc, v1alpha1.PumpMemberType)
+	}
 	if tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase {
@@ -233,6 +243,7 @@ func (m *pumpMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) error
 	}
 
 	newSvc := getNewPumpHeadlessService(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvc, err := m.deps.ServiceLister.Services(newSvc.Namespace).Get(newSvc.Name)
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -368,7 +379,7 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 	replicas := tc.Spec.Pump.Replicas
 	storageClass := tc.Spec.Pump.StorageClassName
 	podLabels := util.CombineStringMap(stsLabels.Labels(), spec.Labels())
-	podAnnos := util.CombineStringMap(spec.Annotations(), controller.AnnProm(8250, "/metrics"))
+	podAnnos := util.CombineStringMap(spec.Annotations(), controller.AnnProm(8250, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.PumpMemberType))
 	storageRequest, err := controller.ParseStorageRequest(tc.Spec.Pump.Requests)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse storage request for pump, tidbcluster %s/%s, error: %v", tc.Namespace, tc.Name, err)
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	errutil "k8s.io/apimachinery/pkg/util/errors"
@@ -216,7 +217,7 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 		if quantity, ok := tc.Spec.TiKV.Requests[corev1.ResourceStorage]; ok {
 			ctx.desiredVolumeQuantity[v1alpha1.GetStorageVolumeName("", v1alpha1.TiKVMemberType)] = quantity
 		}
-		storageVolumes = tc.Spec.TiKV.StorageVolumes
+		storageVolumes = tc.Spec.TiKV.AllStorageVolumes()
 	case v1alpha1.TiFlashMemberType:
 		ctx.selector = selector.Add(*tiflashRequirement)
 		if tc.Status.TiFlash.Volumes == nil {
@@ -545,6 +546,8 @@ func (p *pvcResizer) classifyVolumes(ctx *componentVolumeContext, volumes []*vol
 			if !volumeExpansionSupported {
 				klog.Warningf("Skip to resize PVC %q of %q: storage class %q does not support volume expansion",
 					pvcID, cid, *pvc.Spec.StorageClassName)
+				p.recordEvent(ctx, corev1.EventTypeWarning, "VolumeExpansionNotSupported", "skip to resize PVC %s: storage class %s does not support volume expansion",
+					pvcID, *pvc.Spec.StorageClassName)
 				continue
 			}
 		} else {
@@ -602,11 +605,14 @@ func (p *pvcResizer) resizeVolumesForPod(ctx *componentVolumeContext, pod *corev
 		_, err = p.deps.KubeClientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.TODO(), pvc.Name, types.MergePatchType, mergePatch, metav1.PatchOptions{})
 		if err != nil {
 			errs = append(errs, fmt.Errorf("resize PVC %s failed: %s", pvcID, err))
+			p.recordEvent(ctx, corev1.EventTypeWarning, "FailedResizePVC", "failed to resize PVC %s: %s", pvcID, err)
 			continue
 		}
 
 		klog.Infof("resize PVC %s of %s: storage request is updated from %s to %s",
 			pvcID, ctx.ComponentID(), currentRequest.String(), quantityInSpec.String())
+		p.recordEvent(ctx, corev1.EventTypeNormal, "ResizePVC", "resize PVC %s from %s to %s",
+			pvcID, currentRequest.String(), quantityInSpec.String())
 	}
 
 	return errutil.NewAggregate(errs)
@@ -711,6 +717,7 @@ func (p *pvcResizer) beginResize(ctx *componentVolumeContext) error {
 		Message: "Set resizing condition to begin resizing",
 	})
 	klog.Infof("begin resizing for %s: set resizing condition", ctx.ComponentID())
+	p.recordEvent(ctx, corev1.EventTypeNormal, "BeginResizing", "begin to resize volumes of %s", ctx.status.MemberType())
 	return controller.RequeueErrorf("set condition before resizing volumes for %s", ctx.ComponentID())
 }
 
@@ -737,9 +744,19 @@ func (p *pvcResizer) endResize(ctx *componentVolumeContext) error {
 		Message: "All volumes are resized",
 	})
 	klog.Infof("end resizing for %s: update resizing condition", ctx.ComponentID())
+	p.recordEvent(ctx, corev1.EventTypeNormal, "EndResizing", "all volumes of %s are resized", ctx.status.MemberType())
 	return nil
 }
 
+// recordEvent records an event of the cluster which the volumes belong to
+func (p *pvcResizer) recordEvent(ctx *componentVolumeContext, eventType, reason, messageFmt string, args ...interface{}) {
+	obj, ok := ctx.cluster.(runtime.Object)
+	if !ok || p.deps.Recorder == nil {
+		return
+	}
+	p.deps.Recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+}
+
 // collectAcutalStatus list pods and volumes to build context
 func (p *pvcResizer) collectAcutalStatus(ns string, selector labels.Selector) ([]*podVolumeContext, error) {
 	pods, err := p.deps.PodLister.Pods(ns).List(selector)
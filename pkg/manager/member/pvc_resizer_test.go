@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 )
 
@@ -987,7 +988,10 @@ func TestClassifyVolumes(t *testing.T) {
 func TestResizeHook(t *testing.T) {
 	t.Run("beginResize", func(t *testing.T) {
 		g := NewGomegaWithT(t)
-		resizer := &pvcResizer{}
+		fakeDeps := controller.NewFakeDependencies()
+		resizer := &pvcResizer{
+			deps: fakeDeps,
+		}
 		tc := &v1alpha1.TidbCluster{
 			ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-cluster"},
 			Spec: v1alpha1.TidbClusterSpec{
@@ -1005,6 +1009,8 @@ func TestResizeHook(t *testing.T) {
 		g.Expect(tc.Status.TiKV.Conditions[0].Reason).To(Equal("BeginResizing"))
 		g.Expect(tc.Status.TiKV.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
 		g.Expect(tc.IsComponentVolumeResizing(v1alpha1.TiKVMemberType)).To(BeTrue())
+		events := collectEvents(fakeDeps.Recorder.(*record.FakeRecorder).Events)
+		g.Expect(events).To(ConsistOf("Normal BeginResizing begin to resize volumes of tikv"))
 	})
 
 	t.Run("endResize", func(t *testing.T) {
@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// The phases of reconciling a component, the sync phase covers the whole reconciliation of the component
+// and the other phases are parts of it
+const (
+	ReconcilePhaseSync     = "sync"
+	ReconcilePhaseStatus   = "status"
+	ReconcilePhaseScale    = "scale"
+	ReconcilePhaseFailover = "failover"
+	ReconcilePhaseUpgrade  = "upgrade"
+)
+
+// The reasons of the errors of reconciling a component
+const (
+	reconcileErrorRequeue   = "requeue"
+	reconcileErrorIgnore    = "ignore"
+	reconcileErrorConflict  = "conflict"
+	reconcileErrorNotFound  = "not_found"
+	reconcileErrorThrottled = "throttled"
+	reconcileErrorTimeout   = "timeout"
+	reconcileErrorOther     = "other"
+)
+
+// ObserveReconcilePhase runs a phase of reconciling the component of the tidb cluster, and records the
+// duration and the error of it. The last success time of the component is recorded by the sync phase.
+func ObserveReconcilePhase(tc *v1alpha1.TidbCluster, component string, phase string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	metrics.ObserveClusterReconcile(tc.GetNamespace(), tc.GetName(), component, phase, time.Since(start), reconcileErrorReason(err))
+	if err == nil && phase == ReconcilePhaseSync {
+		metrics.ClusterReconcileLastSuccess.WithLabelValues(tc.GetNamespace(), tc.GetName(), component).SetToCurrentTime()
+	}
+	return err
+}
+
+// reconcileErrorReason classifies the error of reconciling a component, the requeue errors are expected
+// when waiting for the components to be ready, so they are separated from the unexpected ones
+func reconcileErrorReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case controller.IsRequeueError(err):
+		return reconcileErrorRequeue
+	case controller.IsIgnoreError(err):
+		return reconcileErrorIgnore
+	case errors.IsConflict(err):
+		return reconcileErrorConflict
+	case errors.IsNotFound(err):
+		return reconcileErrorNotFound
+	case errors.IsTooManyRequests(err):
+		return reconcileErrorThrottled
+	case errors.IsTimeout(err) || errors.IsServerTimeout(err):
+		return reconcileErrorTimeout
+	default:
+		return reconcileErrorOther
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+func TestObserveReconcilePhase(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Name = "reconcile-metrics"
+	component := string(v1alpha1.PDMemberType)
+
+	err := ObserveReconcilePhase(tc, component, ReconcilePhaseScale, func() error {
+		return controller.RequeueErrorf("waiting for the pd cluster running")
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(testutil.ToFloat64(metrics.ClusterReconcileErrors.WithLabelValues(tc.Namespace, tc.Name, component, ReconcilePhaseScale, reconcileErrorRequeue))).To(Equal(float64(1)))
+
+	g.Expect(ObserveReconcilePhase(tc, component, ReconcilePhaseSync, func() error { return nil })).To(Succeed())
+	g.Expect(testutil.ToFloat64(metrics.ClusterReconcileLastSuccess.WithLabelValues(tc.Namespace, tc.Name, component))).To(BeNumerically(">", 0))
+}
+
+func TestReconcileErrorReason(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gr := schema.GroupResource{Resource: "statefulsets"}
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{nil, ""},
+		{controller.RequeueErrorf("requeue"), reconcileErrorRequeue},
+		{fmt.Errorf("wrapped: %w", controller.RequeueErrorf("requeue")), reconcileErrorRequeue},
+		{controller.IgnoreErrorf("ignore"), reconcileErrorIgnore},
+		{apierrors.NewConflict(gr, "test", fmt.Errorf("conflict")), reconcileErrorConflict},
+		{apierrors.NewNotFound(gr, "test"), reconcileErrorNotFound},
+		{apierrors.NewTooManyRequests("throttled", 1), reconcileErrorThrottled},
+		{apierrors.NewServerTimeout(gr, "update", 1), reconcileErrorTimeout},
+		{fmt.Errorf("unknown"), reconcileErrorOther},
+	}
+	for _, tt := range tests {
+		g.Expect(reconcileErrorReason(tt.err)).To(Equal(tt.reason), "%v", tt.err)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+const (
+	resourceOverrideConflictReason = "ResourceOverrideConflict"
+	resourceOverrideFailedReason   = "ResourceOverrideFailed"
+)
+
+// managedLabelKeys are the labels which tidb-operator selects the generated resources by
+var managedLabelKeys = []string{
+	label.NameLabelKey,
+	label.ManagedByLabelKey,
+	label.InstanceLabelKey,
+	label.ComponentLabelKey,
+}
+
+// overrideStatefulSet applies the overrides of `spec.overrides` targeting the generated StatefulSet, it
+// returns the generated StatefulSet if none of them is applied.
+func overrideStatefulSet(tc *v1alpha1.TidbCluster, recorder record.EventRecorder, set *apps.StatefulSet) *apps.StatefulSet {
+	newObject := func() metav1.Object { return &apps.StatefulSet{} }
+	return applyResourceOverrides(tc, recorder, v1alpha1.ResourceOverrideKindStatefulSet, set, newObject, statefulSetOverrideConflicts).(*apps.StatefulSet)
+}
+
+// overrideService applies the overrides of `spec.overrides` targeting the generated Service, it returns
+// the generated Service if none of them is applied.
+func overrideService(tc *v1alpha1.TidbCluster, recorder record.EventRecorder, svc *corev1.Service) *corev1.Service {
+	newObject := func() metav1.Object { return &corev1.Service{} }
+	return applyResourceOverrides(tc, recorder, v1alpha1.ResourceOverrideKindService, svc, newObject, serviceOverrideConflicts).(*corev1.Service)
+}
+
+// applyResourceOverrides applies the matched overrides to the generated obj in order. The overrides which
+// can't be applied, or which change the fields managed by tidb-operator, are skipped and reported by the
+// status and a warning event, so a broken override never blocks the sync of the cluster.
+func applyResourceOverrides(
+	tc *v1alpha1.TidbCluster,
+	recorder record.EventRecorder,
+	kind v1alpha1.ResourceOverrideKind,
+	generated metav1.Object,
+	newObject func() metav1.Object,
+	conflicts func(generated, patched metav1.Object) []string,
+) metav1.Object {
+	result := generated
+	for i, override := range tc.Spec.Overrides {
+		if override.Kind != kind || override.Name != generated.GetName() {
+			continue
+		}
+		patched, err := patchResource(result, override, newObject)
+		if err != nil {
+			setResourceOverrideStatus(tc, recorder, i, v1alpha1.ResourceOverridePhaseFailed, err.Error())
+			continue
+		}
+		if fields := conflicts(generated, patched); len(fields) > 0 {
+			msg := fmt.Sprintf("the fields managed by tidb-operator are changed: %s", strings.Join(fields, ", "))
+			setResourceOverrideStatus(tc, recorder, i, v1alpha1.ResourceOverridePhaseConflict, msg)
+			continue
+		}
+		result = patched
+		setResourceOverrideStatus(tc, recorder, i, v1alpha1.ResourceOverridePhaseApplied, "")
+	}
+	return result
+}
+
+// patchResource returns a copy of obj patched by the override
+func patchResource(obj metav1.Object, override v1alpha1.ResourceOverride, newObject func() metav1.Object) (metav1.Object, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %v", override.Kind, override.Name, err)
+	}
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the patch: %v", err)
+	}
+
+	var data []byte
+	switch override.Type {
+	case v1alpha1.ResourceOverridePatchTypeJSON:
+		jsonPatch, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the JSON patch: %v", err)
+		}
+		data, err = jsonPatch.Apply(original)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the JSON patch: %v", err)
+		}
+	default:
+		data, err = strategicpatch.StrategicMergePatch(original, patch, newObject())
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the strategic merge patch: %v", err)
+		}
+	}
+
+	patched := newObject()
+	if err := json.Unmarshal(data, patched); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the patched %s %s: %v", override.Kind, override.Name, err)
+	}
+	return patched, nil
+}
+
+func metaOverrideConflicts(generated, patched metav1.Object) []string {
+	var fields []string
+	if patched.GetName() != generated.GetName() {
+		fields = append(fields, "metadata.name")
+	}
+	if patched.GetNamespace() != generated.GetNamespace() {
+		fields = append(fields, "metadata.namespace")
+	}
+	if !apiequality.Semantic.DeepEqual(patched.GetOwnerReferences(), generated.GetOwnerReferences()) {
+		fields = append(fields, "metadata.ownerReferences")
+	}
+	fields = append(fields, labelOverrideConflicts("metadata.labels", generated.GetLabels(), patched.GetLabels())...)
+	return fields
+}
+
+func labelOverrideConflicts(path string, generated, patched map[string]string) []string {
+	var fields []string
+	for _, key := range managedLabelKeys {
+		if patched[key] != generated[key] {
+			fields = append(fields, fmt.Sprintf("%s[%s]", path, key))
+		}
+	}
+	return fields
+}
+
+func statefulSetOverrideConflicts(generatedObj, patchedObj metav1.Object) []string {
+	generated, patched := generatedObj.(*apps.StatefulSet), patchedObj.(*apps.StatefulSet)
+	fields := metaOverrideConflicts(generated, patched)
+	// the replicas and partition are managed by the scaler and upgrader
+	if !apiequality.Semantic.DeepEqual(patched.Spec.Replicas, generated.Spec.Replicas) {
+		fields = append(fields, "spec.replicas")
+	}
+	if !apiequality.Semantic.DeepEqual(patched.Spec.UpdateStrategy, generated.Spec.UpdateStrategy) {
+		fields = append(fields, "spec.updateStrategy")
+	}
+	if !apiequality.Semantic.DeepEqual(patched.Spec.Selector, generated.Spec.Selector) {
+		fields = append(fields, "spec.selector")
+	}
+	if patched.Spec.ServiceName != generated.Spec.ServiceName {
+		fields = append(fields, "spec.serviceName")
+	}
+	// the volume claim templates are immutable
+	if !apiequality.Semantic.DeepEqual(patched.Spec.VolumeClaimTemplates, generated.Spec.VolumeClaimTemplates) {
+		fields = append(fields, "spec.volumeClaimTemplates")
+	}
+	fields = append(fields, labelOverrideConflicts("spec.template.metadata.labels", generated.Spec.Template.Labels, patched.Spec.Template.Labels)...)
+	return fields
+}
+
+func serviceOverrideConflicts(generatedObj, patchedObj metav1.Object) []string {
+	generated, patched := generatedObj.(*corev1.Service), patchedObj.(*corev1.Service)
+	fields := metaOverrideConflicts(generated, patched)
+	if !apiequality.Semantic.DeepEqual(patched.Spec.Selector, generated.Spec.Selector) {
+		fields = append(fields, "spec.selector")
+	}
+	if patched.Spec.ClusterIP != generated.Spec.ClusterIP {
+		fields = append(fields, "spec.clusterIP")
+	}
+	return fields
+}
+
+// SyncResourceOverrideStatus aligns `status.overrides` with `spec.overrides`, the status of the overrides
+// which are added or retargeted is reset to Pending until the member managers apply them.
+func SyncResourceOverrideStatus(tc *v1alpha1.TidbCluster) {
+	if len(tc.Spec.Overrides) == 0 {
+		tc.Status.Overrides = nil
+		return
+	}
+	statuses := make([]v1alpha1.ResourceOverrideStatus, 0, len(tc.Spec.Overrides))
+	for i, override := range tc.Spec.Overrides {
+		if i < len(tc.Status.Overrides) {
+			status := tc.Status.Overrides[i]
+			if status.Kind == override.Kind && status.Name == override.Name {
+				statuses = append(statuses, status)
+				continue
+			}
+		}
+		statuses = append(statuses, v1alpha1.ResourceOverrideStatus{
+			Kind:  override.Kind,
+			Name:  override.Name,
+			Phase: v1alpha1.ResourceOverridePhasePending,
+		})
+	}
+	tc.Status.Overrides = statuses
+}
+
+// setResourceOverrideStatus sets the status of the i-th override, the conflicts and failures are reported
+// by a warning event when they are found first.
+func setResourceOverrideStatus(tc *v1alpha1.TidbCluster, recorder record.EventRecorder, i int, phase v1alpha1.ResourceOverridePhase, msg string) {
+	SyncResourceOverrideStatus(tc)
+	status := &tc.Status.Overrides[i]
+	if status.Phase == phase && status.Message == msg {
+		return
+	}
+	status.Phase = phase
+	status.Message = msg
+
+	switch phase {
+	case v1alpha1.ResourceOverridePhaseConflict, v1alpha1.ResourceOverridePhaseFailed:
+		reason := resourceOverrideFailedReason
+		if phase == v1alpha1.ResourceOverridePhaseConflict {
+			reason = resourceOverrideConflictReason
+		}
+		event := fmt.Sprintf("override %d of %s %s is not applied: %s", i, status.Kind, status.Name, msg)
+		klog.Warningf("tidbcluster %s/%s: %s", tc.GetNamespace(), tc.GetName(), event)
+		recorder.Event(tc, corev1.EventTypeWarning, reason, event)
+	default:
+		klog.Infof("tidbcluster %s/%s: override %d of %s %s is applied", tc.GetNamespace(), tc.GetName(), i, status.Kind, status.Name)
+	}
+}
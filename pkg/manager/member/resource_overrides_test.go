@@ -0,0 +1,145 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestOverrideStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbClusterForPD()
+	setLabels := label.New().Instance(tc.Name).PD().Labels()
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pd", Namespace: tc.Namespace, Labels: setLabels},
+		Spec: apps.StatefulSetSpec{
+			Replicas:    pointer.Int32Ptr(3),
+			Selector:    &metav1.LabelSelector{MatchLabels: setLabels},
+			ServiceName: "test-pd-peer",
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: setLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "pd", Image: "pingcap/pd:v7.5.0"}},
+				},
+			},
+		},
+	}
+	tc.Spec.Overrides = []v1alpha1.ResourceOverride{
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "test-pd",
+			Patch: "spec:\n  revisionHistoryLimit: 10\n  template:\n    spec:\n      containers:\n      - name: pd\n        workingDir: /var/lib/pd\n",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "test-pd",
+			Type:  v1alpha1.ResourceOverridePatchTypeJSON,
+			Patch: `[{"op": "add", "path": "/spec/template/spec/hostNetwork", "value": true}]`,
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "test-pd",
+			Patch: `{"spec": {"replicas": 5}}`,
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindStatefulSet,
+			Name:  "test-pd",
+			Type:  v1alpha1.ResourceOverridePatchTypeJSON,
+			Patch: `[{"op": "remove", "path": "/spec/template/spec/nodeName"}]`,
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindService,
+			Name:  "test-pd",
+			Patch: `{"spec": {"type": "NodePort"}}`,
+		},
+	}
+
+	patched := overrideStatefulSet(tc, recorder, set)
+	g.Expect(patched).NotTo(BeIdenticalTo(set))
+	g.Expect(*patched.Spec.RevisionHistoryLimit).To(Equal(int32(10)))
+	g.Expect(patched.Spec.Template.Spec.HostNetwork).To(BeTrue())
+	g.Expect(patched.Spec.Template.Spec.Containers).To(HaveLen(1))
+	g.Expect(patched.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/pd:v7.5.0"))
+	g.Expect(patched.Spec.Template.Spec.Containers[0].WorkingDir).To(Equal("/var/lib/pd"))
+	g.Expect(*patched.Spec.Replicas).To(Equal(int32(3)))
+	// the generated StatefulSet is not changed
+	g.Expect(set.Spec.RevisionHistoryLimit).To(BeNil())
+
+	g.Expect(tc.Status.Overrides).To(HaveLen(5))
+	g.Expect(tc.Status.Overrides[0].Phase).To(Equal(v1alpha1.ResourceOverridePhaseApplied))
+	g.Expect(tc.Status.Overrides[1].Phase).To(Equal(v1alpha1.ResourceOverridePhaseApplied))
+	g.Expect(tc.Status.Overrides[2].Phase).To(Equal(v1alpha1.ResourceOverridePhaseConflict))
+	g.Expect(tc.Status.Overrides[2].Message).To(ContainSubstring("spec.replicas"))
+	g.Expect(tc.Status.Overrides[3].Phase).To(Equal(v1alpha1.ResourceOverridePhaseFailed))
+	g.Expect(tc.Status.Overrides[4].Phase).To(Equal(v1alpha1.ResourceOverridePhasePending))
+	g.Expect(recorder.Events).To(HaveLen(2))
+
+	// the conflicts and failures are reported only once
+	overrideStatefulSet(tc, recorder, set)
+	g.Expect(recorder.Events).To(HaveLen(2))
+
+	// the status is reset if the override is retargeted
+	tc.Spec.Overrides[2].Name = "test-tikv"
+	SyncResourceOverrideStatus(tc)
+	g.Expect(tc.Status.Overrides[0].Phase).To(Equal(v1alpha1.ResourceOverridePhaseApplied))
+	g.Expect(tc.Status.Overrides[2].Phase).To(Equal(v1alpha1.ResourceOverridePhasePending))
+	tc.Spec.Overrides = nil
+	SyncResourceOverrideStatus(tc)
+	g.Expect(tc.Status.Overrides).To(BeNil())
+}
+
+func TestOverrideService(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbClusterForPD()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pd-peer", Namespace: tc.Namespace},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  label.New().Instance(tc.Name).PD().Labels(),
+		},
+	}
+	tc.Spec.Overrides = []v1alpha1.ResourceOverride{
+		{
+			Kind:  v1alpha1.ResourceOverrideKindService,
+			Name:  "test-pd-peer",
+			Patch: "metadata:\n  annotations:\n    example.com/scrape: \"true\"\n",
+		},
+		{
+			Kind:  v1alpha1.ResourceOverrideKindService,
+			Name:  "test-pd-peer",
+			Patch: "spec:\n  selector:\n    app.kubernetes.io/component: tikv\n",
+		},
+	}
+
+	patched := overrideService(tc, recorder, svc)
+	g.Expect(patched.Annotations).To(HaveKeyWithValue("example.com/scrape", "true"))
+	g.Expect(patched.Spec.Selector).To(Equal(svc.Spec.Selector))
+	g.Expect(tc.Status.Overrides[0].Phase).To(Equal(v1alpha1.ResourceOverridePhaseApplied))
+	g.Expect(tc.Status.Overrides[1].Phase).To(Equal(v1alpha1.ResourceOverridePhaseConflict))
+	g.Expect(tc.Status.Overrides[1].Message).To(ContainSubstring("spec.selector"))
+}
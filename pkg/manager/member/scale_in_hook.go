@@ -0,0 +1,197 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+const (
+	// defaultScaleInHookTimeout is the default timeout of the HTTP callback of the scale-in hook
+	defaultScaleInHookTimeout = 10 * time.Second
+	// scaleInHookLabelVal is the component label value of the Jobs of the scale-in hook
+	scaleInHookLabelVal = "scale-in-hook"
+)
+
+// scaleInHookRequest is the payload posted to the HTTP callback of the scale-in hook
+type scaleInHookRequest struct {
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster"`
+	Component string `json:"component"`
+	Pod       string `json:"pod"`
+	StoreID   string `json:"storeID,omitempty"`
+}
+
+// scaleInHookJobName returns the name of the Job of the scale-in hook for the pod
+func scaleInHookJobName(podName string) string {
+	return fmt.Sprintf("%s-%s", podName, scaleInHookLabelVal)
+}
+
+// runScaleInHook runs the scale-in hook for the pod to be scaled in. It returns nil if the hook is not set or
+// the check passes, otherwise a requeue error is returned so the scale-in is retried in the next round.
+func runScaleInHook(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, hook *v1alpha1.ScaleInHook,
+	memberType v1alpha1.MemberType, pod *corev1.Pod, storeID string) error {
+	if hook == nil {
+		return nil
+	}
+	req := &scaleInHookRequest{
+		Namespace: tc.GetNamespace(),
+		Cluster:   tc.GetName(),
+		Component: memberType.String(),
+		Pod:       pod.GetName(),
+		StoreID:   storeID,
+	}
+	switch {
+	case hook.HTTP != nil:
+		return runScaleInHTTPHook(deps, tc, hook.HTTP, req)
+	case hook.Job != nil:
+		return runScaleInJobHook(deps, tc, hook.Job, pod, req)
+	}
+	return nil
+}
+
+func runScaleInHTTPHook(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, hook *v1alpha1.ScaleInHTTPHook, req *scaleInHookRequest) error {
+	timeout := defaultScaleInHookTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("scale-in hook of %s/%s: invalid request to %s: %v", req.Namespace, req.Pod, hook.URL, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return controller.RequeueErrorf("scale-in hook of %s/%s: failed to call %s: %v", req.Namespace, req.Pod, hook.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		reason := fmt.Sprintf("scale-in of %s is rejected by %s with status %d: %s", req.Pod, hook.URL, resp.StatusCode, bytes.TrimSpace(msg))
+		deps.Recorder.Event(tc, corev1.EventTypeWarning, "ScaleInHookRejected", reason)
+		return controller.RequeueErrorf("scale-in hook of %s/%s: %s", req.Namespace, req.Pod, reason)
+	}
+	klog.Infof("scale-in hook of %s/%s: passed by %s", req.Namespace, req.Pod, hook.URL)
+	return nil
+}
+
+// runScaleInJobHook creates the Job of the scale-in hook for the pod and waits for it to finish. The Job is owned by the
+// pod, so it is removed together with the pod once the scale-in is done.
+func runScaleInJobHook(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, hook *v1alpha1.ScaleInJobHook, pod *corev1.Pod, req *scaleInHookRequest) error {
+	ns := pod.GetNamespace()
+	jobName := scaleInHookJobName(pod.GetName())
+
+	job, err := deps.JobLister.Jobs(ns).Get(jobName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("scale-in hook of %s/%s: failed to get job %s: %v", ns, pod.GetName(), jobName, err)
+	}
+	if errors.IsNotFound(err) {
+		if err := deps.JobControl.CreateJob(tc, newScaleInHookJob(tc, hook, pod, req)); err != nil {
+			return fmt.Errorf("scale-in hook of %s/%s: failed to create job %s: %v", ns, pod.GetName(), jobName, err)
+		}
+		return controller.RequeueErrorf("scale-in hook of %s/%s: job %s is created, wait for it to finish", ns, pod.GetName(), jobName)
+	}
+
+	// the job is left by a former pod with the same name
+	if !metav1.IsControlledBy(job, pod) {
+		if err := deps.JobControl.DeleteJob(tc, job); err != nil {
+			return fmt.Errorf("scale-in hook of %s/%s: failed to delete stale job %s: %v", ns, pod.GetName(), jobName, err)
+		}
+		return controller.RequeueErrorf("scale-in hook of %s/%s: stale job %s is deleted", ns, pod.GetName(), jobName)
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			klog.Infof("scale-in hook of %s/%s: passed by job %s", ns, pod.GetName(), jobName)
+			return nil
+		case batchv1.JobFailed:
+			reason := fmt.Sprintf("scale-in of %s is rejected by job %s: %s, delete the job to run the check again", pod.GetName(), jobName, c.Message)
+			deps.Recorder.Event(tc, corev1.EventTypeWarning, "ScaleInHookRejected", reason)
+			return controller.RequeueErrorf("scale-in hook of %s/%s: %s", ns, pod.GetName(), reason)
+		}
+	}
+	return controller.RequeueErrorf("scale-in hook of %s/%s: job %s is running", ns, pod.GetName(), jobName)
+}
+
+func newScaleInHookJob(tc *v1alpha1.TidbCluster, hook *v1alpha1.ScaleInJobHook, pod *corev1.Pod, req *scaleInHookRequest) *batchv1.Job {
+	jobLabels := label.New().Instance(tc.GetInstanceName()).Component(scaleInHookLabelVal)
+	template := hook.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	for k, v := range jobLabels {
+		template.Labels[k] = v
+	}
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	env := []corev1.EnvVar{
+		{Name: "SCALE_IN_NAMESPACE", Value: req.Namespace},
+		{Name: "SCALE_IN_CLUSTER", Value: req.Cluster},
+		{Name: "SCALE_IN_COMPONENT", Value: req.Component},
+		{Name: "SCALE_IN_POD", Value: req.Pod},
+		{Name: "SCALE_IN_STORE_ID", Value: req.StoreID},
+	}
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, env...)
+	}
+
+	// the deletion of the pod is not blocked by the Job
+	ownerRef := metav1.NewControllerRef(pod, corev1.SchemeGroupVersion.WithKind("Pod"))
+	ownerRef.BlockOwnerDeletion = pointer.BoolPtr(false)
+	backoffLimit := pointer.Int32Ptr(0)
+	if hook.BackoffLimit != nil {
+		backoffLimit = hook.BackoffLimit
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            scaleInHookJobName(pod.GetName()),
+			Namespace:       pod.GetNamespace(),
+			Labels:          jobLabels.Copy(),
+			OwnerReferences: []metav1.OwnerReference{*ownerRef},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: backoffLimit,
+			Template:     *template,
+		},
+	}
+}
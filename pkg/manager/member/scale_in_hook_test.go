@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestRunScaleInHook(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForTiKV()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: "test-tikv-2", UID: types.UID("pod-uid")},
+	}
+
+	// no hook
+	g.Expect(runScaleInHook(deps, tc, nil, v1alpha1.TiKVMemberType, pod, "3")).To(Succeed())
+
+	// HTTP callback
+	var got scaleInHookRequest
+	allow := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+		if !allow {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte("not enough capacity"))
+		}
+	}))
+	defer server.Close()
+	hook := &v1alpha1.ScaleInHook{HTTP: &v1alpha1.ScaleInHTTPHook{URL: server.URL}}
+	err := runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("not enough capacity"))
+	g.Expect(got).To(Equal(scaleInHookRequest{Namespace: tc.Namespace, Cluster: tc.Name, Component: "tikv", Pod: "test-tikv-2", StoreID: "3"}))
+	allow = true
+	g.Expect(runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")).To(Succeed())
+
+	// Job
+	hook = &v1alpha1.ScaleInHook{Job: &v1alpha1.ScaleInJobHook{
+		Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "check", Image: "check"}}}},
+	}}
+	err = runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	job, err := deps.JobLister.Jobs(tc.Namespace).Get("test-tikv-2-scale-in-hook")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(metav1.IsControlledBy(job, pod)).To(BeTrue())
+	g.Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "SCALE_IN_STORE_ID", Value: "3"}))
+
+	// the job is running
+	err = runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	// the job is failed
+	job = job.DeepCopy()
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	g.Expect(deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer().Update(job)).To(Succeed())
+	err = runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("rejected by job"))
+
+	// the job is completed
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	g.Expect(deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer().Update(job)).To(Succeed())
+	g.Expect(runScaleInHook(deps, tc, hook, v1alpha1.TiKVMemberType, pod, "3")).To(Succeed())
+}
@@ -96,3 +96,12 @@ func RenderTiProxyStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 		return "", ErrVersionNotFound
 	}
 }
+
+func RenderPDMSStartScript(tc *v1alpha1.TidbCluster, name string) (string, error) {
+	switch tc.StartScriptVersion() {
+	case v1alpha1.StartScriptV1, v1alpha1.StartScriptV2:
+		return v2.RenderPDMSStartScript(tc, name)
+	default:
+		return "", ErrVersionNotFound
+	}
+}
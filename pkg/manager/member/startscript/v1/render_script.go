@@ -63,8 +63,9 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 			AcrossK8s:     tc.AcrossK8s(),
 			ClusterDomain: tc.Spec.ClusterDomain,
 		},
-		Scheme:  tc.Scheme(),
-		DataDir: filepath.Join(constants.PDDataVolumeMountPath, tc.Spec.PD.DataSubDir),
+		Scheme:   tc.Scheme(),
+		DataDir:  filepath.Join(constants.PDDataVolumeMountPath, tc.Spec.PD.DataSubDir),
+		PDMSMode: tc.PDMSEnabled(),
 	}
 	if tc.Spec.PD.StartUpScriptVersion == "v1" {
 		model.CheckDomainScript = checkDNSV1
@@ -73,7 +74,7 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 }
 
 func RenderTiDBStartScript(tc *v1alpha1.TidbCluster) (string, error) {
-	plugins := tc.Spec.TiDB.Plugins
+	plugins := tc.Spec.TiDB.GetPluginLoadList()
 	model := &TidbStartScriptModel{
 		CommonModel: CommonModel{
 			AcrossK8s:     tc.AcrossK8s(),
@@ -198,6 +198,9 @@ sleep $((RANDOM % 5))
 done
 ARGS="${ARGS}${result}"
 fi
+{{- if .PDMSMode }}
+ARGS="services api ${ARGS}"
+{{- end }}
 
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
@@ -229,6 +232,8 @@ type PDStartScriptModel struct {
 	Scheme            string
 	DataDir           string
 	CheckDomainScript string
+	// PDMSMode starts PD as the API service of the PD microservices
+	PDMSMode bool
 }
 
 var tikvStartScriptTpl = template.Must(template.New("tikv-start-script").Parse(`#!/bin/sh
@@ -356,6 +361,9 @@ func (pssm *PumpStartScriptModel) FormatPumpZone() string {
 
 // tidbInitStartScriptTpl is the template string of tidb initializer start script
 var tidbInitStartScriptTpl = template.Must(template.New("tidb-init-start-script").Parse(`import os, sys, time, MySQLdb
+{{- if .SqlSources }}
+import json
+{{- end }}
 host = '{{ .ClusterName }}-tidb'
 permit_host = '{{ .PermitHost }}'
 port = {{ .TiDBServicePort }}
@@ -398,6 +406,43 @@ with open('/data/init.sql', 'r') as sql:
         conn.cursor().execute(line)
         conn.commit()
 {{- end }}
+{{- if .SqlSources }}
+sql_sources = [
+{{- range .SqlSources }}
+    {'path': '{{ .Path }}', 'retries': {{ .Retries }}, 'abort': {{ if .Abort }}True{{ else }}False{{ end }}},
+{{- end }}
+]
+# the progress of sql sources is reported by the termination message and recorded in the status of TidbInitializer
+progress = []
+def report_progress():
+    with open('/dev/termination-log', 'w') as f:
+        json.dump(progress, f)
+for source in sql_sources:
+    status = {'phase': 'Running', 'executedStatements': 0, 'failedStatements': 0}
+    progress.append(status)
+    with open(source['path'], 'r') as sql:
+        lines = [line for line in sql.read().splitlines() if line.strip()]
+    for line in lines:
+        for attempt in range(0, source['retries'] + 1):
+            try:
+                conn.cursor().execute(line)
+                conn.commit()
+                status['executedStatements'] += 1
+                break
+            except MySQLdb.Error as e:
+                print(e)
+                status['message'] = str(e)[:128]
+                if attempt < source['retries']:
+                    time.sleep(1)
+        else:
+            status['failedStatements'] += 1
+            if source['abort']:
+                status['phase'] = 'Failed'
+                report_progress()
+                sys.exit(1)
+    status['phase'] = 'CompletedWithErrors' if status['failedStatements'] > 0 else 'Completed'
+    report_progress()
+{{- end }}
 if permit_host != '%%':
     conn.cursor().execute("update mysql.user set Host=%s where User='root';", (permit_host,))
 conn.cursor().execute("flush privileges;")
@@ -416,6 +461,14 @@ type TiDBInitStartScriptModel struct {
 	CertPath        string
 	KeyPath         string
 	TiDBServicePort int32
+	SqlSources      []TiDBInitSqlSourceModel
+}
+
+// TiDBInitSqlSourceModel is a SQL source executed by the tidb initializer
+type TiDBInitSqlSourceModel struct {
+	Path    string
+	Retries int32
+	Abort   bool
 }
 
 func RenderTiDBInitStartScript(model *TiDBInitStartScriptModel) (string, error) {
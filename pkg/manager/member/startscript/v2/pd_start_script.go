@@ -34,6 +34,8 @@ type PDStartScriptModel struct {
 	AdvertiseClientURL string
 	DiscoveryAddr      string
 	ExtraArgs          string
+	// PDMSMode starts PD as the API service of the PD microservices
+	PDMSMode bool
 }
 
 // RenderPDStartScript renders PD start script from TidbCluster
@@ -65,6 +67,8 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 
 	m.DiscoveryAddr = fmt.Sprintf("%s-discovery.%s:10261", tcName, tcNS)
 
+	m.PDMSMode = tc.PDMSEnabled()
+
 	return renderTemplateFunc(pdStartScriptTpl, m)
 }
 
@@ -131,6 +135,19 @@ elif [[ ! -d {{ .DataDir }}/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d {{ .DataDir }}/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://{{ .DiscoveryAddr }}/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+{{- if .PDMSMode }}
+ARGS="services api ${ARGS}"
+{{- end }}
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
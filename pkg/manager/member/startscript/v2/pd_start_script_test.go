@@ -108,6 +108,16 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -192,6 +202,16 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -276,6 +296,16 @@ elif [[ ! -d /var/lib/pd/pd-data/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/pd-data/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -360,6 +390,16 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -445,6 +485,111 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
+echo "starting pd-server ..."
+sleep $((RANDOM % 10))
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`,
+		},
+		{
+			name: "pd microservice mode",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Mode = v1alpha1.PDModeMicroservice
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+PD_DOMAIN=${PD_POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc
+
+elapseTime=0
+period=1
+threshold=30
+while true; do
+    sleep ${period}
+    elapseTime=$(( elapseTime+period ))
+
+    if [[ ${elapseTime} -ge ${threshold} ]]; then
+        echo "waiting for pd cluster ready timeout" >&2
+        exit 1
+    fi
+
+    digRes=$(dig ${PD_DOMAIN} A ${PD_DOMAIN} AAAA +search +short)
+    if [ $? -ne 0  ]; then
+        echo "domain resolve ${PD_DOMAIN} failed"
+        echo "$digRes"
+        continue
+    fi
+
+    if [ -z "${digRes}" ]
+    then
+        echo "domain resolve ${PD_DOMAIN} no record return"
+    else
+        echo "domain resolve ${PD_DOMAIN} success"
+        echo "$digRes"
+        break
+    fi
+done
+
+ARGS="--data-dir=/var/lib/pd \
+--name=${PD_POD_NAME} \
+--peer-urls=http://0.0.0.0:2380 \
+--advertise-peer-urls=http://${PD_DOMAIN}:2380 \
+--client-urls=http://0.0.0.0:2379 \
+--advertise-client-urls=http://${PD_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+if [[ -f /var/lib/pd/join ]]; then
+    join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
+    join=${join%,}
+    ARGS="${ARGS} --join=${join}"
+elif [[ ! -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    until result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/new/${encoded_domain_url} 2>/dev/null); do
+        echo "waiting for discovery service to return start args ..."
+        sleep $((RANDOM % 5))
+    done
+    ARGS="${ARGS} ${result}"
+fi
+
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+ARGS="services api ${ARGS}"
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -530,6 +675,16 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+if [[ -d /var/lib/pd/member/wal ]]; then
+    encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
+
+    # the discovery service returns --force-new-cluster for the survivor when PD is recovered from majority loss
+    if result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/recover/${encoded_domain_url} 2>/dev/null) && [[ -n "${result}" ]]; then
+        echo "recovering pd cluster with args ${result} ..."
+        ARGS="${ARGS} ${result}"
+    fi
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
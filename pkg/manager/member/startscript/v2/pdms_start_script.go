@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+// PDMSStartScriptModel contain fields for rendering the start script of PD microservice
+type PDMSStartScriptModel struct {
+	PDMSName            string
+	PDMSDomain          string
+	ListenAddr          string
+	AdvertiseListenAddr string
+	DiscoveryAddr       string
+}
+
+// RenderPDMSStartScript renders the start script of the PD microservice from TidbCluster
+func RenderPDMSStartScript(tc *v1alpha1.TidbCluster, name string) (string, error) {
+	m := &PDMSStartScriptModel{}
+	tcName := tc.Name
+	tcNS := tc.Namespace
+	peerServiceName := controller.PDMSPeerMemberName(tcName, name)
+
+	m.PDMSName = name
+
+	m.PDMSDomain = fmt.Sprintf("${PDMS_POD_NAME}.%s.%s.svc", peerServiceName, tcNS)
+	if tc.Spec.ClusterDomain != "" {
+		m.PDMSDomain = m.PDMSDomain + "." + tc.Spec.ClusterDomain
+	}
+
+	m.ListenAddr = fmt.Sprintf("%s://0.0.0.0:2379", tc.Scheme())
+
+	m.AdvertiseListenAddr = fmt.Sprintf("%s://${PDMS_DOMAIN}:2379", tc.Scheme())
+
+	m.DiscoveryAddr = fmt.Sprintf("%s-discovery.%s:10261", tcName, tcNS)
+
+	return renderTemplateFunc(pdmsStartScriptTpl, m)
+}
+
+const (
+	// pdmsStartScript is the template of start script of PD microservice.
+	pdmsStartScript = `
+PDMS_POD_NAME=${POD_NAME:-$HOSTNAME}
+PDMS_DOMAIN={{ .PDMSDomain }}
+
+ARGS="services {{ .PDMSName }} \
+--listen-addr={{ .ListenAddr }} \
+--advertise-listen-addr={{ .AdvertiseListenAddr }} \
+--config=/etc/pd/pd.toml"
+
+encoded_domain_url=$(echo ${PDMS_DOMAIN}:2379 | base64 | tr "\n" " " | sed "s/ //g")
+
+until result=$(wget -qO- -T 3 http://{{ .DiscoveryAddr }}/new/${encoded_domain_url}/pdms 2>/dev/null); do
+    echo "waiting for discovery service to return backend endpoints ..."
+    sleep $((RANDOM % 5))
+done
+ARGS="${ARGS} ${result}"
+
+echo "starting pd-server ..."
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`
+)
+
+var pdmsStartScriptTpl = template.Must(template.New("pdms-start-script").Parse(componentCommonScript + pdmsStartScript))
@@ -0,0 +1,82 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestRenderPDMSStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{Mode: v1alpha1.PDModeMicroservice},
+			PDMS: []*v1alpha1.PDMSSpec{{Name: "tso", Replicas: 2}},
+		},
+	}
+	tc.Name = "start-script-test"
+	tc.Namespace = "start-script-test-ns"
+
+	expectScript := `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PDMS_POD_NAME=${POD_NAME:-$HOSTNAME}
+PDMS_DOMAIN=${PDMS_POD_NAME}.start-script-test-tso-peer.start-script-test-ns.svc
+
+ARGS="services tso \
+--listen-addr=http://0.0.0.0:2379 \
+--advertise-listen-addr=http://${PDMS_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+encoded_domain_url=$(echo ${PDMS_DOMAIN}:2379 | base64 | tr "\n" " " | sed "s/ //g")
+
+until result=$(wget -qO- -T 3 http://start-script-test-discovery.start-script-test-ns:10261/new/${encoded_domain_url}/pdms 2>/dev/null); do
+    echo "waiting for discovery service to return backend endpoints ..."
+    sleep $((RANDOM % 5))
+done
+ARGS="${ARGS} ${result}"
+
+echo "starting pd-server ..."
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`
+
+	script, err := RenderPDMSStartScript(tc, "tso")
+	g.Expect(err).Should(gomega.Succeed())
+	if diff := cmp.Diff(expectScript, script); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+	g.Expect(validateScript(script)).Should(gomega.Succeed())
+}
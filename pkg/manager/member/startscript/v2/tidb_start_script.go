@@ -58,7 +58,7 @@ func RenderTiDBStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	if tc.IsTiDBBinlogEnabled() {
 		extraArgs = append(extraArgs, "--enable-binlog=true")
 	}
-	if plugins := tc.Spec.TiDB.Plugins; len(plugins) > 0 {
+	if plugins := tc.Spec.TiDB.GetPluginLoadList(); len(plugins) > 0 {
 		extraArgs = append(extraArgs, "--plugin-dir=/plugins")
 		extraArgs = append(extraArgs, fmt.Sprintf("--plugin-load=%s", strings.Join(plugins, ",")))
 	}
@@ -177,7 +177,9 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	oldSts := oldStsTmp.DeepCopy()
 
 	// failed to sync ticdc status will not affect subsequent logic, just print the errors.
-	if err := m.syncTiCDCStatus(tc, oldSts); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiCDCMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTiCDCStatus(tc, oldSts)
+	}); err != nil {
 		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s ticdc status, error: %v",
 			ns, tcName, err)
 	}
@@ -196,6 +198,9 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSts.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	keepTerminationGracePeriodSeconds(tc.BaseTiCDCSpec(), newSts, oldSts)
+	newSts = overrideStatefulSet(tc, m.deps.Recorder, newSts)
 
 	if stsNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
@@ -214,12 +219,16 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldSts, newSts); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiCDCMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldSts, newSts)
+	}); err != nil {
 		return err
 	}
 
 	if !templateEqual(newSts, oldSts) || tc.Status.TiCDC.Phase == v1alpha1.UpgradePhase {
-		if err := m.ticdcUpgrader.Upgrade(tc, oldSts, newSts); err != nil {
+		if err := ObserveReconcilePhase(tc, string(v1alpha1.TiCDCMemberType), ReconcilePhaseUpgrade, func() error {
+			return m.ticdcUpgrader.Upgrade(tc, oldSts, newSts)
+		}); err != nil {
 			return err
 		}
 	}
@@ -298,6 +307,7 @@ func (m *ticdcMemberManager) syncCDCHeadlessService(tc *v1alpha1.TidbCluster) er
 	tcName := tc.GetName()
 
 	newSvc := getNewCDCHeadlessService(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.TiCDCPeerMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -375,7 +385,7 @@ func getNewTiCDCStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*ap
 	stsLabels := labelTiCDC(tc)
 	stsName := controller.TiCDCMemberName(tcName)
 	podLabels := util.CombineStringMap(stsLabels, baseTiCDCSpec.Labels())
-	podAnnotations := util.CombineStringMap(baseTiCDCSpec.Annotations(), controller.AnnProm(8301, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiCDCSpec.Annotations(), controller.AnnProm(8301, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.TiCDCMemberType))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiCDCLabelVal)
 	headlessSvcName := controller.TiCDCPeerMemberName(tcName)
 
@@ -485,6 +495,7 @@ func getNewTiCDCStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*ap
 
 	podSpec := baseTiCDCSpec.BuildPodSpec()
 
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation([]corev1.Container{ticdcContainer}, baseTiCDCSpec.NativeSidecarContainers()))
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{ticdcContainer}, baseTiCDCSpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for TiCDC of [%s/%s], error: %v", ns, tcName, err)
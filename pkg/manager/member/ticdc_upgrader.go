@@ -47,6 +47,7 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	waitForUpgradeDependencies(tc, v1alpha1.TiCDCMemberType)
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase || tc.Status.PD.Phase == v1alpha1.ScalePhase ||
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase || tc.Status.TiKV.Phase == v1alpha1.ScalePhase ||
 		tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase || tc.Status.TiFlash.Phase == v1alpha1.ScalePhase ||
@@ -98,6 +99,10 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == tc.Status.TiCDC.StatefulSet.UpdateRevision {
 			if !podutil.IsPodReady(pod) {
@@ -0,0 +1,170 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	// nolint: gosec
+	// tidbAuthTokenSigningKey is the key of the PEM encoded private key in the generated JWKS Secret,
+	// applications sign their JWTs with it.
+	tidbAuthTokenSigningKey = "signing_key.pem"
+	// nolint: gosec
+	// tidbAuthTokenSigningKeyID is the key of the `kid` of the signing key in the generated JWKS Secret.
+	tidbAuthTokenSigningKeyID = "signing_key_id"
+	// annoKeyTiDBAuthTokenRotatedAt records when the signing key in the generated JWKS Secret was rotated.
+	annoKeyTiDBAuthTokenRotatedAt = "tidb.pingcap.com/tidb-auth-token-rotated-at"
+
+	tidbAuthTokenKeySize = 2048
+)
+
+// jsonWebKey is a RSA public key in the JWK format, see RFC 7517.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// syncAuthTokenJWKS generates the JWKS Secret of `tidb_auth_token` if it does not exist,
+// and rotates the signing key in it once the rotation interval is passed.
+// A JWKS Secret which is not created by the operator is left as it is.
+func (m *tidbMemberManager) syncAuthTokenJWKS(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	name := util.TiDBAuthTokenJWKSSecretName(tc.GetName())
+
+	existing := &corev1.Secret{}
+	exist, err := m.deps.TypedControl.Exist(client.ObjectKey{Namespace: ns, Name: name}, existing)
+	if err != nil {
+		return fmt.Errorf("syncAuthTokenJWKS: failed to get secret %s/%s, error: %v", ns, name, err)
+	}
+
+	var previous *rsa.PrivateKey
+	if exist {
+		if !metav1.IsControlledBy(existing, tc) {
+			klog.V(4).Infof("syncAuthTokenJWKS: secret %s/%s is not created by tc %s, skip rotating", ns, name, tc.GetName())
+			return nil
+		}
+		key, err := parseAuthTokenSigningKey(existing.Data[tidbAuthTokenSigningKey])
+		if err != nil {
+			klog.Warningf("syncAuthTokenJWKS: failed to parse the signing key in secret %s/%s, regenerate it, error: %v", ns, name, err)
+		} else {
+			rotatedAt, err := time.Parse(time.RFC3339, existing.Annotations[annoKeyTiDBAuthTokenRotatedAt])
+			if err == nil && time.Since(rotatedAt) < tc.Spec.TiDB.GetTokenBasedAuthRotationInterval() {
+				return nil
+			}
+			previous = key
+		}
+	}
+
+	secret, err := newAuthTokenJWKSSecret(tc, previous, time.Now())
+	if err != nil {
+		return fmt.Errorf("syncAuthTokenJWKS: failed to generate secret %s/%s, error: %v", ns, name, err)
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateSecret(tc, secret); err != nil {
+		return fmt.Errorf("syncAuthTokenJWKS: failed to create or update secret %s/%s, error: %v", ns, name, err)
+	}
+	klog.Infof("syncAuthTokenJWKS: the signing key of tidb_auth_token in secret %s/%s is rotated, kid: %s", ns, name, secret.Data[tidbAuthTokenSigningKeyID])
+	return nil
+}
+
+// newAuthTokenJWKSSecret returns the JWKS Secret with a newly generated signing key.
+// The public key of the previous signing key is kept in the JWKS, so the tokens signed
+// before the rotation can still be verified by TiDB.
+func newAuthTokenJWKSSecret(tc *v1alpha1.TidbCluster, previous *rsa.PrivateKey, now time.Time) (*corev1.Secret, error) {
+	key, err := rsa.GenerateKey(rand.Reader, tidbAuthTokenKeySize)
+	if err != nil {
+		return nil, err
+	}
+	current := newJSONWebKey(&key.PublicKey)
+	set := jsonWebKeySet{Keys: []jsonWebKey{current}}
+	if previous != nil {
+		set.Keys = append(set.Keys, newJSONWebKey(&previous.PublicKey))
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.TiDBAuthTokenJWKSSecretName(tc.GetName()),
+			Namespace: tc.GetNamespace(),
+			Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().Labels(),
+			Annotations: map[string]string{
+				annoKeyTiDBAuthTokenRotatedAt: now.UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			tidbAuthTokenJWKS:         data,
+			tidbAuthTokenSigningKeyID: []byte(current.Kid),
+			tidbAuthTokenSigningKey: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(key),
+			}),
+		},
+	}, nil
+}
+
+// newJSONWebKey returns the JWK of the public key, whose `kid` is the JWK thumbprint defined in RFC 7638.
+func newJSONWebKey(pub *rsa.PublicKey) jsonWebKey {
+	n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	thumbprint := sha256.Sum256([]byte(fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n)))
+	return jsonWebKey{
+		Kty: "RSA",
+		Alg: "RS256",
+		Use: "sig",
+		Kid: base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+		N:   n,
+		E:   e,
+	}
+}
+
+func parseAuthTokenSigningKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data is found")
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
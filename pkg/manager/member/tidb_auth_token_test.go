@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestTiDBMemberManagerSyncAuthTokenJWKS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
+	m := &tidbMemberManager{deps: fakeDeps}
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiDB.TokenBasedAuth = &v1alpha1.TiDBTokenBasedAuth{
+		RotationInterval: &metav1.Duration{Duration: time.Hour},
+	}
+	getSecret := func() (*corev1.Secret, jsonWebKeySet) {
+		secret := &corev1.Secret{}
+		err := ctrl.FakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: "test-tidb-auth-token-jwks-secret"}, secret)
+		g.Expect(err).To(Succeed())
+		set := jsonWebKeySet{}
+		g.Expect(json.Unmarshal(secret.Data[tidbAuthTokenJWKS], &set)).To(Succeed())
+		return secret, set
+	}
+
+	// the JWKS is generated
+	g.Expect(m.syncAuthTokenJWKS(tc)).To(Succeed())
+	secret, set := getSecret()
+	g.Expect(metav1.IsControlledBy(secret, tc)).To(BeTrue())
+	g.Expect(set.Keys).To(HaveLen(1))
+	kid := string(secret.Data[tidbAuthTokenSigningKeyID])
+	g.Expect(set.Keys[0].Kid).To(Equal(kid))
+	key, err := parseAuthTokenSigningKey(secret.Data[tidbAuthTokenSigningKey])
+	g.Expect(err).To(Succeed())
+	g.Expect(newJSONWebKey(&key.PublicKey)).To(Equal(set.Keys[0]))
+
+	// the signing key is not rotated within the rotation interval
+	g.Expect(m.syncAuthTokenJWKS(tc)).To(Succeed())
+	secret, _ = getSecret()
+	g.Expect(string(secret.Data[tidbAuthTokenSigningKeyID])).To(Equal(kid))
+
+	// the signing key is rotated and the previous public key is kept
+	secret.Annotations[annoKeyTiDBAuthTokenRotatedAt] = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	g.Expect(ctrl.FakeCli.Update(context.TODO(), secret)).To(Succeed())
+	g.Expect(m.syncAuthTokenJWKS(tc)).To(Succeed())
+	secret, set = getSecret()
+	g.Expect(string(secret.Data[tidbAuthTokenSigningKeyID])).NotTo(Equal(kid))
+	g.Expect(set.Keys).To(HaveLen(2))
+	g.Expect(set.Keys[0].Kid).To(Equal(string(secret.Data[tidbAuthTokenSigningKeyID])))
+	g.Expect(set.Keys[1].Kid).To(Equal(kid))
+}
+
+func TestGetNewTiDBSetForTidbClusterWithTokenBasedAuth(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiDB.TokenBasedAuth = &v1alpha1.TiDBTokenBasedAuth{}
+	sts, err := getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).To(Succeed())
+
+	var vol *corev1.Volume
+	for i := range sts.Spec.Template.Spec.Volumes {
+		if sts.Spec.Template.Spec.Volumes[i].Name == "tidb-auth-token" {
+			vol = &sts.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	g.Expect(vol).NotTo(BeNil())
+	g.Expect(vol.Secret.SecretName).To(Equal("test-tidb-auth-token-jwks-secret"))
+	// the signing key is not mounted into TiDB
+	g.Expect(vol.Secret.Items).To(Equal([]corev1.KeyToPath{{Key: tidbAuthTokenJWKS, Path: tidbAuthTokenJWKS}}))
+}
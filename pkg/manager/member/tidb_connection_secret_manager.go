@@ -0,0 +1,220 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	// keys of the connection Secret
+	connectionSecretHostKey     = "host"
+	connectionSecretPortKey     = "port"
+	connectionSecretUserKey     = "user"
+	connectionSecretPasswordKey = "password"
+	connectionSecretURIKey      = "uri"
+)
+
+type tidbConnectionSecretManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTiDBConnectionSecretManager returns a manager which publishes a ready-to-use Secret
+// for applications to connect to TiDB.
+func NewTiDBConnectionSecretManager(deps *controller.Dependencies) manager.Manager {
+	return &tidbConnectionSecretManager{
+		deps: deps,
+	}
+}
+
+func (m *tidbConnectionSecretManager) Sync(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+
+	if tc.Spec.TiDB == nil || tc.Spec.TiDB.ConnectionSecret == nil {
+		// the Secret is not required anymore, remove it if it is created by us before
+		return m.deleteStaleSecrets(tc, "")
+	}
+
+	svcName := controller.TiDBMemberName(tc.GetName())
+	svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+	if errors.IsNotFound(err) {
+		// wait for the service to be created by tidb member manager
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("syncTiDBConnectionSecret: failed to get svc %s/%s, error: %v", ns, svcName, err)
+	}
+
+	data, err := m.getConnectionData(tc, svc)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            TiDBConnectionSecretName(tc.GetName(), tc.Spec.TiDB.ConnectionSecret),
+			Namespace:       ns,
+			Labels:          connectionSecretLabel(tc).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateSecret(tc, secret); err != nil {
+		return fmt.Errorf("syncTiDBConnectionSecret: failed to create or update secret %s/%s, error: %v", ns, secret.Name, err)
+	}
+	// the Secret created before is left behind after spec.tidb.connectionSecret.name is changed
+	return m.deleteStaleSecrets(tc, secret.Name)
+}
+
+// deleteStaleSecrets deletes the connection Secrets created by us except the one named keep
+func (m *tidbConnectionSecretManager) deleteStaleSecrets(tc *v1alpha1.TidbCluster, keep string) error {
+	ns := tc.GetNamespace()
+
+	selector, err := connectionSecretLabel(tc).Selector()
+	if err != nil {
+		return fmt.Errorf("syncTiDBConnectionSecret: failed to get selector for tc %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	secrets, err := m.deps.SecretLister.Secrets(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("syncTiDBConnectionSecret: failed to list secrets in namespace %s, error: %v", ns, err)
+	}
+	for _, secret := range secrets {
+		if secret.Name == keep || !metav1.IsControlledBy(secret, tc) {
+			continue
+		}
+		if err := m.deps.TypedControl.Delete(tc, secret.DeepCopy()); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncTiDBConnectionSecret: failed to delete secret %s/%s, error: %v", ns, secret.Name, err)
+		}
+	}
+	return nil
+}
+
+func connectionSecretLabel(tc *v1alpha1.TidbCluster) label.Label {
+	return label.New().Instance(tc.GetInstanceName()).TiDB().UsedByEndUser()
+}
+
+// getConnectionData returns the connection info of TiDB according to the service, TLS secret
+// and the password secret of the TidbInitializer.
+func (m *tidbConnectionSecretManager) getConnectionData(tc *v1alpha1.TidbCluster, svc *corev1.Service) (map[string][]byte, error) {
+	ns := tc.GetNamespace()
+
+	host := fmt.Sprintf("%s.%s.svc", svc.Name, ns)
+	if tc.Spec.ClusterDomain != "" {
+		host = fmt.Sprintf("%s.%s", host, tc.Spec.ClusterDomain)
+	}
+	port := tc.Spec.TiDB.GetServicePort()
+	for _, p := range svc.Spec.Ports {
+		if p.TargetPort.IntValue() == int(v1alpha1.DefaultTiDBServicePort) {
+			port = p.Port
+			break
+		}
+	}
+
+	data := map[string][]byte{
+		connectionSecretHostKey: []byte(host),
+		connectionSecretPortKey: []byte(strconv.Itoa(int(port))),
+	}
+	uri := url.URL{
+		Scheme: "mysql",
+		Host:   net.JoinHostPort(host, strconv.Itoa(int(port))),
+		Path:   "/",
+	}
+
+	if user := tc.Spec.TiDB.ConnectionSecret.User; user != "" {
+		password, err := m.getUserPassword(tc, user)
+		if err != nil {
+			return nil, err
+		}
+		data[connectionSecretUserKey] = []byte(user)
+		data[connectionSecretPasswordKey] = []byte(password)
+		uri.User = url.UserPassword(user, password)
+	}
+	data[connectionSecretURIKey] = []byte(uri.String())
+
+	if tc.Spec.TiDB.IsTLSClientEnabled() {
+		tlsSecretName := util.TiDBClientTLSSecretName(tc.GetName(), nil)
+		tlsSecret, err := m.deps.SecretLister.Secrets(ns).Get(tlsSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("syncTiDBConnectionSecret: failed to get tls secret %s/%s, error: %v", ns, tlsSecretName, err)
+		}
+		data[corev1.ServiceAccountRootCAKey] = tlsSecret.Data[corev1.ServiceAccountRootCAKey]
+		if !tc.Spec.TiDB.TLSClient.DisableClientAuthn {
+			data[corev1.TLSCertKey] = tlsSecret.Data[corev1.TLSCertKey]
+			data[corev1.TLSPrivateKeyKey] = tlsSecret.Data[corev1.TLSPrivateKeyKey]
+		}
+	}
+	return data, nil
+}
+
+// getUserPassword returns the password of the user from the password secret of the TidbInitializer of the cluster
+func (m *tidbConnectionSecretManager) getUserPassword(tc *v1alpha1.TidbCluster, user string) (string, error) {
+	ns := tc.GetNamespace()
+
+	initializers, err := m.deps.TiDBInitializerLister.TidbInitializers(ns).List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf("syncTiDBConnectionSecret: failed to list tidbinitializers in namespace %s, error: %v", ns, err)
+	}
+	for _, ti := range initializers {
+		if ti.Spec.Clusters.Name != tc.GetName() || ti.Spec.PasswordSecret == nil {
+			continue
+		}
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(*ti.Spec.PasswordSecret)
+		if err != nil {
+			return "", fmt.Errorf("syncTiDBConnectionSecret: failed to get password secret %s/%s, error: %v", ns, *ti.Spec.PasswordSecret, err)
+		}
+		if password, ok := secret.Data[user]; ok {
+			return string(password), nil
+		}
+	}
+	return "", fmt.Errorf("syncTiDBConnectionSecret: password of user %s is not found in the tidbinitializers of tc %s/%s", user, ns, tc.GetName())
+}
+
+// TiDBConnectionSecretName returns the name of the Secret which contains the connection info of TiDB
+func TiDBConnectionSecretName(tcName string, spec *v1alpha1.TiDBConnectionSecret) string {
+	if spec != nil && spec.Name != "" {
+		return spec.Name
+	}
+	return fmt.Sprintf("%s-tidb-connection", tcName)
+}
+
+type FakeTiDBConnectionSecretManager struct {
+	err error
+}
+
+func NewFakeTiDBConnectionSecretManager() *FakeTiDBConnectionSecretManager {
+	return &FakeTiDBConnectionSecretManager{}
+}
+
+func (m *FakeTiDBConnectionSecretManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiDBConnectionSecretManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
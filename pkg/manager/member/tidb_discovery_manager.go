@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
 	"github.com/pingcap/tidb-operator/pkg/util"
 )
 
@@ -37,8 +38,12 @@ const (
 	PdTlsCertPath = "/var/lib/pd-tls"
 )
 
+// TidbDiscoveryManager reconciles the discovery service of the tidb cluster and the dm cluster
 type TidbDiscoveryManager interface {
-	Reconcile(obj client.Object) error
+	// Reconcile creates or updates the resources of the discovery service. The result reports the outcome of
+	// each resource reconciled before the error if any, and what the discovery is waiting on before it is
+	// available. The result is nil if the discovery is not required by the cluster.
+	Reconcile(obj client.Object) (*manager.Result, error)
 }
 
 type realTidbDiscoveryManager struct {
@@ -49,21 +54,22 @@ func NewTidbDiscoveryManager(deps *controller.Dependencies) TidbDiscoveryManager
 	return &realTidbDiscoveryManager{deps: deps}
 }
 
-func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
+func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) (*manager.Result, error) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
-		return fmt.Errorf("%T is not a metav1.Object", obj)
+		return nil, fmt.Errorf("%T is not a metav1.Object", obj)
 	}
 
 	var (
 		clusterPolicyRule rbacv1.PolicyRule
 		preferIPv6        bool
+		imagePullSecrets  []corev1.LocalObjectReference
 	)
 	switch cluster := obj.(type) {
 	case *v1alpha1.TidbCluster:
 		// If PD is not specified return
 		if cluster.Spec.PD == nil && !cluster.AcrossK8s() {
-			return nil
+			return nil, nil
 		}
 		clusterPolicyRule = rbacv1.PolicyRule{
 			APIGroups:     []string{v1alpha1.GroupName},
@@ -72,6 +78,7 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 			Verbs:         []string{"get"},
 		}
 		preferIPv6 = cluster.Spec.PreferIPv6
+		imagePullSecrets = cluster.Spec.ImagePullSecrets
 	case *v1alpha1.DMCluster:
 		clusterPolicyRule = rbacv1.PolicyRule{
 			APIGroups:     []string{v1alpha1.GroupName},
@@ -79,14 +86,16 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 			ResourceNames: []string{metaObj.GetName()},
 			Verbs:         []string{"get"},
 		}
+		imagePullSecrets = cluster.Spec.ImagePullSecrets
 	default:
 		klog.Warningf("unsupported type %T for discovery", obj)
-		return nil
+		return nil, nil
 	}
 
+	result := &manager.Result{}
 	meta, _ := getDiscoveryMeta(metaObj, controller.DiscoveryMemberName)
 	// Ensure RBAC
-	_, err := m.deps.TypedControl.CreateOrUpdateRole(obj, &rbacv1.Role{
+	role := &rbacv1.Role{
 		ObjectMeta: meta,
 		Rules: []rbacv1.PolicyRule{
 			clusterPolicyRule,
@@ -96,17 +105,24 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 				Verbs:     []string{"get", "list", "watch"},
 			},
 		},
+	}
+	_, err := m.ensure(result, "Role", role, func() (client.Object, error) {
+		return m.deps.TypedControl.CreateOrUpdateRole(obj, role)
 	})
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery role: %v", err)
+		return result, controller.RequeueErrorf("error creating or updating discovery role: %v", err)
 	}
-	_, err = m.deps.TypedControl.CreateOrUpdateServiceAccount(obj, &corev1.ServiceAccount{
-		ObjectMeta: meta,
+	sa := &corev1.ServiceAccount{
+		ObjectMeta:       meta,
+		ImagePullSecrets: imagePullSecrets,
+	}
+	_, err = m.ensure(result, "ServiceAccount", sa, func() (client.Object, error) {
+		return m.deps.TypedControl.CreateOrUpdateServiceAccount(obj, sa)
 	})
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery serviceaccount: %v", err)
+		return result, controller.RequeueErrorf("error creating or updating discovery serviceaccount: %v", err)
 	}
-	_, err = m.deps.TypedControl.CreateOrUpdateRoleBinding(obj, &rbacv1.RoleBinding{
+	rb := &rbacv1.RoleBinding{
 		ObjectMeta: meta,
 		Subjects: []rbacv1.Subject{{
 			Kind: rbacv1.ServiceAccountKind,
@@ -117,24 +133,69 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 			Name:     meta.Name,
 			APIGroup: rbacv1.GroupName,
 		},
+	}
+	_, err = m.ensure(result, "RoleBinding", rb, func() (client.Object, error) {
+		return m.deps.TypedControl.CreateOrUpdateRoleBinding(obj, rb)
 	})
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery rolebinding: %v", err)
+		return result, controller.RequeueErrorf("error creating or updating discovery rolebinding: %v", err)
 	}
 	d, err := m.getTidbDiscoveryDeployment(metaObj)
 	if err != nil {
-		return controller.RequeueErrorf("error generating discovery deployment: %v", err)
+		result.Add("Deployment", meta.Name, manager.ResourceFailed)
+		return result, controller.RequeueErrorf("error generating discovery deployment: %v", err)
 	}
-	deploy, err := m.deps.TypedControl.CreateOrUpdateDeployment(obj, d)
+	deployObj, err := m.ensure(result, "Deployment", d, func() (client.Object, error) {
+		return m.deps.TypedControl.CreateOrUpdateDeployment(obj, d)
+	})
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+		return result, controller.RequeueErrorf("error creating or updating discovery deployment: %v", err)
+	}
+	deploy := deployObj.(*appsv1.Deployment)
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	if deploy.Status.AvailableReplicas < replicas {
+		result.Wait("Deployment/%s to be available, %d/%d replicas are available", deploy.Name, deploy.Status.AvailableReplicas, replicas)
 	}
 	// RBAC ensured, reconcile
-	_, err = m.deps.TypedControl.CreateOrUpdateService(obj, getTidbDiscoveryService(metaObj, deploy, preferIPv6))
+	svc := getTidbDiscoveryService(metaObj, deploy, preferIPv6)
+	_, err = m.ensure(result, "Service", svc, func() (client.Object, error) {
+		return m.deps.TypedControl.CreateOrUpdateService(obj, svc)
+	})
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+		return result, controller.RequeueErrorf("error creating or updating discovery service: %v", err)
 	}
-	return nil
+	return result, nil
+}
+
+// ensure creates or updates the desired resource by fn and records the outcome in the result. The resource is
+// created if it does not exist before, and updated if its resource version is changed by fn.
+func (m *realTidbDiscoveryManager) ensure(result *manager.Result, kind string, desired client.Object, fn func() (client.Object, error)) (client.Object, error) {
+	existing, err := controller.EmptyClone(desired)
+	if err != nil {
+		result.Add(kind, desired.GetName(), manager.ResourceFailed)
+		return nil, err
+	}
+	exist, err := m.deps.GenericControl.Exist(client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		result.Add(kind, desired.GetName(), manager.ResourceFailed)
+		return nil, err
+	}
+	obj, err := fn()
+	if err != nil {
+		result.Add(kind, desired.GetName(), manager.ResourceFailed)
+		return nil, err
+	}
+	outcome := manager.ResourceUnchanged
+	if !exist {
+		outcome = manager.ResourceCreated
+	} else if obj.GetResourceVersion() != existing.GetResourceVersion() {
+		outcome = manager.ResourceUpdated
+	}
+	result.Add(kind, desired.GetName(), outcome)
+	return obj, nil
 }
 
 func getTidbDiscoveryService(obj metav1.Object, deploy *appsv1.Deployment, preferIPv6 bool) *corev1.Service {
@@ -156,6 +217,12 @@ func getTidbDiscoveryService(obj metav1.Object, deploy *appsv1.Deployment, prefe
 					TargetPort: intstr.FromInt(10262),
 					Protocol:   corev1.ProtocolTCP,
 				},
+				{
+					Name:       "metrics",
+					Port:       10263,
+					TargetPort: intstr.FromInt(10263),
+					Protocol:   corev1.ProtocolTCP,
+				},
 			},
 			Selector: deploy.Spec.Template.Labels,
 		},
@@ -172,6 +239,9 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		timezone  string
 		baseSpec  v1alpha1.ComponentAccessor
 		podSpec   corev1.PodSpec
+		replicas  int32 = 1
+		mirrors   map[string]string
+		proxyEnvs []corev1.EnvVar
 	)
 
 	switch cluster := obj.(type) {
@@ -180,11 +250,37 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		timezone = cluster.Timezone()
 		baseSpec = cluster.BaseDiscoverySpec()
 		podSpec = baseSpec.BuildPodSpec()
+		if cluster.Spec.Discovery.Replicas != nil {
+			replicas = *cluster.Spec.Discovery.Replicas
+		}
+		mirrors = cluster.Spec.RegistryMirrors
+		if cluster.Spec.Discovery.ProxyTiCDCOwner && cluster.Spec.TiCDC != nil {
+			proxyEnvs = append(proxyEnvs, corev1.EnvVar{
+				Name:  "PROXY_TICDC_OWNER",
+				Value: strconv.FormatBool(true),
+			})
+		}
 	case *v1alpha1.DMCluster:
 		resources = cluster.Spec.Discovery.ResourceRequirements
 		timezone = cluster.Timezone()
 		baseSpec = cluster.BaseDiscoverySpec()
 		podSpec = baseSpec.BuildPodSpec()
+		if cluster.Spec.Discovery.Replicas != nil {
+			replicas = *cluster.Spec.Discovery.Replicas
+		}
+		if cluster.Spec.Discovery.ProxyDMMaster {
+			proxyEnvs = append(proxyEnvs, corev1.EnvVar{
+				Name:  "PROXY_DM_MASTER",
+				Value: strconv.FormatBool(true),
+			})
+			// the client certificate of DM is loaded from the secret by discovery
+			if cluster.IsTLSClusterEnabled() {
+				proxyEnvs = append(proxyEnvs, corev1.EnvVar{
+					Name:  "DM_TLS_ENABLED",
+					Value: strconv.FormatBool(true),
+				})
+			}
+		}
 	default:
 		panic(fmt.Sprintf("unsupported type %T for discovery meta", obj))
 	}
@@ -209,6 +305,17 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 			Value: obj.GetName(), // for DmCluster, we still name it as TC_NAME because only ProxyServer use it now.
 		},
 	}
+	// Multiple discovery replicas can not share the in-memory peer records used to
+	// decide which member bootstraps the cluster, so they work in stateless mode.
+	strategy := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	if replicas > 1 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "DISCOVERY_STATELESS",
+			Value: strconv.FormatBool(true),
+		})
+		strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	}
+	envs = append(envs, proxyEnvs...)
 	envs = util.AppendEnv(envs, baseSpec.Env())
 	volMounts := []corev1.VolumeMount{}
 	volMounts = append(volMounts, baseSpec.AdditionalVolumeMounts()...)
@@ -234,6 +341,11 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 				Protocol:      corev1.ProtocolTCP,
 				ContainerPort: 10262,
 			},
+			{
+				Name:          "metrics",
+				Protocol:      corev1.ProtocolTCP,
+				ContainerPort: 10263,
+			},
 		},
 	})
 
@@ -244,6 +356,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 	}
 
 	podSpec.InitContainers = append(podSpec.InitContainers, baseSpec.InitContainers()...)
+	RewritePodImages(&podSpec, m.deps.CLIConfig.RegistryMirrors, mirrors)
 
 	podSpec.ServiceAccountName = meta.Name
 
@@ -269,12 +382,12 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 	}
 
 	podLabels := util.CombineStringMap(l.Labels(), baseSpec.Labels())
-	podAnnotations := baseSpec.Annotations()
+	podAnnotations := util.CombineStringMap(baseSpec.Annotations(), controller.AnnProm(10263, "/metrics"))
 	d := &appsv1.Deployment{
 		ObjectMeta: meta,
 		Spec: appsv1.DeploymentSpec{
-			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
-			Replicas: pointer.Int32Ptr(1),
+			Strategy: strategy,
+			Replicas: pointer.Int32Ptr(replicas),
 			Selector: l.LabelSelector(),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -331,7 +444,8 @@ func getDiscoveryMeta(obj metav1.Object, nameFunc func(string) string) (metav1.O
 }
 
 type FakeDiscoveryManager struct {
-	err error
+	result *manager.Result
+	err    error
 }
 
 func NewFakeDiscoveryManger() *FakeDiscoveryManager {
@@ -342,9 +456,13 @@ func (m *FakeDiscoveryManager) SetReconcileError(err error) {
 	m.err = err
 }
 
-func (m *FakeDiscoveryManager) Reconcile(_ client.Object) error {
+func (m *FakeDiscoveryManager) SetReconcileResult(result *manager.Result) {
+	m.result = result
+}
+
+func (m *FakeDiscoveryManager) Reconcile(_ client.Object) (*manager.Result, error) {
 	if m.err != nil {
-		return m.err
+		return m.result, m.err
 	}
-	return nil
+	return m.result, nil
 }
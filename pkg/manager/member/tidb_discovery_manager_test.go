@@ -21,9 +21,12 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
@@ -45,7 +48,7 @@ func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
 		if tt.errOnCreateOrUpdate {
 			ctrl.SetCreateOrUpdateError(fmt.Errorf("API server down"), 0)
 		}
-		err := dm.Reconcile(tc)
+		_, err := dm.Reconcile(tc)
 		deployList := &appsv1.DeploymentList{}
 		_ = ctrl.FakeCli.List(context.TODO(), deployList)
 		tt.expect(deployList.Items, tc, err)
@@ -96,6 +99,21 @@ func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
 			},
 			errOnCreateOrUpdate: false,
 		},
+		{
+			name: "Multiple discovery replicas",
+			prepare: func(tc *v1alpha1.TidbCluster, ctrl *controller.FakeGenericControl) {
+				tc.Spec.Discovery.Replicas = pointer.Int32Ptr(3)
+			},
+			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				g.Expect(deploys[0].Name).To(Equal("test-discovery"))
+				g.Expect(*deploys[0].Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(deploys[0].Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+				g.Expect(deploys[0].Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "DISCOVERY_STATELESS", Value: "true"}))
+			},
+			errOnCreateOrUpdate: false,
+		},
 		{
 			name: "Create or update resource error",
 			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
@@ -129,7 +147,7 @@ func TestTidbDiscoveryManager_ReconcileDM(t *testing.T) {
 		if tt.errOnCreateOrUpdate {
 			ctrl.SetCreateOrUpdateError(fmt.Errorf("API server down"), 0)
 		}
-		err := dm.Reconcile(dc)
+		_, err := dm.Reconcile(dc)
 		deployList := &appsv1.DeploymentList{}
 		_ = ctrl.FakeCli.List(context.TODO(), deployList)
 		tt.expect(deployList.Items, dc, err)
@@ -180,6 +198,21 @@ func TestTidbDiscoveryManager_ReconcileDM(t *testing.T) {
 			},
 			errOnCreateOrUpdate: false,
 		},
+		{
+			name: "Multiple discovery replicas",
+			prepare: func(dc *v1alpha1.DMCluster, ctrl *controller.FakeGenericControl) {
+				dc.Spec.Discovery.Replicas = pointer.Int32Ptr(3)
+			},
+			expect: func(deploys []appsv1.Deployment, dc *v1alpha1.DMCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				g.Expect(deploys[0].Name).To(Equal("test-dm-discovery"))
+				g.Expect(*deploys[0].Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(deploys[0].Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+				g.Expect(deploys[0].Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "DISCOVERY_STATELESS", Value: "true"}))
+			},
+			errOnCreateOrUpdate: false,
+		},
 		{
 			name: "Create or update resource error",
 			expect: func(deploys []appsv1.Deployment, dc *v1alpha1.DMCluster, err error) {
@@ -194,6 +227,48 @@ func TestTidbDiscoveryManager_ReconcileDM(t *testing.T) {
 	}
 }
 
+func TestTidbDiscoveryManager_ReconcileResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	dm, ctrl := newFakeTidbDiscoveryManager()
+
+	result, err := dm.Reconcile(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.Resources).To(Equal([]manager.ResourceResult{
+		{Kind: "Role", Name: "test-discovery", Outcome: manager.ResourceCreated},
+		{Kind: "ServiceAccount", Name: "test-discovery", Outcome: manager.ResourceCreated},
+		{Kind: "RoleBinding", Name: "test-discovery", Outcome: manager.ResourceCreated},
+		{Kind: "Deployment", Name: "test-discovery", Outcome: manager.ResourceCreated},
+		{Kind: "Service", Name: "test-discovery", Outcome: manager.ResourceCreated},
+	}))
+	// the pods of the deployment are not available yet
+	g.Expect(result.WaitingOn).To(HaveLen(1))
+	g.Expect(result.Ready()).To(BeFalse())
+
+	tc.Spec.Discovery.Replicas = pointer.Int32Ptr(2)
+	result, err = dm.Reconcile(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.Resources[0]).To(Equal(manager.ResourceResult{Kind: "Role", Name: "test-discovery", Outcome: manager.ResourceUnchanged}))
+	g.Expect(result.Resources[3]).To(Equal(manager.ResourceResult{Kind: "Deployment", Name: "test-discovery", Outcome: manager.ResourceUpdated}))
+
+	deploy := &appsv1.Deployment{}
+	g.Expect(ctrl.FakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: "test-discovery"}, deploy)).To(Succeed())
+	deploy.Status.AvailableReplicas = 2
+	g.Expect(ctrl.FakeCli.Status().Update(context.TODO(), deploy)).To(Succeed())
+	result, err = dm.Reconcile(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.Ready()).To(BeTrue())
+
+	// the progress before the failure is reported
+	dm, ctrl = newFakeTidbDiscoveryManager()
+	ctrl.SetCreateOrUpdateError(fmt.Errorf("API server down"), 2)
+	result, err = dm.Reconcile(tc)
+	g.Expect(err).NotTo(Succeed())
+	g.Expect(result.Resources).To(HaveLen(3))
+	g.Expect(*result.Failed()).To(Equal(manager.ResourceResult{Kind: "RoleBinding", Name: "test-discovery", Outcome: manager.ResourceFailed}))
+}
+
 func newFakeTidbDiscoveryManager() (*realTidbDiscoveryManager, *controller.FakeGenericControl) {
 	fakeDeps := controller.NewFakeDependencies()
 	ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
@@ -0,0 +1,141 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+const (
+	// annotations of the service which are watched by external-dns,
+	// see https://github.com/kubernetes-sigs/external-dns/blob/master/docs/annotations/annotations.md
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTTLAnnotation      = "external-dns.alpha.kubernetes.io/ttl"
+
+	dnsLookupTimeout = 5 * time.Second
+)
+
+// setExternalDNSAnnotations translates the external-dns config into the annotations of the service,
+// they take precedence over the annotations in the service spec.
+func setExternalDNSAnnotations(svc *corev1.Service, spec *v1alpha1.ExternalDNS) {
+	if spec == nil {
+		return
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[externalDNSHostnameAnnotation] = strings.Join(spec.Hostnames, ",")
+	if spec.TTL != nil {
+		svc.Annotations[externalDNSTTLAnnotation] = strconv.Itoa(int(*spec.TTL))
+	}
+}
+
+// syncTiDBServiceReadyCondition sets the ServiceReady condition of TiDB if the DNS records of the
+// TiDB service are managed by external-dns.
+func (m *tidbMemberManager) syncTiDBServiceReadyCondition(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiDB.Service == nil || tc.Spec.TiDB.Service.ExternalDNS == nil {
+		removeComponentCondition(&tc.Status.TiDB, v1alpha1.ConditionTypeServiceReady)
+		return nil
+	}
+
+	// the records have been verified, no need to resolve them again until the spec is changed
+	cond := meta.FindStatusCondition(tc.Status.TiDB.Conditions, v1alpha1.ConditionTypeServiceReady)
+	if cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == tc.Generation {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	svcName := controller.TiDBMemberName(tc.GetName())
+	svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncTiDBServiceReadyCondition: failed to get svc %s/%s, error: %v", ns, svcName, err)
+	}
+
+	status, reason, message := metav1.ConditionFalse, "ServiceNotFound", fmt.Sprintf("service %s is not created", svcName)
+	if svc != nil {
+		status, reason, message = m.checkExternalDNSRecords(svc, tc.Spec.TiDB.Service.ExternalDNS)
+	}
+	meta.SetStatusCondition(&tc.Status.TiDB.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeServiceReady,
+		Status:             status,
+		ObservedGeneration: tc.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return nil
+}
+
+// checkExternalDNSRecords checks whether the load balancer of the service is provisioned and the
+// DNS records are resolved to it if verification is required.
+func (m *tidbMemberManager) checkExternalDNSRecords(svc *corev1.Service, spec *v1alpha1.ExternalDNS) (metav1.ConditionStatus, string, string) {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return metav1.ConditionTrue, "ServiceReady", "DNS records are not verified for non-LoadBalancer service"
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return metav1.ConditionFalse, "LoadBalancerNotReady", fmt.Sprintf("load balancer of service %s is not provisioned", svc.Name)
+	}
+	if !spec.VerifyPropagation {
+		return metav1.ConditionTrue, "ServiceReady", "load balancer is provisioned"
+	}
+
+	expected := sets.NewString()
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			expected.Insert(ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			addrs, err := m.lookupHost(ingress.Hostname)
+			if err != nil {
+				return metav1.ConditionFalse, "LoadBalancerNotResolved", fmt.Sprintf("failed to resolve load balancer %s: %v", ingress.Hostname, err)
+			}
+			expected.Insert(addrs...)
+		}
+	}
+
+	for _, hostname := range spec.Hostnames {
+		// the wildcard records can not be resolved directly
+		if strings.HasPrefix(hostname, "*.") {
+			continue
+		}
+		addrs, err := m.lookupHost(hostname)
+		if err != nil {
+			klog.V(4).Infof("failed to resolve %s of service %s/%s, error: %v", hostname, svc.Namespace, svc.Name, err)
+			return metav1.ConditionFalse, "DNSNotPropagated", fmt.Sprintf("DNS record %s is not resolved: %v", hostname, err)
+		}
+		if !expected.HasAny(addrs...) {
+			return metav1.ConditionFalse, "DNSNotPropagated", fmt.Sprintf("DNS record %s is resolved to %v instead of the load balancer", hostname, addrs)
+		}
+	}
+	return metav1.ConditionTrue, "DNSPropagated", "DNS records are resolved to the load balancer"
+}
+
+func (m *tidbMemberManager) lookupHost(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+	return m.lookupHostFn(ctx, host)
+}
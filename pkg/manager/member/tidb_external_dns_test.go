@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestGetNewTiDBServiceWithExternalDNS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+		ServiceSpec: v1alpha1.ServiceSpec{
+			Type:        corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{"foo": "bar", externalDNSTTLAnnotation: "300"},
+		},
+		ExternalDNS: &v1alpha1.ExternalDNS{
+			Hostnames: []string{"tidb.example.com", "db.example.com"},
+			TTL:       pointer.Int32Ptr(60),
+		},
+	}
+
+	svc := getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Annotations).To(Equal(map[string]string{
+		"foo":                         "bar",
+		externalDNSHostnameAnnotation: "tidb.example.com,db.example.com",
+		externalDNSTTLAnnotation:      "60",
+	}))
+	// the annotations in the spec are not modified
+	g.Expect(tc.Spec.TiDB.Service.Annotations).To(HaveLen(2))
+}
+
+func TestSyncTiDBServiceReadyCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+	records := map[string][]string{
+		"lb.elb.amazonaws.com": {"10.0.0.1", "10.0.0.2"},
+	}
+	tmm.lookupHostFn = func(_ context.Context, host string) ([]string, error) {
+		if addrs, ok := records[host]; ok {
+			return addrs, nil
+		}
+		return nil, fmt.Errorf("no such host %s", host)
+	}
+	getCondition := func(tc *v1alpha1.TidbCluster) *metav1.Condition {
+		return meta.FindStatusCondition(tc.Status.TiDB.Conditions, v1alpha1.ConditionTypeServiceReady)
+	}
+
+	tc := newTidbClusterForTiDB()
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc)).To(BeNil())
+
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+		ServiceSpec: v1alpha1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		ExternalDNS: &v1alpha1.ExternalDNS{
+			Hostnames:         []string{"tidb.example.com", "*.tidb.example.com"},
+			VerifyPropagation: true,
+		},
+	}
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(getCondition(tc).Reason).To(Equal("ServiceNotFound"))
+
+	svc := getNewTiDBServiceOrNil(tc)
+	g.Expect(indexers.svc.Add(svc)).To(Succeed())
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Reason).To(Equal("LoadBalancerNotReady"))
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.elb.amazonaws.com"}}
+	g.Expect(indexers.svc.Update(svc)).To(Succeed())
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(getCondition(tc).Reason).To(Equal("DNSNotPropagated"))
+
+	records["tidb.example.com"] = []string{"10.0.0.3"}
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Reason).To(Equal("DNSNotPropagated"))
+
+	records["tidb.example.com"] = []string{"10.0.0.2"}
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(getCondition(tc).Reason).To(Equal("DNSPropagated"))
+
+	// the verified records are not resolved again
+	delete(records, "tidb.example.com")
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc).Status).To(Equal(metav1.ConditionTrue))
+
+	// the condition is removed when external-dns is not used anymore
+	tc.Spec.TiDB.Service.ExternalDNS = nil
+	g.Expect(tmm.syncTiDBServiceReadyCondition(tc)).To(Succeed())
+	g.Expect(getCondition(tc)).To(BeNil())
+}
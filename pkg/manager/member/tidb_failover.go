@@ -15,7 +15,6 @@ package member
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
@@ -65,8 +64,7 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 			continue
 		}
 
-		deadline := tidbMember.LastTransitionTime.Add(f.deps.CLIConfig.TiDBFailoverPeriod)
-		if time.Now().After(deadline) {
+		if failoverDeadlineReached(tc.Spec.TiDB.Failover, f.deps.CLIConfig.TiDBFailoverPeriod, tidbMember.LastTransitionTime, tidbMember.ProbeFailures) {
 			if len(tc.Status.TiDB.FailureMembers) >= int(maxFailoverCount) {
 				klog.Warningf("the failover count reaches the limit (%d), no more failover pods will be created", maxFailoverCount)
 				break
@@ -84,6 +82,10 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 				klog.Warningf("pod %s/%s is not scheduled yet, skipping failover", pod.Namespace, pod.Name)
 				continue
 			}
+			if !podFailureEvidenceFound(tc.Spec.TiDB.Failover, pod) {
+				klog.Infof("tidb failover: pod %s/%s of unhealthy member is running and ready, skipping failover", pod.Namespace, pod.Name)
+				continue
+			}
 
 			tc.Status.TiDB.FailureMembers[tidbMember.Name] = v1alpha1.TiDBFailureMember{
 				PodName:   tidbMember.Name,
@@ -15,6 +15,7 @@ package member
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 
@@ -44,6 +45,8 @@ const (
 	sqlKey              = "init-sql"
 	sqlPath             = "init.sql"
 	sqlDir              = "/data"
+	sqlSourcesKey       = "sql-sources"
+	sqlSourcesDir       = "/etc/tidb/sql-sources"
 	startScriptPath     = "start_script.py"
 	initStartScriptPath = "init_start_script.sh"
 	startScriptDir      = "/usr/local/bin"
@@ -77,6 +80,9 @@ func (m *tidbInitManager) Sync(ti *v1alpha1.TidbInitializer) error {
 		klog.Infof("TidbInitManager.Sync: Spec.TiDB is nil in tidbcluster %s, skip syncing TidbInitializer %s/%s", tcName, ns, ti.Name)
 		return nil
 	}
+	if err := validateSqlSources(ti); err != nil {
+		return fmt.Errorf("TidbInitManager.Sync: invalid sqlSources of TidbInitializer %s/%s, error: %s", ns, ti.Name, err)
+	}
 
 	err = m.syncTiDBInitConfigMap(ti, tc)
 	if err != nil {
@@ -120,6 +126,16 @@ func (m *tidbInitManager) updateStatus(ti *v1alpha1.TidbInitializer) error {
 		ti.Status.Phase = phase
 		update = true
 	}
+	if len(ti.Spec.SqlSources) > 0 && phase != v1alpha1.InitializePhaseRunning {
+		sourcesStatus, err := m.getSqlSourcesStatus(ti)
+		if err != nil {
+			return err
+		}
+		if sourcesStatus != nil && !apiequality.Semantic.DeepEqual(ti.Status.SqlSources, sourcesStatus) {
+			ti.Status.SqlSources = sourcesStatus
+			update = true
+		}
+	}
 	if update {
 		_, err = m.updateInitializer(ti)
 		return err
@@ -127,6 +143,45 @@ func (m *tidbInitManager) updateStatus(ti *v1alpha1.TidbInitializer) error {
 	return nil
 }
 
+// getSqlSourcesStatus returns the progress of the sql sources reported by the termination message
+// of the initializer pod, nil is returned if the progress is not reported.
+func (m *tidbInitManager) getSqlSourcesStatus(ti *v1alpha1.TidbInitializer) ([]v1alpha1.InitSqlSourceStatus, error) {
+	ns := ti.Namespace
+	_, initLabel := getInitMeta(ti)
+	selector, err := initLabel.Selector()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("getSqlSourcesStatus: failed to list pods for TidbInitializer %s/%s, error: %s", ns, ti.Name, err)
+	}
+
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != containerName || cs.State.Terminated == nil || cs.State.Terminated.Message == "" {
+				continue
+			}
+			var progress []v1alpha1.InitSqlSourceStatus
+			if err := json.Unmarshal([]byte(cs.State.Terminated.Message), &progress); err != nil {
+				klog.Warningf("TidbInitializer %s/%s: failed to parse the progress of sqlSources from pod %s, error: %s", ns, ti.Name, pod.Name, err)
+				return nil, nil
+			}
+			// the progress is reported in the order of sqlSources, and sources not executed are not reported
+			var status []v1alpha1.InitSqlSourceStatus
+			for i := range progress {
+				if i >= len(ti.Spec.SqlSources) {
+					break
+				}
+				progress[i].Name = ti.Spec.SqlSources[i].Name
+				status = append(status, progress[i])
+			}
+			return status, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *tidbInitManager) updateInitializer(ti *v1alpha1.TidbInitializer) (*v1alpha1.TidbInitializer, error) {
 	ns := ti.GetNamespace()
 	tiName := ti.GetName()
@@ -344,6 +399,43 @@ func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer) (*batchv
 		})
 	}
 
+	if len(ti.Spec.SqlSources) > 0 {
+		var projections []corev1.VolumeProjection
+		for i, source := range ti.Spec.SqlSources {
+			items := []corev1.KeyToPath{{Path: sqlSourcePath(i)}}
+			if source.ConfigMapKeyRef != nil {
+				items[0].Key = source.ConfigMapKeyRef.Key
+				projections = append(projections, corev1.VolumeProjection{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: source.ConfigMapKeyRef.LocalObjectReference,
+						Items:                items,
+						Optional:             source.ConfigMapKeyRef.Optional,
+					},
+				})
+			} else {
+				items[0].Key = source.SecretKeyRef.Key
+				projections = append(projections, corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: source.SecretKeyRef.LocalObjectReference,
+						Items:                items,
+						Optional:             source.SecretKeyRef.Optional,
+					},
+				})
+			}
+		}
+		vms = append(vms, corev1.VolumeMount{
+			Name: sqlSourcesKey, ReadOnly: true, MountPath: sqlSourcesDir,
+		})
+		vs = append(vs, corev1.Volume{
+			Name: sqlSourcesKey,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: projections,
+				},
+			},
+		})
+	}
+
 	meta, initLabel := getInitMeta(ti)
 
 	podSpec := &corev1.PodTemplateSpec{
@@ -431,6 +523,13 @@ func getTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tlsClientEnabled bool, s
 		PasswordSet:     passwdSet,
 		TiDBServicePort: tidbSvcPort,
 	}
+	for i, source := range ti.Spec.SqlSources {
+		initModel.SqlSources = append(initModel.SqlSources, startscriptv1.TiDBInitSqlSourceModel{
+			Path:    path.Join(sqlSourcesDir, sqlSourcePath(i)),
+			Retries: source.Retries,
+			Abort:   source.OnFailure != v1alpha1.InitSqlFailurePolicyContinue,
+		})
+	}
 	if tlsClientEnabled {
 		initModel.TLS = true
 		initModel.SkipCA = skipCA
@@ -460,6 +559,30 @@ func getTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tlsClientEnabled bool, s
 	return cm, nil
 }
 
+func sqlSourcePath(index int) string {
+	return fmt.Sprintf("%d.sql", index)
+}
+
+func validateSqlSources(ti *v1alpha1.TidbInitializer) error {
+	for i, source := range ti.Spec.SqlSources {
+		if source.Name == "" {
+			return fmt.Errorf("name of sqlSources[%d] is empty", i)
+		}
+		if (source.ConfigMapKeyRef == nil) == (source.SecretKeyRef == nil) {
+			return fmt.Errorf("exactly one of configMapKeyRef and secretKeyRef should be set in sqlSources[%d]", i)
+		}
+		if source.Retries < 0 {
+			return fmt.Errorf("retries of sqlSources[%d] is negative", i)
+		}
+		switch source.OnFailure {
+		case "", v1alpha1.InitSqlFailurePolicyAbort, v1alpha1.InitSqlFailurePolicyContinue:
+		default:
+			return fmt.Errorf("onFailure of sqlSources[%d] is invalid: %s", i, source.OnFailure)
+		}
+	}
+	return nil
+}
+
 func getInitMeta(ti *v1alpha1.TidbInitializer) (metav1.ObjectMeta, label.Label) {
 	name := controller.TiDBInitializerMemberName(ti.Spec.Clusters.Name)
 	initLabel := label.NewInitializer().Instance(ti.Name).Initializer(ti.Name)
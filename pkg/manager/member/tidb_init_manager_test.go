@@ -128,6 +128,83 @@ func TestTiDBInitManagerSync(t *testing.T) {
 	}
 }
 
+func TestTiDBInitManagerSqlSources(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tim, tmm, indexers := newFakeTiDBInitManager()
+	tc := newTidbClusterForTiDB()
+	_, err := tmm.deps.Controls.TiDBClusterControl.UpdateTidbCluster(tc, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ti := newTidbInitializerForTiDB()
+	ti.Spec.SqlSources = []v1alpha1.InitSqlSource{
+		{
+			Name:            "schema",
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "schema"}, Key: "schema.sql"},
+			Retries:         3,
+		},
+		{
+			Name:         "users",
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "users"}, Key: "users.sql"},
+			OnFailure:    v1alpha1.InitSqlFailurePolicyContinue,
+		},
+	}
+	g.Expect(validateSqlSources(ti)).To(Succeed())
+
+	// the sources are mounted in the declared order
+	job, err := tim.makeTiDBInitJob(ti)
+	g.Expect(err).NotTo(HaveOccurred())
+	var projected *corev1.ProjectedVolumeSource
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.Name == sqlSourcesKey {
+			projected = v.Projected
+		}
+	}
+	g.Expect(projected).NotTo(BeNil())
+	g.Expect(projected.Sources).To(HaveLen(2))
+	g.Expect(projected.Sources[0].ConfigMap.Name).To(Equal("schema"))
+	g.Expect(projected.Sources[0].ConfigMap.Items).To(Equal([]corev1.KeyToPath{{Key: "schema.sql", Path: "0.sql"}}))
+	g.Expect(projected.Sources[1].Secret.Name).To(Equal("users"))
+	g.Expect(projected.Sources[1].Secret.Items).To(Equal([]corev1.KeyToPath{{Key: "users.sql", Path: "1.sql"}}))
+
+	cm, err := getTiDBInitConfigMap(ti, false, false, 4000)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[startKey]).To(ContainSubstring("{'path': '/etc/tidb/sql-sources/0.sql', 'retries': 3, 'abort': True},"))
+	g.Expect(cm.Data[startKey]).To(ContainSubstring("{'path': '/etc/tidb/sql-sources/1.sql', 'retries': 0, 'abort': False},"))
+
+	// the progress is not reported yet
+	status, err := tim.getSqlSourcesStatus(ti)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(BeNil())
+
+	meta, _ := getInitMeta(ti)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tidb-initializer-abcde", Namespace: ti.Namespace, Labels: meta.Labels},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: containerName,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  `[{"phase": "Failed", "executedStatements": 2, "failedStatements": 1, "message": "table exists"}]`,
+				}},
+			}},
+		},
+	}
+	g.Expect(indexers.pod.Add(pod)).To(Succeed())
+	status, err = tim.getSqlSourcesStatus(ti)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal([]v1alpha1.InitSqlSourceStatus{{
+		Name:               "schema",
+		Phase:              v1alpha1.InitSqlSourcePhaseFailed,
+		ExecutedStatements: 2,
+		FailedStatements:   1,
+		Message:            "table exists",
+	}}))
+
+	ti.Spec.SqlSources[1].ConfigMapKeyRef = &corev1.ConfigMapKeySelector{Key: "users.sql"}
+	g.Expect(validateSqlSources(ti)).NotTo(Succeed())
+}
+
 func newFakeTiDBInitManager() (*tidbInitManager, *tidbMemberManager, *fakeIndexers) {
 	tmm, _, _, indexers := newFakeTiDBMemberManager()
 	indexers.job = tmm.deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer()
@@ -18,6 +18,7 @@ import (
 	"crypto/tls"
 	"database/sql"
 	"fmt"
+	"net"
 	"path"
 	"regexp"
 	"strconv"
@@ -36,6 +37,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -76,6 +78,12 @@ const (
 
 	bootstrapSQLFilePath = "/etc/tidb-bootstrap"
 	bootstrapSQLFileName = "bootstrap.sql"
+
+	// tidbDrainingMarkerFile is created by the preStop hook to fail the readiness probe when draining the connections
+	tidbDrainingMarkerFile = "/tmp/tidb-draining"
+	// tidbDrainTerminationBufferSeconds is added to GracefulShutdownSeconds as the default termination grace period,
+	// so that TiDB has time to exit after the connections are drained
+	tidbDrainTerminationBufferSeconds = 30
 )
 
 var (
@@ -92,6 +100,7 @@ type tidbMemberManager struct {
 	podVolumeModifier volumes.PodVolumeModifier
 
 	tidbStatefulSetIsUpgradingFn func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
+	lookupHostFn                 func(ctx context.Context, host string) ([]string, error)
 }
 
 // NewTiDBMemberManager returns a *tidbMemberManager
@@ -104,6 +113,7 @@ func NewTiDBMemberManager(deps *controller.Dependencies, scaler Scaler, tidbUpgr
 		suspender:                    spder,
 		podVolumeModifier:            pvm,
 		tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
+		lookupHostFn:                 net.DefaultResolver.LookupHost,
 	}
 }
 
@@ -150,12 +160,22 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	if err := m.syncTiDBZoneLocal(tc); err != nil {
+		return err
+	}
+
 	if tc.Spec.TiDB.IsTLSClientEnabled() {
 		if err := m.checkTLSClientCert(tc); err != nil {
 			return err
 		}
 	}
 
+	if tc.Spec.TiDB.TokenBasedAuth != nil {
+		if err := m.syncAuthTokenJWKS(tc); err != nil {
+			return err
+		}
+	}
+
 	if tc.NeedToSyncTiDBInitializer() {
 		m.syncInitializer(tc)
 	}
@@ -207,6 +227,7 @@ func (m *tidbMemberManager) syncTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.T
 	tcName := tc.GetName()
 
 	newSvc := getNewTiDBHeadlessServiceForTidbCluster(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.TiDBPeerMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -250,7 +271,9 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	setNotExist := errors.IsNotFound(err)
 
 	oldTiDBSet := oldTiDBSetTemp.DeepCopy()
-	if err = m.syncTidbClusterStatus(tc, oldTiDBSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiDBMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTidbClusterStatus(tc, oldTiDBSet)
+	}); err != nil {
 		return err
 	}
 
@@ -268,6 +291,16 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newTiDBSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	digest, err := syncImageDigest(m.deps, tc, v1alpha1.TiDBMemberType, tc.TiDBImage(), tc.Status.TiDB.ImageDigest, oldTiDBSet, newTiDBSet)
+	tc.Status.TiDB.ImageDigest = digest
+	if err != nil {
+		return err
+	}
+	if tc.Spec.TiDB.GracefulShutdownSeconds == nil {
+		keepTerminationGracePeriodSeconds(tc.BaseTiDBSpec(), newTiDBSet, oldTiDBSet)
+	}
+	newTiDBSet = overrideStatefulSet(tc, m.deps.Recorder, newTiDBSet)
 
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newTiDBSet)
@@ -291,7 +324,9 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldTiDBSet, newTiDBSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiDBMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldTiDBSet, newTiDBSet)
+	}); err != nil {
 		return err
 	}
 
@@ -299,18 +334,26 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		if m.shouldRecover(tc) {
 			m.tidbFailover.Recover(tc)
 		} else if tc.TiDBAllPodsStarted() && !tc.TiDBAllMembersReady() {
-			if err := m.tidbFailover.Failover(tc); err != nil {
+			if err := ObserveReconcilePhase(tc, string(v1alpha1.TiDBMemberType), ReconcilePhaseFailover, func() error {
+				return m.tidbFailover.Failover(tc)
+			}); err != nil {
 				return err
 			}
 		}
 	}
 
 	if !templateEqual(newTiDBSet, oldTiDBSet) || tc.Status.TiDB.Phase == v1alpha1.UpgradePhase {
-		if err := m.tidbUpgrader.Upgrade(tc, oldTiDBSet, newTiDBSet); err != nil {
+		if err := ObserveReconcilePhase(tc, string(v1alpha1.TiDBMemberType), ReconcilePhaseUpgrade, func() error {
+			return m.tidbUpgrader.Upgrade(tc, oldTiDBSet, newTiDBSet)
+		}); err != nil {
 			return err
 		}
 	}
 
+	if err := m.syncScheduledRestart(tc, time.Now()); err != nil {
+		return err
+	}
+
 	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdateTiDBSTS", newTiDBSet, oldTiDBSet)
 }
 
@@ -319,18 +362,14 @@ func (m *tidbMemberManager) syncInitializer(tc *v1alpha1.TidbCluster) {
 	ns := tc.Namespace
 	tcName := tc.Name
 	//check endpoints ready
-	isTiDBReady := false
-	eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.TiDBMemberName(tcName))
+	addrs, epErr := controller.GetServiceEndpointAddresses(m.deps, ns, controller.TiDBMemberName(tcName))
 	if epErr != nil {
 		klog.Errorf("Failed to get endpoints %s for cluster %s/%s, err: %s", controller.TiDBMemberName(tcName), ns, tcName, epErr)
 		return
 	}
-	// TiDB service has endpoints
-	if eps != nil && len(eps.Subsets) > 0 && len(eps.Subsets[0].Addresses) > 0 {
-		isTiDBReady = true
-	}
 
-	if !isTiDBReady {
+	// TiDB service has ready endpoints
+	if !controller.HasReadyEndpointAddress(addrs) {
 		klog.Infof("Wait for TiDB ready for cluster %s/%s", ns, tcName)
 		return
 	}
@@ -462,6 +501,7 @@ func (m *tidbMemberManager) syncTiDBService(tc *v1alpha1.TidbCluster) error {
 	if newSvc == nil {
 		return nil
 	}
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 
 	ns := newSvc.Namespace
 
@@ -532,7 +572,7 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	if tc.Spec.TiDB.Config == nil {
 		return nil, nil
 	}
-	newCm, err := getTiDBConfigMap(tc)
+	newCm, err := getTiDBConfigMap(tc, m.deps.CLIConfig.AirGapped, m.deps.CLIConfig.FIPSMode)
 	if err != nil {
 		return nil, err
 	}
@@ -553,14 +593,17 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
-func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+func getTiDBConfigMap(tc *v1alpha1.TidbCluster, airGapped, fipsMode bool) (*corev1.ConfigMap, error) {
 	if tc.Spec.TiDB.Config == nil {
 		return nil, nil
 	}
 	config := tc.Spec.TiDB.Config.DeepCopy()
 
-	if pointer.BoolPtrDerefOr(tc.Spec.TiDB.TokenBasedAuthEnabled, false) {
+	if tc.Spec.TiDB.IsTokenBasedAuthEnabled() {
 		config.Set("security.auth-token-jwks", path.Join(tidbAuthTokenPath, tidbAuthTokenJWKS))
+		if auth := tc.Spec.TiDB.TokenBasedAuth; auth != nil && auth.RefreshInterval != nil {
+			config.Set("security.auth-token-refresh-interval", auth.RefreshInterval.Duration.String())
+		}
 	}
 
 	// override CA if tls enabled
@@ -582,9 +625,26 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		config.Set("security.ssl-cert", path.Join(serverCertPath, corev1.TLSCertKey))
 		config.Set("security.ssl-key", path.Join(serverCertPath, corev1.TLSPrivateKeyKey))
 	}
+	if svc := tc.Spec.TiDB.Service; svc != nil {
+		setProxyProtocolConfig(config, svc.ProxyProtocol)
+	}
 	if tc.Spec.TiDB.IsBootstrapSQLEnabled() {
 		config.Set("initialize-sql-file", path.Join(bootstrapSQLFilePath, bootstrapSQLFileName))
 	}
+	// the queries on TiFlash are sent to the compute nodes in the disaggregated mode
+	if tc.TiFlashDisaggregated() {
+		config.SetIfNil("disaggregated-tiflash", true)
+	}
+	// the telemetry can not reach the outside in the air-gapped mode
+	if airGapped {
+		config.Set("enable-telemetry", false)
+	}
+	// TLS 1.0 and 1.1 are not allowed in the FIPS mode
+	if fipsMode {
+		if v := config.Get("security.tls-version"); v == nil || (v.Interface() != crypto.FIPSMinTLSVersion && v.Interface() != "TLSv1.3") {
+			config.Set("security.tls-version", crypto.FIPSMinTLSVersion)
+		}
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -633,7 +693,7 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 		{
 			Name:       svcSpec.GetPortName(),
 			Port:       tc.Spec.TiDB.GetServicePort(),
-			TargetPort: intstr.FromInt(4000),
+			TargetPort: intstr.FromInt(int(tidbClientTargetPort(tc))),
 			Protocol:   corev1.ProtocolTCP,
 			NodePort:   svcSpec.GetMySQLNodePort(),
 		},
@@ -680,6 +740,12 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(tidbSvc)
 	}
+	setExternalDNSAnnotations(tidbSvc, svcSpec.ExternalDNS)
+	setProxyProtocolAnnotations(tidbSvc, svcSpec.ProxyProtocol)
+	controller.SetServiceTrafficOptions(tidbSvc, &svcSpec.ServiceSpec)
+	if svcSpec.ZoneLocal != nil && svcSpec.ZoneLocal.Mode == v1alpha1.TiDBZoneLocalModeTopologyAwareHints {
+		controller.SetServiceTopologyAwareRouting(tidbSvc)
+	}
 
 	return tidbSvc
 }
@@ -738,7 +804,7 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		{Name: "config", ReadOnly: true, MountPath: "/etc/tidb"},
 		{Name: "startup-script", ReadOnly: true, MountPath: "/usr/local/bin"},
 	}
-	if pointer.BoolPtrDerefOr(tc.Spec.TiDB.TokenBasedAuthEnabled, false) {
+	if tc.Spec.TiDB.IsTokenBasedAuthEnabled() {
 		volMounts = append(volMounts, corev1.VolumeMount{
 			Name: "tidb-auth-token", ReadOnly: true, MountPath: tidbAuthTokenPath,
 		})
@@ -773,14 +839,19 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 			}},
 		},
 	}
-	if pointer.BoolPtrDerefOr(tc.Spec.TiDB.TokenBasedAuthEnabled, false) {
-		vols = append(vols, corev1.Volume{
+	if tc.Spec.TiDB.IsTokenBasedAuthEnabled() {
+		authTokenVol := corev1.Volume{
 			Name: "tidb-auth-token", VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					SecretName: util.TiDBAuthTokenJWKSSecretName(tcName),
 				},
 			},
-		})
+		}
+		if tc.Spec.TiDB.TokenBasedAuth != nil {
+			// the generated Secret contains the signing key for applications, which is not needed by TiDB
+			authTokenVol.Secret.Items = []corev1.KeyToPath{{Key: tidbAuthTokenJWKS, Path: tidbAuthTokenJWKS}}
+		}
+		vols = append(vols, authTokenVol)
 	}
 	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsBootstrapSQLEnabled() {
 		volMounts = append(volMounts, corev1.VolumeMount{
@@ -857,6 +928,11 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		podSecurityContext.Sysctls = []corev1.Sysctl{}
 	}
 
+	pluginVols, pluginVolMounts, pluginInitContainers := buildTiDBPluginVolumes(tc)
+	vols = append(vols, pluginVols...)
+	volMounts = append(volMounts, pluginVolMounts...)
+	initContainers = append(initContainers, pluginInitContainers...)
+
 	// handle StorageVolumes and AdditionalVolumeMounts in ComponentSpec
 	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.TiDB.StorageVolumes, tc.Spec.TiDB.StorageClassName, v1alpha1.TiDBMemberType)
 	volMounts = append(volMounts, storageVolMounts...)
@@ -984,6 +1060,20 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	if tc.Spec.TiDB.Lifecycle != nil {
 		c.Lifecycle = tc.Spec.TiDB.Lifecycle
 	}
+	if tc.Spec.TiDB.GracefulShutdownSeconds != nil {
+		// fail the readiness probe once the draining starts, and wait for the connections to drop before TiDB is stopped
+		c.ReadinessProbe.Handler = buildTiDBDrainingReadinessProbeHandler(tc)
+		if c.Lifecycle == nil {
+			c.Lifecycle = &corev1.Lifecycle{}
+		} else {
+			c.Lifecycle = c.Lifecycle.DeepCopy()
+		}
+		c.Lifecycle.PreStop = &corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: buildTiDBPreStopDrainCommand(tc),
+			},
+		}
+	}
 	if tc.Spec.TiDB.ReadinessProbe != nil {
 		if tc.Spec.TiDB.ReadinessProbe.InitialDelaySeconds != nil {
 			c.ReadinessProbe.InitialDelaySeconds = *tc.Spec.TiDB.ReadinessProbe.InitialDelaySeconds
@@ -994,8 +1084,14 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	}
 
 	containers = append(containers, c)
+	if tc.Spec.TiDB.ConnectionPool != nil {
+		containers = append(containers, buildTiDBConnectionPoolContainer(tc))
+	}
 
 	podSpec := baseTiDBSpec.BuildPodSpec()
+	if seconds := tc.Spec.TiDB.GracefulShutdownSeconds; seconds != nil && baseTiDBSpec.TerminationGracePeriodSeconds() == nil {
+		podSpec.TerminationGracePeriodSeconds = pointer.Int64Ptr(int64(*seconds) + tidbDrainTerminationBufferSeconds)
+	}
 
 	var err error
 	podSpec.Containers, err = MergePatchContainers(containers, baseTiDBSpec.AdditionalContainers())
@@ -1006,6 +1102,7 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	podSpec.Volumes = append(vols, baseTiDBSpec.AdditionalVolumes()...)
 	podSpec.SecurityContext = podSecurityContext
 	podSpec.InitContainers = append(initContainers, baseTiDBSpec.InitContainers()...)
+	addTiDBSQLReadinessGate(tc, &podSpec)
 	podSpec.ServiceAccountName = tc.Spec.TiDB.ServiceAccount
 	if podSpec.ServiceAccountName == "" {
 		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
@@ -1013,7 +1110,8 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 
 	stsLabels := label.New().Instance(instanceName).TiDB()
 	podLabels := util.CombineStringMap(stsLabels, baseTiDBSpec.Labels())
-	podAnnotations := util.CombineStringMap(baseTiDBSpec.Annotations(), controller.AnnProm(10080, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiDBSpec.Annotations(), controller.AnnProm(10080, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.TiDBMemberType))
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation(containers, baseTiDBSpec.NativeSidecarContainers()))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiDBLabelVal)
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
@@ -1072,6 +1170,9 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 		return err
 	}
 
+	if upgrading {
+		waitForUpgradeDependencies(tc, v1alpha1.TiDBMemberType)
+	}
 	if tc.TiDBStsDesiredReplicas() != *set.Spec.Replicas {
 		tc.Status.TiDB.Phase = v1alpha1.ScalePhase
 	} else if upgrading && tc.Status.TiKV.Phase != v1alpha1.UpgradePhase &&
@@ -1081,6 +1182,22 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 		tc.Status.TiDB.Phase = v1alpha1.NormalPhase
 	}
 
+	// the nodes of the members are resolved from the EndpointSlices of the peer service, which
+	// publishes the not ready addresses, instead of getting the pods one by one if supported
+	var memberNodes map[string]string
+	if m.deps.EndpointSliceLister != nil {
+		addrs, err := controller.GetServiceEndpointAddresses(m.deps, tc.GetNamespace(), controller.TiDBPeerMemberName(tc.GetName()))
+		if err != nil {
+			return err
+		}
+		memberNodes = map[string]string{}
+		for _, addr := range addrs {
+			if addr.PodName != "" && addr.NodeName != "" {
+				memberNodes[addr.PodName] = addr.NodeName
+			}
+		}
+	}
+
 	tidbStatus := map[string]v1alpha1.TiDBMember{}
 	for id := range helper.GetPodOrdinals(tc.Status.TiDB.StatefulSet.Replicas, set) {
 		name := fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.GetName()), id)
@@ -1102,6 +1219,12 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 				newTidbMember.LastTransitionTime = oldTidbMember.LastTransitionTime
 			}
 		}
+		newTidbMember.ProbeFailures = nextProbeFailures(newTidbMember.Health, oldTidbMember.ProbeFailures)
+		if nodeName, ok := memberNodes[name]; ok {
+			newTidbMember.NodeName = nodeName
+			tidbStatus[name] = newTidbMember
+			continue
+		}
 		pod, err := m.deps.PodLister.Pods(tc.GetNamespace()).Get(name)
 		if err != nil && !errors.IsNotFound(err) {
 			return fmt.Errorf("syncTidbClusterStatus: failed to get pods %s for cluster %s/%s, error: %s", name, tc.GetNamespace(), tc.GetName(), err)
@@ -1119,6 +1242,9 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	if c != nil {
 		tc.Status.TiDB.Image = c.Image
 	}
+	if err := m.syncTiDBServiceReadyCondition(tc); err != nil {
+		return err
+	}
 
 	err = volumes.SyncVolumeStatus(m.podVolumeModifier, m.deps.PodLister, tc, v1alpha1.TiDBMemberType)
 	if err != nil {
@@ -1182,7 +1308,7 @@ outer:
 			return setCount, err
 		}
 
-		labels, err := getNodeLabels(m.deps.NodeLister, db.NodeName, config.Replication.LocationLabels)
+		labels, err := getNodeLabels(m.deps.NodeLister, db.NodeName, config.Replication.LocationLabels, m.deps.CLIConfig.StoreLabelNodeLabels)
 		if err != nil || len(labels) == 0 {
 			klog.Warningf("node: [%s] has no node labels %v, skipping set store labels for Pod: [%s/%s]", db.NodeName, config.Replication.LocationLabels, ns, name)
 			continue
@@ -1292,6 +1418,98 @@ func buildTiDBProbeCommand(tc *v1alpha1.TidbCluster) (command []string) {
 	return
 }
 
+// buildTiDBDrainingReadinessProbeHandler returns the readiness probe which fails once the draining
+// of the connections is started by the preStop hook
+func buildTiDBDrainingReadinessProbeHandler(tc *v1alpha1.TidbCluster) corev1.Handler {
+	script := fmt.Sprintf("test ! -f %s && %s", tidbDrainingMarkerFile, strings.Join(buildTiDBProbeCommand(tc), " "))
+	return corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: []string{"/bin/sh", "-c", script},
+		},
+	}
+}
+
+// buildTiDBPreStopDrainCommand returns the preStop command which fails the readiness probe and waits
+// at most GracefulShutdownSeconds for the connections reported by the status API to drop to zero
+func buildTiDBPreStopDrainCommand(tc *v1alpha1.TidbCluster) []string {
+	status := strings.Join(append(buildTiDBProbeCommand(tc), "--silent"), " ")
+	script := fmt.Sprintf(`touch %s
+end=$(($(date +%%s) + %d))
+while [ "$(date +%%s)" -lt "$end" ]; do
+  conns=$(%s | sed -n 's/.*"connections": *\([0-9]*\).*/\1/p')
+  if [ "$conns" = "0" ]; then
+    break
+  fi
+  sleep 1
+done`, tidbDrainingMarkerFile, *tc.Spec.TiDB.GracefulShutdownSeconds, status)
+	return []string{"/bin/sh", "-c", script}
+}
+
+// tidbClientTargetPort returns the port of TiDB pods the client connections of the TiDB Service are forwarded to
+func tidbClientTargetPort(tc *v1alpha1.TidbCluster) int32 {
+	if pool := tc.Spec.TiDB.ConnectionPool; pool != nil {
+		return pool.GetPort()
+	}
+	return 4000
+}
+
+// buildTiDBConnectionPoolContainer returns the connection pool sidecar of `spec.tidb.connectionPool`, the client
+// connections are multiplexed on the connections to the TiDB server in the same pod
+func buildTiDBConnectionPoolContainer(tc *v1alpha1.TidbCluster) corev1.Container {
+	pool := tc.Spec.TiDB.ConnectionPool
+	pullPolicy := tc.BaseTiDBSpec().ImagePullPolicy()
+	if pool.ImagePullPolicy != nil {
+		pullPolicy = *pool.ImagePullPolicy
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "POOL_LISTEN_ADDR",
+			Value: fmt.Sprintf("0.0.0.0:%d", pool.GetPort()),
+		},
+		{
+			Name:  "POOL_SERVER_ADDR",
+			Value: "127.0.0.1:4000",
+		},
+		{
+			Name:  "POOL_MAX_CONNECTIONS",
+			Value: strconv.Itoa(int(pool.GetMaxConnections())),
+		},
+		{
+			Name:  "POOL_MAX_SERVER_CONNECTIONS",
+			Value: strconv.Itoa(int(pool.GetMaxServerConnections())),
+		},
+	}
+	if seconds := tc.Spec.TiDB.GracefulShutdownSeconds; seconds != nil {
+		// the pool drains the client connections after it receives SIGTERM, while TiDB waits in the preStop hook
+		envs = append(envs, corev1.EnvVar{
+			Name:  "POOL_DRAIN_TIMEOUT_SECONDS",
+			Value: strconv.Itoa(int(*seconds)),
+		})
+	}
+	return corev1.Container{
+		Name:            v1alpha1.ContainerConnectionPool.String(),
+		Image:           pool.Image,
+		ImagePullPolicy: pullPolicy,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "pool",
+				ContainerPort: pool.GetPort(),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env:       envs,
+		Resources: controller.ContainerResource(pool.ResourceRequirements),
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(int(pool.GetPort())),
+				},
+			},
+			InitialDelaySeconds: int32(10),
+		},
+	}
+}
+
 func tlsClientSecretName(tc *v1alpha1.TidbCluster) string {
 	return fmt.Sprintf("%s-server-secret", controller.TiDBMemberName(tc.Name))
 }
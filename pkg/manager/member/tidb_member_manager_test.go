@@ -16,6 +16,7 @@ package member
 import (
 	"context"
 	"fmt"
+	"net"
 	"path"
 	"strings"
 	"testing"
@@ -852,6 +853,7 @@ func newFakeTiDBMemberManager() (*tidbMemberManager, *controller.FakeStatefulSet
 		tidbUpgrader:                 NewFakeTiDBUpgrader(),
 		tidbFailover:                 NewFakeTiDBFailover(),
 		tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
+		lookupHostFn:                 net.DefaultResolver.LookupHost,
 		suspender:                    suspender.NewFakeSuspender(),
 		podVolumeModifier:            &volumes.FakePodVolumeModifier{},
 	}
@@ -2142,7 +2144,7 @@ func TestGetTiDBConfigMap(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			cm, err := getTiDBConfigMap(&tt.tc)
+			cm, err := getTiDBConfigMap(&tt.tc, false, false)
 			g.Expect(err).To(Succeed())
 			if tt.expected == nil {
 				g.Expect(cm).To(BeNil())
@@ -2543,6 +2545,78 @@ func TestBuildTiDBProbeHandler(t *testing.T) {
 	g.Expect(get).Should(Equal(defaultHandler))
 }
 
+func TestGetNewTiDBSetWithGracefulShutdown(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.GracefulShutdownSeconds = pointer.Int32Ptr(120)
+	tc.Spec.TiDB.Lifecycle = &corev1.Lifecycle{
+		PostStart: &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"true"}}},
+	}
+	sts, err := getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	podSpec := sts.Spec.Template.Spec
+	g.Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(150)))
+	var c corev1.Container
+	for _, container := range podSpec.Containers {
+		if container.Name == v1alpha1.TiDBMemberType.String() {
+			c = container
+		}
+	}
+	g.Expect(c.Lifecycle.PostStart).NotTo(BeNil())
+	g.Expect(c.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("touch " + tidbDrainingMarkerFile))
+	g.Expect(c.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("+ 120"))
+	g.Expect(c.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("curl http://127.0.0.1:10080/status"))
+	g.Expect(c.ReadinessProbe.Exec.Command[2]).To(HavePrefix("test ! -f " + tidbDrainingMarkerFile + " && curl"))
+	// the lifecycle in the spec is not modified
+	g.Expect(tc.Spec.TiDB.Lifecycle.PreStop).To(BeNil())
+
+	// the termination grace period set explicitly is kept
+	tc.Spec.TiDB.TerminationGracePeriodSeconds = pointer.Int64Ptr(300)
+	sts, err = getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*sts.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(int64(300)))
+}
+
+func TestGetNewTiDBSetWithConnectionPool(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{}
+	tc.Spec.TiDB.GracefulShutdownSeconds = pointer.Int32Ptr(120)
+	tc.Spec.TiDB.ConnectionPool = &v1alpha1.TiDBConnectionPool{
+		Image:          "proxy:latest",
+		MaxConnections: pointer.Int32Ptr(2000),
+	}
+	sts, err := getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var pool *corev1.Container
+	for i, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == v1alpha1.ContainerConnectionPool.String() {
+			pool = &sts.Spec.Template.Spec.Containers[i]
+		}
+	}
+	g.Expect(pool).NotTo(BeNil())
+	g.Expect(pool.Image).To(Equal("proxy:latest"))
+	g.Expect(pool.Ports[0].ContainerPort).To(Equal(int32(6000)))
+	g.Expect(pool.Env).To(ContainElements(
+		corev1.EnvVar{Name: "POOL_LISTEN_ADDR", Value: "0.0.0.0:6000"},
+		corev1.EnvVar{Name: "POOL_SERVER_ADDR", Value: "127.0.0.1:4000"},
+		corev1.EnvVar{Name: "POOL_MAX_CONNECTIONS", Value: "2000"},
+		corev1.EnvVar{Name: "POOL_MAX_SERVER_CONNECTIONS", Value: "100"},
+		corev1.EnvVar{Name: "POOL_DRAIN_TIMEOUT_SECONDS", Value: "120"},
+	))
+
+	// the client connections of the service are forwarded to the pool
+	svc := getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(6000))
+	tc.Spec.TiDB.ConnectionPool = nil
+	svc = getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(4000))
+}
+
 func newTidbClusterForTiDB() *v1alpha1.TidbCluster {
 	return &v1alpha1.TidbCluster{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// tidbPluginDir is the plugin directory of TiDB, it's the same as --plugin-dir in the start script
+	tidbPluginDir = "/plugins"
+	// tidbPluginVolumeName is the name of the volume holding the plugin binaries
+	tidbPluginVolumeName = "tidb-plugins"
+	// tidbPluginCopyDir is where the plugin volume is mounted in the init containers copying the plugin binaries,
+	// it differs from the plugin directory to avoid hiding the binaries in the images
+	tidbPluginCopyDir = "/tidb-plugins"
+)
+
+// buildTiDBPluginVolumes returns the volumes, the volume mounts of the TiDB container and the init containers
+// to put the binaries of the plugin artifacts into the plugin directory of TiDB. The binaries in ConfigMaps and
+// volumes are mounted as files directly, and those in images are copied by init containers.
+func buildTiDBPluginVolumes(tc *v1alpha1.TidbCluster) ([]corev1.Volume, []corev1.VolumeMount, []corev1.Container) {
+	plugins := tc.Spec.TiDB.PluginArtifacts
+	if len(plugins) == 0 {
+		return nil, nil, nil
+	}
+
+	vols := []corev1.Volume{
+		{Name: tidbPluginVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: tidbPluginVolumeName, MountPath: tidbPluginDir},
+	}
+	var initContainers []corev1.Container
+	for i := range plugins {
+		p := &plugins[i]
+		volName := fmt.Sprintf("%s-%d", tidbPluginVolumeName, i)
+		binary := path.Join(tidbPluginDir, p.ID()+".so")
+		switch {
+		case p.ConfigMap != nil:
+			vols = append(vols, corev1.Volume{Name: volName, VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: *p.ConfigMap},
+			}})
+			mounts = append(mounts, corev1.VolumeMount{Name: volName, ReadOnly: true, MountPath: binary, SubPath: p.GetPath()})
+		case p.PersistentVolumeClaim != nil:
+			vols = append(vols, corev1.Volume{Name: volName, VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: p.PersistentVolumeClaim.DeepCopy(),
+			}})
+			mounts = append(mounts, corev1.VolumeMount{Name: volName, ReadOnly: true, MountPath: binary, SubPath: p.GetPath()})
+		case p.Image != "":
+			initContainers = append(initContainers, corev1.Container{
+				Name:            volName,
+				Image:           p.Image,
+				ImagePullPolicy: tc.BaseTiDBSpec().ImagePullPolicy(),
+				Command: []string{
+					"sh",
+					"-c",
+					fmt.Sprintf("cp %s %s", path.Join("/", p.GetPath()), path.Join(tidbPluginCopyDir, p.ID()+".so")),
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: tidbPluginVolumeName, MountPath: tidbPluginCopyDir}},
+			})
+		}
+	}
+	return vols, mounts, initContainers
+}
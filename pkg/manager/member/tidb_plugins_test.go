@@ -0,0 +1,55 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuildTiDBPluginVolumes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	vols, mounts, initContainers := buildTiDBPluginVolumes(tc)
+	g.Expect(vols).To(BeEmpty())
+	g.Expect(mounts).To(BeEmpty())
+	g.Expect(initContainers).To(BeEmpty())
+
+	tc.Spec.TiDB.Plugins = []string{"whitelist-1"}
+	tc.Spec.TiDB.PluginArtifacts = []v1alpha1.TiDBPluginArtifact{
+		{Name: "audit", Version: "1", ConfigMap: &corev1.LocalObjectReference{Name: "audit-plugin"}},
+		{Name: "whitelist", Version: "1", Image: "my-registry/whitelist-plugin:v1", Path: "plugins/whitelist.so"},
+	}
+	g.Expect(tc.Spec.TiDB.GetPluginLoadList()).To(Equal([]string{"whitelist-1", "audit-1"}))
+
+	vols, mounts, initContainers = buildTiDBPluginVolumes(tc)
+	g.Expect(vols).To(HaveLen(2))
+	g.Expect(vols[0].EmptyDir).NotTo(BeNil())
+	g.Expect(vols[1].ConfigMap.Name).To(Equal("audit-plugin"))
+	g.Expect(mounts).To(Equal([]corev1.VolumeMount{
+		{Name: "tidb-plugins", MountPath: "/plugins"},
+		{Name: "tidb-plugins-0", ReadOnly: true, MountPath: "/plugins/audit-1.so", SubPath: "audit-1.so"},
+	}))
+	g.Expect(initContainers).To(HaveLen(1))
+	g.Expect(initContainers[0].Image).To(Equal("my-registry/whitelist-plugin:v1"))
+	g.Expect(initContainers[0].Command).To(Equal([]string{"sh", "-c", "cp /plugins/whitelist.so /tidb-plugins/whitelist-1.so"}))
+
+	sts, err := getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sts.Spec.Template.Spec.InitContainers).To(ContainElement(initContainers[0]))
+}
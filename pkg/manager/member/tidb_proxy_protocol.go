@@ -0,0 +1,61 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+const (
+	// annotations of the service which enable the PROXY protocol on the load balancer of the cloud providers
+	awsProxyProtocolAnnotation          = "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol"
+	alibabaCloudProxyProtocolAnnotation = "service.beta.kubernetes.io/alibaba-cloud-loadbalancer-proxy-protocol"
+)
+
+// setProxyProtocolAnnotations enables the PROXY protocol on the load balancer of the service,
+// the annotations take precedence over the annotations in the service spec.
+func setProxyProtocolAnnotations(svc *corev1.Service, spec *v1alpha1.TiDBProxyProtocol) {
+	if spec == nil || svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return
+	}
+	key, value := "", ""
+	switch spec.Provider {
+	case v1alpha1.TiDBProxyProtocolProviderAWS:
+		key, value = awsProxyProtocolAnnotation, "*"
+	case v1alpha1.TiDBProxyProtocolProviderAlibabaCloud:
+		key, value = alibabaCloudProxyProtocolAnnotation, "on"
+	default:
+		return
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[key] = value
+}
+
+// setProxyProtocolConfig sets the `proxy-protocol` section of the TiDB config, so that TiDB accepts
+// the PROXY protocol header sent by the load balancer.
+func setProxyProtocolConfig(config *v1alpha1.TiDBConfigWraper, spec *v1alpha1.TiDBProxyProtocol) {
+	if spec == nil {
+		return
+	}
+	config.Set("proxy-protocol.networks", strings.Join(spec.Networks, ","))
+	if spec.HeaderTimeout != nil {
+		config.Set("proxy-protocol.header-timeout", int64(*spec.HeaderTimeout))
+	}
+}
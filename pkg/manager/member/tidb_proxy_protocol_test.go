@@ -0,0 +1,76 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestGetNewTiDBServiceWithProxyProtocol(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+		ServiceSpec: v1alpha1.ServiceSpec{
+			Type:        corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{"foo": "bar", awsProxyProtocolAnnotation: "none"},
+		},
+		ProxyProtocol: &v1alpha1.TiDBProxyProtocol{
+			Provider: v1alpha1.TiDBProxyProtocolProviderAWS,
+			Networks: []string{"*"},
+		},
+	}
+	svc := getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Annotations).To(Equal(map[string]string{
+		"foo":                      "bar",
+		awsProxyProtocolAnnotation: "*",
+	}))
+
+	tc.Spec.TiDB.Service.ProxyProtocol.Provider = v1alpha1.TiDBProxyProtocolProviderAlibabaCloud
+	svc = getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Annotations).To(HaveKeyWithValue(alibabaCloudProxyProtocolAnnotation, "on"))
+
+	// the load balancer is configured by the user if the provider is not set
+	tc.Spec.TiDB.Service.ProxyProtocol.Provider = ""
+	svc = getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Annotations).To(Equal(map[string]string{"foo": "bar", awsProxyProtocolAnnotation: "none"}))
+}
+
+func TestGetTiDBConfigMapWithProxyProtocol(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+		ServiceSpec: v1alpha1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		ProxyProtocol: &v1alpha1.TiDBProxyProtocol{
+			Networks:      []string{"10.0.0.0/8", "192.168.0.1"},
+			HeaderTimeout: pointer.Int32Ptr(5),
+		},
+	}
+
+	cm, err := getTiDBConfigMap(tc, false, false)
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring("[proxy-protocol]"))
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring(`networks = "10.0.0.0/8,192.168.0.1"`))
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring("header-timeout = 5"))
+	// the config of the spec is not changed
+	g.Expect(tc.Spec.TiDB.Config.Get("proxy-protocol.networks")).To(BeNil())
+}
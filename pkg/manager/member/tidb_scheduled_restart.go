@@ -0,0 +1,150 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+const (
+	tidbScheduledRestartReason        = "TiDBScheduledRestart"
+	tidbScheduledRestartSkippedReason = "TiDBScheduledRestartSkipped"
+	tidbInvalidRestartScheduleReason  = "InvalidTiDBRestartSchedule"
+)
+
+// syncScheduledRestart restarts the TiDB pods one by one in the windows of spec.tidb.restartPolicy.
+//
+// A pod is restarted by deleting it, so the connections are drained by the preStop hook if
+// gracefulShutdownSeconds is set. The next pod is restarted after all the TiDB pods are ready
+// again. The pods created after the window starts are not restarted, and at most maxPodsPerRun
+// pods are restarted in a window. The restart is skipped while TiDB is upgrading or scaling,
+// or the cluster is degraded, and it continues if the cluster recovers in the window.
+func (m *tidbMemberManager) syncScheduledRestart(tc *v1alpha1.TidbCluster, now time.Time) error {
+	policy := tc.Spec.TiDB.RestartPolicy
+	if policy == nil {
+		tc.Status.TiDB.Restart = nil
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	windowStart, active, err := activeScheduleWindow(tc, policy.Schedule, tc.TiDBRestartWindow(), now)
+	if err != nil {
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, tidbInvalidRestartScheduleReason, "invalid restart schedule %q of tidb: %v", policy.Schedule, err)
+		return nil
+	}
+	if !active {
+		return nil
+	}
+
+	status := tc.Status.TiDB.Restart
+	if status == nil || !status.ScheduleTime.Time.Equal(windowStart) {
+		klog.Infof("tc %s/%s: scheduled restart window of tidb started at %s", ns, tc.Name, windowStart)
+		status = &v1alpha1.TiDBRestartStatus{ScheduleTime: metav1.NewTime(windowStart)}
+		tc.Status.TiDB.Restart = status
+	}
+	if int32(len(status.RestartedPods)) >= tc.TiDBRestartMaxPodsPerRun() {
+		status.Message = fmt.Sprintf("%d pods are restarted in the window", len(status.RestartedPods))
+		return nil
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiDB().Selector()
+	if err != nil {
+		return err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("syncScheduledRestart: failed to list pods for cluster %s/%s, selector %s, error: %v", ns, tc.Name, selector, err)
+	}
+
+	// restart the next pod after the previous one is ready again
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !podutil.IsPodReady(pod) {
+			status.Message = fmt.Sprintf("waiting for pod %s to be ready", pod.Name)
+			return nil
+		}
+	}
+	if int32(len(pods)) < tc.TiDBStsDesiredReplicas() {
+		status.Message = fmt.Sprintf("waiting for %d pods to be created", tc.TiDBStsDesiredReplicas()-int32(len(pods)))
+		return nil
+	}
+
+	if reason := tidbRestartSkipReason(tc); reason != "" {
+		if status.Message != reason {
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, tidbScheduledRestartSkippedReason, "scheduled restart of tidb is skipped: %s", reason)
+		}
+		status.Message = reason
+		return nil
+	}
+
+	restarted := sets.NewString(status.RestartedPods...)
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if !restarted.Has(pod.Name) && pod.CreationTimestamp.Time.Before(windowStart) {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		status.Message = fmt.Sprintf("all pods are restarted in the window, %d pods are restarted", len(status.RestartedPods))
+		return nil
+	}
+	// the oldest pods are restarted first, so all the pods are restarted in turn if maxPodsPerRun is less than the replicas
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[i].Name < candidates[j].Name
+		}
+		return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+	})
+
+	pod := candidates[0]
+	if err := m.deps.PodControl.DeletePod(tc, pod); err != nil {
+		return fmt.Errorf("syncScheduledRestart: failed to restart pod %s/%s, error: %v", ns, pod.Name, err)
+	}
+	klog.Infof("tc %s/%s: tidb pod %s is restarted by the scheduled restart", ns, tc.Name, pod.Name)
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, tidbScheduledRestartReason, "restart tidb pod %s by the scheduled restart", pod.Name)
+	status.RestartedPods = append(status.RestartedPods, pod.Name)
+	status.Message = fmt.Sprintf("restarting pod %s", pod.Name)
+	return nil
+}
+
+// tidbRestartSkipReason returns why the scheduled restart should be skipped, or an empty string
+// if the cluster is healthy enough to restart a TiDB pod.
+func tidbRestartSkipReason(tc *v1alpha1.TidbCluster) string {
+	if tc.Status.TiDB.Phase != v1alpha1.NormalPhase {
+		return fmt.Sprintf("tidb is in %s phase", tc.Status.TiDB.Phase)
+	}
+	if len(tc.Status.TiDB.FailureMembers) > 0 {
+		return "tidb has failure members"
+	}
+	if !tc.TiDBAllMembersReady() {
+		return "not all tidb members are healthy"
+	}
+	if tc.Spec.PD != nil && !tc.PDIsAvailable() {
+		return "pd is unavailable"
+	}
+	if tc.Spec.TiKV != nil && !tc.TiKVIsAvailable() {
+		return "tikv is unavailable"
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestTiDBSyncScheduledRestart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+	tc := newTidbClusterForTiDB()
+	tc.Spec.PD = nil
+	tc.Spec.TiKV = nil
+	tc.Spec.TiDB.RestartPolicy = &v1alpha1.TiDBRestartPolicy{
+		Schedule:      "0 3 * * 0",
+		MaxPodsPerRun: pointer.Int32Ptr(2),
+	}
+	tc.Status.TiDB.Phase = v1alpha1.NormalPhase
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{}
+
+	created := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	newPod := func(ordinal int, creation time.Time, ready bool) *corev1.Pod {
+		name := fmt.Sprintf("test-tidb-%d", ordinal)
+		tc.Status.TiDB.Members[name] = v1alpha1.TiDBMember{Name: name, Health: ready}
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         tc.Namespace,
+				Labels:            label.New().Instance(tc.Name).TiDB().Labels(),
+				CreationTimestamp: metav1.NewTime(creation),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	for i := 0; i < 3; i++ {
+		g.Expect(indexers.pod.Add(newPod(i, created.Add(time.Duration(i)*time.Minute), true))).To(Succeed())
+	}
+	podExists := func(name string) bool {
+		_, err := tmm.deps.PodLister.Pods(tc.Namespace).Get(name)
+		return err == nil
+	}
+
+	// out of the window, Sunday 2023-06-04 03:00 is the start of the window
+	now := time.Date(2023, 6, 4, 2, 0, 0, 0, time.UTC)
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart).To(BeNil())
+
+	// the oldest pod is restarted first
+	now = now.Add(90 * time.Minute)
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.ScheduleTime.Time).To(Equal(time.Date(2023, 6, 4, 3, 0, 0, 0, time.UTC)))
+	g.Expect(tc.Status.TiDB.Restart.RestartedPods).To(Equal([]string{"test-tidb-0"}))
+	g.Expect(podExists("test-tidb-0")).To(BeFalse())
+
+	// wait for the restarted pod to be ready
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.Message).To(ContainSubstring("waiting for 1 pods to be created"))
+	g.Expect(indexers.pod.Add(newPod(0, now, false))).To(Succeed())
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.Message).To(ContainSubstring("waiting for pod test-tidb-0"))
+	g.Expect(indexers.pod.Update(newPod(0, now, true))).To(Succeed())
+
+	// skip the restart if the cluster is degraded
+	tc.Status.TiDB.Phase = v1alpha1.ScalePhase
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.Message).To(Equal("tidb is in Scale phase"))
+	g.Expect(tc.Status.TiDB.Restart.RestartedPods).To(HaveLen(1))
+	tc.Status.TiDB.Phase = v1alpha1.NormalPhase
+
+	// at most maxPodsPerRun pods are restarted in the window
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.RestartedPods).To(Equal([]string{"test-tidb-0", "test-tidb-1"}))
+	g.Expect(indexers.pod.Add(newPod(1, now, true))).To(Succeed())
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.RestartedPods).To(HaveLen(2))
+	g.Expect(podExists("test-tidb-2")).To(BeTrue())
+
+	// the remaining pod is restarted first in the next window
+	now = now.Add(7 * 24 * time.Hour)
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart.RestartedPods).To(Equal([]string{"test-tidb-2"}))
+
+	// the status is cleared if the policy is removed
+	tc.Spec.TiDB.RestartPolicy = nil
+	g.Expect(tmm.syncScheduledRestart(tc, now)).To(Succeed())
+	g.Expect(tc.Status.TiDB.Restart).To(BeNil())
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	// tidbSQLReadyConditionType is the condition type of the readiness gate fed by the SQL health check
+	tidbSQLReadyConditionType corev1.PodConditionType = "tidb.pingcap.com/sql-ready"
+	// defaultTiDBSQLReadinessTimeout is the default timeout of the SQL health check of each pod
+	defaultTiDBSQLReadinessTimeout = 5 * time.Second
+	// maxTiDBSQLReadinessTimeout bounds the time a worker of the pod controller is occupied by a pod
+	maxTiDBSQLReadinessTimeout = 30 * time.Second
+	// tidbSQLReadinessCheckInterval is the interval to check the SQL health of each pod again
+	tidbSQLReadinessCheckInterval = 10 * time.Second
+)
+
+// checkTiDBSQLHealth runs the SQL health check against the DSN, it's a variable so that it can be faked in tests
+var checkTiDBSQLHealth = func(ctx context.Context, dsn string) error {
+	db, err := util.OpenDB(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&n); err != nil {
+		return fmt.Errorf("failed to run SELECT 1, err: %v", err)
+	}
+	// the query fails if the schema of TiDB is not loaded
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.cluster_info").Scan(&n); err != nil {
+		return fmt.Errorf("failed to query information_schema.cluster_info, err: %v", err)
+	}
+	return nil
+}
+
+// addTiDBSQLReadinessGate adds the readiness gate fed by the SQL health check to the pod spec if it's enabled
+func addTiDBSQLReadinessGate(tc *v1alpha1.TidbCluster, podSpec *corev1.PodSpec) {
+	if tc.Spec.TiDB.SQLReadinessGate == nil {
+		return
+	}
+	podSpec.ReadinessGates = append(podSpec.ReadinessGates, corev1.PodReadinessGate{ConditionType: tidbSQLReadyConditionType})
+}
+
+// SyncTiDBSQLReadinessGate checks the SQL health of a TiDB pod whose containers are ready, and sets the condition
+// of the readiness gate according to the result. It's called by the pod controller for each pod, so the checks of
+// the pods run concurrently in its workers and a pod which does not respond does not block the sync of the cluster.
+// The duration after which the pod should be checked again is returned.
+func SyncTiDBSQLReadinessGate(ctx context.Context, deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod) (time.Duration, error) {
+	gate := tc.Spec.TiDB.SQLReadinessGate
+	if gate == nil || pod.DeletionTimestamp != nil || !hasTiDBSQLReadinessGate(pod) {
+		return 0, nil
+	}
+	// the SQL health is not checked until the readiness probe of the containers passes
+	if _, c := podutil.GetPodCondition(&pod.Status, corev1.ContainersReady); c == nil || c.Status != corev1.ConditionTrue {
+		return 0, nil
+	}
+
+	user, password, err := getTiDBSQLReadinessCredentials(deps, tc)
+	if err != nil {
+		return 0, err
+	}
+	timeout := defaultTiDBSQLReadinessTimeout
+	if gate.TimeoutSeconds != nil {
+		timeout = time.Duration(*gate.TimeoutSeconds) * time.Second
+	}
+	if timeout > maxTiDBSQLReadinessTimeout {
+		timeout = maxTiDBSQLReadinessTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	err = checkTiDBSQLHealth(ctx, tidbSQLReadinessDSN(tc, pod.GetName(), user, password, timeout))
+	cancel()
+	cond := corev1.PodCondition{
+		Type:    tidbSQLReadyConditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  "SQLHealthy",
+		Message: "SQL health check passed",
+	}
+	if err != nil {
+		klog.Warningf("SyncTiDBSQLReadinessGate: SQL health check of pod %s/%s failed, err: %v", pod.GetNamespace(), pod.GetName(), err)
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = "SQLUnhealthy"
+		cond.Message = err.Error()
+	}
+	if err := updateTiDBSQLReadyCondition(deps, pod, cond); err != nil {
+		return 0, err
+	}
+	return tidbSQLReadinessCheckInterval, nil
+}
+
+// getTiDBSQLReadinessCredentials returns the user and password used by the SQL health check
+func getTiDBSQLReadinessCredentials(deps *controller.Dependencies, tc *v1alpha1.TidbCluster) (string, string, error) {
+	gate := tc.Spec.TiDB.SQLReadinessGate
+	user := gate.User
+	if user == "" {
+		user = "root"
+	}
+
+	secretName, key := gate.SecretName, constants.TidbPasswordKey
+	if secretName == "" {
+		if tc.Spec.TiDB.Initializer == nil || !tc.Spec.TiDB.Initializer.CreatePassword {
+			return user, "", nil
+		}
+		secretName, key = controller.TiDBInitSecret(tc.GetName()), constants.TidbRootKey
+	}
+	secret, err := deps.SecretLister.Secrets(tc.GetNamespace()).Get(secretName)
+	if err != nil {
+		if gate.SecretName == "" && errors.IsNotFound(err) {
+			// the password is not created yet
+			return user, "", nil
+		}
+		return "", "", fmt.Errorf("failed to get secret %s/%s for the SQL readiness gate, err: %v", tc.GetNamespace(), secretName, err)
+	}
+	return user, string(secret.Data[key]), nil
+}
+
+// updateTiDBSQLReadyCondition updates the condition of the readiness gate in the pod status if it's changed
+func updateTiDBSQLReadyCondition(deps *controller.Dependencies, pod *corev1.Pod, cond corev1.PodCondition) error {
+	idx := -1
+	for i, c := range pod.Status.Conditions {
+		if c.Type == tidbSQLReadyConditionType {
+			if c.Status == cond.Status && c.Reason == cond.Reason {
+				return nil
+			}
+			idx = i
+			break
+		}
+	}
+
+	cond.LastTransitionTime = metav1.Now()
+	newPod := pod.DeepCopy()
+	if idx < 0 {
+		newPod.Status.Conditions = append(newPod.Status.Conditions, cond)
+	} else {
+		newPod.Status.Conditions[idx] = cond
+	}
+	if _, err := deps.KubeClientset.CoreV1().Pods(pod.GetNamespace()).UpdateStatus(context.TODO(), newPod, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update condition %s of pod %s/%s, err: %v", tidbSQLReadyConditionType, pod.GetNamespace(), pod.GetName(), err)
+	}
+	klog.Infof("condition %s of pod %s/%s is set to %s", tidbSQLReadyConditionType, pod.GetNamespace(), pod.GetName(), cond.Status)
+	return nil
+}
+
+// tidbSQLReadinessDSN returns the DSN connecting to the TiDB pod by its peer address
+func tidbSQLReadinessDSN(tc *v1alpha1.TidbCluster, podName, user, password string, timeout time.Duration) string {
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s.%s.%s:4000", podName, controller.TiDBPeerMemberName(tc.GetName()), tc.GetNamespace())
+	cfg.Timeout = timeout
+	if tc.Spec.TiDB.IsTLSClientEnabled() {
+		// the operator has no client certificate, the connection is encrypted without verifying the server certificate
+		cfg.TLSConfig = "preferred"
+	}
+	return cfg.FormatDSN()
+}
+
+func hasTiDBSQLReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == tidbSQLReadyConditionType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncTiDBSQLReadinessGate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.SQLReadinessGate = &v1alpha1.TiDBSQLReadinessGate{SecretName: "probe"}
+	g.Expect(indexers.secret.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: "probe"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	})).To(Succeed())
+
+	sts, err := getNewTiDBSetForTidbCluster(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sts.Spec.Template.Spec.ReadinessGates).To(Equal([]corev1.PodReadinessGate{{ConditionType: tidbSQLReadyConditionType}}))
+
+	newPod := func(ordinal int, containersReady corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tc.Namespace,
+				Name:      fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.Name), ordinal),
+				Labels:    label.New().Instance(tc.Name).TiDB().Labels(),
+			},
+			Spec: corev1.PodSpec{
+				ReadinessGates: []corev1.PodReadinessGate{{ConditionType: tidbSQLReadyConditionType}},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: containersReady}},
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newPod(0, corev1.ConditionTrue),
+		newPod(1, corev1.ConditionTrue),
+		newPod(2, corev1.ConditionFalse),
+	}
+	for _, pod := range pods {
+		g.Expect(indexers.pod.Add(pod)).To(Succeed())
+		_, err := tmm.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	origin := checkTiDBSQLHealth
+	defer func() { checkTiDBSQLHealth = origin }()
+	var checked []string
+	checkTiDBSQLHealth = func(ctx context.Context, dsn string) error {
+		g.Expect(dsn).To(HavePrefix("root:secret@tcp("))
+		checked = append(checked, dsn)
+		if strings.Contains(dsn, "test-tidb-1.") {
+			return fmt.Errorf("Access denied for user 'root'")
+		}
+		return nil
+	}
+
+	for i, pod := range pods {
+		recheckAfter, err := SyncTiDBSQLReadinessGate(context.TODO(), tmm.deps, tc, pod)
+		g.Expect(err).NotTo(HaveOccurred())
+		if i < 2 {
+			g.Expect(recheckAfter).To(Equal(tidbSQLReadinessCheckInterval))
+		} else {
+			g.Expect(recheckAfter).To(BeZero())
+		}
+	}
+	// the pod whose containers are not ready is not checked
+	g.Expect(checked).To(HaveLen(2))
+
+	getCondition := func(name string) *corev1.PodCondition {
+		pod, err := tmm.deps.KubeClientset.CoreV1().Pods(tc.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		for i := range pod.Status.Conditions {
+			if pod.Status.Conditions[i].Type == tidbSQLReadyConditionType {
+				return &pod.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+	g.Expect(getCondition("test-tidb-0").Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(getCondition("test-tidb-1").Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(getCondition("test-tidb-1").Message).To(ContainSubstring("Access denied"))
+	g.Expect(getCondition("test-tidb-2")).To(BeNil())
+}
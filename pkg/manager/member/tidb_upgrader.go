@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
@@ -56,6 +57,7 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	waitForUpgradeDependencies(tc, v1alpha1.TiDBMemberType)
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase || tc.Status.PD.Phase == v1alpha1.ScalePhase ||
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase || tc.Status.TiKV.Phase == v1alpha1.ScalePhase ||
 		tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase || tc.Status.TiFlash.Phase == v1alpha1.ScalePhase ||
@@ -106,6 +108,7 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 	}
 
 	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	upgraded := int32(0)
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
@@ -118,6 +121,10 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == tc.Status.TiDB.StatefulSet.UpdateRevision {
 			if !podutil.IsPodAvailable(pod, int32(minReadySeconds), metav1.Now()) {
@@ -131,8 +138,14 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 			if member, exist := tc.Status.TiDB.Members[podName]; !exist || !member.Health {
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb upgraded pod: [%s] is not ready", ns, tcName, podName)
 			}
+			upgraded++
 			continue
 		}
+		if canaryPaused(tc, tc.Spec.TiDB.RollingUpdateStrategy, label.AnnTiDBCanaryPromoted, tc.Status.TiDB.StatefulSet.UpdateRevision, upgraded) {
+			klog.Infof("tidbcluster: [%s/%s]'s tidb canary of %d pods is upgraded, waiting for annotation %s to be set to %s",
+				ns, tcName, upgraded, label.AnnTiDBCanaryPromoted, tc.Status.TiDB.StatefulSet.UpdateRevision)
+			return nil
+		}
 		return u.upgradeTiDBPod(tc, i, newSet)
 	}
 
@@ -91,6 +91,34 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
 			},
 		},
+		{
+			name: "canary is upgraded and not promoted",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Spec.TiDB.RollingUpdateStrategy = &v1alpha1.RollingUpdateStrategy{Canary: pointer.Int32Ptr(1)}
+				tc.Annotations = map[string]string{label.AnnTiDBCanaryPromoted: "1"}
+			},
+			getLastAppliedConfigErr: false,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "canary is upgraded and promoted",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Spec.TiDB.RollingUpdateStrategy = &v1alpha1.RollingUpdateStrategy{Canary: pointer.Int32Ptr(1)}
+				tc.Annotations = map[string]string{label.AnnTiDBCanaryPromoted: "2"}
+			},
+			getLastAppliedConfigErr: false,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
 		{
 			name: "normal with notReady pod",
 			changePods: func(pods []*corev1.Pod) {
@@ -109,6 +137,23 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 			},
 			errorExpect: true,
 		},
+		{
+			name: "upgraded pod is terminating",
+			changePods: func(pods []*corev1.Pod) {
+				now := metav1.Now()
+				pods[1].DeletionTimestamp = &now
+			},
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+			},
+			getLastAppliedConfigErr: false,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+			errorExpect: true,
+		},
 		{
 			name: "modify oldSet update strategy to OnDelete",
 			changeFn: func(tc *v1alpha1.TidbCluster) {
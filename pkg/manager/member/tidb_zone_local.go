@@ -0,0 +1,247 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+var (
+	// node labels of the zone, in the order of precedence
+	nodeZoneLabels = []string{corev1.LabelZoneFailureDomainStable, corev1.LabelZoneFailureDomain}
+
+	invalidServiceNameChars  = regexp.MustCompile(`[^a-z0-9-]+`)
+	invalidConfigMapKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+)
+
+// zoneServiceName returns the name of the TiDB service for a zone, the zone is converted to a valid DNS label.
+func zoneServiceName(tc *v1alpha1.TidbCluster, zone string) string {
+	suffix := strings.Trim(invalidServiceNameChars.ReplaceAllString(strings.ToLower(zone), "-"), "-")
+	return fmt.Sprintf("%s-%s", controller.TiDBMemberName(tc.GetName()), suffix)
+}
+
+// getNewTiDBZoneServiceOrNil returns the ClusterIP service which selects the TiDB pods in the zone,
+// the ports are the same as the TiDB service.
+func getNewTiDBZoneServiceOrNil(tc *v1alpha1.TidbCluster, zone string) *corev1.Service {
+	tidbSvc := getNewTiDBServiceOrNil(tc)
+	if tidbSvc == nil {
+		return nil
+	}
+	ports := make([]corev1.ServicePort, 0, len(tidbSvc.Spec.Ports))
+	for _, port := range tidbSvc.Spec.Ports {
+		port.NodePort = 0
+		ports = append(ports, port)
+	}
+	svcLabels := label.New().Instance(tc.GetInstanceName()).TiDB().UsedByEndUser().Labels()
+	svcLabels[label.ZoneLabelKey] = zone
+	selector := label.New().Instance(tc.GetInstanceName()).TiDB().Labels()
+	selector[label.ZoneLabelKey] = zone
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            zoneServiceName(tc, zone),
+			Namespace:       tc.Namespace,
+			Labels:          svcLabels,
+			OwnerReferences: tidbSvc.OwnerReferences,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports:    ports,
+			Selector: selector,
+		},
+	}
+}
+
+// TiDBZoneServicesConfigMapName returns the name of the ConfigMap which lists the per-zone TiDB services
+func TiDBZoneServicesConfigMapName(tcName string) string {
+	return fmt.Sprintf("%s-tidb-zones", tcName)
+}
+
+// getNewTiDBZoneServicesConfigMap returns the ConfigMap for the applications to discover the TiDB service of
+// their zone, the keys are the zones and the values are the addresses of the per-zone services.
+func getNewTiDBZoneServicesConfigMap(tc *v1alpha1.TidbCluster, zones []string) *corev1.ConfigMap {
+	data := map[string]string{}
+	for _, zone := range zones {
+		key := invalidConfigMapKeyChars.ReplaceAllString(zone, "_")
+		host := fmt.Sprintf("%s.%s.svc", zoneServiceName(tc, zone), tc.GetNamespace())
+		if tc.Spec.ClusterDomain != "" {
+			host = fmt.Sprintf("%s.%s", host, tc.Spec.ClusterDomain)
+		}
+		data[key] = fmt.Sprintf("%s:%d", host, tc.Spec.TiDB.GetServicePort())
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            TiDBZoneServicesConfigMapName(tc.GetName()),
+			Namespace:       tc.GetNamespace(),
+			Labels:          label.New().Instance(tc.GetInstanceName()).TiDB().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: data,
+	}
+}
+
+// syncTiDBZoneServicesConfigMap syncs the ConfigMap listing the per-zone TiDB services, it's removed if there
+// is no per-zone service.
+func (m *tidbMemberManager) syncTiDBZoneServicesConfigMap(tc *v1alpha1.TidbCluster, zones []string) error {
+	ns := tc.GetNamespace()
+	name := TiDBZoneServicesConfigMapName(tc.GetName())
+	if len(zones) == 0 {
+		cm, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(name)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("syncTiDBZoneServicesConfigMap: failed to get configmap %s/%s, error: %v", ns, name, err)
+		}
+		if err := m.deps.ConfigMapControl.DeleteConfigMap(tc, cm); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncTiDBZoneServicesConfigMap: failed to delete configmap %s/%s, error: %v", ns, name, err)
+		}
+		return nil
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateConfigMap(tc, getNewTiDBZoneServicesConfigMap(tc, zones)); err != nil {
+		return fmt.Errorf("syncTiDBZoneServicesConfigMap: failed to create or update configmap %s/%s, error: %v", ns, name, err)
+	}
+	return nil
+}
+
+// nodeZone returns the zone of the node from the well-known labels.
+func nodeZone(node *corev1.Node) string {
+	for _, key := range nodeZoneLabels {
+		if zone := node.Labels[key]; zone != "" {
+			return zone
+		}
+	}
+	return ""
+}
+
+// syncTiDBZoneLocal labels the TiDB pods with the zones of their nodes, and syncs the per-zone TiDB services
+// for the zones of the TiDB pods if the zone local routing is in the PerZoneService mode. The per-zone services
+// of the zones without TiDB pods, or all of them if the mode is changed, are removed.
+func (m *tidbMemberManager) syncTiDBZoneLocal(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for tidb zone local routing", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	var zoneLocal *v1alpha1.TiDBZoneLocalRouting
+	if tc.Spec.TiDB.Service != nil {
+		zoneLocal = tc.Spec.TiDB.Service.ZoneLocal
+	}
+
+	zones := sets.NewString()
+	if zoneLocal != nil {
+		selector, err := label.New().Instance(tc.GetInstanceName()).TiDB().Selector()
+		if err != nil {
+			return err
+		}
+		pods, err := m.deps.PodLister.Pods(ns).List(selector)
+		if err != nil {
+			return fmt.Errorf("syncTiDBZoneLocal: failed to list pods for cluster %s/%s, error: %v", ns, tc.GetName(), err)
+		}
+		var errs []error
+		for _, pod := range pods {
+			zone, err := m.labelTiDBPodZone(tc, pod)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if zone != "" {
+				zones.Insert(zone)
+			}
+		}
+		if len(errs) > 0 {
+			return errorutils.NewAggregate(errs)
+		}
+	}
+	if zoneLocal == nil || zoneLocal.Mode != v1alpha1.TiDBZoneLocalModePerZoneService {
+		zones = sets.NewString()
+	}
+
+	for _, zone := range zones.List() {
+		newSvc := getNewTiDBZoneServiceOrNil(tc, zone)
+		if newSvc == nil {
+			continue
+		}
+		newSvc = overrideService(tc, m.deps.Recorder, newSvc)
+		if err := CreateOrUpdateService(m.deps.ServiceLister, m.deps.ServiceControl, newSvc, tc); err != nil {
+			return err
+		}
+	}
+	if err := m.syncTiDBZoneServicesConfigMap(tc, zones.List()); err != nil {
+		return err
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiDB().UsedByEndUser().Selector()
+	if err != nil {
+		return err
+	}
+	svcs, err := m.deps.ServiceLister.Services(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("syncTiDBZoneLocal: failed to list services for cluster %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	sort.Slice(svcs, func(i, j int) bool { return svcs[i].Name < svcs[j].Name })
+	for _, svc := range svcs {
+		zone, ok := svc.Labels[label.ZoneLabelKey]
+		if !ok || zones.Has(zone) {
+			continue
+		}
+		if err := m.deps.ServiceControl.DeleteService(tc, svc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncTiDBZoneLocal: failed to delete svc %s/%s of zone %q, error: %v", ns, svc.Name, zone, err)
+		}
+		klog.Infof("TidbCluster: [%s/%s], tidb service %s of zone %q is deleted", ns, tc.GetName(), svc.Name, zone)
+	}
+	return nil
+}
+
+// labelTiDBPodZone labels the TiDB pod with the zone of its node, and returns the zone of the pod.
+func (m *tidbMemberManager) labelTiDBPodZone(tc *v1alpha1.TidbCluster, pod *corev1.Pod) (string, error) {
+	if pod.Spec.NodeName == "" || m.deps.NodeLister == nil {
+		return pod.Labels[label.ZoneLabelKey], nil
+	}
+	node, err := m.deps.NodeLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return pod.Labels[label.ZoneLabelKey], nil
+		}
+		return "", fmt.Errorf("labelTiDBPodZone: failed to get node %s of pod %s/%s, error: %v", pod.Spec.NodeName, pod.Namespace, pod.Name, err)
+	}
+	zone := nodeZone(node)
+	if zone == "" || pod.Labels[label.ZoneLabelKey] == zone {
+		return zone, nil
+	}
+	newPod := pod.DeepCopy()
+	if newPod.Labels == nil {
+		newPod.Labels = map[string]string{}
+	}
+	newPod.Labels[label.ZoneLabelKey] = zone
+	if _, err := m.deps.PodControl.UpdatePod(tc, newPod); err != nil {
+		return "", err
+	}
+	klog.Infof("TidbCluster: [%s/%s], tidb pod %s is labeled with zone %q", tc.GetNamespace(), tc.GetName(), pod.Name, zone)
+	return zone, nil
+}
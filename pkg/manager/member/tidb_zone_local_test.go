@@ -0,0 +1,89 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestSyncTiDBZoneLocal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+		ServiceSpec: v1alpha1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		ZoneLocal:   &v1alpha1.TiDBZoneLocalRouting{Mode: v1alpha1.TiDBZoneLocalModePerZoneService},
+	}
+
+	g.Expect(indexers.node.Add(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-east-1a"}},
+	})).To(Succeed())
+	g.Expect(indexers.node.Add(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{corev1.LabelZoneFailureDomain: "US_East_1b"}},
+	})).To(Succeed())
+	for name, node := range map[string]string{"test-tidb-0": "node-1", "test-tidb-1": "node-2", "test-tidb-2": ""} {
+		g.Expect(indexers.pod.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tc.Namespace,
+				Name:      name,
+				Labels:    label.New().Instance(tc.GetInstanceName()).TiDB().Labels(),
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+		})).To(Succeed())
+	}
+
+	g.Expect(tmm.syncTiDBZoneLocal(tc)).To(Succeed())
+
+	// the pods are labeled with the zones of their nodes
+	pod, err := tmm.deps.PodLister.Pods(tc.Namespace).Get("test-tidb-0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pod.Labels[label.ZoneLabelKey]).To(Equal("us-east-1a"))
+	pod, err = tmm.deps.PodLister.Pods(tc.Namespace).Get("test-tidb-2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pod.Labels).NotTo(HaveKey(label.ZoneLabelKey))
+
+	// a ClusterIP service is created for each zone
+	svc, err := tmm.deps.ServiceLister.Services(tc.Namespace).Get("test-tidb-us-east-1a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ZoneLabelKey, "us-east-1a"))
+	g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(label.ComponentLabelKey, label.TiDBLabelVal))
+	svc, err = tmm.deps.ServiceLister.Services(tc.Namespace).Get("test-tidb-us-east-1b")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(svc.Labels).To(HaveKeyWithValue(label.ZoneLabelKey, "US_East_1b"))
+
+	// the zone services are listed in the discovery configmap
+	cm := &corev1.ConfigMap{}
+	cli := tmm.deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: TiDBZoneServicesConfigMapName(tc.Name)}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{
+		"us-east-1a": "test-tidb-us-east-1a.default.svc:4000",
+		"US_East_1b": "test-tidb-us-east-1b.default.svc:4000",
+	}))
+
+	// the topology aware routing is enabled on the TiDB service
+	tc.Spec.TiDB.Service.ZoneLocal.Mode = v1alpha1.TiDBZoneLocalModeTopologyAwareHints
+	g.Expect(getNewTiDBServiceOrNil(tc).Annotations).To(HaveKeyWithValue("service.kubernetes.io/topology-mode", "Auto"))
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+// componentImage is the image used by a component
+type componentImage struct {
+	memberType v1alpha1.MemberType
+	image      string
+}
+
+// versionCatalog maps the components to the constraints of their supported versions
+type versionCatalog map[v1alpha1.MemberType]*semver.Constraints
+
+type airGapManager struct {
+	deps *controller.Dependencies
+}
+
+// NewAirGapManager returns a manager which validates the images and versions of
+// the components before syncing them in the air-gapped mode.
+func NewAirGapManager(deps *controller.Dependencies) manager.Manager {
+	return &airGapManager{
+		deps: deps,
+	}
+}
+
+func (m *airGapManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !m.deps.CLIConfig.AirGapped {
+		return nil
+	}
+
+	var catalog versionCatalog
+	if m.deps.CLIConfig.VersionCatalogConfigMap != "" {
+		var err error
+		catalog, err = m.getVersionCatalog()
+		if err != nil {
+			return fmt.Errorf("failed to get version catalog for tc %s/%s, error: %v", tc.Namespace, tc.Name, err)
+		}
+	}
+
+	var errs []string
+	for _, comp := range getComponentImages(tc) {
+		if !util.IsImagePinned(comp.image) {
+			errs = append(errs, fmt.Sprintf("image %q of %s is not pinned by a tag or digest", comp.image, comp.memberType))
+			continue
+		}
+		if err := catalog.validate(comp); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		msg := strings.Join(errs, "; ")
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedAirGapValidation", msg)
+		return fmt.Errorf("tc %s/%s is not valid in the air-gapped mode: %s", tc.Namespace, tc.Name, msg)
+	}
+	return nil
+}
+
+func (m *airGapManager) getVersionCatalog() (versionCatalog, error) {
+	ns, name, err := cache.SplitMetaNamespaceKey(m.deps.CLIConfig.VersionCatalogConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	// the version catalog is not created by tidb-operator, so it is not in the label filtered lister
+	cm, err := m.deps.KubeClientset.CoreV1().ConfigMaps(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return parseVersionCatalog(cm.Data)
+}
+
+func parseVersionCatalog(data map[string]string) (versionCatalog, error) {
+	catalog := versionCatalog{}
+	for k, v := range data {
+		memberType := v1alpha1.MemberType(k)
+		switch memberType {
+		case v1alpha1.PDMemberType, v1alpha1.TiKVMemberType, v1alpha1.TiDBMemberType, v1alpha1.TiFlashMemberType,
+			v1alpha1.TiCDCMemberType, v1alpha1.TiProxyMemberType, v1alpha1.PumpMemberType:
+		default:
+			return nil, fmt.Errorf("unknown component %s in version catalog", k)
+		}
+		constraints, err := semver.NewConstraint(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid versions %q of component %s: %v", v, k, err)
+		}
+		catalog[memberType] = constraints
+	}
+	return catalog, nil
+}
+
+// validate returns an error if the version of the component is not supported by the catalog,
+// the components which are not in the catalog or pinned by a digest only are not validated.
+func (c versionCatalog) validate(comp componentImage) error {
+	constraints, ok := c[comp.memberType]
+	if !ok {
+		return nil
+	}
+	version := imageTag(comp.image)
+	if version == "" {
+		return nil
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("version %s of %s is not semantic versioning", version, comp.memberType)
+	}
+	if !constraints.Check(v) {
+		return fmt.Errorf("version %s of %s is not supported by the version catalog", version, comp.memberType)
+	}
+	return nil
+}
+
+// imageTag returns the tag of the image, or empty if the image has no tag
+func imageTag(image string) string {
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		image = image[:i]
+	}
+	name := image[strings.LastIndexByte(image, '/')+1:]
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// getComponentImages returns the images of all the components in the tidb cluster
+func getComponentImages(tc *v1alpha1.TidbCluster) []componentImage {
+	var images []componentImage
+	if tc.Spec.PD != nil {
+		images = append(images, componentImage{v1alpha1.PDMemberType, tc.PDImage()})
+	}
+	if tc.Spec.TiKV != nil {
+		images = append(images, componentImage{v1alpha1.TiKVMemberType, tc.TiKVImage()})
+	}
+	if tc.Spec.TiDB != nil {
+		images = append(images, componentImage{v1alpha1.TiDBMemberType, tc.TiDBImage()})
+	}
+	if tc.Spec.TiFlash != nil {
+		images = append(images, componentImage{v1alpha1.TiFlashMemberType, tc.TiFlashImage()})
+	}
+	if tc.Spec.TiCDC != nil {
+		images = append(images, componentImage{v1alpha1.TiCDCMemberType, tc.TiCDCImage()})
+	}
+	if tc.Spec.TiProxy != nil {
+		images = append(images, componentImage{v1alpha1.TiProxyMemberType, tc.TiProxyImage()})
+	}
+	if image := tc.PumpImage(); image != nil {
+		images = append(images, componentImage{v1alpha1.PumpMemberType, *image})
+	}
+	// the helper image is used by the sidecars and init containers of the components
+	images = append(images, componentImage{"helper", tc.HelperImage()})
+	return images
+}
+
+type FakeAirGapManager struct {
+	err error
+}
+
+func NewFakeAirGapManager() *FakeAirGapManager {
+	return &FakeAirGapManager{}
+}
+
+func (m *FakeAirGapManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeAirGapManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestParseVersionCatalog(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	catalog, err := parseVersionCatalog(map[string]string{
+		"pd":   ">= v6.5.0, < v8.0.0",
+		"tikv": " >= v6.5.0 ",
+	})
+	g.Expect(err).To(Succeed())
+	g.Expect(catalog).To(HaveLen(2))
+
+	_, err = parseVersionCatalog(map[string]string{"tidb-operator": ">= v1.5.0"})
+	g.Expect(err).To(HaveOccurred())
+	_, err = parseVersionCatalog(map[string]string{"pd": "v6.x.y"})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAirGapManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	m := NewAirGapManager(fakeDeps)
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic"},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: "latest",
+			PD:      &v1alpha1.PDSpec{BaseImage: "pingcap/pd"},
+			TiKV:    &v1alpha1.TiKVSpec{BaseImage: "pingcap/tikv"},
+		},
+	}
+
+	// air-gapped mode is disabled
+	g.Expect(m.Sync(tc)).To(Succeed())
+
+	// the images are not pinned
+	fakeDeps.CLIConfig.AirGapped = true
+	g.Expect(m.Sync(tc)).NotTo(Succeed())
+
+	tc.Spec.Version = "v7.1.0"
+	tc.Spec.TiKV.Image = "pingcap/tikv@sha256:0d3a3b4f7cb5f2b5d8e4b5c7ad3e5a1b2c3d4e5f60718293a4b5c6d7e8f90a1b"
+	tc.Spec.TiKV.BaseImage = ""
+	g.Expect(m.Sync(tc)).To(Succeed())
+
+	// the version catalog does not exist
+	fakeDeps.CLIConfig.VersionCatalogConfigMap = "pingcap/version-catalog"
+	g.Expect(m.Sync(tc)).NotTo(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "version-catalog"},
+		Data: map[string]string{
+			"pd":   ">= v7.5.0",
+			"tikv": ">= v7.5.0",
+		},
+	}
+	_, err := fakeDeps.KubeClientset.CoreV1().ConfigMaps("pingcap").Create(context.TODO(), cm, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	// pd is not supported by the catalog, and tikv pinned by a digest only is not validated
+	g.Expect(m.Sync(tc)).NotTo(Succeed())
+
+	tc.Spec.Version = "v7.5.1"
+	g.Expect(m.Sync(tc)).To(Succeed())
+}
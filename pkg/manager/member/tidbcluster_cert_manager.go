@@ -0,0 +1,227 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	certManagerGroup         = "cert-manager.io"
+	certManagerDefaultIssuer = "Issuer"
+)
+
+// certManagerCertificateGVK is the GroupVersionKind of the cert-manager Certificate
+var certManagerCertificateGVK = schema.GroupVersionKind{Group: certManagerGroup, Version: "v1", Kind: "Certificate"}
+
+// tlsCertificate describes a cert-manager Certificate issuing a cluster TLS secret
+type tlsCertificate struct {
+	secretName string
+	commonName string
+	dnsNames   []string
+	// server indicates whether the cert is used by a server, the cert of the client is used by client auth only
+	server bool
+}
+
+type tlsCertManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTLSCertManager returns a manager which creates the cert-manager Certificates of the cluster
+// TLS secrets when spec.tlsCluster.certManager is set. The secrets are issued and renewed by cert-manager.
+func NewTLSCertManager(deps *controller.Dependencies) manager.Manager {
+	return &tlsCertManager{
+		deps: deps,
+	}
+}
+
+func (m *tlsCertManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !tc.IsTLSClusterEnabled() || tc.Spec.TLSCluster.CertManager == nil {
+		return nil
+	}
+
+	for _, cert := range getTLSCertificates(tc) {
+		if err := m.syncCertificate(tc, newCertManagerCertificate(tc, cert)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncCertificate creates the Certificate or updates its spec if it's changed
+func (m *tlsCertManager) syncCertificate(tc *v1alpha1.TidbCluster, desired *unstructured.Unstructured) error {
+	ns := tc.GetNamespace()
+	name := desired.GetName()
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(certManagerCertificateGVK)
+	err := m.deps.GenericClient.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: name}, existing)
+	if errors.IsNotFound(err) {
+		if err := m.deps.GenericClient.Create(context.TODO(), desired); err != nil {
+			return fmt.Errorf("failed to create certificate %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+		}
+		klog.Infof("TidbCluster: [%s/%s], create certificate %s", ns, tc.GetName(), name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get certificate %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+	}
+
+	if !metav1.IsControlledBy(existing, tc) {
+		// the certificates created by the users are not touched
+		klog.V(4).Infof("TidbCluster: [%s/%s], certificate %s is not controlled by the tc, skip", ns, tc.GetName(), name)
+		return nil
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	if err := m.deps.GenericClient.Update(context.TODO(), existing); err != nil {
+		return fmt.Errorf("failed to update certificate %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+	}
+	klog.Infof("TidbCluster: [%s/%s], update certificate %s", ns, tc.GetName(), name)
+	return nil
+}
+
+// getTLSCertificates returns the Certificates of the cluster TLS secrets of the tidb cluster,
+// the discovery shares the secret of PD.
+func getTLSCertificates(tc *v1alpha1.TidbCluster) []tlsCertificate {
+	tcName := tc.GetName()
+	components := []struct {
+		enabled bool
+		name    string
+		svcName string
+		peer    string
+	}{
+		{tc.Spec.PD != nil, label.PDLabelVal, controller.PDMemberName(tcName), controller.PDPeerMemberName(tcName)},
+		{tc.Spec.TiKV != nil, label.TiKVLabelVal, controller.TiKVMemberName(tcName), controller.TiKVPeerMemberName(tcName)},
+		{tc.Spec.TiDB != nil, label.TiDBLabelVal, controller.TiDBMemberName(tcName), controller.TiDBPeerMemberName(tcName)},
+		{tc.Spec.TiFlash != nil, label.TiFlashLabelVal, controller.TiFlashMemberName(tcName), controller.TiFlashPeerMemberName(tcName)},
+		{tc.Spec.TiCDC != nil, label.TiCDCLabelVal, controller.TiCDCMemberName(tcName), controller.TiCDCPeerMemberName(tcName)},
+		{tc.Spec.Pump != nil, label.PumpLabelVal, controller.PumpMemberName(tcName), controller.PumpPeerMemberName(tcName)},
+		{tc.Spec.TiProxy != nil, label.TiProxyLabelVal, controller.TiProxyMemberName(tcName), controller.TiProxyPeerMemberName(tcName)},
+	}
+
+	var certs []tlsCertificate
+	for _, c := range components {
+		if !c.enabled {
+			continue
+		}
+		dnsNames := dmClusterCertHosts(tc.GetNamespace(), c.svcName, c.peer)
+		if tc.Spec.ClusterDomain != "" {
+			dnsNames = append(dnsNames,
+				fmt.Sprintf("%s.%s.svc.%s", c.svcName, tc.GetNamespace(), tc.Spec.ClusterDomain),
+				fmt.Sprintf("*.%s.%s.svc.%s", c.peer, tc.GetNamespace(), tc.Spec.ClusterDomain),
+			)
+		}
+		certs = append(certs, tlsCertificate{
+			secretName: util.ClusterTLSSecretName(tcName, c.name),
+			commonName: c.svcName,
+			dnsNames:   append(dnsNames, "localhost"),
+			server:     true,
+		})
+	}
+	certs = append(certs, tlsCertificate{
+		secretName: util.ClusterClientTLSSecretName(tcName),
+		commonName: fmt.Sprintf("%s-cluster-client", tcName),
+	})
+	return certs
+}
+
+// newCertManagerCertificate returns the cert-manager Certificate issuing the secret
+func newCertManagerCertificate(tc *v1alpha1.TidbCluster, cert tlsCertificate) *unstructured.Unstructured {
+	cfg := tc.Spec.TLSCluster.CertManager
+	issuerRef := map[string]interface{}{
+		"name":  cfg.IssuerRef.Name,
+		"kind":  certManagerDefaultIssuer,
+		"group": certManagerGroup,
+	}
+	if cfg.IssuerRef.Kind != "" {
+		issuerRef["kind"] = cfg.IssuerRef.Kind
+	}
+	if cfg.IssuerRef.Group != "" {
+		issuerRef["group"] = cfg.IssuerRef.Group
+	}
+
+	spec := map[string]interface{}{
+		"secretName": cert.secretName,
+		"commonName": cert.commonName,
+		"issuerRef":  issuerRef,
+		"usages":     []interface{}{"client auth"},
+		"secretTemplate": map[string]interface{}{
+			"labels": toInterfaceMap(label.New().Instance(tc.GetInstanceName()).Labels()),
+		},
+	}
+	if cert.server {
+		spec["usages"] = []interface{}{"server auth", "client auth"}
+		dnsNames := make([]interface{}, 0, len(cert.dnsNames))
+		for _, name := range cert.dnsNames {
+			dnsNames = append(dnsNames, name)
+		}
+		spec["dnsNames"] = dnsNames
+		spec["ipAddresses"] = []interface{}{"127.0.0.1", "::1"}
+	}
+	if cfg.Duration != nil {
+		spec["duration"] = cfg.Duration.Duration.String()
+	}
+	if cfg.RenewBefore != nil {
+		spec["renewBefore"] = cfg.RenewBefore.Duration.String()
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(certManagerCertificateGVK)
+	obj.SetNamespace(tc.GetNamespace())
+	obj.SetName(cert.secretName)
+	obj.SetLabels(label.New().Instance(tc.GetInstanceName()).Labels())
+	obj.SetOwnerReferences([]metav1.OwnerReference{controller.GetOwnerRef(tc)})
+	return obj
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+type FakeTLSCertManager struct {
+	err error
+}
+
+func NewFakeTLSCertManager() *FakeTLSCertManager {
+	return &FakeTLSCertManager{}
+}
+
+func (m *FakeTLSCertManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTLSCertManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
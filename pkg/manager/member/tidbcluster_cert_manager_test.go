@@ -0,0 +1,68 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetTLSCertificates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{
+		Enabled: true,
+		CertManager: &v1alpha1.TLSCertManager{
+			IssuerRef: v1alpha1.TLSCertIssuerRef{Name: "ca-issuer", Kind: "ClusterIssuer"},
+			Duration:  &metav1.Duration{Duration: 2160 * time.Hour},
+		},
+	}
+
+	certs := getTLSCertificates(tc)
+	var secretNames []string
+	for _, cert := range certs {
+		secretNames = append(secretNames, cert.secretName)
+	}
+	g.Expect(secretNames).To(Equal([]string{
+		"test-pd-cluster-secret",
+		"test-tikv-cluster-secret",
+		"test-tidb-cluster-secret",
+		"test-cluster-client-secret",
+	}))
+	g.Expect(certs[0].dnsNames).To(ContainElements("test-pd", "test-pd.default.svc", "*.test-pd-peer.default.svc", "localhost"))
+
+	pd := newCertManagerCertificate(tc, certs[0])
+	g.Expect(pd.GetAPIVersion()).To(Equal("cert-manager.io/v1"))
+	g.Expect(pd.GetKind()).To(Equal("Certificate"))
+	g.Expect(pd.GetName()).To(Equal("test-pd-cluster-secret"))
+	g.Expect(pd.GetOwnerReferences()).To(HaveLen(1))
+	issuerRef, _, _ := unstructured.NestedStringMap(pd.Object, "spec", "issuerRef")
+	g.Expect(issuerRef).To(Equal(map[string]string{"name": "ca-issuer", "kind": "ClusterIssuer", "group": "cert-manager.io"}))
+	duration, _, _ := unstructured.NestedString(pd.Object, "spec", "duration")
+	g.Expect(duration).To(Equal("2160h0m0s"))
+	usages, _, _ := unstructured.NestedStringSlice(pd.Object, "spec", "usages")
+	g.Expect(usages).To(Equal([]string{"server auth", "client auth"}))
+
+	client := newCertManagerCertificate(tc, certs[3])
+	usages, _, _ = unstructured.NestedStringSlice(client.Object, "spec", "usages")
+	g.Expect(usages).To(Equal([]string{"client auth"}))
+	_, found, _ := unstructured.NestedStringSlice(client.Object, "spec", "dnsNames")
+	g.Expect(found).To(BeFalse())
+}
@@ -0,0 +1,334 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+const (
+	// keys of the price sheet ConfigMap, all the prices are monthly unit prices
+	priceSheetCurrencyKey = "currency"
+	// price of one CPU core
+	priceSheetCPUKey = "cpu"
+	// price of 1 GiB memory
+	priceSheetMemoryKey = "memory"
+	// price of 1 GiB storage, it can be overridden by `storage.<storageClassName>`
+	priceSheetStorageKey = "storage"
+
+	gibibyte = 1024 * 1024 * 1024
+)
+
+var (
+	// costEstimateMemberTypes are the components whose cost is estimated
+	costEstimateMemberTypes = []v1alpha1.MemberType{
+		v1alpha1.PDMemberType,
+		v1alpha1.TiKVMemberType,
+		v1alpha1.TiDBMemberType,
+		v1alpha1.TiFlashMemberType,
+		v1alpha1.TiCDCMemberType,
+		v1alpha1.TiProxyMemberType,
+		v1alpha1.PumpMemberType,
+	}
+	// costEstimateTypes are the values of the type label of the cost estimate metrics
+	costEstimateTypes = []string{"compute", "storage"}
+)
+
+// priceSheet contains the monthly unit prices used to estimate the cost
+type priceSheet struct {
+	currency string
+	cpu      float64
+	memory   float64
+	storage  float64
+	// storageClasses contains the storage prices of specific storage classes
+	storageClasses map[string]float64
+}
+
+func (p *priceSheet) storagePrice(storageClassName *string) float64 {
+	if storageClassName != nil {
+		if price, ok := p.storageClasses[*storageClassName]; ok {
+			return price
+		}
+	}
+	return p.storage
+}
+
+// componentStorage is a volume requested by each pod of a component
+type componentStorage struct {
+	size             string
+	storageClassName *string
+}
+
+// componentRequests is the resources requested by a component
+type componentRequests struct {
+	memberType v1alpha1.MemberType
+	replicas   int32
+	requests   corev1.ResourceList
+	storages   []componentStorage
+}
+
+type costEstimateManager struct {
+	deps *controller.Dependencies
+}
+
+// NewCostEstimateManager returns a manager which estimates the monthly cost of
+// each component by the price sheet and records it in the status and metrics.
+func NewCostEstimateManager(deps *controller.Dependencies) manager.Manager {
+	return &costEstimateManager{
+		deps: deps,
+	}
+}
+
+func (m *costEstimateManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if m.deps.CLIConfig.PriceSheetConfigMap == "" {
+		tc.Status.CostEstimates = nil
+		DeleteCostEstimateMetrics(tc.Namespace, tc.Name)
+		return nil
+	}
+
+	sheet, err := m.getPriceSheet()
+	if err != nil {
+		// the cost estimate is only informative, do not block the reconciliation
+		klog.Warningf("failed to get price sheet for tc %s/%s, error: %v", tc.Namespace, tc.Name, err)
+		return nil
+	}
+
+	estimates := map[v1alpha1.MemberType]v1alpha1.ComponentCostEstimate{}
+	for _, comp := range getComponentRequests(tc) {
+		compute, storage := estimateComponentCost(comp, sheet)
+		estimates[comp.memberType] = v1alpha1.ComponentCostEstimate{
+			Currency: sheet.currency,
+			Compute:  formatCost(compute),
+			Storage:  formatCost(storage),
+			Total:    formatCost(compute + storage),
+		}
+
+		component := string(comp.memberType)
+		metrics.ClusterCostEstimate.WithLabelValues(tc.Namespace, tc.Name, component, "compute").Set(compute)
+		metrics.ClusterCostEstimate.WithLabelValues(tc.Namespace, tc.Name, component, "storage").Set(storage)
+	}
+	// the components removed from the spec are not estimated any more
+	for _, memberType := range costEstimateMemberTypes {
+		if _, ok := estimates[memberType]; !ok {
+			deleteComponentCostEstimateMetrics(tc.Namespace, tc.Name, memberType)
+		}
+	}
+	tc.Status.CostEstimates = estimates
+	return nil
+}
+
+// DeleteCostEstimateMetrics deletes the cost estimate metrics of a TidbCluster, it should be
+// called after the TidbCluster is deleted
+func DeleteCostEstimateMetrics(namespace, name string) {
+	for _, memberType := range costEstimateMemberTypes {
+		deleteComponentCostEstimateMetrics(namespace, name, memberType)
+	}
+}
+
+func deleteComponentCostEstimateMetrics(namespace, name string, memberType v1alpha1.MemberType) {
+	for _, typ := range costEstimateTypes {
+		metrics.ClusterCostEstimate.DeleteLabelValues(namespace, name, string(memberType), typ)
+	}
+}
+
+func (m *costEstimateManager) getPriceSheet() (*priceSheet, error) {
+	ns, name, err := cache.SplitMetaNamespaceKey(m.deps.CLIConfig.PriceSheetConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	cm, err := m.deps.PriceSheetLister.ConfigMaps(ns).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return parsePriceSheet(cm.Data)
+}
+
+func parsePriceSheet(data map[string]string) (*priceSheet, error) {
+	sheet := &priceSheet{
+		currency:       data[priceSheetCurrencyKey],
+		storageClasses: map[string]float64{},
+	}
+	for k, v := range data {
+		if k == priceSheetCurrencyKey {
+			continue
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q of key %s: %v", v, k, err)
+		}
+		if price < 0 {
+			return nil, fmt.Errorf("price of key %s should not be negative", k)
+		}
+		switch {
+		case k == priceSheetCPUKey:
+			sheet.cpu = price
+		case k == priceSheetMemoryKey:
+			sheet.memory = price
+		case k == priceSheetStorageKey:
+			sheet.storage = price
+		case strings.HasPrefix(k, priceSheetStorageKey+"."):
+			sheet.storageClasses[strings.TrimPrefix(k, priceSheetStorageKey+".")] = price
+		default:
+			return nil, fmt.Errorf("unknown key %s in price sheet", k)
+		}
+	}
+	return sheet, nil
+}
+
+// estimateComponentCost returns the monthly compute cost and storage cost of a component
+func estimateComponentCost(comp componentRequests, sheet *priceSheet) (float64, float64) {
+	replicas := float64(comp.replicas)
+
+	var compute float64
+	if cpu, ok := comp.requests[corev1.ResourceCPU]; ok {
+		compute += float64(cpu.MilliValue()) / 1000 * sheet.cpu
+	}
+	if mem, ok := comp.requests[corev1.ResourceMemory]; ok {
+		compute += float64(mem.Value()) / gibibyte * sheet.memory
+	}
+
+	var storage float64
+	for _, s := range comp.storages {
+		if s.size == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(s.size)
+		if err != nil {
+			klog.Warningf("failed to parse storage size %q of %s, skip it in cost estimate", s.size, comp.memberType)
+			continue
+		}
+		storage += float64(quantity.Value()) / gibibyte * sheet.storagePrice(s.storageClassName)
+	}
+
+	return compute * replicas, storage * replicas
+}
+
+func getComponentRequests(tc *v1alpha1.TidbCluster) []componentRequests {
+	var comps []componentRequests
+	if tc.Spec.PD != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.PDMemberType,
+			replicas:   tc.Spec.PD.Replicas,
+			requests:   tc.Spec.PD.Requests,
+			storages: append(storageFromRequests(tc.Spec.PD.Requests, tc.Spec.PD.StorageClassName),
+				storageFromVolumes(tc.Spec.PD.StorageVolumes)...),
+		})
+	}
+	if tc.Spec.TiKV != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.TiKVMemberType,
+			replicas:   tc.Spec.TiKV.Replicas,
+			requests:   tc.Spec.TiKV.Requests,
+			storages: append(storageFromRequests(tc.Spec.TiKV.Requests, tc.Spec.TiKV.StorageClassName),
+				storageFromVolumes(tc.Spec.TiKV.AllStorageVolumes())...),
+		})
+	}
+	if tc.Spec.TiDB != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.TiDBMemberType,
+			replicas:   tc.Spec.TiDB.Replicas,
+			requests:   tc.Spec.TiDB.Requests,
+			storages:   storageFromVolumes(tc.Spec.TiDB.StorageVolumes),
+		})
+	}
+	if tc.Spec.TiFlash != nil {
+		var storages []componentStorage
+		for _, claim := range tc.Spec.TiFlash.StorageClaims {
+			storages = append(storages, storageFromRequests(claim.Resources.Requests, claim.StorageClassName)...)
+		}
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.TiFlashMemberType,
+			replicas:   tc.Spec.TiFlash.Replicas,
+			requests:   tc.Spec.TiFlash.Requests,
+			storages:   storages,
+		})
+	}
+	if tc.Spec.TiCDC != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.TiCDCMemberType,
+			replicas:   tc.Spec.TiCDC.Replicas,
+			requests:   tc.Spec.TiCDC.Requests,
+			storages:   storageFromVolumes(tc.Spec.TiCDC.StorageVolumes),
+		})
+	}
+	if tc.Spec.TiProxy != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.TiProxyMemberType,
+			replicas:   tc.Spec.TiProxy.Replicas,
+			requests:   tc.Spec.TiProxy.Requests,
+			storages:   storageFromVolumes(tc.Spec.TiProxy.StorageVolumes),
+		})
+	}
+	if tc.Spec.Pump != nil {
+		comps = append(comps, componentRequests{
+			memberType: v1alpha1.PumpMemberType,
+			replicas:   tc.Spec.Pump.Replicas,
+			requests:   tc.Spec.Pump.Requests,
+			storages:   storageFromRequests(tc.Spec.Pump.Requests, tc.Spec.Pump.StorageClassName),
+		})
+	}
+	return comps
+}
+
+func storageFromRequests(requests corev1.ResourceList, storageClassName *string) []componentStorage {
+	quantity, ok := requests[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	return []componentStorage{{size: quantity.String(), storageClassName: storageClassName}}
+}
+
+func storageFromVolumes(volumes []v1alpha1.StorageVolume) []componentStorage {
+	var storages []componentStorage
+	for _, v := range volumes {
+		storages = append(storages, componentStorage{size: v.StorageSize, storageClassName: v.StorageClassName})
+	}
+	return storages
+}
+
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 2, 64)
+}
+
+type FakeCostEstimateManager struct {
+	err error
+}
+
+func NewFakeCostEstimateManager() *FakeCostEstimateManager {
+	return &FakeCostEstimateManager{}
+}
+
+func (m *FakeCostEstimateManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeCostEstimateManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
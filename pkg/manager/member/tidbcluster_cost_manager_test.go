@@ -0,0 +1,133 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+func TestParsePriceSheet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sheet, err := parsePriceSheet(map[string]string{
+		"currency":    "USD",
+		"cpu":         "20",
+		"memory":      " 2.5 ",
+		"storage":     "0.1",
+		"storage.ssd": "0.3",
+	})
+	g.Expect(err).To(Succeed())
+	g.Expect(sheet.currency).To(Equal("USD"))
+	g.Expect(sheet.cpu).To(Equal(20.0))
+	g.Expect(sheet.memory).To(Equal(2.5))
+	g.Expect(sheet.storagePrice(nil)).To(Equal(0.1))
+	g.Expect(sheet.storagePrice(pointer.StringPtr("ssd"))).To(Equal(0.3))
+	g.Expect(sheet.storagePrice(pointer.StringPtr("hdd"))).To(Equal(0.1))
+
+	_, err = parsePriceSheet(map[string]string{"cpu": "abc"})
+	g.Expect(err).To(HaveOccurred())
+	_, err = parsePriceSheet(map[string]string{"cpu": "-1"})
+	g.Expect(err).To(HaveOccurred())
+	_, err = parsePriceSheet(map[string]string{"gpu": "1"})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestEstimateComponentCost(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sheet := &priceSheet{
+		cpu:            20,
+		memory:         2,
+		storage:        0.1,
+		storageClasses: map[string]float64{"ssd": 0.5},
+	}
+	comp := componentRequests{
+		memberType: v1alpha1.TiKVMemberType,
+		replicas:   3,
+		requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+		storages: []componentStorage{
+			{size: "100Gi", storageClassName: pointer.StringPtr("ssd")},
+			{size: "10Gi"},
+			{size: "invalid"},
+		},
+	}
+	compute, storage := estimateComponentCost(comp, sheet)
+	g.Expect(compute).To(Equal((0.5*20 + 4*2) * 3.0))
+	g.Expect(storage).To(Equal((100*0.5 + 10*0.1) * 3.0))
+}
+
+func TestCostEstimateManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	m := NewCostEstimateManager(fakeDeps)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiKV.Requests = corev1.ResourceList{
+		corev1.ResourceCPU:     resource.MustParse("2"),
+		corev1.ResourceStorage: resource.MustParse("100Gi"),
+	}
+	tc.Status.CostEstimates = map[v1alpha1.MemberType]v1alpha1.ComponentCostEstimate{
+		v1alpha1.TiKVMemberType: {Total: "1.00"},
+	}
+
+	// cost estimate is disabled
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.CostEstimates).To(BeNil())
+
+	// the price sheet does not exist
+	fakeDeps.CLIConfig.PriceSheetConfigMap = "pingcap/price-sheet"
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.CostEstimates).To(BeNil())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "price-sheet"},
+		Data: map[string]string{
+			"currency": "USD",
+			"cpu":      "10",
+			"storage":  "0.1",
+		},
+	}
+	err := fakeDeps.KubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer().Add(cm)
+	g.Expect(err).To(Succeed())
+
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.CostEstimates).To(HaveKey(v1alpha1.TiFlashMemberType))
+	g.Expect(tc.Status.CostEstimates[v1alpha1.TiKVMemberType]).To(Equal(v1alpha1.ComponentCostEstimate{
+		Currency: "USD",
+		Compute:  "60.00",
+		Storage:  "30.00",
+		Total:    "90.00",
+	}))
+	g.Expect(tc.Status.CostEstimates[v1alpha1.PDMemberType].Total).To(Equal("60.00"))
+	g.Expect(tc.Status.CostEstimates[v1alpha1.TiDBMemberType].Total).To(Equal("0.00"))
+
+	// the metrics are deleted after the cost estimation is disabled
+	fakeDeps.CLIConfig.PriceSheetConfigMap = ""
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(metrics.ClusterCostEstimate.DeleteLabelValues(tc.Namespace, tc.Name, string(v1alpha1.TiKVMemberType), "compute")).To(BeFalse())
+}
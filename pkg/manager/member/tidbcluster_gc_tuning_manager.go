@@ -0,0 +1,267 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	gcTuningWindowStartedReason   = "GCTuningWindowStarted"
+	gcTuningWindowEndedReason     = "GCTuningWindowEnded"
+	gcTuningInvalidScheduleReason = "InvalidGCTuningSchedule"
+
+	gcLifeTimeVariable    = "tidb_gc_life_time"
+	gcConcurrencyVariable = "tidb_gc_concurrency"
+)
+
+// gcSettingsControl gets and sets the GC settings of a tidb cluster
+type gcSettingsControl interface {
+	GetGCSettings(tc *v1alpha1.TidbCluster, password string) (*v1alpha1.GCTuningSettings, error)
+	SetGCSettings(tc *v1alpha1.TidbCluster, password string, settings *v1alpha1.GCTuningSettings) error
+}
+
+// sqlGCSettingsControl gets and sets the GC settings by the global system variables of TiDB
+type sqlGCSettingsControl struct{}
+
+func (c *sqlGCSettingsControl) GetGCSettings(tc *v1alpha1.TidbCluster, password string) (*v1alpha1.GCTuningSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	db, err := util.OpenDB(ctx, util.GetDSN(tc, password))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var lifeTime, concurrency string
+	row := db.QueryRowContext(ctx, fmt.Sprintf("SELECT @@GLOBAL.%s, @@GLOBAL.%s", gcLifeTimeVariable, gcConcurrencyVariable))
+	if err := row.Scan(&lifeTime, &concurrency); err != nil {
+		return nil, err
+	}
+	c64, err := strconv.ParseInt(concurrency, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %v", gcConcurrencyVariable, concurrency, err)
+	}
+	return &v1alpha1.GCTuningSettings{
+		GCLifeTime:    pointer.StringPtr(lifeTime),
+		GCConcurrency: pointer.Int32Ptr(int32(c64)),
+	}, nil
+}
+
+func (c *sqlGCSettingsControl) SetGCSettings(tc *v1alpha1.TidbCluster, password string, settings *v1alpha1.GCTuningSettings) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	db, err := util.OpenDB(ctx, util.GetDSN(tc, password))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return setGCSettings(ctx, db, settings)
+}
+
+func setGCSettings(ctx context.Context, db *sql.DB, settings *v1alpha1.GCTuningSettings) error {
+	if settings.GCLifeTime != nil {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("SET GLOBAL %s = ?", gcLifeTimeVariable), *settings.GCLifeTime); err != nil {
+			return err
+		}
+	}
+	if settings.GCConcurrency != nil {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("SET GLOBAL %s = ?", gcConcurrencyVariable), *settings.GCConcurrency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type gcTuningManager struct {
+	deps    *controller.Dependencies
+	control gcSettingsControl
+	now     func() time.Time
+}
+
+// NewGCTuningManager returns a manager which adjusts the GC settings of the tidb cluster in the
+// windows of its GC tuning policy, e.g. a shorter GC life time in the off-peak hours.
+//
+// The settings before a window are recorded in status.gcTuning when the window starts, and they are
+// restored after the window ends or the policy is removed. The windows are checked in each sync of
+// the tidb cluster, so a window starts or ends within a resync period.
+func NewGCTuningManager(deps *controller.Dependencies) manager.Manager {
+	return &gcTuningManager{
+		deps:    deps,
+		control: &sqlGCSettingsControl{},
+		now:     time.Now,
+	}
+}
+
+func (m *gcTuningManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !features.DefaultFeatureGate.Enabled(features.GCTuning) || tc.Spec.TiDB == nil {
+		return nil
+	}
+	policy := tc.Spec.GCTuning
+	status := tc.Status.GCTuning
+	inWindow := status != nil && status.ActiveWindow != ""
+	if policy == nil && !inWindow {
+		tc.Status.GCTuning = nil
+		return nil
+	}
+	// the settings are applied by TiDB, wait for it to be ready
+	if tc.Status.TiDB.StatefulSet == nil || tc.Status.TiDB.StatefulSet.ReadyReplicas == 0 {
+		return nil
+	}
+
+	password, err := m.getPassword(tc)
+	if err != nil {
+		return err
+	}
+	now := m.now()
+	var window *v1alpha1.GCTuningWindow
+	var windowEnd time.Time
+	if policy != nil {
+		window, windowEnd = m.activeWindow(tc, now)
+	}
+
+	if window == nil {
+		if !inWindow {
+			return nil
+		}
+		if err := m.applySettings(tc, password, status.OriginalSettings); err != nil {
+			return fmt.Errorf("failed to restore the gc settings of tc %s/%s after window %s, error: %v", tc.Namespace, tc.Name, status.ActiveWindow, err)
+		}
+		klog.Infof("gc tuning window %s of tc %s/%s ended, the gc settings are restored", status.ActiveWindow, tc.Namespace, tc.Name)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, gcTuningWindowEndedReason, "gc tuning window %s ended, the gc settings are restored", status.ActiveWindow)
+		tc.Status.GCTuning = &v1alpha1.GCTuningStatus{LastTransitionTime: metav1.NewTime(now)}
+		return nil
+	}
+
+	if !inWindow {
+		original, err := m.control.GetGCSettings(tc, password)
+		if err != nil {
+			return fmt.Errorf("failed to get the gc settings of tc %s/%s, error: %v", tc.Namespace, tc.Name, err)
+		}
+		status = &v1alpha1.GCTuningStatus{OriginalSettings: original}
+		tc.Status.GCTuning = status
+	}
+	if status.ActiveWindow != window.Name {
+		klog.Infof("gc tuning window %s of tc %s/%s started, it ends at %s", window.Name, tc.Namespace, tc.Name, windowEnd)
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, gcTuningWindowStartedReason, "gc tuning window %s started, it ends at %s", window.Name, windowEnd.Format(time.RFC3339))
+		status.ActiveWindow = window.Name
+		status.LastTransitionTime = metav1.NewTime(now)
+	}
+	end := metav1.NewTime(windowEnd)
+	status.WindowEnd = &end
+	// the settings are applied in each sync in case they are changed by the users in the window
+	if err := m.applySettings(tc, password, &window.GCTuningSettings); err != nil {
+		return fmt.Errorf("failed to apply the gc settings of window %s to tc %s/%s, error: %v", window.Name, tc.Namespace, tc.Name, err)
+	}
+	return nil
+}
+
+// activeWindow returns the first window which is active at now and its end time
+func (m *gcTuningManager) activeWindow(tc *v1alpha1.TidbCluster, now time.Time) (*v1alpha1.GCTuningWindow, time.Time) {
+	for i := range tc.Spec.GCTuning.Windows {
+		window := &tc.Spec.GCTuning.Windows[i]
+		start, active, err := activeScheduleWindow(tc, window.Schedule, window.Duration.Duration, now)
+		if err != nil {
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, gcTuningInvalidScheduleReason, "invalid schedule %q of gc tuning window %s: %v", window.Schedule, window.Name, err)
+			continue
+		}
+		if active {
+			return window, start.Add(window.Duration.Duration)
+		}
+	}
+	return nil, time.Time{}
+}
+
+// applySettings sets the settings which are different from the current ones
+func (m *gcTuningManager) applySettings(tc *v1alpha1.TidbCluster, password string, settings *v1alpha1.GCTuningSettings) error {
+	if settings == nil {
+		return nil
+	}
+	current, err := m.control.GetGCSettings(tc, password)
+	if err != nil {
+		return err
+	}
+	changes := &v1alpha1.GCTuningSettings{}
+	if settings.GCLifeTime != nil && !gcLifeTimeEqual(*settings.GCLifeTime, current.GCLifeTime) {
+		changes.GCLifeTime = settings.GCLifeTime
+	}
+	if settings.GCConcurrency != nil && (current.GCConcurrency == nil || *settings.GCConcurrency != *current.GCConcurrency) {
+		changes.GCConcurrency = settings.GCConcurrency
+	}
+	if changes.GCLifeTime == nil && changes.GCConcurrency == nil {
+		return nil
+	}
+	return m.control.SetGCSettings(tc, password, changes)
+}
+
+func (m *gcTuningManager) getPassword(tc *v1alpha1.TidbCluster) (string, error) {
+	if tc.Spec.GCTuning == nil || tc.Spec.GCTuning.PasswordSecret == nil {
+		return "", nil
+	}
+	name := *tc.Spec.GCTuning.PasswordSecret
+	secret, err := m.deps.SecretLister.Secrets(tc.Namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", controller.RequeueErrorf("password secret %s/%s of gc tuning does not exist", tc.Namespace, name)
+		}
+		return "", fmt.Errorf("failed to get secret %s/%s for tc %s/%s, error: %v", tc.Namespace, name, tc.Namespace, tc.Name, err)
+	}
+	return string(secret.Data[constants.TidbRootKey]), nil
+}
+
+// gcLifeTimeEqual compares the life times by their durations, TiDB returns `10m0s` for `10m`
+func gcLifeTimeEqual(desired string, current *string) bool {
+	if current == nil {
+		return false
+	}
+	d1, err1 := time.ParseDuration(desired)
+	d2, err2 := time.ParseDuration(*current)
+	if err1 != nil || err2 != nil {
+		return desired == *current
+	}
+	return d1 == d2
+}
+
+type FakeGCTuningManager struct {
+	err error
+}
+
+func NewFakeGCTuningManager() *FakeGCTuningManager {
+	return &FakeGCTuningManager{}
+}
+
+func (m *FakeGCTuningManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeGCTuningManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
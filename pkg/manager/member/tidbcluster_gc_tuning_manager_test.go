@@ -0,0 +1,136 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+)
+
+type fakeGCSettingsControl struct {
+	settings v1alpha1.GCTuningSettings
+	sets     int
+}
+
+func (c *fakeGCSettingsControl) GetGCSettings(_ *v1alpha1.TidbCluster, _ string) (*v1alpha1.GCTuningSettings, error) {
+	return c.settings.DeepCopy(), nil
+}
+
+func (c *fakeGCSettingsControl) SetGCSettings(_ *v1alpha1.TidbCluster, _ string, settings *v1alpha1.GCTuningSettings) error {
+	c.sets++
+	if settings.GCLifeTime != nil {
+		// TiDB returns the normalized duration
+		d, _ := time.ParseDuration(*settings.GCLifeTime)
+		c.settings.GCLifeTime = pointer.StringPtr(d.String())
+	}
+	if settings.GCConcurrency != nil {
+		c.settings.GCConcurrency = pointer.Int32Ptr(*settings.GCConcurrency)
+	}
+	return nil
+}
+
+func TestGCTuningManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	saved := features.DefaultFeatureGate.String()
+	features.DefaultFeatureGate.Set("GCTuning=true")
+	defer features.DefaultFeatureGate.Set(saved)
+
+	control := &fakeGCSettingsControl{settings: v1alpha1.GCTuningSettings{
+		GCLifeTime:    pointer.StringPtr("10m0s"),
+		GCConcurrency: pointer.Int32Ptr(-1),
+	}}
+	now := time.Date(2023, 6, 1, 0, 30, 0, 0, time.UTC)
+	m := &gcTuningManager{
+		deps:    controller.NewFakeDependencies(),
+		control: control,
+		now:     func() time.Time { return now },
+	}
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{},
+			GCTuning: &v1alpha1.GCTuningPolicy{
+				Windows: []v1alpha1.GCTuningWindow{
+					{
+						Name:     "night",
+						Schedule: "0 1 * * *",
+						Duration: metav1.Duration{Duration: 4 * time.Hour},
+						GCTuningSettings: v1alpha1.GCTuningSettings{
+							GCLifeTime:    pointer.StringPtr("30m"),
+							GCConcurrency: pointer.Int32Ptr(16),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// wait for TiDB to be ready
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning).To(BeNil())
+	tc.Status.TiDB.StatefulSet = &apps.StatefulSetStatus{ReadyReplicas: 1}
+
+	// before the window
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning).To(BeNil())
+	g.Expect(control.sets).To(Equal(0))
+
+	// in the window
+	now = now.Add(time.Hour)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning.ActiveWindow).To(Equal("night"))
+	g.Expect(tc.Status.GCTuning.WindowEnd.Time).To(Equal(time.Date(2023, 6, 1, 5, 0, 0, 0, time.UTC)))
+	g.Expect(*tc.Status.GCTuning.OriginalSettings.GCLifeTime).To(Equal("10m0s"))
+	g.Expect(*tc.Status.GCTuning.OriginalSettings.GCConcurrency).To(Equal(int32(-1)))
+	g.Expect(*control.settings.GCLifeTime).To(Equal("30m0s"))
+	g.Expect(*control.settings.GCConcurrency).To(Equal(int32(16)))
+	g.Expect(control.sets).To(Equal(1))
+
+	// the settings are not set again if they are unchanged
+	now = now.Add(time.Hour)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(control.sets).To(Equal(1))
+
+	// the settings are restored after the window
+	now = now.Add(3 * time.Hour)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning.ActiveWindow).To(BeEmpty())
+	g.Expect(tc.Status.GCTuning.OriginalSettings).To(BeNil())
+	g.Expect(*control.settings.GCLifeTime).To(Equal("10m0s"))
+	g.Expect(*control.settings.GCConcurrency).To(Equal(int32(-1)))
+
+	// the window is in the time zone of the cluster
+	tc.Spec.Timezone = "Asia/Shanghai"
+	now = time.Date(2023, 6, 1, 17, 30, 0, 0, time.UTC)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning.ActiveWindow).To(Equal("night"))
+
+	// the settings are restored if the policy is removed in the window
+	tc.Spec.GCTuning = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning.ActiveWindow).To(BeEmpty())
+	g.Expect(*control.settings.GCLifeTime).To(Equal("10m0s"))
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.GCTuning).To(BeNil())
+}
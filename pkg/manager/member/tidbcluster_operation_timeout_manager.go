@@ -0,0 +1,292 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	operationUpgrade  = "Upgrade"
+	operationScaleIn  = "ScaleIn"
+	operationScaleOut = "ScaleOut"
+
+	// operationTimeoutReason is the reason of the ComponentOperationTimeout condition and the event
+	// if the operation is only reported
+	operationTimeoutReason = "OperationTimeout"
+	// operationAbortedReason is the reason of the ComponentOperationTimeout condition and the event
+	// if the operation is aborted
+	operationAbortedReason = "OperationAborted"
+)
+
+type operationTimeoutManager struct {
+	deps *controller.Dependencies
+	now  func() time.Time
+}
+
+// NewOperationTimeoutManager returns a manager which tracks the upgrade and scaling of the components by the
+// ComponentOperationProgressing condition, and reports the operations not completed within the timeouts of
+// spec.operationTimeouts by the ComponentOperationTimeout condition and a warning event. The operations are
+// also aborted if the action of the policy is Abort.
+func NewOperationTimeoutManager(deps *controller.Dependencies) manager.Manager {
+	return &operationTimeoutManager{
+		deps: deps,
+		now:  time.Now,
+	}
+}
+
+func (m *operationTimeoutManager) Sync(tc *v1alpha1.TidbCluster) error {
+	policy := tc.Spec.OperationTimeouts
+	for _, status := range tc.AllComponentStatus() {
+		op := getComponentOperation(tc, status)
+		if policy == nil || op == "" {
+			removeComponentCondition(status, v1alpha1.ComponentOperationProgressing)
+			removeComponentCondition(status, v1alpha1.ComponentOperationTimeout)
+			continue
+		}
+
+		progressing := meta.FindStatusCondition(status.GetConditions(), v1alpha1.ComponentOperationProgressing)
+		if progressing == nil || progressing.Reason != op || progressing.ObservedGeneration != tc.Generation {
+			// the timer restarts if the operation is changed or the spec is changed
+			removeComponentCondition(status, v1alpha1.ComponentOperationProgressing)
+			removeComponentCondition(status, v1alpha1.ComponentOperationTimeout)
+			status.SetCondition(metav1.Condition{
+				Type:               v1alpha1.ComponentOperationProgressing,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: tc.Generation,
+				LastTransitionTime: metav1.NewTime(m.now()),
+				Reason:             op,
+				Message:            fmt.Sprintf("%s of %s is in progress", op, status.MemberType()),
+			})
+			continue
+		}
+
+		timeout := getOperationTimeout(policy, op)
+		if timeout == nil {
+			continue
+		}
+		elapsed := m.now().Sub(progressing.LastTransitionTime.Time)
+		if elapsed < timeout.Duration || meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentOperationTimeout) {
+			continue
+		}
+
+		reason := operationTimeoutReason
+		msg := fmt.Sprintf("%s of %s is not completed in %s", op, status.MemberType(), timeout.Duration)
+		if policy.Action == v1alpha1.OperationTimeoutActionAbort {
+			if err := m.abortOperation(tc, status.MemberType(), op); err != nil {
+				return err
+			}
+			reason = operationAbortedReason
+			msg += ", the operation is aborted until the spec is changed"
+		}
+		klog.Warningf("TidbCluster: [%s/%s], %s", tc.GetNamespace(), tc.GetName(), msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, reason, msg)
+		status.SetCondition(metav1.Condition{
+			Type:               v1alpha1.ComponentOperationTimeout,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tc.Generation,
+			Reason:             reason,
+			Message:            msg,
+		})
+	}
+	return nil
+}
+
+// abortOperation does the rollback of the operation which can not be done by halting it, i.e. the
+// stores being deleted by the scaling in are restored to Up.
+func (m *operationTimeoutManager) abortOperation(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, op string) error {
+	if op != operationScaleIn {
+		return nil
+	}
+	var stores map[string]v1alpha1.TiKVStore
+	switch memberType {
+	case v1alpha1.TiKVMemberType:
+		stores = tc.Status.TiKV.Stores
+	case v1alpha1.TiFlashMemberType:
+		stores = tc.Status.TiFlash.Stores
+	default:
+		return nil
+	}
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	for _, store := range stores {
+		if store.State != v1alpha1.TiKVStateOffline {
+			continue
+		}
+		id, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := pdClient.SetStoreState(id, v1alpha1.TiKVStateUp); err != nil {
+			return fmt.Errorf("failed to restore %s store %d of pod %s to Up for tc %s/%s, error: %v",
+				memberType, id, store.PodName, tc.GetNamespace(), tc.GetName(), err)
+		}
+		klog.Infof("TidbCluster: [%s/%s], %s store %d of pod %s is restored to Up", tc.GetNamespace(), tc.GetName(), memberType, id, store.PodName)
+	}
+	return nil
+}
+
+// getComponentOperation returns the operation of the component, or an empty string if it's not upgraded or scaled
+func getComponentOperation(tc *v1alpha1.TidbCluster, status v1alpha1.ComponentStatus) string {
+	switch status.GetPhase() {
+	case v1alpha1.UpgradePhase:
+		return operationUpgrade
+	case v1alpha1.ScalePhase:
+		sts := status.GetStatefulSet()
+		if sts != nil && sts.Replicas > getDesiredReplicas(tc, status.MemberType()) {
+			return operationScaleIn
+		}
+		return operationScaleOut
+	}
+	return ""
+}
+
+func getDesiredReplicas(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) int32 {
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		return tc.PDStsDesiredReplicas()
+	case v1alpha1.TiKVMemberType:
+		return tc.TiKVStsDesiredReplicas()
+	case v1alpha1.TiDBMemberType:
+		return tc.TiDBStsDesiredReplicas()
+	case v1alpha1.TiFlashMemberType:
+		return tc.TiFlashStsDesiredReplicas()
+	case v1alpha1.TiCDCMemberType:
+		return tc.TiCDCDeployDesiredReplicas()
+	case v1alpha1.TiProxyMemberType:
+		return tc.TiProxyStsDesiredReplicas()
+	case v1alpha1.PumpMemberType:
+		return tc.Spec.Pump.Replicas
+	}
+	return 0
+}
+
+func getOperationTimeout(policy *v1alpha1.OperationTimeoutPolicy, op string) *metav1.Duration {
+	switch op {
+	case operationUpgrade:
+		return policy.Upgrade
+	case operationScaleIn:
+		return policy.ScaleIn
+	case operationScaleOut:
+		return policy.ScaleOut
+	}
+	return nil
+}
+
+// isOperationAborted returns whether the operation of the component is aborted by the operation timeout
+// policy, the abortion is lifted after the spec of the cluster is changed.
+func isOperationAborted(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) bool {
+	status := tc.ComponentStatus(memberType)
+	if status == nil {
+		return false
+	}
+	cond := meta.FindStatusCondition(status.GetConditions(), v1alpha1.ComponentOperationTimeout)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == operationAbortedReason &&
+		cond.ObservedGeneration == tc.Generation
+}
+
+// abortableScaler keeps the replicas of the StatefulSet once the scaling is aborted
+type abortableScaler struct {
+	Scaler
+	memberType v1alpha1.MemberType
+}
+
+// NewAbortableScaler returns a Scaler which stops scaling the component once the scaling is aborted by the operation timeout policy
+func NewAbortableScaler(scaler Scaler, memberType v1alpha1.MemberType) Scaler {
+	return &abortableScaler{Scaler: scaler, memberType: memberType}
+}
+
+func (s *abortableScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok && isOperationAborted(tc, s.memberType) {
+		klog.Infof("TidbCluster: [%s/%s]'s scaling of %s is aborted, skip scaling", tc.GetNamespace(), tc.GetName(), s.memberType)
+		resetReplicas(newSet, oldSet)
+		return nil
+	}
+	return s.Scaler.Scale(meta, oldSet, newSet)
+}
+
+// abortableUpgrader keeps the partition and the pod template of the StatefulSet once the upgrade is aborted
+type abortableUpgrader struct {
+	Upgrader
+	memberType v1alpha1.MemberType
+}
+
+// NewAbortableUpgrader returns an Upgrader which stops upgrading the component once the upgrade is aborted by the operation timeout policy
+func NewAbortableUpgrader(upgrader Upgrader, memberType v1alpha1.MemberType) Upgrader {
+	return &abortableUpgrader{Upgrader: upgrader, memberType: memberType}
+}
+
+func (u *abortableUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if isOperationAborted(tc, u.memberType) {
+		return haltUpgrade(tc, u.memberType, oldSet, newSet)
+	}
+	return u.Upgrader.Upgrade(tc, oldSet, newSet)
+}
+
+type abortableTiKVUpgrader struct {
+	TiKVUpgrader
+}
+
+// NewAbortableTiKVUpgrader returns a TiKVUpgrader which stops upgrading TiKV once the upgrade is aborted by the operation timeout policy
+func NewAbortableTiKVUpgrader(upgrader TiKVUpgrader) TiKVUpgrader {
+	return &abortableTiKVUpgrader{TiKVUpgrader: upgrader}
+}
+
+func (u *abortableTiKVUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok && isOperationAborted(tc, v1alpha1.TiKVMemberType) {
+		return haltUpgrade(tc, v1alpha1.TiKVMemberType, oldSet, newSet)
+	}
+	return u.TiKVUpgrader.Upgrade(meta, oldSet, newSet)
+}
+
+// haltUpgrade keeps the partition and the pod template of the StatefulSet, so no more pods are upgraded
+func haltUpgrade(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	klog.Infof("TidbCluster: [%s/%s]'s upgrade of %s is aborted, skip upgrading", tc.GetNamespace(), tc.GetName(), memberType)
+	_, podSpec, err := GetLastAppliedConfig(oldSet)
+	if err != nil {
+		return err
+	}
+	newSet.Spec.Template.Spec = *podSpec
+	if oldSet.Spec.UpdateStrategy.RollingUpdate != nil && oldSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	}
+	return nil
+}
+
+type FakeOperationTimeoutManager struct {
+	err error
+}
+
+func NewFakeOperationTimeoutManager() *FakeOperationTimeoutManager {
+	return &FakeOperationTimeoutManager{}
+}
+
+func (m *FakeOperationTimeoutManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeOperationTimeoutManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestOperationTimeoutManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	m := &operationTimeoutManager{
+		deps: controller.NewFakeDependencies(),
+		now:  func() time.Time { return now },
+	}
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic", Generation: 1},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 3},
+			OperationTimeouts: &v1alpha1.OperationTimeoutPolicy{
+				ScaleIn: &metav1.Duration{Duration: time.Hour},
+				Action:  v1alpha1.OperationTimeoutActionAbort,
+			},
+		},
+	}
+	tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+	tc.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{Replicas: 4}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", PodName: "basic-tikv-0", State: v1alpha1.TiKVStateUp},
+		"4": {ID: "4", PodName: "basic-tikv-3", State: v1alpha1.TiKVStateOffline},
+	}
+	pdClient := controller.NewFakePDClient(m.deps.PDControl.(*pdapi.FakePDControl), tc)
+	var restored []uint64
+	pdClient.AddReaction(pdapi.SetStoreStateActionType, func(action *pdapi.Action) (interface{}, error) {
+		restored = append(restored, action.ID)
+		return nil, nil
+	})
+
+	// the scaling in is started
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentOperationProgressing)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Reason).To(Equal(operationScaleIn))
+	g.Expect(cond.LastTransitionTime.Time).To(Equal(now))
+	g.Expect(isOperationAborted(tc, v1alpha1.TiKVMemberType)).To(BeFalse())
+
+	// not timed out
+	now = now.Add(30 * time.Minute)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentOperationTimeout)).To(BeNil())
+
+	// timed out and aborted
+	now = now.Add(time.Hour)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond = meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentOperationTimeout)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Reason).To(Equal(operationAbortedReason))
+	g.Expect(restored).To(Equal([]uint64{4}))
+	g.Expect(isOperationAborted(tc, v1alpha1.TiKVMemberType)).To(BeTrue())
+
+	// the stores are restored only once
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(restored).To(HaveLen(1))
+
+	// the scaling is halted
+	oldSet := &apps.StatefulSet{Spec: apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(4)}}
+	newSet := &apps.StatefulSet{Spec: apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)}}
+	scaler := NewAbortableScaler(&fakeScaler{}, v1alpha1.TiKVMemberType)
+	g.Expect(scaler.Scale(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(4)))
+
+	// the timer restarts after the spec is changed
+	tc.Generation = 2
+	now = now.Add(time.Minute)
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(isOperationAborted(tc, v1alpha1.TiKVMemberType)).To(BeFalse())
+	g.Expect(meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentOperationTimeout)).To(BeNil())
+	cond = meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentOperationProgressing)
+	g.Expect(cond.LastTransitionTime.Time).To(Equal(now))
+
+	// the conditions are removed after the scaling is finished
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Conditions).To(BeEmpty())
+}
+
+type fakeScaler struct{}
+
+func (s *fakeScaler) Scale(_ metav1.Object, _ *apps.StatefulSet, _ *apps.StatefulSet) error {
+	return nil
+}
+
+func (s *fakeScaler) ScaleOut(_ metav1.Object, _ *apps.StatefulSet, _ *apps.StatefulSet) error {
+	return nil
+}
+
+func (s *fakeScaler) ScaleIn(_ metav1.Object, _ *apps.StatefulSet, _ *apps.StatefulSet) error {
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/discovery"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+)
+
+const (
+	peerRegistryFailedReason = "PeerRegistryFailed"
+	peerRegisteredReason     = "PeerRegistered"
+)
+
+type peerRegistryManager struct {
+	deps     *controller.Dependencies
+	register func(registryURL, group string, peer v1alpha1.RegisteredPeer) ([]v1alpha1.RegisteredPeer, error)
+}
+
+// NewPeerRegistryManager returns a manager which registers the TidbClusters deployed across Kubernetes clusters
+// with the peer registry of `spec.peerRegistry`.
+//
+// Each TidbCluster registers its cluster domain and the client URLs of its healthy PD members in each sync, and
+// records all the registered TidbClusters in status.registeredPeers. The discovery service joins the first PD
+// member to the PD of the other TidbClusters by the status, so the PD addresses are not configured manually.
+func NewPeerRegistryManager(deps *controller.Dependencies) manager.Manager {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	return &peerRegistryManager{
+		deps: deps,
+		register: func(registryURL, group string, peer v1alpha1.RegisteredPeer) ([]v1alpha1.RegisteredPeer, error) {
+			return discovery.RegisterPeer(httpClient, registryURL, group, peer)
+		},
+	}
+}
+
+func (m *peerRegistryManager) Sync(tc *v1alpha1.TidbCluster) error {
+	registry := tc.Spec.PeerRegistry
+	if !tc.AcrossK8s() || registry == nil {
+		tc.Status.RegisteredPeers = nil
+		return nil
+	}
+	group := registry.Group
+	if group == "" {
+		group = tc.GetName()
+	}
+
+	peer := v1alpha1.RegisteredPeer{
+		Name:          tc.GetName(),
+		Namespace:     tc.GetNamespace(),
+		ClusterDomain: tc.Spec.ClusterDomain,
+	}
+	if tc.Spec.PD != nil {
+		for _, member := range tc.Status.PD.Members {
+			if member.Health && member.ClientURL != "" {
+				peer.PDAddresses = append(peer.PDAddresses, member.ClientURL)
+			}
+		}
+		sort.Strings(peer.PDAddresses)
+	}
+
+	peers, err := m.register(registry.URL, group, peer)
+	if err != nil {
+		msg := fmt.Sprintf("failed to register with the peer registry %s: %v", registry.URL, err)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, peerRegistryFailedReason, msg)
+		return fmt.Errorf("tidbcluster %s/%s %s", tc.GetNamespace(), tc.GetName(), msg)
+	}
+	if len(tc.Status.RegisteredPeers) != len(peers) {
+		klog.Infof("tidbcluster %s/%s: %d tidbclusters are registered in group %s", tc.GetNamespace(), tc.GetName(), len(peers), group)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, peerRegisteredReason,
+			fmt.Sprintf("%d tidbclusters are registered in group %s of the peer registry", len(peers), group))
+	}
+	tc.Status.RegisteredPeers = peers
+	return nil
+}
+
+type FakePeerRegistryManager struct {
+	err error
+}
+
+func NewFakePeerRegistryManager() *FakePeerRegistryManager {
+	return &FakePeerRegistryManager{}
+}
+
+func (m *FakePeerRegistryManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePeerRegistryManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestPeerRegistryManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var registered []v1alpha1.RegisteredPeer
+	var registerErr error
+	m := &peerRegistryManager{
+		deps: controller.NewFakeDependencies(),
+		register: func(registryURL, group string, peer v1alpha1.RegisteredPeer) ([]v1alpha1.RegisteredPeer, error) {
+			g.Expect(registryURL).To(Equal("http://basic-discovery.pingcap.svc.cluster1.com:10261"))
+			g.Expect(group).To(Equal("basic"))
+			if registerErr != nil {
+				return nil, registerErr
+			}
+			registered = append(registered, peer)
+			return registered, nil
+		},
+	}
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "pingcap"},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:            &v1alpha1.PDSpec{},
+			ClusterDomain: "cluster1.com",
+			PeerRegistry:  &v1alpha1.PeerRegistry{URL: "http://basic-discovery.pingcap.svc.cluster1.com:10261"},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			PD: v1alpha1.PDStatus{Members: map[string]v1alpha1.PDMember{
+				"basic-pd-0": {Name: "basic-pd-0", ClientURL: "http://basic-pd-0.basic-pd-peer.pingcap.svc.cluster1.com:2379", Health: true},
+				"basic-pd-1": {Name: "basic-pd-1", ClientURL: "http://basic-pd-1.basic-pd-peer.pingcap.svc.cluster1.com:2379", Health: false},
+			}},
+		},
+	}
+
+	// the registry is only used across kubernetes clusters
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(registered).To(BeEmpty())
+
+	// the healthy pd members are registered
+	tc.Spec.AcrossK8s = true
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(registered).To(HaveLen(1))
+	g.Expect(registered[0].ClusterDomain).To(Equal("cluster1.com"))
+	g.Expect(registered[0].PDAddresses).To(Equal([]string{"http://basic-pd-0.basic-pd-peer.pingcap.svc.cluster1.com:2379"}))
+	g.Expect(tc.Status.RegisteredPeers).To(HaveLen(1))
+
+	// the registered peers are kept if the registry is not available
+	registerErr = fmt.Errorf("connection refused")
+	g.Expect(m.Sync(tc)).NotTo(Succeed())
+	g.Expect(tc.Status.RegisteredPeers).To(HaveLen(1))
+
+	// the registered peers are cleared without the registry
+	tc.Spec.PeerRegistry = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.RegisteredPeers).To(BeEmpty())
+}
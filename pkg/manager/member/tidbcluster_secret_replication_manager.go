@@ -0,0 +1,250 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+)
+
+const (
+	secretReplicatedReason        = "SecretReplicated"
+	secretReplicaRevokedReason    = "SecretReplicaRevoked"
+	secretReplicationFailedReason = "SecretReplicationFailed"
+)
+
+type secretReplicationManager struct {
+	deps *controller.Dependencies
+}
+
+// NewSecretReplicationManager returns a manager which replicates the Secrets of `spec.secretReplication` from the
+// namespace of the TidbCluster referenced by `spec.cluster` into the namespace of the heterogeneous cluster.
+//
+// The referenced TidbCluster must allow the namespace of the heterogeneous cluster by the annotation
+// tidb.pingcap.com/secret-replication-allowed-namespaces, and only the Secrets labeled with its instance and
+// managed by tidb-operator, such as the TLS secrets created by the cert manager, are replicated.
+//
+// The replicas have the same names and are owned by the heterogeneous cluster, they are updated when the hash of
+// the data of the sources changes. The replicas are deleted when they are not listed any more, when the
+// heterogeneous cluster is unlinked from the referenced TidbCluster, or when the replication is not allowed.
+func NewSecretReplicationManager(deps *controller.Dependencies) manager.Manager {
+	return &secretReplicationManager{
+		deps: deps,
+	}
+}
+
+func (m *secretReplicationManager) Sync(tc *v1alpha1.TidbCluster) error {
+	sourceNS := secretReplicationSource(tc)
+	replicated := map[string]bool{}
+	if sourceNS != "" {
+		source, err := m.replicationSourceCluster(tc, sourceNS)
+		if err != nil {
+			return err
+		}
+		if source != nil {
+			for _, name := range tc.Spec.SecretReplication.SecretNames {
+				ok, err := m.replicateSecret(tc, source, name)
+				if err != nil {
+					return err
+				}
+				replicated[name] = ok
+			}
+		}
+	}
+	return m.revokeSecretReplicas(tc, replicated)
+}
+
+// replicationSourceCluster returns the referenced TidbCluster if it allows the namespace of tc to replicate its
+// secrets, it returns nil if the replication is not allowed.
+func (m *secretReplicationManager) replicationSourceCluster(tc *v1alpha1.TidbCluster, sourceNS string) (*v1alpha1.TidbCluster, error) {
+	ns := tc.GetNamespace()
+	name := tc.Spec.Cluster.Name
+	source, err := m.deps.TiDBClusterLister.TidbClusters(sourceNS).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			msg := fmt.Sprintf("tidbcluster %s/%s to replicate secrets from is not found", sourceNS, name)
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, secretReplicationFailedReason, msg)
+			return nil, controller.RequeueErrorf("tidbcluster %s/%s: %s", ns, tc.GetName(), msg)
+		}
+		return nil, fmt.Errorf("failed to get tidbcluster %s/%s for tc %s/%s, error: %v", sourceNS, name, ns, tc.GetName(), err)
+	}
+	for _, allowed := range strings.Split(source.Annotations[label.AnnSecretReplicationAllowedNamespacesKey], ",") {
+		if strings.TrimSpace(allowed) == ns {
+			return source, nil
+		}
+	}
+	msg := fmt.Sprintf("namespace %s is not allowed to replicate secrets by annotation %s of tidbcluster %s/%s",
+		ns, label.AnnSecretReplicationAllowedNamespacesKey, sourceNS, name)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, secretReplicationFailedReason, msg)
+	return nil, nil
+}
+
+// isSecretOfCluster returns whether the secret belongs to the tidb cluster, which is labeled with the instance
+// of the cluster and managed by tidb-operator
+func isSecretOfCluster(secret *corev1.Secret, tc *v1alpha1.TidbCluster) bool {
+	return secret.Labels[label.ManagedByLabelKey] == label.TiDBOperator &&
+		secret.Labels[label.InstanceLabelKey] == tc.GetInstanceName() &&
+		secret.Labels[label.SecretReplicaOfLabelKey] == ""
+}
+
+// secretReplicationSource returns the namespace the Secrets are replicated from, it's empty if the Secrets are
+// not replicated, or the referenced TidbCluster is in the same namespace or another Kubernetes cluster.
+func secretReplicationSource(tc *v1alpha1.TidbCluster) string {
+	ref := tc.Spec.Cluster
+	if tc.Spec.SecretReplication == nil || ref == nil || ref.Name == "" {
+		return ""
+	}
+	if ref.Namespace == "" || ref.Namespace == tc.GetNamespace() {
+		return ""
+	}
+	if ref.ClusterDomain != "" && ref.ClusterDomain != tc.Spec.ClusterDomain {
+		return ""
+	}
+	return ref.Namespace
+}
+
+// replicateSecret replicates the secret of the source cluster, it returns whether the replica is kept
+func (m *secretReplicationManager) replicateSecret(tc, sourceTC *v1alpha1.TidbCluster, name string) (bool, error) {
+	ns := tc.GetNamespace()
+	sourceNS := sourceTC.GetNamespace()
+	source, err := m.deps.SecretLister.Secrets(sourceNS).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			msg := fmt.Sprintf("secret %s/%s to replicate is not found", sourceNS, name)
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, secretReplicationFailedReason, msg)
+			return false, controller.RequeueErrorf("tidbcluster %s/%s: %s", ns, tc.GetName(), msg)
+		}
+		return false, fmt.Errorf("failed to get secret %s/%s for tc %s/%s, error: %v", sourceNS, name, ns, tc.GetName(), err)
+	}
+	if !isSecretOfCluster(source, sourceTC) {
+		msg := fmt.Sprintf("secret %s/%s is not a secret of tidbcluster %s/%s managed by tidb-operator, skip replicating it",
+			sourceNS, name, sourceNS, sourceTC.GetName())
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, secretReplicationFailedReason, msg)
+		return false, nil
+	}
+	hash, err := mngerutils.Sha256Sum(source.Data)
+	if err != nil {
+		return false, err
+	}
+
+	replicaLabels := label.New().Instance(tc.GetName())
+	replicaLabels[label.SecretReplicaOfLabelKey] = sourceNS
+	replica, err := m.deps.SecretLister.Secrets(ns).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get secret %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+	}
+	if errors.IsNotFound(err) {
+		replica = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       ns,
+				Labels:          replicaLabels,
+				Annotations:     map[string]string{label.AnnSecretReplicaHashKey: hash},
+				OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+			},
+			Type: source.Type,
+			Data: source.Data,
+		}
+		if _, err := m.deps.KubeClientset.CoreV1().Secrets(ns).Create(context.TODO(), replica, metav1.CreateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to create secret %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+		}
+		m.recordSecretReplicated(tc, sourceNS, name)
+		return true, nil
+	}
+
+	if _, ok := replica.Labels[label.SecretReplicaOfLabelKey]; !ok {
+		// the secret is managed by the users, which is not overwritten
+		msg := fmt.Sprintf("secret %s/%s already exists and is not a replica, skip replicating it from namespace %s", ns, name, sourceNS)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, secretReplicationFailedReason, msg)
+		return true, nil
+	}
+	if replica.Labels[label.SecretReplicaOfLabelKey] == sourceNS && replica.Annotations[label.AnnSecretReplicaHashKey] == hash {
+		return true, nil
+	}
+	replica = replica.DeepCopy()
+	for k, v := range replicaLabels {
+		replica.Labels[k] = v
+	}
+	if replica.Annotations == nil {
+		replica.Annotations = map[string]string{}
+	}
+	replica.Annotations[label.AnnSecretReplicaHashKey] = hash
+	replica.Data = source.Data
+	if _, err := m.deps.KubeClientset.CoreV1().Secrets(ns).Update(context.TODO(), replica, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("failed to update secret %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.GetName(), err)
+	}
+	m.recordSecretReplicated(tc, sourceNS, name)
+	return true, nil
+}
+
+func (m *secretReplicationManager) recordSecretReplicated(tc *v1alpha1.TidbCluster, sourceNS, name string) {
+	msg := fmt.Sprintf("secret %s is replicated from namespace %s", name, sourceNS)
+	klog.Infof("tidbcluster %s/%s: %s", tc.GetNamespace(), tc.GetName(), msg)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, secretReplicatedReason, msg)
+}
+
+// revokeSecretReplicas deletes the replicas of the tidb cluster which are not replicated any more
+func (m *secretReplicationManager) revokeSecretReplicas(tc *v1alpha1.TidbCluster, replicated map[string]bool) error {
+	ns := tc.GetNamespace()
+	selector, err := label.New().Instance(tc.GetName()).Selector()
+	if err != nil {
+		return err
+	}
+	secrets, err := m.deps.SecretLister.Secrets(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("failed to list secrets for tc %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	for _, secret := range secrets {
+		sourceNS, ok := secret.Labels[label.SecretReplicaOfLabelKey]
+		if !ok || replicated[secret.Name] {
+			continue
+		}
+		err := m.deps.KubeClientset.CoreV1().Secrets(ns).Delete(context.TODO(), secret.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete secret %s/%s for tc %s/%s, error: %v", ns, secret.Name, ns, tc.GetName(), err)
+		}
+		msg := fmt.Sprintf("the replica of secret %s from namespace %s is deleted", secret.Name, sourceNS)
+		klog.Infof("tidbcluster %s/%s: %s", ns, tc.GetName(), msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, secretReplicaRevokedReason, msg)
+	}
+	return nil
+}
+
+type FakeSecretReplicationManager struct {
+	err error
+}
+
+func NewFakeSecretReplicationManager() *FakeSecretReplicationManager {
+	return &FakeSecretReplicationManager{}
+}
+
+func (m *FakeSecretReplicationManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeSecretReplicationManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestSecretReplicationManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	m := &secretReplicationManager{deps: deps}
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hetero", Name: "basic-hetero"},
+		Spec: v1alpha1.TidbClusterSpec{
+			Cluster:           &v1alpha1.TidbClusterRef{Namespace: "pingcap", Name: "basic"},
+			SecretReplication: &v1alpha1.SecretReplication{SecretNames: []string{"basic-cluster-client-secret"}},
+		},
+	}
+	// syncReplica makes the lister observe the replica written by the manager
+	syncReplica := func() *corev1.Secret {
+		replica, err := deps.KubeClientset.CoreV1().Secrets("hetero").Get(context.TODO(), "basic-cluster-client-secret", metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			g.Expect(secretIndexer.Delete(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "hetero", Name: "basic-cluster-client-secret"}})).To(Succeed())
+			return nil
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(secretIndexer.Update(replica)).To(Succeed())
+		return replica
+	}
+	setSource := func(cert string) {
+		g.Expect(secretIndexer.Update(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "basic-cluster-client-secret", Labels: label.New().Instance("basic").Labels()},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(cert)},
+		})).To(Succeed())
+	}
+
+	// the sync is requeued until the source cluster is created
+	err := m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	source := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "basic"}}
+	g.Expect(tcIndexer.Add(source)).To(Succeed())
+
+	// the secrets are not replicated until the namespace is allowed by the source cluster
+	setSource("cert-1")
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica()).To(BeNil())
+	source = source.DeepCopy()
+	source.Annotations = map[string]string{label.AnnSecretReplicationAllowedNamespacesKey: "default, hetero"}
+	g.Expect(tcIndexer.Update(source)).To(Succeed())
+
+	// the sync is requeued until the source secret is created
+	g.Expect(secretIndexer.Delete(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "basic-cluster-client-secret"}})).To(Succeed())
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	// the replica is created
+	setSource("cert-1")
+	g.Expect(m.Sync(tc)).To(Succeed())
+	replica := syncReplica()
+	g.Expect(replica).NotTo(BeNil())
+	g.Expect(replica.Type).To(Equal(corev1.SecretTypeTLS))
+	g.Expect(replica.Data[corev1.TLSCertKey]).To(Equal([]byte("cert-1")))
+	g.Expect(replica.Labels[label.SecretReplicaOfLabelKey]).To(Equal("pingcap"))
+	hash := replica.Annotations[label.AnnSecretReplicaHashKey]
+	g.Expect(hash).NotTo(BeEmpty())
+
+	// the replica is updated when the source changes
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica().Annotations[label.AnnSecretReplicaHashKey]).To(Equal(hash))
+	setSource("cert-2")
+	g.Expect(m.Sync(tc)).To(Succeed())
+	replica = syncReplica()
+	g.Expect(replica.Data[corev1.TLSCertKey]).To(Equal([]byte("cert-2")))
+	g.Expect(replica.Annotations[label.AnnSecretReplicaHashKey]).NotTo(Equal(hash))
+
+	// the secrets which don't belong to the source cluster are not replicated, and the replica is deleted
+	g.Expect(secretIndexer.Update(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pingcap", Name: "basic-cluster-client-secret"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("other")},
+	})).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica()).To(BeNil())
+	setSource("cert-2")
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica()).NotTo(BeNil())
+
+	// the replica is deleted when the cluster is unlinked
+	tc.Spec.Cluster = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica()).To(BeNil())
+
+	// the secrets of the users are not overwritten
+	tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Namespace: "pingcap", Name: "basic"}
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hetero", Name: "basic-cluster-client-secret"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("user")},
+	}
+	_, err = deps.KubeClientset.CoreV1().Secrets("hetero").Create(context.TODO(), userSecret, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(syncReplica()).NotTo(BeNil())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(syncReplica().Data[corev1.TLSCertKey]).To(Equal([]byte("user")))
+}
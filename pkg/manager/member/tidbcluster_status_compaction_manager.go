@@ -0,0 +1,261 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+)
+
+// StatusCompactionManager compacts the status of very large clusters after the sync, and restores
+// the compacted status before the sync.
+type StatusCompactionManager interface {
+	manager.Manager
+	// Restore adds the stores and members moved to the companion ConfigMap back to the status, it must
+	// be called before the status of the components is synced so that the member managers carry the
+	// state of them over as usual.
+	Restore(tc *v1alpha1.TidbCluster) error
+}
+
+type statusCompactionManager struct {
+	deps *controller.Dependencies
+}
+
+// NewStatusCompactionManager returns a manager which compacts the status of TiKV, TiFlash and TiDB for very
+// large clusters. The stores and members are counted in the summary of the component, only the unhealthy
+// ones are kept in the status and the healthy ones are moved to the companion ConfigMap. It must be synced
+// after all the status of the components is synced.
+func NewStatusCompactionManager(deps *controller.Dependencies) StatusCompactionManager {
+	return &statusCompactionManager{
+		deps: deps,
+	}
+}
+
+func (m *statusCompactionManager) Restore(tc *v1alpha1.TidbCluster) error {
+	if !tc.StatusCompacted() {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	name := tc.FullStatusConfigMapName()
+	cm, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(name)
+	if errors.IsNotFound(err) {
+		// the stores and members are listed by the member managers again, only the state carried over is lost
+		klog.Warningf("TidbCluster %s/%s: configmap %s of the compacted status is not found", ns, tc.GetName(), name)
+		cm = &corev1.ConfigMap{}
+	} else if err != nil {
+		return fmt.Errorf("Restore: failed to get configmap %s/%s, error: %v", ns, name, err)
+	}
+	return tc.RestoreCompactedStatus(cm)
+}
+
+func (m *statusCompactionManager) Sync(tc *v1alpha1.TidbCluster) error {
+	tc.Status.TiKV.Summary = nil
+	tc.Status.TiFlash.Summary = nil
+	tc.Status.TiDB.Summary = nil
+
+	data := map[string]string{}
+	var tikvStores, tiflashStores map[string]v1alpha1.TiKVStore
+	var tidbMembers map[string]v1alpha1.TiDBMember
+	if tc.StatusCompactionEnabled() {
+		threshold := tc.StatusCompactionMemberThreshold()
+		if len(tc.Status.TiKV.Stores) >= threshold {
+			var moved map[string]v1alpha1.TiKVStore
+			tikvStores, moved = compactStores(tc.Status.TiKV.Stores)
+			if err := marshalFullStatus(data, v1alpha1.FullStatusTiKVStoresKey, moved); err != nil {
+				return err
+			}
+		}
+		if len(tc.Status.TiFlash.Stores) >= threshold {
+			var moved map[string]v1alpha1.TiKVStore
+			tiflashStores, moved = compactStores(tc.Status.TiFlash.Stores)
+			if err := marshalFullStatus(data, v1alpha1.FullStatusTiFlashStoresKey, moved); err != nil {
+				return err
+			}
+		}
+		if len(tc.Status.TiDB.Members) >= threshold {
+			var moved map[string]v1alpha1.TiDBMember
+			tidbMembers, moved = compactTiDBMembers(tc.Status.TiDB.Members)
+			if err := marshalFullStatus(data, v1alpha1.FullStatusTiDBMembersKey, moved); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the status is left in full if the moved stores and members are not stored
+	if err := m.syncFullStatusConfigMap(tc, data); err != nil {
+		return err
+	}
+
+	if tikvStores != nil {
+		tc.Status.TiKV.Summary = summarizeStores(tc.Status.TiKV.Stores)
+		tc.Status.TiKV.Stores = tikvStores
+	}
+	if tiflashStores != nil {
+		tc.Status.TiFlash.Summary = summarizeStores(tc.Status.TiFlash.Stores)
+		tc.Status.TiFlash.Stores = tiflashStores
+	}
+	if tidbMembers != nil {
+		tc.Status.TiDB.Summary = summarizeTiDBMembers(tc.Status.TiDB.Members)
+		tc.Status.TiDB.Members = tidbMembers
+	}
+	return nil
+}
+
+func marshalFullStatus(data map[string]string, key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s of the full status, error: %v", key, err)
+	}
+	data[key] = string(b)
+	return nil
+}
+
+// syncFullStatusConfigMap stores the stores and members moved out of the status in the companion ConfigMap.
+// The ConfigMap is only updated if the data is changed, and is deleted if no component is compacted.
+func (m *statusCompactionManager) syncFullStatusConfigMap(tc *v1alpha1.TidbCluster, data map[string]string) error {
+	ns := tc.GetNamespace()
+	name := tc.FullStatusConfigMapName()
+
+	existing, err := m.deps.ConfigMapLister.ConfigMaps(ns).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncFullStatusConfigMap: failed to get configmap %s/%s, error: %v", ns, name, err)
+	}
+	if errors.IsNotFound(err) {
+		existing = nil
+	}
+
+	if len(data) == 0 {
+		if existing == nil || !metav1.IsControlledBy(existing, tc) {
+			return nil
+		}
+		if err := m.deps.TypedControl.Delete(tc, existing.DeepCopy()); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncFullStatusConfigMap: failed to delete configmap %s/%s, error: %v", ns, name, err)
+		}
+		return nil
+	}
+	if existing != nil && apiequality.Semantic.DeepEqual(existing.Data, data) {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       ns,
+			Labels:          label.New().Instance(tc.GetInstanceName()).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: data,
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateConfigMap(tc, cm); err != nil {
+		return fmt.Errorf("syncFullStatusConfigMap: failed to create or update configmap %s/%s, error: %v", ns, name, err)
+	}
+	return nil
+}
+
+// compactStores returns the stores which are not up to keep in the status, and the stores which are up to
+// move to the companion ConfigMap. The metrics reported by PD are cleared in the moved stores as they are
+// refreshed on every sync, so that the ConfigMap is only updated if the stores are changed.
+func compactStores(stores map[string]v1alpha1.TiKVStore) (kept, moved map[string]v1alpha1.TiKVStore) {
+	kept = map[string]v1alpha1.TiKVStore{}
+	moved = map[string]v1alpha1.TiKVStore{}
+	for id, store := range stores {
+		if store.State != v1alpha1.TiKVStateUp {
+			kept[id] = store
+			continue
+		}
+		store.LeaderCount = 0
+		store.RegionCount = 0
+		store.Capacity = nil
+		store.Available = nil
+		store.LastHeartbeatTime = nil
+		store.SlowScore = 0
+		moved[id] = store
+	}
+	return kept, moved
+}
+
+// compactTiDBMembers returns the unhealthy members to keep in the status, and the healthy members to move
+// to the companion ConfigMap.
+func compactTiDBMembers(members map[string]v1alpha1.TiDBMember) (kept, moved map[string]v1alpha1.TiDBMember) {
+	kept = map[string]v1alpha1.TiDBMember{}
+	moved = map[string]v1alpha1.TiDBMember{}
+	for name, member := range members {
+		if !member.Health {
+			kept[name] = member
+			continue
+		}
+		moved[name] = member
+	}
+	return kept, moved
+}
+
+// summarizeStores counts the up stores and lists the IDs of the other stores
+func summarizeStores(stores map[string]v1alpha1.TiKVStore) *v1alpha1.MemberSummary {
+	summary := &v1alpha1.MemberSummary{Total: int32(len(stores))}
+	for id, store := range stores {
+		if store.State == v1alpha1.TiKVStateUp {
+			summary.Healthy++
+			continue
+		}
+		summary.Unhealthy = append(summary.Unhealthy, id)
+	}
+	sort.Strings(summary.Unhealthy)
+	return summary
+}
+
+// summarizeTiDBMembers counts the healthy members and lists the names of the other members
+func summarizeTiDBMembers(members map[string]v1alpha1.TiDBMember) *v1alpha1.MemberSummary {
+	summary := &v1alpha1.MemberSummary{Total: int32(len(members))}
+	for name, member := range members {
+		if member.Health {
+			summary.Healthy++
+			continue
+		}
+		summary.Unhealthy = append(summary.Unhealthy, name)
+	}
+	sort.Strings(summary.Unhealthy)
+	return summary
+}
+
+type FakeStatusCompactionManager struct {
+	err error
+}
+
+func NewFakeStatusCompactionManager() *FakeStatusCompactionManager {
+	return &FakeStatusCompactionManager{}
+}
+
+func (m *FakeStatusCompactionManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeStatusCompactionManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
+
+func (m *FakeStatusCompactionManager) Restore(_ *v1alpha1.TidbCluster) error {
+	return nil
+}
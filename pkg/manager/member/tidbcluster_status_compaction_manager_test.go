@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestStatusCompactionManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
+	cmIndexer := fakeDeps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+	m := NewStatusCompactionManager(fakeDeps)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TiDB.Replicas = 3
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", State: v1alpha1.TiKVStateUp, LeaderCount: 10, LeaderCountBeforeUpgrade: pointer.Int32Ptr(10)},
+		"2": {ID: "2", State: v1alpha1.TiKVStateUp, LeaderCount: 20},
+		"3": {ID: "3", State: v1alpha1.TiKVStateDown},
+	}
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"test-tidb-0": {Name: "test-tidb-0", Health: true, NodeName: "node-0"},
+		"test-tidb-1": {Name: "test-tidb-1", Health: true},
+		"test-tidb-2": {Name: "test-tidb-2", Health: true},
+	}
+	tc.Status.TiFlash.Stores = map[string]v1alpha1.TiKVStore{
+		"4": {ID: "4", State: v1alpha1.TiKVStateUp},
+	}
+
+	getFullStatus := func() (*corev1.ConfigMap, error) {
+		cm := &corev1.ConfigMap{}
+		err := ctrl.FakeCli.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: tc.FullStatusConfigMapName()}, cm)
+		return cm, err
+	}
+
+	// compaction is disabled
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(3))
+	g.Expect(tc.Status.TiKV.Summary).To(BeNil())
+	_, err := getFullStatus()
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the healthy stores and members are moved to the companion ConfigMap
+	tc.Spec.StatusCompaction = &v1alpha1.StatusCompaction{MemberThreshold: pointer.Int32Ptr(2)}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(1))
+	g.Expect(tc.Status.TiKV.Stores).To(HaveKey("3"))
+	g.Expect(*tc.Status.TiKV.Summary).To(Equal(v1alpha1.MemberSummary{Total: 3, Healthy: 2, Unhealthy: []string{"3"}}))
+	g.Expect(tc.Status.TiDB.Members).To(BeEmpty())
+	g.Expect(*tc.Status.TiDB.Summary).To(Equal(v1alpha1.MemberSummary{Total: 3, Healthy: 3}))
+	g.Expect(tc.TiDBAllMembersReady()).To(BeTrue())
+	// the number of TiFlash stores is less than the threshold
+	g.Expect(tc.Status.TiFlash.Stores).To(HaveLen(1))
+	g.Expect(tc.Status.TiFlash.Summary).To(BeNil())
+	g.Expect(tc.AllTiKVsAreAvailable()).To(BeFalse())
+
+	cm, err := getFullStatus()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data).To(HaveKey(v1alpha1.FullStatusTiDBMembersKey))
+	g.Expect(cm.Data).NotTo(HaveKey(v1alpha1.FullStatusTiFlashStoresKey))
+	stores := map[string]v1alpha1.TiKVStore{}
+	g.Expect(json.Unmarshal([]byte(cm.Data[v1alpha1.FullStatusTiKVStoresKey]), &stores)).To(Succeed())
+	g.Expect(stores).To(HaveLen(2))
+	// the metrics reported by PD are not stored
+	g.Expect(stores["1"].LeaderCount).To(BeZero())
+	g.Expect(*stores["1"].LeaderCountBeforeUpgrade).To(Equal(int32(10)))
+
+	// the status is restored in full before the sync
+	g.Expect(cmIndexer.Add(cm)).To(Succeed())
+	g.Expect(m.Restore(tc)).To(Succeed())
+	g.Expect(tc.StatusCompacted()).To(BeFalse())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(3))
+	g.Expect(*tc.Status.TiKV.Stores["1"].LeaderCountBeforeUpgrade).To(Equal(int32(10)))
+	g.Expect(tc.Status.TiDB.Members).To(HaveLen(3))
+	g.Expect(tc.Status.TiDB.Members["test-tidb-0"].NodeName).To(Equal("node-0"))
+
+	// the ConfigMap is not updated if the moved stores and members are not changed
+	g.Expect(ctrl.FakeCli.Delete(context.TODO(), cm)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(1))
+	_, err = getFullStatus()
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	// the down store is recovered, the summary and the ConfigMap are refreshed
+	g.Expect(m.Restore(tc)).To(Succeed())
+	tc.Status.TiKV.Stores["3"] = v1alpha1.TiKVStore{ID: "3", State: v1alpha1.TiKVStateUp}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Stores).To(BeEmpty())
+	g.Expect(*tc.Status.TiKV.Summary).To(Equal(v1alpha1.MemberSummary{Total: 3, Healthy: 3}))
+	g.Expect(tc.AllTiKVsAreAvailable()).To(BeTrue())
+	cm, err = getFullStatus()
+	g.Expect(err).NotTo(HaveOccurred())
+	stores = map[string]v1alpha1.TiKVStore{}
+	g.Expect(json.Unmarshal([]byte(cm.Data[v1alpha1.FullStatusTiKVStoresKey]), &stores)).To(Succeed())
+	g.Expect(stores).To(HaveLen(3))
+	g.Expect(cmIndexer.Update(cm)).To(Succeed())
+
+	// the status is listed in full and the ConfigMap is deleted once the compaction is disabled
+	g.Expect(m.Restore(tc)).To(Succeed())
+	tc.Spec.StatusCompaction = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Summary).To(BeNil())
+	g.Expect(tc.Status.TiDB.Summary).To(BeNil())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(3))
+	g.Expect(tc.Status.TiDB.Members).To(HaveLen(3))
+	_, err = getFullStatus()
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestStatusCompactionManagerRestoreWithoutConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	m := NewStatusCompactionManager(controller.NewFakeDependencies())
+	tc := newTidbClusterForPD()
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"3": {ID: "3", State: v1alpha1.TiKVStateDown},
+	}
+	tc.Status.TiKV.Summary = &v1alpha1.MemberSummary{Total: 3, Healthy: 2, Unhealthy: []string{"3"}}
+
+	// the stores are listed by the member managers again
+	g.Expect(m.Restore(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Summary).To(BeNil())
+	g.Expect(tc.Status.TiKV.Stores).To(HaveLen(1))
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/crypto"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+type tlsPolicyManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTLSPolicyManager returns a manager which validates the certificates of the TLS secrets
+// of the tidb cluster against the crypto policy in the FIPS mode, and reports the non-compliant
+// secrets by the TLSCompliant condition.
+func NewTLSPolicyManager(deps *controller.Dependencies) manager.Manager {
+	return &tlsPolicyManager{
+		deps: deps,
+	}
+}
+
+func (m *tlsPolicyManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !m.deps.CLIConfig.FIPSMode {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	var errs []string
+	for _, name := range getTLSSecretNames(tc) {
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(name)
+		if err != nil {
+			// the secrets are created by the users, the components wait for them to be created
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get secret %s/%s for tc %s/%s, error: %v", ns, name, ns, tc.Name, err)
+		}
+		if err := crypto.ValidateSecretForFIPS(secret); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) == 0 {
+		utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+			v1alpha1.TidbClusterTLSCompliant, corev1.ConditionTrue, utiltidbcluster.TLSSecretsCompliant, "all the TLS secrets meet the crypto policy"))
+		return nil
+	}
+	msg := strings.Join(errs, "; ")
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTLSCompliant)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Message != msg {
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, utiltidbcluster.TLSSecretsNotCompliant, msg)
+	}
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+		v1alpha1.TidbClusterTLSCompliant, corev1.ConditionFalse, utiltidbcluster.TLSSecretsNotCompliant, msg))
+	// the message is not updated by SetTidbClusterCondition if the status and the reason are unchanged
+	for i := range tc.Status.Conditions {
+		if tc.Status.Conditions[i].Type == v1alpha1.TidbClusterTLSCompliant {
+			tc.Status.Conditions[i].Message = msg
+		}
+	}
+	return nil
+}
+
+// getTLSSecretNames returns the names of the TLS secrets mounted by the components of the tidb cluster
+func getTLSSecretNames(tc *v1alpha1.TidbCluster) []string {
+	var names []string
+	if tc.IsTLSClusterEnabled() {
+		names = append(names, util.ClusterClientTLSSecretName(tc.Name))
+		components := []struct {
+			enabled bool
+			name    string
+		}{
+			{tc.Spec.PD != nil, label.PDLabelVal},
+			{tc.Spec.TiKV != nil, label.TiKVLabelVal},
+			{tc.Spec.TiDB != nil, label.TiDBLabelVal},
+			{tc.Spec.TiFlash != nil, label.TiFlashLabelVal},
+			{tc.Spec.TiCDC != nil, label.TiCDCLabelVal},
+			{tc.Spec.Pump != nil, label.PumpLabelVal},
+		}
+		for _, c := range components {
+			if c.enabled {
+				names = append(names, util.ClusterTLSSecretName(tc.Name, c.name))
+			}
+		}
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() {
+		names = append(names, util.TiDBServerTLSSecretName(tc.Name))
+	}
+	return names
+}
+
+type FakeTLSPolicyManager struct {
+	err error
+}
+
+func NewFakeTLSPolicyManager() *FakeTLSPolicyManager {
+	return &FakeTLSPolicyManager{}
+}
+
+func (m *FakeTLSPolicyManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTLSPolicyManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
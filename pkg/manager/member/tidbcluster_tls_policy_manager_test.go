@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+func newTLSPolicyTestCert(g *GomegaWithT, keySize int) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	g.Expect(err).To(Succeed())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "basic"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).To(Succeed())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestTLSPolicyManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	m := NewTLSPolicyManager(fakeDeps)
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TLSCluster: &v1alpha1.TLSCluster{Enabled: true},
+			PD:         &v1alpha1.PDSpec{},
+		},
+	}
+
+	// FIPS mode is disabled
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.Conditions).To(BeEmpty())
+
+	fakeDeps.CLIConfig.FIPSMode = true
+	secrets := fakeDeps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	g.Expect(secrets.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: util.ClusterClientTLSSecretName("basic")},
+		Data:       map[string][]byte{corev1.TLSCertKey: newTLSPolicyTestCert(g, 2048)},
+	})).To(Succeed())
+
+	// the missing secret of PD is skipped
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTLSCompliant)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+
+	pdSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: util.ClusterTLSSecretName("basic", "pd")},
+		Data:       map[string][]byte{corev1.TLSCertKey: newTLSPolicyTestCert(g, 1024)},
+	}
+	g.Expect(secrets.Add(pdSecret)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterTLSCompliant)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.TLSSecretsNotCompliant))
+	g.Expect(cond.Message).To(ContainSubstring(pdSecret.Name))
+}
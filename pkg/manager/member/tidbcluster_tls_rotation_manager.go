@@ -0,0 +1,173 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	tlsCertReloadedReason  = "TLSCertReloaded"
+	tlsCertRestartedReason = "TLSCertRestarted"
+)
+
+// tlsRotationOrder is the order the components are rolled to apply the renewed certs, it's the upgrade order
+var tlsRotationOrder = []v1alpha1.MemberType{
+	v1alpha1.PDMemberType,
+	v1alpha1.TiProxyMemberType,
+	v1alpha1.TiFlashMemberType,
+	v1alpha1.TiKVMemberType,
+	v1alpha1.PumpMemberType,
+	v1alpha1.TiDBMemberType,
+	v1alpha1.TiCDCMemberType,
+}
+
+// tlsReloadableComponents are the components reloading the cluster TLS certs from the mounted secrets online
+var tlsReloadableComponents = map[v1alpha1.MemberType]bool{
+	v1alpha1.PDMemberType:    true,
+	v1alpha1.TiKVMemberType:  true,
+	v1alpha1.TiDBMemberType:  true,
+	v1alpha1.TiCDCMemberType: true,
+}
+
+type tlsRotationManager struct {
+	deps *controller.Dependencies
+	now  func() time.Time
+}
+
+// NewTLSRotationManager returns a manager which watches the cluster TLS secrets of the components, and
+// applies the renewed certs by the rotation strategy. The pods of the components which can not reload the
+// certs online are rolled one component at a time by the upgraders, so the leaders are transferred and the
+// connections are drained as in an upgrade. The rotations are tracked in status.tlsRotations.
+func NewTLSRotationManager(deps *controller.Dependencies) manager.Manager {
+	return &tlsRotationManager{
+		deps: deps,
+		now:  time.Now,
+	}
+}
+
+func (m *tlsRotationManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !tc.IsTLSClusterEnabled() {
+		return nil
+	}
+	strategy := tc.Spec.TLSCluster.RotationStrategy
+	if strategy == "" {
+		strategy = v1alpha1.TLSRotationStrategyAuto
+	}
+
+	// only one component is rolled at a time
+	rolling := false
+	for _, status := range tc.AllComponentStatus() {
+		if status.GetPhase() == v1alpha1.UpgradePhase {
+			rolling = true
+			break
+		}
+	}
+
+	ns := tc.GetNamespace()
+	for _, memberType := range tlsRotationOrder {
+		if tc.ComponentStatus(memberType) == nil {
+			continue
+		}
+		secretName := util.ClusterTLSSecretName(tc.GetName(), memberType.String())
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(secretName)
+		if err != nil {
+			// the component waits for the secret to be created
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get secret %s/%s for tc %s/%s, error: %v", ns, secretName, ns, tc.GetName(), err)
+		}
+		hash, err := mngerutils.Sha256Sum(secret.Data)
+		if err != nil {
+			return err
+		}
+
+		if tc.Status.TLSRotations == nil {
+			tc.Status.TLSRotations = map[v1alpha1.MemberType]v1alpha1.TLSRotationStatus{}
+		}
+		rotation, ok := tc.Status.TLSRotations[memberType]
+		if !ok || rotation.CertHash == "" {
+			// the pods are started with the cert observed first
+			tc.Status.TLSRotations[memberType] = v1alpha1.TLSRotationStatus{CertHash: hash}
+			continue
+		}
+		if rotation.CertHash == hash || strategy == v1alpha1.TLSRotationStrategyNone {
+			continue
+		}
+
+		method := v1alpha1.TLSRotationMethodRestart
+		if strategy == v1alpha1.TLSRotationStrategyAuto && tlsReloadableComponents[memberType] {
+			method = v1alpha1.TLSRotationMethodReload
+		}
+		if method == v1alpha1.TLSRotationMethodRestart {
+			if rolling {
+				klog.Infof("TidbCluster: [%s/%s], the cert of %s is renewed, wait for the rolling of other components", ns, tc.GetName(), memberType)
+				continue
+			}
+			rolling = true
+			rotation.RestartHash = hash
+		}
+		rotation.CertHash = hash
+		rotation.Method = method
+		rotation.LastRotationTime = &metav1.Time{Time: m.now()}
+		tc.Status.TLSRotations[memberType] = rotation
+
+		reason, msg := tlsCertReloadedReason, fmt.Sprintf("the renewed cert of %s is reloaded online", memberType)
+		if method == v1alpha1.TLSRotationMethodRestart {
+			reason, msg = tlsCertRestartedReason, fmt.Sprintf("the pods of %s are rolled to apply the renewed cert", memberType)
+		}
+		klog.Infof("TidbCluster: [%s/%s], %s", ns, tc.GetName(), msg)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, reason, msg)
+	}
+	return nil
+}
+
+// tlsRotationPodAnnotations returns the pod annotations rolling the pods of the component to apply the renewed cert
+func tlsRotationPodAnnotations(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) map[string]string {
+	rotation, ok := tc.Status.TLSRotations[memberType]
+	if !ok || rotation.RestartHash == "" {
+		return nil
+	}
+	return map[string]string{label.AnnTLSCertHashKey: rotation.RestartHash}
+}
+
+type FakeTLSRotationManager struct {
+	err error
+}
+
+func NewFakeTLSRotationManager() *FakeTLSRotationManager {
+	return &FakeTLSRotationManager{}
+}
+
+func (m *FakeTLSRotationManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTLSRotationManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
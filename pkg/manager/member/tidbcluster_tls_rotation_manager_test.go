@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+func TestTLSRotationManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	deps := controller.NewFakeDependencies()
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	m := &tlsRotationManager{
+		deps: deps,
+		now:  func() time.Time { return now },
+	}
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TLSCluster: &v1alpha1.TLSCluster{Enabled: true},
+			PD:         &v1alpha1.PDSpec{},
+			TiFlash:    &v1alpha1.TiFlashSpec{},
+			Pump:       &v1alpha1.PumpSpec{},
+		},
+	}
+	setCert := func(memberType v1alpha1.MemberType, cert string) {
+		g.Expect(secretIndexer.Add(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: util.ClusterTLSSecretName(tc.Name, memberType.String())},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(cert)},
+		})).To(Succeed())
+	}
+	setCert(v1alpha1.PDMemberType, "pd-1")
+	setCert(v1alpha1.TiFlashMemberType, "tiflash-1")
+	setCert(v1alpha1.PumpMemberType, "pump-1")
+
+	// the certs observed first are not rotated
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TLSRotations).To(HaveLen(3))
+	for _, memberType := range []v1alpha1.MemberType{v1alpha1.PDMemberType, v1alpha1.TiFlashMemberType, v1alpha1.PumpMemberType} {
+		g.Expect(tc.Status.TLSRotations[memberType].CertHash).NotTo(BeEmpty())
+		g.Expect(tlsRotationPodAnnotations(tc, memberType)).To(BeEmpty())
+	}
+
+	// PD reloads the cert online, and only TiFlash is rolled
+	setCert(v1alpha1.PDMemberType, "pd-2")
+	setCert(v1alpha1.TiFlashMemberType, "tiflash-2")
+	setCert(v1alpha1.PumpMemberType, "pump-2")
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TLSRotations[v1alpha1.PDMemberType].Method).To(Equal(v1alpha1.TLSRotationMethodReload))
+	g.Expect(tlsRotationPodAnnotations(tc, v1alpha1.PDMemberType)).To(BeEmpty())
+	tiflash := tc.Status.TLSRotations[v1alpha1.TiFlashMemberType]
+	g.Expect(tiflash.Method).To(Equal(v1alpha1.TLSRotationMethodRestart))
+	g.Expect(tiflash.LastRotationTime.Time).To(Equal(now))
+	g.Expect(tlsRotationPodAnnotations(tc, v1alpha1.TiFlashMemberType)).To(Equal(map[string]string{label.AnnTLSCertHashKey: tiflash.CertHash}))
+	g.Expect(tlsRotationPodAnnotations(tc, v1alpha1.PumpMemberType)).To(BeEmpty())
+
+	// Pump waits for the rolling of TiFlash
+	tc.Status.TiFlash.Phase = v1alpha1.UpgradePhase
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tlsRotationPodAnnotations(tc, v1alpha1.PumpMemberType)).To(BeEmpty())
+
+	tc.Status.TiFlash.Phase = v1alpha1.NormalPhase
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tlsRotationPodAnnotations(tc, v1alpha1.PumpMemberType)).To(HaveKey(label.AnnTLSCertHashKey))
+}
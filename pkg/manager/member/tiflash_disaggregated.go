@@ -0,0 +1,419 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+)
+
+const (
+	tiflashDisaggregatedWriteMode   = "tiflash_write"
+	tiflashDisaggregatedComputeMode = "tiflash_compute"
+
+	tiflashComputeStoreLimitPattern = `%s-tiflash-compute-\d+\.%s-tiflash-compute-peer\.%s\.svc%s:\d+`
+	// the cache of the data read from the S3 storage on the first disk of the compute nodes
+	tiflashRemoteCacheDir = "/data0/remote_cache"
+)
+
+// tiflashComputeCluster returns a copy of the TidbCluster whose TiFlash spec is replaced by the spec of the compute
+// nodes, so that the pods and the config of the compute nodes are built in the same way as the write nodes.
+func tiflashComputeCluster(tc *v1alpha1.TidbCluster) *v1alpha1.TidbCluster {
+	computeTC := tc.DeepCopy()
+	spec := computeTC.Spec.TiFlash
+	compute := spec.Disaggregated.ComputeNode
+	if compute.Version == nil {
+		compute.Version = spec.Version
+	}
+	if compute.Image == "" {
+		compute.Image = spec.Image
+	}
+	spec.ComponentSpec = compute.ComponentSpec
+	spec.ResourceRequirements = compute.ResourceRequirements
+	spec.Replicas = compute.Replicas
+	spec.StorageClaims = compute.StorageClaims
+	// the compute nodes are stateless, which are not failed over
+	spec.MaxFailoverCount = nil
+	spec.Failover = nil
+	computeTC.Status.TiFlash.FailureStores = nil
+	// the delete slots of the write nodes are not applied to the compute nodes
+	delete(computeTC.Annotations, label.AnnTiFlashDeleteSlots)
+	return computeTC
+}
+
+// setTiFlashDisaggregatedConfig sets the role and the S3 storage of TiFlash in the disaggregated mode
+func setTiFlashDisaggregatedConfig(tc *v1alpha1.TidbCluster, config *v1alpha1.TiFlashConfigWraper, mode string) {
+	s3 := tc.Spec.TiFlash.Disaggregated.S3
+	common := config.Common
+	common.Set("flash.disaggregated_mode", mode)
+	common.SetIfNil("storage.s3.endpoint", s3.Endpoint)
+	common.SetIfNil("storage.s3.bucket", s3.Bucket)
+	if s3.Root != "" {
+		common.SetIfNil("storage.s3.root", s3.Root)
+	}
+}
+
+// tiflashS3CredentialsEnv returns the env of the credentials of the S3 storage in the disaggregated mode
+func tiflashS3CredentialsEnv(tc *v1alpha1.TidbCluster) []corev1.EnvVar {
+	if !tc.TiFlashDisaggregated() || tc.Spec.TiFlash.Disaggregated.S3.SecretName == "" {
+		return nil
+	}
+	secretName := tc.Spec.TiFlash.Disaggregated.S3.SecretName
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: "S3_ACCESS_KEY_ID", ValueFrom: secretKeyRef("access_key")},
+		{Name: "S3_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef("secret_key")},
+	}
+}
+
+func getTiFlashComputeConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+	computeTC := tiflashComputeCluster(tc)
+	config := GetTiFlashConfig(computeTC)
+	setTiFlashDisaggregatedConfig(computeTC, config, tiflashDisaggregatedComputeMode)
+
+	common := config.Common
+	common.SetIfNil("storage.remote.cache.dir", tiflashRemoteCacheDir)
+	if common.Get("storage.remote.cache.capacity") == nil {
+		// leave some space of the first disk to the proxy and the temporary files
+		if quantity, ok := computeTC.Spec.TiFlash.StorageClaims[0].Resources.Requests[corev1.ResourceStorage]; ok {
+			common.Set("storage.remote.cache.capacity", quantity.Value()/10*8)
+		}
+	}
+	// the compute nodes are advertised by their own headless service
+	name := controller.TiFlashComputeMemberName(tc.Name)
+	peer := controller.TiFlashComputePeerMemberName(tc.Name)
+	domain := controller.FormatClusterDomain(tc.Spec.ClusterDomain)
+	common.Set("flash.proxy.advertise-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:20170", name, peer, tc.Namespace, domain))
+	config.Proxy.Set("server.engine-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:3930", name, peer, tc.Namespace, domain))
+	config.Proxy.Set("server.advertise-status-addr", fmt.Sprintf("%s-POD_NUM.%s.%s.svc%s:20292", name, peer, tc.Namespace, domain))
+
+	configText, err := common.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+	proxyText, err := config.Proxy.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       tc.Namespace,
+			Labels:          label.New().Instance(tc.GetInstanceName()).TiFlashCompute().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: map[string]string{
+			"config_templ.toml": string(configText),
+			"proxy_templ.toml":  string(proxyText),
+		},
+	}, nil
+}
+
+func getNewTiFlashComputeHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
+	svc := getNewHeadlessService(tc)
+	svcLabels := label.New().Instance(tc.GetInstanceName()).TiFlashCompute().Labels()
+	svc.Name = controller.TiFlashComputePeerMemberName(tc.Name)
+	svc.Labels = svcLabels
+	svc.Spec.Selector = svcLabels
+	return svc
+}
+
+// getNewTiFlashComputeStatefulSet returns the StatefulSet of the compute nodes, which is the same as the StatefulSet
+// of the write nodes except the spec of the compute nodes, the labels and the headless service.
+func getNewTiFlashComputeStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+	set, err := getNewStatefulSet(tiflashComputeCluster(tc), cm)
+	if err != nil {
+		return nil, err
+	}
+	stsLabels := label.New().Instance(tc.GetInstanceName()).TiFlashCompute()
+	headlessSvcName := controller.TiFlashComputePeerMemberName(tc.Name)
+
+	set.Name = controller.TiFlashComputeMemberName(tc.Name)
+	set.Labels = stsLabels.Labels()
+	set.Spec.Selector = stsLabels.LabelSelector()
+	set.Spec.ServiceName = headlessSvcName
+	for k, v := range stsLabels {
+		set.Spec.Template.Labels[k] = v
+	}
+	for i := range set.Spec.Template.Spec.Containers {
+		c := &set.Spec.Template.Spec.Containers[i]
+		if c.Name != v1alpha1.TiFlashMemberType.String() {
+			continue
+		}
+		for j := range c.Env {
+			if c.Env[j].Name == "HEADLESS_SERVICE_NAME" {
+				c.Env[j].Value = headlessSvcName
+			}
+		}
+	}
+	return set, nil
+}
+
+// syncTiFlashComputeNodes syncs the compute nodes of TiFlash in the disaggregated mode. The compute nodes are
+// upgraded after the write nodes, and they are scaled in one by one after their stores are removed from PD.
+func (m *tiflashMemberManager) syncTiFlashComputeNodes(tc *v1alpha1.TidbCluster) error {
+	if !tc.TiFlashDisaggregated() {
+		tc.Status.TiFlash.ComputeNode = nil
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	setName := controller.TiFlashComputeMemberName(tcName)
+
+	if !tc.Spec.Paused {
+		newSvc := overrideService(tc, m.deps.Recorder, getNewTiFlashComputeHeadlessService(tc))
+		if err := CreateOrUpdateService(m.deps.ServiceLister, m.deps.ServiceControl, newSvc, tc); err != nil {
+			return err
+		}
+	}
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncTiFlashComputeNodes: fail to get sts %s for cluster %s/%s, error: %s", setName, ns, tcName, err)
+	}
+	setNotExist := errors.IsNotFound(err)
+	oldSet := oldSetTmp.DeepCopy()
+
+	if err := m.syncTiFlashComputeStatus(tc, oldSet); err != nil {
+		return err
+	}
+
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tiflash cluster %s/%s is paused, skip syncing for tiflash compute statefulset", ns, tcName)
+		return nil
+	}
+
+	cm, err := m.syncTiFlashComputeConfigMap(tc, oldSet)
+	if err != nil {
+		return err
+	}
+	newSet, err := getNewTiFlashComputeStatefulSet(tc, cm)
+	if err != nil {
+		return err
+	}
+	RewritePodImages(&newSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	keepTerminationGracePeriodSeconds(tiflashComputeCluster(tc).BaseTiFlashSpec(), newSet, oldSet)
+	newSet = overrideStatefulSet(tc, m.deps.Recorder, newSet)
+	if setNotExist {
+		if tc.Status.TiFlash.StatefulSet == nil {
+			klog.Infof("TidbCluster: %s/%s, waiting for the tiflash write nodes created", ns, tcName)
+			return nil
+		}
+		if err := mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet); err != nil {
+			return err
+		}
+		if err := m.deps.StatefulSetControl.CreateStatefulSet(tc, newSet); err != nil {
+			return err
+		}
+		tc.Status.TiFlash.ComputeNode.StatefulSet = &apps.StatefulSetStatus{}
+		return nil
+	}
+
+	if err := m.scaleTiFlashComputeNodes(tc, oldSet, newSet); err != nil {
+		return err
+	}
+	if !templateEqual(newSet, oldSet) || tc.Status.TiFlash.ComputeNode.Phase == v1alpha1.UpgradePhase {
+		if err := m.upgradeTiFlashComputeNodes(tc, oldSet, newSet); err != nil {
+			return err
+		}
+	}
+
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdateTiFlashComputeSTS", newSet, oldSet)
+}
+
+func (m *tiflashMemberManager) syncTiFlashComputeConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	newCm, err := getTiFlashComputeConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, controller.TiFlashComputeMemberName(tc.Name))
+		})
+	}
+
+	strategy := tiflashComputeCluster(tc).BaseTiFlashSpec().ConfigUpdateStrategy()
+	if err := mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, strategy, inUseName, newCm); err != nil {
+		return nil, err
+	}
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func (m *tiflashMemberManager) syncTiFlashComputeStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
+	if tc.Status.TiFlash.ComputeNode == nil {
+		tc.Status.TiFlash.ComputeNode = &v1alpha1.TiFlashComputeNodeStatus{}
+	}
+	status := tc.Status.TiFlash.ComputeNode
+	if set == nil {
+		// skip if not created yet
+		return nil
+	}
+	status.StatefulSet = &set.Status
+	if tc.Spec.TiFlash.Disaggregated.ComputeNode.Replicas != *set.Spec.Replicas {
+		status.Phase = v1alpha1.ScalePhase
+	} else if mngerutils.StatefulSetIsUpgrading(set) {
+		status.Phase = v1alpha1.UpgradePhase
+	} else {
+		status.Phase = v1alpha1.NormalPhase
+	}
+
+	// This only returns Up/Down/Offline stores
+	storesInfo, err := controller.GetPDClient(m.deps.PDControl, tc).GetStores()
+	if err != nil {
+		klog.Warningf("Fail to GetStores for TidbCluster %s/%s: %s", tc.Namespace, tc.Name, err)
+		return err
+	}
+	pattern, err := regexp.Compile(fmt.Sprintf(tiflashComputeStoreLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
+	if err != nil {
+		return err
+	}
+	stores := map[string]v1alpha1.TiKVStore{}
+	for _, store := range storesInfo.Stores {
+		s := m.getTiFlashStore(store)
+		if s == nil || !pattern.MatchString(store.Store.Address) {
+			continue
+		}
+		s.LastTransitionTime = metav1.Now()
+		if old, ok := status.Stores[s.ID]; ok && old.State == s.State {
+			s.LastTransitionTime = old.LastTransitionTime
+		}
+		stores[s.ID] = *s
+	}
+	status.Stores = stores
+	return nil
+}
+
+func tiflashComputeStoreByPodName(status *v1alpha1.TiFlashComputeNodeStatus, podName string) *v1alpha1.TiKVStore {
+	for _, store := range status.Stores {
+		if store.PodName == podName {
+			return &store
+		}
+	}
+	return nil
+}
+
+// scaleTiFlashComputeNodes scales in the compute nodes one by one, the store of the compute node is deleted before its
+// pod is removed. The compute nodes hold no regions, so the stores become tombstone soon. The compute nodes are
+// scaled out by the StatefulSet directly.
+func (m *tiflashMemberManager) scaleTiFlashComputeNodes(tc *v1alpha1.TidbCluster, oldSet, newSet *apps.StatefulSet) error {
+	scaling, ordinal, replicas, deleteSlots := scaleOne(oldSet, newSet)
+	if scaling >= 0 {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	podName := fmt.Sprintf("%s-%d", controller.TiFlashComputeMemberName(tc.GetName()), ordinal)
+	klog.Infof("scaling in tiflash compute statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", ns, oldSet.Name, ordinal, replicas, deleteSlots.List())
+
+	if store := tiflashComputeStoreByPodName(tc.Status.TiFlash.ComputeNode, podName); store != nil {
+		resetReplicas(newSet, oldSet)
+		id, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if store.State != v1alpha1.TiKVStateOffline {
+			if err := controller.GetPDClient(m.deps.PDControl, tc).DeleteStore(id); err != nil {
+				return fmt.Errorf("tiflash compute scale in: failed to delete store %d of %s/%s, error: %v", id, ns, podName, err)
+			}
+			klog.Infof("tiflash compute scale in: delete store %d for %s/%s successfully", id, ns, podName)
+		}
+		return controller.RequeueErrorf("TiFlash compute node %s/%s store %d is still in cluster, state: %s", ns, podName, id, store.State)
+	}
+	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+	return nil
+}
+
+// upgradeTiFlashComputeNodes upgrades the compute nodes one by one from the largest ordinal after the write nodes
+// are upgraded, the next one is upgraded after the store of the upgraded one is up.
+func (m *tiflashMemberManager) upgradeTiFlashComputeNodes(tc *v1alpha1.TidbCluster, oldSet, newSet *apps.StatefulSet) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	status := tc.Status.TiFlash.ComputeNode
+
+	if tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase || status.Phase == v1alpha1.ScalePhase {
+		klog.Infof("TidbCluster: [%s/%s]'s tiflash status is %s, tiflash compute status is %s, can not upgrade tiflash compute nodes",
+			ns, tcName, tc.Status.TiFlash.Phase, status.Phase)
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			return err
+		}
+		newSet.Spec.Template.Spec = *podSpec
+		return nil
+	}
+
+	status.Phase = v1alpha1.UpgradePhase
+	if !templateEqual(newSet, oldSet) {
+		return nil
+	}
+	if status.StatefulSet.UpdateRevision == status.StatefulSet.CurrentRevision {
+		return nil
+	}
+	if oldSet.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType || oldSet.Spec.UpdateStrategy.RollingUpdate == nil {
+		newSet.Spec.UpdateStrategy = oldSet.Spec.UpdateStrategy
+		klog.Warningf("tidbcluster: [%s/%s] TiFlash compute statefulset %s UpdateStrategy has been modified manually", ns, tcName, oldSet.GetName())
+		return nil
+	}
+
+	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
+		i := podOrdinals[_i]
+		podName := fmt.Sprintf("%s-%d", controller.TiFlashComputeMemberName(tcName), i)
+		pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil {
+			return fmt.Errorf("upgradeTiFlashComputeNodes: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+		}
+		revision, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s TiFlash compute pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
+		}
+		if revision == status.StatefulSet.UpdateRevision {
+			if !podutil.IsPodReady(pod) {
+				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded TiFlash compute pod: [%s] is not ready", ns, tcName, podName)
+			}
+			if store := tiflashComputeStoreByPodName(status, podName); store == nil || store.State != v1alpha1.TiKVStateUp {
+				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded TiFlash compute pod: [%s], store state is not UP", ns, tcName, podName)
+			}
+			continue
+		}
+
+		mngerutils.SetUpgradePartition(newSet, i)
+		return nil
+	}
+	return nil
+}
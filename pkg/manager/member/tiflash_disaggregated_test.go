@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func newTidbClusterForTiFlashDisaggregated() *v1alpha1.TidbCluster {
+	tc := newTidbClusterForTiflash()
+	tc.Spec.TiFlash.Image = "pingcap/tiflash:v7.5.0"
+	tc.Spec.TiFlash.Disaggregated = &v1alpha1.TiFlashDisaggregatedSpec{
+		S3: v1alpha1.TiFlashS3Storage{
+			Endpoint:   "http://minio:9000",
+			Bucket:     "tiflash",
+			Root:       "/basic",
+			SecretName: "s3-secret",
+		},
+		ComputeNode: v1alpha1.TiFlashComputeNodeSpec{
+			Replicas: 2,
+			StorageClaims: []v1alpha1.StorageClaim{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+				},
+			}},
+		},
+	}
+	return tc
+}
+
+func TestGetNewTiFlashComputeStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiFlashDisaggregated()
+	set, err := getNewTiFlashComputeStatefulSet(tc, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(set.Name).To(Equal("test-tiflash-compute"))
+	g.Expect(set.Spec.ServiceName).To(Equal("test-tiflash-compute-peer"))
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.ComponentLabelKey, label.TiFlashComputeLabelVal))
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue(label.ComponentLabelKey, label.TiFlashComputeLabelVal))
+	g.Expect(set.Spec.VolumeClaimTemplates).To(HaveLen(1))
+	g.Expect(set.Spec.Template.Spec.Containers[0].Image).To(Equal("pingcap/tiflash:v7.5.0"))
+	env := map[string]corev1.EnvVar{}
+	for _, e := range set.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e
+	}
+	g.Expect(env["HEADLESS_SERVICE_NAME"].Value).To(Equal("test-tiflash-compute-peer"))
+	g.Expect(env["S3_ACCESS_KEY_ID"].ValueFrom.SecretKeyRef.Name).To(Equal("s3-secret"))
+
+	cm, err := getTiFlashComputeConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Name).To(Equal("test-tiflash-compute"))
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring(`disaggregated_mode = "tiflash_compute"`))
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring(`endpoint = "http://minio:9000"`))
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring(`dir = "/data0/remote_cache"`))
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring("test-tiflash-compute-POD_NUM.test-tiflash-compute-peer.default.svc:20170"))
+	g.Expect(cm.Data["proxy_templ.toml"]).To(ContainSubstring("test-tiflash-compute-POD_NUM.test-tiflash-compute-peer.default.svc:3930"))
+
+	cm, err = getTiFlashConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring(`disaggregated_mode = "tiflash_write"`))
+	g.Expect(cm.Data["config_templ.toml"]).To(ContainSubstring(`bucket = "tiflash"`))
+}
+
+func TestScaleTiFlashComputeNodes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiFlashDisaggregated()
+	tmm, _, _, pdClient, _, _ := newFakeTiFlashMemberManager(tc)
+	var deleted []uint64
+	pdClient.AddReaction(pdapi.DeleteStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+		deleted = append(deleted, action.ID)
+		return nil, nil
+	})
+	tc.Status.TiFlash.ComputeNode = &v1alpha1.TiFlashComputeNodeStatus{
+		Stores: map[string]v1alpha1.TiKVStore{
+			"10": {ID: "10", PodName: "test-tiflash-compute-2", State: v1alpha1.TiKVStateUp},
+		},
+	}
+	oldSet := &apps.StatefulSet{Spec: apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)}}
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = pointer.Int32Ptr(2)
+
+	// the store is deleted before the pod is removed
+	err := tmm.scaleTiFlashComputeNodes(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(deleted).To(Equal([]uint64{10}))
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(3)))
+
+	// the pod is removed after the store becomes tombstone
+	tc.Status.TiFlash.ComputeNode.Stores = nil
+	newSet.Spec.Replicas = pointer.Int32Ptr(2)
+	g.Expect(tmm.scaleTiFlashComputeNodes(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(2)))
+}
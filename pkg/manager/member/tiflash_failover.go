@@ -39,6 +39,10 @@ func (tsa *tiflashStoreAccess) GetFailoverPeriod(cliConfig *controller.CLIConfig
 	return cliConfig.TiFlashFailoverPeriod
 }
 
+func (tsa *tiflashStoreAccess) GetFailover(tc *v1alpha1.TidbCluster) *v1alpha1.Failover {
+	return tc.Spec.TiFlash.Failover
+}
+
 func (tsa *tiflashStoreAccess) GetMemberType() v1alpha1.MemberType {
 	return v1alpha1.TiFlashMemberType
 }
@@ -106,7 +106,10 @@ func (m *tiflashMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	return m.syncStatefulSet(tc)
+	if err := m.syncStatefulSet(tc); err != nil {
+		return err
+	}
+	return m.syncTiFlashComputeNodes(tc)
 }
 
 func (m *tiflashMemberManager) syncRecoveryForTiFlash(tc *v1alpha1.TidbCluster) error {
@@ -170,6 +173,7 @@ func (m *tiflashMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) err
 	tcName := tc.GetName()
 
 	newSvc := getNewHeadlessService(tc)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.TiFlashPeerMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -223,7 +227,9 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 
 	oldSet := oldSetTmp.DeepCopy()
 
-	if err := m.syncTidbClusterStatus(tc, oldSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiFlashMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTidbClusterStatus(tc, oldSet)
+	}); err != nil {
 		return err
 	}
 
@@ -251,6 +257,14 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	digest, err := syncImageDigest(m.deps, tc, v1alpha1.TiFlashMemberType, tc.TiFlashImage(), tc.Status.TiFlash.ImageDigest, oldSet, newSet)
+	tc.Status.TiFlash.ImageDigest = digest
+	if err != nil {
+		return err
+	}
+	keepTerminationGracePeriodSeconds(tc.BaseTiFlashSpec(), newSet, oldSet)
+	newSet = overrideStatefulSet(tc, m.deps.Recorder, newSet)
 	if setNotExist {
 		if !tc.PDIsAvailable() {
 			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
@@ -277,20 +291,26 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldSet, newSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiFlashMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldSet, newSet)
+	}); err != nil {
 		return err
 	}
 
 	if m.deps.CLIConfig.AutoFailover && tc.Spec.TiFlash.MaxFailoverCount != nil {
 		if tc.TiFlashAllPodsStarted() && !tc.TiFlashAllStoresReady() {
-			if err := m.failover.Failover(tc); err != nil {
+			if err := ObserveReconcilePhase(tc, string(v1alpha1.TiFlashMemberType), ReconcilePhaseFailover, func() error {
+				return m.failover.Failover(tc)
+			}); err != nil {
 				return err
 			}
 		}
 	}
 
 	if !templateEqual(newSet, oldSet) || tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase {
-		if err := m.upgrader.Upgrade(tc, oldSet, newSet); err != nil {
+		if err := ObserveReconcilePhase(tc, string(v1alpha1.TiFlashMemberType), ReconcilePhaseUpgrade, func() error {
+			return m.upgrader.Upgrade(tc, oldSet, newSet)
+		}); err != nil {
 			return err
 		}
 	}
@@ -509,7 +529,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 	stsLabels := labelTiFlash(tc)
 	setName := controller.TiFlashMemberName(tcName)
 	podLabels := util.CombineStringMap(stsLabels, baseTiFlashSpec.Labels())
-	podAnnotations := util.CombineStringMap(baseTiFlashSpec.Annotations(), controller.AnnProm(8234, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiFlashSpec.Annotations(), controller.AnnProm(8234, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.TiFlashMemberType))
 	podAnnotations = util.CombineStringMap(controller.AnnAdditionalProm("tiflash.proxy", 20292), podAnnotations)
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiFlashLabelVal)
 	capacity := controller.TiKVCapacity(tc.Spec.TiFlash.Limits)
@@ -546,6 +566,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 			Value: tc.Timezone(),
 		},
 	}
+	env = append(env, tiflashS3CredentialsEnv(tc)...)
 
 	startScript, err := startscript.RenderTiFlashStartScript(tc)
 	if err != nil {
@@ -617,6 +638,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 	}
 	podSpec.Containers = append([]corev1.Container{tiflashContainer}, containers...)
 
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation(podSpec.Containers, baseTiFlashSpec.NativeSidecarContainers()))
 	podSpec.Containers, err = MergePatchContainers(podSpec.Containers, baseTiFlashSpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for TiFlash of [%s/%s], err: %v", ns, tcName, err)
@@ -687,6 +709,9 @@ func flashVolumeClaimTemplate(storageClaims []v1alpha1.StorageClaim) ([]corev1.P
 
 func getTiFlashConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	config := GetTiFlashConfig(tc)
+	if tc.TiFlashDisaggregated() {
+		setTiFlashDisaggregatedConfig(tc, config, tiflashDisaggregatedWriteMode)
+	}
 
 	configText, err := config.Common.MarshalTOML()
 	if err != nil {
@@ -773,6 +798,7 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		if exist && status.State == oldStore.State {
 			status.LastTransitionTime = oldStore.LastTransitionTime
 		}
+		status.ProbeFailures = nextProbeFailures(status.State != v1alpha1.TiKVStateDown, oldStore.ProbeFailures)
 
 		if store.Store != nil {
 			if pattern.Match([]byte(store.Store.Address)) {
@@ -816,7 +842,6 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 	if c != nil {
 		tc.Status.TiFlash.Image = c.Image
 	}
-
 	err = volumes.SyncVolumeStatus(m.podVolumeModifier, m.deps.PodLister, tc, v1alpha1.TiFlashMemberType)
 	if err != nil {
 		return fmt.Errorf("failed to sync volume status for tiflash: %v", err)
@@ -890,7 +915,7 @@ func (m *tiflashMemberManager) setStoreLabelsForTiFlash(tc *v1alpha1.TidbCluster
 		}
 
 		nodeName := pod.Spec.NodeName
-		ls, err := getNodeLabels(m.deps.NodeLister, nodeName, locationLabels)
+		ls, err := getNodeLabels(m.deps.NodeLister, nodeName, locationLabels, m.deps.CLIConfig.StoreLabelNodeLabels)
 		if err != nil || len(ls) == 0 {
 			klog.Warningf("node: [%s] has no node labels %v, skipping set store labels for Pod: [%s/%s]", nodeName, locationLabels, ns, podName)
 			continue
@@ -899,7 +924,9 @@ func (m *tiflashMemberManager) setStoreLabelsForTiFlash(tc *v1alpha1.TidbCluster
 		if !m.storeLabelsEqualNodeLabels(store.Store.Labels, ls) {
 			set, err := pdCli.SetStoreLabels(store.Store.Id, ls)
 			if err != nil {
-				klog.Warningf("failed to set pod: [%s/%s]'s store labels: %v", ns, podName, ls)
+				msg := fmt.Sprintf("failed to set labels %v for store (id: %d, pod: %s/%s): %v ",
+					ls, store.Store.Id, ns, podName, err)
+				m.deps.Recorder.Event(tc, corev1.EventTypeWarning, FailedSetStoreLabels, msg)
 				continue
 			}
 			if set {
@@ -123,6 +123,10 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s TiFlash pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tiflash pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == tc.Status.TiFlash.StatefulSet.UpdateRevision {
 			if !podutil.IsPodAvailable(pod, int32(minReadySeconds), metav1.Now()) {
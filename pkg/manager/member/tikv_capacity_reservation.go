@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+const (
+	// tikvReservationLabelVal is the component label value of the placeholder pods of TiKV
+	tikvReservationLabelVal = "tikv-reservation"
+	// defaultReservationImage is the default image of the placeholder pods
+	defaultReservationImage = "registry.k8s.io/pause:3.9"
+)
+
+func tikvReservationName(tcName string) string {
+	return fmt.Sprintf("%s-reservation", controller.TiKVMemberName(tcName))
+}
+
+// syncCapacityReservation syncs the Deployment of the placeholder pods of `spec.tikv.capacityReservation`,
+// the Deployment is removed if the reservation is not set.
+//
+// The placeholder pods request the same resources as a TiKV pod but have a lower priority, and they are kept
+// away from the nodes of the TiKV pods. The scheduler preempts one of them once a replacement TiKV pod can not
+// be scheduled during failover, and the preempted placeholder is pending until there are new nodes.
+func (m *tikvMemberManager) syncCapacityReservation(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	name := tikvReservationName(tc.GetName())
+
+	if tc.Spec.TiKV.CapacityReservation == nil {
+		deploy := &apps.Deployment{}
+		exist, err := m.deps.TypedControl.Exist(client.ObjectKey{Namespace: ns, Name: name}, deploy)
+		if err != nil {
+			return fmt.Errorf("syncCapacityReservation: failed to get deployment %s/%s, error: %v", ns, name, err)
+		}
+		if !exist || !metav1.IsControlledBy(deploy, tc) {
+			return nil
+		}
+		if err := m.deps.TypedControl.Delete(tc, deploy); err != nil {
+			return fmt.Errorf("syncCapacityReservation: failed to delete deployment %s/%s, error: %v", ns, name, err)
+		}
+		return nil
+	}
+
+	deploy := getNewTiKVReservationDeployment(tc, m.deps.CLIConfig.RegistryMirrors)
+	if _, err := m.deps.TypedControl.CreateOrUpdateDeployment(tc, deploy); err != nil {
+		return fmt.Errorf("syncCapacityReservation: failed to create or update deployment %s/%s, error: %v", ns, name, err)
+	}
+	return nil
+}
+
+func getNewTiKVReservationDeployment(tc *v1alpha1.TidbCluster, registryMirrors map[string]string) *apps.Deployment {
+	spec := tc.Spec.TiKV.CapacityReservation
+	baseTiKVSpec := tc.BaseTiKVSpec()
+	instanceName := tc.GetInstanceName()
+	reservationLabels := label.New().Instance(instanceName).Component(tikvReservationLabelVal)
+
+	replicas := int32(1)
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+	image := spec.Image
+	if image == "" {
+		image = defaultReservationImage
+	}
+	nodeSelector := spec.NodeSelector
+	if len(nodeSelector) == 0 {
+		nodeSelector = baseTiKVSpec.NodeSelector()
+	}
+
+	// request the same resources as a TiKV pod except the storage, which is not reserved by the placeholder
+	requests := corev1.ResourceList{}
+	for name, quantity := range tc.Spec.TiKV.Requests {
+		if name == corev1.ResourceStorage {
+			continue
+		}
+		requests[name] = quantity
+	}
+
+	affinity := &corev1.Affinity{
+		// keep the placeholder pods on the standby nodes without TiKV pods, one placeholder per node
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: label.New().Instance(instanceName).TiKV().LabelSelector(),
+					TopologyKey:   corev1.LabelHostname,
+				},
+				{
+					LabelSelector: reservationLabels.LabelSelector(),
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+		},
+	}
+	if tikvAffinity := baseTiKVSpec.Affinity(); tikvAffinity != nil && tikvAffinity.NodeAffinity != nil {
+		affinity.NodeAffinity = tikvAffinity.NodeAffinity.DeepCopy()
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:      "pause",
+				Image:     image,
+				Resources: corev1.ResourceRequirements{Requests: requests},
+			},
+		},
+		PriorityClassName:             spec.PriorityClassName,
+		TerminationGracePeriodSeconds: pointer.Int64Ptr(0),
+		NodeSelector:                  nodeSelector,
+		Tolerations:                   baseTiKVSpec.Tolerations(),
+		Affinity:                      affinity,
+		ImagePullSecrets:              baseTiKVSpec.ImagePullSecrets(),
+	}
+	RewritePodImages(&podSpec, registryMirrors, tc.Spec.RegistryMirrors)
+
+	return &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tikvReservationName(tc.GetName()),
+			Namespace:       tc.GetNamespace(),
+			Labels:          reservationLabels.Copy(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: reservationLabels.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: reservationLabels.Copy(),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestGetNewTiKVReservationDeployment(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.NodeSelector = map[string]string{"pool": "tikv"}
+	tc.Spec.TiKV.CapacityReservation = &v1alpha1.CapacityReservation{
+		Replicas:          pointer.Int32Ptr(2),
+		PriorityClassName: "tikv-reservation",
+	}
+
+	deploy := getNewTiKVReservationDeployment(tc, nil)
+	g.Expect(deploy.Name).To(Equal("test-tikv-reservation"))
+	g.Expect(*deploy.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(deploy.Spec.Selector.MatchLabels).To(HaveKeyWithValue(label.ComponentLabelKey, "tikv-reservation"))
+
+	podSpec := deploy.Spec.Template.Spec
+	g.Expect(podSpec.PriorityClassName).To(Equal("tikv-reservation"))
+	g.Expect(podSpec.NodeSelector).To(Equal(map[string]string{"pool": "tikv"}))
+	g.Expect(podSpec.Containers).To(HaveLen(1))
+	g.Expect(podSpec.Containers[0].Image).To(Equal(defaultReservationImage))
+	// the placeholder requests the resources of a TiKV pod except the storage
+	requests := podSpec.Containers[0].Resources.Requests
+	g.Expect(requests).To(HaveKey(corev1.ResourceCPU))
+	g.Expect(requests).To(HaveKey(corev1.ResourceMemory))
+	g.Expect(requests).NotTo(HaveKey(corev1.ResourceStorage))
+	g.Expect(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(2))
+
+	// the node selector of the reservation takes precedence
+	tc.Spec.TiKV.CapacityReservation.NodeSelector = map[string]string{"pool": "standby"}
+	tc.Spec.TiKV.CapacityReservation.Image = "my-registry/pause:3.9"
+	deploy = getNewTiKVReservationDeployment(tc, nil)
+	g.Expect(deploy.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "standby"}))
+	g.Expect(deploy.Spec.Template.Spec.Containers[0].Image).To(Equal("my-registry/pause:3.9"))
+}
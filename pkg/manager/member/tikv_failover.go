@@ -39,6 +39,10 @@ func (tsa *tikvStoreAccess) GetFailoverPeriod(cliConfig *controller.CLIConfig) t
 	return cliConfig.TiKVFailoverPeriod
 }
 
+func (tsa *tikvStoreAccess) GetFailover(tc *v1alpha1.TidbCluster) *v1alpha1.Failover {
+	return tc.Spec.TiKV.Failover
+}
+
 func (tsa *tikvStoreAccess) GetMemberType() v1alpha1.MemberType {
 	return v1alpha1.TiKVMemberType
 }
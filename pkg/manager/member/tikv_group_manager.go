@@ -0,0 +1,247 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+type tikvGroupManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTiKVGroupManager returns a manager which deploys each TiKV group of the TidbCluster
+// by a heterogeneous TidbCluster, so that every group is rendered as its own StatefulSet.
+func NewTiKVGroupManager(deps *controller.Dependencies) manager.Manager {
+	return &tikvGroupManager{
+		deps: deps,
+	}
+}
+
+func (m *tikvGroupManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Heterogeneous() {
+		// the groups can not be nested
+		return nil
+	}
+
+	groupClusters, err := m.listGroupClusters(tc)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs     []error
+		statuses []v1alpha1.TiKVGroupStatus
+		groups   = map[string]struct{}{}
+	)
+	for i := range tc.Spec.TiKVGroups {
+		group := &tc.Spec.TiKVGroups[i]
+		groups[group.Name] = struct{}{}
+		groupTC, err := m.syncGroupCluster(tc, group, groupClusters[group.Name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		statuses = append(statuses, newTiKVGroupStatus(group.Name, groupTC))
+	}
+	for name, groupTC := range groupClusters {
+		if _, ok := groups[name]; ok {
+			continue
+		}
+		if err := m.removeGroupCluster(tc, name, groupTC); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tc.Status.TiKVGroups = statuses
+
+	return errorutils.NewAggregate(errs)
+}
+
+// listGroupClusters returns the heterogeneous clusters of the TiKV groups, keyed by the group name
+func (m *tikvGroupManager) listGroupClusters(tc *v1alpha1.TidbCluster) (map[string]*v1alpha1.TidbCluster, error) {
+	ns := tc.GetNamespace()
+	r, err := labels.NewRequirement(label.TiKVGroupLabelKey, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.SelectorFromSet(labels.Set{label.BaseTCLabelKey: tc.GetName()}).Add(*r)
+	tcs, err := m.deps.TiDBClusterLister.TidbClusters(ns).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("syncTiKVGroups: failed to list the clusters of tikv groups of tc %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+
+	groupClusters := map[string]*v1alpha1.TidbCluster{}
+	for _, groupTC := range tcs {
+		if !metav1.IsControlledBy(groupTC, tc) {
+			continue
+		}
+		groupClusters[groupTC.Labels[label.TiKVGroupLabelKey]] = groupTC
+	}
+	return groupClusters, nil
+}
+
+// syncGroupCluster creates or updates the heterogeneous cluster of the TiKV group
+func (m *tikvGroupManager) syncGroupCluster(tc *v1alpha1.TidbCluster, group *v1alpha1.TiKVGroup, groupTC *v1alpha1.TidbCluster) (*v1alpha1.TidbCluster, error) {
+	ns := tc.GetNamespace()
+	newGroupTC := newTiKVGroupCluster(tc, group)
+	// the spec of the cluster is defaulted by the controller, so compare the last applied spec instead
+	lastApplied, err := json.Marshal(newGroupTC.Spec)
+	if err != nil {
+		return nil, err
+	}
+	newGroupTC.Annotations = map[string]string{util.LastAppliedConfigAnnotation: string(lastApplied)}
+
+	if groupTC == nil {
+		created, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Create(context.TODO(), newGroupTC, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("syncTiKVGroups: failed to create tc %s/%s for tikv group %s, error: %v", ns, newGroupTC.Name, group.Name, err)
+		}
+		klog.Infof("syncTiKVGroups: tc %s/%s is created for tikv group %s of tc %s/%s", ns, created.Name, group.Name, ns, tc.GetName())
+		m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "SuccessfulCreate", "create TidbCluster %s for TiKV group %s successful", created.Name, group.Name)
+		return created, nil
+	}
+
+	if groupTC.Annotations[util.LastAppliedConfigAnnotation] == string(lastApplied) {
+		return groupTC, nil
+	}
+	updateTC := groupTC.DeepCopy()
+	updateTC.Labels = newGroupTC.Labels
+	if updateTC.Annotations == nil {
+		updateTC.Annotations = map[string]string{}
+	}
+	updateTC.Annotations[util.LastAppliedConfigAnnotation] = string(lastApplied)
+	updateTC.Spec = newGroupTC.Spec
+	updated, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), updateTC, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("syncTiKVGroups: failed to update tc %s/%s for tikv group %s, error: %v", ns, updateTC.Name, group.Name, err)
+	}
+	klog.Infof("syncTiKVGroups: tc %s/%s is updated for tikv group %s of tc %s/%s", ns, updated.Name, group.Name, ns, tc.GetName())
+	return updated, nil
+}
+
+// removeGroupCluster scales in the TiKV of the removed group at first, and deletes the heterogeneous
+// cluster after all the stores of the group are removed from PD, so that no data is lost.
+func (m *tikvGroupManager) removeGroupCluster(tc *v1alpha1.TidbCluster, name string, groupTC *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+
+	if groupTC.Spec.TiKV != nil && groupTC.Spec.TiKV.Replicas > 0 {
+		updateTC := groupTC.DeepCopy()
+		updateTC.Spec.TiKV.Replicas = 0
+		if _, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), updateTC, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("syncTiKVGroups: failed to scale in tc %s/%s for removed tikv group %s, error: %v", ns, groupTC.Name, name, err)
+		}
+		klog.Infof("syncTiKVGroups: tikv group %s of tc %s/%s is removed, scale in tc %s/%s", name, ns, tc.GetName(), ns, groupTC.Name)
+		return nil
+	}
+
+	sts := groupTC.Status.TiKV.StatefulSet
+	if (sts != nil && sts.Replicas > 0) || len(groupTC.Status.TiKV.Stores) > 0 {
+		klog.Infof("syncTiKVGroups: waiting for the tikv of tc %s/%s to be scaled in", ns, groupTC.Name)
+		return nil
+	}
+
+	if err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Delete(context.TODO(), groupTC.Name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("syncTiKVGroups: failed to delete tc %s/%s for removed tikv group %s, error: %v", ns, groupTC.Name, name, err)
+	}
+	klog.Infof("syncTiKVGroups: tc %s/%s is deleted for removed tikv group %s of tc %s/%s", ns, groupTC.Name, name, ns, tc.GetName())
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "SuccessfulDelete", "delete TidbCluster %s for TiKV group %s successful", groupTC.Name, name)
+	return nil
+}
+
+// newTiKVGroupCluster returns the heterogeneous cluster which deploys the TiKV group,
+// the cluster-level settings are inherited from the TidbCluster.
+func newTiKVGroupCluster(tc *v1alpha1.TidbCluster, group *v1alpha1.TiKVGroup) *v1alpha1.TidbCluster {
+	// the labels of the TidbCluster are kept so that the cluster can be selected by the same controller manager
+	groupLabels := map[string]string{}
+	for k, v := range tc.GetLabels() {
+		groupLabels[k] = v
+	}
+	groupLabels[label.BaseTCLabelKey] = tc.GetName()
+	groupLabels[label.TiKVGroupLabelKey] = group.Name
+
+	groupTC := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            TiKVGroupClusterName(tc.GetName(), group.Name),
+			Namespace:       tc.GetNamespace(),
+			Labels:          groupLabels,
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: *tc.Spec.DeepCopy(),
+	}
+
+	groupTC.Spec.Cluster = &v1alpha1.TidbClusterRef{
+		Namespace: tc.GetNamespace(),
+		Name:      tc.GetName(),
+	}
+	groupTC.Spec.PD = nil
+	groupTC.Spec.TiDB = nil
+	groupTC.Spec.TiFlash = nil
+	groupTC.Spec.TiCDC = nil
+	groupTC.Spec.TiProxy = nil
+	groupTC.Spec.Pump = nil
+	groupTC.Spec.Services = nil
+	groupTC.Spec.TiKVGroups = nil
+	groupTC.Spec.TiKV = group.Spec.DeepCopy()
+
+	return groupTC
+}
+
+func newTiKVGroupStatus(name string, groupTC *v1alpha1.TidbCluster) v1alpha1.TiKVGroupStatus {
+	status := v1alpha1.TiKVGroupStatus{
+		Name:    name,
+		Cluster: groupTC.GetName(),
+		Phase:   groupTC.Status.TiKV.Phase,
+	}
+	if sts := groupTC.Status.TiKV.StatefulSet; sts != nil {
+		status.Replicas = sts.Replicas
+		status.ReadyReplicas = sts.ReadyReplicas
+	}
+	return status
+}
+
+// TiKVGroupClusterName returns the name of the heterogeneous cluster which deploys the TiKV group
+func TiKVGroupClusterName(tcName, group string) string {
+	return fmt.Sprintf("%s-%s", tcName, group)
+}
+
+type FakeTiKVGroupManager struct {
+	err error
+}
+
+func NewFakeTiKVGroupManager() *FakeTiKVGroupManager {
+	return &FakeTiKVGroupManager{}
+}
+
+func (m *FakeTiKVGroupManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiKVGroupManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
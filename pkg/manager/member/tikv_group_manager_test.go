@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestTiKVGroupManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	m := NewTiKVGroupManager(deps).(*tikvGroupManager)
+	indexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	cli := deps.Clientset.PingcapV1alpha1().TidbClusters(metav1.NamespaceDefault)
+	getGroupTC := func(name string) *v1alpha1.TidbCluster {
+		groupTC, err := cli.Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		return groupTC
+	}
+
+	tc := newTidbClusterForTiKV()
+	tc.UID = "test-uid"
+	tc.Labels = map[string]string{"foo": "bar"}
+	tc.Spec.Version = "v7.1.0"
+	tc.Spec.TiKVGroups = []v1alpha1.TiKVGroup{
+		{Name: "hot", Spec: v1alpha1.TiKVSpec{Replicas: 3}},
+		{Name: "cold", Spec: v1alpha1.TiKVSpec{Replicas: 2}},
+	}
+
+	// create the clusters of the groups
+	g.Expect(m.Sync(tc)).To(Succeed())
+	hot := getGroupTC("test-hot")
+	g.Expect(hot.Labels).To(Equal(map[string]string{
+		"foo":                   "bar",
+		label.BaseTCLabelKey:    "test",
+		label.TiKVGroupLabelKey: "hot",
+	}))
+	g.Expect(metav1.IsControlledBy(hot, tc)).To(BeTrue())
+	g.Expect(hot.Spec.Cluster).To(Equal(&v1alpha1.TidbClusterRef{Namespace: metav1.NamespaceDefault, Name: "test"}))
+	g.Expect(hot.Spec.Version).To(Equal("v7.1.0"))
+	g.Expect(hot.Spec.PD).To(BeNil())
+	g.Expect(hot.Spec.TiDB).To(BeNil())
+	g.Expect(hot.Spec.TiKVGroups).To(BeNil())
+	g.Expect(hot.Spec.TiKV.Replicas).To(Equal(int32(3)))
+	g.Expect(getGroupTC("test-cold").Spec.TiKV.Replicas).To(Equal(int32(2)))
+	g.Expect(tc.Status.TiKVGroups).To(HaveLen(2))
+	g.Expect(tc.Status.TiKVGroups[0].Cluster).To(Equal("test-hot"))
+
+	for _, name := range []string{"test-hot", "test-cold"} {
+		g.Expect(indexer.Add(getGroupTC(name))).To(Succeed())
+	}
+
+	// update the spec of the group
+	tc.Spec.TiKVGroups[0].Spec.Replicas = 5
+	g.Expect(m.Sync(tc)).To(Succeed())
+	hot = getGroupTC("test-hot")
+	g.Expect(hot.Spec.TiKV.Replicas).To(Equal(int32(5)))
+	g.Expect(indexer.Update(hot)).To(Succeed())
+
+	// the spec defaulted by the controller is not overwritten if the group is not changed
+	hot.Spec.TiKV.BaseImage = "pingcap/tikv"
+	_, err := cli.Update(context.TODO(), hot, metav1.UpdateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(indexer.Update(hot)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(getGroupTC("test-hot").Spec.TiKV.BaseImage).To(Equal("pingcap/tikv"))
+
+	// the removed group is scaled in before deleted
+	tc.Spec.TiKVGroups = tc.Spec.TiKVGroups[:1]
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKVGroups).To(HaveLen(1))
+	cold := getGroupTC("test-cold")
+	g.Expect(cold.Spec.TiKV.Replicas).To(Equal(int32(0)))
+
+	cold.Status.TiKV.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 1}
+	cold.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{"1": {ID: "1"}}
+	g.Expect(indexer.Update(cold)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	getGroupTC("test-cold")
+
+	cold.Status.TiKV.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 0}
+	cold.Status.TiKV.Stores = nil
+	g.Expect(indexer.Update(cold)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	_, err = cli.Get(context.TODO(), "test-cold", metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
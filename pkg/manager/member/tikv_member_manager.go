@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -37,11 +38,15 @@ import (
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/utils/pointer"
 )
 
@@ -131,7 +136,19 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 			return err
 		}
 	}
-	return m.syncStatefulSetForTidbCluster(tc)
+	if err := m.syncStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+	if err := m.syncCapacityReservation(tc); err != nil {
+		return err
+	}
+	if err := m.syncStoreGC(tc); err != nil {
+		return err
+	}
+	if err := m.syncSlowStores(tc); err != nil {
+		return err
+	}
+	return m.syncIOTuning(tc)
 }
 
 func (m *tikvMemberManager) checkRecoveryForTidbCluster(tc *v1alpha1.TidbCluster) error {
@@ -162,6 +179,7 @@ func (m *tikvMemberManager) syncServiceForTidbCluster(tc *v1alpha1.TidbCluster,
 	tcName := tc.GetName()
 
 	newSvc := getNewServiceForTidbCluster(tc, svcConfig)
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(svcConfig.MemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -208,7 +226,9 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 
 	oldSet := oldSetTmp.DeepCopy()
 
-	if err := m.syncTiKVClusterStatus(tc, oldSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiKVMemberType), ReconcilePhaseStatus, func() error {
+		return m.syncTiKVClusterStatus(tc, oldSet)
+	}); err != nil {
 		return err
 	}
 
@@ -236,6 +256,14 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSet.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	digest, err := syncImageDigest(m.deps, tc, v1alpha1.TiKVMemberType, tc.TiKVImage(), tc.Status.TiKV.ImageDigest, oldSet, newSet)
+	tc.Status.TiKV.ImageDigest = digest
+	if err != nil {
+		return err
+	}
+	keepTerminationGracePeriodSeconds(tc.BaseTiKVSpec(), newSet, oldSet)
+	newSet = overrideStatefulSet(tc, m.deps.Recorder, newSet)
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
@@ -258,7 +286,9 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldSet, newSet); err != nil {
+	if err := ObserveReconcilePhase(tc, string(v1alpha1.TiKVMemberType), ReconcilePhaseScale, func() error {
+		return m.scaler.Scale(tc, oldSet, newSet)
+	}); err != nil {
 		return err
 	}
 
@@ -267,14 +297,18 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	// new replica needs to be added).
 	if m.deps.CLIConfig.AutoFailover && tc.Spec.TiKV.MaxFailoverCount != nil {
 		if tc.TiKVAllPodsStarted() && !tc.TiKVAllStoresReady() {
-			if err := m.failover.Failover(tc); err != nil {
+			if err := ObserveReconcilePhase(tc, string(v1alpha1.TiKVMemberType), ReconcilePhaseFailover, func() error {
+				return m.failover.Failover(tc)
+			}); err != nil {
 				return err
 			}
 		}
 	}
 
 	if !templateEqual(newSet, oldSet) || tc.Status.TiKV.Phase == v1alpha1.UpgradePhase {
-		if err := m.upgrader.Upgrade(tc, oldSet, newSet); err != nil {
+		if err := ObserveReconcilePhase(tc, string(v1alpha1.TiKVMemberType), ReconcilePhaseUpgrade, func() error {
+			return m.upgrader.Upgrade(tc, oldSet, newSet)
+		}); err != nil {
 			return err
 		}
 	}
@@ -299,13 +333,47 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 		})
 	}
 
-	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BaseTiKVSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	strategy := tc.BaseTiKVSpec().ConfigUpdateStrategy()
+	if strategy == v1alpha1.ConfigUpdateStrategyHotReload {
+		err = mngerutils.HotReloadConfigMapIfNeed(m.deps.ConfigMapLister, v1alpha1.TiKVMemberType, tc.TiKVVersion(), inUseName, newCm, func(config map[string]interface{}) error {
+			return m.modifyTiKVConfig(tc, config)
+		})
+	} else {
+		err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, strategy, inUseName, newCm)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// modifyTiKVConfig changes the config items of all the ready TiKV pods online, the pods which are not ready
+// load the new config from the configmap after they restart.
+func (m *tikvMemberManager) modifyTiKVConfig(tc *v1alpha1.TidbCluster, config map[string]interface{}) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiKV().Selector()
+	if err != nil {
+		return err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("modifyTiKVConfig: failed to list pods for cluster %s/%s, selector %s, error: %s", ns, tcName, selector, err)
+	}
+
+	var errs []error
+	for _, pod := range pods {
+		if !podutil.IsPodReady(pod) {
+			continue
+		}
+		tikvClient := m.deps.TiKVControl.GetTiKVPodClient(ns, tcName, pod.Name, tc.IsTLSClusterEnabled())
+		if err := tikvClient.ModifyConfig(config); err != nil {
+			errs = append(errs, fmt.Errorf("failed to modify config of tikv pod %s/%s: %v", ns, pod.Name, err))
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
 func getNewServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
@@ -423,7 +491,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		}
 	}
 	// handle StorageVolumes and AdditionalVolumeMounts in ComponentSpec
-	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.TiKV.StorageVolumes, tc.Spec.TiKV.StorageClassName, v1alpha1.TiKVMemberType)
+	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.TiKV.AllStorageVolumes(), tc.Spec.TiKV.StorageClassName, v1alpha1.TiKVMemberType)
 	volMounts = append(volMounts, storageVolMounts...)
 
 	sysctls := "sysctl -w"
@@ -473,7 +541,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	stsLabels := labelTiKV(tc)
 	podLabels := util.CombineStringMap(stsLabels.Labels(), baseTiKVSpec.Labels())
 	setName := controller.TiKVMemberName(tcName)
-	podAnnotations := util.CombineStringMap(baseTiKVSpec.Annotations(), controller.AnnProm(20180, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiKVSpec.Annotations(), controller.AnnProm(20180, "/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.TiKVMemberType))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiKVLabelVal)
 	capacity := controller.TiKVCapacity(tc.Spec.TiKV.Limits)
 	headlessSvcName := controller.TiKVPeerMemberName(tcName)
@@ -673,6 +741,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	podSpec.SecurityContext = podSecurityContext
 	podSpec.InitContainers = append(initContainers, baseTiKVSpec.InitContainers()...)
 
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation(containers, baseTiKVSpec.NativeSidecarContainers()))
 	podSpec.Containers, err = MergePatchContainers(containers, baseTiKVSpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for TiKV of [%s/%s], error: %v", ns, tcName, err)
@@ -817,6 +886,14 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	// Scaling takes precedence over upgrading.
 	if tc.TiKVStsDesiredReplicas() != *set.Spec.Replicas {
 		tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+		if tc.TiKVScaleOutBalanceGateEnabled() && tc.TiKVStsDesiredReplicas() > *set.Spec.Replicas {
+			tc.Status.TiKV.SetCondition(metav1.Condition{
+				Type:    v1alpha1.ComponentRegionBalancing,
+				Status:  metav1.ConditionTrue,
+				Reason:  "ScalingOut",
+				Message: "TiKV is scaling out",
+			})
+		}
 	} else if upgrading && tc.Status.PD.Phase != v1alpha1.UpgradePhase {
 		if !tc.IsComponentLeaderEvicting(v1alpha1.TiKVMemberType) { // skip upgrade if someone is evicting leader
 			tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
@@ -850,6 +927,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	if err != nil {
 		return err
 	}
+	var regionCounts, leaderCounts []int
 	for _, store := range storesInfo.Stores {
 		status := getTiKVStore(store)
 		if status == nil {
@@ -865,16 +943,28 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		if exist && status.State == oldStore.State {
 			status.LastTransitionTime = oldStore.LastTransitionTime
 		}
+		status.ProbeFailures = nextProbeFailures(status.State != v1alpha1.TiKVStateDown, oldStore.ProbeFailures)
 
 		if oldStore.LeaderCountBeforeUpgrade != nil {
 			status.LeaderCountBeforeUpgrade = oldStore.LeaderCountBeforeUpgrade
 		}
+		// the slowness is tracked by syncSlowStores
+		status.SlowSince = oldStore.SlowSince
+		status.LeaderEvicted = oldStore.LeaderEvicted
+
+		if status.State == v1alpha1.TiKVStateOffline {
+			status.DrainProgress = getTiKVStoreDrainProgress(oldStore.DrainProgress, int32(store.Status.RegionCount), status.LeaderCount, time.Now())
+		}
 
 		// In theory, the external tikv can join the cluster, and the operator would only manage the internal tikv.
 		// So we check the store owner to make sure it.
 		if store.Store != nil {
 			if pattern.Match([]byte(store.Store.Address)) {
 				stores[status.ID] = *status
+				if status.State == v1alpha1.TiKVStateUp {
+					regionCounts = append(regionCounts, store.Status.RegionCount)
+					leaderCounts = append(leaderCounts, store.Status.LeaderCount)
+				}
 			} else if util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
 				peerStores[status.ID] = *status
 			}
@@ -914,6 +1004,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	if c != nil {
 		tc.Status.TiKV.Image = c.Image
 	}
+	m.syncScaleOutBalance(tc, regionCounts, leaderCounts)
 
 	err = volumes.SyncVolumeStatus(m.podVolumeModifier, m.deps.PodLister, tc, v1alpha1.TiKVMemberType)
 	if err != nil {
@@ -923,6 +1014,83 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	return nil
 }
 
+// syncScaleOutBalance keeps TiKV in the scale phase after the new stores are up, until the regions
+// and leaders are balanced among the stores within the max skew, or the timeout since the scaling
+// out begins is hit.
+func (m *tikvMemberManager) syncScaleOutBalance(tc *v1alpha1.TidbCluster, regionCounts, leaderCounts []int) {
+	cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentRegionBalancing)
+	if cond == nil || cond.Status != metav1.ConditionTrue || tc.Status.TiKV.Phase != v1alpha1.NormalPhase {
+		return
+	}
+	if !tc.TiKVScaleOutBalanceGateEnabled() {
+		tc.Status.TiKV.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentRegionBalancing,
+			Status:  metav1.ConditionFalse,
+			Reason:  "GateDisabled",
+			Message: "scale out balance gate is disabled",
+		})
+		return
+	}
+
+	skew := storeCountSkewPercent(regionCounts)
+	if leaderSkew := storeCountSkewPercent(leaderCounts); leaderSkew > skew {
+		skew = leaderSkew
+	}
+	maxSkew := tc.TiKVScaleOutBalanceMaxSkewPercent()
+	timeout := tc.TiKVScaleOutBalanceTimeout()
+
+	switch {
+	case skew <= maxSkew:
+		msg := fmt.Sprintf("regions and leaders are balanced among the stores with skew %d%%", skew)
+		tc.Status.TiKV.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentRegionBalancing,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Balanced",
+			Message: msg,
+		})
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, "ScaleOutBalanced", msg)
+	case time.Since(cond.LastTransitionTime.Time) > timeout:
+		msg := fmt.Sprintf("regions and leaders are not balanced among the stores within %v, skew %d%% exceeds %d%%", timeout, skew, maxSkew)
+		tc.Status.TiKV.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentRegionBalancing,
+			Status:  metav1.ConditionFalse,
+			Reason:  "BalanceTimeout",
+			Message: msg,
+		})
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "ScaleOutBalanceTimeout", msg)
+	default:
+		tc.Status.TiKV.Phase = v1alpha1.ScalePhase
+		tc.Status.TiKV.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentRegionBalancing,
+			Status:  metav1.ConditionTrue,
+			Reason:  "WaitingForBalance",
+			Message: fmt.Sprintf("waiting for regions and leaders to be balanced among the stores, skew %d%% exceeds %d%%", skew, maxSkew),
+		})
+		klog.Infof("TidbCluster: [%s/%s], waiting for the balance of TiKV stores after scaling out, skew %d%% exceeds %d%%",
+			tc.Namespace, tc.Name, skew, maxSkew)
+	}
+}
+
+// storeCountSkewPercent returns the difference between the max and the min counts divided by the max count in percentage
+func storeCountSkewPercent(counts []int) int32 {
+	if len(counts) == 0 {
+		return 0
+	}
+	min, max := counts[0], counts[0]
+	for _, c := range counts[1:] {
+		if c < min {
+			min = c
+		}
+		if c > max {
+			max = c
+		}
+	}
+	if max == 0 {
+		return 0
+	}
+	return int32((max - min) * 100 / max)
+}
+
 func getTiKVStore(store *pdapi.StoreInfo) *v1alpha1.TiKVStore {
 	if store.Store == nil || store.Status == nil {
 		return nil
@@ -931,13 +1099,49 @@ func getTiKVStore(store *pdapi.StoreInfo) *v1alpha1.TiKVStore {
 	ip := strings.Split(store.Store.GetAddress(), ":")[0]
 	podName := strings.Split(ip, ".")[0]
 
-	return &v1alpha1.TiKVStore{
+	tikvStore := &v1alpha1.TiKVStore{
 		ID:          storeID,
 		PodName:     podName,
 		IP:          ip,
 		LeaderCount: int32(store.Status.LeaderCount),
+		RegionCount: int32(store.Status.RegionCount),
 		State:       store.Store.StateName,
+		SlowScore:   store.Status.SlowScore,
 	}
+	if store.Status.Capacity > 0 {
+		tikvStore.Capacity = resource.NewQuantity(int64(store.Status.Capacity), resource.BinarySI)
+		tikvStore.Available = resource.NewQuantity(int64(store.Status.Available), resource.BinarySI)
+	}
+	if !store.Status.StartTS.IsZero() {
+		tikvStore.StartTime = &metav1.Time{Time: store.Status.StartTS}
+	}
+	if !store.Status.LastHeartbeatTS.IsZero() {
+		tikvStore.LastHeartbeatTime = &metav1.Time{Time: store.Status.LastHeartbeatTS}
+	}
+	return tikvStore
+}
+
+// getTiKVStoreDrainProgress returns the drain progress of an offline store,
+// the completion time is estimated by the average drain speed since the store began to drain.
+func getTiKVStoreDrainProgress(old *v1alpha1.TiKVStoreDrainProgress, regionCount, leaderCount int32, now time.Time) *v1alpha1.TiKVStoreDrainProgress {
+	progress := &v1alpha1.TiKVStoreDrainProgress{
+		StartTime:          metav1.Time{Time: now},
+		InitialRegionCount: regionCount,
+		RegionCount:        regionCount,
+		LeaderCount:        leaderCount,
+	}
+	if old != nil {
+		progress.StartTime = old.StartTime
+		progress.InitialRegionCount = old.InitialRegionCount
+	}
+
+	drained := progress.InitialRegionCount - regionCount
+	elapsed := now.Sub(progress.StartTime.Time)
+	if drained > 0 && elapsed > 0 {
+		remaining := time.Duration(float64(elapsed) / float64(drained) * float64(regionCount))
+		progress.EstimatedCompletionTime = &metav1.Time{Time: now.Add(remaining)}
+	}
+	return progress
 }
 
 func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int, error) {
@@ -993,7 +1197,7 @@ func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int
 		}
 
 		nodeName := pod.Spec.NodeName
-		ls, err := getNodeLabels(m.deps.NodeLister, nodeName, storeLabels)
+		ls, err := getNodeLabels(m.deps.NodeLister, nodeName, storeLabels, m.deps.CLIConfig.StoreLabelNodeLabels)
 		if err != nil || len(ls) == 0 {
 			klog.Warningf("node: [%s] has no node labels %v, skipping set store labels for Pod: [%s/%s]", nodeName, storeLabels, ns, podName)
 			continue
@@ -1031,6 +1235,97 @@ func (m *tikvMemberManager) storeLabelsEqualNodeLabels(storeLabels []*metapb.Sto
 	return reflect.DeepEqual(ls, nodeLabels)
 }
 
+// syncIOTuning applies `spec.tikv.ioTuning` to the ready TiKV pods by the online config API.
+// The applied tuning is recorded in the annotation of the pod together with the restart count
+// of the TiKV container, so that it is applied again after the container restarts.
+func (m *tikvMemberManager) syncIOTuning(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		return nil
+	}
+	config := tikvIOTuningConfig(tc.Spec.TiKV.IOTuning)
+	if len(config) == 0 {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	hash, err := mngerutils.Sha256Sum(config)
+	if err != nil {
+		return err
+	}
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiKV().Selector()
+	if err != nil {
+		return err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("syncIOTuning: failed to list pods for cluster %s/%s, selector %s, error: %s", ns, tcName, selector, err)
+	}
+
+	var errs []error
+	for _, pod := range pods {
+		if !podutil.IsPodReady(pod) {
+			continue
+		}
+		applied := fmt.Sprintf("%s-%d", hash[:7], tikvContainerRestartCount(pod))
+		if pod.Annotations[label.AnnTiKVIOTuningApplied] == applied {
+			continue
+		}
+		tikvClient := m.deps.TiKVControl.GetTiKVPodClient(ns, tcName, pod.Name, tc.IsTLSClusterEnabled())
+		if err := tikvClient.ModifyConfig(config); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply io tuning to tikv pod %s/%s: %v", ns, pod.Name, err))
+			continue
+		}
+		newPod := pod.DeepCopy()
+		if newPod.Annotations == nil {
+			newPod.Annotations = map[string]string{}
+		}
+		newPod.Annotations[label.AnnTiKVIOTuningApplied] = applied
+		if _, err := m.deps.PodControl.UpdatePod(tc, newPod); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		klog.Infof("TidbCluster: [%s/%s], applied io tuning %v to tikv pod %s", ns, tcName, config, pod.Name)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// tikvIOTuningConfig returns the online config items of TiKV for the io tuning
+func tikvIOTuningConfig(tuning *v1alpha1.TiKVIOTuning) map[string]interface{} {
+	config := map[string]interface{}{}
+	if tuning == nil {
+		return config
+	}
+	if tuning.RateBytesPerSec != nil {
+		config["rocksdb.rate-bytes-per-sec"] = *tuning.RateBytesPerSec
+	}
+	if tuning.IORateLimitMaxBytesPerSec != nil {
+		config["storage.io-rate-limit.max-bytes-per-sec"] = *tuning.IORateLimitMaxBytesPerSec
+	}
+	if tuning.MaxBackgroundJobs != nil {
+		config["rocksdb.max-background-jobs"] = *tuning.MaxBackgroundJobs
+	}
+	if tuning.MaxSubCompactions != nil {
+		config["rocksdb.max-sub-compactions"] = *tuning.MaxSubCompactions
+	}
+	if tuning.BackupNumThreads != nil {
+		config["backup.num-threads"] = *tuning.BackupNumThreads
+	}
+	if tuning.ImportNumThreads != nil {
+		config["import.num-threads"] = *tuning.ImportNumThreads
+	}
+	return config
+}
+
+func tikvContainerRestartCount(pod *corev1.Pod) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == v1alpha1.TiKVMemberType.String() {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
+
 func tikvStatefulSetIsUpgrading(podLister corelisters.PodLister, pdControl pdapi.PDControlInterface, set *apps.StatefulSet, tc *v1alpha1.TidbCluster) (bool, error) {
 	if mngerutils.StatefulSetIsUpgrading(set) {
 		return true, nil
@@ -29,10 +29,12 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/tikvapi"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -2119,6 +2121,42 @@ func TestGetNewTiKVSetForTidbCluster(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "tikv with raft log volume claim",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiKV: &v1alpha1.TiKVSpec{
+						StorageClassName: pointer.StringPtr("default"),
+						RaftLogVolumeClaim: &v1alpha1.RaftLogVolumeClaim{
+							StorageClassName: pointer.StringPtr("fast"),
+							StorageSize:      "50Gi",
+						},
+					},
+					PD:   &v1alpha1.PDSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				var claim *corev1.PersistentVolumeClaim
+				for i := range sts.Spec.VolumeClaimTemplates {
+					if sts.Spec.VolumeClaimTemplates[i].Name == "tikv-raft-log" {
+						claim = &sts.Spec.VolumeClaimTemplates[i]
+					}
+				}
+				g.Expect(claim).NotTo(BeNil())
+				g.Expect(*claim.Spec.StorageClassName).To(Equal("fast"))
+				g.Expect(claim.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("50Gi")))
+				g.Expect(sts.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "tikv-raft-log",
+					MountPath: v1alpha1.TiKVRaftLogVolumeMountPath,
+				}))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2564,6 +2602,67 @@ func TestGetTiKVConfigMap(t *testing.T) {
 [raftstore]
   sync-log = false
   raft-base-tick-interval = "1s"
+`,
+				},
+			},
+		},
+		{
+			name: "raft log volume claim",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiKV: &v1alpha1.TiKVSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							ConfigUpdateStrategy: &updateStrategy,
+						},
+						Config: func() *v1alpha1.TiKVConfigWraper {
+							c := v1alpha1.NewTiKVConfig()
+							c.Set("raftstore.raftdb-path", "/var/lib/tikv/raft")
+							return c
+						}(),
+						RaftLogVolumeClaim: &v1alpha1.RaftLogVolumeClaim{
+							StorageSize: "50Gi",
+						},
+					},
+					PD:   &v1alpha1.PDSpec{},
+					TiDB: &v1alpha1.TiDBSpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-tikv",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "tikv",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `[raftstore]
+  raftdb-path = "/var/lib/tikv/raft"
+
+[raft-engine]
+  dir = "/var/lib/raft-log/raft-engine"
 `,
 				},
 			},
@@ -2702,3 +2801,176 @@ func mustTiKVConfig(x interface{}) *v1alpha1.TiKVConfigWraper {
 
 	return c
 }
+
+func TestGetTiKVStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	startTime := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	store := getTiKVStore(&pdapi.StoreInfo{
+		Store: &pdapi.MetaStore{
+			Store:     &metapb.Store{Id: 1, Address: "test-tikv-0.test-tikv-peer.default.svc:20160"},
+			StateName: v1alpha1.TiKVStateUp,
+		},
+		Status: &pdapi.StoreStatus{
+			Capacity:        100 << 30,
+			Available:       60 << 30,
+			LeaderCount:     10,
+			RegionCount:     30,
+			StartTS:         startTime,
+			LastHeartbeatTS: startTime.Add(time.Hour),
+		},
+	})
+	g.Expect(store.ID).To(Equal("1"))
+	g.Expect(store.PodName).To(Equal("test-tikv-0"))
+	g.Expect(store.LeaderCount).To(Equal(int32(10)))
+	g.Expect(store.RegionCount).To(Equal(int32(30)))
+	g.Expect(store.Capacity.String()).To(Equal("100Gi"))
+	g.Expect(store.Available.String()).To(Equal("60Gi"))
+	g.Expect(store.StartTime.Time).To(Equal(startTime))
+	g.Expect(store.LastHeartbeatTime.Time).To(Equal(startTime.Add(time.Hour)))
+
+	// the capacity and the timestamps are not reported before the first heartbeat
+	store = getTiKVStore(&pdapi.StoreInfo{
+		Store:  &pdapi.MetaStore{Store: &metapb.Store{Id: 2, Address: "test-tikv-1.test-tikv-peer.default.svc:20160"}},
+		Status: &pdapi.StoreStatus{},
+	})
+	g.Expect(store.Capacity).To(BeNil())
+	g.Expect(store.StartTime).To(BeNil())
+	g.Expect(store.LastHeartbeatTime).To(BeNil())
+}
+
+func TestGetTiKVStoreDrainProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	// the store begins to drain
+	progress := getTiKVStoreDrainProgress(nil, 100, 10, now)
+	g.Expect(progress.StartTime.Time).To(Equal(now))
+	g.Expect(progress.InitialRegionCount).To(Equal(int32(100)))
+	g.Expect(progress.RegionCount).To(Equal(int32(100)))
+	g.Expect(progress.LeaderCount).To(Equal(int32(10)))
+	g.Expect(progress.EstimatedCompletionTime).To(BeNil())
+
+	// 40 regions are drained in 10 minutes, 60 regions left need 15 minutes
+	later := now.Add(10 * time.Minute)
+	progress = getTiKVStoreDrainProgress(progress, 60, 0, later)
+	g.Expect(progress.StartTime.Time).To(Equal(now))
+	g.Expect(progress.InitialRegionCount).To(Equal(int32(100)))
+	g.Expect(progress.RegionCount).To(Equal(int32(60)))
+	g.Expect(progress.LeaderCount).To(Equal(int32(0)))
+	g.Expect(progress.EstimatedCompletionTime).NotTo(BeNil())
+	g.Expect(progress.EstimatedCompletionTime.Time).To(Equal(later.Add(15 * time.Minute)))
+}
+
+func TestStoreCountSkewPercent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(storeCountSkewPercent(nil)).To(Equal(int32(0)))
+	g.Expect(storeCountSkewPercent([]int{0, 0})).To(Equal(int32(0)))
+	g.Expect(storeCountSkewPercent([]int{100, 100, 0})).To(Equal(int32(100)))
+	g.Expect(storeCountSkewPercent([]int{100, 90, 85})).To(Equal(int32(15)))
+}
+
+func TestTiKVMemberManagerSyncScaleOutBalance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.ScaleOutBalance = &v1alpha1.ScaleOutBalanceGate{
+		MaxSkewPercent: pointer.Int32Ptr(10),
+		Timeout:        &metav1.Duration{Duration: time.Hour},
+	}
+	tmm, _, _, _, _, _ := newFakeTiKVMemberManager(tc)
+	setBalancing := func(since time.Time) {
+		tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+		tc.Status.TiKV.Conditions = []metav1.Condition{{
+			Type:               v1alpha1.ComponentRegionBalancing,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ScalingOut",
+			LastTransitionTime: metav1.NewTime(since),
+		}}
+	}
+
+	// not balanced yet
+	setBalancing(time.Now())
+	tmm.syncScaleOutBalance(tc, []int{100, 100, 20}, []int{30, 30, 5})
+	g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.ScalePhase))
+	g.Expect(meta.IsStatusConditionTrue(tc.Status.TiKV.Conditions, v1alpha1.ComponentRegionBalancing)).To(BeTrue())
+
+	// balanced
+	setBalancing(time.Now())
+	tmm.syncScaleOutBalance(tc, []int{100, 95, 92}, []int{30, 29, 28})
+	g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.NormalPhase))
+	cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentRegionBalancing)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal("Balanced"))
+
+	// timeout
+	setBalancing(time.Now().Add(-2 * time.Hour))
+	tmm.syncScaleOutBalance(tc, []int{100, 100, 20}, []int{30, 30, 5})
+	g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.NormalPhase))
+	cond = meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentRegionBalancing)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal("BalanceTimeout"))
+}
+
+func TestTiKVMemberManagerSyncIOTuning(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.IOTuning = &v1alpha1.TiKVIOTuning{
+		RateBytesPerSec:   pointer.StringPtr("100MB"),
+		MaxBackgroundJobs: pointer.Int64Ptr(8),
+	}
+	tmm, _, _, _, podIndexer, _ := newFakeTiKVMemberManager(tc)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TikvPodName(tc.GetName(), 0),
+			Namespace: tc.GetNamespace(),
+			Labels:    label.New().Instance(tc.GetInstanceName()).TiKV().Labels(),
+		},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: v1alpha1.TiKVMemberType.String()}},
+		},
+	}
+	g.Expect(podIndexer.Add(pod)).To(Succeed())
+
+	applied := 0
+	tikvClient := tikvapi.NewFakeTiKVClient()
+	tikvClient.AddReaction(tikvapi.ModifyConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		g.Expect(action.Config).To(Equal(map[string]interface{}{
+			"rocksdb.rate-bytes-per-sec":  "100MB",
+			"rocksdb.max-background-jobs": int64(8),
+		}))
+		applied++
+		return nil, nil
+	})
+	tmm.deps.TiKVControl.(*tikvapi.FakeTiKVControl).SetTiKVPodClient(tc.GetNamespace(), tc.GetName(), pod.Name, tikvClient)
+
+	g.Expect(tmm.syncIOTuning(tc)).To(Succeed())
+	g.Expect(applied).To(Equal(1))
+
+	// not applied again if nothing is changed
+	g.Expect(tmm.syncIOTuning(tc)).To(Succeed())
+	g.Expect(applied).To(Equal(1))
+
+	// applied again after the container restarts
+	obj, _, err := podIndexer.Get(pod)
+	g.Expect(err).NotTo(HaveOccurred())
+	restarted := obj.(*corev1.Pod).DeepCopy()
+	restarted.Status.ContainerStatuses[0].RestartCount = 1
+	g.Expect(podIndexer.Update(restarted)).To(Succeed())
+	g.Expect(tmm.syncIOTuning(tc)).To(Succeed())
+	g.Expect(applied).To(Equal(2))
+
+	// applied again after the tuning is changed
+	tc.Spec.TiKV.IOTuning.MaxBackgroundJobs = nil
+	tikvClient.AddReaction(tikvapi.ModifyConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		g.Expect(action.Config).To(Equal(map[string]interface{}{"rocksdb.rate-bytes-per-sec": "100MB"}))
+		applied++
+		return nil, nil
+	})
+	g.Expect(tmm.syncIOTuning(tc)).To(Succeed())
+	g.Expect(applied).To(Equal(3))
+}
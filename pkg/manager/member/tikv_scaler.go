@@ -188,6 +188,7 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 	}
 
 	// call PD API to delete the store of the TiKV Pod to be scaled in
+	drainTimeout := false
 	for _, store := range tc.Status.TiKV.Stores {
 		if store.PodName == podName {
 			state := store.State
@@ -196,6 +197,9 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 				return deletedUpStore, err
 			}
 			if state != v1alpha1.TiKVStateOffline {
+				if err := runScaleInHook(s.deps, tc, tc.Spec.TiKV.ScaleInHook, v1alpha1.TiKVMemberType, pod, store.ID); err != nil {
+					return deletedUpStore, err
+				}
 				if err := controller.GetPDClient(s.deps.PDControl, tc).DeleteStore(id); err != nil {
 					klog.Errorf("tikvScaler.ScaleIn: failed to delete store %d, %v", id, err)
 					return deletedUpStore, err
@@ -204,10 +208,41 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 				if state == v1alpha1.TiKVStateUp {
 					deletedUpStore++
 				}
+			} else if isTiKVStoreDrainTimeout(tc, store) {
+				if pod.Annotations[label.AnnTiKVForceScaleInKey] != "true" {
+					// the regions left on the store may lose replicas if the pod is removed, so the scale-in
+					// stops until the store becomes tombstone or the user confirms it
+					msg := fmt.Sprintf("TiKV %s/%s store %d is not drained in %s, %d regions left, annotate the pod with %s=true to scale in it anyway",
+						ns, podName, id, tc.TiKVDrainTimeout(), store.DrainProgress.RegionCount, label.AnnTiKVForceScaleInKey)
+					klog.Warning(msg)
+					s.deps.Recorder.Event(tc, v1.EventTypeWarning, "DrainTimeout", msg)
+					return deletedUpStore, controller.RequeueErrorf(msg)
+				}
+				klog.Warningf("TiKV %s/%s store %d is not drained in %s, %d regions left, scale in it as the pod is annotated with %s",
+					ns, podName, id, tc.TiKVDrainTimeout(), store.DrainProgress.RegionCount, label.AnnTiKVForceScaleInKey)
+				s.deps.Recorder.Eventf(tc, v1.EventTypeWarning, "ForceScaleIn", "TiKV %s/%s store %d is scaled in before it's drained", ns, podName, id)
+				drainTimeout = true
+				break
+			}
+			if store.DrainProgress != nil {
+				return deletedUpStore, controller.RequeueErrorf("TiKV %s/%s store %d is still in cluster, state: %s, regions left: %d/%d",
+					ns, podName, id, state, store.DrainProgress.RegionCount, store.DrainProgress.InitialRegionCount)
 			}
 			return deletedUpStore, controller.RequeueErrorf("TiKV %s/%s store %d is still in cluster, state: %s", ns, podName, id, state)
+		}
+	}
 
+	if drainTimeout {
+		pvcs, err := util.ResolvePVCFromPod(pod, s.deps.PVCLister)
+		if err != nil {
+			return deletedUpStore, fmt.Errorf("tikvScaler.ScaleIn: failed to get pvcs for pod %s/%s in tc %s/%s, error: %s", ns, pod.Name, ns, tcName, err)
 		}
+		for _, pvc := range pvcs {
+			if err := addDeferDeletingAnnoToPVC(tc, pvc, s.deps.PVCControl, scaleInTime); err != nil {
+				return deletedUpStore, err
+			}
+		}
+		return deletedUpStore, nil
 	}
 
 	// If the store state turns to Tombstone, add defer deleting annotation to the PVCs of the Pod
@@ -281,6 +316,15 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 	return deletedUpStore, fmt.Errorf("TiKV %s/%s not found in cluster", ns, podName)
 }
 
+// isTiKVStoreDrainTimeout returns whether the offline store has been draining longer than the drain timeout
+func isTiKVStoreDrainTimeout(tc *v1alpha1.TidbCluster, store v1alpha1.TiKVStore) bool {
+	timeout := tc.TiKVDrainTimeout()
+	if timeout <= 0 || store.DrainProgress == nil {
+		return false
+	}
+	return time.Since(store.DrainProgress.StartTime.Time) > timeout
+}
+
 func (s *tikvScaler) preCheckUpStores(tc *v1alpha1.TidbCluster, podName string, upTikvStoreCount, deletedUpStoreCount, maxReplicas int) bool {
 	if !tc.TiKVBootStrapped() {
 		klog.Infof("TiKV of Cluster %s/%s is not bootstrapped yet, skip pre check when scale in TiKV", tc.Namespace, tc.Name)
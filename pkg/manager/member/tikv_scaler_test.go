@@ -545,6 +545,7 @@ func TestTiKVScalerScaleIn(t *testing.T) {
 		isPodReady    bool
 		hasSynced     bool
 		pvcUpdateErr  bool
+		forceScaleIn  bool
 		errExpectFn   func(*GomegaWithT, error)
 		changed       bool
 		getStoresFn   func(action *pdapi.Action) (interface{}, error)
@@ -614,6 +615,9 @@ func TestTiKVScalerScaleIn(t *testing.T) {
 		if test.storeIDSynced {
 			pod.Labels[label.StoreIDLabelKey] = "1"
 		}
+		if test.forceScaleIn {
+			pod.Annotations = map[string]string{label.AnnTiKVForceScaleInKey: "true"}
+		}
 		podIndexer.Add(pod)
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
@@ -703,6 +707,70 @@ func TestTiKVScalerScaleIn(t *testing.T) {
 			errExpectFn:   errExpectNil,
 			changed:       true,
 		},
+		{
+			name:          "store is draining, drain timeout is not hit",
+			tikvUpgrading: false,
+			storeFun: func(tc *v1alpha1.TidbCluster) {
+				drainingStoreFun(tc, time.Now().Add(-10*time.Minute))
+				tc.Spec.TiKV.DrainTimeout = &metav1.Duration{Duration: time.Hour}
+			},
+			delStoreErr:   false,
+			hasPVC:        true,
+			storeIDSynced: true,
+			isPodReady:    true,
+			hasSynced:     true,
+			pvcUpdateErr:  false,
+			errExpectFn:   errExpectRequeue,
+			changed:       false,
+		},
+		{
+			name:          "store is draining, drain timeout is hit",
+			tikvUpgrading: false,
+			storeFun: func(tc *v1alpha1.TidbCluster) {
+				drainingStoreFun(tc, time.Now().Add(-2*time.Hour))
+				tc.Spec.TiKV.DrainTimeout = &metav1.Duration{Duration: time.Hour}
+			},
+			delStoreErr:   false,
+			hasPVC:        true,
+			storeIDSynced: true,
+			isPodReady:    true,
+			hasSynced:     true,
+			pvcUpdateErr:  false,
+			errExpectFn:   errExpectRequeue,
+			changed:       false,
+		},
+		{
+			name:          "store is draining, drain timeout is hit and the scale-in is forced",
+			tikvUpgrading: false,
+			storeFun: func(tc *v1alpha1.TidbCluster) {
+				drainingStoreFun(tc, time.Now().Add(-2*time.Hour))
+				tc.Spec.TiKV.DrainTimeout = &metav1.Duration{Duration: time.Hour}
+			},
+			delStoreErr:   false,
+			hasPVC:        true,
+			storeIDSynced: true,
+			isPodReady:    true,
+			hasSynced:     true,
+			pvcUpdateErr:  false,
+			forceScaleIn:  true,
+			errExpectFn:   errExpectNil,
+			changed:       true,
+		},
+		{
+			name:          "store is draining, no drain timeout",
+			tikvUpgrading: false,
+			storeFun: func(tc *v1alpha1.TidbCluster) {
+				drainingStoreFun(tc, time.Now().Add(-2*time.Hour))
+			},
+			delStoreErr:   false,
+			hasPVC:        true,
+			storeIDSynced: true,
+			isPodReady:    true,
+			hasSynced:     true,
+			pvcUpdateErr:  false,
+			errExpectFn:   errExpectRequeue,
+			changed:       false,
+		},
 		{
 			name:          "status.TiKV.Stores is empty",
 			tikvUpgrading: false,
@@ -2060,6 +2128,18 @@ func normalStoreFun(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+func drainingStoreFun(tc *v1alpha1.TidbCluster, startTime time.Time) {
+	normalStoreFun(tc)
+	store := tc.Status.TiKV.Stores["1"]
+	store.State = v1alpha1.TiKVStateOffline
+	store.DrainProgress = &v1alpha1.TiKVStoreDrainProgress{
+		StartTime:          metav1.Time{Time: startTime},
+		InitialRegionCount: 100,
+		RegionCount:        50,
+	}
+	tc.Status.TiKV.Stores["1"] = store
+}
+
 func notReadyStoreFun(tc *v1alpha1.TidbCluster) {
 	normalStoreFun(tc)
 	delete(tc.Status.TiKV.Stores, "1")
@@ -0,0 +1,183 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+const (
+	// defaultSlowScoreThreshold is the default slow score from which a store is slow
+	defaultSlowScoreThreshold = 80
+	// defaultSlowStoreDuration is the default duration of the slow score above the threshold
+	defaultSlowStoreDuration = 5 * time.Minute
+
+	slowStoreLeaderEvictedReason  = "SlowStoreLeaderEvicted"
+	slowStoreLeaderRestoredReason = "SlowStoreLeaderRestored"
+)
+
+// syncSlowStores detects the slow stores by the slow scores reported by PD according to `spec.tikv.slowStore`,
+// and maintains the SlowStore condition with the pods and the nodes of them.
+//
+// If the leader eviction is enabled, the leaders are evicted from the slowest store, and they are allowed to be
+// transferred back once the slow score recovers. The leaders are evicted from at most one store at a time, so
+// the eviction can not move all the leaders to the stores which are slow too. Nothing is done during the upgrade
+// of TiKV as the upgrader evicts the leaders by itself.
+func (m *tikvMemberManager) syncSlowStores(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		return nil
+	}
+	policy := tc.Spec.TiKV.SlowStore
+	threshold, duration := uint64(defaultSlowScoreThreshold), defaultSlowStoreDuration
+	if policy != nil && policy.ScoreThreshold != nil {
+		threshold = uint64(*policy.ScoreThreshold)
+	}
+	if policy != nil && policy.Duration != nil {
+		duration = policy.Duration.Duration
+	}
+
+	now := metav1.Now()
+	var slowStores []v1alpha1.TiKVStore
+	for id, store := range tc.Status.TiKV.Stores {
+		if policy == nil || store.State != v1alpha1.TiKVStateUp || store.SlowScore < threshold {
+			store.SlowSince = nil
+		} else if store.SlowSince == nil {
+			store.SlowSince = &now
+		}
+		tc.Status.TiKV.Stores[id] = store
+		if store.SlowSince != nil && now.Sub(store.SlowSince.Time) >= duration {
+			slowStores = append(slowStores, store)
+		}
+	}
+	// the slowest store first
+	sort.Slice(slowStores, func(i, j int) bool {
+		if slowStores[i].SlowScore != slowStores[j].SlowScore {
+			return slowStores[i].SlowScore > slowStores[j].SlowScore
+		}
+		return slowStores[i].ID < slowStores[j].ID
+	})
+
+	if err := m.syncSlowStoreLeaders(tc, slowStores); err != nil {
+		return err
+	}
+	m.syncSlowStoreCondition(tc, slowStores)
+	return nil
+}
+
+// syncSlowStoreLeaders restores the leaders of the stores which are not slow anymore, and evicts the leaders
+// from the slowest store if no store is evicted.
+func (m *tikvMemberManager) syncSlowStoreLeaders(tc *v1alpha1.TidbCluster, slowStores []v1alpha1.TiKVStore) error {
+	if tc.TiKVUpgrading() {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	policy := tc.Spec.TiKV.SlowStore
+	evictLeader := policy != nil && policy.EvictLeader
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+
+	evicted := false
+	for id, store := range tc.Status.TiKV.Stores {
+		if !store.LeaderEvicted {
+			continue
+		}
+		if evictLeader && store.SlowSince != nil {
+			evicted = true
+			continue
+		}
+		storeID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := pdCli.EndEvictLeader(storeID); err != nil {
+			return fmt.Errorf("syncSlowStores: failed to end evicting leaders from store %s of cluster %s/%s, error: %v", id, ns, tcName, err)
+		}
+		store.LeaderEvicted = false
+		tc.Status.TiKV.Stores[id] = store
+		klog.Infof("syncSlowStores: end evicting leaders from store %s of pod %s for cluster %s/%s", id, store.PodName, ns, tcName)
+		m.deps.Recorder.Event(tc, corev1.EventTypeNormal, slowStoreLeaderRestoredReason,
+			fmt.Sprintf("the leaders are allowed to be transferred back to store %s of pod %s", id, store.PodName))
+	}
+	if !evictLeader || evicted || len(slowStores) == 0 {
+		return nil
+	}
+
+	store := slowStores[0]
+	storeID, err := strconv.ParseUint(store.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := pdCli.BeginEvictLeader(storeID); err != nil {
+		return fmt.Errorf("syncSlowStores: failed to evict leaders from store %s of cluster %s/%s, error: %v", store.ID, ns, tcName, err)
+	}
+	store.LeaderEvicted = true
+	tc.Status.TiKV.Stores[store.ID] = store
+	klog.Infof("syncSlowStores: begin evicting leaders from slow store %s of pod %s for cluster %s/%s", store.ID, store.PodName, ns, tcName)
+	m.deps.Recorder.Event(tc, corev1.EventTypeWarning, slowStoreLeaderEvictedReason,
+		fmt.Sprintf("the leaders are evicted from store %s of pod %s as its slow score is %d", store.ID, store.PodName, store.SlowScore))
+	return nil
+}
+
+// syncSlowStoreCondition sets the SlowStore condition, or removes it if the policy is not set.
+func (m *tikvMemberManager) syncSlowStoreCondition(tc *v1alpha1.TidbCluster, slowStores []v1alpha1.TiKVStore) {
+	if tc.Spec.TiKV.SlowStore == nil {
+		for i := range tc.Status.Conditions {
+			if tc.Status.Conditions[i].Type == v1alpha1.TidbClusterSlowStore {
+				tc.Status.Conditions = append(tc.Status.Conditions[:i], tc.Status.Conditions[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+	if len(slowStores) == 0 {
+		utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+			v1alpha1.TidbClusterSlowStore, corev1.ConditionFalse, utiltidbcluster.NoSlowStores, "no TiKV store is slow"))
+		return
+	}
+
+	var msgs []string
+	for _, store := range slowStores {
+		nodeName := "unknown"
+		if pod, err := m.deps.PodLister.Pods(tc.GetNamespace()).Get(store.PodName); err == nil && pod.Spec.NodeName != "" {
+			nodeName = pod.Spec.NodeName
+		}
+		msgs = append(msgs, fmt.Sprintf("store %s of pod %s on node %s is slow", store.ID, store.PodName, nodeName))
+	}
+	sort.Strings(msgs)
+	msg := strings.Join(msgs, "; ")
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterSlowStore)
+	if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != msg {
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, utiltidbcluster.SlowStoresFound, msg)
+	}
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *utiltidbcluster.NewTidbClusterCondition(
+		v1alpha1.TidbClusterSlowStore, corev1.ConditionTrue, utiltidbcluster.SlowStoresFound, msg))
+	// the message is not updated by SetTidbClusterCondition if the status and the reason are unchanged
+	for i := range tc.Status.Conditions {
+		if tc.Status.Conditions[i].Type == v1alpha1.TidbClusterSlowStore {
+			tc.Status.Conditions[i].Message = msg
+		}
+	}
+}
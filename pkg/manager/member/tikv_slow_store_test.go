@@ -0,0 +1,92 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+)
+
+func TestTiKVMemberManagerSyncSlowStores(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.SlowStore = &v1alpha1.TiKVSlowStorePolicy{Duration: &metav1.Duration{Duration: 0}, EvictLeader: true}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{}
+	for i, score := range []uint64{1, 90, 95} {
+		id := string(rune('1' + i))
+		tc.Status.TiKV.Stores[id] = v1alpha1.TiKVStore{ID: id, PodName: TikvPodName(tc.GetName(), int32(i)), State: v1alpha1.TiKVStateUp, SlowScore: score}
+	}
+	tmm, _, _, pdClient, podIndexer, _ := newFakeTiKVMemberManager(tc)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: TikvPodName(tc.GetName(), 2), Namespace: tc.GetNamespace()},
+		Spec:       corev1.PodSpec{NodeName: "node-2"},
+	}
+	g.Expect(podIndexer.Add(pod)).To(Succeed())
+
+	var evicted, restored []uint64
+	pdClient.AddReaction(pdapi.BeginEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		evicted = append(evicted, action.ID)
+		return nil, nil
+	})
+	pdClient.AddReaction(pdapi.EndEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		restored = append(restored, action.ID)
+		return nil, nil
+	})
+
+	// the leaders are evicted from the slowest store only
+	g.Expect(tmm.syncSlowStores(tc)).To(Succeed())
+	g.Expect(evicted).To(Equal([]uint64{3}))
+	g.Expect(tc.Status.TiKV.Stores["3"].LeaderEvicted).To(BeTrue())
+	g.Expect(tc.Status.TiKV.Stores["1"].SlowSince).To(BeNil())
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterSlowStore)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Message).To(ContainSubstring("store 3 of pod test-tikv-2 on node node-2 is slow"))
+	g.Expect(cond.Message).To(ContainSubstring("store 2 of pod test-tikv-1 on node unknown is slow"))
+
+	// the store is evicted until it recovers
+	g.Expect(tmm.syncSlowStores(tc)).To(Succeed())
+	g.Expect(evicted).To(HaveLen(1))
+	store := tc.Status.TiKV.Stores["3"]
+	store.SlowScore = 1
+	tc.Status.TiKV.Stores["3"] = store
+	g.Expect(tmm.syncSlowStores(tc)).To(Succeed())
+	g.Expect(restored).To(Equal([]uint64{3}))
+	g.Expect(tc.Status.TiKV.Stores["3"].LeaderEvicted).To(BeFalse())
+	g.Expect(evicted).To(Equal([]uint64{3, 2}))
+
+	// the store is not slow before the duration
+	tc.Spec.TiKV.SlowStore.Duration = &metav1.Duration{Duration: time.Hour}
+	store = tc.Status.TiKV.Stores["3"]
+	store.SlowScore = 100
+	tc.Status.TiKV.Stores["3"] = store
+	g.Expect(tmm.syncSlowStores(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.Stores["3"].SlowSince).NotTo(BeNil())
+	g.Expect(evicted).To(HaveLen(2))
+
+	// the leaders are restored and the condition is removed without the policy
+	tc.Spec.TiKV.SlowStore = nil
+	g.Expect(tmm.syncSlowStores(tc)).To(Succeed())
+	g.Expect(restored).To(Equal([]uint64{3, 2}))
+	g.Expect(utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterSlowStore)).To(BeNil())
+}
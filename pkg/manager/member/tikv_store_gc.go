@@ -0,0 +1,188 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+)
+
+const (
+	// defaultStoreGCGracePeriod is the default grace period of the tombstone and the orphan stores
+	defaultStoreGCGracePeriod = 24 * time.Hour
+
+	storeGCOrphanDeletedReason   = "OrphanStoreDeleted"
+	storeGCTombstoneRemoveReason = "TombstoneStoresRemoved"
+	storeGCFailedReason          = "StoreGCFailed"
+)
+
+// syncStoreGC removes the stores left in PD by the failed scale-ins or the node losses according to `spec.tikv.storeGC`.
+//
+// An orphan store is deleted from PD after it has been down for the grace period, the deletion makes PD replicate
+// the regions of the store to the other stores. It is deleted only if there are enough up stores to hold the replicas,
+// and at most one store is deleted in a sync. The tombstone stores are removed after the grace period and only
+// if none of them is still served by a pod, as the pods being scaled in are removed after their stores are tombstone.
+func (m *tikvMemberManager) syncStoreGC(tc *v1alpha1.TidbCluster) error {
+	gc := tc.Spec.TiKV.StoreGC
+	if tc.Spec.Paused || gc == nil || !tc.TiKVBootStrapped() {
+		return nil
+	}
+	now := time.Now()
+
+	if err := m.deleteOrphanStore(tc, storeGCGracePeriod(gc.OrphanGracePeriod), now); err != nil {
+		return err
+	}
+	return m.removeTombstoneStores(tc, storeGCGracePeriod(gc.TombstoneGracePeriod), now)
+}
+
+func (m *tikvMemberManager) deleteOrphanStore(tc *v1alpha1.TidbCluster, gracePeriod time.Duration, now time.Time) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	var orphans []v1alpha1.TiKVStore
+	upStores := 0
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State == v1alpha1.TiKVStateUp {
+			upStores++
+			continue
+		}
+		if store.State != v1alpha1.TiKVStateDown || now.Sub(store.LastTransitionTime.Time) < gracePeriod {
+			continue
+		}
+		orphan, err := m.isOrphanStore(tc, store)
+		if err != nil {
+			return err
+		}
+		if orphan {
+			orphans = append(orphans, store)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+	// delete the store which has been down for the longest time first
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].LastTransitionTime.Before(&orphans[j].LastTransitionTime)
+	})
+	store := orphans[0]
+
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+	config, err := pdCli.GetConfig()
+	if err != nil {
+		return fmt.Errorf("syncStoreGC: failed to get the config of PD for cluster %s/%s, error: %v", ns, tcName, err)
+	}
+	maxReplicas := 3
+	if config.Replication != nil && config.Replication.MaxReplicas != nil {
+		maxReplicas = int(*config.Replication.MaxReplicas)
+	}
+	if upStores < maxReplicas {
+		msg := fmt.Sprintf("orphan store %s of pod %s is kept as there are only %d up stores for %d replicas", store.ID, store.PodName, upStores, maxReplicas)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, storeGCFailedReason, msg)
+		return nil
+	}
+
+	storeID, err := strconv.ParseUint(store.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := pdCli.DeleteStore(storeID); err != nil {
+		msg := fmt.Sprintf("failed to delete orphan store %s of pod %s: %v", store.ID, store.PodName, err)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, storeGCFailedReason, msg)
+		return fmt.Errorf("syncStoreGC: %s, cluster %s/%s", msg, ns, tcName)
+	}
+	klog.Infof("syncStoreGC: deleted orphan store %s of pod %s for cluster %s/%s", store.ID, store.PodName, ns, tcName)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, storeGCOrphanDeletedReason,
+		fmt.Sprintf("orphan store %s of pod %s has been down since %s, it is deleted", store.ID, store.PodName, store.LastTransitionTime.Format(time.RFC3339)))
+	return nil
+}
+
+// isOrphanStore returns whether no pod serves the store anymore. The stores of the failover are managed by the
+// failover and never orphan.
+func (m *tikvMemberManager) isOrphanStore(tc *v1alpha1.TidbCluster, store v1alpha1.TiKVStore) (bool, error) {
+	for _, failureStore := range tc.Status.TiKV.FailureStores {
+		if failureStore.StoreID == store.ID {
+			return false, nil
+		}
+	}
+
+	pod, err := m.deps.PodLister.Pods(tc.GetNamespace()).Get(store.PodName)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("syncStoreGC: failed to get pod %s/%s, error: %v", tc.GetNamespace(), store.PodName, err)
+	}
+	if err == nil {
+		// the pod is recreated with a new store, e.g. after the loss of its node and the local volume
+		storeID, ok := pod.Labels[label.StoreIDLabelKey]
+		return ok && storeID != "" && storeID != store.ID, nil
+	}
+
+	// the pod is missing, the store is orphan unless the pod is still desired and will be recreated
+	ordinal, err := util.GetOrdinalFromPodName(store.PodName)
+	if err != nil {
+		return false, nil
+	}
+	return !tc.TiKVStsDesiredOrdinals(false).Has(ordinal), nil
+}
+
+func (m *tikvMemberManager) removeTombstoneStores(tc *v1alpha1.TidbCluster, gracePeriod time.Duration, now time.Time) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	stores := tc.Status.TiKV.TombstoneStores
+	if len(stores) == 0 {
+		return nil
+	}
+	for _, store := range stores {
+		if now.Sub(store.LastTransitionTime.Time) < gracePeriod {
+			return nil
+		}
+		pod, err := m.deps.PodLister.Pods(ns).Get(store.PodName)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncStoreGC: failed to get pod %s/%s, error: %v", ns, store.PodName, err)
+		}
+		if err == nil && pod.Labels[label.StoreIDLabelKey] == store.ID {
+			// the pod is being scaled in, the scaler checks the tombstone store before removing the pod
+			return nil
+		}
+	}
+
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+	if err := pdCli.RemoveTombStoneStores(); err != nil {
+		msg := fmt.Sprintf("failed to remove %d tombstone stores: %v", len(stores), err)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, storeGCFailedReason, msg)
+		return fmt.Errorf("syncStoreGC: %s, cluster %s/%s", msg, ns, tcName)
+	}
+	klog.Infof("syncStoreGC: removed %d tombstone stores for cluster %s/%s", len(stores), ns, tcName)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, storeGCTombstoneRemoveReason, fmt.Sprintf("%d tombstone stores are removed", len(stores)))
+	tc.Status.TiKV.TombstoneStores = nil
+	return nil
+}
+
+func storeGCGracePeriod(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return defaultStoreGCGracePeriod
+	}
+	return d.Duration
+}
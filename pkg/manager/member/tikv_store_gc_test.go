@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestTiKVMemberManagerSyncStoreGC(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.StoreGC = &v1alpha1.TiKVStoreGC{OrphanGracePeriod: &metav1.Duration{Duration: time.Hour}}
+	tc.Status.TiKV.BootStrapped = true
+	longAgo := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	recently := metav1.NewTime(time.Now().Add(-time.Minute))
+	newStore := func(id string, ordinal int32, state string, since metav1.Time) v1alpha1.TiKVStore {
+		return v1alpha1.TiKVStore{ID: id, PodName: TikvPodName(tc.GetName(), ordinal), State: state, LastTransitionTime: since}
+	}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": newStore("1", 0, v1alpha1.TiKVStateUp, longAgo),
+		"2": newStore("2", 1, v1alpha1.TiKVStateUp, longAgo),
+		"3": newStore("3", 2, v1alpha1.TiKVStateUp, longAgo),
+		// the pod is recreated with a new store
+		"4": newStore("4", 0, v1alpha1.TiKVStateDown, longAgo),
+		// the pod is scaled in
+		"5": newStore("5", 3, v1alpha1.TiKVStateDown, longAgo),
+		// the store is not down for the grace period
+		"6": newStore("6", 4, v1alpha1.TiKVStateDown, recently),
+		// the pod is desired and will be recreated
+		"7": newStore("7", 2, v1alpha1.TiKVStateDown, longAgo),
+	}
+	tc.Status.TiKV.TombstoneStores = map[string]v1alpha1.TiKVStore{
+		"8": newStore("8", 5, v1alpha1.TiKVStateTombstone, longAgo),
+	}
+	tmm, _, _, pdClient, podIndexer, _ := newFakeTiKVMemberManager(tc)
+
+	for ordinal, storeID := range []string{"1", "2"} {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TikvPodName(tc.GetName(), int32(ordinal)),
+				Namespace: tc.GetNamespace(),
+				Labels:    label.New().Instance(tc.GetInstanceName()).TiKV().Labels(),
+			},
+		}
+		pod.Labels[label.StoreIDLabelKey] = storeID
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+
+	maxReplicas := uint64(3)
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas}}, nil
+	})
+	var deleted []uint64
+	pdClient.AddReaction(pdapi.DeleteStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+		deleted = append(deleted, action.ID)
+		return nil, nil
+	})
+	tombstoneRemoved := false
+	pdClient.AddReaction(pdapi.RemoveTombStoneStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+		tombstoneRemoved = true
+		return nil, nil
+	})
+
+	// the tombstone stores are kept with the default grace period
+	g.Expect(tmm.syncStoreGC(tc)).To(Succeed())
+	g.Expect(deleted).To(HaveLen(1))
+	g.Expect(deleted[0]).To(Or(Equal(uint64(4)), Equal(uint64(5))))
+	g.Expect(tombstoneRemoved).To(BeFalse())
+
+	// at most one orphan store is deleted in a sync
+	delete(tc.Status.TiKV.Stores, fmt.Sprint(deleted[0]))
+	tc.Spec.TiKV.StoreGC.TombstoneGracePeriod = &metav1.Duration{Duration: time.Hour}
+	g.Expect(tmm.syncStoreGC(tc)).To(Succeed())
+	g.Expect(deleted).To(ConsistOf(uint64(4), uint64(5)))
+	g.Expect(tombstoneRemoved).To(BeTrue())
+	g.Expect(tc.Status.TiKV.TombstoneStores).To(BeEmpty())
+
+	// the orphan store is kept if there are not enough up stores
+	delete(tc.Status.TiKV.Stores, fmt.Sprint(deleted[1]))
+	deleted = nil
+	tc.Status.TiKV.Stores["9"] = newStore("9", 6, v1alpha1.TiKVStateDown, longAgo)
+	maxReplicas = 5
+	g.Expect(tmm.syncStoreGC(tc)).To(Succeed())
+	g.Expect(deleted).To(BeEmpty())
+
+	// the stores of the failover are never orphan
+	maxReplicas = 3
+	tc.Status.TiKV.FailureStores = map[string]v1alpha1.TiKVFailureStore{
+		TikvPodName(tc.GetName(), 6): {PodName: TikvPodName(tc.GetName(), 6), StoreID: "9"},
+	}
+	g.Expect(tmm.syncStoreGC(tc)).To(Succeed())
+	g.Expect(deleted).To(BeEmpty())
+}
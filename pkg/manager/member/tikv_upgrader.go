@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/features"
@@ -28,6 +29,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
@@ -70,6 +72,7 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 	var status *v1alpha1.TiKVStatus
 	switch meta := meta.(type) {
 	case *v1alpha1.TidbCluster:
+		waitForUpgradeDependencies(meta, v1alpha1.TiKVMemberType)
 		if ready, reason := isTiKVReadyToUpgrade(meta); !ready {
 			klog.Infof("TidbCluster: [%s/%s], can not upgrade tikv because: %s", ns, tcName, reason)
 			_, podSpec, err := GetLastAppliedConfig(oldSet)
@@ -130,6 +133,7 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 	}
 
 	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	upgraded := int32(0)
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
@@ -147,6 +151,10 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tikv pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tikv pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == status.StatefulSet.UpdateRevision {
 
@@ -171,9 +179,16 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 				return controller.RequeueErrorf("waiting to end evict leader of pod %s for tc %s/%s", podName, ns, tcName)
 			}
 
+			upgraded++
 			continue
 		}
 
+		if canaryPaused(tc, tc.Spec.TiKV.RollingUpdateStrategy, label.AnnTiKVCanaryPromoted, status.StatefulSet.UpdateRevision, upgraded) {
+			klog.Infof("tidbcluster: [%s/%s]'s tikv canary of %d pods is upgraded, waiting for annotation %s to be set to %s",
+				ns, tcName, upgraded, label.AnnTiKVCanaryPromoted, status.StatefulSet.UpdateRevision)
+			return nil
+		}
+
 		return u.upgradeTiKVPod(tc, i, newSet)
 	}
 
@@ -234,8 +249,7 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 			return false, nil
 		}
 		if time.Now().After(evictLeaderBeginTime.Add(evictLeaderTimeout)) {
-			klog.Infof("%s: evict leader timeout with threshold %v, so ready to upgrade", logPrefix, evictLeaderTimeout)
-			return true, nil
+			return u.handleEvictLeaderTimeout(tc, upgradePod, evictLeaderTimeout)
 		}
 	}
 
@@ -247,6 +261,14 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 
 	if leaderCount == 0 {
 		klog.Infof("%s: leader count is 0, so ready to upgrade", logPrefix)
+		if meta.IsStatusConditionTrue(tc.Status.TiKV.Conditions, v1alpha1.ComponentEvictLeaderTimeout) {
+			tc.Status.TiKV.SetCondition(metav1.Condition{
+				Type:    v1alpha1.ComponentEvictLeaderTimeout,
+				Status:  metav1.ConditionFalse,
+				Reason:  "LeaderEvicted",
+				Message: fmt.Sprintf("leaders of pod %s are evicted", upgradePod.Name),
+			})
+		}
 		return true, nil
 	}
 
@@ -254,6 +276,39 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 	return false, nil
 }
 
+// handleEvictLeaderTimeout applies the EvictLeaderTimeoutPolicy when the leaders of the pod are not evicted within the timeout,
+// it returns whether the pod is ready to upgrade.
+func (u *tikvUpgrader) handleEvictLeaderTimeout(tc *v1alpha1.TidbCluster, upgradePod *corev1.Pod, timeout time.Duration) (bool, error) {
+	logPrefix := fmt.Sprintf("evictLeaderBeforeUpgrade: for tikv pod %s/%s", upgradePod.Namespace, upgradePod.Name)
+
+	policy := tc.TiKVEvictLeaderTimeoutPolicy()
+	ready := policy != v1alpha1.EvictLeaderTimeoutPolicyPauseAndAlert
+	var msg string
+	if ready {
+		msg = fmt.Sprintf("leaders of pod %s are not evicted within %v, upgrade it anyway", upgradePod.Name, timeout)
+	} else {
+		msg = fmt.Sprintf("leaders of pod %s are not evicted within %v, pause the upgrade until they are evicted, "+
+			"or the evictLeaderTimeout or evictLeaderTimeoutPolicy is changed", upgradePod.Name, timeout)
+	}
+	klog.Infof("%s: %s", logPrefix, msg)
+
+	cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentEvictLeaderTimeout)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != string(policy) || cond.Message != msg {
+		eventType := corev1.EventTypeNormal
+		if !ready {
+			eventType = corev1.EventTypeWarning
+		}
+		u.deps.Recorder.Event(tc, eventType, "EvictLeaderTimeout", msg)
+	}
+	tc.Status.TiKV.SetCondition(metav1.Condition{
+		Type:    v1alpha1.ComponentEvictLeaderTimeout,
+		Status:  metav1.ConditionTrue,
+		Reason:  string(policy),
+		Message: msg,
+	})
+	return ready, nil
+}
+
 func (u *tikvUpgrader) modifyVolumesBeforeUpgrade(tc *v1alpha1.TidbCluster, upgradePod *corev1.Pod) (bool, error) {
 	desiredVolumes, err := u.volumeModifier.GetDesiredVolumes(tc, v1alpha1.TiKVMemberType)
 	if err != nil {
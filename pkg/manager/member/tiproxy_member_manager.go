@@ -106,7 +106,7 @@ func (m *tiproxyMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps
 	PDAddr := fmt.Sprintf("%s:2379", controller.PDMemberName(tc.Name))
 	// TODO: support it
 	if tc.AcrossK8s() {
-		return nil, fmt.Errorf("across k8s is not supported for")
+		return nil, fmt.Errorf("across k8s is not supported for tiproxy")
 	}
 	if tc.Heterogeneous() && tc.WithoutLocalPD() {
 		PDAddr = fmt.Sprintf("%s:2379", controller.PDMemberName(tc.Spec.Cluster.Name)) // use pd of reference cluster
@@ -214,6 +214,9 @@ func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	RewritePodImages(&newSts.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors, tc.Spec.RegistryMirrors)
+	keepTerminationGracePeriodSeconds(tc.BaseTiProxySpec(), newSts, oldStatefulSet)
+	newSts = overrideStatefulSet(tc, m.deps.Recorder, newSts)
 
 	if stsNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
@@ -347,6 +350,7 @@ func (m *tiproxyMemberManager) syncProxyService(tc *v1alpha1.TidbCluster, peer b
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(newSvc)
 	}
+	newSvc = overrideService(tc, m.deps.Recorder, newSvc)
 
 	oldSvcTmp, err := m.deps.ServiceLister.Services(tc.GetNamespace()).Get(newSvc.ObjectMeta.Name)
 	if errors.IsNotFound(err) {
@@ -391,7 +395,7 @@ func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *c
 	stsLabels := labelTiProxy(tc)
 	stsName := controller.TiProxyMemberName(tcName)
 	podLabels := util.CombineStringMap(stsLabels, baseTiProxySpec.Labels())
-	podAnnotations := util.CombineStringMap(baseTiProxySpec.Annotations(), controller.AnnProm(3080, "/api/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiProxySpec.Annotations(), controller.AnnProm(3080, "/api/metrics"), tlsRotationPodAnnotations(tc, v1alpha1.TiProxyMemberType))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiProxyLabelVal)
 	headlessSvcName := controller.TiProxyPeerMemberName(tcName)
 
@@ -517,6 +521,7 @@ func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *c
 
 	podSpec := baseTiProxySpec.BuildPodSpec()
 
+	podAnnotations = util.CombineStringMap(podAnnotations, nativeSidecarContainersAnnotation([]corev1.Container{tiproxyContainer}, baseTiProxySpec.NativeSidecarContainers()))
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{tiproxyContainer}, baseTiProxySpec.AdditionalContainers())
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge containers spec for TiProxy of [%s/%s], error: %v", ns, tcName, err)
@@ -77,6 +77,10 @@ func (u *tiproxyUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 		if !exist {
 			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tiproxy pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
 		}
+		if pod.DeletionTimestamp != nil {
+			// the pod may be still ready during the termination grace period, wait for it to shut down gracefully
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tiproxy pod: [%s] is terminating", ns, tcName, podName)
+		}
 
 		if revision == tc.Status.TiProxy.StatefulSet.UpdateRevision {
 			if !podutil.IsPodReady(pod) {
@@ -28,3 +28,16 @@ type Upgrader interface {
 type DMUpgrader interface {
 	Upgrade(*v1alpha1.DMCluster, *apps.StatefulSet, *apps.StatefulSet) error
 }
+
+// canaryPaused returns whether the rolling update pauses before upgrading the next pod. It pauses
+// after the canary pods are upgraded until the annotation promotedAnnKey of the TidbCluster is set
+// to the update revision, so that a new rolling update always requires a new promotion.
+func canaryPaused(tc *v1alpha1.TidbCluster, strategy *v1alpha1.RollingUpdateStrategy, promotedAnnKey string, updateRevision string, upgraded int32) bool {
+	if strategy == nil || strategy.Canary == nil {
+		return false
+	}
+	if upgraded < *strategy.Canary {
+		return false
+	}
+	return tc.Annotations[promotedAnnKey] != updateRevision
+}
@@ -30,10 +30,12 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/robfig/cron"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -107,6 +109,24 @@ func templateEqual(new *apps.StatefulSet, old *apps.StatefulSet) bool {
 	return false
 }
 
+// keepTerminationGracePeriodSeconds keeps the termination grace period of the existing StatefulSet if it is not
+// set in the spec explicitly, so that changing the default value by component role does not trigger rolling updates.
+func keepTerminationGracePeriodSeconds(spec v1alpha1.ComponentAccessor, newSet, oldSet *apps.StatefulSet) {
+	if oldSet == nil || spec.TerminationGracePeriodSeconds() != nil {
+		return
+	}
+	lastAppliedConfig, ok := oldSet.Annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return
+	}
+	oldStsSpec := apps.StatefulSetSpec{}
+	if err := json.Unmarshal([]byte(lastAppliedConfig), &oldStsSpec); err != nil {
+		klog.Errorf("unmarshal PodTemplate: [%s/%s]'s applied config failed,error: %v", oldSet.GetNamespace(), oldSet.GetName(), err)
+		return
+	}
+	newSet.Spec.Template.Spec.TerminationGracePeriodSeconds = oldStsSpec.Template.Spec.TerminationGracePeriodSeconds
+}
+
 func MemberPodName(controllerName, controllerKind string, ordinal int32, memberType v1alpha1.MemberType) (string, error) {
 	switch controllerKind {
 	case v1alpha1.TiDBClusterKind:
@@ -248,6 +268,15 @@ func getTikVConfigMapForTiKVSpec(tikvSpec *v1alpha1.TiKVSpec, tc *v1alpha1.TidbC
 		config.Set("security.cert-path", path.Join(tikvClusterCertPath, corev1.TLSCertKey))
 		config.Set("security.key-path", path.Join(tikvClusterCertPath, corev1.TLSPrivateKeyKey))
 	}
+	if tikvSpec.RaftLogVolumeClaim != nil {
+		// place the raft engine and raftdb on the dedicated volume unless they are set explicitly
+		if config.Get("raft-engine.dir") == nil {
+			config.Set("raft-engine.dir", path.Join(v1alpha1.TiKVRaftLogVolumeMountPath, "raft-engine"))
+		}
+		if config.Get("raftstore.raftdb-path") == nil {
+			config.Set("raftstore.raftdb-path", path.Join(v1alpha1.TiKVRaftLogVolumeMountPath, "raftdb"))
+		}
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -392,7 +421,7 @@ func CreateOrUpdateService(serviceLister corelisters.ServiceLister, serviceContr
 	if err != nil {
 		return err
 	}
-	annoEqual := util.IsSubMapOf(newSvc.Annotations, oldSvc.Annotations)
+	annoEqual := util.IsSubMapOf(newSvc.Annotations, oldSvc.Annotations) && controller.ServiceTrafficAnnotationsEqual(newSvc, oldSvc)
 	isOrphan := metav1.GetControllerOf(oldSvc) == nil
 
 	if !equal || !annoEqual || isOrphan {
@@ -407,6 +436,7 @@ func CreateOrUpdateService(serviceLister corelisters.ServiceLister, serviceContr
 		for k, v := range newSvc.Annotations {
 			svc.Annotations[k] = v
 		}
+		controller.CopyServiceTrafficAnnotations(&svc, newSvc)
 		// also override labels when adopt orphan
 		if isOrphan {
 			svc.OwnerReferences = newSvc.OwnerReferences
@@ -509,6 +539,25 @@ func TiKVStoreIDFromStatus(tc *v1alpha1.TidbCluster, podName string) (uint64, er
 	return storeID, nil
 }
 
+// nativeSidecarContainersAnnotation returns the pod template annotation which records the additional
+// containers running as native sidecars, the containers generated by TiDB Operator never run as sidecars.
+func nativeSidecarContainersAnnotation(base []corev1.Container, names []string) map[string]string {
+	generated := sets.NewString()
+	for _, c := range base {
+		generated.Insert(c.Name)
+	}
+	var sidecars []string
+	for _, name := range names {
+		if !generated.Has(name) {
+			sidecars = append(sidecars, name)
+		}
+	}
+	if len(sidecars) == 0 {
+		return nil
+	}
+	return map[string]string{label.AnnNativeSidecarContainersKey: strings.Join(sidecars, ",")}
+}
+
 // MergePatchContainers adds patches to base using a strategic merge patch and
 // iterating by container name, failing on the first error
 func MergePatchContainers(base, patches []corev1.Container) ([]corev1.Container, error) {
@@ -599,3 +648,45 @@ func SetServiceWhenPreferIPv6(svc *corev1.Service) {
 	policy := corev1.IPFamilyPolicyPreferDualStack
 	svc.Spec.IPFamilyPolicy = &policy
 }
+
+// RewritePodImages rewrites the images of all the containers of the pod by the registry mirrors,
+// the latter mirrors take precedence over the former ones.
+func RewritePodImages(podSpec *corev1.PodSpec, mirrors ...map[string]string) {
+	merged := util.MergeRegistryMirrors(mirrors...)
+	if len(merged) == 0 {
+		return
+	}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Image = util.RewriteImage(podSpec.InitContainers[i].Image, merged)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Image = util.RewriteImage(podSpec.Containers[i].Image, merged)
+	}
+}
+
+// activeScheduleWindow returns the start of the window which is active at now, the windows start at the
+// times of the cron schedule and last for the duration. The schedule is in the time zone of the cluster.
+func activeScheduleWindow(tc *v1alpha1.TidbCluster, schedule string, duration time.Duration, now time.Time) (time.Time, bool, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	loc, err := time.LoadLocation(tc.Timezone())
+	if err != nil {
+		klog.Warningf("invalid timezone %s of tc %s/%s, use UTC for the schedule %q", tc.Timezone(), tc.Namespace, tc.Name, schedule)
+		loc = time.UTC
+	}
+	now = now.In(loc)
+	// the earliest start of the windows which end after now
+	start := sched.Next(now.Add(-duration))
+	return start, !start.After(now), nil
+}
+
+// removeComponentCondition removes the condition of the type from the component status if it exists, the
+// meta.RemoveStatusCondition panics if the conditions are empty but not nil
+func removeComponentCondition(status v1alpha1.ComponentStatus, conditionType string) {
+	if meta.FindStatusCondition(status.GetConditions(), conditionType) == nil {
+		return
+	}
+	status.RemoveCondition(conditionType)
+}
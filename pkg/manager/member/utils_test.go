@@ -24,7 +24,9 @@ import (
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -937,6 +939,18 @@ func TestPodLabelsAnnotations(t *testing.T) {
 	}
 }
 
+func TestNativeSidecarContainersAnnotation(t *testing.T) {
+	g := NewGomegaWithT(t)
+	base := []corev1.Container{{Name: "tikv"}, {Name: "raftlog"}}
+
+	g.Expect(nativeSidecarContainersAnnotation(base, nil)).To(BeNil())
+	// the generated containers never run as sidecars
+	g.Expect(nativeSidecarContainersAnnotation(base, []string{"tikv"})).To(BeNil())
+	g.Expect(nativeSidecarContainersAnnotation(base, []string{"tikv", "proxy", "fluent-bit"})).To(Equal(map[string]string{
+		label.AnnNativeSidecarContainersKey: "proxy,fluent-bit",
+	}))
+}
+
 func TestMergePatchContainersOrderPreserved(t *testing.T) {
 	g := NewGomegaWithT(t)
 	build := func(name, image string) v1.Container {
@@ -978,3 +992,57 @@ func TestMergePatchContainersOrderPreserved(t *testing.T) {
 		}
 	}
 }
+
+func TestKeepTerminationGracePeriodSeconds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newSet := func(seconds *int64) *apps.StatefulSet {
+		set := &apps.StatefulSet{}
+		set.Spec.Template.Spec.TerminationGracePeriodSeconds = seconds
+		return set
+	}
+	newOldSet := func(seconds *int64) *apps.StatefulSet {
+		set := newSet(seconds)
+		g.Expect(mngerutils.SetStatefulSetLastAppliedConfigAnnotation(set)).To(Succeed())
+		return set
+	}
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	tc := &v1alpha1.TidbCluster{}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+
+	// the new StatefulSet uses the default value
+	set := newSet(int64Ptr(300))
+	keepTerminationGracePeriodSeconds(tc.BaseTiKVSpec(), set, nil)
+	g.Expect(set.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(int64Ptr(300)))
+
+	// the existing StatefulSet keeps the current value
+	set = newSet(int64Ptr(300))
+	keepTerminationGracePeriodSeconds(tc.BaseTiKVSpec(), set, newOldSet(nil))
+	g.Expect(set.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+
+	// the value set explicitly is always used
+	tc.Spec.TiKV.TerminationGracePeriodSeconds = int64Ptr(600)
+	set = newSet(int64Ptr(600))
+	keepTerminationGracePeriodSeconds(tc.BaseTiKVSpec(), set, newOldSet(nil))
+	g.Expect(set.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(int64Ptr(600)))
+}
+
+func TestRewritePodImages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.34.1"}},
+		Containers: []corev1.Container{
+			{Name: "pd", Image: "pingcap/pd:v7.1.0"},
+			{Name: "sidecar", Image: "quay.io/example/sidecar:v1"},
+		},
+	}
+	global := map[string]string{"docker.io": "global.example.com"}
+	cluster := map[string]string{"docker.io/pingcap": "cluster.example.com/pingcap"}
+	RewritePodImages(podSpec, global, cluster)
+
+	g.Expect(podSpec.InitContainers[0].Image).To(Equal("global.example.com/library/busybox:1.34.1"))
+	g.Expect(podSpec.Containers[0].Image).To(Equal("cluster.example.com/pingcap/pd:v7.1.0"))
+	g.Expect(podSpec.Containers[1].Image).To(Equal("quay.io/example/sidecar:v1"))
+}
@@ -39,6 +39,9 @@ var (
 		v1alpha1.DMWorkerMemberType,
 		v1alpha1.DMMasterMemberType,
 	}
+	// resume components in the reverse order of suspension, e.g. PD -> TiKV -> TiDB
+	resumeOrderForTC = reverseOrder(suspendOrderForTC)
+	resumeOrderForDM = reverseOrder(suspendOrderForDM)
 
 	_ Suspender = &suspender{}
 	_ Suspender = &FakeSuspender{}
@@ -91,6 +94,11 @@ func (s *suspender) SuspendComponent(cluster v1alpha1.Cluster, comp v1alpha1.Mem
 
 	if !needsSuspendComponent(ctx.cluster, ctx.component) {
 		if suspending {
+			if can, reason := canResumeComponent(ctx.cluster, ctx.component); !can {
+				klog.Infof("component %s can not be resumed now because: %s", ctx.ComponentID(), reason)
+				return true, nil
+			}
+
 			err := s.end(ctx)
 			return true, err
 		}
@@ -250,3 +258,44 @@ func canSuspendComponent(cluster v1alpha1.Cluster, comp v1alpha1.MemberType) (bo
 
 	return true, ""
 }
+
+// canResumeComponent checks whether suspender can end the suspension of the component.
+//
+// The components are resumed in the dependency order, a component is resumed only after the
+// components it depends on have been resumed and all of their replicas are ready.
+func canResumeComponent(cluster v1alpha1.Cluster, comp v1alpha1.MemberType) (bool, string) {
+	var resumeOrder []v1alpha1.MemberType
+	switch cluster.(type) {
+	case *v1alpha1.TidbCluster:
+		resumeOrder = resumeOrderForTC
+	case *v1alpha1.DMCluster:
+		resumeOrder = resumeOrderForDM
+	}
+	for _, typ := range resumeOrder {
+		if typ == comp {
+			break
+		}
+
+		status := cluster.ComponentStatus(typ)
+		if cluster.ComponentSpec(typ) == nil || status == nil {
+			continue
+		}
+		if cluster.ComponentIsSuspending(typ) {
+			return false, fmt.Sprintf("wait another component %s to be resumed", typ)
+		}
+		sts := status.GetStatefulSet()
+		if sts == nil || sts.ReadyReplicas < sts.Replicas {
+			return false, fmt.Sprintf("wait another component %s to be ready", typ)
+		}
+	}
+
+	return true, ""
+}
+
+func reverseOrder(order []v1alpha1.MemberType) []v1alpha1.MemberType {
+	reversed := make([]v1alpha1.MemberType, 0, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		reversed = append(reversed, order[i])
+	}
+	return reversed
+}
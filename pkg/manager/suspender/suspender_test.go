@@ -109,6 +109,28 @@ func TestSuspendComponent(t *testing.T) {
 				g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.NormalPhase))
 			},
 		},
+		"wait to end suspension": {
+			setup: func(cluster v1alpha1.Cluster) {
+				tc := cluster.(*v1alpha1.TidbCluster)
+				tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+				tc.Status.TiKV = v1alpha1.TiKVStatus{}
+				tc.Spec.TiKV.SuspendAction = &v1alpha1.SuspendAction{SuspendStatefulSet: false}
+				tc.Status.TiKV.Phase = v1alpha1.SuspendPhase
+				// PD is not resumed
+				tc.Spec.PD = &v1alpha1.PDSpec{}
+				tc.Status.PD.Phase = v1alpha1.SuspendPhase
+			},
+			component: v1alpha1.TiKVMemberType,
+			sts:       nil,
+			expect: func(suspeded bool, err error) {
+				g.Expect(suspeded).To(BeTrue())
+				g.Expect(err).To(BeNil())
+			},
+			expectResource: func(cluster v1alpha1.Cluster, s *suspender) {
+				tc := cluster.(*v1alpha1.TidbCluster)
+				g.Expect(tc.Status.TiKV.Phase).To(Equal(v1alpha1.SuspendPhase))
+			},
+		},
 		"delete sts if suspend sts": {
 			setup: func(cluster v1alpha1.Cluster) {
 				tc := cluster.(*v1alpha1.TidbCluster)
@@ -457,3 +479,94 @@ func TestCanSuspendComponent(t *testing.T) {
 		c.expect(can, reason)
 	}
 }
+
+func TestCanResumeComponent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := map[string]struct {
+		setup     func(tc *v1alpha1.TidbCluster)
+		component v1alpha1.MemberType
+		expect    func(can bool, reason string)
+	}{
+		"can resume PD at first": {
+			setup:     func(tc *v1alpha1.TidbCluster) {},
+			component: v1alpha1.PDMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeTrue())
+				g.Expect(reason).To(BeEmpty())
+			},
+		},
+		"wait for PD to be resumed": {
+			setup:     func(tc *v1alpha1.TidbCluster) {},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeFalse())
+				g.Expect(reason).To(Equal("wait another component pd to be resumed"))
+			},
+		},
+		"wait for PD to be ready": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.PD.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 2}
+			},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeFalse())
+				g.Expect(reason).To(Equal("wait another component pd to be ready"))
+			},
+		},
+		"can resume TiKV after PD is ready": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.PD.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3}
+			},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeTrue())
+				g.Expect(reason).To(BeEmpty())
+			},
+		},
+		"wait for TiKV to be resumed": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.PD.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3}
+			},
+			component: v1alpha1.TiDBMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeFalse())
+				g.Expect(reason).To(Equal("wait another component tikv to be resumed"))
+			},
+		},
+		"skip the components not deployed": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD = nil
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3}
+			},
+			component: v1alpha1.TiDBMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeTrue())
+				g.Expect(reason).To(BeEmpty())
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Logf("test case: %s\n", name)
+
+		tc := &v1alpha1.TidbCluster{}
+		tc.Name = "test-cluster"
+		tc.Namespace = "test-namespace"
+		tc.Spec.PD = &v1alpha1.PDSpec{}
+		tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+		tc.Spec.TiDB = &v1alpha1.TiDBSpec{}
+		tc.Status.PD.Phase = v1alpha1.SuspendPhase
+		tc.Status.TiKV.Phase = v1alpha1.SuspendPhase
+		tc.Status.TiDB.Phase = v1alpha1.SuspendPhase
+
+		c.setup(tc)
+
+		can, reason := canResumeComponent(tc, c.component)
+		c.expect(can, reason)
+	}
+}
@@ -86,9 +86,10 @@ func (m *Manager) syncService(td *v1alpha1.TidbDashboard) error {
 	if err != nil {
 		return err
 	}
-	if !equal {
+	if !equal || !controller.ServiceTrafficAnnotationsEqual(newSvc, oldSvc) {
 		svc := *oldSvc
 		svc.Spec = newSvc.Spec
+		controller.CopyServiceTrafficAnnotations(&svc, newSvc)
 		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
 		if err != nil {
 			return err
@@ -122,7 +123,7 @@ func (m *Manager) syncCore(td *v1alpha1.TidbDashboard, tc *v1alpha1.TidbCluster)
 	}
 
 	// Generate the new statefulset.
-	newSts, err := generateTiDBDashboardStatefulSet(td, tc)
+	newSts, err := generateTiDBDashboardStatefulSet(td, tc, m.deps.CLIConfig.AirGapped)
 	if err != nil {
 		return err
 	}
@@ -193,7 +194,7 @@ func (m *Manager) confirmStatefulSetIsUpgrading(td *v1alpha1.TidbDashboard, oldS
 	return false, nil
 }
 
-func generateTiDBDashboardStatefulSet(td *v1alpha1.TidbDashboard, tc *v1alpha1.TidbCluster) (*apps.StatefulSet, error) {
+func generateTiDBDashboardStatefulSet(td *v1alpha1.TidbDashboard, tc *v1alpha1.TidbCluster, airGapped bool) (*apps.StatefulSet, error) {
 	memberName := v1alpha1.TiDBDashboardMemberType.String()
 	clusterTLSEnabled := tc.IsTLSClusterEnabled()
 	mysqlTLSEnabled := tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB()
@@ -211,6 +212,10 @@ func generateTiDBDashboardStatefulSet(td *v1alpha1.TidbDashboard, tc *v1alpha1.T
 	} else {
 		telemetry = *td.Spec.Telemetry
 	}
+	// the telemetry can not reach the outside in the air-gapped mode
+	if airGapped {
+		telemetry = false
+	}
 
 	var experimental bool
 	if td.Spec.Experimental == nil {
@@ -401,6 +406,7 @@ func generateTiDBDashboardService(td *v1alpha1.TidbDashboard) *corev1.Service {
 			svc.Spec.LoadBalancerSourceRanges = td.Spec.Service.LoadBalancerSourceRanges
 		}
 	}
+	controller.SetServiceTrafficOptions(svc, &td.Spec.Service)
 
 	return svc
 }
@@ -214,7 +214,7 @@ func TestManager(t *testing.T) {
 
 			if testcase.getSts != nil {
 				old, newly := testcase.getSts()
-				patch := gomonkey.ApplyFunc(generateTiDBDashboardStatefulSet, func(_ *v1alpha1.TidbDashboard, _ *v1alpha1.TidbCluster) (*apps.StatefulSet, error) {
+				patch := gomonkey.ApplyFunc(generateTiDBDashboardStatefulSet, func(_ *v1alpha1.TidbDashboard, _ *v1alpha1.TidbCluster, _ bool) (*apps.StatefulSet, error) {
 					return newly, nil
 				})
 				defer patch.Reset()
@@ -676,7 +676,7 @@ func TestGenerateDashboardStatefulSet(t *testing.T) {
 			testcase.setInputs(td, tc)
 		}
 
-		sts, err := generateTiDBDashboardStatefulSet(td, tc)
+		sts, err := generateTiDBDashboardStatefulSet(td, tc, false)
 		testcase.expectFn(sts, err)
 	}
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
@@ -40,6 +41,8 @@ const (
 	ngmPodConfigFilename       = "ng-monitoring.toml"     // the filename of config file
 	ngmTCClientTLSMountDir     = "/var/lib/tc-client-tls" // the dir for tc client tls
 
+	ngmOffloadCredMountDir = "/var/lib/conprof-offload" // the dir for the credentials of conprof offload storage
+
 	ngmConfigMapConfigKey = "config-file" // the key for config data in config map
 
 	ngmServicePort = 12020
@@ -381,6 +384,28 @@ func GenerateNGMonitoringStatefulSet(tngm *v1alpha1.TidbNGMonitoring, tc *v1alph
 		return nil, fmt.Errorf("failed to merge containers spec for TNGM of [%s/%s], error: %v", tngm.Namespace, tngm.Name, err)
 	}
 
+	// conprof offload storage credentials
+	if offload := tngm.ConprofOffload(); offload != nil {
+		if offload.S3 != nil && offload.S3.SecretName != "" {
+			builder.PodTemplateSpecBuilder().ContainerBuilder(nmContainerName).AddEnvs(
+				secretKeyEnv("AWS_ACCESS_KEY_ID", offload.S3.SecretName, constants.S3AccessKey),
+				secretKeyEnv("AWS_SECRET_ACCESS_KEY", offload.S3.SecretName, constants.S3SecretKey),
+			)
+		}
+		if offload.Gcs != nil && offload.Gcs.SecretName != "" {
+			builder.PodTemplateSpecBuilder().ContainerBuilder(nmContainerName).AddVolumeMounts(corev1.VolumeMount{
+				Name: "conprof-offload", ReadOnly: true, MountPath: ngmOffloadCredMountDir,
+			})
+			builder.PodTemplateSpecBuilder().AddVolumes(corev1.Volume{
+				Name: "conprof-offload", VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: offload.Gcs.SecretName,
+					},
+				},
+			})
+		}
+	}
+
 	// tc enable tls
 	if tc.IsTLSClusterEnabled() {
 		builder.PodTemplateSpecBuilder().ContainerBuilder(nmContainerName).AddVolumeMounts(corev1.VolumeMount{
@@ -418,6 +443,13 @@ func GenerateNGMonitoringConfigMap(tngm *v1alpha1.TidbNGMonitoring, tc *v1alpha1
 		ngmConfig.Set("security.key-path", path.Join(ngmTCClientTLSMountDir, assetKey(tcName, tcNS, corev1.TLSPrivateKeyKey)))
 	}
 
+	if conprof := tngm.Spec.Conprof; conprof != nil {
+		if ngmConfig == nil {
+			ngmConfig = config.New(map[string]interface{}{})
+		}
+		setConprofConfig(ngmConfig, conprof)
+	}
+
 	confText, err := ngmConfig.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -433,6 +465,50 @@ func GenerateNGMonitoringConfigMap(tngm *v1alpha1.TidbNGMonitoring, tc *v1alpha1
 	}, nil
 }
 
+// setConprofConfig sets the retention and the offload storage of the continuous profiling data to the config
+func setConprofConfig(ngmConfig *config.GenericConfig, conprof *v1alpha1.NGMonitoringConprofSpec) {
+	if conprof.Retention != nil {
+		ngmConfig.Set("continuous_profiling.data_retention_seconds", int64(conprof.Retention.Seconds()))
+	}
+	if conprof.Offload == nil {
+		return
+	}
+	switch {
+	case conprof.Offload.S3 != nil:
+		s3 := conprof.Offload.S3
+		ngmConfig.Set("continuous_profiling.offload.storage", "s3")
+		ngmConfig.Set("continuous_profiling.offload.bucket", s3.Bucket)
+		ngmConfig.Set("continuous_profiling.offload.prefix", s3.Prefix)
+		if s3.Region != "" {
+			ngmConfig.Set("continuous_profiling.offload.region", s3.Region)
+		}
+		if s3.Endpoint != "" {
+			ngmConfig.Set("continuous_profiling.offload.endpoint", s3.Endpoint)
+		}
+	case conprof.Offload.Gcs != nil:
+		gcs := conprof.Offload.Gcs
+		ngmConfig.Set("continuous_profiling.offload.storage", "gcs")
+		ngmConfig.Set("continuous_profiling.offload.bucket", gcs.Bucket)
+		ngmConfig.Set("continuous_profiling.offload.prefix", gcs.Prefix)
+		if gcs.SecretName != "" {
+			ngmConfig.Set("continuous_profiling.offload.credentials_path", path.Join(ngmOffloadCredMountDir, constants.GcsCredentialsKey))
+		}
+	}
+}
+
+// secretKeyEnv returns the env whose value is the key of the secret
+func secretKeyEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
 // GenerateNGMonitoringMeta build ObjectMeta and Label for ng monitoring
 func GenerateNGMonitoringMeta(tngm *v1alpha1.TidbNGMonitoring, name string) (metav1.ObjectMeta, label.Label) {
 	instanceName := tngm.GetInstanceName()
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -819,6 +820,43 @@ func TestGenerateNGMonitoringConfigMap(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "should set config about conprof retention and offload",
+			setInputs: func(tngm *v1alpha1.TidbNGMonitoring, tc *v1alpha1.TidbCluster) {
+				tngm.Spec.Conprof = &v1alpha1.NGMonitoringConprofSpec{
+					Retention: &metav1.Duration{Duration: 24 * time.Hour},
+					Offload: &v1alpha1.NGMonitoringConprofOffload{
+						Gcs: &v1alpha1.GcsStorageProvider{
+							Bucket:     "conprof",
+							Prefix:     "ngm",
+							SecretName: "gcs-secret",
+						},
+					},
+				}
+			},
+			expectFn: func(tngm *v1alpha1.TidbNGMonitoring, cm *corev1.ConfigMap, err error) {
+				g.Expect(err).Should(Succeed())
+
+				cfg := config.New(nil)
+				err = cfg.UnmarshalTOML([]byte(cm.Data[ngmConfigMapConfigKey]))
+				g.Expect(err).Should(Succeed())
+
+				expectConfig := map[string]interface{}{
+					"continuous_profiling": map[string]interface{}{
+						"data_retention_seconds": int64(86400),
+						"offload": map[string]interface{}{
+							"storage":          "gcs",
+							"bucket":           "conprof",
+							"prefix":           "ngm",
+							"credentials_path": path.Join(ngmOffloadCredMountDir, "credentials"),
+						},
+					},
+				}
+				for k, v := range expectConfig {
+					g.Expect(cfg.MP).Should(HaveKeyWithValue(k, v))
+				}
+			},
+		},
 		{
 			name: "shouldn't change config in spec",
 			setInputs: func(tngm *v1alpha1.TidbNGMonitoring, tc *v1alpha1.TidbCluster) {
@@ -87,7 +87,8 @@ func UpdateConfigMapIfNeed(
 			desired.Name = inUseName
 		}
 		return nil
-	case v1alpha1.ConfigUpdateStrategyRollingUpdate:
+	case v1alpha1.ConfigUpdateStrategyRollingUpdate, v1alpha1.ConfigUpdateStrategyHotReload:
+		// the components which don't support the online config API are rolling updated for HotReload
 		existing, err := cmLister.ConfigMaps(desired.Namespace).Get(inUseName)
 		if err != nil {
 			if errors.IsNotFound(err) {
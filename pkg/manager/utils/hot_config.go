@@ -0,0 +1,233 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+
+	perrors "github.com/pingcap/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+)
+
+// hotReloadableItem is a config item, or a section of config items, which can be changed online
+type hotReloadableItem struct {
+	// key is the full name of the item, or the prefix of the items in a section if it ends with `.`
+	key string
+	// since is the first version of the component supporting the online change of the item
+	since string
+}
+
+// hotReloadableItems are the config items which can be changed by the online config API of the components,
+// see https://docs.pingcap.com/tidb/stable/dynamic-config
+var hotReloadableItems = map[v1alpha1.MemberType][]hotReloadableItem{
+	v1alpha1.PDMemberType: {
+		{key: "log.level", since: "v4.0.0"},
+		{key: "schedule.", since: "v4.0.0"},
+		{key: "replication.max-replicas", since: "v4.0.0"},
+		{key: "replication.location-labels", since: "v4.0.0"},
+		{key: "replication.strictly-match-label", since: "v4.0.0"},
+		{key: "replication.enable-placement-rules", since: "v4.0.0"},
+		{key: "replication.isolation-level", since: "v4.0.0"},
+		{key: "label-property.", since: "v4.0.0"},
+		{key: "pd-server.use-region-storage", since: "v4.0.0"},
+		{key: "pd-server.max-gap-reset-ts", since: "v4.0.0"},
+		{key: "pd-server.key-type", since: "v4.0.0"},
+		{key: "pd-server.metric-storage", since: "v4.0.0"},
+		{key: "pd-server.dashboard-address", since: "v4.0.0"},
+		{key: "pd-server.flow-round-by-digit", since: "v5.0.0"},
+	},
+	v1alpha1.TiKVMemberType: {
+		{key: "raftstore.raft-max-inflight-msgs", since: "v5.0.0"},
+		{key: "raftstore.raft-log-gc-tick-interval", since: "v4.0.0"},
+		{key: "raftstore.raft-log-gc-threshold", since: "v4.0.0"},
+		{key: "raftstore.raft-log-gc-count-limit", since: "v4.0.0"},
+		{key: "raftstore.raft-log-gc-size-limit", since: "v4.0.0"},
+		{key: "raftstore.raft-entry-cache-life-time", since: "v4.0.0"},
+		{key: "raftstore.split-region-check-tick-interval", since: "v4.0.0"},
+		{key: "raftstore.region-split-check-diff", since: "v4.0.0"},
+		{key: "raftstore.region-compact-check-interval", since: "v4.0.0"},
+		{key: "raftstore.region-compact-check-step", since: "v4.0.0"},
+		{key: "raftstore.region-compact-min-tombstones", since: "v4.0.0"},
+		{key: "raftstore.region-compact-tombstones-percent", since: "v4.0.0"},
+		{key: "raftstore.pd-heartbeat-tick-interval", since: "v4.0.0"},
+		{key: "raftstore.pd-store-heartbeat-tick-interval", since: "v4.0.0"},
+		{key: "raftstore.snap-mgr-gc-tick-interval", since: "v4.0.0"},
+		{key: "raftstore.snap-gc-timeout", since: "v4.0.0"},
+		{key: "raftstore.lock-cf-compact-interval", since: "v4.0.0"},
+		{key: "raftstore.lock-cf-compact-bytes-threshold", since: "v4.0.0"},
+		{key: "raftstore.messages-per-tick", since: "v4.0.0"},
+		{key: "raftstore.max-peer-down-duration", since: "v4.0.0"},
+		{key: "raftstore.max-leader-missing-duration", since: "v4.0.0"},
+		{key: "raftstore.abnormal-leader-missing-duration", since: "v4.0.0"},
+		{key: "raftstore.peer-stale-state-check-interval", since: "v4.0.0"},
+		{key: "raftstore.consistency-check-interval", since: "v4.0.0"},
+		{key: "raftstore.apply-pool-size", since: "v5.0.0"},
+		{key: "raftstore.store-pool-size", since: "v5.0.0"},
+		{key: "coprocessor.split-region-on-table", since: "v4.0.0"},
+		{key: "coprocessor.batch-split-limit", since: "v4.0.0"},
+		{key: "coprocessor.region-max-size", since: "v4.0.0"},
+		{key: "coprocessor.region-split-size", since: "v4.0.0"},
+		{key: "coprocessor.region-max-keys", since: "v4.0.0"},
+		{key: "coprocessor.region-split-keys", since: "v4.0.0"},
+		{key: "pessimistic-txn.wait-for-lock-timeout", since: "v4.0.0"},
+		{key: "pessimistic-txn.wake-up-delay-duration", since: "v4.0.0"},
+		{key: "pessimistic-txn.pipelined", since: "v4.0.0"},
+		{key: "gc.ratio-threshold", since: "v4.0.0"},
+		{key: "gc.batch-keys", since: "v4.0.0"},
+		{key: "gc.max-write-bytes-per-sec", since: "v4.0.0"},
+		{key: "gc.enable-compaction-filter", since: "v5.0.0"},
+		{key: "rocksdb.max-background-jobs", since: "v4.0.0"},
+		{key: "rocksdb.max-open-files", since: "v4.0.0"},
+		{key: "rocksdb.compaction-readahead-size", since: "v4.0.0"},
+		{key: "rocksdb.bytes-per-sync", since: "v4.0.0"},
+		{key: "rocksdb.wal-bytes-per-sync", since: "v4.0.0"},
+		{key: "rocksdb.writable-file-max-buffer-size", since: "v4.0.0"},
+		{key: "rocksdb.rate-bytes-per-sec", since: "v5.0.0"},
+		{key: "storage.block-cache.capacity", since: "v4.0.0"},
+		{key: "storage.io-rate-limit.max-bytes-per-sec", since: "v5.0.0"},
+		{key: "backup.num-threads", since: "v5.0.0"},
+		{key: "split.", since: "v5.0.0"},
+		{key: "server.grpc-memory-pool-quota", since: "v5.0.0"},
+		{key: "readpool.unified.max-thread-count", since: "v5.0.0"},
+		{key: "import.num-threads", since: "v5.0.0"},
+	},
+}
+
+// isHotReloadable returns whether the config item can be changed online in the version of the component
+func isHotReloadable(memberType v1alpha1.MemberType, version, key string) bool {
+	for _, item := range hotReloadableItems[memberType] {
+		if item.key != key && !(strings.HasSuffix(item.key, ".") && strings.HasPrefix(key, item.key)) {
+			continue
+		}
+		ok, err := cmpver.Compare(version, cmpver.GreaterOrEqual, item.since)
+		if err != nil {
+			klog.V(4).Infof("failed to compare version %q of %s with %s: %v", version, memberType, item.since, err)
+			return false
+		}
+		return ok
+	}
+	return false
+}
+
+// flattenConfig flattens the nested tables of the config, the keys of the result are the full names of the items
+func flattenConfig(prefix string, config map[string]interface{}, result map[string]interface{}) {
+	for k, v := range config {
+		key := prefix + k
+		if sub, ok := v.(map[string]interface{}); ok {
+			flattenConfig(key+".", sub, result)
+			continue
+		}
+		result[key] = v
+	}
+}
+
+// HotReloadableChanges returns the config items changed from oldData to newData.
+// The returned bool is false if any of the changes can not be applied online in the version of the component,
+// including the items removed from the config.
+func HotReloadableChanges(memberType v1alpha1.MemberType, version, oldData, newData string) (map[string]interface{}, bool, error) {
+	oldConfig, newConfig := map[string]interface{}{}, map[string]interface{}{}
+	if err := toml.Unmarshal([]byte(oldData), &oldConfig); err != nil {
+		return nil, false, perrors.Annotatef(err, "unmarshal config %s failed", oldData)
+	}
+	if err := toml.Unmarshal([]byte(newData), &newConfig); err != nil {
+		return nil, false, perrors.Annotatef(err, "unmarshal config %s failed", newData)
+	}
+	oldItems, newItems := map[string]interface{}{}, map[string]interface{}{}
+	flattenConfig("", oldConfig, oldItems)
+	flattenConfig("", newConfig, newItems)
+
+	for k := range oldItems {
+		if _, ok := newItems[k]; !ok {
+			return nil, false, nil
+		}
+	}
+	changes := map[string]interface{}{}
+	for k, v := range newItems {
+		if old, ok := oldItems[k]; ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		if !isHotReloadable(memberType, version, k) {
+			return nil, false, nil
+		}
+		changes[k] = v
+	}
+	return changes, true, nil
+}
+
+// HotReloadConfigMapIfNeed works like UpdateConfigMapIfNeed with ConfigUpdateStrategyRollingUpdate, except that
+// the changes are applied by apply and the configmap in use is updated in-place if only the hot-reloadable items
+// of the config file are changed, so the pods are not restarted.
+//
+// apply is called before the configmap is updated, so the changes are applied again in the next sync if it fails.
+func HotReloadConfigMapIfNeed(
+	cmLister corelisters.ConfigMapLister,
+	memberType v1alpha1.MemberType,
+	version string,
+	inUseName string,
+	desired *corev1.ConfigMap,
+	apply func(config map[string]interface{}) error,
+) error {
+	existing, err := cmLister.ConfigMaps(desired.Namespace).Get(inUseName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return UpdateConfigMapIfNeed(cmLister, v1alpha1.ConfigUpdateStrategyRollingUpdate, inUseName, desired)
+		}
+		return perrors.AddStack(err)
+	}
+
+	dataEqual, err := updateConfigMap(existing, desired)
+	if err != nil {
+		return err
+	}
+	if !dataEqual && onlyConfigFileChanged(existing, desired) {
+		changes, hot, err := HotReloadableChanges(memberType, version, existing.Data["config-file"], desired.Data["config-file"])
+		if err != nil {
+			return err
+		}
+		if hot && len(changes) > 0 {
+			if err := apply(changes); err != nil {
+				return perrors.Annotatef(err, "apply config changes %v of %s online failed", changes, memberType)
+			}
+			klog.Infof("config changes %v of %s are applied online, update configmap %s/%s in-place", changes, memberType, existing.Namespace, existing.Name)
+			desired.Name = existing.Name
+			return nil
+		}
+	}
+	return UpdateConfigMapIfNeed(cmLister, v1alpha1.ConfigUpdateStrategyRollingUpdate, inUseName, desired)
+}
+
+// onlyConfigFileChanged returns whether the data of the configmaps are the same except the config file
+func onlyConfigFileChanged(existing, desired *corev1.ConfigMap) bool {
+	if len(existing.Data) != len(desired.Data) {
+		return false
+	}
+	for k, v := range desired.Data {
+		old, ok := existing.Data[k]
+		if !ok {
+			return false
+		}
+		if k != "config-file" && old != v {
+			return false
+		}
+	}
+	return true
+}
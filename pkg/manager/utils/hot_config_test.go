@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestHotReloadableChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := `
+[schedule]
+leader-schedule-limit = 4
+[replication]
+max-replicas = 3
+`
+	tests := []struct {
+		name    string
+		version string
+		data    string
+		changes map[string]interface{}
+		hot     bool
+	}{
+		{
+			name:    "no change",
+			version: "v6.5.0",
+			data:    old,
+			changes: map[string]interface{}{},
+			hot:     true,
+		},
+		{
+			name:    "hot items changed",
+			version: "v6.5.0",
+			data:    "[schedule]\nleader-schedule-limit = 8\nregion-schedule-limit = 1024\n[replication]\nmax-replicas = 5\n",
+			changes: map[string]interface{}{
+				"schedule.leader-schedule-limit": int64(8),
+				"schedule.region-schedule-limit": int64(1024),
+				"replication.max-replicas":       int64(5),
+			},
+			hot: true,
+		},
+		{
+			name:    "item not hot-reloadable",
+			version: "v6.5.0",
+			data:    old + "[security]\ncert-allowed-cn = [\"tidb\"]\n",
+			hot:     false,
+		},
+		{
+			name:    "item removed",
+			version: "v6.5.0",
+			data:    "[schedule]\nleader-schedule-limit = 4\n",
+			hot:     false,
+		},
+		{
+			name:    "item not hot-reloadable in the version",
+			version: "v4.0.0",
+			data:    old + "[pd-server]\nflow-round-by-digit = 2\n",
+			hot:     false,
+		},
+		{
+			name:    "unknown version",
+			version: "custom-build",
+			data:    "[schedule]\nleader-schedule-limit = 8\n[replication]\nmax-replicas = 3\n",
+			hot:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Log(tt.name)
+		changes, hot, err := HotReloadableChanges(v1alpha1.PDMemberType, tt.version, old, tt.data)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hot).To(Equal(tt.hot))
+		if tt.hot {
+			g.Expect(changes).To(Equal(tt.changes))
+		}
+	}
+}
+
+func TestHotReloadConfigMapIfNeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	cmLister := corelisters.NewConfigMapLister(indexer)
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic-tikv-1234abcd"},
+		Data: map[string]string{
+			"config-file":    "[gc]\nbatch-keys = 512\n",
+			"startup-script": "start",
+		},
+	}
+	g.Expect(indexer.Add(existing)).To(Succeed())
+	newDesired := func(config, script string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic-tikv"},
+			Data: map[string]string{
+				"config-file":    config,
+				"startup-script": script,
+			},
+		}
+	}
+
+	var applied map[string]interface{}
+	var applyErr error
+	apply := func(config map[string]interface{}) error {
+		applied = config
+		return applyErr
+	}
+
+	// hot items are applied online and the configmap is updated in-place
+	desired := newDesired("[gc]\nbatch-keys = 1024\n", "start")
+	g.Expect(HotReloadConfigMapIfNeed(cmLister, v1alpha1.TiKVMemberType, "v6.5.0", existing.Name, desired, apply)).To(Succeed())
+	g.Expect(applied).To(Equal(map[string]interface{}{"gc.batch-keys": int64(1024)}))
+	g.Expect(desired.Name).To(Equal(existing.Name))
+
+	// the configmap is not updated if the changes fail to be applied
+	applied, applyErr = nil, fmt.Errorf("connection refused")
+	desired = newDesired("[gc]\nbatch-keys = 1024\n", "start")
+	g.Expect(HotReloadConfigMapIfNeed(cmLister, v1alpha1.TiKVMemberType, "v6.5.0", existing.Name, desired, apply)).NotTo(Succeed())
+	applyErr = nil
+
+	// rolling update if the startup script is changed
+	applied = nil
+	desired = newDesired("[gc]\nbatch-keys = 1024\n", "start --new")
+	g.Expect(HotReloadConfigMapIfNeed(cmLister, v1alpha1.TiKVMemberType, "v6.5.0", existing.Name, desired, apply)).To(Succeed())
+	g.Expect(applied).To(BeNil())
+	g.Expect(desired.Name).NotTo(Equal(existing.Name))
+
+	// rolling update if the items are not hot-reloadable
+	desired = newDesired("[gc]\nbatch-keys = 512\n[storage]\ndata-dir = \"/data\"\n", "start")
+	g.Expect(HotReloadConfigMapIfNeed(cmLister, v1alpha1.TiKVMemberType, "v6.5.0", existing.Name, desired, apply)).To(Succeed())
+	g.Expect(applied).To(BeNil())
+	g.Expect(desired.Name).NotTo(Equal(existing.Name))
+
+	// nothing is applied if the config is unchanged
+	desired = newDesired("[gc]\n  batch-keys = 512\n", "start")
+	g.Expect(HotReloadConfigMapIfNeed(cmLister, v1alpha1.TiKVMemberType, "v6.5.0", existing.Name, desired, apply)).To(Succeed())
+	g.Expect(applied).To(BeNil())
+	g.Expect(desired.Name).To(Equal(existing.Name))
+}
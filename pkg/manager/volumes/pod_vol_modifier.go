@@ -214,7 +214,7 @@ func (p *podVolModifier) GetDesiredVolumes(tc *v1alpha1.TidbCluster, mt v1alpha1
 		}
 		desiredVolumes = append(desiredVolumes, d)
 
-		storageVolumes = tc.Spec.TiKV.StorageVolumes
+		storageVolumes = tc.Spec.TiKV.AllStorageVolumes()
 
 	case v1alpha1.TiFlashMemberType:
 		for i, claim := range tc.Spec.TiFlash.StorageClaims {
@@ -283,6 +283,10 @@ func getStorageClass(name *string, scLister storagelister.StorageClassLister) (*
 	if name == nil {
 		return nil, nil
 	}
+	if scLister == nil {
+		klog.V(4).Infof("Storage classes lister is unavailable, skip getting storage class %s. This may be caused by no relevant permissions", *name)
+		return nil, nil
+	}
 	return scLister.Get(*name)
 }
 
@@ -319,6 +323,10 @@ func (p *podVolModifier) getStorageClassFromPVC(pvc *corev1.PersistentVolumeClai
 	if sc == "" {
 		return nil, nil
 	}
+	if p.deps.StorageClassLister == nil {
+		klog.V(4).Infof("Storage classes lister is unavailable, skip getting storage class %s. This may be caused by no relevant permissions", sc)
+		return nil, nil
+	}
 
 	return p.deps.StorageClassLister.Get(sc)
 }
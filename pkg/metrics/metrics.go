@@ -22,6 +22,7 @@ const (
 	LabelNamespace = "namespace"
 	LabelName      = "name"
 	LabelComponent = "component"
+	LabelType      = "type"
 )
 
 var (
@@ -75,5 +76,16 @@ func init() {
 
 		ClusterSpecReplicas,
 		ClusterUpdateErrors,
+		ClusterCostEstimate,
+		ClusterReconcileDuration,
+		ClusterReconcileErrors,
+		ClusterReconcileLastSuccess,
+
+		OrphanResources,
+		OrphanResourcesDeleted,
+
+		Notifications,
+
+		PreflightAffectedClusters,
 	)
 }
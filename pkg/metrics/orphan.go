@@ -0,0 +1,36 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	OrphanResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "orphan_sweeper",
+			Name:      "orphan_resources",
+			Help:      "Number of resources managed by tidb-operator whose owner CR no longer exists, found in the last sweep",
+		}, []string{LabelNamespace, LabelType})
+
+	OrphanResourcesDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "orphan_sweeper",
+			Name:      "deleted_resources_total",
+			Help:      "Number of orphaned resources deleted by the orphan sweeper",
+		}, []string{LabelNamespace, LabelType})
+)
@@ -14,9 +14,17 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// LabelPhase and LabelReason are the labels of the reconcile metrics of the components
+const (
+	LabelPhase  = "phase"
+	LabelReason = "reason"
+)
+
 var (
 	ClusterSpecReplicas = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -33,4 +41,46 @@ var (
 			Name:      "update_errors",
 			Help:      "Number of errors generated in each stage when updating TiDB Clusters",
 		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterCostEstimate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "monthly_cost_estimate",
+			Help:      "Estimated monthly cost of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelType})
+
+	ClusterReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of each phase of reconciling the components of TiDB Clusters",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{LabelComponent, LabelPhase})
+
+	ClusterReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "reconcile_errors_total",
+			Help:      "Number of errors of each phase of reconciling the components of TiDB Clusters by reason",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelPhase, LabelReason})
+
+	ClusterReconcileLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "reconcile_last_success_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful reconciliation of the components of TiDB Clusters",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
 )
+
+// ObserveClusterReconcile records the duration of a phase of reconciling a component, and the error of it
+// by reason if the reason is not empty
+func ObserveClusterReconcile(ns, name, component, phase string, duration time.Duration, reason string) {
+	ClusterReconcileDuration.WithLabelValues(component, phase).Observe(duration.Seconds())
+	if reason != "" {
+		ClusterReconcileErrors.WithLabelValues(ns, name, component, phase, reason).Inc()
+	}
+}
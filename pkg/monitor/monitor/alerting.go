@@ -0,0 +1,121 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// getAlertingClaimedByOthers returns the keys (namespace/name) of the TidbClusters in tcRefs whose alerting is
+// claimed by other TidbMonitors. A TidbCluster is claimed by the TidbMonitor with the highest alerting priority
+// among all TidbMonitors monitoring it with alert rules, ties are broken by the namespace and name.
+func (m *MonitorManager) getAlertingClaimedByOthers(monitor *v1alpha1.TidbMonitor, tcRefs []v1alpha1.TidbClusterRef) (sets.String, error) {
+	claimed := sets.NewString()
+	if !monitor.RendersAlertRules() || len(tcRefs) == 0 {
+		return claimed, nil
+	}
+	monitors, err := m.deps.TiDBMonitorLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("tm[%s/%s] failed to list tidbmonitors, err: %v", monitor.Namespace, monitor.Name, err)
+	}
+	var contenders []*v1alpha1.TidbMonitor
+	for _, other := range monitors {
+		if other.DeletionTimestamp != nil || !other.RendersAlertRules() || !preferredForAlerting(other, monitor) {
+			continue
+		}
+		contenders = append(contenders, other)
+	}
+	if len(contenders) == 0 {
+		return claimed, nil
+	}
+
+	for _, ref := range tcRefs {
+		for _, other := range contenders {
+			ok, err := m.isMonitoredBy(other, ref)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				klog.V(4).Infof("tm[%s/%s]: alerting of tc[%s/%s] is claimed by tm[%s/%s]", monitor.Namespace, monitor.Name, ref.Namespace, ref.Name, other.Namespace, other.Name)
+				claimed.Insert(ref.Namespace + "/" + ref.Name)
+				break
+			}
+		}
+	}
+	return claimed, nil
+}
+
+// preferredForAlerting returns whether the TidbMonitor a takes precedence over b in claiming the alerting of a cluster
+func preferredForAlerting(a, b *v1alpha1.TidbMonitor) bool {
+	if pa, pb := a.AlertingPriority(), b.AlertingPriority(); pa != pb {
+		return pa > pb
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// isMonitoredBy returns whether the TidbCluster is listed in the spec or selected by the cluster selector of the TidbMonitor
+func (m *MonitorManager) isMonitoredBy(monitor *v1alpha1.TidbMonitor, ref v1alpha1.TidbClusterRef) (bool, error) {
+	for _, r := range monitor.Spec.Clusters {
+		ns := r.Namespace
+		if ns == "" {
+			ns = monitor.Namespace
+		}
+		if ns == ref.Namespace && r.Name == ref.Name {
+			return true, nil
+		}
+	}
+
+	cs := monitor.Spec.ClusterSelector
+	if cs == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&cs.LabelSelector)
+	if err != nil {
+		// the invalid selector is reported by the sync of that TidbMonitor
+		return false, nil
+	}
+	tc, err := m.deps.TiDBClusterLister.TidbClusters(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get tc[%s/%s], err: %v", ref.Namespace, ref.Name, err)
+	}
+	if !selector.Matches(labels.Set(tc.Labels)) {
+		return false, nil
+	}
+	if cs.NamespaceSelector == nil {
+		return ref.Namespace == monitor.Namespace, nil
+	}
+	nsSelector, err := metav1.LabelSelectorAsSelector(cs.NamespaceSelector)
+	if err != nil {
+		return false, nil
+	}
+	ns, err := m.deps.KubeClientset.CoreV1().Namespaces().Get(context.TODO(), ref.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get namespace %s, err: %v", ref.Namespace, err)
+	}
+	return nsSelector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetAlertingClaimedByOthers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm := newFakeTidbMonitorManager()
+	tcIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	for _, tc := range []*v1alpha1.TidbCluster{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo", Labels: map[string]string{"team": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bar"}},
+	} {
+		g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	}
+
+	tm := newTidbMonitor(v1alpha1.TidbClusterRef{Namespace: "ns", Name: "foo"})
+	tm.Spec.Clusters = append(tm.Spec.Clusters, v1alpha1.TidbClusterRef{Namespace: "ns", Name: "bar"})
+	tm.Spec.AlertmanagerURL = pointer.StringPtr("alertmanager:9093")
+
+	// the central monitor selects the cluster foo by labels with a higher priority
+	central := newTidbMonitor(v1alpha1.TidbClusterRef{})
+	central.Name = "central"
+	central.Spec.Clusters = nil
+	central.Spec.ClusterSelector = &v1alpha1.TidbMonitorClusterSelector{
+		LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+	}
+	central.Spec.EnableAlertRules = true
+	central.Spec.Alerting = &v1alpha1.TidbMonitorAlertingSpec{Priority: pointer.Int32Ptr(10)}
+	// the monitor without alert rules never claims the alerting
+	noAlert := newTidbMonitor(v1alpha1.TidbClusterRef{Namespace: "ns", Name: "bar"})
+	noAlert.Name = "a-no-alert"
+	tmIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().TidbMonitors().Informer().GetIndexer()
+	for _, m := range []*v1alpha1.TidbMonitor{tm, central, noAlert} {
+		g.Expect(tmIndexer.Add(m)).To(Succeed())
+	}
+
+	refs := tm.Spec.Clusters
+	claimed, err := tmm.getAlertingClaimedByOthers(tm, refs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claimed.List()).To(Equal([]string{"ns/foo"}))
+
+	// the central monitor claims nothing from the others
+	claimed, err = tmm.getAlertingClaimedByOthers(central, []v1alpha1.TidbClusterRef{{Namespace: "ns", Name: "foo"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claimed.List()).To(BeEmpty())
+
+	// ties are broken by the name, "central" takes precedence over "foo"
+	central.Spec.Alerting.Priority = nil
+	claimed, err = tmm.getAlertingClaimedByOthers(tm, refs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claimed.List()).To(Equal([]string{"ns/foo"}))
+
+	tm.Spec.Alerting = &v1alpha1.TidbMonitorAlertingSpec{Priority: pointer.Int32Ptr(1)}
+	claimed, err = tmm.getAlertingClaimedByOthers(tm, refs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claimed.List()).To(BeEmpty())
+}
+
+func TestRenderPrometheusConfigWithAlertingClaims(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	getItem := func(cfg yaml.MapSlice, key string) interface{} {
+		for _, item := range cfg {
+			if item.Key == key {
+				return item.Value
+			}
+		}
+		return nil
+	}
+
+	model := &MonitorConfigModel{
+		AlertmanagerURL: "alertmanager:9093",
+		ClusterInfos: []ClusterRegexInfo{
+			{Name: "foo", Namespace: "ns", alertingClaimedByOthers: true},
+			{Name: "bar", Namespace: "ns"},
+		},
+		AlertDedupLabels: []string{"prometheus_replica"},
+	}
+	cfg, err := RenderPrometheusConfig(model)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getItem(cfg, "rule_files")).To(Equal([]string{"/prometheus-rules/rules/*.rules.yml"}))
+	alerting, ok := getItem(cfg, "alerting").(yaml.MapSlice)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(getItem(alerting, "alert_relabel_configs")).To(Equal([]yaml.MapSlice{
+		{
+			{Key: "regex", Value: "prometheus_replica"},
+			{Key: "action", Value: "labeldrop"},
+		},
+		{
+			{Key: "source_labels", Value: []string{"tidb_cluster"}},
+			{Key: "regex", Value: "ns-foo"},
+			{Key: "action", Value: "drop"},
+		},
+	}))
+
+	// the alert rules are not rendered if all clusters are claimed by others
+	model.ClusterInfos[1].alertingClaimedByOthers = true
+	model.EnableAlertRules = true
+	cfg, err = RenderPrometheusConfig(model)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getItem(cfg, "rule_files")).To(BeNil())
+	g.Expect(getItem(cfg, "alerting")).To(BeNil())
+}
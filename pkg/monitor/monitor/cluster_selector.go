@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// getMonitoredClusters returns the TidbClusters and DMClusters monitored by the TidbMonitor,
+// including the clusters listed in the spec and the clusters selected by spec.clusterSelector.
+// The spec of the TidbMonitor is not changed, so the selected clusters are never persisted.
+func (m *MonitorManager) getMonitoredClusters(monitor *v1alpha1.TidbMonitor) ([]v1alpha1.TidbClusterRef, []v1alpha1.ClusterRef, error) {
+	tcRefs := append([]v1alpha1.TidbClusterRef{}, monitor.Spec.Clusters...)
+	var dcRefs []v1alpha1.ClusterRef
+	if monitor.Spec.DM != nil {
+		dcRefs = append(dcRefs, monitor.Spec.DM.Clusters...)
+	}
+	cs := monitor.Spec.ClusterSelector
+	if cs == nil {
+		return tcRefs, dcRefs, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&cs.LabelSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tm[%s/%s] has invalid cluster label selector: %v", monitor.Namespace, monitor.Name, err)
+	}
+	namespaces, err := m.getSelectedNamespaces(monitor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existTcs := sets.NewString()
+	for _, ref := range tcRefs {
+		existTcs.Insert(ref.Namespace + "/" + ref.Name)
+	}
+	existDcs := sets.NewString()
+	for _, ref := range dcRefs {
+		existDcs.Insert(ref.Namespace + "/" + ref.Name)
+	}
+
+	var selectedTcRefs []v1alpha1.TidbClusterRef
+	var selectedDcRefs []v1alpha1.ClusterRef
+	for _, ns := range namespaces {
+		tcs, err := m.deps.TiDBClusterLister.TidbClusters(ns).List(selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tm[%s/%s] failed to list tidbclusters in namespace %q, err: %v", monitor.Namespace, monitor.Name, ns, err)
+		}
+		for _, tc := range tcs {
+			if tc.DeletionTimestamp != nil || existTcs.Has(tc.Namespace+"/"+tc.Name) {
+				continue
+			}
+			existTcs.Insert(tc.Namespace + "/" + tc.Name)
+			selectedTcRefs = append(selectedTcRefs, v1alpha1.TidbClusterRef{Namespace: tc.Namespace, Name: tc.Name})
+		}
+
+		if monitor.Spec.DM == nil {
+			continue
+		}
+		dcs, err := m.deps.DMClusterLister.DMClusters(ns).List(selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tm[%s/%s] failed to list dmclusters in namespace %q, err: %v", monitor.Namespace, monitor.Name, ns, err)
+		}
+		for _, dc := range dcs {
+			if dc.DeletionTimestamp != nil || existDcs.Has(dc.Namespace+"/"+dc.Name) {
+				continue
+			}
+			existDcs.Insert(dc.Namespace + "/" + dc.Name)
+			selectedDcRefs = append(selectedDcRefs, v1alpha1.ClusterRef{Namespace: dc.Namespace, Name: dc.Name})
+		}
+	}
+
+	// sort the selected clusters for the stability of the Prometheus config
+	sort.Slice(selectedTcRefs, func(i, j int) bool {
+		if selectedTcRefs[i].Namespace != selectedTcRefs[j].Namespace {
+			return selectedTcRefs[i].Namespace < selectedTcRefs[j].Namespace
+		}
+		return selectedTcRefs[i].Name < selectedTcRefs[j].Name
+	})
+	sort.Slice(selectedDcRefs, func(i, j int) bool {
+		if selectedDcRefs[i].Namespace != selectedDcRefs[j].Namespace {
+			return selectedDcRefs[i].Namespace < selectedDcRefs[j].Namespace
+		}
+		return selectedDcRefs[i].Name < selectedDcRefs[j].Name
+	})
+	return append(tcRefs, selectedTcRefs...), append(dcRefs, selectedDcRefs...), nil
+}
+
+// getSelectedNamespaces returns the namespaces selected by the namespace selector of the cluster selector.
+// Only the namespace of the TidbMonitor is selected if the namespace selector is not set.
+func (m *MonitorManager) getSelectedNamespaces(monitor *v1alpha1.TidbMonitor) ([]string, error) {
+	nsSelector := monitor.Spec.ClusterSelector.NamespaceSelector
+	if nsSelector == nil {
+		return []string{monitor.Namespace}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(nsSelector)
+	if err != nil {
+		return nil, fmt.Errorf("tm[%s/%s] has invalid cluster namespace selector: %v", monitor.Namespace, monitor.Name, err)
+	}
+	nsList, err := m.deps.KubeClientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("tm[%s/%s] failed to list namespaces, err: %v", monitor.Namespace, monitor.Name, err)
+	}
+	namespaces := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
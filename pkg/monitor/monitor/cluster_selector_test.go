@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetMonitoredClusters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm := newFakeTidbMonitorManager()
+	for _, ns := range []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{"team": "db"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"team": "db"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+	} {
+		_, err := tmm.deps.KubeClientset.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	tcIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	for _, tc := range []*v1alpha1.TidbCluster{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo", Labels: map[string]string{"monitor": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "bar", Labels: map[string]string{"monitor": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "baz"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "foo", Labels: map[string]string{"monitor": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "unrelated", Name: "foo", Labels: map[string]string{"monitor": "true"}}},
+	} {
+		g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	}
+	dcIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().GetIndexer()
+	g.Expect(dcIndexer.Add(&v1alpha1.DMCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dm", Labels: map[string]string{"monitor": "true"}}})).To(Succeed())
+
+	tm := newTidbMonitor(v1alpha1.TidbClusterRef{Name: "foo", Namespace: "ns"})
+
+	// only the clusters listed explicitly are monitored without cluster selector
+	tcRefs, dcRefs, err := tmm.getMonitoredClusters(tm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcRefs).To(Equal([]v1alpha1.TidbClusterRef{{Namespace: "ns", Name: "foo"}}))
+	g.Expect(dcRefs).To(BeEmpty())
+
+	// the clusters in the namespace of the TidbMonitor are selected by default
+	tm.Spec.ClusterSelector = &v1alpha1.TidbMonitorClusterSelector{
+		LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"monitor": "true"}},
+	}
+	tcRefs, dcRefs, err = tmm.getMonitoredClusters(tm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcRefs).To(Equal([]v1alpha1.TidbClusterRef{{Namespace: "ns", Name: "foo"}, {Namespace: "ns", Name: "bar"}}))
+	// DMClusters are not selected without spec.dm
+	g.Expect(dcRefs).To(BeEmpty())
+
+	// the clusters in the selected namespaces
+	tm.Spec.ClusterSelector.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}}
+	tm.Spec.DM = &v1alpha1.DMMonitorSpec{}
+	tcRefs, dcRefs, err = tmm.getMonitoredClusters(tm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcRefs).To(Equal([]v1alpha1.TidbClusterRef{{Namespace: "ns", Name: "foo"}, {Namespace: "ns", Name: "bar"}, {Namespace: "other", Name: "foo"}}))
+	g.Expect(dcRefs).To(Equal([]v1alpha1.ClusterRef{{Namespace: "ns", Name: "dm"}}))
+	// the spec is not changed
+	g.Expect(tm.Spec.Clusters).To(HaveLen(1))
+}
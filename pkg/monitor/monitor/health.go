@@ -0,0 +1,361 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	healthCheckTimeout      = 5 * time.Second
+	defaultRestartThreshold = 10 * time.Minute
+	prometheusPort          = 9090
+	grafanaPort             = 3000
+
+	healthyReason   = "Healthy"
+	unhealthyReason = "Unhealthy"
+)
+
+// HealthChecker checks the health of the Prometheus and Grafana in a TidbMonitor pod
+type HealthChecker interface {
+	// CheckPrometheus returns an error if the TSDB of the Prometheus is not ready
+	CheckPrometheus(pod *corev1.Pod) error
+	// CheckGrafana returns an error if the Grafana is unhealthy or any of its Prometheus datasources is invalid
+	CheckGrafana(pod *corev1.Pod, username, password string) error
+}
+
+type httpHealthChecker struct {
+	client *http.Client
+}
+
+// NewHealthChecker returns a HealthChecker which checks the pods through their HTTP APIs
+func NewHealthChecker() HealthChecker {
+	return &httpHealthChecker{
+		client: &http.Client{Timeout: healthCheckTimeout},
+	}
+}
+
+func (c *httpHealthChecker) CheckPrometheus(pod *corev1.Pod) error {
+	// Prometheus returns 503 until the TSDB is opened and the WAL is replayed
+	return c.get(podURL(pod, prometheusPort, "/-/ready"), "", "", nil)
+}
+
+type grafanaHealth struct {
+	Database string `json:"database"`
+}
+
+type grafanaDatasource struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (c *httpHealthChecker) CheckGrafana(pod *corev1.Pod, username, password string) error {
+	health := &grafanaHealth{}
+	if err := c.get(podURL(pod, grafanaPort, "/api/health"), "", "", health); err != nil {
+		return err
+	}
+	if health.Database != "ok" {
+		return fmt.Errorf("grafana database is %q", health.Database)
+	}
+
+	var datasources []grafanaDatasource
+	if err := c.get(podURL(pod, grafanaPort, "/api/datasources"), username, password, &datasources); err != nil {
+		return err
+	}
+	if len(datasources) == 0 {
+		return fmt.Errorf("no datasource is provisioned in grafana")
+	}
+	for _, ds := range datasources {
+		if ds.Type != "prometheus" {
+			continue
+		}
+		// query through the datasource proxy to make sure the datasource can reach the Prometheus
+		path := "/api/datasources/proxy/" + strconv.FormatInt(ds.ID, 10) + "/api/v1/query?query=1"
+		if err := c.get(podURL(pod, grafanaPort, path), username, password, nil); err != nil {
+			return fmt.Errorf("datasource %s is invalid: %v", ds.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *httpHealthChecker) get(url, username, password string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returns %d: %s", url, resp.StatusCode, string(body))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
+
+func podURL(pod *corev1.Pod, port int, path string) string {
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port)), path)
+}
+
+// syncTidbMonitorHealth checks the Prometheus and Grafana of all the pods of the TidbMonitor,
+// reports the results in the conditions and restarts the pods which keep unhealthy if needed.
+func (m *MonitorManager) syncTidbMonitorHealth(monitor *v1alpha1.TidbMonitor) error {
+	if monitor.Spec.HealthCheck == nil {
+		return nil
+	}
+
+	var pods []*corev1.Pod
+	for shard := int32(0); shard < monitor.GetShards(); shard++ {
+		selector := labels.SelectorFromSet(buildTidbMonitorLabel(GetMonitorInstanceName(monitor, shard)))
+		shardPods, err := m.deps.PodLister.Pods(monitor.Namespace).List(selector)
+		if err != nil {
+			return fmt.Errorf("list pods of tm[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, err)
+		}
+		pods = append(pods, shardPods...)
+	}
+
+	var username, password string
+	if monitor.Spec.Grafana != nil {
+		var err error
+		username, password, err = m.getGrafanaCredentials(monitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	var prometheusErrs, grafanaErrs []string
+	unhealthyPods := map[string]*corev1.Pod{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			msg := fmt.Sprintf("pod %s is not running", pod.Name)
+			prometheusErrs = append(prometheusErrs, msg)
+			if monitor.Spec.Grafana != nil {
+				grafanaErrs = append(grafanaErrs, msg)
+			}
+			continue
+		}
+		if err := m.healthChecker.CheckPrometheus(pod); err != nil {
+			klog.Warningf("tm[%s/%s]'s prometheus in pod %s is unhealthy, err: %v", monitor.Namespace, monitor.Name, pod.Name, err)
+			prometheusErrs = append(prometheusErrs, fmt.Sprintf("pod %s: %v", pod.Name, err))
+			unhealthyPods[pod.Name] = pod
+		}
+		if monitor.Spec.Grafana == nil {
+			continue
+		}
+		if err := m.healthChecker.CheckGrafana(pod, username, password); err != nil {
+			klog.Warningf("tm[%s/%s]'s grafana in pod %s is unhealthy, err: %v", monitor.Namespace, monitor.Name, pod.Name, err)
+			grafanaErrs = append(grafanaErrs, fmt.Sprintf("pod %s: %v", pod.Name, err))
+			unhealthyPods[pod.Name] = pod
+		}
+	}
+	if len(pods) == 0 {
+		prometheusErrs = append(prometheusErrs, "no pod is found")
+		if monitor.Spec.Grafana != nil {
+			grafanaErrs = append(grafanaErrs, "no pod is found")
+		}
+	}
+
+	setHealthCondition(monitor, v1alpha1.TidbMonitorPrometheusReady, prometheusErrs)
+	if monitor.Spec.Grafana != nil {
+		setHealthCondition(monitor, v1alpha1.TidbMonitorGrafanaReady, grafanaErrs)
+	} else {
+		meta.RemoveStatusCondition(&monitor.Status.Conditions, v1alpha1.TidbMonitorGrafanaReady)
+	}
+
+	if !monitor.Spec.HealthCheck.AutoRestart {
+		return nil
+	}
+	threshold := defaultRestartThreshold
+	if monitor.Spec.HealthCheck.RestartThreshold != nil {
+		threshold = monitor.Spec.HealthCheck.RestartThreshold.Duration
+	}
+	if !unhealthyLongerThan(monitor, threshold) {
+		return nil
+	}
+	for _, pod := range unhealthyPods {
+		// give the restarted pod enough time to recover, e.g. the WAL replay of Prometheus may take minutes
+		if pod.Status.StartTime == nil || time.Since(pod.Status.StartTime.Time) < threshold {
+			continue
+		}
+		klog.Infof("tm[%s/%s]'s pod %s keeps unhealthy longer than %s, restart it", monitor.Namespace, monitor.Name, pod.Name, threshold)
+		if err := m.deps.PodControl.DeletePod(monitor, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unhealthyLongerThan returns whether any of the health conditions keeps false longer than the threshold
+func unhealthyLongerThan(monitor *v1alpha1.TidbMonitor, threshold time.Duration) bool {
+	for _, condType := range []string{v1alpha1.TidbMonitorPrometheusReady, v1alpha1.TidbMonitorGrafanaReady} {
+		cond := meta.FindStatusCondition(monitor.Status.Conditions, condType)
+		if cond != nil && cond.Status == metav1.ConditionFalse && time.Since(cond.LastTransitionTime.Time) >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func setHealthCondition(monitor *v1alpha1.TidbMonitor, condType string, errs []string) {
+	cond := metav1.Condition{
+		Type:               condType,
+		Status:             metav1.ConditionTrue,
+		Reason:             healthyReason,
+		ObservedGeneration: monitor.Generation,
+	}
+	if len(errs) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = unhealthyReason
+		cond.Message = strings.Join(errs, "; ")
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, cond)
+}
+
+// setTidbMonitorConditions sets the standard Ready, Progressing and Degraded conditions of TidbMonitor
+// from its statefulset and the health conditions.
+func setTidbMonitorConditions(monitor *v1alpha1.TidbMonitor, sts *appsv1.StatefulSet) {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	progressing := metav1.Condition{
+		Type:               v1alpha1.TidbMonitorProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             "RolloutComplete",
+		ObservedGeneration: monitor.Generation,
+	}
+	switch {
+	case sts.Status.ObservedGeneration < sts.Generation || sts.Status.CurrentRevision != sts.Status.UpdateRevision:
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "StatefulSetNotUpToDate"
+		progressing.Message = "Statefulset is in progress"
+	case sts.Status.ReadyReplicas < replicas:
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "PodsNotReady"
+		progressing.Message = fmt.Sprintf("%d of %d pods are ready", sts.Status.ReadyReplicas, replicas)
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, progressing)
+
+	degraded := metav1.Condition{
+		Type:               v1alpha1.TidbMonitorDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             healthyReason,
+		ObservedGeneration: monitor.Generation,
+	}
+	var unhealthy []string
+	for _, condType := range []string{v1alpha1.TidbMonitorPrometheusReady, v1alpha1.TidbMonitorGrafanaReady} {
+		if meta.IsStatusConditionFalse(monitor.Status.Conditions, condType) {
+			unhealthy = append(unhealthy, condType)
+		}
+	}
+	if len(unhealthy) > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = unhealthyReason
+		degraded.Message = fmt.Sprintf("%s are not true", strings.Join(unhealthy, ", "))
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, degraded)
+
+	ready := metav1.Condition{
+		Type:               v1alpha1.TidbMonitorReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Ready",
+		Message:            "TidbMonitor is fully up and running",
+		ObservedGeneration: monitor.Generation,
+	}
+	if progressing.Status == metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = progressing.Reason
+		ready.Message = progressing.Message
+	} else if degraded.Status == metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = degraded.Reason
+		ready.Message = degraded.Message
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, ready)
+}
+
+// getGrafanaCredentials returns the admin username and password of the Grafana,
+// UsernameSecret and PasswordSecret take precedence over Username and Password.
+func (m *MonitorManager) getGrafanaCredentials(monitor *v1alpha1.TidbMonitor) (string, string, error) {
+	username := monitor.Spec.Grafana.Username
+	password := monitor.Spec.Grafana.Password
+	if ref := monitor.Spec.Grafana.UsernameSecret; ref != nil {
+		value, err := m.getSecretValue(monitor.Namespace, ref)
+		if err != nil {
+			return "", "", err
+		}
+		username = value
+	}
+	if ref := monitor.Spec.Grafana.PasswordSecret; ref != nil {
+		value, err := m.getSecretValue(monitor.Namespace, ref)
+		if err != nil {
+			return "", "", err
+		}
+		password = value
+	}
+	return username, password, nil
+}
+
+func (m *MonitorManager) getSecretValue(ns string, ref *corev1.SecretKeySelector) (string, error) {
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("get secret %s/%s failed, err: %v", ns, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", ref.Key, ns, ref.Name)
+	}
+	return string(value), nil
+}
+
+// FakeHealthChecker is a fake HealthChecker which returns the preset errors
+type FakeHealthChecker struct {
+	PrometheusErrs map[string]error
+	GrafanaErrs    map[string]error
+}
+
+func (c *FakeHealthChecker) CheckPrometheus(pod *corev1.Pod) error {
+	return c.PrometheusErrs[pod.Name]
+}
+
+func (c *FakeHealthChecker) CheckGrafana(pod *corev1.Pod, _, _ string) error {
+	return c.GrafanaErrs[pod.Name]
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncTidbMonitorHealth(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name            string
+		healthCheck     *v1alpha1.TidbMonitorHealthCheck
+		prometheusErr   error
+		grafanaErr      error
+		podStarted      time.Duration
+		unhealthySince  time.Duration
+		expectPromReady metav1.ConditionStatus
+		expectGrafReady metav1.ConditionStatus
+		expectDeleted   bool
+	}
+
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+
+		tmm := newFakeTidbMonitorManager()
+		tm := newTidbMonitor(v1alpha1.TidbClusterRef{Name: "basic"})
+		tm.Spec.Grafana = &v1alpha1.GrafanaSpec{Username: "admin", Password: "admin"}
+		tm.Spec.HealthCheck = test.healthCheck
+		if test.unhealthySince > 0 {
+			tm.Status.Conditions = []metav1.Condition{
+				{
+					Type:               v1alpha1.TidbMonitorPrometheusReady,
+					Status:             metav1.ConditionFalse,
+					Reason:             unhealthyReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-test.unhealthySince)),
+				},
+			}
+		}
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-0", GetMonitorObjectName(tm)),
+				Namespace: tm.Namespace,
+				Labels:    buildTidbMonitorLabel(tm.Name),
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodRunning,
+				PodIP:     "10.0.0.1",
+				StartTime: &metav1.Time{Time: time.Now().Add(-test.podStarted)},
+			},
+		}
+		tmm.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+		tmm.healthChecker = &FakeHealthChecker{
+			PrometheusErrs: map[string]error{pod.Name: test.prometheusErr},
+			GrafanaErrs:    map[string]error{pod.Name: test.grafanaErr},
+		}
+
+		err := tmm.syncTidbMonitorHealth(tm)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		if test.healthCheck == nil {
+			g.Expect(tm.Status.Conditions).To(BeEmpty())
+			return
+		}
+		g.Expect(meta.FindStatusCondition(tm.Status.Conditions, v1alpha1.TidbMonitorPrometheusReady).Status).To(Equal(test.expectPromReady))
+		g.Expect(meta.FindStatusCondition(tm.Status.Conditions, v1alpha1.TidbMonitorGrafanaReady).Status).To(Equal(test.expectGrafReady))
+
+		podControl := tmm.deps.PodControl.(*controller.FakePodControl)
+		_, exist, err := podControl.PodIndexer.GetByKey(fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exist).To(Equal(!test.expectDeleted))
+	}
+
+	tests := []testcase{
+		{
+			name:        "health checks are disabled",
+			healthCheck: nil,
+		},
+		{
+			name:            "all healthy",
+			healthCheck:     &v1alpha1.TidbMonitorHealthCheck{},
+			expectPromReady: metav1.ConditionTrue,
+			expectGrafReady: metav1.ConditionTrue,
+		},
+		{
+			name:            "grafana datasource is invalid",
+			healthCheck:     &v1alpha1.TidbMonitorHealthCheck{},
+			grafanaErr:      fmt.Errorf("datasource tidb-cluster is invalid"),
+			expectPromReady: metav1.ConditionTrue,
+			expectGrafReady: metav1.ConditionFalse,
+		},
+		{
+			name:            "prometheus is wedged and restarted",
+			healthCheck:     &v1alpha1.TidbMonitorHealthCheck{AutoRestart: true},
+			prometheusErr:   fmt.Errorf("service unavailable"),
+			podStarted:      time.Hour,
+			unhealthySince:  20 * time.Minute,
+			expectPromReady: metav1.ConditionFalse,
+			expectGrafReady: metav1.ConditionTrue,
+			expectDeleted:   true,
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i])
+	}
+}
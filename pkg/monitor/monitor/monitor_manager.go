@@ -48,6 +48,7 @@ type MonitorManager struct {
 	deps               *controller.Dependencies
 	pvManager          monitor.MonitorManager
 	discoveryInterface discovery.CachedDiscoveryInterface
+	healthChecker      HealthChecker
 }
 
 const (
@@ -63,6 +64,7 @@ func NewMonitorManager(deps *controller.Dependencies) *MonitorManager {
 		deps:               deps,
 		pvManager:          meta.NewReclaimPolicyManager(deps),
 		discoveryInterface: discoverycachedmemory.NewMemCacheClient(deps.KubeClientset.Discovery()),
+		healthChecker:      NewHealthChecker(),
 	}
 }
 
@@ -70,7 +72,7 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 	if monitor.DeletionTimestamp != nil {
 		return nil
 	}
-	if len(monitor.Spec.Clusters) < 1 && (monitor.Spec.DM == nil || len(monitor.Spec.DM.Clusters) < 1) {
+	if len(monitor.Spec.Clusters) < 1 && (monitor.Spec.DM == nil || len(monitor.Spec.DM.Clusters) < 1) && monitor.Spec.ClusterSelector == nil {
 		klog.Errorf("tm[%s/%s] does not configure the target tidbcluster", monitor.Namespace, monitor.Name)
 		return nil
 	}
@@ -80,10 +82,19 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 		return nil // fatal error, no need to retry on invalid object
 	}
 
+	tcRefs, dcRefs, err := m.getMonitoredClusters(monitor)
+	if err != nil {
+		return err
+	}
+	if len(tcRefs) < 1 && len(dcRefs) < 1 {
+		klog.Infof("tm[%s/%s] does not select any cluster, skip syncing", monitor.Namespace, monitor.Name)
+		return nil
+	}
+
 	var firstTc *v1alpha1.TidbCluster
 	assetStore := NewStore(m.deps.SecretLister)
 
-	for _, tcRef := range monitor.Spec.Clusters {
+	for _, tcRef := range tcRefs {
 		tc, err := m.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
 		if err != nil {
 			rerr := fmt.Errorf("get tm[%s/%s]'s target tc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -111,7 +122,7 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 
 	var firstDc *v1alpha1.DMCluster
 	if monitor.Spec.DM != nil {
-		for _, dcRef := range monitor.Spec.DM.Clusters {
+		for _, dcRef := range dcRefs {
 			dc, err := m.deps.DMClusterLister.DMClusters(dcRef.Namespace).Get(dcRef.Name)
 			if err != nil {
 				rerr := fmt.Errorf("get tm[%s/%s]'s target dc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, dcRef.Namespace, dcRef.Name, err)
@@ -131,8 +142,18 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 		}
 	}
 
+	// add the tls assets referenced by remote write
+	for _, remoteWrite := range monitor.Spec.Prometheus.RemoteWrite {
+		if remoteWrite.TLSConfig == nil {
+			continue
+		}
+		if err := assetStore.AddSafeTLSConfig(monitor.Namespace, &remoteWrite.TLSConfig.SafeTLSConfig); err != nil {
+			return err
+		}
+	}
+
 	// create or update tls asset secret
-	err := m.syncAssetSecret(monitor, assetStore)
+	err = m.syncAssetSecret(monitor, assetStore)
 	if err != nil {
 		return err
 	}
@@ -151,7 +172,7 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 	klog.V(4).Infof("tm[%s/%s]'s service synced", monitor.Namespace, monitor.Name)
 
 	// Sync Statefulset
-	if err := m.syncTidbMonitorStatefulset(firstTc, firstDc, monitor, assetStore); err != nil {
+	if err := m.syncTidbMonitorStatefulset(firstTc, firstDc, tcRefs, dcRefs, monitor, assetStore); err != nil {
 		message := fmt.Sprintf("Sync TidbMonitor[%s/%s] Statefulset failed, err:%v", monitor.Namespace, monitor.Name, err)
 		m.deps.Recorder.Event(monitor, corev1.EventTypeWarning, FailedSync, message)
 		return err
@@ -178,6 +199,11 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 	}
 	klog.V(4).Infof("tm[%s/%s]'s ingress synced", monitor.Namespace, monitor.Name)
 
+	// Sync health, the failure of health checks should not block the sync
+	if err := m.syncTidbMonitorHealth(monitor); err != nil {
+		klog.Errorf("Fail to sync tm[%s/%s]'s health, err: %v", monitor.Namespace, monitor.Name, err)
+	}
+
 	err = m.syncTidbMonitorStatus(monitor)
 	if err != nil {
 		klog.Errorf("Fail to sync tm[%s/%s]'s status, err: %v", monitor.Namespace, monitor.Name, err)
@@ -197,6 +223,7 @@ func (m *MonitorManager) syncTidbMonitorStatus(monitor *v1alpha1.TidbMonitor) er
 		return err
 	}
 	monitor.Status.StatefulSet = &sts.Status
+	setTidbMonitorConditions(monitor, sts)
 	return nil
 }
 
@@ -210,10 +237,10 @@ func (m *MonitorManager) syncTidbMonitorService(monitor *v1alpha1.TidbMonitor) e
 	return nil
 }
 
-func (m *MonitorManager) syncTidbMonitorStatefulset(tc *v1alpha1.TidbCluster, dc *v1alpha1.DMCluster, monitor *v1alpha1.TidbMonitor, store *Store) error {
+func (m *MonitorManager) syncTidbMonitorStatefulset(tc *v1alpha1.TidbCluster, dc *v1alpha1.DMCluster, tcRefs []v1alpha1.TidbClusterRef, dcRefs []v1alpha1.ClusterRef, monitor *v1alpha1.TidbMonitor, store *Store) error {
 	ns := monitor.Namespace
 	name := monitor.Name
-	err := m.syncTidbMonitorConfig(monitor, store)
+	err := m.syncTidbMonitorConfig(tcRefs, dcRefs, monitor, store)
 	if err != nil {
 		klog.Errorf("tm[%s/%s]'s configmap failed to sync,err: %v", ns, name, err)
 		return err
@@ -247,6 +274,7 @@ func (m *MonitorManager) syncTidbMonitorStatefulset(tc *v1alpha1.TidbCluster, dc
 			klog.Errorf("Fail to generate statefulset for tm [%s/%s], err: %v", ns, name, err)
 			return err
 		}
+		member.RewritePodImages(&newMonitorSts.Spec.Template.Spec, m.deps.CLIConfig.RegistryMirrors)
 		stsName := newMonitorSts.Name
 		oldMonitorSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(stsName)
 		if err != nil && !errors.IsNotFound(err) {
@@ -286,14 +314,13 @@ func (m *MonitorManager) syncTidbMonitorSecret(monitor *v1alpha1.TidbMonitor) (*
 	return m.deps.TypedControl.CreateOrUpdateSecret(monitor, newSt)
 }
 
-func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, store *Store) error {
+func (m *MonitorManager) syncTidbMonitorConfig(tcRefs []v1alpha1.TidbClusterRef, dcRefs []v1alpha1.ClusterRef, monitor *v1alpha1.TidbMonitor, store *Store) error {
 	if features.DefaultFeatureGate.Enabled(features.AutoScaling) {
 		// TODO: We need to update the status to tell users we are monitoring extra clusters
-		// Get all autoscaling clusters for TC, and add them to .Spec.Clusters to
+		// Get all autoscaling clusters for TC, and add them to the monitored clusters to
 		// generate Prometheus config without modifying the original TidbMonitor
-		cloned := monitor.DeepCopy()
 		autoTcRefs := []v1alpha1.TidbClusterRef{}
-		for _, tcRef := range monitor.Spec.Clusters {
+		for _, tcRef := range tcRefs {
 			r1, err := labels.NewRequirement(label.AutoInstanceLabelKey, selection.Exists, nil)
 			if err != nil {
 				klog.Errorf("tm[%s/%s] gets tc[%s/%s]'s autoscaling clusters failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -326,12 +353,15 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 			return cmpNS < 0
 		})
 
-		cloned.Spec.Clusters = append(cloned.Spec.Clusters, autoTcRefs...)
-		monitor = cloned
+		tcRefs = append(append([]v1alpha1.TidbClusterRef{}, tcRefs...), autoTcRefs...)
 	}
 
+	claimedByOthers, err := m.getAlertingClaimedByOthers(monitor, tcRefs)
+	if err != nil {
+		return err
+	}
 	var monitorClusterInfos []ClusterRegexInfo
-	for _, tcRef := range monitor.Spec.Clusters {
+	for _, tcRef := range tcRefs {
 		tc, err := m.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
 		if err != nil {
 			rerr := fmt.Errorf("get tm[%s/%s]'s target tc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -345,12 +375,13 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 		if tc.IsTLSClusterEnabled() {
 			clusterRegex.enableTLS = true
 		}
+		clusterRegex.alertingClaimedByOthers = claimedByOthers.Has(tcRef.Namespace + "/" + tcRef.Name)
 		monitorClusterInfos = append(monitorClusterInfos, clusterRegex)
 	}
 
 	var dmClusterInfos []ClusterRegexInfo
 	if monitor.Spec.DM != nil {
-		for _, dmRef := range monitor.Spec.DM.Clusters {
+		for _, dmRef := range dcRefs {
 			dm, err := m.deps.DMClusterLister.DMClusters(dmRef.Namespace).Get(dmRef.Name)
 			if err != nil {
 				rerr := fmt.Errorf("get tm[%s/%s]'s target dm[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, dmRef.Namespace, dmRef.Name, err)
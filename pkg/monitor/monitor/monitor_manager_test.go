@@ -688,6 +688,7 @@ func newFakeTidbMonitorManager() *MonitorManager {
 	return &MonitorManager{deps: fakeDeps,
 		pvManager:          meta.NewReclaimPolicyManager(fakeDeps),
 		discoveryInterface: discoverycachedmemory.NewMemCacheClient(discoveryClient),
+		healthChecker:      &FakeHealthChecker{},
 	}
 
 }
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
 
@@ -86,6 +87,32 @@ func (s *Store) AddBasicAuth(ns string, ba *v1alpha1.BasicAuth, key string) erro
 	return nil
 }
 
+// AddSafeTLSConfig processes the given *SafeTLSConfig and adds the referenced CA, certificate and key to the store.
+func (s *Store) AddSafeTLSConfig(ns string, tlsConfig *v1alpha1.SafeTLSConfig) error {
+	if tlsConfig == nil {
+		return nil
+	}
+	if tlsConfig.CA.ConfigMap != nil || tlsConfig.Cert.ConfigMap != nil {
+		return fmt.Errorf("configmap is not supported in tls config, use secret instead")
+	}
+
+	for _, ref := range []*corev1.SecretKeySelector{tlsConfig.CA.Secret, tlsConfig.Cert.Secret, tlsConfig.KeySecret} {
+		if ref == nil {
+			continue
+		}
+		secret, err := s.secretLister.Secrets(ns).Get(ref.Name)
+		if err != nil {
+			return fmt.Errorf("get secret [%s/%s] failed, err: %v", ns, ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("secret:[%s/%s] not contain key:[%s]", secret.Namespace, secret.Name, ref.Key)
+		}
+		s.TLSAssets[TLSAssetKey{"secret", secret.Namespace, secret.Name, ref.Key}] = TLSAsset(value)
+	}
+	return nil
+}
+
 // TLSAssetKey is a key for a TLS asset.
 type TLSAssetKey struct {
 	from string
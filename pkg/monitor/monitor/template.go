@@ -16,6 +16,7 @@ package monitor
 import (
 	"fmt"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/pingcap/tidb-operator/pkg/util"
@@ -53,6 +54,7 @@ var (
 	cdcPattern       = "ticdc"
 	importerPattern  = "importer"
 	lightningPattern = "tidb-lightning"
+	discoveryPattern = "discovery"
 	dmWorkerPattern  = dmWorker
 	dmMasterPattern  = dmMaster
 	dashBoardConfig  = `{
@@ -80,6 +82,7 @@ type MonitorConfigModel struct {
 	RemoteWriteCfg            *yaml.MapItem
 	EnableAlertRules          bool
 	EnableExternalRuleConfigs bool
+	AlertDedupLabels          []string
 	shards                    int32
 }
 
@@ -88,6 +91,8 @@ type ClusterRegexInfo struct {
 	Name      string
 	Namespace string
 	enableTLS bool
+	// alertingClaimedByOthers indicates the alerts of the cluster are sent by another TidbMonitor
+	alertingClaimedByOthers bool
 }
 
 func newPrometheusConfig(cmodel *MonitorConfigModel) yaml.MapSlice {
@@ -103,6 +108,7 @@ func newPrometheusConfig(cmodel *MonitorConfigModel) yaml.MapSlice {
 	scrapeJobs = append(scrapeJobs, scrapeJob("ticdc", cdcPattern, cmodel, buildAddressRelabelConfigByComponent("ticdc"))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob("importer", importerPattern, cmodel, buildAddressRelabelConfigByComponent("importer"))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob("lightning", lightningPattern, cmodel, buildAddressRelabelConfigByComponent("lightning"))...)
+	scrapeJobs = append(scrapeJobs, scrapeJob("discovery", discoveryPattern, cmodel, buildAddressRelabelConfigByComponent("discovery"))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob(dmWorker, dmWorkerPattern, cmodel, buildAddressRelabelConfigByComponent(dmWorker))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob(dmMaster, dmMasterPattern, cmodel, buildAddressRelabelConfigByComponent(dmMaster))...)
 	cfg := yaml.MapSlice{}
@@ -260,6 +266,8 @@ func scrapeJob(jobName string, componentPattern string, cmodel *MonitorConfigMod
 
 		if cluster.enableTLS {
 			switch {
+			case jobName == "discovery":
+				// the metrics of discovery are always served over http.
 			case jobName == "tiproxy":
 				// tiproxy use certs from tidb. There is no suitable CA for peer addresses.
 				schemeRelabelConfig.Value = "https"
@@ -458,20 +466,17 @@ func isDMJob(jobName string) bool {
 }
 
 func addAlertManagerUrl(cfg yaml.MapSlice, cmodel *MonitorConfigModel) yaml.MapSlice {
-	cfg = append(cfg, yaml.MapItem{
-		Key: "alerting",
-		Value: yaml.MapSlice{
-			{
-				Key: "alertmanagers",
-				Value: []yaml.MapSlice{
+	alerting := yaml.MapSlice{
+		{
+			Key: "alertmanagers",
+			Value: []yaml.MapSlice{
+				{
 					{
-						{
-							Key: "static_configs", Value: []yaml.MapSlice{
+						Key: "static_configs", Value: []yaml.MapSlice{
+							{
 								{
-									{
-										Key:   "targets",
-										Value: []string{cmodel.AlertmanagerURL},
-									},
+									Key:   "targets",
+									Value: []string{cmodel.AlertmanagerURL},
 								},
 							},
 						},
@@ -479,20 +484,69 @@ func addAlertManagerUrl(cfg yaml.MapSlice, cmodel *MonitorConfigModel) yaml.MapS
 				},
 			},
 		},
+	}
+	if relabelConfigs := alertRelabelConfigs(cmodel); len(relabelConfigs) > 0 {
+		alerting = append(alerting, yaml.MapItem{Key: "alert_relabel_configs", Value: relabelConfigs})
+	}
+	cfg = append(cfg, yaml.MapItem{
+		Key:   "alerting",
+		Value: alerting,
 	})
 	return cfg
 }
 
+// alertRelabelConfigs drops the dedup labels from the alerts so the same alerts from the replicas are deduplicated
+// by Alertmanager, and drops the alerts of the clusters whose alerting is claimed by other TidbMonitors.
+func alertRelabelConfigs(cmodel *MonitorConfigModel) []yaml.MapSlice {
+	var relabelConfigs []yaml.MapSlice
+	if len(cmodel.AlertDedupLabels) > 0 {
+		relabelConfigs = append(relabelConfigs, yaml.MapSlice{
+			{Key: "regex", Value: strings.Join(cmodel.AlertDedupLabels, "|")},
+			{Key: "action", Value: "labeldrop"},
+		})
+	}
+	var claimedByOthers []string
+	for _, cluster := range cmodel.ClusterInfos {
+		if cluster.alertingClaimedByOthers {
+			// same as the tidb_cluster label of the targets
+			claimedByOthers = append(claimedByOthers, regexp.QuoteMeta(cluster.Namespace+"-"+cluster.Name))
+		}
+	}
+	if len(claimedByOthers) > 0 {
+		relabelConfigs = append(relabelConfigs, yaml.MapSlice{
+			{Key: "source_labels", Value: []string{"tidb_cluster"}},
+			{Key: "regex", Value: strings.Join(claimedByOthers, "|")},
+			{Key: "action", Value: "drop"},
+		})
+	}
+	return relabelConfigs
+}
+
+// claimsAlerting returns whether any monitored cluster is alerted by this TidbMonitor
+func (cmodel *MonitorConfigModel) claimsAlerting() bool {
+	if len(cmodel.DMClusterInfos) > 0 {
+		return true
+	}
+	for _, cluster := range cmodel.ClusterInfos {
+		if !cluster.alertingClaimedByOthers {
+			return true
+		}
+	}
+	return false
+}
+
 func RenderPrometheusConfig(model *MonitorConfigModel) (yaml.MapSlice, error) {
 	cfg := newPrometheusConfig(model)
 	var rulesPath []string
-	if len(model.AlertmanagerURL) > 0 {
+	// the built-in alert rules are not rendered if the alerting of all clusters is claimed by other TidbMonitors
+	claimsAlerting := model.claimsAlerting()
+	if len(model.AlertmanagerURL) > 0 && claimsAlerting {
 		cfg = addAlertManagerUrl(cfg, model)
 		rulesPath = []string{
 			"/prometheus-rules/rules/*.rules.yml",
 		}
 	}
-	if model.EnableAlertRules {
+	if model.EnableAlertRules && claimsAlerting {
 		// Add alert rules when `EnableAlertRules` enabled even if AlertManager not configured.
 		rulesPath = []string{
 			"/prometheus-rules/rules/*.rules.yml",
@@ -158,6 +158,7 @@ func getPromConfigMap(monitor *v1alpha1.TidbMonitor, monitorClusterInfos []Clust
 		DMClusterInfos:   dmClusterInfos,
 		ExternalLabels:   buildExternalLabels(monitor),
 		EnableAlertRules: monitor.Spec.EnableAlertRules,
+		AlertDedupLabels: getAlertDedupLabels(monitor),
 		shards:           shard,
 	}
 
@@ -234,6 +235,7 @@ func getMonitorServiceAccount(monitor *v1alpha1.TidbMonitor) *core.ServiceAccoun
 			Labels:          buildTidbMonitorLabel(monitor.Name),
 			OwnerReferences: []meta.OwnerReference{controller.GetTiDBMonitorOwnerRef(monitor)},
 		},
+		ImagePullSecrets: monitor.Spec.ImagePullSecrets,
 	}
 	return sa
 }
@@ -988,6 +990,7 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 				prometheusService.Spec.LoadBalancerSourceRanges = monitor.Spec.Prometheus.Service.LoadBalancerSourceRanges
 			}
 		}
+		controller.SetServiceTrafficOptions(prometheusService, &monitor.Spec.Prometheus.Service)
 
 		if monitor.Spec.Thanos != nil {
 			prometheusService.Spec.Ports = append(prometheusService.Spec.Ports, core.ServicePort{
@@ -1033,6 +1036,7 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 				reloaderService.Spec.LoadBalancerSourceRanges = monitor.Spec.Reloader.Service.LoadBalancerSourceRanges
 			}
 		}
+		controller.SetServiceTrafficOptions(reloaderService, &monitor.Spec.Reloader.Service)
 
 		services = append(services, prometheusService, reloaderService)
 		if monitor.Spec.Grafana != nil {
@@ -1066,6 +1070,7 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 					grafanaService.Spec.LoadBalancerSourceRanges = monitor.Spec.Grafana.Service.LoadBalancerSourceRanges
 				}
 			}
+			controller.SetServiceTrafficOptions(grafanaService, &monitor.Spec.Grafana.Service)
 
 			services = append(services, grafanaService)
 		}
@@ -1218,7 +1223,7 @@ func getMonitorStatefulSet(sa *core.ServiceAccount, secret *core.Secret, monitor
 	statefulSet := getMonitorStatefulSetSkeleton(sa, monitor, shard)
 	initContainer := getMonitorInitContainer(monitor, tc)
 	statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers, initContainer)
-	if monitor.Spec.DM != nil && len(monitor.Spec.DM.Clusters) > 0 {
+	if monitor.Spec.DM != nil && dc != nil {
 		dmInitContainer := getMonitorDMInitContainer(monitor, dc)
 		statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers, dmInitContainer)
 	}
@@ -1451,18 +1456,34 @@ func getThanosSidecarContainer(monitor *v1alpha1.TidbMonitor) core.Container {
 	return container
 }
 
-func buildExternalLabels(monitor *v1alpha1.TidbMonitor) model.LabelSet {
-	m := model.LabelSet{}
+// getReplicaExternalLabelName returns the name of the external label denoting the replica,
+// an empty string means the external label is not added.
+func getReplicaExternalLabelName(monitor *v1alpha1.TidbMonitor) string {
 	// Use defaultReplicaExternalLabelName constant by default if field is missing.
 	// Do not add external label if field is set to empty string.
-	replicaExternalLabelName := defaultReplicaExternalLabelName
 	if monitor.Spec.ReplicaExternalLabelName != nil {
-		if *monitor.Spec.ReplicaExternalLabelName != "" {
-			replicaExternalLabelName = *monitor.Spec.ReplicaExternalLabelName
-		} else {
-			replicaExternalLabelName = ""
-		}
+		return *monitor.Spec.ReplicaExternalLabelName
+	}
+	return defaultReplicaExternalLabelName
+}
+
+// getAlertDedupLabels returns the labels dropped from the alerts before they are sent to Alertmanager
+func getAlertDedupLabels(monitor *v1alpha1.TidbMonitor) []string {
+	if monitor.Spec.Alerting == nil {
+		return nil
 	}
+	if len(monitor.Spec.Alerting.DedupLabels) > 0 {
+		return monitor.Spec.Alerting.DedupLabels
+	}
+	if name := getReplicaExternalLabelName(monitor); name != "" {
+		return []string{name}
+	}
+	return nil
+}
+
+func buildExternalLabels(monitor *v1alpha1.TidbMonitor) model.LabelSet {
+	m := model.LabelSet{}
+	replicaExternalLabelName := getReplicaExternalLabelName(monitor)
 	if replicaExternalLabelName != "" {
 		m[model.LabelName(replicaExternalLabelName)] = "$(NAMESPACE)_$(POD_NAME)"
 	}
@@ -1560,6 +1581,10 @@ func generateRemoteWrite(monitor *v1alpha1.TidbMonitor, store *Store) (yaml.MapI
 			cfg = append(cfg, yaml.MapItem{Key: "proxy_url", Value: spec.ProxyURL})
 		}
 
+		if spec.TLSConfig != nil {
+			cfg = append(cfg, yaml.MapItem{Key: "tls_config", Value: buildRemoteWriteTLSConfig(monitor.Namespace, spec.TLSConfig)})
+		}
+
 		if spec.QueueConfig != nil {
 			queueConfig := yaml.MapSlice{}
 
@@ -1616,6 +1641,38 @@ func generateRemoteWrite(monitor *v1alpha1.TidbMonitor, store *Store) (yaml.MapI
 	}, nil
 }
 
+// buildRemoteWriteTLSConfig builds the tls_config of remote write, the files take precedence
+// over the secrets, which are mounted from the TLS assets.
+func buildRemoteWriteTLSConfig(ns string, tls *v1alpha1.TLSConfig) yaml.MapSlice {
+	cfg := yaml.MapSlice{}
+	assetPath := func(ref *core.SecretKeySelector) string {
+		return path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", ns, ref.Name, ref.Key}.String())
+	}
+
+	if tls.CAFile != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "ca_file", Value: tls.CAFile})
+	} else if tls.CA.Secret != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "ca_file", Value: assetPath(tls.CA.Secret)})
+	}
+	if tls.CertFile != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "cert_file", Value: tls.CertFile})
+	} else if tls.Cert.Secret != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "cert_file", Value: assetPath(tls.Cert.Secret)})
+	}
+	if tls.KeyFile != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "key_file", Value: tls.KeyFile})
+	} else if tls.KeySecret != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "key_file", Value: assetPath(tls.KeySecret)})
+	}
+	if tls.ServerName != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "server_name", Value: tls.ServerName})
+	}
+	if tls.InsecureSkipVerify {
+		cfg = append(cfg, yaml.MapItem{Key: "insecure_skip_verify", Value: true})
+	}
+	return cfg
+}
+
 func GreaterThanOrEqual(left *semver.Version, right *semver.Version) bool {
 	return left.GreaterThan(right) || left.Equal(right)
 }
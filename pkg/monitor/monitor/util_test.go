@@ -45,6 +45,10 @@ func TestGenerateRemoteWrite(t *testing.T) {
   bearer_token: /test/file
   bearer_token_file: /test/file1
   proxy_url: test1
+  tls_config:
+    ca_file: /test/ca.pem
+    cert_file: /test/cert.key
+    key_file: /test/key
   queue_config:
     capacity: 1
     min_shards: 1
@@ -147,6 +151,10 @@ func TestGenerateRemoteWriteWithHighVersion(t *testing.T) {
   bearer_token: /test/file
   bearer_token_file: /test/file1
   proxy_url: test1
+  tls_config:
+    ca_file: /test/ca.pem
+    cert_file: /test/cert.key
+    key_file: /test/key
   queue_config:
     capacity: 1
     min_shards: 1
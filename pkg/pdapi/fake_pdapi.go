@@ -32,10 +32,12 @@ const (
 	GetStoreActionType                          ActionType = "GetStore"
 	DeleteStoreActionType                       ActionType = "DeleteStore"
 	SetStoreStateActionType                     ActionType = "SetStoreState"
+	RemoveTombStoneStoresActionType             ActionType = "RemoveTombStoneStores"
 	DeleteMemberByIDActionType                  ActionType = "DeleteMemberByID"
 	DeleteMemberActionType                      ActionType = "DeleteMember "
 	SetStoreLabelsActionType                    ActionType = "SetStoreLabels"
 	UpdateReplicationActionType                 ActionType = "UpdateReplicationConfig"
+	UpdateConfigActionType                      ActionType = "UpdateConfig"
 	BeginEvictLeaderActionType                  ActionType = "BeginEvictLeader"
 	EndEvictLeaderActionType                    ActionType = "EndEvictLeader"
 	GetEvictLeaderSchedulersActionType          ActionType = "GetEvictLeaderSchedulers"
@@ -44,6 +46,10 @@ const (
 	TransferPDLeaderActionType                  ActionType = "TransferPDLeader"
 	GetAutoscalingPlansActionType               ActionType = "GetAutoscalingPlans"
 	GetRecoveringMarkActionType                 ActionType = "GetRecoveringMark"
+	UnsafeRemoveFailedStoresActionType          ActionType = "UnsafeRemoveFailedStores"
+	GetUnsafeRecoveryStagesActionType           ActionType = "GetUnsafeRecoveryStages"
+	ScatterRegionsByRangeActionType             ActionType = "ScatterRegionsByRange"
+	GetOperatorsActionType                      ActionType = "GetOperators"
 )
 
 type NotFoundReaction struct {
@@ -59,6 +65,8 @@ type Action struct {
 	Name        string
 	Labels      map[string]string
 	Replication PDReplicationConfig
+	StoreIDs    []uint64
+	Config      map[string]interface{}
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -162,6 +170,14 @@ func (c *FakePDClient) DeleteStore(id uint64) error {
 	return nil
 }
 
+func (c *FakePDClient) RemoveTombStoneStores() error {
+	if reaction, ok := c.reactions[RemoveTombStoneStoresActionType]; ok {
+		_, err := reaction(&Action{})
+		return err
+	}
+	return nil
+}
+
 func (c *FakePDClient) SetStoreState(id uint64, state string) error {
 	if reaction, ok := c.reactions[SetStoreStateActionType]; ok {
 		action := &Action{ID: id}
@@ -209,6 +225,16 @@ func (c *FakePDClient) UpdateReplicationConfig(config PDReplicationConfig) error
 	return nil
 }
 
+// UpdateConfig updates the config items
+func (c *FakePDClient) UpdateConfig(config map[string]interface{}) error {
+	if reaction, ok := c.reactions[UpdateConfigActionType]; ok {
+		action := &Action{Config: config}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
 func (c *FakePDClient) BeginEvictLeader(storeID uint64) error {
 	if reaction, ok := c.reactions[BeginEvictLeaderActionType]; ok {
 		action := &Action{ID: storeID}
@@ -281,3 +307,39 @@ func (c *FakePDClient) GetRecoveringMark() (bool, error) {
 
 	return true, nil
 }
+
+func (c *FakePDClient) UnsafeRemoveFailedStores(storeIDs []uint64) error {
+	if reaction, ok := c.reactions[UnsafeRemoveFailedStoresActionType]; ok {
+		action := &Action{StoreIDs: storeIDs}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetUnsafeRecoveryStages() ([]UnsafeRecoveryStage, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetUnsafeRecoveryStagesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]UnsafeRecoveryStage), nil
+}
+
+func (c *FakePDClient) ScatterRegionsByRange(startKey, endKey []byte, retryLimit int) (*ScatterRegionsResult, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(ScatterRegionsByRangeActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ScatterRegionsResult), nil
+}
+
+func (c *FakePDClient) GetOperators() ([]string, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetOperatorsActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
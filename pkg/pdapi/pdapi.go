@@ -16,6 +16,7 @@ package pdapi
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -69,10 +70,15 @@ type PDClient interface {
 	SetStoreLabels(storeID uint64, labels map[string]string) (bool, error)
 	// UpdateReplicationConfig updates the replication config
 	UpdateReplicationConfig(config PDReplicationConfig) error
+	// UpdateConfig changes the config items of PD online, the keys are the full names of the items,
+	// e.g. `schedule.leader-schedule-limit`
+	UpdateConfig(config map[string]interface{}) error
 	// DeleteStore deletes a TiKV store from cluster
 	DeleteStore(storeID uint64) error
 	// SetStoreState sets store to specified state.
 	SetStoreState(storeID uint64, state string) error
+	// RemoveTombStoneStores removes all the tombstone stores from cluster
+	RemoveTombStoneStores() error
 	// DeleteMember deletes a PD member from cluster
 	DeleteMember(name string) error
 	// DeleteMemberByID deletes a PD member from cluster
@@ -94,6 +100,14 @@ type PDClient interface {
 	GetAutoscalingPlans(strategy Strategy) ([]Plan, error)
 	// GetRecoveringMark return the pd recovering mark
 	GetRecoveringMark() (bool, error)
+	// UnsafeRemoveFailedStores starts the unsafe recovery which removes the failed stores from the regions
+	UnsafeRemoveFailedStores(storeIDs []uint64) error
+	// GetUnsafeRecoveryStages returns the stages of the running or the last unsafe recovery
+	GetUnsafeRecoveryStages() ([]UnsafeRecoveryStage, error)
+	// ScatterRegionsByRange scatters the regions in the range [startKey, endKey), the keys are encoded as the region keys
+	ScatterRegionsByRange(startKey, endKey []byte, retryLimit int) (*ScatterRegionsResult, error)
+	// GetOperators returns the descriptions of the running operators
+	GetOperators() ([]string, error)
 }
 
 var (
@@ -112,6 +126,9 @@ var (
 	evictLeaderSchedulerConfigPrefix = "pd/api/v1/scheduler-config/evict-leader-scheduler/list"
 	autoscalingPrefix                = "autoscaling"
 	recoveringMarkPrefix             = "pd/api/v1/admin/cluster/markers/snapshot-recovering"
+	unsafeRecoveryPrefix             = "pd/api/v1/admin/unsafe/remove-failed-stores"
+	scatterRegionsPrefix             = "pd/api/v1/regions/scatter"
+	operatorsPrefix                  = "pd/api/v1/operators"
 )
 
 // pdClient is default implementation of PDClient
@@ -161,12 +178,14 @@ type MetaStore struct {
 type StoreStatus struct {
 	Capacity           typeutil.ByteSize `json:"capacity"`
 	Available          typeutil.ByteSize `json:"available"`
+	UsedSize           typeutil.ByteSize `json:"used_size"`
 	LeaderCount        int               `json:"leader_count"`
 	RegionCount        int               `json:"region_count"`
 	SendingSnapCount   uint32            `json:"sending_snap_count"`
 	ReceivingSnapCount uint32            `json:"receiving_snap_count"`
 	ApplyingSnapCount  uint32            `json:"applying_snap_count"`
 	IsBusy             bool              `json:"is_busy"`
+	SlowScore          uint64            `json:"slow_score"`
 
 	StartTS         time.Time         `json:"start_ts"`
 	LastHeartbeatTS time.Time         `json:"last_heartbeat_ts"`
@@ -252,6 +271,29 @@ type RecoveringMark struct {
 	Mark bool `json:"marked"`
 }
 
+// UnsafeRecoveryStage is a stage of the unsafe recovery reported by PD
+type UnsafeRecoveryStage struct {
+	Info    string   `json:"info"`
+	Time    string   `json:"time"`
+	Details []string `json:"details,omitempty"`
+}
+
+type unsafeRecoveryRequest struct {
+	Stores []uint64 `json:"stores"`
+}
+
+// ScatterRegionsResult is the result of scattering the regions in a range reported by PD
+type ScatterRegionsResult struct {
+	ProcessedPercentage int               `json:"processed-percentage"`
+	FailedRegions       map[uint64]string `json:"failed-regions,omitempty"`
+}
+
+type scatterRegionsRequest struct {
+	StartKey   string `json:"start_key"`
+	EndKey     string `json:"end_key"`
+	RetryLimit int    `json:"retry_limit"`
+}
+
 func (c *pdClient) GetHealth() (*HealthInfo, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, healthPrefix)
 	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
@@ -390,6 +432,12 @@ func (c *pdClient) DeleteStore(storeID uint64) error {
 	return fmt.Errorf("failed to delete store %d: %v", storeID, string(body))
 }
 
+func (c *pdClient) RemoveTombStoneStores() error {
+	apiURL := fmt.Sprintf("%s/%s/remove-tombstone", c.url, storesPrefix)
+	_, err := httputil.DeleteBodyOK(c.httpClient, apiURL)
+	return err
+}
+
 // SetStoreState sets store to specified state.
 func (c *pdClient) SetStoreState(storeID uint64, state string) error {
 	apiURL := fmt.Sprintf("%s/%s/%d/state?state=%s", c.url, storePrefix, storeID, state)
@@ -514,6 +562,24 @@ func (c *pdClient) UpdateReplicationConfig(config PDReplicationConfig) error {
 	return fmt.Errorf("failed %v to update replication: %v", res.StatusCode, err)
 }
 
+func (c *pdClient) UpdateConfig(config map[string]interface{}) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to update config: %v", res.StatusCode, err)
+}
+
 func (c *pdClient) BeginEvictLeader(storeID uint64) error {
 	leaderEvictInfo := getLeaderEvictSchedulerInfo(storeID)
 	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
@@ -697,6 +763,73 @@ func (c *pdClient) GetRecoveringMark() (bool, error) {
 	return recoveringMark.Mark, nil
 }
 
+func (c *pdClient) UnsafeRemoveFailedStores(storeIDs []uint64) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, unsafeRecoveryPrefix)
+	data, err := json.Marshal(unsafeRecoveryRequest{Stores: storeIDs})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to remove failed stores %v: %v", res.StatusCode, storeIDs, err)
+}
+
+func (c *pdClient) GetUnsafeRecoveryStages() ([]UnsafeRecoveryStage, error) {
+	apiURL := fmt.Sprintf("%s/%s/show", c.url, unsafeRecoveryPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	stages := []UnsafeRecoveryStage{}
+	if err := json.Unmarshal(body, &stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (c *pdClient) ScatterRegionsByRange(startKey, endKey []byte, retryLimit int) (*ScatterRegionsResult, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, scatterRegionsPrefix)
+	data, err := json.Marshal(scatterRegionsRequest{
+		StartKey:   hex.EncodeToString(startKey),
+		EndKey:     hex.EncodeToString(endKey),
+		RetryLimit: retryLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	result := &ScatterRegionsResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *pdClient) GetOperators() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, operatorsPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	// the operators are encoded as their descriptions by PD, e.g.
+	// "scatter-region {mv peer: store [1] to [4]} (kind:region, region:2(1,1), ...)"
+	var operators []string
+	if err := json.Unmarshal(body, &operators); err != nil {
+		return nil, err
+	}
+	return operators, nil
+}
+
 func (c *pdClient) GetPDLeader() (*pdpb.Member, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, pdLeaderPrefix)
 	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
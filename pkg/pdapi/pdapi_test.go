@@ -786,6 +786,14 @@ func TestGeneric(t *testing.T) {
 			wantQuery:   fmt.Sprintf("state=%d", metapb.StoreState_Tombstone),
 			checkResult: checkNoError,
 		},
+		{
+			name:        "RemoveTombStoneStores",
+			method:      "RemoveTombStoneStores",
+			statusCode:  http.StatusOK,
+			wantMethod:  "DELETE",
+			wantPath:    fmt.Sprintf("/%s/remove-tombstone", storesPrefix),
+			checkResult: checkNoError,
+		},
 		{
 			name:   "UpdateReplicationConfig",
 			method: "UpdateReplicationConfig",
@@ -798,6 +806,18 @@ func TestGeneric(t *testing.T) {
 			wantPath:    fmt.Sprintf("/%s", pdReplicationPrefix),
 			checkResult: checkNoError,
 		},
+		{
+			name:   "UpdateConfig",
+			method: "UpdateConfig",
+			args: []reflect.Value{
+				reflect.ValueOf(map[string]interface{}{"schedule.leader-schedule-limit": 8}),
+			},
+			resp:        []byte(``),
+			statusCode:  http.StatusOK,
+			wantMethod:  "POST",
+			wantPath:    fmt.Sprintf("/%s", configPrefix),
+			checkResult: checkNoError,
+		},
 		{
 			name:   "BeginEvictLeader",
 			method: "BeginEvictLeader",
@@ -16,6 +16,7 @@ package registry
 import (
 	"context"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -75,3 +76,10 @@ type CreateUpdateStrategy interface {
 	// ValidateUpdate validates an update request for existing resource
 	ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList
 }
+
+// TransitionValidator is implemented by the strategies which validate the transitions of resources on update,
+// the rejected transitions are returned as causes with machine-readable reasons, and the dangerous but allowed
+// ones are returned as warnings.
+type TransitionValidator interface {
+	ValidateTransition(ctx context.Context, obj, old runtime.Object) ([]metav1.StatusCause, []string)
+}
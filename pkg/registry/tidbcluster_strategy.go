@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
@@ -27,6 +28,8 @@ import (
 // +k8s:deepcopy-gen=false
 type TidbClusterStrategy struct{}
 
+var _ TransitionValidator = TidbClusterStrategy{}
+
 func (TidbClusterStrategy) NewObject() runtime.Object {
 	return &v1alpha1.TidbCluster{}
 }
@@ -57,6 +60,15 @@ func (TidbClusterStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.
 	return field.ErrorList{}
 }
 
+func (TidbClusterStrategy) ValidateTransition(ctx context.Context, obj, old runtime.Object) ([]metav1.StatusCause, []string) {
+	oldTc, oldOk := castTidbCluster(old)
+	tc, ok := castTidbCluster(obj)
+	if ok && oldOk {
+		return validation.ValidateTidbClusterTransition(oldTc, tc)
+	}
+	return nil, nil
+}
+
 func castTidbCluster(obj runtime.Object) (*v1alpha1.TidbCluster, bool) {
 	tc, ok := obj.(*v1alpha1.TidbCluster)
 	if !ok {
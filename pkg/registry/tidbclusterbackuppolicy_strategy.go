@@ -0,0 +1,62 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+)
+
+// +k8s:deepcopy-gen=false
+type TidbClusterBackupPolicyStrategy struct{}
+
+func (TidbClusterBackupPolicyStrategy) NewObject() runtime.Object {
+	return &v1alpha1.TidbClusterBackupPolicy{}
+}
+
+func (TidbClusterBackupPolicyStrategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	// no defaulting, the policy guards all the operations if none is specified
+}
+
+func (TidbClusterBackupPolicyStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	// no defaulting, the policy guards all the operations if none is specified
+}
+
+func (TidbClusterBackupPolicyStrategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	if p, ok := castTidbClusterBackupPolicy(obj); ok {
+		return validation.ValidateTidbClusterBackupPolicy(p)
+	}
+	return field.ErrorList{}
+}
+
+func (TidbClusterBackupPolicyStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	if p, ok := castTidbClusterBackupPolicy(obj); ok {
+		return validation.ValidateTidbClusterBackupPolicy(p)
+	}
+	return field.ErrorList{}
+}
+
+func castTidbClusterBackupPolicy(obj runtime.Object) (*v1alpha1.TidbClusterBackupPolicy, bool) {
+	p, ok := obj.(*v1alpha1.TidbClusterBackupPolicy)
+	if !ok {
+		klog.Errorf("Object %T is not v1alpha1.TidbClusterBackupPolicy, cannot processed by TidbClusterBackupPolicyStrategy", obj)
+		return nil, false
+	}
+	return p, true
+}
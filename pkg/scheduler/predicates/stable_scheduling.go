@@ -90,6 +90,16 @@ func (p *stableScheduling) Filter(instanceName string, pod *apiv1.Pod, nodes []a
 		}
 		return nil, err
 	}
+	if tc.Status.TiDB.Summary != nil {
+		// the healthy members are moved to the companion ConfigMap if the status is compacted
+		cm, err := p.kubeCli.CoreV1().ConfigMaps(ns).Get(context.TODO(), tc.FullStatusConfigMapName(), metav1.GetOptions{})
+		if err == nil {
+			err = tc.RestoreCompactedStatus(cm)
+		}
+		if err != nil {
+			klog.Warningf("failed to restore the compacted status of TiDB cluster %q: %v", tcName, err)
+		}
+	}
 
 	nodeName := p.findPreviousNodeInTC(tc, pod)
 
@@ -21,6 +21,7 @@ type ActionType string
 
 const (
 	GetLeaderCountActionType ActionType = "GetLeaderCount"
+	ModifyConfigActionType   ActionType = "ModifyConfig"
 )
 
 type NotFoundReaction struct {
@@ -35,6 +36,7 @@ type Action struct {
 	ID     uint64
 	Name   string
 	Labels map[string]string
+	Config map[string]interface{}
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -72,3 +74,9 @@ func (c *FakeTiKVClient) GetLeaderCount() (int, error) {
 	}
 	return result.(int), nil
 }
+
+func (c *FakeTiKVClient) ModifyConfig(config map[string]interface{}) error {
+	action := &Action{Config: config}
+	_, err := c.fakeAPI(ModifyConfigActionType, action)
+	return err
+}
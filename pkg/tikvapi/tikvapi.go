@@ -14,8 +14,11 @@
 package tikvapi
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"strconv"
@@ -31,11 +34,15 @@ const (
 	metricNameRegionCount = "tikv_raftstore_region_count"
 	labelNameLeaderCount  = "leader"
 	metricsPrefix         = "metrics"
+	configPrefix          = "config"
 )
 
 // TiKVClient provides tikv server's api
 type TiKVClient interface {
 	GetLeaderCount() (int, error)
+	// ModifyConfig changes the config items of TiKV online, the keys are the full names of the items,
+	// e.g. `rocksdb.rate-bytes-per-sec`
+	ModifyConfig(config map[string]interface{}) error
 }
 
 // tikvClient is default implementation of TiKVClient
@@ -75,6 +82,24 @@ func (c *tikvClient) GetLeaderCount() (int, error) {
 }
 
 // NewTiKVClient returns a new TiKVClient
+func (c *tikvClient) ModifyConfig(config map[string]interface{}) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to modify config of %s, status code %d, response %s", apiURL, res.StatusCode, string(body))
+	}
+	return nil
+}
+
 func NewTiKVClient(url string, timeout time.Duration, tlsConfig *tls.Config, disableKeepalive bool) TiKVClient {
 	return &tikvClient{
 		url: url,
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/diagnose"
 
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/completion"
+	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/convert"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/ctop"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/debug"
 	"github.com/pingcap/tidb-operator/pkg/tkctl/cmd/get"
@@ -81,6 +82,7 @@ func NewTkcCommand(streams genericclioptions.IOStreams) *cobra.Command {
 				version.NewCmdVersion(tkcContext, streams.Out),
 				upinfo.NewCmdUpInfo(tkcContext, streams),
 				diagnose.NewCmdDiagnoseInfo(tkcContext, streams),
+				convert.NewCmdConvert(tkcContext, streams),
 			},
 		},
 		{
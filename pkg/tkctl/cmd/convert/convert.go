@@ -0,0 +1,127 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pingcap/tidb-operator/pkg/tkctl/config"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	convertLongDesc = `
+		Convert the values.yaml of the legacy tidb-cluster chart to an equivalent TidbCluster.
+
+		The TidbCluster is printed to the standard output, and the migration checklist,
+		which lists the settings that can not be converted, is printed to the standard error.
+`
+	convertExample = `
+		# convert the values of the release 'demo' to a TidbCluster
+		tkctl convert -f values.yaml -t demo > tidbcluster.yaml
+`
+	convertUsage = `expected 'convert -f VALUES_FILE -t CLUSTER_NAME' for the convert command`
+)
+
+// ConvertOptions contains the input to the convert command.
+type ConvertOptions struct {
+	ValuesFile      string
+	TidbClusterName string
+	Namespace       string
+
+	genericclioptions.IOStreams
+}
+
+// NewConvertOptions returns a ConvertOptions
+func NewConvertOptions(streams genericclioptions.IOStreams) *ConvertOptions {
+	return &ConvertOptions{
+		IOStreams: streams,
+	}
+}
+
+// NewCmdConvert creates the convert command which converts the values of the legacy tidb-cluster chart to a TidbCluster
+func NewCmdConvert(tkcContext *config.TkcContext, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewConvertOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "convert",
+		Short:   "Convert the values of the tidb-cluster chart to a TidbCluster.",
+		Example: convertExample,
+		Long:    convertLongDesc,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(tkcContext, cmd, args))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.ValuesFile, "filename", "f", o.ValuesFile, "The values.yaml of the tidb-cluster chart")
+
+	return cmd
+}
+
+func (o *ConvertOptions) Complete(tkcContext *config.TkcContext, cmd *cobra.Command, args []string) error {
+	if o.ValuesFile == "" {
+		return cmdutil.UsageErrorf(cmd, convertUsage)
+	}
+
+	clientConfig, err := tkcContext.ToTkcClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if tidbClusterName, ok := clientConfig.TidbClusterName(); ok {
+		o.TidbClusterName = tidbClusterName
+	} else {
+		return cmdutil.UsageErrorf(cmd, convertUsage)
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+
+	return nil
+}
+
+func (o *ConvertOptions) Run() error {
+	data, err := os.ReadFile(o.ValuesFile)
+	if err != nil {
+		return err
+	}
+	values := &chartValues{}
+	if err := yaml.Unmarshal(data, values); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", o.ValuesFile, err)
+	}
+
+	tc, checklist, err := convertValues(values, o.TidbClusterName, o.Namespace)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(tc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(o.Out, string(out))
+
+	fmt.Fprintln(o.ErrOut, "Migration checklist:")
+	for _, item := range checklist {
+		fmt.Fprintf(o.ErrOut, "  - %s\n", item)
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// chartValues is the subset of the values.yaml of the legacy tidb-cluster chart
+// which can be expressed by the TidbCluster CR
+type chartValues struct {
+	ClusterName     string                               `json:"clusterName,omitempty"`
+	ExtraLabels     map[string]string                    `json:"extraLabels,omitempty"`
+	SchedulerName   string                               `json:"schedulerName,omitempty"`
+	Timezone        string                               `json:"timezone,omitempty"`
+	PVReclaimPolicy corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+	EnablePVReclaim *bool                                `json:"enablePVReclaim,omitempty"`
+	Services        []v1alpha1.Service                   `json:"services,omitempty"`
+	HATopologyKey   string                               `json:"haTopologyKey,omitempty"`
+	TLSCluster      *v1alpha1.TLSCluster                 `json:"tlsCluster,omitempty"`
+	Helper          struct {
+		Image string `json:"image,omitempty"`
+	} `json:"helper,omitempty"`
+
+	PD   componentValues `json:"pd,omitempty"`
+	TiKV componentValues `json:"tikv,omitempty"`
+	TiDB tidbValues      `json:"tidb,omitempty"`
+
+	Binlog struct {
+		Pump    pumpValues `json:"pump,omitempty"`
+		Drainer struct {
+			Create bool `json:"create,omitempty"`
+		} `json:"drainer,omitempty"`
+	} `json:"binlog,omitempty"`
+	Monitor struct {
+		Create bool `json:"create,omitempty"`
+	} `json:"monitor,omitempty"`
+	ScheduledBackup struct {
+		Create bool `json:"create,omitempty"`
+	} `json:"scheduledBackup,omitempty"`
+	Importer struct {
+		Create bool `json:"create,omitempty"`
+	} `json:"importer,omitempty"`
+}
+
+type componentValues struct {
+	Replicas           int32                       `json:"replicas,omitempty"`
+	Image              string                      `json:"image,omitempty"`
+	ImagePullPolicy    corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	StorageClassName   string                      `json:"storageClassName,omitempty"`
+	Config             string                      `json:"config,omitempty"`
+	Resources          corev1.ResourceRequirements `json:"resources,omitempty"`
+	Affinity           *corev1.Affinity            `json:"affinity,omitempty"`
+	NodeSelector       map[string]string           `json:"nodeSelector,omitempty"`
+	Tolerations        []corev1.Toleration         `json:"tolerations,omitempty"`
+	Annotations        map[string]string           `json:"annotations,omitempty"`
+	HostNetwork        *bool                       `json:"hostNetwork,omitempty"`
+	PodSecurityContext *corev1.PodSecurityContext  `json:"podSecurityContext,omitempty"`
+	PriorityClassName  string                      `json:"priorityClassName,omitempty"`
+	MaxFailoverCount   *int32                      `json:"maxFailoverCount,omitempty"`
+	PostArgScript      string                      `json:"postArgScript,omitempty"`
+}
+
+type tidbValues struct {
+	componentValues `json:",inline"`
+
+	Service struct {
+		Type                  corev1.ServiceType                      `json:"type,omitempty"`
+		Annotations           map[string]string                       `json:"annotations,omitempty"`
+		LoadBalancerIP        string                                  `json:"loadBalancerIP,omitempty"`
+		ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+		ExposeStatus          *bool                                   `json:"exposeStatus,omitempty"`
+	} `json:"service,omitempty"`
+	SeparateSlowLog *bool `json:"separateSlowLog,omitempty"`
+	SlowLogTailer   struct {
+		Image           string                      `json:"image,omitempty"`
+		ImagePullPolicy corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+		Resources       corev1.ResourceRequirements `json:"resources,omitempty"`
+	} `json:"slowLogTailer,omitempty"`
+	Plugin struct {
+		Enable bool     `json:"enable,omitempty"`
+		List   []string `json:"list,omitempty"`
+	} `json:"plugin,omitempty"`
+	TLSClient   *v1alpha1.TiDBTLSClient `json:"tlsClient,omitempty"`
+	Initializer *struct {
+		CreateUser string `json:"createUser,omitempty"`
+	} `json:"initializer,omitempty"`
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+}
+
+type pumpValues struct {
+	Create            bool                        `json:"create,omitempty"`
+	Replicas          int32                       `json:"replicas,omitempty"`
+	Image             string                      `json:"image,omitempty"`
+	ImagePullPolicy   corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	StorageClassName  string                      `json:"storageClassName,omitempty"`
+	Storage           string                      `json:"storage,omitempty"`
+	Resources         corev1.ResourceRequirements `json:"resources,omitempty"`
+	Affinity          *corev1.Affinity            `json:"affinity,omitempty"`
+	Tolerations       []corev1.Toleration         `json:"tolerations,omitempty"`
+	GC                *int64                      `json:"gc,omitempty"`
+	HeartbeatInterval *int64                      `json:"heartbeatInterval,omitempty"`
+	SyncLog           *bool                       `json:"syncLog,omitempty"`
+}
+
+// convertValues converts the values of the legacy tidb-cluster chart to an equivalent TidbCluster,
+// the settings which can not be converted are returned as the migration checklist.
+func convertValues(values *chartValues, name, namespace string) (*v1alpha1.TidbCluster, []string, error) {
+	var checklist []string
+	if values.ClusterName != "" {
+		name = values.ClusterName
+	}
+
+	tc := &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.TiDBClusterKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    values.ExtraLabels,
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			SchedulerName:   values.SchedulerName,
+			Timezone:        values.Timezone,
+			EnablePVReclaim: values.EnablePVReclaim,
+			TLSCluster:      values.TLSCluster,
+			Services:        values.Services,
+		},
+	}
+	if values.PVReclaimPolicy != "" {
+		policy := values.PVReclaimPolicy
+		tc.Spec.PVReclaimPolicy = &policy
+	}
+	if values.HATopologyKey != "" {
+		tc.Annotations = map[string]string{label.AnnHATopologyKey: values.HATopologyKey}
+	}
+	if values.Helper.Image != "" {
+		image := values.Helper.Image
+		tc.Spec.Helper = &v1alpha1.HelperSpec{Image: &image}
+	}
+
+	// PD
+	pdBaseImage, version := splitImage(values.PD.Image)
+	tc.Spec.Version = version
+	tc.Spec.PD = &v1alpha1.PDSpec{
+		ComponentSpec:        convertComponentSpec(&values.PD, version, tc.Spec.Version),
+		ResourceRequirements: values.PD.Resources,
+		Replicas:             values.PD.Replicas,
+		BaseImage:            pdBaseImage,
+		StorageClassName:     optionalString(values.PD.StorageClassName),
+	}
+	if values.PD.Config != "" {
+		tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+		if err := tc.Spec.PD.Config.UnmarshalTOML([]byte(values.PD.Config)); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse pd.config: %v", err)
+		}
+	}
+
+	// TiKV
+	tikvBaseImage, version := splitImage(values.TiKV.Image)
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{
+		ComponentSpec:        convertComponentSpec(&values.TiKV, version, tc.Spec.Version),
+		ResourceRequirements: values.TiKV.Resources,
+		Replicas:             values.TiKV.Replicas,
+		BaseImage:            tikvBaseImage,
+		StorageClassName:     optionalString(values.TiKV.StorageClassName),
+		MaxFailoverCount:     values.TiKV.MaxFailoverCount,
+	}
+	if values.TiKV.Config != "" {
+		tc.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
+		if err := tc.Spec.TiKV.Config.UnmarshalTOML([]byte(values.TiKV.Config)); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse tikv.config: %v", err)
+		}
+	}
+
+	// TiDB
+	tidbBaseImage, version := splitImage(values.TiDB.Image)
+	tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+		ComponentSpec:        convertComponentSpec(&values.TiDB.componentValues, version, tc.Spec.Version),
+		ResourceRequirements: values.TiDB.Resources,
+		Replicas:             values.TiDB.Replicas,
+		BaseImage:            tidbBaseImage,
+		MaxFailoverCount:     values.TiDB.MaxFailoverCount,
+		SeparateSlowLog:      values.TiDB.SeparateSlowLog,
+		TLSClient:            values.TiDB.TLSClient,
+	}
+	if values.TiDB.Config != "" {
+		tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+		if err := tc.Spec.TiDB.Config.UnmarshalTOML([]byte(values.TiDB.Config)); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse tidb.config: %v", err)
+		}
+	}
+	if svc := values.TiDB.Service; svc.Type != "" {
+		tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{
+			ServiceSpec: v1alpha1.ServiceSpec{
+				Type:           svc.Type,
+				Annotations:    svc.Annotations,
+				LoadBalancerIP: optionalString(svc.LoadBalancerIP),
+			},
+			ExposeStatus: svc.ExposeStatus,
+		}
+		if svc.ExternalTrafficPolicy != "" {
+			policy := svc.ExternalTrafficPolicy
+			tc.Spec.TiDB.Service.ExternalTrafficPolicy = &policy
+		}
+	}
+	if tailer := values.TiDB.SlowLogTailer; tailer.Image != "" {
+		tc.Spec.TiDB.SlowLogTailer = &v1alpha1.TiDBSlowLogTailerSpec{
+			ResourceRequirements: tailer.Resources,
+			Image:                optionalString(tailer.Image),
+		}
+		if tailer.ImagePullPolicy != "" {
+			policy := tailer.ImagePullPolicy
+			tc.Spec.TiDB.SlowLogTailer.ImagePullPolicy = &policy
+		}
+	}
+	if values.TiDB.Plugin.Enable {
+		tc.Spec.TiDB.Plugins = values.TiDB.Plugin.List
+		checklist = append(checklist, "tidb.plugin: make sure the plugins are available in the TiDB image, the plugin directory of the chart is not used by the TidbCluster")
+	}
+
+	// Pump
+	if pump := values.Binlog.Pump; pump.Create {
+		pumpBaseImage, version := splitImage(pump.Image)
+		tc.Spec.Pump = &v1alpha1.PumpSpec{
+			ComponentSpec: convertComponentSpec(&componentValues{
+				ImagePullPolicy: pump.ImagePullPolicy,
+				Affinity:        pump.Affinity,
+				Tolerations:     pump.Tolerations,
+			}, version, tc.Spec.Version),
+			ResourceRequirements: pump.Resources,
+			Replicas:             pump.Replicas,
+			BaseImage:            pumpBaseImage,
+			StorageClassName:     optionalString(pump.StorageClassName),
+		}
+		if pump.Storage != "" {
+			if tc.Spec.Pump.Requests == nil {
+				tc.Spec.Pump.Requests = corev1.ResourceList{}
+			}
+			storage, err := resource.ParseQuantity(pump.Storage)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse binlog.pump.storage: %v", err)
+			}
+			tc.Spec.Pump.Requests[corev1.ResourceStorage] = storage
+		}
+		cfg := map[string]interface{}{}
+		if pump.GC != nil {
+			cfg["gc"] = *pump.GC
+		}
+		if pump.HeartbeatInterval != nil {
+			cfg["heartbeat-interval"] = *pump.HeartbeatInterval
+		}
+		if pump.SyncLog != nil {
+			cfg["storage"] = map[string]interface{}{"sync-log": *pump.SyncLog}
+		}
+		tc.Spec.Pump.Config = config.New(cfg)
+		binlogEnabled := true
+		tc.Spec.TiDB.BinlogEnabled = &binlogEnabled
+	}
+
+	for _, comp := range []struct {
+		name   string
+		values *componentValues
+	}{
+		{"pd", &values.PD},
+		{"tikv", &values.TiKV},
+		{"tidb", &values.TiDB.componentValues},
+	} {
+		if comp.values.PostArgScript != "" {
+			checklist = append(checklist, fmt.Sprintf("%s.postArgScript: the start script is generated by the operator, use spec.%s.config or spec.startScriptVersion instead", comp.name, comp.name))
+		}
+	}
+	if values.TiDB.Initializer != nil || values.TiDB.PasswordSecretName != "" {
+		checklist = append(checklist, "tidb.initializer: create a TidbInitializer for the cluster if the initialization is still required")
+	}
+	if values.Binlog.Drainer.Create {
+		checklist = append(checklist, "binlog.drainer: keep the drainer deployed by the tidb-drainer chart")
+	}
+	if values.Monitor.Create {
+		checklist = append(checklist, "monitor: create a TidbMonitor for the cluster and remove the monitor deployed by the chart")
+	}
+	if values.ScheduledBackup.Create {
+		checklist = append(checklist, "scheduledBackup: create a BackupSchedule for the cluster and remove the backup CronJob deployed by the chart")
+	}
+	if values.Importer.Create {
+		checklist = append(checklist, "importer: keep the importer deployed by the tikv-importer chart")
+	}
+	checklist = append(checklist,
+		fmt.Sprintf("apply the TidbCluster in namespace %s before uninstalling the chart release, and uninstall the release with the TidbCluster kept", namespace),
+		"check that the pods are not restarted unexpectedly after the TidbCluster is taken over by the operator")
+
+	return tc, checklist, nil
+}
+
+func convertComponentSpec(values *componentValues, version, clusterVersion string) v1alpha1.ComponentSpec {
+	spec := v1alpha1.ComponentSpec{
+		Affinity:           values.Affinity,
+		NodeSelector:       values.NodeSelector,
+		Tolerations:        values.Tolerations,
+		Annotations:        values.Annotations,
+		HostNetwork:        values.HostNetwork,
+		PodSecurityContext: values.PodSecurityContext,
+		PriorityClassName:  optionalString(values.PriorityClassName),
+	}
+	if values.ImagePullPolicy != "" {
+		policy := values.ImagePullPolicy
+		spec.ImagePullPolicy = &policy
+	}
+	if version != clusterVersion {
+		spec.Version = optionalString(version)
+	}
+	return spec
+}
+
+// splitImage splits the image into the base image and the tag
+func splitImage(image string) (string, string) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
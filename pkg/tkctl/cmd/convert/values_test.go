@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const testValues = `
+extraLabels:
+  team: db
+schedulerName: tidb-scheduler
+timezone: UTC
+pvReclaimPolicy: Retain
+haTopologyKey: kubernetes.io/hostname
+helper:
+  image: busybox:1.34.1
+pd:
+  config: |
+    [replication]
+    location-labels = ["zone", "host"]
+  replicas: 3
+  image: pingcap/pd:v6.5.0
+  storageClassName: local-storage
+  resources:
+    requests:
+      storage: 1Gi
+tikv:
+  replicas: 3
+  image: pingcap/tikv:v6.5.0
+  maxFailoverCount: 3
+  postArgScript: |
+    echo
+tidb:
+  replicas: 2
+  image: localhost:5000/pingcap/tidb:v6.5.1
+  service:
+    type: NodePort
+    exposeStatus: true
+  separateSlowLog: true
+  slowLogTailer:
+    image: busybox:1.33.0
+binlog:
+  pump:
+    create: true
+    replicas: 1
+    image: pingcap/tidb-binlog:v6.5.0
+    storage: 20Gi
+    gc: 7
+    syncLog: true
+monitor:
+  create: true
+`
+
+func TestConvertValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	values := &chartValues{}
+	g.Expect(yaml.Unmarshal([]byte(testValues), values)).To(Succeed())
+
+	tc, checklist, err := convertValues(values, "demo", "tidb")
+	g.Expect(err).To(Succeed())
+	g.Expect(tc.Name).To(Equal("demo"))
+	g.Expect(tc.Namespace).To(Equal("tidb"))
+	g.Expect(tc.Labels).To(Equal(map[string]string{"team": "db"}))
+	g.Expect(tc.Annotations).To(HaveKeyWithValue(label.AnnHATopologyKey, "kubernetes.io/hostname"))
+	g.Expect(tc.Spec.Version).To(Equal("v6.5.0"))
+	g.Expect(*tc.Spec.PVReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+	g.Expect(*tc.Spec.Helper.Image).To(Equal("busybox:1.34.1"))
+
+	g.Expect(tc.Spec.PD.BaseImage).To(Equal("pingcap/pd"))
+	g.Expect(tc.Spec.PD.Version).To(BeNil())
+	g.Expect(tc.Spec.PD.Replicas).To(Equal(int32(3)))
+	g.Expect(*tc.Spec.PD.StorageClassName).To(Equal("local-storage"))
+	g.Expect(tc.Spec.PD.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+	g.Expect(tc.Spec.PD.Config.Get("replication.location-labels").MustStringSlice()).To(Equal([]string{"zone", "host"}))
+
+	g.Expect(tc.Spec.TiKV.BaseImage).To(Equal("pingcap/tikv"))
+	g.Expect(*tc.Spec.TiKV.MaxFailoverCount).To(Equal(int32(3)))
+
+	// the version of the component is kept if it differs from the cluster
+	g.Expect(tc.Spec.TiDB.BaseImage).To(Equal("localhost:5000/pingcap/tidb"))
+	g.Expect(*tc.Spec.TiDB.Version).To(Equal("v6.5.1"))
+	g.Expect(tc.Spec.TiDB.Service.Type).To(Equal(corev1.ServiceTypeNodePort))
+	g.Expect(*tc.Spec.TiDB.Service.ExposeStatus).To(BeTrue())
+	g.Expect(*tc.Spec.TiDB.SeparateSlowLog).To(BeTrue())
+	g.Expect(*tc.Spec.TiDB.SlowLogTailer.Image).To(Equal("busybox:1.33.0"))
+	g.Expect(*tc.Spec.TiDB.BinlogEnabled).To(BeTrue())
+
+	g.Expect(tc.Spec.Pump.BaseImage).To(Equal("pingcap/tidb-binlog"))
+	g.Expect(tc.Spec.Pump.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+	g.Expect(tc.Spec.Pump.Config.Get("gc").MustInt()).To(Equal(int64(7)))
+	g.Expect(tc.Spec.Pump.Config.Get("storage.sync-log").Interface()).To(Equal(true))
+
+	g.Expect(checklist).To(ContainElement(HavePrefix("tikv.postArgScript")))
+	g.Expect(checklist).To(ContainElement(HavePrefix("monitor")))
+	g.Expect(checklist).NotTo(ContainElement(HavePrefix("scheduledBackup")))
+}
+
+func TestSplitImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		image     string
+		baseImage string
+		tag       string
+	}{
+		{"pingcap/pd:v6.5.0", "pingcap/pd", "v6.5.0"},
+		{"pingcap/pd", "pingcap/pd", ""},
+		{"localhost:5000/pingcap/pd", "localhost:5000/pingcap/pd", ""},
+		{"localhost:5000/pingcap/pd:v6.5.0", "localhost:5000/pingcap/pd", "v6.5.0"},
+	}
+	for _, tt := range tests {
+		baseImage, tag := splitImage(tt.image)
+		g.Expect(baseImage).To(Equal(tt.baseImage))
+		g.Expect(tag).To(Equal(tt.tag))
+	}
+}
@@ -175,6 +175,9 @@ func getState(updateReplicas int32, ordinal int32, tc *v1alpha1.TidbCluster, pod
 		if pod.Labels[apps.ControllerRevisionHashLabelKey] == tc.Status.TiDB.StatefulSet.UpdateRevision {
 			if member, exist := tc.Status.TiDB.Members[pod.Name]; exist && member.Health {
 				state = UPDATED
+			} else if !exist && tc.Status.TiDB.Summary != nil {
+				// only the unhealthy members are listed if the status is compacted
+				state = UPDATED
 			}
 		}
 
@@ -0,0 +1,211 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// PreflightCheck detects the TidbClusters whose spec or behavior is changed by the current version of the operator.
+type PreflightCheck struct {
+	// Name identifies the check in the logs, metrics and report
+	Name string
+	// Description tells the users what is changed for the affected clusters
+	Description string
+	// Affects returns whether the cluster is affected by the change
+	Affects func(tc *v1alpha1.TidbCluster, cliCfg *controller.CLIConfig) bool
+}
+
+// preflightChecks are the changes of the current version which need the attention of the users,
+// new checks should be added here when the behavior of the existing clusters is changed
+var preflightChecks = []PreflightCheck{
+	{
+		Name:        "pd-start-script-v2",
+		Description: "the v2 start script of PD queries the discovery service for the recovery of PD, PD is rolling restarted",
+		Affects: func(tc *v1alpha1.TidbCluster, _ *controller.CLIConfig) bool {
+			return tc.Spec.PD != nil && tc.StartScriptVersion() == v1alpha1.StartScriptV2
+		},
+	},
+	{
+		Name:        "fips-mode-tls",
+		Description: "the TLS versions and cipher suites are restricted to the FIPS approved ones in the FIPS mode, the components are rolling restarted",
+		Affects: func(tc *v1alpha1.TidbCluster, cliCfg *controller.CLIConfig) bool {
+			return cliCfg.FIPSMode && tc.IsTLSClusterEnabled()
+		},
+	},
+}
+
+// PreflightReport is the result of the preflight, it maps the `namespace/name` of the affected clusters to
+// the descriptions of the changes.
+type PreflightReport struct {
+	Version  string              `json:"version"`
+	Clusters map[string][]string `json:"clusters"`
+}
+
+// Preflight scans the existing TidbClusters on startup for the changes of the current version of the operator,
+// the affected clusters are reported by the logs, metrics and optionally a ConfigMap, and their reconciliation
+// is optionally held until the changes are acknowledged.
+type Preflight struct {
+	kubeCli kubernetes.Interface
+	cli     versioned.Interface
+	cliCfg  *controller.CLIConfig
+	nss     []string
+	checks  []PreflightCheck
+}
+
+// NewPreflight returns a Preflight of the TidbClusters in namespaces, all namespaces if it's metav1.NamespaceAll.
+func NewPreflight(kubeCli kubernetes.Interface, cli versioned.Interface, cliCfg *controller.CLIConfig, namespaces ...string) *Preflight {
+	return &Preflight{
+		kubeCli: kubeCli,
+		cli:     cli,
+		cliCfg:  cliCfg,
+		nss:     namespaces,
+		checks:  preflightChecks,
+	}
+}
+
+// Run runs the preflight, it is idempotent and can be rerun after the operator is restarted.
+func (p *Preflight) Run() (*PreflightReport, error) {
+	operatorVersion := version.Get().GitVersion
+	var tcs []v1alpha1.TidbCluster
+	for _, ns := range p.nss {
+		tcList, err := p.cli.PingcapV1alpha1().TidbClusters(ns).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("preflight: failed to list TidbClusters: %v", err)
+		}
+		tcs = append(tcs, tcList.Items...)
+	}
+
+	report := &PreflightReport{Version: operatorVersion, Clusters: map[string][]string{}}
+	affected := map[string]int{}
+	for _, check := range p.checks {
+		affected[check.Name] = 0
+	}
+	for i := range tcs {
+		tc := &tcs[i]
+		// the changes are acknowledged by the users
+		if tc.Annotations[label.AnnPreflightAckKey] == operatorVersion {
+			continue
+		}
+
+		var changes []string
+		for _, check := range p.checks {
+			if !check.Affects(tc, p.cliCfg) {
+				continue
+			}
+			affected[check.Name]++
+			changes = append(changes, fmt.Sprintf("%s: %s", check.Name, check.Description))
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", tc.Namespace, tc.Name)
+		report.Clusters[key] = changes
+		klog.Warningf("Preflight: TidbCluster %s is affected by the changes of operator %s: %s", key, operatorVersion, strings.Join(changes, "; "))
+
+		if p.cliCfg.PreflightHoldReconcile && tc.Annotations[label.AnnPreflightHoldKey] != operatorVersion {
+			if err := p.holdCluster(tc, operatorVersion); err != nil {
+				return nil, err
+			}
+			klog.Warningf("Preflight: the reconciliation of TidbCluster %s is held until it is annotated with %s=%s", key, label.AnnPreflightAckKey, operatorVersion)
+		}
+	}
+
+	for name, count := range affected {
+		metrics.PreflightAffectedClusters.WithLabelValues(name).Set(float64(count))
+	}
+	klog.Infof("Preflight: %d TidbClusters are affected by the changes of operator %s", len(report.Clusters), operatorVersion)
+
+	if p.cliCfg.PreflightReportConfigMap != "" {
+		if err := p.writeReport(report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (p *Preflight) holdCluster(tc *v1alpha1.TidbCluster, operatorVersion string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{label.AnnPreflightHoldKey: operatorVersion},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Patch(context.TODO(), tc.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("preflight: failed to hold TidbCluster %s/%s: %v", tc.Namespace, tc.Name, err)
+	}
+	return nil
+}
+
+// writeReport writes the report to the ConfigMap, the key of each cluster is `namespace.name` because
+// `/` is not allowed in the keys of ConfigMap.
+func (p *Preflight) writeReport(report *PreflightReport) error {
+	ns, name, err := cache.SplitMetaNamespaceKey(p.cliCfg.PreflightReportConfigMap)
+	if err != nil {
+		return fmt.Errorf("preflight: invalid report ConfigMap %q: %v", p.cliCfg.PreflightReportConfigMap, err)
+	}
+
+	data := map[string]string{"version": report.Version}
+	for key, changes := range report.Clusters {
+		data[strings.Replace(key, "/", ".", 1)] = strings.Join(changes, "\n")
+	}
+
+	cms := p.kubeCli.CoreV1().ConfigMaps(ns)
+	cm, err := cms.Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Data:       data,
+		}
+		_, err = cms.Create(context.TODO(), cm, metav1.CreateOptions{})
+	} else if err == nil {
+		cm.Data = data
+		_, err = cms.Update(context.TODO(), cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("preflight: failed to write the report to ConfigMap %s/%s: %v", ns, name, err)
+	}
+	return nil
+}
+
+// HeldByPreflight returns the operator version which holds the reconciliation of the cluster, or an empty
+// string if the cluster is not held or the hold is acknowledged.
+func HeldByPreflight(tc *v1alpha1.TidbCluster) string {
+	holdVersion, ok := tc.Annotations[label.AnnPreflightHoldKey]
+	if !ok || tc.Annotations[label.AnnPreflightAckKey] == holdVersion {
+		return ""
+	}
+	return holdVersion
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	versionedfake "github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPreflightRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	operatorVersion := version.Get().GitVersion
+	newTC := func(name string, scriptVersion v1alpha1.StartScriptVersion, annotations map[string]string) *v1alpha1.TidbCluster {
+		return &v1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Annotations: annotations},
+			Spec: v1alpha1.TidbClusterSpec{
+				PD:                 &v1alpha1.PDSpec{},
+				StartScriptVersion: scriptVersion,
+				TLSCluster:         &v1alpha1.TLSCluster{Enabled: true},
+			},
+		}
+	}
+	cli := versionedfake.NewSimpleClientset(
+		newTC("v1", v1alpha1.StartScriptV1, nil),
+		newTC("v2", v1alpha1.StartScriptV2, nil),
+		newTC("acked", v1alpha1.StartScriptV2, map[string]string{label.AnnPreflightAckKey: operatorVersion}),
+	)
+	kubeCli := fake.NewSimpleClientset()
+	cliCfg := controller.DefaultCLIConfig()
+	cliCfg.FIPSMode = true
+	cliCfg.PreflightReportConfigMap = "tidb-admin/preflight-report"
+	cliCfg.PreflightHoldReconcile = true
+
+	report, err := NewPreflight(kubeCli, cli, cliCfg, "").Run()
+	g.Expect(err).To(Succeed())
+	g.Expect(report.Clusters).To(HaveLen(2))
+	g.Expect(report.Clusters["ns/v1"]).To(HaveLen(1))
+	g.Expect(report.Clusters["ns/v1"][0]).To(HavePrefix("fips-mode-tls:"))
+	g.Expect(report.Clusters["ns/v2"]).To(HaveLen(2))
+
+	// the affected clusters are held
+	for _, name := range []string{"v1", "v2", "acked"} {
+		tc, err := cli.PingcapV1alpha1().TidbClusters("ns").Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		if name == "acked" {
+			g.Expect(HeldByPreflight(tc)).To(BeEmpty())
+			continue
+		}
+		g.Expect(HeldByPreflight(tc)).To(Equal(operatorVersion))
+
+		// the hold is released after it is acknowledged
+		tc.Annotations[label.AnnPreflightAckKey] = operatorVersion
+		g.Expect(HeldByPreflight(tc)).To(BeEmpty())
+	}
+
+	cm, err := kubeCli.CoreV1().ConfigMaps("tidb-admin").Get(context.TODO(), "preflight-report", metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Data).To(HaveKeyWithValue("version", operatorVersion))
+	g.Expect(cm.Data).To(HaveKey("ns.v1"))
+	g.Expect(cm.Data).To(HaveKey("ns.v2"))
+
+	// the report is updated when the preflight is rerun
+	cliCfg.FIPSMode = false
+	report, err = NewPreflight(kubeCli, cli, cliCfg, "ns").Run()
+	g.Expect(err).To(Succeed())
+	g.Expect(report.Clusters).To(HaveLen(1))
+	cm, err = kubeCli.CoreV1().ConfigMaps("tidb-admin").Get(context.TODO(), "preflight-report", metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Data).NotTo(HaveKey("ns.v1"))
+}
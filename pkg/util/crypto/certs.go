@@ -22,7 +22,9 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -85,6 +87,85 @@ func NewCSR(commonName string, hostList []string, IPList []string) ([]byte, []by
 	return csr, convertKeyToPEM("RSA PRIVATE KEY", privKey), nil
 }
 
+// NewSelfSignedCA generates a self-signed CA certificate and its private key in PEM format
+func NewSelfSignedCA(commonName string, validity time.Duration) ([]byte, []byte, error) {
+	privKey, err := newPrivateKey(rsaKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"PingCAP"},
+			OrganizationalUnit: []string{"TiDB Operator"},
+			CommonName:         commonName,
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), convertKeyToPEM("RSA PRIVATE KEY", privKey), nil
+}
+
+// NewSignedCert generates a certificate for both server and client auth signed by the CA,
+// and returns the certificate and its private key in PEM format
+func NewSignedCert(caCertPEM, caKeyPEM []byte, commonName string, hostList []string, validity time.Duration) ([]byte, []byte, error) {
+	caPair, err := tls.X509KeyPair(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load CA key pair: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(caPair.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse CA cert: %v", err)
+	}
+
+	privKey, err := newPrivateKey(rsaKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"PingCAP"},
+			OrganizationalUnit: []string{"TiDB Operator"},
+			CommonName:         commonName,
+		},
+		DNSNames:    hostList,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &privKey.PublicKey, caPair.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), convertKeyToPEM("RSA PRIVATE KEY", privKey), nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
 func readCACerts(tryAppendCAFile string) (*x509.CertPool, error) {
 	// try to load system CA certs
 	rootCAs, err := x509.SystemCertPool()
@@ -129,9 +210,9 @@ func LoadTlsConfigFromSecret(secret *corev1.Secret) (*tls.Config, error) {
 		return nil, fmt.Errorf("unable to load certificates from secret %s/%s: %v", secret.Namespace, secret.Name, err)
 	}
 
-	return &tls.Config{
+	return ApplyFIPSPolicy(&tls.Config{
 		RootCAs:      rootCAs,
 		ClientCAs:    rootCAs,
 		Certificates: []tls.Certificate{tlsCert},
-	}, nil
+	}), nil
 }
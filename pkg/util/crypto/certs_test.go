@@ -18,11 +18,40 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 )
 
+func TestNewSignedCert(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	caCert, caKey, err := NewSelfSignedCA("test-cluster-ca", time.Hour)
+	g.Expect(err).Should(BeNil())
+	certPEM, keyPEM, err := NewSignedCert(caCert, caKey, "test-cluster", []string{"test-cluster-dm-master"}, time.Hour)
+	g.Expect(err).Should(BeNil())
+
+	tlsConfig, err := LoadTlsConfigFromSecret(&corev1.Secret{
+		Data: map[string][]byte{
+			corev1.ServiceAccountRootCAKey: caCert,
+			corev1.TLSCertKey:              certPEM,
+			corev1.TLSPrivateKeyKey:        keyPEM,
+		},
+	})
+	g.Expect(err).Should(BeNil())
+
+	cert, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	g.Expect(err).Should(BeNil())
+	g.Expect(cert.DNSNames).Should(Equal([]string{"test-cluster-dm-master"}))
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:   "test-cluster-dm-master",
+		Roots:     tlsConfig.RootCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	g.Expect(err).Should(BeNil())
+}
+
 func TestNewCSR(t *testing.T) {
 	g := NewGomegaWithT(t)
 	cluster := "test-cluster"
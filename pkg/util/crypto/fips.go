@@ -0,0 +1,133 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// FIPSMinRSAKeySize is the minimum size of the RSA keys allowed in the FIPS mode
+	FIPSMinRSAKeySize = 2048
+	// FIPSMinTLSVersion is the minimum TLS version of the components in the FIPS mode,
+	// it is the value of `security.tls-version` in the component configs
+	FIPSMinTLSVersion = "TLSv1.2"
+)
+
+var fipsMode atomic.Value
+
+// fipsCipherSuites are the approved cipher suites of TLS 1.2, the cipher suites
+// of TLS 1.3 are not configurable and all of them are approved
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+
+// SetFIPSMode enables or disables the FIPS mode of the process
+func SetFIPSMode(enabled bool) {
+	fipsMode.Store(enabled)
+}
+
+// FIPSModeEnabled returns whether the FIPS mode of the process is enabled
+func FIPSModeEnabled() bool {
+	enabled, _ := fipsMode.Load().(bool)
+	return enabled
+}
+
+// FIPSCipherSuiteNames returns the names of the cipher suites allowed in the FIPS mode
+func FIPSCipherSuiteNames() []string {
+	names := make([]string, 0, len(fipsCipherSuites))
+	for _, id := range fipsCipherSuites {
+		names = append(names, tls.CipherSuiteName(id))
+	}
+	return names
+}
+
+// ApplyFIPSPolicy restricts the TLS versions, cipher suites and curves of the config
+// in the FIPS mode, it does nothing if the FIPS mode is disabled
+func ApplyFIPSPolicy(config *tls.Config) *tls.Config {
+	if config == nil || !FIPSModeEnabled() {
+		return config
+	}
+	if config.MinVersion < tls.VersionTLS12 {
+		config.MinVersion = tls.VersionTLS12
+	}
+	config.CipherSuites = fipsCipherSuites
+	config.CurvePreferences = fipsCurves
+	return config
+}
+
+// ValidateCertificateForFIPS checks whether the key and the signature algorithm of
+// the certificate are allowed in the FIPS mode
+func ValidateCertificateForFIPS(cert *x509.Certificate) error {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := key.N.BitLen(); size < FIPSMinRSAKeySize {
+			return fmt.Errorf("RSA key size %d of certificate %q is less than %d", size, cert.Subject.CommonName, FIPSMinRSAKeySize)
+		}
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return fmt.Errorf("ECDSA curve %s of certificate %q is not allowed", key.Curve.Params().Name, cert.Subject.CommonName)
+		}
+	default:
+		return fmt.Errorf("public key algorithm %s of certificate %q is not allowed", cert.PublicKeyAlgorithm, cert.Subject.CommonName)
+	}
+
+	switch cert.SignatureAlgorithm {
+	case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+	default:
+		return fmt.Errorf("signature algorithm %s of certificate %q is not allowed", cert.SignatureAlgorithm, cert.Subject.CommonName)
+	}
+	return nil
+}
+
+// ValidateSecretForFIPS checks all the certificates in the TLS secret, including the CA
+func ValidateSecretForFIPS(secret *corev1.Secret) error {
+	for _, key := range []string{corev1.TLSCertKey, corev1.ServiceAccountRootCAKey} {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s of secret %s/%s: %v", key, secret.Namespace, secret.Name, err)
+			}
+			if err := ValidateCertificateForFIPS(cert); err != nil {
+				return fmt.Errorf("%s of secret %s/%s: %v", key, secret.Namespace, secret.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestCertPEM(g *GomegaWithT, keySize int) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	g.Expect(err).Should(BeNil())
+	serial, err := newSerialNumber()
+	g.Expect(err).Should(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).Should(BeNil())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestApplyFIPSPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	defer SetFIPSMode(false)
+
+	config := ApplyFIPSPolicy(&tls.Config{})
+	g.Expect(config.MinVersion).Should(BeZero())
+	g.Expect(config.CipherSuites).Should(BeEmpty())
+
+	SetFIPSMode(true)
+	config = ApplyFIPSPolicy(&tls.Config{})
+	g.Expect(config.MinVersion).Should(Equal(uint16(tls.VersionTLS12)))
+	g.Expect(config.CipherSuites).Should(Equal(fipsCipherSuites))
+	g.Expect(config.CurvePreferences).Should(Equal(fipsCurves))
+
+	config = ApplyFIPSPolicy(&tls.Config{MinVersion: tls.VersionTLS13})
+	g.Expect(config.MinVersion).Should(Equal(uint16(tls.VersionTLS13)))
+
+	g.Expect(FIPSCipherSuiteNames()).Should(ContainElement("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"))
+}
+
+func TestValidateSecretForFIPS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	caCert, _, err := NewSelfSignedCA("test-cluster-ca", time.Hour)
+	g.Expect(err).Should(BeNil())
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.ServiceAccountRootCAKey: caCert,
+			corev1.TLSCertKey:              newTestCertPEM(g, 2048),
+		},
+	}
+	g.Expect(ValidateSecretForFIPS(secret)).Should(Succeed())
+
+	secret.Data[corev1.TLSCertKey] = newTestCertPEM(g, 1024)
+	err = ValidateSecretForFIPS(secret)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(ContainSubstring("RSA key size 1024"))
+}
@@ -28,6 +28,29 @@ const (
 	StatfulSetNotUpToDate = "StatefulSetNotUpToDate"
 	// MasterUnhealthy is added when one of dm-master members is unhealthy.
 	MasterUnhealthy = "DMMasterUnhealthy"
+
+	// Progressing
+
+	// ComponentsProgressing is added when any component is in the Upgrade or Scale phase.
+	ComponentsProgressing = "ComponentsProgressing"
+	// RolloutComplete is added when all statefulsets are up to date and no component is progressing.
+	RolloutComplete = "RolloutComplete"
+
+	// Degraded
+
+	// FailureMembersFound is added when any component has failure members.
+	FailureMembersFound = "FailureMembersFound"
+	// AsExpected is added when the cluster is not degraded.
+	AsExpected = "AsExpected"
+
+	// DiscoveryReady
+
+	// DiscoveryAvailable is added when the resources of the discovery are reconciled and it is available.
+	DiscoveryAvailable = "DiscoveryAvailable"
+	// DiscoveryNotAvailable is added when the resources of the discovery are reconciled but it is not available yet.
+	DiscoveryNotAvailable = "DiscoveryNotAvailable"
+	// DiscoveryReconcileFailed is added when any resource of the discovery fails to be reconciled.
+	DiscoveryReconcileFailed = "DiscoveryReconcileFailed"
 )
 
 // NewDMClusterCondition creates a new dmcluster condition.
@@ -0,0 +1,119 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+)
+
+const (
+	defaultRegistry   = "docker.io"
+	defaultRepoPrefix = "library"
+)
+
+// MergeRegistryMirrors merges the registry mirrors, the latter ones take precedence over the former ones.
+func MergeRegistryMirrors(mirrors ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range mirrors {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// RewriteImage rewrites the image by the registry mirrors. The key of the mirrors is a registry,
+// e.g. `docker.io`, or a repository prefix, e.g. `docker.io/pingcap`, and the longest matched key
+// is replaced by its mirror. The image is returned as it is if no key matches.
+func RewriteImage(image string, mirrors map[string]string) string {
+	if image == "" || len(mirrors) == 0 {
+		return image
+	}
+	normalized := normalizeImage(image)
+
+	var matched string
+	for key := range mirrors {
+		key = strings.TrimSuffix(key, "/")
+		if len(key) <= len(matched) {
+			continue
+		}
+		if normalized == key || strings.HasPrefix(normalized, key+"/") {
+			matched = key
+		}
+	}
+	if matched == "" {
+		return image
+	}
+	mirror, ok := mirrors[matched]
+	if !ok {
+		mirror = mirrors[matched+"/"]
+	}
+	return strings.TrimSuffix(mirror, "/") + normalized[len(matched):]
+}
+
+// normalizeImage returns the image with the registry, e.g. `pingcap/pd:v7.1.0`
+// is normalized to `docker.io/pingcap/pd:v7.1.0`.
+func normalizeImage(image string) string {
+	i := strings.IndexByte(image, '/')
+	if i < 0 {
+		return defaultRegistry + "/" + defaultRepoPrefix + "/" + image
+	}
+	// the first component is a registry if it contains a dot or a port, or is localhost
+	first := image[:i]
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return image
+	}
+	return defaultRegistry + "/" + image
+}
+
+// IsImagePinned returns whether the image is pinned by a digest, or by a tag other than
+// the floating ones, e.g. `latest`, so that it always refers to the same content.
+func IsImagePinned(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	name := image[strings.LastIndexByte(image, '/')+1:]
+	i := strings.IndexByte(name, ':')
+	if i < 0 {
+		return false
+	}
+	switch name[i+1:] {
+	case "", "latest", "nightly":
+		return false
+	}
+	return true
+}
+
+// ImageDigestOf returns the digest in the image ID reported by the container runtime, e.g. `sha256:...` for
+// `docker-pullable://pingcap/pd@sha256:...`. An empty string is returned if the image ID has no repository
+// digest, e.g. the image is built locally.
+func ImageDigestOf(imageID string) string {
+	i := strings.LastIndexByte(imageID, '@')
+	if i < 0 {
+		return ""
+	}
+	return imageID[i+1:]
+}
+
+// PinImageDigest returns the image referred by the digest, the tag or the digest in the image is replaced,
+// e.g. `pingcap/pd:v7.1.0` is pinned to `pingcap/pd@sha256:...`.
+func PinImageDigest(image, digest string) string {
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
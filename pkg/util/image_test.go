@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRewriteImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	mirrors := map[string]string{
+		"docker.io":         "mirror.example.com",
+		"docker.io/pingcap": "mirror.example.com/tidb",
+		"gcr.io/":           "mirror.example.com/gcr/",
+		"localhost:5000":    "mirror.example.com/local",
+	}
+
+	tests := []struct {
+		image  string
+		expect string
+	}{
+		{"", ""},
+		{"busybox:1.34.1", "mirror.example.com/library/busybox:1.34.1"},
+		{"pingcap/pd:v7.1.0", "mirror.example.com/tidb/pd:v7.1.0"},
+		{"docker.io/pingcap/tidb:v7.1.0", "mirror.example.com/tidb/tidb:v7.1.0"},
+		{"grafana/grafana:7.5.11", "mirror.example.com/grafana/grafana:7.5.11"},
+		{"gcr.io/google-containers/pause:3.2", "mirror.example.com/gcr/google-containers/pause:3.2"},
+		{"localhost:5000/pingcap/tikv", "mirror.example.com/local/pingcap/tikv"},
+		{"quay.io/prometheus/prometheus:v2.27.1", "quay.io/prometheus/prometheus:v2.27.1"},
+		{"docker.io/pingcapx/tidb:v7.1.0", "mirror.example.com/pingcapx/tidb:v7.1.0"},
+	}
+	for _, tt := range tests {
+		g.Expect(RewriteImage(tt.image, mirrors)).To(Equal(tt.expect), tt.image)
+	}
+
+	g.Expect(RewriteImage("pingcap/pd:v7.1.0", nil)).To(Equal("pingcap/pd:v7.1.0"))
+}
+
+func TestMergeRegistryMirrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	global := map[string]string{"docker.io": "global.example.com", "gcr.io": "global.example.com/gcr"}
+	cluster := map[string]string{"docker.io": "cluster.example.com"}
+	g.Expect(MergeRegistryMirrors(global, cluster)).To(Equal(map[string]string{
+		"docker.io": "cluster.example.com",
+		"gcr.io":    "global.example.com/gcr",
+	}))
+	g.Expect(MergeRegistryMirrors(global, nil)).To(Equal(global))
+}
+
+func TestIsImagePinned(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		image  string
+		expect bool
+	}{
+		{"", false},
+		{"pingcap/pd", false},
+		{"pingcap/pd:latest", false},
+		{"pingcap/tidb:nightly", false},
+		{"localhost:5000/pingcap/tikv", false},
+		{"pingcap/pd:v7.1.0", true},
+		{"localhost:5000/pingcap/tikv:v7.1.0", true},
+		{"pingcap/pd@sha256:0d3a3b4f7cb5f2b5d8e4b5c7ad3e5a1b2c3d4e5f60718293a4b5c6d7e8f90a1b", true},
+	}
+	for _, tt := range tests {
+		g.Expect(IsImagePinned(tt.image)).To(Equal(tt.expect), tt.image)
+	}
+}
+
+func TestPinImageDigest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	digest := "sha256:0d3a3b4f7cb5f2b5d8e4b5c7ad3e5a1b2c3d4e5f60718293a4b5c6d7e8f90a1b"
+	g.Expect(ImageDigestOf("docker-pullable://pingcap/pd@" + digest)).To(Equal(digest))
+	g.Expect(ImageDigestOf("docker.io/pingcap/pd@" + digest)).To(Equal(digest))
+	g.Expect(ImageDigestOf(digest)).To(BeEmpty())
+
+	g.Expect(PinImageDigest("pingcap/pd:v7.1.0", digest)).To(Equal("pingcap/pd@" + digest))
+	g.Expect(PinImageDigest("localhost:5000/pingcap/tikv", digest)).To(Equal("localhost:5000/pingcap/tikv@" + digest))
+	g.Expect(PinImageDigest("localhost:5000/pingcap/tikv:v7.1.0", digest)).To(Equal("localhost:5000/pingcap/tikv@" + digest))
+	g.Expect(PinImageDigest("pingcap/tidb@sha256:1234", digest)).To(Equal("pingcap/tidb@" + digest))
+}
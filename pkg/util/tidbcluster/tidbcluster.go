@@ -30,12 +30,66 @@ const (
 	PDUnhealthy = "PDUnhealthy"
 	// TiKVStoreNotUp is added when one of tikv stores is not up.
 	TiKVStoreNotUp = "TiKVStoreNotUp"
+	// TiKVRegionNotBalanced is added when the regions are being balanced among tikv stores after scaling out.
+	TiKVRegionNotBalanced = "TiKVRegionNotBalanced"
 	// TiDBUnhealthy is added when one of tidb pods is unhealthy.
 	TiDBUnhealthy = "TiDBUnhealthy"
 	// TiFlashStoreNotUp is added when one of tiflash stores is not up.
 	TiFlashStoreNotUp = "TiFlashStoreNotUp"
 	// TiCDCCaptureNotReady is added when one of ticdc capture is not ready.
 	TiCDCCaptureNotReady = "TiCDCCaptureNotReady"
+
+	// Progressing
+
+	// ComponentsProgressing is added when any component is in the Upgrade or Scale phase.
+	ComponentsProgressing = "ComponentsProgressing"
+	// RolloutComplete is added when all statefulsets are up to date and no component is progressing.
+	RolloutComplete = "RolloutComplete"
+
+	// Degraded
+
+	// FailureMembersFound is added when any component has failure members or stores.
+	FailureMembersFound = "FailureMembersFound"
+	// BootstrapBlocked is added when the bootstrap of PD is blocked.
+	BootstrapBlocked = "BootstrapBlocked"
+	// AsExpected is added when the cluster is not degraded.
+	AsExpected = "AsExpected"
+
+	// BootstrapBlocked
+
+	// StaleVolumesFound is added when the volumes contain the data of another cluster.
+	StaleVolumesFound = "StaleVolumesFound"
+	// RetainedVolumesFound is added when the volumes are retained from a deleted tidb cluster.
+	RetainedVolumesFound = "RetainedVolumesFound"
+	// AdoptedClusterIDMismatch is added when the cluster ID reported by PD mismatches the adopted one.
+	AdoptedClusterIDMismatch = "AdoptedClusterIDMismatch"
+	// StaleVolumesAdopted is added when the volumes of another cluster are adopted by the annotation.
+	StaleVolumesAdopted = "StaleVolumesAdopted"
+	// NoStaleVolumes is added when the volumes of another cluster are gone.
+	NoStaleVolumes = "NoStaleVolumes"
+
+	// TLSCompliant
+
+	// TLSSecretsCompliant is added when all the TLS secrets meet the crypto policy.
+	TLSSecretsCompliant = "TLSSecretsCompliant"
+	// TLSSecretsNotCompliant is added when any TLS secret does not meet the crypto policy.
+	TLSSecretsNotCompliant = "TLSSecretsNotCompliant"
+
+	// DiscoveryReady
+
+	// DiscoveryAvailable is added when the resources of the discovery are reconciled and it is available.
+	DiscoveryAvailable = "DiscoveryAvailable"
+	// DiscoveryNotAvailable is added when the resources of the discovery are reconciled but it is not available yet.
+	DiscoveryNotAvailable = "DiscoveryNotAvailable"
+	// DiscoveryReconcileFailed is added when any resource of the discovery fails to be reconciled.
+	DiscoveryReconcileFailed = "DiscoveryReconcileFailed"
+
+	// SlowStore
+
+	// SlowStoresFound is added when the slow score of any TiKV store stays above the threshold.
+	SlowStoresFound = "SlowStoresFound"
+	// NoSlowStores is added when no TiKV store is slow.
+	NoSlowStores = "NoSlowStores"
 )
 
 // NewTidbClusterCondition creates a new tidbcluster condition.
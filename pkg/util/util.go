@@ -44,14 +44,18 @@ import (
 )
 
 var (
-	ClusterClientTLSPath   = "/var/lib/cluster-client-tls"
-	ClusterAssetsTLSPath   = "/var/lib/cluster-assets-tls"
-	TiDBClientTLSPath      = "/var/lib/tidb-client-tls"
-	BRBinPath              = "/var/lib/br-bin"
-	DumplingBinPath        = "/var/lib/dumpling-bin"
-	LightningBinPath       = "/var/lib/lightning-bin"
-	ClusterClientVolName   = "cluster-client-tls"
-	DMClusterClientVolName = "dm-cluster-client-tls"
+	ClusterClientTLSPath       = "/var/lib/cluster-client-tls"
+	ClusterAssetsTLSPath       = "/var/lib/cluster-assets-tls"
+	TiDBClientTLSPath          = "/var/lib/tidb-client-tls"
+	BRBinPath                  = "/var/lib/br-bin"
+	DumplingBinPath            = "/var/lib/dumpling-bin"
+	LightningBinPath           = "/var/lib/lightning-bin"
+	BackupEncryptionKeyPath    = "/var/lib/backup-encryption"
+	ClusterClientVolName       = "cluster-client-tls"
+	DMClusterClientVolName     = "dm-cluster-client-tls"
+	BackupEncryptionKeyVolName = "backup-encryption-key"
+	// BackupEncryptionKeyFile is the file name of the data key under BackupEncryptionKeyPath
+	BackupEncryptionKeyFile = "key"
 )
 
 const (
@@ -377,10 +381,18 @@ func VolumeClaimTemplate(r corev1.ResourceRequirements, metaName string, storage
 func MatchLabelFromStoreLabels(storeLabels []*metapb.StoreLabel, componentLabel string) bool {
 	storeKind := label.TiKVLabelVal
 	for _, storeLabel := range storeLabels {
-		if storeLabel.Key == "engine" && storeLabel.Value == label.TiFlashLabelVal {
+		if storeLabel.Key != "engine" {
+			continue
+		}
+		if storeLabel.Value == label.TiFlashLabelVal {
 			storeKind = label.TiFlashLabelVal
 			break
 		}
+		// the stores of the tiflash compute nodes in the disaggregated mode
+		if storeLabel.Value == "tiflash_compute" {
+			storeKind = label.TiFlashComputeLabelVal
+			break
+		}
 	}
 	return storeKind == componentLabel
 }
@@ -0,0 +1,225 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backuppolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	"github.com/pingcap/tidb-operator/pkg/webhook/util"
+	admission "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// BackupPolicyAdmissionControl refuses the destructive operations on the TidbClusters guarded by
+// TidbClusterBackupPolicies unless a recent successful backup of the cluster exists
+type BackupPolicyAdmissionControl struct {
+	lock        sync.RWMutex
+	initialized bool
+	// operator client interface
+	operatorCli versioned.Interface
+	now         func() time.Time
+}
+
+var _ apiserver.ValidatingAdmissionHook = &BackupPolicyAdmissionControl{}
+
+func NewBackupPolicyAdmissionControl() *BackupPolicyAdmissionControl {
+	return &BackupPolicyAdmissionControl{now: time.Now}
+}
+
+func (bc *BackupPolicyAdmissionControl) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	return schema.GroupVersionResource{
+			Group:    "admission.tidb.pingcap.com",
+			Version:  "v1alpha1",
+			Resource: "tidbclusterbackuppolicyvalidations",
+		},
+		"tidbclusterbackuppolicyvalidation"
+}
+
+func (bc *BackupPolicyAdmissionControl) Validate(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	if !bc.initialized {
+		return &admission.AdmissionResponse{
+			Allowed: false,
+		}
+	}
+	if ar.Kind.Kind != v1alpha1.TiDBClusterKind {
+		return util.ARSuccess()
+	}
+
+	switch ar.Operation {
+	case admission.Update:
+		tc, old := &v1alpha1.TidbCluster{}, &v1alpha1.TidbCluster{}
+		if err := json.Unmarshal(ar.Object.Raw, tc); err != nil {
+			return util.ARFail(err)
+		}
+		if err := json.Unmarshal(ar.OldObject.Raw, old); err != nil {
+			return util.ARFail(err)
+		}
+		ops := destructiveOperations(old, tc)
+		if len(ops) == 0 {
+			return util.ARSuccess()
+		}
+		return bc.admit(tc, ops)
+	case admission.Delete:
+		tc := &v1alpha1.TidbCluster{}
+		if len(ar.OldObject.Raw) > 0 {
+			if err := json.Unmarshal(ar.OldObject.Raw, tc); err != nil {
+				return util.ARFail(err)
+			}
+		} else {
+			// the old object is not sent by the old versions of kube-apiserver
+			var err error
+			tc, err = bc.operatorCli.PingcapV1alpha1().TidbClusters(ar.Namespace).Get(context.TODO(), ar.Name, metav1.GetOptions{})
+			if err != nil {
+				return util.ARFail(fmt.Errorf("get tidbcluster %s/%s failed, err: %v", ar.Namespace, ar.Name, err))
+			}
+		}
+		return bc.admit(tc, []v1alpha1.TidbClusterBackupPolicyOperation{v1alpha1.TidbClusterBackupPolicyOperationDelete})
+	}
+	return util.ARSuccess()
+}
+
+// admit checks the backups of the cluster against the policies which guard the operations
+func (bc *BackupPolicyAdmissionControl) admit(tc *v1alpha1.TidbCluster, ops []v1alpha1.TidbClusterBackupPolicyOperation) *admission.AdmissionResponse {
+	ns, name := tc.GetNamespace(), tc.GetName()
+	policies, err := bc.operatorCli.PingcapV1alpha1().TidbClusterBackupPolicies(ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return util.ARFail(fmt.Errorf("list backup policies in namespace %s failed, err: %v", ns, err))
+	}
+
+	// the strictest policy of all the operations is enforced
+	var policy *v1alpha1.TidbClusterBackupPolicy
+	var guarded []v1alpha1.TidbClusterBackupPolicyOperation
+	for _, op := range ops {
+		applied := false
+		for i := range policies.Items {
+			p := &policies.Items[i]
+			if !p.AppliesTo(tc, op) {
+				continue
+			}
+			applied = true
+			if policy == nil || p.Spec.MaxBackupAge.Duration < policy.Spec.MaxBackupAge.Duration {
+				policy = p
+			}
+		}
+		if applied {
+			guarded = append(guarded, op)
+		}
+	}
+	if policy == nil {
+		return util.ARSuccess()
+	}
+
+	backups, err := bc.operatorCli.PingcapV1alpha1().Backups(ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return util.ARFail(fmt.Errorf("list backups in namespace %s failed, err: %v", ns, err))
+	}
+	var latest *v1alpha1.Backup
+	for i := range backups.Items {
+		b := &backups.Items[i]
+		if !isBackupOfCluster(b, tc) || !v1alpha1.IsBackupComplete(b) {
+			continue
+		}
+		if latest == nil || b.Status.TimeCompleted.After(latest.Status.TimeCompleted.Time) {
+			latest = b
+		}
+	}
+
+	maxAge := policy.Spec.MaxBackupAge.Duration
+	if latest == nil {
+		klog.Infof("refuse %v of tidbcluster %s/%s by backup policy %s, no successful backup", guarded, ns, name, policy.Name)
+		return util.ARFail(fmt.Errorf("%v of tidbcluster %s/%s is refused by backup policy %s: no successful backup of the cluster, a backup completed in the last %s is required",
+			guarded, ns, name, policy.Name, maxAge))
+	}
+	if age := bc.now().Sub(latest.Status.TimeCompleted.Time); age > maxAge {
+		klog.Infof("refuse %v of tidbcluster %s/%s by backup policy %s, the latest backup %s is completed %s ago", guarded, ns, name, policy.Name, latest.Name, age)
+		return util.ARFail(fmt.Errorf("%v of tidbcluster %s/%s is refused by backup policy %s: the latest backup %s is completed %s ago, a backup completed in the last %s is required",
+			guarded, ns, name, policy.Name, latest.Name, age.Round(time.Second), maxAge))
+	}
+	return util.ARSuccess()
+}
+
+// destructiveOperations returns the destructive operations of the update of the cluster
+func destructiveOperations(old, tc *v1alpha1.TidbCluster) []v1alpha1.TidbClusterBackupPolicyOperation {
+	var ops []v1alpha1.TidbClusterBackupPolicyOperation
+
+	type versions struct {
+		exist    bool
+		old, new string
+	}
+	components := []versions{
+		{old.Spec.PD != nil && tc.Spec.PD != nil, old.PDVersion(), tc.PDVersion()},
+		{old.Spec.TiKV != nil && tc.Spec.TiKV != nil, old.TiKVVersion(), tc.TiKVVersion()},
+		{old.Spec.TiDB != nil && tc.Spec.TiDB != nil, old.TiDBVersion(), tc.TiDBVersion()},
+		{old.Spec.TiFlash != nil && tc.Spec.TiFlash != nil, old.TiFlashVersion(), tc.TiFlashVersion()},
+		{old.Spec.TiCDC != nil && tc.Spec.TiCDC != nil, old.TiCDCVersion(), tc.TiCDCVersion()},
+	}
+	for _, c := range components {
+		if !c.exist || c.old == c.new {
+			continue
+		}
+		// the versions which can not be compared, e.g. latest, are not regarded as downgrade
+		if downgrade, err := cmpver.Compare(c.new, cmpver.Less, c.old); err == nil && downgrade {
+			ops = append(ops, v1alpha1.TidbClusterBackupPolicyOperationDowngrade)
+			break
+		}
+	}
+
+	if old.Spec.TiKV != nil && old.Spec.TiKV.Replicas > 0 && tc.Spec.TiKV != nil && tc.Spec.TiKV.Replicas == 0 {
+		ops = append(ops, v1alpha1.TidbClusterBackupPolicyOperationScaleTiKVToZero)
+	}
+	return ops
+}
+
+// isBackupOfCluster returns whether the backup is a full backup of the cluster, the log backups
+// can not be restored without a full backup
+func isBackupOfCluster(b *v1alpha1.Backup, tc *v1alpha1.TidbCluster) bool {
+	if b.Spec.Mode == v1alpha1.BackupModeLog || b.Spec.BR == nil || b.Spec.BR.Cluster != tc.GetName() {
+		return false
+	}
+	ns := b.Spec.BR.ClusterNamespace
+	if ns == "" {
+		ns = b.GetNamespace()
+	}
+	return ns == tc.GetNamespace()
+}
+
+// Initialize implements AdmissionHook.Initialize interface. It's is called as
+// a post-start hook.
+func (bc *BackupPolicyAdmissionControl) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	cli, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	bc.operatorCli = cli
+
+	bc.initialized = true
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backuppolicy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	admission "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDestructiveOperations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTc := func(version string, tikvReplicas int32) *v1alpha1.TidbCluster {
+		return &v1alpha1.TidbCluster{
+			Spec: v1alpha1.TidbClusterSpec{
+				Version: version,
+				PD:      &v1alpha1.PDSpec{BaseImage: "pingcap/pd", Replicas: 3},
+				TiKV:    &v1alpha1.TiKVSpec{BaseImage: "pingcap/tikv", Replicas: tikvReplicas},
+				TiDB:    &v1alpha1.TiDBSpec{BaseImage: "pingcap/tidb", Replicas: 2},
+			},
+		}
+	}
+
+	g.Expect(destructiveOperations(newTc("v7.5.0", 3), newTc("v7.5.1", 3))).To(BeEmpty())
+	g.Expect(destructiveOperations(newTc("v7.5.0", 3), newTc("latest", 3))).To(BeEmpty())
+	g.Expect(destructiveOperations(newTc("v7.5.0", 3), newTc("v7.1.0", 3))).To(Equal(
+		[]v1alpha1.TidbClusterBackupPolicyOperation{v1alpha1.TidbClusterBackupPolicyOperationDowngrade}))
+	g.Expect(destructiveOperations(newTc("v7.5.0", 3), newTc("v7.1.0", 0))).To(Equal(
+		[]v1alpha1.TidbClusterBackupPolicyOperation{v1alpha1.TidbClusterBackupPolicyOperationDowngrade, v1alpha1.TidbClusterBackupPolicyOperationScaleTiKVToZero}))
+	g.Expect(destructiveOperations(newTc("v7.5.0", 0), newTc("v7.5.0", 0))).To(BeEmpty())
+}
+
+func TestBackupPolicyAdmissionControl(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tc := &v1alpha1.TidbCluster{
+		TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.TiDBClusterKind, APIVersion: "pingcap.com/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: corev1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: "v7.5.0",
+			TiKV:    &v1alpha1.TiKVSpec{Replicas: 3},
+		},
+	}
+	scaled := tc.DeepCopy()
+	scaled.Spec.TiKV.Replicas = 0
+	policy := &v1alpha1.TidbClusterBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: corev1.NamespaceDefault},
+		Spec:       v1alpha1.TidbClusterBackupPolicySpec{MaxBackupAge: metav1.Duration{Duration: 24 * time.Hour}},
+	}
+	newBackup := func(name, cluster string, mode v1alpha1.BackupMode, completed time.Time) *v1alpha1.Backup {
+		return &v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: v1alpha1.BackupSpec{
+				Mode: mode,
+				BR:   &v1alpha1.BRConfig{Cluster: cluster},
+			},
+			Status: v1alpha1.BackupStatus{
+				TimeCompleted: metav1.NewTime(completed),
+				Conditions:    []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	raw := func(obj runtime.Object) runtime.RawExtension {
+		data, _ := json.Marshal(obj)
+		return runtime.RawExtension{Raw: data}
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		request     *admission.AdmissionRequest
+		wantAllowed bool
+	}{
+		{
+			name:        "no policy",
+			request:     &admission.AdmissionRequest{Operation: admission.Delete, OldObject: raw(tc)},
+			wantAllowed: true,
+		},
+		{
+			name:        "no destructive operation",
+			objects:     []runtime.Object{policy},
+			request:     &admission.AdmissionRequest{Operation: admission.Update, Object: raw(tc), OldObject: raw(tc)},
+			wantAllowed: true,
+		},
+		{
+			name:        "no backup",
+			objects:     []runtime.Object{policy},
+			request:     &admission.AdmissionRequest{Operation: admission.Delete, OldObject: raw(tc)},
+			wantAllowed: false,
+		},
+		{
+			name: "backup is too old",
+			objects: []runtime.Object{policy,
+				newBackup("old", "basic", v1alpha1.BackupModeSnapshot, now.Add(-25*time.Hour)),
+				newBackup("log", "basic", v1alpha1.BackupModeLog, now.Add(-time.Hour)),
+				newBackup("other", "other", v1alpha1.BackupModeSnapshot, now.Add(-time.Hour)),
+			},
+			request:     &admission.AdmissionRequest{Operation: admission.Update, Object: raw(scaled), OldObject: raw(tc)},
+			wantAllowed: false,
+		},
+		{
+			name: "recent backup",
+			objects: []runtime.Object{policy,
+				newBackup("old", "basic", v1alpha1.BackupModeSnapshot, now.Add(-25*time.Hour)),
+				newBackup("new", "basic", v1alpha1.BackupModeSnapshot, now.Add(-time.Hour)),
+			},
+			request:     &admission.AdmissionRequest{Operation: admission.Update, Object: raw(scaled), OldObject: raw(tc)},
+			wantAllowed: true,
+		},
+		{
+			name: "operation is not guarded",
+			objects: []runtime.Object{&v1alpha1.TidbClusterBackupPolicy{
+				ObjectMeta: policy.ObjectMeta,
+				Spec: v1alpha1.TidbClusterBackupPolicySpec{
+					Operations:   []v1alpha1.TidbClusterBackupPolicyOperation{v1alpha1.TidbClusterBackupPolicyOperationDowngrade},
+					MaxBackupAge: policy.Spec.MaxBackupAge,
+				},
+			}},
+			request:     &admission.AdmissionRequest{Operation: admission.Delete, OldObject: raw(tc)},
+			wantAllowed: true,
+		},
+		{
+			name: "policy of another cluster",
+			objects: []runtime.Object{&v1alpha1.TidbClusterBackupPolicy{
+				ObjectMeta: policy.ObjectMeta,
+				Spec:       v1alpha1.TidbClusterBackupPolicySpec{Cluster: "other", MaxBackupAge: policy.Spec.MaxBackupAge},
+			}},
+			request:     &admission.AdmissionRequest{Operation: admission.Delete, OldObject: raw(tc)},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			bc := NewBackupPolicyAdmissionControl()
+			bc.operatorCli = fake.NewSimpleClientset(tt.objects...)
+			bc.now = func() time.Time { return now }
+			bc.initialized = true
+
+			tt.request.Kind = metav1.GroupVersionKind{Group: "pingcap.com", Version: "v1alpha1", Kind: v1alpha1.TiDBClusterKind}
+			tt.request.Namespace = tc.Namespace
+			tt.request.Name = tc.Name
+			resp := bc.Validate(tt.request)
+			g.Expect(resp.Allowed).To(Equal(tt.wantAllowed), "%v", resp.Result)
+		})
+	}
+}
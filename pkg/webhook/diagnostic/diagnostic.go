@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/webhook/util"
+	admission "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// DiagnosticAdmissionControl records the user who creates a Diagnostic in its annotations, the collector
+// job checks the permission of the creator to collect the bundle without redaction
+type DiagnosticAdmissionControl struct{}
+
+var _ apiserver.MutatingAdmissionHook = &DiagnosticAdmissionControl{}
+
+func NewDiagnosticAdmissionControl() *DiagnosticAdmissionControl {
+	return &DiagnosticAdmissionControl{}
+}
+
+func (dc *DiagnosticAdmissionControl) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	return schema.GroupVersionResource{
+			Group:    "admission.tidb.pingcap.com",
+			Version:  "v1alpha1",
+			Resource: "diagnosticmutations",
+		},
+		"diagnosticmutation"
+}
+
+func (dc *DiagnosticAdmissionControl) Admit(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+	if ar.Kind.Kind != v1alpha1.DiagnosticKind {
+		return util.ARSuccess()
+	}
+	if ar.Operation != admission.Create && ar.Operation != admission.Update {
+		return util.ARSuccess()
+	}
+
+	d := &v1alpha1.Diagnostic{}
+	if err := json.Unmarshal(ar.Object.Raw, d); err != nil {
+		klog.Errorf("admission mutating failed: cannot unmarshal %s to %T", ar.Kind, d)
+		return util.ARFail(err)
+	}
+	original := d.DeepCopy()
+
+	creator, groups := ar.UserInfo.Username, strings.Join(ar.UserInfo.Groups, ",")
+	if ar.Operation == admission.Update {
+		// the creator can't be changed after the Diagnostic is created
+		old := &v1alpha1.Diagnostic{}
+		if err := json.Unmarshal(ar.OldObject.Raw, old); err != nil {
+			klog.Errorf("admission mutating failed: cannot unmarshal %s to %T", ar.Kind, old)
+			return util.ARFail(err)
+		}
+		creator, groups = old.Annotations[label.AnnDiagnosticCreatorKey], old.Annotations[label.AnnDiagnosticCreatorGroupsKey]
+	}
+	setCreator(d, creator, groups)
+
+	patch, err := util.CreateJsonPatch(original, d)
+	if err != nil {
+		return util.ARFail(err)
+	}
+	return util.ARPatch(patch)
+}
+
+// setCreator overwrites the creator annotations of the Diagnostic, the annotations are removed if the value is empty
+func setCreator(d *v1alpha1.Diagnostic, creator, groups string) {
+	if d.Annotations == nil {
+		d.Annotations = map[string]string{}
+	}
+	for key, value := range map[string]string{
+		label.AnnDiagnosticCreatorKey:       creator,
+		label.AnnDiagnosticCreatorGroupsKey: groups,
+	} {
+		if value == "" {
+			delete(d.Annotations, key)
+			continue
+		}
+		d.Annotations[key] = value
+	}
+}
+
+func (dc *DiagnosticAdmissionControl) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	admission "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDiagnosticAdmissionControl(t *testing.T) {
+	g := NewGomegaWithT(t)
+	dc := NewDiagnosticAdmissionControl()
+
+	newRequest := func(op admission.Operation, d, old *v1alpha1.Diagnostic) *admission.AdmissionRequest {
+		raw, err := json.Marshal(d)
+		g.Expect(err).To(Succeed())
+		ar := &admission.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: "pingcap.com", Version: "v1alpha1", Kind: v1alpha1.DiagnosticKind},
+			Operation: op,
+			Object:    runtime.RawExtension{Raw: raw},
+			UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"dba", "system:authenticated"}},
+		}
+		if old != nil {
+			oldRaw, err := json.Marshal(old)
+			g.Expect(err).To(Succeed())
+			ar.OldObject = runtime.RawExtension{Raw: oldRaw}
+		}
+		return ar
+	}
+	newDiagnostic := func(annotations map[string]string) *v1alpha1.Diagnostic {
+		return &v1alpha1.Diagnostic{
+			TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.DiagnosticKind, APIVersion: "pingcap.com/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "diag", Namespace: corev1.NamespaceDefault, Annotations: annotations},
+		}
+	}
+
+	// the creator set by the user is overwritten on creation
+	forged := newDiagnostic(map[string]string{label.AnnDiagnosticCreatorKey: "mallory"})
+	resp := dc.Admit(newRequest(admission.Create, forged, nil))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(string(resp.Patch)).To(ContainSubstring("alice"))
+	g.Expect(string(resp.Patch)).To(ContainSubstring("dba,system:authenticated"))
+
+	d := newDiagnostic(nil)
+	setCreator(d, "alice", "dba")
+	g.Expect(d.Annotations).To(Equal(map[string]string{
+		label.AnnDiagnosticCreatorKey:       "alice",
+		label.AnnDiagnosticCreatorGroupsKey: "dba",
+	}))
+
+	// the creator is kept on update
+	old := newDiagnostic(map[string]string{label.AnnDiagnosticCreatorKey: "bob"})
+	updated := newDiagnostic(map[string]string{label.AnnDiagnosticCreatorKey: "mallory"})
+	resp = dc.Admit(newRequest(admission.Update, updated, old))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(string(resp.Patch)).To(ContainSubstring("bob"))
+	g.Expect(string(resp.Patch)).NotTo(ContainSubstring("alice"))
+
+	// the other kinds are not mutated
+	tc := &v1alpha1.TidbCluster{TypeMeta: metav1.TypeMeta{Kind: v1alpha1.TiDBClusterKind}}
+	raw, err := json.Marshal(tc)
+	g.Expect(err).To(Succeed())
+	resp = dc.Admit(&admission.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Kind: v1alpha1.TiDBClusterKind},
+		Operation: admission.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	})
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Patch).To(BeEmpty())
+}
@@ -18,8 +18,10 @@ import (
 	"encoding/json"
 
 	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/registry"
 	"github.com/pingcap/tidb-operator/pkg/webhook/util"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
@@ -71,6 +73,8 @@ func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest)
 		return util.ARFail(err)
 	}
 	var allErr field.ErrorList
+	var causes []metav1.StatusCause
+	var warnings []string
 	if ar.Operation == admissionv1beta1.Create {
 		allErr = s.Validate(context.TODO(), obj)
 	} else {
@@ -80,11 +84,21 @@ func (w *StrategyAdmissionHook) Validate(ar *admissionv1beta1.AdmissionRequest)
 			return util.ARFail(err)
 		}
 		allErr = s.ValidateUpdate(context.TODO(), obj, old)
+		if tv, ok := s.(registry.TransitionValidator); ok {
+			causes, warnings = tv.ValidateTransition(context.TODO(), obj, old)
+		}
 	}
-	if len(allErr) > 0 {
-		return util.ARFail(allErr.ToAggregate())
+	var resp *admissionv1beta1.AdmissionResponse
+	switch {
+	case len(allErr) > 0:
+		resp = util.ARFail(allErr.ToAggregate())
+	case len(causes) > 0:
+		resp = util.ARFailWithCauses(causes)
+	default:
+		resp = util.ARSuccess()
 	}
-	return util.ARSuccess()
+	resp.Warnings = warnings
+	return resp
 }
 
 func (w *StrategyAdmissionHook) Admit(ar *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
@@ -15,6 +15,8 @@ package util
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"gomodules.xyz/jsonpatch/v2"
 	admission "k8s.io/api/admission/v1beta1"
@@ -34,6 +36,25 @@ func ARFail(err error) *admission.AdmissionResponse {
 	}
 }
 
+// ARFailWithCauses is a helper function to create an AdmissionResponse
+// with the machine-readable causes of the failure
+func ARFailWithCauses(causes []metav1.StatusCause) *admission.AdmissionResponse {
+	msgs := make([]string, 0, len(causes))
+	for _, c := range causes {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", c.Field, c.Message))
+	}
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Message: strings.Join(msgs, ", "),
+			Reason:  metav1.StatusReasonForbidden,
+			Details: &metav1.StatusDetails{
+				Causes: causes,
+			},
+		},
+	}
+}
+
 // ARSuccess return allow to action
 func ARSuccess() *admission.AdmissionResponse {
 	return &admission.AdmissionResponse{